@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check a cluster",
+	Long:  "Use eksctl anywhere check to evaluate a running cluster before operating on it",
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradereadiness"
+)
+
+type checkUpgradeReadinessOptions struct {
+	clusterOptions
+	wConfig string
+	output  string
+}
+
+var cur = &checkUpgradeReadinessOptions{}
+
+var checkUpgradeReadinessCmd = &cobra.Command{
+	Use:          "upgrade-readiness -f <cluster-config-file> [flags]",
+	Short:        "Check a cluster for upgrade blockers",
+	Long:         "Use eksctl anywhere check upgrade-readiness to evaluate a running cluster for the issues that block an upgrade to the Kubernetes version of the cluster config file and output a readiness report",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         cur.checkUpgradeReadiness,
+}
+
+func init() {
+	checkCmd.AddCommand(checkUpgradeReadinessCmd)
+	applyClusterOptionFlags(checkUpgradeReadinessCmd.Flags(), &cur.clusterOptions)
+	checkUpgradeReadinessCmd.Flags().StringVarP(&cur.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster to check")
+	checkUpgradeReadinessCmd.Flags().StringVarP(&cur.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+
+	if err := checkUpgradeReadinessCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (opts *checkUpgradeReadinessOptions) checkUpgradeReadiness(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	if opts.output != outputText && opts.output != outputJson {
+		return fmt.Errorf("invalid output format [%s]", opts.output)
+	}
+
+	removeOCIFiles, err := opts.pullOCIFiles(ctx)
+	if err != nil {
+		return err
+	}
+	defer removeOCIFiles()
+
+	defer opts.removeManagementKubeconfig()
+	clusterSpec, err := newClusterSpec(&opts.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, opts.wConfig),
+	}
+	if err := kubeconfig.ValidateFilename(workloadCluster.KubeconfigFile); err != nil {
+		return err
+	}
+	managementCluster := getManagementCluster(clusterSpec)
+
+	checkerOpts, err := opts.checkerOpts(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).
+		WithExecutableMountDirs(append(opts.mountDirs(), filepath.Dir(workloadCluster.KubeconfigFile))...).
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	report, err := upgradereadiness.NewChecker(deps.Kubectl, checkerOpts...).Check(ctx, clusterSpec, workloadCluster, managementCluster)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := serializeReadinessReport(report, opts.output)
+	if err != nil {
+		return err
+	}
+	fmt.Println(serialized)
+
+	if !report.Ready {
+		return errors.New("cluster is not ready for upgrade")
+	}
+
+	return nil
+}
+
+func (opts *checkUpgradeReadinessOptions) checkerOpts(clusterSpec *cluster.Spec) ([]upgradereadiness.CheckerOpt, error) {
+	if clusterSpec.Cluster.Spec.DatacenterRef.Kind != v1alpha1.TinkerbellDatacenterKind {
+		return nil, nil
+	}
+
+	machineConfigs, err := v1alpha1.GetTinkerbellMachineConfigs(opts.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("reading tinkerbell machine configs: %v", err)
+	}
+	requirements, err := upgradereadiness.TinkerbellHardwareRequirements(clusterSpec, machineConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	return []upgradereadiness.CheckerOpt{upgradereadiness.WithSpareHardware(requirements)}, nil
+}
+
+func serializeReadinessReport(report *upgradereadiness.Report, outputFormat string) (string, error) {
+	if outputFormat == outputJson {
+		b, err := json.Marshal(report)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the readiness report to json: %v", err)
+		}
+		return string(b), nil
+	}
+
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tSTATUS\tDETAILS")
+	for _, check := range report.Checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", check.Name, check.Status, strings.Join(check.Details, "; "))
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	if report.Ready {
+		buffer.WriteString(fmt.Sprintf("\nCluster %s is ready for upgrade", report.Cluster))
+	} else {
+		buffer.WriteString(fmt.Sprintf("\nCluster %s is not ready for upgrade, fix the failed checks first", report.Cluster))
+	}
+
+	return buffer.String(), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -16,7 +17,16 @@ func cleanup(deps *dependencies.Dependencies, commandErr *error) {
 	}
 }
 
+// closeTimeout is the maximum time closers have to finish when the operation has been interrupted.
+const closeTimeout = time.Minute
+
 func close(ctx context.Context, closer types.Closer) {
+	if ctx.Err() != nil {
+		// Closers still need to run after an interruption to stop the containers and processes they own.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), closeTimeout)
+		defer cancel()
+	}
 	if err := closer.Close(ctx); err != nil {
 		logger.Error(err, "Closer failed", "closerType", fmt.Sprintf("%T", closer))
 	}
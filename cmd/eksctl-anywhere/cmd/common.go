@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/version"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -21,6 +25,95 @@ func getImages(spec string) ([]v1alpha1.Image, error) {
 	return images, nil
 }
 
+const (
+	clusterConfigFilePattern   = "eksa-cluster-config-*.yaml"
+	bundlesOverrideFilePattern = "eksa-bundles-override-*.yaml"
+)
+
+// localFile returns the path to a local copy of the file. When uri references an OCI artifact, e.g.
+// oci://registry/org/specs/prod:v3, the artifact is pulled to a temporary file, which the returned func removes.
+// Artifacts need to be referenced by digest unless publicKeyFile is set to verify their cosign signature.
+func localFile(ctx context.Context, uri, publicKeyFile, pattern string) (path string, remove func(), err error) {
+	if !files.IsOCIReference(uri) {
+		return uri, func() {}, nil
+	}
+
+	var pullerOpts []files.RegistryPullerOpt
+	if publicKeyFile != "" {
+		publicKey, err := files.ReadPublicKey(publicKeyFile)
+		if err != nil {
+			return "", nil, err
+		}
+		pullerOpts = append(pullerOpts, files.WithSignatureVerification(publicKey))
+	} else if !files.IsPinnedOCIReference(uri) {
+		return "", nil, fmt.Errorf("oci artifact %s must be referenced by digest, like oci://registry/repository@sha256:<digest>, or signed and verified with --oci-public-key", uri)
+	}
+
+	content, err := files.NewReader(files.WithOCIPuller(files.NewRegistryPuller(pullerOpts...))).ReadOCIFile(ctx, uri)
+	if err != nil {
+		return "", nil, err
+	}
+
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", nil, fmt.Errorf("creating file for %s: %v", uri, err)
+	}
+	defer f.Close()
+	remove = func() { os.Remove(f.Name()) }
+
+	if _, err := f.Write(content); err != nil {
+		remove()
+		return "", nil, fmt.Errorf("writing %s: %v", uri, err)
+	}
+
+	logger.V(4).Info("Pulled OCI artifact", "reference", uri, "path", f.Name())
+
+	return f.Name(), remove, nil
+}
+
+// patchedClusterConfigFile applies the patch files, in order, to the cluster config and writes the result
+// to a temporary file, returning its path and a func that removes it. It returns fileName when there are no patches.
+func patchedClusterConfigFile(fileName string, patchFiles []string) (path string, remove func(), err error) {
+	if len(patchFiles) == 0 {
+		return fileName, func() {}, nil
+	}
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading cluster config file: %v", err)
+	}
+
+	patches := make([][]byte, 0, len(patchFiles))
+	for _, p := range patchFiles {
+		patch, err := os.ReadFile(p)
+		if err != nil {
+			return "", nil, fmt.Errorf("reading cluster config patch: %v", err)
+		}
+		patches = append(patches, patch)
+	}
+
+	patched, err := cluster.PatchConfig(content, patches...)
+	if err != nil {
+		return "", nil, fmt.Errorf("patching cluster config %s: %v", fileName, err)
+	}
+
+	f, err := os.CreateTemp("", "eksa-cluster-config-patched-*.yaml")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating file for patched cluster config %s: %v", fileName, err)
+	}
+	defer f.Close()
+	remove = func() { os.Remove(f.Name()) }
+
+	if _, err := f.Write(patched); err != nil {
+		remove()
+		return "", nil, fmt.Errorf("writing patched cluster config %s: %v", fileName, err)
+	}
+
+	logger.V(4).Info("Patched cluster config", "path", f.Name(), "patches", patchFiles)
+
+	return f.Name(), remove, nil
+}
+
 // getKubeconfigPath returns an EKS-A kubeconfig path. The return van be overriden using override
 // to give preference to a user specified kubeconfig.
 func getKubeconfigPath(clusterName, override string) string {
@@ -30,6 +123,15 @@ func getKubeconfigPath(clusterName, override string) string {
 	return override
 }
 
+// managementKubeconfigPath validates the kubeconfig file and returns a kubeconfig file with only its context
+// contextName, or its current context when empty, and a func that removes it. See kubeconfig.WithContext.
+func managementKubeconfigPath(kubeconfigPath, contextName string) (path string, remove func(), err error) {
+	if err := kubeconfig.ValidateFilename(kubeconfigPath); err != nil {
+		return "", nil, err
+	}
+	return kubeconfig.WithContext(kubeconfigPath, contextName)
+}
+
 func NewDependenciesForPackages(ctx context.Context, opts ...PackageOpt) (*dependencies.Dependencies, error) {
 	config := New(opts...)
 	return dependencies.NewFactory().
@@ -6,6 +6,9 @@ const (
 	cpWaitTimeoutFlag           = "control-plane-wait-timeout"
 	externalEtcdWaitTimeoutFlag = "external-etcd-wait-timeout"
 	perMachineWaitTimeoutFlag   = "per-machine-wait-timeout"
+	ntpServerFlag               = "ntp-server"
+	validateTimeSyncFlag        = "validate-time-sync"
+	maxClockSkewFlag            = "max-clock-skew"
 )
 
 type Operation int
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var copyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copy resources",
+	Long:  "Use eksctl anywhere copy to copy artifacts used by EKS Anywhere between registries",
+}
+
+func init() {
+	rootCmd.AddCommand(copyCmd)
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/pkg/content"
+
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+)
+
+var copyPackagesCmd = &cobra.Command{
+	Use:   "packages",
+	Short: "Copy curated packages artifacts to a registry",
+	Long: `Copy the package bundles and the helm charts and images of the curated packages to a registry,
+preserving their digests. Interrupted copies are resumed from the state file when run again and
+the artifacts in the registry are verified after the copy.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return copyPackagesCommand.call(cmd.Context())
+	},
+}
+
+func init() {
+	copyCmd.AddCommand(copyPackagesCmd)
+
+	copyPackagesCmd.Flags().StringVarP(&copyPackagesCommand.registryEndpoint, "registry", "r", "", "Registry where to copy the curated packages artifacts")
+	if err := copyPackagesCmd.MarkFlagRequired("registry"); err != nil {
+		log.Fatalf("Cannot mark 'registry' as required: %s", err)
+	}
+	copyPackagesCmd.Flags().StringVarP(&copyPackagesCommand.bundlesFile, "bundles", "b", "", "Bundles file to read the package bundles from")
+	if err := copyPackagesCmd.MarkFlagRequired("bundles"); err != nil {
+		log.Fatalf("Cannot mark 'bundles' as required: %s", err)
+	}
+	copyPackagesCmd.Flags().StringVar(&copyPackagesCommand.stateFile, "state-file", "eks-a-copy-packages-state.json", "File recording the copied artifacts, used to resume an interrupted copy")
+	copyPackagesCmd.Flags().IntVar(&copyPackagesCommand.workers, "workers", oras.DefaultCopyWorkers, "Number of artifacts copied in parallel")
+	copyPackagesCmd.Flags().BoolVar(&copyPackagesCommand.insecure, "insecure", false, "Flag to indicate skipping TLS verification while copying the artifacts")
+}
+
+var copyPackagesCommand = copyPackagesOptions{}
+
+type copyPackagesOptions struct {
+	registryEndpoint string
+	bundlesFile      string
+	stateFile        string
+	workers          int
+	insecure         bool
+}
+
+func (c copyPackagesOptions) call(ctx context.Context) error {
+	username, password, err := config.ReadCredentials()
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithManifestReader().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer deps.Close(ctx)
+
+	bundle, err := bundles.Read(deps.ManifestReader, c.bundlesFile)
+	if err != nil {
+		return err
+	}
+
+	artifacts, err := oras.PackagesArtifacts(ctx, bundle)
+	if err != nil {
+		return err
+	}
+
+	// The source registries use the credentials of the docker config.
+	src, err := content.NewRegistry(content.RegistryOptions{})
+	if err != nil {
+		return err
+	}
+	dst, err := content.NewRegistry(content.RegistryOptions{
+		Username: username,
+		Password: password,
+		Insecure: c.insecure,
+	})
+	if err != nil {
+		return err
+	}
+
+	return oras.NewRegistryCopier(
+		oras.NewOrasRegistry(src, dst),
+		c.registryEndpoint,
+		c.stateFile,
+		c.workers,
+	).Copy(ctx, artifacts)
+}
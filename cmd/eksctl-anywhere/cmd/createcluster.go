@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"runtime"
@@ -17,8 +18,10 @@ import (
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
+	"github.com/aws/eks-anywhere/pkg/validations/postcreate"
 	"github.com/aws/eks-anywhere/pkg/workflow/management"
 	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
 type createClusterOptions struct {
@@ -29,6 +32,9 @@ type createClusterOptions struct {
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
 	installPackages       string
+	skipPostCreate        bool
+	validateLoadBalancer  bool
+	patchFiles            []string
 }
 
 var cc = &createClusterOptions{}
@@ -45,12 +51,16 @@ var createClusterCmd = &cobra.Command{
 func init() {
 	createCmd.AddCommand(createClusterCmd)
 	applyClusterOptionFlags(createClusterCmd.Flags(), &cc.clusterOptions)
+	applyCredentialsFlags(createClusterCmd.Flags(), &cc.clusterOptions)
 	applyTimeoutFlags(createClusterCmd.Flags(), &cc.timeoutOptions)
 	applyTinkerbellHardwareFlag(createClusterCmd.Flags(), &cc.hardwareCSVPath)
 	createClusterCmd.Flags().StringVar(&cc.tinkerbellBootstrapIP, "tinkerbell-bootstrap-ip", "", "Override the local tinkerbell IP in the bootstrap cluster")
 	createClusterCmd.Flags().BoolVar(&cc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	createClusterCmd.Flags().BoolVar(&cc.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
 	createClusterCmd.Flags().StringVar(&cc.installPackages, "install-packages", "", "Location of curated packages configuration files to install to the cluster")
+	createClusterCmd.Flags().BoolVar(&cc.skipPostCreate, "skip-post-create-validations", false, "Skip the smoke tests run against the cluster once it's created")
+	createClusterCmd.Flags().StringArrayVar(&cc.patchFiles, "patch", nil, "Strategic merge or JSON6902 patches applied, in order, to the cluster config before validation. Can be repeated")
+	createClusterCmd.Flags().BoolVar(&cc.validateLoadBalancer, "validate-load-balancer", false, "Include a LoadBalancer service in the post-create validations, requires a load balancer controller in the cluster")
 
 	if err := createClusterCmd.MarkFlagRequired("filename"); err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -60,6 +70,13 @@ func init() {
 func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
 
+	fileName, removeClusterConfigFile, err := cc.localPatchedClusterConfigFile(ctx)
+	if err != nil {
+		return err
+	}
+	defer removeClusterConfigFile()
+	cc.fileName = fileName
+
 	clusterConfigFileExist := validations.FileExists(cc.fileName)
 	if !clusterConfigFileExist {
 		return fmt.Errorf("the cluster config file %s does not exist", cc.fileName)
@@ -98,7 +115,8 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		)
 	}
 
-	clusterSpec, err := newClusterSpec(cc.clusterOptions)
+	defer cc.removeManagementKubeconfig()
+	clusterSpec, err := newClusterSpec(&cc.clusterOptions)
 	if err != nil {
 		return err
 	}
@@ -123,6 +141,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		WithWriter().
 		WithEksdInstaller().
 		WithPackageInstaller(clusterSpec, cc.installPackages, cc.managementKubeconfig).
+		WithPostCreateValidator(cc.postCreateValidatorOpts()...).
 		Build(ctx)
 	if err != nil {
 		return err
@@ -145,6 +164,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		deps.Writer,
 		deps.EksdInstaller,
 		deps.PackageInstaller,
+		cc.postCreateValidator(deps),
 	)
 
 	validationOpts := &validations.Opts{
@@ -157,6 +177,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		ManagementCluster: getManagementCluster(clusterSpec),
 		Provider:          deps.Provider,
 		CliConfig:         cliConfig,
+		PackagesLocation:  cc.installPackages,
 	}
 	createValidations := createvalidations.New(validationOpts)
 
@@ -182,3 +203,37 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	cleanup(deps, &err)
 	return err
 }
+
+func (cc *createClusterOptions) postCreateValidatorOpts() []postcreate.ValidatorOpt {
+	if cc.validateLoadBalancer {
+		return []postcreate.ValidatorOpt{postcreate.WithLoadBalancerValidation()}
+	}
+	return nil
+}
+
+func (cc *createClusterOptions) postCreateValidator(deps *dependencies.Dependencies) interfaces.PostCreateValidator {
+	if cc.skipPostCreate {
+		return nil
+	}
+	return deps.PostCreateValidator
+}
+
+// localPatchedClusterConfigFile returns the path to a local copy of the cluster config with the patches applied
+// and a func that removes the temporary files created for it.
+func (cc *createClusterOptions) localPatchedClusterConfigFile(ctx context.Context) (string, func(), error) {
+	removeLocal, err := cc.pullOCIFiles(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	patchedFileName, removePatched, err := patchedClusterConfigFile(cc.fileName, cc.patchFiles)
+	if err != nil {
+		removeLocal()
+		return "", nil, err
+	}
+
+	return patchedFileName, func() {
+		removePatched()
+		removeLocal()
+	}, nil
+}
@@ -17,6 +17,8 @@ type deleteClusterOptions struct {
 	clusterOptions
 	wConfig               string
 	forceCleanup          bool
+	force                 bool
+	confirmOrphanCleanup  bool
 	hardwareFileName      string
 	tinkerbellBootstrapIP string
 }
@@ -45,8 +47,12 @@ func init() {
 	deleteClusterCmd.Flags().StringVarP(&dc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration, required if <cluster-name> is not provided")
 	deleteClusterCmd.Flags().StringVarP(&dc.wConfig, "w-config", "w", "", "Kubeconfig file to use when deleting a workload cluster")
 	deleteClusterCmd.Flags().BoolVar(&dc.forceCleanup, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
+	deleteClusterCmd.Flags().BoolVar(&dc.force, "force", false, "Remove the cluster infrastructure through the provider when its CAPI objects are gone or can't be read")
+	deleteClusterCmd.Flags().BoolVar(&dc.confirmOrphanCleanup, "confirm-orphan-cleanup", false, "Confirm that the machines of a cluster whose CAPI objects are gone can be destroyed when using --force")
 	deleteClusterCmd.Flags().StringVar(&dc.managementKubeconfig, "kubeconfig", "", "kubeconfig file pointing to a management cluster")
+	applyManagementContextFlag(deleteClusterCmd.Flags(), &dc.managementContext)
 	deleteClusterCmd.Flags().StringVar(&dc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	applyCredentialsFlags(deleteClusterCmd.Flags(), &dc.clusterOptions)
 }
 
 func (dc *deleteClusterOptions) validate(ctx context.Context, args []string) error {
@@ -66,6 +72,11 @@ func (dc *deleteClusterOptions) validate(ctx context.Context, args []string) err
 		return err
 	}
 
+	// A cluster that failed to be created might not have a kubeconfig, which is fine when forcing its cleanup.
+	if dc.force {
+		return nil
+	}
+
 	kubeconfigPath := getKubeconfigPath(clusterConfig.Name, dc.wConfig)
 	if err := kubeconfig.ValidateFilename(kubeconfigPath); err != nil {
 		return err
@@ -75,7 +86,8 @@ func (dc *deleteClusterOptions) validate(ctx context.Context, args []string) err
 }
 
 func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
-	clusterSpec, err := newClusterSpec(dc.clusterOptions)
+	defer dc.removeManagementKubeconfig()
+	clusterSpec, err := newClusterSpec(&dc.clusterOptions)
 	if err != nil {
 		return fmt.Errorf("unable to get cluster config from file: %v", err)
 	}
@@ -122,7 +134,7 @@ func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 		}
 	}
 
-	err = deleteCluster.Run(ctx, cluster, clusterSpec, dc.forceCleanup, dc.managementKubeconfig)
+	err = deleteCluster.Run(ctx, cluster, clusterSpec, dc.forceCleanup, dc.force, dc.confirmOrphanCleanup, dc.managementKubeconfig)
 	cleanup(deps, &err)
 	return err
 }
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/bundles"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+type describeBundleOptions struct {
+	includeImages bool
+}
+
+var dbo = &describeBundleOptions{}
+
+func init() {
+	describeCmd.AddCommand(describeBundleCmd)
+	describeBundleCmd.Flags().BoolVar(&dbo.includeImages, "images", false, "Include the images shipped in the bundle")
+}
+
+var describeBundleCmd = &cobra.Command{
+	Use:          "bundle [version]",
+	Short:        "Describe the bundle for an EKS Anywhere version",
+	Long:         "This command is used to show the supported Kubernetes versions, component versions and images in the bundle for an EKS Anywhere version. Defaults to the current CLI version",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eksaVersion := version.Get().GitVersion
+		if len(args) == 1 {
+			eksaVersion = args[0]
+		}
+		return describeBundle(cmd.Context(), eksaVersion, dbo)
+	},
+}
+
+func describeBundle(ctx context.Context, eksaVersion string, opts *describeBundleOptions) error {
+	deps, err := dependencies.NewFactory().
+		WithManifestReader().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	cmd := bundles.Describe{
+		Reader:        deps.ManifestReader,
+		Writer:        os.Stdout,
+		Version:       eksaVersion,
+		IncludeImages: opts.includeImages,
+	}
+
+	return cmd.Run(ctx)
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/capacity"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+type estimateOptions struct {
+	fileName            string
+	ociPublicKey        string
+	output              string
+	prices              capacity.Prices
+	snowDeviceVCPUs     int64
+	snowDeviceMemoryGiB float64
+}
+
+var eo = &estimateOptions{}
+
+var estimateCmd = &cobra.Command{
+	Use:          "estimate -f <cluster-config-file> [flags]",
+	Short:        "Estimate the resources of a cluster",
+	Long:         "Use eksctl anywhere estimate to compute the vCPU, memory and storage a cluster config demands from its provider, and its cost when prices are provided",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         eo.estimate,
+}
+
+func init() {
+	rootCmd.AddCommand(estimateCmd)
+	estimateCmd.Flags().StringVarP(&eo.fileName, "filename", "f", "", "Filename or OCI artifact reference (oci://registry/repository:tag) that contains EKS-A cluster configuration")
+	applyOCIPublicKeyFlag(estimateCmd.Flags(), &eo.ociPublicKey)
+	estimateCmd.Flags().StringVarP(&eo.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	estimateCmd.Flags().Float64Var(&eo.prices.VCPU, "vcpu-price", 0, "Price of a vCPU, used to estimate the cluster cost")
+	estimateCmd.Flags().Float64Var(&eo.prices.MemoryGiB, "memory-gib-price", 0, "Price of a GiB of memory, used to estimate the cluster cost")
+	estimateCmd.Flags().Float64Var(&eo.prices.StorageGiB, "storage-gib-price", 0, "Price of a GiB of storage, used to estimate the cluster cost")
+	estimateCmd.Flags().Int64Var(&eo.snowDeviceVCPUs, "snow-device-vcpus", capacity.DefaultSnowDeviceCapacity.VCPUs, "vCPUs of each Snow device available for the cluster instances")
+	estimateCmd.Flags().Float64Var(&eo.snowDeviceMemoryGiB, "snow-device-memory-gib", capacity.DefaultSnowDeviceCapacity.MemoryGiB, "Memory in GiB of each Snow device available for the cluster instances")
+
+	if err := estimateCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (opts *estimateOptions) estimate(cmd *cobra.Command, _ []string) error {
+	if opts.output != outputText && opts.output != outputJson {
+		return fmt.Errorf("invalid output format [%s]", opts.output)
+	}
+
+	fileName, removeClusterConfigFile, err := localFile(cmd.Context(), opts.fileName, opts.ociPublicKey, clusterConfigFilePattern)
+	if err != nil {
+		return err
+	}
+	defer removeClusterConfigFile()
+
+	config, err := cluster.ParseConfigFromFile(fileName)
+	if err != nil {
+		return err
+	}
+	if err := cluster.SetConfigDefaults(config); err != nil {
+		return err
+	}
+	if err := cluster.ValidateConfig(config); err != nil {
+		return fmt.Errorf("invalid cluster config: %v", err)
+	}
+
+	report, err := capacity.NewEstimator(
+		capacity.WithPrices(opts.prices),
+		capacity.WithSnowDeviceCapacity(capacity.Resources{VCPUs: opts.snowDeviceVCPUs, MemoryGiB: opts.snowDeviceMemoryGiB}),
+	).Estimate(config)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := serializeEstimateReport(report, opts.output)
+	if err != nil {
+		return err
+	}
+	fmt.Println(serialized)
+
+	if !report.SnowDevicesFit() {
+		return errors.New("cluster machines don't fit in their Snow devices")
+	}
+
+	return nil
+}
+
+func serializeEstimateReport(report *capacity.Report, outputFormat string) (string, error) {
+	if outputFormat == outputJson {
+		b, err := json.Marshal(report)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the estimate report to json: %v", err)
+		}
+		return string(b), nil
+	}
+
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "MACHINE GROUP\tMACHINE CONFIG\tMACHINES\tVCPUS\tMEMORY (GiB)\tSTORAGE (GiB)")
+	for _, g := range report.MachineGroups {
+		if g.Total == nil {
+			fmt.Fprintf(w, "%s\t%s\t%d\tunknown\tunknown\tunknown\n", g.Name, g.MachineConfig, g.Count)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%g\t%g\n", g.Name, g.MachineConfig, g.Count, g.Total.VCPUs, g.Total.MemoryGiB, g.Total.StorageGiB)
+	}
+	fmt.Fprintf(w, "TOTAL\t\t\t%d\t%g\t%g\n", report.Total.VCPUs, report.Total.MemoryGiB, report.Total.StorageGiB)
+
+	if len(report.SnowDevices) > 0 {
+		fmt.Fprintln(w, "\nSNOW DEVICE\tMACHINES\tVCPUS\tMEMORY (GiB)\tFITS")
+		for _, d := range report.SnowDevices {
+			fmt.Fprintf(w, "%s\t%d\t%d/%d\t%g/%g\t%t\n", d.Device, d.Machines, d.Demand.VCPUs, d.Capacity.VCPUs, d.Demand.MemoryGiB, d.Capacity.MemoryGiB, d.Fits)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	if report.Cost != nil {
+		fmt.Fprintf(&buffer, "\nEstimated cost: %.2f\n", *report.Cost)
+	}
+	if report.Incomplete {
+		fmt.Fprintf(&buffer, "\nThe %s provider machine configs don't define the resources of some machines, they're not included in the total.\n", report.Provider)
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export resources",
+	Long:  "Use eksctl anywhere export to export resources, such as the state of a cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clusterstate"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+)
+
+type exportStateOptions struct {
+	fileName             string
+	managementKubeconfig string
+	managementContext    string
+	format               string
+	output               string
+}
+
+var expStateOpts = &exportStateOptions{}
+
+var exportStateCmd = &cobra.Command{
+	Use:          "state -f <cluster-config-file> [flags]",
+	Short:        "Export the state of a cluster",
+	Long:         "Use eksctl anywhere export state to print a stable machine-readable representation of a realized cluster, including its endpoint, kubeconfig secret, node groups and versions",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         expStateOpts.exportState,
+}
+
+func init() {
+	exportCmd.AddCommand(exportStateCmd)
+	exportStateCmd.Flags().StringVarP(&expStateOpts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	exportStateCmd.Flags().StringVar(&expStateOpts.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	applyManagementContextFlag(exportStateCmd.Flags(), &expStateOpts.managementContext)
+	exportStateCmd.Flags().StringVar(&expStateOpts.format, "format", string(clusterstate.FormatTerraformJSON), "Output format, only terraform-json is supported")
+	exportStateCmd.Flags().StringVarP(&expStateOpts.output, "output", "o", "", "File to write the state to, defaults to stdout")
+
+	if err := exportStateCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (opts *exportStateOptions) exportState(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	format := clusterstate.Format(opts.format)
+	if err := clusterstate.ValidateFormat(format); err != nil {
+		return err
+	}
+
+	clusterConfig, err := commonValidation(ctx, opts.fileName)
+	if err != nil {
+		return err
+	}
+
+	managementCluster := clusterConfig.ManagedBy()
+	if managementCluster == "" {
+		managementCluster = clusterConfig.Name
+	}
+	kubeconfigPath, removeKubeconfig, err := managementKubeconfigPath(getKubeconfigPath(managementCluster, opts.managementKubeconfig), opts.managementContext)
+	if err != nil {
+		return err
+	}
+	defer removeKubeconfig()
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(kubeconfigPath)).
+		WithExecutableBuilder().
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	exporter := clusterstate.NewExporter(deps.UnAuthKubeClient.KubeconfigClient(kubeconfigPath))
+	namespace := clusterConfig.Namespace
+	if namespace == "" {
+		namespace = constants.DefaultNamespace
+	}
+	state, err := exporter.Export(ctx, clusterConfig.Name, namespace)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	return clusterstate.Write(out, state, format)
+}
@@ -28,9 +28,24 @@ func bindFlagsToViper(cmd *cobra.Command, args []string) error {
 }
 
 func applyClusterOptionFlags(flagSet *pflag.FlagSet, clusterOpt *clusterOptions) {
-	flagSet.StringVarP(&clusterOpt.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
-	flagSet.StringVar(&clusterOpt.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	flagSet.StringVarP(&clusterOpt.fileName, "filename", "f", "", "Filename or OCI artifact reference (oci://registry/repository:tag) that contains EKS-A cluster configuration")
+	flagSet.StringVar(&clusterOpt.bundlesOverride, "bundles-override", "", "Override default Bundles manifest with a file, URL or OCI artifact reference (not recommended)")
 	flagSet.StringVar(&clusterOpt.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	applyManagementContextFlag(flagSet, &clusterOpt.managementContext)
+	applyOCIPublicKeyFlag(flagSet, &clusterOpt.ociPublicKey)
+}
+
+func applyOCIPublicKeyFlag(flagSet *pflag.FlagSet, publicKeyOut *string) {
+	flagSet.StringVar(publicKeyOut, "oci-public-key", "", "Public key file to verify the cosign signature of OCI artifact references, required for the ones not referenced by digest")
+}
+
+func applyManagementContextFlag(flagSet *pflag.FlagSet, contextOut *string) {
+	flagSet.StringVar(contextOut, "context", "", "Context of the management cluster kubeconfig to use, instead of its current context")
+}
+
+func applyCredentialsFlags(flagSet *pflag.FlagSet, clusterOpt *clusterOptions) {
+	flagSet.StringVar(&clusterOpt.credentialsFile, "credentials-file", "", "Credentials file with the provider credentials profiles, used instead of the provider environment variables")
+	flagSet.StringVar(&clusterOpt.credentialsProfile, "credentials-profile", "", "Profile of the credentials file to use, overrides the cluster credentials-profile annotation (default \"default\")")
 }
 
 func applyTinkerbellHardwareFlag(flagSet *pflag.FlagSet, pathOut *string) {
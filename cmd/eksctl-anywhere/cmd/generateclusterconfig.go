@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -12,7 +15,9 @@ import (
 
 	"github.com/aws/eks-anywhere/internal/pkg/api"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/templater"
@@ -24,9 +29,20 @@ var removeFromDefaultConfig = []string{"spec.clusterNetwork.dns"}
 var generateClusterConfigCmd = &cobra.Command{
 	Use:    "clusterconfig <cluster-name> (max 80 chars)",
 	Short:  "Generate cluster config",
-	Long:   "This command is used to generate a cluster config yaml for the create cluster command",
+	Long:   "This command is used to generate a cluster config yaml for the create cluster command, or with --from-cluster to generate the cluster config of an existing cluster from its objects in the management cluster",
 	PreRun: preRunGenerateClusterConfig,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if fromCluster := viper.GetString("from-cluster"); fromCluster != "" {
+			if err := generateClusterConfigFromCluster(cmd.Context(), fromCluster); err != nil {
+				return fmt.Errorf("generating eks-a cluster config from cluster %s: %v", fromCluster, err)
+			}
+			return nil
+		}
+
+		if viper.GetString("provider") == "" {
+			return errors.New("required flag \"provider\" not set")
+		}
+
 		clusterName, err := validations.ValidateClusterNameArg(args)
 		if err != nil {
 			return err
@@ -50,11 +66,50 @@ func preRunGenerateClusterConfig(cmd *cobra.Command, args []string) {
 
 func init() {
 	generateCmd.AddCommand(generateClusterConfigCmd)
-	generateClusterConfigCmd.Flags().StringP("provider", "p", "", "Provider to use (vsphere or tinkerbell or docker)")
-	err := generateClusterConfigCmd.MarkFlagRequired("provider")
+	generateClusterConfigCmd.Flags().StringP("provider", "p", "", "Provider to use (vsphere or tinkerbell or docker), required unless --from-cluster is set")
+	generateClusterConfigCmd.Flags().String("from-cluster", "", "Name of an existing cluster to generate the config from, reading its objects from the management cluster")
+	generateClusterConfigCmd.Flags().String("namespace", constants.DefaultNamespace, "Namespace of the cluster to generate the config from, used with --from-cluster")
+	generateClusterConfigCmd.Flags().String("kubeconfig", "", "Management cluster kubeconfig file, used with --from-cluster")
+	generateClusterConfigCmd.Flags().String("context", "", "Context of the management cluster kubeconfig to use, instead of its current context")
+}
+
+// generateClusterConfigFromCluster prints the normalized config of an existing cluster, built
+// from the EKS-A objects in its management cluster.
+func generateClusterConfigFromCluster(ctx context.Context, clusterName string) error {
+	kubeconfigPath, removeKubeconfig, err := managementKubeconfigPath(getKubeconfigPath(clusterName, viper.GetString("kubeconfig")), viper.GetString("context"))
 	if err != nil {
-		log.Fatalf("marking flag as required: %v", err)
+		return err
 	}
+	defer removeKubeconfig()
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(kubeconfigPath)).
+		WithExecutableBuilder().
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	client := deps.UnAuthKubeClient.KubeconfigClient(kubeconfigPath)
+	eksaCluster := &v1alpha1.Cluster{}
+	if err = client.Get(ctx, clusterName, viper.GetString("namespace"), eksaCluster); err != nil {
+		return fmt.Errorf("getting cluster: %v", err)
+	}
+
+	config, err := cluster.NewDefaultConfigClientBuilder().Build(ctx, client, eksaCluster)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := cluster.NormalizedManifest(config)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(manifest))
+	return nil
 }
 
 func generateClusterConfig(clusterName string) error {
@@ -4,15 +4,24 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/discovery"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 )
 
 type hardwareOptions struct {
 	csvPath    string
 	outputPath string
+
+	scanRange   string
+	bmcUsername string
+	bmcPassword string
+	scanTimeout time.Duration
+	scanWorkers int
 }
 
 var hOpts = &hardwareOptions{}
@@ -22,6 +31,9 @@ var generateHardwareCmd = &cobra.Command{
 	Short: "Generate hardware files",
 	Long: `
 Generate Kubernetes hardware YAML manifests for each Hardware entry in the source.
+
+With --scan, probe an IP range for Redfish and IPMI BMCs instead and generate a hardware CSV
+pre-filled with the BMC, MAC address and model of each machine found.
 `,
 	RunE: hOpts.generateHardware,
 }
@@ -38,13 +50,24 @@ func init() {
 		"",
 		TinkerbellHardwareCSVFlagDescription,
 	)
-
-	if err := generateHardwareCmd.MarkFlagRequired(TinkerbellHardwareCSVFlagName); err != nil {
-		panic(err)
-	}
+	flags.StringVar(&hOpts.scanRange, "scan", "", "IP range scanned for BMCs to generate a hardware CSV, as a CIDR or a range like 10.0.0.10-10.0.0.50")
+	flags.StringVar(&hOpts.bmcUsername, "bmc-username", "", "Username of the scanned BMCs")
+	flags.StringVar(&hOpts.bmcPassword, "bmc-password", "", "Password of the scanned BMCs")
+	flags.DurationVar(&hOpts.scanTimeout, "scan-timeout", discovery.DefaultTimeout, "Timeout probing each address of the scan")
+	flags.IntVar(&hOpts.scanWorkers, "scan-workers", discovery.DefaultWorkers, "Number of addresses probed in parallel")
 }
 
 func (hOpts *hardwareOptions) generateHardware(cmd *cobra.Command, args []string) error {
+	if hOpts.scanRange != "" {
+		if hOpts.csvPath != "" {
+			return fmt.Errorf("--scan and --%s are mutually exclusive", TinkerbellHardwareCSVFlagName)
+		}
+		return hOpts.scanHardware(cmd)
+	}
+	if hOpts.csvPath == "" {
+		return fmt.Errorf("either --%s or --scan is required", TinkerbellHardwareCSVFlagName)
+	}
+
 	csvFile, err := os.Open(hOpts.csvPath)
 	if err != nil {
 		return fmt.Errorf("csv: %v", err)
@@ -67,3 +90,30 @@ func (hOpts *hardwareOptions) generateHardware(cmd *cobra.Command, args []string
 
 	return hardware.TranslateAll(reader, writer, validator)
 }
+
+func (hOpts *hardwareOptions) scanHardware(cmd *cobra.Command) error {
+	ips, err := discovery.ParseIPRange(hOpts.scanRange)
+	if err != nil {
+		return err
+	}
+
+	scanner := discovery.NewScanner(hOpts.scanTimeout, hOpts.scanWorkers,
+		discovery.NewRedfishProber(hOpts.bmcUsername, hOpts.bmcPassword),
+		discovery.NewIPMIProber(),
+	)
+	logger.Info("Scanning for BMCs", "range", hOpts.scanRange, "addresses", len(ips))
+	bmcs := scanner.Scan(cmd.Context(), ips)
+	logger.Info("Scan finished", "bmcs", len(bmcs))
+
+	fh, err := hardware.CreateOrStdout(hOpts.outputPath)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(fh)
+	defer writer.Flush()
+
+	if err := hardware.WriteCSV(writer, discovery.Machines(bmcs, hOpts.bmcUsername, hOpts.bmcPassword)); err != nil {
+		return fmt.Errorf("writing hardware csv: %v", err)
+	}
+	return nil
+}
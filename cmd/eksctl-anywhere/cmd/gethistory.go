@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/aws/eks-anywhere/pkg/clusterhistory"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type getHistoryOptions struct {
+	output     string
+	kubeConfig string
+	context    string
+	namespace  string
+}
+
+var gho = &getHistoryOptions{}
+
+var getHistoryCmd = &cobra.Command{
+	Use:          "history <cluster-name> [flags]",
+	Short:        "Get the history of changes of a cluster",
+	Long:         "This command displays the changes applied to the spec of a cluster by the CLI and the EKS-A controller, oldest first",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return gho.getHistory(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getHistoryCmd)
+
+	getHistoryCmd.Flags().StringVarP(&gho.output, outputFlagName, "o", outputText, "Output format: text|json")
+	getHistoryCmd.Flags().StringVar(&gho.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	applyManagementContextFlag(getHistoryCmd.Flags(), &gho.context)
+	getHistoryCmd.Flags().StringVarP(&gho.namespace, "namespace", "n", "default", "Namespace of the cluster")
+}
+
+func (o *getHistoryOptions) getHistory(ctx context.Context, clusterName string) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(o.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+	kubeConfig, removeKubeconfig, err := kubeconfig.WithContext(kubeConfig, o.context)
+	if err != nil {
+		return err
+	}
+	defer removeKubeconfig()
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeConfig).
+		WithExecutableBuilder().
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	cm := &corev1.ConfigMap{}
+	err = deps.Kubectl.GetObject(ctx, "configmap", clusterhistory.ConfigMapName(clusterName), o.namespace, kubeConfig, cm)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("no history found for cluster %s in namespace %s", clusterName, o.namespace)
+	} else if err != nil {
+		return err
+	}
+
+	entries, err := clusterhistory.Entries(cm)
+	if err != nil {
+		return err
+	}
+
+	out, err := serializeHistory(entries, o.output)
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+
+	return nil
+}
+
+func serializeHistory(entries []clusterhistory.Entry, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeHistoryToText(entries)
+	case outputJson:
+		b, err := json.MarshalIndent(entries, "", "    ")
+		if err != nil {
+			return "", fmt.Errorf("serializing history: %v", err)
+		}
+		return string(b) + "\n", nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializeHistoryToText(entries []clusterhistory.Entry) (string, error) {
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "TIME\tSOURCE\tACTOR\tGENERATION\tCHANGE")
+	for _, e := range entries {
+		generation := ""
+		if e.Generation != 0 {
+			generation = strconv.FormatInt(e.Generation, 10)
+		}
+		for i, c := range e.Changes {
+			if i == 0 {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Time.Format(time.RFC3339), e.Source, e.Actor, generation, c)
+			} else {
+				fmt.Fprintf(w, "\t\t\t\t%s\n", c)
+			}
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,101 @@
+package bundles
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type Reader interface {
+	ReadReleases() (*releasev1.Release, error)
+	ReadBundlesForVersion(eksaVersion string) (*releasev1.Bundles, error)
+}
+
+// List writes the EKS-A releases available in the releases manifest along with the
+// bundle manifest backing each release.
+type List struct {
+	Reader Reader
+	Writer io.Writer
+}
+
+func (l List) Run(_ context.Context) error {
+	release, err := l.Reader.ReadReleases()
+	if err != nil {
+		return fmt.Errorf("reading releases manifest: %v", err)
+	}
+
+	w := tabwriter.NewWriter(l.Writer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNUMBER\tDATE\tBUNDLE MANIFEST")
+	for _, r := range release.Spec.Releases {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", r.Version, r.Number, r.Date, r.BundleManifestUrl)
+	}
+
+	return w.Flush()
+}
+
+// Describe writes the supported Kubernetes versions and component versions, and optionally
+// images, shipped in the bundle for an EKS-A version.
+type Describe struct {
+	Reader        Reader
+	Writer        io.Writer
+	Version       string
+	IncludeImages bool
+}
+
+func (d Describe) Run(_ context.Context) error {
+	b, err := d.Reader.ReadBundlesForVersion(d.Version)
+	if err != nil {
+		return fmt.Errorf("reading bundles manifest: %v", err)
+	}
+
+	fmt.Fprintf(d.Writer, "Bundle number: %d\n", b.Spec.Number)
+	fmt.Fprintf(d.Writer, "CLI min version: %s\n", b.Spec.CliMinVersion)
+	fmt.Fprintf(d.Writer, "CLI max version: %s\n", b.Spec.CliMaxVersion)
+
+	for i := range b.Spec.VersionsBundles {
+		versionsBundle := &b.Spec.VersionsBundles[i]
+		fmt.Fprintf(d.Writer, "\nKubernetes %s\n", versionsBundle.KubeVersion)
+
+		versions := versionsBundle.ComponentVersions()
+		w := tabwriter.NewWriter(d.Writer, 10, 4, 3, ' ', 0)
+		fmt.Fprintln(w, "COMPONENT\tVERSION")
+		for _, component := range sortedKeys(versions) {
+			fmt.Fprintf(w, "%s\t%s\n", component, versions[component])
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if !d.IncludeImages {
+			continue
+		}
+
+		fmt.Fprintln(d.Writer)
+		w = tabwriter.NewWriter(d.Writer, 10, 4, 3, ' ', 0)
+		fmt.Fprintln(w, "IMAGE\tURI")
+		for _, image := range versionsBundle.Images() {
+			if image.URI == "" {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\n", image.Name, image.VersionedImage())
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,82 @@
+package bundles_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/bundles"
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/bundles/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func TestListRun(t *testing.T) {
+	g := NewWithT(t)
+	reader := mocks.NewMockReader(gomock.NewController(t))
+	out := &bytes.Buffer{}
+
+	reader.EXPECT().ReadReleases().Return(&releasev1.Release{
+		Spec: releasev1.ReleaseSpec{
+			Releases: []releasev1.EksARelease{
+				{Version: "v0.11.0", Number: 10, BundleManifestUrl: "https://bundles/10.yaml"},
+			},
+		},
+	}, nil)
+
+	l := bundles.List{Reader: reader, Writer: out}
+	g.Expect(l.Run(context.Background())).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("v0.11.0"))
+	g.Expect(out.String()).To(ContainSubstring("https://bundles/10.yaml"))
+}
+
+func TestListRunError(t *testing.T) {
+	g := NewWithT(t)
+	reader := mocks.NewMockReader(gomock.NewController(t))
+
+	reader.EXPECT().ReadReleases().Return(nil, errors.New("error reading"))
+
+	l := bundles.List{Reader: reader, Writer: &bytes.Buffer{}}
+	g.Expect(l.Run(context.Background())).To(MatchError(ContainSubstring("error reading")))
+}
+
+func TestDescribeRun(t *testing.T) {
+	g := NewWithT(t)
+	reader := mocks.NewMockReader(gomock.NewController(t))
+	out := &bytes.Buffer{}
+
+	reader.EXPECT().ReadBundlesForVersion("v0.11.0").Return(&releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			Number: 10,
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1.23",
+					EksD:        releasev1.EksDRelease{Name: "kubernetes-1-23-eks-4"},
+					Cilium: releasev1.CiliumBundle{
+						Version: "v1.10.14",
+						Cilium:  releasev1.Image{Name: "cilium", URI: "public.ecr.aws/cilium:v1.10.14"},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	d := bundles.Describe{Reader: reader, Writer: out, Version: "v0.11.0", IncludeImages: true}
+	g.Expect(d.Run(context.Background())).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("Kubernetes 1.23"))
+	g.Expect(out.String()).To(ContainSubstring("kubernetes-1-23-eks-4"))
+	g.Expect(out.String()).To(ContainSubstring("public.ecr.aws/cilium:v1.10.14"))
+}
+
+func TestDescribeRunError(t *testing.T) {
+	g := NewWithT(t)
+	reader := mocks.NewMockReader(gomock.NewController(t))
+
+	reader.EXPECT().ReadBundlesForVersion("v0.11.0").Return(nil, errors.New("error reading"))
+
+	d := bundles.Describe{Reader: reader, Writer: &bytes.Buffer{}, Version: "v0.11.0"}
+	g.Expect(d.Run(context.Background())).To(MatchError(ContainSubstring("error reading")))
+}
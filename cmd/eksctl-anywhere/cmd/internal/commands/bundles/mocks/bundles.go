@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cmd/eksctl-anywhere/cmd/internal/commands/bundles/bundles.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// ReadBundlesForVersion mocks base method.
+func (m *MockReader) ReadBundlesForVersion(eksaVersion string) (*v1alpha1.Bundles, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadBundlesForVersion", eksaVersion)
+	ret0, _ := ret[0].(*v1alpha1.Bundles)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadBundlesForVersion indicates an expected call of ReadBundlesForVersion.
+func (mr *MockReaderMockRecorder) ReadBundlesForVersion(eksaVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadBundlesForVersion", reflect.TypeOf((*MockReader)(nil).ReadBundlesForVersion), eksaVersion)
+}
+
+// ReadReleases mocks base method.
+func (m *MockReader) ReadReleases() (*v1alpha1.Release, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadReleases")
+	ret0, _ := ret[0].(*v1alpha1.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadReleases indicates an expected call of ReadReleases.
+func (mr *MockReaderMockRecorder) ReadReleases() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadReleases", reflect.TypeOf((*MockReader)(nil).ReadReleases))
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/bundles"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+)
+
+func init() {
+	listCmd.AddCommand(listBundlesCmd)
+}
+
+var listBundlesCmd = &cobra.Command{
+	Use:          "bundles",
+	Short:        "List the EKS Anywhere releases and their bundles",
+	Long:         "This command is used to list the EKS Anywhere releases available in the releases manifest along with their bundle manifests",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listBundles(cmd.Context())
+	},
+}
+
+func listBundles(ctx context.Context) error {
+	deps, err := dependencies.NewFactory().
+		WithManifestReader().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	cmd := bundles.List{
+		Reader: deps.ManifestReader,
+		Writer: os.Stdout,
+	}
+
+	return cmd.Run(ctx)
+}
@@ -2,16 +2,36 @@ package cmd
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/version"
+	"github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	imagesOutputText = "text"
+	imagesOutputCSV  = "csv"
+	imagesOutputJSON = "json"
+
+	allProviders = "all"
 )
 
 type listImagesOptions struct {
 	fileName string
+	provider string
+	features []string
+	output   string
 }
 
 var lio = &listImagesOptions{}
@@ -19,6 +39,9 @@ var lio = &listImagesOptions{}
 func init() {
 	listCmd.AddCommand(listImagesCommand)
 	listImagesCommand.Flags().StringVarP(&lio.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	listImagesCommand.Flags().StringVar(&lio.provider, "provider", "", "Provider to list images for. Defaults to all providers")
+	listImagesCommand.Flags().StringSliceVar(&lio.features, "features", nil, "Optional features to include images for (packages, iam-authenticator, flux, conformance, backup, bottlerocket-updates). Defaults to all features")
+	listImagesCommand.Flags().StringVarP(&lio.output, "output", "o", imagesOutputText, "Output format: text, csv or json")
 	err := listImagesCommand.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking filename flag as required: %v", err)
@@ -39,23 +62,104 @@ var listImagesCommand = &cobra.Command{
 	},
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return listImages(cmd.Context(), lio.fileName)
+		return listImages(cmd.Context(), lio)
 	},
 }
 
-func listImages(context context.Context, spec string) error {
-	images, err := getImages(spec)
+func listImages(context context.Context, opts *listImagesOptions) error {
+	clusterSpec, err := readAndValidateClusterSpec(opts.fileName, version.Get())
 	if err != nil {
 		return err
 	}
 
-	for _, image := range images {
-		if image.ImageDigest != "" {
-			fmt.Printf("%s@%s\n", image.URI, image.ImageDigest)
-		} else {
-			fmt.Printf("%s\n", image.URI)
+	filter, err := opts.imagesFilter()
+	if err != nil {
+		return err
+	}
+
+	images, err := clusterSpec.FilteredImages(filter)
+	if err != nil {
+		return err
+	}
+
+	return writeImages(os.Stdout, images, opts.output)
+}
+
+func (opts *listImagesOptions) imagesFilter() (cluster.ImagesFilter, error) {
+	filter := cluster.ImagesFilter{}
+
+	// Without filters, all the images are listed, so they can all be mirrored to a private registry.
+	if opts.provider != "" && opts.provider != allProviders {
+		filter.Providers = []string{opts.provider}
+	}
+
+	if opts.features == nil {
+		filter.Features = cluster.Features()
+		return filter, nil
+	}
+
+	supported := map[cluster.Feature]struct{}{}
+	for _, f := range cluster.Features() {
+		supported[f] = struct{}{}
+	}
+	for _, f := range opts.features {
+		feature := cluster.Feature(strings.TrimSpace(f))
+		if _, ok := supported[feature]; !ok {
+			return filter, fmt.Errorf("unsupported feature %s", f)
 		}
+		filter.Features = append(filter.Features, feature)
+	}
+
+	return filter, nil
+}
+
+type imageEntry struct {
+	Name   string `json:"name"`
+	URI    string `json:"uri"`
+	Digest string `json:"digest,omitempty"`
+	OS     string `json:"os,omitempty"`
+	Arch   string `json:"arch,omitempty"`
+}
+
+func writeImages(w io.Writer, images []v1alpha1.Image, output string) error {
+	entries := make([]imageEntry, 0, len(images))
+	for _, image := range images {
+		entries = append(entries, imageEntry{
+			Name:   image.Name,
+			URI:    image.URI,
+			Digest: image.ImageDigest,
+			OS:     image.OS,
+			Arch:   strings.Join(image.Arch, ","),
+		})
 	}
 
-	return nil
+	switch output {
+	case imagesOutputText:
+		for _, e := range entries {
+			if e.Digest != "" {
+				fmt.Fprintf(w, "%s@%s\n", e.URI, e.Digest)
+			} else {
+				fmt.Fprintf(w, "%s\n", e.URI)
+			}
+		}
+		return nil
+	case imagesOutputCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"name", "uri", "digest", "os", "arch"}); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := cw.Write([]string{e.Name, e.URI, e.Digest, e.OS, e.Arch}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case imagesOutputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	default:
+		return fmt.Errorf("unsupported output format %s", output)
+	}
 }
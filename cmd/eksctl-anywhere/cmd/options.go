@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,9 +14,11 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/credentials"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/timesync"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
@@ -62,10 +65,87 @@ func buildClusterManagerOpts(t timeoutOptions) ([]clustermanager.ClusterManagerO
 	}, nil
 }
 
+type timeSyncOptions struct {
+	ntpServer    string
+	maxClockSkew time.Duration
+}
+
+func applyTimeSyncFlags(flagSet *pflag.FlagSet, t *timeSyncOptions) {
+	flagSet.StringVar(&t.ntpServer, ntpServerFlag, timesync.DefaultNTPServer, "NTP server used as time reference to validate the clocks of the nodes. The local clock is used if it can't be reached")
+	flagSet.DurationVar(&t.maxClockSkew, maxClockSkewFlag, timesync.DefaultMaxSkew, "Maximum clock skew tolerated between the nodes and the NTP server")
+}
+
+func (t timeSyncOptions) validate() error {
+	if t.maxClockSkew <= 0 {
+		return fmt.Errorf("--%s must be greater than 0", maxClockSkewFlag)
+	}
+	return nil
+}
+
+// timeSyncChecker returns a checker for the node clocks.
+func (t timeSyncOptions) timeSyncChecker(nodes timesync.NodeLister) *timesync.Checker {
+	return timesync.NewChecker(
+		nodes,
+		timesync.NewKubeletClock(),
+		timesync.NewNTPClock(t.ntpServer, timesync.WithLocalFallback()),
+		timesync.WithMaxSkew(t.maxClockSkew),
+	)
+}
+
 type clusterOptions struct {
 	fileName             string
 	bundlesOverride      string
 	managementKubeconfig string
+	managementContext    string
+	credentialsFile      string
+	credentialsProfile   string
+	ociPublicKey         string
+	// removeKubeconfigFile removes the kubeconfig file written by resolveManagementKubeconfig.
+	removeKubeconfigFile func()
+}
+
+// resolveManagementKubeconfig points the management kubeconfig to a kubeconfig file with only the
+// management context, the one set with the context flag or the current context of the kubeconfig.
+// The file is removed with removeManagementKubeconfig.
+func (c *clusterOptions) resolveManagementKubeconfig() error {
+	kubeconfigFile, remove, err := kubeconfig.WithContext(c.managementKubeconfig, c.managementContext)
+	if err != nil {
+		return err
+	}
+	c.managementKubeconfig = kubeconfigFile
+	c.removeKubeconfigFile = remove
+
+	return nil
+}
+
+// removeManagementKubeconfig removes the kubeconfig file written by resolveManagementKubeconfig, if any.
+// Commands call it once they are done with the management cluster, so its credentials don't stay on disk.
+func (c *clusterOptions) removeManagementKubeconfig() {
+	if c.removeKubeconfigFile != nil {
+		c.removeKubeconfigFile()
+	}
+}
+
+// pullOCIFiles replaces the cluster config and bundles override OCI artifact references with local copies
+// of the artifacts. The returned func removes them.
+func (c *clusterOptions) pullOCIFiles(ctx context.Context) (remove func(), err error) {
+	fileName, removeClusterConfig, err := localFile(ctx, c.fileName, c.ociPublicKey, clusterConfigFilePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	bundlesOverride, removeBundlesOverride, err := localFile(ctx, c.bundlesOverride, c.ociPublicKey, bundlesOverrideFilePattern)
+	if err != nil {
+		removeClusterConfig()
+		return nil, err
+	}
+
+	c.fileName, c.bundlesOverride = fileName, bundlesOverride
+
+	return func() {
+		removeBundlesOverride()
+		removeClusterConfig()
+	}, nil
 }
 
 func (c clusterOptions) mountDirs() []string {
@@ -89,7 +169,13 @@ func readAndValidateClusterSpec(clusterConfigPath string, cliVersion version.Inf
 	return clusterSpec, nil
 }
 
-func newClusterSpec(options clusterOptions) (*cluster.Spec, error) {
+// newClusterSpec builds the spec of the cluster config file. It resolves the management kubeconfig
+// context first, so the options point to the same management cluster the spec was built with.
+func newClusterSpec(options *clusterOptions) (*cluster.Spec, error) {
+	if err := options.resolveManagementKubeconfig(); err != nil {
+		return nil, err
+	}
+
 	var specOpts []cluster.SpecOpt
 	if options.bundlesOverride != "" {
 		specOpts = append(specOpts, cluster.WithOverrideBundlesManifest(options.bundlesOverride))
@@ -107,9 +193,32 @@ func newClusterSpec(options clusterOptions) (*cluster.Spec, error) {
 		return nil, fmt.Errorf("unable to get cluster config from file: %v", err)
 	}
 
+	if err := options.loadCredentials(clusterSpec); err != nil {
+		return nil, err
+	}
+
 	return clusterSpec, nil
 }
 
+// loadCredentials exports the provider credentials of the credentials file profile, the one set
+// with the flag or in the cluster annotations, to the provider environment variables.
+func (c clusterOptions) loadCredentials(clusterSpec *cluster.Spec) error {
+	if c.credentialsFile == "" {
+		return nil
+	}
+
+	profile := c.credentialsProfile
+	if profile == "" {
+		profile = clusterSpec.Cluster.CredentialsProfile()
+	}
+	if profile == "" {
+		profile = credentials.DefaultProfile
+	}
+
+	logger.V(4).Info("Loading provider credentials", "file", c.credentialsFile, "profile", profile)
+	return credentials.Load(c.credentialsFile, clusterSpec.Cluster.Spec.DatacenterRef.Kind, profile)
+}
+
 func markFlagHidden(flagSet *pflag.FlagSet, flagName string) {
 	if err := flagSet.MarkHidden(flagName); err != nil {
 		logger.V(5).Info("Warning: Failed to mark flag as hidden: " + flagName)
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/telemetry"
 )
 
 var rootCmd = &cobra.Command{
@@ -19,7 +23,9 @@ var rootCmd = &cobra.Command{
 }
 
 func init() {
+	rootCmd.SetErr(logger.NewRedactingWriter(os.Stderr))
 	rootCmd.PersistentFlags().IntP("verbosity", "v", 0, "Set the log level verbosity")
+	rootCmd.PersistentFlags().Bool("no-telemetry", false, fmt.Sprintf("Don't record usage statistics for this command, even if telemetry has been enabled with %s", telemetry.EnabledEnvVar))
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
 		log.Fatalf("failed to bind flags for root: %v", err)
 	}
@@ -35,10 +41,41 @@ func initLogger() error {
 	if err := logger.InitZap(viper.GetInt("verbosity")); err != nil {
 		return fmt.Errorf("failed init zap logger in root command: %v", err)
 	}
+	executables.RegisterEnvSecrets()
 
 	return nil
 }
 
 func Execute() error {
-	return rootCmd.ExecuteContext(context.Background())
+	return ExecuteContext(context.Background())
+}
+
+// ExecuteContext runs the root command with ctx. Canceling ctx stops the running operation
+// and the commands it's running.
+func ExecuteContext(ctx context.Context) error {
+	recorder, err := telemetry.NewRecorderFromEnv()
+	if err != nil {
+		logger.V(4).Info("Telemetry disabled", "error", err)
+	}
+
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteContextC(telemetry.WithRecorder(ctx, recorder))
+	if !viper.GetBool("no-telemetry") {
+		recordTelemetry(recorder, cmd.CommandPath(), time.Since(start), err)
+	}
+
+	return err
+}
+
+// recordTelemetry never fails the command, telemetry errors are only logged.
+func recordTelemetry(recorder *telemetry.Recorder, command string, duration time.Duration, cmdErr error) {
+	if err := recorder.Record(command, duration, cmdErr); err != nil {
+		logger.V(4).Info("Failed recording telemetry", "error", err)
+		return
+	}
+
+	// The command context might have been canceled, so events are sent with a new one.
+	if err := recorder.Flush(context.Background()); err != nil {
+		logger.V(4).Info("Failed sending telemetry", "error", err)
+	}
 }
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run tests or operations against a cluster",
+	Long:  "Use eksctl anywhere run to run tests or operations against a cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+}
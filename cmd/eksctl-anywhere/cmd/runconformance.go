@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/conformance"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+const defaultConformanceTimeout = 6 * time.Hour
+
+type runConformanceOptions struct {
+	clusterOptions
+	wConfig   string
+	mode      string
+	outputDir string
+	timeout   time.Duration
+}
+
+var rco = &runConformanceOptions{}
+
+var runConformanceCmd = &cobra.Command{
+	Use:          "conformance -f <cluster-config-file> [flags]",
+	Short:        "Run the Kubernetes conformance tests",
+	Long:         "Use eksctl anywhere run conformance to run the sonobuoy Kubernetes conformance tests against a cluster with the images of the EKS-A bundle and save the results archive",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         rco.runConformance,
+}
+
+func init() {
+	runCmd.AddCommand(runConformanceCmd)
+	applyClusterOptionFlags(runConformanceCmd.Flags(), &rco.clusterOptions)
+	runConformanceCmd.Flags().StringVarP(&rco.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster to test")
+	runConformanceCmd.Flags().StringVar(&rco.mode, "mode", string(executables.SonobuoyCertifiedConformance), fmt.Sprintf("Tests to run: %s for the whole conformance suite, %s for a smoke test", executables.SonobuoyCertifiedConformance, executables.SonobuoyQuick))
+	runConformanceCmd.Flags().StringVarP(&rco.outputDir, "output-dir", "o", "", "Directory to save the results archive to. Defaults to <cluster-name>/conformance")
+	runConformanceCmd.Flags().DurationVar(&rco.timeout, "timeout", defaultConformanceTimeout, "Maximum time to wait for the tests to complete")
+
+	if err := runConformanceCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (opts *runConformanceOptions) runConformance(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	mode := executables.SonobuoyMode(opts.mode)
+	if mode != executables.SonobuoyCertifiedConformance && mode != executables.SonobuoyQuick {
+		return fmt.Errorf("invalid --mode %s, must be %s or %s", opts.mode, executables.SonobuoyCertifiedConformance, executables.SonobuoyQuick)
+	}
+
+	removeOCIFiles, err := opts.pullOCIFiles(ctx)
+	if err != nil {
+		return err
+	}
+	defer removeOCIFiles()
+
+	defer opts.removeManagementKubeconfig()
+	clusterSpec, err := newClusterSpec(&opts.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	kubeconfigPath := getKubeconfigPath(clusterSpec.Cluster.Name, opts.wConfig)
+	if err := kubeconfig.ValidateFilename(kubeconfigPath); err != nil {
+		return err
+	}
+
+	outputDir := opts.outputDir
+	if outputDir == "" {
+		outputDir = filepath.Join(clusterSpec.Cluster.Name, "conformance")
+	}
+	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+		return fmt.Errorf("creating conformance results directory: %v", err)
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).
+		WithExecutableMountDirs(append(opts.mountDirs(), filepath.Dir(kubeconfigPath), outputDir)...).
+		WithSonobuoy().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	result, err := conformance.NewRunner(deps.Sonobuoy).Run(ctx, clusterSpec, kubeconfigPath, outputDir, mode, opts.timeout)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result.Summary)
+	fmt.Printf("Conformance results saved to %s\n", result.Archive)
+
+	if !result.Passed {
+		return errors.New("conformance tests failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"log"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/operatorapi"
+)
+
+type serveOptions struct {
+	clusterName   string
+	kubeconfig    string
+	context       string
+	listenAddress string
+	tokenFile     string
+	tlsCertFile   string
+	tlsKeyFile    string
+	queueSize     int
+}
+
+var srvOpts = &serveOptions{}
+
+var serveCmd = &cobra.Command{
+	Use:          "serve --cluster-name <management-cluster-name> --token-file <file> --tls-cert-file <file> --tls-key-file <file>",
+	Short:        "Serve the cluster lifecycle API",
+	Long:         "Serve an authenticated REST API to create, upgrade, delete and get the status of the workload clusters of a management cluster. Operations are carried out by the EKS-A controller running in the management cluster",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         srvOpts.serve,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&srvOpts.clusterName, "cluster-name", "", "Name of the management cluster")
+	serveCmd.Flags().StringVar(&srvOpts.kubeconfig, "kubeconfig", "", "Kubeconfig file of the management cluster")
+	applyManagementContextFlag(serveCmd.Flags(), &srvOpts.context)
+	serveCmd.Flags().StringVar(&srvOpts.listenAddress, "listen-address", ":8443", "Address the API listens on")
+	serveCmd.Flags().StringVar(&srvOpts.tokenFile, "token-file", "", "File with the bearer tokens accepted by the API, one per line")
+	serveCmd.Flags().StringVar(&srvOpts.tlsCertFile, "tls-cert-file", "", "TLS certificate file for the API")
+	serveCmd.Flags().StringVar(&srvOpts.tlsKeyFile, "tls-key-file", "", "TLS private key file for the API")
+	serveCmd.Flags().IntVar(&srvOpts.queueSize, "queue-size", 100, "Maximum number of operations waiting to be processed")
+
+	for _, flag := range []string{"cluster-name", "token-file", "tls-cert-file", "tls-key-file"} {
+		if err := serveCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (opts *serveOptions) serve(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	kubeconfigPath, removeKubeconfig, err := managementKubeconfigPath(getKubeconfigPath(opts.clusterName, opts.kubeconfig), opts.context)
+	if err != nil {
+		return err
+	}
+	defer removeKubeconfig()
+
+	auth, err := operatorapi.NewTokenAuthenticatorFromFile(opts.tokenFile)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(kubeconfigPath)).
+		WithExecutableBuilder().
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	server := operatorapi.NewServer(
+		deps.UnAuthKubeClient,
+		auth,
+		opts.clusterName,
+		kubeconfigPath,
+		operatorapi.WithQueueSize(opts.queueSize),
+	)
+
+	return server.ListenAndServeTLS(ctx, opts.listenAddress, opts.tlsCertFile, opts.tlsKeyFile)
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var snowCmd = &cobra.Command{
+	Use:   "snow",
+	Short: "Utility snow operations",
+	Long:  "Use eksctl anywhere exp snow to perform utility operations for the Snow provider",
+}
+
+func init() {
+	expCmd.AddCommand(snowCmd)
+}
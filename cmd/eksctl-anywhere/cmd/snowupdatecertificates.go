@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type snowUpdateCertificatesOptions struct {
+	fileName             string
+	managementKubeconfig string
+	managementContext    string
+}
+
+var suc = &snowUpdateCertificatesOptions{}
+
+var snowUpdateCertificatesCmd = &cobra.Command{
+	Use:          "update-certificates -f <cluster-config-file> [flags]",
+	Short:        "Update the snow device credentials and certificates",
+	Long:         "Use eksctl anywhere exp snow update-certificates to replace the snow device credentials and certificates used by the cluster controllers with the ones in EKSA_AWS_CREDENTIALS_FILE and EKSA_AWS_CA_BUNDLES_FILE",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return suc.updateCertificates(cmd)
+	},
+}
+
+func init() {
+	snowCmd.AddCommand(snowUpdateCertificatesCmd)
+
+	snowUpdateCertificatesCmd.Flags().StringVarP(&suc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	snowUpdateCertificatesCmd.Flags().StringVar(&suc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	applyManagementContextFlag(snowUpdateCertificatesCmd.Flags(), &suc.managementContext)
+
+	if err := snowUpdateCertificatesCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *snowUpdateCertificatesOptions) updateCertificates(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
+	config, err := cluster.ParseConfigFromFile(o.fileName)
+	if err != nil {
+		return err
+	}
+	if config.SnowDatacenter == nil {
+		return fmt.Errorf("cluster %s doesn't use the snow provider", config.Cluster.Name)
+	}
+
+	if err := snow.SetupEksaCredentialsSecret(config); err != nil {
+		return err
+	}
+
+	kubeconfigPath, removeKubeconfig, err := managementKubeconfigPath(getKubeconfigPath(config.Cluster.ManagedBy(), o.managementKubeconfig), o.managementContext)
+	if err != nil {
+		return err
+	}
+	defer removeKubeconfig()
+	managementCluster := &types.Cluster{
+		Name:           config.Cluster.ManagedBy(),
+		KubeconfigFile: kubeconfigPath,
+	}
+
+	deps, err := dependencies.NewFactory().WithUnAuthKubeClient().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	if err := snow.NewCredentialsRotator(deps.UnAuthKubeClient).Rotate(ctx, managementCluster, config); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Snow device credentials and certificates updated")
+	return nil
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var sshCmd = &cobra.Command{
+	Use:   "ssh",
+	Short: "SSH into cluster resources",
+	Long:  "Use eksctl anywhere ssh to open ssh sessions to cluster resources",
+}
+
+func init() {
+	rootCmd.AddCommand(sshCmd)
+}
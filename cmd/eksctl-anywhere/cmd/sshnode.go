@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/nodessh"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
+)
+
+type sshNodeOptions struct {
+	fileName             string
+	sshKey               string
+	managementKubeconfig string
+	managementContext    string
+}
+
+var sno = &sshNodeOptions{}
+
+var sshNodeCmd = &cobra.Command{
+	Use:          "node <machine> -f <cluster-config-file> [flags] [-- command]",
+	Short:        "SSH into a cluster node",
+	Long:         "Use eksctl anywhere ssh node to open an ssh session to the node of a CAPI machine of the cluster, or to run a command in it. The node address is read from the machine in the management cluster and the login user from its machine config. Bottlerocket nodes are logged into through the admin container.",
+	Args:         cobra.MinimumNArgs(1),
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return sno.sshNode(cmd, args[0], args[1:])
+	},
+}
+
+func init() {
+	sshCmd.AddCommand(sshNodeCmd)
+
+	sshNodeCmd.Flags().StringVarP(&sno.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	sshNodeCmd.Flags().StringVarP(&sno.sshKey, "ssh-key", "i", "", "Private key to log into the node (default is the key generated by the CLI for the cluster)")
+	sshNodeCmd.Flags().StringVar(&sno.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	applyManagementContextFlag(sshNodeCmd.Flags(), &sno.managementContext)
+
+	if err := sshNodeCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *sshNodeOptions) sshNode(cmd *cobra.Command, machine string, command []string) error {
+	ctx := cmd.Context()
+
+	config, err := cluster.ParseConfigFromFile(o.fileName)
+	if err != nil {
+		return err
+	}
+
+	kubeconfigPath, removeKubeconfig, err := managementKubeconfigPath(getKubeconfigPath(config.Cluster.ManagedBy(), o.managementKubeconfig), o.managementContext)
+	if err != nil {
+		return err
+	}
+	defer removeKubeconfig()
+
+	client, err := kubernetes.NewRuntimeClientFactory().BuildClientFromKubeconfig(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	node, err := nodessh.NewResolver(client).Resolve(ctx, config, machine)
+	if err != nil {
+		return err
+	}
+
+	sshKey := o.sshKey
+	if sshKey == "" {
+		sshKey = common.GeneratedSSHPrivateKeyPath(config.Cluster.Name)
+	}
+
+	return node.Run(ctx, sshKey, command)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
@@ -22,6 +23,8 @@ type createSupportBundleOptions struct {
 	since                 string
 	sinceTime             string
 	bundleConfig          string
+	redactions            []string
+	logsMaxSize           string
 	hardwareFileName      string
 	tinkerbellBootstrapIP string
 }
@@ -50,6 +53,8 @@ func init() {
 	supportbundleCmd.Flags().StringVarP(&csbo.sinceTime, "since-time", "", "", "Collect pod logs after a specific datetime(RFC3339) like 2021-06-28T15:04:05Z")
 	supportbundleCmd.Flags().StringVarP(&csbo.since, "since", "", "", "Collect pod logs in the latest duration like 5s, 2m, or 3h.")
 	supportbundleCmd.Flags().StringVarP(&csbo.bundleConfig, "bundle-config", "", "", "Bundle Config file to use when generating support bundle")
+	supportbundleCmd.Flags().StringArrayVar(&csbo.redactions, "redact", nil, "Regex whose matches are masked from all the collected files, on top of the default redactors. Can be repeated")
+	supportbundleCmd.Flags().StringVar(&csbo.logsMaxSize, "logs-max-size", "", "Maximum size of the logs collected from each container, like 100Mi. No limit by default")
 	supportbundleCmd.Flags().StringVarP(&csbo.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
 	supportbundleCmd.Flags().StringVarP(&csbo.wConfig, "w-config", "w", "", "Kubeconfig file to use when creating support bundle for a workload cluster")
 	err := supportbundleCmd.MarkFlagRequired("filename")
@@ -88,8 +93,14 @@ func (csbo *createSupportBundleOptions) createBundle(ctx context.Context, since,
 		return fmt.Errorf("unable to get cluster config from file: %v", err)
 	}
 
+	logsMaxBytes, err := parseLogsMaxSize(csbo.logsMaxSize)
+	if err != nil {
+		return err
+	}
+
 	deps, err := dependencies.ForSpec(ctx, clusterSpec).
 		WithProvider(csbo.fileName, clusterSpec.Cluster, cc.skipIpCheck, csbo.hardwareFileName, false, csbo.tinkerbellBootstrapIP).
+		WithDiagnosticBundleLimits(csbo.redactions, logsMaxBytes).
 		WithDiagnosticBundleFactory().
 		Build(ctx)
 	if err != nil {
@@ -120,3 +131,20 @@ func (csbo *createSupportBundleOptions) createBundle(ctx context.Context, since,
 
 	return nil
 }
+
+// parseLogsMaxSize parses a quantity like 100Mi into bytes. An empty size means no limit.
+func parseLogsMaxSize(size string) (int64, error) {
+	if size == "" {
+		return 0, nil
+	}
+
+	q, err := resource.ParseQuantity(size)
+	if err != nil {
+		return 0, fmt.Errorf("invalid logs-max-size %s: %v", size, err)
+	}
+	if q.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid logs-max-size %s: must be positive", size)
+	}
+
+	return q.Value(), nil
+}
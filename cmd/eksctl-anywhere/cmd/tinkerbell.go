@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var tinkerbellCmd = &cobra.Command{
+	Use:   "tinkerbell",
+	Short: "Utility tinkerbell operations",
+	Long:  "Use eksctl anywhere exp tinkerbell to perform utility operations for the Tinkerbell provider",
+}
+
+var virtualHardwareCmd = &cobra.Command{
+	Use:   "virtual-hardware",
+	Short: "Manage virtual bare metal hardware",
+	Long:  "Use eksctl anywhere exp tinkerbell virtual-hardware to simulate Tinkerbell hardware with libvirt VMs and virtual BMCs",
+}
+
+func init() {
+	expCmd.AddCommand(tinkerbellCmd)
+	tinkerbellCmd.AddCommand(virtualHardwareCmd)
+}
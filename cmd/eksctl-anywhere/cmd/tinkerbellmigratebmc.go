@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+type migrateBMCCredentialsOptions struct {
+	hardwareCSVPath string
+	outputCSVPath   string
+	secretsPath     string
+}
+
+var migrateBMCCredentialsOpts = &migrateBMCCredentialsOptions{}
+
+var migrateBMCCredentialsCmd = &cobra.Command{
+	Use:   "migrate-bmc-credentials [flags]",
+	Short: "Move plaintext BMC credentials out of a hardware CSV",
+	Long: "Rewrite a hardware CSV to reference BMC credential Secrets with the bmc_secret column and " +
+		"write the Secret manifests holding the credentials it had in plaintext",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         migrateBMCCredentialsOpts.migrate,
+}
+
+func init() {
+	tinkerbellCmd.AddCommand(migrateBMCCredentialsCmd)
+
+	flags := migrateBMCCredentialsCmd.Flags()
+	flags.StringVarP(
+		&migrateBMCCredentialsOpts.hardwareCSVPath,
+		TinkerbellHardwareCSVFlagName,
+		TinkerbellHardwareCSVFlagAlias,
+		"",
+		TinkerbellHardwareCSVFlagDescription,
+	)
+	flags.StringVar(&migrateBMCCredentialsOpts.outputCSVPath, "output-csv", "", "Path to output the hardware CSV referencing the BMC Secrets")
+	flags.StringVar(&migrateBMCCredentialsOpts.secretsPath, "output-secrets", "", "Path to output the BMC Secret manifests")
+
+	for _, flag := range []string{TinkerbellHardwareCSVFlagName, "output-csv", "output-secrets"} {
+		if err := migrateBMCCredentialsCmd.MarkFlagRequired(flag); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (o *migrateBMCCredentialsOptions) migrate(cmd *cobra.Command, _ []string) error {
+	reader, err := hardware.NewNormalizedCSVReaderFromFile(o.hardwareCSVPath)
+	if err != nil {
+		return fmt.Errorf("reading hardware csv: %v", err)
+	}
+
+	catalogue := hardware.NewCatalogue()
+	migrator := hardware.NewBMCCredentialsMigrator(catalogue)
+	if err := hardware.TranslateAll(reader, migrator, hardware.NewDefaultMachineValidator()); err != nil {
+		return err
+	}
+
+	secrets, err := hardware.MarshalCatalogue(catalogue)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(o.secretsPath, secrets, 0o600); err != nil {
+		return fmt.Errorf("writing bmc secrets: %v", err)
+	}
+
+	fh, err := hardware.CreateOrStdout(o.outputCSVPath)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	writer := bufio.NewWriter(fh)
+	if err := hardware.WriteCSV(writer, migrator.Machines()); err != nil {
+		return fmt.Errorf("writing hardware csv: %v", err)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("writing hardware csv: %v", err)
+	}
+
+	logger.Info("BMC credentials migrated", "secrets", catalogue.TotalSecrets(), "hardwareCSV", o.outputCSVPath, "secretsManifest", o.secretsPath)
+	return nil
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/virtual"
+)
+
+type virtualHardwareOptions struct {
+	config     virtual.Config
+	libvirtURI string
+	outputPath string
+}
+
+var virtualHardwareOpts = &virtualHardwareOptions{}
+
+var createVirtualHardwareCmd = &cobra.Command{
+	Use:          "create [flags]",
+	Short:        "Create virtual hardware",
+	Long:         "Create libvirt VMs with a virtual BMC each and write the matching Tinkerbell hardware CSV",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         virtualHardwareOpts.create,
+}
+
+var deleteVirtualHardwareCmd = &cobra.Command{
+	Use:          "delete [flags]",
+	Short:        "Delete virtual hardware",
+	Long:         "Delete the libvirt VMs, disks and virtual BMCs created by virtual-hardware create with the same flags",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         virtualHardwareOpts.delete,
+}
+
+func init() {
+	virtualHardwareCmd.AddCommand(createVirtualHardwareCmd)
+	virtualHardwareCmd.AddCommand(deleteVirtualHardwareCmd)
+
+	applyVirtualHardwareFlags(createVirtualHardwareCmd.Flags(), virtualHardwareOpts)
+	createVirtualHardwareCmd.Flags().StringVarP(&virtualHardwareOpts.outputPath, "output", "o", "", "Path to output hardware CSV. Defaults to stdout")
+	applyVirtualHardwareFlags(deleteVirtualHardwareCmd.Flags(), virtualHardwareOpts)
+}
+
+func applyVirtualHardwareFlags(flags *pflag.FlagSet, o *virtualHardwareOptions) {
+	c := &o.config
+	flags.StringVar(&c.NamePrefix, "name-prefix", "eksa-virtual", "Prefix of the VM names and hostnames")
+	flags.IntVar(&c.ControlPlaneCount, "control-plane-count", 1, "Number of machines labeled type=cp")
+	flags.IntVar(&c.WorkerCount, "worker-count", 1, "Number of machines labeled type=worker")
+	flags.IntVar(&c.CPUs, "cpus", 2, "vCPUs of each VM")
+	flags.IntVar(&c.MemoryMiB, "memory", 8192, "Memory of each VM in MiB")
+	flags.IntVar(&c.DiskGiB, "disk-size", 40, "Disk size of each VM in GiB")
+	flags.StringVar(&c.Arch, "arch", hardware.X86_64, fmt.Sprintf("Architecture of the VMs, one of %s, %s", hardware.X86_64, hardware.AArch64))
+	flags.StringVar(&c.Network, "network", "default", "Libvirt network the VMs are attached to. It must not serve DHCP for the VM addresses")
+	flags.StringVar(&c.StoragePool, "storage-pool", "default", "Libvirt storage pool for the VM disks")
+	flags.StringVar(&c.StartIPAddress, "start-ip", "", "IP address of the first VM, the next VMs get the following addresses")
+	flags.StringVar(&c.Netmask, "netmask", "255.255.255.0", "Netmask of the VM network")
+	flags.StringVar(&c.Gateway, "gateway", "", "Gateway of the VM network")
+	flags.StringSliceVar(&c.Nameservers, "nameservers", []string{"8.8.8.8"}, "Nameservers of the VMs")
+	flags.StringVar(&c.BMCAddress, "bmc-address", "", "Host address the virtual BMCs listen on. It must be reachable from the cluster")
+	flags.IntVar(&c.BMCStartPort, "bmc-start-port", 6230, "IPMI port of the first virtual BMC, the next BMCs get the following ports")
+	flags.StringVar(&c.BMCUsername, "bmc-username", "admin", "Username of the virtual BMCs")
+	flags.StringVar(&c.BMCPassword, "bmc-password", "password", "Password of the virtual BMCs")
+	flags.StringVar(&o.libvirtURI, "libvirt-uri", executables.DefaultLibvirtURI, "Libvirt connection URI")
+}
+
+func (o *virtualHardwareOptions) simulator() *virtual.Simulator {
+	return virtual.NewSimulator(
+		executables.BuildVirshExecutable(o.libvirtURI),
+		executables.BuildVbmcExecutable(o.libvirtURI),
+		o.config,
+	)
+}
+
+func (o *virtualHardwareOptions) create(cmd *cobra.Command, _ []string) error {
+	machines, err := o.simulator().Create(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	fh, err := hardware.CreateOrStdout(o.outputPath)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(fh)
+	defer writer.Flush()
+
+	if err := hardware.WriteCSV(writer, machines); err != nil {
+		return fmt.Errorf("writing hardware csv: %v", err)
+	}
+
+	logger.V(1).Info("Virtual hardware created", "machines", len(machines))
+	return nil
+}
+
+func (o *virtualHardwareOptions) delete(cmd *cobra.Command, _ []string) error {
+	if err := o.simulator().Delete(cmd.Context()); err != nil {
+		return err
+	}
+
+	logger.Info("Virtual hardware deleted")
+	return nil
+}
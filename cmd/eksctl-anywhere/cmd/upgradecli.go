@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/cliupgrade"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifests/releases"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+type upgradeCLIOptions struct {
+	version            string
+	releaseManifestURL string
+	artifactsMirror    string
+}
+
+var ucli = &upgradeCLIOptions{}
+
+var upgradeCLICmd = &cobra.Command{
+	Use:          "cli",
+	Short:        "Upgrade the EKS Anywhere CLI",
+	Long:         "Use eksctl anywhere upgrade cli to replace the EKS Anywhere CLI binary with the one of the latest or a specific release of the EKS Anywhere release manifest",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         ucli.upgradeCLI,
+}
+
+func init() {
+	upgradeCmd.AddCommand(upgradeCLICmd)
+	upgradeCLICmd.Flags().StringVar(&ucli.version, "version", "", "EKS Anywhere release to upgrade to. Defaults to the latest release")
+	upgradeCLICmd.Flags().StringVar(&ucli.releaseManifestURL, "release-manifest-url", releases.ManifestURL(), "URL or local path of the EKS Anywhere release manifest")
+	upgradeCLICmd.Flags().StringVar(&ucli.artifactsMirror, "artifacts-mirror", "", "Base URL of a mirror of the EKS Anywhere release artifacts, like a local artifact server in air-gapped environments")
+}
+
+func (opts *upgradeCLIOptions) upgradeCLI(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	var upgraderOpts []cliupgrade.UpgraderOpt
+	if opts.artifactsMirror != "" {
+		mirror, err := url.Parse(opts.artifactsMirror)
+		if err != nil || mirror.Scheme == "" || mirror.Host == "" {
+			return fmt.Errorf("invalid --artifacts-mirror %s, must be a URL like http://10.0.0.5:8080", opts.artifactsMirror)
+		}
+		upgraderOpts = append(upgraderOpts, cliupgrade.WithArtifactsMirror(mirror))
+	}
+
+	deps, err := dependencies.NewFactory().WithFileReader().Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	upgrader := cliupgrade.NewUpgrader(deps.FileReader, upgraderOpts...)
+	release, err := upgrader.Release(opts.releaseManifestURL, opts.version)
+	if err != nil {
+		return err
+	}
+
+	current := version.Get().GitVersion
+	if release.Version == current {
+		logger.Info("EKS Anywhere CLI is already at the requested version", "version", current)
+		return nil
+	}
+
+	logger.Info("Upgrading EKS Anywhere CLI", "from", current, "to", release.Version)
+	if err := upgrader.Upgrade(release); err != nil {
+		return fmt.Errorf("upgrading EKS Anywhere CLI: %v", err)
+	}
+	logger.MarkSuccess(fmt.Sprintf("EKS Anywhere CLI upgraded to %s", release.Version))
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/timesync"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
@@ -19,6 +20,8 @@ import (
 type upgradeClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	timeSyncOptions
+	validateTimeSync      bool
 	wConfig               string
 	forceClean            bool
 	hardwareCSVPath       string
@@ -44,8 +47,11 @@ var upgradeClusterCmd = &cobra.Command{
 func init() {
 	upgradeCmd.AddCommand(upgradeClusterCmd)
 	applyClusterOptionFlags(upgradeClusterCmd.Flags(), &uc.clusterOptions)
+	applyCredentialsFlags(upgradeClusterCmd.Flags(), &uc.clusterOptions)
 	applyTimeoutFlags(upgradeClusterCmd.Flags(), &uc.timeoutOptions)
 	applyTinkerbellHardwareFlag(upgradeClusterCmd.Flags(), &uc.hardwareCSVPath)
+	applyTimeSyncFlags(upgradeClusterCmd.Flags(), &uc.timeSyncOptions)
+	upgradeClusterCmd.Flags().BoolVar(&uc.validateTimeSync, validateTimeSyncFlag, false, "Validate that the node clocks are synchronized before upgrading")
 	upgradeClusterCmd.Flags().StringVarP(&uc.wConfig, "w-config", "w", "", "Kubeconfig file to use when upgrading a workload cluster")
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 
@@ -57,6 +63,12 @@ func init() {
 func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 	ctx := cmd.Context()
 
+	removeOCIFiles, err := uc.pullOCIFiles(ctx)
+	if err != nil {
+		return err
+	}
+	defer removeOCIFiles()
+
 	clusterConfigFileExist := validations.FileExists(uc.fileName)
 	if !clusterConfigFileExist {
 		return fmt.Errorf("the cluster config file %s does not exist", uc.fileName)
@@ -76,7 +88,8 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 	if _, err := uc.commonValidations(ctx); err != nil {
 		return fmt.Errorf("common validations failed due to: %v", err)
 	}
-	clusterSpec, err := newClusterSpec(uc.clusterOptions)
+	defer uc.removeManagementKubeconfig()
+	clusterSpec, err := newClusterSpec(&uc.clusterOptions)
 	if err != nil {
 		return err
 	}
@@ -139,6 +152,7 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		ManagementCluster: managementCluster,
 		Provider:          deps.Provider,
 		CliConfig:         cliConfig,
+		TimeSyncValidator: uc.timeSyncValidator(deps.Kubectl),
 	}
 	upgradeValidations := upgradevalidations.New(validationOpts)
 
@@ -158,5 +172,19 @@ func (uc *upgradeClusterOptions) commonValidations(ctx context.Context) (cluster
 		return nil, err
 	}
 
+	if uc.validateTimeSync {
+		if err := uc.timeSyncOptions.validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	return clusterConfig, nil
 }
+
+// timeSyncValidator returns the validator of the node clocks preflight, nil unless it's enabled.
+func (uc *upgradeClusterOptions) timeSyncValidator(nodes timesync.NodeLister) validations.TimeSyncValidator {
+	if !uc.validateTimeSync {
+		return nil
+	}
+	return uc.timeSyncChecker(nodes)
+}
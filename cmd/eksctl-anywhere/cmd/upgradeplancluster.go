@@ -6,15 +6,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	capiupgrader "github.com/aws/eks-anywhere/pkg/clusterapi"
 	eksaupgrader "github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/deprecatedapis"
 	fluxupgrader "github.com/aws/eks-anywhere/pkg/gitops/flux"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
@@ -56,10 +59,13 @@ func preRunUpgradePlanCluster(cmd *cobra.Command, args []string) error {
 
 func init() {
 	upgradePlanCmd.AddCommand(upgradePlanClusterCmd)
-	upgradePlanClusterCmd.Flags().StringVarP(&uc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	upgradePlanClusterCmd.Flags().StringVarP(&uc.fileName, "filename", "f", "", "Filename or OCI artifact reference (oci://registry/repository:tag) that contains EKS-A cluster configuration")
 	upgradePlanClusterCmd.Flags().StringVar(&uc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	upgradePlanClusterCmd.Flags().StringVarP(&output, outputFlagName, "o", outputDefault, "Output format: text|json")
 	upgradePlanClusterCmd.Flags().StringVar(&uc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	applyManagementContextFlag(upgradePlanClusterCmd.Flags(), &uc.managementContext)
+	applyOCIPublicKeyFlag(upgradePlanClusterCmd.Flags(), &uc.ociPublicKey)
+	upgradePlanClusterCmd.Flags().StringVarP(&uc.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster to scan for removed API usage")
 	err := upgradePlanClusterCmd.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -67,11 +73,18 @@ func init() {
 }
 
 func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
+	removeOCIFiles, err := uc.pullOCIFiles(ctx)
+	if err != nil {
+		return err
+	}
+	defer removeOCIFiles()
+
 	if _, err := uc.commonValidations(ctx); err != nil {
 		return fmt.Errorf("common validations failed due to: %v", err)
 	}
 
-	newClusterSpec, err := newClusterSpec(uc.clusterOptions)
+	defer uc.removeManagementKubeconfig()
+	newClusterSpec, err := newClusterSpec(&uc.clusterOptions)
 	if err != nil {
 		return err
 	}
@@ -81,6 +94,7 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 		WithProvider(uc.fileName, newClusterSpec.Cluster, false, uc.hardwareCSVPath, uc.forceClean, uc.tinkerbellBootstrapIP).
 		WithGitOpsFlux(newClusterSpec.Cluster, newClusterSpec.FluxConfig, nil).
 		WithCAPIManager().
+		WithKubectl().
 		Build(ctx)
 	if err != nil {
 		return err
@@ -106,7 +120,15 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 	componentChangeDiffs.Append(capiupgrader.CapiChangeDiff(currentSpec, newClusterSpec, deps.Provider))
 	componentChangeDiffs.Append(cilium.ChangeDiff(currentSpec, newClusterSpec))
 
-	serializedDiff, err := serialize(componentChangeDiffs, output)
+	plan := &upgradePlan{
+		ChangeDiff: componentChangeDiffs,
+		RemovedAPIs: removedAPIs(
+			ctx, deps.Kubectl, getKubeconfigPath(newClusterSpec.Cluster.Name, uc.wConfig), newClusterSpec.Cluster.Spec.KubernetesVersion,
+		),
+		kubernetesVersion: newClusterSpec.Cluster.Spec.KubernetesVersion,
+	}
+
+	serializedDiff, err := serialize(plan, output)
 	if err != nil {
 		return err
 	}
@@ -116,18 +138,58 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 	return nil
 }
 
-func serialize(componentChangeDiffs *types.ChangeDiff, outputFormat string) (string, error) {
+// upgradePlan is the component changes of the upgrade along with the APIs the upgrade removes
+// that are still in use in the cluster.
+type upgradePlan struct {
+	*types.ChangeDiff
+	RemovedAPIs       []deprecatedapis.API `json:"removedApis,omitempty"`
+	kubernetesVersion anywherev1.KubernetesVersion
+}
+
+// removedAPIs scans the cluster for the usage of APIs removed in the target Kubernetes version.
+// A failed scan doesn't prevent displaying the plan.
+func removedAPIs(ctx context.Context, kubectl deprecatedapis.KubectlClient, kubeconfig string, target anywherev1.KubernetesVersion) []deprecatedapis.API {
+	apis, err := deprecatedapis.NewScanner(kubectl).Removed(ctx, kubeconfig, target)
+	if err != nil {
+		logger.Info("Warning: failed scanning the cluster for removed API usage", "error", err)
+		return nil
+	}
+	return apis
+}
+
+func serialize(plan *upgradePlan, outputFormat string) (string, error) {
 	switch outputFormat {
 	case outputText:
-		return serializeToText(componentChangeDiffs)
+		return serializeToText(plan)
 	case outputJson:
-		return serializeToJson(componentChangeDiffs)
+		return serializeToJson(plan)
 	default:
 		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
 	}
 }
 
-func serializeToText(componentChangeDiffs *types.ChangeDiff) (string, error) {
+func serializeToText(plan *upgradePlan) (string, error) {
+	components, err := serializeComponentsToText(plan.ChangeDiff)
+	if err != nil {
+		return "", err
+	}
+	if len(plan.RemovedAPIs) == 0 {
+		return components, nil
+	}
+
+	buffer := bytes.NewBufferString(components)
+	if !strings.HasSuffix(components, "\n") {
+		buffer.WriteString("\n")
+	}
+	fmt.Fprintf(buffer, "\nAPIs removed in Kubernetes %s still in use, migrate their clients before upgrading:\n", plan.kubernetesVersion)
+	for _, api := range plan.RemovedAPIs {
+		fmt.Fprintf(buffer, "  %s\n", api)
+	}
+
+	return buffer.String(), nil
+}
+
+func serializeComponentsToText(componentChangeDiffs *types.ChangeDiff) (string, error) {
 	if componentChangeDiffs == nil {
 		return "All the components are up to date with the latest versions", nil
 	}
@@ -145,12 +207,12 @@ func serializeToText(componentChangeDiffs *types.ChangeDiff) (string, error) {
 	return buffer.String(), nil
 }
 
-func serializeToJson(componentChangeDiffs *types.ChangeDiff) (string, error) {
-	if componentChangeDiffs == nil {
-		componentChangeDiffs = &types.ChangeDiff{ComponentReports: []types.ComponentChangeDiff{}}
+func serializeToJson(plan *upgradePlan) (string, error) {
+	if plan.ChangeDiff == nil {
+		plan.ChangeDiff = &types.ChangeDiff{ComponentReports: []types.ComponentChangeDiff{}}
 	}
 
-	jsonDiff, err := json.Marshal(componentChangeDiffs)
+	jsonDiff, err := json.Marshal(plan)
 	if err != nil {
 		return "", fmt.Errorf("failed serializing the components diff to json: %v", err)
 	}
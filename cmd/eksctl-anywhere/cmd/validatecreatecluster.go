@@ -39,6 +39,7 @@ func init() {
 	applyTinkerbellHardwareFlag(validateCreateClusterCmd.Flags(), &valOpt.hardwareCSVPath)
 	validateCreateClusterCmd.Flags().StringVarP(&valOpt.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
 	validateCreateClusterCmd.Flags().StringVar(&valOpt.tinkerbellBootstrapIP, "tinkerbell-bootstrap-ip", "", "Override the local tinkerbell IP in the bootstrap cluster")
+	applyCredentialsFlags(validateCreateClusterCmd.Flags(), &valOpt.clusterOptions)
 
 	if err := validateCreateClusterCmd.MarkFlagRequired("filename"); err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -53,6 +54,10 @@ func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []str
 		return err
 	}
 
+	if err := valOpt.loadCredentials(clusterSpec); err != nil {
+		return err
+	}
+
 	if clusterSpec.Config.Cluster.Spec.DatacenterRef.Kind == v1alpha1.TinkerbellDatacenterKind {
 		if err := checkTinkerbellFlags(cmd.Flags(), valOpt.hardwareCSVPath, 0); err != nil {
 			return err
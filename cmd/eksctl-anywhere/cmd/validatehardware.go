@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+type validateHardwareOptions struct {
+	fileName        string
+	hardwareCSVPath string
+	skipBMCCheck    bool
+}
+
+var valHardwareOpts = &validateHardwareOptions{}
+
+var validateHardwareCmd = &cobra.Command{
+	Use:          "hardware --hardware-csv <hardware-csv-file> [flags]",
+	Short:        "Validate hardware",
+	Long:         "Use eksctl anywhere exp validate hardware to validate a Tinkerbell hardware CSV and print a per-row report",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         valHardwareOpts.validateHardware,
+}
+
+func init() {
+	validateCmd.AddCommand(validateHardwareCmd)
+	applyTinkerbellHardwareFlag(validateHardwareCmd.Flags(), &valHardwareOpts.hardwareCSVPath)
+	validateHardwareCmd.Flags().StringVarP(&valHardwareOpts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration used to check hardware selector coverage")
+	validateHardwareCmd.Flags().BoolVar(&valHardwareOpts.skipBMCCheck, "skip-bmc-check", false, "Skip checking BMC reachability")
+
+	if err := validateHardwareCmd.MarkFlagRequired(TinkerbellHardwareCSVFlagName); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (opts *validateHardwareOptions) validateHardware(cmd *cobra.Command, _ []string) error {
+	reader, err := hardware.NewNormalizedCSVReaderFromFile(opts.hardwareCSVPath)
+	if err != nil {
+		return fmt.Errorf("reading csv: %v", err)
+	}
+
+	assertions := []hardware.MachineAssertion{
+		hardware.StaticMachineAssertions(),
+		hardware.UniqueIPAddress(),
+		hardware.UniqueMACAddress(),
+		hardware.UniqueHostnames(),
+		hardware.UniqueBMCIPAddress(),
+	}
+	if !opts.skipBMCCheck {
+		assertions = append(assertions, hardware.BMCReachable(&networkutils.DefaultNetClient{}))
+	}
+
+	report, err := hardware.ValidateAll(reader, assertions...)
+	if err != nil {
+		return err
+	}
+
+	if opts.fileName != "" {
+		if err := addSelectorCoverage(report, opts.fileName); err != nil {
+			return err
+		}
+	}
+
+	if err := report.Write(os.Stdout); err != nil {
+		return err
+	}
+
+	if !report.Valid() {
+		return errors.New("hardware validation failed")
+	}
+
+	return nil
+}
+
+// addSelectorCoverage adds coverage for each hardware selector referenced by the cluster
+// configuration in fileName to report.
+func addSelectorCoverage(report *hardware.Report, fileName string) error {
+	clusterConfig, err := v1alpha1.GetAndValidateClusterConfig(fileName)
+	if err != nil {
+		return err
+	}
+
+	machineConfigs, err := v1alpha1.GetTinkerbellMachineConfigs(fileName)
+	if err != nil {
+		return err
+	}
+
+	add := func(ref *v1alpha1.Ref, count int) error {
+		if ref == nil {
+			return nil
+		}
+		machineConfig, ok := machineConfigs[ref.Name]
+		if !ok {
+			return fmt.Errorf("machine config %v not found", ref.Name)
+		}
+		report.AddSelectorCoverage(ref.Name, machineConfig.Spec.HardwareSelector, count)
+		return nil
+	}
+
+	cp := clusterConfig.Spec.ControlPlaneConfiguration
+	if err := add(cp.MachineGroupRef, cp.Count); err != nil {
+		return err
+	}
+
+	if etcd := clusterConfig.Spec.ExternalEtcdConfiguration; etcd != nil {
+		if err := add(etcd.MachineGroupRef, etcd.Count); err != nil {
+			return err
+		}
+	}
+
+	for _, group := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		count := 0
+		if group.Count != nil {
+			count = *group.Count
+		}
+		if err := add(group.MachineGroupRef, count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
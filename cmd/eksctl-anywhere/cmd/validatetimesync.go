@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type validateTimeSyncOptions struct {
+	timeSyncOptions
+	fileName string
+	wConfig  string
+}
+
+var valTimeSyncOpts = &validateTimeSyncOptions{}
+
+var validateTimeSyncCmd = &cobra.Command{
+	Use:          "time-sync -f <cluster-config-file> [flags]",
+	Short:        "Validate node clocks",
+	Long:         "Use eksctl anywhere exp validate time-sync to compare the clocks of the cluster nodes against an NTP server and print a per-node report",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         valTimeSyncOpts.validateTimeSync,
+}
+
+func init() {
+	validateCmd.AddCommand(validateTimeSyncCmd)
+	validateTimeSyncCmd.Flags().StringVarP(&valTimeSyncOpts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	validateTimeSyncCmd.Flags().StringVarP(&valTimeSyncOpts.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster to validate")
+	applyTimeSyncFlags(validateTimeSyncCmd.Flags(), &valTimeSyncOpts.timeSyncOptions)
+
+	if err := validateTimeSyncCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (opts *validateTimeSyncOptions) validateTimeSync(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	clusterConfig, err := commonValidation(ctx, opts.fileName)
+	if err != nil {
+		return err
+	}
+
+	kubeconfigPath := getKubeconfigPath(clusterConfig.Name, opts.wConfig)
+	if err := kubeconfig.ValidateFilename(kubeconfigPath); err != nil {
+		return err
+	}
+
+	if err := opts.timeSyncOptions.validate(); err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(kubeconfigPath)).
+		WithExecutableBuilder().
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	report, err := opts.timeSyncChecker(deps.Kubectl).Check(ctx, kubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	if err := report.Write(os.Stdout); err != nil {
+		return err
+	}
+
+	if !report.Valid() {
+		return errors.New("node clocks are not synchronized")
+	}
+
+	return nil
+}
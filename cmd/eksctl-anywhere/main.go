@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -12,11 +13,15 @@ import (
 )
 
 func main() {
+	ctx, cancel := context.WithCancel(context.Background())
 	sigChannel := make(chan os.Signal, 1)
 	signal.Notify(sigChannel, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-sigChannel
 		logger.Info("Warning: Terminating this operation may leave the cluster in an irrecoverable state")
+		logger.Info("Stopping running commands, interrupt again to exit immediately")
+		cancel()
+		<-sigChannel
 		os.Exit(-1)
 	}()
 	if eksctl.Enabled() {
@@ -26,7 +31,7 @@ func main() {
 			os.Exit(-1)
 		}
 	}
-	if cmd.Execute() == nil {
+	if cmd.ExecuteContext(ctx) == nil {
 		os.Exit(0)
 	}
 	os.Exit(-1)
@@ -23,6 +23,9 @@ cat << EOF > ${INTEGRATION_TEST_INFRA_CONFIG}
 ec2:
   amiId: ${INTEGRATION_TEST_AL2_AMI_ID}
   subnetId: ${INTEGRATION_TEST_SUBNET_ID}
+  instanceType: ${INTEGRATION_TEST_INSTANCE_TYPE}
+  useSpot: ${INTEGRATION_TEST_USE_SPOT:-false}
+  maxSpotPrice: "${INTEGRATION_TEST_MAX_SPOT_PRICE}"
 
 vSphere:
   url: ${TEST_RUNNER_GOVC_URL}
@@ -34,4 +37,9 @@ vSphere:
   resourcePool: ${TEST_RUNNER_GOVC_RESOURCE_POOL}
   network: ${TEST_RUNNER_GOVC_NETWORK}
   folder: ${TEST_RUNNER_GOVC_FOLDER}
+  templateUrl: ${TEST_RUNNER_GOVC_TEMPLATE_URL}
+  templateChecksum: ${TEST_RUNNER_GOVC_TEMPLATE_CHECKSUM}
+
+tinkerbell:
+  instanceIds: [${TEST_RUNNER_TINKERBELL_INSTANCE_IDS}]
 EOF
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/internal/test/e2e"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	ttlFlagName    = "ttl"
+	dryRunFlagName = "dry-run"
+)
+
+var cleanUpJanitorCmd = &cobra.Command{
+	Use:          "janitor",
+	Short:        "Decommission leaked e2e test runners",
+	Long:         "Decommission the e2e test runners older than a ttl, with their ssm registrations and cloudwatch outputs, left behind by runs that failed to clean up",
+	SilenceUsage: true,
+	PreRun:       preRunCleanUpJanitorSetup,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := runJanitor(cmd.Context())
+		if err != nil {
+			logger.Fatal(err, "Failed to decommission leaked e2e test runners")
+		}
+		return nil
+	},
+}
+
+func preRunCleanUpJanitorSetup(cmd *cobra.Command, args []string) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+}
+
+var requiredJanitorCleanUpFlags = []string{instanceConfigFlagName}
+
+func init() {
+	cleanUpInstancesCmd.AddCommand(cleanUpJanitorCmd)
+	cleanUpJanitorCmd.Flags().StringP(instanceConfigFlagName, "c", "", "File path to the instance-config.yml config")
+	cleanUpJanitorCmd.Flags().Duration(ttlFlagName, 24*time.Hour, "Age after which a test runner is considered leaked, it must be longer than the longest e2e run")
+	cleanUpJanitorCmd.Flags().Bool(dryRunFlagName, false, "Only list the leaked resources without decommissioning them")
+
+	for _, flag := range requiredJanitorCleanUpFlags {
+		if err := cleanUpJanitorCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag %s as required: %v", flag, err)
+		}
+	}
+}
+
+func runJanitor(ctx context.Context) error {
+	conf := e2e.JanitorConf{
+		TestInstanceConfigFile: viper.GetString(instanceConfigFlagName),
+		TTL:                    viper.GetDuration(ttlFlagName),
+		DryRun:                 viper.GetBool(dryRunFlagName),
+		Logger:                 logger.Get(),
+	}
+
+	if err := e2e.RunJanitor(conf); err != nil {
+		return fmt.Errorf("running e2e janitor: %v", err)
+	}
+
+	return nil
+}
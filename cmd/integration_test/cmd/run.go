@@ -27,6 +27,7 @@ const (
 	branchNameFlagName         = "branch-name"
 	instanceConfigFlagName     = "instance-config"
 	baremetalBranchFlagName    = "baremetal-branch"
+	retainFailedFlagName       = "retain-failed-instances"
 )
 
 var runE2ECmd = &cobra.Command{
@@ -63,10 +64,11 @@ func init() {
 	runE2ECmd.Flags().StringP(instanceProfileFlagName, "i", "", "IAM instance profile name to attach to ssm instances")
 	runE2ECmd.Flags().StringP(regexFlagName, "r", "", "Run only those tests and examples matching the regular expression. Equivalent to go test -run")
 	runE2ECmd.Flags().IntP(maxInstancesFlagName, "m", 1, "Run tests in parallel on same instance within the max EC2 instance count")
-	runE2ECmd.Flags().IntP(maxConcurrentTestsFlagName, "p", 1, "Maximum number of parallel tests that can be run at a time")
+	runE2ECmd.Flags().IntP(maxConcurrentTestsFlagName, "p", 1, "Maximum number of test runners that can run tests at a time")
 	runE2ECmd.Flags().StringSlice(skipFlagName, nil, "List of tests to skip")
 	runE2ECmd.Flags().Bool(bundlesOverrideFlagName, false, "Flag to indicate if the tests should run with a bundles override")
 	runE2ECmd.Flags().Bool(cleanupVmsFlagName, false, "Flag to indicate if VSphere VMs should be cleaned up automatically as tests complete")
+	runE2ECmd.Flags().Bool(retainFailedFlagName, false, "Flag to indicate if the ec2 test runners of failed tests should be kept for debugging. The cleanup janitor decommissions them after its ttl")
 	runE2ECmd.Flags().String(testReportFolderFlagName, "", "Folder destination for JUnit tests reports")
 	runE2ECmd.Flags().String(branchNameFlagName, "main", "EKS-A origin branch from where the tests are being run")
 	runE2ECmd.Flags().String(baremetalBranchFlagName, "main", "Branch for baremetal tests to run on")
@@ -89,6 +91,7 @@ func runE2E(ctx context.Context) error {
 	testsToSkip := viper.GetStringSlice(skipFlagName)
 	bundlesOverride := viper.GetBool(bundlesOverrideFlagName)
 	cleanupVms := viper.GetBool(cleanupVmsFlagName)
+	retainFailedInstances := viper.GetBool(retainFailedFlagName)
 	testReportFolder := viper.GetString(testReportFolderFlagName)
 	branchName := viper.GetString(branchNameFlagName)
 	baremetalBranchName := viper.GetString(baremetalBranchFlagName)
@@ -103,6 +106,7 @@ func runE2E(ctx context.Context) error {
 		TestsToSkip:            testsToSkip,
 		BundlesOverride:        bundlesOverride,
 		CleanupVms:             cleanupVms,
+		RetainFailedInstances:  retainFailedInstances,
 		TestReportFolder:       testReportFolder,
 		BranchName:             branchName,
 		TestInstanceConfigFile: instanceConfigFile,
@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+)
+
+// certificateExpiryResyncPeriod is how often the certificates of a cluster are inspected.
+const certificateExpiryResyncPeriod = time.Hour
+
+var certificateDaysToExpiry = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "eksa_cluster_certificate_days_to_expiry",
+		Help: "Number of full days left before a control plane or etcd certificate of a cluster expires.",
+	},
+	[]string{"cluster", "namespace", "component", "certificate", "machine"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(certificateDaysToExpiry)
+}
+
+// CertificateInspector reads the expiry of the certificates of a cluster.
+type CertificateInspector interface {
+	Inspect(ctx context.Context, cluster *anywherev1.Cluster) ([]certificates.Certificate, error)
+}
+
+// CertificateExpiryReconciler publishes the expiry of the control plane and etcd certificates of clusters
+// in their status and as metrics, and emits warning events for the ones expiring within a threshold.
+type CertificateExpiryReconciler struct {
+	client    client.Client
+	log       logr.Logger
+	inspector CertificateInspector
+	recorder  record.EventRecorder
+	threshold time.Duration
+
+	mu sync.Mutex
+	// series tracks the metric labels set for each cluster, to delete the ones of replaced machines.
+	series map[string][]prometheus.Labels
+}
+
+func NewCertificateExpiryReconciler(client client.Client, log logr.Logger, inspector CertificateInspector, recorder record.EventRecorder, threshold time.Duration) *CertificateExpiryReconciler {
+	return &CertificateExpiryReconciler{
+		client:    client,
+		log:       log,
+		inspector: inspector,
+		recorder:  recorder,
+		threshold: threshold,
+		series:    map[string][]prometheus.Labels{},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// Only spec changes trigger a new inspection, status updates like the one of this controller
+// don't, and the rest of the inspections come from the hourly requeue.
+func (r *CertificateExpiryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("certificateexpiry").
+		For(&anywherev1.Cluster{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
+
+func (r *CertificateExpiryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("cluster", req.NamespacedName)
+
+	cluster := &anywherev1.Cluster{}
+	if err := r.client.Get(ctx, req.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.setMetrics(req.String(), nil)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !cluster.DeletionTimestamp.IsZero() {
+		r.setMetrics(req.String(), nil)
+		return ctrl.Result{}, nil
+	}
+
+	certs, err := r.inspector.Inspect(ctx, cluster)
+	if err != nil {
+		if len(certs) == 0 {
+			return ctrl.Result{}, fmt.Errorf("inspecting certificates of cluster %s: %v", cluster.Name, err)
+		}
+		log.Error(err, "Failed inspecting some certificates")
+	}
+
+	now := time.Now()
+	statuses := make([]anywherev1.CertificateStatus, 0, len(certs))
+	labels := make([]prometheus.Labels, 0, len(certs))
+	for _, c := range certs {
+		status := anywherev1.CertificateStatus{
+			Component:    c.Component,
+			Name:         c.Name,
+			Machine:      c.Machine,
+			ExpiresAt:    metav1.NewTime(c.NotAfter.UTC().Truncate(time.Second)),
+			DaysToExpiry: c.DaysToExpiry(now),
+		}
+		statuses = append(statuses, status)
+		labels = append(labels, prometheus.Labels{
+			"cluster":     cluster.Name,
+			"namespace":   cluster.Namespace,
+			"component":   c.Component,
+			"certificate": c.Name,
+			"machine":     c.Machine,
+		})
+		certificateDaysToExpiry.With(labels[len(labels)-1]).Set(float64(status.DaysToExpiry))
+
+		if c.NotAfter.Sub(now) < r.threshold {
+			log.Info("Certificate is about to expire", "certificate", c.Name, "machine", c.Machine, "expiry", status.ExpiresAt)
+			r.recorder.Eventf(cluster, corev1.EventTypeWarning, "CertificateExpiring",
+				"%s certificate %s expires on %s, %d days left", c.Component, certificateDescription(c), status.ExpiresAt.Format(time.RFC3339), status.DaysToExpiry)
+		}
+	}
+	r.setMetrics(req.String(), labels)
+
+	if !reflect.DeepEqual(cluster.Status.Certificates, statuses) {
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Status.Certificates = statuses
+		if err := r.client.Status().Patch(ctx, cluster, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("patching certificates status of cluster %s: %v", cluster.Name, err)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: certificateExpiryResyncPeriod}, nil
+}
+
+// setMetrics records the metric series set for a cluster and deletes the ones that are not set anymore.
+func (r *CertificateExpiryReconciler) setMetrics(key string, labels []prometheus.Labels) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, previous := range r.series[key] {
+		if !containsLabels(labels, previous) {
+			certificateDaysToExpiry.Delete(previous)
+		}
+	}
+
+	if len(labels) == 0 {
+		delete(r.series, key)
+		return
+	}
+	r.series[key] = labels
+}
+
+func certificateDescription(c certificates.Certificate) string {
+	if c.Machine == "" {
+		return c.Name
+	}
+	return fmt.Sprintf("%s of machine %s", c.Name, c.Machine)
+}
+
+func containsLabels(all []prometheus.Labels, l prometheus.Labels) bool {
+	for _, a := range all {
+		if reflect.DeepEqual(a, l) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/controllers/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+)
+
+func TestCertificateExpiryReconcilerSetupWithManager(t *testing.T) {
+	client := env.Client()
+	r := controllers.NewCertificateExpiryReconciler(client, logf.Log, nil, record.NewFakeRecorder(10), time.Hour)
+
+	g := NewWithT(t)
+	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
+}
+
+func TestCertificateExpiryReconcilerReconcile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"}}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+	inspector := mocks.NewMockCertificateInspector(gomock.NewController(t))
+	recorder := record.NewFakeRecorder(10)
+	r := controllers.NewCertificateExpiryReconciler(cl, logf.Log, inspector, recorder, 30*24*time.Hour)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	now := time.Now()
+	inspector.EXPECT().Inspect(ctx, gomock.AssignableToTypeOf(cluster)).Return([]certificates.Certificate{
+		{Component: "control-plane", Name: "kube-apiserver", Machine: "workload-cp-1", NotAfter: now.Add(10*24*time.Hour + time.Hour)},
+		{Component: "etcd", Name: "apiserver-etcd-client", NotAfter: now.Add(200*24*time.Hour + time.Hour)},
+	}, errors.New("reading etcd-server certificate of machine workload-etcd-1: connection refused"))
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(time.Hour))
+
+	got := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, req.NamespacedName, got)).To(Succeed())
+	g.Expect(got.Status.Certificates).To(HaveLen(2))
+	g.Expect(got.Status.Certificates[0].Machine).To(Equal("workload-cp-1"))
+	g.Expect(got.Status.Certificates[0].DaysToExpiry).To(Equal(10))
+	g.Expect(got.Status.Certificates[1].DaysToExpiry).To(Equal(200))
+
+	g.Expect(recorder.Events).To(HaveLen(1))
+	g.Expect(<-recorder.Events).To(HavePrefix("Warning CertificateExpiring control-plane certificate kube-apiserver of machine workload-cp-1 expires on"))
+
+	metric := `
+		# HELP eksa_cluster_certificate_days_to_expiry Number of full days left before a control plane or etcd certificate of a cluster expires.
+		# TYPE eksa_cluster_certificate_days_to_expiry gauge
+		eksa_cluster_certificate_days_to_expiry{certificate="apiserver-etcd-client",cluster="workload",component="etcd",machine="",namespace="default"} 200
+		eksa_cluster_certificate_days_to_expiry{certificate="kube-apiserver",cluster="workload",component="control-plane",machine="workload-cp-1",namespace="default"} 10
+	`
+	g.Expect(testutil.GatherAndCompare(metrics.Registry, strings.NewReader(metric), "eksa_cluster_certificate_days_to_expiry")).To(Succeed())
+
+	g.Expect(cl.Delete(ctx, cluster)).To(Succeed())
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(testutil.GatherAndCompare(metrics.Registry, strings.NewReader(""), "eksa_cluster_certificate_days_to_expiry")).To(Succeed())
+}
+
+func TestCertificateExpiryReconcilerReconcileInspectError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"}}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+	inspector := mocks.NewMockCertificateInspector(gomock.NewController(t))
+	r := controllers.NewCertificateExpiryReconciler(cl, logf.Log, inspector, record.NewFakeRecorder(10), time.Hour)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	inspector.EXPECT().Inspect(ctx, gomock.Any()).Return(nil, errors.New("getting secret workload-ca: not found"))
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).To(MatchError("inspecting certificates of cluster workload: getting secret workload-ca: not found"))
+}
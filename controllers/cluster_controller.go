@@ -2,11 +2,14 @@ package controllers
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -20,7 +23,9 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterhistory"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
 	"github.com/aws/eks-anywhere/pkg/controller/handlers"
@@ -36,6 +41,7 @@ type ClusterReconciler struct {
 	client                     client.Client
 	log                        logr.Logger
 	providerReconcilerRegistry ProviderClusterReconcilerRegistry
+	history                    *clusterhistory.Recorder
 }
 
 type ProviderClusterReconcilerRegistry interface {
@@ -47,6 +53,7 @@ func NewClusterReconciler(client client.Client, log logr.Logger, registry Provid
 		client:                     client,
 		log:                        log,
 		providerReconcilerRegistry: registry,
+		history:                    clusterhistory.NewRecorder(client, time.Now),
 	}
 }
 
@@ -80,6 +87,10 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &anywherev1.VSphereMachineConfig{}},
 			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
 		).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(handlers.VSphereCredentialsToClusters(r.client, r.log)),
+		).
 		Watches(
 			&source.Kind{Type: &anywherev1.SnowDatacenterConfig{}},
 			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
@@ -91,7 +102,7 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;snowmachineconfigs;vspheredatacenterconfigs;vspheremachineconfigs;dockerdatacenterconfigs;bundles;awsiamconfigs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;snowmachineconfigs;snowippools;vspheredatacenterconfigs;vspheremachineconfigs;dockerdatacenterconfigs;tinkerbelldatacenterconfigs;tinkerbellmachineconfigs;tinkerbelltemplateconfigs;bundles;awsiamconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=oidcconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/status;snowmachineconfigs/status;vspheredatacenterconfigs/status;vspheremachineconfigs/status;dockerdatacenterconfigs/status;bundles/status;awsiamconfigs/status,verbs=;get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/finalizers;snowmachineconfigs/finalizers;vspheredatacenterconfigs/finalizers;vspheremachineconfigs/finalizers;dockerdatacenterconfigs/finalizers;bundles/finalizers;awsiamconfigs/finalizers,verbs=get;list;watch;create;update;patch;delete
@@ -99,7 +110,10 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=test,resources=test,verbs=get;list;watch;create;update;patch;delete;kill
 // +kubebuilder:rbac:groups=distro.eks.amazonaws.com,resources=releases,verbs=get;list;watch
-// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awssnowclusters;awssnowmachinetemplates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awssnowclusters;awssnowmachinetemplates;awssnowippools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=tinkerbellclusters;tinkerbellmachinetemplates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=tinkerbell.org,resources=hardware,verbs=get;list;watch
+// +kubebuilder:rbac:groups=bmc.tinkerbell.org,resources=baseboardmanagements,verbs=get;list;watch
 func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := r.log.WithValues("cluster", req.NamespacedName)
 	// Fetch the Cluster object
@@ -139,6 +153,11 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 		return ctrl.Result{}, nil
 	}
 
+	// The history is an audit aid, failing to record it shouldn't block the reconciliation.
+	if err := r.history.Record(ctx, cluster, clusterhistory.SourceController, clusterhistory.Actor(cluster)); err != nil {
+		log.Error(err, "Failed to record the change in the cluster history")
+	}
+
 	if cluster.IsSelfManaged() {
 		log.Info("Ignoring self managed cluster")
 		return ctrl.Result{}, nil
@@ -166,13 +185,65 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *anywherev1.Cluster, log logr.Logger) (ctrl.Result, error) {
 	clusterProviderReconciler := r.providerReconcilerRegistry.Get(cluster.Spec.DatacenterRef.Kind)
 
+	ctx, changes := controller.WithObjectChanges(ctx)
 	reconcileResult, err := clusterProviderReconciler.Reconcile(ctx, log, cluster)
+	// Objects changed before a failure are part of the summary too.
+	if summaryErr := r.updateLastAppliedChanges(ctx, cluster, changes); summaryErr != nil {
+		log.Error(summaryErr, "Failed to summarize the changes applied to the cluster")
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 	return reconcileResult.ToCtrlResult(), nil
 }
 
+// updateLastAppliedChanges adds the object changes of a reconciliation to the summary of the changes applied
+// for the current generation of the cluster, starting a new summary when the generation changes.
+func (r *ClusterReconciler) updateLastAppliedChanges(ctx context.Context, cluster *anywherev1.Cluster, changes *controller.ObjectChanges) error {
+	summary := cluster.Status.LastAppliedChanges
+	if summary == nil || summary.Generation != cluster.Generation {
+		entry, err := r.history.LastEntry(ctx, cluster)
+		if err != nil {
+			return err
+		}
+		summary = &anywherev1.AppliedChanges{
+			Generation: cluster.Generation,
+			Time:       metav1.Now(),
+		}
+		if entry != nil && entry.Generation == cluster.Generation {
+			summary.ChangedFields = entry.Changes
+		}
+		cluster.Status.LastAppliedChanges = summary
+	}
+
+	if changes.Empty() {
+		return nil
+	}
+
+	summary.Time = metav1.Now()
+	summary.Created = mergeObjectNames(summary.Created, changes.CreatedObjects())
+	summary.Updated = mergeObjectNames(summary.Updated, changes.UpdatedObjects())
+	summary.Deleted = mergeObjectNames(summary.Deleted, changes.DeletedObjects())
+	return nil
+}
+
+// mergeObjectNames returns the sorted union of two lists of object names.
+func mergeObjectNames(a, b []string) []string {
+	if len(b) == 0 {
+		return a
+	}
+	names := map[string]struct{}{}
+	for _, n := range append(append([]string{}, a...), b...) {
+		names[n] = struct{}{}
+	}
+	merged := make([]string, 0, len(names))
+	for n := range names {
+		merged = append(merged, n)
+	}
+	sort.Strings(merged)
+	return merged
+}
+
 func (r *ClusterReconciler) reconcileDelete(ctx context.Context, cluster *anywherev1.Cluster) (ctrl.Result, error) {
 	capiCluster := &clusterv1.Cluster{}
 	capiClusterName := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: cluster.Name}
@@ -0,0 +1,126 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/internal/test/envtest"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/providers/fake"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+type fakeProviderTest struct {
+	*WithT
+	ctx        context.Context
+	now        time.Time
+	cluster    *anywherev1.Cluster
+	reconciler *controllers.ClusterReconciler
+}
+
+func newFakeProviderTest(t *testing.T, config fake.Config) *fakeProviderTest {
+	ctx := context.Background()
+	c := env.Client()
+	namespace := env.CreateNamespaceForTest(ctx, t)
+	tt := &fakeProviderTest{
+		WithT: NewWithT(t),
+		ctx:   ctx,
+		now:   time.Now(),
+	}
+
+	bundlesRef := &anywherev1.BundlesRef{Name: "bundles-1", Namespace: namespace, APIVersion: "anywhere.eks.amazonaws.com/v1alpha1"}
+	managementCluster := fakeProviderCluster(namespace, "management-cluster", func(c *anywherev1.Cluster) {
+		c.Spec.BundlesRef = bundlesRef
+	})
+	datacenter := &anywherev1.DockerDatacenterConfig{
+		TypeMeta:   metav1.TypeMeta{Kind: anywherev1.DockerDatacenterKind, APIVersion: anywherev1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "datacenter", Namespace: namespace},
+	}
+	tt.cluster = fakeProviderCluster(namespace, "workload-cluster", func(c *anywherev1.Cluster) {
+		c.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: managementCluster.Name}
+		c.Spec.BundlesRef = bundlesRef
+		c.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.DockerDatacenterKind, Name: datacenter.Name}
+	})
+	envtest.CreateObjs(ctx, t, c, managementCluster, datacenter, tt.cluster)
+
+	provider := fake.NewReconciler(config, func() time.Time { return tt.now })
+	registry := clusters.NewProviderClusterReconcilerRegistryBuilder().
+		Add(anywherev1.DockerDatacenterKind, provider).
+		Build()
+	tt.reconciler = controllers.NewClusterReconciler(c, nullLog(), &registry)
+
+	return tt
+}
+
+func (tt *fakeProviderTest) reconcile() ctrl.Result {
+	result, err := tt.reconciler.Reconcile(tt.ctx, clusterRequest(tt.cluster))
+	tt.Expect(err).NotTo(HaveOccurred())
+	return result
+}
+
+func (tt *fakeProviderTest) getCluster() *anywherev1.Cluster {
+	cluster := &anywherev1.Cluster{}
+	tt.Expect(env.APIReader().Get(tt.ctx, client.ObjectKeyFromObject(tt.cluster), cluster)).To(Succeed())
+	return cluster
+}
+
+func TestClusterReconcilerFakeProviderProvisionsMachines(t *testing.T) {
+	tt := newFakeProviderTest(t, fake.Config{MachineLatency: time.Minute})
+
+	tt.Expect(tt.reconcile()).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+	cluster := tt.getCluster()
+	tt.Expect(conditions.IsFalse(cluster, fake.MachinesProvisionedCondition)).To(BeTrue())
+	tt.Expect(conditions.GetMessage(cluster, fake.MachinesProvisionedCondition)).To(Equal("0 of 3 machines provisioned"))
+
+	tt.now = tt.now.Add(time.Minute)
+	tt.Expect(tt.reconcile()).To(Equal(ctrl.Result{}))
+	cluster = tt.getCluster()
+	tt.Expect(conditions.IsTrue(cluster, fake.MachinesProvisionedCondition)).To(BeTrue())
+	tt.Expect(cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestClusterReconcilerFakeProviderMachineFailure(t *testing.T) {
+	tt := newFakeProviderTest(t, fake.Config{FailedMachines: []string{"workload-cluster-control-plane-0"}})
+
+	tt.Expect(tt.reconcile()).To(Equal(ctrl.Result{}))
+	cluster := tt.getCluster()
+	tt.Expect(cluster.Status.FailureMessage).To(Equal(ptr.String("machine workload-cluster-control-plane-0 failed to provision")))
+	tt.Expect(conditions.IsFalse(cluster, fake.MachinesProvisionedCondition)).To(BeTrue())
+}
+
+func fakeProviderCluster(namespace, name string, opts ...func(*anywherev1.Cluster)) *anywherev1.Cluster {
+	c := &anywherev1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.ClusterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: "1.23",
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				Pods:     anywherev1.Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+				Services: anywherev1.Services{CidrBlocks: []string{"10.96.0.0/12"}},
+			},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{Count: 1},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: ptr.Int(2)},
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
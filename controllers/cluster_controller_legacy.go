@@ -16,6 +16,7 @@ import (
 
 	"github.com/aws/eks-anywhere/controllers/resource"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterhistory"
 )
 
 // ClusterReconcilerLegacy reconciles a Cluster object.
@@ -25,6 +26,7 @@ type ClusterReconcilerLegacy struct {
 	Scheme          *runtime.Scheme
 	reconcilers     []resource.Reconciler
 	resourceFetcher resource.ResourceFetcher
+	history         *clusterhistory.Recorder
 }
 
 func NewClusterReconcilerLegacy(client client.Client, log logr.Logger, scheme *runtime.Scheme) *ClusterReconcilerLegacy {
@@ -40,10 +42,11 @@ func NewClusterReconcilerLegacy(client client.Client, log logr.Logger, scheme *r
 				log),
 		},
 		resourceFetcher: resource.NewCAPIResourceFetcher(client, log),
+		history:         clusterhistory.NewRecorder(client, time.Now),
 	}
 }
 
-//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;snowmachineconfigs;vspheredatacenterconfigs;vspheremachineconfigs;cloudstackdatacenterconfigs;cloudstackmachineconfigs;dockerdatacenterconfigs;nutanixdatacenterconfigs;nutanixmachineconfigs;bundles;awsiamconfigs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;snowmachineconfigs;vspheredatacenterconfigs;vspheremachineconfigs;cloudstackdatacenterconfigs;cloudstackmachineconfigs;dockerdatacenterconfigs;nutanixdatacenterconfigs;nutanixmachineconfigs;tinkerbelldatacenterconfigs;tinkerbellmachineconfigs;tinkerbelltemplateconfigs;bundles;awsiamconfigs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=oidcconfigs,verbs=get;list;watch
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/status;snowmachineconfigs/status;vspheredatacenterconfigs/status;vspheremachineconfigs/status;cloudstackdatacenterconfigs/status;cloudstackmachineconfigs/status;dockerdatacenterconfigs/status;bundles/status;awsiamconfigs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/finalizers;snowmachineconfigs/finalizers;vspheredatacenterconfigs/finalizers;vspheremachineconfigs/finalizers;cloudstackdatacenterconfigs/finalizers;cloudstackmachineconfigs/finalizers;dockerdatacenterconfigs/finalizers;bundles/finalizers;awsiamconfigs/finalizers,verbs=update
@@ -54,6 +57,9 @@ func NewClusterReconcilerLegacy(client client.Client, log logr.Logger, scheme *r
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=gitopsconfigs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=clusterctl.cluster.x-k8s.io,resources=providers,verbs=get;list;watch
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awssnowclusters;awssnowmachinetemplates,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=tinkerbellclusters;tinkerbellmachinetemplates,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=tinkerbell.org,resources=hardware,verbs=get;list;watch
+//+kubebuilder:rbac:groups=bmc.tinkerbell.org,resources=baseboardmanagements,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -89,6 +95,11 @@ func (r *ClusterReconcilerLegacy) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
+	// The history is an audit aid, failing to record it shouldn't block the reconciliation.
+	if err := r.history.Record(ctx, cluster, clusterhistory.SourceController, clusterhistory.Actor(cluster)); err != nil {
+		r.Log.Error(err, "Failed to record the change in the cluster history")
+	}
+
 	// dry run
 	result, err := r.reconcile(ctx, req.NamespacedName, true)
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"github.com/aws/eks-anywhere/controllers"
 	_ "github.com/aws/eks-anywhere/internal/test/envtest"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterhistory"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
@@ -58,8 +59,10 @@ func newVsphereClusterReconcilerTest(t *testing.T, objs ...runtime.Object) *vsph
 		cl,
 		validator,
 		defaulter,
+		vsphere.NewClusterTagger(govcClient),
 		cniReconciler,
 		nil,
+		nil,
 	)
 	registry := clusters.NewProviderClusterReconcilerRegistryBuilder().
 		Add(anywherev1.VSphereDatacenterKind, reconciler).
@@ -110,6 +113,33 @@ func TestClusterReconcilerSkipManagement(t *testing.T) {
 	}
 }
 
+func TestClusterReconcilerRecordsHistory(t *testing.T) {
+	g := NewWithT(t)
+	secret := createSecret()
+	cluster := createCluster()
+	datacenterConfig := createDataCenter(cluster)
+	bundle := createBundle(cluster)
+	machineConfigCP := createCPMachineConfig()
+	machineConfigWN := createWNMachineConfig()
+
+	objs := []runtime.Object{cluster, datacenterConfig, secret, bundle, machineConfigCP, machineConfigWN}
+
+	tt := newVsphereClusterReconcilerTest(t, objs...)
+	req := clusterRequest(cluster)
+	ctx := context.Background()
+
+	_, err := tt.reconciler.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	history := &apiv1.ConfigMap{}
+	g.Expect(tt.client.Get(ctx, client.ObjectKey{Name: clusterhistory.ConfigMapName(cluster.Name), Namespace: cluster.Namespace}, history)).To(Succeed())
+	entries, err := clusterhistory.Entries(history)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entries).To(HaveLen(1))
+	g.Expect(entries[0].Source).To(Equal(clusterhistory.SourceController))
+	g.Expect(entries[0].Changes).To(ConsistOf("cluster created"))
+}
+
 func TestClusterReconcilerDeleteExistingCAPIClusterSuccess(t *testing.T) {
 	secret := createSecret()
 	managementCluster := createCluster()
@@ -160,6 +160,57 @@ func TestClusterReconcilerSetBundlesRef(t *testing.T) {
 	g.Expect(newCluster.Spec.BundlesRef).To(Equal(mgmtCluster.Spec.BundlesRef))
 }
 
+func TestClusterReconcilerSummarizesAppliedChanges(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	managementCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-management-cluster",
+			Namespace: "my-namespace",
+		},
+	}
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-cluster",
+			Namespace:  "my-namespace",
+			Generation: 1,
+		},
+		Spec: anywherev1.ClusterSpec{
+			BundlesRef: &anywherev1.BundlesRef{Name: "my-bundles-ref"},
+		},
+	}
+	cluster.SetManagedBy("my-management-cluster")
+
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster, managementCluster).Build()
+	capiCluster := &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: "cluster.x-k8s.io/v1beta1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "eksa-system"},
+	}
+	registry := dummyProviderReconcilerRegistry{
+		reconciler: changingProviderReconciler{created: capiCluster},
+	}
+
+	r := controllers.NewClusterReconciler(cl, nullLog(), registry)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	registry.reconciler = changingProviderReconciler{updated: capiCluster}
+	r = controllers.NewClusterReconciler(cl, nullLog(), registry)
+	_, err = r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	newCluster := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(cluster), newCluster)).To(Succeed())
+	summary := newCluster.Status.LastAppliedChanges
+	g.Expect(summary).NotTo(BeNil())
+	g.Expect(summary.Generation).To(Equal(int64(1)))
+	g.Expect(summary.ChangedFields).To(ConsistOf("cluster created"))
+	g.Expect(summary.Created).To(ConsistOf("Cluster eksa-system/my-cluster"))
+	g.Expect(summary.Updated).To(ConsistOf("Cluster eksa-system/my-cluster"))
+	g.Expect(summary.Deleted).To(BeEmpty())
+}
+
 func newRegistryForDummyProviderReconciler() controllers.ProviderClusterReconcilerRegistry {
 	return dummyProviderReconcilerRegistry{
 		reconciler: dummyProviderReconciler{},
@@ -192,3 +243,20 @@ func clusterRequest(cluster *anywherev1.Cluster) reconcile.Request {
 func nullLog() logr.Logger {
 	return logr.New(logf.NullLogSink{})
 }
+
+// changingProviderReconciler reports the creation or update of an object, as the provider reconcilers do
+// through the object changes in the context.
+type changingProviderReconciler struct {
+	created, updated client.Object
+}
+
+func (c changingProviderReconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	changes := controller.ObjectChangesFromContext(ctx)
+	if c.created != nil {
+		changes.Created(c.created)
+	}
+	if c.updated != nil {
+		changes.Updated(c.updated)
+	}
+	return controller.Result{}, nil
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller"
+)
+
+// etcdEncryptionRewriteRetryPeriod is how long to wait before trying again to rewrite the encrypted
+// resources of a cluster that couldn't be reached.
+const etcdEncryptionRewriteRetryPeriod = 10 * time.Minute
+
+// EncryptedResourcesRewriter rewrites the objects of an encrypted resource in a cluster.
+type EncryptedResourcesRewriter interface {
+	Rewrite(ctx context.Context, cluster client.ObjectKey, resource string) error
+}
+
+// EtcdEncryptionRewriteReconciler rewrites the objects of the encrypted resources of clusters every key
+// rotation period, so they are encrypted again with the current key, and reports the last rewrite of each
+// resource in the cluster status. The first period of a resource starts when the controller first sees it.
+type EtcdEncryptionRewriteReconciler struct {
+	client   client.Client
+	log      logr.Logger
+	rewriter EncryptedResourcesRewriter
+}
+
+func NewEtcdEncryptionRewriteReconciler(client client.Client, log logr.Logger, rewriter EncryptedResourcesRewriter) *EtcdEncryptionRewriteReconciler {
+	return &EtcdEncryptionRewriteReconciler{
+		client:   client,
+		log:      log,
+		rewriter: rewriter,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EtcdEncryptionRewriteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("etcdencryptionrewrite").
+		For(&anywherev1.Cluster{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
+
+func (r *EtcdEncryptionRewriteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("cluster", req.NamespacedName)
+
+	cluster := &anywherev1.Cluster{}
+	if err := r.client.Get(ctx, req.NamespacedName, cluster); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !cluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	statuses, requeueAfter, err := r.rewrite(ctx, log, cluster, time.Now())
+	if !reflect.DeepEqual(cluster.Status.EtcdEncryptionRewrites, statuses) {
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Status.EtcdEncryptionRewrites = statuses
+		if err := r.client.Status().Patch(ctx, cluster, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("patching etcd encryption rewrites status of cluster %s: %v", cluster.Name, err)
+		}
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		log.Info("Kubeconfig for cluster not available yet, requeuing")
+		return ctrl.Result{RequeueAfter: etcdEncryptionRewriteRetryPeriod}, nil
+	case err != nil:
+		return ctrl.Result{}, fmt.Errorf("rewriting encrypted resources of cluster %s: %v", cluster.Name, err)
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// rewrite rewrites the encrypted resources of cluster whose key rotation period is over at now. It returns
+// the last rewrite of each resource with a key rotation period and how long until the next one is due.
+// It stops at the first resource that can't be rewritten.
+func (r *EtcdEncryptionRewriteReconciler) rewrite(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, now time.Time) ([]anywherev1.EtcdEncryptionRewriteStatus, time.Duration, error) {
+	lastRewrites := make(map[string]metav1.Time, len(cluster.Status.EtcdEncryptionRewrites))
+	for _, rewrite := range cluster.Status.EtcdEncryptionRewrites {
+		lastRewrites[rewrite.Resource] = rewrite.Time
+	}
+
+	var statuses []anywherev1.EtcdEncryptionRewriteStatus
+	var requeueAfter time.Duration
+	var err error
+	for _, config := range cluster.Spec.EtcdEncryption {
+		if config.KeyRotationPeriod == nil {
+			continue
+		}
+		period := config.KeyRotationPeriod.Duration
+		for _, resource := range config.EncryptedResources() {
+			last, ok := lastRewrites[resource]
+			if !ok {
+				last = metav1.NewTime(now.Truncate(time.Second))
+			} else if err == nil && now.Sub(last.Time) >= period {
+				log.Info("Rewriting encrypted resource", "resource", resource)
+				if err = r.rewriter.Rewrite(ctx, controller.CapiClusterObjectKey(cluster), resource); err == nil {
+					last = metav1.NewTime(now.Truncate(time.Second))
+				}
+			}
+
+			statuses = append(statuses, anywherev1.EtcdEncryptionRewriteStatus{Resource: resource, Time: last})
+			requeueAfter = minRequeue(requeueAfter, last.Add(period).Sub(now))
+		}
+	}
+
+	return statuses, requeueAfter, err
+}
+
+// minRequeue returns the shortest of the positive durations, requeueing right away the overdue ones.
+func minRequeue(current, next time.Duration) time.Duration {
+	if next <= 0 {
+		next = time.Second
+	}
+	if current == 0 || next < current {
+		return next
+	}
+	return current
+}
@@ -0,0 +1,153 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/controllers/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func etcdEncryptionRewriteCluster(rewrites ...anywherev1.EtcdEncryptionRewriteStatus) *anywherev1.Cluster {
+	kms := &anywherev1.KMS{Name: "key", SocketListenAddress: "unix:///var/run/kmsplugin/socket.sock"}
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			EtcdEncryption: []anywherev1.EtcdEncryption{
+				{
+					Providers:         []anywherev1.EtcdEncryptionProvider{{KMS: kms}},
+					Resources:         []string{"secrets", "configmaps"},
+					KeyRotationPeriod: &metav1.Duration{Duration: 24 * time.Hour},
+				},
+				{
+					Providers: []anywherev1.EtcdEncryptionProvider{{KMS: kms}},
+					Resources: []string{"events"},
+				},
+			},
+		},
+		Status: anywherev1.ClusterStatus{EtcdEncryptionRewrites: rewrites},
+	}
+}
+
+func TestEtcdEncryptionRewriteReconcilerSetupWithManager(t *testing.T) {
+	client := env.Client()
+	r := controllers.NewEtcdEncryptionRewriteReconciler(client, logf.Log, nil)
+
+	g := NewWithT(t)
+	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
+}
+
+func TestEtcdEncryptionRewriteReconcilerReconcileStartsPeriod(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cl := fake.NewClientBuilder().WithRuntimeObjects(etcdEncryptionRewriteCluster()).Build()
+	rewriter := mocks.NewMockEncryptedResourcesRewriter(gomock.NewController(t))
+	r := controllers.NewEtcdEncryptionRewriteReconciler(cl, logf.Log, rewriter)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically("~", 24*time.Hour, time.Minute))
+
+	got := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, req.NamespacedName, got)).To(Succeed())
+	g.Expect(got.Status.EtcdEncryptionRewrites).To(HaveLen(2))
+	g.Expect(got.Status.EtcdEncryptionRewrites[0].Resource).To(Equal("secrets"))
+	g.Expect(got.Status.EtcdEncryptionRewrites[1].Resource).To(Equal("configmaps"))
+	g.Expect(got.Status.EtcdEncryptionRewrites[0].Time.Time).To(BeTemporally("~", time.Now(), time.Minute))
+}
+
+func TestEtcdEncryptionRewriteReconcilerReconcileRewritesDueResources(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	recent := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	cluster := etcdEncryptionRewriteCluster(
+		anywherev1.EtcdEncryptionRewriteStatus{Resource: "secrets", Time: metav1.NewTime(time.Now().Add(-25 * time.Hour))},
+		anywherev1.EtcdEncryptionRewriteStatus{Resource: "configmaps", Time: recent},
+	)
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+	rewriter := mocks.NewMockEncryptedResourcesRewriter(gomock.NewController(t))
+	r := controllers.NewEtcdEncryptionRewriteReconciler(cl, logf.Log, rewriter)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	rewriter.EXPECT().Rewrite(ctx, client.ObjectKey{Name: "workload", Namespace: constants.EksaSystemNamespace}, "secrets")
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically("~", 23*time.Hour, time.Minute))
+
+	got := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, req.NamespacedName, got)).To(Succeed())
+	g.Expect(got.Status.EtcdEncryptionRewrites[0].Time.Time).To(BeTemporally("~", time.Now(), time.Minute))
+	g.Expect(got.Status.EtcdEncryptionRewrites[1].Time).To(Equal(recent))
+}
+
+func TestEtcdEncryptionRewriteReconcilerReconcileRewriteError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	due := metav1.NewTime(time.Now().Add(-25 * time.Hour).Truncate(time.Second))
+	cluster := etcdEncryptionRewriteCluster(
+		anywherev1.EtcdEncryptionRewriteStatus{Resource: "secrets", Time: due},
+		anywherev1.EtcdEncryptionRewriteStatus{Resource: "configmaps", Time: due},
+	)
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+	rewriter := mocks.NewMockEncryptedResourcesRewriter(gomock.NewController(t))
+	r := controllers.NewEtcdEncryptionRewriteReconciler(cl, logf.Log, rewriter)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	rewriter.EXPECT().Rewrite(ctx, gomock.Any(), "secrets").Return(errors.New("listing secrets: forbidden"))
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).To(MatchError(ContainSubstring("rewriting encrypted resources of cluster workload: listing secrets: forbidden")))
+
+	got := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, req.NamespacedName, got)).To(Succeed())
+	g.Expect(got.Status.EtcdEncryptionRewrites[0].Time).To(Equal(due))
+}
+
+func TestEtcdEncryptionRewriteReconcilerReconcileKubeconfigNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := etcdEncryptionRewriteCluster(
+		anywherev1.EtcdEncryptionRewriteStatus{Resource: "secrets", Time: metav1.NewTime(time.Now().Add(-25 * time.Hour))},
+	)
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+	rewriter := mocks.NewMockEncryptedResourcesRewriter(gomock.NewController(t))
+	r := controllers.NewEtcdEncryptionRewriteReconciler(cl, logf.Log, rewriter)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	rewriter.EXPECT().Rewrite(ctx, gomock.Any(), "secrets").Return(apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "workload-kubeconfig"))
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+}
+
+func TestEtcdEncryptionRewriteReconcilerReconcileNoKeyRotation(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := etcdEncryptionRewriteCluster()
+	cluster.Spec.EtcdEncryption[0].KeyRotationPeriod = nil
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+	r := controllers.NewEtcdEncryptionRewriteReconciler(cl, logf.Log, nil)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(reconcile.Result{}))
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
@@ -9,12 +10,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/bootstrapmanifests"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/features"
 	ciliumreconciler "github.com/aws/eks-anywhere/pkg/networking/cilium/reconciler"
 	cnireconciler "github.com/aws/eks-anywhere/pkg/networking/reconciler"
+	cloudstackreconciler "github.com/aws/eks-anywhere/pkg/providers/cloudstack/reconciler"
+	"github.com/aws/eks-anywhere/pkg/providers/fake"
+	nutanixreconciler "github.com/aws/eks-anywhere/pkg/providers/nutanix/reconciler"
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
 	snowreconciler "github.com/aws/eks-anywhere/pkg/providers/snow/reconciler"
+	tinkerbellreconciler "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler"
 	vspherereconciler "github.com/aws/eks-anywhere/pkg/providers/vsphere/reconciler"
 )
 
@@ -27,13 +34,18 @@ type Factory struct {
 	registryBuilder   *clusters.ProviderClusterReconcilerRegistryBuilder
 	reconcilers       Reconcilers
 
-	tracker                  *remote.ClusterCacheTracker
-	registry                 *clusters.ProviderClusterReconcilerRegistry
-	vsphereClusterReconciler *vspherereconciler.Reconciler
-	snowClusterReconciler    *snowreconciler.Reconciler
-	cniReconciler            *cnireconciler.Reconciler
-	logger                   logr.Logger
-	deps                     *dependencies.Dependencies
+	tracker                     *remote.ClusterCacheTracker
+	registry                    *clusters.ProviderClusterReconcilerRegistry
+	vsphereClusterReconciler    *vspherereconciler.Reconciler
+	snowClusterReconciler       *snowreconciler.Reconciler
+	cloudStackClusterReconciler *cloudstackreconciler.Reconciler
+	nutanixClusterReconciler    *nutanixreconciler.Reconciler
+	tinkerbellClusterReconciler *tinkerbellreconciler.Reconciler
+	fakeClusterReconciler       *fake.Reconciler
+	cniReconciler               *cnireconciler.Reconciler
+	manifestsReconciler         *bootstrapmanifests.Reconciler
+	logger                      logr.Logger
+	deps                        *dependencies.Dependencies
 }
 
 type Reconcilers struct {
@@ -93,7 +105,7 @@ func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider) *
 }
 
 func (f *Factory) WithVSphereDatacenterReconciler() *Factory {
-	f.dependencyFactory.WithVSphereDefaulter().WithVSphereValidator()
+	f.dependencyFactory.WithVSphereDefaulter().WithVSphereValidator().WithVSphereClusterTagger()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.reconcilers.VSphereDatacenterReconciler != nil {
@@ -155,8 +167,11 @@ func (f *Factory) withTracker() *Factory {
 }
 
 const (
-	snowProviderName    = "snow"
-	vSphereProviderName = "vsphere"
+	snowProviderName       = "snow"
+	vSphereProviderName    = "vsphere"
+	cloudStackProviderName = "cloudstack"
+	tinkerbellProviderName = "tinkerbell"
+	nutanixProviderName    = "nutanix"
 )
 
 func (f *Factory) WithProviderClusterReconcilerRegistry(capiProviders []clusterctlv1.Provider) *Factory {
@@ -172,11 +187,21 @@ func (f *Factory) WithProviderClusterReconcilerRegistry(capiProviders []clusterc
 			f.withSnowClusterReconciler()
 		case vSphereProviderName:
 			f.withVSphereClusterReconciler()
+		case cloudStackProviderName:
+			f.withCloudStackClusterReconciler()
+		case tinkerbellProviderName:
+			f.withTinkerbellClusterReconciler()
+		case nutanixProviderName:
+			f.withNutanixClusterReconciler()
 		default:
 			f.logger.Info("Found unknown CAPI provider, ignoring", "providerName", p.ProviderName)
 		}
 	}
 
+	if features.IsActive(features.FakeProvider()) {
+		f.withFakeClusterReconciler()
+	}
+
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.registry != nil {
 			return nil
@@ -191,8 +216,8 @@ func (f *Factory) WithProviderClusterReconcilerRegistry(capiProviders []clusterc
 }
 
 func (f *Factory) withVSphereClusterReconciler() *Factory {
-	f.dependencyFactory.WithVSphereDefaulter().WithVSphereValidator()
-	f.withTracker()
+	f.dependencyFactory.WithVSphereDefaulter().WithVSphereValidator().WithVSphereClusterTagger()
+	f.withTracker().withBootstrapManifestsReconciler()
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.vsphereClusterReconciler != nil {
 			return nil
@@ -202,7 +227,9 @@ func (f *Factory) withVSphereClusterReconciler() *Factory {
 			f.manager.GetClient(),
 			f.deps.VSphereValidator,
 			f.deps.VSphereDefaulter,
+			f.deps.VSphereClusterTagger,
 			f.cniReconciler,
+			f.manifestsReconciler,
 			f.tracker,
 		)
 		f.registryBuilder.Add(anywherev1.VSphereDatacenterKind, f.vsphereClusterReconciler)
@@ -214,17 +241,20 @@ func (f *Factory) withVSphereClusterReconciler() *Factory {
 }
 
 func (f *Factory) withSnowClusterReconciler() *Factory {
-	f.withCNIReconciler().withTracker()
+	f.withCNIReconciler().withTracker().withBootstrapManifestsReconciler()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.snowClusterReconciler != nil {
 			return nil
 		}
 
+		client := f.manager.GetClient()
 		f.snowClusterReconciler = snowreconciler.New(
-			f.manager.GetClient(),
+			client,
 			f.cniReconciler,
+			f.manifestsReconciler,
 			f.tracker,
+			snowreconciler.NewAwsClientBuilder(client),
 		)
 		f.registryBuilder.Add(anywherev1.SnowDatacenterKind, f.snowClusterReconciler)
 
@@ -234,6 +264,100 @@ func (f *Factory) withSnowClusterReconciler() *Factory {
 	return f
 }
 
+func (f *Factory) withCloudStackClusterReconciler() *Factory {
+	f.dependencyFactory.WithCloudStackValidatorRegistry(false).WithCloudStackTagsRegistry()
+	f.withCNIReconciler().withTracker().withBootstrapManifestsReconciler()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.cloudStackClusterReconciler != nil {
+			return nil
+		}
+
+		f.cloudStackClusterReconciler = cloudstackreconciler.New(
+			f.manager.GetClient(),
+			f.deps.CloudStackValidatorRegistry,
+			f.deps.CloudStackTagsRegistry,
+			f.cniReconciler,
+			f.manifestsReconciler,
+			f.tracker,
+		)
+		f.registryBuilder.Add(anywherev1.CloudStackDatacenterKind, f.cloudStackClusterReconciler)
+
+		return nil
+	})
+
+	return f
+}
+
+func (f *Factory) withNutanixClusterReconciler() *Factory {
+	f.dependencyFactory.WithNutanixValidatorRegistry()
+	f.withCNIReconciler().withTracker().withBootstrapManifestsReconciler()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.nutanixClusterReconciler != nil {
+			return nil
+		}
+
+		f.nutanixClusterReconciler = nutanixreconciler.New(
+			f.manager.GetClient(),
+			f.deps.NutanixValidatorRegistry,
+			f.cniReconciler,
+			f.manifestsReconciler,
+			f.tracker,
+		)
+		f.registryBuilder.Add(anywherev1.NutanixDatacenterKind, f.nutanixClusterReconciler)
+
+		return nil
+	})
+
+	return f
+}
+
+func (f *Factory) withTinkerbellClusterReconciler() *Factory {
+	f.withCNIReconciler().withTracker().withBootstrapManifestsReconciler()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.tinkerbellClusterReconciler != nil {
+			return nil
+		}
+
+		f.tinkerbellClusterReconciler = tinkerbellreconciler.New(
+			f.manager.GetClient(),
+			f.cniReconciler,
+			f.manifestsReconciler,
+			f.tracker,
+		)
+		f.registryBuilder.Add(anywherev1.TinkerbellDatacenterKind, f.tinkerbellClusterReconciler)
+
+		return nil
+	})
+
+	return f
+}
+
+// withFakeClusterReconciler reconciles Docker clusters with the in-memory fake provider, configured from the
+// FAKE_PROVIDER_* env vars. It's only meant for integration tests of the controller.
+func (f *Factory) withFakeClusterReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.fakeClusterReconciler != nil {
+			return nil
+		}
+
+		config, err := fake.ConfigFromEnv()
+		if err != nil {
+			return err
+		}
+
+		f.logger.Info("Warning: reconciling Docker clusters with the fake provider, no infrastructure will be provisioned")
+		f.fakeClusterReconciler = fake.NewReconciler(config, time.Now)
+		f.registryBuilder.Add(anywherev1.DockerDatacenterKind, f.fakeClusterReconciler)
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) withCNIReconciler() *Factory {
 	f.dependencyFactory.WithCiliumTemplater()
 
@@ -249,3 +373,21 @@ func (f *Factory) withCNIReconciler() *Factory {
 
 	return f
 }
+
+func (f *Factory) withBootstrapManifestsReconciler() *Factory {
+	f.dependencyFactory.WithFileReader()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.manifestsReconciler != nil {
+			return nil
+		}
+
+		f.manifestsReconciler = bootstrapmanifests.NewReconciler(
+			bootstrapmanifests.NewReader(f.deps.FileReader, bootstrapmanifests.NewGoGitReader()),
+		)
+
+		return nil
+	})
+
+	return f
+}
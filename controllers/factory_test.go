@@ -10,6 +10,8 @@ import (
 
 	"github.com/aws/eks-anywhere/controllers"
 	"github.com/aws/eks-anywhere/controllers/mocks"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/providers/fake"
 )
 
 func TestFactoryBuildAllVSphereReconciler(t *testing.T) {
@@ -54,6 +56,18 @@ func TestFactoryBuildClusterReconciler(t *testing.T) {
 			Type:         string(clusterctlv1.InfrastructureProviderType),
 			ProviderName: "snow",
 		},
+		{
+			Type:         string(clusterctlv1.InfrastructureProviderType),
+			ProviderName: "cloudstack",
+		},
+		{
+			Type:         string(clusterctlv1.InfrastructureProviderType),
+			ProviderName: "tinkerbell",
+		},
+		{
+			Type:         string(clusterctlv1.InfrastructureProviderType),
+			ProviderName: "nutanix",
+		},
 		{
 			Type:         string(clusterctlv1.InfrastructureProviderType),
 			ProviderName: "unknown-provider",
@@ -71,6 +85,47 @@ func TestFactoryBuildClusterReconciler(t *testing.T) {
 	g.Expect(reconcilers.ClusterReconciler).NotTo(BeNil())
 }
 
+func TestFactoryBuildClusterReconcilerWithFakeProvider(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(features.FakeProviderEnvVar, "true")
+	t.Setenv(fake.MachineLatencyEnvVar, "5s")
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetScheme().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithClusterReconciler(nil)
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.ClusterReconciler).NotTo(BeNil())
+}
+
+func TestFactoryBuildClusterReconcilerWithFakeProviderInvalidConfig(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(features.FakeProviderEnvVar, "true")
+	t.Setenv(fake.MachineLatencyEnvVar, "forever")
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetScheme().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithClusterReconciler(nil)
+
+	_, err := f.Build(ctx)
+	g.Expect(err).To(MatchError(ContainSubstring(fake.MachineLatencyEnvVar)))
+}
+
 func TestFactoryBuildAllSnowReconciler(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+)
+
+const (
+	// fluxGitSecretName is the Secret created by flux bootstrap with the credentials of the Git repository.
+	fluxGitSecretName = "flux-system"
+	// fluxCredentialsRenewAtAnnotation records in the Flux Secret when its credentials need to be rotated.
+	fluxCredentialsRenewAtAnnotation = "anywhere.eks.amazonaws.com/credentials-renew-at"
+	// fluxCredentialsBootstrapRetryPeriod is how often the Flux Secret is looked for until flux is bootstrapped.
+	fluxCredentialsBootstrapRetryPeriod = time.Minute
+
+	githubAppPrivateKeySecretKey = "privateKey"
+	githubAppTokenUsername       = "x-access-token"
+)
+
+// GithubAppTokenIssuer issues access tokens for installations of GitHub Apps.
+type GithubAppTokenIssuer interface {
+	InstallationToken(ctx context.Context, appID, installationID int64, privateKey []byte) (*github.InstallationToken, error)
+}
+
+// FluxCredentialsReconciler issues GitHub App installation tokens for Flux and rotates them in the Flux Secret
+// before they expire. It replaces right away the personal access token flux bootstrap stores in the Secret.
+type FluxCredentialsReconciler struct {
+	client      client.Client
+	log         logr.Logger
+	tokenIssuer GithubAppTokenIssuer
+	now         func() time.Time
+}
+
+func NewFluxCredentialsReconciler(client client.Client, log logr.Logger, tokenIssuer GithubAppTokenIssuer) *FluxCredentialsReconciler {
+	return &FluxCredentialsReconciler{
+		client:      client,
+		log:         log,
+		tokenIssuer: tokenIssuer,
+		now:         time.Now,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FluxCredentialsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("fluxcredentials").
+		For(&anywherev1.FluxConfig{}).
+		Complete(r)
+}
+
+func (r *FluxCredentialsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("fluxConfig", req.NamespacedName)
+
+	fluxConfig := &anywherev1.FluxConfig{}
+	if err := r.client.Get(ctx, req.NamespacedName, fluxConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !fluxConfig.DeletionTimestamp.IsZero() || !managesFluxCredentials(fluxConfig) {
+		return ctrl.Result{}, nil
+	}
+
+	namespace := fluxSystemNamespace(fluxConfig)
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: fluxGitSecretName, Namespace: namespace}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("Flux secret not found, waiting for flux to be bootstrapped", "namespace", namespace)
+			return ctrl.Result{RequeueAfter: fluxCredentialsBootstrapRetryPeriod}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	now := r.now()
+	if renewAt, ok := credentialsRenewAt(secret); ok && now.Before(renewAt) && hasInstallationToken(secret) {
+		return ctrl.Result{RequeueAfter: renewAt.Sub(now)}, nil
+	}
+
+	renewAt, err := r.rotateCredentials(ctx, fluxConfig, secret, now)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	log.Info("Rotated flux git credentials", "renewAt", renewAt)
+
+	return ctrl.Result{RequeueAfter: renewAt.Sub(now)}, nil
+}
+
+// rotateCredentials issues new credentials in the Flux Secret and returns when they need to be renewed.
+func (r *FluxCredentialsReconciler) rotateCredentials(ctx context.Context, fluxConfig *anywherev1.FluxConfig, secret *corev1.Secret, now time.Time) (time.Time, error) {
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	expiresAt, err := r.issueGithubAppToken(ctx, fluxConfig.Spec.Github.App, secret)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Credentials are renewed after two thirds of their lifetime, leaving time to retry on failures.
+	renewAt := now.Add(expiresAt.Sub(now) * 2 / 3)
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[fluxCredentialsRenewAtAnnotation] = renewAt.UTC().Format(time.RFC3339)
+
+	if err := r.client.Patch(ctx, secret, patch); err != nil {
+		return time.Time{}, fmt.Errorf("updating credentials in flux secret %s/%s: %v", secret.Namespace, secret.Name, err)
+	}
+
+	return renewAt, nil
+}
+
+func (r *FluxCredentialsReconciler) issueGithubAppToken(ctx context.Context, app *anywherev1.GithubAppConfig, secret *corev1.Secret) (time.Time, error) {
+	privateKey, err := r.eksaSecretValue(ctx, app.PrivateKeySecretRef, githubAppPrivateKeySecretKey)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	token, err := r.tokenIssuer.InstallationToken(ctx, app.AppID, app.InstallationID, privateKey)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	secret.Data["username"] = []byte(githubAppTokenUsername)
+	secret.Data["password"] = []byte(token.Token)
+	return token.ExpiresAt, nil
+}
+
+func (r *FluxCredentialsReconciler) eksaSecretValue(ctx context.Context, name, key string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: name, Namespace: constants.EksaSystemNamespace}, secret); err != nil {
+		return nil, fmt.Errorf("reading secret %s/%s: %v", constants.EksaSystemNamespace, name, err)
+	}
+
+	value, ok := secret.Data[key]
+	if !ok || len(value) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no %s", constants.EksaSystemNamespace, name, key)
+	}
+	return value, nil
+}
+
+func managesFluxCredentials(fluxConfig *anywherev1.FluxConfig) bool {
+	return fluxConfig.Spec.Github != nil && fluxConfig.Spec.Github.App != nil
+}
+
+// hasInstallationToken returns false when the Secret holds other credentials, like the personal access token
+// written by flux bootstrap when the cluster is created or upgraded.
+func hasInstallationToken(secret *corev1.Secret) bool {
+	return string(secret.Data["username"]) == githubAppTokenUsername
+}
+
+func fluxSystemNamespace(fluxConfig *anywherev1.FluxConfig) string {
+	if fluxConfig.Spec.SystemNamespace == "" {
+		return anywherev1.FluxDefaultNamespace
+	}
+	return fluxConfig.Spec.SystemNamespace
+}
+
+func credentialsRenewAt(secret *corev1.Secret) (time.Time, bool) {
+	value, ok := secret.Annotations[fluxCredentialsRenewAtAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	renewAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return renewAt, true
+}
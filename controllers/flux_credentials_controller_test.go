@@ -0,0 +1,192 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/controllers/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+)
+
+func TestFluxCredentialsReconcilerSetupWithManager(t *testing.T) {
+	client := env.Client()
+	r := controllers.NewFluxCredentialsReconciler(client, logf.Log, nil)
+
+	g := NewWithT(t)
+	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
+}
+
+func TestFluxCredentialsReconcilerReconcileGithubApp(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fluxConfig := &anywherev1.FluxConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux", Namespace: "default"},
+		Spec: anywherev1.FluxConfigSpec{
+			Github: &anywherev1.GithubProviderConfig{
+				Owner:      "janedoe",
+				Repository: "flux-fleet",
+				App:        &anywherev1.GithubAppConfig{AppID: 1234, InstallationID: 5678, PrivateKeySecretRef: "github-app"},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(
+		fluxConfig,
+		fluxSecret(map[string][]byte{"username": []byte("git"), "password": []byte("ghp_pat")}),
+		eksaSecret("github-app", "privateKey", []byte("app-private-key")),
+	).Build()
+	issuer := mocks.NewMockGithubAppTokenIssuer(gomock.NewController(t))
+	r := controllers.NewFluxCredentialsReconciler(cl, logf.Log, issuer)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "flux", Namespace: "default"}}
+
+	issuer.EXPECT().InstallationToken(ctx, int64(1234), int64(5678), []byte("app-private-key")).Return(
+		&github.InstallationToken{Token: "ghs_token", ExpiresAt: time.Now().Add(time.Hour)}, nil,
+	)
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically("~", 40*time.Minute, time.Minute))
+
+	secret := getFluxSecret(ctx, g, cl)
+	g.Expect(secret.Data).To(HaveKeyWithValue("username", []byte("x-access-token")))
+	g.Expect(secret.Data).To(HaveKeyWithValue("password", []byte("ghs_token")))
+	g.Expect(secret.Annotations).To(HaveKey("anywhere.eks.amazonaws.com/credentials-renew-at"))
+
+	// Credentials are not rotated again until they need to be renewed.
+	result, err = r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically("~", 40*time.Minute, time.Minute))
+}
+
+func TestFluxCredentialsReconcilerReconcileGithubAppError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fluxConfig := &anywherev1.FluxConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux", Namespace: "default"},
+		Spec: anywherev1.FluxConfigSpec{
+			Github: &anywherev1.GithubProviderConfig{
+				App: &anywherev1.GithubAppConfig{AppID: 1234, InstallationID: 5678, PrivateKeySecretRef: "github-app"},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(
+		fluxConfig,
+		fluxSecret(nil),
+		eksaSecret("github-app", "privateKey", []byte("app-private-key")),
+	).Build()
+	issuer := mocks.NewMockGithubAppTokenIssuer(gomock.NewController(t))
+	r := controllers.NewFluxCredentialsReconciler(cl, logf.Log, issuer)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "flux", Namespace: "default"}}
+
+	issuer.EXPECT().InstallationToken(ctx, int64(1234), int64(5678), gomock.Any()).Return(nil, errors.New("bad credentials"))
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).To(MatchError("bad credentials"))
+}
+
+func TestFluxCredentialsReconcilerReconcileReplacesBootstrapToken(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fluxConfig := &anywherev1.FluxConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux", Namespace: "default"},
+		Spec: anywherev1.FluxConfigSpec{
+			Github: &anywherev1.GithubProviderConfig{
+				App: &anywherev1.GithubAppConfig{AppID: 1234, InstallationID: 5678, PrivateKeySecretRef: "github-app"},
+			},
+		},
+	}
+	// flux bootstrap, run again by an upgrade, wrote the personal access token back in the Secret
+	// before the installation token had to be renewed.
+	secret := fluxSecret(map[string][]byte{"username": []byte("git"), "password": []byte("ghp_pat")})
+	secret.Annotations = map[string]string{
+		"anywhere.eks.amazonaws.com/credentials-renew-at": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(
+		fluxConfig,
+		secret,
+		eksaSecret("github-app", "privateKey", []byte("app-private-key")),
+	).Build()
+	issuer := mocks.NewMockGithubAppTokenIssuer(gomock.NewController(t))
+	r := controllers.NewFluxCredentialsReconciler(cl, logf.Log, issuer)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "flux", Namespace: "default"}}
+
+	issuer.EXPECT().InstallationToken(ctx, int64(1234), int64(5678), []byte("app-private-key")).Return(
+		&github.InstallationToken{Token: "ghs_token", ExpiresAt: time.Now().Add(time.Hour)}, nil,
+	)
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getFluxSecret(ctx, g, cl).Data).To(HaveKeyWithValue("password", []byte("ghs_token")))
+}
+
+func TestFluxCredentialsReconcilerReconcileFluxNotBootstrapped(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fluxConfig := &anywherev1.FluxConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux", Namespace: "default"},
+		Spec: anywherev1.FluxConfigSpec{
+			Github: &anywherev1.GithubProviderConfig{
+				App: &anywherev1.GithubAppConfig{AppID: 1234, InstallationID: 5678, PrivateKeySecretRef: "github-app"},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(fluxConfig).Build()
+	r := controllers.NewFluxCredentialsReconciler(cl, logf.Log, nil)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "flux", Namespace: "default"}}
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(time.Minute))
+}
+
+func TestFluxCredentialsReconcilerReconcileNotManaged(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fluxConfig := &anywherev1.FluxConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux", Namespace: "default"},
+		Spec: anywherev1.FluxConfigSpec{
+			Github: &anywherev1.GithubProviderConfig{Owner: "janedoe", Repository: "flux-fleet"},
+		},
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(fluxConfig, fluxSecret(nil)).Build()
+	r := controllers.NewFluxCredentialsReconciler(cl, logf.Log, nil)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "flux", Namespace: "default"}}
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(reconcile.Result{}))
+}
+
+func fluxSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux-system", Namespace: "flux-system"},
+		Data:       data,
+	}
+}
+
+func eksaSecret(name, key string, value []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace},
+		Data:       map[string][]byte{key: value},
+	}
+}
+
+func getFluxSecret(ctx context.Context, g *WithT, cl client.Client) *corev1.Secret {
+	secret := &corev1.Secret{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Name: "flux-system", Namespace: "flux-system"}, secret)).To(Succeed())
+	return secret
+}
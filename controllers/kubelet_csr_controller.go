@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/kubeletcsr"
+)
+
+// kubeletCSRResyncPeriod is how often the pending kubelet serving CSRs of a cluster are checked.
+const kubeletCSRResyncPeriod = 30 * time.Second
+
+// ClientsetBuilder builds clientsets for the clusters managed from the management cluster.
+type ClientsetBuilder interface {
+	Clientset(ctx context.Context, cluster client.ObjectKey) (kubernetes.Interface, error)
+}
+
+// KubeletCSRReconciler approves the kubelet serving certificate signing requests of the clusters
+// with kubelet serving certificate rotation enabled.
+type KubeletCSRReconciler struct {
+	client     client.Client
+	log        logr.Logger
+	clientsets ClientsetBuilder
+}
+
+// NewKubeletCSRReconciler constructs a new KubeletCSRReconciler.
+func NewKubeletCSRReconciler(client client.Client, log logr.Logger, clientsets ClientsetBuilder) *KubeletCSRReconciler {
+	return &KubeletCSRReconciler{
+		client:     client,
+		log:        log,
+		clientsets: clientsets,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KubeletCSRReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("kubeletcsr").
+		For(&anywherev1.Cluster{}).
+		Complete(r)
+}
+
+// Reconcile approves the valid pending kubelet serving CSRs in the cluster. The requests are read and
+// approved with the cluster's own kubeconfig, so no extra permissions are needed in the management cluster.
+func (r *KubeletCSRReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("cluster", req.NamespacedName)
+
+	cluster := &anywherev1.Cluster{}
+	if err := r.client.Get(ctx, req.NamespacedName, cluster); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !cluster.DeletionTimestamp.IsZero() || !cluster.KubeletServingCertificateRotationEnabled() {
+		return ctrl.Result{}, nil
+	}
+
+	clientset, err := r.clientsets.Clientset(ctx, controller.CapiClusterObjectKey(cluster))
+	if apierrors.IsNotFound(err) {
+		log.Info("Kubeconfig for cluster not available yet, requeuing")
+		return ctrl.Result{RequeueAfter: kubeletCSRResyncPeriod}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	approved, err := kubeletcsr.NewApprover(clientset).ApprovePending(ctx)
+	for _, name := range approved {
+		log.Info("Approved kubelet serving certificate signing request", "csr", name)
+	}
+	if err != nil {
+		// Requests that don't pass validation stay pending for an administrator to review.
+		log.Error(err, "Failed approving kubelet serving certificate signing requests")
+	}
+
+	return ctrl.Result{RequeueAfter: kubeletCSRResyncPeriod}, nil
+}
@@ -0,0 +1,87 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/controllers/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func kubeletCSRCluster(rotation bool) *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			KubeletConfiguration: &anywherev1.KubeletConfiguration{ServingCertificateRotation: rotation},
+		},
+	}
+}
+
+func TestKubeletCSRReconcilerSetupWithManager(t *testing.T) {
+	client := env.Client()
+	r := controllers.NewKubeletCSRReconciler(client, logf.Log, nil)
+
+	g := NewWithT(t)
+	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
+}
+
+func TestKubeletCSRReconcilerReconcile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cl := fake.NewClientBuilder().WithRuntimeObjects(kubeletCSRCluster(true)).Build()
+	clientsets := mocks.NewMockClientsetBuilder(gomock.NewController(t))
+	r := controllers.NewKubeletCSRReconciler(cl, logf.Log, clientsets)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	clientsets.EXPECT().
+		Clientset(ctx, client.ObjectKey{Name: "workload", Namespace: constants.EksaSystemNamespace}).
+		Return(kubefake.NewSimpleClientset(), nil)
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+}
+
+func TestKubeletCSRReconcilerReconcileRotationDisabled(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cl := fake.NewClientBuilder().WithRuntimeObjects(kubeletCSRCluster(false)).Build()
+	clientsets := mocks.NewMockClientsetBuilder(gomock.NewController(t))
+	r := controllers.NewKubeletCSRReconciler(cl, logf.Log, clientsets)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(reconcile.Result{}))
+}
+
+func TestKubeletCSRReconcilerReconcileKubeconfigNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cl := fake.NewClientBuilder().WithRuntimeObjects(kubeletCSRCluster(true)).Build()
+	clientsets := mocks.NewMockClientsetBuilder(gomock.NewController(t))
+	r := controllers.NewKubeletCSRReconciler(cl, logf.Log, clientsets)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	clientsets.EXPECT().Clientset(ctx, gomock.Any()).
+		Return(nil, apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "workload-kubeconfig"))
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+}
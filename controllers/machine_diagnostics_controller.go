@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller/handlers"
+	"github.com/aws/eks-anywhere/pkg/machinediagnostics"
+)
+
+// machineDiagnosticsResyncPeriod is how often the machines of a cluster are diagnosed
+// again, to detect the machines whose node doesn't join in time, which doesn't change any object.
+const machineDiagnosticsResyncPeriod = 5 * time.Minute
+
+// MachineDiagnoser finds the machines of a cluster that failed to bootstrap.
+type MachineDiagnoser interface {
+	Diagnose(ctx context.Context, cluster *anywherev1.Cluster) ([]machinediagnostics.Failure, error)
+}
+
+// MachineDiagnosticsReconciler publishes in the cluster status the machines that failed to bootstrap,
+// with the reason and an excerpt of their bootstrap logs, and emits a warning event for each new failure.
+type MachineDiagnosticsReconciler struct {
+	client    client.Client
+	log       logr.Logger
+	diagnoser MachineDiagnoser
+	recorder  record.EventRecorder
+}
+
+func NewMachineDiagnosticsReconciler(client client.Client, log logr.Logger, diagnoser MachineDiagnoser, recorder record.EventRecorder) *MachineDiagnosticsReconciler {
+	return &MachineDiagnosticsReconciler{
+		client:    client,
+		log:       log,
+		diagnoser: diagnoser,
+		recorder:  recorder,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MachineDiagnosticsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("machinediagnostics").
+		For(&anywherev1.Cluster{}).
+		Watches(
+			&source.Kind{Type: &clusterv1.Machine{}},
+			handler.EnqueueRequestsFromMapFunc(handlers.CAPIMachineToCluster(mgr.GetClient(), r.log)),
+		).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=tinkerbell.org,resources=workflows,verbs=get;list;watch
+
+func (r *MachineDiagnosticsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("cluster", req.NamespacedName)
+
+	cluster := &anywherev1.Cluster{}
+	if err := r.client.Get(ctx, req.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !cluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	failures, err := r.diagnoser.Diagnose(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("diagnosing machines of cluster %s: %v", cluster.Name, err)
+	}
+
+	statuses := machinediagnostics.Statuses(failures, cluster.Status.MachineFailures)
+	for _, s := range newMachineFailures(statuses, cluster.Status.MachineFailures) {
+		log.Info("Machine failed to bootstrap", "machine", s.Machine, "reason", s.Reason, "message", s.Message)
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, "MachineBootstrapFailed", "Machine %s failed to bootstrap: %s: %s", s.Machine, s.Reason, s.Message)
+	}
+
+	if !reflect.DeepEqual(cluster.Status.MachineFailures, statuses) {
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Status.MachineFailures = statuses
+		if err := r.client.Status().Patch(ctx, cluster, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("patching machine failures status of cluster %s: %v", cluster.Name, err)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: machineDiagnosticsResyncPeriod}, nil
+}
+
+// newMachineFailures returns the failures in current that weren't reported in previous with the same reason.
+func newMachineFailures(current, previous []anywherev1.MachineFailureStatus) []anywherev1.MachineFailureStatus {
+	reported := make(map[string]string, len(previous))
+	for _, p := range previous {
+		reported[p.Machine] = p.Reason
+	}
+
+	var failures []anywherev1.MachineFailureStatus
+	for _, c := range current {
+		if reason, ok := reported[c.Machine]; !ok || reason != c.Reason {
+			failures = append(failures, c)
+		}
+	}
+
+	return failures
+}
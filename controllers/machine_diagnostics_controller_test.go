@@ -0,0 +1,110 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/controllers/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/machinediagnostics"
+)
+
+func TestMachineDiagnosticsReconcilerSetupWithManager(t *testing.T) {
+	client := env.Client()
+	r := controllers.NewMachineDiagnosticsReconciler(client, logf.Log, nil, record.NewFakeRecorder(10))
+
+	g := NewWithT(t)
+	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
+}
+
+func TestMachineDiagnosticsReconcilerReconcile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	since := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Status: anywherev1.ClusterStatus{
+			MachineFailures: []anywherev1.MachineFailureStatus{
+				{Machine: "workload-md-0-1", Reason: machinediagnostics.NodeJoinTimeoutReason, Since: metav1.NewTime(since.Add(-time.Hour))},
+				{Machine: "workload-md-0-2", Reason: "CreateError", Since: metav1.NewTime(since.Add(-time.Hour))},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+	diagnoser := mocks.NewMockMachineDiagnoser(gomock.NewController(t))
+	recorder := record.NewFakeRecorder(10)
+	r := controllers.NewMachineDiagnosticsReconciler(cl, logf.Log, diagnoser, recorder)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	diagnoser.EXPECT().Diagnose(ctx, gomock.AssignableToTypeOf(cluster)).Return([]machinediagnostics.Failure{
+		{Machine: "workload-cp-1", Reason: machinediagnostics.BootstrapFailedReason, Message: "DataSecretGenerationFailed: invalid join config", Since: since},
+		{Machine: "workload-md-0-1", Reason: machinediagnostics.NodeJoinTimeoutReason, Message: "node didn't join", Log: "[ERROR] kubeadm join failed", Since: since},
+	}, nil)
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+	got := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, req.NamespacedName, got)).To(Succeed())
+	g.Expect(got.Status.MachineFailures).To(HaveLen(2))
+	g.Expect(got.Status.MachineFailures[0].Machine).To(Equal("workload-cp-1"))
+	g.Expect(got.Status.MachineFailures[0].Since.UTC()).To(Equal(since))
+	g.Expect(got.Status.MachineFailures[1].Machine).To(Equal("workload-md-0-1"))
+	g.Expect(got.Status.MachineFailures[1].Log).To(Equal("[ERROR] kubeadm join failed"))
+	g.Expect(got.Status.MachineFailures[1].Since.UTC()).To(Equal(since.Add(-time.Hour)))
+
+	g.Expect(recorder.Events).To(HaveLen(1))
+	g.Expect(<-recorder.Events).To(Equal("Warning MachineBootstrapFailed Machine workload-cp-1 failed to bootstrap: BootstrapFailed: DataSecretGenerationFailed: invalid join config"))
+}
+
+func TestMachineDiagnosticsReconcilerReconcileNoFailures(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Status: anywherev1.ClusterStatus{
+			MachineFailures: []anywherev1.MachineFailureStatus{{Machine: "workload-md-0-1", Reason: "CreateError"}},
+		},
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+	diagnoser := mocks.NewMockMachineDiagnoser(gomock.NewController(t))
+	r := controllers.NewMachineDiagnosticsReconciler(cl, logf.Log, diagnoser, record.NewFakeRecorder(10))
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	diagnoser.EXPECT().Diagnose(ctx, gomock.Any()).Return(nil, nil)
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	got := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, req.NamespacedName, got)).To(Succeed())
+	g.Expect(got.Status.MachineFailures).To(BeEmpty())
+}
+
+func TestMachineDiagnosticsReconcilerReconcileDiagnoseError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"}}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+	diagnoser := mocks.NewMockMachineDiagnoser(gomock.NewController(t))
+	r := controllers.NewMachineDiagnosticsReconciler(cl, logf.Log, diagnoser, record.NewFakeRecorder(10))
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	diagnoser.EXPECT().Diagnose(ctx, gomock.Any()).Return(nil, errors.New("listing machines for cluster workload: forbidden"))
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).To(MatchError("diagnosing machines of cluster workload: listing machines for cluster workload: forbidden"))
+}
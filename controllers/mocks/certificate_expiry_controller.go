@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controllers/certificate_expiry_controller.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	certificates "github.com/aws/eks-anywhere/pkg/certificates"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCertificateInspector is a mock of CertificateInspector interface.
+type MockCertificateInspector struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertificateInspectorMockRecorder
+}
+
+// MockCertificateInspectorMockRecorder is the mock recorder for MockCertificateInspector.
+type MockCertificateInspectorMockRecorder struct {
+	mock *MockCertificateInspector
+}
+
+// NewMockCertificateInspector creates a new mock instance.
+func NewMockCertificateInspector(ctrl *gomock.Controller) *MockCertificateInspector {
+	mock := &MockCertificateInspector{ctrl: ctrl}
+	mock.recorder = &MockCertificateInspectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertificateInspector) EXPECT() *MockCertificateInspectorMockRecorder {
+	return m.recorder
+}
+
+// Inspect mocks base method.
+func (m *MockCertificateInspector) Inspect(ctx context.Context, cluster *v1alpha1.Cluster) ([]certificates.Certificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inspect", ctx, cluster)
+	ret0, _ := ret[0].([]certificates.Certificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Inspect indicates an expected call of Inspect.
+func (mr *MockCertificateInspectorMockRecorder) Inspect(ctx, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inspect", reflect.TypeOf((*MockCertificateInspector)(nil).Inspect), ctx, cluster)
+}
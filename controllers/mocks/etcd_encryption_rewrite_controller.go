@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controllers/etcd_encryption_rewrite_controller.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MockEncryptedResourcesRewriter is a mock of EncryptedResourcesRewriter interface.
+type MockEncryptedResourcesRewriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptedResourcesRewriterMockRecorder
+}
+
+// MockEncryptedResourcesRewriterMockRecorder is the mock recorder for MockEncryptedResourcesRewriter.
+type MockEncryptedResourcesRewriterMockRecorder struct {
+	mock *MockEncryptedResourcesRewriter
+}
+
+// NewMockEncryptedResourcesRewriter creates a new mock instance.
+func NewMockEncryptedResourcesRewriter(ctrl *gomock.Controller) *MockEncryptedResourcesRewriter {
+	mock := &MockEncryptedResourcesRewriter{ctrl: ctrl}
+	mock.recorder = &MockEncryptedResourcesRewriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptedResourcesRewriter) EXPECT() *MockEncryptedResourcesRewriterMockRecorder {
+	return m.recorder
+}
+
+// Rewrite mocks base method.
+func (m *MockEncryptedResourcesRewriter) Rewrite(ctx context.Context, cluster client.ObjectKey, resource string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rewrite", ctx, cluster, resource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rewrite indicates an expected call of Rewrite.
+func (mr *MockEncryptedResourcesRewriterMockRecorder) Rewrite(ctx, cluster, resource interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rewrite", reflect.TypeOf((*MockEncryptedResourcesRewriter)(nil).Rewrite), ctx, cluster, resource)
+}
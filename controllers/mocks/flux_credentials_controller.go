@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controllers/flux_credentials_controller.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	github "github.com/aws/eks-anywhere/pkg/git/providers/github"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGithubAppTokenIssuer is a mock of GithubAppTokenIssuer interface.
+type MockGithubAppTokenIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockGithubAppTokenIssuerMockRecorder
+}
+
+// MockGithubAppTokenIssuerMockRecorder is the mock recorder for MockGithubAppTokenIssuer.
+type MockGithubAppTokenIssuerMockRecorder struct {
+	mock *MockGithubAppTokenIssuer
+}
+
+// NewMockGithubAppTokenIssuer creates a new mock instance.
+func NewMockGithubAppTokenIssuer(ctrl *gomock.Controller) *MockGithubAppTokenIssuer {
+	mock := &MockGithubAppTokenIssuer{ctrl: ctrl}
+	mock.recorder = &MockGithubAppTokenIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGithubAppTokenIssuer) EXPECT() *MockGithubAppTokenIssuerMockRecorder {
+	return m.recorder
+}
+
+// InstallationToken mocks base method.
+func (m *MockGithubAppTokenIssuer) InstallationToken(ctx context.Context, appID, installationID int64, privateKey []byte) (*github.InstallationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallationToken", ctx, appID, installationID, privateKey)
+	ret0, _ := ret[0].(*github.InstallationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstallationToken indicates an expected call of InstallationToken.
+func (mr *MockGithubAppTokenIssuerMockRecorder) InstallationToken(ctx, appID, installationID, privateKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallationToken", reflect.TypeOf((*MockGithubAppTokenIssuer)(nil).InstallationToken), ctx, appID, installationID, privateKey)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controllers/kubelet_csr_controller.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	kubernetes "k8s.io/client-go/kubernetes"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MockClientsetBuilder is a mock of ClientsetBuilder interface.
+type MockClientsetBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientsetBuilderMockRecorder
+}
+
+// MockClientsetBuilderMockRecorder is the mock recorder for MockClientsetBuilder.
+type MockClientsetBuilderMockRecorder struct {
+	mock *MockClientsetBuilder
+}
+
+// NewMockClientsetBuilder creates a new mock instance.
+func NewMockClientsetBuilder(ctrl *gomock.Controller) *MockClientsetBuilder {
+	mock := &MockClientsetBuilder{ctrl: ctrl}
+	mock.recorder = &MockClientsetBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientsetBuilder) EXPECT() *MockClientsetBuilderMockRecorder {
+	return m.recorder
+}
+
+// Clientset mocks base method.
+func (m *MockClientsetBuilder) Clientset(ctx context.Context, cluster client.ObjectKey) (kubernetes.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clientset", ctx, cluster)
+	ret0, _ := ret[0].(kubernetes.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clientset indicates an expected call of Clientset.
+func (mr *MockClientsetBuilderMockRecorder) Clientset(ctx, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clientset", reflect.TypeOf((*MockClientsetBuilder)(nil).Clientset), ctx, cluster)
+}
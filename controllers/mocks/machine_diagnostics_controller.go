@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controllers/machine_diagnostics_controller.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	machinediagnostics "github.com/aws/eks-anywhere/pkg/machinediagnostics"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMachineDiagnoser is a mock of MachineDiagnoser interface.
+type MockMachineDiagnoser struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachineDiagnoserMockRecorder
+}
+
+// MockMachineDiagnoserMockRecorder is the mock recorder for MockMachineDiagnoser.
+type MockMachineDiagnoserMockRecorder struct {
+	mock *MockMachineDiagnoser
+}
+
+// NewMockMachineDiagnoser creates a new mock instance.
+func NewMockMachineDiagnoser(ctrl *gomock.Controller) *MockMachineDiagnoser {
+	mock := &MockMachineDiagnoser{ctrl: ctrl}
+	mock.recorder = &MockMachineDiagnoserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachineDiagnoser) EXPECT() *MockMachineDiagnoserMockRecorder {
+	return m.recorder
+}
+
+// Diagnose mocks base method.
+func (m *MockMachineDiagnoser) Diagnose(ctx context.Context, cluster *v1alpha1.Cluster) ([]machinediagnostics.Failure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diagnose", ctx, cluster)
+	ret0, _ := ret[0].([]machinediagnostics.Failure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diagnose indicates an expected call of Diagnose.
+func (mr *MockMachineDiagnoserMockRecorder) Diagnose(ctx, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diagnose", reflect.TypeOf((*MockMachineDiagnoser)(nil).Diagnose), ctx, cluster)
+}
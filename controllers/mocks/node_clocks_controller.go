@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controllers/node_clocks_controller.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	timesync "github.com/aws/eks-anywhere/pkg/timesync"
+	gomock "github.com/golang/mock/gomock"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MockNodeClocksChecker is a mock of NodeClocksChecker interface.
+type MockNodeClocksChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeClocksCheckerMockRecorder
+}
+
+// MockNodeClocksCheckerMockRecorder is the mock recorder for MockNodeClocksChecker.
+type MockNodeClocksCheckerMockRecorder struct {
+	mock *MockNodeClocksChecker
+}
+
+// NewMockNodeClocksChecker creates a new mock instance.
+func NewMockNodeClocksChecker(ctrl *gomock.Controller) *MockNodeClocksChecker {
+	mock := &MockNodeClocksChecker{ctrl: ctrl}
+	mock.recorder = &MockNodeClocksCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeClocksChecker) EXPECT() *MockNodeClocksCheckerMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockNodeClocksChecker) Check(ctx context.Context, cluster client.ObjectKey) (*timesync.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, cluster)
+	ret0, _ := ret[0].(*timesync.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockNodeClocksCheckerMockRecorder) Check(ctx, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockNodeClocksChecker)(nil).Check), ctx, cluster)
+}
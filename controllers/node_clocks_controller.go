@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/timesync"
+)
+
+// nodeClocksResyncPeriod is how often the node clocks of a cluster are checked again, since clocks
+// drift without changing any object.
+const nodeClocksResyncPeriod = 10 * time.Minute
+
+// NodeClocksChecker measures the clock skew of the nodes of a cluster.
+type NodeClocksChecker interface {
+	Check(ctx context.Context, cluster client.ObjectKey) (*timesync.Report, error)
+}
+
+// NodeClocksReconciler reports in the NodeClocksSynchronized condition of the cluster whether the clocks
+// of its nodes are synchronized. It never blocks the cluster reconciliation.
+type NodeClocksReconciler struct {
+	client  client.Client
+	log     logr.Logger
+	checker NodeClocksChecker
+}
+
+func NewNodeClocksReconciler(client client.Client, log logr.Logger, checker NodeClocksChecker) *NodeClocksReconciler {
+	return &NodeClocksReconciler{
+		client:  client,
+		log:     log,
+		checker: checker,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeClocksReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("nodeclocks").
+		For(&anywherev1.Cluster{}).
+		Complete(r)
+}
+
+func (r *NodeClocksReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("cluster", req.NamespacedName)
+
+	cluster := &anywherev1.Cluster{}
+	if err := r.client.Get(ctx, req.NamespacedName, cluster); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !cluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	original := cluster.DeepCopy()
+	report, err := r.checker.Check(ctx, controller.CapiClusterObjectKey(cluster))
+	switch {
+	case apierrors.IsNotFound(err):
+		log.Info("Kubeconfig for cluster not available yet, requeuing")
+		return ctrl.Result{RequeueAfter: nodeClocksResyncPeriod}, nil
+	case err != nil:
+		log.Error(err, "Failed checking the node clocks")
+		conditions.MarkUnknown(cluster, anywherev1.NodeClocksSynchronizedCondition, anywherev1.NodeClocksCheckFailedReason, "%v", err)
+	case report.Err() != nil:
+		conditions.MarkFalse(cluster, anywherev1.NodeClocksSynchronizedCondition, anywherev1.NodeClocksSkewedReason, clusterv1.ConditionSeverityWarning, "%v", report.Err())
+	default:
+		conditions.MarkTrue(cluster, anywherev1.NodeClocksSynchronizedCondition)
+	}
+
+	if !reflect.DeepEqual(original.Status.Conditions, cluster.Status.Conditions) {
+		if err := r.client.Status().Patch(ctx, cluster, client.MergeFrom(original)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("patching node clocks condition of cluster %s: %v", cluster.Name, err)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: nodeClocksResyncPeriod}, nil
+}
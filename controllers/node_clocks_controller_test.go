@@ -0,0 +1,117 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/controllers/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/timesync"
+)
+
+type nodeClocksReconcilerTest struct {
+	*WithT
+	ctx        context.Context
+	client     client.Client
+	checker    *mocks.MockNodeClocksChecker
+	reconciler *controllers.NodeClocksReconciler
+	req        reconcile.Request
+	capiKey    client.ObjectKey
+}
+
+func newNodeClocksReconcilerTest(t *testing.T) *nodeClocksReconcilerTest {
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"}}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+	checker := mocks.NewMockNodeClocksChecker(gomock.NewController(t))
+	return &nodeClocksReconcilerTest{
+		WithT:      NewWithT(t),
+		ctx:        context.Background(),
+		client:     cl,
+		checker:    checker,
+		reconciler: controllers.NewNodeClocksReconciler(cl, logf.Log, checker),
+		req:        reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}},
+		capiKey:    client.ObjectKey{Name: "workload", Namespace: "eksa-system"},
+	}
+}
+
+func (tt *nodeClocksReconcilerTest) condition() *clusterv1.Condition {
+	got := &anywherev1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, tt.req.NamespacedName, got)).To(Succeed())
+	return conditions.Get(got, anywherev1.NodeClocksSynchronizedCondition)
+}
+
+func TestNodeClocksReconcilerSetupWithManager(t *testing.T) {
+	client := env.Client()
+	r := controllers.NewNodeClocksReconciler(client, logf.Log, nil)
+
+	g := NewWithT(t)
+	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
+}
+
+func TestNodeClocksReconcilerReconcileSynchronized(t *testing.T) {
+	tt := newNodeClocksReconcilerTest(t)
+	tt.checker.EXPECT().Check(tt.ctx, tt.capiKey).Return(&timesync.Report{
+		MaxSkew: 2 * time.Second,
+		Nodes:   []timesync.NodeSkew{{Node: "node-1", Skew: 500 * time.Millisecond}},
+	}, nil)
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, tt.req)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+	tt.Expect(tt.condition().Status).To(Equal(corev1.ConditionTrue))
+}
+
+func TestNodeClocksReconcilerReconcileSkewed(t *testing.T) {
+	tt := newNodeClocksReconcilerTest(t)
+	tt.checker.EXPECT().Check(tt.ctx, tt.capiKey).Return(&timesync.Report{
+		MaxSkew: 2 * time.Second,
+		Nodes:   []timesync.NodeSkew{{Node: "node-1", Skew: -30 * time.Second}},
+	}, nil)
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, tt.req)
+	tt.Expect(err).NotTo(HaveOccurred())
+	c := tt.condition()
+	tt.Expect(c.Status).To(Equal(corev1.ConditionFalse))
+	tt.Expect(c.Severity).To(Equal(clusterv1.ConditionSeverityWarning))
+	tt.Expect(c.Reason).To(Equal(anywherev1.NodeClocksSkewedReason))
+	tt.Expect(c.Message).To(Equal("node clocks not synchronized within 2s: node-1: clock skew -30s"))
+}
+
+func TestNodeClocksReconcilerReconcileCheckError(t *testing.T) {
+	tt := newNodeClocksReconcilerTest(t)
+	tt.checker.EXPECT().Check(tt.ctx, tt.capiKey).Return(nil, errors.New("listing nodes: forbidden"))
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, tt.req)
+	tt.Expect(err).NotTo(HaveOccurred())
+	c := tt.condition()
+	tt.Expect(c.Status).To(Equal(corev1.ConditionUnknown))
+	tt.Expect(c.Reason).To(Equal(anywherev1.NodeClocksCheckFailedReason))
+	tt.Expect(c.Message).To(Equal("listing nodes: forbidden"))
+}
+
+func TestNodeClocksReconcilerReconcileKubeconfigNotFound(t *testing.T) {
+	tt := newNodeClocksReconcilerTest(t)
+	tt.checker.EXPECT().Check(tt.ctx, tt.capiKey).Return(nil, apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "workload-kubeconfig"))
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, tt.req)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+	tt.Expect(tt.condition()).To(BeNil())
+}
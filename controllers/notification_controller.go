@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/notifications"
+)
+
+// notificationResyncPeriod is how often the state of a cluster is re-evaluated. Machine health and
+// the time left before certificates expire change without the EKS-A cluster object being updated.
+const notificationResyncPeriod = time.Minute
+
+// Notifier sends notifications for cluster events.
+type Notifier interface {
+	Notify(ctx context.Context, e notifications.Event) error
+}
+
+// NotificationReconciler watches clusters and sends notifications for their lifecycle and health transitions.
+// The certificate expiry is read from the cluster status reported by the CertificateExpiryReconciler, and
+// the state last notified is persisted in the cluster status.
+type NotificationReconciler struct {
+	client   client.Client
+	log      logr.Logger
+	watcher  *notifications.Watcher
+	notifier Notifier
+}
+
+func NewNotificationReconciler(client client.Client, log logr.Logger, watcher *notifications.Watcher, notifier Notifier) *NotificationReconciler {
+	return &NotificationReconciler{
+		client:   client,
+		log:      log,
+		watcher:  watcher,
+		notifier: notifier,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NotificationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("notification").
+		For(&anywherev1.Cluster{}).
+		Complete(r)
+}
+
+func (r *NotificationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("cluster", req.NamespacedName)
+
+	cluster := &anywherev1.Cluster{}
+	if err := r.client.Get(ctx, req.NamespacedName, cluster); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !cluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	snapshot, err := r.snapshot(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	original := cluster.DeepCopy()
+	for _, e := range r.watcher.Observe(*snapshot) {
+		log.Info("Sending notification", "event", e.Type)
+		if err := r.notifier.Notify(ctx, e); err != nil {
+			// Delivery is best effort, the transition is not re-sent on the next reconciliation.
+			log.Error(err, "Failed sending notification", "event", e.Type)
+		}
+	}
+
+	if !reflect.DeepEqual(original.Status.Notifications, cluster.Status.Notifications) {
+		if err := r.client.Status().Patch(ctx, cluster, client.MergeFrom(original)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("patching notifications status of cluster %s: %v", cluster.Name, err)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: notificationResyncPeriod}, nil
+}
+
+func (r *NotificationReconciler) snapshot(ctx context.Context, cluster *anywherev1.Cluster) (*notifications.Snapshot, error) {
+	s := &notifications.Snapshot{
+		Cluster: cluster,
+		Time:    time.Now(),
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	key := client.ObjectKey{Name: cluster.Name, Namespace: constants.EksaSystemNamespace}
+	if err := r.client.Get(ctx, key, kcp); err == nil {
+		s.ControlPlane = kcp
+	} else if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("getting control plane for cluster %s: %v", cluster.Name, err)
+	}
+
+	listOpts := []client.ListOption{
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{clusterv1.ClusterLabelName: cluster.Name},
+	}
+
+	mds := &clusterv1.MachineDeploymentList{}
+	if err := r.client.List(ctx, mds, listOpts...); err != nil {
+		return nil, fmt.Errorf("listing machine deployments for cluster %s: %v", cluster.Name, err)
+	}
+	s.MachineDeployments = mds.Items
+
+	machines := &clusterv1.MachineList{}
+	if err := r.client.List(ctx, machines, listOpts...); err != nil {
+		return nil, fmt.Errorf("listing machines for cluster %s: %v", cluster.Name, err)
+	}
+	s.Machines = machines.Items
+
+	return s, nil
+}
@@ -0,0 +1,163 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/controllers/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/notifications"
+)
+
+func notificationTestObjects(updatedReplicas int32) []runtime.Object {
+	version := "v1.23.7-eks-1-23-4"
+	return []runtime.Object{
+		&anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+			Spec:       anywherev1.ClusterSpec{KubernetesVersion: anywherev1.Kube123},
+		},
+		&controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+			Spec:       controlplanev1.KubeadmControlPlaneSpec{Version: version},
+			Status: controlplanev1.KubeadmControlPlaneStatus{
+				Version:         &version,
+				Replicas:        3,
+				UpdatedReplicas: updatedReplicas,
+			},
+		},
+		&clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-md-0",
+				Namespace: constants.EksaSystemNamespace,
+				Labels:    map[string]string{clusterv1.ClusterLabelName: "workload"},
+			},
+		},
+	}
+}
+
+func TestNotificationReconcilerSetupWithManager(t *testing.T) {
+	client := env.Client()
+	r := controllers.NewNotificationReconciler(client, logf.Log, notifications.NewWatcher(), nil)
+
+	g := NewWithT(t)
+	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
+}
+
+func TestNotificationReconcilerReconcileUpgradeStarted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	notifier := mocks.NewMockNotifier(gomock.NewController(t))
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	cl := fake.NewClientBuilder().WithRuntimeObjects(notificationTestObjects(3)...).Build()
+	r := controllers.NewNotificationReconciler(cl, logf.Log, notifications.NewWatcher(), notifier)
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	g.Expect(cl.Get(ctx, types.NamespacedName{Name: "workload", Namespace: constants.EksaSystemNamespace}, kcp)).To(Succeed())
+	kcp.Status.UpdatedReplicas = 1
+	g.Expect(cl.Update(ctx, kcp)).To(Succeed())
+
+	notifier.EXPECT().Notify(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e notifications.Event) error {
+		g.Expect(e.Type).To(Equal(notifications.UpgradeStarted))
+		g.Expect(e.Cluster).To(Equal("workload"))
+		return errors.New("webhook unavailable")
+	})
+	// A new reconciler, like after a restart or a leader change, picks up the state reported in the status.
+	r = controllers.NewNotificationReconciler(cl, logf.Log, notifications.NewWatcher(), notifier)
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cluster := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, req.NamespacedName, cluster)).To(Succeed())
+	g.Expect(cluster.Status.Notifications).To(Equal(&anywherev1.NotificationStatus{Upgrading: true}))
+
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestNotificationReconcilerReconcileClusterNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	notifier := mocks.NewMockNotifier(gomock.NewController(t))
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+
+	cl := fake.NewClientBuilder().Build()
+	r := controllers.NewNotificationReconciler(cl, logf.Log, notifications.NewWatcher(), notifier)
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(reconcile.Result{}))
+}
+
+func TestNotificationReconcilerReconcileCertificateExpiring(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	notifier := mocks.NewMockNotifier(gomock.NewController(t))
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "workload", Namespace: "default"}}
+	expiry := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	objs := notificationTestObjects(3)
+	objs[0].(*anywherev1.Cluster).Status.Certificates = []anywherev1.CertificateStatus{
+		{
+			Component: certificates.ControlPlaneComponent,
+			Name:      certificates.APIServerCertificate,
+			Machine:   "workload-cp-1",
+			ExpiresAt: metav1.NewTime(expiry),
+		},
+	}
+	objs = append(objs,
+		&clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-cp-1",
+				Namespace: constants.EksaSystemNamespace,
+				Labels: map[string]string{
+					clusterv1.ClusterLabelName:             "workload",
+					clusterv1.MachineControlPlaneLabelName: "",
+				},
+			},
+		},
+		&clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-cp-2",
+				Namespace: constants.EksaSystemNamespace,
+				Labels: map[string]string{
+					clusterv1.ClusterLabelName:             "workload",
+					clusterv1.MachineControlPlaneLabelName: "",
+				},
+			},
+		},
+	)
+	notifier.EXPECT().Notify(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e notifications.Event) error {
+		g.Expect(e.Type).To(Equal(notifications.CertificateExpiring))
+		g.Expect(e.Details).To(HaveKeyWithValue("machine", "workload-cp-1"))
+		g.Expect(e.Details).To(HaveKeyWithValue("expiry", expiry.UTC().Format(time.RFC3339)))
+		return nil
+	})
+
+	cl := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	r := controllers.NewNotificationReconciler(cl, logf.Log, notifications.NewWatcher(), notifier)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cluster := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, req.NamespacedName, cluster)).To(Succeed())
+	g.Expect(cluster.Status.Notifications.CertificateExpiringMachines).To(ConsistOf("workload-cp-1"))
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -13,6 +14,7 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	anywhereTypes "github.com/aws/eks-anywhere/pkg/types"
@@ -207,9 +209,74 @@ func (cor *clusterReconciler) Reconcile(ctx context.Context, objectKey types.Nam
 			}
 		}
 	}
+	return cor.checkAndApplyTemplates(ctx, cs, resources, dryRun)
+}
+
+// checkAndApplyTemplates applies the templated resources once the checks of the changes they make pass.
+func (cor *clusterReconciler) checkAndApplyTemplates(ctx context.Context, cs *anywherev1.Cluster, resources []*unstructured.Unstructured, dryRun bool) error {
+	if !dryRun {
+		if err := cor.checkControlPlaneRollout(ctx, cs, resources); err != nil {
+			return err
+		}
+	}
+
 	return cor.applyTemplates(ctx, cs, resources, dryRun)
 }
 
+// checkControlPlaneRollout fails if the templated KubeadmControlPlane references a new machine template,
+// rolling out new control plane machines, while the control plane can't safely do it. The control plane
+// machine templates of self-managed clusters aren't reconciled, so they never roll out.
+func (cor *clusterReconciler) checkControlPlaneRollout(ctx context.Context, cs *anywherev1.Cluster, resources []*unstructured.Unstructured) error {
+	machineTemplate, err := controlPlaneMachineTemplateName(resources)
+	if err != nil || machineTemplate == "" || cs.IsSelfManaged() {
+		return err
+	}
+
+	kcp, err := cor.ControlPlane(ctx, cs)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if kcp.Spec.MachineTemplate.InfrastructureRef.Name == machineTemplate {
+		return nil
+	}
+
+	etcdadmCluster, err := cor.externalEtcdCluster(ctx, cs)
+	if err != nil {
+		return err
+	}
+
+	if err := clusterapi.ControlPlaneRolloutReady(kcp, etcdadmCluster); err != nil {
+		return fmt.Errorf("waiting to roll out control plane machine template %s: %v", machineTemplate, err)
+	}
+
+	cor.Log.Info("Rolling out new control plane machines", "machineTemplate", machineTemplate)
+	return nil
+}
+
+// externalEtcdCluster returns the etcd cluster of clusters with external etcd, nil for clusters with stacked etcd.
+func (cor *clusterReconciler) externalEtcdCluster(ctx context.Context, cs *anywherev1.Cluster) (*etcdv1.EtcdadmCluster, error) {
+	if cs.Spec.ExternalEtcdConfiguration == nil {
+		return nil, nil
+	}
+	return cor.Etcd(ctx, cs)
+}
+
+// controlPlaneMachineTemplateName returns the name of the machine template referenced by the
+// templated KubeadmControlPlane, empty if there isn't one.
+func controlPlaneMachineTemplateName(resources []*unstructured.Unstructured) (string, error) {
+	for _, resource := range resources {
+		if resource.GetKind() == "KubeadmControlPlane" {
+			name, _, err := unstructured.NestedString(resource.Object, "spec", "machineTemplate", "infrastructureRef", "name")
+			return name, err
+		}
+	}
+	return "", nil
+}
+
 func (cor *clusterReconciler) applyTemplates(ctx context.Context, cs *anywherev1.Cluster, resources []*unstructured.Unstructured, dryRun bool) error {
 	for _, resource := range resources {
 		kind := resource.GetKind()
@@ -0,0 +1,101 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/controllers/resource/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func kubeadmControlPlaneTemplate(machineTemplate string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": controlplanev1.GroupVersion.String(),
+		"kind":       "KubeadmControlPlane",
+		"spec": map[string]interface{}{
+			"machineTemplate": map[string]interface{}{
+				"infrastructureRef": map[string]interface{}{
+					"name": machineTemplate,
+				},
+			},
+		},
+	}}
+}
+
+func currentKubeadmControlPlane(ready int32) *controlplanev1.KubeadmControlPlane {
+	replicas := int32(3)
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	kcp.Name = "workload"
+	kcp.Spec.Replicas = &replicas
+	kcp.Spec.MachineTemplate.InfrastructureRef.Name = "workload-control-plane-template-1"
+	kcp.Status.Replicas = replicas
+	kcp.Status.UpdatedReplicas = replicas
+	kcp.Status.ReadyReplicas = ready
+	return kcp
+}
+
+func workloadCluster() *anywherev1.Cluster {
+	cluster := &anywherev1.Cluster{}
+	cluster.Name = "workload"
+	cluster.Spec.ManagementCluster.Name = "management"
+	return cluster
+}
+
+func TestCheckControlPlaneRollout(t *testing.T) {
+	tests := []struct {
+		name            string
+		cluster         *anywherev1.Cluster
+		machineTemplate string
+		current         *controlplanev1.KubeadmControlPlane
+		wantErr         string
+	}{
+		{
+			name:            "self-managed cluster",
+			cluster:         &anywherev1.Cluster{},
+			machineTemplate: "workload-control-plane-template-2",
+		},
+		{
+			name:            "same machine template",
+			cluster:         workloadCluster(),
+			machineTemplate: "workload-control-plane-template-1",
+			current:         currentKubeadmControlPlane(2),
+		},
+		{
+			name:            "new machine template and ready control plane",
+			cluster:         workloadCluster(),
+			machineTemplate: "workload-control-plane-template-2",
+			current:         currentKubeadmControlPlane(3),
+		},
+		{
+			name:            "new machine template and not ready control plane",
+			cluster:         workloadCluster(),
+			machineTemplate: "workload-control-plane-template-2",
+			current:         currentKubeadmControlPlane(2),
+			wantErr:         "waiting to roll out control plane machine template workload-control-plane-template-2: kubeadm control plane workload is not ready: 2 of 3 machines ready",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			fetcher := mocks.NewMockResourceFetcher(gomock.NewController(t))
+			if tt.current != nil {
+				fetcher.EXPECT().ControlPlane(ctx, tt.cluster).Return(tt.current, nil)
+			}
+			cor := &clusterReconciler{Log: logr.Discard(), ResourceFetcher: fetcher}
+
+			err := cor.checkControlPlaneRollout(ctx, tt.cluster, []*unstructured.Unstructured{kubeadmControlPlaneTemplate(tt.machineTemplate)})
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
@@ -136,7 +136,7 @@ func (r *VsphereTemplate) TemplateResources(ctx context.Context, eksaCluster *an
 		if err != nil {
 			return nil, err
 		}
-		updateEtcdTemplate := vsphere.AnyImmutableFieldChanged(oldVdc, &vdc, oldEtcdVmc, &etcdVmc)
+		updateEtcdTemplate := vsphere.AnyEtcdImmutableFieldChanged(oldVdc, &vdc, oldEtcdVmc, &etcdVmc)
 		etcd, err := r.Etcd(ctx, eksaCluster)
 		if err != nil {
 			return nil, err
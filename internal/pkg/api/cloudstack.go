@@ -6,11 +6,10 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/yaml"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
-	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
 type CloudStackConfig struct {
@@ -20,38 +19,28 @@ type CloudStackConfig struct {
 
 type CloudStackFiller func(config CloudStackConfig)
 
-func AutoFillCloudStackProvider(filename string, fillers ...CloudStackFiller) ([]byte, error) {
-	config, err := cluster.ParseConfigFromFile(filename)
-	if err != nil {
-		return nil, err
-	}
-
-	cloudStackConfig := CloudStackConfig{
-		datacenterConfig: config.CloudStackDatacenter,
-		machineConfigs:   config.CloudStackMachineConfigs,
+func (c *CloudStackConfig) load(config *cluster.Config) error {
+	if config.CloudStackDatacenter == nil {
+		return fmt.Errorf("cluster config doesn't have a %s", anywherev1.CloudStackDatacenterKind)
 	}
 
-	for _, f := range fillers {
-		f(cloudStackConfig)
+	c.datacenterConfig = config.CloudStackDatacenter
+	c.machineConfigs = config.CloudStackMachineConfigs
+	// Fillers can add new machine configs, so the map can't be nil.
+	if c.machineConfigs == nil {
+		c.machineConfigs = map[string]*anywherev1.CloudStackMachineConfig{}
 	}
+	return nil
+}
 
-	resources := make([]interface{}, 0, len(cloudStackConfig.machineConfigs)+1)
-	resources = append(resources, cloudStackConfig.datacenterConfig)
-	for _, m := range cloudStackConfig.machineConfigs {
-		resources = append(resources, m)
-	}
-
-	yamlResources := make([][]byte, 0, len(resources))
-	for _, r := range resources {
-		yamlContent, err := yaml.Marshal(r)
-		if err != nil {
-			return nil, fmt.Errorf("marshalling cloudstack resource: %v", err)
-		}
-
-		yamlResources = append(yamlResources, yamlContent)
-	}
+func (c *CloudStackConfig) objects() []kubernetes.Object {
+	objs := make([]kubernetes.Object, 0, len(c.machineConfigs)+1)
+	objs = append(objs, c.datacenterConfig)
+	return appendObjects(objs, c.machineConfigs)
+}
 
-	return templater.AppendYamlResources(yamlResources...), nil
+func AutoFillCloudStackProvider(filename string, fillers ...CloudStackFiller) ([]byte, error) {
+	return AutoFill[CloudStackConfig](filename, fillersOf(fillers)...)
 }
 
 func WithCloudStackComputeOfferingForAllMachines(value string) CloudStackFiller {
@@ -3,14 +3,18 @@ package api
 import (
 	"fmt"
 	"io/ioutil"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
 )
 
 type ClusterFiller func(c *anywherev1.Cluster)
@@ -24,6 +28,8 @@ func AutoFillClusterFromFile(filename string, fillers ...ClusterFiller) ([]byte,
 	return AutoFillClusterFromYaml(content, fillers...)
 }
 
+// AutoFillClusterFromYaml applies the fillers to the Cluster in yamlContent and returns it as yaml.
+// The order of the fields and the comments of the original Cluster document are preserved.
 func AutoFillClusterFromYaml(yamlContent []byte, fillers ...ClusterFiller) ([]byte, error) {
 	clusterConfig, err := anywherev1.GetClusterConfigFromContent(yamlContent)
 	if err != nil {
@@ -39,7 +45,30 @@ func AutoFillClusterFromYaml(yamlContent []byte, fillers ...ClusterFiller) ([]by
 		return nil, fmt.Errorf("marshalling cluster config: %v", err)
 	}
 
-	return clusterOutput, nil
+	merged, err := yamlutil.MergeLayout(clusterDocument(yamlContent), clusterOutput)
+	if err != nil {
+		return nil, fmt.Errorf("preserving cluster config layout: %v", err)
+	}
+
+	// The merge drops empty values, which can be meaningful, like an empty cilium config.
+	mergedConfig := &anywherev1.Cluster{}
+	if err := yaml.Unmarshal(merged, mergedConfig); err != nil || !equality.Semantic.DeepEqual(mergedConfig, clusterConfig) {
+		return clusterOutput, nil
+	}
+
+	return merged, nil
+}
+
+// clusterDocument returns the Cluster document of a multi-document yaml manifest.
+func clusterDocument(yamlContent []byte) []byte {
+	for _, doc := range yamlutil.SplitDocuments(yamlContent) {
+		obj := &metav1.TypeMeta{}
+		if err := yaml.Unmarshal(doc.Content, obj); err == nil && obj.Kind == anywherev1.ClusterKind {
+			return doc.Content
+		}
+	}
+
+	return nil
 }
 
 func WithKubernetesVersion(v anywherev1.KubernetesVersion) ClusterFiller {
@@ -197,6 +226,29 @@ func WithAWSIamIdentityProviderRef(name string) ClusterFiller {
 	}
 }
 
+// WithEtcdEncryption adds an etcd encryption config that encrypts the resources at rest with the
+// KMS plugins, the first one encrypting the new writes. The resources default to secrets when empty.
+func WithEtcdEncryption(resources []string, kmsPlugins ...anywherev1.KMS) ClusterFiller {
+	return func(c *anywherev1.Cluster) {
+		config := anywherev1.EtcdEncryption{Resources: resources}
+		for _, kms := range kmsPlugins {
+			kms := kms
+			config.Providers = append(config.Providers, anywherev1.EtcdEncryptionProvider{KMS: &kms})
+		}
+		c.Spec.EtcdEncryption = append(c.Spec.EtcdEncryption, config)
+	}
+}
+
+// WithEtcdEncryptionKeyRotationPeriod sets how often the resources of all the etcd encryption configs
+// are rewritten to be encrypted with the current key.
+func WithEtcdEncryptionKeyRotationPeriod(period time.Duration) ClusterFiller {
+	return func(c *anywherev1.Cluster) {
+		for i := range c.Spec.EtcdEncryption {
+			c.Spec.EtcdEncryption[i].KeyRotationPeriod = &metav1.Duration{Duration: period}
+		}
+	}
+}
+
 func RemoveAllWorkerNodeGroups() ClusterFiller {
 	return func(c *anywherev1.Cluster) {
 		c.Spec.WorkerNodeGroupConfigurations = make([]anywherev1.WorkerNodeGroupConfiguration, 0)
@@ -2,8 +2,10 @@ package api_test
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/internal/pkg/api"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -101,3 +103,88 @@ func TestWithWorkerNodeCount(t *testing.T) {
 		})
 	}
 }
+
+func TestWithEtcdEncryption(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &anywherev1.Cluster{}
+	kms := []anywherev1.KMS{
+		{Name: "new-key", SocketListenAddress: "unix:///var/run/kmsplugin/new.sock"},
+		{Name: "old-key", SocketListenAddress: "unix:///var/run/kmsplugin/old.sock"},
+	}
+
+	api.WithEtcdEncryption(nil, kms...)(cluster)
+	api.WithEtcdEncryption([]string{"configmaps"}, kms[1])(cluster)
+
+	g.Expect(cluster.Spec.EtcdEncryption).To(Equal([]anywherev1.EtcdEncryption{
+		{
+			Providers: []anywherev1.EtcdEncryptionProvider{{KMS: &kms[0]}, {KMS: &kms[1]}},
+		},
+		{
+			Providers: []anywherev1.EtcdEncryptionProvider{{KMS: &kms[1]}},
+			Resources: []string{"configmaps"},
+		},
+	}))
+}
+
+func TestWithEtcdEncryptionKeyRotationPeriod(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &anywherev1.Cluster{}
+	kms := anywherev1.KMS{Name: "key", SocketListenAddress: "unix:///var/run/kmsplugin/socket.sock"}
+
+	api.WithEtcdEncryption(nil, kms)(cluster)
+	api.WithEtcdEncryption([]string{"configmaps"}, kms)(cluster)
+	api.WithEtcdEncryptionKeyRotationPeriod(720 * time.Hour)(cluster)
+
+	for _, config := range cluster.Spec.EtcdEncryption {
+		g.Expect(config.KeyRotationPeriod).To(Equal(&metav1.Duration{Duration: 720 * time.Hour}))
+	}
+}
+
+func TestAutoFillClusterFromYamlPreservesLayout(t *testing.T) {
+	g := NewWithT(t)
+	content := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereDatacenterConfig
+metadata:
+  name: my-datacenter
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  # Upgraded by the tests
+  kubernetesVersion: "1.23"
+  workerNodeGroupConfigurations:
+    - name: md-0
+      count: 1 # one is enough
+`)
+
+	got, err := api.AutoFillClusterFromYaml(content, api.WithKubernetesVersion(anywherev1.Kube124))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(got)).To(Equal(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  # Upgraded by the tests
+  kubernetesVersion: "1.24"
+  workerNodeGroupConfigurations:
+    - name: md-0
+      count: 1 # one is enough
+`))
+}
+
+func TestAutoFillClusterFromYamlUnknownField(t *testing.T) {
+	g := NewWithT(t)
+	content := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  workerNodeGroupConfiguration:
+    - name: md-0
+`)
+
+	_, err := api.AutoFillClusterFromYaml(content)
+	g.Expect(err).To(MatchError(ContainSubstring(`line 6, column 3: unknown field "spec.workerNodeGroupConfiguration", did you mean "workerNodeGroupConfigurations"?`)))
+}
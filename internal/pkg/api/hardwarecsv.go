@@ -0,0 +1,201 @@
+package api
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/gocarina/gocsv"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// HardwareFiller mutates a machine of a Tinkerbell hardware inventory.
+type HardwareFiller func(h *Hardware) error
+
+// NewHardwareInventory generates count machines applying, in order, the fillers to each of them.
+func NewHardwareInventory(count int, fillers ...HardwareFiller) ([]*Hardware, error) {
+	inventory := make([]*Hardware, 0, count)
+	for i := 0; i < count; i++ {
+		inventory = append(inventory, &Hardware{Labels: hardware.Labels{}})
+	}
+
+	if err := AutoFillHardware(inventory, fillers...); err != nil {
+		return nil, err
+	}
+
+	return inventory, nil
+}
+
+// AutoFillHardware applies, in order, the fillers to each machine of the inventory.
+func AutoFillHardware(inventory []*Hardware, fillers ...HardwareFiller) error {
+	for _, h := range inventory {
+		if h.Labels == nil {
+			h.Labels = hardware.Labels{}
+		}
+		for _, f := range fillers {
+			if err := f(h); err != nil {
+				return fmt.Errorf("failed to apply hardware filler: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// AutoFillHardwareCSV applies the fillers to the machines of a hardware csv file and returns the filled csv.
+func AutoFillHardwareCSV(filename string, fillers ...HardwareFiller) ([]byte, error) {
+	inventory, err := NewHardwareSliceFromFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := AutoFillHardware(inventory, fillers...); err != nil {
+		return nil, err
+	}
+
+	csv, err := gocsv.MarshalBytes(&inventory)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling hardware csv: %v", err)
+	}
+
+	return csv, nil
+}
+
+// WithHardwareSelector applies the fillers only to the machines with all the labels.
+func WithHardwareSelector(labels map[string]string, fillers ...HardwareFiller) HardwareFiller {
+	return func(h *Hardware) error {
+		for k, v := range labels {
+			if !h.Labels.Has(k) || h.Labels.Get(k) != v {
+				return nil
+			}
+		}
+		for _, f := range fillers {
+			if err := f(h); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// WithHardwareHostname sets the hostname of the machines.
+func WithHardwareHostname(hostname string) HardwareFiller {
+	return func(h *Hardware) error {
+		h.Hostname = hostname
+		return nil
+	}
+}
+
+// WithHardwareHostnamePrefix names each machine it fills prefix-N, with N starting at 1.
+func WithHardwareHostnamePrefix(prefix string) HardwareFiller {
+	n := 0
+	return func(h *Hardware) error {
+		n++
+		h.Hostname = fmt.Sprintf("%s-%d", prefix, n)
+		return nil
+	}
+}
+
+// WithHardwareNetwork sets the IP address, netmask and gateway of the machines.
+func WithHardwareNetwork(ip, netmask, gateway string) HardwareFiller {
+	return func(h *Hardware) error {
+		h.IPAddress = ip
+		h.Netmask = netmask
+		h.Gateway = gateway
+		return nil
+	}
+}
+
+// WithHardwareIPRange assigns consecutive IP addresses, starting at startIP, to the machines it fills.
+func WithHardwareIPRange(startIP, netmask, gateway string) HardwareFiller {
+	next := net.ParseIP(startIP).To4()
+	return func(h *Hardware) error {
+		if next == nil {
+			return fmt.Errorf("invalid or exhausted IPv4 range starting at %s", startIP)
+		}
+		h.IPAddress = next.String()
+		h.Netmask = netmask
+		h.Gateway = gateway
+		next = increment(next)
+		return nil
+	}
+}
+
+// WithHardwareNameservers sets the nameservers of the machines.
+func WithHardwareNameservers(nameservers ...string) HardwareFiller {
+	return func(h *Hardware) error {
+		h.Nameservers = nameservers
+		return nil
+	}
+}
+
+// WithHardwareMACAddress sets the MAC address of the machines.
+func WithHardwareMACAddress(mac string) HardwareFiller {
+	return func(h *Hardware) error {
+		h.MACAddress = mac
+		return nil
+	}
+}
+
+// WithHardwareMACRange assigns consecutive MAC addresses, starting at startMAC, to the machines it fills.
+func WithHardwareMACRange(startMAC string) HardwareFiller {
+	next, err := net.ParseMAC(startMAC)
+	return func(h *Hardware) error {
+		if err != nil {
+			return fmt.Errorf("invalid MAC address range start: %v", err)
+		}
+		if next == nil {
+			return fmt.Errorf("exhausted MAC address range starting at %s", startMAC)
+		}
+		h.MACAddress = next.String()
+		next = increment(next)
+		return nil
+	}
+}
+
+// WithHardwareDisk sets the disk the OS is installed to in the machines.
+func WithHardwareDisk(disk string) HardwareFiller {
+	return func(h *Hardware) error {
+		h.Disk = disk
+		return nil
+	}
+}
+
+// WithHardwareLabel adds a label to the machines, used by the machine config hardware selectors.
+func WithHardwareLabel(key, value string) HardwareFiller {
+	return func(h *Hardware) error {
+		h.Labels[key] = value
+		return nil
+	}
+}
+
+// WithHardwareBMC sets the BMC address and credentials of the machines.
+func WithHardwareBMC(ip, username, password string) HardwareFiller {
+	return func(h *Hardware) error {
+		h.BMCIPAddress = ip
+		h.BMCUsername = username
+		h.BMCPassword = password
+		return nil
+	}
+}
+
+// WithHardwareBMCSecret sets the pre-existing Secret with the BMC credentials of the machines.
+func WithHardwareBMCSecret(secret string) HardwareFiller {
+	return func(h *Hardware) error {
+		h.BMCSecret = secret
+		return nil
+	}
+}
+
+// increment returns a copy of the address incremented by one, nil if it overflows.
+func increment(address []byte) []byte {
+	next := make([]byte, len(address))
+	copy(next, address)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next
+		}
+	}
+	return nil
+}
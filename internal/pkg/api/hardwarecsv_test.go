@@ -0,0 +1,81 @@
+package api
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewHardwareInventory(t *testing.T) {
+	g := NewWithT(t)
+
+	inventory, err := NewHardwareInventory(3,
+		WithHardwareHostnamePrefix("eksa-node"),
+		WithHardwareIPRange("10.10.10.254", "255.255.255.0", "10.10.10.1"),
+		WithHardwareMACRange("00:00:00:00:00:ff"),
+		WithHardwareNameservers("1.1.1.1", "8.8.8.8"),
+		WithHardwareDisk("/dev/sda"),
+		WithHardwareLabel(HardwareLabelTypeKeyName, Worker),
+		WithHardwareBMCSecret("bmc-credentials"),
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(inventory).To(HaveLen(3))
+
+	g.Expect(inventory[0].Hostname).To(Equal("eksa-node-1"))
+	g.Expect(inventory[2].Hostname).To(Equal("eksa-node-3"))
+	g.Expect(inventory[1].IPAddress).To(Equal("10.10.10.255"))
+	g.Expect(inventory[2].IPAddress).To(Equal("10.10.11.0"))
+	g.Expect(inventory[1].MACAddress).To(Equal("00:00:00:00:01:00"))
+	for _, h := range inventory {
+		g.Expect(h.Netmask).To(Equal("255.255.255.0"))
+		g.Expect(h.Gateway).To(Equal("10.10.10.1"))
+		g.Expect(h.Nameservers).To(ConsistOf("1.1.1.1", "8.8.8.8"))
+		g.Expect(h.Disk).To(Equal("/dev/sda"))
+		g.Expect(h.Labels).To(HaveKeyWithValue(HardwareLabelTypeKeyName, Worker))
+		g.Expect(h.BMCSecret).To(Equal("bmc-credentials"))
+	}
+}
+
+func TestNewHardwareInventoryRangeExhausted(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := NewHardwareInventory(2, WithHardwareMACRange("ff:ff:ff:ff:ff:ff"))
+	g.Expect(err).To(MatchError(ContainSubstring("exhausted MAC address range starting at ff:ff:ff:ff:ff:ff")))
+}
+
+func TestNewHardwareInventoryInvalidIP(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := NewHardwareInventory(1, WithHardwareIPRange("10.10.10", "255.255.255.0", "10.10.10.1"))
+	g.Expect(err).To(MatchError(ContainSubstring("invalid or exhausted IPv4 range starting at 10.10.10")))
+}
+
+func TestAutoFillHardwareCSV(t *testing.T) {
+	g := NewWithT(t)
+
+	csv, err := AutoFillHardwareCSV("testdata/tinkerbell/hardware.csv",
+		WithHardwareDisk("/dev/nvme0n1"),
+		WithHardwareSelector(map[string]string{HardwareLabelTypeKeyName: "cp"},
+			WithHardwareBMC("192.168.0.10", "admin", "secret"),
+			WithHardwareLabel(HardwareLabelTypeKeyName, ControlPlane),
+		),
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	inventory, err := NewHardwareSlice(bytes.NewReader(csv))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(inventory).To(HaveLen(2))
+
+	cp, worker := inventory[0], inventory[1]
+	g.Expect(cp.Disk).To(Equal("/dev/nvme0n1"))
+	g.Expect(cp.Labels).To(HaveKeyWithValue(HardwareLabelTypeKeyName, ControlPlane))
+	g.Expect(cp.BMCIPAddress).To(Equal("192.168.0.10"))
+	g.Expect(cp.BMCUsername).To(Equal("admin"))
+	g.Expect(cp.BMCPassword).To(Equal("secret"))
+
+	g.Expect(worker.Disk).To(Equal("/dev/nvme0n1"))
+	g.Expect(worker.Labels).To(HaveKeyWithValue(HardwareLabelTypeKeyName, Worker))
+	g.Expect(worker.BMCIPAddress).To(Equal("10.10.11.2"))
+	g.Expect(worker.BMCUsername).To(Equal("root"))
+}
@@ -6,11 +6,10 @@ import (
 	"strconv"
 
 	"k8s.io/apimachinery/pkg/api/resource"
-	"sigs.k8s.io/yaml"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
-	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
 type NutanixConfig struct {
@@ -20,45 +19,37 @@ type NutanixConfig struct {
 
 type NutanixFiller func(config *NutanixConfig)
 
-func newNutanixConfig(filename string) (*NutanixConfig, error) {
-	config, err := cluster.ParseConfigFromFile(filename)
-	if err != nil {
-		return nil, err
+func (c *NutanixConfig) load(config *cluster.Config) error {
+	if config.NutanixDatacenter == nil {
+		return fmt.Errorf("cluster config doesn't have a %s", anywherev1.NutanixDatacenterKind)
 	}
 
-	nutanixConfig := &NutanixConfig{
-		datacenterConfig: config.NutanixDatacenter,
-		machineConfigs:   config.NutanixMachineConfigs,
-	}
-	return nutanixConfig, nil
+	c.datacenterConfig = config.NutanixDatacenter
+	c.machineConfigs = config.NutanixMachineConfigs
+	return nil
 }
 
-func AutoFillNutanixProvider(filename string, fillers ...NutanixFiller) ([]byte, error) {
-	nutanixConfig, err := newNutanixConfig(filename)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, f := range fillers {
-		f(nutanixConfig)
-	}
-
-	resources := []interface{}{nutanixConfig.datacenterConfig}
-	for _, m := range nutanixConfig.machineConfigs {
-		resources = append(resources, m)
-	}
+func (c *NutanixConfig) objects() []kubernetes.Object {
+	objs := make([]kubernetes.Object, 0, len(c.machineConfigs)+1)
+	objs = append(objs, c.datacenterConfig)
+	return appendObjects(objs, c.machineConfigs)
+}
 
-	yamlResources := make([][]byte, 0, len(resources))
-	for _, r := range resources {
-		yamlContent, err := yaml.Marshal(r)
-		if err != nil {
-			return nil, fmt.Errorf("marshalling nutanix resource: %v", err)
-		}
+func newNutanixConfig(filename string) (*NutanixConfig, error) {
+	return newProviderConfig[NutanixConfig](filename)
+}
 
-		yamlResources = append(yamlResources, yamlContent)
+func AutoFillNutanixProvider(filename string, fillers ...NutanixFiller) ([]byte, error) {
+	adapted := make([]Filler[NutanixConfig], 0, len(fillers))
+	for _, f := range fillers {
+		f := f
+		adapted = append(adapted, func(config *NutanixConfig) error {
+			f(config)
+			return nil
+		})
 	}
 
-	return templater.AppendYamlResources(yamlResources...), nil
+	return AutoFill[NutanixConfig](filename, adapted...)
 }
 
 func WithNutanixStringFromEnvVar(envVar string, opt func(string) NutanixFiller) NutanixFiller {
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+// ProviderConfig is the provider part of a cluster config: the datacenter, the machine configs and
+// any other provider object. AutoFill loads it from a cluster config file, edits it with fillers
+// and writes its objects out.
+type ProviderConfig interface {
+	// load sets the provider objects from a parsed cluster config.
+	load(config *cluster.Config) error
+	// objects returns the provider objects in the order they are written out.
+	objects() []kubernetes.Object
+}
+
+// ProviderConfigPointer is satisfied by the pointers to the types implementing ProviderConfig,
+// so AutoFill can instantiate them.
+type ProviderConfigPointer[C any] interface {
+	*C
+	ProviderConfig
+}
+
+// Filler edits the provider config C.
+type Filler[C any] func(config *C) error
+
+// AutoFill parses a cluster config file, loads the provider config C from it, applies the fillers
+// and returns the provider objects as a multi-document yaml.
+func AutoFill[C any, P ProviderConfigPointer[C]](filename string, fillers ...Filler[C]) ([]byte, error) {
+	config, err := newProviderConfig[C, P](filename)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fillers {
+		if err := f(config); err != nil {
+			return nil, fmt.Errorf("applying filler: %v", err)
+		}
+	}
+
+	objects := P(config).objects()
+	yamlResources := make([][]byte, 0, len(objects))
+	for _, o := range objects {
+		yamlContent, err := yaml.Marshal(o)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling %s %s: %v", o.GetObjectKind().GroupVersionKind().Kind, o.GetName(), err)
+		}
+
+		yamlResources = append(yamlResources, yamlContent)
+	}
+
+	return templater.AppendYamlResources(yamlResources...), nil
+}
+
+func newProviderConfig[C any, P ProviderConfigPointer[C]](filename string) (*C, error) {
+	clusterConfig, err := cluster.ParseConfigFromFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	config := new(C)
+	if err := P(config).load(clusterConfig); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// fillersOf adapts the fillers of a provider that can't fail to Fillers.
+func fillersOf[C any, F ~func(C)](fillers []F) []Filler[C] {
+	adapted := make([]Filler[C], 0, len(fillers))
+	for _, f := range fillers {
+		f := f
+		adapted = append(adapted, func(config *C) error {
+			f(*config)
+			return nil
+		})
+	}
+	return adapted
+}
+
+// sortedByName returns the objects of a map keyed by name, sorted by name,
+// so the generated configs don't change from one run to the next.
+func sortedByName[O any](objects map[string]O) []O {
+	names := make([]string, 0, len(objects))
+	for name := range objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sorted := make([]O, 0, len(objects))
+	for _, name := range names {
+		sorted = append(sorted, objects[name])
+	}
+	return sorted
+}
+
+// appendObjects appends to objs the objects, sorted by name.
+func appendObjects[O kubernetes.Object](objs []kubernetes.Object, objects map[string]O) []kubernetes.Object {
+	for _, o := range sortedByName(objects) {
+		objs = append(objs, o)
+	}
+	return objs
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAutoFill(t *testing.T) {
+	g := NewWithT(t)
+	resources, err := AutoFill(snowConfigFile,
+		func(config *SnowConfig) error {
+			WithSnowIPPool("ip-pool-2", "1.2.4.10", "1.2.4.20", "1.2.4.1", "1.2.4.0/24")(*config)
+			WithSnowIPPool("ip-pool-1", "1.2.3.10", "1.2.3.20", "1.2.3.1", "1.2.3.0/24")(*config)
+			return nil
+		},
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var names []string
+	for _, line := range strings.Split(string(resources), "\n") {
+		if strings.HasPrefix(line, "  name: ") {
+			names = append(names, strings.TrimPrefix(line, "  name: "))
+		}
+	}
+	g.Expect(names).To(Equal([]string{"eksa-unit-test", "eksa-unit-test", "eksa-unit-test-cp", "ip-pool-1", "ip-pool-2"}))
+}
+
+func TestAutoFillFillerError(t *testing.T) {
+	g := NewWithT(t)
+	_, err := AutoFill(snowConfigFile, func(config *SnowConfig) error {
+		return errors.New("invalid device")
+	})
+	g.Expect(err).To(MatchError("applying filler: invalid device"))
+}
+
+func TestAutoFillMissingDatacenter(t *testing.T) {
+	g := NewWithT(t)
+	_, err := AutoFill[VSphereConfig](snowConfigFile)
+	g.Expect(err).To(MatchError("cluster config doesn't have a VSphereDatacenterConfig"))
+}
+
+func TestAutoFillParseError(t *testing.T) {
+	g := NewWithT(t)
+	_, err := AutoFill[SnowConfig]("testdata/missing.yaml")
+	g.Expect(err).To(HaveOccurred())
+}
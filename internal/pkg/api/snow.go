@@ -6,53 +6,57 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/yaml"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
-	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
 type SnowConfig struct {
 	datacenterConfig *anywherev1.SnowDatacenterConfig
 	machineConfigs   map[string]*anywherev1.SnowMachineConfig
+	ipPools          map[string]*anywherev1.SnowIPPool
 }
 
 type SnowFiller func(config SnowConfig)
 
-func AutoFillSnowProvider(filename string, fillers ...SnowFiller) ([]byte, error) {
-	config, err := cluster.ParseConfigFromFile(filename)
-	if err != nil {
-		return nil, err
+func (c *SnowConfig) load(config *cluster.Config) error {
+	if config.SnowDatacenter == nil {
+		return fmt.Errorf("cluster config doesn't have a %s", anywherev1.SnowDatacenterKind)
 	}
 
-	snowConfig := SnowConfig{
-		datacenterConfig: config.SnowDatacenter,
-		machineConfigs:   config.SnowMachineConfigs,
-	}
+	c.datacenterConfig = config.SnowDatacenter
+	c.machineConfigs = config.SnowMachineConfigs
+	c.ipPools = config.SnowIPPools
 
-	for _, f := range fillers {
-		f(snowConfig)
+	// Fillers can add new objects, so the maps can't be nil.
+	if c.machineConfigs == nil {
+		c.machineConfigs = map[string]*anywherev1.SnowMachineConfig{}
 	}
-
-	resources := make([]interface{}, 0, len(snowConfig.machineConfigs)+1)
-	resources = append(resources, snowConfig.datacenterConfig)
-
-	for _, m := range snowConfig.machineConfigs {
-		resources = append(resources, m)
+	if c.ipPools == nil {
+		c.ipPools = map[string]*anywherev1.SnowIPPool{}
 	}
 
-	yamlResources := make([][]byte, 0, len(resources))
-	for _, r := range resources {
-		yamlContent, err := yaml.Marshal(r)
-		if err != nil {
-			return nil, fmt.Errorf("marshalling snow resource: %v", err)
-		}
+	return nil
+}
 
-		yamlResources = append(yamlResources, yamlContent)
+func (c *SnowConfig) objects() []kubernetes.Object {
+	objs := make([]kubernetes.Object, 0, len(c.machineConfigs)+len(c.ipPools)+1)
+	objs = append(objs, c.datacenterConfig)
+	objs = appendObjects(objs, c.machineConfigs)
+	return appendObjects(objs, c.ipPools)
+}
+
+func newSnowConfig(filename string) (SnowConfig, error) {
+	config, err := newProviderConfig[SnowConfig](filename)
+	if err != nil {
+		return SnowConfig{}, err
 	}
+	return *config, nil
+}
 
-	return templater.AppendYamlResources(yamlResources...), nil
+func AutoFillSnowProvider(filename string, fillers ...SnowFiller) ([]byte, error) {
+	return AutoFill[SnowConfig](filename, fillersOf(fillers)...)
 }
 
 func WithSnowStringFromEnvVar(envVar string, opt func(string) SnowFiller) SnowFiller {
@@ -118,3 +122,52 @@ func WithSnowMachineConfig(name string, fillers ...SnowMachineConfigFiller) Snow
 		FillSnowMachineConfig(m, fillers...)
 	}
 }
+
+// WithSnowIPPool adds an ip range to the SnowIPPool with the given name, creating the pool if it doesn't exist.
+// It can be called multiple times with the same name to build a pool with one ip range per device.
+func WithSnowIPPool(name, ipStart, ipEnd, gateway, subnet string) SnowFiller {
+	return func(config SnowConfig) {
+		p, ok := config.ipPools[name]
+		if !ok {
+			p = &anywherev1.SnowIPPool{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       anywherev1.SnowIPPoolKind,
+					APIVersion: anywherev1.SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+			}
+			if config.datacenterConfig != nil {
+				p.Namespace = config.datacenterConfig.Namespace
+			}
+			config.ipPools[name] = p
+		}
+
+		p.Spec.Pools = append(p.Spec.Pools, anywherev1.IPPool{
+			IPStart: ipStart,
+			IPEnd:   ipEnd,
+			Gateway: gateway,
+			Subnet:  subnet,
+		})
+	}
+}
+
+// WithSnowDHCPForAllMachines configures a single primary DNI using DHCP in all the machine configs.
+func WithSnowDHCPForAllMachines() SnowFiller {
+	return func(config SnowConfig) {
+		for _, m := range config.machineConfigs {
+			WithSnowDHCP()(m)
+		}
+	}
+}
+
+// WithSnowStaticIPForAllMachines configures a single primary DNI using static ips from the SnowIPPool poolName
+// in all the machine configs.
+func WithSnowStaticIPForAllMachines(poolName string) SnowFiller {
+	return func(config SnowConfig) {
+		for _, m := range config.machineConfigs {
+			WithSnowStaticIP(poolName)(m)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const snowConfigFile = "testdata/snow/cluster-config.yaml"
+
+func TestSnowMachineConfigFillers(t *testing.T) {
+	g := NewWithT(t)
+	conf, err := newSnowConfig(snowConfigFile)
+	g.Expect(err).To(Succeed())
+	g.Expect(conf.machineConfigs).To(HaveLen(2))
+
+	WithSnowAMIIDForAllMachines("ami-1")(conf)
+	WithSnowInstanceTypeForAllMachines(anywherev1.SbeCXLarge)(conf)
+	WithSnowPhysicalNetworkConnectorForAllMachines(anywherev1.QSFP)(conf)
+	WithSnowSshKeyNameForAllMachines("key-1")(conf)
+	WithSnowDevicesForAllMachines("1.2.3.4,1.2.3.5")(conf)
+
+	for _, m := range conf.machineConfigs {
+		g.Expect(m.Spec.AMIID).To(Equal("ami-1"))
+		g.Expect(m.Spec.InstanceType).To(Equal(anywherev1.SbeCXLarge))
+		g.Expect(m.Spec.PhysicalNetworkConnector).To(Equal(anywherev1.QSFP))
+		g.Expect(m.Spec.SshKeyName).To(Equal("key-1"))
+		g.Expect(m.Spec.Devices).To(Equal([]string{"1.2.3.4", "1.2.3.5"}))
+	}
+}
+
+func TestWithSnowMachineConfigNew(t *testing.T) {
+	g := NewWithT(t)
+	conf, err := newSnowConfig(snowConfigFile)
+	g.Expect(err).To(Succeed())
+
+	WithSnowMachineConfig("new-machine", WithSnowMachineDefaultValues(), WithSnowDevices("1.2.3.4"), WithSnowDHCP())(conf)
+
+	m := conf.machineConfigs["new-machine"]
+	g.Expect(m).NotTo(BeNil())
+	g.Expect(m.Kind).To(Equal(anywherev1.SnowMachineConfigKind))
+	g.Expect(m.Spec.InstanceType).To(Equal(anywherev1.DefaultSnowInstanceType))
+	g.Expect(m.Spec.Devices).To(Equal([]string{"1.2.3.4"}))
+	g.Expect(m.Spec.Network.DirectNetworkInterfaces).To(Equal([]anywherev1.SnowDirectNetworkInterface{
+		{Index: 1, DHCP: true, Primary: true},
+	}))
+}
+
+func TestWithSnowIPPool(t *testing.T) {
+	g := NewWithT(t)
+	conf, err := newSnowConfig(snowConfigFile)
+	g.Expect(err).To(Succeed())
+	g.Expect(conf.ipPools).To(BeEmpty())
+
+	WithSnowIPPool("ip-pool-1", "1.2.3.10", "1.2.3.20", "1.2.3.1", "1.2.3.0/24")(conf)
+	WithSnowIPPool("ip-pool-1", "1.2.4.10", "1.2.4.20", "1.2.4.1", "1.2.4.0/24")(conf)
+
+	p := conf.ipPools["ip-pool-1"]
+	g.Expect(p).NotTo(BeNil())
+	g.Expect(p.Kind).To(Equal(anywherev1.SnowIPPoolKind))
+	g.Expect(p.Spec.Pools).To(Equal([]anywherev1.IPPool{
+		{IPStart: "1.2.3.10", IPEnd: "1.2.3.20", Gateway: "1.2.3.1", Subnet: "1.2.3.0/24"},
+		{IPStart: "1.2.4.10", IPEnd: "1.2.4.20", Gateway: "1.2.4.1", Subnet: "1.2.4.0/24"},
+	}))
+	g.Expect(p.Validate()).To(Succeed())
+}
+
+func TestWithSnowStaticIPForAllMachines(t *testing.T) {
+	g := NewWithT(t)
+	conf, err := newSnowConfig(snowConfigFile)
+	g.Expect(err).To(Succeed())
+
+	WithSnowStaticIPForAllMachines("ip-pool-1")(conf)
+
+	for _, m := range conf.machineConfigs {
+		g.Expect(m.Spec.Network.DirectNetworkInterfaces).To(Equal([]anywherev1.SnowDirectNetworkInterface{
+			{
+				Index: 1,
+				IPPoolRef: &anywherev1.Ref{
+					Kind: anywherev1.SnowIPPoolKind,
+					Name: "ip-pool-1",
+				},
+				Primary: true,
+			},
+		}))
+	}
+
+	WithSnowDHCPForAllMachines()(conf)
+
+	for _, m := range conf.machineConfigs {
+		g.Expect(m.Spec.Network.DirectNetworkInterfaces).To(Equal([]anywherev1.SnowDirectNetworkInterface{
+			{Index: 1, DHCP: true, Primary: true},
+		}))
+	}
+}
+
+func TestAutoFillSnowProvider(t *testing.T) {
+	g := NewWithT(t)
+	resources, err := AutoFillSnowProvider(
+		snowConfigFile,
+		WithSnowDevicesForAllMachines("1.2.3.4"),
+		WithSnowStaticIPForAllMachines("ip-pool-1"),
+		WithSnowIPPool("ip-pool-1", "1.2.3.10", "1.2.3.20", "1.2.3.1", "1.2.3.0/24"),
+	)
+	g.Expect(err).To(Succeed())
+
+	got := string(resources)
+	g.Expect(got).To(ContainSubstring("kind: SnowDatacenterConfig"))
+	g.Expect(got).To(ContainSubstring("kind: SnowMachineConfig"))
+	g.Expect(got).To(ContainSubstring("kind: SnowIPPool"))
+	g.Expect(got).To(ContainSubstring(`  name: ip-pool-1
+spec:
+  pools:
+  - gateway: 1.2.3.1
+    ipEnd: 1.2.3.20
+    ipStart: 1.2.3.10
+    subnet: 1.2.3.0/24`))
+	g.Expect(got).To(ContainSubstring(`    directNetworkInterfaces:
+    - index: 1
+      ipPoolRef:
+        kind: SnowIPPool
+        name: ip-pool-1
+      primary: true`))
+}
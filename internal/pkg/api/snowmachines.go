@@ -51,3 +51,34 @@ func WithSnowDevices(devices string) SnowMachineConfigFiller {
 		m.Spec.Devices = strings.Split(devices, ",")
 	}
 }
+
+// WithSnowDHCP configures a single primary DNI using DHCP for ip allocation.
+func WithSnowDHCP() SnowMachineConfigFiller {
+	return WithSnowDirectNetworkInterfaces(anywherev1.SnowDirectNetworkInterface{
+		Index:   1,
+		DHCP:    true,
+		Primary: true,
+	})
+}
+
+// WithSnowStaticIP configures a single primary DNI using static ips from the SnowIPPool poolName.
+func WithSnowStaticIP(poolName string) SnowMachineConfigFiller {
+	return WithSnowDirectNetworkInterfaces(anywherev1.SnowDirectNetworkInterface{
+		Index: 1,
+		IPPoolRef: &anywherev1.Ref{
+			Kind: anywherev1.SnowIPPoolKind,
+			Name: poolName,
+		},
+		Primary: true,
+	})
+}
+
+// WithSnowDirectNetworkInterfaces replaces the DNIs in the machine config.
+func WithSnowDirectNetworkInterfaces(dnis ...anywherev1.SnowDirectNetworkInterface) SnowMachineConfigFiller {
+	return func(m *anywherev1.SnowMachineConfig) {
+		m.Spec.Network.DirectNetworkInterfaces = make([]anywherev1.SnowDirectNetworkInterface, 0, len(dnis))
+		for _, dni := range dnis {
+			m.Spec.Network.DirectNetworkInterfaces = append(m.Spec.Network.DirectNetworkInterfaces, *dni.DeepCopy())
+		}
+	}
+}
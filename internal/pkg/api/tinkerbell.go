@@ -3,13 +3,15 @@ package api
 import (
 	"fmt"
 	"os"
+	"sort"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/yaml"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/providers"
-	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 )
 
 type TinkerbellConfig struct {
@@ -17,67 +19,60 @@ type TinkerbellConfig struct {
 	datacenterConfig *anywherev1.TinkerbellDatacenterConfig
 	machineConfigs   map[string]*anywherev1.TinkerbellMachineConfig
 	templateConfigs  map[string]*anywherev1.TinkerbellTemplateConfig
+	// bmcs holds the rufio BaseboardManagements and their Secrets added by the fillers.
+	bmcs *hardware.Catalogue
 }
 
 type TinkerbellFiller func(config TinkerbellConfig) error
 
-func AutoFillTinkerbellProvider(filename string, fillers ...TinkerbellFiller) ([]byte, error) {
-	tinkerbellDatacenterConfig, err := anywherev1.GetTinkerbellDatacenterConfig(filename)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get tinkerbell datacenter config from file: %v", err)
-	}
-
-	tinkerbellMachineConfigs, err := anywherev1.GetTinkerbellMachineConfigs(filename)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get tinkerbell machine config from file: %v", err)
+func (c *TinkerbellConfig) load(config *cluster.Config) error {
+	if config.TinkerbellDatacenter == nil {
+		return fmt.Errorf("cluster config doesn't have a %s", anywherev1.TinkerbellDatacenterKind)
 	}
 
-	tinkerbellTemplateConfigs, err := anywherev1.GetTinkerbellTemplateConfig(filename)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get tinkerbell template configs from file: %v", err)
-	}
+	c.clusterConfig = config.Cluster
+	c.datacenterConfig = config.TinkerbellDatacenter
+	c.machineConfigs = config.TinkerbellMachineConfigs
+	c.templateConfigs = config.TinkerbellTemplateConfigs
+	c.bmcs = hardware.NewCatalogue()
 
-	clusterConfig, err := anywherev1.GetClusterConfig(filename)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get tinkerbell cluster config from file: %v", err)
+	// Fillers can add new machine configs, so the map can't be nil.
+	if c.machineConfigs == nil {
+		c.machineConfigs = map[string]*anywherev1.TinkerbellMachineConfig{}
 	}
 
-	config := TinkerbellConfig{
-		clusterConfig:    clusterConfig,
-		datacenterConfig: tinkerbellDatacenterConfig,
-		machineConfigs:   tinkerbellMachineConfigs,
-		templateConfigs:  tinkerbellTemplateConfigs,
-	}
-
-	for _, f := range fillers {
-		err := f(config)
-		if err != nil {
-			return nil, fmt.Errorf("failed to apply tinkerbell config filler: %v", err)
-		}
-	}
+	return nil
+}
 
-	resources := make([]interface{}, 0, len(config.machineConfigs)+len(config.templateConfigs)+1)
-	resources = append(resources, config.datacenterConfig)
+// objects returns the datacenter, machine and template configs sorted by name, followed by
+// the BaseboardManagements and their Secrets in the order the fillers added them.
+func (c *TinkerbellConfig) objects() []kubernetes.Object {
+	objs := make([]kubernetes.Object, 0, len(c.machineConfigs)+len(c.templateConfigs)+c.bmcs.TotalBMCs()+c.bmcs.TotalSecrets()+1)
+	objs = append(objs, c.datacenterConfig)
+	objs = appendObjects(objs, c.machineConfigs)
+	objs = appendObjects(objs, c.templateConfigs)
 
-	for _, m := range config.machineConfigs {
-		resources = append(resources, m)
+	for _, b := range c.bmcs.AllBMCs() {
+		objs = append(objs, b)
 	}
 
-	for _, m := range config.templateConfigs {
-		resources = append(resources, m)
+	for _, s := range c.bmcs.AllSecrets() {
+		objs = append(objs, s)
 	}
 
-	yamlResources := make([][]byte, 0, len(resources))
-	for _, r := range resources {
-		yamlContent, err := yaml.Marshal(r)
-		if err != nil {
-			return nil, fmt.Errorf("marshalling tinkerbell resource: %v", err)
-		}
+	return objs
+}
 
-		yamlResources = append(yamlResources, yamlContent)
+func AutoFillTinkerbellProvider(filename string, fillers ...TinkerbellFiller) ([]byte, error) {
+	adapted := make([]Filler[TinkerbellConfig], 0, len(fillers))
+	for _, f := range fillers {
+		f := f
+		adapted = append(adapted, func(config *TinkerbellConfig) error {
+			return f(*config)
+		})
 	}
 
-	return templater.AppendYamlResources(yamlResources...), nil
+	return AutoFill[TinkerbellConfig](filename, adapted...)
 }
 
 func WithTinkerbellServer(value string) TinkerbellFiller {
@@ -122,6 +117,108 @@ func WithImageUrlForAllTinkerbellMachines(value string) TinkerbellFiller {
 	}
 }
 
+// TinkerbellAction identifies an action of a task in the Tinkerbell template configs.
+type TinkerbellAction struct {
+	// Task is the name of the task, the action is matched in all the tasks when empty.
+	Task string
+	// Action is the name of the action, like stream-image, kexec-image or reboot-image.
+	Action string
+}
+
+// WithTinkerbellActionImageOverride sets the image of the action actionName of the task taskName in all the
+// template configs. The action is matched in all the tasks when taskName is empty.
+func WithTinkerbellActionImageOverride(taskName, actionName, image string) TinkerbellFiller {
+	return WithTinkerbellActionImageOverrides(map[TinkerbellAction]string{
+		{Task: taskName, Action: actionName}: image,
+	})
+}
+
+// WithTinkerbellActionImageOverrides sets the images of the actions in all the template configs, so all the
+// actions can be pinned, for example, to images in a registry mirror. It fails if any of the actions isn't
+// in the template configs.
+func WithTinkerbellActionImageOverrides(images map[TinkerbellAction]string) TinkerbellFiller {
+	return func(config TinkerbellConfig) error {
+		found := make(map[TinkerbellAction]bool, len(images))
+		for _, t := range config.templateConfigs {
+			for _, task := range t.Spec.Template.Tasks {
+				for i, action := range task.Actions {
+					for ref, image := range images {
+						if ref.Action == action.Name && (ref.Task == "" || ref.Task == task.Name) {
+							task.Actions[i].Image = image
+							found[ref] = true
+						}
+					}
+				}
+			}
+		}
+
+		for ref := range images {
+			if !found[ref] {
+				return fmt.Errorf("action %s of task %s not found in tinkerbell template configs", ref.Action, ref.Task)
+			}
+		}
+		return nil
+	}
+}
+
+// WithTinkerbellMachineConfigProxy sets the proxy env vars of all the actions in the template configs, so
+// clusters behind a proxy can stream the OS images.
+func WithTinkerbellMachineConfigProxy(httpProxy, httpsProxy string, noProxy []string) TinkerbellFiller {
+	return func(config TinkerbellConfig) error {
+		for _, t := range config.templateConfigs {
+			t.SetActionsProxy(httpProxy, httpsProxy, noProxy)
+		}
+		return nil
+	}
+}
+
+// TinkerbellBMC is the BMC of a bare metal machine.
+type TinkerbellBMC struct {
+	IPAddress string
+	Username  string
+	Password  string
+	// Port overrides the default port of the protocol.
+	Port int
+	// Protocol is the protocol used to manage the machine, ipmi or redfish. Defaults to ipmi.
+	Protocol string
+}
+
+// WithTinkerbellBMCConfig adds to the generated config the rufio BaseboardManagements, and the Secrets
+// with their credentials, for the BMCs of the machines by hostname. They are named the same as the ones
+// generated from a hardware csv, so the Hardware of the machines references them.
+func WithTinkerbellBMCConfig(bmcs map[string]TinkerbellBMC) TinkerbellFiller {
+	return func(config TinkerbellConfig) error {
+		hostnames := make([]string, 0, len(bmcs))
+		for hostname := range bmcs {
+			hostnames = append(hostnames, hostname)
+		}
+		sort.Strings(hostnames)
+
+		writer := hardware.MultiMachineWriter(
+			hardware.NewBMCCatalogueWriter(config.bmcs),
+			hardware.NewSecretCatalogueWriter(config.bmcs),
+		)
+		for _, hostname := range hostnames {
+			bmc := bmcs[hostname]
+			if bmc.IPAddress == "" || bmc.Username == "" || bmc.Password == "" {
+				return fmt.Errorf("bmc of machine %s requires an ip address, username and password", hostname)
+			}
+
+			if err := writer.Write(hardware.Machine{
+				Hostname:     hostname,
+				BMCIPAddress: bmc.IPAddress,
+				BMCUsername:  bmc.Username,
+				BMCPassword:  bmc.Password,
+				BMCPort:      bmc.Port,
+				BMCProtocol:  bmc.Protocol,
+			}); err != nil {
+				return fmt.Errorf("generating bmc of machine %s: %v", hostname, err)
+			}
+		}
+		return nil
+	}
+}
+
 func WithSSHAuthorizedKeyForAllTinkerbellMachines(key string) TinkerbellFiller {
 	return func(config TinkerbellConfig) error {
 		for _, m := range config.machineConfigs {
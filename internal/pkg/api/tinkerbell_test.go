@@ -0,0 +1,130 @@
+package api
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+func newTinkerbellTemplateConfig() TinkerbellConfig {
+	template := &anywherev1.TinkerbellTemplateConfig{}
+	template.Name = "test"
+	template.Spec.Template.Tasks = []tinkerbell.Task{
+		{
+			Name: "test",
+			Actions: []tinkerbell.Action{
+				{Name: "stream-image", Image: "public.ecr.aws/eks-anywhere/image2disk:v1"},
+				{Name: "kexec-image", Image: "public.ecr.aws/eks-anywhere/kexec:v1"},
+				{Name: "reboot-image", Image: "public.ecr.aws/eks-anywhere/reboot:v1"},
+			},
+		},
+	}
+
+	return TinkerbellConfig{
+		templateConfigs: map[string]*anywherev1.TinkerbellTemplateConfig{template.Name: template},
+	}
+}
+
+func TestWithTinkerbellActionImageOverride(t *testing.T) {
+	g := NewWithT(t)
+	config := newTinkerbellTemplateConfig()
+
+	g.Expect(WithTinkerbellActionImageOverride("test", "kexec-image", "mirror.local/kexec:v1")(config)).To(Succeed())
+
+	actions := config.templateConfigs["test"].Spec.Template.Tasks[0].Actions
+	g.Expect(actions[0].Image).To(Equal("public.ecr.aws/eks-anywhere/image2disk:v1"))
+	g.Expect(actions[1].Image).To(Equal("mirror.local/kexec:v1"))
+	g.Expect(actions[2].Image).To(Equal("public.ecr.aws/eks-anywhere/reboot:v1"))
+}
+
+func TestWithTinkerbellActionImageOverrides(t *testing.T) {
+	g := NewWithT(t)
+	config := newTinkerbellTemplateConfig()
+
+	g.Expect(WithTinkerbellActionImageOverrides(map[TinkerbellAction]string{
+		{Action: "stream-image"}:               "mirror.local/image2disk:v1",
+		{Task: "test", Action: "reboot-image"}: "mirror.local/reboot:v1",
+	})(config)).To(Succeed())
+
+	actions := config.templateConfigs["test"].Spec.Template.Tasks[0].Actions
+	g.Expect(actions[0].Image).To(Equal("mirror.local/image2disk:v1"))
+	g.Expect(actions[1].Image).To(Equal("public.ecr.aws/eks-anywhere/kexec:v1"))
+	g.Expect(actions[2].Image).To(Equal("mirror.local/reboot:v1"))
+}
+
+func TestWithTinkerbellMachineConfigProxy(t *testing.T) {
+	g := NewWithT(t)
+	config := newTinkerbellTemplateConfig()
+
+	g.Expect(WithTinkerbellMachineConfigProxy("http://proxy:3128", "https://proxy:3128", []string{"1.2.3.4", ".svc"})(config)).To(Succeed())
+
+	for _, action := range config.templateConfigs["test"].Spec.Template.Tasks[0].Actions {
+		g.Expect(action.Environment).To(Equal(map[string]string{
+			"HTTP_PROXY":  "http://proxy:3128",
+			"HTTPS_PROXY": "https://proxy:3128",
+			"NO_PROXY":    "1.2.3.4,.svc",
+		}))
+	}
+}
+
+func TestWithTinkerbellActionImageOverrideActionNotFound(t *testing.T) {
+	g := NewWithT(t)
+	config := newTinkerbellTemplateConfig()
+
+	g.Expect(WithTinkerbellActionImageOverride("other", "kexec-image", "mirror.local/kexec:v1")(config)).To(
+		MatchError("action kexec-image of task other not found in tinkerbell template configs"),
+	)
+}
+
+func TestWithTinkerbellBMCConfig(t *testing.T) {
+	g := NewWithT(t)
+	config := TinkerbellConfig{bmcs: hardware.NewCatalogue()}
+
+	g.Expect(WithTinkerbellBMCConfig(map[string]TinkerbellBMC{
+		"worker-1": {IPAddress: "10.0.0.2", Username: "admin", Password: "secret", Protocol: hardware.BMCProtocolRedfish},
+		"cp-1":     {IPAddress: "10.0.0.1", Username: "root", Password: "calvin", Port: 6230},
+	})(config)).To(Succeed())
+
+	bmcs := config.bmcs.AllBMCs()
+	g.Expect(bmcs).To(HaveLen(2))
+	g.Expect(bmcs[0].Name).To(Equal("bmc-cp-1"))
+	g.Expect(bmcs[0].Spec.Connection.Host).To(Equal("10.0.0.1"))
+	g.Expect(bmcs[0].Spec.Connection.Port).To(Equal(6230))
+	g.Expect(bmcs[0].Spec.Connection.AuthSecretRef.Name).To(Equal("bmc-cp-1-auth"))
+	g.Expect(bmcs[1].Name).To(Equal("bmc-worker-1"))
+	g.Expect(bmcs[1].Spec.Connection.Port).To(Equal(443))
+
+	secrets := config.bmcs.AllSecrets()
+	g.Expect(secrets).To(HaveLen(2))
+	g.Expect(secrets[0].Name).To(Equal("bmc-cp-1-auth"))
+	g.Expect(secrets[0].Data).To(Equal(map[string][]byte{"username": []byte("root"), "password": []byte("calvin")}))
+}
+
+func TestWithTinkerbellBMCConfigMissingCredentials(t *testing.T) {
+	g := NewWithT(t)
+	config := TinkerbellConfig{bmcs: hardware.NewCatalogue()}
+
+	err := WithTinkerbellBMCConfig(map[string]TinkerbellBMC{
+		"cp-1": {IPAddress: "10.0.0.1", Username: "root"},
+	})(config)
+	g.Expect(err).To(MatchError("bmc of machine cp-1 requires an ip address, username and password"))
+}
+
+func TestAutoFillTinkerbellProviderWithBMCConfig(t *testing.T) {
+	g := NewWithT(t)
+	resources, err := AutoFillTinkerbellProvider(
+		"testdata/tinkerbell/cluster-config.yaml",
+		WithTinkerbellBMCConfig(map[string]TinkerbellBMC{
+			"cp-1": {IPAddress: "10.0.0.1", Username: "root", Password: "calvin"},
+		}),
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+	expectedResources, err := os.ReadFile("testdata/tinkerbell/templated-resources.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(resources)).To(Equal(string(expectedResources)))
+}
@@ -5,10 +5,10 @@ import (
 	"os"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/yaml"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
-	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 )
 
 type VSphereConfig struct {
@@ -18,43 +18,28 @@ type VSphereConfig struct {
 
 type VSphereFiller func(config VSphereConfig)
 
-func AutoFillVSphereProvider(filename string, fillers ...VSphereFiller) ([]byte, error) {
-	vsphereDatacenterConfig, err := anywherev1.GetVSphereDatacenterConfig(filename)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get vsphere datacenter config from file: %v", err)
-	}
-
-	vsphereMachineConfigs, err := anywherev1.GetVSphereMachineConfigs(filename)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get vsphere machine config from file: %v", err)
-	}
-
-	config := VSphereConfig{
-		datacenterConfig: vsphereDatacenterConfig,
-		machineConfigs:   vsphereMachineConfigs,
-	}
-
-	for _, f := range fillers {
-		f(config)
+func (c *VSphereConfig) load(config *cluster.Config) error {
+	if config.VSphereDatacenter == nil {
+		return fmt.Errorf("cluster config doesn't have a %s", anywherev1.VSphereDatacenterKind)
 	}
 
-	resources := make([]interface{}, 0, len(config.machineConfigs)+1)
-	resources = append(resources, config.datacenterConfig)
-	for _, m := range config.machineConfigs {
-		resources = append(resources, m)
+	c.datacenterConfig = config.VSphereDatacenter
+	c.machineConfigs = config.VSphereMachineConfigs
+	// Fillers can add new machine configs, so the map can't be nil.
+	if c.machineConfigs == nil {
+		c.machineConfigs = map[string]*anywherev1.VSphereMachineConfig{}
 	}
+	return nil
+}
 
-	yamlResources := make([][]byte, 0, len(resources))
-	for _, r := range resources {
-		yamlContent, err := yaml.Marshal(r)
-		if err != nil {
-			return nil, fmt.Errorf("marshalling vsphere resource: %v", err)
-		}
-
-		yamlResources = append(yamlResources, yamlContent)
-	}
+func (c *VSphereConfig) objects() []kubernetes.Object {
+	objs := make([]kubernetes.Object, 0, len(c.machineConfigs)+1)
+	objs = append(objs, c.datacenterConfig)
+	return appendObjects(objs, c.machineConfigs)
+}
 
-	return templater.AppendYamlResources(yamlResources...), nil
+func AutoFillVSphereProvider(filename string, fillers ...VSphereFiller) ([]byte, error) {
+	return AutoFill[VSphereConfig](filename, fillersOf(fillers)...)
 }
 
 func WithOsFamilyForAllMachines(value anywherev1.OSFamily) VSphereFiller {
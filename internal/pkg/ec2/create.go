@@ -26,62 +26,93 @@ EOF
 systemctl restart docker --no-block
 `
 
-func CreateInstance(session *session.Session, amiId, key, tag, instanceProfileName, subnetId, name string) (string, error) {
-	r := retrier.New(180*time.Minute, retrier.WithBackoffFactor(1.5), retrier.WithRetryPolicy(func(totalRetries int, err error) (retry bool, wait time.Duration) {
-		// EC2 Request token bucket has a refill rate of 2 request tokens
-		// per second, so waiting between 5 and 10 seconds per retry with a backoff factor of 1.5 should be sufficient
-		if isThrottleError(err) && totalRetries < 50 {
-			fmt.Println("Throttled, retrying")
-			maxWait := 10
-			minWait := 5
-			waitWithJitter := time.Duration(rand.Intn(maxWait-minWait)+minWait) * time.Second
-			return true, waitWithJitter
+const defaultInstanceType = "t3.2xlarge"
+
+// InstanceOpt customizes the instance created by CreateInstance.
+type InstanceOpt func(i *ec2.RunInstancesInput)
+
+// WithInstanceType overrides the default instance type.
+func WithInstanceType(instanceType string) InstanceOpt {
+	return func(i *ec2.RunInstancesInput) {
+		if instanceType != "" {
+			i.InstanceType = aws.String(instanceType)
 		}
-		return false, 0
-	}))
+	}
+}
 
-	service := ec2.New(session)
-	var result *ec2.Reservation
+// WithSpot requests a one-time spot instance. An empty maxPrice caps the price at the on-demand one.
+// CreateInstance falls back to an on-demand instance when there is no spot capacity at that price.
+func WithSpot(maxPrice string) InstanceOpt {
+	return func(i *ec2.RunInstancesInput) {
+		spot := &ec2.SpotMarketOptions{
+			SpotInstanceType:             aws.String(ec2.SpotInstanceTypeOneTime),
+			InstanceInterruptionBehavior: aws.String(ec2.InstanceInterruptionBehaviorTerminate),
+		}
+		if maxPrice != "" {
+			spot.MaxPrice = aws.String(maxPrice)
+		}
+		i.InstanceMarketOptions = &ec2.InstanceMarketOptionsRequest{
+			MarketType:  aws.String(ec2.MarketTypeSpot),
+			SpotOptions: spot,
+		}
+	}
+}
 
-	err := r.Retry(func() error {
-		var err error
-		result, err = service.RunInstances(&ec2.RunInstancesInput{
-			ImageId:      aws.String(amiId),
-			InstanceType: aws.String("t3.2xlarge"),
-			MinCount:     aws.Int64(1),
-			MaxCount:     aws.Int64(1),
-			BlockDeviceMappings: []*ec2.BlockDeviceMapping{
-				{
-					DeviceName: aws.String("/dev/xvda"),
-					Ebs: &ec2.EbsBlockDevice{
-						VolumeSize: aws.Int64(100),
-					},
+func CreateInstance(session *session.Session, amiId, key, tag, instanceProfileName, subnetId, name string, opts ...InstanceOpt) (string, error) {
+	r := newThrottleRetrier()
+	service := ec2.New(session)
+	runInput := &ec2.RunInstancesInput{
+		ImageId:      aws.String(amiId),
+		InstanceType: aws.String(defaultInstanceType),
+		MinCount:     aws.Int64(1),
+		MaxCount:     aws.Int64(1),
+		BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+			{
+				DeviceName: aws.String("/dev/xvda"),
+				Ebs: &ec2.EbsBlockDevice{
+					VolumeSize:          aws.Int64(100),
+					DeleteOnTermination: aws.Bool(true),
 				},
 			},
-			IamInstanceProfile: &ec2.IamInstanceProfileSpecification{
-				Name: aws.String(instanceProfileName),
-			},
-			SubnetId: aws.String(subnetId),
-			TagSpecifications: []*ec2.TagSpecification{
-				{
-					ResourceType: aws.String("instance"),
-					Tags: []*ec2.Tag{
-						{
-							Key:   aws.String(key),
-							Value: aws.String(tag),
-						},
-						{
-							Key:   aws.String("Name"),
-							Value: aws.String(name),
-						},
+		},
+		IamInstanceProfile: &ec2.IamInstanceProfileSpecification{
+			Name: aws.String(instanceProfileName),
+		},
+		SubnetId: aws.String(subnetId),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String("instance"),
+				Tags: []*ec2.Tag{
+					{
+						Key:   aws.String(key),
+						Value: aws.String(tag),
+					},
+					{
+						Key:   aws.String("Name"),
+						Value: aws.String(name),
 					},
 				},
 			},
-			UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(dockerLogsUserData))),
-		})
+		},
+		UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(dockerLogsUserData))),
+	}
+	for _, opt := range opts {
+		opt(runInput)
+	}
 
+	var result *ec2.Reservation
+	runInstances := func() error {
+		var err error
+		result, err = service.RunInstances(runInput)
 		return err
-	})
+	}
+
+	err := r.Retry(runInstances)
+	if err != nil && runInput.InstanceMarketOptions != nil && isSpotCapacityError(err) {
+		logger.V(2).Info("No spot capacity available, falling back to on-demand instance", "error", err)
+		runInput.InstanceMarketOptions = nil
+		err = r.Retry(runInstances)
+	}
 	if err != nil {
 		return "", fmt.Errorf("retries exhausted when trying to create instances: %v", err)
 	}
@@ -101,6 +132,40 @@ func CreateInstance(session *session.Session, amiId, key, tag, instanceProfileNa
 	return *result.Instances[0].InstanceId, nil
 }
 
+// newThrottleRetrier returns a retrier that only retries the requests throttled by EC2.
+func newThrottleRetrier() *retrier.Retrier {
+	return retrier.New(180*time.Minute, retrier.WithBackoffFactor(1.5), retrier.WithRetryPolicy(func(totalRetries int, err error) (retry bool, wait time.Duration) {
+		// EC2 Request token bucket has a refill rate of 2 request tokens
+		// per second, so waiting between 5 and 10 seconds per retry with a backoff factor of 1.5 should be sufficient
+		if isThrottleError(err) && totalRetries < 50 {
+			fmt.Println("Throttled, retrying")
+			maxWait := 10
+			minWait := 5
+			waitWithJitter := time.Duration(rand.Intn(maxWait-minWait)+minWait) * time.Second
+			return true, waitWithJitter
+		}
+		return false, 0
+	}))
+}
+
+// spotCapacityErrorCodes are the errors returned by EC2 when a spot request can't be fulfilled, either
+// because there's no capacity or because the spot price is over the max price.
+var spotCapacityErrorCodes = map[string]struct{}{
+	"InsufficientInstanceCapacity": {},
+	"MaxSpotInstanceCountExceeded": {},
+	"SpotMaxPriceTooLow":           {},
+	"UnfulfillableCapacity":        {},
+}
+
+func isSpotCapacityError(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		_, ok := spotCapacityErrorCodes[aerr.Code()]
+		return ok
+	}
+
+	return false
+}
+
 func isThrottleError(err error) bool {
 	if aerr, ok := err.(awserr.Error); ok {
 		if aerr.Code() == "RequestLimitExceeded" {
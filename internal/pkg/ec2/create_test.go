@@ -0,0 +1,33 @@
+package ec2
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/gomega"
+)
+
+func TestInstanceOpts(t *testing.T) {
+	g := NewWithT(t)
+	input := &ec2.RunInstancesInput{InstanceType: aws.String(defaultInstanceType)}
+
+	WithInstanceType("")(input)
+	g.Expect(aws.StringValue(input.InstanceType)).To(Equal(defaultInstanceType))
+
+	WithInstanceType("m5.2xlarge")(input)
+	WithSpot("0.2")(input)
+	g.Expect(aws.StringValue(input.InstanceType)).To(Equal("m5.2xlarge"))
+	g.Expect(aws.StringValue(input.InstanceMarketOptions.MarketType)).To(Equal(ec2.MarketTypeSpot))
+	g.Expect(aws.StringValue(input.InstanceMarketOptions.SpotOptions.MaxPrice)).To(Equal("0.2"))
+}
+
+func TestIsSpotCapacityError(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(isSpotCapacityError(awserr.New("InsufficientInstanceCapacity", "no capacity", nil))).To(BeTrue())
+	g.Expect(isSpotCapacityError(awserr.New("SpotMaxPriceTooLow", "price too low", nil))).To(BeTrue())
+	g.Expect(isSpotCapacityError(awserr.New("RequestLimitExceeded", "throttled", nil))).To(BeFalse())
+	g.Expect(isSpotCapacityError(errors.New("other"))).To(BeFalse())
+}
@@ -70,3 +70,44 @@ func ListInstances(session *session.Session, key string, value string, maxAge fl
 	}
 	return instanceList, nil
 }
+
+// ListInstancesByNamePrefix returns the ids of the instances whose Name tag starts with namePrefix
+// and that were launched before launchedBefore.
+func ListInstancesByNamePrefix(session *session.Session, namePrefix string, launchedBefore time.Time) ([]*string, error) {
+	service := ec2.New(session)
+	var instanceList []*string
+
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag:Name"),
+				Values: []*string{aws.String(namePrefix + "*")},
+			},
+			{
+				Name: aws.String("instance-state-name"),
+				Values: []*string{
+					aws.String("running"),
+					aws.String("pending"),
+					aws.String("stopping"),
+					aws.String("stopped"),
+				},
+			},
+		},
+	}
+
+	err := service.DescribeInstancesPages(input, func(page *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				if instance.LaunchTime != nil && instance.LaunchTime.Before(launchedBefore) {
+					instanceList = append(instanceList, instance.InstanceId)
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing EC2 instances: %v", err)
+	}
+
+	return instanceList, nil
+}
@@ -3,8 +3,11 @@ package ec2
 import (
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 func TerminateEc2Instances(session *session.Session, instances []*string) error {
@@ -20,3 +23,77 @@ func TerminateEc2Instances(session *session.Session, instances []*string) error
 
 	return nil
 }
+
+// TerminateInstance terminates an instance and waits until it's gone. The EBS volumes attached to it
+// that aren't deleted on termination are deleted as well, so they aren't leaked with the instance.
+func TerminateInstance(session *session.Session, instanceId string) error {
+	service := ec2.New(session)
+	input := &ec2.DescribeInstancesInput{
+		InstanceIds: []*string{aws.String(instanceId)},
+	}
+
+	volumes, err := retainedVolumes(service, input)
+	if err != nil {
+		return err
+	}
+
+	err = newThrottleRetrier().Retry(func() error {
+		_, err := service.TerminateInstances(&ec2.TerminateInstancesInput{
+			InstanceIds: []*string{aws.String(instanceId)},
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("terminating instance %s: %v", instanceId, err)
+	}
+
+	logger.V(2).Info("Waiting until the instance is terminated", "instance-id", instanceId)
+	if err = service.WaitUntilInstanceTerminated(input); err != nil {
+		return fmt.Errorf("waiting for instance %s to terminate: %v", instanceId, err)
+	}
+	logger.V(2).Info("Instance is terminated", "instance-id", instanceId)
+
+	for _, volume := range volumes {
+		if err = deleteVolume(service, volume); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retainedVolumes returns the ids of the EBS volumes attached to the instance that outlive it.
+func retainedVolumes(service *ec2.EC2, input *ec2.DescribeInstancesInput) ([]*string, error) {
+	result, err := service.DescribeInstances(input)
+	if err != nil {
+		return nil, fmt.Errorf("describing instance: %v", err)
+	}
+
+	var volumes []*string
+	for _, reservation := range result.Reservations {
+		for _, instance := range reservation.Instances {
+			for _, mapping := range instance.BlockDeviceMappings {
+				if mapping.Ebs != nil && !aws.BoolValue(mapping.Ebs.DeleteOnTermination) {
+					volumes = append(volumes, mapping.Ebs.VolumeId)
+				}
+			}
+		}
+	}
+
+	return volumes, nil
+}
+
+func deleteVolume(service *ec2.EC2, volumeId *string) error {
+	err := service.WaitUntilVolumeAvailable(&ec2.DescribeVolumesInput{
+		VolumeIds: []*string{volumeId},
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for volume %s to detach: %v", aws.StringValue(volumeId), err)
+	}
+
+	if _, err = service.DeleteVolume(&ec2.DeleteVolumeInput{VolumeId: volumeId}); err != nil {
+		return fmt.Errorf("deleting volume %s: %v", aws.StringValue(volumeId), err)
+	}
+
+	return nil
+}
@@ -2,7 +2,10 @@ package ssm
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ssm"
 )
@@ -43,3 +46,24 @@ func DeleteActivation(session *session.Session, activationId string) (*ssm.Delet
 
 	return result, nil
 }
+
+// ListActivations returns the ids of the activations whose default instance name starts with namePrefix
+// and that were created before createdBefore.
+func ListActivations(session *session.Session, namePrefix string, createdBefore time.Time) ([]string, error) {
+	s := ssm.New(session)
+
+	var ids []string
+	err := s.DescribeActivationsPages(&ssm.DescribeActivationsInput{}, func(page *ssm.DescribeActivationsOutput, _ bool) bool {
+		for _, a := range page.ActivationList {
+			if strings.HasPrefix(aws.StringValue(a.DefaultInstanceName), namePrefix) && a.CreatedDate != nil && a.CreatedDate.Before(createdBefore) {
+				ids = append(ids, aws.StringValue(a.ActivationId))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ssm activations: %v", err)
+	}
+
+	return ids, nil
+}
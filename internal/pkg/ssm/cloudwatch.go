@@ -0,0 +1,70 @@
+package ssm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// ListCloudwatchOutputs returns the log streams with the cloudwatch output of the commands run in
+// instanceIds that haven't been written to since lastEventBefore.
+func ListCloudwatchOutputs(session *session.Session, instanceIds []string, lastEventBefore time.Time) ([]string, error) {
+	service := cloudwatchlogs.New(session)
+	instances := make(map[string]struct{}, len(instanceIds))
+	for _, id := range instanceIds {
+		instances[id] = struct{}{}
+	}
+
+	var streams []string
+	input := &cloudwatchlogs.DescribeLogStreamsInput{LogGroupName: aws.String(ssmLogGroup)}
+	err := service.DescribeLogStreamsPages(input, func(page *cloudwatchlogs.DescribeLogStreamsOutput, _ bool) bool {
+		for _, stream := range page.LogStreams {
+			name := aws.StringValue(stream.LogStreamName)
+			if _, ok := instances[commandOutputInstanceId(name)]; !ok {
+				continue
+			}
+
+			lastEvent := aws.Int64Value(stream.LastEventTimestamp)
+			if lastEvent == 0 {
+				lastEvent = aws.Int64Value(stream.CreationTime)
+			}
+			if time.UnixMilli(lastEvent).Before(lastEventBefore) {
+				streams = append(streams, name)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cloudwatch log streams in %s: %v", ssmLogGroup, err)
+	}
+
+	return streams, nil
+}
+
+// DeleteCloudwatchOutput deletes a log stream with the cloudwatch output of a command.
+func DeleteCloudwatchOutput(session *session.Session, stream string) error {
+	service := cloudwatchlogs.New(session)
+	_, err := service.DeleteLogStream(&cloudwatchlogs.DeleteLogStreamInput{
+		LogGroupName:  aws.String(ssmLogGroup),
+		LogStreamName: aws.String(stream),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete cloudwatch log stream %s: %v", stream, err)
+	}
+
+	return nil
+}
+
+// commandOutputInstanceId returns the id of the instance a command output log stream belongs to.
+// Streams are named commandId/instanceId/pluginName/stdout or stderr.
+func commandOutputInstanceId(stream string) string {
+	parts := strings.Split(stream, "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
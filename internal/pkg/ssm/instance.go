@@ -2,7 +2,10 @@ package ssm
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ssm"
 )
@@ -43,3 +46,46 @@ func DeregisterInstance(session *session.Session, id string) (*ssm.DeregisterMan
 
 	return output, nil
 }
+
+// TagInstance adds the tag key with value to the ssm managed instance id.
+func TagInstance(session *session.Session, id, key, value string) error {
+	s := ssm.New(session)
+	resourceType := ssm.ResourceTypeForTaggingManagedInstance
+	input := ssm.AddTagsToResourceInput{
+		ResourceId:   &id,
+		ResourceType: &resourceType,
+		Tags:         []*ssm.Tag{{Key: &key, Value: &value}},
+	}
+
+	if _, err := s.AddTagsToResource(&input); err != nil {
+		return fmt.Errorf("failed to tag ssm instance %s: %v", id, err)
+	}
+
+	return nil
+}
+
+// ListManagedInstances returns the ids of the on-premises managed instances whose name starts with
+// namePrefix and that were registered before registeredBefore.
+func ListManagedInstances(session *session.Session, namePrefix string, registeredBefore time.Time) ([]string, error) {
+	s := ssm.New(session)
+	input := ssm.DescribeInstanceInformationInput{
+		Filters: []*ssm.InstanceInformationStringFilter{
+			{Key: aws.String("ResourceType"), Values: []*string{aws.String(ssm.ResourceTypeManagedInstance)}},
+		},
+	}
+
+	var ids []string
+	err := s.DescribeInstanceInformationPages(&input, func(page *ssm.DescribeInstanceInformationOutput, _ bool) bool {
+		for _, i := range page.InstanceInformationList {
+			if strings.HasPrefix(aws.StringValue(i.Name), namePrefix) && i.RegistrationDate != nil && i.RegistrationDate.Before(registeredBefore) {
+				ids = append(ids, aws.StringValue(i.InstanceId))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ssm managed instances: %v", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,247 @@
+package vsphere
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	libraryContentCorrupted    = "1"
+	libraryContentDoesNotExist = "-1"
+
+	importLockSuffix       = "-import-lock"
+	importLockTimeout      = 45 * time.Minute
+	importLockPollInterval = 30 * time.Second
+)
+
+// ImportTemplateIfMissing imports the ova in ovaURL into library as templateName if it's not there
+// already, creating the library in datastore if needed. The ova is downloaded once and, when checksum
+// is not empty, verified against this sha256 checksum before importing the local file. Imports of the
+// same template are serialized across processes with a lock item in the content library.
+func ImportTemplateIfMissing(envMap map[string]string, library, templateName, datastore, ovaURL, checksum string) error {
+	ctx := context.Background()
+	executableBuilder, close, err := executables.InitInDockerExecutablesBuilder(ctx, executables.DefaultEksaImage())
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	defer close.CheckErr(ctx)
+	tmpWriter, _ := filewriter.NewWriter(templateName)
+	govc := executableBuilder.BuildGovcExecutable(tmpWriter, executables.WithGovcEnvMap(envMap))
+	defer govc.Close(ctx)
+
+	if err = createLibraryIfMissing(ctx, govc, library, datastore); err != nil {
+		return err
+	}
+
+	return importOVAIfMissing(ctx, govc, tmpWriter, library, templateName, ovaURL, checksum)
+}
+
+func createLibraryIfMissing(ctx context.Context, govc *executables.Govc, library, datastore string) error {
+	libraryExists, err := govc.LibraryElementExists(ctx, library)
+	if err != nil {
+		return fmt.Errorf("failed to validate library for template: %v", err)
+	}
+
+	if !libraryExists {
+		if err = govc.CreateLibrary(ctx, datastore, library); err != nil {
+			return fmt.Errorf("failed creating library for template: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func importOVAIfMissing(ctx context.Context, govc *executables.Govc, writer filewriter.FileWriter, library, templateName, ovaURL, checksum string) error {
+	templatePath := filepath.Join(library, templateName)
+	contentVersion, err := govc.GetLibraryElementContentVersion(ctx, templatePath)
+	if err != nil {
+		return fmt.Errorf("failed to validate template in library: %v", err)
+	}
+
+	// A template being imported by another process looks corrupted until it's done,
+	// so it can only be deleted while holding the lock.
+	if contentVersion != libraryContentCorrupted && contentVersion != libraryContentDoesNotExist {
+		return nil
+	}
+
+	lock := &importLock{govc: govc, writer: writer, library: library, name: templateName + importLockSuffix}
+	if err = lock.acquire(ctx); err != nil {
+		return err
+	}
+	defer lock.release(ctx)
+
+	missing, err := deleteCorruptedTemplate(ctx, govc, templatePath)
+	if err != nil || !missing {
+		return err
+	}
+
+	ovaPath := filepath.Join(writer.Dir(), templateName+".ova")
+	if err = DownloadOVA(ctx, ovaURL, checksum, ovaPath); err != nil {
+		return err
+	}
+	defer os.Remove(ovaPath)
+
+	if err = govc.ImportLibraryItem(ctx, library, ovaPath, templateName); err != nil {
+		return fmt.Errorf("failed importing template into library: %v", err)
+	}
+
+	return nil
+}
+
+// deleteCorruptedTemplate deletes the template in templatePath if it's corrupted and
+// returns whether the template is missing from the library.
+func deleteCorruptedTemplate(ctx context.Context, govc *executables.Govc, templatePath string) (bool, error) {
+	contentVersion, err := govc.GetLibraryElementContentVersion(ctx, templatePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to validate template in library: %v", err)
+	}
+
+	if contentVersion == libraryContentCorrupted {
+		if err = govc.DeleteLibraryElement(ctx, templatePath); err != nil {
+			return false, fmt.Errorf("failed to delete corrupted template in library: %v", err)
+		}
+		contentVersion = libraryContentDoesNotExist
+	}
+
+	return contentVersion == libraryContentDoesNotExist, nil
+}
+
+// importLock is a lock shared by every process importing into the same content library.
+// It's held while the library contains an item with the lock name.
+type importLock struct {
+	govc    *executables.Govc
+	writer  filewriter.FileWriter
+	library string
+	name    string
+}
+
+func (l *importLock) acquire(ctx context.Context) error {
+	lockPath := filepath.Join(l.library, l.name)
+	deadline := time.Now().Add(importLockTimeout)
+	for {
+		acquired, err := l.tryAcquire(ctx)
+		if err != nil || acquired {
+			return err
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for template import lock %s, delete it if no other import is running", lockPath)
+		}
+
+		logger.V(2).Info("Waiting for another process to import template", "lock", lockPath)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(importLockPollInterval):
+		}
+	}
+}
+
+func (l *importLock) tryAcquire(ctx context.Context) (bool, error) {
+	held, err := l.held(ctx)
+	if err != nil || held {
+		return false, err
+	}
+
+	lockFile, err := l.writer.Write(l.name, []byte(l.name))
+	if err != nil {
+		return false, fmt.Errorf("writing template import lock file: %v", err)
+	}
+
+	importErr := l.govc.ImportLibraryItem(ctx, l.library, lockFile, l.name)
+	_ = os.Remove(lockFile)
+	if importErr == nil {
+		return true, nil
+	}
+
+	// Item names are unique in a library, so the import fails if another process got the lock first.
+	if held, err = l.held(ctx); err != nil {
+		return false, err
+	}
+	if !held {
+		return false, fmt.Errorf("creating template import lock %s: %v", filepath.Join(l.library, l.name), importErr)
+	}
+
+	return false, nil
+}
+
+func (l *importLock) held(ctx context.Context) (bool, error) {
+	contentVersion, err := l.govc.GetLibraryElementContentVersion(ctx, filepath.Join(l.library, l.name))
+	if err != nil {
+		return false, fmt.Errorf("failed to validate template import lock in library: %v", err)
+	}
+
+	return contentVersion != libraryContentDoesNotExist, nil
+}
+
+func (l *importLock) release(ctx context.Context) {
+	lockPath := filepath.Join(l.library, l.name)
+	if err := l.govc.DeleteLibraryElement(ctx, lockPath); err != nil {
+		logger.Info("Failed to release template import lock, delete it manually", "lock", lockPath, "error", err)
+	}
+}
+
+// DownloadOVA downloads the ova in ovaURL to path. When checksum is not empty, the ova is
+// hashed while it's downloaded and its sha256 checksum must match checksum.
+func DownloadOVA(ctx context.Context, ovaURL, checksum, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ovaURL, nil)
+	if err != nil {
+		return fmt.Errorf("building request for ova %s: %v", ovaURL, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("downloading ova %s: %v", ovaURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading ova %s: unexpected status %s", ovaURL, resp.Status)
+	}
+
+	sum, err := writeOVA(resp.Body, path)
+	if err != nil {
+		_ = os.Remove(path)
+		return fmt.Errorf("downloading ova %s: %v", ovaURL, err)
+	}
+
+	if checksum != "" && !strings.EqualFold(sum, checksum) {
+		_ = os.Remove(path)
+		return fmt.Errorf("ova %s sha256 checksum %s doesn't match the expected %s", ovaURL, sum, checksum)
+	}
+
+	return nil
+}
+
+// writeOVA writes the ova in r to path and returns its sha256 checksum.
+func writeOVA(r io.Reader, path string) (string, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(file, hash), r); err != nil {
+		file.Close()
+		return "", err
+	}
+
+	if err = file.Close(); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
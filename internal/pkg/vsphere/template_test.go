@@ -0,0 +1,78 @@
+package vsphere_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/pkg/vsphere"
+)
+
+func TestDownloadOVA(t *testing.T) {
+	ova := []byte("ova content")
+	sum := sha256.Sum256(ova)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/template.ova" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(ova)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		url      string
+		checksum string
+		wantErr  string
+	}{
+		{
+			name:     "valid checksum",
+			url:      server.URL + "/template.ova",
+			checksum: hex.EncodeToString(sum[:]),
+		},
+		{
+			name:     "valid uppercase checksum",
+			url:      server.URL + "/template.ova",
+			checksum: strings.ToUpper(hex.EncodeToString(sum[:])),
+		},
+		{
+			name: "no checksum",
+			url:  server.URL + "/template.ova",
+		},
+		{
+			name:     "invalid checksum",
+			url:      server.URL + "/template.ova",
+			checksum: "abcd",
+			wantErr:  "doesn't match the expected abcd",
+		},
+		{
+			name:     "ova not found",
+			url:      server.URL + "/other.ova",
+			checksum: "abcd",
+			wantErr:  "unexpected status 404 Not Found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			path := filepath.Join(t.TempDir(), "template.ova")
+			err := vsphere.DownloadOVA(context.Background(), tt.url, tt.checksum, path)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(os.ReadFile(path)).To(Equal(ova))
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				g.Expect(path).NotTo(BeAnExistingFile())
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package vsphere
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// ListVMs returns the paths of the VMs under folder whose name starts with namePrefix and that were
+// created before createdBefore. VMs without a creation date are skipped, since their age is unknown.
+func ListVMs(envMap map[string]string, folder, namePrefix string, createdBefore time.Time) ([]string, error) {
+	ctx := context.Background()
+	executableBuilder, close, err := executables.InitInDockerExecutablesBuilder(ctx, executables.DefaultEksaImage())
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	defer close.CheckErr(ctx)
+	tmpWriter, _ := filewriter.NewWriter("listvms")
+	govc := executableBuilder.BuildGovcExecutable(tmpWriter, executables.WithGovcEnvMap(envMap))
+	defer govc.Close(ctx)
+
+	result, err := govc.ExecuteWithEnv(ctx, envMap, "find", folder, "-type", "m", "-name", namePrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find vms in %s: %v", folder, err)
+	}
+
+	var vms []string
+	scanner := bufio.NewScanner(strings.NewReader(result.String()))
+	for scanner.Scan() {
+		vm := strings.TrimSpace(scanner.Text())
+		if vm == "" {
+			continue
+		}
+
+		info, err := govc.ExecuteWithEnv(ctx, envMap, "vm.info", "-json", vm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get info of vm %s: %v", vm, err)
+		}
+
+		createDate, err := vmCreateDate(info.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read info of vm %s: %v", vm, err)
+		}
+		if createDate.IsZero() {
+			logger.V(2).Info("Skipping vm without creation date", "vm", vm)
+			continue
+		}
+
+		if createDate.Before(createdBefore) {
+			vms = append(vms, vm)
+		}
+	}
+
+	return vms, nil
+}
+
+// DeleteVMs powers off and destroys the VMs in paths.
+func DeleteVMs(envMap map[string]string, paths []string) error {
+	ctx := context.Background()
+	executableBuilder, close, err := executables.InitInDockerExecutablesBuilder(ctx, executables.DefaultEksaImage())
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	defer close.CheckErr(ctx)
+	tmpWriter, _ := filewriter.NewWriter("rmvms")
+	govc := executableBuilder.BuildGovcExecutable(tmpWriter, executables.WithGovcEnvMap(envMap))
+	defer govc.Close(ctx)
+
+	for _, vm := range paths {
+		if _, err := govc.ExecuteWithEnv(ctx, envMap, "vm.power", "-off", "-force", vm); err != nil {
+			logger.V(3).Info("Failed powering off vm, it may already be off", "vm", vm, "error", err)
+		}
+		if _, err := govc.ExecuteWithEnv(ctx, envMap, "object.destroy", vm); err != nil {
+			return fmt.Errorf("failed to delete vm %s: %v", vm, err)
+		}
+	}
+
+	return nil
+}
+
+type vmInfo struct {
+	VirtualMachines []struct {
+		Config struct {
+			CreateDate *time.Time
+		}
+	}
+}
+
+// vmCreateDate returns the creation date in the output of govc vm.info -json. It's zero when vSphere
+// doesn't report it.
+func vmCreateDate(info []byte) (time.Time, error) {
+	parsed := &vmInfo{}
+	if err := json.Unmarshal(info, parsed); err != nil {
+		return time.Time{}, err
+	}
+
+	if len(parsed.VirtualMachines) == 0 || parsed.VirtualMachines[0].Config.CreateDate == nil {
+		return time.Time{}, nil
+	}
+
+	return *parsed.VirtualMachines[0].Config.CreateDate, nil
+}
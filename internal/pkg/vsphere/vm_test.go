@@ -0,0 +1,50 @@
+package vsphere
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestVMCreateDate(t *testing.T) {
+	tests := []struct {
+		name string
+		info string
+		want time.Time
+	}{
+		{
+			name: "create date",
+			info: `{"VirtualMachines":[{"Config":{"Name":"eksa-e2e-1","CreateDate":"2022-08-01T10:00:00Z"}}]}`,
+			want: time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "lower camel case keys",
+			info: `{"virtualMachines":[{"config":{"name":"eksa-e2e-1","createDate":"2022-08-01T10:00:00Z"}}]}`,
+			want: time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "no create date",
+			info: `{"VirtualMachines":[{"Config":{"Name":"eksa-e2e-1"}}]}`,
+		},
+		{
+			name: "no vm",
+			info: `{"VirtualMachines":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := vmCreateDate([]byte(tt.info))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got.Equal(tt.want)).To(BeTrue(), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestVMCreateDateInvalidInfo(t *testing.T) {
+	g := NewWithT(t)
+	_, err := vmCreateDate([]byte("not json"))
+	g.Expect(err).To(HaveOccurred())
+}
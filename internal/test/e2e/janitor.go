@@ -0,0 +1,208 @@
+package e2e
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/go-logr/logr"
+
+	"github.com/aws/eks-anywhere/internal/pkg/ec2"
+	"github.com/aws/eks-anywhere/internal/pkg/ssm"
+	"github.com/aws/eks-anywhere/internal/pkg/vsphere"
+)
+
+// JanitorConf configures a janitor run, which decommissions the test runners leaked by e2e runs that
+// failed to clean up after themselves.
+type JanitorConf struct {
+	TestInstanceConfigFile string
+	// TTL is the age after which a test runner is considered leaked. It has to be longer than the
+	// longest e2e run, so the test runners still in use are left alone.
+	TTL time.Duration
+	// DryRun only logs the resources that would be decommissioned.
+	DryRun bool
+	Logger logr.Logger
+}
+
+type janitor struct {
+	JanitorConf
+	session *session.Session
+	config  *TestInfraConfig
+	cutoff  time.Time
+}
+
+// RunJanitor decommissions the ec2 instances and vSphere VMs named after a test runner that are older
+// than the TTL, the ssm activations and managed instances registered for them and the cloudwatch
+// outputs of the commands they ran. It goes through all of them even if some fail, so one failure
+// doesn't leak the rest.
+func RunJanitor(conf JanitorConf) error {
+	config, err := NewTestRunnerConfigFromFile(conf.Logger, conf.TestInstanceConfigFile)
+	if err != nil {
+		return err
+	}
+
+	session, err := session.NewSession()
+	if err != nil {
+		return fmt.Errorf("creating session: %v", err)
+	}
+
+	j := &janitor{
+		JanitorConf: conf,
+		session:     session,
+		config:      config,
+		cutoff:      time.Now().Add(-conf.TTL),
+	}
+
+	errs := map[string]error{}
+	ec2Instances, err := j.decommEc2Instances()
+	if err != nil {
+		errs["ec2 instances"] = err
+	}
+
+	if err = j.decommVSphereVMs(); err != nil {
+		errs["vSphere vms"] = err
+	}
+
+	ssmInstances, err := j.decommSSMInstances()
+	if err != nil {
+		errs["ssm instances"] = err
+	}
+
+	if err = j.deleteCloudwatchOutputs(append(ec2Instances, ssmInstances...)); err != nil {
+		errs["cloudwatch outputs"] = err
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("decommissioning leaked test runners: %+v", errs)
+	}
+
+	return nil
+}
+
+func (j *janitor) decommEc2Instances() ([]string, error) {
+	instances, err := ec2.ListInstancesByNamePrefix(j.session, testRunnerNamePrefix, j.cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := aws.StringValueSlice(instances)
+	j.Logger.Info("Found leaked ec2 test runners", "instances", ids)
+	if len(ids) == 0 || j.DryRun {
+		return ids, nil
+	}
+
+	if err = ec2.TerminateEc2Instances(j.session, instances); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (j *janitor) decommVSphereVMs() error {
+	v := &j.config.VSphereTestRunner
+	if v.Url == "" {
+		j.Logger.V(1).Info("No vSphere test runner configured, skipping vSphere vms")
+		return nil
+	}
+
+	envMap, err := v.setEnvironment()
+	if err != nil {
+		return fmt.Errorf("failed to set env for vSphere test runner: %v", err)
+	}
+
+	folder := path.Join("/", v.Datacenter, "vm", v.Folder)
+	vms, err := vsphere.ListVMs(envMap, folder, testRunnerNamePrefix, j.cutoff)
+	if err != nil {
+		return err
+	}
+
+	j.Logger.Info("Found leaked vSphere test runners", "vms", vms)
+	if len(vms) == 0 || j.DryRun {
+		return nil
+	}
+
+	return vsphere.DeleteVMs(envMap, vms)
+}
+
+// decommSSMInstances deregisters the managed instances of the leaked vSphere test runners and deletes
+// their activations. It returns the ids of the managed instances.
+func (j *janitor) decommSSMInstances() ([]string, error) {
+	instances, err := ssm.ListManagedInstances(j.session, testRunnerNamePrefix, j.cutoff)
+	if err != nil {
+		return nil, err
+	}
+	instances = withoutLabHosts(instances, j.config.TinkerbellTestRunner.InstanceIDs)
+
+	activations, err := ssm.ListActivations(j.session, testRunnerNamePrefix, j.cutoff)
+	if err != nil {
+		return instances, err
+	}
+
+	j.Logger.Info("Found leaked ssm test runners", "instances", instances, "activations", activations)
+	if j.DryRun {
+		return instances, nil
+	}
+
+	errs := map[string]error{}
+	for _, id := range instances {
+		if _, err := ssm.DeregisterInstance(j.session, id); err != nil {
+			errs[id] = err
+		}
+	}
+
+	for _, id := range activations {
+		if _, err := ssm.DeleteActivation(j.session, id); err != nil {
+			errs[id] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return instances, fmt.Errorf("%+v", errs)
+	}
+
+	return instances, nil
+}
+
+func (j *janitor) deleteCloudwatchOutputs(instances []string) error {
+	if len(instances) == 0 {
+		return nil
+	}
+
+	streams, err := ssm.ListCloudwatchOutputs(j.session, instances, j.cutoff)
+	if err != nil {
+		return err
+	}
+
+	j.Logger.Info("Found cloudwatch outputs of leaked test runners", "streams", len(streams))
+	if j.DryRun {
+		return nil
+	}
+
+	for _, stream := range streams {
+		if err := ssm.DeleteCloudwatchOutput(j.session, stream); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withoutLabHosts removes the Tinkerbell lab hosts from instances. They are long-lived managed
+// instances shared by all the runs, so they are never decommissioned.
+func withoutLabHosts(instances, labHosts []string) []string {
+	hosts := make(map[string]struct{}, len(labHosts))
+	for _, h := range labHosts {
+		hosts[h] = struct{}{}
+	}
+
+	var filtered []string
+	for _, i := range instances {
+		if _, ok := hosts[i]; !ok {
+			filtered = append(filtered, i)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,14 @@
+package e2e
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWithoutLabHosts(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(withoutLabHosts([]string{"mi-1", "mi-2", "mi-3"}, []string{"mi-2"})).To(Equal([]string{"mi-1", "mi-3"}))
+	g.Expect(withoutLabHosts([]string{"mi-1"}, nil)).To(Equal([]string{"mi-1"}))
+	g.Expect(withoutLabHosts([]string{"mi-1"}, []string{"mi-1"})).To(BeEmpty())
+}
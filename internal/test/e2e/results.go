@@ -0,0 +1,246 @@
+package e2e
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/aws/eks-anywhere/internal/pkg/s3"
+)
+
+const (
+	testResultSkip = "skip"
+
+	junitResultsFile   = "junit.xml"
+	summaryResultsFile = "summary.json"
+)
+
+// testResultLineRe matches the verbose go test result line of a top level test.
+var testResultLineRe = regexp.MustCompile(`^--- (PASS|FAIL|SKIP): (\S+) \(([0-9.]+)s\)`)
+
+var testResultStatuses = map[string]string{
+	"PASS": testResultPass,
+	"FAIL": testResultFail,
+	"SKIP": testResultSkip,
+}
+
+type testResult struct {
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	JobID           string  `json:"jobId"`
+	InstanceID      string  `json:"instanceId,omitempty"`
+	CommandID       string  `json:"commandId,omitempty"`
+	Message         string  `json:"message,omitempty"`
+}
+
+type testResultsSummary struct {
+	JobID           string       `json:"jobId"`
+	Total           int          `json:"total"`
+	Passed          int          `json:"passed"`
+	Failed          int          `json:"failed"`
+	Skipped         int          `json:"skipped"`
+	Errored         int          `json:"errored"`
+	DurationSeconds float64      `json:"durationSeconds"`
+	Tests           []testResult `json:"tests"`
+}
+
+// resultsCollector aggregates the results of the tests run by every test runner.
+type resultsCollector struct {
+	jobID   string
+	results []testResult
+}
+
+func newResultsCollector(jobID string) *resultsCollector {
+	return &resultsCollector{jobID: jobID}
+}
+
+// add records the results of the tests run by an instance. Tests without a result in the command
+// output, because the runner failed before or while running them, are reported as errors.
+func (c *resultsCollector) add(r instanceTestsResults) {
+	var output []byte
+	var commandID string
+	if r.testCommandResult != nil {
+		output = r.testCommandResult.StdOut
+		commandID = r.testCommandResult.CommandId
+	}
+
+	parsed := parseTestResults(output)
+	for _, name := range strings.Split(strings.Trim(r.conf.regex, "\""), "|") {
+		result, ok := parsed[name]
+		if !ok {
+			result = testResult{Name: name, Status: testResultError, Message: "no result found in the test runner output"}
+			if r.err != nil {
+				result.Message = r.err.Error()
+			}
+		} else if result.Status == testResultFail {
+			result.Message = fmt.Sprintf("test failed, see the output of ssm command %s", commandID)
+		}
+
+		result.JobID = r.conf.jobId
+		result.InstanceID = r.conf.instanceId
+		result.CommandID = commandID
+		c.results = append(c.results, result)
+	}
+}
+
+func parseTestResults(output []byte) map[string]testResult {
+	results := map[string]testResult{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		m := testResultLineRe.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		seconds, err := strconv.ParseFloat(m[3], 64)
+		if err != nil {
+			continue
+		}
+		results[m[2]] = testResult{Name: m[2], Status: testResultStatuses[m[1]], DurationSeconds: seconds}
+	}
+	return results
+}
+
+func (c *resultsCollector) summary() testResultsSummary {
+	s := testResultsSummary{JobID: c.jobID, Total: len(c.results), Tests: c.results}
+	for _, r := range c.results {
+		switch r.Status {
+		case testResultPass:
+			s.Passed++
+		case testResultFail:
+			s.Failed++
+		case testResultSkip:
+			s.Skipped++
+		default:
+			s.Errored++
+		}
+		s.DurationSeconds += r.DurationSeconds
+	}
+	return s
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Hostname  string          `xml:"hostname,attr,omitempty"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// junit builds the JUnit report of the tests, with a test suite per test runner.
+func (c *resultsCollector) junit() ([]byte, error) {
+	s := c.summary()
+	report := junitTestSuites{
+		Name:     c.jobID,
+		Tests:    s.Total,
+		Failures: s.Failed,
+		Errors:   s.Errored,
+		Skipped:  s.Skipped,
+		Time:     junitTime(s.DurationSeconds),
+	}
+
+	suites := map[string]int{}
+	var suiteSeconds []float64
+	for _, r := range c.results {
+		i, ok := suites[r.JobID]
+		if !ok {
+			i = len(report.Suites)
+			suites[r.JobID] = i
+			report.Suites = append(report.Suites, junitTestSuite{Name: r.JobID, Hostname: r.InstanceID})
+			suiteSeconds = append(suiteSeconds, 0)
+		}
+		suite := &report.Suites[i]
+		suiteSeconds[i] += r.DurationSeconds
+
+		testCase := junitTestCase{Name: r.Name, Classname: "e2e", Time: junitTime(r.DurationSeconds)}
+		switch r.Status {
+		case testResultFail:
+			testCase.Failure = &junitMessage{Message: r.Message}
+			suite.Failures++
+		case testResultSkip:
+			testCase.Skipped = &junitMessage{}
+			suite.Skipped++
+		case testResultError:
+			testCase.Error = &junitMessage{Message: r.Message}
+			suite.Errors++
+		}
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	for i := range report.Suites {
+		report.Suites[i].Time = junitTime(suiteSeconds[i])
+	}
+
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshalling junit report: %v", err)
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+func junitTime(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
+
+// upload writes the JUnit report and the JSON summary of the tests to the results folder of the job in bucket.
+func (c *resultsCollector) upload(session *session.Session, bucket string) error {
+	junit, err := c.junit()
+	if err != nil {
+		return err
+	}
+
+	summary, err := json.MarshalIndent(c.summary(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling tests summary: %v", err)
+	}
+
+	folder := resultsPath(c.jobID)
+	if err = s3.Upload(session, junit, filepath.Join(folder, junitResultsFile), bucket); err != nil {
+		return fmt.Errorf("uploading junit report: %v", err)
+	}
+	if err = s3.Upload(session, summary, filepath.Join(folder, summaryResultsFile), bucket); err != nil {
+		return fmt.Errorf("uploading tests summary: %v", err)
+	}
+
+	return nil
+}
+
+func resultsPath(jobID string) string {
+	return filepath.Join(jobID, "results")
+}
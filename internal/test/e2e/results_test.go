@@ -0,0 +1,72 @@
+package e2e
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const testRunnerOutput = `=== RUN   TestVSphereKubernetes121SimpleFlow
+--- PASS: TestVSphereKubernetes121SimpleFlow (612.40s)
+=== RUN   TestVSphereKubernetes121Upgrade
+    --- FAIL: TestVSphereKubernetes121Upgrade/upgrade (10.00s)
+--- FAIL: TestVSphereKubernetes121Upgrade (700.25s)
+=== RUN   TestVSphereKubernetes121Skipped
+--- SKIP: TestVSphereKubernetes121Skipped (0.00s)
+FAIL
+`
+
+func newTestResultsCollector() *resultsCollector {
+	c := newResultsCollector("job")
+	c.add(instanceTestsResults{
+		conf: instanceRunConf{
+			jobId:      "job-0",
+			instanceId: "i-0",
+			regex:      "TestVSphereKubernetes121SimpleFlow|TestVSphereKubernetes121Upgrade|TestVSphereKubernetes121Skipped|TestVSphereKubernetes121Missing",
+		},
+		testCommandResult: &testCommandResult{CommandId: "command-0", StdOut: []byte(testRunnerOutput)},
+	})
+	c.add(instanceTestsResults{
+		conf: instanceRunConf{jobId: "job-1", regex: "TestDockerKubernetes121SimpleFlow"},
+		err:  errors.New("creating instance"),
+	})
+	return c
+}
+
+func TestResultsCollectorSummary(t *testing.T) {
+	g := NewWithT(t)
+	s := newTestResultsCollector().summary()
+
+	g.Expect(s.JobID).To(Equal("job"))
+	g.Expect(s.Total).To(Equal(5))
+	g.Expect(s.Passed).To(Equal(1))
+	g.Expect(s.Failed).To(Equal(1))
+	g.Expect(s.Skipped).To(Equal(1))
+	g.Expect(s.Errored).To(Equal(2))
+	g.Expect(s.DurationSeconds).To(BeNumerically("~", 1312.65, 0.001))
+	g.Expect(s.Tests).To(ConsistOf(
+		testResult{Name: "TestVSphereKubernetes121SimpleFlow", Status: testResultPass, DurationSeconds: 612.40, JobID: "job-0", InstanceID: "i-0", CommandID: "command-0"},
+		testResult{Name: "TestVSphereKubernetes121Upgrade", Status: testResultFail, DurationSeconds: 700.25, JobID: "job-0", InstanceID: "i-0", CommandID: "command-0", Message: "test failed, see the output of ssm command command-0"},
+		testResult{Name: "TestVSphereKubernetes121Skipped", Status: testResultSkip, JobID: "job-0", InstanceID: "i-0", CommandID: "command-0"},
+		testResult{Name: "TestVSphereKubernetes121Missing", Status: testResultError, JobID: "job-0", InstanceID: "i-0", CommandID: "command-0", Message: "no result found in the test runner output"},
+		testResult{Name: "TestDockerKubernetes121SimpleFlow", Status: testResultError, JobID: "job-1", Message: "creating instance"},
+	))
+}
+
+func TestResultsCollectorJUnit(t *testing.T) {
+	g := NewWithT(t)
+	junit, err := newTestResultsCollector().junit()
+	g.Expect(err).To(BeNil())
+
+	report := string(junit)
+	g.Expect(report).To(HavePrefix(`<?xml version="1.0" encoding="UTF-8"?>`))
+	g.Expect(report).To(ContainSubstring(`<testsuites name="job" tests="5" failures="1" errors="2" skipped="1" time="1312.650">`))
+	g.Expect(report).To(ContainSubstring(`<testsuite name="job-0" hostname="i-0" tests="4" failures="1" errors="1" skipped="1" time="1312.650">`))
+	g.Expect(report).To(ContainSubstring(`<testsuite name="job-1" tests="1" failures="0" errors="1" skipped="0" time="0.000">`))
+	g.Expect(report).To(ContainSubstring(`<testcase name="TestVSphereKubernetes121SimpleFlow" classname="e2e" time="612.400"></testcase>`))
+	g.Expect(report).To(ContainSubstring(`<failure message="test failed, see the output of ssm command command-0"></failure>`))
+	g.Expect(report).To(ContainSubstring(`<error message="creating instance"></error>`))
+	g.Expect(strings.Count(report, "<skipped></skipped>")).To(Equal(1))
+}
@@ -6,7 +6,6 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/go-logr/logr"
@@ -37,6 +36,7 @@ type ParallelRunConf struct {
 	TestsToSkip            []string
 	BundlesOverride        bool
 	CleanupVms             bool
+	RetainFailedInstances  bool
 	TestReportFolder       string
 	BranchName             string
 	BaremetalBranchName    string
@@ -66,42 +66,25 @@ func RunTestsInParallel(conf ParallelRunConf) error {
 		}
 	}
 
-	var wg sync.WaitGroup
-
 	instancesConf, err := splitTests(testsList, conf)
 	if err != nil {
 		return fmt.Errorf("failed to split tests: %v", err)
 	}
 
-	results := make([]instanceTestsResults, 0, len(instancesConf))
 	logTestGroups(conf.Logger, instancesConf)
-	maxConcurrentTests := conf.MaxConcurrentTests
-	// Add a blocking channel to only allow for certain number of tests to run at a time
-	queue := make(chan struct{}, maxConcurrentTests)
-	for _, instanceConf := range instancesConf {
-		queue <- struct{}{}
-		go func(c instanceRunConf) {
-			defer wg.Done()
-			r := instanceTestsResults{conf: c}
-
-			r.conf.instanceId, r.testCommandResult, err = RunTests(c)
-			if err != nil {
-				r.err = err
-			}
-
-			results = append(results, r)
-			<-queue
-		}(instanceConf)
-		wg.Add(1)
-	}
-
-	wg.Wait()
-	close(queue)
+	scheduler := newRunnerScheduler(conf.MaxConcurrentTests, func(c instanceRunConf) instanceTestsResults {
+		conf.Logger.Info("Instance tests run started", "tests", c.regex, "jobId", c.jobId)
+		r := instanceTestsResults{conf: c}
+		r.conf.instanceId, r.testCommandResult, r.err = RunTests(c)
+		return r
+	})
 
 	failedInstances := 0
 	totalInstances := len(instancesConf)
 	completedInstances := 0
-	for _, r := range results {
+	results := newResultsCollector(conf.JobId)
+	for r := range scheduler.schedule(instancesConf) {
+		results.add(r)
 		var result string
 		// TODO: keeping the old logs temporarily for compatibility with the test tool
 		// Once the tool is updated to support the unified message, remove them
@@ -128,6 +111,10 @@ func RunTestsInParallel(conf ParallelRunConf) error {
 		)
 	}
 
+	if err = uploadTestResults(conf, results); err != nil {
+		conf.Logger.Error(err, "Failed uploading e2e tests results", "jobId", conf.JobId)
+	}
+
 	if failedInstances > 0 {
 		return fmt.Errorf("%d/%d e2e instances failed", failedInstances, totalInstances)
 	}
@@ -135,6 +122,20 @@ func RunTestsInParallel(conf ParallelRunConf) error {
 	return nil
 }
 
+func uploadTestResults(conf ParallelRunConf, results *resultsCollector) error {
+	awsSession, err := session.NewSession()
+	if err != nil {
+		return fmt.Errorf("creating aws session for tests results: %v", err)
+	}
+
+	if err = results.upload(awsSession, conf.StorageBucket); err != nil {
+		return err
+	}
+
+	conf.Logger.Info("Uploaded e2e tests results", "bucket", conf.StorageBucket, "path", resultsPath(conf.JobId))
+	return nil
+}
+
 type instanceRunConf struct {
 	session                                                                   *session.Session
 	instanceProfileName, storageBucket, jobId, parentJobId, regex, instanceId string
@@ -145,6 +146,8 @@ type instanceRunConf struct {
 	testRunnerType                                                            TestRunnerType
 	testRunnerConfig                                                          TestInfraConfig
 	cleanupVms                                                                bool
+	retainFailedInstances                                                     bool
+	failed                                                                    bool
 	logger                                                                    logr.Logger
 }
 
@@ -160,9 +163,9 @@ func RunTests(conf instanceRunConf) (testInstanceID string, testCommandResult *t
 	}
 
 	defer func() {
-		err := testRunner.decommInstance(conf)
-		if err != nil {
-			conf.logger.V(1).Info("WARN: Failed to decomm e2e test runner instance", "error", err)
+		conf.failed = err != nil || !testCommandResult.Successful()
+		if decommErr := testRunner.decommInstance(conf); decommErr != nil {
+			conf.logger.V(1).Info("WARN: Failed to decomm e2e test runner instance", "error", decommErr)
 		}
 	}()
 
@@ -380,7 +383,7 @@ func splitTinkerbellTests(awsSession *session.Session, testsList []string, conf
 				hardware, hardwareChunks = hardwareChunks[0], hardwareChunks[1:]
 			}
 
-			runConfs = append(runConfs, newInstanceRunConf(awsSession, conf, len(runConfs), strings.Join(testsInVSphereInstance, "|"), ips, hardware, VSphereTestRunnerType, testRunnerConfig))
+			runConfs = append(runConfs, newInstanceRunConf(awsSession, conf, len(runConfs), strings.Join(testsInVSphereInstance, "|"), ips, hardware, testRunnerConfig.tinkerbellTestRunnerType(), testRunnerConfig))
 
 			if remainingTests > 0 {
 				remainingTests--
@@ -401,21 +404,22 @@ func splitTinkerbellTests(awsSession *session.Session, testsList []string, conf
 func newInstanceRunConf(awsSession *session.Session, conf ParallelRunConf, jobNumber int, testRegex string, ipPool networkutils.IPPool, hardware []*api.Hardware, testRunnerType TestRunnerType, testRunnerConfig *TestInfraConfig) instanceRunConf {
 	jobID := fmt.Sprintf("%s-%d", conf.JobId, jobNumber)
 	return instanceRunConf{
-		session:             awsSession,
-		instanceProfileName: conf.InstanceProfileName,
-		storageBucket:       conf.StorageBucket,
-		jobId:               jobID,
-		parentJobId:         conf.JobId,
-		regex:               testRegex,
-		ipPool:              ipPool,
-		hardware:            hardware,
-		bundlesOverride:     conf.BundlesOverride,
-		testReportFolder:    conf.TestReportFolder,
-		branchName:          conf.BranchName,
-		cleanupVms:          conf.CleanupVms,
-		testRunnerType:      testRunnerType,
-		testRunnerConfig:    *testRunnerConfig,
-		logger:              conf.Logger.WithValues("jobID", jobID, "test", testRegex),
+		session:               awsSession,
+		instanceProfileName:   conf.InstanceProfileName,
+		storageBucket:         conf.StorageBucket,
+		jobId:                 jobID,
+		parentJobId:           conf.JobId,
+		regex:                 testRegex,
+		ipPool:                ipPool,
+		hardware:              hardware,
+		bundlesOverride:       conf.BundlesOverride,
+		testReportFolder:      conf.TestReportFolder,
+		branchName:            conf.BranchName,
+		cleanupVms:            conf.CleanupVms,
+		retainFailedInstances: conf.RetainFailedInstances,
+		testRunnerType:        testRunnerType,
+		testRunnerConfig:      *testRunnerConfig,
+		logger:                conf.Logger.WithValues("jobID", jobID, "test", testRegex),
 	}
 }
 
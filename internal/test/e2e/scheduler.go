@@ -0,0 +1,49 @@
+package e2e
+
+import "sync"
+
+type runInstanceFunc func(instanceRunConf) instanceTestsResults
+
+// runnerScheduler runs a batch of instance run confs concurrently, never having more than parallelism
+// test runners at a time.
+type runnerScheduler struct {
+	parallelism int
+	run         runInstanceFunc
+}
+
+func newRunnerScheduler(parallelism int, run runInstanceFunc) *runnerScheduler {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	return &runnerScheduler{
+		parallelism: parallelism,
+		run:         run,
+	}
+}
+
+// schedule starts running all confs and returns a channel where the result of each runner is sent as soon
+// as it finishes. The channel is closed once all the runners are done.
+func (s *runnerScheduler) schedule(confs []instanceRunConf) <-chan instanceTestsResults {
+	results := make(chan instanceTestsResults, len(confs))
+	queue := make(chan struct{}, s.parallelism)
+	var wg sync.WaitGroup
+
+	go func() {
+		for _, c := range confs {
+			queue <- struct{}{}
+			wg.Add(1)
+			go func(c instanceRunConf) {
+				defer wg.Done()
+				results <- s.run(c)
+				<-queue
+			}(c)
+		}
+
+		wg.Wait()
+		close(queue)
+		close(results)
+	}()
+
+	return results
+}
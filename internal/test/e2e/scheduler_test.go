@@ -0,0 +1,78 @@
+package e2e
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRunnerSchedulerRunsAllConfs(t *testing.T) {
+	g := NewWithT(t)
+	confs := []instanceRunConf{{jobId: "job-1"}, {jobId: "job-2"}, {jobId: "job-3"}}
+	s := newRunnerScheduler(2, func(c instanceRunConf) instanceTestsResults {
+		return instanceTestsResults{conf: c}
+	})
+
+	jobs := []string{}
+	for r := range s.schedule(confs) {
+		jobs = append(jobs, r.conf.jobId)
+	}
+
+	g.Expect(jobs).To(ConsistOf("job-1", "job-2", "job-3"))
+}
+
+func TestRunnerSchedulerParallelismLimit(t *testing.T) {
+	g := NewWithT(t)
+	confs := make([]instanceRunConf, 10)
+	var lock sync.Mutex
+	running, maxRunning := 0, 0
+	s := newRunnerScheduler(3, func(c instanceRunConf) instanceTestsResults {
+		lock.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		lock.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		lock.Lock()
+		running--
+		lock.Unlock()
+		return instanceTestsResults{conf: c}
+	})
+
+	completed := 0
+	for range s.schedule(confs) {
+		completed++
+	}
+
+	g.Expect(completed).To(Equal(10))
+	g.Expect(maxRunning).To(BeNumerically("<=", 3))
+}
+
+func TestRunnerSchedulerStreamsResults(t *testing.T) {
+	g := NewWithT(t)
+	release := make(chan struct{})
+	confs := []instanceRunConf{{jobId: "fast"}, {jobId: "slow"}}
+	s := newRunnerScheduler(2, func(c instanceRunConf) instanceTestsResults {
+		if c.jobId == "slow" {
+			<-release
+		}
+		return instanceTestsResults{conf: c}
+	})
+
+	results := s.schedule(confs)
+	g.Expect((<-results).conf.jobId).To(Equal("fast"))
+	close(release)
+	g.Expect((<-results).conf.jobId).To(Equal("slow"))
+	g.Eventually(results).Should(BeClosed())
+}
+
+func TestRunnerSchedulerDefaultParallelism(t *testing.T) {
+	g := NewWithT(t)
+	s := newRunnerScheduler(0, nil)
+	g.Expect(s.parallelism).To(Equal(1))
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/aws/eks-anywhere/internal/pkg/vsphere"
 	"github.com/aws/eks-anywhere/internal/test/cleanup"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 )
 
@@ -30,6 +31,13 @@ const (
 	ssmActivationCodeKey       string = "ssm_activation_code"
 	ssmActivationIdKey         string = "ssm_activation_id"
 	ssmActivationRegionKey     string = "ssm_activation_region"
+
+	// testRunnerNamePrefix starts the names of all the test runners, so the janitor can find them.
+	testRunnerNamePrefix  = "eksa-e2e-"
+	maxTestRunnerNameSize = 80
+
+	decommMaxRetries = 3
+	decommBackoff    = 10 * time.Second
 )
 
 type TestRunner interface {
@@ -41,12 +49,14 @@ type TestRunner interface {
 type TestRunnerType string
 
 const (
-	Ec2TestRunnerType     TestRunnerType = "ec2"
-	VSphereTestRunnerType TestRunnerType = "vSphere"
+	Ec2TestRunnerType        TestRunnerType = "ec2"
+	VSphereTestRunnerType    TestRunnerType = "vSphere"
+	TinkerbellTestRunnerType TestRunnerType = "tinkerbell"
 )
 
 func newTestRunner(runnerType TestRunnerType, config TestInfraConfig) (TestRunner, error) {
-	if runnerType == VSphereTestRunnerType {
+	switch runnerType {
+	case VSphereTestRunnerType:
 		var err error
 		v := &config.VSphereTestRunner
 		v.envMap, err = v.setEnvironment()
@@ -54,14 +64,26 @@ func newTestRunner(runnerType TestRunnerType, config TestInfraConfig) (TestRunne
 			return nil, fmt.Errorf("failed to set env for vSphere test runner: %v", err)
 		}
 		return v, nil
-	} else {
+	case TinkerbellTestRunnerType:
+		return &config.TinkerbellTestRunner, nil
+	default:
 		return &config.Ec2TestRunner, nil
 	}
 }
 
 type TestInfraConfig struct {
-	Ec2TestRunner     `yaml:"ec2,omitempty"`
-	VSphereTestRunner `yaml:"vSphere,omitempty"`
+	Ec2TestRunner        `yaml:"ec2,omitempty"`
+	VSphereTestRunner    `yaml:"vSphere,omitempty"`
+	TinkerbellTestRunner `yaml:"tinkerbell,omitempty"`
+}
+
+// tinkerbellTestRunnerType returns the runner type for the Tinkerbell tests. They run in the bare metal
+// lab hosts when any is configured, nested in vSphere VMs otherwise.
+func (c *TestInfraConfig) tinkerbellTestRunnerType() TestRunnerType {
+	if len(c.TinkerbellTestRunner.InstanceIDs) > 0 {
+		return TinkerbellTestRunnerType
+	}
+	return VSphereTestRunnerType
 }
 
 func NewTestRunnerConfigFromFile(logger logr.Logger, configFile string) (*TestInfraConfig, error) {
@@ -73,11 +95,13 @@ func NewTestRunnerConfigFromFile(logger logr.Logger, configFile string) (*TestIn
 	config := TestInfraConfig{}
 	config.VSphereTestRunner.logger = logger
 	config.Ec2TestRunner.logger = logger
+	config.TinkerbellTestRunner.logger = logger
 
 	err = yaml.Unmarshal(file, &config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create test runner config from file: %v", err)
 	}
+	config.TinkerbellTestRunner.hosts = newHostPool(config.TinkerbellTestRunner.InstanceIDs)
 
 	return &config, nil
 }
@@ -91,6 +115,12 @@ type Ec2TestRunner struct {
 	testRunner
 	AmiID    string `yaml:"amiId"`
 	SubnetID string `yaml:"subnetId"`
+	// InstanceType overrides the default instance type of the test runners.
+	InstanceType string `yaml:"instanceType"`
+	// UseSpot runs the test runners on spot instances, falling back to on-demand ones when there's no
+	// spot capacity. MaxSpotPrice caps the hourly price, which defaults to the on-demand price.
+	UseSpot      bool   `yaml:"useSpot"`
+	MaxSpotPrice string `yaml:"maxSpotPrice"`
 }
 
 type VSphereTestRunner struct {
@@ -106,6 +136,41 @@ type VSphereTestRunner struct {
 	ResourcePool string `yaml:"resourcePool"`
 	Network      string `yaml:"network"`
 	Folder       string `yaml:"folder"`
+	// TemplateUrl is the ova imported as Template when it's not in Library yet.
+	TemplateUrl string `yaml:"templateUrl"`
+	// TemplateChecksum is the sha256 checksum the ova in TemplateUrl is verified against before importing it.
+	TemplateChecksum string `yaml:"templateChecksum"`
+}
+
+// TinkerbellTestRunner runs the tests in a static pool of bare metal lab hosts, already registered as ssm
+// managed instances, so the Tinkerbell tests don't need to run nested in vSphere VMs. Each host runs one
+// test group at a time and is reset before and after running it.
+type TinkerbellTestRunner struct {
+	testRunner
+	InstanceIDs []string `yaml:"instanceIds"`
+	hosts       *hostPool
+}
+
+// hostPool hands out the lab hosts to the test runners. It's shared by all the copies of the config.
+type hostPool struct {
+	free chan string
+}
+
+func newHostPool(instanceIDs []string) *hostPool {
+	p := &hostPool{free: make(chan string, len(instanceIDs))}
+	for _, id := range instanceIDs {
+		p.free <- id
+	}
+	return p
+}
+
+// claim waits until a host is free and returns its instance id.
+func (p *hostPool) claim() string {
+	return <-p.free
+}
+
+func (p *hostPool) release(instanceID string) {
+	p.free <- instanceID
 }
 
 func (v *VSphereTestRunner) setEnvironment() (map[string]string, error) {
@@ -138,8 +203,13 @@ func (v *VSphereTestRunner) createInstance(c instanceRunConf) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("unable to create ssm activation: %v", err)
 	}
+	logger.RegisterSecrets(ssmActivationInfo.ActivationCode)
 
-	// TODO: import ova template from url if not exist
+	if v.TemplateUrl != "" {
+		if err := vsphere.ImportTemplateIfMissing(v.envMap, v.Library, v.Template, v.Datastore, v.TemplateUrl, v.TemplateChecksum); err != nil {
+			return "", fmt.Errorf("importing test runner template: %v", err)
+		}
+	}
 
 	opts := vsphere.OVFDeployOptions{
 		Name:             name,
@@ -181,7 +251,7 @@ func (v *VSphereTestRunner) createInstance(c instanceRunConf) (string, error) {
 func (e *Ec2TestRunner) createInstance(c instanceRunConf) (string, error) {
 	name := getTestRunnerName(e.logger, c.jobId)
 	e.logger.V(1).Info("Creating ec2 Test Runner instance", "name", name)
-	instanceId, err := ec2.CreateInstance(c.session, e.AmiID, key, tag, c.instanceProfileName, e.SubnetID, name)
+	instanceId, err := ec2.CreateInstance(c.session, e.AmiID, key, tag, c.instanceProfileName, e.SubnetID, name, e.instanceOpts()...)
 	if err != nil {
 		return "", fmt.Errorf("creating instance for e2e tests: %v", err)
 	}
@@ -190,6 +260,37 @@ func (e *Ec2TestRunner) createInstance(c instanceRunConf) (string, error) {
 	return instanceId, nil
 }
 
+func (e *Ec2TestRunner) instanceOpts() []ec2.InstanceOpt {
+	opts := []ec2.InstanceOpt{ec2.WithInstanceType(e.InstanceType)}
+	if e.UseSpot {
+		opts = append(opts, ec2.WithSpot(e.MaxSpotPrice))
+	}
+	return opts
+}
+
+// resetLabHostCommand removes any leftovers of previous runs from a lab host: the kind clusters and
+// containers of the tests and the e2e folder.
+const resetLabHostCommand = "docker ps -aq | xargs -r docker rm -f && docker system prune -f --volumes && rm -rf /home/e2e"
+
+func (t *TinkerbellTestRunner) createInstance(c instanceRunConf) (string, error) {
+	t.logger.V(1).Info("Waiting for a free Tinkerbell lab host", "jobId", c.jobId)
+	instanceID := t.hosts.claim()
+	t.logger.V(1).Info("Claimed Tinkerbell lab host", "instance-id", instanceID, "jobId", c.jobId)
+
+	if err := ssm.WaitForSSMReady(c.session, instanceID); err != nil {
+		t.hosts.release(instanceID)
+		return "", fmt.Errorf("waiting for tinkerbell lab host %s: %v", instanceID, err)
+	}
+
+	if err := ssm.Run(c.session, t.logger, instanceID, resetLabHostCommand); err != nil {
+		t.hosts.release(instanceID)
+		return "", fmt.Errorf("resetting tinkerbell lab host %s: %v", instanceID, err)
+	}
+
+	t.InstanceID = instanceID
+	return instanceID, nil
+}
+
 func (v *VSphereTestRunner) tagInstance(c instanceRunConf, key, value string) error {
 	vmName := getTestRunnerName(v.logger, c.jobId)
 	vmPath := fmt.Sprintf("/%s/vm/%s/%s", v.Datacenter, v.Folder, vmName)
@@ -209,10 +310,27 @@ func (e *Ec2TestRunner) tagInstance(c instanceRunConf, key, value string) error
 	return nil
 }
 
+func (t *TinkerbellTestRunner) tagInstance(c instanceRunConf, key, value string) error {
+	if err := ssm.TagInstance(c.session, t.InstanceID, key, value); err != nil {
+		return fmt.Errorf("failed to tag Tinkerbell test runner: %v", err)
+	}
+	return nil
+}
+
 func (v *VSphereTestRunner) decommInstance(c instanceRunConf) error {
-	_, deregisterError := ssm.DeregisterInstance(c.session, v.InstanceID)
-	_, deactivateError := ssm.DeleteActivation(c.session, v.ActivationId)
-	deleteError := cleanup.VsphereRmVms(context.Background(), getTestRunnerName(v.logger, c.jobId), executables.WithGovcEnvMap(v.envMap))
+	// Each step is retried on its own, so a transient failure in one doesn't leak the resources of the
+	// others. Whatever is still left after the retries is collected later by the janitor.
+	deregisterError := retrier.Retry(decommMaxRetries, decommBackoff, func() error {
+		_, err := ssm.DeregisterInstance(c.session, v.InstanceID)
+		return err
+	})
+	deactivateError := retrier.Retry(decommMaxRetries, decommBackoff, func() error {
+		_, err := ssm.DeleteActivation(c.session, v.ActivationId)
+		return err
+	})
+	deleteError := retrier.Retry(decommMaxRetries, decommBackoff, func() error {
+		return cleanup.VsphereRmVms(context.Background(), getTestRunnerName(v.logger, c.jobId), executables.WithGovcEnvMap(v.envMap))
+	})
 
 	if deregisterError != nil {
 		return fmt.Errorf("failed to decommission vsphere test runner ssm instance: %v", deregisterError)
@@ -230,14 +348,35 @@ func (v *VSphereTestRunner) decommInstance(c instanceRunConf) error {
 }
 
 func (v *Ec2TestRunner) decommInstance(c instanceRunConf) error {
+	if c.retainFailedInstances && c.failed {
+		v.logger.V(1).Info("Retaining failed ec2 test runner for debugging", "instance-id", v.InstanceID)
+		return nil
+	}
+
+	if err := ec2.TerminateInstance(c.session, v.InstanceID); err != nil {
+		return fmt.Errorf("failed to decommission ec2 test runner %s: %v", v.InstanceID, err)
+	}
+
+	return nil
+}
+
+func (t *TinkerbellTestRunner) decommInstance(c instanceRunConf) error {
+	// The host is reset again before the next run, so it goes back to the pool even if this fails.
+	defer t.hosts.release(t.InstanceID)
+
+	if err := ssm.Run(c.session, t.logger, t.InstanceID, resetLabHostCommand); err != nil {
+		return fmt.Errorf("failed to decommission tinkerbell test runner %s: %v", t.InstanceID, err)
+	}
+
 	return nil
 }
 
 func getTestRunnerName(logger logr.Logger, jobId string) string {
-	name := fmt.Sprintf("eksa-e2e-%s", jobId)
-	if len(name) > 80 {
+	name := testRunnerNamePrefix + jobId
+	if len(name) > maxTestRunnerNameSize {
 		logger.V(1).Info("Truncating test runner name to 80 chars", "original_name", name)
-		name = name[len(name)-80:]
+		// The end of the job id is kept, since it's the part that tells the test runners of a job apart.
+		name = testRunnerNamePrefix + jobId[len(name)-maxTestRunnerNameSize:]
 		logger.V(1).Info("Truncated test runner name", "truncated_name", name)
 	}
 	return name
@@ -0,0 +1,73 @@
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+)
+
+func TestNewTestRunnerConfigFromFileTinkerbell(t *testing.T) {
+	g := NewWithT(t)
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := []byte("tinkerbell:\n  instanceIds:\n  - mi-1\n  - mi-2\n")
+	g.Expect(os.WriteFile(configFile, content, 0o644)).To(Succeed())
+
+	config, err := NewTestRunnerConfigFromFile(logr.Discard(), configFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.TinkerbellTestRunner.InstanceIDs).To(Equal([]string{"mi-1", "mi-2"}))
+	g.Expect(config.tinkerbellTestRunnerType()).To(Equal(TinkerbellTestRunnerType))
+
+	runner, err := newTestRunner(TinkerbellTestRunnerType, *config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(runner).To(BeAssignableToTypeOf(&TinkerbellTestRunner{}))
+
+	// The pool is shared by all the copies of the config.
+	tinkerbellRunner := runner.(*TinkerbellTestRunner)
+	g.Expect(tinkerbellRunner.hosts.claim()).To(Equal("mi-1"))
+	g.Expect(config.TinkerbellTestRunner.hosts.claim()).To(Equal("mi-2"))
+	tinkerbellRunner.hosts.release("mi-1")
+	g.Expect(config.TinkerbellTestRunner.hosts.claim()).To(Equal("mi-1"))
+}
+
+func TestTinkerbellTestRunnerTypeWithoutLabHosts(t *testing.T) {
+	g := NewWithT(t)
+	config := &TestInfraConfig{}
+	g.Expect(config.tinkerbellTestRunnerType()).To(Equal(VSphereTestRunnerType))
+}
+
+func TestGetTestRunnerName(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(getTestRunnerName(logr.Discard(), "job-1")).To(Equal("eksa-e2e-job-1"))
+
+	jobId := strings.Repeat("a", 70) + "-job-1234"
+	name := getTestRunnerName(logr.Discard(), jobId)
+	g.Expect(name).To(HaveLen(80))
+	g.Expect(name).To(HavePrefix("eksa-e2e-"), "the janitor finds the test runners by prefix")
+	g.Expect(name).To(HaveSuffix("-job-1234"))
+}
+
+func TestEc2TestRunnerDecommInstanceRetainsFailedInstance(t *testing.T) {
+	g := NewWithT(t)
+	runner := &Ec2TestRunner{testRunner: testRunner{InstanceID: "i-1", logger: logr.Discard()}}
+	conf := instanceRunConf{retainFailedInstances: true, failed: true}
+
+	g.Expect(runner.decommInstance(conf)).To(Succeed())
+}
+
+func TestNewTestRunnerConfigFromFileEc2Spot(t *testing.T) {
+	g := NewWithT(t)
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := []byte("ec2:\n  amiId: ami-1\n  subnetId: subnet-1\n  instanceType: m5.2xlarge\n  useSpot: true\n  maxSpotPrice: \"0.2\"\n")
+	g.Expect(os.WriteFile(configFile, content, 0o644)).To(Succeed())
+
+	config, err := NewTestRunnerConfigFromFile(logr.Discard(), configFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Ec2TestRunner.InstanceType).To(Equal("m5.2xlarge"))
+	g.Expect(config.Ec2TestRunner.UseSpot).To(BeTrue())
+	g.Expect(config.Ec2TestRunner.MaxSpotPrice).To(Equal("0.2"))
+	g.Expect(config.Ec2TestRunner.instanceOpts()).To(HaveLen(2))
+}
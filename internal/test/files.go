@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -96,6 +97,33 @@ func NewWriter(t *testing.T) (dir string, writer filewriter.FileWriter) {
 	return dir, writer
 }
 
+// InTempDir changes the working directory to a temporary directory until the end of the test, for code that
+// writes files relative to it. The temporary directory links to the testdata folder of the package, so tests can
+// keep reading their testdata with relative paths. Call it before NewWriter, which creates its folder relative
+// to the working directory. It can't be used in parallel tests.
+func InTempDir(t *testing.T) (dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getting working directory: %v", err)
+	}
+
+	dir = t.TempDir()
+	if err := os.Symlink(filepath.Join(wd, "testdata"), filepath.Join(dir, "testdata")); err != nil {
+		t.Fatalf("linking testdata folder: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("changing working directory: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatalf("restoring working directory: %v", err)
+		}
+	})
+
+	return dir
+}
+
 func cleanupDir(t *testing.T, dir string) func() {
 	return func() {
 		if !t.Failed() {
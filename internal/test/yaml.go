@@ -0,0 +1,171 @@
+package test
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+var yamlDocumentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// AssertYAMLContentToFile compares the YAML documents in gotContent with the ones in wantFile semantically:
+// formatting, key order and document order don't matter. On mismatch, it reports the path of every value
+// that differs in each object. Run the tests with -update to rewrite wantFile with gotContent.
+func AssertYAMLContentToFile(t *testing.T, gotContent, wantFile string) {
+	t.Helper()
+	processUpdate(t, wantFile, gotContent)
+
+	diffs, err := YAMLDiff(ReadFile(t, wantFile), gotContent)
+	if err != nil {
+		t.Fatalf("Comparing content with golden file %s: %v\n%s", wantFile, err, gotContent)
+	}
+	if len(diffs) > 0 {
+		t.Fatalf("Content doesn't match golden file %s, run the tests with -update to accept the changes:\n%s", wantFile, strings.Join(diffs, "\n"))
+	}
+}
+
+// YAMLDiff describes the differences between the objects in the want and got YAML documents. Objects are
+// matched by kind, namespace and name, or by position when they aren't kubernetes objects. It returns
+// nothing when the documents are equivalent.
+func YAMLDiff(want, got string) ([]string, error) {
+	wantObjects, err := yamlObjects(want)
+	if err != nil {
+		return nil, fmt.Errorf("parsing want: %v", err)
+	}
+	gotObjects, err := yamlObjects(got)
+	if err != nil {
+		return nil, fmt.Errorf("parsing got: %v", err)
+	}
+
+	var diffs []string
+	for _, id := range sortedKeys(wantObjects, gotObjects) {
+		w, inWant := wantObjects[id]
+		g, inGot := gotObjects[id]
+		switch {
+		case !inGot:
+			diffs = append(diffs, fmt.Sprintf("%s: missing", id))
+		case !inWant:
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected", id))
+		default:
+			for _, d := range valueDiff("", w, g) {
+				diffs = append(diffs, fmt.Sprintf("%s: %s", id, d))
+			}
+		}
+	}
+	return diffs, nil
+}
+
+// yamlObjects parses the YAML documents in content, indexed by the kind, namespace and name of the
+// kubernetes objects and by position for anything else. Empty documents are skipped.
+func yamlObjects(content string) (map[string]interface{}, error) {
+	objects := map[string]interface{}{}
+	for i, doc := range yamlDocumentSeparator.Split(content, -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		var o interface{}
+		if err := yaml.Unmarshal([]byte(doc), &o); err != nil {
+			return nil, fmt.Errorf("document %d: %v", i, err)
+		}
+		if o == nil {
+			continue
+		}
+		objects[objectID(i, o)] = o
+	}
+	return objects, nil
+}
+
+func objectID(index int, o interface{}) string {
+	m, ok := o.(map[string]interface{})
+	if !ok || m["kind"] == nil {
+		return fmt.Sprintf("document %d", index)
+	}
+	id := fmt.Sprintf("%v", m["kind"])
+	if meta, ok := m["metadata"].(map[string]interface{}); ok {
+		if ns, ok := meta["namespace"]; ok {
+			id += fmt.Sprintf(" %v/%v", ns, meta["name"])
+		} else {
+			id += fmt.Sprintf(" %v", meta["name"])
+		}
+	}
+	return id
+}
+
+func valueDiff(path string, want, got interface{}) []string {
+	wantMap, wantIsMap := want.(map[string]interface{})
+	gotMap, gotIsMap := got.(map[string]interface{})
+	if wantIsMap && gotIsMap {
+		return mapDiff(path, wantMap, gotMap)
+	}
+
+	wantSlice, wantIsSlice := want.([]interface{})
+	gotSlice, gotIsSlice := got.([]interface{})
+	if wantIsSlice && gotIsSlice && len(wantSlice) == len(gotSlice) {
+		var diffs []string
+		for i := range wantSlice {
+			diffs = append(diffs, valueDiff(fmt.Sprintf("%s[%d]", path, i), wantSlice[i], gotSlice[i])...)
+		}
+		return diffs
+	}
+
+	if reflect.DeepEqual(want, got) {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s: want %s, got %s", pathOrRoot(path), compact(want), compact(got))}
+}
+
+func mapDiff(path string, want, got map[string]interface{}) []string {
+	var diffs []string
+	for _, k := range sortedKeys(want, got) {
+		w, inWant := want[k]
+		g, inGot := got[k]
+		p := path + "." + k
+		switch {
+		case !inGot:
+			diffs = append(diffs, fmt.Sprintf("%s: missing, want %s", p, compact(w)))
+		case !inWant:
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected %s", p, compact(g)))
+		default:
+			diffs = append(diffs, valueDiff(p, w, g)...)
+		}
+	}
+	return diffs
+}
+
+func sortedKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+func compact(v interface{}) string {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	s := strings.TrimSpace(string(b))
+	if strings.Contains(s, "\n") {
+		return "\n" + s
+	}
+	return s
+}
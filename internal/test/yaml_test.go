@@ -0,0 +1,88 @@
+package test_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+)
+
+func TestYAMLDiffEquivalent(t *testing.T) {
+	g := NewWithT(t)
+	want := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+  namespace: ns
+data:
+  key: value
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: b
+`
+	got := `kind: Secret
+metadata: {name: b}
+apiVersion: v1
+---
+data:
+  key: "value"
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  namespace: ns
+  name: a
+`
+
+	diffs, err := test.YAMLDiff(want, got)
+	g.Expect(err).To(Succeed())
+	g.Expect(diffs).To(BeEmpty())
+}
+
+func TestYAMLDiffChanges(t *testing.T) {
+	g := NewWithT(t)
+	want := `kind: Deployment
+metadata:
+  name: a
+spec:
+  replicas: 1
+  template:
+    containers:
+    - image: old
+---
+kind: Service
+metadata:
+  name: removed
+`
+	got := `kind: Deployment
+metadata:
+  name: a
+spec:
+  paused: true
+  template:
+    containers:
+    - image: new
+---
+kind: Service
+metadata:
+  name: added
+`
+
+	diffs, err := test.YAMLDiff(want, got)
+	g.Expect(err).To(Succeed())
+	g.Expect(diffs).To(ConsistOf(
+		"Deployment a: .spec.paused: unexpected true",
+		"Deployment a: .spec.replicas: missing, want 1",
+		"Deployment a: .spec.template.containers[0].image: want old, got new",
+		"Service added: unexpected",
+		"Service removed: missing",
+	))
+}
+
+func TestYAMLDiffInvalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := test.YAMLDiff("key: [", "")
+	g.Expect(err).To(MatchError(ContainSubstring("parsing want")))
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"flag"
 	"os"
+	"time"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
 	"github.com/spf13/pflag"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -26,9 +29,18 @@ import (
 
 	"github.com/aws/eks-anywhere/controllers"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/etcdencryption"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/machinediagnostics"
+	"github.com/aws/eks-anywhere/pkg/notifications"
 	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
+	"github.com/aws/eks-anywhere/pkg/timesync"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -37,8 +49,21 @@ var (
 	setupLog             = ctrl.Log.WithName("setup")
 	metricsAddr          string
 	enableLeaderElection bool
+	leaderElectionNS     string
+	leaseDuration        time.Duration
+	renewDeadline        time.Duration
+	retryPeriod          time.Duration
 	probeAddr            string
 	gates                = []string{}
+
+	notificationWebhookURL     string
+	notificationSNSTopicARN    string
+	notificationTemplateFile   string
+	certificateExpiryThreshold time.Duration
+	ntpServer                  string
+	maxClockSkew               time.Duration
+	phaseFaults                string
+	providerAPIRateLimit       = ratelimit.NewDefaultConfig()
 )
 
 const WEBHOOK = "webhook"
@@ -57,6 +82,8 @@ func init() {
 	utilruntime.Must(kubeadmv1.AddToScheme(scheme))
 	utilruntime.Must(eksdv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(snowv1.AddToScheme(scheme))
+	utilruntime.Must(tinkv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(rufiov1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -66,7 +93,22 @@ func initFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	fs.StringVar(&leaderElectionNS, "leader-elect-namespace", "", "Namespace of the leader election lease. Defaults to the namespace the manager runs in.")
+	fs.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second, "How long non-leader replicas wait before trying to acquire a lease that hasn't been renewed.")
+	fs.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second, "How long the leader keeps retrying to renew its lease before giving up leadership.")
+	fs.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second, "How long replicas wait between attempts to acquire or renew the lease.")
 	fs.StringSliceVar(&gates, "feature-gates", []string{}, "A set of key=value pairs that describe feature gates for alpha/experimental features. ")
+	fs.StringVar(&notificationWebhookURL, "notification-webhook-url", "", "URL of a webhook notified of cluster lifecycle and health transitions.")
+	fs.StringVar(&notificationSNSTopicARN, "notification-sns-topic-arn", "", "ARN of an SNS topic notified of cluster lifecycle and health transitions.")
+	fs.StringVar(&notificationTemplateFile, "notification-template-file", "", "Path to a go template used to render the notification payloads. Defaults to the event as JSON.")
+	fs.DurationVar(&certificateExpiryThreshold, "certificate-expiry-threshold", notifications.DefaultCertificateExpiryThreshold, "How long before expiry control plane and etcd certificates are reported as expiring.")
+	fs.StringVar(&ntpServer, "ntp-server", timesync.DefaultNTPServer, "NTP server the clocks of the cluster nodes are compared against. The manager clock is used if it can't be reached.")
+	fs.DurationVar(&maxClockSkew, "max-clock-skew", timesync.DefaultMaxSkew, "Maximum clock skew of the cluster nodes before the NodeClocksSynchronized condition is set to false.")
+	fs.StringVar(&phaseFaults, "phase-faults", "", "Faults injected in the reconciliation phases, as <phase>=<action>[:<duration>][*<times>] comma separated. Only for tests, requires "+features.PhaseFaultInjectionEnvVar+"=true.")
+	fs.Float64Var(&providerAPIRateLimit.QPS, "provider-api-qps", providerAPIRateLimit.QPS, "Maximum calls per second to each provider API endpoint, like a vCenter server, shared by all clusters.")
+	fs.IntVar(&providerAPIRateLimit.Burst, "provider-api-burst", providerAPIRateLimit.Burst, "Maximum calls to each provider API endpoint allowed at once above the QPS.")
+	fs.IntVar(&providerAPIRateLimit.FailureThreshold, "provider-api-failure-threshold", providerAPIRateLimit.FailureThreshold, "Consecutive failures that suspend the calls to a provider API endpoint. 0 disables it.")
+	fs.DurationVar(&providerAPIRateLimit.Cooldown, "provider-api-cooldown", providerAPIRateLimit.Cooldown, "How long calls to a provider API endpoint are suspended after too many consecutive failures.")
 }
 
 func main() {
@@ -75,20 +117,29 @@ func main() {
 		Level:       zap.NewAtomicLevelAt(-4),
 	}
 	opts.BindFlags(flag.CommandLine)
+	opts.ZapOpts = append(opts.ZapOpts, zap.WrapCore(logger.NewRedactingCore))
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 
 	initFlags(pflag.CommandLine)
 	pflag.Parse()
 	ctrl.SetLogger(kzap.New(kzap.UseFlagOptions(&opts)))
 	features.FeedGates(gates)
+	setupPhaseFaults()
+	setupProviderAPIRateLimit()
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 scheme,
-		MetricsBindAddress:     metricsAddr,
-		Port:                   9443,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "f64ae69e.eks.amazonaws.com",
+		Scheme:                  scheme,
+		MetricsBindAddress:      metricsAddr,
+		Port:                    9443,
+		HealthProbeBindAddress:  probeAddr,
+		LeaderElection:          enableLeaderElection,
+		LeaderElectionID:        "f64ae69e.eks.amazonaws.com",
+		LeaderElectionNamespace: leaderElectionNS,
+		// Release the lease on shutdown so another replica takes over without waiting for it to expire.
+		LeaderElectionReleaseOnCancel: true,
+		LeaseDuration:                 &leaseDuration,
+		RenewDeadline:                 &renewDeadline,
+		RetryPeriod:                   &retryPeriod,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -110,6 +161,35 @@ func main() {
 	}
 }
 
+func setupPhaseFaults() {
+	if phaseFaults == "" {
+		return
+	}
+
+	if !features.IsActive(features.PhaseFaultInjection()) {
+		setupLog.Error(nil, "phase faults can only be injected with fault injection enabled", "envVar", features.PhaseFaultInjectionEnvVar)
+		os.Exit(1)
+	}
+
+	faults, err := controller.ParsePhaseFaults(phaseFaults)
+	if err != nil {
+		setupLog.Error(err, "unable to parse phase faults")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Warning: injecting faults in reconciliation phases", "faults", phaseFaults)
+	controller.SetDefaultPhaseFaults(faults)
+}
+
+func setupProviderAPIRateLimit() {
+	if err := providerAPIRateLimit.Validate(); err != nil {
+		setupLog.Error(err, "invalid provider API rate limit")
+		os.Exit(1)
+	}
+
+	ratelimit.SetDefaultConfig(providerAPIRateLimit)
+}
+
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	if features.IsActive(features.FullLifecycleAPI()) {
 		setupLog.Info("Reading CAPI providers")
@@ -151,6 +231,139 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		setupLog.Info("Setting up legacy cluster controller")
 		setupLegacyClusterReconciler(mgr)
 	}
+
+	setupCertificateExpiryReconciler(mgr)
+	setupMachineDiagnosticsReconciler(mgr)
+	setupNodeClocksReconciler(mgr)
+	setupEtcdEncryptionRewriteReconciler(mgr)
+	setupKubeletCSRReconciler(mgr)
+	setupNotificationReconciler(mgr)
+	setupFluxCredentialsReconciler(mgr)
+}
+
+func setupCertificateExpiryReconciler(mgr ctrl.Manager) {
+	setupLog.Info("Setting up certificate expiry controller")
+	if err := (controllers.NewCertificateExpiryReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName("certificateexpiry"),
+		certificates.NewInspector(mgr.GetClient(), certificates.TLSReader{}),
+		mgr.GetEventRecorderFor("certificateexpiry-controller"),
+		certificateExpiryThreshold,
+	)).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "certificateexpiry")
+		os.Exit(1)
+	}
+}
+
+func setupMachineDiagnosticsReconciler(mgr ctrl.Manager) {
+	setupLog.Info("Setting up machine diagnostics controller")
+	if err := (controllers.NewMachineDiagnosticsReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName("machinediagnostics"),
+		machinediagnostics.NewDiagnoser(mgr.GetClient(),
+			machinediagnostics.WithLogReader(machinediagnostics.TinkerbellMachineKind, machinediagnostics.NewTinkerbellWorkflowReader(mgr.GetClient())),
+		),
+		mgr.GetEventRecorderFor("machinediagnostics-controller"),
+	)).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "machinediagnostics")
+		os.Exit(1)
+	}
+}
+
+func setupNodeClocksReconciler(mgr ctrl.Manager) {
+	setupLog.Info("Setting up node clocks controller")
+	if err := (controllers.NewNodeClocksReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName("nodeclocks"),
+		timesync.NewClusterChecker(mgr.GetClient(), "nodeclocks-controller",
+			timesync.NewNTPClock(ntpServer, timesync.WithLocalFallback(), timesync.WithRefreshInterval(time.Hour)),
+			timesync.WithMaxSkew(maxClockSkew),
+		),
+	)).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "nodeclocks")
+		os.Exit(1)
+	}
+}
+
+func setupEtcdEncryptionRewriteReconciler(mgr ctrl.Manager) {
+	setupLog.Info("Setting up etcd encryption rewrite controller")
+	if err := (controllers.NewEtcdEncryptionRewriteReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName("etcdencryptionrewrite"),
+		etcdencryption.NewRewriter(mgr.GetClient(), "etcdencryptionrewrite-controller"),
+	)).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "etcdencryptionrewrite")
+		os.Exit(1)
+	}
+}
+
+func setupKubeletCSRReconciler(mgr ctrl.Manager) {
+	setupLog.Info("Setting up kubelet CSR controller")
+	if err := (controllers.NewKubeletCSRReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName("kubeletcsr"),
+		controller.NewRemoteClientsetBuilder(mgr.GetClient(), "kubeletcsr-controller"),
+	)).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "kubeletcsr")
+		os.Exit(1)
+	}
+}
+
+func setupFluxCredentialsReconciler(mgr ctrl.Manager) {
+	setupLog.Info("Setting up flux credentials controller")
+	if err := (controllers.NewFluxCredentialsReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName("fluxcredentials"),
+		github.NewAppTokenIssuer(),
+	)).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "fluxcredentials")
+		os.Exit(1)
+	}
+}
+
+func setupNotificationReconciler(mgr ctrl.Manager) {
+	var sinks []notifications.Sink
+	if notificationWebhookURL != "" {
+		sinks = append(sinks, notifications.NewWebhookSink(notificationWebhookURL))
+	}
+	if notificationSNSTopicARN != "" {
+		sink, err := notifications.NewSNSSink(notificationSNSTopicARN)
+		if err != nil {
+			setupLog.Error(err, "unable to create SNS notification sink")
+			os.Exit(1)
+		}
+		sinks = append(sinks, sink)
+	}
+	if len(sinks) == 0 {
+		return
+	}
+
+	var opts []notifications.NotifierOpt
+	if notificationTemplateFile != "" {
+		content, err := os.ReadFile(notificationTemplateFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read notification template")
+			os.Exit(1)
+		}
+		opts = append(opts, notifications.WithTemplate(string(content)))
+	}
+
+	notifier, err := notifications.NewNotifier(sinks, opts...)
+	if err != nil {
+		setupLog.Error(err, "unable to create notifier")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Setting up notification controller")
+	if err := (controllers.NewNotificationReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName("notification"),
+		notifications.NewWatcher(notifications.WithCertificateExpiryThreshold(certificateExpiryThreshold)),
+		notifier,
+	)).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "notification")
+		os.Exit(1)
+	}
 }
 
 func setupLegacyClusterReconciler(mgr ctrl.Manager) {
@@ -209,6 +422,10 @@ func setupWebhooks(mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to create webhook", "webhook", "SnowDatacenterConfig")
 		os.Exit(1)
 	}
+	if err := (&anywherev1.SnowIPPool{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.SnowIPPoolKind)
+		os.Exit(1)
+	}
 }
 
 func setupChecks(mgr ctrl.Manager) {
@@ -220,4 +437,10 @@ func setupChecks(mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	// All replicas serve the webhooks, not only the leader. Only send them requests once
+	// the serving certificates have been loaded.
+	if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+		setupLog.Error(err, "unable to set up webhook ready check")
+		os.Exit(1)
+	}
 }
@@ -10,16 +10,21 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
 )
 
 const (
@@ -185,9 +190,11 @@ func NewCluster(clusterName string) *Cluster {
 var clusterConfigValidations = []func(*Cluster) error{
 	validateClusterConfigName,
 	validateControlPlaneEndpoint,
+	validateControlPlaneKubeVip,
 	validateMachineGroupRefs,
 	validateControlPlaneReplicas,
 	validateWorkerNodeGroups,
+	validateSingleNode,
 	validateNetworking,
 	validateGitOps,
 	validateEtcdReplicas,
@@ -197,6 +204,23 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validatePodIAMConfig,
 	validateCPUpgradeRolloutStrategy,
 	validateControlPlaneLabels,
+	validateControlPlaneSchedulable,
+	validateControlPlaneCertSANs,
+	validateControlPlaneStaticPodManifests,
+	validateContainerdConfigurations,
+	validateEksdReleasePins,
+	validateHelmValuesOverrides,
+	validateManagedComponents,
+	validateClusterProfile,
+	validateCertManager,
+	validateImageCredentialProviders,
+	validateCoreDNSConfiguration,
+	validateTags,
+	validateBackup,
+	validateBootstrapManifests,
+	validateBottlerocketUpdates,
+	validateEtcdEncryption,
+	validateEtcdEncryptionKeyRotationPeriods,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -276,14 +300,14 @@ type kindObject struct {
 // ParseClusterConfigFromContent unmarshalls an API object implementing the KindAccessor interface
 // from a multiobject yaml content. It doesn't set defaults nor validates the object.
 func ParseClusterConfigFromContent(content []byte, clusterConfig KindAccessor) error {
-	for _, c := range strings.Split(string(content), YamlSeparator) {
+	for _, doc := range yamlutil.SplitDocuments(content) {
 		k := &kindObject{}
-		if err := yaml.Unmarshal([]byte(c), k); err != nil {
+		if err := yaml.Unmarshal(doc.Content, k); err != nil {
 			return err
 		}
 
 		if k.Kind == clusterConfig.ExpectedKind() {
-			return yaml.UnmarshalStrict([]byte(c), clusterConfig)
+			return doc.UnmarshalStrict(clusterConfig)
 		}
 	}
 
@@ -413,6 +437,118 @@ func validateControlPlaneLabels(clusterConfig *Cluster) error {
 	return nil
 }
 
+func validateControlPlaneSchedulable(clusterConfig *Cluster) error {
+	cp := clusterConfig.Spec.ControlPlaneConfiguration
+	if !cp.Schedulable {
+		return nil
+	}
+	for _, taint := range cp.Taints {
+		if taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute {
+			return fmt.Errorf("controlPlaneConfiguration.taints %s can't have effect %s when the control plane is schedulable", taint.Key, taint.Effect)
+		}
+	}
+	return nil
+}
+
+func validateControlPlaneCertSANs(clusterConfig *Cluster) error {
+	for _, san := range clusterConfig.Spec.ControlPlaneConfiguration.CertSANs {
+		if net.ParseIP(san) != nil {
+			continue
+		}
+		if errs := utilvalidation.IsDNS1123Subdomain(strings.ToLower(san)); len(errs) > 0 {
+			return fmt.Errorf("controlPlaneConfiguration.certSANs %s is invalid, it must be an IP or a DNS name: %s", san, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// reservedStaticPodManifests are the static pods EKS Anywhere and kubeadm manage in the control plane nodes.
+var reservedStaticPodManifests = map[string]struct{}{
+	"etcd":                    {},
+	"kube-apiserver":          {},
+	"kube-controller-manager": {},
+	"kube-scheduler":          {},
+	"kube-vip":                {},
+}
+
+func validateControlPlaneStaticPodManifests(clusterConfig *Cluster) error {
+	names := make(map[string]struct{}, len(clusterConfig.Spec.ControlPlaneConfiguration.StaticPodManifests))
+	for _, m := range clusterConfig.Spec.ControlPlaneConfiguration.StaticPodManifests {
+		if errs := utilvalidation.IsDNS1123Label(m.Name); len(errs) > 0 {
+			return fmt.Errorf("controlPlaneConfiguration.staticPodManifests name %s is invalid: %s", m.Name, strings.Join(errs, ", "))
+		}
+		if _, ok := reservedStaticPodManifests[m.Name]; ok {
+			return fmt.Errorf("controlPlaneConfiguration.staticPodManifests name %s is reserved for a control plane component", m.Name)
+		}
+		if _, ok := names[m.Name]; ok {
+			return fmt.Errorf("controlPlaneConfiguration.staticPodManifests name %s is duplicated", m.Name)
+		}
+		names[m.Name] = struct{}{}
+
+		if err := validateStaticPodManifestContent(m); err != nil {
+			return fmt.Errorf("controlPlaneConfiguration.staticPodManifests %s is invalid: %v", m.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateStaticPodManifestContent(m StaticPodManifest) error {
+	pod := &corev1.Pod{}
+	if err := yaml.UnmarshalStrict([]byte(m.Content), pod); err != nil {
+		return fmt.Errorf("parsing content: %v", err)
+	}
+	if pod.APIVersion != "v1" || pod.Kind != "Pod" {
+		return fmt.Errorf("content must be a v1 Pod, got %s %s", pod.APIVersion, pod.Kind)
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return errors.New("pod must have at least one container")
+	}
+	return nil
+}
+
+func validateContainerdConfigurations(clusterConfig *Cluster) error {
+	if err := validateContainerdConfiguration(clusterConfig, clusterConfig.Spec.ControlPlaneConfiguration.ContainerdConfiguration, "controlPlaneConfiguration"); err != nil {
+		return err
+	}
+	for _, workerNodeGroup := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		path := fmt.Sprintf("workerNodeGroupConfigurations[%s]", workerNodeGroup.Name)
+		if err := validateContainerdConfiguration(clusterConfig, workerNodeGroup.ContainerdConfiguration, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateContainerdConfiguration(clusterConfig *Cluster, config *ContainerdConfiguration, path string) error {
+	if config == nil {
+		return nil
+	}
+
+	if clusterConfig.Spec.DatacenterRef.Kind != VSphereDatacenterKind {
+		return fmt.Errorf("%s.containerdConfiguration is only supported for vSphere provider currently", path)
+	}
+
+	if config.SandboxImage != "" && !sandboxImageRegex.MatchString(config.SandboxImage) {
+		return fmt.Errorf("%s.containerdConfiguration.sandboxImage %s is invalid, it must be an image reference with a tag", path, config.SandboxImage)
+	}
+
+	for name, value := range config.RegistryHeaders {
+		if !registryHeaderNameRegex.MatchString(name) {
+			return fmt.Errorf("%s.containerdConfiguration.registryHeaders name %s is invalid", path, name)
+		}
+		if strings.ContainsAny(value, "\"\\\r\n") {
+			return fmt.Errorf("%s.containerdConfiguration.registryHeaders value for %s can't contain quotes, backslashes or new lines", path, name)
+		}
+	}
+
+	return nil
+}
+
+var (
+	sandboxImageRegex       = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*(:[0-9]+)?(/[a-z0-9]+([._-][a-z0-9]+)*)+:[\w][\w.-]{0,127}$`)
+	registryHeaderNameRegex = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+)
+
 func validateControlPlaneEndpoint(clusterConfig *Cluster) error {
 	if clusterConfig.Spec.DatacenterRef.Kind == DockerDatacenterKind {
 		if clusterConfig.Spec.ControlPlaneConfiguration.Endpoint != nil {
@@ -425,6 +561,15 @@ func validateControlPlaneEndpoint(clusterConfig *Cluster) error {
 		return errors.New("cluster controlPlaneConfiguration.Endpoint.Host is not set or is empty")
 	}
 
+	if clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.ExternalLoadBalancer && clusterConfig.Spec.DatacenterRef.Kind != VSphereDatacenterKind {
+		return errors.New("controlPlaneConfiguration.endpoint.externalLoadBalancer is only supported for vSphere provider currently")
+	}
+
+	// vSphere serves the API server, through kube-vip or the external load balancer, on port 6443.
+	if clusterConfig.Spec.DatacenterRef.Kind == VSphereDatacenterKind && clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Hostname() != clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host {
+		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host <%s> can't include a port for vSphere, the API server is served on port 6443", clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host)
+	}
+
 	// TODO: validate IP
 	//if err := networkutils.ValidateIP(clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host); err != nil {
 	//
@@ -434,10 +579,48 @@ func validateControlPlaneEndpoint(clusterConfig *Cluster) error {
 	return nil
 }
 
+func validateControlPlaneKubeVip(clusterConfig *Cluster) error {
+	endpoint := clusterConfig.Spec.ControlPlaneConfiguration.Endpoint
+	if endpoint == nil || endpoint.KubeVip == nil {
+		return nil
+	}
+
+	switch clusterConfig.Spec.DatacenterRef.Kind {
+	case VSphereDatacenterKind, CloudStackDatacenterKind, NutanixDatacenterKind, TinkerbellDatacenterKind:
+	default:
+		return fmt.Errorf("controlPlaneConfiguration.endpoint.kubeVip is not supported for %s provider", clusterConfig.Spec.DatacenterRef.Kind)
+	}
+	if endpoint.UsesExternalLoadBalancer() {
+		return errors.New("controlPlaneConfiguration.endpoint.kubeVip is not supported with an external load balancer, kube-vip is not deployed")
+	}
+
+	return validateKubeVipConfiguration(endpoint.KubeVip)
+}
+
+// kubeAPIServerPort is the port of the API server in the control plane nodes, the port of the kube-vip endpoint.
+const kubeAPIServerPort = 6443
+
+func validateKubeVipConfiguration(kubeVip *KubeVipConfiguration) error {
+	if kubeVip.LeaseDurationSeconds < 0 || kubeVip.RenewDeadlineSeconds < 0 || kubeVip.RetryPeriodSeconds < 0 {
+		return errors.New("controlPlaneConfiguration.endpoint.kubeVip durations must be positive")
+	}
+	if kubeVip.RenewDeadline() >= kubeVip.LeaseDuration() {
+		return fmt.Errorf("controlPlaneConfiguration.endpoint.kubeVip.renewDeadlineSeconds %d must be lower than leaseDurationSeconds %d", kubeVip.RenewDeadline(), kubeVip.LeaseDuration())
+	}
+	if kubeVip.RetryPeriod() >= kubeVip.RenewDeadline() {
+		return fmt.Errorf("controlPlaneConfiguration.endpoint.kubeVip.retryPeriodSeconds %d must be lower than renewDeadlineSeconds %d", kubeVip.RetryPeriod(), kubeVip.RenewDeadline())
+	}
+	if kubeVip.PrometheusPort < 0 || kubeVip.PrometheusPort > 65535 || kubeVip.PrometheusPort == kubeAPIServerPort {
+		return fmt.Errorf("controlPlaneConfiguration.endpoint.kubeVip.prometheusPort %d is invalid, it must be a valid port other than the API server port %d", kubeVip.PrometheusPort, kubeAPIServerPort)
+	}
+
+	return nil
+}
+
 func validateWorkerNodeGroups(clusterConfig *Cluster) error {
 	workerNodeGroupConfigs := clusterConfig.Spec.WorkerNodeGroupConfigurations
 	if len(workerNodeGroupConfigs) <= 0 {
-		if clusterConfig.Spec.DatacenterRef.Kind == TinkerbellDatacenterKind {
+		if clusterConfig.Spec.DatacenterRef.Kind == TinkerbellDatacenterKind || clusterConfig.IsSingleNode() {
 			logger.Info("Warning: No configurations provided for worker node groups, pods will be scheduled on control-plane nodes")
 		} else {
 			return fmt.Errorf("WorkerNodeGroupConfigs cannot be empty for %s", clusterConfig.Spec.DatacenterRef.Kind)
@@ -461,7 +644,7 @@ func validateWorkerNodeGroups(clusterConfig *Cluster) error {
 			return fmt.Errorf("validating autoscaling configuration: %v", err)
 		}
 
-		if err := validateMDUpgradeRolloutStrategy(&workerNodeGroupConfig); err != nil {
+		if err := validateMDUpgradeRolloutStrategy(&workerNodeGroupConfig, clusterConfig.Spec.DatacenterRef.Kind); err != nil {
 			return fmt.Errorf("validating upgrade rollout strategy configuration: %v", err)
 		}
 
@@ -496,6 +679,35 @@ func validateWorkerNodeGroups(clusterConfig *Cluster) error {
 	return nil
 }
 
+// singleNodeDatacenterKinds are the datacenters supporting single node clusters, meant for edge deployments.
+var singleNodeDatacenterKinds = map[string]struct{}{
+	TinkerbellDatacenterKind: {},
+	SnowDatacenterKind:       {},
+}
+
+// validateSingleNode ensures single node clusters run on a datacenter that supports them, with stacked
+// etcd. Their only machine can't be deleted before its replacement is running, so the control plane
+// upgrades always need a max surge of 1.
+func validateSingleNode(clusterConfig *Cluster) error {
+	if !clusterConfig.IsSingleNode() {
+		return nil
+	}
+
+	if _, ok := singleNodeDatacenterKinds[clusterConfig.Spec.DatacenterRef.Kind]; !ok {
+		return fmt.Errorf("single node clusters are only supported for %s and %s", TinkerbellDatacenterKind, SnowDatacenterKind)
+	}
+
+	if clusterConfig.Spec.ExternalEtcdConfiguration != nil {
+		return errors.New("single node clusters don't support external etcd")
+	}
+
+	if strategy := clusterConfig.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy; strategy != nil && strategy.RollingUpdate.MaxSurge != 1 {
+		return errors.New("ControlPlaneConfiguration: maxSurge for the control plane of single node clusters must be 1")
+	}
+
+	return nil
+}
+
 func validateAutoscalingConfig(w *WorkerNodeGroupConfiguration) error {
 	if w == nil {
 		return nil
@@ -694,6 +906,23 @@ func validateProxyData(proxy string) error {
 	return nil
 }
 
+func validateEksdReleasePins(clusterConfig *Cluster) error {
+	seen := make(map[KubernetesVersion]struct{}, len(clusterConfig.Spec.EksdReleasePins))
+	for _, pin := range clusterConfig.Spec.EksdReleasePins {
+		if pin.KubernetesVersion == "" {
+			return errors.New("eksdReleasePins: kubernetesVersion is required")
+		}
+		if pin.Number <= 0 {
+			return fmt.Errorf("eksdReleasePins: release number for kubernetes version %s must be greater than 0", pin.KubernetesVersion)
+		}
+		if _, ok := seen[pin.KubernetesVersion]; ok {
+			return fmt.Errorf("eksdReleasePins: duplicate pin for kubernetes version %s", pin.KubernetesVersion)
+		}
+		seen[pin.KubernetesVersion] = struct{}{}
+	}
+	return nil
+}
+
 func validateMirrorConfig(clusterConfig *Cluster) error {
 	if clusterConfig.Spec.RegistryMirrorConfiguration == nil {
 		return nil
@@ -773,8 +1002,8 @@ func validateCPUpgradeRolloutStrategy(clusterConfig *Cluster) error {
 		return nil
 	}
 
-	if clusterConfig.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.Type != "RollingUpdate" {
-		return fmt.Errorf("ControlPlaneConfiguration: only 'RollingUpdate' supported for upgrade rollout strategy type")
+	if err := validateUpgradeRolloutStrategyType(clusterConfig.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.Type, clusterConfig.Spec.DatacenterRef.Kind); err != nil {
+		return fmt.Errorf("ControlPlaneConfiguration: %v", err)
 	}
 
 	if clusterConfig.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge < 0 {
@@ -788,23 +1017,441 @@ func validateCPUpgradeRolloutStrategy(clusterConfig *Cluster) error {
 	return nil
 }
 
-func validateMDUpgradeRolloutStrategy(w *WorkerNodeGroupConfiguration) error {
+func validateMDUpgradeRolloutStrategy(w *WorkerNodeGroupConfiguration, datacenterKind string) error {
 	if w.UpgradeRolloutStrategy == nil {
 		logger.Info("WorkerNodeGroupConfigurations: UpgradeRolloutStrategy not specified in cluster config. CAPI will default to 'RollingUpdate' with maxSurge=1 and maxUnavailable=0")
 		return nil
 	}
 
-	if w.UpgradeRolloutStrategy.Type != "RollingUpdate" {
-		return fmt.Errorf("WorkerNodeGroupConfiguration: only 'RollingUpdate' supported for upgrade rollout strategy type")
+	if err := validateUpgradeRolloutStrategyType(w.UpgradeRolloutStrategy.Type, datacenterKind); err != nil {
+		return fmt.Errorf("WorkerNodeGroupConfiguration: %v", err)
 	}
 
 	if w.UpgradeRolloutStrategy.RollingUpdate.MaxSurge < 0 || w.UpgradeRolloutStrategy.RollingUpdate.MaxUnavailable < 0 {
 		return fmt.Errorf("WorkerNodeGroupConfiguration: maxSurge and maxUnavailable values cannot be negative")
 	}
 
+	// The rolling update parameters are optional for in-place upgrades. They only tune the
+	// rolling update used for the upgrades that can't be done in place.
+	if w.UpgradeRolloutStrategy.Type == InPlaceStrategyType {
+		return nil
+	}
+
 	if w.UpgradeRolloutStrategy.RollingUpdate.MaxSurge == 0 && w.UpgradeRolloutStrategy.RollingUpdate.MaxUnavailable == 0 {
 		return fmt.Errorf("WorkerNodeGroupConfiguration: maxSurge and maxUnavailable not specified or are 0. maxSurge and maxUnavailable cannot both be 0")
 	}
 
 	return nil
 }
+
+// validateUpgradeRolloutStrategyType ensures the upgrade rollout strategy type is supported by the
+// datacenter. Only Tinkerbell nodes can be upgraded in place, since its hardware is scarce.
+func validateUpgradeRolloutStrategyType(strategyType, datacenterKind string) error {
+	switch strategyType {
+	case RollingUpdateStrategyType:
+		return nil
+	case InPlaceStrategyType:
+		if datacenterKind != TinkerbellDatacenterKind {
+			return fmt.Errorf("'%s' upgrade rollout strategy type is only supported for %s", InPlaceStrategyType, TinkerbellDatacenterKind)
+		}
+		return nil
+	default:
+		return fmt.Errorf("only '%s' and '%s' supported for upgrade rollout strategy type", RollingUpdateStrategyType, InPlaceStrategyType)
+	}
+}
+
+func validateCertManager(clusterConfig *Cluster) error {
+	if clusterConfig.Spec.CertManager == nil {
+		return nil
+	}
+
+	switch mode := clusterConfig.CertManagerMode(); mode {
+	case CertManagerManaged, CertManagerExternal:
+	default:
+		return fmt.Errorf("certManager: unsupported mode %s, supported modes are %s and %s", mode, CertManagerManaged, CertManagerExternal)
+	}
+
+	if !clusterConfig.IsSelfManaged() {
+		return errors.New("certManager can only be configured for management clusters")
+	}
+
+	return nil
+}
+
+var (
+	imageCredentialProviderNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+	envVarNameRegex                  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+func validateImageCredentialProviders(clusterConfig *Cluster) error {
+	names := map[string]struct{}{}
+	for _, provider := range clusterConfig.ImageCredentialProviders() {
+		if !imageCredentialProviderNameRegex.MatchString(provider.Name) {
+			return fmt.Errorf("kubeletConfiguration.imageCredentialProviders name %q is invalid, it must be the name of an executable", provider.Name)
+		}
+		if _, ok := names[provider.Name]; ok {
+			return fmt.Errorf("kubeletConfiguration.imageCredentialProviders name %s is duplicated", provider.Name)
+		}
+		names[provider.Name] = struct{}{}
+
+		if err := validateImageCredentialProvider(provider); err != nil {
+			return fmt.Errorf("kubeletConfiguration.imageCredentialProviders %s: %v", provider.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateImageCredentialProvider(provider ImageCredentialProvider) error {
+	if len(provider.MatchImages) == 0 {
+		return errors.New("matchImages can't be empty")
+	}
+	for _, image := range provider.MatchImages {
+		if image == "" || strings.ContainsAny(image, " \t\r\n") {
+			return fmt.Errorf("matchImages pattern %q is invalid", image)
+		}
+	}
+
+	if provider.DefaultCacheDuration != "" {
+		if _, err := time.ParseDuration(provider.DefaultCacheDuration); err != nil {
+			return fmt.Errorf("defaultCacheDuration %s is invalid: %v", provider.DefaultCacheDuration, err)
+		}
+	}
+
+	for name := range provider.Env {
+		if !envVarNameRegex.MatchString(name) {
+			return fmt.Errorf("env name %s is invalid", name)
+		}
+	}
+
+	return nil
+}
+
+func validateCoreDNSConfiguration(clusterConfig *Cluster) error {
+	config := clusterConfig.Spec.CoreDNSConfiguration
+	if config == nil {
+		return nil
+	}
+
+	for _, server := range config.UpstreamServers {
+		if err := validateDNSServer(server); err != nil {
+			return fmt.Errorf("coreDNSConfiguration.upstreamServers: %v", err)
+		}
+	}
+
+	domains := map[string]struct{}{}
+	for _, stub := range config.StubDomains {
+		if err := validateCoreDNSStubDomain(stub); err != nil {
+			return fmt.Errorf("coreDNSConfiguration.stubDomains %s: %v", stub.Domain, err)
+		}
+		domain := strings.ToLower(strings.TrimSuffix(stub.Domain, "."))
+		if _, ok := domains[domain]; ok {
+			return fmt.Errorf("coreDNSConfiguration.stubDomains domain %s is duplicated", stub.Domain)
+		}
+		domains[domain] = struct{}{}
+	}
+
+	if strings.Count(config.CustomServerBlocks, "{") != strings.Count(config.CustomServerBlocks, "}") {
+		return errors.New("coreDNSConfiguration.customServerBlocks has unbalanced braces")
+	}
+
+	return nil
+}
+
+// maxTagKeyLength and maxTagValueLength are the maximum lengths of the keys and values of the tags of a
+// cluster for each provider: vSphere tag categories and tags, CloudStack resource tags and EC2 tags.
+var (
+	maxTagKeyLength = map[string]int{
+		VSphereDatacenterKind:    256,
+		CloudStackDatacenterKind: 255,
+		SnowDatacenterKind:       128,
+	}
+	maxTagValueLength = map[string]int{
+		VSphereDatacenterKind:    256,
+		CloudStackDatacenterKind: 255,
+		SnowDatacenterKind:       256,
+	}
+)
+
+func validateTags(clusterConfig *Cluster) error {
+	if len(clusterConfig.Spec.Tags) == 0 {
+		return nil
+	}
+
+	kind := clusterConfig.Spec.DatacenterRef.Kind
+	maxKeyLength, ok := maxTagKeyLength[kind]
+	if !ok {
+		return fmt.Errorf("tags are not supported for %s provider", kind)
+	}
+	maxValueLength := maxTagValueLength[kind]
+
+	for key, value := range clusterConfig.Spec.Tags {
+		if key == "" || value == "" {
+			return fmt.Errorf("tags key and value can't be empty: %q: %q", key, value)
+		}
+		if len(key) > maxKeyLength || len(value) > maxValueLength {
+			return fmt.Errorf("tags key %s must be at most %d characters and its value at most %d", key, maxKeyLength, maxValueLength)
+		}
+		if err := validateTagKey(kind, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateTagKey(kind, key string) error {
+	if kind == VSphereDatacenterKind {
+		return nil
+	}
+	// The keys of the tags EKS-A sets are recorded comma separated in the ManagedTagsKey tag.
+	if key == constants.ManagedTagsKey || strings.Contains(key, ",") {
+		return fmt.Errorf("tags key %s is reserved or contains a comma", key)
+	}
+	if kind == SnowDatacenterKind && (key == "Name" || strings.HasPrefix(key, "aws:") || strings.HasPrefix(key, "sigs.k8s.io/")) {
+		return fmt.Errorf("tags key %s is reserved for the instances managed by CAPAS", key)
+	}
+
+	return nil
+}
+
+func validateBackup(clusterConfig *Cluster) error {
+	backup := clusterConfig.Spec.Backup
+	if backup == nil {
+		return nil
+	}
+
+	if err := validateBackupStorageLocation(backup.StorageLocation); err != nil {
+		return fmt.Errorf("backup.storageLocation: %v", err)
+	}
+
+	names := map[string]struct{}{}
+	for _, schedule := range backup.Schedules {
+		if err := validateBackupSchedule(schedule); err != nil {
+			return fmt.Errorf("backup.schedules %s: %v", schedule.Name, err)
+		}
+		if _, ok := names[schedule.Name]; ok {
+			return fmt.Errorf("backup.schedules name %s is duplicated", schedule.Name)
+		}
+		names[schedule.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+func validateBackupStorageLocation(location BackupStorageLocation) error {
+	if location.Bucket == "" {
+		return errors.New("bucket can't be empty")
+	}
+	if location.Region == "" {
+		return errors.New("region can't be empty")
+	}
+	if errs := utilvalidation.IsDNS1123Subdomain(location.CredentialsSecretName); len(errs) > 0 {
+		return fmt.Errorf("credentialsSecretName is invalid: %s", strings.Join(errs, ", "))
+	}
+	if location.S3URL != "" {
+		u, err := url.Parse(location.S3URL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("s3Url %s must be an absolute URL", location.S3URL)
+		}
+	}
+	return nil
+}
+
+func validateBackupSchedule(schedule BackupSchedule) error {
+	if errs := utilvalidation.IsDNS1123Subdomain(schedule.Name); len(errs) > 0 {
+		return fmt.Errorf("name is invalid: %s", strings.Join(errs, ", "))
+	}
+	// Velero accepts standard cron expressions with 5 fields and descriptors like @daily.
+	if !strings.HasPrefix(schedule.Schedule, "@") && len(strings.Fields(schedule.Schedule)) != 5 {
+		return fmt.Errorf("schedule %q must be a cron expression with 5 fields or a descriptor", schedule.Schedule)
+	}
+	if schedule.TTL != "" {
+		if _, err := time.ParseDuration(schedule.TTL); err != nil {
+			return fmt.Errorf("ttl is invalid: %v", err)
+		}
+	}
+	return nil
+}
+
+func validateBottlerocketUpdates(clusterConfig *Cluster) error {
+	updates := clusterConfig.Spec.BottlerocketUpdates
+	if updates == nil {
+		return nil
+	}
+
+	if kind := clusterConfig.Spec.DatacenterRef.Kind; kind != VSphereDatacenterKind && kind != TinkerbellDatacenterKind {
+		return fmt.Errorf("bottlerocketUpdates is only supported for vSphere and Tinkerbell clusters, not %s", kind)
+	}
+	if updates.MaxConcurrentUpdates < 0 {
+		return fmt.Errorf("bottlerocketUpdates.maxConcurrentUpdates %d can't be negative", updates.MaxConcurrentUpdates)
+	}
+	// The update operator accepts cron expressions with seconds, 6 fields, and optionally the year, 7 fields.
+	if fields := len(strings.Fields(updates.Schedule)); updates.Schedule != "" && fields != 6 && fields != 7 {
+		return fmt.Errorf("bottlerocketUpdates.schedule %q must be a cron expression with 6 or 7 fields", updates.Schedule)
+	}
+
+	return nil
+}
+
+func validateBootstrapManifests(clusterConfig *Cluster) error {
+	if len(clusterConfig.Spec.BootstrapManifests) > 0 && clusterConfig.Spec.DatacenterRef.Kind == DockerDatacenterKind {
+		return errors.New("bootstrapManifests are not supported for Docker clusters")
+	}
+
+	names := map[string]struct{}{}
+	for _, manifest := range clusterConfig.Spec.BootstrapManifests {
+		if err := validateBootstrapManifest(manifest); err != nil {
+			return fmt.Errorf("bootstrapManifests %s: %v", manifest.Name, err)
+		}
+		if _, ok := names[manifest.Name]; ok {
+			return fmt.Errorf("bootstrapManifests name %s is duplicated", manifest.Name)
+		}
+		names[manifest.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+func validateBootstrapManifest(manifest BootstrapManifest) error {
+	if errs := utilvalidation.IsDNS1123Subdomain(manifest.Name); len(errs) > 0 {
+		return fmt.Errorf("name is invalid: %s", strings.Join(errs, ", "))
+	}
+
+	sources := 0
+	for _, set := range []bool{manifest.Inline != "", manifest.OCI != "", manifest.Git != nil} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return errors.New("exactly one of inline, oci and git must be set")
+	}
+
+	if manifest.OCI != "" && !strings.HasPrefix(manifest.OCI, "oci://") {
+		return fmt.Errorf("oci %s must be an oci:// reference", manifest.OCI)
+	}
+	if manifest.Git != nil {
+		return validateBootstrapManifestGitRef(manifest.Git)
+	}
+
+	return nil
+}
+
+func validateBootstrapManifestGitRef(git *BootstrapManifestGitRef) error {
+	u, err := url.Parse(git.Repository)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("git.repository %s must be an https URL", git.Repository)
+	}
+	if git.Path == "" {
+		return errors.New("git.path can't be empty")
+	}
+
+	return nil
+}
+
+const kmsSocketScheme = "unix://"
+
+func validateEtcdEncryption(clusterConfig *Cluster) error {
+	kmsNames := map[string]struct{}{}
+	resources := map[string]struct{}{}
+	for i, config := range clusterConfig.Spec.EtcdEncryption {
+		if len(config.Providers) == 0 {
+			return fmt.Errorf("etcdEncryption[%d].providers can't be empty", i)
+		}
+		for _, provider := range config.Providers {
+			if err := validateKMS(provider.KMS); err != nil {
+				return fmt.Errorf("etcdEncryption[%d].providers: %v", i, err)
+			}
+			if _, ok := kmsNames[provider.KMS.Name]; ok {
+				return fmt.Errorf("etcdEncryption[%d].providers kms name %s is duplicated", i, provider.KMS.Name)
+			}
+			kmsNames[provider.KMS.Name] = struct{}{}
+		}
+
+		for _, resource := range config.EncryptedResources() {
+			if resource == "" || strings.ContainsAny(resource, " \t\r\n") {
+				return fmt.Errorf("etcdEncryption[%d].resources %q is invalid", i, resource)
+			}
+			if _, ok := resources[resource]; ok {
+				return fmt.Errorf("etcdEncryption[%d].resources %s is duplicated, a resource can only be in one etcdEncryption config", i, resource)
+			}
+			resources[resource] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// minEtcdEncryptionKeyRotationPeriod bounds how often all the objects of the encrypted resources are
+// rewritten, since it loads the kube-apiserver, the KMS plugins and etcd.
+const minEtcdEncryptionKeyRotationPeriod = time.Hour
+
+func validateEtcdEncryptionKeyRotationPeriods(clusterConfig *Cluster) error {
+	for i, config := range clusterConfig.Spec.EtcdEncryption {
+		if config.KeyRotationPeriod == nil {
+			continue
+		}
+		if config.KeyRotationPeriod.Duration < minEtcdEncryptionKeyRotationPeriod {
+			return fmt.Errorf("etcdEncryption[%d].keyRotationPeriod %s must be at least %s", i, config.KeyRotationPeriod.Duration, minEtcdEncryptionKeyRotationPeriod)
+		}
+		for _, resource := range config.EncryptedResources() {
+			if strings.Contains(resource, "*") {
+				return fmt.Errorf("etcdEncryption[%d].keyRotationPeriod can't be set for the wildcard resource %s, the objects to rewrite must be listed by resource", i, resource)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateKMS(kms *KMS) error {
+	if kms == nil {
+		return errors.New("kms can't be empty")
+	}
+	if !imageCredentialProviderNameRegex.MatchString(kms.Name) {
+		return fmt.Errorf("kms name %q is invalid", kms.Name)
+	}
+	if !strings.HasPrefix(kms.SocketListenAddress, kmsSocketScheme+"/") {
+		return fmt.Errorf("kms %s socketListenAddress %q must be an absolute unix socket path, like unix:///var/run/kmsplugin/socket.sock", kms.Name, kms.SocketListenAddress)
+	}
+	if kms.Timeout != nil && kms.Timeout.Duration <= 0 {
+		return fmt.Errorf("kms %s timeout %s must be positive", kms.Name, kms.Timeout.Duration)
+	}
+
+	return nil
+}
+
+func validateCoreDNSStubDomain(stub CoreDNSStubDomain) error {
+	domain := strings.ToLower(strings.TrimSuffix(stub.Domain, "."))
+	if errs := utilvalidation.IsDNS1123Subdomain(domain); len(errs) > 0 {
+		return fmt.Errorf("domain is invalid: %s", strings.Join(errs, ", "))
+	}
+	if domain == constants.DefaultClusterDomain {
+		return fmt.Errorf("domain can't be the cluster domain %s", constants.DefaultClusterDomain)
+	}
+	if len(stub.Servers) == 0 {
+		return errors.New("servers can't be empty")
+	}
+	for _, server := range stub.Servers {
+		if err := validateDNSServer(server); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDNSServer checks the server is an IP address with an optional port.
+func validateDNSServer(server string) error {
+	if net.ParseIP(server) != nil {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(server)
+	if err != nil || net.ParseIP(host) == nil {
+		return fmt.Errorf("server %q must be an IP address with an optional port", server)
+	}
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("server %q has an invalid port", server)
+	}
+	return nil
+}
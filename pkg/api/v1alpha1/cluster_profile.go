@@ -0,0 +1,63 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// edgeProfileResources are the resources of the managed components in the edge profile, used when the
+// managed components configuration of the cluster doesn't set them.
+var edgeProfileResources = map[ManagedComponent]corev1.ResourceRequirements{
+	CiliumAgentComponent: {
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+	},
+	CiliumOperatorComponent: {
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("25m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+	},
+}
+
+// managedComponentConfigurations returns the managed components configuration of the cluster with the
+// defaults of its profile.
+func (c *Cluster) managedComponentConfigurations() []ManagedComponentConfiguration {
+	if !c.IsEdgeProfile() {
+		return c.Spec.ManagedComponents
+	}
+
+	configs := make([]ManagedComponentConfiguration, 0, len(c.Spec.ManagedComponents)+len(edgeProfileResources))
+	configured := make(map[ManagedComponent]struct{}, len(c.Spec.ManagedComponents))
+	for _, config := range c.Spec.ManagedComponents {
+		configured[config.Name] = struct{}{}
+		if resources, ok := edgeProfileResources[config.Name]; ok && config.Resources == nil {
+			config.Resources = resources.DeepCopy()
+		}
+		configs = append(configs, config)
+	}
+
+	for _, name := range []ManagedComponent{CiliumAgentComponent, CiliumOperatorComponent} {
+		if _, ok := configured[name]; ok {
+			continue
+		}
+		resources := edgeProfileResources[name]
+		configs = append(configs, ManagedComponentConfiguration{Name: name, Resources: resources.DeepCopy()})
+	}
+
+	return configs
+}
+
+func validateClusterProfile(clusterConfig *Cluster) error {
+	switch clusterConfig.Spec.ClusterProfile {
+	case "", EdgeClusterProfile:
+		return nil
+	default:
+		return fmt.Errorf("clusterProfile: unsupported profile %s, the only supported profile is %s",
+			clusterConfig.Spec.ClusterProfile, EdgeClusterProfile)
+	}
+}
@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestValidateClusterProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile ClusterProfile
+		wantErr string
+	}{
+		{
+			name: "no profile",
+		},
+		{
+			name:    "edge profile",
+			profile: EdgeClusterProfile,
+		},
+		{
+			name:    "unsupported profile",
+			profile: "tiny",
+			wantErr: "clusterProfile: unsupported profile tiny",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateClusterProfile(&Cluster{Spec: ClusterSpec{ClusterProfile: tt.profile}})
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterManagedComponentHelmValuesEdgeProfile(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{
+		Spec: ClusterSpec{
+			ClusterProfile: EdgeClusterProfile,
+			ManagedComponents: []ManagedComponentConfiguration{
+				{
+					Name: CiliumAgentComponent,
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+					},
+				},
+				{
+					Name:         CiliumOperatorComponent,
+					NodeSelector: map[string]string{"pool": "system"},
+				},
+			},
+		},
+	}
+
+	g.Expect(c.ManagedComponentHelmValues(CiliumChart)).To(Equal(map[string]interface{}{
+		"resources": map[string]interface{}{
+			"limits": map[string]interface{}{"memory": "512Mi"},
+		},
+		"operator.resources": map[string]interface{}{
+			"requests": map[string]interface{}{"cpu": "25m", "memory": "64Mi"},
+		},
+		"operator.nodeSelector": map[string]interface{}{"kubernetes.io/os": "linux", "pool": "system"},
+	}))
+	g.Expect(c.Spec.ManagedComponents[1].Resources).To(BeNil())
+
+	c.Spec.ManagedComponents = nil
+	g.Expect(c.ManagedComponentHelmValues(CiliumChart)).To(Equal(map[string]interface{}{
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{"cpu": "50m", "memory": "128Mi"},
+		},
+		"operator.resources": map[string]interface{}{
+			"requests": map[string]interface{}{"cpu": "25m", "memory": "64Mi"},
+		},
+	}))
+	g.Expect(c.ManagedComponentHelmValues(PackagesChart)).To(BeEmpty())
+}
@@ -7,11 +7,13 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
@@ -1201,7 +1203,7 @@ func TestParseClusterConfig(t *testing.T) {
 				clusterConfig: &Cluster{},
 			},
 			wantErr:    true,
-			matchError: fmt.Errorf("unmarshaling JSON: while decoding JSON: json: unknown field \"registryMirro rConfiguration\""),
+			matchError: fmt.Errorf("line 6, column 3: unknown field \"spec.registryMirro rConfiguration\", did you mean \"registryMirrorConfiguration\"?"),
 		},
 		{
 			name: "Invalid yaml",
@@ -1219,7 +1221,7 @@ func TestParseClusterConfig(t *testing.T) {
 				clusterConfig: &Cluster{},
 			},
 			wantErr:    true,
-			matchError: fmt.Errorf("unmarshaling JSON: while decoding JSON: json: unknown field \"invalidField\""),
+			matchError: fmt.Errorf("line 6, column 3: unknown field \"spec.invalidField\""),
 		},
 		{
 			name: "Cluster definition at the end",
@@ -2622,6 +2624,57 @@ func TestValidateControlPlaneEndpoint(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "external load balancer for vsphere",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Endpoint: &Endpoint{
+							Host:                 "1.2.3.4",
+							ExternalLoadBalancer: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "endpoint with port for vsphere",
+			wantErr: "cluster controlPlaneConfiguration.Endpoint.Host <lb.example.com:443> can't include a port for vSphere, the API server is served on port 6443",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Endpoint: &Endpoint{
+							Host:                 "lb.example.com:443",
+							ExternalLoadBalancer: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "external load balancer not supported",
+			wantErr: "controlPlaneConfiguration.endpoint.externalLoadBalancer is only supported for vSphere provider currently",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: CloudStackDatacenterKind,
+					},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Endpoint: &Endpoint{
+							Host:                 "1.2.3.4",
+							ExternalLoadBalancer: true,
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2644,7 +2697,7 @@ func TestValidateCPUpgradeRolloutStrategy(t *testing.T) {
 	}{
 		{
 			name:    "rolling upgrade strategy invalid",
-			wantErr: "ControlPlaneConfiguration: only 'RollingUpdate' supported for upgrade rollout strategy type",
+			wantErr: "ControlPlaneConfiguration: only 'RollingUpdate' and 'InPlace' supported for upgrade rollout strategy type",
 			cluster: &Cluster{
 				Spec: ClusterSpec{
 					ControlPlaneConfiguration: ControlPlaneConfiguration{
@@ -2708,6 +2761,30 @@ func TestValidateCPUpgradeRolloutStrategy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "in place upgrade tinkerbell",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "InPlace"},
+					},
+					DatacenterRef: Ref{Kind: TinkerbellDatacenterKind},
+				},
+			},
+		},
+		{
+			name:    "in place upgrade vsphere",
+			wantErr: "ControlPlaneConfiguration: 'InPlace' upgrade rollout strategy type is only supported for TinkerbellDatacenterConfig",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "InPlace"},
+					},
+					DatacenterRef: Ref{Kind: VSphereDatacenterKind},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2730,7 +2807,7 @@ func TestValidateMDUpgradeRolloutStrategy(t *testing.T) {
 	}{
 		{
 			name:    "rolling upgrade strategy invalid",
-			wantErr: "WorkerNodeGroupConfiguration: only 'RollingUpdate' supported for upgrade rollout strategy type",
+			wantErr: "WorkerNodeGroupConfiguration: only 'RollingUpdate' and 'InPlace' supported for upgrade rollout strategy type",
 			cluster: &Cluster{
 				Spec: ClusterSpec{
 					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
@@ -2838,11 +2915,47 @@ func TestValidateMDUpgradeRolloutStrategy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "in place upgrade tinkerbell without rolling upgrade knobs",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
+						UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{Type: "InPlace"},
+					}},
+					DatacenterRef: Ref{Kind: TinkerbellDatacenterKind},
+				},
+			},
+		},
+		{
+			name:    "in place upgrade tinkerbell rolling upgrade knobs invalid",
+			wantErr: "WorkerNodeGroupConfiguration: maxSurge and maxUnavailable values cannot be negative",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
+						UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{Type: "InPlace", RollingUpdate: WorkerNodesRollingUpdateParams{MaxSurge: -1}},
+					}},
+					DatacenterRef: Ref{Kind: TinkerbellDatacenterKind},
+				},
+			},
+		},
+		{
+			name:    "in place upgrade cloudstack",
+			wantErr: "WorkerNodeGroupConfiguration: 'InPlace' upgrade rollout strategy type is only supported for TinkerbellDatacenterConfig",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
+						UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{Type: "InPlace"},
+					}},
+					DatacenterRef: Ref{Kind: CloudStackDatacenterKind},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
-			err := validateMDUpgradeRolloutStrategy(&tt.cluster.Spec.WorkerNodeGroupConfigurations[0])
+			err := validateMDUpgradeRolloutStrategy(&tt.cluster.Spec.WorkerNodeGroupConfigurations[0], tt.cluster.Spec.DatacenterRef.Kind)
 			if tt.wantErr == "" {
 				g.Expect(err).To(BeNil())
 			} else {
@@ -3009,3 +3122,1162 @@ func TestClusterMDUpgradeRolloutStrategyNotNil(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEksdReleasePins(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		pins    []EksdReleasePin
+	}{
+		{
+			name: "no pins",
+		},
+		{
+			name: "valid pins",
+			pins: []EksdReleasePin{{KubernetesVersion: Kube122, Number: 10}, {KubernetesVersion: Kube123, Number: 4}},
+		},
+		{
+			name:    "missing kubernetes version",
+			wantErr: "kubernetesVersion is required",
+			pins:    []EksdReleasePin{{Number: 4}},
+		},
+		{
+			name:    "invalid number",
+			wantErr: "must be greater than 0",
+			pins:    []EksdReleasePin{{KubernetesVersion: Kube123}},
+		},
+		{
+			name:    "duplicate pins",
+			wantErr: "duplicate pin for kubernetes version 1.23",
+			pins:    []EksdReleasePin{{KubernetesVersion: Kube123, Number: 4}, {KubernetesVersion: Kube123, Number: 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateEksdReleasePins(&Cluster{Spec: ClusterSpec{EksdReleasePins: tt.pins}})
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateCertManager(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantErr     string
+		certManager *CertManagerConfiguration
+		management  string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:        "external mode",
+			certManager: &CertManagerConfiguration{Mode: CertManagerExternal},
+		},
+		{
+			name:        "unsupported mode",
+			wantErr:     "certManager: unsupported mode helm",
+			certManager: &CertManagerConfiguration{Mode: "helm"},
+		},
+		{
+			name:        "workload cluster",
+			wantErr:     "certManager can only be configured for management clusters",
+			certManager: &CertManagerConfiguration{Mode: CertManagerExternal},
+			management:  "mgmt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := &Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: ClusterSpec{
+					CertManager:       tt.certManager,
+					ManagementCluster: ManagementCluster{Name: tt.management},
+				},
+			}
+			if tt.management == "" {
+				c.SetSelfManaged()
+			}
+			err := validateCertManager(c)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterCertManagerMode(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{}
+	g.Expect(c.CertManagerMode()).To(Equal(CertManagerManaged))
+
+	c.Spec.CertManager = &CertManagerConfiguration{Mode: CertManagerExternal}
+	g.Expect(c.CertManagerMode()).To(Equal(CertManagerExternal))
+}
+
+func TestValidateControlPlaneKubeVip(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		endpoint *Endpoint
+		wantErr  string
+	}{
+		{
+			name:     "no kube-vip configuration",
+			kind:     SnowDatacenterKind,
+			endpoint: &Endpoint{Host: "1.2.3.4"},
+		},
+		{
+			name: "valid",
+			kind: VSphereDatacenterKind,
+			endpoint: &Endpoint{Host: "1.2.3.4", KubeVip: &KubeVipConfiguration{
+				LeaseDurationSeconds: 30,
+				RenewDeadlineSeconds: 20,
+				RetryPeriodSeconds:   4,
+				PrometheusPort:       2112,
+			}},
+		},
+		{
+			name:     "unsupported provider",
+			kind:     SnowDatacenterKind,
+			endpoint: &Endpoint{Host: "1.2.3.4", KubeVip: &KubeVipConfiguration{}},
+			wantErr:  "controlPlaneConfiguration.endpoint.kubeVip is not supported for SnowDatacenterConfig provider",
+		},
+		{
+			name:     "external load balancer",
+			kind:     VSphereDatacenterKind,
+			endpoint: &Endpoint{Host: "api.example.com", ExternalLoadBalancer: true, KubeVip: &KubeVipConfiguration{}},
+			wantErr:  "controlPlaneConfiguration.endpoint.kubeVip is not supported with an external load balancer",
+		},
+		{
+			name:     "dns name",
+			kind:     VSphereDatacenterKind,
+			endpoint: &Endpoint{Host: "api.example.com", KubeVip: &KubeVipConfiguration{}},
+		},
+		{
+			name:     "negative duration",
+			kind:     TinkerbellDatacenterKind,
+			endpoint: &Endpoint{Host: "1.2.3.4", KubeVip: &KubeVipConfiguration{RetryPeriodSeconds: -1}},
+			wantErr:  "controlPlaneConfiguration.endpoint.kubeVip durations must be positive",
+		},
+		{
+			name:     "renew deadline longer than default lease duration",
+			kind:     CloudStackDatacenterKind,
+			endpoint: &Endpoint{Host: "1.2.3.4", KubeVip: &KubeVipConfiguration{RenewDeadlineSeconds: 20}},
+			wantErr:  "controlPlaneConfiguration.endpoint.kubeVip.renewDeadlineSeconds 20 must be lower than leaseDurationSeconds 15",
+		},
+		{
+			name:     "retry period longer than renew deadline",
+			kind:     NutanixDatacenterKind,
+			endpoint: &Endpoint{Host: "1.2.3.4", KubeVip: &KubeVipConfiguration{RenewDeadlineSeconds: 5, RetryPeriodSeconds: 5}},
+			wantErr:  "controlPlaneConfiguration.endpoint.kubeVip.retryPeriodSeconds 5 must be lower than renewDeadlineSeconds 5",
+		},
+		{
+			name:     "prometheus port is the api server port",
+			kind:     VSphereDatacenterKind,
+			endpoint: &Endpoint{Host: "1.2.3.4", KubeVip: &KubeVipConfiguration{PrometheusPort: 6443}},
+			wantErr:  "controlPlaneConfiguration.endpoint.kubeVip.prometheusPort 6443 is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{Kind: tt.kind},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Endpoint: tt.endpoint,
+					},
+				},
+			}
+			err := validateControlPlaneKubeVip(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateSingleNode(t *testing.T) {
+	tests := []struct {
+		name           string
+		datacenterKind string
+		cpCount        int
+		workers        []WorkerNodeGroupConfiguration
+		externalEtcd   *ExternalEtcdConfiguration
+		strategy       *ControlPlaneUpgradeRolloutStrategy
+		wantErr        string
+	}{
+		{
+			name:           "snow single node",
+			datacenterKind: SnowDatacenterKind,
+			cpCount:        1,
+		},
+		{
+			name:           "tinkerbell single node with max surge 1",
+			datacenterKind: TinkerbellDatacenterKind,
+			cpCount:        1,
+			strategy:       &ControlPlaneUpgradeRolloutStrategy{Type: InPlaceStrategyType, RollingUpdate: ControlPlaneRollingUpdateParams{MaxSurge: 1}},
+		},
+		{
+			name:           "vsphere with workers",
+			datacenterKind: VSphereDatacenterKind,
+			cpCount:        1,
+			workers:        []WorkerNodeGroupConfiguration{{Name: "md-0"}},
+		},
+		{
+			name:           "vsphere single node",
+			datacenterKind: VSphereDatacenterKind,
+			cpCount:        1,
+			wantErr:        "single node clusters are only supported for TinkerbellDatacenterConfig and SnowDatacenterConfig",
+		},
+		{
+			name:           "external etcd",
+			datacenterKind: SnowDatacenterKind,
+			cpCount:        1,
+			externalEtcd:   &ExternalEtcdConfiguration{Count: 3},
+			wantErr:        "single node clusters don't support external etcd",
+		},
+		{
+			name:           "in place without max surge",
+			datacenterKind: TinkerbellDatacenterKind,
+			cpCount:        1,
+			strategy:       &ControlPlaneUpgradeRolloutStrategy{Type: InPlaceStrategyType},
+			wantErr:        "maxSurge for the control plane of single node clusters must be 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{Kind: tt.datacenterKind},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Count:                  tt.cpCount,
+						UpgradeRolloutStrategy: tt.strategy,
+					},
+					WorkerNodeGroupConfigurations: tt.workers,
+					ExternalEtcdConfiguration:     tt.externalEtcd,
+				},
+			}
+			err := validateSingleNode(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateWorkerNodeGroupsSnowSingleNode(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &Cluster{
+		Spec: ClusterSpec{
+			DatacenterRef:             Ref{Kind: SnowDatacenterKind},
+			ControlPlaneConfiguration: ControlPlaneConfiguration{Count: 1},
+		},
+	}
+	g.Expect(validateWorkerNodeGroups(cluster)).To(Succeed())
+
+	cluster.Spec.ControlPlaneConfiguration.Count = 3
+	g.Expect(validateWorkerNodeGroups(cluster)).To(MatchError(ContainSubstring("WorkerNodeGroupConfigs cannot be empty for SnowDatacenterConfig")))
+}
+
+func TestValidateControlPlaneSchedulable(t *testing.T) {
+	tests := []struct {
+		name        string
+		schedulable bool
+		taints      []v1.Taint
+		wantErr     string
+	}{
+		{
+			name:   "not schedulable with NoSchedule taint",
+			taints: []v1.Taint{{Key: "key1", Effect: v1.TaintEffectNoSchedule}},
+		},
+		{
+			name:        "schedulable with PreferNoSchedule taint",
+			schedulable: true,
+			taints:      []v1.Taint{{Key: "key1", Effect: v1.TaintEffectPreferNoSchedule}},
+		},
+		{
+			name:        "schedulable with NoExecute taint",
+			schedulable: true,
+			taints:      []v1.Taint{{Key: "key1", Effect: v1.TaintEffectNoExecute}},
+			wantErr:     "controlPlaneConfiguration.taints key1 can't have effect NoExecute when the control plane is schedulable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Schedulable: tt.schedulable,
+						Taints:      tt.taints,
+					},
+				},
+			}
+			err := validateControlPlaneSchedulable(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestControlPlaneConfigurationNodeTaints(t *testing.T) {
+	g := NewWithT(t)
+	taints := []v1.Taint{{Key: "key1", Effect: v1.TaintEffectPreferNoSchedule}}
+
+	g.Expect((&ControlPlaneConfiguration{}).NodeTaints()).To(BeNil())
+	g.Expect((&ControlPlaneConfiguration{Schedulable: true}).NodeTaints()).To(Equal([]v1.Taint{}))
+	g.Expect((&ControlPlaneConfiguration{Schedulable: true, Taints: taints}).NodeTaints()).To(Equal(taints))
+}
+
+func TestValidateControlPlaneCertSANs(t *testing.T) {
+	tests := []struct {
+		name     string
+		certSANs []string
+		wantErr  string
+	}{
+		{
+			name:     "dns names and ips",
+			certSANs: []string{"api.example.com", "Alias.Example.com", "10.0.0.1", "fd00::1"},
+		},
+		{
+			name:     "invalid name",
+			certSANs: []string{"api.example.com", "not_valid!"},
+			wantErr:  "controlPlaneConfiguration.certSANs not_valid! is invalid, it must be an IP or a DNS name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						CertSANs: tt.certSANs,
+					},
+				},
+			}
+			err := validateControlPlaneCertSANs(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateControlPlaneStaticPodManifests(t *testing.T) {
+	validPod := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: log-shipper\n  namespace: kube-system\nspec:\n  containers:\n  - name: shipper\n    image: public.ecr.aws/shipper:v1\n"
+	tests := []struct {
+		name      string
+		manifests []StaticPodManifest
+		wantErr   string
+	}{
+		{
+			name:      "valid",
+			manifests: []StaticPodManifest{{Name: "log-shipper", Content: validPod}},
+		},
+		{
+			name:      "invalid name",
+			manifests: []StaticPodManifest{{Name: "Log_Shipper", Content: validPod}},
+			wantErr:   "controlPlaneConfiguration.staticPodManifests name Log_Shipper is invalid",
+		},
+		{
+			name:      "reserved name",
+			manifests: []StaticPodManifest{{Name: "kube-apiserver", Content: validPod}},
+			wantErr:   "controlPlaneConfiguration.staticPodManifests name kube-apiserver is reserved for a control plane component",
+		},
+		{
+			name:      "duplicated name",
+			manifests: []StaticPodManifest{{Name: "log-shipper", Content: validPod}, {Name: "log-shipper", Content: validPod}},
+			wantErr:   "controlPlaneConfiguration.staticPodManifests name log-shipper is duplicated",
+		},
+		{
+			name:      "not yaml",
+			manifests: []StaticPodManifest{{Name: "log-shipper", Content: "{{"}},
+			wantErr:   "controlPlaneConfiguration.staticPodManifests log-shipper is invalid: parsing content",
+		},
+		{
+			name:      "not a pod",
+			manifests: []StaticPodManifest{{Name: "log-shipper", Content: "apiVersion: apps/v1\nkind: Deployment\n"}},
+			wantErr:   "content must be a v1 Pod, got apps/v1 Deployment",
+		},
+		{
+			name:      "no containers",
+			manifests: []StaticPodManifest{{Name: "log-shipper", Content: "apiVersion: v1\nkind: Pod\n"}},
+			wantErr:   "pod must have at least one container",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						StaticPodManifests: tt.manifests,
+					},
+				},
+			}
+			err := validateControlPlaneStaticPodManifests(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateContainerdConfigurations(t *testing.T) {
+	tests := []struct {
+		name           string
+		datacenterKind string
+		config         *ContainerdConfiguration
+		wantErr        string
+	}{
+		{
+			name:           "nil config",
+			datacenterKind: CloudStackDatacenterKind,
+		},
+		{
+			name:           "valid",
+			datacenterKind: VSphereDatacenterKind,
+			config: &ContainerdConfiguration{
+				SandboxImage:    "registry.local:5000/eks/pause:3.9",
+				RegistryHeaders: map[string]string{"Authorization": "Basic abc="},
+				EnableNRI:       true,
+			},
+		},
+		{
+			name:           "not supported provider",
+			datacenterKind: CloudStackDatacenterKind,
+			config:         &ContainerdConfiguration{EnableNRI: true},
+			wantErr:        "workerNodeGroupConfigurations[md-0].containerdConfiguration is only supported for vSphere provider currently",
+		},
+		{
+			name:           "sandbox image without tag",
+			datacenterKind: VSphereDatacenterKind,
+			config:         &ContainerdConfiguration{SandboxImage: "registry.local/eks/pause"},
+			wantErr:        "workerNodeGroupConfigurations[md-0].containerdConfiguration.sandboxImage registry.local/eks/pause is invalid",
+		},
+		{
+			name:           "invalid header name",
+			datacenterKind: VSphereDatacenterKind,
+			config:         &ContainerdConfiguration{RegistryHeaders: map[string]string{"X Header": "a"}},
+			wantErr:        "workerNodeGroupConfigurations[md-0].containerdConfiguration.registryHeaders name X Header is invalid",
+		},
+		{
+			name:           "invalid header value",
+			datacenterKind: VSphereDatacenterKind,
+			config:         &ContainerdConfiguration{RegistryHeaders: map[string]string{"X-Header": "a\"b"}},
+			wantErr:        "registryHeaders value for X-Header can't contain quotes, backslashes or new lines",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{Kind: tt.datacenterKind},
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{Name: "md-0", ContainerdConfiguration: tt.config},
+					},
+				},
+			}
+			err := validateContainerdConfigurations(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateImageCredentialProviders(t *testing.T) {
+	ecr := ImageCredentialProvider{
+		Name:                 "ecr-credential-provider",
+		MatchImages:          []string{"*.dkr.ecr.*.amazonaws.com"},
+		DefaultCacheDuration: "12h",
+		Env:                  map[string]string{"AWS_PROFILE": "ecr"},
+	}
+	tests := []struct {
+		name      string
+		providers []ImageCredentialProvider
+		wantErr   string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:      "valid",
+			providers: []ImageCredentialProvider{ecr},
+		},
+		{
+			name:      "invalid name",
+			providers: []ImageCredentialProvider{{Name: "../bin/sh", MatchImages: ecr.MatchImages}},
+			wantErr:   `kubeletConfiguration.imageCredentialProviders name "../bin/sh" is invalid`,
+		},
+		{
+			name:      "duplicated name",
+			providers: []ImageCredentialProvider{ecr, ecr},
+			wantErr:   "kubeletConfiguration.imageCredentialProviders name ecr-credential-provider is duplicated",
+		},
+		{
+			name:      "no match images",
+			providers: []ImageCredentialProvider{{Name: "ecr-credential-provider"}},
+			wantErr:   "kubeletConfiguration.imageCredentialProviders ecr-credential-provider: matchImages can't be empty",
+		},
+		{
+			name:      "invalid match image",
+			providers: []ImageCredentialProvider{{Name: "ecr-credential-provider", MatchImages: []string{"*.dkr.ecr.*.amazonaws.com\nfoo"}}},
+			wantErr:   "matchImages pattern",
+		},
+		{
+			name:      "invalid cache duration",
+			providers: []ImageCredentialProvider{{Name: "ecr-credential-provider", MatchImages: ecr.MatchImages, DefaultCacheDuration: "1d"}},
+			wantErr:   "defaultCacheDuration 1d is invalid",
+		},
+		{
+			name:      "invalid env name",
+			providers: []ImageCredentialProvider{{Name: "ecr-credential-provider", MatchImages: ecr.MatchImages, Env: map[string]string{"AWS-PROFILE": "ecr"}}},
+			wantErr:   "env name AWS-PROFILE is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					KubeletConfiguration: &KubeletConfiguration{ImageCredentialProviders: tt.providers},
+				},
+			}
+			err := validateImageCredentialProviders(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestImageCredentialProvidersEqual(t *testing.T) {
+	g := NewWithT(t)
+	provider := ImageCredentialProvider{
+		Name:        "ecr-credential-provider",
+		MatchImages: []string{"*.dkr.ecr.*.amazonaws.com"},
+		Args:        []string{"get-credentials"},
+	}
+	changed := provider
+	changed.Args = []string{"get-credentials", "--verbose"}
+
+	g.Expect(ImageCredentialProvidersEqual(nil, []ImageCredentialProvider{})).To(BeTrue())
+	g.Expect(ImageCredentialProvidersEqual([]ImageCredentialProvider{provider}, []ImageCredentialProvider{provider})).To(BeTrue())
+	g.Expect(ImageCredentialProvidersEqual([]ImageCredentialProvider{provider}, nil)).To(BeFalse())
+	g.Expect(ImageCredentialProvidersEqual([]ImageCredentialProvider{provider}, []ImageCredentialProvider{changed})).To(BeFalse())
+}
+
+func TestValidateCoreDNSConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *CoreDNSConfiguration
+		wantErr string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid",
+			config: &CoreDNSConfiguration{
+				UpstreamServers: []string{"10.0.0.2", "10.0.0.3:5353", "[fd00::2]:53"},
+				StubDomains: []CoreDNSStubDomain{
+					{Domain: "corp.example.com.", Servers: []string{"10.1.0.2"}},
+					{Domain: "10.in-addr.arpa", Servers: []string{"fd00::3"}},
+				},
+				CustomServerBlocks: "lab.example.com:53 {\n    file /etc/coredns/lab.db\n}",
+			},
+		},
+		{
+			name:    "invalid upstream server",
+			config:  &CoreDNSConfiguration{UpstreamServers: []string{"dns.example.com"}},
+			wantErr: `coreDNSConfiguration.upstreamServers: server "dns.example.com" must be an IP address with an optional port`,
+		},
+		{
+			name:    "invalid upstream server port",
+			config:  &CoreDNSConfiguration{UpstreamServers: []string{"10.0.0.2:70000"}},
+			wantErr: `server "10.0.0.2:70000" has an invalid port`,
+		},
+		{
+			name:    "invalid stub domain",
+			config:  &CoreDNSConfiguration{StubDomains: []CoreDNSStubDomain{{Domain: "corp_example.com", Servers: []string{"10.1.0.2"}}}},
+			wantErr: "coreDNSConfiguration.stubDomains corp_example.com: domain is invalid",
+		},
+		{
+			name:    "cluster domain",
+			config:  &CoreDNSConfiguration{StubDomains: []CoreDNSStubDomain{{Domain: "cluster.local", Servers: []string{"10.1.0.2"}}}},
+			wantErr: "domain can't be the cluster domain cluster.local",
+		},
+		{
+			name:    "stub domain without servers",
+			config:  &CoreDNSConfiguration{StubDomains: []CoreDNSStubDomain{{Domain: "corp.example.com"}}},
+			wantErr: "coreDNSConfiguration.stubDomains corp.example.com: servers can't be empty",
+		},
+		{
+			name: "duplicated stub domain",
+			config: &CoreDNSConfiguration{StubDomains: []CoreDNSStubDomain{
+				{Domain: "corp.example.com", Servers: []string{"10.1.0.2"}},
+				{Domain: "Corp.example.com.", Servers: []string{"10.1.0.3"}},
+			}},
+			wantErr: "coreDNSConfiguration.stubDomains domain Corp.example.com. is duplicated",
+		},
+		{
+			name:    "unbalanced custom server blocks",
+			config:  &CoreDNSConfiguration{CustomServerBlocks: "lab.example.com:53 {\n    file /etc/coredns/lab.db\n"},
+			wantErr: "coreDNSConfiguration.customServerBlocks has unbalanced braces",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					CoreDNSConfiguration: tt.config,
+				},
+			}
+			err := validateCoreDNSConfiguration(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestCoreDNSConfigurationEqual(t *testing.T) {
+	g := NewWithT(t)
+	config := &CoreDNSConfiguration{
+		UpstreamServers: []string{"10.0.0.2"},
+		StubDomains:     []CoreDNSStubDomain{{Domain: "corp.example.com", Servers: []string{"10.1.0.2"}}},
+	}
+	changed := config.DeepCopy()
+	changed.StubDomains[0].Servers = []string{"10.1.0.3"}
+
+	g.Expect((*CoreDNSConfiguration)(nil).Equal(&CoreDNSConfiguration{})).To(BeTrue())
+	g.Expect(config.Equal(config.DeepCopy())).To(BeTrue())
+	g.Expect(config.Equal(nil)).To(BeFalse())
+	g.Expect(config.Equal(changed)).To(BeFalse())
+}
+
+func TestValidateTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		tags    map[string]string
+		wantErr string
+	}{
+		{
+			name: "no tags",
+			kind: SnowDatacenterKind,
+		},
+		{
+			name: "valid",
+			kind: VSphereDatacenterKind,
+			tags: map[string]string{"cost-center": "platform", "environment": "prod"},
+		},
+		{
+			name: "valid cloudstack",
+			kind: CloudStackDatacenterKind,
+			tags: map[string]string{"cost-center": "platform"},
+		},
+		{
+			name: "valid snow",
+			kind: SnowDatacenterKind,
+			tags: map[string]string{"cost-center": "platform"},
+		},
+		{
+			name:    "unsupported provider",
+			kind:    DockerDatacenterKind,
+			tags:    map[string]string{"cost-center": "platform"},
+			wantErr: "tags are not supported for DockerDatacenterConfig provider",
+		},
+		{
+			name:    "empty tag",
+			kind:    VSphereDatacenterKind,
+			tags:    map[string]string{"cost-center": ""},
+			wantErr: "tags key and value can't be empty",
+		},
+		{
+			name:    "tag too long",
+			kind:    VSphereDatacenterKind,
+			tags:    map[string]string{"cost-center": strings.Repeat("a", 257)},
+			wantErr: "tags key cost-center must be at most 256 characters and its value at most 256",
+		},
+		{
+			name:    "snow key too long",
+			kind:    SnowDatacenterKind,
+			tags:    map[string]string{strings.Repeat("a", 129): "platform"},
+			wantErr: "must be at most 128 characters",
+		},
+		{
+			name:    "reserved key",
+			kind:    CloudStackDatacenterKind,
+			tags:    map[string]string{constants.ManagedTagsKey: "environment"},
+			wantErr: "is reserved or contains a comma",
+		},
+		{
+			name:    "key with comma",
+			kind:    SnowDatacenterKind,
+			tags:    map[string]string{"cost,center": "platform"},
+			wantErr: "is reserved or contains a comma",
+		},
+		{
+			name:    "snow name key",
+			kind:    SnowDatacenterKind,
+			tags:    map[string]string{"Name": "my-instance"},
+			wantErr: "tags key Name is reserved for the instances managed by CAPAS",
+		},
+		{
+			name:    "snow capas key",
+			kind:    SnowDatacenterKind,
+			tags:    map[string]string{"sigs.k8s.io/cluster-api-provider-aws-snow/role": "node"},
+			wantErr: "is reserved for the instances managed by CAPAS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{Kind: tt.kind},
+					Tags:          tt.tags,
+				},
+			}
+			err := validateTags(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterEqualTags(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &Cluster{Spec: ClusterSpec{Tags: map[string]string{"cost-center": "platform"}}}
+	changed := cluster.DeepCopy()
+	changed.Spec.Tags["cost-center"] = "storage"
+
+	g.Expect(cluster.Equal(cluster.DeepCopy())).To(BeTrue())
+	g.Expect(cluster.Equal(changed)).To(BeFalse())
+	g.Expect((&Cluster{}).Equal(&Cluster{Spec: ClusterSpec{Tags: map[string]string{}}})).To(BeTrue())
+}
+
+func validBackupConfiguration() *BackupConfiguration {
+	return &BackupConfiguration{
+		StorageLocation: BackupStorageLocation{
+			Bucket:                "backups",
+			Region:                "us-west-2",
+			S3URL:                 "https://minio.example.com:9000",
+			CredentialsSecretName: "backup-credentials",
+		},
+		Schedules: []BackupSchedule{
+			{Name: "daily", Schedule: "0 2 * * *", TTL: "720h"},
+			{Name: "weekly", Schedule: "@weekly", IncludedNamespaces: []string{"apps"}},
+		},
+	}
+}
+
+func TestValidateBackup(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*BackupConfiguration)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*BackupConfiguration) {},
+		},
+		{
+			name:    "empty bucket",
+			mutate:  func(b *BackupConfiguration) { b.StorageLocation.Bucket = "" },
+			wantErr: "backup.storageLocation: bucket can't be empty",
+		},
+		{
+			name:    "empty region",
+			mutate:  func(b *BackupConfiguration) { b.StorageLocation.Region = "" },
+			wantErr: "backup.storageLocation: region can't be empty",
+		},
+		{
+			name:    "invalid credentials secret",
+			mutate:  func(b *BackupConfiguration) { b.StorageLocation.CredentialsSecretName = "" },
+			wantErr: "backup.storageLocation: credentialsSecretName is invalid",
+		},
+		{
+			name:    "relative s3 url",
+			mutate:  func(b *BackupConfiguration) { b.StorageLocation.S3URL = "minio:9000" },
+			wantErr: "backup.storageLocation: s3Url minio:9000 must be an absolute URL",
+		},
+		{
+			name:    "invalid schedule name",
+			mutate:  func(b *BackupConfiguration) { b.Schedules[0].Name = "Daily" },
+			wantErr: "backup.schedules Daily: name is invalid",
+		},
+		{
+			name:    "invalid cron expression",
+			mutate:  func(b *BackupConfiguration) { b.Schedules[0].Schedule = "0 2 * *" },
+			wantErr: "backup.schedules daily: schedule \"0 2 * *\" must be a cron expression with 5 fields or a descriptor",
+		},
+		{
+			name:    "invalid ttl",
+			mutate:  func(b *BackupConfiguration) { b.Schedules[0].TTL = "30d" },
+			wantErr: "backup.schedules daily: ttl is invalid",
+		},
+		{
+			name:    "duplicated schedule",
+			mutate:  func(b *BackupConfiguration) { b.Schedules[1].Name = "daily" },
+			wantErr: "backup.schedules name daily is duplicated",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			backup := validBackupConfiguration()
+			tt.mutate(backup)
+			cluster := &Cluster{Spec: ClusterSpec{Backup: backup}}
+			err := validateBackup(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterEqualBackup(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &Cluster{Spec: ClusterSpec{Backup: validBackupConfiguration()}}
+	changedSchedule := cluster.DeepCopy()
+	changedSchedule.Spec.Backup.Schedules[0].Schedule = "0 3 * * *"
+	changedLocation := cluster.DeepCopy()
+	changedLocation.Spec.Backup.StorageLocation.Prefix = "prod"
+
+	g.Expect(cluster.Equal(cluster.DeepCopy())).To(BeTrue())
+	g.Expect(cluster.Equal(changedSchedule)).To(BeFalse())
+	g.Expect(cluster.Equal(changedLocation)).To(BeFalse())
+	g.Expect(cluster.Equal(&Cluster{})).To(BeFalse())
+}
+
+func validBootstrapManifests() []BootstrapManifest {
+	return []BootstrapManifest{
+		{Name: "namespaces", Inline: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: apps\n"},
+		{Name: "quotas", OCI: "oci://public.ecr.aws/org/quotas:v1"},
+		{Name: "network-policies", Git: &BootstrapManifestGitRef{
+			Repository: "https://github.com/org/policies.git",
+			Ref:        "v1",
+			Path:       "policies/default-deny.yaml",
+		}},
+	}
+}
+
+func TestValidateBootstrapManifests(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		mutate  func([]BootstrapManifest)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			kind:   VSphereDatacenterKind,
+			mutate: func([]BootstrapManifest) {},
+		},
+		{
+			name:    "invalid name",
+			mutate:  func(m []BootstrapManifest) { m[0].Name = "Namespaces" },
+			wantErr: "bootstrapManifests Namespaces: name is invalid",
+		},
+		{
+			name:    "no source",
+			mutate:  func(m []BootstrapManifest) { m[0].Inline = "" },
+			wantErr: "bootstrapManifests namespaces: exactly one of inline, oci and git must be set",
+		},
+		{
+			name:    "several sources",
+			mutate:  func(m []BootstrapManifest) { m[1].Inline = "kind: Namespace" },
+			wantErr: "bootstrapManifests quotas: exactly one of inline, oci and git must be set",
+		},
+		{
+			name:    "invalid oci reference",
+			mutate:  func(m []BootstrapManifest) { m[1].OCI = "public.ecr.aws/org/quotas:v1" },
+			wantErr: "bootstrapManifests quotas: oci public.ecr.aws/org/quotas:v1 must be an oci:// reference",
+		},
+		{
+			name:    "ssh git repository",
+			mutate:  func(m []BootstrapManifest) { m[2].Git.Repository = "git@github.com:org/policies.git" },
+			wantErr: "bootstrapManifests network-policies: git.repository git@github.com:org/policies.git must be an https URL",
+		},
+		{
+			name:    "empty git path",
+			mutate:  func(m []BootstrapManifest) { m[2].Git.Path = "" },
+			wantErr: "bootstrapManifests network-policies: git.path can't be empty",
+		},
+		{
+			name:    "duplicated name",
+			mutate:  func(m []BootstrapManifest) { m[1].Name = "namespaces" },
+			wantErr: "bootstrapManifests name namespaces is duplicated",
+		},
+		{
+			name:    "docker",
+			kind:    DockerDatacenterKind,
+			mutate:  func([]BootstrapManifest) {},
+			wantErr: "bootstrapManifests are not supported for Docker clusters",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			manifests := validBootstrapManifests()
+			tt.mutate(manifests)
+			cluster := &Cluster{Spec: ClusterSpec{BootstrapManifests: manifests, DatacenterRef: Ref{Kind: tt.kind}}}
+			err := validateBootstrapManifests(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterEqualBootstrapManifests(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &Cluster{Spec: ClusterSpec{BootstrapManifests: validBootstrapManifests()}}
+	changedInline := cluster.DeepCopy()
+	changedInline.Spec.BootstrapManifests[0].Inline = "kind: Namespace"
+	changedGitRef := cluster.DeepCopy()
+	changedGitRef.Spec.BootstrapManifests[2].Git.Ref = "v2"
+
+	g.Expect(cluster.Equal(cluster.DeepCopy())).To(BeTrue())
+	g.Expect(cluster.Equal(changedInline)).To(BeFalse())
+	g.Expect(cluster.Equal(changedGitRef)).To(BeFalse())
+	g.Expect(cluster.Equal(&Cluster{})).To(BeFalse())
+}
+
+func TestValidateBottlerocketUpdates(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		updates *BottlerocketUpdatesConfiguration
+		wantErr string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:    "defaults",
+			updates: &BottlerocketUpdatesConfiguration{},
+		},
+		{
+			name:    "schedule with seconds",
+			updates: &BottlerocketUpdatesConfiguration{MaxConcurrentUpdates: 2, Schedule: "0 0 23 * * Sat"},
+		},
+		{
+			name:    "schedule with year",
+			updates: &BottlerocketUpdatesConfiguration{Schedule: "0 0 23 * * Sat *"},
+		},
+		{
+			name:    "negative max concurrent updates",
+			updates: &BottlerocketUpdatesConfiguration{MaxConcurrentUpdates: -1},
+			wantErr: "bottlerocketUpdates.maxConcurrentUpdates -1 can't be negative",
+		},
+		{
+			name:    "schedule without seconds",
+			updates: &BottlerocketUpdatesConfiguration{Schedule: "0 23 * * Sat"},
+			wantErr: "bottlerocketUpdates.schedule \"0 23 * * Sat\" must be a cron expression with 6 or 7 fields",
+		},
+		{
+			name:    "unsupported provider",
+			kind:    CloudStackDatacenterKind,
+			updates: &BottlerocketUpdatesConfiguration{},
+			wantErr: "bottlerocketUpdates is only supported for vSphere and Tinkerbell clusters, not CloudStackDatacenterConfig",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			kind := tt.kind
+			if kind == "" {
+				kind = VSphereDatacenterKind
+			}
+			cluster := &Cluster{Spec: ClusterSpec{DatacenterRef: Ref{Kind: kind}, BottlerocketUpdates: tt.updates}}
+			err := validateBottlerocketUpdates(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestClusterEqualBottlerocketUpdates(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &Cluster{Spec: ClusterSpec{BottlerocketUpdates: &BottlerocketUpdatesConfiguration{MaxConcurrentUpdates: 2}}}
+	changedSchedule := cluster.DeepCopy()
+	changedSchedule.Spec.BottlerocketUpdates.Schedule = "0 0 23 * * Sat *"
+
+	g.Expect(cluster.Equal(cluster.DeepCopy())).To(BeTrue())
+	g.Expect(cluster.Equal(changedSchedule)).To(BeFalse())
+	g.Expect(cluster.Equal(&Cluster{})).To(BeFalse())
+}
+
+func validEtcdEncryption() []EtcdEncryption {
+	return []EtcdEncryption{
+		{
+			Providers: []EtcdEncryptionProvider{
+				{KMS: &KMS{Name: "new-key", SocketListenAddress: "unix:///var/run/kmsplugin/new.sock"}},
+				{KMS: &KMS{Name: "old-key", SocketListenAddress: "unix:///var/run/kmsplugin/old.sock", Timeout: &metav1.Duration{Duration: 5 * time.Second}}},
+			},
+		},
+		{
+			Providers: []EtcdEncryptionProvider{
+				{KMS: &KMS{Name: "configmaps-key", SocketListenAddress: "unix:///var/run/kmsplugin/configmaps.sock"}},
+			},
+			Resources:         []string{"configmaps"},
+			KeyRotationPeriod: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+		},
+	}
+}
+
+func TestValidateEtcdEncryption(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func([]EtcdEncryption)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func([]EtcdEncryption) {},
+		},
+		{
+			name:    "no providers",
+			mutate:  func(e []EtcdEncryption) { e[1].Providers = nil },
+			wantErr: "etcdEncryption[1].providers can't be empty",
+		},
+		{
+			name:    "no kms",
+			mutate:  func(e []EtcdEncryption) { e[0].Providers[1].KMS = nil },
+			wantErr: "etcdEncryption[0].providers: kms can't be empty",
+		},
+		{
+			name:    "invalid kms name",
+			mutate:  func(e []EtcdEncryption) { e[0].Providers[0].KMS.Name = "new key" },
+			wantErr: "etcdEncryption[0].providers: kms name \"new key\" is invalid",
+		},
+		{
+			name:    "tcp socket",
+			mutate:  func(e []EtcdEncryption) { e[0].Providers[0].KMS.SocketListenAddress = "tcp://127.0.0.1:8080" },
+			wantErr: "etcdEncryption[0].providers: kms new-key socketListenAddress \"tcp://127.0.0.1:8080\" must be an absolute unix socket path",
+		},
+		{
+			name:    "relative socket",
+			mutate:  func(e []EtcdEncryption) { e[0].Providers[0].KMS.SocketListenAddress = "unix://kmsplugin/new.sock" },
+			wantErr: "etcdEncryption[0].providers: kms new-key socketListenAddress \"unix://kmsplugin/new.sock\" must be an absolute unix socket path",
+		},
+		{
+			name:    "zero timeout",
+			mutate:  func(e []EtcdEncryption) { e[0].Providers[1].KMS.Timeout.Duration = 0 },
+			wantErr: "etcdEncryption[0].providers: kms old-key timeout 0s must be positive",
+		},
+		{
+			name:    "duplicated kms name",
+			mutate:  func(e []EtcdEncryption) { e[1].Providers[0].KMS.Name = "new-key" },
+			wantErr: "etcdEncryption[1].providers kms name new-key is duplicated",
+		},
+		{
+			name:    "empty resource",
+			mutate:  func(e []EtcdEncryption) { e[1].Resources = []string{""} },
+			wantErr: "etcdEncryption[1].resources \"\" is invalid",
+		},
+		{
+			name:    "resource in several configs",
+			mutate:  func(e []EtcdEncryption) { e[1].Resources = []string{"configmaps", "secrets"} },
+			wantErr: "etcdEncryption[1].resources secrets is duplicated, a resource can only be in one etcdEncryption config",
+		},
+		{
+			name:    "key rotation period too short",
+			mutate:  func(e []EtcdEncryption) { e[1].KeyRotationPeriod.Duration = time.Minute },
+			wantErr: "etcdEncryption[1].keyRotationPeriod 1m0s must be at least 1h0m0s",
+		},
+		{
+			name:    "key rotation period with wildcard resource",
+			mutate:  func(e []EtcdEncryption) { e[1].Resources = []string{"*.apps"} },
+			wantErr: "etcdEncryption[1].keyRotationPeriod can't be set for the wildcard resource *.apps, the objects to rewrite must be listed by resource",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			encryption := validEtcdEncryption()
+			tt.mutate(encryption)
+			cluster := &Cluster{Spec: ClusterSpec{EtcdEncryption: encryption}}
+			err := validateEtcdEncryption(cluster)
+			if err == nil {
+				err = validateEtcdEncryptionKeyRotationPeriods(cluster)
+			}
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestEtcdEncryptionEncryptedResources(t *testing.T) {
+	g := NewWithT(t)
+	encryption := validEtcdEncryption()
+	g.Expect(encryption[0].EncryptedResources()).To(Equal([]string{"secrets"}))
+	g.Expect(encryption[1].EncryptedResources()).To(Equal([]string{"configmaps"}))
+}
+
+func TestClusterEqualEtcdEncryption(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &Cluster{Spec: ClusterSpec{EtcdEncryption: validEtcdEncryption()}}
+	changedTimeout := cluster.DeepCopy()
+	changedTimeout.Spec.EtcdEncryption[0].Providers[1].KMS.Timeout.Duration = time.Second
+	changedResources := cluster.DeepCopy()
+	changedResources.Spec.EtcdEncryption[1].Resources = append(changedResources.Spec.EtcdEncryption[1].Resources, "events")
+	rotatedKey := cluster.DeepCopy()
+	rotatedKey.Spec.EtcdEncryption[0].Providers = rotatedKey.Spec.EtcdEncryption[0].Providers[:1]
+	changedRotationPeriod := cluster.DeepCopy()
+	changedRotationPeriod.Spec.EtcdEncryption[1].KeyRotationPeriod = nil
+
+	g.Expect(cluster.Equal(cluster.DeepCopy())).To(BeTrue())
+	g.Expect(cluster.Equal(changedRotationPeriod)).To(BeFalse())
+	g.Expect(cluster.Equal(changedTimeout)).To(BeFalse())
+	g.Expect(cluster.Equal(changedResources)).To(BeFalse())
+	g.Expect(cluster.Equal(rotatedKey)).To(BeFalse())
+	g.Expect(cluster.Equal(&Cluster{})).To(BeFalse())
+}
@@ -1,9 +1,11 @@
 package v1alpha1
 
 import (
+	"net"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
@@ -28,6 +30,10 @@ const (
 	// cluster object.
 	managementAnnotation = "anywhere.eks.amazonaws.com/managed-by"
 
+	// credentialsProfileAnnotation selects the profile of the credentials file the CLI reads
+	// the provider credentials from.
+	credentialsProfileAnnotation = "anywhere.eks.amazonaws.com/credentials-profile"
+
 	// defaultEksaNamespace is the default namespace for EKS-A resources when not specified.
 	defaultEksaNamespace = "default"
 )
@@ -51,6 +57,43 @@ type ClusterSpec struct {
 	PodIAMConfig                *PodIAMConfig                `json:"podIamConfig,omitempty"`
 	// BundlesRef contains a reference to the Bundles containing the desired dependencies for the cluster
 	BundlesRef *BundlesRef `json:"bundlesRef,omitempty"`
+	// EksdReleasePins pins the EKS Distro release used per Kubernetes version instead of the
+	// latest release available in the Bundles.
+	EksdReleasePins []EksdReleasePin `json:"eksdReleasePins,omitempty"`
+	// HelmValuesOverrides overrides an allowlisted set of Helm values for the charts managed by EKS-A.
+	HelmValuesOverrides []HelmValuesOverride `json:"helmValuesOverrides,omitempty"`
+	// ManagedComponents configures scheduling and resources for the controllers and DaemonSets
+	// EKS-A deploys to the cluster.
+	ManagedComponents []ManagedComponentConfiguration `json:"managedComponents,omitempty"`
+	// CertManager configures how cert-manager is managed in management clusters.
+	CertManager *CertManagerConfiguration `json:"certManager,omitempty"`
+	// KubeletConfiguration configures the kubelet on all the nodes of the cluster.
+	KubeletConfiguration *KubeletConfiguration `json:"kubeletConfiguration,omitempty"`
+	// CoreDNSConfiguration customizes the CoreDNS Corefile of the cluster. EKS-A applies it
+	// after creating the cluster and again after every upgrade.
+	CoreDNSConfiguration *CoreDNSConfiguration `json:"coreDNSConfiguration,omitempty"`
+	// Tags are propagated to the infrastructure resources of the cluster in the provider, for cost
+	// allocation and inventory. Supported for vSphere, where each key is a tag category and its value
+	// the tag of that category attached to the VMs of the cluster, and for CloudStack and Snow, where
+	// they are set as resource tags on the virtual machines and EC2 instances of the cluster.
+	Tags map[string]string `json:"tags,omitempty"`
+	// Backup deploys Velero to the cluster to back up its workloads to an S3 compatible storage.
+	// EKS-A installs it from the bundle after creating the cluster and upgrades it with the cluster.
+	Backup *BackupConfiguration `json:"backup,omitempty"`
+	// BootstrapManifests are applied by the EKS-A controller to the cluster as soon as its CNI is ready, like
+	// namespaces, resource quotas or network policies, and reapplied on every reconciliation.
+	BootstrapManifests []BootstrapManifest `json:"bootstrapManifests,omitempty"`
+	// BottlerocketUpdates deploys the Bottlerocket update operator to the cluster to patch the OS of its
+	// Bottlerocket worker nodes in place, without replacing the machines. Only supported for vSphere and
+	// bare metal. EKS-A installs it from the bundle after creating the cluster and upgrades it with the cluster.
+	BottlerocketUpdates *BottlerocketUpdatesConfiguration `json:"bottlerocketUpdates,omitempty"`
+	// ClusterProfile selects a preset for the components EKS-A deploys to the cluster. The edge profile
+	// reduces their footprint for nodes with 2 to 4 GB of memory. It is immutable.
+	ClusterProfile ClusterProfile `json:"clusterProfile,omitempty"`
+	// EtcdEncryption configures the kube-apiserver to encrypt resources at rest in etcd with KMS plugins.
+	// The plugins must run in the control plane nodes, for example as static pods, and listen on the
+	// configured sockets. It can't be removed once set, since the encrypted resources couldn't be read.
+	EtcdEncryption []EtcdEncryption `json:"etcdEncryption,omitempty"`
 }
 
 func (n *Cluster) Equal(o *Cluster) bool {
@@ -96,6 +139,45 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if !n.Spec.BundlesRef.Equal(o.Spec.BundlesRef) {
 		return false
 	}
+	if !EksdReleasePinsSliceEqual(n.Spec.EksdReleasePins, o.Spec.EksdReleasePins) {
+		return false
+	}
+	if !HelmValuesOverridesSliceEqual(n.Spec.HelmValuesOverrides, o.Spec.HelmValuesOverrides) {
+		return false
+	}
+	if !ManagedComponentsSliceEqual(n.Spec.ManagedComponents, o.Spec.ManagedComponents) {
+		return false
+	}
+	if n.CertManagerMode() != o.CertManagerMode() {
+		return false
+	}
+	if n.KubeletServingCertificateRotationEnabled() != o.KubeletServingCertificateRotationEnabled() {
+		return false
+	}
+	if !ImageCredentialProvidersEqual(n.ImageCredentialProviders(), o.ImageCredentialProviders()) {
+		return false
+	}
+	if !n.Spec.CoreDNSConfiguration.Equal(o.Spec.CoreDNSConfiguration) {
+		return false
+	}
+	if !LabelsMapEqual(n.Spec.Tags, o.Spec.Tags) {
+		return false
+	}
+	if !n.Spec.Backup.Equal(o.Spec.Backup) {
+		return false
+	}
+	if !BootstrapManifestsSliceEqual(n.Spec.BootstrapManifests, o.Spec.BootstrapManifests) {
+		return false
+	}
+	if !n.Spec.BottlerocketUpdates.Equal(o.Spec.BottlerocketUpdates) {
+		return false
+	}
+	if n.Spec.ClusterProfile != o.Spec.ClusterProfile {
+		return false
+	}
+	if !EtcdEncryptionSliceEqual(n.Spec.EtcdEncryption, o.Spec.EtcdEncryption) {
+		return false
+	}
 
 	return true
 }
@@ -146,6 +228,10 @@ type RegistryMirrorConfiguration struct {
 	// Only use this solution for isolated testing or in a tightly controlled, air-gapped environment.
 	// Currently only supported for snow provider
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// DisconnectedPackages makes the curated packages controller resolve package bundles, helm charts and images
+	// only from the registry mirror, without calling ECR. The package bundles activated are the ones imported to the mirror.
+	DisconnectedPackages bool `json:"disconnectedPackages,omitempty"`
 }
 
 func (n *RegistryMirrorConfiguration) Equal(o *RegistryMirrorConfiguration) bool {
@@ -155,7 +241,8 @@ func (n *RegistryMirrorConfiguration) Equal(o *RegistryMirrorConfiguration) bool
 	if n == nil || o == nil {
 		return false
 	}
-	return n.Endpoint == o.Endpoint && n.Port == o.Port && n.CACertContent == o.CACertContent && n.InsecureSkipVerify == o.InsecureSkipVerify && n.Authenticate == o.Authenticate
+	return n.Endpoint == o.Endpoint && n.Port == o.Port && n.CACertContent == o.CACertContent && n.InsecureSkipVerify == o.InsecureSkipVerify && n.Authenticate == o.Authenticate &&
+		n.DisconnectedPackages == o.DisconnectedPackages
 }
 
 type ControlPlaneConfiguration struct {
@@ -167,11 +254,70 @@ type ControlPlaneConfiguration struct {
 	MachineGroupRef *Ref `json:"machineGroupRef,omitempty"`
 	// Taints define the set of taints to be applied on control plane nodes
 	Taints []corev1.Taint `json:"taints,omitempty"`
+	// Schedulable removes the default NoSchedule taint from the control plane nodes so they can run
+	// workloads, for example in small edge clusters.
+	Schedulable bool `json:"schedulable,omitempty"`
 	// Labels define the labels to assign to the node
 	Labels map[string]string `json:"labels,omitempty"`
 	// UpgradeRolloutStrategy determines the rollout strategy to use for rolling upgrades
 	// and related parameters/knobs
 	UpgradeRolloutStrategy *ControlPlaneUpgradeRolloutStrategy `json:"upgradeRolloutStrategy,omitempty"`
+	// CertSANs defines additional DNS names or IPs to include in the API server certificate,
+	// for example corporate aliases or NAT addresses.
+	CertSANs []string `json:"certSANs,omitempty"`
+	// StaticPodManifests defines additional static pods to run in the control plane nodes,
+	// for example an audit log shipper or a keepalived sidecar.
+	StaticPodManifests []StaticPodManifest `json:"staticPodManifests,omitempty"`
+	// ContainerdConfiguration defines overrides for the containerd configuration of the control plane nodes.
+	ContainerdConfiguration *ContainerdConfiguration `json:"containerdConfiguration,omitempty"`
+}
+
+// ContainerdConfiguration defines overrides for the containerd configuration of a group of nodes.
+type ContainerdConfiguration struct {
+	// SandboxImage overrides the sandbox (pause) image used by containerd,
+	// for example with a copy in a private registry for air-gapped environments.
+	SandboxImage string `json:"sandboxImage,omitempty"`
+	// RegistryHeaders defines additional HTTP headers containerd sends in the requests to
+	// image registries, for example for header based registry authentication.
+	RegistryHeaders map[string]string `json:"registryHeaders,omitempty"`
+	// EnableNRI enables the containerd Node Resource Interface plugin.
+	EnableNRI bool `json:"enableNRI,omitempty"`
+}
+
+// Equal returns true if both containerd configurations are equal.
+func (n *ContainerdConfiguration) Equal(o *ContainerdConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.SandboxImage == o.SandboxImage && LabelsMapEqual(n.RegistryHeaders, o.RegistryHeaders) && n.EnableNRI == o.EnableNRI
+}
+
+// StaticPodManifest is a Pod manifest written to the kubelet static pod directory of the control plane nodes.
+type StaticPodManifest struct {
+	// Name of the manifest file, without extension. It must be unique across the control plane static pods.
+	Name string `json:"name"`
+	// Content is the yaml Pod manifest.
+	Content string `json:"content"`
+}
+
+// StaticPodManifestsEqual returns true if both slices contain the same manifests, in any order.
+func StaticPodManifestsEqual(s1, s2 []StaticPodManifest) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	manifests := make(map[StaticPodManifest]struct{}, len(s1))
+	for _, m := range s1 {
+		manifests[m] = struct{}{}
+	}
+	for _, m := range s2 {
+		if _, ok := manifests[m]; !ok {
+			return false
+		}
+	}
+	return true
 }
 
 func TaintsSliceEqual(s1, s2 []corev1.Taint) bool {
@@ -215,22 +361,126 @@ func (n *ControlPlaneConfiguration) Equal(o *ControlPlaneConfiguration) bool {
 		return false
 	}
 	return n.Count == o.Count && n.Endpoint.Equal(o.Endpoint) && n.MachineGroupRef.Equal(o.MachineGroupRef) &&
-		TaintsSliceEqual(n.Taints, o.Taints) && LabelsMapEqual(n.Labels, o.Labels)
+		TaintsSliceEqual(n.Taints, o.Taints) && n.Schedulable == o.Schedulable && LabelsMapEqual(n.Labels, o.Labels) && SliceEqual(n.CertSANs, o.CertSANs) &&
+		StaticPodManifestsEqual(n.StaticPodManifests, o.StaticPodManifests) && n.ContainerdConfiguration.Equal(o.ContainerdConfiguration)
+}
+
+// NodeTaints returns the taints of the control plane nodes for the kubeadm node registration. Nil keeps
+// the default NoSchedule taint kubeadm adds to control plane nodes, while an empty slice removes it.
+func (n *ControlPlaneConfiguration) NodeTaints() []corev1.Taint {
+	if n.Schedulable && len(n.Taints) == 0 {
+		return []corev1.Taint{}
+	}
+	return n.Taints
 }
 
 type Endpoint struct {
-	// Host defines the ip that you want to use to connect to the control plane
+	// Host defines the ip or the DNS name that you want to use to connect to the control plane.
+	// A DNS name must resolve to the kube-vip address or, with an external load balancer, to the load balancer.
 	Host string `json:"host"`
+	// ExternalLoadBalancer declares the endpoint is served by a load balancer managed outside
+	// of EKS Anywhere. When set, kube-vip is not deployed in the control plane nodes.
+	ExternalLoadBalancer bool `json:"externalLoadBalancer,omitempty"`
+	// KubeVip tunes the kube-vip instances serving the endpoint in the control plane nodes.
+	// Not supported with an external load balancer.
+	KubeVip *KubeVipConfiguration `json:"kubeVip,omitempty"`
+}
+
+const (
+	defaultKubeVipLeaseDurationSeconds = 15
+	defaultKubeVipRenewDeadlineSeconds = 10
+	defaultKubeVipRetryPeriodSeconds   = 2
+)
+
+// KubeVipConfiguration tunes the kube-vip leader election, which decides how fast the endpoint fails
+// over to another control plane node. Shorter durations fail over faster, longer ones avoid flapping
+// when the API servers of large clusters are slow to answer.
+type KubeVipConfiguration struct {
+	// LeaseDurationSeconds is how long the other kube-vip instances wait before taking over the
+	// endpoint from a leader that stopped renewing its lease. Defaults to 15.
+	LeaseDurationSeconds int `json:"leaseDurationSeconds,omitempty"`
+	// RenewDeadlineSeconds is how long the leader keeps retrying to renew its lease before giving
+	// up the endpoint. Defaults to 10. It must be lower than leaseDurationSeconds.
+	RenewDeadlineSeconds int `json:"renewDeadlineSeconds,omitempty"`
+	// RetryPeriodSeconds is the interval between the lease acquisition and renewal attempts.
+	// Defaults to 2. It must be lower than renewDeadlineSeconds.
+	RetryPeriodSeconds int `json:"retryPeriodSeconds,omitempty"`
+	// PrometheusPort is the port kube-vip serves its Prometheus metrics on in the control plane nodes.
+	PrometheusPort int `json:"prometheusPort,omitempty"`
+}
+
+// Equal returns true if both kube-vip configurations render the same kube-vip settings.
+// Nil configurations are equal to the defaults.
+func (c *KubeVipConfiguration) Equal(o *KubeVipConfiguration) bool {
+	return c.LeaseDuration() == o.LeaseDuration() && c.RenewDeadline() == o.RenewDeadline() &&
+		c.RetryPeriod() == o.RetryPeriod() && c.prometheusPort() == o.prometheusPort()
+}
+
+func (c *KubeVipConfiguration) prometheusPort() int {
+	if c == nil {
+		return 0
+	}
+	return c.PrometheusPort
+}
+
+// LeaseDuration returns the lease duration in seconds, the default if not set.
+func (c *KubeVipConfiguration) LeaseDuration() int {
+	if c == nil || c.LeaseDurationSeconds == 0 {
+		return defaultKubeVipLeaseDurationSeconds
+	}
+	return c.LeaseDurationSeconds
 }
 
+// RenewDeadline returns the renew deadline in seconds, the default if not set.
+func (c *KubeVipConfiguration) RenewDeadline() int {
+	if c == nil || c.RenewDeadlineSeconds == 0 {
+		return defaultKubeVipRenewDeadlineSeconds
+	}
+	return c.RenewDeadlineSeconds
+}
+
+// RetryPeriod returns the retry period in seconds, the default if not set.
+func (c *KubeVipConfiguration) RetryPeriod() int {
+	if c == nil || c.RetryPeriodSeconds == 0 {
+		return defaultKubeVipRetryPeriodSeconds
+	}
+	return c.RetryPeriodSeconds
+}
+
+// UsesExternalLoadBalancer returns true if the endpoint is served by a load balancer not managed
+// by EKS Anywhere instead of kube-vip.
+func (n *Endpoint) UsesExternalLoadBalancer() bool {
+	return n.ExternalLoadBalancer
+}
+
+// IsHostname returns true if the endpoint host is a DNS name instead of an IP address.
+func (n *Endpoint) IsHostname() bool {
+	return net.ParseIP(n.Hostname()) == nil
+}
+
+// Hostname returns the endpoint host without the port, if it has one.
+func (n *Endpoint) Hostname() string {
+	if host, _, err := net.SplitHostPort(n.Host); err == nil {
+		return host
+	}
+	return n.Host
+}
+
+// Equal returns true if both endpoints are the same, including their kube-vip configuration.
 func (n *Endpoint) Equal(o *Endpoint) bool {
+	return n.AddressEqual(o) && (n == nil || n.KubeVip.Equal(o.KubeVip))
+}
+
+// AddressEqual returns true if both endpoints serve the same host the same way. It ignores the
+// kube-vip configuration, which can be changed after the cluster is created.
+func (n *Endpoint) AddressEqual(o *Endpoint) bool {
 	if n == o {
 		return true
 	}
 	if n == nil || o == nil {
 		return false
 	}
-	return n.Host == o.Host
+	return n.Host == o.Host && n.ExternalLoadBalancer == o.ExternalLoadBalancer
 }
 
 type WorkerNodeGroupConfiguration struct {
@@ -249,6 +499,8 @@ type WorkerNodeGroupConfiguration struct {
 	// UpgradeRolloutStrategy determines the rollout strategy to use for rolling upgrades
 	// and related parameters/knobs
 	UpgradeRolloutStrategy *WorkerNodesUpgradeRolloutStrategy `json:"upgradeRolloutStrategy,omitempty"`
+	// ContainerdConfiguration defines overrides for the containerd configuration of the worker nodes.
+	ContainerdConfiguration *ContainerdConfiguration `json:"containerdConfiguration,omitempty"`
 }
 
 func generateWorkerNodeGroupKey(c WorkerNodeGroupConfiguration) (key string) {
@@ -623,6 +875,108 @@ type ClusterStatus struct {
 	EksdReleaseRef *EksdReleaseRef `json:"eksdReleaseRef,omitempty"`
 	// +optional
 	Conditions []clusterv1.Condition `json:"conditions,omitempty"`
+	// Certificates reports the expiry of the control plane and etcd certificates of the cluster
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+	// Notifications is the state of the cluster last reported in notifications
+	// +optional
+	Notifications *NotificationStatus `json:"notifications,omitempty"`
+	// LastAppliedChanges summarizes what the controller did to reconcile the latest generation of the spec
+	// +optional
+	LastAppliedChanges *AppliedChanges `json:"lastAppliedChanges,omitempty"`
+	// MachineFailures reports the machines of the cluster that failed to bootstrap
+	// +optional
+	MachineFailures []MachineFailureStatus `json:"machineFailures,omitempty"`
+	// EtcdEncryptionRewrites reports when the objects of the encrypted resources with a key rotation period
+	// were last rewritten
+	// +optional
+	EtcdEncryptionRewrites []EtcdEncryptionRewriteStatus `json:"etcdEncryptionRewrites,omitempty"`
+}
+
+const (
+	// NodeClocksSynchronizedCondition reports whether the clocks of the nodes of the cluster are within the
+	// tolerated skew of the reference time. Clock skew breaks etcd and TLS.
+	NodeClocksSynchronizedCondition clusterv1.ConditionType = "NodeClocksSynchronized"
+
+	// NodeClocksSkewedReason is used when the clock of a node is skewed beyond the tolerated skew or can't be read.
+	NodeClocksSkewedReason = "NodeClocksSkewed"
+	// NodeClocksCheckFailedReason is used when the clocks of the nodes can't be checked.
+	NodeClocksCheckFailedReason = "NodeClocksCheckFailed"
+)
+
+// MachineFailureStatus is the diagnostic of a machine of the cluster that failed to bootstrap.
+type MachineFailureStatus struct {
+	// Machine is the name of the CAPI Machine
+	Machine string `json:"machine"`
+	// Reason is a short, machine understandable reason for the failure, like BootstrapFailed or NodeJoinTimeout
+	Reason string `json:"reason"`
+	// Message describes the failure, truncated
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Log is an excerpt of the bootstrap logs of the machine reported by the provider, truncated to the last lines
+	// +optional
+	Log string `json:"log,omitempty"`
+	// Since is the time the failure was first observed
+	Since metav1.Time `json:"since"`
+}
+
+// AppliedChanges is a summary of the changes the controller applied to reconcile a generation of the cluster spec.
+type AppliedChanges struct {
+	// Generation is the generation of the cluster spec the changes were applied for
+	Generation int64 `json:"generation"`
+	// Time is the last time the controller applied changes for this generation
+	Time metav1.Time `json:"time"`
+	// ChangedFields lists the fields of the spec that changed from the previous generation
+	// +optional
+	ChangedFields []string `json:"changedFields,omitempty"`
+	// Created lists the objects the controller created, as "<kind> <namespace>/<name>"
+	// +optional
+	Created []string `json:"created,omitempty"`
+	// Updated lists the objects the controller updated, as "<kind> <namespace>/<name>"
+	// +optional
+	Updated []string `json:"updated,omitempty"`
+	// Deleted lists the objects the controller deleted, as "<kind> <namespace>/<name>"
+	// +optional
+	Deleted []string `json:"deleted,omitempty"`
+}
+
+// EtcdEncryptionRewriteStatus is the last rewrite of the objects of an encrypted resource.
+type EtcdEncryptionRewriteStatus struct {
+	// Resource is the encrypted resource, like secrets
+	Resource string `json:"resource"`
+	// Time is the last time all the objects of the resource were rewritten
+	Time metav1.Time `json:"time"`
+}
+
+// CertificateStatus is the observed expiry of a certificate of the cluster.
+type CertificateStatus struct {
+	// Component is the part of the cluster the certificate belongs to, control-plane or etcd
+	Component string `json:"component"`
+	// Name identifies the certificate, for example kube-apiserver
+	Name string `json:"name"`
+	// Machine is the machine presenting the certificate, empty for certificates not tied to a machine
+	// +optional
+	Machine string `json:"machine,omitempty"`
+	// ExpiresAt is the expiry date of the certificate
+	ExpiresAt metav1.Time `json:"expiresAt"`
+	// DaysToExpiry is the number of full days left before the certificate expires
+	DaysToExpiry int `json:"daysToExpiry"`
+}
+
+// NotificationStatus is the state of the cluster last reported in notifications.
+type NotificationStatus struct {
+	// Upgrading is true if the cluster was reported as rolling out changes
+	// +optional
+	Upgrading bool `json:"upgrading,omitempty"`
+	// FailureMessage is the last failure reported
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+	// UnhealthyMachines are the machines reported as failing their health check
+	// +optional
+	UnhealthyMachines []string `json:"unhealthyMachines,omitempty"`
+	// CertificateExpiringMachines are the machines reported as having certificates about to expire
+	// +optional
+	CertificateExpiringMachines []string `json:"certificateExpiringMachines,omitempty"`
 }
 
 type EksdReleaseRef struct {
@@ -653,6 +1007,142 @@ func (b *BundlesRef) Equal(o *BundlesRef) bool {
 	return b.APIVersion == o.APIVersion && b.Name == o.Name && b.Namespace == o.Namespace
 }
 
+// EksdReleasePin pins the EKS Distro release number used for a Kubernetes version.
+type EksdReleasePin struct {
+	// KubernetesVersion is the Kubernetes version the pin applies to.
+	KubernetesVersion KubernetesVersion `json:"kubernetesVersion"`
+	// Number is the EKS Distro release number for the Kubernetes version.
+	Number int `json:"number"`
+}
+
+func EksdReleasePinsSliceEqual(a, b []EksdReleasePin) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	m := make(map[KubernetesVersion]int, len(a))
+	for _, pin := range a {
+		m[pin.KubernetesVersion] = pin.Number
+	}
+
+	for _, pin := range b {
+		if number, ok := m[pin.KubernetesVersion]; !ok || number != pin.Number {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EksdReleasePin returns the pinned EKS Distro release number for the Kubernetes version of the
+// cluster, if any.
+func (c *Cluster) EksdReleasePin() (number int, ok bool) {
+	for _, pin := range c.Spec.EksdReleasePins {
+		if pin.KubernetesVersion == c.Spec.KubernetesVersion {
+			return pin.Number, true
+		}
+	}
+	return 0, false
+}
+
+// ClusterProfile is a preset for the components EKS-A deploys to the cluster.
+type ClusterProfile string
+
+// EdgeClusterProfile deploys minimized components: curated packages are not installed, Cilium runs
+// without metrics and a single operator replica and the controllers request fewer resources.
+const EdgeClusterProfile ClusterProfile = "edge"
+
+// ManagedChart is a Helm chart installed and managed by EKS-A.
+type ManagedChart string
+
+const (
+	CiliumChart   ManagedChart = "cilium"
+	PackagesChart ManagedChart = "eks-anywhere-packages"
+)
+
+// HelmValuesOverride overrides Helm values for a chart managed by EKS-A.
+type HelmValuesOverride struct {
+	// Chart is the managed chart the values apply to. Supported values are cilium and eks-anywhere-packages.
+	Chart ManagedChart `json:"chart"`
+	// Values maps dotted Helm value paths, e.g. operator.resources.limits.cpu, to YAML encoded values.
+	Values map[string]string `json:"values,omitempty"`
+}
+
+func HelmValuesOverridesSliceEqual(a, b []HelmValuesOverride) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	m := make(map[ManagedChart]map[string]string, len(a))
+	for _, override := range a {
+		m[override.Chart] = override.Values
+	}
+
+	for _, override := range b {
+		values, ok := m[override.Chart]
+		if !ok || !LabelsMapEqual(values, override.Values) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ManagedComponent is a controller or DaemonSet deployed by EKS-A to the cluster.
+type ManagedComponent string
+
+const (
+	CiliumAgentComponent       ManagedComponent = "cilium-agent"
+	CiliumOperatorComponent    ManagedComponent = "cilium-operator"
+	PackageControllerComponent ManagedComponent = "package-controller"
+)
+
+// ManagedComponentConfiguration configures the resources and scheduling of a managed component.
+type ManagedComponentConfiguration struct {
+	// Name of the component. Supported values are cilium-agent, cilium-operator and package-controller.
+	Name ManagedComponent `json:"name"`
+	// Resources sets the compute resource requests and limits for the component containers.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// NodeSelector constrains the nodes the component pods can be scheduled on. It is merged with the
+	// kubernetes.io/os: linux selector, so the pods are never scheduled on Windows nodes.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations allows the component pods to be scheduled on nodes with matching taints.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+func ManagedComponentsSliceEqual(a, b []ManagedComponentConfiguration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	m := make(map[ManagedComponent]ManagedComponentConfiguration, len(a))
+	for _, component := range a {
+		m[component.Name] = component
+	}
+
+	for _, component := range b {
+		c, ok := m[component.Name]
+		if !ok || !c.Equal(&component) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (n *ManagedComponentConfiguration) Equal(o *ManagedComponentConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Name == o.Name &&
+		equality.Semantic.DeepEqual(n.Resources, o.Resources) &&
+		LabelsMapEqual(n.NodeSelector, o.NodeSelector) &&
+		equality.Semantic.DeepEqual(n.Tolerations, o.Tolerations)
+}
+
 type Ref struct {
 	Kind string `json:"kind,omitempty"`
 	Name string `json:"name,omitempty"`
@@ -732,6 +1222,15 @@ type AutoScalingConfiguration struct {
 	MaxCount int `json:"maxCount,omitempty"`
 }
 
+const (
+	// RollingUpdateStrategyType replaces the machines of the nodes with new ones during upgrades.
+	RollingUpdateStrategyType = "RollingUpdate"
+	// InPlaceStrategyType upgrades the Kubernetes components of the existing machines for
+	// Kubernetes patch version upgrades. Any other upgrade falls back to a rolling update
+	// with the rollingUpdate parameters.
+	InPlaceStrategyType = "InPlace"
+)
+
 // ControlPlaneUpgradeRolloutStrategy indicates rollout strategy for cluster.
 type ControlPlaneUpgradeRolloutStrategy struct {
 	Type          string                          `json:"type,omitempty"`
@@ -807,10 +1306,27 @@ func (c *Cluster) EtcdAnnotation() string {
 	return etcdAnnotation
 }
 
+// CredentialsProfile returns the profile of the credentials file set in the cluster annotations,
+// empty if not set.
+func (c *Cluster) CredentialsProfile() string {
+	return c.Annotations[credentialsProfileAnnotation]
+}
+
 func (c *Cluster) IsSelfManaged() bool {
 	return c.Spec.ManagementCluster.Name == "" || c.Spec.ManagementCluster.Name == c.Name
 }
 
+// IsSingleNode returns true if the cluster runs on a single machine, with one control plane node
+// that also runs the workloads and no worker nodes.
+func (c *Cluster) IsSingleNode() bool {
+	return c.Spec.ControlPlaneConfiguration.Count == 1 && len(c.Spec.WorkerNodeGroupConfigurations) == 0
+}
+
+// IsEdgeProfile returns true if the cluster uses the reduced footprint edge profile.
+func (c *Cluster) IsEdgeProfile() bool {
+	return c.Spec.ClusterProfile == EdgeClusterProfile
+}
+
 func (c *Cluster) SetManagedBy(managementClusterName string) {
 	if c.Annotations == nil {
 		c.Annotations = map[string]string{}
@@ -913,3 +1429,347 @@ type ClusterList struct {
 func init() {
 	SchemeBuilder.Register(&Cluster{}, &ClusterList{})
 }
+
+// CertManagerMode defines who manages the lifecycle of cert-manager in the cluster.
+type CertManagerMode string
+
+const (
+	// CertManagerManaged is the default mode, EKS-A installs and upgrades cert-manager.
+	CertManagerManaged CertManagerMode = "managed"
+	// CertManagerExternal uses a cert-manager installed and upgraded by the user. EKS-A
+	// installs cert-manager during cluster creation but doesn't upgrade it afterwards.
+	CertManagerExternal CertManagerMode = "external"
+)
+
+// CertManagerConfiguration configures how cert-manager is managed in the cluster.
+type CertManagerConfiguration struct {
+	// Mode is either managed or external. Defaults to managed.
+	// +kubebuilder:validation:Enum=managed;external
+	Mode CertManagerMode `json:"mode,omitempty"`
+}
+
+// CertManagerMode returns the configured cert-manager mode, CertManagerManaged if not set.
+func (c *Cluster) CertManagerMode() CertManagerMode {
+	if c.Spec.CertManager == nil || c.Spec.CertManager.Mode == "" {
+		return CertManagerManaged
+	}
+	return c.Spec.CertManager.Mode
+}
+
+// KubeletConfiguration configures the kubelet on all the nodes of the cluster.
+type KubeletConfiguration struct {
+	// ServingCertificateRotation makes the kubelets request their serving certificate from the
+	// cluster CA and rotate it before expiry. EKS-A approves the certificate signing requests
+	// that match the node they come from. This field is immutable.
+	ServingCertificateRotation bool `json:"servingCertificateRotation,omitempty"`
+	// ImageCredentialProviders configures the kubelet to get the credentials to pull images from
+	// exec plugins, like ecr-credential-provider, instead of imagePullSecrets. The plugin executables
+	// must be present in the node images. This field is immutable.
+	ImageCredentialProviders []ImageCredentialProvider `json:"imageCredentialProviders,omitempty"`
+}
+
+// ImageCredentialProvider configures a kubelet image credential provider plugin.
+type ImageCredentialProvider struct {
+	// Name of the provider. It must match the name of the plugin executable.
+	Name string `json:"name"`
+	// MatchImages are the patterns of the images the provider is called for, like "*.dkr.ecr.*.amazonaws.com".
+	MatchImages []string `json:"matchImages"`
+	// DefaultCacheDuration is how long the kubelet caches the credentials when the plugin
+	// doesn't set a duration in its response. Defaults to 12h.
+	DefaultCacheDuration string `json:"defaultCacheDuration,omitempty"`
+	// Args are passed to the plugin executable.
+	Args []string `json:"args,omitempty"`
+	// Env are environment variables set for the plugin executable.
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// KubeletServingCertificateRotationEnabled returns true if the kubelets request their serving
+// certificate from the cluster CA.
+func (c *Cluster) KubeletServingCertificateRotationEnabled() bool {
+	return c.Spec.KubeletConfiguration != nil && c.Spec.KubeletConfiguration.ServingCertificateRotation
+}
+
+// ImageCredentialProviders returns the kubelet image credential providers of the cluster.
+func (c *Cluster) ImageCredentialProviders() []ImageCredentialProvider {
+	if c.Spec.KubeletConfiguration == nil {
+		return nil
+	}
+	return c.Spec.KubeletConfiguration.ImageCredentialProviders
+}
+
+// ImageCredentialProvidersEqual returns true if both lists contain the same providers in the same order.
+// Nil and empty lists are equal.
+func ImageCredentialProvidersEqual(a, b []ImageCredentialProvider) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p ImageCredentialProvider) equal(o ImageCredentialProvider) bool {
+	return p.Name == o.Name && p.DefaultCacheDuration == o.DefaultCacheDuration &&
+		SliceEqual(p.MatchImages, o.MatchImages) && equality.Semantic.DeepEqual(p.Args, o.Args) &&
+		LabelsMapEqual(p.Env, o.Env)
+}
+
+// CoreDNSConfiguration customizes the CoreDNS Corefile of the cluster.
+type CoreDNSConfiguration struct {
+	// UpstreamServers are the servers CoreDNS forwards the queries outside of the cluster domain to,
+	// instead of the ones in the node /etc/resolv.conf.
+	UpstreamServers []string `json:"upstreamServers,omitempty"`
+	// StubDomains forward the queries for a domain to specific servers.
+	StubDomains []CoreDNSStubDomain `json:"stubDomains,omitempty"`
+	// CustomServerBlocks are Corefile server blocks appended as is to the Corefile.
+	CustomServerBlocks string `json:"customServerBlocks,omitempty"`
+}
+
+// CoreDNSStubDomain forwards the queries for a domain to specific servers.
+type CoreDNSStubDomain struct {
+	// Domain is the DNS domain, like "corp.example.com".
+	Domain string `json:"domain"`
+	// Servers are the IP addresses, with optional port, of the DNS servers for the domain.
+	Servers []string `json:"servers"`
+}
+
+// Equal returns true if both configurations are the same. Nil and empty configurations are equal.
+func (n *CoreDNSConfiguration) Equal(o *CoreDNSConfiguration) bool {
+	if n == nil || o == nil {
+		return n.IsEmpty() && o.IsEmpty()
+	}
+	if n.CustomServerBlocks != o.CustomServerBlocks || !SliceEqual(n.UpstreamServers, o.UpstreamServers) {
+		return false
+	}
+	if len(n.StubDomains) != len(o.StubDomains) {
+		return false
+	}
+	for i := range n.StubDomains {
+		if n.StubDomains[i].Domain != o.StubDomains[i].Domain || !SliceEqual(n.StubDomains[i].Servers, o.StubDomains[i].Servers) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEmpty returns true if the configuration doesn't customize the Corefile.
+func (n *CoreDNSConfiguration) IsEmpty() bool {
+	return n == nil || (len(n.UpstreamServers) == 0 && len(n.StubDomains) == 0 && n.CustomServerBlocks == "")
+}
+
+// BackupConfiguration configures the Velero backup component of the cluster.
+type BackupConfiguration struct {
+	// StorageLocation is the bucket the backups are stored in.
+	StorageLocation BackupStorageLocation `json:"storageLocation"`
+	// Schedules back up the cluster periodically.
+	Schedules []BackupSchedule `json:"schedules,omitempty"`
+	// FileSystemBackup deploys the Velero node agent to back up the content of the pod volumes.
+	FileSystemBackup bool `json:"fileSystemBackup,omitempty"`
+}
+
+// BackupStorageLocation is an S3 compatible bucket.
+type BackupStorageLocation struct {
+	Bucket string `json:"bucket"`
+	// Prefix is the path in the bucket the backups are stored under.
+	Prefix string `json:"prefix,omitempty"`
+	Region string `json:"region"`
+	// S3URL is the endpoint of an S3 compatible storage, like MinIO. Defaults to the AWS S3
+	// endpoint of the region.
+	S3URL string `json:"s3Url,omitempty"`
+	// CredentialsSecretName is the name of the Secret in the velero namespace of the cluster with
+	// the credentials for the bucket, in the AWS credentials file format under the "cloud" key.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
+// BackupSchedule backs up the cluster periodically.
+type BackupSchedule struct {
+	Name string `json:"name"`
+	// Schedule is a cron expression, like "0 2 * * *", or a descriptor, like "@daily".
+	Schedule string `json:"schedule"`
+	// IncludedNamespaces are the namespaces backed up. All of them when empty.
+	IncludedNamespaces []string `json:"includedNamespaces,omitempty"`
+	// ExcludedNamespaces are the namespaces not backed up.
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+	// TTL is how long the backups are kept, like "720h". Defaults to 30 days.
+	TTL string `json:"ttl,omitempty"`
+}
+
+// Equal returns true if both backup configurations are the same.
+func (n *BackupConfiguration) Equal(o *BackupConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	if n.StorageLocation != o.StorageLocation || n.FileSystemBackup != o.FileSystemBackup {
+		return false
+	}
+	if len(n.Schedules) != len(o.Schedules) {
+		return false
+	}
+	for i := range n.Schedules {
+		if !n.Schedules[i].Equal(&o.Schedules[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if both backup schedules are the same.
+func (n *BackupSchedule) Equal(o *BackupSchedule) bool {
+	return n.Name == o.Name && n.Schedule == o.Schedule && n.TTL == o.TTL &&
+		SliceEqual(n.IncludedNamespaces, o.IncludedNamespaces) &&
+		SliceEqual(n.ExcludedNamespaces, o.ExcludedNamespaces)
+}
+
+// BootstrapManifest is a manifest applied to the cluster once its CNI is ready. Only one of inline,
+// oci and git can be set.
+type BootstrapManifest struct {
+	Name string `json:"name"`
+	// Inline is the yaml content of the manifest.
+	Inline string `json:"inline,omitempty"`
+	// OCI is the reference, oci://registry/repository:tag, of an OCI artifact with the manifest.
+	OCI string `json:"oci,omitempty"`
+	// Git is a file with the manifest in a Git repository.
+	Git *BootstrapManifestGitRef `json:"git,omitempty"`
+}
+
+// BootstrapManifestGitRef is a file in a Git repository.
+type BootstrapManifestGitRef struct {
+	// Repository is the https URL of the repository.
+	Repository string `json:"repository"`
+	// Ref is the branch or tag the file is read from. Defaults to the default branch of the repository.
+	Ref string `json:"ref,omitempty"`
+	// Path of the file in the repository.
+	Path string `json:"path"`
+}
+
+// Equal returns true if both bootstrap manifests are the same.
+func (n *BootstrapManifest) Equal(o *BootstrapManifest) bool {
+	if n.Name != o.Name || n.Inline != o.Inline || n.OCI != o.OCI {
+		return false
+	}
+	if n.Git == nil || o.Git == nil {
+		return n.Git == o.Git
+	}
+	return *n.Git == *o.Git
+}
+
+// BootstrapManifestsSliceEqual returns true if both lists have the same bootstrap manifests in the same order.
+func BootstrapManifestsSliceEqual(a, b []BootstrapManifest) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(&b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// BottlerocketUpdatesConfiguration configures how the Bottlerocket update operator rolls out the OS
+// updates to the nodes.
+type BottlerocketUpdatesConfiguration struct {
+	// MaxConcurrentUpdates is the number of nodes updated at the same time in each wave. Defaults to 1.
+	MaxConcurrentUpdates int `json:"maxConcurrentUpdates,omitempty"`
+	// Schedule is a cron expression with seconds and an optional year, like "0 0 23 * * Sat *", for when
+	// the updates are allowed to start. Defaults to any time.
+	Schedule string `json:"schedule,omitempty"`
+}
+
+// Equal returns true if both Bottlerocket updates configurations are the same.
+func (n *BottlerocketUpdatesConfiguration) Equal(o *BottlerocketUpdatesConfiguration) bool {
+	if n == nil || o == nil {
+		return n == o
+	}
+	return *n == *o
+}
+
+// EtcdEncryption encrypts a set of resources with a list of KMS providers.
+type EtcdEncryption struct {
+	// Providers encrypt the resources. The first one encrypts the new writes and all of them are
+	// used to decrypt, so a KMS key can be rotated by adding a provider for the new key first.
+	Providers []EtcdEncryptionProvider `json:"providers"`
+	// Resources to encrypt, like secrets or configmaps. Defaults to secrets.
+	Resources []string `json:"resources,omitempty"`
+	// KeyRotationPeriod is how often the controller rewrites the objects of the resources, so they are
+	// encrypted again with the current key of the first provider. It makes the rotation of the KMS key,
+	// or the addition of a provider for a new key, effective for the objects already stored.
+	// Unset disables the rewrites.
+	// +optional
+	KeyRotationPeriod *metav1.Duration `json:"keyRotationPeriod,omitempty"`
+}
+
+// EncryptedResources returns the resources encrypted with the providers, secrets if none is set.
+func (e *EtcdEncryption) EncryptedResources() []string {
+	if len(e.Resources) == 0 {
+		return []string{"secrets"}
+	}
+	return e.Resources
+}
+
+// EtcdEncryptionProvider is an encryption provider for the resources stored in etcd.
+type EtcdEncryptionProvider struct {
+	// KMS configures a KMS v1 plugin as the provider.
+	KMS *KMS `json:"kms"`
+}
+
+// KMS configures the kube-apiserver connection to a KMS v1 plugin.
+type KMS struct {
+	// Name of the KMS plugin. It must be unique across the etcd encryption providers of the cluster.
+	Name string `json:"name"`
+	// SocketListenAddress is the unix socket the KMS plugin listens on, like
+	// unix:///var/run/kmsplugin/socket.sock.
+	SocketListenAddress string `json:"socketListenAddress"`
+	// CacheSize is the number of data encryption keys cached in memory by the kube-apiserver.
+	// Defaults to 1000 in the kube-apiserver.
+	CacheSize *int32 `json:"cacheSize,omitempty"`
+	// Timeout for the calls to the KMS plugin. Defaults to 3s in the kube-apiserver.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// EtcdEncryptionSliceEqual returns true if both lists have the same etcd encryption configs in the same order.
+func EtcdEncryptionSliceEqual(a, b []EtcdEncryption) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(&b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if both etcd encryption configs have the same resources and providers in the same order.
+func (n *EtcdEncryption) Equal(o *EtcdEncryption) bool {
+	if n == nil || o == nil {
+		return n == o
+	}
+	if !SliceEqual(n.Resources, o.Resources) || len(n.Providers) != len(o.Providers) {
+		return false
+	}
+	if !equality.Semantic.DeepEqual(n.KeyRotationPeriod, o.KeyRotationPeriod) {
+		return false
+	}
+	for i := range n.Providers {
+		if !n.Providers[i].KMS.Equal(o.Providers[i].KMS) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if both KMS configs are the same.
+func (n *KMS) Equal(o *KMS) bool {
+	if n == nil || o == nil {
+		return n == o
+	}
+	return n.Name == o.Name && n.SocketListenAddress == o.SocketListenAddress &&
+		equality.Semantic.DeepEqual(n.CacheSize, o.CacheSize) && equality.Semantic.DeepEqual(n.Timeout, o.Timeout)
+}
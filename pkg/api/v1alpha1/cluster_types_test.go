@@ -145,6 +145,15 @@ func TestClusterSetManagedBy(t *testing.T) {
 	g.Expect(c.ManagedBy()).To(Equal(managementClusterName))
 }
 
+func TestClusterCredentialsProfile(t *testing.T) {
+	g := NewWithT(t)
+	c := &v1alpha1.Cluster{}
+	g.Expect(c.CredentialsProfile()).To(BeEmpty())
+
+	c.Annotations = map[string]string{"anywhere.eks.amazonaws.com/credentials-profile": "prod"}
+	g.Expect(c.CredentialsProfile()).To(Equal("prod"))
+}
+
 func TestClusterSetSelfManaged(t *testing.T) {
 	c := &v1alpha1.Cluster{}
 	c.SetSelfManaged()
@@ -1113,6 +1122,17 @@ func TestClusterEqualRegistryMirrorConfiguration(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			testName: "both exist, diff disconnected packages",
+			cluster1Regi: &v1alpha1.RegistryMirrorConfiguration{
+				Endpoint:             "1.2.3.4",
+				DisconnectedPackages: true,
+			},
+			cluster2Regi: &v1alpha1.RegistryMirrorConfiguration{
+				Endpoint: "1.2.3.4",
+			},
+			want: false,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -1639,3 +1659,127 @@ func setSelfManaged(c *v1alpha1.Cluster, s bool) {
 		c.SetManagedBy("management-cluster")
 	}
 }
+
+func TestClusterEqualEksdReleasePins(t *testing.T) {
+	cluster1 := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			EksdReleasePins: []v1alpha1.EksdReleasePin{
+				{KubernetesVersion: v1alpha1.Kube122, Number: 10},
+				{KubernetesVersion: v1alpha1.Kube123, Number: 4},
+			},
+		},
+	}
+
+	g := NewWithT(t)
+	cluster2 := cluster1.DeepCopy()
+	cluster2.Spec.EksdReleasePins[0], cluster2.Spec.EksdReleasePins[1] = cluster2.Spec.EksdReleasePins[1], cluster2.Spec.EksdReleasePins[0]
+	g.Expect(cluster1.Equal(cluster2)).To(BeTrue())
+
+	cluster2.Spec.EksdReleasePins[0].Number = 3
+	g.Expect(cluster1.Equal(cluster2)).To(BeFalse())
+}
+
+func TestClusterEksdReleasePin(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			KubernetesVersion: v1alpha1.Kube123,
+			EksdReleasePins: []v1alpha1.EksdReleasePin{
+				{KubernetesVersion: v1alpha1.Kube122, Number: 10},
+			},
+		},
+	}
+
+	_, ok := cluster.EksdReleasePin()
+	g.Expect(ok).To(BeFalse())
+
+	cluster.Spec.EksdReleasePins = append(cluster.Spec.EksdReleasePins, v1alpha1.EksdReleasePin{KubernetesVersion: v1alpha1.Kube123, Number: 4})
+	number, ok := cluster.EksdReleasePin()
+	g.Expect(ok).To(BeTrue())
+	g.Expect(number).To(Equal(4))
+}
+
+func TestEndpointIsHostname(t *testing.T) {
+	tests := []struct {
+		name         string
+		host         string
+		wantHostname string
+		wantIsName   bool
+	}{
+		{name: "ipv4", host: "1.2.3.4", wantHostname: "1.2.3.4", wantIsName: false},
+		{name: "ipv4 with port", host: "1.2.3.4:6443", wantHostname: "1.2.3.4", wantIsName: false},
+		{name: "ipv6", host: "fd00::1", wantHostname: "fd00::1", wantIsName: false},
+		{name: "dns name", host: "api.cluster.example.com", wantHostname: "api.cluster.example.com", wantIsName: true},
+		{name: "dns name with port", host: "api.cluster.example.com:6443", wantHostname: "api.cluster.example.com", wantIsName: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			e := &v1alpha1.Endpoint{Host: tt.host}
+			g.Expect(e.Hostname()).To(Equal(tt.wantHostname))
+			g.Expect(e.IsHostname()).To(Equal(tt.wantIsName))
+		})
+	}
+}
+
+func TestEndpointUsesExternalLoadBalancer(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint *v1alpha1.Endpoint
+		want     bool
+	}{
+		{name: "ip", endpoint: &v1alpha1.Endpoint{Host: "1.2.3.4"}, want: false},
+		{name: "ip with external load balancer", endpoint: &v1alpha1.Endpoint{Host: "1.2.3.4", ExternalLoadBalancer: true}, want: true},
+		{name: "dns name", endpoint: &v1alpha1.Endpoint{Host: "api.cluster.example.com"}, want: false},
+		{name: "dns name with external load balancer", endpoint: &v1alpha1.Endpoint{Host: "api.cluster.example.com", ExternalLoadBalancer: true}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.endpoint.UsesExternalLoadBalancer()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestEndpointEqualExternalLoadBalancer(t *testing.T) {
+	g := NewWithT(t)
+	e1 := &v1alpha1.Endpoint{Host: "1.2.3.4"}
+	e2 := &v1alpha1.Endpoint{Host: "1.2.3.4", ExternalLoadBalancer: true}
+	g.Expect(e1.Equal(e2)).To(BeFalse())
+	g.Expect(e2.Equal(e2.DeepCopy())).To(BeTrue())
+}
+
+func TestEndpointEqualKubeVip(t *testing.T) {
+	g := NewWithT(t)
+	e1 := &v1alpha1.Endpoint{Host: "1.2.3.4"}
+	e2 := &v1alpha1.Endpoint{Host: "1.2.3.4", KubeVip: &v1alpha1.KubeVipConfiguration{LeaseDurationSeconds: 15}}
+	g.Expect(e1.Equal(e2)).To(BeTrue(), "default kube-vip configuration")
+
+	e2.KubeVip.PrometheusPort = 2112
+	g.Expect(e1.Equal(e2)).To(BeFalse())
+	g.Expect(e1.AddressEqual(e2)).To(BeTrue())
+	g.Expect(e1.AddressEqual(&v1alpha1.Endpoint{Host: "1.2.3.5"})).To(BeFalse())
+}
+
+func TestStaticPodManifestsEqual(t *testing.T) {
+	g := NewWithT(t)
+	a := v1alpha1.StaticPodManifest{Name: "a", Content: "a"}
+	b := v1alpha1.StaticPodManifest{Name: "b", Content: "b"}
+	g.Expect(v1alpha1.StaticPodManifestsEqual(nil, []v1alpha1.StaticPodManifest{})).To(BeTrue())
+	g.Expect(v1alpha1.StaticPodManifestsEqual([]v1alpha1.StaticPodManifest{a, b}, []v1alpha1.StaticPodManifest{b, a})).To(BeTrue())
+	g.Expect(v1alpha1.StaticPodManifestsEqual([]v1alpha1.StaticPodManifest{a}, []v1alpha1.StaticPodManifest{b})).To(BeFalse())
+	g.Expect(v1alpha1.StaticPodManifestsEqual([]v1alpha1.StaticPodManifest{a}, []v1alpha1.StaticPodManifest{a, b})).To(BeFalse())
+}
+
+func TestContainerdConfigurationEqual(t *testing.T) {
+	g := NewWithT(t)
+	c := &v1alpha1.ContainerdConfiguration{
+		SandboxImage:    "registry.local/pause:3.9",
+		RegistryHeaders: map[string]string{"Authorization": "Basic abc="},
+		EnableNRI:       true,
+	}
+	g.Expect(c.Equal(c.DeepCopy())).To(BeTrue())
+	g.Expect((*v1alpha1.ContainerdConfiguration)(nil).Equal(nil)).To(BeTrue())
+	g.Expect(c.Equal(nil)).To(BeFalse())
+	g.Expect(c.Equal(&v1alpha1.ContainerdConfiguration{SandboxImage: c.SandboxImage})).To(BeFalse())
+}
@@ -122,6 +122,23 @@ func validateBundlesRefCluster(new, old *Cluster) field.ErrorList {
 	return allErrs
 }
 
+func validateImmutableFieldsKubeletConfiguration(new, old *Cluster, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if new.KubeletServingCertificateRotationEnabled() != old.KubeletServingCertificateRotationEnabled() {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(path.Child("servingCertificateRotation"), fmt.Sprintf("field is immutable %v", new.KubeletServingCertificateRotationEnabled())))
+	}
+
+	if !ImageCredentialProvidersEqual(new.ImageCredentialProviders(), old.ImageCredentialProviders()) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(path.Child("imageCredentialProviders"), "field is immutable"))
+	}
+
+	return allErrs
+}
+
 func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 	if old.IsReconcilePaused() {
 		return nil
@@ -136,7 +153,7 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 			field.Forbidden(specPath.Child("managementCluster", new.Spec.ManagementCluster.Name), fmt.Sprintf("field is immutable %v", new.Spec.ManagementCluster)))
 	}
 
-	if !new.Spec.ControlPlaneConfiguration.Endpoint.Equal(old.Spec.ControlPlaneConfiguration.Endpoint) {
+	if !new.Spec.ControlPlaneConfiguration.Endpoint.AddressEqual(old.Spec.ControlPlaneConfiguration.Endpoint) {
 		allErrs = append(
 			allErrs,
 			field.Forbidden(specPath.Child("ControlPlaneConfiguration.endpoint"), fmt.Sprintf("field is immutable %v", new.Spec.ControlPlaneConfiguration.Endpoint)))
@@ -181,6 +198,20 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 			field.Forbidden(specPath.Child("GitOpsRef"), fmt.Sprintf("field is immutable %v", new.Spec.GitOpsRef)))
 	}
 
+	allErrs = append(allErrs, validateImmutableFieldsKubeletConfiguration(new, old, specPath.Child("kubeletConfiguration"))...)
+
+	if new.Spec.ClusterProfile != old.Spec.ClusterProfile {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("clusterProfile"), fmt.Sprintf("field is immutable %v", new.Spec.ClusterProfile)))
+	}
+
+	if len(old.Spec.EtcdEncryption) > 0 && len(new.Spec.EtcdEncryption) == 0 {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("etcdEncryption"), "etcd encryption can't be removed once enabled"))
+	}
+
 	if !old.IsSelfManaged() {
 		clusterlog.Info("Cluster config is associated with workload cluster", "name", old.Name)
 
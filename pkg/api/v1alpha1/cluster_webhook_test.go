@@ -187,6 +187,19 @@ func TestClusterValidateUpdateControlPlaneConfigurationOldEndpointImmutable(t *t
 	g.Expect(c.ValidateUpdate(cOld)).NotTo(Succeed())
 }
 
+func TestClusterValidateUpdateControlPlaneEndpointKubeVipMutableWorkloadCluster(t *testing.T) {
+	cOld := createCluster()
+	cOld.SetManagedBy("management-cluster")
+	c := cOld.DeepCopy()
+	c.Spec.ControlPlaneConfiguration.Endpoint.KubeVip = &v1alpha1.KubeVipConfiguration{
+		LeaseDurationSeconds: 30,
+		RenewDeadlineSeconds: 20,
+	}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(Succeed())
+}
+
 func TestClusterValidateUpdateControlPlaneConfigurationOldEndpointNilImmutable(t *testing.T) {
 	cOld := &v1alpha1.Cluster{
 		Spec: v1alpha1.ClusterSpec{
@@ -717,6 +730,50 @@ func TestClusterValidateUpdateGitOpsRefImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(cOld)).NotTo(Succeed())
 }
 
+func TestClusterValidateUpdateKubeletServingCertificateRotationImmutable(t *testing.T) {
+	features.ClearCache()
+	cOld := createCluster()
+	c := cOld.DeepCopy()
+	c.Spec.KubeletConfiguration = &v1alpha1.KubeletConfiguration{ServingCertificateRotation: true}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.kubeletConfiguration.servingCertificateRotation: Forbidden: field is immutable true")))
+}
+
+func TestClusterValidateUpdateKubeletServingCertificateRotationUnchanged(t *testing.T) {
+	features.ClearCache()
+	cOld := createCluster()
+	cOld.Spec.KubeletConfiguration = &v1alpha1.KubeletConfiguration{ServingCertificateRotation: false}
+	c := cOld.DeepCopy()
+	c.Spec.KubeletConfiguration = nil
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(Succeed())
+}
+
+func TestClusterValidateUpdateImageCredentialProvidersImmutable(t *testing.T) {
+	features.ClearCache()
+	cOld := createCluster()
+	c := cOld.DeepCopy()
+	c.Spec.KubeletConfiguration = &v1alpha1.KubeletConfiguration{
+		ImageCredentialProviders: []v1alpha1.ImageCredentialProvider{
+			{Name: "ecr-credential-provider", MatchImages: []string{"*.dkr.ecr.*.amazonaws.com"}},
+		},
+	}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.kubeletConfiguration.imageCredentialProviders: Forbidden: field is immutable")))
+}
+
+func TestClusterValidateUpdateClusterProfileImmutable(t *testing.T) {
+	cOld := createCluster()
+	c := cOld.DeepCopy()
+	c.Spec.ClusterProfile = v1alpha1.EdgeClusterProfile
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.clusterProfile: Forbidden: field is immutable edge")))
+}
+
 func TestClusterValidateUpdateGitOpsRefImmutableName(t *testing.T) {
 	cOld := createCluster()
 	cOld.Spec.GitOpsRef = &v1alpha1.Ref{
@@ -1785,3 +1842,36 @@ func createCluster(opts ...clusterOpt) *v1alpha1.Cluster {
 
 	return c
 }
+
+func TestClusterValidateUpdateEtcdEncryptionRemoved(t *testing.T) {
+	cOld := createCluster()
+	cOld.SetManagedBy("management-cluster")
+	cOld.Spec.EtcdEncryption = []v1alpha1.EtcdEncryption{
+		{
+			Providers: []v1alpha1.EtcdEncryptionProvider{
+				{KMS: &v1alpha1.KMS{Name: "new-key", SocketListenAddress: "unix:///var/run/kmsplugin/new.sock"}},
+			},
+		},
+	}
+	c := cOld.DeepCopy()
+	c.Spec.EtcdEncryption = nil
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.etcdEncryption: Forbidden: etcd encryption can't be removed once enabled")))
+}
+
+func TestClusterValidateUpdateEtcdEncryptionEnabled(t *testing.T) {
+	cOld := createCluster()
+	cOld.SetManagedBy("management-cluster")
+	c := cOld.DeepCopy()
+	c.Spec.EtcdEncryption = []v1alpha1.EtcdEncryption{
+		{
+			Providers: []v1alpha1.EtcdEncryptionProvider{
+				{KMS: &v1alpha1.KMS{Name: "new-key", SocketListenAddress: "unix:///var/run/kmsplugin/new.sock"}},
+			},
+		},
+	}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(Succeed())
+}
@@ -71,6 +71,22 @@ func validateGithubProviderConfig(config GithubProviderConfig) error {
 	if err != nil {
 		return err
 	}
+	return validateGithubAppConfig(config.App)
+}
+
+func validateGithubAppConfig(config *GithubAppConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.AppID <= 0 {
+		return errors.New("'appId' is not set in githubProviderConfig app; appId is a required field")
+	}
+	if config.InstallationID <= 0 {
+		return errors.New("'installationId' is not set in githubProviderConfig app; installationId is a required field")
+	}
+	if len(config.PrivateKeySecretRef) <= 0 {
+		return errors.New("'privateKeySecretRef' is not set or empty in githubProviderConfig app; privateKeySecretRef is a required field")
+	}
 	return nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"testing"
 
+	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -188,6 +189,58 @@ func TestValidateFluxConfig(t *testing.T) {
 			gitProvider: true,
 			error:       nil,
 		},
+		{
+			testName: "valid github app",
+			fluxConfig: &FluxConfig{
+				Spec: FluxConfigSpec{
+					Github: &GithubProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						App: &GithubAppConfig{
+							AppID:               1234,
+							InstallationID:      5678,
+							PrivateKeySecretRef: "github-app",
+						},
+					},
+				},
+			},
+			wantErr: false,
+			error:   nil,
+		},
+		{
+			testName: "github app without installation id",
+			fluxConfig: &FluxConfig{
+				Spec: FluxConfigSpec{
+					Github: &GithubProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						App: &GithubAppConfig{
+							AppID:               1234,
+							PrivateKeySecretRef: "github-app",
+						},
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'installationId' is not set in githubProviderConfig app; installationId is a required field"),
+		},
+		{
+			testName: "github app without private key secret",
+			fluxConfig: &FluxConfig{
+				Spec: FluxConfigSpec{
+					Github: &GithubProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						App: &GithubAppConfig{
+							AppID:          1234,
+							InstallationID: 5678,
+						},
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'privateKeySecretRef' is not set or empty in githubProviderConfig app; privateKeySecretRef is a required field"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -208,3 +261,16 @@ func TestValidateFluxConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestFluxConfigSpecEqualAuth(t *testing.T) {
+	g := NewWithT(t)
+	app := func(installationID int64) *FluxConfigSpec {
+		return &FluxConfigSpec{Github: &GithubProviderConfig{
+			Owner: "janedoe", Repository: "flux-fleet",
+			App: &GithubAppConfig{AppID: 1, InstallationID: installationID, PrivateKeySecretRef: "github-app"},
+		}}
+	}
+
+	g.Expect(app(2).Equal(app(2))).To(BeTrue())
+	g.Expect(app(2).Equal(app(3))).To(BeFalse())
+}
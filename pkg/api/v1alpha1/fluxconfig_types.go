@@ -46,6 +46,23 @@ type GithubProviderConfig struct {
 
 	// if true, the owner is assumed to be a Git user; otherwise an org.
 	Personal bool `json:"personal,omitempty"`
+
+	// App authenticates Flux with short lived installation tokens of a GitHub App, issued and rotated
+	// by the controller, instead of the personal access token used to bootstrap it.
+	App *GithubAppConfig `json:"app,omitempty"`
+}
+
+// GithubAppConfig defines the GitHub App installation used to authenticate Flux with GitHub.
+type GithubAppConfig struct {
+	// AppID is the id of the GitHub App.
+	AppID int64 `json:"appId"`
+
+	// InstallationID is the id of the installation of the GitHub App in the owner of the repository.
+	InstallationID int64 `json:"installationId"`
+
+	// PrivateKeySecretRef is the name of the Secret in the eksa-system namespace holding the PEM encoded
+	// private key of the GitHub App in its privateKey field.
+	PrivateKeySecretRef string `json:"privateKeySecretRef"`
 }
 
 type GitProviderConfig struct {
@@ -101,6 +118,19 @@ func (e *FluxConfigSpec) Equal(n *FluxConfigSpec) bool {
 }
 
 func (e *GithubProviderConfig) Equal(n *GithubProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return e.Owner == n.Owner &&
+		e.Repository == n.Repository &&
+		e.Personal == n.Personal &&
+		e.App.Equal(n.App)
+}
+
+func (e *GithubAppConfig) Equal(n *GithubAppConfig) bool {
 	if e == n {
 		return true
 	}
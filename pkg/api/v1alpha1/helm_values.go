@@ -0,0 +1,113 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// allowedHelmValues lists, per managed chart, the Helm value paths that can be overridden.
+// A path allows overriding itself and any value nested under it.
+var allowedHelmValues = map[ManagedChart][]string{
+	CiliumChart: {
+		"resources",
+		"nodeSelector",
+		"tolerations",
+		"priorityClassName",
+		"podAnnotations",
+		"podLabels",
+		"operator.replicas",
+		"operator.resources",
+		"operator.nodeSelector",
+		"operator.tolerations",
+		"operator.priorityClassName",
+		"operator.podAnnotations",
+		"operator.podLabels",
+	},
+	PackagesChart: {
+		"replicas",
+		"resources",
+		"nodeSelector",
+		"tolerations",
+		"affinity",
+		"priorityClassName",
+		"podAnnotations",
+		"podLabels",
+	},
+}
+
+// AllowedHelmValues returns the Helm value paths that can be overridden for chart.
+func AllowedHelmValues(chart ManagedChart) []string {
+	return allowedHelmValues[chart]
+}
+
+// IsAllowed returns true if path can be overridden for the chart.
+func (o HelmValuesOverride) IsAllowed(path string) bool {
+	for _, allowed := range allowedHelmValues[o.Chart] {
+		if path == allowed || strings.HasPrefix(path, allowed+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsedValues returns the override values decoded from YAML, keyed by their dotted Helm path.
+func (o HelmValuesOverride) ParsedValues() (map[string]interface{}, error) {
+	parsed := make(map[string]interface{}, len(o.Values))
+	for path, value := range o.Values {
+		var v interface{}
+		if err := yaml.Unmarshal([]byte(value), &v); err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %v", path, err)
+		}
+		parsed[path] = v
+	}
+	return parsed, nil
+}
+
+// SortedPaths returns the overridden value paths in lexical order so parent values are always
+// applied before the values nested under them.
+func (o HelmValuesOverride) SortedPaths() []string {
+	paths := make([]string, 0, len(o.Values))
+	for path := range o.Values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// HelmValuesOverride returns the Helm values overrides configured for chart, if any.
+func (c *Cluster) HelmValuesOverride(chart ManagedChart) (HelmValuesOverride, bool) {
+	for _, override := range c.Spec.HelmValuesOverrides {
+		if override.Chart == chart {
+			return override, true
+		}
+	}
+	return HelmValuesOverride{}, false
+}
+
+func validateHelmValuesOverrides(clusterConfig *Cluster) error {
+	seen := make(map[ManagedChart]struct{}, len(clusterConfig.Spec.HelmValuesOverrides))
+	for _, override := range clusterConfig.Spec.HelmValuesOverrides {
+		if _, ok := allowedHelmValues[override.Chart]; !ok {
+			return fmt.Errorf("helmValuesOverrides: unsupported chart %s", override.Chart)
+		}
+		if _, ok := seen[override.Chart]; ok {
+			return fmt.Errorf("helmValuesOverrides: duplicate overrides for chart %s", override.Chart)
+		}
+		seen[override.Chart] = struct{}{}
+
+		for _, path := range override.SortedPaths() {
+			if !override.IsAllowed(path) {
+				return fmt.Errorf("helmValuesOverrides: value %s can't be overridden for chart %s, allowed values are %s",
+					path, override.Chart, strings.Join(allowedHelmValues[override.Chart], ", "))
+			}
+		}
+
+		if _, err := override.ParsedValues(); err != nil {
+			return fmt.Errorf("helmValuesOverrides: chart %s: %v", override.Chart, err)
+		}
+	}
+	return nil
+}
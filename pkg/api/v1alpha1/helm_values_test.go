@@ -0,0 +1,111 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateHelmValuesOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantErr   string
+		overrides []HelmValuesOverride
+	}{
+		{
+			name: "no overrides",
+		},
+		{
+			name: "valid overrides",
+			overrides: []HelmValuesOverride{
+				{Chart: CiliumChart, Values: map[string]string{"operator.resources.limits.cpu": "500m", "tolerations": "[{operator: Exists}]"}},
+				{Chart: PackagesChart, Values: map[string]string{"resources": "{limits: {memory: 1Gi}}"}},
+			},
+		},
+		{
+			name:      "unsupported chart",
+			wantErr:   "unsupported chart kube-vip",
+			overrides: []HelmValuesOverride{{Chart: "kube-vip"}},
+		},
+		{
+			name:      "duplicate chart",
+			wantErr:   "duplicate overrides for chart cilium",
+			overrides: []HelmValuesOverride{{Chart: CiliumChart}, {Chart: CiliumChart}},
+		},
+		{
+			name:      "value not allowed",
+			wantErr:   "value image.repository can't be overridden for chart cilium",
+			overrides: []HelmValuesOverride{{Chart: CiliumChart, Values: map[string]string{"image.repository": "my-image"}}},
+		},
+		{
+			name:      "value with allowed prefix",
+			wantErr:   "value operator.resourcesX can't be overridden",
+			overrides: []HelmValuesOverride{{Chart: CiliumChart, Values: map[string]string{"operator.resourcesX": "1"}}},
+		},
+		{
+			name:      "invalid yaml",
+			wantErr:   "invalid value for tolerations",
+			overrides: []HelmValuesOverride{{Chart: CiliumChart, Values: map[string]string{"tolerations": "[{operator: Exists"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateHelmValuesOverrides(&Cluster{Spec: ClusterSpec{HelmValuesOverrides: tt.overrides}})
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestHelmValuesOverrideParsedValues(t *testing.T) {
+	g := NewWithT(t)
+	override := HelmValuesOverride{
+		Chart: CiliumChart,
+		Values: map[string]string{
+			"operator.replicas": "2",
+			"tolerations":       "[{operator: Exists}]",
+		},
+	}
+
+	g.Expect(override.ParsedValues()).To(Equal(map[string]interface{}{
+		"operator.replicas": float64(2),
+		"tolerations":       []interface{}{map[string]interface{}{"operator": "Exists"}},
+	}))
+	g.Expect(override.SortedPaths()).To(Equal([]string{"operator.replicas", "tolerations"}))
+}
+
+func TestClusterHelmValuesOverride(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{
+		Spec: ClusterSpec{
+			HelmValuesOverrides: []HelmValuesOverride{{Chart: PackagesChart, Values: map[string]string{"replicas": "2"}}},
+		},
+	}
+
+	override, ok := c.HelmValuesOverride(PackagesChart)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(override.Values).To(HaveKeyWithValue("replicas", "2"))
+
+	_, ok = c.HelmValuesOverride(CiliumChart)
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestHelmValuesOverridesSliceEqual(t *testing.T) {
+	g := NewWithT(t)
+	a := []HelmValuesOverride{
+		{Chart: CiliumChart, Values: map[string]string{"operator.replicas": "1"}},
+		{Chart: PackagesChart, Values: map[string]string{"replicas": "2"}},
+	}
+	b := []HelmValuesOverride{
+		{Chart: PackagesChart, Values: map[string]string{"replicas": "2"}},
+		{Chart: CiliumChart, Values: map[string]string{"operator.replicas": "1"}},
+	}
+	g.Expect(HelmValuesOverridesSliceEqual(a, b)).To(BeTrue())
+
+	b[0].Values["replicas"] = "3"
+	g.Expect(HelmValuesOverridesSliceEqual(a, b)).To(BeFalse())
+}
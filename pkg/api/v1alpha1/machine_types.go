@@ -1,15 +1,224 @@
 package v1alpha1
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
 type OSFamily string
 
 const (
 	Ubuntu       OSFamily = "ubuntu"
 	Bottlerocket OSFamily = "bottlerocket"
 	RedHat       OSFamily = "redhat"
+	// Windows is only supported for vSphere worker node groups.
+	Windows OSFamily = "windows"
+)
+
+// Architecture is the CPU architecture of the nodes of a machine group.
+type Architecture string
+
+const (
+	AMD64 Architecture = "amd64"
+	ARM64 Architecture = "arm64"
+
+	// DefaultArchitecture is used when a machine config doesn't specify an architecture.
+	DefaultArchitecture = AMD64
 )
 
+// ArchitectureOrDefault returns arch or DefaultArchitecture if arch is empty.
+func ArchitectureOrDefault(arch Architecture) Architecture {
+	if arch == "" {
+		return DefaultArchitecture
+	}
+	return arch
+}
+
+func validateArchitecture(arch Architecture) error {
+	switch arch {
+	case "", AMD64, ARM64:
+		return nil
+	default:
+		return fmt.Errorf("architecture %s is not supported, please use one of the following: %s, %s", arch, AMD64, ARM64)
+	}
+}
+
 // UserConfiguration defines the configuration of the user to be added to the VM.
 type UserConfiguration struct {
 	Name              string   `json:"name"`
 	SshAuthorizedKeys []string `json:"sshAuthorizedKeys"`
 }
+
+// HostOSConfiguration defines the configuration of the host OS of the nodes of a machine group.
+type HostOSConfiguration struct {
+	// KernelModules are loaded on boot, before kubeadm runs.
+	KernelModules []string `json:"kernelModules,omitempty"`
+	// Sysctls are kernel parameters applied on boot, before kubeadm runs.
+	Sysctls map[string]string `json:"sysctls,omitempty"`
+}
+
+// Equal returns true if both HostOSConfigurations are equivalent. Nil and empty configurations are equal.
+func (c *HostOSConfiguration) Equal(o *HostOSConfiguration) bool {
+	if c == nil || o == nil {
+		return c.isEmpty() && o.isEmpty()
+	}
+	return SliceEqual(c.KernelModules, o.KernelModules) && reflect.DeepEqual(c.sysctls(), o.sysctls())
+}
+
+func (c *HostOSConfiguration) isEmpty() bool {
+	return c == nil || (len(c.KernelModules) == 0 && len(c.Sysctls) == 0)
+}
+
+func (c *HostOSConfiguration) sysctls() map[string]string {
+	if len(c.Sysctls) == 0 {
+		return nil
+	}
+	return c.Sysctls
+}
+
+var (
+	kernelModuleNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	sysctlKeyRegex        = regexp.MustCompile(`^[a-zA-Z0-9_-]+([./][a-zA-Z0-9_-]+)+$`)
+)
+
+// ValidateHostOSConfiguration validates the host OS configuration of a machine config with the given OS family.
+// Windows nodes don't support it.
+func ValidateHostOSConfiguration(config *HostOSConfiguration, osFamily OSFamily) error {
+	if config.isEmpty() {
+		return nil
+	}
+	if osFamily == Windows {
+		return fmt.Errorf("hostOSConfiguration is not supported for osFamily %s", osFamily)
+	}
+
+	for _, module := range config.KernelModules {
+		if !kernelModuleNameRegex.MatchString(module) {
+			return fmt.Errorf("hostOSConfiguration.kernelModules: invalid kernel module name %q", module)
+		}
+	}
+
+	for key, value := range config.Sysctls {
+		if !sysctlKeyRegex.MatchString(key) {
+			return fmt.Errorf("hostOSConfiguration.sysctls: invalid key %q", key)
+		}
+		if value == "" || strings.ContainsAny(value, "\n\r") {
+			return fmt.Errorf("hostOSConfiguration.sysctls: invalid value for key %s, it must be a non empty single line", key)
+		}
+	}
+
+	return nil
+}
+
+// SerialConsoleConfiguration captures the output of the machines serial console, to diagnose the failures
+// that happen while they boot, before they can send their logs anywhere else.
+type SerialConsoleConfiguration struct {
+	// Enabled captures the output of the first serial port of the machines.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// IsEnabled returns true if the serial console output is captured. It's false for a nil configuration.
+func (c *SerialConsoleConfiguration) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// Equal returns true if both SerialConsoleConfigurations are equivalent. Nil and disabled configurations are equal.
+func (c *SerialConsoleConfiguration) Equal(o *SerialConsoleConfiguration) bool {
+	return c.IsEnabled() == o.IsEnabled()
+}
+
+// EtcdDiskConfiguration tunes the storage of the etcd data in the external etcd machines.
+type EtcdDiskConfiguration struct {
+	// SizeGiB is the size of a disk dedicated to the etcd data directory, /var/lib/etcd.
+	// Etcd keeps its data in the root disk when not set.
+	SizeGiB int `json:"sizeGiB,omitempty"`
+	// IOScheduler is the Linux IO scheduler of the etcd data disk. Supported values are none,
+	// mq-deadline, bfq and kyber. Requires sizeGiB.
+	IOScheduler string `json:"ioScheduler,omitempty"`
+	// HighIOPriority runs etcd with the highest best-effort IO priority, so other processes
+	// can't starve it from disk access.
+	HighIOPriority bool `json:"highIOPriority,omitempty"`
+}
+
+// Equal returns true if both EtcdDiskConfigurations are equivalent. Nil and empty configurations are equal.
+func (c *EtcdDiskConfiguration) Equal(o *EtcdDiskConfiguration) bool {
+	empty := EtcdDiskConfiguration{}
+	if c == nil {
+		c = &empty
+	}
+	if o == nil {
+		o = &empty
+	}
+	return *c == *o
+}
+
+// ValidateEtcdDiskConfiguration validates the etcd disk configuration of a machine config with the given OS family.
+// Bottlerocket and Windows nodes don't support it.
+func ValidateEtcdDiskConfiguration(config *EtcdDiskConfiguration, osFamily OSFamily) error {
+	if config.Equal(nil) {
+		return nil
+	}
+	if osFamily == Bottlerocket || osFamily == Windows {
+		return fmt.Errorf("etcdDisk is not supported for osFamily %s", osFamily)
+	}
+	if config.SizeGiB < 0 {
+		return fmt.Errorf("etcdDisk.sizeGiB: %d is invalid, it must be positive", config.SizeGiB)
+	}
+	if config.IOScheduler == "" {
+		return nil
+	}
+	if config.SizeGiB == 0 {
+		return errors.New("etcdDisk.ioScheduler requires etcdDisk.sizeGiB, the scheduler is only set for the dedicated etcd data disk")
+	}
+
+	switch config.IOScheduler {
+	case "none", "mq-deadline", "bfq", "kyber":
+		return nil
+	default:
+		return fmt.Errorf("etcdDisk.ioScheduler: %s is not supported, please use one of the following: none, mq-deadline, bfq, kyber", config.IOScheduler)
+	}
+}
+
+// NodeNetworkInterfaces separates the node traffic between two host network interfaces.
+type NodeNetworkInterfaces struct {
+	// Management is the interface for the Kubernetes control traffic. The control plane
+	// endpoint VIP is bound to it.
+	Management string `json:"management"`
+	// Workload is the interface for the node and pod traffic. The kubelet uses its IPv4
+	// address as node IP.
+	Workload string `json:"workload"`
+}
+
+// Equal returns true if both NodeNetworkInterfaces are the same.
+func (n *NodeNetworkInterfaces) Equal(o *NodeNetworkInterfaces) bool {
+	if n == nil || o == nil {
+		return n == o
+	}
+	return *n == *o
+}
+
+// networkInterfaceNameRegex matches Linux interface names, which are at most 15 characters long.
+var networkInterfaceNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,15}$`)
+
+// ValidateNodeNetworkInterfaces validates the network interfaces of a machine config with the given OS family.
+// Bottlerocket and Windows nodes don't support them.
+func ValidateNodeNetworkInterfaces(interfaces *NodeNetworkInterfaces, osFamily OSFamily) error {
+	if interfaces == nil {
+		return nil
+	}
+	if osFamily == Bottlerocket || osFamily == Windows {
+		return fmt.Errorf("networkInterfaces is not supported for osFamily %s", osFamily)
+	}
+	if !networkInterfaceNameRegex.MatchString(interfaces.Management) {
+		return fmt.Errorf("networkInterfaces.management: invalid interface name %q", interfaces.Management)
+	}
+	if !networkInterfaceNameRegex.MatchString(interfaces.Workload) {
+		return fmt.Errorf("networkInterfaces.workload: invalid interface name %q", interfaces.Workload)
+	}
+	if interfaces.Management == interfaces.Workload {
+		return errors.New("networkInterfaces.management and networkInterfaces.workload must be different interfaces")
+	}
+	return nil
+}
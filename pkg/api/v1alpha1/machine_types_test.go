@@ -0,0 +1,83 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateNodeNetworkInterfaces(t *testing.T) {
+	tests := []struct {
+		name       string
+		interfaces *NodeNetworkInterfaces
+		osFamily   OSFamily
+		wantErr    string
+	}{
+		{
+			name:     "not set",
+			osFamily: Bottlerocket,
+		},
+		{
+			name:       "valid",
+			interfaces: &NodeNetworkInterfaces{Management: "eno1", Workload: "bond0.100"},
+			osFamily:   Ubuntu,
+		},
+		{
+			name:       "bottlerocket",
+			interfaces: &NodeNetworkInterfaces{Management: "eno1", Workload: "eno2"},
+			osFamily:   Bottlerocket,
+			wantErr:    "networkInterfaces is not supported for osFamily bottlerocket",
+		},
+		{
+			name:       "windows",
+			interfaces: &NodeNetworkInterfaces{Management: "eno1", Workload: "eno2"},
+			osFamily:   Windows,
+			wantErr:    "networkInterfaces is not supported for osFamily windows",
+		},
+		{
+			name:       "missing management",
+			interfaces: &NodeNetworkInterfaces{Workload: "eno2"},
+			osFamily:   RedHat,
+			wantErr:    `networkInterfaces.management: invalid interface name ""`,
+		},
+		{
+			name:       "invalid workload",
+			interfaces: &NodeNetworkInterfaces{Management: "eno1", Workload: "eno2; reboot"},
+			osFamily:   Ubuntu,
+			wantErr:    `networkInterfaces.workload: invalid interface name "eno2; reboot"`,
+		},
+		{
+			name:       "workload name too long",
+			interfaces: &NodeNetworkInterfaces{Management: "eno1", Workload: "enp0s31f6workload"},
+			osFamily:   Ubuntu,
+			wantErr:    "networkInterfaces.workload: invalid interface name",
+		},
+		{
+			name:       "same interface",
+			interfaces: &NodeNetworkInterfaces{Management: "eno1", Workload: "eno1"},
+			osFamily:   Ubuntu,
+			wantErr:    "networkInterfaces.management and networkInterfaces.workload must be different interfaces",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateNodeNetworkInterfaces(tt.interfaces, tt.osFamily)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestNodeNetworkInterfacesEqual(t *testing.T) {
+	g := NewWithT(t)
+	interfaces := &NodeNetworkInterfaces{Management: "eno1", Workload: "eno2"}
+
+	g.Expect((*NodeNetworkInterfaces)(nil).Equal(nil)).To(BeTrue())
+	g.Expect(interfaces.Equal(&NodeNetworkInterfaces{Management: "eno1", Workload: "eno2"})).To(BeTrue())
+	g.Expect(interfaces.Equal(nil)).To(BeFalse())
+	g.Expect(interfaces.Equal(&NodeNetworkInterfaces{Management: "eno2", Workload: "eno1"})).To(BeFalse())
+}
@@ -0,0 +1,121 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+type managedComponentValues struct {
+	chart ManagedChart
+	// prefix is the path of the component values in the chart, empty if they are top level values.
+	prefix string
+}
+
+// managedComponents maps each managed component to the Helm chart and values that configure it.
+var managedComponents = map[ManagedComponent]managedComponentValues{
+	CiliumAgentComponent:       {chart: CiliumChart},
+	CiliumOperatorComponent:    {chart: CiliumChart, prefix: "operator"},
+	PackageControllerComponent: {chart: PackagesChart},
+}
+
+// ManagedComponent returns the configuration for component, if any.
+func (c *Cluster) ManagedComponent(component ManagedComponent) (ManagedComponentConfiguration, bool) {
+	for _, config := range c.Spec.ManagedComponents {
+		if config.Name == component {
+			return config, true
+		}
+	}
+	return ManagedComponentConfiguration{}, false
+}
+
+// ManagedComponentHelmValues returns the Helm values for chart derived from the managed components
+// configuration, keyed by their dotted Helm path. Values are JSON decoded so they can be set in
+// the same way as the ones from HelmValuesOverrides. The edge profile sets default resources for the
+// components that don't configure them.
+func (c *Cluster) ManagedComponentHelmValues(chart ManagedChart) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, config := range c.managedComponentConfigurations() {
+		component, ok := managedComponents[config.Name]
+		if !ok || component.chart != chart {
+			continue
+		}
+
+		set := func(key string, value interface{}) error {
+			path := key
+			if component.prefix != "" {
+				path = component.prefix + "." + key
+			}
+			b, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("encoding %s for %s: %v", key, config.Name, err)
+			}
+			var v interface{}
+			if err := json.Unmarshal(b, &v); err != nil {
+				return fmt.Errorf("decoding %s for %s: %v", key, config.Name, err)
+			}
+			values[path] = v
+			return nil
+		}
+
+		if config.Resources != nil {
+			if err := set("resources", config.Resources); err != nil {
+				return nil, err
+			}
+		}
+		if len(config.NodeSelector) > 0 {
+			if err := set("nodeSelector", linuxNodeSelector(config.NodeSelector)); err != nil {
+				return nil, err
+			}
+		}
+		if len(config.Tolerations) > 0 {
+			if err := set("tolerations", config.Tolerations); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return values, nil
+}
+
+// linuxNodeSelector merges nodeSelector with the Linux OS selector the charts set by default, which
+// the nodeSelector value replaces, so the components are never scheduled on Windows nodes.
+func linuxNodeSelector(nodeSelector map[string]string) map[string]string {
+	merged := make(map[string]string, len(nodeSelector)+1)
+	merged[corev1.LabelOSStable] = "linux"
+	for k, v := range nodeSelector {
+		merged[k] = v
+	}
+	return merged
+}
+
+func validateManagedComponents(clusterConfig *Cluster) error {
+	seen := make(map[ManagedComponent]struct{}, len(clusterConfig.Spec.ManagedComponents))
+	for _, config := range clusterConfig.Spec.ManagedComponents {
+		if _, ok := managedComponents[config.Name]; !ok {
+			return fmt.Errorf("managedComponents: unsupported component %s", config.Name)
+		}
+		if _, ok := seen[config.Name]; ok {
+			return fmt.Errorf("managedComponents: duplicate configuration for component %s", config.Name)
+		}
+		seen[config.Name] = struct{}{}
+
+		if config.Resources != nil {
+			for name, request := range config.Resources.Requests {
+				limit, ok := config.Resources.Limits[name]
+				if ok && request.Cmp(limit) > 0 {
+					return fmt.Errorf("managedComponents: component %s %s request %s must be less than or equal to limit %s",
+						config.Name, name, request.String(), limit.String())
+				}
+			}
+		}
+
+		for _, toleration := range config.Tolerations {
+			if toleration.Operator == corev1.TolerationOpExists && toleration.Value != "" {
+				return fmt.Errorf("managedComponents: component %s toleration %s value must be empty when operator is Exists",
+					config.Name, toleration.Key)
+			}
+		}
+	}
+	return nil
+}
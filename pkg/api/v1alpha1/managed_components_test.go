@@ -0,0 +1,143 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestValidateManagedComponents(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantErr    string
+		components []ManagedComponentConfiguration
+	}{
+		{
+			name: "no components",
+		},
+		{
+			name: "valid components",
+			components: []ManagedComponentConfiguration{
+				{
+					Name: CiliumAgentComponent,
+					Resources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+						Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				},
+				{
+					Name:        PackageControllerComponent,
+					Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+				},
+			},
+		},
+		{
+			name:       "unsupported component",
+			wantErr:    "unsupported component kube-vip",
+			components: []ManagedComponentConfiguration{{Name: "kube-vip"}},
+		},
+		{
+			name:       "duplicate component",
+			wantErr:    "duplicate configuration for component cilium-operator",
+			components: []ManagedComponentConfiguration{{Name: CiliumOperatorComponent}, {Name: CiliumOperatorComponent}},
+		},
+		{
+			name:    "request over limit",
+			wantErr: "component cilium-agent memory request 1Gi must be less than or equal to limit 512Mi",
+			components: []ManagedComponentConfiguration{
+				{
+					Name: CiliumAgentComponent,
+					Resources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+						Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+					},
+				},
+			},
+		},
+		{
+			name:    "exists toleration with value",
+			wantErr: "toleration dedicated value must be empty when operator is Exists",
+			components: []ManagedComponentConfiguration{
+				{
+					Name:        CiliumOperatorComponent,
+					Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists, Value: "system"}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateManagedComponents(&Cluster{Spec: ClusterSpec{ManagedComponents: tt.components}})
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterManagedComponentHelmValues(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{
+		Spec: ClusterSpec{
+			ManagedComponents: []ManagedComponentConfiguration{
+				{
+					Name: CiliumAgentComponent,
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+					},
+				},
+				{
+					Name:         CiliumOperatorComponent,
+					NodeSelector: map[string]string{"pool": "system"},
+					Tolerations:  []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+				},
+				{
+					Name:         PackageControllerComponent,
+					NodeSelector: map[string]string{"pool": "packages"},
+				},
+			},
+		},
+	}
+
+	g.Expect(c.ManagedComponentHelmValues(CiliumChart)).To(Equal(map[string]interface{}{
+		"resources": map[string]interface{}{
+			"limits": map[string]interface{}{"memory": "512Mi"},
+		},
+		"operator.nodeSelector": map[string]interface{}{"kubernetes.io/os": "linux", "pool": "system"},
+		"operator.tolerations": []interface{}{
+			map[string]interface{}{"key": "dedicated", "operator": "Exists"},
+		},
+	}))
+	g.Expect(c.ManagedComponentHelmValues(PackagesChart)).To(Equal(map[string]interface{}{
+		"nodeSelector": map[string]interface{}{"kubernetes.io/os": "linux", "pool": "packages"},
+	}))
+
+	config, ok := c.ManagedComponent(PackageControllerComponent)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(config.NodeSelector).To(Equal(map[string]string{"pool": "packages"}))
+}
+
+func TestManagedComponentsSliceEqual(t *testing.T) {
+	g := NewWithT(t)
+	a := []ManagedComponentConfiguration{
+		{Name: CiliumAgentComponent, Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		}},
+		{Name: PackageControllerComponent, NodeSelector: map[string]string{"pool": "system"}},
+	}
+	b := []ManagedComponentConfiguration{
+		{Name: PackageControllerComponent, NodeSelector: map[string]string{"pool": "system"}},
+		{Name: CiliumAgentComponent, Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1000m")},
+		}},
+	}
+	g.Expect(ManagedComponentsSliceEqual(a, b)).To(BeTrue())
+
+	b[0].Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}
+	g.Expect(ManagedComponentsSliceEqual(a, b)).To(BeFalse())
+}
@@ -28,7 +28,7 @@ func TestGetNutanixDatacenterConfigInvalidConfig(t *testing.T) {
 		{
 			name:        "invalid-cluster-extraneous-field",
 			fileName:    "testdata/nutanix/invalid-cluster.yaml",
-			expectedErr: "unknown field \"idont\"",
+			expectedErr: "unknown field \"spec.idont\"",
 		},
 		{
 			name:        "invalid-kind",
@@ -3,11 +3,12 @@ package v1alpha1
 import (
 	"fmt"
 	"io/ioutil"
-	"strings"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
 )
 
 // NutanixIdentifierType is an enumeration of different resource identifier types.
@@ -109,20 +110,20 @@ func GetNutanixMachineConfigs(fileName string) (map[string]*NutanixMachineConfig
 	if err != nil {
 		return nil, fmt.Errorf("unable to read file due to: %v", err)
 	}
-	for _, c := range strings.Split(string(content), YamlSeparator) {
+	for _, doc := range yamlutil.SplitDocuments(content) {
 		config := NutanixMachineConfig{
 			TypeMeta: metav1.TypeMeta{},
 			ObjectMeta: metav1.ObjectMeta{
 				Annotations: map[string]string{},
 			},
 		}
-		if err = yaml.UnmarshalStrict([]byte(c), &config); err == nil {
+		if err = doc.UnmarshalStrict(&config); err == nil {
 			if config.Kind == NutanixMachineConfigKind {
 				configs[config.Name] = &config
 				continue
 			}
 		}
-		_ = yaml.Unmarshal([]byte(c), &config) // this is to check if there is a bad spec in the file
+		_ = yaml.Unmarshal(doc.Content, &config) // this is to check if there is a bad spec in the file
 		if config.Kind == NutanixMachineConfigKind {
 			return nil, fmt.Errorf("unable to unmarshall content from file due to: %v", err)
 		}
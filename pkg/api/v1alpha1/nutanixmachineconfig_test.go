@@ -31,7 +31,7 @@ func TestGetNutanixMachineConfigsInvalidConfig(t *testing.T) {
 		{
 			name:        "invalid-cluster-extraneuous-field",
 			fileName:    "testdata/nutanix/invalid-cluster.yaml",
-			expectedErr: "unknown field \"idont\"",
+			expectedErr: "unknown field \"spec.idont\"",
 		},
 		{
 			name:        "invalid kind",
@@ -24,7 +24,7 @@ func TestGetSnowDatacenterConfig(t *testing.T) {
 			name:     "not parseable file",
 			fileName: "testdata/not_parseable_cluster_snow.yaml",
 			want:     nil,
-			wantErr:  "error unmarshaling JSON: while decoding JSON: json: unknown field",
+			wantErr:  "line 50, column 3: unknown field \"spec.idont\"",
 		},
 		{
 			name:     "valid 1.21",
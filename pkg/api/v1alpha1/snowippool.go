@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// SnowIPPoolKind is the kind of the SnowIPPool object.
+const SnowIPPoolKind = "SnowIPPool"
+
+func validateSnowIPPool(pool *SnowIPPool) error {
+	if len(pool.Spec.Pools) == 0 {
+		return errors.New("SnowIPPool Pools must contain at least one ip pool")
+	}
+
+	for i, p := range pool.Spec.Pools {
+		if err := validateIPPool(p); err != nil {
+			return fmt.Errorf("SnowIPPool Pools[%d] %v", i, err)
+		}
+	}
+
+	return validateIPPoolsOverlap(pool.Spec.Pools)
+}
+
+func validateIPPool(pool IPPool) error {
+	start := net.ParseIP(pool.IPStart).To4()
+	if start == nil {
+		return fmt.Errorf("IPStart %s is not a valid IPv4 address", pool.IPStart)
+	}
+
+	end := net.ParseIP(pool.IPEnd).To4()
+	if end == nil {
+		return fmt.Errorf("IPEnd %s is not a valid IPv4 address", pool.IPEnd)
+	}
+
+	if bytes.Compare(start, end) > 0 {
+		return fmt.Errorf("IPStart %s must not be greater than IPEnd %s", pool.IPStart, pool.IPEnd)
+	}
+
+	_, subnet, err := net.ParseCIDR(pool.Subnet)
+	if err != nil {
+		return fmt.Errorf("Subnet %s is not a valid CIDR", pool.Subnet)
+	}
+
+	if !subnet.Contains(start) || !subnet.Contains(end) {
+		return fmt.Errorf("ip range %s-%s is not within Subnet %s", pool.IPStart, pool.IPEnd, pool.Subnet)
+	}
+
+	if gateway := net.ParseIP(pool.Gateway); gateway == nil || !subnet.Contains(gateway) {
+		return fmt.Errorf("Gateway %s is not a valid IP address within Subnet %s", pool.Gateway, pool.Subnet)
+	}
+
+	return nil
+}
+
+// validateIPPoolsOverlap expects the pools to be valid.
+func validateIPPoolsOverlap(pools []IPPool) error {
+	for i := range pools {
+		for j := i + 1; j < len(pools); j++ {
+			if ipRangesOverlap(pools[i], pools[j]) {
+				return fmt.Errorf("SnowIPPool Pools[%d] and Pools[%d] ip ranges overlap", i, j)
+			}
+		}
+	}
+
+	return nil
+}
+
+func ipRangesOverlap(a, b IPPool) bool {
+	aStart, aEnd := net.ParseIP(a.IPStart).To4(), net.ParseIP(a.IPEnd).To4()
+	bStart, bEnd := net.ParseIP(b.IPStart).To4(), net.ParseIP(b.IPEnd).To4()
+	return bytes.Compare(aStart, bEnd) <= 0 && bytes.Compare(bStart, aEnd) <= 0
+}
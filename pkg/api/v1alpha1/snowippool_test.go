@@ -0,0 +1,146 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSnowIPPoolValidate(t *testing.T) {
+	validPool := IPPool{
+		IPStart: "1.2.3.4",
+		IPEnd:   "1.2.3.10",
+		Subnet:  "1.2.3.0/24",
+		Gateway: "1.2.3.1",
+	}
+
+	tests := []struct {
+		name    string
+		pools   []IPPool
+		wantErr string
+	}{
+		{
+			name:    "valid pool",
+			pools:   []IPPool{validPool},
+			wantErr: "",
+		},
+		{
+			name: "valid multiple pools",
+			pools: []IPPool{
+				validPool,
+				{
+					IPStart: "1.2.3.11",
+					IPEnd:   "1.2.3.20",
+					Subnet:  "1.2.3.0/24",
+					Gateway: "1.2.3.1",
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name:    "empty pools",
+			wantErr: "Pools must contain at least one ip pool",
+		},
+		{
+			name: "invalid ip start",
+			pools: []IPPool{
+				{
+					IPStart: "invalid",
+					IPEnd:   "1.2.3.10",
+					Subnet:  "1.2.3.0/24",
+					Gateway: "1.2.3.1",
+				},
+			},
+			wantErr: "Pools[0] IPStart invalid is not a valid IPv4 address",
+		},
+		{
+			name: "invalid ip end",
+			pools: []IPPool{
+				{
+					IPStart: "1.2.3.4",
+					IPEnd:   "2001:db8::1",
+					Subnet:  "1.2.3.0/24",
+					Gateway: "1.2.3.1",
+				},
+			},
+			wantErr: "IPEnd 2001:db8::1 is not a valid IPv4 address",
+		},
+		{
+			name: "ip start greater than ip end",
+			pools: []IPPool{
+				{
+					IPStart: "1.2.3.10",
+					IPEnd:   "1.2.3.4",
+					Subnet:  "1.2.3.0/24",
+					Gateway: "1.2.3.1",
+				},
+			},
+			wantErr: "IPStart 1.2.3.10 must not be greater than IPEnd 1.2.3.4",
+		},
+		{
+			name: "invalid subnet",
+			pools: []IPPool{
+				{
+					IPStart: "1.2.3.4",
+					IPEnd:   "1.2.3.10",
+					Subnet:  "1.2.3.0",
+					Gateway: "1.2.3.1",
+				},
+			},
+			wantErr: "Subnet 1.2.3.0 is not a valid CIDR",
+		},
+		{
+			name: "ip range not in subnet",
+			pools: []IPPool{
+				{
+					IPStart: "1.2.3.4",
+					IPEnd:   "1.2.4.10",
+					Subnet:  "1.2.3.0/24",
+					Gateway: "1.2.3.1",
+				},
+			},
+			wantErr: "ip range 1.2.3.4-1.2.4.10 is not within Subnet 1.2.3.0/24",
+		},
+		{
+			name: "gateway not in subnet",
+			pools: []IPPool{
+				{
+					IPStart: "1.2.3.4",
+					IPEnd:   "1.2.3.10",
+					Subnet:  "1.2.3.0/24",
+					Gateway: "1.2.4.1",
+				},
+			},
+			wantErr: "Gateway 1.2.4.1 is not a valid IP address within Subnet 1.2.3.0/24",
+		},
+		{
+			name: "overlapping pools",
+			pools: []IPPool{
+				validPool,
+				{
+					IPStart: "1.2.3.10",
+					IPEnd:   "1.2.3.20",
+					Subnet:  "1.2.3.0/24",
+					Gateway: "1.2.3.1",
+				},
+			},
+			wantErr: "Pools[0] and Pools[1] ip ranges overlap",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			pool := &SnowIPPool{
+				Spec: SnowIPPoolSpec{
+					Pools: tt.pools,
+				},
+			}
+			err := pool.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
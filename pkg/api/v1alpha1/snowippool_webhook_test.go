@@ -0,0 +1,73 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestSnowIPPoolValidateCreateValid(t *testing.T) {
+	g := NewWithT(t)
+
+	pool := snowIPPool()
+
+	g.Expect(pool.ValidateCreate()).To(Succeed())
+}
+
+func TestSnowIPPoolValidateCreateEmptyPools(t *testing.T) {
+	g := NewWithT(t)
+
+	pool := snowIPPool()
+	pool.Spec.Pools = nil
+
+	g.Expect(pool.ValidateCreate()).To(MatchError(ContainSubstring("Pools must contain at least one ip pool")))
+}
+
+func TestSnowIPPoolValidateUpdateValid(t *testing.T) {
+	g := NewWithT(t)
+
+	poolOld := snowIPPool()
+	poolNew := poolOld.DeepCopy()
+	poolNew.Spec.Pools[0].IPEnd = "1.2.3.20"
+
+	g.Expect(poolNew.ValidateUpdate(&poolOld)).To(Succeed())
+}
+
+func TestSnowIPPoolValidateUpdateInvalidGateway(t *testing.T) {
+	g := NewWithT(t)
+
+	poolOld := snowIPPool()
+	poolNew := poolOld.DeepCopy()
+	poolNew.Spec.Pools[0].Gateway = "invalid"
+
+	g.Expect(poolNew.ValidateUpdate(&poolOld)).To(MatchError(ContainSubstring("Gateway invalid is not a valid IP address")))
+}
+
+func TestSnowIPPoolValidateDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	pool := snowIPPool()
+
+	g.Expect(pool.ValidateDelete()).To(Succeed())
+}
+
+func snowIPPool() v1alpha1.SnowIPPool {
+	return v1alpha1.SnowIPPool{
+		TypeMeta:   metav1.TypeMeta{},
+		ObjectMeta: metav1.ObjectMeta{Annotations: make(map[string]string, 2)},
+		Spec: v1alpha1.SnowIPPoolSpec{
+			Pools: []v1alpha1.IPPool{
+				{
+					IPStart: "1.2.3.4",
+					IPEnd:   "1.2.3.10",
+					Subnet:  "1.2.3.0/24",
+					Gateway: "1.2.3.1",
+				},
+			},
+		},
+		Status: v1alpha1.SnowIPPoolStatus{},
+	}
+}
@@ -15,6 +15,8 @@ const (
 	DefaultSnowInstanceType                 = SbeCLarge
 	DefaultSnowPhysicalNetworkConnectorType = SFPPlus
 	MinimumContainerVolumeSize              = 8
+	MaxSnowDirectNetworkInterfaces          = 8
+	MaxSnowVlanID                           = 4095
 )
 
 // Used for generating yaml for generate clusterconfig command.
@@ -61,6 +63,64 @@ func validateSnowMachineConfig(config *SnowMachineConfig) error {
 		return errors.New("SnowMachineConfig Devices must contain at least one device IP")
 	}
 
+	return validateSnowNetwork(config.Spec.Network)
+}
+
+func validateSnowNetwork(network SnowNetwork) error {
+	dnis := network.DirectNetworkInterfaces
+	if len(dnis) == 0 {
+		return nil
+	}
+
+	if len(dnis) > MaxSnowDirectNetworkInterfaces {
+		return fmt.Errorf("SnowMachineConfig Network.DirectNetworkInterfaces length must be no greater than %d", MaxSnowDirectNetworkInterfaces)
+	}
+
+	indices := map[int]struct{}{}
+	primaries := 0
+	for _, dni := range dnis {
+		if dni.Index < 1 || dni.Index > MaxSnowDirectNetworkInterfaces {
+			return fmt.Errorf("SnowMachineConfig Network.DirectNetworkInterfaces index %d must be between 1 and %d", dni.Index, MaxSnowDirectNetworkInterfaces)
+		}
+
+		if _, ok := indices[dni.Index]; ok {
+			return fmt.Errorf("SnowMachineConfig Network.DirectNetworkInterfaces index %d is duplicated", dni.Index)
+		}
+		indices[dni.Index] = struct{}{}
+
+		if err := validateSnowDirectNetworkInterface(dni); err != nil {
+			return err
+		}
+
+		if dni.Primary {
+			primaries++
+		}
+	}
+
+	if primaries != 1 {
+		return errors.New("SnowMachineConfig Network.DirectNetworkInterfaces must contain exactly one primary DNI")
+	}
+
+	return nil
+}
+
+func validateSnowDirectNetworkInterface(dni SnowDirectNetworkInterface) error {
+	if dni.VlanID != nil && (*dni.VlanID < 0 || *dni.VlanID > MaxSnowVlanID) {
+		return fmt.Errorf("SnowMachineConfig Network.DirectNetworkInterfaces[%d] VlanID %d must be between 0 and %d", dni.Index, *dni.VlanID, MaxSnowVlanID)
+	}
+
+	if dni.DHCP && dni.IPPoolRef != nil {
+		return fmt.Errorf("SnowMachineConfig Network.DirectNetworkInterfaces[%d] IPPoolRef must be empty when DHCP is enabled", dni.Index)
+	}
+
+	if !dni.DHCP && dni.IPPoolRef == nil {
+		return fmt.Errorf("SnowMachineConfig Network.DirectNetworkInterfaces[%d] IPPoolRef is required when DHCP is disabled", dni.Index)
+	}
+
+	if dni.IPPoolRef != nil && dni.IPPoolRef.Kind != SnowIPPoolKind {
+		return fmt.Errorf("SnowMachineConfig Network.DirectNetworkInterfaces[%d] IPPoolRef kind %s is invalid, the only supported kind is %s", dni.Index, dni.IPPoolRef.Kind, SnowIPPoolKind)
+	}
+
 	return nil
 }
 
@@ -74,4 +134,21 @@ func setSnowMachineConfigDefaults(config *SnowMachineConfig) {
 		config.Spec.PhysicalNetworkConnector = DefaultSnowPhysicalNetworkConnectorType
 		logger.V(1).Info("SnowMachineConfig PhysicalNetworkConnector is empty. Using default", "default physical network connector", DefaultSnowPhysicalNetworkConnectorType)
 	}
+
+	setSnowNetworkDefaults(&config.Spec.Network)
+}
+
+// setSnowNetworkDefaults sets the index of the DNIs to their position in the list when empty,
+// and makes a single DNI primary.
+func setSnowNetworkDefaults(network *SnowNetwork) {
+	dnis := network.DirectNetworkInterfaces
+	for i := range dnis {
+		if dnis[i].Index == 0 {
+			dnis[i].Index = i + 1
+		}
+	}
+
+	if len(dnis) == 1 {
+		dnis[0].Primary = true
+	}
 }
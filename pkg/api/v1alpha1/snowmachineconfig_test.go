@@ -7,6 +7,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
 func TestSnowMachineConfigSetDefaults(t *testing.T) {
@@ -70,6 +71,56 @@ func TestSnowMachineConfigSetDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "single dni",
+			before: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{DHCP: true},
+						},
+					},
+				},
+			},
+			after: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					InstanceType:             DefaultSnowInstanceType,
+					PhysicalNetworkConnector: DefaultSnowPhysicalNetworkConnectorType,
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{Index: 1, DHCP: true, Primary: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "multiple dnis",
+			before: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{DHCP: true, Primary: true},
+							{Index: 3, DHCP: true},
+							{DHCP: true},
+						},
+					},
+				},
+			},
+			after: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					InstanceType:             DefaultSnowInstanceType,
+					PhysicalNetworkConnector: DefaultSnowPhysicalNetworkConnectorType,
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{Index: 1, DHCP: true, Primary: true},
+							{Index: 3, DHCP: true},
+							{Index: 3, DHCP: true},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -142,6 +193,154 @@ func TestSnowMachineConfigValidate(t *testing.T) {
 			},
 			wantErr: "ContainersVolume.Size must be no smaller than 8 Gi",
 		},
+		{
+			name: "valid dnis",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:        "ami-1",
+					InstanceType: DefaultSnowInstanceType,
+					Devices:      []string{"1.2.3.4"},
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{Index: 1, DHCP: true, Primary: true},
+							{Index: 2, VlanID: ptr.Int32(1), IPPoolRef: &Ref{Kind: SnowIPPoolKind, Name: "ip-pool-1"}},
+						},
+					},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "dni index out of range",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:        "ami-1",
+					InstanceType: DefaultSnowInstanceType,
+					Devices:      []string{"1.2.3.4"},
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{Index: 9, DHCP: true, Primary: true},
+						},
+					},
+				},
+			},
+			wantErr: "index 9 must be between 1 and 8",
+		},
+		{
+			name: "duplicated dni index",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:        "ami-1",
+					InstanceType: DefaultSnowInstanceType,
+					Devices:      []string{"1.2.3.4"},
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{Index: 1, DHCP: true, Primary: true},
+							{Index: 1, DHCP: true},
+						},
+					},
+				},
+			},
+			wantErr: "index 1 is duplicated",
+		},
+		{
+			name: "no primary dni",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:        "ami-1",
+					InstanceType: DefaultSnowInstanceType,
+					Devices:      []string{"1.2.3.4"},
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{Index: 1, DHCP: true},
+							{Index: 2, DHCP: true},
+						},
+					},
+				},
+			},
+			wantErr: "must contain exactly one primary DNI",
+		},
+		{
+			name: "multiple primary dnis",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:        "ami-1",
+					InstanceType: DefaultSnowInstanceType,
+					Devices:      []string{"1.2.3.4"},
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{Index: 1, DHCP: true, Primary: true},
+							{Index: 2, DHCP: true, Primary: true},
+						},
+					},
+				},
+			},
+			wantErr: "must contain exactly one primary DNI",
+		},
+		{
+			name: "invalid vlan id",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:        "ami-1",
+					InstanceType: DefaultSnowInstanceType,
+					Devices:      []string{"1.2.3.4"},
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{Index: 1, DHCP: true, Primary: true, VlanID: ptr.Int32(4096)},
+						},
+					},
+				},
+			},
+			wantErr: "VlanID 4096 must be between 0 and 4095",
+		},
+		{
+			name: "dhcp and ip pool",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:        "ami-1",
+					InstanceType: DefaultSnowInstanceType,
+					Devices:      []string{"1.2.3.4"},
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{Index: 1, DHCP: true, Primary: true, IPPoolRef: &Ref{Kind: SnowIPPoolKind, Name: "ip-pool-1"}},
+						},
+					},
+				},
+			},
+			wantErr: "IPPoolRef must be empty when DHCP is enabled",
+		},
+		{
+			name: "no dhcp and no ip pool",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:        "ami-1",
+					InstanceType: DefaultSnowInstanceType,
+					Devices:      []string{"1.2.3.4"},
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{Index: 1, Primary: true},
+						},
+					},
+				},
+			},
+			wantErr: "IPPoolRef is required when DHCP is disabled",
+		},
+		{
+			name: "invalid ip pool kind",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:        "ami-1",
+					InstanceType: DefaultSnowInstanceType,
+					Devices:      []string{"1.2.3.4"},
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{Index: 1, Primary: true, IPPoolRef: &Ref{Kind: "InvalidKind", Name: "ip-pool-1"}},
+						},
+					},
+				},
+			},
+			wantErr: "IPPoolRef kind InvalidKind is invalid",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -46,6 +46,34 @@ type SnowMachineConfigSpec struct {
 
 	// ContainersVolume provides the configuration options for the containers data storage volume.
 	ContainersVolume *snowv1.Volume `json:"containersVolume,omitempty"`
+
+	// Network provides the direct network interfaces (DNI) configuration.
+	Network SnowNetwork `json:"network,omitempty"`
+}
+
+// SnowNetwork specifies the network configurations for snow.
+type SnowNetwork struct {
+	// DirectNetworkInterfaces contains a list of direct network interface (DNI) configuration.
+	DirectNetworkInterfaces []SnowDirectNetworkInterface `json:"directNetworkInterfaces,omitempty"`
+}
+
+// SnowDirectNetworkInterface defines a direct network interface (DNI) configuration.
+type SnowDirectNetworkInterface struct {
+	// Index is the index number of DNI used to clarify the position in the list. Usually starts with 1.
+	Index int `json:"index,omitempty"`
+
+	// VlanID is the vlan id assigned by the user for the DNI.
+	VlanID *int32 `json:"vlanID,omitempty"`
+
+	// DHCP defines whether DHCP is used to assign ip for the DNI.
+	DHCP bool `json:"dhcp,omitempty"`
+
+	// IPPool contains a reference to a snow ip pool which provides a range of ip addresses.
+	// When specified, an ip address selected from the pool is allocated to this DNI.
+	IPPoolRef *Ref `json:"ipPoolRef,omitempty"`
+
+	// Primary indicates whether the DNI is primary or not.
+	Primary bool `json:"primary,omitempty"`
 }
 
 func (s *SnowMachineConfig) SetManagedBy(clusterName string) {
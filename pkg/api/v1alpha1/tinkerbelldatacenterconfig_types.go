@@ -18,6 +18,15 @@ type TinkerbellDatacenterConfigSpec struct {
 	// SkipLoadBalancerDeployment when set to "true" can be used to skip deploying a load balancer to expose Tinkerbell stack.
 	// Users will need to deploy and configure a load balancer manually after the cluster is created.
 	SkipLoadBalancerDeployment bool `json:"skipLoadBalancerDeployment,omitempty"`
+	// IsoBoot provisions the machines by mounting the Hook ISO through the Redfish virtual media of
+	// their BMC instead of netbooting them with DHCP and PXE. All the hardware must use Redfish BMCs.
+	IsoBoot bool `json:"isoBoot,omitempty"`
+	// HookIsoURL is the URL of the Hook ISO mounted on the machines when isoBoot is enabled.
+	HookIsoURL string `json:"hookIsoURL,omitempty"`
+	// SerialConsole sends the console of the Hook kernel to the first serial port of the machines,
+	// readable through the serial-over-LAN of their BMC, and collects the logs the Hook forwards to
+	// Boots in the support bundles.
+	SerialConsole *SerialConsoleConfiguration `json:"serialConsole,omitempty"`
 }
 
 // TinkerbellDatacenterConfigStatus defines the observed state of TinkerbellDatacenterConfig
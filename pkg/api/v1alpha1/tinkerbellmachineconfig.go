@@ -3,10 +3,11 @@ package v1alpha1
 import (
 	"fmt"
 	"io/ioutil"
-	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
 )
 
 const TinkerbellMachineConfigKind = "TinkerbellMachineConfig"
@@ -61,15 +62,15 @@ func GetTinkerbellMachineConfigs(fileName string) (map[string]*TinkerbellMachine
 	if err != nil {
 		return nil, fmt.Errorf("unable to read file due to: %v", err)
 	}
-	for _, c := range strings.Split(string(content), YamlSeparator) {
+	for _, doc := range yamlutil.SplitDocuments(content) {
 		var config TinkerbellMachineConfig
-		if err = yaml.UnmarshalStrict([]byte(c), &config); err == nil {
+		if err = doc.UnmarshalStrict(&config); err == nil {
 			if config.Kind == TinkerbellMachineConfigKind {
 				configs[config.Name] = &config
 				continue
 			}
 		}
-		_ = yaml.Unmarshal([]byte(c), &config) // this is to check if there is a bad spec in the file
+		_ = yaml.Unmarshal(doc.Content, &config) // this is to check if there is a bad spec in the file
 		if config.Kind == TinkerbellMachineConfigKind {
 			return nil, fmt.Errorf("unable to unmarshall content from file due to: %v", err)
 		}
@@ -14,6 +14,16 @@ type TinkerbellMachineConfigSpec struct {
 	TemplateRef      Ref                 `json:"templateRef,omitempty"`
 	OSFamily         OSFamily            `json:"osFamily"`
 	Users            []UserConfiguration `json:"users,omitempty"`
+	// Architecture of the hardware. Supported values are amd64 and arm64. Defaults to amd64.
+	Architecture Architecture `json:"architecture,omitempty"`
+	// OSImageURL overrides the datacenter osImageURL for the machines using this config. It's required
+	// for node groups with an architecture different from the control plane.
+	OSImageURL string `json:"osImageURL,omitempty"`
+	// HostOSConfiguration loads kernel modules and sets sysctls in the nodes. Not supported for Bottlerocket.
+	HostOSConfiguration *HostOSConfiguration `json:"hostOSConfiguration,omitempty"`
+	// NetworkInterfaces separates the Kubernetes control traffic from the node and pod traffic
+	// between two host interfaces, which must be configured in the OS. Not supported for Bottlerocket.
+	NetworkInterfaces *NodeNetworkInterfaces `json:"networkInterfaces,omitempty"`
 }
 
 // HardwareSelector models a simple key-value selector used in Tinkerbell provisioning.
@@ -83,6 +93,11 @@ func (c *TinkerbellMachineConfig) OSFamily() OSFamily {
 	return c.Spec.OSFamily
 }
 
+// Arch returns the architecture of the machines, DefaultArchitecture if not set.
+func (c *TinkerbellMachineConfig) Arch() Architecture {
+	return ArchitectureOrDefault(c.Spec.Architecture)
+}
+
 func (c *TinkerbellMachineConfig) GetNamespace() string {
 	return c.Namespace
 }
@@ -3,12 +3,12 @@ package v1alpha1
 import (
 	"fmt"
 	"io/ioutil"
-	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -71,14 +71,14 @@ func GetTinkerbellTemplateConfig(fileName string) (map[string]*TinkerbellTemplat
 	if err != nil {
 		return nil, fmt.Errorf("unable to read file due to: %v", err)
 	}
-	for _, c := range strings.Split(string(content), YamlSeparator) {
+	for _, doc := range yamlutil.SplitDocuments(content) {
 		var template TinkerbellTemplateConfig
-		if err := yaml.Unmarshal([]byte(c), &template); err != nil {
+		if err := yaml.Unmarshal(doc.Content, &template); err != nil {
 			return nil, fmt.Errorf("unable to unmarshall content from file due to: %v", err)
 		}
 
 		if template.Kind() == template.ExpectedKind() {
-			if err = yaml.UnmarshalStrict([]byte(c), &template); err != nil {
+			if err = doc.UnmarshalStrict(&template); err != nil {
 				return nil, fmt.Errorf("invalid template config content: %v", err)
 			}
 			templates[template.Name] = &template
@@ -172,3 +172,42 @@ func TestGetTinkerbellTemplateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestTinkerbellTemplateConfigSetActionsProxy(t *testing.T) {
+	g := gomega.NewWithT(t)
+	config := &TinkerbellTemplateConfig{
+		Spec: TinkerbellTemplateConfigSpec{
+			Template: tinkerbell.Workflow{
+				Tasks: []tinkerbell.Task{
+					{
+						Name: "os-installation",
+						Actions: []tinkerbell.Action{
+							{
+								Name:        "stream-image",
+								Environment: map[string]string{"IMG_URL": "http://image"},
+							},
+							{
+								Name: "reboot-image",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	config.SetActionsProxy("http://proxy:3128", "https://proxy:3128", []string{"1.2.3.4", ".svc"})
+
+	wantEnv := map[string]string{
+		"HTTP_PROXY":  "http://proxy:3128",
+		"HTTPS_PROXY": "https://proxy:3128",
+		"NO_PROXY":    "1.2.3.4,.svc",
+	}
+	g.Expect(config.Spec.Template.Tasks[0].Actions[0].Environment).To(gomega.Equal(map[string]string{
+		"IMG_URL":     "http://image",
+		"HTTP_PROXY":  "http://proxy:3128",
+		"HTTPS_PROXY": "https://proxy:3128",
+		"NO_PROXY":    "1.2.3.4,.svc",
+	}))
+	g.Expect(config.Spec.Template.Tasks[0].Actions[1].Environment).To(gomega.Equal(wantEnv))
+}
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
@@ -49,6 +50,23 @@ func (t *TinkerbellTemplateConfig) ToTemplateString() (string, error) {
 	return string(b), nil
 }
 
+// SetActionsProxy sets the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of all the
+// actions in the template, so they can reach the internet through a proxy.
+func (t *TinkerbellTemplateConfig) SetActionsProxy(httpProxy, httpsProxy string, noProxy []string) {
+	for i := range t.Spec.Template.Tasks {
+		task := &t.Spec.Template.Tasks[i]
+		for j := range task.Actions {
+			action := &task.Actions[j]
+			if action.Environment == nil {
+				action.Environment = map[string]string{}
+			}
+			action.Environment["HTTP_PROXY"] = httpProxy
+			action.Environment["HTTPS_PROXY"] = httpsProxy
+			action.Environment["NO_PROXY"] = strings.Join(noProxy, ",")
+		}
+	}
+}
+
 func (c *TinkerbellTemplateConfig) ConvertConfigToConfigGenerateStruct() *TinkerbellTemplateConfigGenerate {
 	namespace := defaultEksaNamespace
 	if c.Namespace != "" {
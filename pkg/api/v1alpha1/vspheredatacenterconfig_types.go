@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"errors"
+	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -20,6 +21,10 @@ type VSphereDatacenterConfigSpec struct {
 	Server     string `json:"server"`
 	Thumbprint string `json:"thumbprint"`
 	Insecure   bool   `json:"insecure"`
+	// WorkloadNetwork attaches a second network to the control plane and worker VMs for the node
+	// and pod traffic. The kubelet uses the VM address in this network as node IP, while the control
+	// plane endpoint VIP stays in Network. This field is immutable.
+	WorkloadNetwork string `json:"workloadNetwork,omitempty"`
 }
 
 // VSphereDatacenterConfigStatus defines the observed state of VSphereDatacenterConfig.
@@ -77,6 +82,7 @@ func (v *VSphereDatacenterConfig) ClearPauseAnnotation() {
 
 func (v *VSphereDatacenterConfig) SetDefaults() {
 	v.Spec.Network = generateFullVCenterPath(networkFolderType, v.Spec.Network, v.Spec.Datacenter)
+	v.Spec.WorkloadNetwork = generateFullVCenterPath(networkFolderType, v.Spec.WorkloadNetwork, v.Spec.Datacenter)
 
 	if v.Spec.Insecure {
 		logger.Info("Warning: VSphereDatacenterConfig configured in insecure mode")
@@ -101,6 +107,22 @@ func (v *VSphereDatacenterConfig) Validate() error {
 		return err
 	}
 
+	return v.validateWorkloadNetwork()
+}
+
+func (v *VSphereDatacenterConfig) validateWorkloadNetwork() error {
+	if v.Spec.WorkloadNetwork == "" {
+		return nil
+	}
+
+	if err := validatePath(networkFolderType, v.Spec.WorkloadNetwork, v.Spec.Datacenter); err != nil {
+		return fmt.Errorf("VSphereDatacenterConfig workloadNetwork: %v", err)
+	}
+
+	if v.Spec.WorkloadNetwork == v.Spec.Network {
+		return errors.New("VSphereDatacenterConfig workloadNetwork must be different from network")
+	}
+
 	return nil
 }
 
@@ -136,6 +136,13 @@ func validateImmutableFieldsVSphereCluster(new, old *VSphereDatacenterConfig) fi
 		)
 	}
 
+	if old.Spec.WorkloadNetwork != new.Spec.WorkloadNetwork {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("workloadNetwork"), "field is immutable"),
+		)
+	}
+
 	if old.Spec.Insecure != new.Spec.Insecure {
 		allErrs = append(
 			allErrs,
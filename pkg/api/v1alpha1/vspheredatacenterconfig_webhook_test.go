@@ -48,6 +48,15 @@ func TestVSphereDatacenterValidateUpdateNetworkImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(&vOld)).NotTo(Succeed())
 }
 
+func TestVSphereDatacenterValidateUpdateWorkloadNetworkImmutable(t *testing.T) {
+	vOld := vsphereDatacenterConfig()
+	c := vOld.DeepCopy()
+
+	c.Spec.WorkloadNetwork = "/datacenter/network-2"
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(&vOld)).To(MatchError(ContainSubstring("spec.workloadNetwork: Forbidden: field is immutable")))
+}
+
 func TestVSphereDatacenterValidateUpdateTLSInsecureImmutable(t *testing.T) {
 	vOld := vsphereDatacenterConfig()
 	vOld.Spec.Insecure = true
@@ -166,3 +175,48 @@ func TestVSphereDatacenterValidateCreateFail(t *testing.T) {
 	g := NewWithT(t)
 	g.Expect(dataCenterConfig.ValidateCreate()).NotTo(Succeed())
 }
+
+func TestVSphereDatacenterValidateWorkloadNetwork(t *testing.T) {
+	tests := []struct {
+		name            string
+		workloadNetwork string
+		wantErr         string
+	}{
+		{
+			name:            "valid",
+			workloadNetwork: "/datacenter/network-2",
+		},
+		{
+			name:            "not in datacenter",
+			workloadNetwork: "/other-datacenter/network/network-2",
+			wantErr:         "VSphereDatacenterConfig workloadNetwork: invalid path",
+		},
+		{
+			name:            "same as network",
+			workloadNetwork: "/datacenter/network-1",
+			wantErr:         "VSphereDatacenterConfig workloadNetwork must be different from network",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			dataCenterConfig := vsphereDatacenterConfig()
+			dataCenterConfig.Spec.WorkloadNetwork = tt.workloadNetwork
+			err := dataCenterConfig.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestVSphereDatacenterSetDefaultsWorkloadNetwork(t *testing.T) {
+	g := NewWithT(t)
+	dataCenterConfig := vsphereDatacenterConfig()
+	dataCenterConfig.Spec.WorkloadNetwork = "network-2"
+
+	dataCenterConfig.SetDefaults()
+	g.Expect(dataCenterConfig.Spec.WorkloadNetwork).To(Equal("/datacenter/network/network-2"))
+}
@@ -3,12 +3,12 @@ package v1alpha1
 import (
 	"fmt"
 	"io/ioutil"
-	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
 )
 
 const (
@@ -62,15 +62,15 @@ func GetVSphereMachineConfigs(fileName string) (map[string]*VSphereMachineConfig
 	if err != nil {
 		return nil, fmt.Errorf("unable to read file due to: %v", err)
 	}
-	for _, c := range strings.Split(string(content), YamlSeparator) {
+	for _, doc := range yamlutil.SplitDocuments(content) {
 		var config VSphereMachineConfig
-		if err = yaml.UnmarshalStrict([]byte(c), &config); err == nil {
+		if err = doc.UnmarshalStrict(&config); err == nil {
 			if config.Kind == VSphereMachineConfigKind {
 				configs[config.Name] = &config
 				continue
 			}
 		}
-		_ = yaml.Unmarshal([]byte(c), &config) // this is to check if there is a bad spec in the file
+		_ = yaml.Unmarshal(doc.Content, &config) // this is to check if there is a bad spec in the file
 		if config.Kind == VSphereMachineConfigKind {
 			return nil, fmt.Errorf("unable to unmarshall content from file due to: %v", err)
 		}
@@ -131,16 +131,36 @@ func validateVSphereMachineConfig(config *VSphereMachineConfig) error {
 	if len(config.Spec.ResourcePool) <= 0 {
 		return fmt.Errorf("VSphereMachineConfig %s VM resourcePool is not set or is empty", config.Name)
 	}
-	if config.Spec.OSFamily != Bottlerocket && config.Spec.OSFamily != Ubuntu && config.Spec.OSFamily != RedHat {
-		return fmt.Errorf("VSphereMachineConfig %s osFamily: %s is not supported, please use one of the following: %s, %s, %s", config.Name, config.Spec.OSFamily, Bottlerocket, Ubuntu, RedHat)
+	if config.Spec.OSFamily != Bottlerocket && config.Spec.OSFamily != Ubuntu && config.Spec.OSFamily != RedHat && config.Spec.OSFamily != Windows {
+		return fmt.Errorf("VSphereMachineConfig %s osFamily: %s is not supported, please use one of the following: %s, %s, %s, %s", config.Name, config.Spec.OSFamily, Bottlerocket, Ubuntu, RedHat, Windows)
+	}
+	if err := validateArchitecture(config.Spec.Architecture); err != nil {
+		return fmt.Errorf("VSphereMachineConfig %s: %v", config.Name, err)
+	}
+	if config.Spec.OSFamily == Windows && config.Arch() != AMD64 {
+		return fmt.Errorf("VSphereMachineConfig %s architecture %s is not supported for osFamily %s", config.Name, config.Arch(), Windows)
+	}
+	if config.Spec.OSFamily == Windows && config.Spec.Template == "" {
+		return fmt.Errorf("VSphereMachineConfig %s template is required for osFamily %s, OVAs can't be auto-imported for Windows", config.Name, Windows)
 	}
 	if config.Spec.OSFamily == Bottlerocket && config.Spec.Users[0].Name != bottlerocketDefaultUser {
 		return fmt.Errorf("SSHUsername %s is invalid. Please use 'ec2-user' for Bottlerocket", config.Spec.Users[0].Name)
 	}
+	if err := validateVSphereMachineConfigHostOS(config); err != nil {
+		return fmt.Errorf("VSphereMachineConfig %s: %v", config.Name, err)
+	}
 
 	return nil
 }
 
+// validateVSphereMachineConfigHostOS validates the host OS tuning of the machines.
+func validateVSphereMachineConfigHostOS(config *VSphereMachineConfig) error {
+	if err := ValidateHostOSConfiguration(config.Spec.HostOSConfiguration, config.Spec.OSFamily); err != nil {
+		return err
+	}
+	return ValidateEtcdDiskConfiguration(config.Spec.EtcdDisk, config.Spec.OSFamily)
+}
+
 func validateVSphereMachineConfigHasTemplate(config *VSphereMachineConfig) error {
 	if config.Spec.Template == "" {
 		return fmt.Errorf("template field is required")
@@ -352,6 +352,56 @@ func TestVSphereMachineConfigValidate(t *testing.T) {
 			},
 			wantErr: "VSphereMachineConfig test osFamily: suse is not supported, please use one of the following: bottlerocket, ubuntu",
 		},
+		{
+			name: "unsupported architecture",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Architecture: "ppc64le",
+					Users: []UserConfiguration{
+						{
+							Name: "capv",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+				},
+			},
+			wantErr: "VSphereMachineConfig test: architecture ppc64le is not supported",
+		},
+		{
+			name: "windows without template",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "windows",
+					Users: []UserConfiguration{
+						{
+							Name: "capv",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+				},
+			},
+			wantErr: "VSphereMachineConfig test template is required for osFamily windows",
+		},
 		{
 			name: "invalid ssh username",
 			obj: &VSphereMachineConfig{
@@ -377,6 +427,262 @@ func TestVSphereMachineConfigValidate(t *testing.T) {
 			},
 			wantErr: "SSHUsername test is invalid",
 		},
+		{
+			name: "valid host os configuration",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Users: []UserConfiguration{
+						{
+							Name: "capv",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					HostOSConfiguration: &HostOSConfiguration{
+						KernelModules: []string{"br_netfilter", "sctp"},
+						Sysctls:       map[string]string{"net.ipv4.ip_forward": "1"},
+					},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "host os configuration on bottlerocket",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "bottlerocket",
+					Users: []UserConfiguration{
+						{
+							Name: "ec2-user",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					HostOSConfiguration: &HostOSConfiguration{
+						KernelModules: []string{"sctp"},
+					},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid host os configuration kernel module",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Users: []UserConfiguration{
+						{
+							Name: "capv",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					HostOSConfiguration: &HostOSConfiguration{
+						KernelModules: []string{"sctp && reboot"},
+					},
+				},
+			},
+			wantErr: "hostOSConfiguration.kernelModules: invalid kernel module name \"sctp && reboot\"",
+		},
+		{
+			name: "invalid host os configuration sysctl key",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Users: []UserConfiguration{
+						{
+							Name: "capv",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					HostOSConfiguration: &HostOSConfiguration{
+						Sysctls: map[string]string{"ip_forward": "1"},
+					},
+				},
+			},
+			wantErr: "hostOSConfiguration.sysctls: invalid key \"ip_forward\"",
+		},
+		{
+			name: "invalid host os configuration sysctl value",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Users: []UserConfiguration{
+						{
+							Name: "capv",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					HostOSConfiguration: &HostOSConfiguration{
+						Sysctls: map[string]string{"net.ipv4.ip_forward": "1\nkernel.panic = 1"},
+					},
+				},
+			},
+			wantErr: "hostOSConfiguration.sysctls: invalid value for key net.ipv4.ip_forward",
+		},
+		{
+			name: "etcd disk",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Users: []UserConfiguration{
+						{
+							Name: "capv",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					EtcdDisk: &EtcdDiskConfiguration{
+						SizeGiB:        20,
+						IOScheduler:    "mq-deadline",
+						HighIOPriority: true,
+					},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "etcd disk on bottlerocket",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "bottlerocket",
+					Users: []UserConfiguration{
+						{
+							Name: "ec2-user",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					EtcdDisk: &EtcdDiskConfiguration{
+						SizeGiB: 20,
+					},
+				},
+			},
+			wantErr: "VSphereMachineConfig test: etcdDisk is not supported for osFamily bottlerocket",
+		},
+		{
+			name: "etcd disk io scheduler without data disk",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Users: []UserConfiguration{
+						{
+							Name: "capv",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					EtcdDisk: &EtcdDiskConfiguration{
+						IOScheduler: "none",
+					},
+				},
+			},
+			wantErr: "etcdDisk.ioScheduler requires etcdDisk.sizeGiB",
+		},
+		{
+			name: "invalid etcd disk io scheduler",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Users: []UserConfiguration{
+						{
+							Name: "capv",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					EtcdDisk: &EtcdDiskConfiguration{
+						SizeGiB:     20,
+						IOScheduler: "cfq",
+					},
+				},
+			},
+			wantErr: "etcdDisk.ioScheduler: cfq is not supported, please use one of the following: none, mq-deadline, bfq, kyber",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -390,3 +696,34 @@ func TestVSphereMachineConfigValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestHostOSConfigurationEqual(t *testing.T) {
+	g := NewWithT(t)
+	config := &HostOSConfiguration{
+		KernelModules: []string{"sctp"},
+		Sysctls:       map[string]string{"net.ipv4.ip_forward": "1"},
+	}
+	g.Expect(config.Equal(config.DeepCopy())).To(BeTrue())
+	g.Expect((*HostOSConfiguration)(nil).Equal(&HostOSConfiguration{Sysctls: map[string]string{}})).To(BeTrue())
+	g.Expect(config.Equal(nil)).To(BeFalse())
+	g.Expect(config.Equal(&HostOSConfiguration{KernelModules: []string{"sctp"}})).To(BeFalse())
+}
+
+func TestEtcdDiskConfigurationEqual(t *testing.T) {
+	g := NewWithT(t)
+	config := &EtcdDiskConfiguration{SizeGiB: 20, IOScheduler: "none"}
+	g.Expect(config.Equal(config.DeepCopy())).To(BeTrue())
+	g.Expect((*EtcdDiskConfiguration)(nil).Equal(&EtcdDiskConfiguration{})).To(BeTrue())
+	g.Expect(config.Equal(nil)).To(BeFalse())
+	g.Expect(config.Equal(&EtcdDiskConfiguration{SizeGiB: 20})).To(BeFalse())
+}
+
+func TestSerialConsoleConfigurationEqual(t *testing.T) {
+	g := NewWithT(t)
+	config := &SerialConsoleConfiguration{Enabled: true}
+	g.Expect(config.IsEnabled()).To(BeTrue())
+	g.Expect((*SerialConsoleConfiguration)(nil).IsEnabled()).To(BeFalse())
+	g.Expect(config.Equal(config.DeepCopy())).To(BeTrue())
+	g.Expect((*SerialConsoleConfiguration)(nil).Equal(&SerialConsoleConfiguration{})).To(BeTrue())
+	g.Expect(config.Equal(nil)).To(BeFalse())
+}
@@ -16,6 +16,16 @@ type VSphereMachineConfigSpec struct {
 	StoragePolicyName string              `json:"storagePolicyName,omitempty"`
 	Template          string              `json:"template,omitempty"`
 	Users             []UserConfiguration `json:"users,omitempty"`
+	// Architecture of the template. Supported values are amd64 and arm64. Defaults to amd64.
+	Architecture Architecture `json:"architecture,omitempty"`
+	// HostOSConfiguration loads kernel modules and sets sysctls in the nodes. Not supported for Bottlerocket and Windows.
+	HostOSConfiguration *HostOSConfiguration `json:"hostOSConfiguration,omitempty"`
+	// EtcdDisk tunes the storage of the etcd data. Only supported for the external etcd machines.
+	// Not supported for Bottlerocket.
+	EtcdDisk *EtcdDiskConfiguration `json:"etcdDisk,omitempty"`
+	// SerialConsole writes the output of the VMs serial port to a serial-console.log file in the VM
+	// directory of their datastore, which the support bundles collect.
+	SerialConsole *SerialConsoleConfiguration `json:"serialConsole,omitempty"`
 }
 
 func (c *VSphereMachineConfig) PauseReconcile() {
@@ -70,6 +80,11 @@ func (c *VSphereMachineConfig) OSFamily() OSFamily {
 	return c.Spec.OSFamily
 }
 
+// Arch returns the architecture of the machines, DefaultArchitecture if not set.
+func (c *VSphereMachineConfig) Arch() Architecture {
+	return ArchitectureOrDefault(c.Spec.Architecture)
+}
+
 func (c *VSphereMachineConfig) GetNamespace() string {
 	return c.Namespace
 }
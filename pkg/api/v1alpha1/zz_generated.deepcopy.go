@@ -22,6 +22,7 @@ package v1alpha1
 import (
 	apiv1beta1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -223,6 +224,42 @@ func (in *AWSIamConfigStatus) DeepCopy() *AWSIamConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedChanges) DeepCopyInto(out *AppliedChanges) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.ChangedFields != nil {
+		in, out := &in.ChangedFields, &out.ChangedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Created != nil {
+		in, out := &in.Created, &out.Created
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Updated != nil {
+		in, out := &in.Updated, &out.Updated
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deleted != nil {
+		in, out := &in.Deleted, &out.Deleted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedChanges.
+func (in *AppliedChanges) DeepCopy() *AppliedChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalingConfiguration) DeepCopyInto(out *AutoScalingConfiguration) {
 	*out = *in
@@ -238,6 +275,119 @@ func (in *AutoScalingConfiguration) DeepCopy() *AutoScalingConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupConfiguration) DeepCopyInto(out *BackupConfiguration) {
+	*out = *in
+	out.StorageLocation = in.StorageLocation
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]BackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfiguration.
+func (in *BackupConfiguration) DeepCopy() *BackupConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSchedule) DeepCopyInto(out *BackupSchedule) {
+	*out = *in
+	if in.IncludedNamespaces != nil {
+		in, out := &in.IncludedNamespaces, &out.IncludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedNamespaces != nil {
+		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSchedule.
+func (in *BackupSchedule) DeepCopy() *BackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStorageLocation) DeepCopyInto(out *BackupStorageLocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStorageLocation.
+func (in *BackupStorageLocation) DeepCopy() *BackupStorageLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStorageLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapManifest) DeepCopyInto(out *BootstrapManifest) {
+	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(BootstrapManifestGitRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapManifest.
+func (in *BootstrapManifest) DeepCopy() *BootstrapManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapManifestGitRef) DeepCopyInto(out *BootstrapManifestGitRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapManifestGitRef.
+func (in *BootstrapManifestGitRef) DeepCopy() *BootstrapManifestGitRef {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapManifestGitRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BottlerocketUpdatesConfiguration) DeepCopyInto(out *BottlerocketUpdatesConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketUpdatesConfiguration.
+func (in *BottlerocketUpdatesConfiguration) DeepCopy() *BottlerocketUpdatesConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BottlerocketUpdatesConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundlesRef) DeepCopyInto(out *BundlesRef) {
 	*out = *in
@@ -278,6 +428,37 @@ func (in *CNIConfig) DeepCopy() *CNIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerConfiguration) DeepCopyInto(out *CertManagerConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerConfiguration.
+func (in *CertManagerConfiguration) DeepCopy() *CertManagerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CiliumConfig) DeepCopyInto(out *CiliumConfig) {
 	*out = *in
@@ -723,6 +904,71 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(BundlesRef)
 		**out = **in
 	}
+	if in.EksdReleasePins != nil {
+		in, out := &in.EksdReleasePins, &out.EksdReleasePins
+		*out = make([]EksdReleasePin, len(*in))
+		copy(*out, *in)
+	}
+	if in.HelmValuesOverrides != nil {
+		in, out := &in.HelmValuesOverrides, &out.HelmValuesOverrides
+		*out = make([]HelmValuesOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManagedComponents != nil {
+		in, out := &in.ManagedComponents, &out.ManagedComponents
+		*out = make([]ManagedComponentConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerConfiguration)
+		**out = **in
+	}
+	if in.KubeletConfiguration != nil {
+		in, out := &in.KubeletConfiguration, &out.KubeletConfiguration
+		*out = new(KubeletConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CoreDNSConfiguration != nil {
+		in, out := &in.CoreDNSConfiguration, &out.CoreDNSConfiguration
+		*out = new(CoreDNSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootstrapManifests != nil {
+		in, out := &in.BootstrapManifests, &out.BootstrapManifests
+		*out = make([]BootstrapManifest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BottlerocketUpdates != nil {
+		in, out := &in.BottlerocketUpdates, &out.BottlerocketUpdates
+		*out = new(BottlerocketUpdatesConfiguration)
+		**out = **in
+	}
+	if in.EtcdEncryption != nil {
+		in, out := &in.EtcdEncryption, &out.EtcdEncryption
+		*out = make([]EtcdEncryption, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -755,6 +1001,37 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(NotificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastAppliedChanges != nil {
+		in, out := &in.LastAppliedChanges, &out.LastAppliedChanges
+		*out = new(AppliedChanges)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineFailures != nil {
+		in, out := &in.MachineFailures, &out.MachineFailures
+		*out = make([]MachineFailureStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EtcdEncryptionRewrites != nil {
+		in, out := &in.EtcdEncryptionRewrites, &out.EtcdEncryptionRewrites
+		*out = make([]EtcdEncryptionRewriteStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -767,13 +1044,35 @@ func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdConfiguration) DeepCopyInto(out *ContainerdConfiguration) {
+	*out = *in
+	if in.RegistryHeaders != nil {
+		in, out := &in.RegistryHeaders, &out.RegistryHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdConfiguration.
+func (in *ContainerdConfiguration) DeepCopy() *ContainerdConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneConfiguration) DeepCopyInto(out *ControlPlaneConfiguration) {
 	*out = *in
 	if in.Endpoint != nil {
 		in, out := &in.Endpoint, &out.Endpoint
 		*out = new(Endpoint)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.MachineGroupRef != nil {
 		in, out := &in.MachineGroupRef, &out.MachineGroupRef
@@ -794,6 +1093,26 @@ func (in *ControlPlaneConfiguration) DeepCopyInto(out *ControlPlaneConfiguration
 			(*out)[key] = val
 		}
 	}
+	if in.UpgradeRolloutStrategy != nil {
+		in, out := &in.UpgradeRolloutStrategy, &out.UpgradeRolloutStrategy
+		*out = new(ControlPlaneUpgradeRolloutStrategy)
+		**out = **in
+	}
+	if in.CertSANs != nil {
+		in, out := &in.CertSANs, &out.CertSANs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StaticPodManifests != nil {
+		in, out := &in.StaticPodManifests, &out.StaticPodManifests
+		*out = make([]StaticPodManifest, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContainerdConfiguration != nil {
+		in, out := &in.ContainerdConfiguration, &out.ContainerdConfiguration
+		*out = new(ContainerdConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfiguration.
@@ -806,6 +1125,84 @@ func (in *ControlPlaneConfiguration) DeepCopy() *ControlPlaneConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneRollingUpdateParams) DeepCopyInto(out *ControlPlaneRollingUpdateParams) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneRollingUpdateParams.
+func (in *ControlPlaneRollingUpdateParams) DeepCopy() *ControlPlaneRollingUpdateParams {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneRollingUpdateParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopyInto(out *ControlPlaneUpgradeRolloutStrategy) {
+	*out = *in
+	out.RollingUpdate = in.RollingUpdate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeRolloutStrategy.
+func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopy() *ControlPlaneUpgradeRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneUpgradeRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSConfiguration) DeepCopyInto(out *CoreDNSConfiguration) {
+	*out = *in
+	if in.UpstreamServers != nil {
+		in, out := &in.UpstreamServers, &out.UpstreamServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StubDomains != nil {
+		in, out := &in.StubDomains, &out.StubDomains
+		*out = make([]CoreDNSStubDomain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSConfiguration.
+func (in *CoreDNSConfiguration) DeepCopy() *CoreDNSConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSStubDomain) DeepCopyInto(out *CoreDNSStubDomain) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSStubDomain.
+func (in *CoreDNSStubDomain) DeepCopy() *CoreDNSStubDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSStubDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNS) DeepCopyInto(out *DNS) {
 	*out = *in
@@ -915,6 +1312,21 @@ func (in *DockerDatacenterConfigStatus) DeepCopy() *DockerDatacenterConfigStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EksdReleasePin) DeepCopyInto(out *EksdReleasePin) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EksdReleasePin.
+func (in *EksdReleasePin) DeepCopy() *EksdReleasePin {
+	if in == nil {
+		return nil
+	}
+	out := new(EksdReleasePin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EksdReleaseRef) DeepCopyInto(out *EksdReleaseRef) {
 	*out = *in
@@ -933,6 +1345,11 @@ func (in *EksdReleaseRef) DeepCopy() *EksdReleaseRef {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
+	if in.KubeVip != nil {
+		in, out := &in.KubeVip, &out.KubeVip
+		*out = new(KubeVipConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
@@ -945,6 +1362,89 @@ func (in *Endpoint) DeepCopy() *Endpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdDiskConfiguration) DeepCopyInto(out *EtcdDiskConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdDiskConfiguration.
+func (in *EtcdDiskConfiguration) DeepCopy() *EtcdDiskConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdDiskConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdEncryption) DeepCopyInto(out *EtcdEncryption) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]EtcdEncryptionProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeyRotationPeriod != nil {
+		in, out := &in.KeyRotationPeriod, &out.KeyRotationPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdEncryption.
+func (in *EtcdEncryption) DeepCopy() *EtcdEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdEncryptionProvider) DeepCopyInto(out *EtcdEncryptionProvider) {
+	*out = *in
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(KMS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdEncryptionProvider.
+func (in *EtcdEncryptionProvider) DeepCopy() *EtcdEncryptionProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdEncryptionProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdEncryptionRewriteStatus) DeepCopyInto(out *EtcdEncryptionRewriteStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdEncryptionRewriteStatus.
+func (in *EtcdEncryptionRewriteStatus) DeepCopy() *EtcdEncryptionRewriteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdEncryptionRewriteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalEtcdConfiguration) DeepCopyInto(out *ExternalEtcdConfiguration) {
 	*out = *in
@@ -1046,7 +1546,7 @@ func (in *FluxConfigSpec) DeepCopyInto(out *FluxConfigSpec) {
 	if in.Github != nil {
 		in, out := &in.Github, &out.Github
 		*out = new(GithubProviderConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
@@ -1145,108 +1645,336 @@ func (in *GitOpsConfigSpec) DeepCopyInto(out *GitOpsConfigSpec) {
 	out.Flux = in.Flux
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsConfigSpec.
-func (in *GitOpsConfigSpec) DeepCopy() *GitOpsConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsConfigSpec.
+func (in *GitOpsConfigSpec) DeepCopy() *GitOpsConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsConfigStatus) DeepCopyInto(out *GitOpsConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsConfigStatus.
+func (in *GitOpsConfigStatus) DeepCopy() *GitOpsConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitProviderConfig) DeepCopyInto(out *GitProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitProviderConfig.
+func (in *GitProviderConfig) DeepCopy() *GitProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GitProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Github) DeepCopyInto(out *Github) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Github.
+func (in *Github) DeepCopy() *Github {
+	if in == nil {
+		return nil
+	}
+	out := new(Github)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GithubAppConfig) DeepCopyInto(out *GithubAppConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubAppConfig.
+func (in *GithubAppConfig) DeepCopy() *GithubAppConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GithubAppConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GithubProviderConfig) DeepCopyInto(out *GithubProviderConfig) {
+	*out = *in
+	if in.App != nil {
+		in, out := &in.App, &out.App
+		*out = new(GithubAppConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubProviderConfig.
+func (in *GithubProviderConfig) DeepCopy() *GithubProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GithubProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in HardwareSelector) DeepCopyInto(out *HardwareSelector) {
+	{
+		in := &in
+		*out = make(HardwareSelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareSelector.
+func (in HardwareSelector) DeepCopy() HardwareSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(HardwareSelector)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmValuesOverride) DeepCopyInto(out *HelmValuesOverride) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmValuesOverride.
+func (in *HelmValuesOverride) DeepCopy() *HelmValuesOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmValuesOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOSConfiguration) DeepCopyInto(out *HostOSConfiguration) {
+	*out = *in
+	if in.KernelModules != nil {
+		in, out := &in.KernelModules, &out.KernelModules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOSConfiguration.
+func (in *HostOSConfiguration) DeepCopy() *HostOSConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOSConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPool) DeepCopyInto(out *IPPool) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPool.
+func (in *IPPool) DeepCopy() *IPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageCredentialProvider) DeepCopyInto(out *ImageCredentialProvider) {
+	*out = *in
+	if in.MatchImages != nil {
+		in, out := &in.MatchImages, &out.MatchImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageCredentialProvider.
+func (in *ImageCredentialProvider) DeepCopy() *ImageCredentialProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(GitOpsConfigSpec)
+	out := new(ImageCredentialProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitOpsConfigStatus) DeepCopyInto(out *GitOpsConfigStatus) {
+func (in *KMS) DeepCopyInto(out *KMS) {
 	*out = *in
+	if in.CacheSize != nil {
+		in, out := &in.CacheSize, &out.CacheSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsConfigStatus.
-func (in *GitOpsConfigStatus) DeepCopy() *GitOpsConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMS.
+func (in *KMS) DeepCopy() *KMS {
 	if in == nil {
 		return nil
 	}
-	out := new(GitOpsConfigStatus)
+	out := new(KMS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitProviderConfig) DeepCopyInto(out *GitProviderConfig) {
+func (in *KindnetdConfig) DeepCopyInto(out *KindnetdConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitProviderConfig.
-func (in *GitProviderConfig) DeepCopy() *GitProviderConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KindnetdConfig.
+func (in *KindnetdConfig) DeepCopy() *KindnetdConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GitProviderConfig)
+	out := new(KindnetdConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Github) DeepCopyInto(out *Github) {
+func (in *KubeVipConfiguration) DeepCopyInto(out *KubeVipConfiguration) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Github.
-func (in *Github) DeepCopy() *Github {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVipConfiguration.
+func (in *KubeVipConfiguration) DeepCopy() *KubeVipConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(Github)
+	out := new(KubeVipConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GithubProviderConfig) DeepCopyInto(out *GithubProviderConfig) {
+func (in *KubeletConfiguration) DeepCopyInto(out *KubeletConfiguration) {
 	*out = *in
+	if in.ImageCredentialProviders != nil {
+		in, out := &in.ImageCredentialProviders, &out.ImageCredentialProviders
+		*out = make([]ImageCredentialProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubProviderConfig.
-func (in *GithubProviderConfig) DeepCopy() *GithubProviderConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletConfiguration.
+func (in *KubeletConfiguration) DeepCopy() *KubeletConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(GithubProviderConfig)
+	out := new(KubeletConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in HardwareSelector) DeepCopyInto(out *HardwareSelector) {
-	{
-		in := &in
-		*out = make(HardwareSelector, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+func (in *MachineFailureStatus) DeepCopyInto(out *MachineFailureStatus) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareSelector.
-func (in HardwareSelector) DeepCopy() HardwareSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineFailureStatus.
+func (in *MachineFailureStatus) DeepCopy() *MachineFailureStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HardwareSelector)
+	out := new(MachineFailureStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KindnetdConfig) DeepCopyInto(out *KindnetdConfig) {
+func (in *ManagedComponentConfiguration) DeepCopyInto(out *ManagedComponentConfiguration) {
 	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KindnetdConfig.
-func (in *KindnetdConfig) DeepCopy() *KindnetdConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedComponentConfiguration.
+func (in *ManagedComponentConfiguration) DeepCopy() *ManagedComponentConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(KindnetdConfig)
+	out := new(ManagedComponentConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1306,6 +2034,21 @@ func (in *MapUsers) DeepCopy() *MapUsers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeNetworkInterfaces) DeepCopyInto(out *NodeNetworkInterfaces) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeNetworkInterfaces.
+func (in *NodeNetworkInterfaces) DeepCopy() *NodeNetworkInterfaces {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeNetworkInterfaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Nodes) DeepCopyInto(out *Nodes) {
 	*out = *in
@@ -1326,6 +2069,31 @@ func (in *Nodes) DeepCopy() *Nodes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationStatus) DeepCopyInto(out *NotificationStatus) {
+	*out = *in
+	if in.UnhealthyMachines != nil {
+		in, out := &in.UnhealthyMachines, &out.UnhealthyMachines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificateExpiringMachines != nil {
+		in, out := &in.CertificateExpiringMachines, &out.CertificateExpiringMachines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationStatus.
+func (in *NotificationStatus) DeepCopy() *NotificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NutanixDatacenterConfig) DeepCopyInto(out *NutanixDatacenterConfig) {
 	*out = *in
@@ -1794,6 +2562,21 @@ func (in *ResolvConf) DeepCopy() *ResolvConf {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SerialConsoleConfiguration) DeepCopyInto(out *SerialConsoleConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SerialConsoleConfiguration.
+func (in *SerialConsoleConfiguration) DeepCopy() *SerialConsoleConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SerialConsoleConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Services) DeepCopyInto(out *Services) {
 	*out = *in
@@ -1904,6 +2687,125 @@ func (in *SnowDatacenterConfigStatus) DeepCopy() *SnowDatacenterConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowDirectNetworkInterface) DeepCopyInto(out *SnowDirectNetworkInterface) {
+	*out = *in
+	if in.VlanID != nil {
+		in, out := &in.VlanID, &out.VlanID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IPPoolRef != nil {
+		in, out := &in.IPPoolRef, &out.IPPoolRef
+		*out = new(Ref)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowDirectNetworkInterface.
+func (in *SnowDirectNetworkInterface) DeepCopy() *SnowDirectNetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowDirectNetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowIPPool) DeepCopyInto(out *SnowIPPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowIPPool.
+func (in *SnowIPPool) DeepCopy() *SnowIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnowIPPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowIPPoolList) DeepCopyInto(out *SnowIPPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SnowIPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowIPPoolList.
+func (in *SnowIPPoolList) DeepCopy() *SnowIPPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowIPPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnowIPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowIPPoolSpec) DeepCopyInto(out *SnowIPPoolSpec) {
+	*out = *in
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]IPPool, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowIPPoolSpec.
+func (in *SnowIPPoolSpec) DeepCopy() *SnowIPPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowIPPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowIPPoolStatus) DeepCopyInto(out *SnowIPPoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowIPPoolStatus.
+func (in *SnowIPPoolStatus) DeepCopy() *SnowIPPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowIPPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnowMachineConfig) DeepCopyInto(out *SnowMachineConfig) {
 	*out = *in
@@ -1976,6 +2878,7 @@ func (in *SnowMachineConfigSpec) DeepCopyInto(out *SnowMachineConfigSpec) {
 		*out = new(apiv1beta1.Volume)
 		**out = **in
 	}
+	in.Network.DeepCopyInto(&out.Network)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowMachineConfigSpec.
@@ -2008,6 +2911,43 @@ func (in *SnowMachineConfigStatus) DeepCopy() *SnowMachineConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowNetwork) DeepCopyInto(out *SnowNetwork) {
+	*out = *in
+	if in.DirectNetworkInterfaces != nil {
+		in, out := &in.DirectNetworkInterfaces, &out.DirectNetworkInterfaces
+		*out = make([]SnowDirectNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowNetwork.
+func (in *SnowNetwork) DeepCopy() *SnowNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticPodManifest) DeepCopyInto(out *StaticPodManifest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticPodManifest.
+func (in *StaticPodManifest) DeepCopy() *StaticPodManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticPodManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in SymlinkMaps) DeepCopyInto(out *SymlinkMaps) {
 	{
@@ -2034,7 +2974,7 @@ func (in *TinkerbellDatacenterConfig) DeepCopyInto(out *TinkerbellDatacenterConf
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -2091,6 +3031,11 @@ func (in *TinkerbellDatacenterConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TinkerbellDatacenterConfigSpec) DeepCopyInto(out *TinkerbellDatacenterConfigSpec) {
 	*out = *in
+	if in.SerialConsole != nil {
+		in, out := &in.SerialConsole, &out.SerialConsole
+		*out = new(SerialConsoleConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TinkerbellDatacenterConfigSpec.
@@ -2195,6 +3140,16 @@ func (in *TinkerbellMachineConfigSpec) DeepCopyInto(out *TinkerbellMachineConfig
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HostOSConfiguration != nil {
+		in, out := &in.HostOSConfiguration, &out.HostOSConfiguration
+		*out = new(HostOSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkInterfaces != nil {
+		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
+		*out = new(NodeNetworkInterfaces)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TinkerbellMachineConfigSpec.
@@ -2495,6 +3450,21 @@ func (in *VSphereMachineConfigSpec) DeepCopyInto(out *VSphereMachineConfigSpec)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HostOSConfiguration != nil {
+		in, out := &in.HostOSConfiguration, &out.HostOSConfiguration
+		*out = new(HostOSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EtcdDisk != nil {
+		in, out := &in.EtcdDisk, &out.EtcdDisk
+		*out = new(EtcdDiskConfiguration)
+		**out = **in
+	}
+	if in.SerialConsole != nil {
+		in, out := &in.SerialConsole, &out.SerialConsole
+		*out = new(SerialConsoleConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineConfigSpec.
@@ -2554,6 +3524,16 @@ func (in *WorkerNodeGroupConfiguration) DeepCopyInto(out *WorkerNodeGroupConfigu
 			(*out)[key] = val
 		}
 	}
+	if in.UpgradeRolloutStrategy != nil {
+		in, out := &in.UpgradeRolloutStrategy, &out.UpgradeRolloutStrategy
+		*out = new(WorkerNodesUpgradeRolloutStrategy)
+		**out = **in
+	}
+	if in.ContainerdConfiguration != nil {
+		in, out := &in.ContainerdConfiguration, &out.ContainerdConfiguration
+		*out = new(ContainerdConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodeGroupConfiguration.
@@ -2565,3 +3545,34 @@ func (in *WorkerNodeGroupConfiguration) DeepCopy() *WorkerNodeGroupConfiguration
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerNodesRollingUpdateParams) DeepCopyInto(out *WorkerNodesRollingUpdateParams) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodesRollingUpdateParams.
+func (in *WorkerNodesRollingUpdateParams) DeepCopy() *WorkerNodesRollingUpdateParams {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerNodesRollingUpdateParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerNodesUpgradeRolloutStrategy) DeepCopyInto(out *WorkerNodesUpgradeRolloutStrategy) {
+	*out = *in
+	out.RollingUpdate = in.RollingUpdate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodesUpgradeRolloutStrategy.
+func (in *WorkerNodesUpgradeRolloutStrategy) DeepCopy() *WorkerNodesUpgradeRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerNodesUpgradeRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
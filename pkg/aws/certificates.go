@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CABundleExpiry returns the earliest expiration date among the certificates in the PEM encoded bundle.
+// Snow devices stop accepting connections once their certificate expires.
+func CABundleExpiry(bundle []byte) (time.Time, error) {
+	var expiry time.Time
+	found := false
+	for block, rest := pem.Decode(bundle); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parsing certificate: %v", err)
+		}
+		if !found || cert.NotAfter.Before(expiry) {
+			expiry = cert.NotAfter
+		}
+		found = true
+	}
+
+	if !found {
+		return time.Time{}, errors.New("no certificate found in CA bundle")
+	}
+
+	return expiry, nil
+}
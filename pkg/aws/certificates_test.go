@@ -0,0 +1,33 @@
+package aws_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/aws"
+)
+
+func TestCABundleExpiry(t *testing.T) {
+	g := NewWithT(t)
+	bundle, err := os.ReadFile("testdata/valid_certificates")
+	g.Expect(err).To(Succeed())
+
+	expiry, err := aws.CABundleExpiry(bundle)
+	g.Expect(err).To(Succeed())
+	g.Expect(expiry).To(Equal(time.Date(2025, time.December, 16, 22, 7, 58, 0, time.UTC)))
+}
+
+func TestCABundleExpiryNoCertificate(t *testing.T) {
+	g := NewWithT(t)
+	_, err := aws.CABundleExpiry([]byte("not a certificate"))
+	g.Expect(err).To(MatchError("no certificate found in CA bundle"))
+}
+
+func TestCABundleExpiryInvalidCertificate(t *testing.T) {
+	g := NewWithT(t)
+	_, err := aws.CABundleExpiry([]byte("-----BEGIN CERTIFICATE-----\nYWJj\n-----END CERTIFICATE-----\n"))
+	g.Expect(err).To(MatchError(ContainSubstring("parsing certificate")))
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/smithy-go"
 )
 
@@ -14,6 +16,10 @@ type EC2Client interface {
 	DescribeImages(ctx context.Context, params *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error)
 	DescribeKeyPairs(ctx context.Context, params *ec2.DescribeKeyPairsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error)
 	ImportKeyPair(ctx context.Context, params *ec2.ImportKeyPairInput, optFns ...func(*ec2.Options)) (*ec2.ImportKeyPairOutput, error)
+	DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error)
+	TerminateInstances(ctx context.Context, params *ec2.TerminateInstancesInput, optFns ...func(*ec2.Options)) (*ec2.TerminateInstancesOutput, error)
+	CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error)
+	DeleteTags(ctx context.Context, params *ec2.DeleteTagsInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error)
 }
 
 func NewEC2Client(config aws.Config) *ec2.Client {
@@ -70,3 +76,79 @@ func (c *Client) EC2ImportKeyPair(ctx context.Context, keyName string, keyMateri
 	}
 	return nil
 }
+
+// EC2TerminateInstances calls aws sdk ec2.TerminateInstances to terminate the instances with ids.
+func (c *Client) EC2TerminateInstances(ctx context.Context, ids []string) error {
+	params := &ec2.TerminateInstancesInput{
+		InstanceIds: ids,
+	}
+	if _, err := c.ec2.TerminateInstances(ctx, params); err != nil {
+		return fmt.Errorf("terminating ec2 instances: %v", err)
+	}
+	return nil
+}
+
+// EC2InstanceTagsByTag calls aws sdk ec2.DescribeInstances to fetch the tags of the instances
+// that haven't been terminated and are tagged with key=value, keyed by instance id.
+func (c *Client) EC2InstanceTagsByTag(ctx context.Context, key, value string) (map[string]map[string]string, error) {
+	params := &ec2.DescribeInstancesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("tag:" + key), Values: []string{value}},
+			{Name: aws.String("instance-state-name"), Values: []string{"pending", "running", "stopping", "stopped"}},
+		},
+	}
+
+	instanceTags := map[string]map[string]string{}
+	for {
+		out, err := c.ec2.DescribeInstances(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("aws describe instances [tag=%s:%s]: %v", key, value, err)
+		}
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				tags := make(map[string]string, len(instance.Tags))
+				for _, tag := range instance.Tags {
+					tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+				}
+				instanceTags[aws.ToString(instance.InstanceId)] = tags
+			}
+		}
+		if out.NextToken == nil {
+			return instanceTags, nil
+		}
+		params.NextToken = out.NextToken
+	}
+}
+
+// EC2CreateTags calls aws sdk ec2.CreateTags to add or overwrite the tags of the instances with ids.
+func (c *Client) EC2CreateTags(ctx context.Context, ids []string, tags map[string]string) error {
+	params := &ec2.CreateTagsInput{
+		Resources: ids,
+	}
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		params.Tags = append(params.Tags, types.Tag{Key: aws.String(key), Value: aws.String(tags[key])})
+	}
+	if _, err := c.ec2.CreateTags(ctx, params); err != nil {
+		return fmt.Errorf("creating ec2 tags: %v", err)
+	}
+	return nil
+}
+
+// EC2DeleteTags calls aws sdk ec2.DeleteTags to remove the tags with keys from the instances with ids.
+func (c *Client) EC2DeleteTags(ctx context.Context, ids []string, keys []string) error {
+	params := &ec2.DeleteTagsInput{
+		Resources: ids,
+	}
+	for _, key := range keys {
+		params.Tags = append(params.Tags, types.Tag{Key: aws.String(key)})
+	}
+	if _, err := c.ec2.DeleteTags(ctx, params); err != nil {
+		return fmt.Errorf("deleting ec2 tags: %v", err)
+	}
+	return nil
+}
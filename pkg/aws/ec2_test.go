@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	awstypes "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/golang/mock/gomock"
@@ -116,3 +117,80 @@ func TestEC2ImportKeyPair(t *testing.T) {
 	err := g.client.EC2ImportKeyPair(g.ctx, key, val)
 	g.Expect(err).To(Succeed())
 }
+
+func TestEC2TerminateInstances(t *testing.T) {
+	g := newEC2Test(t)
+	params := &ec2.TerminateInstancesInput{
+		InstanceIds: []string{"i-1"},
+	}
+	g.ec2.EXPECT().TerminateInstances(g.ctx, params).Return(nil, errors.New("error"))
+	err := g.client.EC2TerminateInstances(g.ctx, []string{"i-1"})
+	g.Expect(err).To(MatchError(ContainSubstring("terminating ec2 instances: error")))
+}
+
+func TestEC2InstanceTagsByTag(t *testing.T) {
+	g := newEC2Test(t)
+	token := "next"
+	instance := func(id string, tags ...types.Tag) types.Instance {
+		return types.Instance{InstanceId: &id, Tags: tags}
+	}
+	tag := func(key, value string) types.Tag {
+		return types.Tag{Key: &key, Value: &value}
+	}
+	g.ec2.EXPECT().DescribeInstances(g.ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, params *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			g.Expect(*params.Filters[0].Name).To(Equal("tag:owner"))
+			g.Expect(params.Filters[0].Values).To(ConsistOf("cluster"))
+			g.Expect(params.NextToken).To(BeNil())
+			return &ec2.DescribeInstancesOutput{
+				Reservations: []types.Reservation{{Instances: []types.Instance{instance("i-1", tag("owner", "cluster"), tag("env", "prod"))}}},
+				NextToken:    &token,
+			}, nil
+		},
+	)
+	g.ec2.EXPECT().DescribeInstances(g.ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, params *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			g.Expect(params.NextToken).To(Equal(&token))
+			return &ec2.DescribeInstancesOutput{
+				Reservations: []types.Reservation{{Instances: []types.Instance{instance("i-2", tag("owner", "cluster"))}}},
+			}, nil
+		},
+	)
+	got, err := g.client.EC2InstanceTagsByTag(g.ctx, "owner", "cluster")
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal(map[string]map[string]string{
+		"i-1": {"owner": "cluster", "env": "prod"},
+		"i-2": {"owner": "cluster"},
+	}))
+}
+
+func TestEC2InstanceTagsByTagError(t *testing.T) {
+	g := newEC2Test(t)
+	g.ec2.EXPECT().DescribeInstances(g.ctx, gomock.Any()).Return(nil, errors.New("error"))
+	_, err := g.client.EC2InstanceTagsByTag(g.ctx, "owner", "cluster")
+	g.Expect(err).To(MatchError(ContainSubstring("aws describe instances [tag=owner:cluster]: error")))
+}
+
+func TestEC2CreateTags(t *testing.T) {
+	g := newEC2Test(t)
+	params := &ec2.CreateTagsInput{
+		Resources: []string{"i-1"},
+		Tags: []types.Tag{
+			{Key: awstypes.String("cost-center"), Value: awstypes.String("platform")},
+			{Key: awstypes.String("env"), Value: awstypes.String("prod")},
+		},
+	}
+	g.ec2.EXPECT().CreateTags(g.ctx, params).Return(nil, errors.New("error"))
+	err := g.client.EC2CreateTags(g.ctx, []string{"i-1"}, map[string]string{"env": "prod", "cost-center": "platform"})
+	g.Expect(err).To(MatchError(ContainSubstring("creating ec2 tags: error")))
+}
+
+func TestEC2DeleteTags(t *testing.T) {
+	g := newEC2Test(t)
+	params := &ec2.DeleteTagsInput{
+		Resources: []string{"i-1"},
+		Tags:      []types.Tag{{Key: awstypes.String("env")}},
+	}
+	g.ec2.EXPECT().DeleteTags(g.ctx, params).Return(&ec2.DeleteTagsOutput{}, nil)
+	g.Expect(g.client.EC2DeleteTags(g.ctx, []string{"i-1"}, []string{"env"})).To(Succeed())
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/bootstrapmanifests/reader.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOCIReader is a mock of OCIReader interface.
+type MockOCIReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockOCIReaderMockRecorder
+}
+
+// MockOCIReaderMockRecorder is the mock recorder for MockOCIReader.
+type MockOCIReaderMockRecorder struct {
+	mock *MockOCIReader
+}
+
+// NewMockOCIReader creates a new mock instance.
+func NewMockOCIReader(ctrl *gomock.Controller) *MockOCIReader {
+	mock := &MockOCIReader{ctrl: ctrl}
+	mock.recorder = &MockOCIReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOCIReader) EXPECT() *MockOCIReaderMockRecorder {
+	return m.recorder
+}
+
+// ReadOCIFile mocks base method.
+func (m *MockOCIReader) ReadOCIFile(ctx context.Context, uri string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadOCIFile", ctx, uri)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadOCIFile indicates an expected call of ReadOCIFile.
+func (mr *MockOCIReaderMockRecorder) ReadOCIFile(ctx, uri interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadOCIFile", reflect.TypeOf((*MockOCIReader)(nil).ReadOCIFile), ctx, uri)
+}
+
+// MockGitReader is a mock of GitReader interface.
+type MockGitReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitReaderMockRecorder
+}
+
+// MockGitReaderMockRecorder is the mock recorder for MockGitReader.
+type MockGitReaderMockRecorder struct {
+	mock *MockGitReader
+}
+
+// NewMockGitReader creates a new mock instance.
+func NewMockGitReader(ctrl *gomock.Controller) *MockGitReader {
+	mock := &MockGitReader{ctrl: ctrl}
+	mock.recorder = &MockGitReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitReader) EXPECT() *MockGitReaderMockRecorder {
+	return m.recorder
+}
+
+// ReadFile mocks base method.
+func (m *MockGitReader) ReadFile(ctx context.Context, repository, ref, path string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFile", ctx, repository, ref, path)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadFile indicates an expected call of ReadFile.
+func (mr *MockGitReaderMockRecorder) ReadFile(ctx, repository, ref, path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFile", reflect.TypeOf((*MockGitReader)(nil).ReadFile), ctx, repository, ref, path)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/bootstrapmanifests/reconciler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockManifestReader is a mock of ManifestReader interface.
+type MockManifestReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockManifestReaderMockRecorder
+}
+
+// MockManifestReaderMockRecorder is the mock recorder for MockManifestReader.
+type MockManifestReaderMockRecorder struct {
+	mock *MockManifestReader
+}
+
+// NewMockManifestReader creates a new mock instance.
+func NewMockManifestReader(ctrl *gomock.Controller) *MockManifestReader {
+	mock := &MockManifestReader{ctrl: ctrl}
+	mock.recorder = &MockManifestReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockManifestReader) EXPECT() *MockManifestReaderMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockManifestReader) Read(ctx context.Context, manifest v1alpha1.BootstrapManifest) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, manifest)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockManifestReaderMockRecorder) Read(ctx, manifest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockManifestReader)(nil).Read), ctx, manifest)
+}
@@ -0,0 +1,114 @@
+// Package bootstrapmanifests applies the bootstrap manifests of a cluster spec to the cluster once its CNI is ready.
+package bootstrapmanifests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// OCIReader reads single file OCI artifacts.
+type OCIReader interface {
+	ReadOCIFile(ctx context.Context, uri string) ([]byte, error)
+}
+
+// GitReader reads files from Git repositories.
+type GitReader interface {
+	ReadFile(ctx context.Context, repository, ref, path string) ([]byte, error)
+}
+
+// Reader reads the content of bootstrap manifests.
+type Reader struct {
+	oci OCIReader
+	git GitReader
+}
+
+// NewReader builds a Reader.
+func NewReader(oci OCIReader, git GitReader) *Reader {
+	return &Reader{
+		oci: oci,
+		git: git,
+	}
+}
+
+// Read returns the content of the manifest, from its inline content, its OCI artifact or its Git repository.
+func (r *Reader) Read(ctx context.Context, manifest anywherev1.BootstrapManifest) ([]byte, error) {
+	switch {
+	case manifest.Inline != "":
+		return []byte(manifest.Inline), nil
+	case manifest.OCI != "":
+		return r.oci.ReadOCIFile(ctx, manifest.OCI)
+	case manifest.Git != nil:
+		return r.git.ReadFile(ctx, manifest.Git.Repository, manifest.Git.Ref, manifest.Git.Path)
+	default:
+		return nil, errors.New("manifest doesn't have any content")
+	}
+}
+
+// GoGitReader reads files from Git repositories cloned in memory.
+type GoGitReader struct{}
+
+// NewGoGitReader builds a GoGitReader.
+func NewGoGitReader() *GoGitReader {
+	return &GoGitReader{}
+}
+
+// ReadFile returns the content of the file path in the branch, tag or commit ref of the repository,
+// or in its default branch when ref is empty.
+func (g *GoGitReader) ReadFile(ctx context.Context, repository, ref, path string) ([]byte, error) {
+	repo, err := git.CloneContext(ctx, memory.NewStorage(), nil, &git.CloneOptions{
+		URL:  repository,
+		Tags: git.AllTags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cloning repository %s: %v", repository, err)
+	}
+
+	hash, err := resolveRef(repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("repository %s: %v", repository, err)
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("reading commit %s of repository %s: %v", hash, repository, err)
+	}
+
+	file, err := commit.File(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s from repository %s: %v", path, repository, err)
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s from repository %s: %v", path, repository, err)
+	}
+
+	return []byte(content), nil
+}
+
+func resolveRef(repo *git.Repository, ref string) (*plumbing.Hash, error) {
+	if ref == "" {
+		head, err := repo.Head()
+		if err != nil {
+			return nil, fmt.Errorf("reading default branch: %v", err)
+		}
+		hash := head.Hash()
+		return &hash, nil
+	}
+
+	// Branches are only available as remote branches in a bare clone.
+	for _, revision := range []string{"origin/" + ref, ref} {
+		if hash, err := repo.ResolveRevision(plumbing.Revision(revision)); err == nil {
+			return hash, nil
+		}
+	}
+
+	return nil, fmt.Errorf("ref %s not found", ref)
+}
@@ -0,0 +1,127 @@
+package bootstrapmanifests_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/bootstrapmanifests"
+	"github.com/aws/eks-anywhere/pkg/bootstrapmanifests/mocks"
+)
+
+const namespaceManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: team-a
+`
+
+type readerTest struct {
+	*WithT
+	ctx    context.Context
+	oci    *mocks.MockOCIReader
+	git    *mocks.MockGitReader
+	reader *bootstrapmanifests.Reader
+}
+
+func newReaderTest(t *testing.T) *readerTest {
+	ctrl := gomock.NewController(t)
+	oci := mocks.NewMockOCIReader(ctrl)
+	git := mocks.NewMockGitReader(ctrl)
+	return &readerTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		oci:    oci,
+		git:    git,
+		reader: bootstrapmanifests.NewReader(oci, git),
+	}
+}
+
+func TestReaderReadInline(t *testing.T) {
+	tt := newReaderTest(t)
+	manifest := anywherev1.BootstrapManifest{Name: "namespaces", Inline: namespaceManifest}
+
+	tt.Expect(tt.reader.Read(tt.ctx, manifest)).To(BeEquivalentTo(namespaceManifest))
+}
+
+func TestReaderReadOCI(t *testing.T) {
+	tt := newReaderTest(t)
+	manifest := anywherev1.BootstrapManifest{Name: "namespaces", OCI: "oci://public.ecr.aws/org/manifests:v1"}
+	tt.oci.EXPECT().ReadOCIFile(tt.ctx, "oci://public.ecr.aws/org/manifests:v1").Return([]byte(namespaceManifest), nil)
+
+	tt.Expect(tt.reader.Read(tt.ctx, manifest)).To(BeEquivalentTo(namespaceManifest))
+}
+
+func TestReaderReadGit(t *testing.T) {
+	tt := newReaderTest(t)
+	manifest := anywherev1.BootstrapManifest{
+		Name: "namespaces",
+		Git: &anywherev1.BootstrapManifestGitRef{
+			Repository: "https://github.com/org/manifests.git",
+			Ref:        "v1",
+			Path:       "namespaces.yaml",
+		},
+	}
+	tt.git.EXPECT().ReadFile(tt.ctx, "https://github.com/org/manifests.git", "v1", "namespaces.yaml").Return(nil, errors.New("repository not found"))
+
+	_, err := tt.reader.Read(tt.ctx, manifest)
+	tt.Expect(err).To(MatchError("repository not found"))
+}
+
+func TestReaderReadNoContent(t *testing.T) {
+	tt := newReaderTest(t)
+
+	_, err := tt.reader.Read(tt.ctx, anywherev1.BootstrapManifest{Name: "namespaces"})
+	tt.Expect(err).To(MatchError("manifest doesn't have any content"))
+}
+
+func TestGoGitReaderReadFile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	repo, err := git.PlainInit(dir, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	first := commitFile(t, repo, dir, "namespaces.yaml", "first")
+	g.Expect(repo.CreateTag("v1", first, nil)).Error().NotTo(HaveOccurred())
+	commitFile(t, repo, dir, "namespaces.yaml", namespaceManifest)
+
+	reader := bootstrapmanifests.NewGoGitReader()
+
+	g.Expect(reader.ReadFile(ctx, dir, "", "namespaces.yaml")).To(BeEquivalentTo(namespaceManifest))
+	g.Expect(reader.ReadFile(ctx, dir, "master", "namespaces.yaml")).To(BeEquivalentTo(namespaceManifest))
+	g.Expect(reader.ReadFile(ctx, dir, "v1", "namespaces.yaml")).To(BeEquivalentTo("first"))
+	g.Expect(reader.ReadFile(ctx, dir, first.String(), "namespaces.yaml")).To(BeEquivalentTo("first"))
+
+	_, err = reader.ReadFile(ctx, dir, "v2", "namespaces.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("ref v2 not found")))
+
+	_, err = reader.ReadFile(ctx, dir, "", "quotas.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("reading quotas.yaml from repository")))
+}
+
+func commitFile(t *testing.T, repo *git.Repository, dir, path, content string) plumbing.Hash {
+	t.Helper()
+	g := NewWithT(t)
+	g.Expect(os.WriteFile(filepath.Join(dir, path), []byte(content), 0o644)).To(Succeed())
+
+	worktree, err := repo.Worktree()
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = worktree.Add(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	hash, err := worktree.Commit("update "+path, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return hash
+}
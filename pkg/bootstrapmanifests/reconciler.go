@@ -0,0 +1,84 @@
+package bootstrapmanifests
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+)
+
+const defaultRequeueTime = time.Second * 10
+
+// ManifestReader reads the content of bootstrap manifests.
+type ManifestReader interface {
+	Read(ctx context.Context, manifest anywherev1.BootstrapManifest) ([]byte, error)
+}
+
+// Reconciler applies the bootstrap manifests of a cluster.
+type Reconciler struct {
+	reader ManifestReader
+}
+
+// NewReconciler builds a Reconciler.
+func NewReconciler(reader ManifestReader) *Reconciler {
+	return &Reconciler{
+		reader: reader,
+	}
+}
+
+// Reconcile applies, in order, the bootstrap manifests of the spec to the cluster with server side apply once
+// the CNI is ready, that is once a node is ready. It requeues until then.
+// Objects removed from the manifests aren't deleted from the cluster.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
+	ready, err := cniReady(ctx, client)
+	if err != nil {
+		return controller.Result{}, err
+	}
+	if !ready {
+		log.Info("CNI is not ready yet, requeueing before applying bootstrap manifests")
+		return controller.Result{Result: &ctrl.Result{
+			RequeueAfter: defaultRequeueTime,
+		}}, nil
+	}
+
+	for _, manifest := range spec.Cluster.Spec.BootstrapManifests {
+		log.Info("Applying bootstrap manifest", "name", manifest.Name)
+		content, err := r.reader.Read(ctx, manifest)
+		if err != nil {
+			return controller.Result{}, fmt.Errorf("reading bootstrap manifest %s: %v", manifest.Name, err)
+		}
+
+		if err := serverside.ReconcileYaml(ctx, client, content); err != nil {
+			return controller.Result{}, fmt.Errorf("applying bootstrap manifest %s: %v", manifest.Name, err)
+		}
+	}
+
+	return controller.Result{}, nil
+}
+
+// cniReady returns true once a node of the cluster is ready, since nodes aren't ready until the CNI is.
+func cniReady(ctx context.Context, c client.Client) (bool, error) {
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return false, fmt.Errorf("listing nodes: %v", err)
+	}
+
+	for _, node := range nodes.Items {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,88 @@
+package bootstrapmanifests_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/bootstrapmanifests"
+	"github.com/aws/eks-anywhere/pkg/bootstrapmanifests/mocks"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+type reconcilerTest struct {
+	*WithT
+	ctx        context.Context
+	reader     *mocks.MockManifestReader
+	reconciler *bootstrapmanifests.Reconciler
+	spec       *cluster.Spec
+}
+
+func newReconcilerTest(t *testing.T) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockManifestReader(ctrl)
+	return &reconcilerTest{
+		WithT:      NewWithT(t),
+		ctx:        context.Background(),
+		reader:     reader,
+		reconciler: bootstrapmanifests.NewReconciler(reader),
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Spec.BootstrapManifests = []anywherev1.BootstrapManifest{
+				{Name: "namespaces", Inline: namespaceManifest},
+			}
+		}),
+	}
+}
+
+func node(status corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: status},
+			},
+		},
+	}
+}
+
+func remoteClient(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithObjects(objs...).Build()
+}
+
+func TestReconcilerReconcileCNINotReady(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), remoteClient(node(corev1.ConditionFalse)), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Result).NotTo(BeNil())
+	tt.Expect(result.Result.RequeueAfter).To(Equal(10 * time.Second))
+}
+
+func TestReconcilerReconcileReadError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.reader.EXPECT().Read(tt.ctx, tt.spec.Cluster.Spec.BootstrapManifests[0]).Return(nil, errors.New("pulling artifact"))
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), remoteClient(node(corev1.ConditionTrue)), tt.spec)
+
+	tt.Expect(err).To(MatchError("reading bootstrap manifest namespaces: pulling artifact"))
+}
+
+func TestReconcilerReconcileInvalidManifest(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.reader.EXPECT().Read(tt.ctx, tt.spec.Cluster.Spec.BootstrapManifests[0]).Return([]byte("kind: [Namespace"), nil)
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), remoteClient(node(corev1.ConditionTrue)), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("applying bootstrap manifest namespaces")))
+}
@@ -0,0 +1,129 @@
+// Package bottlerocketupdates deploys the Bottlerocket update operator to the clusters that enable
+// Bottlerocket updates, so their Bottlerocket nodes are patched in place.
+package bottlerocketupdates
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/semver"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// Namespace is the namespace the Bottlerocket update operator is deployed to.
+const Namespace = "brupop-bottlerocket-aws"
+
+const (
+	defaultMaxConcurrentUpdates = 1
+	// anyTimeSchedule allows the updates to start at any time.
+	anyTimeSchedule = "* * * * * * *"
+)
+
+// Helm renders Helm charts.
+type Helm interface {
+	TemplateWithCRDs(ctx context.Context, ociURI, version, namespace string, values interface{}, kubeVersion string) ([]byte, error)
+	RegistryLogin(ctx context.Context, registry, username, password string) error
+}
+
+// KubernetesClient applies manifests to a cluster.
+type KubernetesClient interface {
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+}
+
+// Installer deploys and upgrades the Bottlerocket update operator with the chart and image of the cluster bundle.
+type Installer struct {
+	helm Helm
+	k8s  KubernetesClient
+}
+
+// NewInstaller builds an Installer.
+func NewInstaller(helm Helm, k8s KubernetesClient) *Installer {
+	return &Installer{
+		helm: helm,
+		k8s:  k8s,
+	}
+}
+
+// Install deploys the Bottlerocket update operator to the cluster, or upgrades it to the version in the spec
+// bundle if it's already deployed. It does nothing if the spec doesn't enable Bottlerocket updates.
+func (i *Installer) Install(ctx context.Context, cluster *types.Cluster, spec *cluster.Spec) error {
+	if spec.Cluster.Spec.BottlerocketUpdates == nil {
+		return nil
+	}
+
+	manifest, err := i.GenerateManifest(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	if err := i.k8s.ApplyKubeSpecFromBytes(ctx, cluster, manifest); err != nil {
+		return fmt.Errorf("applying bottlerocket update operator manifest: %v", err)
+	}
+
+	return nil
+}
+
+// GenerateManifest renders the Bottlerocket update operator chart of the spec bundle with the Bottlerocket
+// updates configuration of the spec. The chart includes its namespace and CRDs.
+func (i *Installer) GenerateManifest(ctx context.Context, spec *cluster.Spec) ([]byte, error) {
+	chart := spec.VersionsBundle.BottlerocketUpdates.HelmChart
+	if chart.URI == "" {
+		return nil, fmt.Errorf("bundle for Kubernetes %s doesn't include the bottlerocket update operator, bottlerocketUpdates requires a newer EKS-A version", spec.Cluster.Spec.KubernetesVersion)
+	}
+
+	kubeVersion, err := semver.New(spec.VersionsBundle.KubeDistro.Kubernetes.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubernetes version %s: %v", spec.VersionsBundle.KubeDistro.Kubernetes.Tag, err)
+	}
+
+	if mirror := spec.Cluster.Spec.RegistryMirrorConfiguration; mirror != nil && mirror.Authenticate {
+		username, password, err := config.ReadCredentials()
+		if err != nil {
+			return nil, err
+		}
+		if err := i.helm.RegistryLogin(ctx, spec.Cluster.RegistryMirror(), username, password); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest, err := i.helm.TemplateWithCRDs(
+		ctx,
+		fmt.Sprintf("oci://%s", chart.Image()),
+		chart.Tag(),
+		Namespace,
+		templateValues(spec),
+		fmt.Sprintf("%d.%d", kubeVersion.Major, kubeVersion.Minor),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("generating bottlerocket update operator manifest: %v", err)
+	}
+
+	return manifest, nil
+}
+
+type values map[string]interface{}
+
+func templateValues(spec *cluster.Spec) values {
+	updates := spec.Cluster.Spec.BottlerocketUpdates
+
+	maxConcurrentUpdates := updates.MaxConcurrentUpdates
+	if maxConcurrentUpdates == 0 {
+		maxConcurrentUpdates = defaultMaxConcurrentUpdates
+	}
+
+	schedule := updates.Schedule
+	if schedule == "" {
+		schedule = anyTimeSchedule
+	}
+
+	return values{
+		"namespace": Namespace,
+		"image":     spec.VersionsBundle.BottlerocketUpdates.Operator.VersionedImage(),
+		// The chart reads the number as a string, since it also accepts "unlimited".
+		"max_concurrent_update":     strconv.Itoa(maxConcurrentUpdates),
+		"scheduler_cron_expression": schedule,
+	}
+}
@@ -0,0 +1,130 @@
+package bottlerocketupdates_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/bottlerocketupdates"
+	"github.com/aws/eks-anywhere/pkg/bottlerocketupdates/mocks"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	chartURI     = "oci://public.ecr.aws/eks-anywhere/bottlerocket-update-operator"
+	chartVersion = "1.1.0"
+	manifest     = "kind: Deployment"
+)
+
+type installerTest struct {
+	*WithT
+	ctx       context.Context
+	helm      *mocks.MockHelm
+	k8s       *mocks.MockKubernetesClient
+	installer *bottlerocketupdates.Installer
+	cluster   *types.Cluster
+	spec      *cluster.Spec
+}
+
+func newInstallerTest(t *testing.T) *installerTest {
+	ctrl := gomock.NewController(t)
+	helm := mocks.NewMockHelm(ctrl)
+	k8s := mocks.NewMockKubernetesClient(ctrl)
+	return &installerTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		helm:      helm,
+		k8s:       k8s,
+		installer: bottlerocketupdates.NewInstaller(helm, k8s),
+		cluster:   &types.Cluster{Name: "my-cluster", KubeconfigFile: "my-cluster.kubeconfig"},
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Spec.BottlerocketUpdates = &anywherev1.BottlerocketUpdatesConfiguration{
+				MaxConcurrentUpdates: 3,
+				Schedule:             "0 0 23 * * Sat *",
+			}
+			s.VersionsBundle.KubeDistro.Kubernetes.Tag = "v1.24.13-eks-1-24-14"
+			s.VersionsBundle.BottlerocketUpdates = releasev1alpha1.BottlerocketUpdatesBundle{
+				Operator:  releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/bottlerocket-update-operator:v1.1.0"},
+				HelmChart: releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/bottlerocket-update-operator:1.1.0"},
+			}
+		}),
+	}
+}
+
+func (tt *installerTest) expectTemplate(wantValues string, err error) {
+	tt.helm.EXPECT().TemplateWithCRDs(tt.ctx, chartURI, chartVersion, bottlerocketupdates.Namespace, gomock.Any(), "1.24").DoAndReturn(
+		func(_ context.Context, _, _, _ string, values interface{}, _ string) ([]byte, error) {
+			gotValues, marshalErr := yaml.Marshal(values)
+			tt.Expect(marshalErr).NotTo(HaveOccurred())
+			tt.Expect(string(gotValues)).To(Equal(wantValues))
+			return []byte(manifest), err
+		},
+	)
+}
+
+func TestInstallerInstallSuccess(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.expectTemplate(`image: public.ecr.aws/eks-anywhere/bottlerocket-update-operator:v1.1.0
+max_concurrent_update: "3"
+namespace: brupop-bottlerocket-aws
+scheduler_cron_expression: 0 0 23 * * Sat *
+`, nil)
+	tt.k8s.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, []byte(manifest))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallDefaults(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.Spec.BottlerocketUpdates = &anywherev1.BottlerocketUpdatesConfiguration{}
+	tt.expectTemplate(`image: public.ecr.aws/eks-anywhere/bottlerocket-update-operator:v1.1.0
+max_concurrent_update: "1"
+namespace: brupop-bottlerocket-aws
+scheduler_cron_expression: '* * * * * * *'
+`, nil)
+	tt.k8s.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, []byte(manifest))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallNoBottlerocketUpdates(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.Spec.BottlerocketUpdates = nil
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallBundleWithoutOperator(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.Spec.KubernetesVersion = anywherev1.Kube124
+	tt.spec.VersionsBundle.BottlerocketUpdates = releasev1alpha1.BottlerocketUpdatesBundle{}
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(MatchError(
+		"bundle for Kubernetes 1.24 doesn't include the bottlerocket update operator, bottlerocketUpdates requires a newer EKS-A version",
+	))
+}
+
+func TestInstallerInstallTemplateError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.helm.EXPECT().TemplateWithCRDs(tt.ctx, chartURI, chartVersion, bottlerocketupdates.Namespace, gomock.Any(), "1.24").
+		Return(nil, errors.New("chart not found"))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(MatchError("generating bottlerocket update operator manifest: chart not found"))
+}
+
+func TestInstallerInstallApplyError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.helm.EXPECT().TemplateWithCRDs(tt.ctx, chartURI, chartVersion, bottlerocketupdates.Namespace, gomock.Any(), "1.24").
+		Return([]byte(manifest), nil)
+	tt.k8s.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, []byte(manifest)).Return(errors.New("connection refused"))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(MatchError("applying bottlerocket update operator manifest: connection refused"))
+}
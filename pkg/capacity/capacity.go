@@ -0,0 +1,332 @@
+// Package capacity estimates the infrastructure resources a cluster demands from its provider.
+package capacity
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const (
+	controlPlaneGroupName = "control-plane"
+	etcdGroupName         = "etcd"
+	mebibytesPerGibibyte  = 1024
+	bytesPerGibibyte      = 1024 * 1024 * 1024
+)
+
+// snowInstanceTypes are the resources of the Snow instance types supported for the cluster machines.
+var snowInstanceTypes = map[v1alpha1.SnowInstanceType]Resources{
+	v1alpha1.SbeCLarge:   {VCPUs: 2, MemoryGiB: 8},
+	v1alpha1.SbeCXLarge:  {VCPUs: 4, MemoryGiB: 16},
+	v1alpha1.SbeC2XLarge: {VCPUs: 8, MemoryGiB: 32},
+	v1alpha1.SbeC4XLarge: {VCPUs: 16, MemoryGiB: 64},
+}
+
+// DefaultSnowDeviceCapacity is the compute capacity of a Snowball Edge Compute Optimized device
+// available for the cluster instances.
+var DefaultSnowDeviceCapacity = Resources{VCPUs: 52, MemoryGiB: 208}
+
+// Resources are the compute and storage resources of one or more machines.
+type Resources struct {
+	VCPUs      int64   `json:"vcpus"`
+	MemoryGiB  float64 `json:"memoryGiB"`
+	StorageGiB float64 `json:"storageGiB"`
+}
+
+func (r Resources) times(count int) Resources {
+	return Resources{
+		VCPUs:      r.VCPUs * int64(count),
+		MemoryGiB:  r.MemoryGiB * float64(count),
+		StorageGiB: r.StorageGiB * float64(count),
+	}
+}
+
+func (r Resources) plus(o Resources) Resources {
+	return Resources{
+		VCPUs:      r.VCPUs + o.VCPUs,
+		MemoryGiB:  r.MemoryGiB + o.MemoryGiB,
+		StorageGiB: r.StorageGiB + o.StorageGiB,
+	}
+}
+
+// MachineGroup is a group of machines of the cluster sharing a machine config, like the control plane
+// or a worker node group.
+type MachineGroup struct {
+	Name          string `json:"name"`
+	MachineConfig string `json:"machineConfig"`
+	// Count is the number of machines of the group, the maximum one for autoscaling worker node groups.
+	Count int `json:"count"`
+	// PerMachine are the resources of each machine, nil when they can't be known from the spec,
+	// like the ones of a CloudStack compute offering or a bare metal machine.
+	PerMachine *Resources `json:"perMachine,omitempty"`
+	Total      *Resources `json:"total,omitempty"`
+}
+
+// SnowDevice is the demand of the cluster machines placed in a Snow device and whether they fit in it.
+type SnowDevice struct {
+	Device   string    `json:"device"`
+	Machines int       `json:"machines"`
+	Demand   Resources `json:"demand"`
+	Capacity Resources `json:"capacity"`
+	Fits     bool      `json:"fits"`
+}
+
+// Report is the resources demanded by a cluster from its provider.
+type Report struct {
+	Cluster       string         `json:"cluster"`
+	Provider      string         `json:"provider"`
+	MachineGroups []MachineGroup `json:"machineGroups"`
+	// Total are the resources of all the machine groups with known resources.
+	Total Resources `json:"total"`
+	// Incomplete is true when the resources of some machine groups can't be known from the spec.
+	Incomplete bool `json:"incomplete"`
+	// Cost is the cost of the total resources, only set when prices are provided.
+	Cost        *float64     `json:"cost,omitempty"`
+	SnowDevices []SnowDevice `json:"snowDevices,omitempty"`
+}
+
+// SnowDevicesFit returns true if the cluster machines fit in all their Snow devices.
+func (r *Report) SnowDevicesFit() bool {
+	for _, d := range r.SnowDevices {
+		if !d.Fits {
+			return false
+		}
+	}
+	return true
+}
+
+// Prices are the prices of a unit of each resource, used to estimate the cost of a cluster.
+type Prices struct {
+	VCPU       float64
+	MemoryGiB  float64
+	StorageGiB float64
+}
+
+func (p Prices) isZero() bool {
+	return p == Prices{}
+}
+
+func (p Prices) cost(r Resources) float64 {
+	return float64(r.VCPUs)*p.VCPU + r.MemoryGiB*p.MemoryGiB + r.StorageGiB*p.StorageGiB
+}
+
+// Estimator estimates the resources demanded by a cluster from its provider.
+type Estimator struct {
+	prices             Prices
+	snowDeviceCapacity Resources
+}
+
+// EstimatorOpt configures an Estimator.
+type EstimatorOpt func(*Estimator)
+
+// WithPrices makes the Estimator estimate the cost of the cluster with prices.
+func WithPrices(prices Prices) EstimatorOpt {
+	return func(e *Estimator) {
+		e.prices = prices
+	}
+}
+
+// WithSnowDeviceCapacity overrides the capacity of the Snow devices, DefaultSnowDeviceCapacity by default.
+func WithSnowDeviceCapacity(capacity Resources) EstimatorOpt {
+	return func(e *Estimator) {
+		e.snowDeviceCapacity = capacity
+	}
+}
+
+// NewEstimator builds an Estimator.
+func NewEstimator(opts ...EstimatorOpt) *Estimator {
+	e := &Estimator{snowDeviceCapacity: DefaultSnowDeviceCapacity}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Estimate computes the resources the machines of a cluster config demand from its provider. For Snow,
+// it also checks the machines fit in the devices they're placed in, spreading the machines of each
+// machine config evenly across its devices.
+func (e *Estimator) Estimate(config *cluster.Config) (*Report, error) {
+	report := &Report{
+		Cluster:  config.Cluster.Name,
+		Provider: config.Cluster.Spec.DatacenterRef.Kind,
+	}
+
+	for _, g := range machineGroups(config.Cluster) {
+		perMachine, err := machineResources(config, g.MachineConfig)
+		if err != nil {
+			return nil, fmt.Errorf("estimating machine group %s: %v", g.Name, err)
+		}
+
+		if perMachine == nil {
+			report.Incomplete = true
+		} else {
+			total := perMachine.times(g.Count)
+			g.PerMachine = perMachine
+			g.Total = &total
+			report.Total = report.Total.plus(total)
+		}
+		report.MachineGroups = append(report.MachineGroups, g)
+	}
+
+	if !e.prices.isZero() {
+		cost := e.prices.cost(report.Total)
+		report.Cost = &cost
+	}
+
+	if config.Cluster.Spec.DatacenterRef.Kind == v1alpha1.SnowDatacenterKind {
+		report.SnowDevices = e.snowDevices(config, report.MachineGroups)
+	}
+
+	return report, nil
+}
+
+// machineGroups returns the machine groups of the cluster without their resources.
+func machineGroups(c *v1alpha1.Cluster) []MachineGroup {
+	cp := c.Spec.ControlPlaneConfiguration
+	groups := []MachineGroup{{
+		Name:          controlPlaneGroupName,
+		MachineConfig: machineConfigName(cp.MachineGroupRef),
+		Count:         defaultCount(cp.Count),
+	}}
+
+	if etcd := c.Spec.ExternalEtcdConfiguration; etcd != nil {
+		groups = append(groups, MachineGroup{
+			Name:          etcdGroupName,
+			MachineConfig: machineConfigName(etcd.MachineGroupRef),
+			Count:         defaultCount(etcd.Count),
+		})
+	}
+
+	for _, w := range c.Spec.WorkerNodeGroupConfigurations {
+		count := 1
+		if w.Count != nil {
+			count = *w.Count
+		}
+		if w.AutoScalingConfiguration != nil && w.AutoScalingConfiguration.MaxCount > count {
+			count = w.AutoScalingConfiguration.MaxCount
+		}
+		groups = append(groups, MachineGroup{
+			Name:          w.Name,
+			MachineConfig: machineConfigName(w.MachineGroupRef),
+			Count:         count,
+		})
+	}
+
+	return groups
+}
+
+func machineConfigName(ref *v1alpha1.Ref) string {
+	if ref == nil {
+		return ""
+	}
+	return ref.Name
+}
+
+func defaultCount(count int) int {
+	if count == 0 {
+		return 1
+	}
+	return count
+}
+
+// machineResources returns the resources of a machine with the machine config, nil if the
+// provider doesn't define them in the machine config.
+func machineResources(config *cluster.Config, machineConfig string) (*Resources, error) {
+	switch config.Cluster.Spec.DatacenterRef.Kind {
+	case v1alpha1.VSphereDatacenterKind:
+		mc, ok := config.VSphereMachineConfigs[machineConfig]
+		if !ok {
+			return nil, fmt.Errorf("VSphereMachineConfig %s not found", machineConfig)
+		}
+		return vsphereMachineResources(mc), nil
+	case v1alpha1.NutanixDatacenterKind:
+		mc, ok := config.NutanixMachineConfigs[machineConfig]
+		if !ok {
+			return nil, fmt.Errorf("NutanixMachineConfig %s not found", machineConfig)
+		}
+		return nutanixMachineResources(mc), nil
+	case v1alpha1.SnowDatacenterKind:
+		mc, ok := config.SnowMachineConfigs[machineConfig]
+		if !ok {
+			return nil, fmt.Errorf("SnowMachineConfig %s not found", machineConfig)
+		}
+		return snowMachineResources(mc)
+	default:
+		return nil, nil
+	}
+}
+
+func vsphereMachineResources(mc *v1alpha1.VSphereMachineConfig) *Resources {
+	r := &Resources{
+		VCPUs:      int64(valueOrDefault(mc.Spec.NumCPUs, v1alpha1.DefaultVSphereNumCPUs)),
+		MemoryGiB:  float64(valueOrDefault(mc.Spec.MemoryMiB, v1alpha1.DefaultVSphereMemoryMiB)) / mebibytesPerGibibyte,
+		StorageGiB: float64(valueOrDefault(mc.Spec.DiskGiB, v1alpha1.DefaultVSphereDiskGiB)),
+	}
+	if mc.Spec.EtcdDisk != nil {
+		r.StorageGiB += float64(mc.Spec.EtcdDisk.SizeGiB)
+	}
+	return r
+}
+
+func nutanixMachineResources(mc *v1alpha1.NutanixMachineConfig) *Resources {
+	return &Resources{
+		VCPUs:      int64(mc.Spec.VCPUSockets) * int64(mc.Spec.VCPUsPerSocket),
+		MemoryGiB:  float64(mc.Spec.MemorySize.Value()) / bytesPerGibibyte,
+		StorageGiB: float64(mc.Spec.SystemDiskSize.Value()) / bytesPerGibibyte,
+	}
+}
+
+func snowMachineResources(mc *v1alpha1.SnowMachineConfig) (*Resources, error) {
+	instanceType := mc.Spec.InstanceType
+	if instanceType == "" {
+		instanceType = v1alpha1.DefaultSnowInstanceType
+	}
+	r, ok := snowInstanceTypes[instanceType]
+	if !ok {
+		return nil, fmt.Errorf("unknown Snow instance type %s", instanceType)
+	}
+	if mc.Spec.ContainersVolume != nil {
+		r.StorageGiB = float64(mc.Spec.ContainersVolume.Size)
+	}
+	return &r, nil
+}
+
+func valueOrDefault(value, defaultValue int) int {
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// snowDevices spreads the machines of each group evenly across the devices of its machine config
+// and checks the compute demand of each device fits in its capacity.
+func (e *Estimator) snowDevices(config *cluster.Config, groups []MachineGroup) []SnowDevice {
+	devices := map[string]*SnowDevice{}
+	for _, g := range groups {
+		mc := config.SnowMachineConfigs[g.MachineConfig]
+		if len(mc.Spec.Devices) == 0 {
+			continue
+		}
+		for i := 0; i < g.Count; i++ {
+			name := mc.Spec.Devices[i%len(mc.Spec.Devices)]
+			d, ok := devices[name]
+			if !ok {
+				d = &SnowDevice{Device: name, Capacity: e.snowDeviceCapacity}
+				devices[name] = d
+			}
+			d.Machines++
+			d.Demand = d.Demand.plus(*g.PerMachine)
+		}
+	}
+
+	result := make([]SnowDevice, 0, len(devices))
+	for _, d := range devices {
+		d.Fits = d.Demand.VCPUs <= d.Capacity.VCPUs && d.Demand.MemoryGiB <= d.Capacity.MemoryGiB
+		result = append(result, *d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Device < result[j].Device })
+
+	return result
+}
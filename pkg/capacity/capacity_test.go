@@ -0,0 +1,169 @@
+package capacity_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/capacity"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func newCluster(kind string) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			DatacenterRef: v1alpha1.Ref{Kind: kind, Name: "dc"},
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Count:           3,
+				MachineGroupRef: &v1alpha1.Ref{Name: "cp"},
+			},
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:            "md-0",
+					Count:           ptr.Int(2),
+					MachineGroupRef: &v1alpha1.Ref{Name: "worker"},
+				},
+			},
+		},
+	}
+}
+
+func TestEstimateVSphere(t *testing.T) {
+	g := NewWithT(t)
+	c := newCluster(v1alpha1.VSphereDatacenterKind)
+	c.Name = "my-cluster"
+	c.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count:           3,
+		MachineGroupRef: &v1alpha1.Ref{Name: "etcd"},
+	}
+	c.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 4}
+	config := &cluster.Config{
+		Cluster: c,
+		VSphereMachineConfigs: map[string]*v1alpha1.VSphereMachineConfig{
+			"cp": {},
+			"etcd": {Spec: v1alpha1.VSphereMachineConfigSpec{
+				NumCPUs:   2,
+				MemoryMiB: 4096,
+				DiskGiB:   20,
+				EtcdDisk:  &v1alpha1.EtcdDiskConfiguration{SizeGiB: 10},
+			}},
+			"worker": {Spec: v1alpha1.VSphereMachineConfigSpec{NumCPUs: 8, MemoryMiB: 32768, DiskGiB: 100}},
+		},
+	}
+
+	report, err := capacity.NewEstimator(capacity.WithPrices(capacity.Prices{VCPU: 10, MemoryGiB: 1, StorageGiB: 0.1})).Estimate(config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Cluster).To(Equal("my-cluster"))
+	g.Expect(report.Provider).To(Equal(v1alpha1.VSphereDatacenterKind))
+	g.Expect(report.Incomplete).To(BeFalse())
+	g.Expect(report.MachineGroups).To(Equal([]capacity.MachineGroup{
+		{
+			Name:          "control-plane",
+			MachineConfig: "cp",
+			Count:         3,
+			PerMachine:    &capacity.Resources{VCPUs: 2, MemoryGiB: 8, StorageGiB: 25},
+			Total:         &capacity.Resources{VCPUs: 6, MemoryGiB: 24, StorageGiB: 75},
+		},
+		{
+			Name:          "etcd",
+			MachineConfig: "etcd",
+			Count:         3,
+			PerMachine:    &capacity.Resources{VCPUs: 2, MemoryGiB: 4, StorageGiB: 30},
+			Total:         &capacity.Resources{VCPUs: 6, MemoryGiB: 12, StorageGiB: 90},
+		},
+		{
+			Name:          "md-0",
+			MachineConfig: "worker",
+			Count:         4,
+			PerMachine:    &capacity.Resources{VCPUs: 8, MemoryGiB: 32, StorageGiB: 100},
+			Total:         &capacity.Resources{VCPUs: 32, MemoryGiB: 128, StorageGiB: 400},
+		},
+	}))
+	g.Expect(report.Total).To(Equal(capacity.Resources{VCPUs: 44, MemoryGiB: 164, StorageGiB: 565}))
+	g.Expect(*report.Cost).To(BeNumerically("~", 660.5))
+	g.Expect(report.SnowDevices).To(BeEmpty())
+}
+
+func TestEstimateNutanix(t *testing.T) {
+	g := NewWithT(t)
+	machineConfig := &v1alpha1.NutanixMachineConfig{Spec: v1alpha1.NutanixMachineConfigSpec{
+		VCPUSockets:    2,
+		VCPUsPerSocket: 2,
+		MemorySize:     resource.MustParse("8Gi"),
+		SystemDiskSize: resource.MustParse("40Gi"),
+	}}
+	config := &cluster.Config{
+		Cluster: newCluster(v1alpha1.NutanixDatacenterKind),
+		NutanixMachineConfigs: map[string]*v1alpha1.NutanixMachineConfig{
+			"cp":     machineConfig,
+			"worker": machineConfig,
+		},
+	}
+
+	report, err := capacity.NewEstimator().Estimate(config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Total).To(Equal(capacity.Resources{VCPUs: 20, MemoryGiB: 40, StorageGiB: 200}))
+	g.Expect(report.Cost).To(BeNil())
+}
+
+func TestEstimateCloudStackIncomplete(t *testing.T) {
+	g := NewWithT(t)
+	config := &cluster.Config{Cluster: newCluster(v1alpha1.CloudStackDatacenterKind)}
+
+	report, err := capacity.NewEstimator().Estimate(config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Incomplete).To(BeTrue())
+	g.Expect(report.MachineGroups).To(HaveLen(2))
+	g.Expect(report.MachineGroups[0].PerMachine).To(BeNil())
+	g.Expect(report.Total).To(Equal(capacity.Resources{}))
+}
+
+func TestEstimateMachineConfigNotFound(t *testing.T) {
+	g := NewWithT(t)
+	config := &cluster.Config{Cluster: newCluster(v1alpha1.VSphereDatacenterKind)}
+
+	_, err := capacity.NewEstimator().Estimate(config)
+	g.Expect(err).To(MatchError("estimating machine group control-plane: VSphereMachineConfig cp not found"))
+}
+
+func TestEstimateSnowDevices(t *testing.T) {
+	g := NewWithT(t)
+	config := &cluster.Config{
+		Cluster: newCluster(v1alpha1.SnowDatacenterKind),
+		SnowMachineConfigs: map[string]*v1alpha1.SnowMachineConfig{
+			"cp": {Spec: v1alpha1.SnowMachineConfigSpec{
+				Devices: []string{"1.2.3.4", "1.2.3.5"},
+			}},
+			"worker": {Spec: v1alpha1.SnowMachineConfigSpec{
+				InstanceType:     v1alpha1.SbeC4XLarge,
+				Devices:          []string{"1.2.3.5"},
+				ContainersVolume: &snowv1.Volume{Size: 100},
+			}},
+		},
+	}
+
+	report, err := capacity.NewEstimator(capacity.WithSnowDeviceCapacity(capacity.Resources{VCPUs: 32, MemoryGiB: 128})).Estimate(config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Total).To(Equal(capacity.Resources{VCPUs: 38, MemoryGiB: 152, StorageGiB: 200}))
+	g.Expect(report.SnowDevices).To(Equal([]capacity.SnowDevice{
+		{
+			Device:   "1.2.3.4",
+			Machines: 2,
+			Demand:   capacity.Resources{VCPUs: 4, MemoryGiB: 16},
+			Capacity: capacity.Resources{VCPUs: 32, MemoryGiB: 128},
+			Fits:     true,
+		},
+		{
+			Device:   "1.2.3.5",
+			Machines: 3,
+			Demand:   capacity.Resources{VCPUs: 34, MemoryGiB: 136, StorageGiB: 200},
+			Capacity: capacity.Resources{VCPUs: 32, MemoryGiB: 128},
+			Fits:     false,
+		},
+	}))
+	g.Expect(report.SnowDevicesFit()).To(BeFalse())
+}
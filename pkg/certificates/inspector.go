@@ -0,0 +1,269 @@
+package certificates
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// ControlPlaneComponent is the component of the certificates of the control plane nodes.
+	ControlPlaneComponent = "control-plane"
+	// EtcdComponent is the component of the certificates of the etcd nodes.
+	EtcdComponent = "etcd"
+
+	// APIServerCertificate is the serving certificate of kube-apiserver.
+	APIServerCertificate = "kube-apiserver"
+	// EtcdServerCertificate is the serving certificate of etcd.
+	EtcdServerCertificate = "etcd-server"
+	// APIServerEtcdClientCertificate is the certificate kube-apiserver uses to connect to an external etcd.
+	APIServerEtcdClientCertificate = "apiserver-etcd-client"
+
+	apiServerPort = 6443
+	etcdPort      = 2379
+	dialTimeout   = 10 * time.Second
+)
+
+// Certificate is the observed expiry of a certificate of a cluster.
+type Certificate struct {
+	Component string
+	Name      string
+	Machine   string
+	NotAfter  time.Time
+}
+
+// DaysToExpiry returns the number of full days left before the certificate expires at now.
+func (c Certificate) DaysToExpiry(now time.Time) int {
+	return int(c.NotAfter.Sub(now).Hours() / 24)
+}
+
+// PeerCertificateReader returns the leaf certificate presented by the server at address.
+type PeerCertificateReader interface {
+	PeerCertificate(ctx context.Context, address string, config *tls.Config) (*x509.Certificate, error)
+}
+
+// TLSReader reads peer certificates performing a TLS handshake.
+type TLSReader struct{}
+
+// PeerCertificate performs a TLS handshake with address and returns the leaf certificate presented by the server.
+func (TLSReader) PeerCertificate(ctx context.Context, address string, config *tls.Config) (*x509.Certificate, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: dialTimeout},
+		Config:    config,
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate presented by %s", address)
+	}
+	return certs[0], nil
+}
+
+// Inspector reads the expiry of the control plane and etcd certificates of the workload clusters
+// from their management cluster.
+type Inspector struct {
+	client client.Client
+	reader PeerCertificateReader
+}
+
+// NewInspector constructs a new Inspector.
+func NewInspector(client client.Client, reader PeerCertificateReader) *Inspector {
+	return &Inspector{
+		client: client,
+		reader: reader,
+	}
+}
+
+// Inspect returns the certificates of the control plane and etcd machines of cluster, sorted by
+// component, machine and name. Machines that can't be reached are skipped and reported in the
+// returned error, along with the certificates that could be read.
+func (i *Inspector) Inspect(ctx context.Context, cluster *anywherev1.Cluster) ([]Certificate, error) {
+	machines := &clusterv1.MachineList{}
+	if err := i.client.List(ctx, machines,
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{clusterv1.ClusterLabelName: cluster.Name},
+	); err != nil {
+		return nil, fmt.Errorf("listing machines for cluster %s: %v", cluster.Name, err)
+	}
+
+	certs, err := i.inspectMachines(ctx, cluster, machines.Items)
+	if err != nil && len(certs) == 0 {
+		return nil, err
+	}
+
+	sort.Slice(certs, func(a, b int) bool {
+		if certs[a].Component != certs[b].Component {
+			return certs[a].Component < certs[b].Component
+		}
+		if certs[a].Machine != certs[b].Machine {
+			return certs[a].Machine < certs[b].Machine
+		}
+		return certs[a].Name < certs[b].Name
+	})
+
+	return certs, err
+}
+
+func (i *Inspector) inspectMachines(ctx context.Context, cluster *anywherev1.Cluster, machines []clusterv1.Machine) ([]Certificate, error) {
+	var certs []Certificate
+	var errs []error
+	var controlPlaneConfig, etcdConfig *tls.Config
+	for m := range machines {
+		machine := &machines[m]
+		address := machineAddress(machine)
+
+		var err error
+		switch {
+		case address == "":
+			continue
+		case isControlPlane(machine):
+			if controlPlaneConfig == nil {
+				if controlPlaneConfig, err = i.controlPlaneTLSConfig(ctx, cluster); err != nil {
+					return nil, err
+				}
+			}
+			certs, err = i.read(ctx, certs, machine, net.JoinHostPort(address, strconv.Itoa(apiServerPort)), controlPlaneConfig, ControlPlaneComponent, APIServerCertificate)
+		case isEtcd(machine):
+			if etcdConfig == nil {
+				if etcdConfig, err = i.etcdTLSConfig(ctx, cluster); err != nil {
+					return nil, err
+				}
+				certs = append(certs, Certificate{
+					Component: EtcdComponent,
+					Name:      APIServerEtcdClientCertificate,
+					NotAfter:  etcdConfig.Certificates[0].Leaf.NotAfter,
+				})
+			}
+			certs, err = i.read(ctx, certs, machine, net.JoinHostPort(address, strconv.Itoa(etcdPort)), etcdConfig, EtcdComponent, EtcdServerCertificate)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return certs, kerrors.NewAggregate(errs)
+}
+
+// read appends to certs the certificate presented by machine at address.
+func (i *Inspector) read(ctx context.Context, certs []Certificate, machine *clusterv1.Machine, address string, config *tls.Config, component, name string) ([]Certificate, error) {
+	c, err := i.reader.PeerCertificate(ctx, address, config)
+	if err != nil {
+		return certs, fmt.Errorf("reading %s certificate of machine %s: %v", name, machine.Name, err)
+	}
+
+	return append(certs, Certificate{
+		Component: component,
+		Name:      name,
+		Machine:   machine.Name,
+		NotAfter:  c.NotAfter,
+	}), nil
+}
+
+func (i *Inspector) controlPlaneTLSConfig(ctx context.Context, cluster *anywherev1.Cluster) (*tls.Config, error) {
+	ca, err := i.secretData(ctx, secret.Name(cluster.Name, secret.ClusterCA), secret.TLSCrtDataName)
+	if err != nil {
+		return nil, err
+	}
+
+	return tlsConfig(ca, nil)
+}
+
+func (i *Inspector) etcdTLSConfig(ctx context.Context, cluster *anywherev1.Cluster) (*tls.Config, error) {
+	ca, err := i.secretData(ctx, secret.Name(cluster.Name, secret.EtcdCA), secret.TLSCrtDataName)
+	if err != nil {
+		return nil, err
+	}
+
+	clientSecret := secret.Name(cluster.Name, secret.APIServerEtcdClient)
+	crt, err := i.secretData(ctx, clientSecret, secret.TLSCrtDataName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := i.secretData(ctx, clientSecret, secret.TLSKeyDataName)
+	if err != nil {
+		return nil, err
+	}
+	keyPair, err := tls.X509KeyPair(crt, key)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate in secret %s: %v", clientSecret, err)
+	}
+	if keyPair.Leaf, err = x509.ParseCertificate(keyPair.Certificate[0]); err != nil {
+		return nil, fmt.Errorf("parsing certificate in secret %s: %v", clientSecret, err)
+	}
+
+	return tlsConfig(ca, &keyPair)
+}
+
+func (i *Inspector) secretData(ctx context.Context, name, key string) ([]byte, error) {
+	s := &corev1.Secret{}
+	if err := i.client.Get(ctx, client.ObjectKey{Name: name, Namespace: constants.EksaSystemNamespace}, s); err != nil {
+		return nil, fmt.Errorf("getting secret %s: %v", name, err)
+	}
+	data, ok := s.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s doesn't contain %s", name, key)
+	}
+	return data, nil
+}
+
+func tlsConfig(ca []byte, clientCert *tls.Certificate) (*tls.Config, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("invalid CA certificate")
+	}
+
+	config := &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	if clientCert != nil {
+		config.Certificates = []tls.Certificate{*clientCert}
+	}
+
+	return config, nil
+}
+
+func isControlPlane(m *clusterv1.Machine) bool {
+	_, ok := m.Labels[clusterv1.MachineControlPlaneLabelName]
+	return ok
+}
+
+func isEtcd(m *clusterv1.Machine) bool {
+	_, ok := m.Labels[clusterv1.MachineEtcdClusterLabelName]
+	return ok
+}
+
+// machineAddress returns the first internal address of m, falling back to its first external address.
+// It returns an empty address for machines being deleted.
+func machineAddress(m *clusterv1.Machine) string {
+	if !m.DeletionTimestamp.IsZero() {
+		return ""
+	}
+	for _, t := range []clusterv1.MachineAddressType{clusterv1.MachineInternalIP, clusterv1.MachineExternalIP} {
+		for _, a := range m.Status.Addresses {
+			if a.Type == t {
+				return a.Address
+			}
+		}
+	}
+	return ""
+}
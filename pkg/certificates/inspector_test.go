@@ -0,0 +1,193 @@
+package certificates_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+var expiry = time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
+type fakeReader struct {
+	addresses []string
+	notAfter  map[string]time.Time
+}
+
+func (f *fakeReader) PeerCertificate(_ context.Context, address string, config *tls.Config) (*x509.Certificate, error) {
+	f.addresses = append(f.addresses, address)
+	if config.RootCAs == nil {
+		return nil, errors.New("no root CAs")
+	}
+	notAfter, ok := f.notAfter[address]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return &x509.Certificate{NotAfter: notAfter}, nil
+}
+
+func certificatePEM(t *testing.T, notAfter time.Time) (crt, key []byte) {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &k.PublicKey, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)})
+}
+
+func secret(name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace},
+		Data:       data,
+	}
+}
+
+func machine(name, label, address string) *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1.ClusterLabelName: "workload"},
+		},
+	}
+	if label != "" {
+		m.Labels[label] = ""
+	}
+	if address != "" {
+		m.Status.Addresses = clusterv1.MachineAddresses{
+			{Type: clusterv1.MachineExternalIP, Address: "192.168.0.1"},
+			{Type: clusterv1.MachineInternalIP, Address: address},
+		}
+	}
+	return m
+}
+
+func newClient(g *WithT, objs ...runtime.Object) client.Client {
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	return fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+}
+
+var cluster = &anywherev1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+}
+
+func TestInspectorInspect(t *testing.T) {
+	g := NewWithT(t)
+	ca, _ := certificatePEM(t, expiry.Add(10*365*24*time.Hour))
+	clientCrt, clientKey := certificatePEM(t, expiry.Add(-24*time.Hour))
+	objs := []runtime.Object{
+		secret("workload-ca", map[string][]byte{"tls.crt": ca}),
+		secret("workload-etcd", map[string][]byte{"tls.crt": ca}),
+		secret("workload-apiserver-etcd-client", map[string][]byte{"tls.crt": clientCrt, "tls.key": clientKey}),
+		machine("workload-cp-2", clusterv1.MachineControlPlaneLabelName, "10.0.0.2"),
+		machine("workload-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1"),
+		machine("workload-cp-3", clusterv1.MachineControlPlaneLabelName, ""),
+		machine("workload-etcd-1", clusterv1.MachineEtcdClusterLabelName, "10.0.0.10"),
+		machine("workload-md-0-1", "", "10.0.0.20"),
+	}
+	reader := &fakeReader{notAfter: map[string]time.Time{
+		"10.0.0.1:6443":  expiry,
+		"10.0.0.2:6443":  expiry.Add(time.Hour),
+		"10.0.0.10:2379": expiry.Add(2 * time.Hour),
+	}}
+	i := certificates.NewInspector(newClient(g, objs...), reader)
+
+	certs, err := i.Inspect(context.Background(), cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reader.addresses).To(ConsistOf("10.0.0.1:6443", "10.0.0.2:6443", "10.0.0.10:2379"))
+	g.Expect(certs).To(Equal([]certificates.Certificate{
+		{Component: "control-plane", Name: "kube-apiserver", Machine: "workload-cp-1", NotAfter: expiry},
+		{Component: "control-plane", Name: "kube-apiserver", Machine: "workload-cp-2", NotAfter: expiry.Add(time.Hour)},
+		{Component: "etcd", Name: "apiserver-etcd-client", NotAfter: expiry.Add(-24 * time.Hour)},
+		{Component: "etcd", Name: "etcd-server", Machine: "workload-etcd-1", NotAfter: expiry.Add(2 * time.Hour)},
+	}))
+}
+
+func TestInspectorInspectUnreachableMachine(t *testing.T) {
+	g := NewWithT(t)
+	ca, _ := certificatePEM(t, expiry)
+	objs := []runtime.Object{
+		secret("workload-ca", map[string][]byte{"tls.crt": ca}),
+		machine("workload-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1"),
+		machine("workload-cp-2", clusterv1.MachineControlPlaneLabelName, "10.0.0.2"),
+	}
+	reader := &fakeReader{notAfter: map[string]time.Time{"10.0.0.1:6443": expiry}}
+	i := certificates.NewInspector(newClient(g, objs...), reader)
+
+	certs, err := i.Inspect(context.Background(), cluster)
+	g.Expect(err).To(MatchError("reading kube-apiserver certificate of machine workload-cp-2: connection refused"))
+	g.Expect(certs).To(HaveLen(1))
+}
+
+func TestInspectorInspectMissingCA(t *testing.T) {
+	g := NewWithT(t)
+	objs := []runtime.Object{
+		machine("workload-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1"),
+	}
+	i := certificates.NewInspector(newClient(g, objs...), &fakeReader{})
+
+	_, err := i.Inspect(context.Background(), cluster)
+	g.Expect(err).To(MatchError(ContainSubstring("getting secret workload-ca")))
+}
+
+func TestCertificateDaysToExpiry(t *testing.T) {
+	g := NewWithT(t)
+	c := certificates.Certificate{NotAfter: expiry}
+	g.Expect(c.DaysToExpiry(expiry.Add(-49 * time.Hour))).To(Equal(2))
+	g.Expect(c.DaysToExpiry(expiry.Add(time.Hour))).To(Equal(0))
+}
+
+func TestTLSReaderPeerCertificate(t *testing.T) {
+	g := NewWithT(t)
+	crt, key := certificatePEM(t, expiry)
+	keyPair, err := tls.X509KeyPair(crt, key)
+	g.Expect(err).NotTo(HaveOccurred())
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{keyPair}})
+	g.Expect(err).NotTo(HaveOccurred())
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			_ = conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	c, err := certificates.TLSReader{}.PeerCertificate(context.Background(), listener.Addr().String(), &tls.Config{InsecureSkipVerify: true}) // #nosec G402
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(c.NotAfter).To(Equal(expiry))
+}
@@ -0,0 +1,10 @@
+package kubernetes
+
+import (
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func SetNewClient(f *RuntimeClientFactory, newClient func(config *rest.Config, options client.Options) (client.Client, error)) {
+	f.newClient = newClient
+}
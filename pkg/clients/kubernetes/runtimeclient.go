@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// RuntimeClientFactory builds typed controller-runtime clients that talk directly
+// to the kube API server, without shelling out to kubectl.
+// Clients are cached per kubeconfig file, so the API discovery cost is only paid once.
+// It is safe for concurrent use.
+type RuntimeClientFactory struct {
+	restConfig func(kubeconfig []byte) (*rest.Config, error)
+	newClient  func(config *rest.Config, options client.Options) (client.Client, error)
+
+	mu      sync.Mutex
+	clients map[string]client.Client
+}
+
+// NewRuntimeClientFactory returns a new RuntimeClientFactory.
+func NewRuntimeClientFactory() *RuntimeClientFactory {
+	return &RuntimeClientFactory{
+		restConfig: clientcmd.RESTConfigFromKubeConfig,
+		newClient:  client.New,
+		clients:    map[string]client.Client{},
+	}
+}
+
+// BuildClientFromKubeconfig returns a client authenticated with the credentials in the kubeconfig file.
+func (f *RuntimeClientFactory) BuildClientFromKubeconfig(kubeconfig string) (client.Client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if c, ok := f.clients[kubeconfig]; ok {
+		return c, nil
+	}
+
+	c, err := f.build(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	f.clients[kubeconfig] = c
+
+	return c, nil
+}
+
+func (f *RuntimeClientFactory) build(kubeconfig string) (client.Client, error) {
+	data, err := os.ReadFile(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("reading kubeconfig for kubernetes client: %v", err)
+	}
+
+	config, err := f.restConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("building rest config from kubeconfig %s: %v", kubeconfig, err)
+	}
+
+	scheme, err := NewScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := f.newClient(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("building kubernetes client for kubeconfig %s: %v", kubeconfig, err)
+	}
+
+	return c, nil
+}
+
+// NewScheme returns a scheme with all the API types the CLI works with,
+// including the core kubernetes types.
+func NewScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("adding core types to scheme: %v", err)
+	}
+	if err := addToScheme(scheme, schemeAdders...); err != nil {
+		return nil, fmt.Errorf("adding types to scheme: %v", err)
+	}
+	for _, adder := range []schemeAdder{releasev1.AddToScheme, eksdv1.AddToScheme} {
+		if err := adder(scheme); err != nil {
+			return nil, fmt.Errorf("adding release types to scheme: %v", err)
+		}
+	}
+
+	return scheme, nil
+}
@@ -0,0 +1,82 @@
+package kubernetes_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const kubeconfigContent = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:6443
+  name: test
+contexts:
+- context:
+    cluster: test
+    user: test
+  name: test
+current-context: test
+users:
+- name: test
+  user:
+    token: token
+`
+
+func writeKubeconfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.kubeconfig")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRuntimeClientFactoryBuildClientFromKubeconfigCachesClients(t *testing.T) {
+	g := NewWithT(t)
+	kubeconfig := writeKubeconfig(t, kubeconfigContent)
+	f := kubernetes.NewRuntimeClientFactory()
+	builds := 0
+	kubernetes.SetNewClient(f, func(config *rest.Config, options client.Options) (client.Client, error) {
+		builds++
+		g.Expect(config.Host).To(Equal("https://127.0.0.1:6443"))
+		g.Expect(options.Scheme.Recognizes(anywherev1.GroupVersion.WithKind("Cluster"))).To(BeTrue())
+		g.Expect(options.Scheme.Recognizes(releasev1.GroupVersion.WithKind("Bundles"))).To(BeTrue())
+		return fake.NewClientBuilder().Build(), nil
+	})
+
+	c1, err := f.BuildClientFromKubeconfig(kubeconfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	c2, err := f.BuildClientFromKubeconfig(kubeconfig)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c2).To(BeIdenticalTo(c1))
+	g.Expect(builds).To(Equal(1))
+}
+
+func TestRuntimeClientFactoryBuildClientFromKubeconfigMissingFile(t *testing.T) {
+	g := NewWithT(t)
+	f := kubernetes.NewRuntimeClientFactory()
+
+	_, err := f.BuildClientFromKubeconfig(filepath.Join(t.TempDir(), "missing.kubeconfig"))
+	g.Expect(err).To(MatchError(ContainSubstring("reading kubeconfig for kubernetes client")))
+}
+
+func TestRuntimeClientFactoryBuildClientFromKubeconfigInvalidKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+	kubeconfig := writeKubeconfig(t, "invalid kubeconfig")
+	f := kubernetes.NewRuntimeClientFactory()
+
+	_, err := f.BuildClientFromKubeconfig(kubeconfig)
+	g.Expect(err).To(MatchError(ContainSubstring("building rest config from kubeconfig")))
+}
@@ -0,0 +1,266 @@
+// Package cliupgrade upgrades the EKS-A CLI binary to a release of the EKS-A release manifest.
+package cliupgrade
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifests/releases"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// BinaryName is the name of the EKS-A CLI binary in the release archives.
+const BinaryName = "eksctl-anywhere"
+
+// Reader reads files from a URL or a local path.
+type Reader interface {
+	ReadFile(url string) ([]byte, error)
+}
+
+// Upgrader replaces the EKS-A CLI binary with the one of a release.
+type Upgrader struct {
+	reader          Reader
+	executable      string
+	goos, goarch    string
+	artifactsMirror *url.URL
+}
+
+// UpgraderOpt configures an Upgrader.
+type UpgraderOpt func(*Upgrader)
+
+// WithExecutable sets the path of the binary to replace. It defaults to the running executable.
+func WithExecutable(path string) UpgraderOpt {
+	return func(u *Upgrader) {
+		u.executable = path
+	}
+}
+
+// WithPlatform sets the platform of the binary to download. It defaults to the running platform.
+func WithPlatform(goos, goarch string) UpgraderOpt {
+	return func(u *Upgrader) {
+		u.goos = goos
+		u.goarch = goarch
+	}
+}
+
+// WithArtifactsMirror downloads the release archives from a mirror of the release artifacts,
+// like a local artifact server in air-gapped environments. The mirror replaces the scheme
+// and host of the archive URIs, their path is kept.
+func WithArtifactsMirror(mirror *url.URL) UpgraderOpt {
+	return func(u *Upgrader) {
+		u.artifactsMirror = mirror
+	}
+}
+
+// NewUpgrader constructs a new Upgrader.
+func NewUpgrader(reader Reader, opts ...UpgraderOpt) *Upgrader {
+	u := &Upgrader{
+		reader: reader,
+		goos:   runtime.GOOS,
+		goarch: runtime.GOARCH,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// Release returns the release of the manifest for version, the latest release when version is empty.
+func (u *Upgrader) Release(manifestURL, version string) (*releasev1.EksARelease, error) {
+	manifest, err := releases.ReadReleasesFromURL(u.reader, manifestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == "" {
+		version = manifest.Spec.LatestVersion
+	}
+	for _, r := range manifest.Spec.Releases {
+		if r.Version == version {
+			return &r, nil
+		}
+	}
+
+	return nil, fmt.Errorf("release %s not found in release manifest %s", version, manifestURL)
+}
+
+// Upgrade downloads the binary of the release for the platform, verifies its checksum and
+// atomically replaces the executable with it.
+func (u *Upgrader) Upgrade(release *releasev1.EksARelease) error {
+	archive, err := u.archive(release)
+	if err != nil {
+		return err
+	}
+
+	uri, err := u.mirror(archive.URI)
+	if err != nil {
+		return err
+	}
+
+	logger.V(2).Info("Downloading EKS-A CLI", "version", release.Version, "uri", uri)
+	content, err := u.reader.ReadFile(uri)
+	if err != nil {
+		return fmt.Errorf("downloading EKS-A CLI archive: %v", err)
+	}
+
+	if err := verify(archive, content); err != nil {
+		return err
+	}
+
+	binary, err := extractBinary(content)
+	if err != nil {
+		return err
+	}
+
+	return u.replace(binary)
+}
+
+func (u *Upgrader) archive(release *releasev1.EksARelease) (*releasev1.Archive, error) {
+	var archive releasev1.Archive
+	switch u.goos {
+	case "linux":
+		archive = release.EksABinary.LinuxBinary
+	case "darwin":
+		archive = release.EksABinary.DarwinBinary
+	default:
+		return nil, fmt.Errorf("release %s doesn't include a binary for %s", release.Version, u.goos)
+	}
+
+	if archive.URI == "" || !supportsArch(archive, u.goarch) {
+		return nil, fmt.Errorf("release %s doesn't include a binary for %s/%s", release.Version, u.goos, u.goarch)
+	}
+
+	return &archive, nil
+}
+
+func supportsArch(archive releasev1.Archive, goarch string) bool {
+	if len(archive.Arch) == 0 {
+		return true
+	}
+	for _, a := range archive.Arch {
+		if a == goarch {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *Upgrader) mirror(uri string) (string, error) {
+	if u.artifactsMirror == nil {
+		return uri, nil
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("parsing EKS-A CLI archive uri: %v", err)
+	}
+	mirrored := *u.artifactsMirror
+	mirrored.Path = path.Join(mirrored.Path, parsed.Path)
+	return mirrored.String(), nil
+}
+
+func verify(archive *releasev1.Archive, content []byte) error {
+	if archive.SHA256 == "" && archive.SHA512 == "" {
+		return errors.New("the release manifest doesn't include a checksum for the EKS-A CLI archive")
+	}
+
+	if archive.SHA256 != "" {
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != archive.SHA256 {
+			return fmt.Errorf("EKS-A CLI archive sha256 checksum doesn't match the release manifest")
+		}
+	}
+
+	if archive.SHA512 != "" {
+		sum := sha512.Sum512(content)
+		if hex.EncodeToString(sum[:]) != archive.SHA512 {
+			return fmt.Errorf("EKS-A CLI archive sha512 checksum doesn't match the release manifest")
+		}
+	}
+
+	return nil
+}
+
+func extractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("reading EKS-A CLI archive: %v", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found in EKS-A CLI archive", BinaryName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading EKS-A CLI archive: %v", err)
+		}
+		if header.Typeflag == tar.TypeReg && path.Base(header.Name) == BinaryName {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// replace writes the binary to a temporary file next to the executable and renames it over the
+// executable, so the executable is either the old or the new binary, never a partial one.
+func (u *Upgrader) replace(binary []byte) error {
+	executable, err := u.executablePath()
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(executable)
+	if err != nil {
+		return fmt.Errorf("reading EKS-A CLI executable: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(executable), "."+BinaryName+"-*")
+	if err != nil {
+		return fmt.Errorf("creating EKS-A CLI temporary file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing EKS-A CLI temporary file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing EKS-A CLI temporary file: %v", err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("setting EKS-A CLI permissions: %v", err)
+	}
+
+	if err := os.Rename(tmp.Name(), executable); err != nil {
+		return fmt.Errorf("replacing EKS-A CLI executable: %v", err)
+	}
+
+	return nil
+}
+
+func (u *Upgrader) executablePath() (string, error) {
+	if u.executable != "" {
+		return u.executable, nil
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("finding EKS-A CLI executable: %v", err)
+	}
+	return filepath.EvalSymlinks(executable)
+}
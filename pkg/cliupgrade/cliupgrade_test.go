@@ -0,0 +1,181 @@
+package cliupgrade_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cliupgrade"
+	"github.com/aws/eks-anywhere/pkg/cliupgrade/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	manifestURL = "https://anywhere-assets.eks.amazonaws.com/releases/eks-a/manifest.yaml"
+	archiveURI  = "https://anywhere-assets.eks.amazonaws.com/releases/eks-a/2/artifacts/eks-a/v0.12.0/linux/amd64/eksctl-anywhere-v0.12.0-linux-amd64.tar.gz"
+)
+
+type upgraderTest struct {
+	*WithT
+	reader     *mocks.MockReader
+	executable string
+	archive    []byte
+	release    releasev1.EksARelease
+}
+
+func newUpgraderTest(t *testing.T) *upgraderTest {
+	g := NewWithT(t)
+	executable := filepath.Join(t.TempDir(), cliupgrade.BinaryName)
+	g.Expect(os.WriteFile(executable, []byte("old"), 0o755)).To(Succeed())
+
+	archive := tarball(t, map[string]string{"./LICENSE": "license", "./eksctl-anywhere": "new"})
+	sum := sha256.Sum256(archive)
+
+	return &upgraderTest{
+		WithT:      g,
+		reader:     mocks.NewMockReader(gomock.NewController(t)),
+		executable: executable,
+		archive:    archive,
+		release: releasev1.EksARelease{
+			Version: "v0.12.0",
+			EksABinary: releasev1.BinaryBundle{
+				LinuxBinary: releasev1.Archive{
+					URI:    archiveURI,
+					Arch:   []string{"amd64"},
+					SHA256: hex.EncodeToString(sum[:]),
+				},
+			},
+		},
+	}
+}
+
+func (tt *upgraderTest) upgrader(opts ...cliupgrade.UpgraderOpt) *cliupgrade.Upgrader {
+	opts = append([]cliupgrade.UpgraderOpt{
+		cliupgrade.WithExecutable(tt.executable),
+		cliupgrade.WithPlatform("linux", "amd64"),
+	}, opts...)
+	return cliupgrade.NewUpgrader(tt.reader, opts...)
+}
+
+func (tt *upgraderTest) expectExecutable(content string) {
+	tt.Expect(os.ReadFile(tt.executable)).To(Equal([]byte(content)))
+	info, err := os.Stat(tt.executable)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o755)))
+}
+
+func tarball(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUpgraderUpgrade(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.reader.EXPECT().ReadFile(archiveURI).Return(tt.archive, nil)
+
+	tt.Expect(tt.upgrader().Upgrade(&tt.release)).To(Succeed())
+	tt.expectExecutable("new")
+}
+
+func TestUpgraderUpgradeArtifactsMirror(t *testing.T) {
+	tt := newUpgraderTest(t)
+	mirror, err := url.Parse("http://10.0.0.5:8080/eks-a")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.reader.EXPECT().ReadFile("http://10.0.0.5:8080/eks-a/releases/eks-a/2/artifacts/eks-a/v0.12.0/linux/amd64/eksctl-anywhere-v0.12.0-linux-amd64.tar.gz").Return(tt.archive, nil)
+
+	tt.Expect(tt.upgrader(cliupgrade.WithArtifactsMirror(mirror)).Upgrade(&tt.release)).To(Succeed())
+	tt.expectExecutable("new")
+}
+
+func TestUpgraderUpgradeChecksumMismatch(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.release.EksABinary.LinuxBinary.SHA256 = "0000"
+	tt.reader.EXPECT().ReadFile(archiveURI).Return(tt.archive, nil)
+
+	tt.Expect(tt.upgrader().Upgrade(&tt.release)).To(MatchError(ContainSubstring("sha256 checksum doesn't match")))
+	tt.expectExecutable("old")
+}
+
+func TestUpgraderUpgradeNoChecksum(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.release.EksABinary.LinuxBinary.SHA256 = ""
+	tt.reader.EXPECT().ReadFile(archiveURI).Return(tt.archive, nil)
+
+	tt.Expect(tt.upgrader().Upgrade(&tt.release)).To(MatchError(ContainSubstring("doesn't include a checksum")))
+	tt.expectExecutable("old")
+}
+
+func TestUpgraderUpgradeUnsupportedPlatform(t *testing.T) {
+	tt := newUpgraderTest(t)
+
+	tt.Expect(tt.upgrader(cliupgrade.WithPlatform("linux", "arm64")).Upgrade(&tt.release)).To(
+		MatchError("release v0.12.0 doesn't include a binary for linux/arm64"),
+	)
+	tt.Expect(tt.upgrader(cliupgrade.WithPlatform("darwin", "amd64")).Upgrade(&tt.release)).To(
+		MatchError("release v0.12.0 doesn't include a binary for darwin/amd64"),
+	)
+}
+
+func TestUpgraderUpgradeBinaryNotInArchive(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.archive = tarball(t, map[string]string{"./LICENSE": "license"})
+	sum := sha256.Sum256(tt.archive)
+	tt.release.EksABinary.LinuxBinary.SHA256 = hex.EncodeToString(sum[:])
+	tt.reader.EXPECT().ReadFile(archiveURI).Return(tt.archive, nil)
+
+	tt.Expect(tt.upgrader().Upgrade(&tt.release)).To(MatchError("eksctl-anywhere not found in EKS-A CLI archive"))
+	tt.expectExecutable("old")
+}
+
+func TestUpgraderUpgradeDownloadError(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.reader.EXPECT().ReadFile(archiveURI).Return(nil, errors.New("connection refused"))
+
+	tt.Expect(tt.upgrader().Upgrade(&tt.release)).To(MatchError("downloading EKS-A CLI archive: connection refused"))
+}
+
+func TestUpgraderRelease(t *testing.T) {
+	tt := newUpgraderTest(t)
+	previous := releasev1.EksARelease{Version: "v0.11.4"}
+	manifest := &releasev1.Release{
+		Spec: releasev1.ReleaseSpec{
+			LatestVersion: "v0.12.0",
+			Releases:      []releasev1.EksARelease{previous, tt.release},
+		},
+	}
+	content, err := yaml.Marshal(manifest)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.reader.EXPECT().ReadFile(manifestURL).Return(content, nil).Times(3)
+
+	tt.Expect(tt.upgrader().Release(manifestURL, "")).To(Equal(&tt.release))
+	tt.Expect(tt.upgrader().Release(manifestURL, "v0.11.4")).To(Equal(&previous))
+	_, err = tt.upgrader().Release(manifestURL, "v0.10.0")
+	tt.Expect(err).To(MatchError(ContainSubstring("release v0.10.0 not found")))
+}
@@ -6,9 +6,17 @@ func NewDefaultConfigClientBuilder() *ConfigClientBuilder {
 	return NewConfigClientBuilder().Register(
 		getVSphereDatacenter,
 		getVSphereMachineConfigs,
+		getCloudStackDatacenter,
+		getCloudStackMachineConfigs,
 		getSnowDatacenter,
 		getSnowMachineConfigs,
+		getSnowIPPools,
 		getSnowIdentitySecret,
+		getTinkerbellDatacenter,
+		getTinkerbellMachineConfigs,
+		getNutanixDatacenter,
+		getNutanixMachineConfigs,
+		getDockerDatacenter,
 		getOIDC,
 		getAWSIam,
 		getGitOps,
@@ -1,11 +1,17 @@
 package cluster_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/cluster/mocks"
 )
 
 func TestParseConfigMissingCloudstackDatacenter(t *testing.T) {
@@ -15,3 +21,90 @@ func TestParseConfigMissingCloudstackDatacenter(t *testing.T) {
 	g.Expect(err).To(Not(HaveOccurred()))
 	g.Expect(got.CloudStackDatacenter).To(BeNil())
 }
+
+func TestDefaultConfigClientBuilderCloudStackCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	b := cluster.NewDefaultConfigClientBuilder()
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{
+				Kind: anywherev1.CloudStackDatacenterKind,
+				Name: "datacenter",
+			},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				MachineGroupRef: &anywherev1.Ref{
+					Kind: anywherev1.CloudStackMachineConfigKind,
+					Name: "machine-1",
+				},
+			},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{
+					MachineGroupRef: &anywherev1.Ref{
+						Kind: anywherev1.CloudStackMachineConfigKind,
+						Name: "machine-2",
+					},
+				},
+				{
+					MachineGroupRef: &anywherev1.Ref{
+						Kind: anywherev1.VSphereMachineConfigKind, // Should not process this one
+						Name: "machine-3",
+					},
+				},
+			},
+		},
+	}
+	datacenter := &anywherev1.CloudStackDatacenterConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datacenter",
+			Namespace: "default",
+		},
+	}
+	machineControlPlane := &anywherev1.CloudStackMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-1",
+			Namespace: "default",
+		},
+	}
+	machineWorker := &anywherev1.CloudStackMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-2",
+			Namespace: "default",
+		},
+	}
+
+	client.EXPECT().Get(ctx, "datacenter", "default", &anywherev1.CloudStackDatacenterConfig{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			d := obj.(*anywherev1.CloudStackDatacenterConfig)
+			d.ObjectMeta = datacenter.ObjectMeta
+			return nil
+		},
+	)
+	client.EXPECT().Get(ctx, "machine-1", "default", &anywherev1.CloudStackMachineConfig{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			m := obj.(*anywherev1.CloudStackMachineConfig)
+			m.ObjectMeta = machineControlPlane.ObjectMeta
+			return nil
+		},
+	)
+	client.EXPECT().Get(ctx, "machine-2", "default", &anywherev1.CloudStackMachineConfig{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			m := obj.(*anywherev1.CloudStackMachineConfig)
+			m.ObjectMeta = machineWorker.ObjectMeta
+			return nil
+		},
+	)
+
+	config, err := b.Build(ctx, client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.CloudStackDatacenter).To(Equal(datacenter))
+	g.Expect(len(config.CloudStackMachineConfigs)).To(Equal(2))
+	g.Expect(config.CloudStackMachineConfigs["machine-1"]).To(Equal(machineControlPlane))
+	g.Expect(config.CloudStackMachineConfigs["machine-2"]).To(Equal(machineWorker))
+}
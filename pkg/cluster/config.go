@@ -16,15 +16,20 @@ type Config struct {
 	DockerDatacenter         *anywherev1.DockerDatacenterConfig
 	SnowDatacenter           *anywherev1.SnowDatacenterConfig
 	NutanixDatacenter        *anywherev1.NutanixDatacenterConfig
+	TinkerbellDatacenter     *anywherev1.TinkerbellDatacenterConfig
 	VSphereMachineConfigs    map[string]*anywherev1.VSphereMachineConfig
 	CloudStackMachineConfigs map[string]*anywherev1.CloudStackMachineConfig
 	SnowMachineConfigs       map[string]*anywherev1.SnowMachineConfig
+	SnowIPPools              map[string]*anywherev1.SnowIPPool
 	NutanixMachineConfigs    map[string]*anywherev1.NutanixMachineConfig
-	OIDCConfigs              map[string]*anywherev1.OIDCConfig
-	AWSIAMConfigs            map[string]*anywherev1.AWSIamConfig
-	GitOpsConfig             *anywherev1.GitOpsConfig
-	FluxConfig               *anywherev1.FluxConfig
-	SnowCredentialsSecret    *v1.Secret
+	TinkerbellMachineConfigs map[string]*anywherev1.TinkerbellMachineConfig
+	// TinkerbellTemplateConfigs is only set when parsing a config, it's not read from a cluster.
+	TinkerbellTemplateConfigs map[string]*anywherev1.TinkerbellTemplateConfig
+	OIDCConfigs               map[string]*anywherev1.OIDCConfig
+	AWSIAMConfigs             map[string]*anywherev1.AWSIamConfig
+	GitOpsConfig              *anywherev1.GitOpsConfig
+	FluxConfig                *anywherev1.FluxConfig
+	SnowCredentialsSecret     *v1.Secret
 }
 
 func (c *Config) VsphereMachineConfig(name string) *anywherev1.VSphereMachineConfig {
@@ -39,6 +44,11 @@ func (c *Config) SnowMachineConfig(name string) *anywherev1.SnowMachineConfig {
 	return c.SnowMachineConfigs[name]
 }
 
+// SnowIPPool returns the SnowIPPool with the given name, nil if it doesn't exist.
+func (c *Config) SnowIPPool(name string) *anywherev1.SnowIPPool {
+	return c.SnowIPPools[name]
+}
+
 func (c *Config) OIDCConfig(name string) *anywherev1.OIDCConfig {
 	return c.OIDCConfigs[name]
 }
@@ -51,16 +61,28 @@ func (c *Config) NutanixMachineConfig(name string) *anywherev1.NutanixMachineCon
 	return c.NutanixMachineConfigs[name]
 }
 
+// TinkerbellMachineConfig returns the TinkerbellMachineConfig with the given name, nil if it doesn't exist.
+func (c *Config) TinkerbellMachineConfig(name string) *anywherev1.TinkerbellMachineConfig {
+	return c.TinkerbellMachineConfigs[name]
+}
+
+// TinkerbellTemplateConfig returns the TinkerbellTemplateConfig with the given name, nil if it doesn't exist.
+func (c *Config) TinkerbellTemplateConfig(name string) *anywherev1.TinkerbellTemplateConfig {
+	return c.TinkerbellTemplateConfigs[name]
+}
+
 func (c *Config) DeepCopy() *Config {
 	c2 := &Config{
-		Cluster:              c.Cluster.DeepCopy(),
-		CloudStackDatacenter: c.CloudStackDatacenter.DeepCopy(),
-		VSphereDatacenter:    c.VSphereDatacenter.DeepCopy(),
-		NutanixDatacenter:    c.NutanixDatacenter.DeepCopy(),
-		DockerDatacenter:     c.DockerDatacenter.DeepCopy(),
-		SnowDatacenter:       c.SnowDatacenter.DeepCopy(),
-		GitOpsConfig:         c.GitOpsConfig.DeepCopy(),
-		FluxConfig:           c.FluxConfig.DeepCopy(),
+		Cluster:               c.Cluster.DeepCopy(),
+		CloudStackDatacenter:  c.CloudStackDatacenter.DeepCopy(),
+		VSphereDatacenter:     c.VSphereDatacenter.DeepCopy(),
+		NutanixDatacenter:     c.NutanixDatacenter.DeepCopy(),
+		DockerDatacenter:      c.DockerDatacenter.DeepCopy(),
+		SnowDatacenter:        c.SnowDatacenter.DeepCopy(),
+		TinkerbellDatacenter:  c.TinkerbellDatacenter.DeepCopy(),
+		GitOpsConfig:          c.GitOpsConfig.DeepCopy(),
+		FluxConfig:            c.FluxConfig.DeepCopy(),
+		SnowCredentialsSecret: c.SnowCredentialsSecret.DeepCopy(),
 	}
 
 	if c.VSphereMachineConfigs != nil {
@@ -106,6 +128,27 @@ func (c *Config) DeepCopy() *Config {
 		c2.SnowMachineConfigs[k] = v.DeepCopy()
 	}
 
+	if c.SnowIPPools != nil {
+		c2.SnowIPPools = make(map[string]*anywherev1.SnowIPPool, len(c.SnowIPPools))
+	}
+	for k, v := range c.SnowIPPools {
+		c2.SnowIPPools[k] = v.DeepCopy()
+	}
+
+	if c.TinkerbellMachineConfigs != nil {
+		c2.TinkerbellMachineConfigs = make(map[string]*anywherev1.TinkerbellMachineConfig, len(c.TinkerbellMachineConfigs))
+	}
+	for k, v := range c.TinkerbellMachineConfigs {
+		c2.TinkerbellMachineConfigs[k] = v.DeepCopy()
+	}
+
+	if c.TinkerbellTemplateConfigs != nil {
+		c2.TinkerbellTemplateConfigs = make(map[string]*anywherev1.TinkerbellTemplateConfig, len(c.TinkerbellTemplateConfigs))
+	}
+	for k, v := range c.TinkerbellTemplateConfigs {
+		c2.TinkerbellTemplateConfigs[k] = v.DeepCopy()
+	}
+
 	return c2
 }
 
@@ -114,8 +157,8 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 	objs := make(
 		[]kubernetes.Object,
 		0,
-		len(c.VSphereMachineConfigs)+len(c.SnowMachineConfigs)+len(c.CloudStackMachineConfigs)+4,
-		// machine configs length + datacenter + OIDC + IAM + gitops
+		len(c.VSphereMachineConfigs)+len(c.SnowMachineConfigs)+len(c.SnowIPPools)+len(c.CloudStackMachineConfigs)+len(c.TinkerbellMachineConfigs)+len(c.TinkerbellTemplateConfigs)+4,
+		// machine configs length + ip pools length + template configs length + datacenter + OIDC + IAM + gitops
 	)
 
 	objs = appendIfNotNil(objs,
@@ -124,6 +167,7 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 		c.NutanixDatacenter,
 		c.DockerDatacenter,
 		c.SnowDatacenter,
+		c.TinkerbellDatacenter,
 		c.GitOpsConfig,
 		c.FluxConfig,
 	)
@@ -140,10 +184,22 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 		objs = appendIfNotNil(objs, e)
 	}
 
+	for _, e := range c.SnowIPPools {
+		objs = appendIfNotNil(objs, e)
+	}
+
 	for _, e := range c.NutanixMachineConfigs {
 		objs = appendIfNotNil(objs, e)
 	}
 
+	for _, e := range c.TinkerbellMachineConfigs {
+		objs = appendIfNotNil(objs, e)
+	}
+
+	for _, e := range c.TinkerbellTemplateConfigs {
+		objs = appendIfNotNil(objs, e)
+	}
+
 	for _, e := range c.OIDCConfigs {
 		objs = appendIfNotNil(objs, e)
 	}
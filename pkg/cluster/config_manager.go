@@ -3,8 +3,6 @@ package cluster
 import (
 	"errors"
 	"fmt"
-	"regexp"
-	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -12,6 +10,7 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
 )
 
 // ConfigManager allows to parse from yaml, set defaults and validate a Cluster struct
@@ -113,18 +112,16 @@ type parsed struct {
 	cluster *anywherev1.Cluster
 }
 
-var separatorRegex = regexp.MustCompile(`(?m)^---$`)
-
+// unmarshal decodes the known API objects in the manifest. Unknown fields, usually typos in the field
+// names, are reported with their position but don't fail, since they have always been ignored here.
 func (c *ConfigManager) unmarshal(yamlManifest []byte) (*parsed, error) {
 	parsed := &parsed{
 		objects: ObjectLookup{},
 	}
-	yamlObjs := separatorRegex.Split(string(yamlManifest), -1)
 
-	for _, yamlObj := range yamlObjs {
-		trimmedYamlObj := strings.TrimSuffix(yamlObj, "\n")
+	for _, doc := range yamlutil.SplitDocuments(yamlManifest) {
 		k := &basicAPIObject{}
-		err := yaml.Unmarshal([]byte(trimmedYamlObj), k)
+		err := yaml.Unmarshal(doc.Content, k)
 		if err != nil {
 			return nil, err
 		}
@@ -150,8 +147,11 @@ func (c *ConfigManager) unmarshal(yamlManifest []byte) (*parsed, error) {
 			continue
 		}
 
-		if err := yaml.Unmarshal([]byte(trimmedYamlObj), obj); err != nil {
-			return nil, err
+		if strictErr := doc.UnmarshalStrict(obj); strictErr != nil {
+			if err := yaml.Unmarshal(doc.Content, obj); err != nil {
+				return nil, fmt.Errorf("parsing %s %s: %v", k.Kind, k.Name, strictErr)
+			}
+			logger.MarkWarning(fmt.Sprintf("Ignoring invalid field in %s %s: %v", k.Kind, k.Name, strictErr))
 		}
 		parsed.objects.add(obj)
 	}
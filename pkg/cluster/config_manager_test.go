@@ -147,6 +147,24 @@ kind: MysteryCRD
 	g.Expect(c.Parse([]byte(manifest))).To(Equal(wantConfig))
 }
 
+func TestConfigManagerParseIgnoresUnknownFields(t *testing.T) {
+	g := NewWithT(t)
+	manifest := `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: eksa-unit-test
+spec:
+  controlPlaneConfiguration:
+    name: eksa-unit-test
+    count: 3
+`
+
+	c := cluster.NewConfigManager()
+	config, err := c.Parse([]byte(manifest))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Cluster.Spec.ControlPlaneConfiguration.Count).To(Equal(3))
+}
+
 func TestConfigManagerSetDefaultsSuccess(t *testing.T) {
 	g := NewWithT(t)
 	defaultNamespace := "default"
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -18,6 +19,7 @@ func TestConfigChildObjects(t *testing.T) {
 		CloudStackDatacenter: &anywherev1.CloudStackDatacenterConfig{},
 		VSphereDatacenter:    &anywherev1.VSphereDatacenterConfig{},
 		NutanixDatacenter:    &anywherev1.NutanixDatacenterConfig{},
+		TinkerbellDatacenter: &anywherev1.TinkerbellDatacenterConfig{},
 		SnowMachineConfigs: map[string]*anywherev1.SnowMachineConfig{
 			"machine1": {}, "machine2": {},
 		},
@@ -30,6 +32,9 @@ func TestConfigChildObjects(t *testing.T) {
 		NutanixMachineConfigs: map[string]*anywherev1.NutanixMachineConfig{
 			"machine1": {}, "machine2": {},
 		},
+		TinkerbellMachineConfigs: map[string]*anywherev1.TinkerbellMachineConfig{
+			"machine1": {}, "machine2": {},
+		},
 		OIDCConfigs: map[string]*anywherev1.OIDCConfig{
 			"machine1": {},
 		},
@@ -40,7 +45,7 @@ func TestConfigChildObjects(t *testing.T) {
 	}
 
 	objs := config.ChildObjects()
-	g.Expect(objs).To(HaveLen(15))
+	g.Expect(objs).To(HaveLen(18))
 	for _, o := range objs {
 		g.Expect(reflect.ValueOf(o).IsNil()).To(BeFalse())
 	}
@@ -55,6 +60,7 @@ func TestConfigDeepCopy(t *testing.T) {
 		DockerDatacenter:     &anywherev1.DockerDatacenterConfig{},
 		SnowDatacenter:       &anywherev1.SnowDatacenterConfig{},
 		NutanixDatacenter:    &anywherev1.NutanixDatacenterConfig{},
+		TinkerbellDatacenter: &anywherev1.TinkerbellDatacenterConfig{},
 		GitOpsConfig:         &anywherev1.GitOpsConfig{},
 		SnowMachineConfigs: map[string]*anywherev1.SnowMachineConfig{
 			"machine1": {}, "machine2": {},
@@ -68,13 +74,17 @@ func TestConfigDeepCopy(t *testing.T) {
 		NutanixMachineConfigs: map[string]*anywherev1.NutanixMachineConfig{
 			"machine1": {}, "machine2": {},
 		},
+		TinkerbellMachineConfigs: map[string]*anywherev1.TinkerbellMachineConfig{
+			"machine1": {}, "machine2": {},
+		},
 		OIDCConfigs: map[string]*anywherev1.OIDCConfig{
 			"machine1": {},
 		},
 		AWSIAMConfigs: map[string]*anywherev1.AWSIamConfig{
 			"config1": {},
 		},
-		FluxConfig: &anywherev1.FluxConfig{},
+		FluxConfig:            &anywherev1.FluxConfig{},
+		SnowCredentialsSecret: &corev1.Secret{},
 	}
 
 	copyConf := config.DeepCopy()
@@ -0,0 +1,136 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	v1alpha1release "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// eksdReleaseNameRegex matches EKS-D release names like kubernetes-1-23-eks-4, capturing
+// the channel prefix and the release number.
+var eksdReleaseNameRegex = regexp.MustCompile(`^(kubernetes-\d+-\d+-eks-)(\d+)$`)
+
+// EksdReleaseBundleReader reads the EKS-D bundle entries of pinned releases.
+type EksdReleaseBundleReader interface {
+	ReadEksDReleaseBundle(kubeVersion, name string) (*v1alpha1release.EksDRelease, error)
+}
+
+// EksdReleaseBundleReaderFunc is an adapter to use a function as an EksdReleaseBundleReader.
+type EksdReleaseBundleReaderFunc func(kubeVersion, name string) (*v1alpha1release.EksDRelease, error)
+
+// ReadEksDReleaseBundle calls f(kubeVersion, name).
+func (f EksdReleaseBundleReaderFunc) ReadEksDReleaseBundle(kubeVersion, name string) (*v1alpha1release.EksDRelease, error) {
+	return f(kubeVersion, name)
+}
+
+// applyEksdReleasePin replaces the EKS-D bundle entry in versionsBundle with the one of the release pinned
+// in the cluster for its Kubernetes version. The OS images, kind node image and the rest of the artifacts
+// in the entry are built for a specific EKS-D release, so the whole entry is taken from the Bundles that
+// shipped the pinned release. versionsBundle is left untouched if there's no pin.
+func applyEksdReleasePin(cluster *v1alpha1.Cluster, versionsBundle *v1alpha1release.VersionsBundle, reader EksdReleaseBundleReader) error {
+	name, err := eksdReleaseName(cluster, versionsBundle)
+	if err != nil {
+		return err
+	}
+
+	if name == versionsBundle.EksD.Name {
+		return nil
+	}
+
+	eksd, err := reader.ReadEksDReleaseBundle(versionsBundle.KubeVersion, name)
+	if err != nil {
+		return fmt.Errorf("pinning eks-d release for kubernetes version %s: %v", cluster.Spec.KubernetesVersion, err)
+	}
+	versionsBundle.EksD = *eksd
+
+	return nil
+}
+
+// eksdReleaseName returns the name of the EKS-D release used by the cluster for versionsBundle,
+// with any release pin in the cluster applied.
+func eksdReleaseName(cluster *v1alpha1.Cluster, versionsBundle *v1alpha1release.VersionsBundle) (string, error) {
+	number, ok := cluster.EksdReleasePin()
+	if !ok {
+		return versionsBundle.EksD.Name, nil
+	}
+
+	name, err := pinnedEksdReleaseName(versionsBundle.EksD, number)
+	if err != nil {
+		return "", fmt.Errorf("pinning eks-d release for kubernetes version %s: %v", cluster.Spec.KubernetesVersion, err)
+	}
+
+	return name, nil
+}
+
+func pinnedEksdReleaseName(eksd v1alpha1release.EksDRelease, number int) (string, error) {
+	matches := eksdReleaseNameRegex.FindStringSubmatch(eksd.Name)
+	if matches == nil {
+		return "", fmt.Errorf("unrecognized eks-d release name %s", eksd.Name)
+	}
+
+	return matches[1] + strconv.Itoa(number), nil
+}
+
+// PinnedEksdReleaseBundles returns the Bundles that persist in the cluster the EKS-D bundle entry of the
+// release pinned in the spec, or nil if the cluster doesn't pin a release. It's named after the EKS-D release
+// and lives in the eksa-system namespace, like the EKS-D Release objects, so the controller reads the
+// entry from the cluster instead of looking it up in the EKS-A releases.
+func (s *Spec) PinnedEksdReleaseBundles() *v1alpha1release.Bundles {
+	if _, pinned := s.Cluster.EksdReleasePin(); !pinned {
+		return nil
+	}
+
+	return &v1alpha1release.Bundles{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1release.GroupVersion.String(),
+			Kind:       "Bundles",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.VersionsBundle.EksD.Name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: v1alpha1release.BundlesSpec{
+			Number: s.Bundles.Spec.Number,
+			VersionsBundles: []v1alpha1release.VersionsBundle{
+				{
+					KubeVersion: s.VersionsBundle.KubeVersion,
+					EksD:        *s.VersionsBundle.EksD.DeepCopy(),
+				},
+			},
+		},
+	}
+}
+
+// clusterEksdReleaseBundleReader reads the EKS-D bundle entries of pinned releases from the Bundles
+// persisted in the cluster by the CLI, see PinnedEksdReleaseBundles.
+func clusterEksdReleaseBundleReader(ctx context.Context, fetch BundlesFetch) EksdReleaseBundleReaderFunc {
+	return func(kubeVersion, name string) (*v1alpha1release.EksDRelease, error) {
+		b, err := fetch(ctx, name, constants.EksaSystemNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("fetching Bundles for eks-d release %s: %v", name, err)
+		}
+
+		versionsBundle := bundles.VersionsBundleForKubernetesVersion(b, kubeVersion)
+		if versionsBundle == nil || versionsBundle.EksD.Name != name {
+			return nil, fmt.Errorf("bundles %s don't include eks-d release %s for kubernetes version %s", name, name, kubeVersion)
+		}
+
+		return versionsBundle.EksD.DeepCopy(), nil
+	}
+}
+
+// bundlesOverrideEksdReleaseBundleReader doesn't find any pinned release. A bundles override replaces the
+// Bundles of the EKS-A releases, so a pinned release must be the one in the override.
+func bundlesOverrideEksdReleaseBundleReader(bundlesManifestURL string) EksdReleaseBundleReaderFunc {
+	return func(_, name string) (*v1alpha1release.EksDRelease, error) {
+		return nil, fmt.Errorf("eks-d release %s is not in the bundles override %s", name, bundlesManifestURL)
+	}
+}
@@ -0,0 +1,150 @@
+package cluster_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/cluster/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func eksdPinBundles() *releasev1.Bundles {
+	return &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1.23",
+					EksD: releasev1.EksDRelease{
+						Name:           "kubernetes-1-23-eks-6",
+						EksDReleaseUrl: "https://distro.eks.amazonaws.com/kubernetes-1-23/kubernetes-1-23-eks-6.yaml",
+					},
+				},
+			},
+		},
+	}
+}
+
+func eksdPinCluster(number int) *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube123,
+			EksdReleasePins: []anywherev1.EksdReleasePin{
+				{KubernetesVersion: anywherev1.Kube123, Number: number},
+			},
+		},
+	}
+}
+
+func TestGetVersionsBundleWithEksdReleasePin(t *testing.T) {
+	g := NewWithT(t)
+	reader := mocks.NewMockEksdReleaseBundleReader(gomock.NewController(t))
+	pinned := &releasev1.EksDRelease{
+		Name:           "kubernetes-1-23-eks-4",
+		KubeVersion:    "v1.23.7",
+		EksDReleaseUrl: "https://distro.eks.amazonaws.com/kubernetes-1-23/kubernetes-1-23-eks-4.yaml",
+		KindNode:       releasev1.Image{URI: "public.ecr.aws/eks-anywhere/kubernetes-sigs/kind/node:v1.23.7-eks-d-1-23-4"},
+		Ova: releasev1.OSImageBundle{
+			Bottlerocket: releasev1.Archive{URI: "https://anywhere-assets.eks.amazonaws.com/bottlerocket-v1.23.7-eks-d-1-23-4-amd64.ova"},
+		},
+	}
+	reader.EXPECT().ReadEksDReleaseBundle("1.23", "kubernetes-1-23-eks-4").Return(pinned.DeepCopy(), nil)
+	bundles := eksdPinBundles()
+
+	versionsBundle, err := cluster.GetVersionsBundle(eksdPinCluster(4), bundles, reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(versionsBundle.EksD).To(Equal(*pinned))
+	g.Expect(bundles.Spec.VersionsBundles[0].EksD.Name).To(Equal("kubernetes-1-23-eks-6"), "bundles should not be modified")
+}
+
+func TestGetVersionsBundleWithEksdReleasePinCurrentRelease(t *testing.T) {
+	g := NewWithT(t)
+	reader := mocks.NewMockEksdReleaseBundleReader(gomock.NewController(t))
+
+	versionsBundle, err := cluster.GetVersionsBundle(eksdPinCluster(6), eksdPinBundles(), reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(versionsBundle.EksD).To(Equal(eksdPinBundles().Spec.VersionsBundles[0].EksD))
+}
+
+func TestGetVersionsBundleWithEksdReleasePinNotShipped(t *testing.T) {
+	g := NewWithT(t)
+	reader := mocks.NewMockEksdReleaseBundleReader(gomock.NewController(t))
+	reader.EXPECT().ReadEksDReleaseBundle("1.23", "kubernetes-1-23-eks-1").Return(nil, errors.New("eks-d release kubernetes-1-23-eks-1 is not shipped by any eks-a release"))
+
+	_, err := cluster.GetVersionsBundle(eksdPinCluster(1), eksdPinBundles(), reader)
+	g.Expect(err).To(MatchError("pinning eks-d release for kubernetes version 1.23: eks-d release kubernetes-1-23-eks-1 is not shipped by any eks-a release"))
+}
+
+func TestGetVersionsBundleWithEksdReleasePinOtherVersion(t *testing.T) {
+	g := NewWithT(t)
+	c := &anywherev1.Cluster{
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube123,
+			EksdReleasePins: []anywherev1.EksdReleasePin{
+				{KubernetesVersion: anywherev1.Kube122, Number: 4},
+			},
+		},
+	}
+
+	versionsBundle, err := cluster.GetVersionsBundle(c, eksdPinBundles(), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(versionsBundle.EksD.Name).To(Equal("kubernetes-1-23-eks-6"))
+}
+
+func TestGetVersionsBundleWithEksdReleasePinInvalidName(t *testing.T) {
+	g := NewWithT(t)
+	bundles := eksdPinBundles()
+	bundles.Spec.VersionsBundles[0].EksD.Name = "eksd"
+
+	_, err := cluster.GetVersionsBundle(eksdPinCluster(4), bundles, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("unrecognized eks-d release name eksd")))
+}
+
+func TestBuildSpecFromBundlesWithEksdReleasePinBundlesOverride(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := cluster.BuildSpecFromBundles(eksdPinCluster(4), eksdPinBundles(), cluster.WithOverrideBundlesManifest("bundles.yaml"))
+	g.Expect(err).To(MatchError("pinning eks-d release for kubernetes version 1.23: eks-d release kubernetes-1-23-eks-4 is not in the bundles override bundles.yaml"))
+}
+
+func TestPinnedEksdReleaseBundles(t *testing.T) {
+	g := NewWithT(t)
+	eksd := releasev1.EksDRelease{
+		Name:           "kubernetes-1-23-eks-4",
+		EksDReleaseUrl: "https://distro.eks.amazonaws.com/kubernetes-1-23/kubernetes-1-23-eks-4.yaml",
+	}
+	spec := &cluster.Spec{
+		Config: &cluster.Config{Cluster: eksdPinCluster(4)},
+		VersionsBundle: &cluster.VersionsBundle{
+			VersionsBundle: &releasev1.VersionsBundle{KubeVersion: "1.23", EksD: eksd},
+		},
+		Bundles: &releasev1.Bundles{Spec: releasev1.BundlesSpec{Number: 2}},
+	}
+
+	g.Expect(spec.PinnedEksdReleaseBundles()).To(Equal(&releasev1.Bundles{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "anywhere.eks.amazonaws.com/v1alpha1",
+			Kind:       "Bundles",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kubernetes-1-23-eks-4",
+			Namespace: "eksa-system",
+		},
+		Spec: releasev1.BundlesSpec{
+			Number:          2,
+			VersionsBundles: []releasev1.VersionsBundle{{KubeVersion: "1.23", EksD: eksd}},
+		},
+	}))
+}
+
+func TestPinnedEksdReleaseBundlesNoPin(t *testing.T) {
+	g := NewWithT(t)
+	spec := &cluster.Spec{Config: &cluster.Config{Cluster: &anywherev1.Cluster{}}}
+
+	g.Expect(spec.PinnedEksdReleaseBundles()).To(BeNil())
+}
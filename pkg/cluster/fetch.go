@@ -64,7 +64,7 @@ func BuildSpecForCluster(ctx context.Context, cluster *v1alpha1.Cluster, bundles
 	if err != nil {
 		return nil, err
 	}
-	return BuildSpecFromBundles(cluster, bundles, WithEksdRelease(eksd), WithGitOpsConfig(gitOpsConfig), WithFluxConfig(fluxConfig), WithOIDCConfig(oidcConfig), WithAWSIamConfig(awsIamConfig))
+	return buildSpecFromBundles(cluster, bundles, clusterEksdReleaseBundleReader(ctx, bundlesFetch), WithEksdRelease(eksd), WithGitOpsConfig(gitOpsConfig), WithFluxConfig(fluxConfig), WithOIDCConfig(oidcConfig), WithAWSIamConfig(awsIamConfig))
 }
 
 func GetBundlesForCluster(ctx context.Context, cluster *v1alpha1.Cluster, fetch BundlesFetch) (*v1alpha1release.Bundles, error) {
@@ -117,11 +117,15 @@ func GetGitOpsForCluster(ctx context.Context, cluster *v1alpha1.Cluster, fetch G
 }
 
 func GetEksdReleaseForCluster(ctx context.Context, cluster *v1alpha1.Cluster, bundles *v1alpha1release.Bundles, fetch EksdReleaseFetch) (*eksdv1alpha1.Release, error) {
-	versionsBundle, err := GetVersionsBundle(cluster, bundles)
+	versionsBundle, err := getVersionsBundleForKubernetesVersion(cluster.Spec.KubernetesVersion, bundles)
 	if err != nil {
 		return nil, fmt.Errorf("failed fetching versions bundle: %v", err)
 	}
-	eksd, err := fetch(ctx, versionsBundle.EksD.Name, constants.EksaSystemNamespace)
+	eksdName, err := eksdReleaseName(cluster, versionsBundle)
+	if err != nil {
+		return nil, err
+	}
+	eksd, err := fetch(ctx, eksdName, constants.EksaSystemNamespace)
 	if err != nil {
 		logger.V(4).Info("EKS-D release objects cannot be retrieved from the cluster. Fetching EKS-D release manifest from the URL in the bundle")
 		return nil, nil
@@ -130,8 +134,19 @@ func GetEksdReleaseForCluster(ctx context.Context, cluster *v1alpha1.Cluster, bu
 	return eksd, nil
 }
 
-func GetVersionsBundle(clusterConfig *v1alpha1.Cluster, bundles *v1alpha1release.Bundles) (*v1alpha1release.VersionsBundle, error) {
-	return getVersionsBundleForKubernetesVersion(clusterConfig.Spec.KubernetesVersion, bundles)
+// GetVersionsBundle returns the VersionsBundle for the cluster Kubernetes version with any EKS-D
+// release pin in the cluster applied. reader reads the EKS-D bundle entry of the pinned release.
+func GetVersionsBundle(clusterConfig *v1alpha1.Cluster, bundles *v1alpha1release.Bundles, reader EksdReleaseBundleReader) (*v1alpha1release.VersionsBundle, error) {
+	versionsBundle, err := getVersionsBundleForKubernetesVersion(clusterConfig.Spec.KubernetesVersion, bundles)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyEksdReleasePin(clusterConfig, versionsBundle, reader); err != nil {
+		return nil, err
+	}
+
+	return versionsBundle, nil
 }
 
 func getVersionsBundleForKubernetesVersion(kubernetesVersion v1alpha1.KubernetesVersion, bundles *v1alpha1release.Bundles) (*v1alpha1release.VersionsBundle, error) {
@@ -192,7 +207,15 @@ func BuildSpec(ctx context.Context, client Client, cluster *v1alpha1.Cluster) (*
 		return nil, err
 	}
 
-	versionsBundle, err := GetVersionsBundle(cluster, bundles)
+	spec := NewSpec()
+	bundlesFetch := func(ctx context.Context, name, namespace string) (*v1alpha1release.Bundles, error) {
+		b := &v1alpha1release.Bundles{}
+		if err := client.Get(ctx, name, namespace, b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	}
+	versionsBundle, err := GetVersionsBundle(cluster, bundles, clusterEksdReleaseBundleReader(ctx, bundlesFetch))
 	if err != nil {
 		return nil, err
 	}
@@ -204,7 +227,6 @@ func BuildSpec(ctx context.Context, client Client, cluster *v1alpha1.Cluster) (*
 		return nil, err
 	}
 
-	spec := NewSpec()
 	if err := spec.init(config, bundles, versionsBundle, eksdRelease); err != nil {
 		return nil, err
 	}
@@ -338,6 +338,47 @@ func TestBuildSpecInitError(t *testing.T) {
 	tt.Expect(err).To(MatchError(ContainSubstring("is no present in eksd release")))
 }
 
+func TestBuildSpecWithEksdReleasePin(t *testing.T) {
+	tt := newBuildSpecTest(t)
+	tt.bundles.Spec.VersionsBundles[0].EksD.Name = "kubernetes-1-23-eks-6"
+	tt.cluster.Spec.EksdReleasePins = []anywherev1.EksdReleasePin{{KubernetesVersion: anywherev1.Kube123, Number: 4}}
+	pinned := releasev1.EksDRelease{
+		Name:           "kubernetes-1-23-eks-4",
+		EksDReleaseUrl: "https://distro.eks.amazonaws.com/kubernetes-1-23/kubernetes-1-23-eks-4.yaml",
+	}
+	tt.expectGetBundles()
+	tt.client.EXPECT().Get(tt.ctx, "kubernetes-1-23-eks-4", "eksa-system", &releasev1.Bundles{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			o := obj.(*releasev1.Bundles)
+			o.Spec.VersionsBundles = []releasev1.VersionsBundle{{KubeVersion: "1.23", EksD: pinned}}
+			return nil
+		},
+	)
+	tt.client.EXPECT().Get(tt.ctx, "kubernetes-1-23-eks-4", "eksa-system", &eksdv1.Release{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			o := obj.(*eksdv1.Release)
+			o.ObjectMeta = tt.eksdRelease.ObjectMeta
+			o.Status = tt.eksdRelease.Status
+			return nil
+		},
+	)
+
+	spec, err := cluster.BuildSpec(tt.ctx, tt.client, tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(spec.VersionsBundle.EksD).To(Equal(pinned))
+}
+
+func TestBuildSpecWithEksdReleasePinNotInCluster(t *testing.T) {
+	tt := newBuildSpecTest(t)
+	tt.bundles.Spec.VersionsBundles[0].EksD.Name = "kubernetes-1-23-eks-6"
+	tt.cluster.Spec.EksdReleasePins = []anywherev1.EksdReleasePin{{KubernetesVersion: anywherev1.Kube123, Number: 4}}
+	tt.expectGetBundles()
+	tt.client.EXPECT().Get(tt.ctx, "kubernetes-1-23-eks-4", "eksa-system", &releasev1.Bundles{}).Return(errors.New("not found"))
+
+	_, err := cluster.BuildSpec(tt.ctx, tt.client, tt.cluster)
+	tt.Expect(err).To(MatchError("pinning eks-d release for kubernetes version 1.23: fetching Bundles for eks-d release kubernetes-1-23-eks-4: not found"))
+}
+
 func wantKubeDistroForEksdRelease() (*eksdv1.Release, *cluster.KubeDistro) {
 	eksdRelease := &eksdv1.Release{
 		ObjectMeta: metav1.ObjectMeta{
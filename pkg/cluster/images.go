@@ -0,0 +1,176 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// Feature is an optional EKS-A component that ships its own images.
+type Feature string
+
+const (
+	PackagesFeature            Feature = "packages"
+	IAMAuthenticatorFeature    Feature = "iam-authenticator"
+	FluxFeature                Feature = "flux"
+	ConformanceFeature         Feature = "conformance"
+	BackupFeature              Feature = "backup"
+	BottlerocketUpdatesFeature Feature = "bottlerocket-updates"
+)
+
+// Features returns all the optional features.
+func Features() []Feature {
+	return []Feature{PackagesFeature, IAMAuthenticatorFeature, FluxFeature, ConformanceFeature, BackupFeature, BottlerocketUpdatesFeature}
+}
+
+// EnabledFeatures returns the optional features enabled by the spec. Curated packages are always
+// considered enabled as the package controller is installed by default.
+func (s *Spec) EnabledFeatures() []Feature {
+	features := []Feature{PackagesFeature}
+	if s.AWSIamConfig != nil {
+		features = append(features, IAMAuthenticatorFeature)
+	}
+	if s.FluxConfig != nil || s.GitOpsConfig != nil || s.Cluster.Spec.GitOpsRef != nil {
+		features = append(features, FluxFeature)
+	}
+	if s.Cluster.Spec.Backup != nil {
+		features = append(features, BackupFeature)
+	}
+	if s.Cluster.Spec.BottlerocketUpdates != nil {
+		features = append(features, BottlerocketUpdatesFeature)
+	}
+	return features
+}
+
+// ImagesFilter configures which images are returned by Spec.FilteredImages.
+type ImagesFilter struct {
+	// Providers to include images for, e.g. "vsphere". All providers are included if empty.
+	Providers []string
+	// Features to include images for. Images for features not listed are excluded.
+	Features []Feature
+}
+
+// FilteredImages returns the images from the versions bundle and the EKS-D release for the spec
+// Kubernetes version that match filter. It filters out the images of the providers and features not
+// selected, so any other image in the bundle is always included.
+func (s *Spec) FilteredImages(filter ImagesFilter) ([]v1alpha1.Image, error) {
+	vb := s.VersionsBundle
+	providerImages := map[string][]v1alpha1.Image{
+		constants.VSphereProviderName:    vb.VsphereImages(),
+		constants.DockerProviderName:     vb.DockerImages(),
+		constants.CloudStackProviderName: vb.CloudStackImages(),
+		constants.SnowProviderName:       vb.SnowImages(),
+		constants.TinkerbellProviderName: vb.TinkerbellImages(),
+		constants.NutanixProviderName:    vb.NutanixImages(),
+	}
+
+	included := map[string]struct{}{}
+	for _, provider := range filter.Providers {
+		pImages, ok := providerImages[provider]
+		if !ok {
+			return nil, fmt.Errorf("unsupported provider %s", provider)
+		}
+		for _, i := range pImages {
+			included[i.URI] = struct{}{}
+		}
+	}
+
+	excluded := map[string]struct{}{}
+	if len(filter.Providers) > 0 {
+		for _, pImages := range providerImages {
+			for _, i := range pImages {
+				// Some images, like kube-vip, are used by more than one provider.
+				if _, ok := included[i.URI]; !ok {
+					excluded[i.URI] = struct{}{}
+				}
+			}
+		}
+	}
+
+	enabled := make(map[Feature]struct{}, len(filter.Features))
+	for _, f := range filter.Features {
+		enabled[f] = struct{}{}
+	}
+	exclude := func(feature Feature, images ...v1alpha1.Image) {
+		if _, ok := enabled[feature]; ok {
+			return
+		}
+		for _, i := range images {
+			excluded[i.URI] = struct{}{}
+		}
+	}
+	exclude(PackagesFeature, vb.PackageControllerImages()...)
+	exclude(FluxFeature, vb.FluxImages()...)
+	exclude(ConformanceFeature, vb.ConformanceImages()...)
+	exclude(BackupFeature, vb.VeleroImages()...)
+	exclude(BottlerocketUpdatesFeature, vb.BottlerocketUpdatesImages()...)
+	exclude(IAMAuthenticatorFeature, vb.KubeDistro.AwsIamAuthImage)
+
+	images := vb.Images()
+	if s.eksdRelease != nil {
+		images = append(images, s.KubeDistroImages()...)
+	}
+
+	filtered := make([]v1alpha1.Image, 0, len(images))
+	for _, i := range images {
+		if i.URI == "" {
+			continue
+		}
+		if _, ok := excluded[i.URI]; ok {
+			continue
+		}
+		filtered = append(filtered, i)
+	}
+
+	return filtered, nil
+}
+
+// ImagesMissingArch returns the images needed by the spec for provider that are not available for
+// arch. Images that don't declare their architectures are assumed to be available for all of them.
+func (s *Spec) ImagesMissingArch(provider string, arch eksav1alpha1.Architecture) ([]v1alpha1.Image, error) {
+	images, err := s.FilteredImages(ImagesFilter{
+		Providers: []string{provider},
+		Features:  s.EnabledFeatures(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []v1alpha1.Image
+	for _, i := range images {
+		if len(i.Arch) > 0 && !containsArch(i.Arch, string(arch)) {
+			missing = append(missing, i)
+		}
+	}
+	return missing, nil
+}
+
+// ValidateImagesArch returns an error listing the images needed by the spec for provider that
+// are not available for arch.
+func (s *Spec) ValidateImagesArch(provider string, arch eksav1alpha1.Architecture) error {
+	missing, err := s.ImagesMissingArch(provider, arch)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	uris := make([]string, 0, len(missing))
+	for _, i := range missing {
+		uris = append(uris, i.VersionedImage())
+	}
+	return fmt.Errorf("images not available for architecture %s: %s", arch, strings.Join(uris, ", "))
+}
+
+func containsArch(archs []string, arch string) bool {
+	for _, a := range archs {
+		if a == arch {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package cluster_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func imagesTestSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind}
+		s.VersionsBundle.VersionsBundle.Bootstrap.Controller = releasev1.Image{URI: "public.ecr.aws/bootstrap:v1"}
+		s.VersionsBundle.VersionsBundle.VSphere.Manager = releasev1.Image{URI: "public.ecr.aws/vsphere:v1"}
+		s.VersionsBundle.VersionsBundle.Docker.Manager = releasev1.Image{URI: "public.ecr.aws/docker:v1"}
+		s.VersionsBundle.VersionsBundle.Flux.SourceController = releasev1.Image{URI: "public.ecr.aws/flux:v1"}
+		s.VersionsBundle.VersionsBundle.PackageController.Controller = releasev1.Image{URI: "public.ecr.aws/packages:v1"}
+		s.VersionsBundle.VersionsBundle.Conformance.Sonobuoy = releasev1.Image{URI: "public.ecr.aws/sonobuoy:v1"}
+		s.VersionsBundle.VersionsBundle.Velero.Velero = releasev1.Image{URI: "public.ecr.aws/velero:v1"}
+		s.VersionsBundle.VersionsBundle.BottlerocketUpdates.Operator = releasev1.Image{URI: "public.ecr.aws/brupop:v1"}
+	})
+}
+
+func imageURIs(images []releasev1.Image) []string {
+	uris := make([]string, 0, len(images))
+	for _, i := range images {
+		uris = append(uris, i.URI)
+	}
+	return uris
+}
+
+func TestSpecEnabledFeatures(t *testing.T) {
+	g := NewWithT(t)
+	s := imagesTestSpec()
+	g.Expect(s.EnabledFeatures()).To(ConsistOf(cluster.PackagesFeature))
+
+	s.AWSIamConfig = &anywherev1.AWSIamConfig{}
+	s.Cluster.Spec.GitOpsRef = &anywherev1.Ref{Kind: anywherev1.FluxConfigKind}
+	s.Cluster.Spec.Backup = &anywherev1.BackupConfiguration{}
+	s.Cluster.Spec.BottlerocketUpdates = &anywherev1.BottlerocketUpdatesConfiguration{}
+	g.Expect(s.EnabledFeatures()).To(ConsistOf(
+		cluster.PackagesFeature, cluster.IAMAuthenticatorFeature, cluster.FluxFeature, cluster.BackupFeature, cluster.BottlerocketUpdatesFeature,
+	))
+}
+
+func TestSpecFilteredImagesByProvider(t *testing.T) {
+	g := NewWithT(t)
+	s := imagesTestSpec()
+
+	images, err := s.FilteredImages(cluster.ImagesFilter{
+		Providers: []string{"vsphere"},
+		Features:  []cluster.Feature{cluster.PackagesFeature},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(imageURIs(images)).To(ConsistOf(
+		"public.ecr.aws/bootstrap:v1",
+		"public.ecr.aws/vsphere:v1",
+		"public.ecr.aws/packages:v1",
+	))
+}
+
+func TestSpecFilteredImagesAllProvidersAndFeatures(t *testing.T) {
+	g := NewWithT(t)
+	s := imagesTestSpec()
+
+	images, err := s.FilteredImages(cluster.ImagesFilter{Features: cluster.Features()})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(imageURIs(images)).To(ConsistOf(
+		"public.ecr.aws/bootstrap:v1",
+		"public.ecr.aws/vsphere:v1",
+		"public.ecr.aws/docker:v1",
+		"public.ecr.aws/flux:v1",
+		"public.ecr.aws/packages:v1",
+		"public.ecr.aws/sonobuoy:v1",
+		"public.ecr.aws/velero:v1",
+		"public.ecr.aws/brupop:v1",
+	))
+}
+
+func TestSpecFilteredImagesIncludesImagesWithoutProviderOrFeature(t *testing.T) {
+	g := NewWithT(t)
+	s := imagesTestSpec()
+	s.VersionsBundle.VersionsBundle.Upgrader.Upgrader = releasev1.Image{URI: "public.ecr.aws/upgrader:v1"}
+
+	images, err := s.FilteredImages(cluster.ImagesFilter{Providers: []string{"docker"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(imageURIs(images)).To(ConsistOf(
+		"public.ecr.aws/bootstrap:v1",
+		"public.ecr.aws/docker:v1",
+		"public.ecr.aws/upgrader:v1",
+	))
+}
+
+func TestSpecFilteredImagesUnsupportedProvider(t *testing.T) {
+	g := NewWithT(t)
+	s := imagesTestSpec()
+
+	_, err := s.FilteredImages(cluster.ImagesFilter{Providers: []string{"unknown"}})
+	g.Expect(err).To(MatchError(ContainSubstring("unsupported provider unknown")))
+}
+
+func TestSpecImagesMissingArch(t *testing.T) {
+	g := NewWithT(t)
+	s := imagesTestSpec()
+	s.VersionsBundle.VersionsBundle.Bootstrap.Controller.Arch = []string{"amd64", "arm64"}
+	s.VersionsBundle.VersionsBundle.VSphere.Manager.Arch = []string{"amd64"}
+	s.VersionsBundle.VersionsBundle.Docker.Manager.Arch = []string{"amd64"}
+
+	missing, err := s.ImagesMissingArch("vsphere", anywherev1.ARM64)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(imageURIs(missing)).To(ConsistOf("public.ecr.aws/vsphere:v1"))
+
+	g.Expect(s.ValidateImagesArch("vsphere", anywherev1.AMD64)).To(Succeed())
+	g.Expect(s.ValidateImagesArch("vsphere", anywherev1.ARM64)).To(MatchError(
+		"images not available for architecture arm64: public.ecr.aws/vsphere:v1",
+	))
+}
@@ -0,0 +1,251 @@
+package cluster
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+// manifestIgnoredAnnotations are set by the CLI, the controllers or kubectl while operating
+// a cluster and don't belong in a user provided cluster spec.
+var manifestIgnoredAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	(&anywherev1.Cluster{}).PausedAnnotation(),
+}
+
+// manifestProtectedFields are never elided, even if the Config defaults would set them.
+var manifestProtectedFields = map[string]struct{}{
+	"apiVersion":         {},
+	"kind":               {},
+	"metadata":           {},
+	"metadata.name":      {},
+	"metadata.namespace": {},
+}
+
+// manifestSecretRefFields reference the secrets with the infrastructure credentials. They are
+// never elided, even if they match the name the CLI defaults to, since the spec might not be
+// applied with the CLI.
+var manifestSecretRefFields = []string{
+	"spec.identityRef",
+	"spec.credentialRef",
+}
+
+// NormalizedManifest returns a yaml manifest with the API objects in a Config in the shape of a
+// user provided cluster spec: server populated metadata and status are dropped, as well as any
+// field the Config defaults would set to the same value. Secrets are never included, the
+// objects keep referencing them by name.
+func NormalizedManifest(c *Config) ([]byte, error) {
+	objs, err := normalizedObjects(c)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := defaultedObjects(objs)
+	if err != nil {
+		return nil, fmt.Errorf("setting defaults on cluster config: %v", err)
+	}
+
+	for _, obj := range objs {
+		elideDefaults(objs, obj, "", target)
+	}
+
+	return marshalObjects(objs)
+}
+
+// normalizedObjects converts the Cluster and its child objects to unstructured, keeping only
+// the user provided metadata. The Cluster goes first, followed by the rest of objects sorted
+// by kind and name so the output is stable.
+func normalizedObjects(c *Config) ([]map[string]interface{}, error) {
+	children := c.ChildObjects()
+	sort.SliceStable(children, func(i, j int) bool {
+		ki, kj := reflect.TypeOf(children[i]).Elem().Name(), reflect.TypeOf(children[j]).Elem().Name()
+		if ki != kj {
+			return ki < kj
+		}
+		return children[i].GetName() < children[j].GetName()
+	})
+
+	scheme := runtime.NewScheme()
+	if err := anywherev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	objs := make([]map[string]interface{}, 0, len(children)+1)
+	for _, o := range append([]kubernetes.Object{c.Cluster}, children...) {
+		gvk, err := apiutil.GVKForObject(o, scheme)
+		if err != nil {
+			return nil, err
+		}
+
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+		if err != nil {
+			return nil, fmt.Errorf("converting %s %s to unstructured: %v", gvk.Kind, o.GetName(), err)
+		}
+
+		u["apiVersion"], u["kind"] = gvk.GroupVersion().String(), gvk.Kind
+		delete(u, "status")
+		u["metadata"] = normalizedMetadata(o)
+		objs = append(objs, u)
+	}
+
+	return objs, nil
+}
+
+func normalizedMetadata(o kubernetes.Object) map[string]interface{} {
+	metadata := map[string]interface{}{"name": o.GetName()}
+	if ns := o.GetNamespace(); ns != "" && ns != constants.DefaultNamespace {
+		metadata["namespace"] = ns
+	}
+
+	if labels := o.GetLabels(); len(labels) > 0 {
+		m := make(map[string]interface{}, len(labels))
+		for k, v := range labels {
+			m[k] = v
+		}
+		metadata["labels"] = m
+	}
+
+	annotations := make(map[string]interface{}, len(o.GetAnnotations()))
+	for k, v := range o.GetAnnotations() {
+		annotations[k] = v
+	}
+	for _, a := range manifestIgnoredAnnotations {
+		delete(annotations, a)
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+
+	return metadata
+}
+
+// defaultedObjects parses objs as a cluster spec, sets the Config defaults and returns
+// the normalized result.
+func defaultedObjects(objs []map[string]interface{}) ([]map[string]interface{}, error) {
+	manifest, err := marshalObjects(objs)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := ParseConfig(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = SetConfigDefaults(c); err != nil {
+		return nil, err
+	}
+
+	return normalizedObjects(c)
+}
+
+// elideDefaults removes from obj, recursively and in place, the fields that don't change
+// the result of setting the Config defaults on objs.
+func elideDefaults(objs []map[string]interface{}, obj map[string]interface{}, path string, target []map[string]interface{}) {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fieldPath := k
+		if path != "" {
+			fieldPath = path + "." + k
+		}
+
+		protected := isProtectedField(fieldPath, obj[k])
+		if !protected && elideField(objs, obj, k, target) {
+			continue
+		}
+
+		child, ok := obj[k].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		elideDefaults(objs, child, fieldPath, target)
+		if len(child) == 0 && !protected {
+			elideField(objs, obj, k, target)
+		}
+	}
+}
+
+// isProtectedField reports if the field at path, with value v, can't be elided. Fields
+// containing a secret reference are protected as well.
+func isProtectedField(path string, v interface{}) bool {
+	if _, ok := manifestProtectedFields[path]; ok {
+		return true
+	}
+
+	for _, f := range manifestSecretRefFields {
+		if path == f || strings.HasPrefix(path, f+".") {
+			return true
+		}
+
+		m, ok := v.(map[string]interface{})
+		if !ok || !strings.HasPrefix(f, path+".") {
+			continue
+		}
+
+		if _, found, _ := unstructured.NestedFieldNoCopy(m, strings.Split(strings.TrimPrefix(f, path+"."), ".")...); found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// elideField removes key from obj if that doesn't change the result of setting the Config
+// defaults on objs. It returns true if the field was removed.
+func elideField(objs []map[string]interface{}, obj map[string]interface{}, key string, target []map[string]interface{}) bool {
+	v := obj[key]
+	delete(obj, key)
+	if defaultsMatch(objs, target) {
+		return true
+	}
+
+	obj[key] = v
+	return false
+}
+
+func defaultsMatch(objs, target []map[string]interface{}) (match bool) {
+	// Some defaulters expect a complete cluster spec and panic when a required field is missing,
+	// in which case the field can't be elided anyway.
+	defer func() {
+		if r := recover(); r != nil {
+			match = false
+		}
+	}()
+
+	defaulted, err := defaultedObjects(objs)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(defaulted, target)
+}
+
+func marshalObjects(objs []map[string]interface{}) ([]byte, error) {
+	resources := make([][]byte, 0, len(objs))
+	for _, o := range objs {
+		b, err := yaml.Marshal(o)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling %s: %v", o["kind"], err)
+		}
+		resources = append(resources, b)
+	}
+
+	return templater.AppendYamlResources(resources...), nil
+}
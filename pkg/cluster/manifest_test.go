@@ -0,0 +1,88 @@
+package cluster_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// liveConfig returns the Config in file as it would be read from the API server: with defaults,
+// server populated metadata and status.
+func liveConfig(t *testing.T, file string) *cluster.Config {
+	t.Helper()
+	g := NewWithT(t)
+	c, err := cluster.ParseConfigFromFile(file)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cluster.SetConfigDefaults(c)).To(Succeed())
+
+	c.Cluster.Namespace = "default"
+	c.Cluster.ResourceVersion = "1234"
+	c.Cluster.UID = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	c.Cluster.Generation = 3
+	c.Cluster.Finalizers = []string{"clusters.anywhere.eks.amazonaws.com/finalizer"}
+	c.Cluster.CreationTimestamp = metav1.Now()
+	c.Cluster.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "manager", Operation: metav1.ManagedFieldsOperationUpdate}}
+	c.Cluster.Annotations = map[string]string{
+		c.Cluster.PausedAnnotation():                       "true",
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+	}
+	c.Cluster.Status.Conditions = []clusterv1.Condition{{Type: clusterv1.ReadyCondition, Status: corev1.ConditionTrue}}
+	c.Cluster.Status.EksdReleaseRef = &anywherev1.EksdReleaseRef{ApiVersion: "distro.eks.amazonaws.com/v1alpha1", Kind: "Release", Name: "kubernetes-1-19-eks-7"}
+
+	for _, o := range c.ChildObjects() {
+		o.SetNamespace("default")
+		o.SetResourceVersion("1234")
+	}
+
+	return c
+}
+
+func TestNormalizedManifestVSphere(t *testing.T) {
+	g := NewWithT(t)
+	c := liveConfig(t, "testdata/cluster_1_19.yaml")
+	c.Cluster.Annotations["anywhere.eks.amazonaws.com/credentials-profile"] = "lab"
+
+	got, err := cluster.NormalizedManifest(c)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(got), "testdata/expected_results_normalized_vsphere.yaml")
+}
+
+func TestNormalizedManifestDockerWithIdentityProvidersAndFlux(t *testing.T) {
+	g := NewWithT(t)
+	c := liveConfig(t, "testdata/docker_cluster_oidc_awsiam_flux.yaml")
+
+	got, err := cluster.NormalizedManifest(c)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(got), "testdata/expected_results_normalized_docker.yaml")
+}
+
+func TestNormalizedManifestSnowReferencesCredentialsSecret(t *testing.T) {
+	g := NewWithT(t)
+	c := liveConfig(t, "testdata/cluster_snow_1_21.yaml")
+	c.SnowCredentialsSecret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "eksa-unit-test-snow-credentials", Namespace: "eksa-system"},
+		Data:       map[string][]byte{"credentials": []byte("secret")},
+	}
+
+	got, err := cluster.NormalizedManifest(c)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(got)).NotTo(ContainSubstring("\nkind: Secret"))
+	g.Expect(string(got)).NotTo(ContainSubstring("credentials: "))
+	g.Expect(string(got)).To(ContainSubstring("identityRef:\n    kind: Secret\n    name: eksa-unit-test-snow-credentials"))
+
+	parsed, err := cluster.ParseConfig(got)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cluster.SetConfigDefaults(parsed)).To(Succeed())
+	g.Expect(parsed.SnowDatacenter.Spec.IdentityRef).To(Equal(c.SnowDatacenter.Spec.IdentityRef))
+	g.Expect(parsed.SnowMachineConfigs).To(HaveLen(len(c.SnowMachineConfigs)))
+	for name, m := range c.SnowMachineConfigs {
+		g.Expect(parsed.SnowMachineConfigs[name].Spec).To(Equal(m.Spec))
+	}
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/cluster/eksd.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEksdReleaseBundleReader is a mock of EksdReleaseBundleReader interface.
+type MockEksdReleaseBundleReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockEksdReleaseBundleReaderMockRecorder
+}
+
+// MockEksdReleaseBundleReaderMockRecorder is the mock recorder for MockEksdReleaseBundleReader.
+type MockEksdReleaseBundleReaderMockRecorder struct {
+	mock *MockEksdReleaseBundleReader
+}
+
+// NewMockEksdReleaseBundleReader creates a new mock instance.
+func NewMockEksdReleaseBundleReader(ctrl *gomock.Controller) *MockEksdReleaseBundleReader {
+	mock := &MockEksdReleaseBundleReader{ctrl: ctrl}
+	mock.recorder = &MockEksdReleaseBundleReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEksdReleaseBundleReader) EXPECT() *MockEksdReleaseBundleReaderMockRecorder {
+	return m.recorder
+}
+
+// ReadEksDReleaseBundle mocks base method.
+func (m *MockEksdReleaseBundleReader) ReadEksDReleaseBundle(kubeVersion, name string) (*v1alpha1.EksDRelease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadEksDReleaseBundle", kubeVersion, name)
+	ret0, _ := ret[0].(*v1alpha1.EksDRelease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadEksDReleaseBundle indicates an expected call of ReadEksDReleaseBundle.
+func (mr *MockEksdReleaseBundleReaderMockRecorder) ReadEksDReleaseBundle(kubeVersion, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEksDReleaseBundle", reflect.TypeOf((*MockEksdReleaseBundleReader)(nil).ReadEksDReleaseBundle), kubeVersion, name)
+}
@@ -0,0 +1,177 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+// patchTarget selects the objects of a cluster config a JSON6902 patch applies to.
+type patchTarget struct {
+	Kind string `json:"kind"`
+	// Name of the object, all the objects of the kind are patched when empty.
+	Name string `json:"name,omitempty"`
+}
+
+// jsonPatch is a JSON6902 patch document of a patches file.
+type jsonPatch struct {
+	Target *patchTarget     `json:"target"`
+	Patch  *json.RawMessage `json:"patch"`
+}
+
+type manifestObject struct {
+	basicAPIObject
+	json []byte
+}
+
+// PatchConfig applies patches to a yaml manifest with cluster config objects using the default package
+// config manager. See ConfigManager.Patch.
+func PatchConfig(yamlManifest []byte, patches ...[]byte) ([]byte, error) {
+	return manager().Patch(yamlManifest, patches...)
+}
+
+// Patch applies the patches, in order, to the objects of a yaml manifest and returns the patched manifest.
+// Each document in a patches manifest is either a strategic merge patch, a partial object patching the object
+// of the manifest with the same kind and name, or a JSON6902 patch, a document with a target kind and,
+// optionally, name, and a list of operations in patch.
+func (c *ConfigManager) Patch(yamlManifest []byte, patches ...[]byte) ([]byte, error) {
+	objects, err := splitManifest(yamlManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range patches {
+		for _, doc := range yamlutil.SplitDocuments(p) {
+			if len(bytes.TrimSpace(doc.Content)) == 0 {
+				continue
+			}
+			if err := c.applyPatch(objects, doc.Content); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	patched := make([][]byte, 0, len(objects))
+	for _, o := range objects {
+		y, err := yaml.JSONToYAML(o.json)
+		if err != nil {
+			return nil, err
+		}
+		patched = append(patched, y)
+	}
+
+	return bytes.Join(patched, []byte("---\n")), nil
+}
+
+func splitManifest(yamlManifest []byte) ([]*manifestObject, error) {
+	var objects []*manifestObject
+	for _, doc := range yamlutil.SplitDocuments(yamlManifest) {
+		o := &manifestObject{}
+		if err := yaml.Unmarshal(doc.Content, &o.basicAPIObject); err != nil {
+			return nil, err
+		}
+		if o.empty() {
+			continue
+		}
+
+		var err error
+		if o.json, err = yaml.YAMLToJSON(doc.Content); err != nil {
+			return nil, err
+		}
+		objects = append(objects, o)
+	}
+
+	return objects, nil
+}
+
+func (c *ConfigManager) applyPatch(objects []*manifestObject, doc []byte) error {
+	p := &jsonPatch{}
+	if err := yaml.Unmarshal(doc, p); err != nil {
+		return fmt.Errorf("parsing patch: %v", err)
+	}
+	if p.Target != nil {
+		return applyJSONPatch(objects, p)
+	}
+
+	return c.applyStrategicMergePatch(objects, doc)
+}
+
+func applyJSONPatch(objects []*manifestObject, p *jsonPatch) error {
+	if p.Target.Kind == "" || p.Patch == nil {
+		return fmt.Errorf("json patch needs a target kind and a patch")
+	}
+	patch, err := jsonpatch.DecodePatch(*p.Patch)
+	if err != nil {
+		return fmt.Errorf("parsing json patch for %s: %v", p.Target.Kind, err)
+	}
+
+	found := false
+	for _, o := range objects {
+		if o.Kind != p.Target.Kind || (p.Target.Name != "" && o.Name != p.Target.Name) {
+			continue
+		}
+		found = true
+		if o.json, err = patch.Apply(o.json); err != nil {
+			return fmt.Errorf("applying json patch to %s %s: %v", o.Kind, o.Name, err)
+		}
+	}
+	if !found {
+		target := p.Target.Kind
+		if p.Target.Name != "" {
+			target = fmt.Sprintf("%s %s", target, p.Target.Name)
+		}
+		return fmt.Errorf("json patch target %s not found in cluster config", target)
+	}
+
+	return nil
+}
+
+func (c *ConfigManager) applyStrategicMergePatch(objects []*manifestObject, doc []byte) error {
+	target := &basicAPIObject{}
+	if err := yaml.Unmarshal(doc, target); err != nil {
+		return fmt.Errorf("parsing patch: %v", err)
+	}
+	if target.Kind == "" || target.Name == "" {
+		return fmt.Errorf("strategic merge patch needs a kind and a metadata.name")
+	}
+	patch, err := yaml.YAMLToJSON(doc)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range objects {
+		if o.Kind != target.Kind || o.Name != target.Name {
+			continue
+		}
+
+		// Objects of unknown kinds are patched with JSON merge patch semantics, since their schema is unknown.
+		if dataStruct := c.apiObject(o.Kind); dataStruct != nil {
+			o.json, err = strategicpatch.StrategicMergePatch(o.json, patch, dataStruct)
+		} else {
+			o.json, err = jsonpatch.MergePatch(o.json, patch)
+		}
+		if err != nil {
+			return fmt.Errorf("applying patch to %s %s: %v", o.Kind, o.Name, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("patch target %s %s not found in cluster config", target.Kind, target.Name)
+}
+
+func (c *ConfigManager) apiObject(kind string) APIObject {
+	if kind == anywherev1.ClusterKind {
+		return &anywherev1.Cluster{}
+	}
+	if generate, ok := c.entry.APIObjectMapping[kind]; ok {
+		return generate()
+	}
+	return nil
+}
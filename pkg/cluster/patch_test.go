@@ -0,0 +1,197 @@
+package cluster_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const baseManifest = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  controlPlaneConfiguration:
+    count: 1
+    machineGroupRef:
+      kind: VSphereMachineConfig
+      name: cp
+  datacenterRef:
+    kind: VSphereDatacenterConfig
+    name: dc
+  kubernetesVersion: "1.24"
+  workerNodeGroupConfigurations:
+  - count: 1
+    machineGroupRef:
+      kind: VSphereMachineConfig
+      name: worker
+    name: md-0
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereMachineConfig
+metadata:
+  name: cp
+spec:
+  memoryMiB: 8192
+  numCPUs: 2
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereMachineConfig
+metadata:
+  name: worker
+spec:
+  memoryMiB: 8192
+  numCPUs: 2
+`
+
+func TestPatchConfigStrategicMerge(t *testing.T) {
+	g := NewWithT(t)
+	patch := `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  controlPlaneConfiguration:
+    count: 3
+    labels:
+      env: prod
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereMachineConfig
+metadata:
+  name: worker
+spec:
+  numCPUs: 8
+`
+
+	patched, err := cluster.PatchConfig([]byte(baseManifest), []byte(patch))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	config, err := cluster.ParseConfig(patched)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Cluster.Spec.ControlPlaneConfiguration.Count).To(Equal(3))
+	g.Expect(config.Cluster.Spec.ControlPlaneConfiguration.Labels).To(HaveKeyWithValue("env", "prod"))
+	g.Expect(config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name).To(Equal("cp"))
+	g.Expect(config.VSphereMachineConfigs["worker"].Spec.NumCPUs).To(Equal(8))
+	g.Expect(config.VSphereMachineConfigs["worker"].Spec.MemoryMiB).To(Equal(8192))
+	g.Expect(config.VSphereMachineConfigs["cp"].Spec.NumCPUs).To(Equal(2))
+}
+
+func TestPatchConfigJSONPatch(t *testing.T) {
+	g := NewWithT(t)
+	patch := `target:
+  kind: VSphereMachineConfig
+patch:
+- op: replace
+  path: /spec/memoryMiB
+  value: 16384
+---
+target:
+  kind: Cluster
+  name: my-cluster
+patch:
+- op: add
+  path: /spec/workerNodeGroupConfigurations/-
+  value:
+    name: md-1
+    count: 2
+    machineGroupRef:
+      kind: VSphereMachineConfig
+      name: worker
+`
+
+	patched, err := cluster.PatchConfig([]byte(baseManifest), []byte(patch))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	config, err := cluster.ParseConfig(patched)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.VSphereMachineConfigs["cp"].Spec.MemoryMiB).To(Equal(16384))
+	g.Expect(config.VSphereMachineConfigs["worker"].Spec.MemoryMiB).To(Equal(16384))
+	g.Expect(config.Cluster.Spec.WorkerNodeGroupConfigurations).To(HaveLen(2))
+	g.Expect(config.Cluster.Spec.WorkerNodeGroupConfigurations[1].Name).To(Equal("md-1"))
+}
+
+func TestPatchConfigPatchesInOrder(t *testing.T) {
+	g := NewWithT(t)
+	first := `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereMachineConfig
+metadata:
+  name: cp
+spec:
+  numCPUs: 4
+`
+	second := `target:
+  kind: VSphereMachineConfig
+  name: cp
+patch:
+- op: test
+  path: /spec/numCPUs
+  value: 4
+- op: replace
+  path: /spec/numCPUs
+  value: 6
+`
+
+	patched, err := cluster.PatchConfig([]byte(baseManifest), []byte(first), []byte(second))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	config, err := cluster.ParseConfig(patched)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.VSphereMachineConfigs["cp"].Spec.NumCPUs).To(Equal(6))
+}
+
+func TestPatchConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr string
+	}{
+		{
+			name: "strategic merge target not found",
+			patch: `kind: VSphereMachineConfig
+metadata:
+  name: etcd
+spec:
+  numCPUs: 4
+`,
+			wantErr: "patch target VSphereMachineConfig etcd not found in cluster config",
+		},
+		{
+			name: "strategic merge without name",
+			patch: `kind: Cluster
+spec:
+  kubernetesVersion: "1.23"
+`,
+			wantErr: "strategic merge patch needs a kind and a metadata.name",
+		},
+		{
+			name: "json patch target not found",
+			patch: `target:
+  kind: SnowMachineConfig
+patch:
+- op: remove
+  path: /spec/devices
+`,
+			wantErr: "json patch target SnowMachineConfig not found in cluster config",
+		},
+		{
+			name: "json patch invalid path",
+			patch: `target:
+  kind: Cluster
+patch:
+- op: remove
+  path: /spec/etcd
+`,
+			wantErr: "applying json patch to Cluster my-cluster",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := cluster.PatchConfig([]byte(baseManifest), []byte(tt.patch))
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
@@ -19,10 +19,14 @@ func snowEntry() *ConfigManagerEntry {
 			anywherev1.SnowMachineConfigKind: func() APIObject {
 				return &anywherev1.SnowMachineConfig{}
 			},
+			anywherev1.SnowIPPoolKind: func() APIObject {
+				return &anywherev1.SnowIPPool{}
+			},
 		},
 		Processors: []ParsedProcessor{
 			processSnowDatacenter,
 			machineConfigsProcessor(processSnowMachineConfig),
+			processSnowIPPools,
 		},
 		Defaulters: []Defaulter{
 			func(c *Config) error {
@@ -70,9 +74,11 @@ func snowEntry() *ConfigManagerEntry {
 				}
 				return nil
 			},
+			validateSnowIPPools,
 			func(c *Config) error {
 				return ValidateSnowMachineRefExists(c)
 			},
+			ValidateSnowIPPoolRefExists,
 		},
 	}
 }
@@ -107,6 +113,36 @@ func processSnowMachineConfig(c *Config, objects ObjectLookup, machineRef *anywh
 	c.SnowMachineConfigs[m.GetName()] = m.(*anywherev1.SnowMachineConfig)
 }
 
+// processSnowIPPools adds to the Config the SnowIPPools referenced by the direct network
+// interfaces of the SnowMachineConfigs. It expects the machine configs to be processed already.
+func processSnowIPPools(c *Config, objects ObjectLookup) {
+	for _, ref := range snowIPPoolRefs(c) {
+		if c.SnowIPPools == nil {
+			c.SnowIPPools = map[string]*anywherev1.SnowIPPool{}
+		}
+
+		p := objects.GetFromRef(c.Cluster.APIVersion, ref)
+		if p == nil {
+			continue
+		}
+
+		c.SnowIPPools[p.GetName()] = p.(*anywherev1.SnowIPPool)
+	}
+}
+
+// snowIPPoolRefs returns the SnowIPPool references from all SnowMachineConfig direct network interfaces.
+func snowIPPoolRefs(c *Config) []anywherev1.Ref {
+	var refs []anywherev1.Ref
+	for _, m := range c.SnowMachineConfigs {
+		for _, dni := range m.Spec.Network.DirectNetworkInterfaces {
+			if dni.IPPoolRef != nil && dni.IPPoolRef.Kind == anywherev1.SnowIPPoolKind {
+				refs = append(refs, *dni.IPPoolRef)
+			}
+		}
+	}
+	return refs
+}
+
 func SetSnowMachineConfigsAnnotations(c *Config) error {
 	if c.SnowMachineConfigs == nil {
 		return nil
@@ -165,6 +201,31 @@ func getSnowMachineConfigs(ctx context.Context, client Client, c *Config) error
 	return nil
 }
 
+func getSnowIPPools(ctx context.Context, client Client, c *Config) error {
+	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.SnowDatacenterKind {
+		return nil
+	}
+
+	for _, ref := range snowIPPoolRefs(c) {
+		if c.SnowIPPools == nil {
+			c.SnowIPPools = map[string]*anywherev1.SnowIPPool{}
+		}
+
+		if _, ok := c.SnowIPPools[ref.Name]; ok {
+			continue
+		}
+
+		pool := &anywherev1.SnowIPPool{}
+		if err := client.Get(ctx, ref.Name, c.Cluster.Namespace, pool); err != nil {
+			return err
+		}
+
+		c.SnowIPPools[pool.Name] = pool
+	}
+
+	return nil
+}
+
 func getSnowIdentitySecret(ctx context.Context, client Client, c *Config) error {
 	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.SnowDatacenterKind {
 		return nil
@@ -206,3 +267,27 @@ func ValidateSnowMachineRefExists(c *Config) error {
 	}
 	return nil
 }
+
+func validateSnowIPPools(c *Config) error {
+	for _, p := range c.SnowIPPools {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+
+		if err := validateSameNamespace(c, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateSnowIPPoolRefExists checks the SnowMachineConfig direct network interfaces and makes sure
+// the SnowIPPool object exists for each ip pool reference.
+func ValidateSnowIPPoolRefExists(c *Config) error {
+	for _, ref := range snowIPPoolRefs(c) {
+		if c.SnowIPPool(ref.Name) == nil {
+			return fmt.Errorf("unable to find SnowIPPool %s", ref.Name)
+		}
+	}
+	return nil
+}
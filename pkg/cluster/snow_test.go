@@ -347,3 +347,173 @@ func TestValidateSnowMachineRefExistsError(t *testing.T) {
 		MatchError(ContainSubstring("unable to find SnowMachineConfig worker-not-exists")),
 	)
 }
+
+func TestParseConfigSnowIPPools(t *testing.T) {
+	g := NewWithT(t)
+	got, err := cluster.ParseConfigFromFile("testdata/cluster_snow_ip_pools.yaml")
+
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(len(got.SnowIPPools)).To(Equal(1), "it should only include the referenced SnowIPPools")
+	g.Expect(got.SnowIPPool("ip-pool-1")).To(Equal(&anywherev1.SnowIPPool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.SnowIPPoolKind,
+			APIVersion: anywherev1.SchemeBuilder.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ip-pool-1",
+		},
+		Spec: anywherev1.SnowIPPoolSpec{
+			Pools: []anywherev1.IPPool{
+				{
+					IPStart: "1.2.3.10",
+					IPEnd:   "1.2.3.20",
+					Subnet:  "1.2.3.0/24",
+					Gateway: "1.2.3.1",
+				},
+			},
+		},
+	}))
+	g.Expect(got.ChildObjects()).To(ContainElement(got.SnowIPPool("ip-pool-1")))
+}
+
+func TestDefaultConfigClientBuilderSnowClusterIPPools(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	b := cluster.NewDefaultConfigClientBuilder()
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{
+				Kind: anywherev1.SnowDatacenterKind,
+				Name: "datacenter",
+			},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				MachineGroupRef: &anywherev1.Ref{
+					Kind: anywherev1.SnowMachineConfigKind,
+					Name: "machine-1",
+				},
+			},
+		},
+	}
+	datacenter := &anywherev1.SnowDatacenterConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datacenter",
+			Namespace: "default",
+		},
+		Spec: anywherev1.SnowDatacenterConfigSpec{
+			IdentityRef: anywherev1.Ref{
+				Kind: "Secret",
+				Name: "snow-secret",
+			},
+		},
+	}
+	machine := &anywherev1.SnowMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-1",
+			Namespace: "default",
+		},
+		Spec: anywherev1.SnowMachineConfigSpec{
+			Network: anywherev1.SnowNetwork{
+				DirectNetworkInterfaces: []anywherev1.SnowDirectNetworkInterface{
+					{
+						Index:   1,
+						Primary: true,
+						IPPoolRef: &anywherev1.Ref{
+							Kind: anywherev1.SnowIPPoolKind,
+							Name: "ip-pool-1",
+						},
+					},
+					{
+						Index: 2,
+						IPPoolRef: &anywherev1.Ref{
+							Kind: anywherev1.SnowIPPoolKind,
+							Name: "ip-pool-1",
+						},
+					},
+				},
+			},
+		},
+	}
+	pool := &anywherev1.SnowIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ip-pool-1",
+			Namespace: "default",
+		},
+		Spec: anywherev1.SnowIPPoolSpec{
+			Pools: []anywherev1.IPPool{
+				{
+					IPStart: "1.2.3.10",
+					IPEnd:   "1.2.3.20",
+					Subnet:  "1.2.3.0/24",
+					Gateway: "1.2.3.1",
+				},
+			},
+		},
+	}
+
+	client.EXPECT().Get(ctx, "datacenter", "default", &anywherev1.SnowDatacenterConfig{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			d := obj.(*anywherev1.SnowDatacenterConfig)
+			d.ObjectMeta = datacenter.ObjectMeta
+			d.Spec = datacenter.Spec
+			return nil
+		},
+	)
+
+	client.EXPECT().Get(ctx, "machine-1", "default", &anywherev1.SnowMachineConfig{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			m := obj.(*anywherev1.SnowMachineConfig)
+			m.ObjectMeta = machine.ObjectMeta
+			m.Spec = machine.Spec
+			return nil
+		},
+	)
+
+	client.EXPECT().Get(ctx, "ip-pool-1", "default", &anywherev1.SnowIPPool{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			p := obj.(*anywherev1.SnowIPPool)
+			p.ObjectMeta = pool.ObjectMeta
+			p.Spec = pool.Spec
+			return nil
+		},
+	)
+
+	client.EXPECT().Get(ctx, "snow-secret", "default", &corev1.Secret{}).Return(nil)
+
+	config, err := b.Build(ctx, client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.SnowMachineConfigs["machine-1"]).To(Equal(machine))
+	g.Expect(len(config.SnowIPPools)).To(Equal(1))
+	g.Expect(config.SnowIPPools["ip-pool-1"]).To(Equal(pool))
+}
+
+func TestValidateSnowIPPoolRefExistsError(t *testing.T) {
+	g := NewWithT(t)
+	c := &cluster.Config{
+		Cluster: &anywherev1.Cluster{},
+		SnowMachineConfigs: map[string]*anywherev1.SnowMachineConfig{
+			"machine-1": {
+				Spec: anywherev1.SnowMachineConfigSpec{
+					Network: anywherev1.SnowNetwork{
+						DirectNetworkInterfaces: []anywherev1.SnowDirectNetworkInterface{
+							{
+								IPPoolRef: &anywherev1.Ref{
+									Kind: anywherev1.SnowIPPoolKind,
+									Name: "ip-pool-not-exists",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	g.Expect(cluster.ValidateSnowIPPoolRefExists(c)).To(
+		MatchError(ContainSubstring("unable to find SnowIPPool ip-pool-not-exists")),
+	)
+}
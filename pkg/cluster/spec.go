@@ -202,13 +202,16 @@ func NewSpecFromClusterConfig(clusterConfigPath string, cliVersion version.Info,
 	clusterConfig.Cluster.Spec.BundlesRef.Namespace = bundlesManifest.Namespace
 	clusterConfig.Cluster.Spec.BundlesRef.APIVersion = v1alpha1.GroupVersion.String()
 
-	versionsBundle, err := GetVersionsBundle(clusterConfig.Cluster, bundlesManifest)
+	versionsBundle, err := GetVersionsBundle(clusterConfig.Cluster, bundlesManifest, s.pinnedEksdReleaseReader())
 	if err != nil {
 		return nil, err
 	}
 
 	eksd, err := bundles.ReadEKSD(s.reader, *versionsBundle)
 	if err != nil {
+		if _, pinned := clusterConfig.Cluster.EksdReleasePin(); pinned {
+			return nil, fmt.Errorf("reading pinned eks-d release %s: %v", versionsBundle.EksD.Name, err)
+		}
 		return nil, err
 	}
 
@@ -261,9 +264,18 @@ func (s *Spec) init(config *Config, bundles *v1alpha1.Bundles, versionsBundle *v
 }
 
 func BuildSpecFromBundles(cluster *eksav1alpha1.Cluster, bundlesManifest *v1alpha1.Bundles, opts ...SpecOpt) (*Spec, error) {
+	return buildSpecFromBundles(cluster, bundlesManifest, nil, opts...)
+}
+
+// buildSpecFromBundles builds a Spec like BuildSpecFromBundles, reading the EKS-D bundle entries of pinned
+// releases with eksdReader or, when nil, with the default reader of the Spec.
+func buildSpecFromBundles(cluster *eksav1alpha1.Cluster, bundlesManifest *v1alpha1.Bundles, eksdReader EksdReleaseBundleReader, opts ...SpecOpt) (*Spec, error) {
 	s := NewSpec(opts...)
+	if eksdReader == nil {
+		eksdReader = s.pinnedEksdReleaseReader()
+	}
 
-	versionsBundle, err := GetVersionsBundle(cluster, bundlesManifest)
+	versionsBundle, err := GetVersionsBundle(cluster, bundlesManifest, eksdReader)
 	if err != nil {
 		return nil, err
 	}
@@ -294,11 +306,25 @@ func (s *Spec) newReader() *files.Reader {
 	return files.NewReader(files.WithEmbedFS(s.configFS), files.WithUserAgent(s.userAgent))
 }
 
+func (s *Spec) manifestReader() *manifests.Reader {
+	return manifests.NewReader(s.reader, manifests.WithReleasesManifest(s.releasesManifestURL))
+}
+
+// pinnedEksdReleaseReader returns the reader for the EKS-D bundle entries of pinned releases. They are
+// looked up in the EKS-A releases or, with a bundles override, only in the override.
+func (s *Spec) pinnedEksdReleaseReader() EksdReleaseBundleReader {
+	switch {
+	case s.bundlesManifestURL != "":
+		return bundlesOverrideEksdReleaseBundleReader(s.bundlesManifestURL)
+	default:
+		return s.manifestReader()
+	}
+}
+
 func (s *Spec) GetBundles(cliVersion version.Info) (*v1alpha1.Bundles, error) {
 	bundlesURL := s.bundlesManifestURL
 	if bundlesURL == "" {
-		manifestReader := manifests.NewReader(s.reader, manifests.WithReleasesManifest(s.releasesManifestURL))
-		return manifestReader.ReadBundlesForVersion(cliVersion.GitVersion)
+		return s.manifestReader().ReadBundlesForVersion(cliVersion.GitVersion)
 	}
 
 	return bundles.Read(s.reader, bundlesURL)
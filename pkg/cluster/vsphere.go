@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"fmt"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 )
@@ -65,10 +66,28 @@ func vsphereEntry() *ConfigManagerEntry {
 				}
 				return nil
 			},
+			validateVSphereWindowsMachineConfigs,
 		},
 	}
 }
 
+// validateVSphereWindowsMachineConfigs checks Windows VSphereMachineConfigs are only used by worker node groups.
+func validateVSphereWindowsMachineConfigs(c *Config) error {
+	refs := []*anywherev1.Ref{c.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef}
+	if c.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		refs = append(refs, c.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef)
+	}
+	for _, ref := range refs {
+		if ref == nil || ref.Kind != anywherev1.VSphereMachineConfigKind {
+			continue
+		}
+		if m := c.VsphereMachineConfig(ref.Name); m != nil && m.OSFamily() == anywherev1.Windows {
+			return fmt.Errorf("VSphereMachineConfig %s: osFamily %s is only supported for worker node groups", m.Name, anywherev1.Windows)
+		}
+	}
+	return nil
+}
+
 func processVSphereDatacenter(c *Config, objects ObjectLookup) {
 	if c.Cluster.Spec.DatacenterRef.Kind == anywherev1.VSphereDatacenterKind {
 		datacenter := objects.GetFromRef(c.Cluster.APIVersion, c.Cluster.Spec.DatacenterRef)
@@ -137,3 +156,17 @@ func getVSphereMachineConfigs(ctx context.Context, client Client, c *Config) err
 
 	return nil
 }
+
+// WindowsWorkerNodeGroups returns the worker node groups that use a Windows VSphereMachineConfig.
+func (c *Config) WindowsWorkerNodeGroups() []anywherev1.WorkerNodeGroupConfiguration {
+	var groups []anywherev1.WorkerNodeGroupConfiguration
+	for _, group := range c.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if group.MachineGroupRef == nil || group.MachineGroupRef.Kind != anywherev1.VSphereMachineConfigKind {
+			continue
+		}
+		if m := c.VsphereMachineConfig(group.MachineGroupRef.Name); m != nil && m.OSFamily() == anywherev1.Windows {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
@@ -115,3 +115,69 @@ func TestDefaultConfigClientBuilderVSphereCluster(t *testing.T) {
 	g.Expect(config.VSphereMachineConfigs["machine-1"]).To(Equal(machineControlPlane))
 	g.Expect(config.VSphereMachineConfigs["machine-2"]).To(Equal(machineWorker))
 }
+
+func windowsWorkersConfig() *cluster.Config {
+	return &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			Spec: anywherev1.ClusterSpec{
+				DatacenterRef: anywherev1.Ref{
+					Kind: anywherev1.VSphereDatacenterKind,
+					Name: "datacenter",
+				},
+				ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+					MachineGroupRef: &anywherev1.Ref{
+						Kind: anywherev1.VSphereMachineConfigKind,
+						Name: "linux",
+					},
+				},
+				WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+					{
+						Name: "md-0",
+						MachineGroupRef: &anywherev1.Ref{
+							Kind: anywherev1.VSphereMachineConfigKind,
+							Name: "linux",
+						},
+					},
+					{
+						Name: "md-windows",
+						MachineGroupRef: &anywherev1.Ref{
+							Kind: anywherev1.VSphereMachineConfigKind,
+							Name: "windows",
+						},
+					},
+				},
+			},
+		},
+		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{
+			"linux": {
+				ObjectMeta: metav1.ObjectMeta{Name: "linux"},
+				Spec:       anywherev1.VSphereMachineConfigSpec{OSFamily: anywherev1.Ubuntu},
+			},
+			"windows": {
+				ObjectMeta: metav1.ObjectMeta{Name: "windows"},
+				Spec:       anywherev1.VSphereMachineConfigSpec{OSFamily: anywherev1.Windows},
+			},
+		},
+	}
+}
+
+func TestConfigWindowsWorkerNodeGroups(t *testing.T) {
+	g := NewWithT(t)
+	config := windowsWorkersConfig()
+
+	groups := config.WindowsWorkerNodeGroups()
+	g.Expect(groups).To(HaveLen(1))
+	g.Expect(groups[0].Name).To(Equal("md-windows"))
+}
+
+func TestValidateVSphereWindowsControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	config := windowsWorkersConfig()
+	config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name = "windows"
+
+	m, err := cluster.NewDefaultConfigManager()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(m.Validate(config)).To(MatchError(ContainSubstring(
+		"VSphereMachineConfig windows: osFamily windows is only supported for worker node groups",
+	)))
+}
@@ -124,6 +124,31 @@ func Cluster(clusterSpec *cluster.Spec, infrastructureObject, controlPlaneObject
 	return cluster
 }
 
+// defaultControlPlaneTaintKeys are the keys of the NoSchedule taints kubeadm adds by default to the control plane nodes.
+var defaultControlPlaneTaintKeys = map[string]struct{}{
+	"node-role.kubernetes.io/master":        {},
+	"node-role.kubernetes.io/control-plane": {},
+}
+
+// controlPlaneNodeTaints returns the taints of the control plane nodes. Single node clusters run their
+// workloads in the control plane node, so the default NoSchedule taint is removed, keeping any other taint.
+func controlPlaneNodeTaints(cluster *anywherev1.Cluster) []v1.Taint {
+	taints := cluster.Spec.ControlPlaneConfiguration.NodeTaints()
+	if !cluster.IsSingleNode() {
+		return taints
+	}
+
+	singleNodeTaints := []v1.Taint{}
+	for _, taint := range taints {
+		if _, ok := defaultControlPlaneTaintKeys[taint.Key]; ok && taint.Effect == v1.TaintEffectNoSchedule {
+			continue
+		}
+		singleNodeTaints = append(singleNodeTaints, taint)
+	}
+
+	return singleNodeTaints
+}
+
 func KubeadmControlPlane(clusterSpec *cluster.Spec, infrastructureObject APIObject) (*controlplanev1.KubeadmControlPlane, error) {
 	replicas := int32(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count)
 
@@ -174,6 +199,7 @@ func KubeadmControlPlane(clusterSpec *cluster.Spec, infrastructureObject APIObje
 							ExtraArgs:    map[string]string{},
 							ExtraVolumes: []bootstrapv1.HostPathMount{},
 						},
+						CertSANs: clusterSpec.Cluster.Spec.ControlPlaneConfiguration.CertSANs,
 					},
 					ControllerManager: bootstrapv1.ControlPlaneComponent{
 						ExtraArgs: ControllerManagerArgs(clusterSpec),
@@ -182,15 +208,19 @@ func KubeadmControlPlane(clusterSpec *cluster.Spec, infrastructureObject APIObje
 				InitConfiguration: &bootstrapv1.InitConfiguration{
 					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
 						KubeletExtraArgs: SecureTlsCipherSuitesExtraArgs().
-							Append(ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)),
-						Taints: clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
+							Append(ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+							Append(KubeletServingCertificateExtraArgs(clusterSpec.Cluster)).
+							Append(ImageCredentialProviderExtraArgs(clusterSpec.Cluster)),
+						Taints: controlPlaneNodeTaints(clusterSpec.Cluster),
 					},
 				},
 				JoinConfiguration: &bootstrapv1.JoinConfiguration{
 					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
 						KubeletExtraArgs: SecureTlsCipherSuitesExtraArgs().
-							Append(ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)),
-						Taints: clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
+							Append(ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+							Append(KubeletServingCertificateExtraArgs(clusterSpec.Cluster)).
+							Append(ImageCredentialProviderExtraArgs(clusterSpec.Cluster)),
+						Taints: controlPlaneNodeTaints(clusterSpec.Cluster),
 					},
 				},
 				PreKubeadmCommands:  []string{},
@@ -203,6 +233,10 @@ func KubeadmControlPlane(clusterSpec *cluster.Spec, infrastructureObject APIObje
 	}
 
 	SetIdentityAuthInKubeadmControlPlane(kcp, clusterSpec)
+	SetStaticPodManifestsInKubeadmControlPlane(kcp, clusterSpec.Cluster.Spec.ControlPlaneConfiguration)
+	SetImageCredentialProviderConfigInKubeadmControlPlane(kcp, clusterSpec.Cluster)
+	// The control planes built here run Ubuntu.
+	SetEtcdEncryptionInKubeadmControlPlane(kcp, clusterSpec.Cluster, anywherev1.Ubuntu)
 
 	return kcp, nil
 }
@@ -232,8 +266,10 @@ func KubeadmConfigTemplate(clusterSpec *cluster.Spec, workerNodeGroupConfig anyw
 					},
 					JoinConfiguration: &bootstrapv1.JoinConfiguration{
 						NodeRegistration: bootstrapv1.NodeRegistrationOptions{
-							KubeletExtraArgs: WorkerNodeLabelsExtraArgs(workerNodeGroupConfig),
-							Taints:           workerNodeGroupConfig.Taints,
+							KubeletExtraArgs: WorkerNodeLabelsExtraArgs(workerNodeGroupConfig).
+								Append(KubeletServingCertificateExtraArgs(clusterSpec.Cluster)).
+								Append(ImageCredentialProviderExtraArgs(clusterSpec.Cluster)),
+							Taints: workerNodeGroupConfig.Taints,
 						},
 					},
 					PreKubeadmCommands:  []string{},
@@ -244,6 +280,8 @@ func KubeadmConfigTemplate(clusterSpec *cluster.Spec, workerNodeGroupConfig anyw
 		},
 	}
 
+	SetImageCredentialProviderConfigInKubeadmConfigTemplate(kct, clusterSpec.Cluster)
+
 	return kct, nil
 }
 
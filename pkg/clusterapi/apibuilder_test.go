@@ -306,6 +306,51 @@ func TestKubeadmControlPlane(t *testing.T) {
 	tt.Expect(got).To(Equal(want))
 }
 
+func TestKubeadmControlPlaneSingleNode(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	got, err := clusterapi.KubeadmControlPlane(tt.clusterSpec, tt.providerMachineTemplate)
+	tt.Expect(err).To(Succeed())
+	want := wantKubeadmControlPlane()
+	replicas := int32(1)
+	want.Spec.Replicas = &replicas
+	tt.Expect(got).To(Equal(want))
+}
+
+func TestKubeadmControlPlaneSingleNodeWithTaints(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	userTaint := v1.Taint{Key: "dedicated", Value: "edge", Effect: v1.TaintEffectPreferNoSchedule}
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints = []v1.Taint{
+		{Key: "node-role.kubernetes.io/control-plane", Effect: v1.TaintEffectNoSchedule},
+		userTaint,
+	}
+	got, err := clusterapi.KubeadmControlPlane(tt.clusterSpec, tt.providerMachineTemplate)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(got.Spec.KubeadmConfigSpec.InitConfiguration.NodeRegistration.Taints).To(Equal([]v1.Taint{userTaint}))
+	tt.Expect(got.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.Taints).To(Equal([]v1.Taint{userTaint}))
+}
+
+func TestKubeadmControlPlaneSingleNodeNoTaints(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints = nil
+	got, err := clusterapi.KubeadmControlPlane(tt.clusterSpec, tt.providerMachineTemplate)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(got.Spec.KubeadmConfigSpec.InitConfiguration.NodeRegistration.Taints).To(Equal([]v1.Taint{}))
+	tt.Expect(got.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.Taints).To(Equal([]v1.Taint{}))
+}
+
+func TestKubeadmControlPlaneCertSANs(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.CertSANs = []string{"api.example.com", "10.0.0.1"}
+	got, err := clusterapi.KubeadmControlPlane(tt.clusterSpec, tt.providerMachineTemplate)
+	tt.Expect(err).To(Succeed())
+	want := wantKubeadmControlPlane()
+	want.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.CertSANs = []string{"api.example.com", "10.0.0.1"}
+	tt.Expect(got).To(Equal(want))
+}
+
 func wantKubeadmConfigTemplate() *bootstrapv1.KubeadmConfigTemplate {
 	return &bootstrapv1.KubeadmConfigTemplate{
 		TypeMeta: metav1.TypeMeta{
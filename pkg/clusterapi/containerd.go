@@ -0,0 +1,84 @@
+package clusterapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	containerdConfigPath          = "/etc/containerd/config.toml"
+	containerdConfigOverridesPath = "/etc/containerd/config_overrides.toml"
+)
+
+// ContainerdConfigFiles returns the files with the containerd configuration overrides
+// to append to the node containerd config. It doesn't support Bottlerocket.
+func ContainerdConfigFiles(config *v1alpha1.ContainerdConfiguration) []bootstrapv1.File {
+	if config == nil || (len(config.RegistryHeaders) == 0 && !config.EnableNRI) {
+		return nil
+	}
+
+	return []bootstrapv1.File{
+		{
+			Path:    containerdConfigOverridesPath,
+			Owner:   "root:root",
+			Content: containerdConfigOverridesContent(config),
+		},
+	}
+}
+
+func containerdConfigOverridesContent(config *v1alpha1.ContainerdConfiguration) string {
+	var lines []string
+	if len(config.RegistryHeaders) > 0 {
+		lines = append(lines, `[plugins."io.containerd.grpc.v1.cri".registry.headers]`)
+		names := make([]string, 0, len(config.RegistryHeaders))
+		for name := range config.RegistryHeaders {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			lines = append(lines, fmt.Sprintf(`  "%s" = ["%s"]`, name, config.RegistryHeaders[name]))
+		}
+	}
+	if config.EnableNRI {
+		lines = append(lines, `[plugins."io.containerd.nri.v1.nri"]`, "  disable = false")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ContainerdConfigCommands returns the commands to apply the containerd configuration overrides
+// to the node containerd config. containerd needs to be restarted after running them.
+// It doesn't support Bottlerocket.
+func ContainerdConfigCommands(config *v1alpha1.ContainerdConfiguration) []string {
+	if config == nil {
+		return nil
+	}
+
+	var commands []string
+	if len(config.RegistryHeaders) > 0 || config.EnableNRI {
+		commands = append(commands, fmt.Sprintf("cat %s >> %s", containerdConfigOverridesPath, containerdConfigPath))
+	}
+	if config.SandboxImage != "" {
+		commands = append(commands, fmt.Sprintf(`sed -i 's|^\(\s*\)sandbox_image = .*|\1sandbox_image = "%s"|' %s`, config.SandboxImage, containerdConfigPath))
+	}
+
+	return commands
+}
+
+// SandboxImageRepositoryAndTag splits the containerd sandbox image override in repository and tag.
+// It returns empty strings if the sandbox image is not overridden.
+func SandboxImageRepositoryAndTag(config *v1alpha1.ContainerdConfiguration) (repository, tag string) {
+	if config == nil || config.SandboxImage == "" {
+		return "", ""
+	}
+
+	i := strings.LastIndex(config.SandboxImage, ":")
+	if i < strings.LastIndex(config.SandboxImage, "/") {
+		return config.SandboxImage, ""
+	}
+	return config.SandboxImage[:i], config.SandboxImage[i+1:]
+}
@@ -0,0 +1,117 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestContainerdConfigFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *v1alpha1.ContainerdConfiguration
+		want   []bootstrapv1.File
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			want:   nil,
+		},
+		{
+			name:   "only sandbox image",
+			config: &v1alpha1.ContainerdConfiguration{SandboxImage: "registry.local/pause:3.9"},
+			want:   nil,
+		},
+		{
+			name: "registry headers and nri",
+			config: &v1alpha1.ContainerdConfiguration{
+				RegistryHeaders: map[string]string{"X-Custom": "b", "Authorization": "Basic abc"},
+				EnableNRI:       true,
+			},
+			want: []bootstrapv1.File{
+				{
+					Path:  "/etc/containerd/config_overrides.toml",
+					Owner: "root:root",
+					Content: `[plugins."io.containerd.grpc.v1.cri".registry.headers]
+  "Authorization" = ["Basic abc"]
+  "X-Custom" = ["b"]
+[plugins."io.containerd.nri.v1.nri"]
+  disable = false`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusterapi.ContainerdConfigFiles(tt.config)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestContainerdConfigCommands(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *v1alpha1.ContainerdConfiguration
+		want   []string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			want:   nil,
+		},
+		{
+			name:   "nri",
+			config: &v1alpha1.ContainerdConfiguration{EnableNRI: true},
+			want:   []string{"cat /etc/containerd/config_overrides.toml >> /etc/containerd/config.toml"},
+		},
+		{
+			name:   "sandbox image",
+			config: &v1alpha1.ContainerdConfiguration{SandboxImage: "registry.local/pause:3.9"},
+			want:   []string{`sed -i 's|^\(\s*\)sandbox_image = .*|\1sandbox_image = "registry.local/pause:3.9"|' /etc/containerd/config.toml`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusterapi.ContainerdConfigCommands(tt.config)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSandboxImageRepositoryAndTag(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         *v1alpha1.ContainerdConfiguration
+		wantRepository string
+		wantTag        string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+		},
+		{
+			name:           "image with tag",
+			config:         &v1alpha1.ContainerdConfiguration{SandboxImage: "registry.local:5000/eks/pause:3.9"},
+			wantRepository: "registry.local:5000/eks/pause",
+			wantTag:        "3.9",
+		},
+		{
+			name:           "image without tag",
+			config:         &v1alpha1.ContainerdConfiguration{SandboxImage: "registry.local:5000/eks/pause"},
+			wantRepository: "registry.local:5000/eks/pause",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			repository, tag := clusterapi.SandboxImageRepositoryAndTag(tt.config)
+			g.Expect(repository).To(Equal(tt.wantRepository))
+			g.Expect(tag).To(Equal(tt.wantTag))
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package clusterapi
+
+import (
+	"fmt"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	etcdDataDir                 = "/var/lib/etcd"
+	etcdDataDiskLabel           = "etcd-data"
+	etcdDataDiskRulesPath       = "/etc/udev/rules.d/60-etcd-data-disk.rules"
+	etcdIOPriorityDropIn        = "/etc/systemd/system/etcd.service.d/10-io-priority.conf"
+	etcdIOPriorityServiceConfig = `[Service]
+IOSchedulingClass=best-effort
+IOSchedulingPriority=0`
+)
+
+// EtcdDiskFiles returns the files that persist the IO scheduler of the etcd data disk and the
+// IO priority of etcd across reboots. device is the name of the etcd data disk block device
+// in the machines, like sdb. It doesn't support Bottlerocket.
+func EtcdDiskFiles(config *v1alpha1.EtcdDiskConfiguration, device string) []bootstrapv1.File {
+	if config == nil {
+		return nil
+	}
+
+	var files []bootstrapv1.File
+	if config.SizeGiB > 0 && config.IOScheduler != "" {
+		files = append(files, bootstrapv1.File{
+			Path:    etcdDataDiskRulesPath,
+			Owner:   "root:root",
+			Content: fmt.Sprintf(`ACTION=="add|change", KERNEL=="%s", ATTR{queue/scheduler}="%s"`, device, config.IOScheduler),
+		})
+	}
+	if config.HighIOPriority {
+		files = append(files, bootstrapv1.File{
+			Path:    etcdIOPriorityDropIn,
+			Owner:   "root:root",
+			Content: etcdIOPriorityServiceConfig,
+		})
+	}
+
+	return files
+}
+
+// EtcdDiskCommands returns the commands to format and mount the etcd data disk in the etcd data
+// directory and to set its IO scheduler during the first boot. They need to run before etcdadm.
+// device is the name of the etcd data disk block device in the machines, like sdb.
+// It doesn't support Bottlerocket.
+func EtcdDiskCommands(config *v1alpha1.EtcdDiskConfiguration, device string) []string {
+	if config == nil || config.SizeGiB == 0 {
+		return nil
+	}
+
+	commands := []string{
+		fmt.Sprintf("mkfs.ext4 -L %s /dev/%s", etcdDataDiskLabel, device),
+		"mkdir -p " + etcdDataDir,
+		fmt.Sprintf(`echo "LABEL=%s %s ext4 defaults,noatime 0 2" >> /etc/fstab`, etcdDataDiskLabel, etcdDataDir),
+		"mount " + etcdDataDir,
+	}
+	if config.IOScheduler != "" {
+		commands = append(commands, fmt.Sprintf("echo %s > /sys/block/%s/queue/scheduler", config.IOScheduler, device))
+	}
+
+	return commands
+}
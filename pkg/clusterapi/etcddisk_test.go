@@ -0,0 +1,92 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestEtcdDiskFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *v1alpha1.EtcdDiskConfiguration
+		want   []bootstrapv1.File
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			want:   nil,
+		},
+		{
+			name:   "data disk without tuning",
+			config: &v1alpha1.EtcdDiskConfiguration{SizeGiB: 20},
+			want:   nil,
+		},
+		{
+			name: "io scheduler and priority",
+			config: &v1alpha1.EtcdDiskConfiguration{
+				SizeGiB:        20,
+				IOScheduler:    "mq-deadline",
+				HighIOPriority: true,
+			},
+			want: []bootstrapv1.File{
+				{
+					Path:    "/etc/udev/rules.d/60-etcd-data-disk.rules",
+					Owner:   "root:root",
+					Content: `ACTION=="add|change", KERNEL=="sdb", ATTR{queue/scheduler}="mq-deadline"`,
+				},
+				{
+					Path:    "/etc/systemd/system/etcd.service.d/10-io-priority.conf",
+					Owner:   "root:root",
+					Content: "[Service]\nIOSchedulingClass=best-effort\nIOSchedulingPriority=0",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusterapi.EtcdDiskFiles(tt.config, "sdb")).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestEtcdDiskCommands(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *v1alpha1.EtcdDiskConfiguration
+		want   []string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			want:   nil,
+		},
+		{
+			name:   "no data disk",
+			config: &v1alpha1.EtcdDiskConfiguration{HighIOPriority: true},
+			want:   nil,
+		},
+		{
+			name:   "data disk with io scheduler",
+			config: &v1alpha1.EtcdDiskConfiguration{SizeGiB: 20, IOScheduler: "none"},
+			want: []string{
+				"mkfs.ext4 -L etcd-data /dev/sdb",
+				"mkdir -p /var/lib/etcd",
+				`echo "LABEL=etcd-data /var/lib/etcd ext4 defaults,noatime 0 2" >> /etc/fstab`,
+				"mount /var/lib/etcd",
+				"echo none > /sys/block/sdb/queue/scheduler",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusterapi.EtcdDiskCommands(tt.config, "sdb")).To(Equal(tt.want))
+		})
+	}
+}
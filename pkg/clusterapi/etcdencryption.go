@@ -0,0 +1,148 @@
+package clusterapi
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	etcdEncryptionConfigDir  = "/etc/kubernetes/enc"
+	etcdEncryptionConfigPath = etcdEncryptionConfigDir + "/encryption-config.yaml"
+	// bottlerocketEtcdEncryptionConfigDir is where the bootstrap container of Bottlerocket writes
+	// the files kubeadm places in etcdEncryptionConfigDir.
+	bottlerocketEtcdEncryptionConfigDir = "/var/lib/kubeadm/enc"
+	kmsSocketScheme                     = "unix://"
+)
+
+// EtcdEncryptionExtraArgs returns the kube-apiserver args to encrypt resources at rest in etcd.
+// It returns nil if the cluster doesn't configure etcd encryption.
+func EtcdEncryptionExtraArgs(cluster *v1alpha1.Cluster) ExtraArgs {
+	if len(cluster.Spec.EtcdEncryption) == 0 {
+		return nil
+	}
+
+	return ExtraArgs{
+		"encryption-provider-config": etcdEncryptionConfigPath,
+	}
+}
+
+// EtcdEncryptionExtraVolumes returns the kube-apiserver volumes for the encryption config and the
+// directories of the KMS plugin sockets, for control plane nodes running osFamily. It returns nil if
+// the cluster doesn't configure etcd encryption.
+func EtcdEncryptionExtraVolumes(cluster *v1alpha1.Cluster, osFamily v1alpha1.OSFamily) []bootstrapv1.HostPathMount {
+	if len(cluster.Spec.EtcdEncryption) == 0 {
+		return nil
+	}
+
+	hostPath := etcdEncryptionConfigDir
+	if osFamily == v1alpha1.Bottlerocket {
+		hostPath = bottlerocketEtcdEncryptionConfigDir
+	}
+
+	volumes := []bootstrapv1.HostPathMount{
+		{
+			Name:      "encryption-config",
+			HostPath:  hostPath,
+			MountPath: etcdEncryptionConfigDir,
+			PathType:  v1.HostPathDirectoryOrCreate,
+			ReadOnly:  true,
+		},
+	}
+	for i, dir := range kmsSocketDirs(cluster.Spec.EtcdEncryption) {
+		volumes = append(volumes, bootstrapv1.HostPathMount{
+			Name:      fmt.Sprintf("kms-plugin-%d", i),
+			HostPath:  dir,
+			MountPath: dir,
+			PathType:  v1.HostPathDirectoryOrCreate,
+		})
+	}
+
+	return volumes
+}
+
+// EtcdEncryptionConfigFiles returns the file with the kube-apiserver EncryptionConfiguration.
+// It returns nil if the cluster doesn't configure etcd encryption.
+func EtcdEncryptionConfigFiles(cluster *v1alpha1.Cluster) []bootstrapv1.File {
+	if len(cluster.Spec.EtcdEncryption) == 0 {
+		return nil
+	}
+
+	return []bootstrapv1.File{
+		{
+			Path:        etcdEncryptionConfigPath,
+			Owner:       "root:root",
+			Permissions: "0600",
+			Content:     etcdEncryptionConfigContent(cluster.Spec.EtcdEncryption),
+		},
+	}
+}
+
+// SetEtcdEncryptionInKubeadmControlPlane configures the kube-apiserver in the control plane to
+// encrypt resources at rest in etcd.
+func SetEtcdEncryptionInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, cluster *v1alpha1.Cluster, osFamily v1alpha1.OSFamily) {
+	apiServer := &kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer
+	apiServer.ExtraArgs = ExtraArgs{}.Append(apiServer.ExtraArgs).Append(EtcdEncryptionExtraArgs(cluster))
+	apiServer.ExtraVolumes = append(apiServer.ExtraVolumes, EtcdEncryptionExtraVolumes(cluster, osFamily)...)
+	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, EtcdEncryptionConfigFiles(cluster)...)
+}
+
+// kmsSocketDirs returns the sorted directories of the KMS plugin sockets, without duplicates.
+func kmsSocketDirs(configs []v1alpha1.EtcdEncryption) []string {
+	dirs := map[string]struct{}{}
+	for _, config := range configs {
+		for _, provider := range config.Providers {
+			dirs[filepath.Dir(strings.TrimPrefix(provider.KMS.SocketListenAddress, kmsSocketScheme))] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// etcdEncryptionConfigContent builds the EncryptionConfiguration for the etcd encryption configs.
+// The identity provider goes last so the resources written before enabling encryption can still be read.
+func etcdEncryptionConfigContent(configs []v1alpha1.EtcdEncryption) string {
+	lines := []string{
+		"apiVersion: apiserver.config.k8s.io/v1",
+		"kind: EncryptionConfiguration",
+		"resources:",
+	}
+	for _, config := range configs {
+		lines = append(lines, "- resources:")
+		for _, resource := range config.EncryptedResources() {
+			lines = append(lines, "  - "+quoteYamlString(resource))
+		}
+
+		lines = append(lines, "  providers:")
+		for _, provider := range config.Providers {
+			lines = append(lines,
+				"  - kms:",
+				"      name: "+quoteYamlString(provider.KMS.Name),
+				"      endpoint: "+quoteYamlString(provider.KMS.SocketListenAddress),
+			)
+			if provider.KMS.CacheSize != nil {
+				lines = append(lines, "      cachesize: "+strconv.Itoa(int(*provider.KMS.CacheSize)))
+			}
+			if provider.KMS.Timeout != nil {
+				lines = append(lines, "      timeout: "+quoteYamlString(provider.KMS.Timeout.Duration.String()))
+			}
+		}
+		lines = append(lines, "  - identity: {}")
+	}
+
+	return strings.Join(lines, "\n")
+}
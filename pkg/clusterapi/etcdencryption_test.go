@@ -0,0 +1,178 @@
+package clusterapi_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func clusterWithEtcdEncryption() *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			EtcdEncryption: []v1alpha1.EtcdEncryption{
+				{
+					Providers: []v1alpha1.EtcdEncryptionProvider{
+						{
+							KMS: &v1alpha1.KMS{
+								Name:                "new-key",
+								SocketListenAddress: "unix:///var/run/kmsplugin/new.sock",
+								CacheSize:           ptr.Int32(500),
+								Timeout:             &metav1.Duration{Duration: 5 * time.Second},
+							},
+						},
+						{
+							KMS: &v1alpha1.KMS{
+								Name:                "old-key",
+								SocketListenAddress: "unix:///var/run/kmsplugin/old.sock",
+							},
+						},
+					},
+				},
+				{
+					Providers: []v1alpha1.EtcdEncryptionProvider{
+						{
+							KMS: &v1alpha1.KMS{
+								Name:                "configmaps-key",
+								SocketListenAddress: "unix:///run/configmaps-kms/socket.sock",
+							},
+						},
+					},
+					Resources: []string{"configmaps"},
+				},
+			},
+		},
+	}
+}
+
+func TestEtcdEncryptionExtraArgs(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.EtcdEncryptionExtraArgs(&v1alpha1.Cluster{})).To(BeNil())
+	g.Expect(clusterapi.EtcdEncryptionExtraArgs(clusterWithEtcdEncryption())).To(Equal(clusterapi.ExtraArgs{
+		"encryption-provider-config": "/etc/kubernetes/enc/encryption-config.yaml",
+	}))
+}
+
+func TestEtcdEncryptionExtraVolumes(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.EtcdEncryptionExtraVolumes(&v1alpha1.Cluster{}, v1alpha1.Ubuntu)).To(BeNil())
+	g.Expect(clusterapi.EtcdEncryptionExtraVolumes(clusterWithEtcdEncryption(), v1alpha1.Ubuntu)).To(Equal([]bootstrapv1.HostPathMount{
+		{
+			Name:      "encryption-config",
+			HostPath:  "/etc/kubernetes/enc",
+			MountPath: "/etc/kubernetes/enc",
+			PathType:  v1.HostPathDirectoryOrCreate,
+			ReadOnly:  true,
+		},
+		{
+			Name:      "kms-plugin-0",
+			HostPath:  "/run/configmaps-kms",
+			MountPath: "/run/configmaps-kms",
+			PathType:  v1.HostPathDirectoryOrCreate,
+		},
+		{
+			Name:      "kms-plugin-1",
+			HostPath:  "/var/run/kmsplugin",
+			MountPath: "/var/run/kmsplugin",
+			PathType:  v1.HostPathDirectoryOrCreate,
+		},
+	}))
+}
+
+func TestEtcdEncryptionExtraVolumesBottlerocket(t *testing.T) {
+	g := NewWithT(t)
+	volumes := clusterapi.EtcdEncryptionExtraVolumes(clusterWithEtcdEncryption(), v1alpha1.Bottlerocket)
+	g.Expect(volumes).To(HaveLen(3))
+	g.Expect(volumes[0]).To(Equal(bootstrapv1.HostPathMount{
+		Name:      "encryption-config",
+		HostPath:  "/var/lib/kubeadm/enc",
+		MountPath: "/etc/kubernetes/enc",
+		PathType:  v1.HostPathDirectoryOrCreate,
+		ReadOnly:  true,
+	}))
+}
+
+func TestEtcdEncryptionConfigFiles(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.EtcdEncryptionConfigFiles(&v1alpha1.Cluster{})).To(BeNil())
+	g.Expect(clusterapi.EtcdEncryptionConfigFiles(clusterWithEtcdEncryption())).To(Equal([]bootstrapv1.File{
+		{
+			Path:        "/etc/kubernetes/enc/encryption-config.yaml",
+			Owner:       "root:root",
+			Permissions: "0600",
+			Content: `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - "secrets"
+  providers:
+  - kms:
+      name: "new-key"
+      endpoint: "unix:///var/run/kmsplugin/new.sock"
+      cachesize: 500
+      timeout: "5s"
+  - kms:
+      name: "old-key"
+      endpoint: "unix:///var/run/kmsplugin/old.sock"
+  - identity: {}
+- resources:
+  - "configmaps"
+  providers:
+  - kms:
+      name: "configmaps-key"
+      endpoint: "unix:///run/configmaps-kms/socket.sock"
+  - identity: {}`,
+		},
+	}))
+}
+
+func TestSetEtcdEncryptionInKubeadmControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	cluster := clusterWithEtcdEncryption()
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+					APIServer: bootstrapv1.APIServer{
+						ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
+							ExtraArgs: map[string]string{"authentication-token-webhook-config-file": "/etc/kubernetes/aws-iam-authenticator/kubeconfig.yaml"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	clusterapi.SetEtcdEncryptionInKubeadmControlPlane(kcp, cluster, v1alpha1.Ubuntu)
+	apiServer := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer
+	g.Expect(apiServer.ExtraArgs).To(Equal(map[string]string{
+		"authentication-token-webhook-config-file": "/etc/kubernetes/aws-iam-authenticator/kubeconfig.yaml",
+		"encryption-provider-config":               "/etc/kubernetes/enc/encryption-config.yaml",
+	}))
+	g.Expect(apiServer.ExtraVolumes).To(Equal(clusterapi.EtcdEncryptionExtraVolumes(cluster, v1alpha1.Ubuntu)))
+	g.Expect(kcp.Spec.KubeadmConfigSpec.Files).To(Equal(clusterapi.EtcdEncryptionConfigFiles(cluster)))
+}
+
+func TestSetEtcdEncryptionInKubeadmControlPlaneDisabled(t *testing.T) {
+	g := NewWithT(t)
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: &bootstrapv1.ClusterConfiguration{},
+			},
+		},
+	}
+
+	clusterapi.SetEtcdEncryptionInKubeadmControlPlane(kcp, &v1alpha1.Cluster{}, v1alpha1.Ubuntu)
+	g.Expect(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs).To(BeEmpty())
+	g.Expect(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraVolumes).To(BeEmpty())
+	g.Expect(kcp.Spec.KubeadmConfigSpec.Files).To(BeEmpty())
+}
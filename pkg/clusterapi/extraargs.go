@@ -98,6 +98,28 @@ func WorkerNodeLabelsExtraArgs(wnc v1alpha1.WorkerNodeGroupConfiguration) ExtraA
 	return nodeLabelsExtraArgs(wnc.Labels)
 }
 
+// BottlerocketUpdatesNodeLabel is the label of the nodes the Bottlerocket update operator agent runs on.
+const (
+	BottlerocketUpdatesNodeLabel      = "bottlerocket.aws/updater-interface-version"
+	bottlerocketUpdatesNodeLabelValue = "2.0.0"
+)
+
+// WorkerNodeLabelsWithOSUpdatesExtraArgs is like WorkerNodeLabelsExtraArgs, adding the Bottlerocket update
+// operator label to the Bottlerocket nodes of clusters that enable Bottlerocket updates, so their OS is patched.
+func WorkerNodeLabelsWithOSUpdatesExtraArgs(cluster *v1alpha1.Cluster, wnc v1alpha1.WorkerNodeGroupConfiguration, osFamily v1alpha1.OSFamily) ExtraArgs {
+	if cluster.Spec.BottlerocketUpdates == nil || osFamily != v1alpha1.Bottlerocket {
+		return WorkerNodeLabelsExtraArgs(wnc)
+	}
+
+	labels := make(map[string]string, len(wnc.Labels)+1)
+	for k, v := range wnc.Labels {
+		labels[k] = v
+	}
+	labels[BottlerocketUpdatesNodeLabel] = bottlerocketUpdatesNodeLabelValue
+
+	return nodeLabelsExtraArgs(labels)
+}
+
 func ControlPlaneNodeLabelsExtraArgs(cpc v1alpha1.ControlPlaneConfiguration) ExtraArgs {
 	return nodeLabelsExtraArgs(cpc.Labels)
 }
@@ -109,6 +131,15 @@ func CgroupDriverExtraArgs() ExtraArgs {
 	return args
 }
 
+// KubeletServingCertificateExtraArgs makes the kubelet request its serving certificate from the cluster CA
+// when serving certificate rotation is enabled in the cluster.
+func KubeletServingCertificateExtraArgs(cluster *v1alpha1.Cluster) ExtraArgs {
+	if !cluster.KubeletServingCertificateRotationEnabled() {
+		return nil
+	}
+	return ExtraArgs{"rotate-server-certificates": "true"}
+}
+
 func nodeLabelsExtraArgs(labels map[string]string) ExtraArgs {
 	args := ExtraArgs{}
 	args.AddIfNotEmpty("node-labels", labelsMapToArg(labels))
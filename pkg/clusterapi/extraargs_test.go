@@ -366,6 +366,49 @@ func TestNodeLabelsExtraArgs(t *testing.T) {
 	}
 }
 
+func TestWorkerNodeLabelsWithOSUpdatesExtraArgs(t *testing.T) {
+	wnc := v1alpha1.WorkerNodeGroupConfiguration{
+		Count:  ptr.Int(3),
+		Labels: map[string]string{"label1": "foo"},
+	}
+	tests := []struct {
+		testName string
+		updates  *v1alpha1.BottlerocketUpdatesConfiguration
+		osFamily v1alpha1.OSFamily
+		want     clusterapi.ExtraArgs
+	}{
+		{
+			testName: "updates disabled",
+			osFamily: v1alpha1.Bottlerocket,
+			want:     clusterapi.ExtraArgs{"node-labels": "label1=foo"},
+		},
+		{
+			testName: "ubuntu nodes",
+			updates:  &v1alpha1.BottlerocketUpdatesConfiguration{},
+			osFamily: v1alpha1.Ubuntu,
+			want:     clusterapi.ExtraArgs{"node-labels": "label1=foo"},
+		},
+		{
+			testName: "bottlerocket nodes",
+			updates:  &v1alpha1.BottlerocketUpdatesConfiguration{},
+			osFamily: v1alpha1.Bottlerocket,
+			want:     clusterapi.ExtraArgs{"node-labels": "bottlerocket.aws/updater-interface-version=2.0.0,label1=foo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster := &v1alpha1.Cluster{Spec: v1alpha1.ClusterSpec{BottlerocketUpdates: tt.updates}}
+			if got := clusterapi.WorkerNodeLabelsWithOSUpdatesExtraArgs(cluster, wnc, tt.osFamily); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WorkerNodeLabelsWithOSUpdatesExtraArgs() = %v, want %v", got, tt.want)
+			}
+			if len(wnc.Labels) != 1 {
+				t.Errorf("WorkerNodeLabelsWithOSUpdatesExtraArgs() modified the node group labels: %v", wnc.Labels)
+			}
+		})
+	}
+}
+
 func TestCpNodeLabelsExtraArgs(t *testing.T) {
 	tests := []struct {
 		testName string
@@ -532,3 +575,38 @@ func TestFeatureGatesExtraArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestKubeletServingCertificateExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName             string
+		kubeletConfiguration *v1alpha1.KubeletConfiguration
+		want                 clusterapi.ExtraArgs
+	}{
+		{
+			testName:             "no kubelet configuration",
+			kubeletConfiguration: nil,
+			want:                 nil,
+		},
+		{
+			testName:             "rotation disabled",
+			kubeletConfiguration: &v1alpha1.KubeletConfiguration{ServingCertificateRotation: false},
+			want:                 nil,
+		},
+		{
+			testName:             "rotation enabled",
+			kubeletConfiguration: &v1alpha1.KubeletConfiguration{ServingCertificateRotation: true},
+			want: clusterapi.ExtraArgs{
+				"rotate-server-certificates": "true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster := &v1alpha1.Cluster{Spec: v1alpha1.ClusterSpec{KubeletConfiguration: tt.kubeletConfiguration}}
+			if got := clusterapi.KubeletServingCertificateExtraArgs(cluster); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("KubeletServingCertificateExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
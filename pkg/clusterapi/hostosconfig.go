@@ -0,0 +1,115 @@
+package clusterapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	kernelModulesConfigPath = "/etc/modules-load.d/eks-anywhere.conf"
+	sysctlConfigPath        = "/etc/sysctl.d/99-eks-anywhere.conf"
+)
+
+// HostOSConfigFiles returns the files that persist the kernel modules and sysctls
+// of the host OS configuration across reboots. Bottlerocket nodes use BottlerocketKernelModules
+// and BottlerocketKernelSysctls instead.
+func HostOSConfigFiles(config *v1alpha1.HostOSConfiguration) []bootstrapv1.File {
+	if config == nil {
+		return nil
+	}
+
+	var files []bootstrapv1.File
+	if len(config.KernelModules) > 0 {
+		files = append(files, bootstrapv1.File{
+			Path:    kernelModulesConfigPath,
+			Owner:   "root:root",
+			Content: strings.Join(config.KernelModules, "\n"),
+		})
+	}
+	if len(config.Sysctls) > 0 {
+		files = append(files, bootstrapv1.File{
+			Path:    sysctlConfigPath,
+			Owner:   "root:root",
+			Content: sysctlConfigContent(config.Sysctls),
+		})
+	}
+
+	return files
+}
+
+func sysctlConfigContent(sysctls map[string]string) string {
+	keys := make([]string, 0, len(sysctls))
+	for key := range sysctls {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("%s = %s", key, sysctls[key]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// HostOSConfigCommands returns the commands to load the kernel modules and apply the sysctls
+// of the host OS configuration during the first boot. They need to run before kubeadm.
+// Bottlerocket nodes use BottlerocketKernelModules and BottlerocketKernelSysctls instead.
+func HostOSConfigCommands(config *v1alpha1.HostOSConfiguration) []string {
+	if config == nil {
+		return nil
+	}
+
+	commands := make([]string, 0, len(config.KernelModules)+1)
+	for _, module := range config.KernelModules {
+		commands = append(commands, "modprobe "+module)
+	}
+	if len(config.Sysctls) > 0 {
+		commands = append(commands, "sysctl --system")
+	}
+
+	return commands
+}
+
+// BottlerocketKernelModules returns the kernel modules of the host OS configuration for the
+// settings.kernel.modules of Bottlerocket nodes.
+func BottlerocketKernelModules(config *v1alpha1.HostOSConfiguration) []string {
+	if config == nil {
+		return nil
+	}
+	return config.KernelModules
+}
+
+// BottlerocketKernelSysctls returns the sysctls of the host OS configuration for the settings.kernel.sysctl
+// of Bottlerocket nodes. Bottlerocket only accepts dotted keys, so keys in the slash form are converted the
+// same way sysctl.d does, swapping slashes and dots.
+func BottlerocketKernelSysctls(config *v1alpha1.HostOSConfiguration) map[string]string {
+	if config == nil || len(config.Sysctls) == 0 {
+		return nil
+	}
+
+	sysctls := make(map[string]string, len(config.Sysctls))
+	for key, value := range config.Sysctls {
+		sysctls[dottedSysctlKey(key)] = value
+	}
+	return sysctls
+}
+
+func dottedSysctlKey(key string) string {
+	if i := strings.IndexAny(key, "./"); i < 0 || key[i] == '.' {
+		return key
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/':
+			return '.'
+		case '.':
+			return '/'
+		}
+		return r
+	}, key)
+}
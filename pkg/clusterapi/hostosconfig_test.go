@@ -0,0 +1,136 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestHostOSConfigFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *v1alpha1.HostOSConfiguration
+		want   []bootstrapv1.File
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			want:   nil,
+		},
+		{
+			name: "kernel modules and sysctls",
+			config: &v1alpha1.HostOSConfiguration{
+				KernelModules: []string{"br_netfilter", "sctp"},
+				Sysctls: map[string]string{
+					"net.ipv4.ip_forward":         "1",
+					"fs.inotify.max_user_watches": "524288",
+				},
+			},
+			want: []bootstrapv1.File{
+				{
+					Path:    "/etc/modules-load.d/eks-anywhere.conf",
+					Owner:   "root:root",
+					Content: "br_netfilter\nsctp",
+				},
+				{
+					Path:    "/etc/sysctl.d/99-eks-anywhere.conf",
+					Owner:   "root:root",
+					Content: "fs.inotify.max_user_watches = 524288\nnet.ipv4.ip_forward = 1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusterapi.HostOSConfigFiles(tt.config)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestHostOSConfigCommands(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *v1alpha1.HostOSConfiguration
+		want   []string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			want:   nil,
+		},
+		{
+			name:   "only kernel modules",
+			config: &v1alpha1.HostOSConfiguration{KernelModules: []string{"br_netfilter", "sctp"}},
+			want:   []string{"modprobe br_netfilter", "modprobe sctp"},
+		},
+		{
+			name: "kernel modules and sysctls",
+			config: &v1alpha1.HostOSConfiguration{
+				KernelModules: []string{"sctp"},
+				Sysctls:       map[string]string{"net.ipv4.ip_forward": "1"},
+			},
+			want: []string{"modprobe sctp", "sysctl --system"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusterapi.HostOSConfigCommands(tt.config)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestBottlerocketKernelModules(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.BottlerocketKernelModules(nil)).To(BeNil())
+	g.Expect(clusterapi.BottlerocketKernelModules(&v1alpha1.HostOSConfiguration{
+		KernelModules: []string{"br_netfilter", "sctp"},
+	})).To(Equal([]string{"br_netfilter", "sctp"}))
+}
+
+func TestBottlerocketKernelSysctls(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *v1alpha1.HostOSConfiguration
+		want   map[string]string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			want:   nil,
+		},
+		{
+			name:   "only kernel modules",
+			config: &v1alpha1.HostOSConfiguration{KernelModules: []string{"sctp"}},
+			want:   nil,
+		},
+		{
+			name: "dotted and slash keys",
+			config: &v1alpha1.HostOSConfiguration{
+				Sysctls: map[string]string{
+					"net.ipv4.ip_forward":                 "1",
+					"net/ipv4/conf/eth0.100/rp_filter":    "2",
+					"fs/inotify/max_user_watches":         "524288",
+					"net.ipv4.conf.eth0/100.accept_local": "1",
+				},
+			},
+			want: map[string]string{
+				"net.ipv4.ip_forward":                 "1",
+				"net.ipv4.conf.eth0/100.rp_filter":    "2",
+				"fs.inotify.max_user_watches":         "524288",
+				"net.ipv4.conf.eth0/100.accept_local": "1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusterapi.BottlerocketKernelSysctls(tt.config)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package clusterapi
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	imageCredentialProviderBinDir     = "/etc/kubernetes/image-credential-provider"
+	imageCredentialProviderConfigPath = "/etc/kubernetes/image-credential-provider-config.yaml"
+	// defaultImageCredentialProviderCacheDuration is the kubelet credentials cache duration
+	// for providers that don't set one.
+	defaultImageCredentialProviderCacheDuration = "12h"
+)
+
+// ImageCredentialProviderExtraArgs returns the kubelet args to enable the image credential providers
+// of the cluster. It returns nil if the cluster doesn't configure any.
+func ImageCredentialProviderExtraArgs(cluster *v1alpha1.Cluster) ExtraArgs {
+	if len(cluster.ImageCredentialProviders()) == 0 {
+		return nil
+	}
+
+	// The feature gate is needed until Kubernetes 1.24, where it's enabled by default.
+	return FeatureGatesExtraArgs("KubeletCredentialProviders=true").Append(ExtraArgs{
+		"image-credential-provider-config":  imageCredentialProviderConfigPath,
+		"image-credential-provider-bin-dir": imageCredentialProviderBinDir,
+	})
+}
+
+// ImageCredentialProviderConfigFiles returns the file with the kubelet CredentialProviderConfig
+// for the image credential providers of the cluster. It returns nil if the cluster doesn't configure any.
+func ImageCredentialProviderConfigFiles(cluster *v1alpha1.Cluster) []bootstrapv1.File {
+	providers := cluster.ImageCredentialProviders()
+	if len(providers) == 0 {
+		return nil
+	}
+
+	return []bootstrapv1.File{
+		{
+			Path:    imageCredentialProviderConfigPath,
+			Owner:   "root:root",
+			Content: imageCredentialProviderConfigContent(providers),
+		},
+	}
+}
+
+// SetImageCredentialProviderConfigInKubeadmControlPlane adds the image credential provider config to the control plane files.
+func SetImageCredentialProviderConfigInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, cluster *v1alpha1.Cluster) {
+	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, ImageCredentialProviderConfigFiles(cluster)...)
+}
+
+// SetImageCredentialProviderConfigInKubeadmConfigTemplate adds the image credential provider config to the worker files.
+func SetImageCredentialProviderConfigInKubeadmConfigTemplate(kct *bootstrapv1.KubeadmConfigTemplate, cluster *v1alpha1.Cluster) {
+	kct.Spec.Template.Spec.Files = append(kct.Spec.Template.Spec.Files, ImageCredentialProviderConfigFiles(cluster)...)
+}
+
+func imageCredentialProviderConfigContent(providers []v1alpha1.ImageCredentialProvider) string {
+	lines := []string{
+		"apiVersion: kubelet.config.k8s.io/v1alpha1",
+		"kind: CredentialProviderConfig",
+		"providers:",
+	}
+	for _, provider := range providers {
+		cacheDuration := provider.DefaultCacheDuration
+		if cacheDuration == "" {
+			cacheDuration = defaultImageCredentialProviderCacheDuration
+		}
+
+		lines = append(lines,
+			"- name: "+quoteYamlString(provider.Name),
+			"  apiVersion: credentialprovider.kubelet.k8s.io/v1alpha1",
+			"  defaultCacheDuration: "+quoteYamlString(cacheDuration),
+			"  matchImages:",
+		)
+		for _, image := range provider.MatchImages {
+			lines = append(lines, "  - "+quoteYamlString(image))
+		}
+
+		if len(provider.Args) > 0 {
+			lines = append(lines, "  args:")
+			for _, arg := range provider.Args {
+				lines = append(lines, "  - "+quoteYamlString(arg))
+			}
+		}
+
+		lines = append(lines, imageCredentialProviderEnvLines(provider.Env)...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func imageCredentialProviderEnvLines(env map[string]string) []string {
+	if len(env) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := []string{"  env:"}
+	for _, name := range names {
+		lines = append(lines, "  - name: "+name, "    value: "+quoteYamlString(env[name]))
+	}
+	return lines
+}
+
+// quoteYamlString returns s as a double quoted yaml scalar. The Go escape sequences
+// are valid in yaml double quoted scalars.
+func quoteYamlString(s string) string {
+	return strconv.Quote(s)
+}
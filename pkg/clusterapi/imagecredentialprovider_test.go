@@ -0,0 +1,102 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func clusterWithImageCredentialProviders(providers ...v1alpha1.ImageCredentialProvider) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			KubeletConfiguration: &v1alpha1.KubeletConfiguration{ImageCredentialProviders: providers},
+		},
+	}
+}
+
+var ecrCredentialProvider = v1alpha1.ImageCredentialProvider{
+	Name:        "ecr-credential-provider",
+	MatchImages: []string{"*.dkr.ecr.*.amazonaws.com"},
+	Args:        []string{"get-credentials"},
+	Env:         map[string]string{"AWS_SHARED_CREDENTIALS_FILE": "/etc/aws/credentials", "AWS_PROFILE": "ecr"},
+}
+
+func TestImageCredentialProviderExtraArgs(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.ImageCredentialProviderExtraArgs(&v1alpha1.Cluster{})).To(BeNil())
+	g.Expect(clusterapi.ImageCredentialProviderExtraArgs(clusterWithImageCredentialProviders(ecrCredentialProvider))).To(Equal(clusterapi.ExtraArgs{
+		"feature-gates":                     "KubeletCredentialProviders=true",
+		"image-credential-provider-config":  "/etc/kubernetes/image-credential-provider-config.yaml",
+		"image-credential-provider-bin-dir": "/etc/kubernetes/image-credential-provider",
+	}))
+}
+
+func TestImageCredentialProviderConfigFiles(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.ImageCredentialProviderConfigFiles(&v1alpha1.Cluster{})).To(BeNil())
+
+	private := v1alpha1.ImageCredentialProvider{
+		Name:                 "private-registry-provider",
+		MatchImages:          []string{"registry.example.com", "*.registry.example.com:5000"},
+		DefaultCacheDuration: "30m",
+	}
+	cluster := clusterWithImageCredentialProviders(ecrCredentialProvider, private)
+	g.Expect(clusterapi.ImageCredentialProviderConfigFiles(cluster)).To(Equal([]bootstrapv1.File{
+		{
+			Path:  "/etc/kubernetes/image-credential-provider-config.yaml",
+			Owner: "root:root",
+			Content: `apiVersion: kubelet.config.k8s.io/v1alpha1
+kind: CredentialProviderConfig
+providers:
+- name: "ecr-credential-provider"
+  apiVersion: credentialprovider.kubelet.k8s.io/v1alpha1
+  defaultCacheDuration: "12h"
+  matchImages:
+  - "*.dkr.ecr.*.amazonaws.com"
+  args:
+  - "get-credentials"
+  env:
+  - name: AWS_PROFILE
+    value: "ecr"
+  - name: AWS_SHARED_CREDENTIALS_FILE
+    value: "/etc/aws/credentials"
+- name: "private-registry-provider"
+  apiVersion: credentialprovider.kubelet.k8s.io/v1alpha1
+  defaultCacheDuration: "30m"
+  matchImages:
+  - "registry.example.com"
+  - "*.registry.example.com:5000"`,
+		},
+	}))
+}
+
+func TestSetImageCredentialProviderConfigInKubeadmControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				Files: []bootstrapv1.File{{Path: "/etc/kubernetes/audit-policy.yaml"}},
+			},
+		},
+	}
+
+	clusterapi.SetImageCredentialProviderConfigInKubeadmControlPlane(kcp, clusterWithImageCredentialProviders(ecrCredentialProvider))
+	g.Expect(kcp.Spec.KubeadmConfigSpec.Files).To(HaveLen(2))
+	g.Expect(kcp.Spec.KubeadmConfigSpec.Files[1].Path).To(Equal("/etc/kubernetes/image-credential-provider-config.yaml"))
+}
+
+func TestSetImageCredentialProviderConfigInKubeadmConfigTemplate(t *testing.T) {
+	g := NewWithT(t)
+	kct := &bootstrapv1.KubeadmConfigTemplate{}
+
+	clusterapi.SetImageCredentialProviderConfigInKubeadmConfigTemplate(kct, &v1alpha1.Cluster{})
+	g.Expect(kct.Spec.Template.Spec.Files).To(BeEmpty())
+
+	clusterapi.SetImageCredentialProviderConfigInKubeadmConfigTemplate(kct, clusterWithImageCredentialProviders(ecrCredentialProvider))
+	g.Expect(kct.Spec.Template.Spec.Files).To(HaveLen(1))
+}
@@ -19,6 +19,10 @@ const (
 	machineHealthCheckKind    = "MachineHealthCheck"
 	maxUnhealthyControlPlane  = "100%"
 	maxUnhealthyWorker        = "40%"
+
+	// singleNodeUnhealthyConditionTimeout is longer for single node clusters, since remediating their
+	// only machine takes the whole cluster down. It leaves time for reboots, like the OS updates.
+	singleNodeUnhealthyConditionTimeout = 30 * time.Minute
 )
 
 func machineHealthCheck(clusterName string) *clusterv1.MachineHealthCheck {
@@ -58,6 +62,11 @@ func MachineHealthCheckForControlPlane(clusterSpec *cluster.Spec) *clusterv1.Mac
 	mhc.Spec.Selector.MatchLabels[clusterv1.MachineControlPlaneLabelName] = ""
 	maxUnhealthy := intstr.Parse(maxUnhealthyControlPlane)
 	mhc.Spec.MaxUnhealthy = &maxUnhealthy
+	if clusterSpec.Cluster.IsSingleNode() {
+		for i := range mhc.Spec.UnhealthyConditions {
+			mhc.Spec.UnhealthyConditions[i].Timeout = metav1.Duration{Duration: singleNodeUnhealthyConditionTimeout}
+		}
+	}
 	return mhc
 }
 
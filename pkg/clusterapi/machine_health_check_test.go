@@ -54,6 +54,15 @@ func TestMachineHealthCheckForControlPlane(t *testing.T) {
 	tt.Expect(got).To(Equal(want))
 }
 
+func TestMachineHealthCheckForControlPlaneSingleNode(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	got := clusterapi.MachineHealthCheckForControlPlane(tt.clusterSpec)
+	for _, condition := range got.Spec.UnhealthyConditions {
+		tt.Expect(condition.Timeout).To(Equal(metav1.Duration{Duration: 30 * time.Minute}))
+	}
+}
+
 func TestMachineHealthCheckForWorkers(t *testing.T) {
 	tt := newApiBuilerTest(t)
 	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{*tt.workerNodeGroupConfig}
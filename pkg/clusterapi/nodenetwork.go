@@ -0,0 +1,32 @@
+package clusterapi
+
+import (
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// NodeIPCommands returns the commands that make the kubelet use the IPv4 address of the workload
+// network interface as node IP. They wait up to a minute for the interface to get an address and
+// need to run before kubeadm. It doesn't support Bottlerocket.
+func NodeIPCommands(interfaces *v1alpha1.NodeNetworkInterfaces) []string {
+	if interfaces == nil {
+		return nil
+	}
+
+	addresses := fmt.Sprintf("ip -4 -o addr show dev %s", interfaces.Workload)
+	return []string{
+		fmt.Sprintf("for i in $(seq 1 60); do %s | grep -q inet && break; sleep 1; done", addresses),
+		"mkdir -p /etc/sysconfig",
+		fmt.Sprintf(`echo "KUBELET_EXTRA_ARGS=--node-ip=$(%s | awk '{print $4}' | cut -d/ -f1 | head -n1)" | tee /etc/default/kubelet /etc/sysconfig/kubelet`, addresses),
+	}
+}
+
+// VIPInterface returns the interface kube-vip binds the control plane endpoint VIP to,
+// empty to let kube-vip choose the interface of the default route.
+func VIPInterface(interfaces *v1alpha1.NodeNetworkInterfaces) string {
+	if interfaces == nil {
+		return ""
+	}
+	return interfaces.Management
+}
@@ -0,0 +1,32 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestNodeIPCommands(t *testing.T) {
+	g := NewWithT(t)
+	interfaces := &v1alpha1.NodeNetworkInterfaces{Management: "eth0", Workload: "eth1"}
+
+	g.Expect(clusterapi.NodeIPCommands(interfaces)).To(Equal([]string{
+		"for i in $(seq 1 60); do ip -4 -o addr show dev eth1 | grep -q inet && break; sleep 1; done",
+		"mkdir -p /etc/sysconfig",
+		`echo "KUBELET_EXTRA_ARGS=--node-ip=$(ip -4 -o addr show dev eth1 | awk '{print $4}' | cut -d/ -f1 | head -n1)" | tee /etc/default/kubelet /etc/sysconfig/kubelet`,
+	}))
+}
+
+func TestNodeIPCommandsNotSet(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.NodeIPCommands(nil)).To(BeEmpty())
+}
+
+func TestVIPInterface(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.VIPInterface(&v1alpha1.NodeNetworkInterfaces{Management: "eno1", Workload: "eno2"})).To(Equal("eno1"))
+	g.Expect(clusterapi.VIPInterface(nil)).To(BeEmpty())
+}
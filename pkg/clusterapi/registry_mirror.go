@@ -17,6 +17,21 @@ var containerdConfig string
 
 type values map[string]interface{}
 
+// RegistryMirrorCACertTrustCommand returns a shell command that adds the registry mirror CA
+// certificate written to the containerd certs directory to the node OS trust store. It supports
+// both Debian based (update-ca-certificates) and RHEL based (update-ca-trust) distributions.
+func RegistryMirrorCACertTrustCommand(registryAddress string) string {
+	caFile := registryMirrorCACertPath(registryAddress)
+	return fmt.Sprintf(
+		"if command -v update-ca-certificates >/dev/null; then cp %[1]s /usr/local/share/ca-certificates/registry-mirror-ca.crt && update-ca-certificates; else cp %[1]s /etc/pki/ca-trust/source/anchors/registry-mirror-ca.crt && update-ca-trust extract; fi",
+		caFile,
+	)
+}
+
+func registryMirrorCACertPath(registryAddress string) string {
+	return fmt.Sprintf("/etc/containerd/certs.d/%s/ca.crt", registryAddress)
+}
+
 func registryMirrorConfigContent(registryAddress, registryCert string, insecureSkip bool) (string, error) {
 	val := values{
 		"registryMirrorAddress": registryAddress,
@@ -47,7 +62,7 @@ func registryMirrorConfig(registryMirrorConfig *v1alpha1.RegistryMirrorConfigura
 
 	if registryMirrorConfig.CACertContent != "" {
 		files = append(files, bootstrapv1.File{
-			Path:    fmt.Sprintf("/etc/containerd/certs.d/%s/ca.crt", registryAddress),
+			Path:    registryMirrorCACertPath(registryAddress),
 			Owner:   "root:root",
 			Content: registryMirrorConfig.CACertContent,
 		})
@@ -56,6 +71,14 @@ func registryMirrorConfig(registryMirrorConfig *v1alpha1.RegistryMirrorConfigura
 	return files, nil
 }
 
+func registryMirrorTrustCommands(registryMirrorConfig *v1alpha1.RegistryMirrorConfiguration) []string {
+	if registryMirrorConfig.CACertContent == "" {
+		return nil
+	}
+	registryAddress := net.JoinHostPort(registryMirrorConfig.Endpoint, registryMirrorConfig.Port)
+	return []string{RegistryMirrorCACertTrustCommand(registryAddress)}
+}
+
 func SetRegistryMirrorInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, mirrorConfig *v1alpha1.RegistryMirrorConfiguration) error {
 	if mirrorConfig == nil {
 		return nil
@@ -67,6 +90,7 @@ func SetRegistryMirrorInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPl
 	}
 
 	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, containerdFiles...)
+	kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands = append(kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands, registryMirrorTrustCommands(mirrorConfig)...)
 
 	return nil
 }
@@ -82,6 +106,7 @@ func SetRegistryMirrorInKubeadmConfigTemplate(kct *bootstrapv1.KubeadmConfigTemp
 	}
 
 	kct.Spec.Template.Spec.Files = append(kct.Spec.Template.Spec.Files, containerdFiles...)
+	kct.Spec.Template.Spec.PreKubeadmCommands = append(kct.Spec.Template.Spec.PreKubeadmCommands, registryMirrorTrustCommands(mirrorConfig)...)
 
 	return nil
 }
@@ -14,6 +14,7 @@ var registryMirrorTests = []struct {
 	name                 string
 	registryMirrorConfig *v1alpha1.RegistryMirrorConfiguration
 	wantFiles            []bootstrapv1.File
+	wantCommands         []string
 }{
 	{
 		name: "with ca cert",
@@ -38,6 +39,9 @@ var registryMirrorTests = []struct {
 				Content: "xyz",
 			},
 		},
+		wantCommands: []string{
+			"if command -v update-ca-certificates >/dev/null; then cp /etc/containerd/certs.d/1.2.3.4:443/ca.crt /usr/local/share/ca-certificates/registry-mirror-ca.crt && update-ca-certificates; else cp /etc/containerd/certs.d/1.2.3.4:443/ca.crt /etc/pki/ca-trust/source/anchors/registry-mirror-ca.crt && update-ca-trust extract; fi",
+		},
 	},
 	{
 		name: "with insecure skip",
@@ -83,6 +87,9 @@ var registryMirrorTests = []struct {
 				Content: "xyz",
 			},
 		},
+		wantCommands: []string{
+			"if command -v update-ca-certificates >/dev/null; then cp /etc/containerd/certs.d/1.2.3.4:443/ca.crt /usr/local/share/ca-certificates/registry-mirror-ca.crt && update-ca-certificates; else cp /etc/containerd/certs.d/1.2.3.4:443/ca.crt /etc/pki/ca-trust/source/anchors/registry-mirror-ca.crt && update-ca-trust extract; fi",
+		},
 	},
 }
 
@@ -94,6 +101,7 @@ func TestSetRegistryMirrorInKubeadmControlPlane(t *testing.T) {
 			g.Expect(clusterapi.SetRegistryMirrorInKubeadmControlPlane(got, tt.registryMirrorConfig)).To(Succeed())
 			want := wantKubeadmControlPlane()
 			want.Spec.KubeadmConfigSpec.Files = tt.wantFiles
+			want.Spec.KubeadmConfigSpec.PreKubeadmCommands = append(want.Spec.KubeadmConfigSpec.PreKubeadmCommands, tt.wantCommands...)
 			g.Expect(got).To(Equal(want))
 		})
 	}
@@ -107,6 +115,7 @@ func TestSetRegistryMirrorInKubeadmConfigTemplate(t *testing.T) {
 			g.Expect(clusterapi.SetRegistryMirrorInKubeadmConfigTemplate(got, tt.registryMirrorConfig)).To(Succeed())
 			want := wantKubeadmConfigTemplate()
 			want.Spec.Template.Spec.Files = tt.wantFiles
+			want.Spec.Template.Spec.PreKubeadmCommands = append(want.Spec.Template.Spec.PreKubeadmCommands, tt.wantCommands...)
 			g.Expect(got).To(Equal(want))
 		})
 	}
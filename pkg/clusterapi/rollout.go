@@ -0,0 +1,79 @@
+package clusterapi
+
+import (
+	"fmt"
+
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// minReplicasWithoutSurge is the minimum number of control plane machines that can be
+// replaced in place, deleting a machine before creating its replacement, keeping etcd quorum.
+const minReplicasWithoutSurge = 3
+
+// ControlPlaneRolloutReady checks a control plane can safely replace its machines with a rolling
+// update, like the one triggered by a new machine template when the machines are vertically scaled.
+// The control plane must be done with any previous rollout, all its machines must be ready, etcd
+// and the control plane components must be healthy and the rollout must keep etcd quorum.
+// etcdadmCluster is the external etcd cluster, nil for clusters with stacked etcd.
+func ControlPlaneRolloutReady(kcp *controlplanev1.KubeadmControlPlane, etcdadmCluster *etcdv1.EtcdadmCluster) error {
+	replicas := int32(1)
+	if kcp.Spec.Replicas != nil {
+		replicas = *kcp.Spec.Replicas
+	}
+
+	if err := controlPlaneMachinesReady(kcp, replicas); err != nil {
+		return err
+	}
+
+	if conditions.IsFalse(kcp, controlplanev1.EtcdClusterHealthyCondition) {
+		return fmt.Errorf("etcd cluster is not healthy: %s", conditions.GetMessage(kcp, controlplanev1.EtcdClusterHealthyCondition))
+	}
+
+	if conditions.IsFalse(kcp, controlplanev1.ControlPlaneComponentsHealthyCondition) {
+		return fmt.Errorf("control plane components are not healthy: %s", conditions.GetMessage(kcp, controlplanev1.ControlPlaneComponentsHealthyCondition))
+	}
+
+	if etcdadmCluster != nil && !etcdadmCluster.Status.Ready {
+		return fmt.Errorf("external etcd cluster %s is not ready", etcdadmCluster.Name)
+	}
+
+	if maxSurge := controlPlaneMaxSurge(kcp, replicas); maxSurge < 1 && replicas < minReplicasWithoutSurge && etcdadmCluster == nil {
+		return fmt.Errorf("kubeadm control plane %s with %d machines requires a max surge of 1 to roll out without losing etcd quorum", kcp.Name, replicas)
+	}
+
+	return nil
+}
+
+// controlPlaneMachinesReady checks all the kcp machines are ready and up to date.
+func controlPlaneMachinesReady(kcp *controlplanev1.KubeadmControlPlane, replicas int32) error {
+	if kcp.Status.ObservedGeneration != kcp.Generation {
+		return fmt.Errorf("kubeadm control plane %s status is outdated", kcp.Name)
+	}
+
+	if kcp.Status.Replicas != replicas || kcp.Status.UpdatedReplicas != replicas {
+		return fmt.Errorf("kubeadm control plane %s is rolling out: %d of %d machines up to date", kcp.Name, kcp.Status.UpdatedReplicas, replicas)
+	}
+
+	if kcp.Status.ReadyReplicas != replicas || kcp.Status.UnavailableReplicas != 0 {
+		return fmt.Errorf("kubeadm control plane %s is not ready: %d of %d machines ready", kcp.Name, kcp.Status.ReadyReplicas, replicas)
+	}
+
+	return nil
+}
+
+// controlPlaneMaxSurge returns the max surge of the kcp rolling updates, which defaults to 1.
+func controlPlaneMaxSurge(kcp *controlplanev1.KubeadmControlPlane, replicas int32) int {
+	strategy := kcp.Spec.RolloutStrategy
+	if strategy == nil || strategy.RollingUpdate == nil || strategy.RollingUpdate.MaxSurge == nil {
+		return 1
+	}
+
+	maxSurge, err := intstr.GetScaledValueFromIntOrPercent(strategy.RollingUpdate.MaxSurge, int(replicas), true)
+	if err != nil {
+		return 1
+	}
+	return maxSurge
+}
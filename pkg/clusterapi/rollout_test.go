@@ -0,0 +1,146 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func readyKubeadmControlPlane(replicas int32) *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-cluster",
+			Generation: 2,
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Replicas: &replicas,
+		},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			ObservedGeneration: 2,
+			Replicas:           replicas,
+			UpdatedReplicas:    replicas,
+			ReadyReplicas:      replicas,
+			Conditions: clusterv1.Conditions{
+				{Type: controlplanev1.EtcdClusterHealthyCondition, Status: corev1.ConditionTrue},
+				{Type: controlplanev1.ControlPlaneComponentsHealthyCondition, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func TestControlPlaneRolloutReady(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*controlplanev1.KubeadmControlPlane)
+		etcd    *etcdv1.EtcdadmCluster
+		wantErr string
+	}{
+		{
+			name:   "ready",
+			mutate: func(*controlplanev1.KubeadmControlPlane) {},
+		},
+		{
+			name: "no health conditions yet",
+			mutate: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Conditions = nil
+			},
+		},
+		{
+			name: "outdated status",
+			mutate: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.ObservedGeneration = 1
+			},
+			wantErr: "kubeadm control plane my-cluster status is outdated",
+		},
+		{
+			name: "rollout in progress",
+			mutate: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Replicas = 4
+				kcp.Status.UpdatedReplicas = 1
+			},
+			wantErr: "kubeadm control plane my-cluster is rolling out: 1 of 3 machines up to date",
+		},
+		{
+			name: "machine not ready",
+			mutate: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.ReadyReplicas = 2
+				kcp.Status.UnavailableReplicas = 1
+			},
+			wantErr: "kubeadm control plane my-cluster is not ready: 2 of 3 machines ready",
+		},
+		{
+			name: "etcd not healthy",
+			mutate: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Conditions[0].Status = corev1.ConditionFalse
+				kcp.Status.Conditions[0].Message = "Following machines are reporting etcd member errors: my-cluster-abcde"
+			},
+			wantErr: "etcd cluster is not healthy: Following machines are reporting etcd member errors: my-cluster-abcde",
+		},
+		{
+			name: "control plane components not healthy",
+			mutate: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Conditions[1].Status = corev1.ConditionFalse
+				kcp.Status.Conditions[1].Message = "kube-apiserver is not running"
+			},
+			wantErr: "control plane components are not healthy: kube-apiserver is not running",
+		},
+		{
+			name:    "external etcd not ready",
+			mutate:  func(*controlplanev1.KubeadmControlPlane) {},
+			etcd:    &etcdv1.EtcdadmCluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-etcd"}},
+			wantErr: "external etcd cluster my-cluster-etcd is not ready",
+		},
+		{
+			name:   "external etcd ready",
+			mutate: func(*controlplanev1.KubeadmControlPlane) {},
+			etcd: &etcdv1.EtcdadmCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-etcd"},
+				Status:     etcdv1.EtcdadmClusterStatus{Ready: true},
+			},
+		},
+		{
+			name: "no surge keeps quorum",
+			mutate: func(kcp *controlplanev1.KubeadmControlPlane) {
+				maxSurge := intstr.FromInt(0)
+				kcp.Spec.RolloutStrategy = &controlplanev1.RolloutStrategy{
+					RollingUpdate: &controlplanev1.RollingUpdate{MaxSurge: &maxSurge},
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			kcp := readyKubeadmControlPlane(3)
+			tt.mutate(kcp)
+
+			err := clusterapi.ControlPlaneRolloutReady(kcp, tt.etcd)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestControlPlaneRolloutReadyNoSurgeLosesQuorum(t *testing.T) {
+	g := NewWithT(t)
+	kcp := readyKubeadmControlPlane(1)
+	maxSurge := intstr.FromInt(0)
+	kcp.Spec.RolloutStrategy = &controlplanev1.RolloutStrategy{
+		RollingUpdate: &controlplanev1.RollingUpdate{MaxSurge: &maxSurge},
+	}
+
+	g.Expect(clusterapi.ControlPlaneRolloutReady(kcp, nil)).To(
+		MatchError("kubeadm control plane my-cluster with 1 machines requires a max surge of 1 to roll out without losing etcd quorum"),
+	)
+}
@@ -0,0 +1,32 @@
+package clusterapi
+
+import (
+	"fmt"
+	"strings"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const staticPodManifestsDir = "/etc/kubernetes/manifests"
+
+// StaticPodManifestFiles returns the kubeadm files to write the additional control plane static pod manifests.
+// The trailing new line of the content is removed so it can be embedded in yaml literal blocks.
+func StaticPodManifestFiles(controlPlane v1alpha1.ControlPlaneConfiguration) []bootstrapv1.File {
+	files := make([]bootstrapv1.File, 0, len(controlPlane.StaticPodManifests))
+	for _, m := range controlPlane.StaticPodManifests {
+		files = append(files, bootstrapv1.File{
+			Path:    fmt.Sprintf("%s/%s.yaml", staticPodManifestsDir, m.Name),
+			Owner:   "root:root",
+			Content: strings.TrimSuffix(m.Content, "\n"),
+		})
+	}
+	return files
+}
+
+// SetStaticPodManifestsInKubeadmControlPlane adds the additional static pod manifests to the control plane files.
+func SetStaticPodManifestsInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, controlPlane v1alpha1.ControlPlaneConfiguration) {
+	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, StaticPodManifestFiles(controlPlane)...)
+}
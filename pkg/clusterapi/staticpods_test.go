@@ -0,0 +1,42 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestSetStaticPodManifestsInKubeadmControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				Files: []bootstrapv1.File{{Path: "/etc/kubernetes/audit-policy.yaml"}},
+			},
+		},
+	}
+	controlPlane := v1alpha1.ControlPlaneConfiguration{
+		StaticPodManifests: []v1alpha1.StaticPodManifest{
+			{Name: "log-shipper", Content: "apiVersion: v1\nkind: Pod\n"},
+			{Name: "keepalived", Content: "apiVersion: v1\nkind: Pod\n"},
+		},
+	}
+
+	clusterapi.SetStaticPodManifestsInKubeadmControlPlane(kcp, controlPlane)
+
+	g.Expect(kcp.Spec.KubeadmConfigSpec.Files).To(Equal([]bootstrapv1.File{
+		{Path: "/etc/kubernetes/audit-policy.yaml"},
+		{Path: "/etc/kubernetes/manifests/log-shipper.yaml", Owner: "root:root", Content: "apiVersion: v1\nkind: Pod"},
+		{Path: "/etc/kubernetes/manifests/keepalived.yaml", Owner: "root:root", Content: "apiVersion: v1\nkind: Pod"},
+	}))
+}
+
+func TestStaticPodManifestFilesEmpty(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.StaticPodManifestFiles(v1alpha1.ControlPlaneConfiguration{})).To(BeEmpty())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -75,7 +76,9 @@ func capiChangeDiff(currentSpec, newSpec *cluster.Spec, provider providers.Provi
 	changeDiff := &CAPIChangeDiff{}
 	componentChanged := false
 
-	if currentSpec.VersionsBundle.CertManager.Version != newSpec.VersionsBundle.CertManager.Version {
+	// A user managed cert-manager is never upgraded by EKS-A.
+	if newSpec.Cluster.CertManagerMode() != anywherev1.CertManagerExternal &&
+		currentSpec.VersionsBundle.CertManager.Version != newSpec.VersionsBundle.CertManager.Version {
 		changeDiff.CertManager = &types.ComponentChangeDiff{
 			ComponentName: "cert-manager",
 			NewVersion:    newSpec.VersionsBundle.CertManager.Version,
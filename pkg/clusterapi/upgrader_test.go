@@ -117,6 +117,16 @@ func TestUpgraderUpgradeCoreChanges(t *testing.T) {
 	tt.Expect(tt.upgrader.Upgrade(tt.ctx, tt.cluster, tt.provider, tt.currentSpec, tt.newSpec)).To(Equal(wantDiff))
 }
 
+func TestUpgraderUpgradeCertManagerExternal(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.newSpec.Cluster.Spec.CertManager = &v1alpha1.CertManagerConfiguration{Mode: v1alpha1.CertManagerExternal}
+	tt.newSpec.VersionsBundle.CertManager.Version = "v0.2.0"
+
+	tt.provider.EXPECT().ChangeDiff(tt.currentSpec, tt.newSpec).Return(nil)
+
+	tt.Expect(tt.upgrader.Upgrade(tt.ctx, tt.cluster, tt.provider, tt.currentSpec, tt.newSpec)).To(BeNil())
+}
+
 func TestUpgraderUpgradeEverythingChangesStackedEtcd(t *testing.T) {
 	tt := newUpgraderTest(t)
 	tt.newSpec.VersionsBundle.CertManager.Version = "v0.2.0"
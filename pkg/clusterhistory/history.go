@@ -0,0 +1,269 @@
+// Package clusterhistory keeps an append-only log of the changes applied to the spec of each cluster,
+// stored in a ConfigMap next to the cluster object.
+package clusterhistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	// ClusterNameLabel identifies the cluster a history ConfigMap belongs to.
+	ClusterNameLabel = "anywhere.eks.amazonaws.com/history-for-cluster"
+
+	// SourceCLI identifies the changes applied by the eksctl anywhere CLI.
+	SourceCLI = "cli"
+	// SourceController identifies the changes applied by the EKS-A controller.
+	SourceController = "controller"
+
+	// MaxEntries is the number of entries kept in the history of a cluster, the oldest ones are pruned.
+	MaxEntries = 200
+
+	configMapSuffix = "-history"
+	specKey         = "spec"
+	entryKeyPrefix  = "entry-"
+	// maxDataSize keeps the history ConfigMap well below the 1MiB limit of kubernetes objects.
+	maxDataSize = 512 * 1024
+)
+
+// Entry is a change applied to a cluster spec.
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Source is the component that applied the change: the CLI or the controller.
+	Source string `json:"source"`
+	// Actor is who made the change, when known: the user running the CLI or the field manager of the change.
+	Actor      string `json:"actor,omitempty"`
+	Generation int64  `json:"generation,omitempty"`
+	// Changes summarizes the differences with the previous spec, one field per change.
+	Changes []string `json:"changes"`
+}
+
+// ConfigMapName returns the name of the ConfigMap holding the history of a cluster.
+func ConfigMapName(clusterName string) string {
+	return clusterName + configMapSuffix
+}
+
+// NewConfigMap returns an empty history ConfigMap for the cluster.
+func NewConfigMap(cluster *anywherev1.Cluster) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(cluster.Name),
+			Namespace: cluster.Namespace,
+			Labels:    map[string]string{ClusterNameLabel: cluster.Name},
+		},
+	}
+}
+
+// Record appends an entry for the changes in the cluster spec since the last recorded one, completing
+// the Changes and Generation of the entry, and prunes the oldest entries to keep at most MaxEntries and
+// the ConfigMap within its size limit. It returns false, without modifying the ConfigMap, when the spec
+// hasn't changed.
+func Record(cm *corev1.ConfigMap, entry Entry, cluster *anywherev1.Cluster) (bool, error) {
+	spec, err := json.Marshal(cluster.Spec)
+	if err != nil {
+		return false, fmt.Errorf("marshalling spec of cluster %s: %v", cluster.Name, err)
+	}
+
+	if last, ok := cm.Data[specKey]; !ok {
+		entry.Changes = []string{"cluster created"}
+	} else if entry.Changes, err = specChanges([]byte(last), spec); err != nil {
+		return false, fmt.Errorf("comparing spec of cluster %s with the last recorded one: %v", cluster.Name, err)
+	}
+	if len(entry.Changes) == 0 {
+		return false, nil
+	}
+
+	entry.Generation = cluster.Generation
+	e, err := json.Marshal(entry)
+	if err != nil {
+		return false, fmt.Errorf("marshalling history entry: %v", err)
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[entryKey(countEntries(cm)+1)] = string(e)
+	cm.Data[specKey] = string(spec)
+	prune(cm)
+
+	return true, nil
+}
+
+// prune removes the oldest entries over MaxEntries or over the size limit, always keeping the latest one,
+// and renumbers the remaining ones from 1.
+func prune(cm *corev1.ConfigMap) {
+	n := countEntries(cm)
+	size := 0
+	for k, v := range cm.Data {
+		size += len(k) + len(v)
+	}
+
+	drop := 0
+	for drop < n-1 && (n-drop > MaxEntries || size > maxDataSize) {
+		drop++
+		size -= len(entryKey(drop)) + len(cm.Data[entryKey(drop)])
+	}
+	if drop == 0 {
+		return
+	}
+
+	for i := 1; i <= n; i++ {
+		if i+drop <= n {
+			cm.Data[entryKey(i)] = cm.Data[entryKey(i+drop)]
+		} else {
+			delete(cm.Data, entryKey(i))
+		}
+	}
+}
+
+// Entries returns the entries in the history, oldest first.
+func Entries(cm *corev1.ConfigMap) ([]Entry, error) {
+	entries := make([]Entry, 0, countEntries(cm))
+	for i := 1; i <= countEntries(cm); i++ {
+		e := Entry{}
+		if err := json.Unmarshal([]byte(cm.Data[entryKey(i)]), &e); err != nil {
+			return nil, fmt.Errorf("parsing history entry %d: %v", i, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// LastEntry returns the latest entry in the history, nil if the history is empty.
+func LastEntry(cm *corev1.ConfigMap) (*Entry, error) {
+	n := countEntries(cm)
+	if n == 0 {
+		return nil, nil
+	}
+	e := &Entry{}
+	if err := json.Unmarshal([]byte(cm.Data[entryKey(n)]), e); err != nil {
+		return nil, fmt.Errorf("parsing history entry %d: %v", n, err)
+	}
+	return e, nil
+}
+
+// Actor returns the field manager of the latest update to the object, which identifies the client that made it.
+func Actor(obj metav1.Object) string {
+	var latest *metav1.ManagedFieldsEntry
+	for i, f := range obj.GetManagedFields() {
+		if f.Time == nil {
+			continue
+		}
+		if latest == nil || latest.Time.Before(f.Time) {
+			latest = &obj.GetManagedFields()[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Manager
+}
+
+func entryKey(i int) string {
+	return fmt.Sprintf("%s%06d", entryKeyPrefix, i)
+}
+
+func countEntries(cm *corev1.ConfigMap) int {
+	n := 0
+	for k := range cm.Data {
+		if strings.HasPrefix(k, entryKeyPrefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func specChanges(old, new []byte) ([]string, error) {
+	var o, n map[string]interface{}
+	if err := json.Unmarshal(old, &o); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(new, &n); err != nil {
+		return nil, err
+	}
+	return valueChanges("spec", o, n), nil
+}
+
+func valueChanges(path string, old, new interface{}) []string {
+	oldMap, oldIsMap := old.(map[string]interface{})
+	newMap, newIsMap := new.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		return mapChanges(path, oldMap, newMap)
+	}
+
+	oldSlice, oldIsSlice := old.([]interface{})
+	newSlice, newIsSlice := new.([]interface{})
+	if oldIsSlice && newIsSlice && len(oldSlice) == len(newSlice) {
+		var changes []string
+		for i := range oldSlice {
+			changes = append(changes, valueChanges(fmt.Sprintf("%s[%d]", path, i), oldSlice[i], newSlice[i])...)
+		}
+		return changes
+	}
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s: %s -> %s", path, compact(old), compact(new))}
+}
+
+func mapChanges(path string, old, new map[string]interface{}) []string {
+	keys := make([]string, 0, len(old)+len(new))
+	for k := range old {
+		keys = append(keys, k)
+	}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []string
+	for _, k := range keys {
+		o, inOld := old[k]
+		n, inNew := new[k]
+		p := path + "." + k
+		switch {
+		case !inNew:
+			changes = append(changes, fmt.Sprintf("%s: removed %s", p, compact(o)))
+		case !inOld:
+			changes = append(changes, fmt.Sprintf("%s: added %s", p, compact(n)))
+		default:
+			changes = append(changes, valueChanges(p, o, n)...)
+		}
+	}
+	return changes
+}
+
+func compact(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// LocalUser returns the name of the user running the current process, to identify who applied
+// changes from the CLI.
+func LocalUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,174 @@
+package clusterhistory_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterhistory"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func newCluster() *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-cluster",
+			Namespace:  "default",
+			Generation: 1,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion:         anywherev1.Kube123,
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{Count: 1},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: ptr.Int(1)},
+			},
+		},
+	}
+}
+
+func TestRecord(t *testing.T) {
+	g := NewWithT(t)
+	cluster := newCluster()
+	cm := clusterhistory.NewConfigMap(cluster)
+	created := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	g.Expect(cm.Name).To(Equal("my-cluster-history"))
+	g.Expect(cm.Namespace).To(Equal("default"))
+	g.Expect(clusterhistory.Record(cm, clusterhistory.Entry{Time: created, Source: clusterhistory.SourceCLI, Actor: "admin"}, cluster)).To(BeTrue())
+
+	cluster.Generation = 2
+	cluster.Spec.KubernetesVersion = anywherev1.Kube124
+	cluster.Spec.ControlPlaneConfiguration.Count = 3
+	cluster.Spec.WorkerNodeGroupConfigurations = append(cluster.Spec.WorkerNodeGroupConfigurations, anywherev1.WorkerNodeGroupConfiguration{Name: "md-1"})
+	upgraded := created.Add(time.Hour)
+	g.Expect(clusterhistory.Record(cm, clusterhistory.Entry{Time: upgraded, Source: clusterhistory.SourceController, Actor: "kubectl-edit"}, cluster)).To(BeTrue())
+
+	g.Expect(clusterhistory.Entries(cm)).To(Equal([]clusterhistory.Entry{
+		{
+			Time:       created,
+			Source:     clusterhistory.SourceCLI,
+			Actor:      "admin",
+			Generation: 1,
+			Changes:    []string{"cluster created"},
+		},
+		{
+			Time:       upgraded,
+			Source:     clusterhistory.SourceController,
+			Actor:      "kubectl-edit",
+			Generation: 2,
+			Changes: []string{
+				"spec.controlPlaneConfiguration.count: 1 -> 3",
+				"spec.kubernetesVersion: \"1.23\" -> \"1.24\"",
+				`spec.workerNodeGroupConfigurations: [{"count":1,"name":"md-0"}] -> [{"count":1,"name":"md-0"},{"name":"md-1"}]`,
+			},
+		},
+	}))
+}
+
+func TestRecordUnchanged(t *testing.T) {
+	g := NewWithT(t)
+	cluster := newCluster()
+	cm := clusterhistory.NewConfigMap(cluster)
+	g.Expect(clusterhistory.Record(cm, clusterhistory.Entry{}, cluster)).To(BeTrue())
+
+	cluster.Generation = 2
+	cluster.Annotations = map[string]string{"anywhere.eks.amazonaws.com/paused": "true"}
+	g.Expect(clusterhistory.Record(cm, clusterhistory.Entry{}, cluster)).To(BeFalse())
+	g.Expect(clusterhistory.Entries(cm)).To(HaveLen(1))
+}
+
+func TestRecordFieldChanges(t *testing.T) {
+	g := NewWithT(t)
+	cluster := newCluster()
+	cm := clusterhistory.NewConfigMap(cluster)
+	g.Expect(clusterhistory.Record(cm, clusterhistory.Entry{}, cluster)).To(BeTrue())
+
+	cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(2)
+	cluster.Spec.ProxyConfiguration = &anywherev1.ProxyConfiguration{HttpProxy: "proxy:3128"}
+	g.Expect(clusterhistory.Record(cm, clusterhistory.Entry{}, cluster)).To(BeTrue())
+
+	cluster.Spec.ProxyConfiguration = nil
+	g.Expect(clusterhistory.Record(cm, clusterhistory.Entry{}, cluster)).To(BeTrue())
+
+	entries, err := clusterhistory.Entries(cm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entries).To(HaveLen(3))
+	g.Expect(entries[1].Changes).To(Equal([]string{
+		`spec.proxyConfiguration: added {"httpProxy":"proxy:3128"}`,
+		"spec.workerNodeGroupConfigurations[0].count: 1 -> 2",
+	}))
+	g.Expect(entries[2].Changes).To(Equal([]string{
+		`spec.proxyConfiguration: removed {"httpProxy":"proxy:3128"}`,
+	}))
+}
+
+func TestEntriesInvalid(t *testing.T) {
+	g := NewWithT(t)
+	cm := clusterhistory.NewConfigMap(newCluster())
+	cm.Data = map[string]string{"entry-000001": "{"}
+
+	_, err := clusterhistory.Entries(cm)
+	g.Expect(err).To(MatchError(ContainSubstring("parsing history entry 1")))
+}
+
+func TestActor(t *testing.T) {
+	g := NewWithT(t)
+	cluster := newCluster()
+	g.Expect(clusterhistory.Actor(cluster)).To(BeEmpty())
+
+	now := time.Now()
+	cluster.ManagedFields = []metav1.ManagedFieldsEntry{
+		{Manager: "eksctl-anywhere", Time: &metav1.Time{Time: now.Add(-time.Hour)}},
+		{Manager: "kubectl-edit", Time: &metav1.Time{Time: now}},
+		{Manager: "manager"},
+	}
+	g.Expect(clusterhistory.Actor(cluster)).To(Equal("kubectl-edit"))
+}
+
+func TestRecordPrunesOldestEntries(t *testing.T) {
+	g := NewWithT(t)
+	cluster := newCluster()
+	cm := clusterhistory.NewConfigMap(cluster)
+	start := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < clusterhistory.MaxEntries+5; i++ {
+		cluster.Generation = int64(i + 1)
+		cluster.Spec.ControlPlaneConfiguration.Count = i + 1
+		g.Expect(clusterhistory.Record(cm, clusterhistory.Entry{Time: start.Add(time.Duration(i) * time.Minute), Source: clusterhistory.SourceController}, cluster)).To(BeTrue())
+	}
+
+	entries, err := clusterhistory.Entries(cm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entries).To(HaveLen(clusterhistory.MaxEntries))
+	g.Expect(entries[0].Generation).To(Equal(int64(6)))
+	g.Expect(entries[len(entries)-1].Generation).To(Equal(int64(clusterhistory.MaxEntries + 5)))
+}
+
+func TestRecordPrunesLargeHistory(t *testing.T) {
+	g := NewWithT(t)
+	cluster := newCluster()
+	cm := clusterhistory.NewConfigMap(cluster)
+	now := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 20; i++ {
+		cluster.Generation = int64(i + 1)
+		cluster.Spec.ControlPlaneConfiguration.Endpoint = &anywherev1.Endpoint{Host: strings.Repeat(fmt.Sprintf("%d", i%10), 100*1024)}
+		g.Expect(clusterhistory.Record(cm, clusterhistory.Entry{Time: now, Source: clusterhistory.SourceController}, cluster)).To(BeTrue())
+	}
+
+	size := 0
+	for k, v := range cm.Data {
+		size += len(k) + len(v)
+	}
+	g.Expect(size).To(BeNumerically("<", 1024*1024))
+
+	entries, err := clusterhistory.Entries(cm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(len(entries)).To(BeNumerically("<", 20))
+	g.Expect(entries[len(entries)-1].Generation).To(Equal(int64(20)))
+}
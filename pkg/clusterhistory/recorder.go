@@ -0,0 +1,70 @@
+package clusterhistory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// Recorder appends the changes of cluster specs to their history through the kubernetes API.
+type Recorder struct {
+	client client.Client
+	now    types.NowFunc
+}
+
+// NewRecorder builds a Recorder.
+func NewRecorder(client client.Client, now types.NowFunc) *Recorder {
+	return &Recorder{
+		client: client,
+		now:    now,
+	}
+}
+
+// Record appends an entry to the history of the cluster if its spec changed since the last recorded one.
+// Concurrent updates of the history fail with a conflict error, so no entry is lost.
+func (r *Recorder) Record(ctx context.Context, cluster *anywherev1.Cluster, source, actor string) error {
+	cm := &corev1.ConfigMap{}
+	err := r.client.Get(ctx, client.ObjectKey{Name: ConfigMapName(cluster.Name), Namespace: cluster.Namespace}, cm)
+	exists := err == nil
+	if apierrors.IsNotFound(err) {
+		cm = NewConfigMap(cluster)
+	} else if err != nil {
+		return fmt.Errorf("reading history of cluster %s: %v", cluster.Name, err)
+	}
+
+	changed, err := Record(cm, Entry{Time: r.now().UTC().Truncate(time.Second), Source: source, Actor: actor}, cluster)
+	if err != nil || !changed {
+		return err
+	}
+
+	if exists {
+		err = r.client.Update(ctx, cm)
+	} else {
+		err = r.client.Create(ctx, cm)
+	}
+	if err != nil {
+		return fmt.Errorf("saving history of cluster %s: %v", cluster.Name, err)
+	}
+
+	return nil
+}
+
+// LastEntry returns the latest entry in the history of the cluster, nil if nothing has been recorded yet.
+func (r *Recorder) LastEntry(ctx context.Context, cluster *anywherev1.Cluster) (*Entry, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.client.Get(ctx, client.ObjectKey{Name: ConfigMapName(cluster.Name), Namespace: cluster.Namespace}, cm)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading history of cluster %s: %v", cluster.Name, err)
+	}
+
+	return LastEntry(cm)
+}
@@ -0,0 +1,61 @@
+package clusterhistory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterhistory"
+)
+
+func TestRecorderRecord(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	now := time.Date(2022, 8, 1, 10, 30, 0, 0, time.UTC)
+	r := clusterhistory.NewRecorder(c, func() time.Time { return now })
+	cluster := newCluster()
+
+	g.Expect(r.Record(ctx, cluster, clusterhistory.SourceController, "manager")).To(Succeed())
+	g.Expect(r.Record(ctx, cluster, clusterhistory.SourceController, "manager")).To(Succeed())
+	cluster.Spec.KubernetesVersion = anywherev1.Kube124
+	g.Expect(r.Record(ctx, cluster, clusterhistory.SourceController, "kubectl-edit")).To(Succeed())
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "my-cluster-history", Namespace: "default"}, cm)).To(Succeed())
+	g.Expect(cm.Labels).To(HaveKeyWithValue(clusterhistory.ClusterNameLabel, "my-cluster"))
+	entries, err := clusterhistory.Entries(cm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entries).To(HaveLen(2))
+	g.Expect(entries[0].Changes).To(ConsistOf("cluster created"))
+	g.Expect(entries[1].Actor).To(Equal("kubectl-edit"))
+	g.Expect(entries[1].Time).To(Equal(now))
+	g.Expect(entries[1].Changes).To(ConsistOf(`spec.kubernetesVersion: "1.23" -> "1.24"`))
+}
+
+func TestRecorderLastEntry(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r := clusterhistory.NewRecorder(fake.NewClientBuilder().Build(), time.Now)
+	cluster := newCluster()
+
+	entry, err := r.LastEntry(ctx, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entry).To(BeNil())
+
+	g.Expect(r.Record(ctx, cluster, clusterhistory.SourceCLI, "admin")).To(Succeed())
+	cluster.Spec.KubernetesVersion = anywherev1.Kube124
+	cluster.Generation = 2
+	g.Expect(r.Record(ctx, cluster, clusterhistory.SourceController, "kubectl-edit")).To(Succeed())
+
+	entry, err = r.LastEntry(ctx, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entry.Generation).To(Equal(int64(2)))
+	g.Expect(entry.Changes).To(ConsistOf(`spec.kubernetesVersion: "1.23" -> "1.24"`))
+}
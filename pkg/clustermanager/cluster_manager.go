@@ -3,7 +3,6 @@ package clustermanager
 import (
 	"context"
 	_ "embed"
-	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -12,6 +11,9 @@ import (
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/integer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/yaml"
@@ -20,9 +22,11 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/clusterhistory"
 	"github.com/aws/eks-anywhere/pkg/clustermanager/internal"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/coredns"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/features"
@@ -32,6 +36,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/wait"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -63,8 +68,12 @@ type ClusterManager struct {
 	machineBackoff          time.Duration
 	machinesMinWait         time.Duration
 	awsIamAuth              AwsIamAuth
+	backupInstaller         BackupInstaller
+	osUpdatesInstaller      OSUpdatesInstaller
 	controlPlaneWaitTimeout time.Duration
 	externalEtcdWaitTimeout time.Duration
+	nodeUpgradeTimeout      time.Duration
+	nodeUpgradePollInterval time.Duration
 }
 
 type ClusterClient interface {
@@ -96,6 +105,8 @@ type ClusterClient interface {
 	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
 	GetEksaVSphereDatacenterConfig(ctx context.Context, VSphereDatacenterName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereDatacenterConfig, error)
 	UpdateEnvironmentVariablesInNamespace(ctx context.Context, resourceType, resourceName string, envMap map[string]string, cluster *types.Cluster, namespace string) error
+	UpdateResourceRequestsInNamespace(ctx context.Context, resourceType, resourceName string, requests map[string]string, cluster *types.Cluster, namespace string) error
+	UpdateReplicasInNamespace(ctx context.Context, resourceType, resourceName string, replicas int, cluster *types.Cluster, namespace string) error
 	UpdateAnnotationInNamespace(ctx context.Context, resourceType, objectName string, annotations map[string]string, cluster *types.Cluster, namespace string) error
 	RemoveAnnotationInNamespace(ctx context.Context, resourceType, objectName, key string, cluster *types.Cluster, namespace string) error
 	GetEksaVSphereMachineConfig(ctx context.Context, VSphereDatacenterName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereMachineConfig, error)
@@ -113,6 +124,9 @@ type ClusterClient interface {
 	GetMachineDeployment(ctx context.Context, workerNodeGroupName string, opts ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error)
 	GetEksdRelease(ctx context.Context, name, namespace, kubeconfigFile string) (*eksdv1alpha1.Release, error)
 	ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list kubernetes.ObjectList) error
+	DeleteIgnoreNotFound(ctx context.Context, resourceType, name, namespace, kubeconfig string) error
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+	Apply(ctx context.Context, kubeconfig string, obj runtime.Object) error
 }
 
 type Networking interface {
@@ -127,6 +141,16 @@ type AwsIamAuth interface {
 	UpgradeAWSIAMAuth(ctx context.Context, cluster *types.Cluster, spec *cluster.Spec) error
 }
 
+// BackupInstaller deploys the backup component to the clusters that configure backup.
+type BackupInstaller interface {
+	Install(ctx context.Context, cluster *types.Cluster, spec *cluster.Spec) error
+}
+
+// OSUpdatesInstaller deploys the OS updates component to the clusters that enable Bottlerocket updates.
+type OSUpdatesInstaller interface {
+	Install(ctx context.Context, cluster *types.Cluster, spec *cluster.Spec) error
+}
+
 type ClusterManagerOpt func(*ClusterManager)
 
 func New(clusterClient ClusterClient, networking Networking, writer filewriter.FileWriter, diagnosticBundleFactory diagnostics.DiagnosticBundleFactory, awsIamAuth AwsIamAuth, opts ...ClusterManagerOpt) *ClusterManager {
@@ -145,6 +169,8 @@ func New(clusterClient ClusterClient, networking Networking, writer filewriter.F
 		awsIamAuth:              awsIamAuth,
 		controlPlaneWaitTimeout: DefaultControlPlaneWait,
 		externalEtcdWaitTimeout: DefaultEtcdWait,
+		nodeUpgradeTimeout:      defaultNodeUpgradeTimeout,
+		nodeUpgradePollInterval: defaultNodeUpgradePollInterval,
 	}
 
 	for _, o := range opts {
@@ -166,6 +192,36 @@ func WithExternalEtcdWaitTimeout(timeout time.Duration) ClusterManagerOpt {
 	}
 }
 
+// WithNodeUpgradeTimeout sets the max time to wait for a node to be upgraded in place.
+func WithNodeUpgradeTimeout(timeout time.Duration) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.nodeUpgradeTimeout = timeout
+	}
+}
+
+// WithNodeUpgradePollInterval sets the interval between checks of the upgrader pods of the nodes
+// upgraded in place.
+func WithNodeUpgradePollInterval(interval time.Duration) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.nodeUpgradePollInterval = interval
+	}
+}
+
+// WithBackupInstaller sets the installer for the backup component of the clusters that configure backup.
+func WithBackupInstaller(installer BackupInstaller) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.backupInstaller = installer
+	}
+}
+
+// WithOSUpdatesInstaller sets the installer for the OS updates component of the clusters that enable
+// Bottlerocket updates.
+func WithOSUpdatesInstaller(installer OSUpdatesInstaller) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.osUpdatesInstaller = installer
+	}
+}
+
 func WithMachineBackoff(machineBackoff time.Duration) ClusterManagerOpt {
 	return func(c *ClusterManager) {
 		c.machineBackoff = machineBackoff
@@ -318,7 +374,28 @@ func (c *ClusterManager) generateWorkloadKubeconfig(ctx context.Context, cluster
 func (c *ClusterManager) RunPostCreateWorkloadCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	logger.V(3).Info("Waiting for controlplane and worker machines to be ready")
 	labels := []string{clusterv1.MachineControlPlaneLabelName, clusterv1.MachineDeploymentLabelName}
-	return c.waitForNodesReady(ctx, managementCluster, workloadCluster.Name, labels, types.WithNodeRef())
+	if err := c.waitForNodesReady(ctx, managementCluster, workloadCluster.Name, labels, types.WithNodeRef()); err != nil {
+		return err
+	}
+
+	if clusterSpec.Cluster.Spec.CoreDNSConfiguration.IsEmpty() {
+		return nil
+	}
+	return c.applyCoreDNSConfiguration(ctx, workloadCluster, clusterSpec)
+}
+
+// applyCoreDNSConfiguration replaces the CoreDNS Corefile of the cluster with the one for its spec.
+func (c *ClusterManager) applyCoreDNSConfiguration(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	logger.V(3).Info("Applying CoreDNS configuration")
+	configMap, err := templater.ObjectsToYaml(coredns.ConfigMap(clusterSpec.Cluster))
+	if err != nil {
+		return err
+	}
+
+	if err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, workloadCluster, configMap); err != nil {
+		return fmt.Errorf("applying CoreDNS configuration: %v", err)
+	}
+	return nil
 }
 
 func (c *ClusterManager) DeleteCluster(ctx context.Context, managementCluster, clusterToDelete *types.Cluster, provider providers.Provider, clusterSpec *cluster.Spec) error {
@@ -383,9 +460,8 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 	if err = c.writeCAPISpecFile(newClusterSpec.Cluster.Name, templater.AppendYamlResources(cpContent, mdContent)); err != nil {
 		return err
 	}
-	err = c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, cpContent, constants.EksaSystemNamespace)
-	if err != nil {
-		return fmt.Errorf("applying capi control plane spec: %v", err)
+	if err = c.applyControlPlaneSpec(ctx, managementCluster, workloadCluster, currentSpec, newClusterSpec, cpContent, mdContent); err != nil {
+		return err
 	}
 
 	var externalEtcdTopology bool
@@ -490,11 +566,32 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		}
 	}
 
-	if err = c.InstallStorageClass(ctx, workloadCluster, provider); err != nil {
+	return c.runPostUpgradeInstalls(ctx, managementCluster, workloadCluster, provider, currentSpec, newClusterSpec)
+}
+
+func (c *ClusterManager) runPostUpgradeInstalls(ctx context.Context, managementCluster, workloadCluster *types.Cluster, provider providers.Provider, currentSpec, newSpec *cluster.Spec) error {
+	if err := c.InstallStorageClass(ctx, workloadCluster, provider); err != nil {
 		return fmt.Errorf("installing storage class during upgrade: %v", err)
 	}
 
-	return nil
+	if err := c.TagClusterResources(ctx, managementCluster, newSpec, provider); err != nil {
+		return err
+	}
+
+	if err := c.InstallBackup(ctx, workloadCluster, newSpec); err != nil {
+		return err
+	}
+
+	if err := c.InstallOSUpdates(ctx, workloadCluster, newSpec); err != nil {
+		return err
+	}
+
+	// kubeadm resets the Corefile when it upgrades CoreDNS, so the configuration is applied again
+	// after every upgrade. It's also applied when it was removed to restore the default Corefile.
+	if newSpec.Cluster.Spec.CoreDNSConfiguration.IsEmpty() && currentSpec.Cluster.Spec.CoreDNSConfiguration.IsEmpty() {
+		return nil
+	}
+	return c.applyCoreDNSConfiguration(ctx, workloadCluster, newSpec)
 }
 
 func (c *ClusterManager) EKSAClusterSpecChanged(ctx context.Context, cluster *types.Cluster, newClusterSpec *cluster.Spec) (bool, error) {
@@ -612,6 +709,54 @@ func (c *ClusterManager) InstallStorageClass(ctx context.Context, cluster *types
 	return nil
 }
 
+// TagClusterResources propagates the tags of the cluster spec to the infrastructure resources of the
+// cluster for the providers that support it. managementCluster is the cluster with the CAPI objects.
+func (c *ClusterManager) TagClusterResources(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	// Same as with the storage class, only some providers tag the resources of a cluster after creating
+	// them, so an anonymous interface avoids adding a no-op method to all the providers. The providers
+	// are called even without tags, so they can remove the tags that left the spec.
+	tagger, ok := provider.(interface {
+		TagClusterResources(context.Context, *types.Cluster, *cluster.Spec) error
+	})
+	if !ok {
+		return nil
+	}
+
+	logger.Info("Tagging cluster resources")
+	if err := tagger.TagClusterResources(ctx, managementCluster, clusterSpec); err != nil {
+		return fmt.Errorf("tagging cluster resources: %v", err)
+	}
+	return nil
+}
+
+// InstallBackup deploys the backup component to the cluster, or upgrades it to the version in the spec
+// bundle, if the spec configures backup.
+func (c *ClusterManager) InstallBackup(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if clusterSpec.Cluster.Spec.Backup == nil || c.backupInstaller == nil {
+		return nil
+	}
+
+	logger.Info("Installing backup component on cluster")
+	if err := c.backupInstaller.Install(ctx, workloadCluster, clusterSpec); err != nil {
+		return fmt.Errorf("installing backup component: %v", err)
+	}
+	return nil
+}
+
+// InstallOSUpdates deploys the Bottlerocket update operator to the cluster, or upgrades it to the version
+// in the spec bundle, if the spec enables Bottlerocket updates.
+func (c *ClusterManager) InstallOSUpdates(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if clusterSpec.Cluster.Spec.BottlerocketUpdates == nil || c.osUpdatesInstaller == nil {
+		return nil
+	}
+
+	logger.Info("Installing Bottlerocket update operator on cluster")
+	if err := c.osUpdatesInstaller.Install(ctx, workloadCluster, clusterSpec); err != nil {
+		return fmt.Errorf("installing bottlerocket update operator: %v", err)
+	}
+	return nil
+}
+
 func (c *ClusterManager) InstallMachineHealthChecks(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error {
 	mhc, err := templater.ObjectsToYaml(clusterapi.MachineHealthCheckObjects(clusterSpec)...)
 	if err != nil {
@@ -687,100 +832,92 @@ func collectDiagnosticBundle(ctx context.Context, bundle diagnostics.DiagnosticB
 }
 
 func (c *ClusterManager) waitForControlPlaneReplicasReady(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
-	isCpReady := func() error {
-		return c.clusterClient.ValidateControlPlaneNodes(ctx, managementCluster, clusterSpec.Cluster.Name)
-	}
-
-	err := isCpReady()
-	if err == nil {
-		return nil
-	}
-
-	timeout := time.Duration(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count) * c.machineMaxWait
-	if timeout <= c.machinesMinWait {
-		timeout = c.machinesMinWait
+	isCpReady := func(_ context.Context) (wait.Status, error) {
+		if err := c.clusterClient.ValidateControlPlaneNodes(ctx, managementCluster, clusterSpec.Cluster.Name); err != nil {
+			return wait.Status{}, err
+		}
+		return wait.Status{Done: true}, nil
 	}
 
-	r := retrier.New(timeout)
-	if err := r.Retry(isCpReady); err != nil {
-		return fmt.Errorf("retries exhausted waiting for controlplane replicas to be ready: %v", err)
+	timeout := c.machinesTimeout(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count)
+	if err := c.machinesWaiter(timeout).For(ctx, "controlplane replicas to be ready", isCpReady); err != nil {
+		return fmt.Errorf("waiting for controlplane replicas to be ready: %w", err)
 	}
 	return nil
 }
 
 func (c *ClusterManager) waitForMachineDeploymentReplicasReady(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
-	ready, total := 0, 0
-	policy := func(_ int, _ error) (bool, time.Duration) {
-		return true, c.machineBackoff * time.Duration(integer.IntMax(1, total-ready))
-	}
-
 	var machineDeploymentReplicasCount int
 	for _, workerNodeGroupConfiguration := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
 		machineDeploymentReplicasCount += *workerNodeGroupConfiguration.Count
 	}
 
-	areMdReplicasReady := func() error {
-		var err error
-		ready, total, err = c.clusterClient.CountMachineDeploymentReplicasReady(ctx, clusterSpec.Cluster.Name, managementCluster.KubeconfigFile)
+	areMdReplicasReady := func(_ context.Context) (wait.Status, error) {
+		ready, total, err := c.clusterClient.CountMachineDeploymentReplicasReady(ctx, clusterSpec.Cluster.Name, managementCluster.KubeconfigFile)
 		if err != nil {
-			return err
-		}
-		if ready != total {
-			return fmt.Errorf("%d machine deployment replicas are not ready", total-ready)
+			return wait.Status{}, err
 		}
-		return nil
-	}
-
-	timeout := time.Duration(machineDeploymentReplicasCount) * c.machineMaxWait
-	if timeout <= c.machinesMinWait {
-		timeout = c.machinesMinWait
+		return c.machinesReadyStatus("machine deployment replicas", ready, total), nil
 	}
 
-	r := retrier.New(timeout, retrier.WithRetryPolicy(policy))
-	if err := r.Retry(areMdReplicasReady); err != nil {
-		return fmt.Errorf("retries exhausted waiting for machinedeployment replicas to be ready: %v", err)
+	timeout := c.machinesTimeout(machineDeploymentReplicasCount)
+	if err := c.machinesWaiter(timeout).For(ctx, "machinedeployment replicas to be ready", areMdReplicasReady); err != nil {
+		return fmt.Errorf("waiting for machinedeployment replicas to be ready: %w", err)
 	}
 	return nil
 }
 
 func (c *ClusterManager) waitForNodesReady(ctx context.Context, managementCluster *types.Cluster, clusterName string, labels []string, checkers ...types.NodeReadyChecker) error {
-	readyNodes, totalNodes := 0, 0
-	policy := func(_ int, _ error) (bool, time.Duration) {
-		return true, c.machineBackoff * time.Duration(integer.IntMax(1, totalNodes-readyNodes))
-	}
-
-	areNodesReady := func() error {
-		var err error
-		readyNodes, totalNodes, err = c.countNodesReady(ctx, managementCluster, clusterName, labels, checkers...)
+	totalNodes := 0
+	areNodesReady := func(_ context.Context) (wait.Status, error) {
+		ready, total, err := c.countNodesReady(ctx, managementCluster, clusterName, labels, checkers...)
 		if err != nil {
-			return err
+			return wait.Status{}, err
 		}
+		totalNodes = total
 
-		if readyNodes != totalNodes {
-			logger.V(4).Info("Nodes are not ready yet", "total", totalNodes, "ready", readyNodes, "cluster name", clusterName)
-			return errors.New("nodes are not ready yet")
+		status := c.machinesReadyStatus("nodes", ready, total)
+		if status.Done {
+			logger.V(4).Info("Nodes ready", "total", total)
+		} else {
+			logger.V(4).Info("Nodes are not ready yet", "total", total, "ready", ready, "cluster name", clusterName)
 		}
+		return status, nil
+	}
 
-		logger.V(4).Info("Nodes ready", "total", totalNodes)
+	if status, err := areNodesReady(ctx); err == nil && status.Done {
 		return nil
 	}
 
-	err := areNodesReady()
-	if err == nil {
-		return nil
+	timeout := c.machinesTimeout(totalNodes)
+	if err := c.machinesWaiter(timeout).For(ctx, "machines to be ready", areNodesReady); err != nil {
+		return fmt.Errorf("waiting for machines to be ready: %w", err)
 	}
 
-	timeout := time.Duration(totalNodes) * c.machineMaxWait
+	return nil
+}
+
+// machinesTimeout returns the max time to wait for a number of machines to be ready.
+func (c *ClusterManager) machinesTimeout(machines int) time.Duration {
+	timeout := time.Duration(machines) * c.machineMaxWait
 	if timeout <= c.machinesMinWait {
 		timeout = c.machinesMinWait
 	}
+	return timeout
+}
 
-	r := retrier.New(timeout, retrier.WithRetryPolicy(policy))
-	if err := r.Retry(areNodesReady); err != nil {
-		return fmt.Errorf("retries exhausted waiting for machines to be ready: %v", err)
-	}
+func (c *ClusterManager) machinesWaiter(timeout time.Duration) *wait.Waiter {
+	return wait.New(timeout, wait.WithInterval(c.machineBackoff), wait.WithProgress(wait.LogProgress(6)))
+}
 
-	return nil
+// machinesReadyStatus polls less frequently the more machines are pending, since
+// each of them usually takes a while to be provisioned.
+func (c *ClusterManager) machinesReadyStatus(kind string, ready, total int) wait.Status {
+	return wait.Status{
+		Done:       ready == total,
+		Message:    fmt.Sprintf("%d/%d %s ready", ready, total, kind),
+		RetryAfter: c.machineBackoff * time.Duration(integer.IntMax(1, total-ready)),
+	}
 }
 
 func (c *ClusterManager) countNodesReady(ctx context.Context, managementCluster *types.Cluster, clusterName string, labels []string, checkers ...types.NodeReadyChecker) (ready, total int, err error) {
@@ -911,9 +1048,41 @@ func (c *ClusterManager) CreateEKSAResources(ctx context.Context, cluster *types
 	if err = c.applyResource(ctx, cluster, resourcesSpec); err != nil {
 		return err
 	}
+	if err = c.recordHistory(ctx, cluster, clusterSpec.Cluster.Name); err != nil {
+		logger.Info("Warning: failed to record the change in the cluster history", "error", err)
+	}
 	return c.ApplyBundles(ctx, clusterSpec, cluster)
 }
 
+// recordHistory appends the changes just applied to the spec of the cluster to its history.
+func (c *ClusterManager) recordHistory(ctx context.Context, cluster *types.Cluster, clusterName string) error {
+	eksaCluster, err := c.clusterClient.GetEksaCluster(ctx, cluster, clusterName)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = c.clusterClient.GetObject(ctx, "configmap", clusterhistory.ConfigMapName(clusterName), eksaCluster.Namespace, cluster.KubeconfigFile, cm)
+	if apierrors.IsNotFound(err) {
+		cm = clusterhistory.NewConfigMap(eksaCluster)
+	} else if err != nil {
+		return err
+	}
+
+	entry := clusterhistory.Entry{
+		Time:   time.Now().UTC().Truncate(time.Second),
+		Source: clusterhistory.SourceCLI,
+		Actor:  clusterhistory.LocalUser(),
+	}
+	changed, err := clusterhistory.Record(cm, entry, eksaCluster)
+	if err != nil || !changed {
+		return err
+	}
+
+	cm.ManagedFields = nil
+	return c.clusterClient.Apply(ctx, cluster.KubeconfigFile, cm)
+}
+
 func (c *ClusterManager) ApplyBundles(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
 	bundleObj, err := yaml.Marshal(clusterSpec.Bundles)
 	if err != nil {
@@ -924,6 +1093,18 @@ func (c *ClusterManager) ApplyBundles(ctx context.Context, clusterSpec *cluster.
 	if err != nil {
 		return fmt.Errorf("applying bundle spec: %v", err)
 	}
+
+	// The controller reads the EKS-D bundle entry of a pinned release from the cluster.
+	if pinnedBundles := clusterSpec.PinnedEksdReleaseBundles(); pinnedBundles != nil {
+		pinnedBundlesObj, err := yaml.Marshal(pinnedBundles)
+		if err != nil {
+			return fmt.Errorf("outputting pinned eks-d release bundle yaml: %v", err)
+		}
+		logger.V(1).Info("Applying pinned EKS-D release Bundles to cluster", "release", pinnedBundles.Name)
+		if err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, cluster, pinnedBundlesObj); err != nil {
+			return fmt.Errorf("applying pinned eks-d release bundle spec: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -1105,6 +1286,22 @@ func (c *ClusterManager) DeleteEKSACluster(ctx context.Context, managementCluste
 	return c.clusterClient.DeleteEKSACluster(ctx, managementCluster, name, namespace)
 }
 
+// CAPIClusterExists reports whether managementCluster holds the CAPI cluster object of the cluster named clusterName.
+func (c *ClusterManager) CAPIClusterExists(ctx context.Context, managementCluster *types.Cluster, clusterName string) (bool, error) {
+	clusters, err := c.clusterClient.GetClusters(ctx, managementCluster)
+	if err != nil {
+		return false, err
+	}
+
+	for _, cluster := range clusters {
+		if cluster.Metadata.Name == clusterName {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (c *ClusterManager) DeletePackageResources(ctx context.Context, managementCluster *types.Cluster, clusterName string) error {
 	return c.clusterClient.DeletePackageResources(ctx, managementCluster, clusterName)
 }
@@ -4,18 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterhistory"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/clustermanager/internal"
 	mocksmanager "github.com/aws/eks-anywhere/pkg/clustermanager/mocks"
@@ -122,6 +128,83 @@ func TestClusterManagerInstallStorageClass(t *testing.T) {
 	}
 }
 
+type tagsProviderMock struct {
+	providers.Provider
+	Called bool
+	Return error
+}
+
+func (s *tagsProviderMock) TagClusterResources(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	s.Called = true
+	return s.Return
+}
+
+func TestClusterManagerTagClusterResources(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.Tags = map[string]string{"cost-center": "platform"}
+	provider := &tagsProviderMock{Provider: tt.mocks.provider, Return: errors.New("permission denied")}
+
+	tt.Expect(tt.clusterManager.TagClusterResources(tt.ctx, tt.cluster, tt.clusterSpec, provider)).To(
+		MatchError("tagging cluster resources: permission denied"),
+	)
+	tt.Expect(provider.Called).To(BeTrue())
+}
+
+func TestClusterManagerTagClusterResourcesNoTags(t *testing.T) {
+	tt := newTest(t)
+	provider := &tagsProviderMock{Provider: tt.mocks.provider}
+
+	tt.Expect(tt.clusterManager.TagClusterResources(tt.ctx, tt.cluster, tt.clusterSpec, provider)).To(Succeed())
+	tt.Expect(provider.Called).To(BeTrue())
+}
+
+func TestClusterManagerTagClusterResourcesProviderWithoutTags(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.Tags = map[string]string{"cost-center": "platform"}
+
+	tt.Expect(tt.clusterManager.TagClusterResources(tt.ctx, tt.cluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+}
+
+func TestClusterManagerInstallBackup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backup := mocksmanager.NewMockBackupInstaller(ctrl)
+	tt := newTest(t, clustermanager.WithBackupInstaller(backup))
+	tt.clusterSpec.Cluster.Spec.Backup = &v1alpha1.BackupConfiguration{}
+	backup.EXPECT().Install(tt.ctx, tt.cluster, tt.clusterSpec).Return(errors.New("chart not found"))
+
+	tt.Expect(tt.clusterManager.InstallBackup(tt.ctx, tt.cluster, tt.clusterSpec)).To(
+		MatchError("installing backup component: chart not found"),
+	)
+}
+
+func TestClusterManagerInstallBackupNotConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backup := mocksmanager.NewMockBackupInstaller(ctrl)
+	tt := newTest(t, clustermanager.WithBackupInstaller(backup))
+
+	tt.Expect(tt.clusterManager.InstallBackup(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerInstallOSUpdates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	osUpdates := mocksmanager.NewMockOSUpdatesInstaller(ctrl)
+	tt := newTest(t, clustermanager.WithOSUpdatesInstaller(osUpdates))
+	tt.clusterSpec.Cluster.Spec.BottlerocketUpdates = &v1alpha1.BottlerocketUpdatesConfiguration{}
+	osUpdates.EXPECT().Install(tt.ctx, tt.cluster, tt.clusterSpec).Return(errors.New("chart not found"))
+
+	tt.Expect(tt.clusterManager.InstallOSUpdates(tt.ctx, tt.cluster, tt.clusterSpec)).To(
+		MatchError("installing bottlerocket update operator: chart not found"),
+	)
+}
+
+func TestClusterManagerInstallOSUpdatesNotEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	osUpdates := mocksmanager.NewMockOSUpdatesInstaller(ctrl)
+	tt := newTest(t, clustermanager.WithOSUpdatesInstaller(osUpdates))
+
+	tt.Expect(tt.clusterManager.InstallOSUpdates(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
 func TestClusterManagerCAPIWaitForDeploymentStackedEtcd(t *testing.T) {
 	ctx := context.Background()
 	clusterObj := &types.Cluster{}
@@ -296,6 +379,67 @@ func TestClusterManagerRunPostCreateWorkloadClusterSuccess(t *testing.T) {
 	}
 }
 
+func TestClusterManagerRunPostCreateWorkloadClusterCoreDNSConfiguration(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.Cluster.Spec.CoreDNSConfiguration = &v1alpha1.CoreDNSConfiguration{
+			UpstreamServers: []string{"10.0.0.2"},
+		}
+	})
+
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
+	}
+	workloadCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "workload-kubeconfig",
+	}
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Return([]types.Machine{}, nil)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			if !strings.Contains(string(data), "forward . 10.0.0.2 {") {
+				t.Errorf("CoreDNS ConfigMap doesn't forward to the upstream servers:\n%s", data)
+			}
+			return nil
+		},
+	)
+	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.RunPostCreateWorkloadCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerRunPostCreateWorkloadClusterCoreDNSConfigurationError(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.Cluster.Spec.CoreDNSConfiguration = &v1alpha1.CoreDNSConfiguration{
+			UpstreamServers: []string{"10.0.0.2"},
+		}
+	})
+
+	mgmtCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "mgmt-kubeconfig",
+	}
+	workloadCluster := &types.Cluster{
+		Name:           clusterName,
+		KubeconfigFile: "workload-kubeconfig",
+	}
+
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	m.client.EXPECT().GetMachines(ctx, mgmtCluster, mgmtCluster.Name).Return([]types.Machine{}, nil)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, gomock.Any()).Return(errors.New("error applying"))
+	if err := c.RunPostCreateWorkloadCluster(ctx, mgmtCluster, workloadCluster, clusterSpec); err == nil {
+		t.Error("ClusterManager.RunPostCreateWorkloadCluster() error = nil, wantErr not nil")
+	}
+}
+
 func TestClusterManagerCreateWorkloadClusterWithExternalEtcdSuccess(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
@@ -665,6 +809,55 @@ func TestClusterManagerUpgradeWorkloadClusterSuccess(t *testing.T) {
 	}
 }
 
+func TestClusterManagerUpgradeWorkloadClusterRestoresDefaultCoreDNSConfiguration(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+	md := &clusterv1.MachineDeployment{}
+
+	tt := newSpecChangedTest(t)
+	tt.oldClusterConfig.Spec.CoreDNSConfiguration = &v1alpha1.CoreDNSConfiguration{
+		UpstreamServers: []string{"10.0.0.2"},
+	}
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any())
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, gomock.Any(), tt.clusterSpec)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, gomock.Any(), tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.AssignableToTypeOf(executables.WithKubeconfig(mCluster.KubeconfigFile)), gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(md, nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, md, mCluster.KubeconfigFile)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, wCluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			if !strings.Contains(string(data), "forward . /etc/resolv.conf {") {
+				t.Errorf("CoreDNS ConfigMap doesn't have the default Corefile:\n%s", data)
+			}
+			return nil
+		},
+	)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
 func TestClusterManagerUpgradeWorkloadClusterInstallStorageClassSuccess(t *testing.T) {
 	mgmtClusterName := "cluster-name"
 	workClusterName := "cluster-name-w"
@@ -1302,6 +1495,19 @@ func TestClusterManagerCreateEKSAResourcesSuccess(t *testing.T) {
 	m.client.EXPECT().ApplyKubeSpecFromBytesForce(ctx, tt.cluster, gomock.Any())
 	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, tt.cluster, gomock.Any())
 	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, tt.cluster, gomock.Any(), gomock.Any()).MaxTimes(2)
+	m.client.EXPECT().GetEksaCluster(ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.clusterSpec.Cluster, nil)
+	m.client.EXPECT().GetObject(ctx, "configmap", "fluxTestCluster-history", tt.clusterSpec.Cluster.Namespace, tt.cluster.KubeconfigFile, gomock.Any()).
+		Return(apierrors.NewNotFound(schema.GroupResource{Resource: "configmap"}, "fluxTestCluster-history"))
+	m.client.EXPECT().Apply(ctx, tt.cluster.KubeconfigFile, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, obj runtime.Object) error {
+			entries, err := clusterhistory.Entries(obj.(*corev1.ConfigMap))
+			tt.Expect(err).NotTo(HaveOccurred())
+			tt.Expect(entries).To(HaveLen(1))
+			tt.Expect(entries[0].Source).To(Equal(clusterhistory.SourceCLI))
+			tt.Expect(entries[0].Changes).To(ConsistOf("cluster created"))
+			return nil
+		},
+	)
 	tt.Expect(c.CreateEKSAResources(ctx, tt.cluster, tt.clusterSpec, datacenterConfig, machineConfigs)).To(Succeed())
 	_, ok := datacenterConfig.GetAnnotations()["anywhere.eks.amazonaws.com/paused"]
 	tt.Expect(ok).To(BeTrue())
@@ -1309,6 +1515,68 @@ func TestClusterManagerCreateEKSAResourcesSuccess(t *testing.T) {
 	tt.Expect(ok).To(BeTrue())
 }
 
+func TestClusterManagerApplyBundlesWithEksdReleasePin(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.EksdReleasePins = []v1alpha1.EksdReleasePin{
+		{KubernetesVersion: tt.clusterSpec.Cluster.Spec.KubernetesVersion, Number: 4},
+	}
+	tt.clusterSpec.VersionsBundle.EksD.Name = "kubernetes-1-21-eks-4"
+
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("name: kubernetes-1-21-eks-4"))
+			tt.Expect(string(data)).To(ContainSubstring("namespace: eksa-system"))
+			return nil
+		},
+	)
+
+	tt.Expect(tt.clusterManager.ApplyBundles(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+}
+
+func TestClusterManagerCreateEKSAResourcesHistoryUnchanged(t *testing.T) {
+	features.ClearCache()
+	ctx := context.Background()
+	tt := newTest(t)
+	tt.clusterSpec.VersionsBundle.EksD.Components = "testdata/eksa_components.yaml"
+	tt.clusterSpec.VersionsBundle.EksD.EksDReleaseUrl = "testdata/eksa_components.yaml"
+
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
+	machineConfigs := []providers.MachineConfig{}
+	history := clusterhistory.NewConfigMap(tt.clusterSpec.Cluster)
+	_, err := clusterhistory.Record(history, clusterhistory.Entry{}, tt.clusterSpec.Cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	c, m := newClusterManager(t)
+
+	m.client.EXPECT().ApplyKubeSpecFromBytesForce(ctx, tt.cluster, gomock.Any())
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, tt.cluster, gomock.Any())
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, tt.cluster, gomock.Any(), gomock.Any()).MaxTimes(2)
+	m.client.EXPECT().GetEksaCluster(ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.clusterSpec.Cluster, nil)
+	m.client.EXPECT().GetObject(ctx, "configmap", "fluxTestCluster-history", tt.clusterSpec.Cluster.Namespace, tt.cluster.KubeconfigFile, gomock.Any()).
+		SetArg(5, *history)
+	tt.Expect(c.CreateEKSAResources(ctx, tt.cluster, tt.clusterSpec, datacenterConfig, machineConfigs)).To(Succeed())
+}
+
+func TestClusterManagerCreateEKSAResourcesHistoryError(t *testing.T) {
+	features.ClearCache()
+	ctx := context.Background()
+	tt := newTest(t)
+	tt.clusterSpec.VersionsBundle.EksD.Components = "testdata/eksa_components.yaml"
+	tt.clusterSpec.VersionsBundle.EksD.EksDReleaseUrl = "testdata/eksa_components.yaml"
+
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
+	machineConfigs := []providers.MachineConfig{}
+
+	c, m := newClusterManager(t)
+
+	m.client.EXPECT().ApplyKubeSpecFromBytesForce(ctx, tt.cluster, gomock.Any())
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, tt.cluster, gomock.Any())
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, tt.cluster, gomock.Any(), gomock.Any()).MaxTimes(2)
+	m.client.EXPECT().GetEksaCluster(ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(nil, errors.New("connection refused"))
+	tt.Expect(c.CreateEKSAResources(ctx, tt.cluster, tt.clusterSpec, datacenterConfig, machineConfigs)).To(Succeed())
+}
+
 func TestClusterManagerCreateEKSAResourcesFailure(t *testing.T) {
 	features.ClearCache()
 	ctx := context.Background()
@@ -1784,6 +2052,70 @@ func TestClusterManagerInstallCustomComponentsSuccessWithFullLifecycleAPI(t *tes
 	}
 }
 
+func TestClusterManagerInstallCustomComponentsEdgeProfile(t *testing.T) {
+	features.ClearCache()
+	ctx := context.Background()
+	tt := newTest(t)
+	tt.clusterSpec.VersionsBundle.Eksa.Components.URI = "testdata/testClusterSpec.yaml"
+	tt.clusterSpec.Cluster.Spec.ClusterProfile = v1alpha1.EdgeClusterProfile
+
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Not(gomock.Nil())).Return(nil)
+	tt.mocks.client.EXPECT().UpdateResourceRequestsInNamespace(
+		tt.ctx, "deployment", "eksa-controller-manager", map[string]string{"cpu": "20m", "memory": "64Mi"}, tt.cluster, "eksa-system",
+	).Return(nil)
+	tt.mocks.client.EXPECT().UpdateReplicasInNamespace(tt.ctx, "deployment", "eksa-controller-manager", 1, tt.cluster, "eksa-system")
+	tt.mocks.client.EXPECT().DeleteIgnoreNotFound(tt.ctx, "poddisruptionbudget", "eksa-controller-manager", "eksa-system", tt.cluster.KubeconfigFile)
+
+	for namespace, deployments := range internal.EksaDeployments {
+		for _, deployment := range deployments {
+			tt.mocks.client.EXPECT().WaitForDeployment(ctx, tt.cluster, "30m", "Available", deployment, namespace)
+		}
+	}
+	tt.mocks.provider.EXPECT().InstallCustomProviderComponents(ctx, tt.cluster.KubeconfigFile)
+	if err := tt.clusterManager.InstallCustomComponents(tt.ctx, tt.clusterSpec, tt.cluster, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.InstallCustomComponents() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerInstallCustomComponentsSingleNode(t *testing.T) {
+	features.ClearCache()
+	ctx := context.Background()
+	tt := newTest(t)
+	tt.clusterSpec.VersionsBundle.Eksa.Components.URI = "testdata/testClusterSpec.yaml"
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = nil
+
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Not(gomock.Nil())).Return(nil)
+	tt.mocks.client.EXPECT().UpdateReplicasInNamespace(tt.ctx, "deployment", "eksa-controller-manager", 1, tt.cluster, "eksa-system")
+	tt.mocks.client.EXPECT().DeleteIgnoreNotFound(tt.ctx, "poddisruptionbudget", "eksa-controller-manager", "eksa-system", tt.cluster.KubeconfigFile)
+
+	for namespace, deployments := range internal.EksaDeployments {
+		for _, deployment := range deployments {
+			tt.mocks.client.EXPECT().WaitForDeployment(ctx, tt.cluster, "30m", "Available", deployment, namespace)
+		}
+	}
+	tt.mocks.provider.EXPECT().InstallCustomProviderComponents(ctx, tt.cluster.KubeconfigFile)
+	if err := tt.clusterManager.InstallCustomComponents(tt.ctx, tt.clusterSpec, tt.cluster, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.InstallCustomComponents() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerInstallCustomComponentsSingleNodeErrorScaling(t *testing.T) {
+	features.ClearCache()
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	tt.clusterSpec.VersionsBundle.Eksa.Components.URI = "testdata/testClusterSpec.yaml"
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = nil
+
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Not(gomock.Nil())).Return(nil)
+	tt.mocks.client.EXPECT().UpdateReplicasInNamespace(tt.ctx, "deployment", "eksa-controller-manager", 1, tt.cluster, "eksa-system").Return(errors.New("forbidden"))
+
+	err := tt.clusterManager.InstallCustomComponents(tt.ctx, tt.clusterSpec, tt.cluster, tt.mocks.provider)
+	if err == nil || err.Error() != "scaling eks-a controller to a single replica: forbidden" {
+		t.Errorf("ClusterManager.InstallCustomComponents() error = %v, want scaling error", err)
+	}
+}
+
 func TestClusterManagerInstallCustomComponentsErrorReadingManifest(t *testing.T) {
 	tt := newTest(t)
 	tt.clusterSpec.VersionsBundle.Eksa.Components.URI = "fake.yaml"
@@ -2067,3 +2399,34 @@ func TestCreateAwsIamAuthCaSecretSuccess(t *testing.T) {
 	err := tt.clusterManager.CreateAwsIamAuthCaSecret(tt.ctx, tt.cluster, tt.clusterName)
 	tt.Expect(err).To(BeNil())
 }
+
+func TestClusterManagerCAPIClusterExists(t *testing.T) {
+	tt := newTest(t)
+	tt.mocks.client.EXPECT().GetClusters(tt.ctx, tt.cluster).Return([]types.CAPICluster{
+		{Metadata: types.Metadata{Name: "other"}},
+		{Metadata: types.Metadata{Name: "workload"}},
+	}, nil)
+
+	exists, err := tt.clusterManager.CAPIClusterExists(tt.ctx, tt.cluster, "workload")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(exists).To(BeTrue())
+}
+
+func TestClusterManagerCAPIClusterExistsNotFound(t *testing.T) {
+	tt := newTest(t)
+	tt.mocks.client.EXPECT().GetClusters(tt.ctx, tt.cluster).Return([]types.CAPICluster{
+		{Metadata: types.Metadata{Name: "other"}},
+	}, nil)
+
+	exists, err := tt.clusterManager.CAPIClusterExists(tt.ctx, tt.cluster, "workload")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(exists).To(BeFalse())
+}
+
+func TestClusterManagerCAPIClusterExistsError(t *testing.T) {
+	tt := newTest(t)
+	tt.mocks.client.EXPECT().GetClusters(tt.ctx, tt.cluster).Return(nil, errors.New("connection refused"))
+
+	_, err := tt.clusterManager.CAPIClusterExists(tt.ctx, tt.cluster, "workload")
+	tt.Expect(err).To(MatchError("connection refused"))
+}
@@ -0,0 +1,12 @@
+package clustermanager
+
+import (
+	"context"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func (c *ClusterManager) UpgradeNodesInPlace(ctx context.Context, managementCluster, workloadCluster *types.Cluster, currentSpec, newSpec *cluster.Spec, cpContent, mdContent []byte) error {
+	return c.upgradeNodesInPlace(ctx, managementCluster, workloadCluster, currentSpec, newSpec, cpContent, mdContent)
+}
@@ -0,0 +1,432 @@
+package clustermanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/wait"
+)
+
+const (
+	defaultNodeUpgradeTimeout      = 20 * time.Minute
+	defaultNodeUpgradePollInterval = 10 * time.Second
+)
+
+var (
+	capiClusterResourceType       = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
+	machineResourceType           = fmt.Sprintf("machines.%s", clusterv1.GroupVersion.Group)
+	machineSetResourceType        = fmt.Sprintf("machinesets.%s", clusterv1.GroupVersion.Group)
+	machineDeploymentResourceType = fmt.Sprintf("machinedeployments.%s", clusterv1.GroupVersion.Group)
+	kubeadmControlPlaneType       = fmt.Sprintf("kubeadmcontrolplanes.%s", controlplanev1.GroupVersion.Group)
+	kubeadmConfigResourceType     = fmt.Sprintf("kubeadmconfigs.%s", bootstrapv1.GroupVersion.Group)
+)
+
+// applyControlPlaneSpec applies the control plane CAPI spec for an upgrade. When any of the nodes upgrade
+// in place, it also applies the machine deployments spec, since all of them are upgraded at once.
+func (c *ClusterManager) applyControlPlaneSpec(ctx context.Context, managementCluster, workloadCluster *types.Cluster, currentSpec, newSpec *cluster.Spec, cpContent, mdContent []byte) error {
+	if upgradesNodesInPlace(currentSpec, newSpec) {
+		if err := c.upgradeNodesInPlace(ctx, managementCluster, workloadCluster, currentSpec, newSpec, cpContent, mdContent); err != nil {
+			return fmt.Errorf("upgrading nodes in place: %v", err)
+		}
+		return nil
+	}
+
+	if err := c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, cpContent, constants.EksaSystemNamespace); err != nil {
+		return fmt.Errorf("applying capi control plane spec: %v", err)
+	}
+
+	return nil
+}
+
+// upgradesNodesInPlace returns true when any of the nodes of the cluster should be upgraded in place.
+func upgradesNodesInPlace(currentSpec, newSpec *cluster.Spec) bool {
+	if nodeupgrader.ControlPlaneUpgradesInPlace(currentSpec, newSpec) {
+		return true
+	}
+
+	for _, group := range newSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if nodeupgrader.WorkerNodeGroupUpgradesInPlace(currentSpec, newSpec, group) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// upgradeNodesInPlace applies the CAPI specs with the cluster reconciliation paused and upgrades the
+// Kubernetes components of the nodes with the InPlace upgrade rollout strategy. Then it updates their
+// machines to match the new specs, so CAPI doesn't replace them when the reconciliation resumes.
+// The nodes that can't be upgraded in place, because their machines need other changes, are left
+// for CAPI to roll out, same as when the upgrade fails.
+func (c *ClusterManager) upgradeNodesInPlace(ctx context.Context, managementCluster, workloadCluster *types.Cluster, currentSpec, newSpec *cluster.Spec, cpContent, mdContent []byte) (err error) {
+	if newSpec.VersionsBundle.Upgrader.Upgrader.URI == "" {
+		return fmt.Errorf("bundle for Kubernetes %s doesn't include the node upgrader, the InPlace upgrade rollout strategy requires a newer EKS-A version", newSpec.Cluster.Spec.KubernetesVersion)
+	}
+
+	clusterName := newSpec.Cluster.Name
+	currentKCP := &controlplanev1.KubeadmControlPlane{}
+	if err = c.clusterClient.GetObject(ctx, kubeadmControlPlaneType, clusterName, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, currentKCP); err != nil {
+		return fmt.Errorf("getting kubeadm control plane: %v", err)
+	}
+
+	logger.V(3).Info("Pausing cluster reconciliation to upgrade nodes in place")
+	if err = c.setCAPIClusterPaused(ctx, managementCluster, clusterName, true); err != nil {
+		return err
+	}
+	defer func() {
+		logger.V(3).Info("Resuming cluster reconciliation")
+		if resumeErr := c.setCAPIClusterPaused(ctx, managementCluster, clusterName, false); resumeErr != nil && err == nil {
+			err = resumeErr
+		}
+	}()
+
+	return c.applyAndUpgradeNodesInPlace(ctx, managementCluster, workloadCluster, currentSpec, newSpec, currentKCP, cpContent, mdContent)
+}
+
+func (c *ClusterManager) applyAndUpgradeNodesInPlace(ctx context.Context, managementCluster, workloadCluster *types.Cluster, currentSpec, newSpec *cluster.Spec, currentKCP *controlplanev1.KubeadmControlPlane, cpContent, mdContent []byte) error {
+	if err := c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, cpContent, constants.EksaSystemNamespace); err != nil {
+		return fmt.Errorf("applying capi control plane spec: %v", err)
+	}
+	if err := c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, mdContent, constants.EksaSystemNamespace); err != nil {
+		return fmt.Errorf("applying capi machine deployment spec: %v", err)
+	}
+
+	image := newSpec.VersionsBundle.Upgrader.Upgrader.VersionedImage()
+	if nodeupgrader.ControlPlaneUpgradesInPlace(currentSpec, newSpec) {
+		if err := c.upgradeControlPlaneInPlace(ctx, managementCluster, workloadCluster, currentKCP, image); err != nil {
+			return err
+		}
+	}
+
+	for _, group := range newSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if !nodeupgrader.WorkerNodeGroupUpgradesInPlace(currentSpec, newSpec, group) {
+			continue
+		}
+		if err := c.upgradeWorkerNodeGroupInPlace(ctx, managementCluster, workloadCluster, fmt.Sprintf("%s-%s", newSpec.Cluster.Name, group.Name), image); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterManager) upgradeControlPlaneInPlace(ctx context.Context, managementCluster, workloadCluster *types.Cluster, currentKCP *controlplanev1.KubeadmControlPlane, image string) error {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := c.clusterClient.GetObject(ctx, kubeadmControlPlaneType, currentKCP.Name, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, kcp); err != nil {
+		return fmt.Errorf("getting kubeadm control plane: %v", err)
+	}
+
+	if !controlPlaneOnlyChangesVersion(currentKCP, kcp) {
+		logger.Info("Control plane configuration changed, falling back to a rolling upgrade for the control plane nodes")
+		return nil
+	}
+
+	machines, err := c.listMachines(ctx, managementCluster, func(m clusterv1.Machine) bool {
+		_, ok := m.Labels[clusterv1.MachineControlPlaneLabelName]
+		return ok && m.Labels[clusterv1.ClusterLabelName] == kcp.Labels[clusterv1.ClusterLabelName]
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Upgrading control plane nodes in place", "version", kcp.Spec.Version)
+	return c.upgradeControlPlaneMachinesInPlace(ctx, managementCluster, workloadCluster, machines, kcp, image)
+}
+
+func (c *ClusterManager) upgradeControlPlaneMachinesInPlace(ctx context.Context, managementCluster, workloadCluster *types.Cluster, machines []clusterv1.Machine, kcp *controlplanev1.KubeadmControlPlane, image string) error {
+	// kubeadm upgrade apply runs in the first node only, unless a previous attempt already upgraded it.
+	firstUpgraded := false
+	for _, m := range machines {
+		firstUpgraded = firstUpgraded || machineHasVersion(m, kcp.Spec.Version)
+	}
+
+	for i := range machines {
+		m := &machines[i]
+		if machineHasVersion(*m, kcp.Spec.Version) {
+			continue
+		}
+
+		nodeName, err := machineNodeName(m)
+		if err != nil {
+			return err
+		}
+
+		pod := nodeupgrader.UpgradeRestControlPlanePod(nodeName, image)
+		if !firstUpgraded {
+			pod = nodeupgrader.UpgradeFirstControlPlanePod(nodeName, image, kcp.Spec.Version)
+			firstUpgraded = true
+		}
+		if err = c.runNodeUpgrader(ctx, workloadCluster, pod); err != nil {
+			return err
+		}
+
+		if err = c.updateControlPlaneMachine(ctx, managementCluster, m, kcp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterManager) upgradeWorkerNodeGroupInPlace(ctx context.Context, managementCluster, workloadCluster *types.Cluster, machineDeploymentName, image string) error {
+	md := &clusterv1.MachineDeployment{}
+	if err := c.clusterClient.GetObject(ctx, machineDeploymentResourceType, machineDeploymentName, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, md); err != nil {
+		return fmt.Errorf("getting machine deployment %s: %v", machineDeploymentName, err)
+	}
+
+	ms, err := c.inPlaceMachineSet(ctx, managementCluster, md)
+	if err != nil {
+		return err
+	}
+	if ms == nil {
+		logger.Info("Worker node group configuration changed, falling back to a rolling upgrade", "machineDeployment", md.Name)
+		return nil
+	}
+
+	machines, err := c.listMachines(ctx, managementCluster, func(m clusterv1.Machine) bool {
+		return metav1.IsControlledBy(&m, ms)
+	})
+	if err != nil {
+		return err
+	}
+
+	version := *md.Spec.Template.Spec.Version
+	logger.Info("Upgrading worker nodes in place", "machineDeployment", md.Name, "version", version)
+	if err = c.upgradeWorkerMachinesInPlace(ctx, managementCluster, workloadCluster, machines, version, image); err != nil {
+		return err
+	}
+
+	// With the same template as the machine deployment, the machine set stays as the current one
+	// instead of being replaced by a new one.
+	ms.Spec.Template.Spec.Version = &version
+	if err = c.update(ctx, managementCluster, ms); err != nil {
+		return fmt.Errorf("updating machine set %s: %v", ms.Name, err)
+	}
+
+	return nil
+}
+
+func (c *ClusterManager) upgradeWorkerMachinesInPlace(ctx context.Context, managementCluster, workloadCluster *types.Cluster, machines []clusterv1.Machine, version, image string) error {
+	for i := range machines {
+		m := &machines[i]
+		if machineHasVersion(*m, version) {
+			continue
+		}
+
+		nodeName, err := machineNodeName(m)
+		if err != nil {
+			return err
+		}
+		if err = c.runNodeUpgrader(ctx, workloadCluster, nodeupgrader.UpgradeWorkerPod(nodeName, image)); err != nil {
+			return err
+		}
+
+		m.Spec.Version = &version
+		if err = c.update(ctx, managementCluster, m); err != nil {
+			return fmt.Errorf("updating machine %s: %v", m.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// inPlaceMachineSet returns the machine set with the machines of the machine deployment if its template
+// only differs from the machine deployment one in the Kubernetes version. Otherwise, the machines need
+// to be replaced anyway, so it returns nil.
+func (c *ClusterManager) inPlaceMachineSet(ctx context.Context, managementCluster *types.Cluster, md *clusterv1.MachineDeployment) (*clusterv1.MachineSet, error) {
+	machineSets := &clusterv1.MachineSetList{}
+	if err := c.clusterClient.ListObjects(ctx, machineSetResourceType, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, machineSets); err != nil {
+		return nil, fmt.Errorf("listing machine sets: %v", err)
+	}
+
+	var current []*clusterv1.MachineSet
+	for i := range machineSets.Items {
+		ms := &machineSets.Items[i]
+		if metav1.IsControlledBy(ms, md) && ms.Spec.Replicas != nil && *ms.Spec.Replicas > 0 {
+			current = append(current, ms)
+		}
+	}
+
+	// A machine deployment in the middle of a rollout has its machines split in more than one set.
+	if len(current) != 1 || md.Spec.Template.Spec.Version == nil {
+		return nil, nil
+	}
+
+	msTemplate := current[0].Spec.Template.DeepCopy()
+	mdTemplate := md.Spec.Template.DeepCopy()
+	delete(msTemplate.Labels, clusterv1.MachineDeploymentUniqueLabel)
+	delete(mdTemplate.Labels, clusterv1.MachineDeploymentUniqueLabel)
+	msTemplate.Spec.Version = mdTemplate.Spec.Version
+	if !apiequality.Semantic.DeepEqual(msTemplate, mdTemplate) {
+		return nil, nil
+	}
+
+	return current[0], nil
+}
+
+// updateControlPlaneMachine makes the machine match the kubeadm control plane, so it isn't rolled out.
+// The kubeadm control plane compares the machine version, the ClusterConfiguration annotation and
+// the kubeadm config of the machine.
+func (c *ClusterManager) updateControlPlaneMachine(ctx context.Context, managementCluster *types.Cluster, m *clusterv1.Machine, kcp *controlplanev1.KubeadmControlPlane) error {
+	if m.Spec.Bootstrap.ConfigRef != nil {
+		config := &bootstrapv1.KubeadmConfig{}
+		if err := c.clusterClient.GetObject(ctx, kubeadmConfigResourceType, m.Spec.Bootstrap.ConfigRef.Name, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, config); err != nil {
+			return fmt.Errorf("getting kubeadm config %s: %v", m.Spec.Bootstrap.ConfigRef.Name, err)
+		}
+
+		config.Spec = kubeadmConfigSpecFromControlPlane(config.Spec, kcp)
+		if err := c.update(ctx, managementCluster, config); err != nil {
+			return fmt.Errorf("updating kubeadm config %s: %v", config.Name, err)
+		}
+	}
+
+	clusterConfig, err := json.Marshal(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration)
+	if err != nil {
+		return fmt.Errorf("marshalling cluster configuration: %v", err)
+	}
+	if m.Annotations == nil {
+		m.Annotations = map[string]string{}
+	}
+	m.Annotations[controlplanev1.KubeadmClusterConfigurationAnnotation] = string(clusterConfig)
+	m.Spec.Version = &kcp.Spec.Version
+
+	if err = c.update(ctx, managementCluster, m); err != nil {
+		return fmt.Errorf("updating machine %s: %v", m.Name, err)
+	}
+
+	return nil
+}
+
+// runNodeUpgrader runs the upgrader pod in the workload cluster until it completes.
+func (c *ClusterManager) runNodeUpgrader(ctx context.Context, workloadCluster *types.Cluster, pod *corev1.Pod) error {
+	logger.V(3).Info("Upgrading node in place", "node", pod.Spec.NodeName)
+
+	// Remove the pod left by a previous attempt, if any, so it runs again.
+	if err := c.clusterClient.DeleteIgnoreNotFound(ctx, "pod", pod.Name, pod.Namespace, workloadCluster.KubeconfigFile); err != nil {
+		return err
+	}
+	if err := c.clusterClient.Apply(ctx, workloadCluster.KubeconfigFile, pod); err != nil {
+		return fmt.Errorf("creating upgrader pod for node %s: %v", pod.Spec.NodeName, err)
+	}
+
+	waiter := wait.New(c.nodeUpgradeTimeout, wait.WithInterval(c.nodeUpgradePollInterval), wait.WithProgress(wait.LogProgress(6)))
+	err := waiter.For(ctx, fmt.Sprintf("upgrade of node %s", pod.Spec.NodeName), func(ctx context.Context) (wait.Status, error) {
+		current := &corev1.Pod{}
+		if err := c.clusterClient.GetObject(ctx, "pod", pod.Name, pod.Namespace, workloadCluster.KubeconfigFile, current); err != nil {
+			return wait.Status{}, err
+		}
+
+		switch current.Status.Phase {
+		case corev1.PodSucceeded:
+			return wait.Status{Done: true}, nil
+		case corev1.PodFailed:
+			return wait.Status{}, wait.Failed(fmt.Errorf("upgrader pod %s failed: %s", pod.Name, current.Status.Message))
+		default:
+			return wait.Status{Message: fmt.Sprintf("upgrader pod is %s", current.Status.Phase)}, nil
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("upgrading node %s in place: %v", pod.Spec.NodeName, err)
+	}
+
+	return c.clusterClient.DeleteIgnoreNotFound(ctx, "pod", pod.Name, pod.Namespace, workloadCluster.KubeconfigFile)
+}
+
+// setCAPIClusterPaused pauses or resumes the reconciliation of all the CAPI objects of the cluster.
+func (c *ClusterManager) setCAPIClusterPaused(ctx context.Context, managementCluster *types.Cluster, clusterName string, paused bool) error {
+	capiCluster := &clusterv1.Cluster{}
+	if err := c.clusterClient.GetObject(ctx, capiClusterResourceType, clusterName, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, capiCluster); err != nil {
+		return fmt.Errorf("getting capi cluster: %v", err)
+	}
+
+	capiCluster.Spec.Paused = paused
+	if err := c.update(ctx, managementCluster, capiCluster); err != nil {
+		return fmt.Errorf("setting capi cluster paused to %t: %v", paused, err)
+	}
+
+	return nil
+}
+
+func (c *ClusterManager) listMachines(ctx context.Context, managementCluster *types.Cluster, include func(clusterv1.Machine) bool) ([]clusterv1.Machine, error) {
+	machineList := &clusterv1.MachineList{}
+	if err := c.clusterClient.ListObjects(ctx, machineResourceType, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, machineList); err != nil {
+		return nil, fmt.Errorf("listing machines: %v", err)
+	}
+
+	var machines []clusterv1.Machine
+	for _, m := range machineList.Items {
+		if include(m) {
+			machines = append(machines, m)
+		}
+	}
+	sort.Slice(machines, func(i, j int) bool { return machines[i].Name < machines[j].Name })
+
+	return machines, nil
+}
+
+// update writes back an object read from the cluster. The managed fields are dropped,
+// since the server doesn't accept them in updates.
+func (c *ClusterManager) update(ctx context.Context, managementCluster *types.Cluster, obj runtimeclient.Object) error {
+	obj.SetManagedFields(nil)
+	return c.clusterClient.Apply(ctx, managementCluster.KubeconfigFile, obj)
+}
+
+// controlPlaneOnlyChangesVersion returns true when the new kubeadm control plane only changes the
+// Kubernetes version and ClusterConfiguration, the parts the node upgrader takes care of.
+func controlPlaneOnlyChangesVersion(current, updated *controlplanev1.KubeadmControlPlane) bool {
+	currentConfig := current.Spec.KubeadmConfigSpec.DeepCopy()
+	updatedConfig := updated.Spec.KubeadmConfigSpec.DeepCopy()
+	currentConfig.ClusterConfiguration = nil
+	updatedConfig.ClusterConfiguration = nil
+
+	return current.Spec.MachineTemplate.InfrastructureRef.Name == updated.Spec.MachineTemplate.InfrastructureRef.Name &&
+		apiequality.Semantic.DeepEqual(currentConfig, updatedConfig)
+}
+
+// kubeadmConfigSpecFromControlPlane returns the kubeadm config spec of a control plane machine as the
+// kubeadm control plane would generate it now. The machine keeps its own ClusterConfiguration, which
+// is compared through an annotation instead, and join discovery.
+func kubeadmConfigSpecFromControlPlane(machineSpec bootstrapv1.KubeadmConfigSpec, kcp *controlplanev1.KubeadmControlPlane) bootstrapv1.KubeadmConfigSpec {
+	spec := kcp.Spec.KubeadmConfigSpec.DeepCopy()
+	spec.ClusterConfiguration = machineSpec.ClusterConfiguration
+
+	// Only the first control plane machine has an init configuration, the rest join.
+	if machineSpec.InitConfiguration == nil {
+		spec.InitConfiguration = nil
+	}
+	if machineSpec.JoinConfiguration == nil {
+		spec.JoinConfiguration = nil
+	} else if spec.JoinConfiguration != nil {
+		spec.JoinConfiguration.Discovery = machineSpec.JoinConfiguration.Discovery
+	}
+
+	return *spec
+}
+
+func machineHasVersion(m clusterv1.Machine, version string) bool {
+	return m.Spec.Version != nil && *m.Spec.Version == version
+}
+
+func machineNodeName(m *clusterv1.Machine) (string, error) {
+	if m.Status.NodeRef == nil {
+		return "", fmt.Errorf("machine %s doesn't have a node to upgrade", m.Name)
+	}
+
+	return m.Status.NodeRef.Name, nil
+}
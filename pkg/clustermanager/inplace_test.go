@@ -0,0 +1,361 @@
+package clustermanager_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	currentKubeVersion = "v1.24.13-eks-1-24-13"
+	newKubeVersion     = "v1.24.14-eks-1-24-14"
+	upgraderImage      = "public.ecr.aws/eks-anywhere/upgrader:v1-24-14-eks-a-1"
+)
+
+type inPlaceTest struct {
+	*testSetup
+	workload    *types.Cluster
+	currentSpec *cluster.Spec
+	newSpec     *cluster.Spec
+	currentKCP  *controlplanev1.KubeadmControlPlane
+	newKCP      *controlplanev1.KubeadmControlPlane
+	md          *clusterv1.MachineDeployment
+	machineSet  *clusterv1.MachineSet
+	machines    []clusterv1.Machine
+	podPhase    corev1.PodPhase
+	applied     []runtime.Object
+}
+
+func newInPlaceTest(t *testing.T) *inPlaceTest {
+	tt := &inPlaceTest{
+		testSetup:   newTest(t, clustermanager.WithNodeUpgradePollInterval(time.Millisecond)),
+		workload:    &types.Cluster{Name: "cluster-name", KubeconfigFile: "cluster-name.kubeconfig"},
+		currentSpec: inPlaceClusterSpec(currentKubeVersion),
+		newSpec:     inPlaceClusterSpec(newKubeVersion),
+		currentKCP:  kubeadmControlPlane(currentKubeVersion),
+		newKCP:      kubeadmControlPlane(newKubeVersion),
+		podPhase:    corev1.PodSucceeded,
+	}
+
+	tt.md = &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-name-md-0", Namespace: constants.EksaSystemNamespace, UID: "md-uid"},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{Spec: clusterv1.MachineSpec{Version: stringPtr(newKubeVersion)}},
+		},
+	}
+	tt.machineSet = &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "cluster-name-md-0-abcde",
+			Namespace:       constants.EksaSystemNamespace,
+			UID:             "ms-uid",
+			OwnerReferences: []metav1.OwnerReference{controllerRef("MachineDeployment", tt.md.Name, tt.md.UID)},
+		},
+		Spec: clusterv1.MachineSetSpec{
+			Replicas: int32Ptr(1),
+			Template: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{Labels: map[string]string{clusterv1.MachineDeploymentUniqueLabel: "abcde"}},
+				Spec:       clusterv1.MachineSpec{Version: stringPtr(currentKubeVersion)},
+			},
+		},
+	}
+	tt.machines = []clusterv1.Machine{
+		controlPlaneMachine("cluster-name-cp-b", "cp-node-b"),
+		controlPlaneMachine("cluster-name-cp-a", "cp-node-a"),
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "cluster-name-md-0-abcde-xyz",
+				Namespace:       constants.EksaSystemNamespace,
+				OwnerReferences: []metav1.OwnerReference{controllerRef("MachineSet", tt.machineSet.Name, tt.machineSet.UID)},
+			},
+			Spec:   clusterv1.MachineSpec{Version: stringPtr(currentKubeVersion)},
+			Status: clusterv1.MachineStatus{NodeRef: &corev1.ObjectReference{Name: "worker-node"}},
+		},
+	}
+
+	return tt
+}
+
+func (tt *inPlaceTest) expectClusterCalls() {
+	client := tt.mocks.client
+	kubeconfig := tt.cluster.KubeconfigFile
+	kcpType := "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io"
+
+	gomock.InOrder(
+		client.EXPECT().GetObject(tt.ctx, kcpType, "cluster-name", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _, _ string, obj *controlplanev1.KubeadmControlPlane) error {
+				*obj = *tt.currentKCP.DeepCopy()
+				return nil
+			}),
+		client.EXPECT().GetObject(tt.ctx, kcpType, "cluster-name", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _, _ string, obj *controlplanev1.KubeadmControlPlane) error {
+				*obj = *tt.newKCP.DeepCopy()
+				return nil
+			}).MaxTimes(1),
+	)
+	client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, gomock.Any(), constants.EksaSystemNamespace).Times(2)
+	client.EXPECT().GetObject(tt.ctx, "clusters.cluster.x-k8s.io", "cluster-name", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, name, namespace, _ string, obj *clusterv1.Cluster) error {
+			*obj = clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+			return nil
+		}).Times(2)
+	client.EXPECT().GetObject(tt.ctx, "kubeadmconfigs.bootstrap.cluster.x-k8s.io", gomock.Any(), constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, name, namespace, _ string, obj *bootstrapv1.KubeadmConfig) error {
+			*obj = bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{ClusterName: "cluster-name"},
+					JoinConfiguration: &bootstrapv1.JoinConfiguration{
+						Discovery: bootstrapv1.Discovery{BootstrapToken: &bootstrapv1.BootstrapTokenDiscovery{Token: "token"}},
+					},
+				},
+			}
+			return nil
+		}).AnyTimes()
+	client.EXPECT().GetObject(tt.ctx, "machinedeployments.cluster.x-k8s.io", tt.md.Name, constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj *clusterv1.MachineDeployment) error {
+			*obj = *tt.md.DeepCopy()
+			return nil
+		}).AnyTimes()
+	client.EXPECT().ListObjects(tt.ctx, "machinesets.cluster.x-k8s.io", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _ string, obj *clusterv1.MachineSetList) error {
+			obj.Items = []clusterv1.MachineSet{*tt.machineSet.DeepCopy()}
+			return nil
+		}).AnyTimes()
+	client.EXPECT().ListObjects(tt.ctx, "machines.cluster.x-k8s.io", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _ string, obj *clusterv1.MachineList) error {
+			for _, m := range tt.machines {
+				obj.Items = append(obj.Items, *m.DeepCopy())
+			}
+			return nil
+		}).AnyTimes()
+	client.EXPECT().GetObject(gomock.Any(), "pod", gomock.Any(), constants.EksaSystemNamespace, tt.workload.KubeconfigFile, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj *corev1.Pod) error {
+			obj.Status.Phase = tt.podPhase
+			return nil
+		}).AnyTimes()
+	client.EXPECT().DeleteIgnoreNotFound(tt.ctx, "pod", gomock.Any(), constants.EksaSystemNamespace, tt.workload.KubeconfigFile).AnyTimes()
+	client.EXPECT().Apply(tt.ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, obj runtime.Object) error {
+			tt.applied = append(tt.applied, obj.DeepCopyObject())
+			return nil
+		}).AnyTimes()
+}
+
+func (tt *inPlaceTest) upgradeNodesInPlace() error {
+	return tt.clusterManager.UpgradeNodesInPlace(tt.ctx, tt.cluster, tt.workload, tt.currentSpec, tt.newSpec, []byte("cp"), []byte("md"))
+}
+
+func (tt *inPlaceTest) appliedPods() []*corev1.Pod {
+	var pods []*corev1.Pod
+	for _, obj := range tt.applied {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+func (tt *inPlaceTest) appliedMachines() map[string]*clusterv1.Machine {
+	machines := map[string]*clusterv1.Machine{}
+	for _, obj := range tt.applied {
+		if m, ok := obj.(*clusterv1.Machine); ok {
+			machines[m.Name] = m
+		}
+	}
+	return machines
+}
+
+func (tt *inPlaceTest) expectClusterPausedDuringUpgrade() {
+	tt.Expect(tt.applied).ToNot(BeEmpty())
+	first, ok := tt.applied[0].(*clusterv1.Cluster)
+	tt.Expect(ok).To(BeTrue(), "the cluster should be paused first")
+	tt.Expect(first.Spec.Paused).To(BeTrue())
+	last, ok := tt.applied[len(tt.applied)-1].(*clusterv1.Cluster)
+	tt.Expect(ok).To(BeTrue(), "the cluster should be resumed last")
+	tt.Expect(last.Spec.Paused).To(BeFalse())
+}
+
+func TestClusterManagerUpgradeNodesInPlace(t *testing.T) {
+	tt := newInPlaceTest(t)
+	tt.expectClusterCalls()
+
+	tt.Expect(tt.upgradeNodesInPlace()).To(Succeed())
+	tt.expectClusterPausedDuringUpgrade()
+
+	pods := tt.appliedPods()
+	tt.Expect(pods).To(HaveLen(3))
+	tt.Expect(pods[0].Spec.NodeName).To(Equal("cp-node-a"))
+	tt.Expect(pods[0].Spec.InitContainers[1].Args).To(ContainElements("kubeadm_in_first_cp", newKubeVersion))
+	tt.Expect(pods[0].Spec.InitContainers[1].Image).To(Equal(upgraderImage))
+	tt.Expect(pods[1].Spec.NodeName).To(Equal("cp-node-b"))
+	tt.Expect(pods[1].Spec.InitContainers[1].Args).To(ContainElement("kubeadm_in_rest_cp"))
+	tt.Expect(pods[2].Spec.NodeName).To(Equal("worker-node"))
+	tt.Expect(pods[2].Spec.InitContainers[1].Args).To(ContainElement("kubeadm_in_worker"))
+
+	machines := tt.appliedMachines()
+	tt.Expect(machines).To(HaveLen(3))
+	for _, m := range machines {
+		tt.Expect(*m.Spec.Version).To(Equal(newKubeVersion))
+	}
+	clusterConfig, err := json.Marshal(tt.newKCP.Spec.KubeadmConfigSpec.ClusterConfiguration)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(machines["cluster-name-cp-a"].Annotations).To(HaveKeyWithValue(controlplanev1.KubeadmClusterConfigurationAnnotation, string(clusterConfig)))
+
+	for _, obj := range tt.applied {
+		switch o := obj.(type) {
+		case *bootstrapv1.KubeadmConfig:
+			tt.Expect(o.Spec.Files).To(Equal(tt.newKCP.Spec.KubeadmConfigSpec.Files))
+			tt.Expect(o.Spec.ClusterConfiguration.ClusterName).To(Equal("cluster-name"))
+			tt.Expect(o.Spec.InitConfiguration).To(BeNil())
+			tt.Expect(o.Spec.JoinConfiguration.Discovery.BootstrapToken.Token).To(Equal("token"))
+		case *clusterv1.MachineSet:
+			tt.Expect(*o.Spec.Template.Spec.Version).To(Equal(newKubeVersion))
+		}
+	}
+}
+
+func TestClusterManagerUpgradeNodesInPlaceSkipsUpgradedMachines(t *testing.T) {
+	tt := newInPlaceTest(t)
+	tt.machines[1].Spec.Version = stringPtr(newKubeVersion)
+	tt.expectClusterCalls()
+
+	tt.Expect(tt.upgradeNodesInPlace()).To(Succeed())
+
+	pods := tt.appliedPods()
+	tt.Expect(pods).To(HaveLen(2))
+	tt.Expect(pods[0].Spec.NodeName).To(Equal("cp-node-b"))
+	tt.Expect(pods[0].Spec.InitContainers[1].Args).To(ContainElement("kubeadm_in_rest_cp"))
+	tt.Expect(pods[1].Spec.NodeName).To(Equal("worker-node"))
+}
+
+func TestClusterManagerUpgradeNodesInPlaceControlPlaneChanged(t *testing.T) {
+	tt := newInPlaceTest(t)
+	tt.newKCP.Spec.MachineTemplate.InfrastructureRef.Name = "cluster-name-control-plane-template-2"
+	tt.expectClusterCalls()
+
+	tt.Expect(tt.upgradeNodesInPlace()).To(Succeed())
+	tt.expectClusterPausedDuringUpgrade()
+
+	pods := tt.appliedPods()
+	tt.Expect(pods).To(HaveLen(1))
+	tt.Expect(pods[0].Spec.NodeName).To(Equal("worker-node"))
+}
+
+func TestClusterManagerUpgradeNodesInPlaceMachineDeploymentRollingOut(t *testing.T) {
+	tt := newInPlaceTest(t)
+	tt.newSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = nil
+	tt.machineSet.Spec.Template.Spec.FailureDomain = stringPtr("rack-1")
+	tt.expectClusterCalls()
+
+	tt.Expect(tt.upgradeNodesInPlace()).To(Succeed())
+	tt.expectClusterPausedDuringUpgrade()
+	tt.Expect(tt.appliedPods()).To(BeEmpty())
+}
+
+func TestClusterManagerUpgradeNodesInPlaceUpgraderFailed(t *testing.T) {
+	tt := newInPlaceTest(t)
+	tt.podPhase = corev1.PodFailed
+	tt.expectClusterCalls()
+
+	tt.Expect(tt.upgradeNodesInPlace()).To(MatchError(ContainSubstring("upgrading node cp-node-a in place")))
+	tt.expectClusterPausedDuringUpgrade()
+
+	for _, obj := range tt.applied {
+		_, isMachine := obj.(*clusterv1.Machine)
+		tt.Expect(isMachine).To(BeFalse(), "machines of failed upgrades should not be updated")
+	}
+}
+
+func TestClusterManagerUpgradeNodesInPlaceMissingUpgraderImage(t *testing.T) {
+	tt := newInPlaceTest(t)
+	tt.newSpec.VersionsBundle.Upgrader.Upgrader.URI = ""
+
+	tt.Expect(tt.upgradeNodesInPlace()).To(MatchError(ContainSubstring("doesn't include the node upgrader")))
+}
+
+func inPlaceClusterSpec(kubeVersion string) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "cluster-name"
+		s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube124
+		s.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+			Type: v1alpha1.InPlaceStrategyType,
+		}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{
+			Name:                   "md-0",
+			UpgradeRolloutStrategy: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType},
+		}}
+		s.VersionsBundle.KubeDistro.Kubernetes.Tag = kubeVersion
+		s.VersionsBundle.Upgrader.Upgrader.URI = upgraderImage
+	})
+}
+
+func kubeadmControlPlane(kubeVersion string) *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-name",
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1.ClusterLabelName: "cluster-name"},
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: kubeVersion,
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: corev1.ObjectReference{Name: "cluster-name-control-plane-template-1"},
+			},
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: &bootstrapv1.ClusterConfiguration{KubernetesVersion: kubeVersion},
+				InitConfiguration:    &bootstrapv1.InitConfiguration{},
+				JoinConfiguration:    &bootstrapv1.JoinConfiguration{},
+				Files:                []bootstrapv1.File{{Path: "/etc/kubernetes/manifests/kube-vip.yaml"}},
+			},
+		},
+	}
+}
+
+func controlPlaneMachine(name, nodeName string) clusterv1.Machine {
+	return clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				clusterv1.ClusterLabelName:             "cluster-name",
+				clusterv1.MachineControlPlaneLabelName: "",
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			Version:   stringPtr(currentKubeVersion),
+			Bootstrap: clusterv1.Bootstrap{ConfigRef: &corev1.ObjectReference{Name: name}},
+		},
+		Status: clusterv1.MachineStatus{NodeRef: &corev1.ObjectReference{Name: nodeName}},
+	}
+}
+
+func controllerRef(kind, name string, uid apitypes.UID) metav1.OwnerReference {
+	controller := true
+	return metav1.OwnerReference{Kind: kind, Name: name, UID: uid, Controller: &controller}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,268 @@
+package clustermanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/wait"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	defaultKubernetesWaitPollInterval = 5 * time.Second
+	// kubernetesClientFieldManager owns the fields of the objects applied by the CLI.
+	kubernetesClientFieldManager = "eks-a-cli"
+)
+
+// KubernetesClientFactory builds typed kubernetes API clients authenticated with a kubeconfig file.
+type KubernetesClientFactory interface {
+	BuildClientFromKubeconfig(kubeconfig string) (runtimeclient.Client, error)
+}
+
+// KubernetesClusterClient is a ClusterClient that talks directly to the kube API server instead of
+// shelling out to kubectl for a subset of the operations: getting the eks-a Cluster, Bundles, eks-d
+// Release and the GitOps, Flux, OIDC and AWS IAM configs, getting and listing typed objects, applying
+// typed objects and waiting for the CAPI cluster conditions. This preserves the API errors returned by
+// the server, so callers can inspect them with the apimachinery helpers. The rest of the operations are
+// delegated to the wrapped ClusterClient, which still shells out to kubectl.
+type KubernetesClusterClient struct {
+	ClusterClient
+	clients      KubernetesClientFactory
+	pollInterval time.Duration
+}
+
+// NewKubernetesClusterClient returns a ClusterClient that uses the typed clients built by
+// clientFactory for the operations listed in KubernetesClusterClient and clusterClient for the rest.
+func NewKubernetesClusterClient(clusterClient ClusterClient, clientFactory KubernetesClientFactory) *KubernetesClusterClient {
+	return &KubernetesClusterClient{
+		ClusterClient: clusterClient,
+		clients:       clientFactory,
+		pollInterval:  defaultKubernetesWaitPollInterval,
+	}
+}
+
+// GetEksaCluster returns the eks-a Cluster with the given name, searching in all namespaces.
+func (c *KubernetesClusterClient) GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error) {
+	k, err := c.clients.BuildClientFromKubeconfig(cluster.KubeconfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := &v1alpha1.ClusterList{}
+	if err := k.List(ctx, clusters); err != nil {
+		return nil, fmt.Errorf("getting eksa cluster: %w", err)
+	}
+
+	for i := range clusters.Items {
+		if clusters.Items[i].Name == clusterName {
+			return &clusters.Items[i], nil
+		}
+	}
+
+	return nil, apierrors.NewNotFound(v1alpha1.GroupVersion.WithResource("clusters").GroupResource(), clusterName)
+}
+
+// GetBundles returns the Bundles object with the given name.
+func (c *KubernetesClusterClient) GetBundles(ctx context.Context, kubeconfigFile, name, namespace string) (*releasev1alpha1.Bundles, error) {
+	bundles := &releasev1alpha1.Bundles{}
+	if err := c.get(ctx, kubeconfigFile, name, namespace, bundles); err != nil {
+		return nil, err
+	}
+
+	return bundles, nil
+}
+
+// GetEksdRelease returns the eks-d Release object with the given name.
+func (c *KubernetesClusterClient) GetEksdRelease(ctx context.Context, name, namespace, kubeconfigFile string) (*eksdv1alpha1.Release, error) {
+	release := &eksdv1alpha1.Release{}
+	if err := c.get(ctx, kubeconfigFile, name, namespace, release); err != nil {
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// GetEksaGitOpsConfig returns the GitOpsConfig with the given name.
+func (c *KubernetesClusterClient) GetEksaGitOpsConfig(ctx context.Context, gitOpsConfigName, kubeconfigFile, namespace string) (*v1alpha1.GitOpsConfig, error) {
+	config := &v1alpha1.GitOpsConfig{}
+	if err := c.get(ctx, kubeconfigFile, gitOpsConfigName, namespace, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// GetEksaFluxConfig returns the FluxConfig with the given name.
+func (c *KubernetesClusterClient) GetEksaFluxConfig(ctx context.Context, fluxConfigName, kubeconfigFile, namespace string) (*v1alpha1.FluxConfig, error) {
+	config := &v1alpha1.FluxConfig{}
+	if err := c.get(ctx, kubeconfigFile, fluxConfigName, namespace, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// GetEksaOIDCConfig returns the OIDCConfig with the given name.
+func (c *KubernetesClusterClient) GetEksaOIDCConfig(ctx context.Context, oidcConfigName, kubeconfigFile, namespace string) (*v1alpha1.OIDCConfig, error) {
+	config := &v1alpha1.OIDCConfig{}
+	if err := c.get(ctx, kubeconfigFile, oidcConfigName, namespace, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// GetEksaAWSIamConfig returns the AWSIamConfig with the given name.
+func (c *KubernetesClusterClient) GetEksaAWSIamConfig(ctx context.Context, awsIamConfigName, kubeconfigFile, namespace string) (*v1alpha1.AWSIamConfig, error) {
+	config := &v1alpha1.AWSIamConfig{}
+	if err := c.get(ctx, kubeconfigFile, awsIamConfigName, namespace, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// GetObject gets the object with the given name and unmarshalls it into obj.
+// The resourceType is not needed since the type is inferred from obj.
+func (c *KubernetesClusterClient) GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error {
+	o, ok := obj.(runtimeclient.Object)
+	if !ok {
+		return c.ClusterClient.GetObject(ctx, resourceType, name, namespace, kubeconfig, obj)
+	}
+
+	return c.get(ctx, kubeconfig, name, namespace, o)
+}
+
+// ListObjects lists all the objects of the list type in a namespace.
+// The resourceType is not needed since the type is inferred from list.
+func (c *KubernetesClusterClient) ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list kubernetes.ObjectList) error {
+	k, err := c.clients.BuildClientFromKubeconfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	return k.List(ctx, list, runtimeclient.InNamespace(defaultNamespace(namespace)))
+}
+
+// Apply server side applies the object, creating it if it doesn't exist. The CLI takes ownership of the
+// fields set in obj, the fields owned by other managers and not set in obj are left untouched.
+// If obj contains a resource version, the apply will fail if the object has changed since it was read.
+func (c *KubernetesClusterClient) Apply(ctx context.Context, kubeconfig string, obj runtime.Object) error {
+	o, ok := obj.(runtimeclient.Object)
+	if !ok {
+		return c.ClusterClient.Apply(ctx, kubeconfig, obj)
+	}
+
+	k, err := c.clients.BuildClientFromKubeconfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	// Server side apply requires the type meta, which typed objects usually don't have,
+	// and rejects the managed fields of objects that were read from the API server.
+	gvk, err := apiutil.GVKForObject(o, k.Scheme())
+	if err != nil {
+		return fmt.Errorf("applying object %s: %w", o.GetName(), err)
+	}
+	o.GetObjectKind().SetGroupVersionKind(gvk)
+	o.SetManagedFields(nil)
+
+	if err := k.Patch(ctx, o, runtimeclient.Apply, runtimeclient.FieldOwner(kubernetesClientFieldManager), runtimeclient.ForceOwnership); err != nil {
+		return fmt.Errorf("applying object %s: %w", o.GetName(), err)
+	}
+
+	return nil
+}
+
+// WaitForClusterReady waits until the CAPI cluster is Ready.
+func (c *KubernetesClusterClient) WaitForClusterReady(ctx context.Context, cluster *types.Cluster, timeout, clusterName string) error {
+	return c.waitForCAPIClusterCondition(ctx, cluster, timeout, clusterName, clusterv1.ReadyCondition, corev1.ConditionTrue)
+}
+
+// WaitForControlPlaneReady waits until the control plane of the CAPI cluster is ready.
+func (c *KubernetesClusterClient) WaitForControlPlaneReady(ctx context.Context, cluster *types.Cluster, timeout, newClusterName string) error {
+	return c.waitForCAPIClusterCondition(ctx, cluster, timeout, newClusterName, clusterv1.ControlPlaneReadyCondition, corev1.ConditionTrue)
+}
+
+// WaitForControlPlaneNotReady waits until the control plane of the CAPI cluster is not ready.
+func (c *KubernetesClusterClient) WaitForControlPlaneNotReady(ctx context.Context, cluster *types.Cluster, timeout, newClusterName string) error {
+	return c.waitForCAPIClusterCondition(ctx, cluster, timeout, newClusterName, clusterv1.ControlPlaneReadyCondition, corev1.ConditionFalse)
+}
+
+// WaitForManagedExternalEtcdReady waits until the external etcd of the CAPI cluster is ready.
+func (c *KubernetesClusterClient) WaitForManagedExternalEtcdReady(ctx context.Context, cluster *types.Cluster, timeout, newClusterName string) error {
+	return c.waitForCAPIClusterCondition(ctx, cluster, timeout, newClusterName, clusterv1.ManagedExternalEtcdClusterReadyCondition, corev1.ConditionTrue)
+}
+
+// WaitForManagedExternalEtcdNotReady waits until the external etcd of the CAPI cluster is not ready.
+func (c *KubernetesClusterClient) WaitForManagedExternalEtcdNotReady(ctx context.Context, cluster *types.Cluster, timeout, newClusterName string) error {
+	return c.waitForCAPIClusterCondition(ctx, cluster, timeout, newClusterName, clusterv1.ManagedExternalEtcdClusterReadyCondition, corev1.ConditionFalse)
+}
+
+func (c *KubernetesClusterClient) get(ctx context.Context, kubeconfig, name, namespace string, obj runtimeclient.Object) error {
+	k, err := c.clients.BuildClientFromKubeconfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	return k.Get(ctx, runtimeclient.ObjectKey{Name: name, Namespace: defaultNamespace(namespace)}, obj)
+}
+
+// waitForCAPIClusterCondition polls the CAPI cluster until the condition has the expected status.
+// Errors reading the cluster are retried until the timeout, except for the ones
+// that won't go away by retrying, like authentication or authorization failures.
+func (c *KubernetesClusterClient) waitForCAPIClusterCondition(ctx context.Context, cluster *types.Cluster, timeout, clusterName string, conditionType clusterv1.ConditionType, status corev1.ConditionStatus) error {
+	timeoutDuration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return fmt.Errorf("unparsable timeout specified: %w", err)
+	}
+
+	k, err := c.clients.BuildClientFromKubeconfig(cluster.KubeconfigFile)
+	if err != nil {
+		return err
+	}
+
+	key := runtimeclient.ObjectKey{Name: clusterName, Namespace: constants.EksaSystemNamespace}
+	waiter := wait.New(timeoutDuration, wait.WithInterval(c.pollInterval), wait.WithProgress(wait.LogProgress(6)))
+	waitingFor := fmt.Sprintf("condition %s=%s on cluster %s", conditionType, status, clusterName)
+
+	return waiter.For(ctx, waitingFor, func(ctx context.Context) (wait.Status, error) {
+		capiCluster := &clusterv1.Cluster{}
+		if err := k.Get(ctx, key, capiCluster); err != nil {
+			if apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err) {
+				return wait.Status{}, wait.Failed(err)
+			}
+			return wait.Status{}, err
+		}
+
+		condition := conditions.Get(capiCluster, conditionType)
+		if condition == nil {
+			return wait.Status{Message: fmt.Sprintf("condition %s not reported", conditionType)}, nil
+		}
+
+		return wait.Status{Done: condition.Status == status, Message: fmt.Sprintf("condition %s is %s", conditionType, condition.Status)}, nil
+	})
+}
+
+// defaultNamespace mimics kubectl, which uses the default namespace when none is provided.
+func defaultNamespace(namespace string) string {
+	if namespace == "" {
+		return corev1.NamespaceDefault
+	}
+
+	return namespace
+}
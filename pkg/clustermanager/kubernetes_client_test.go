@@ -0,0 +1,299 @@
+package clustermanager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	mocksmanager "github.com/aws/eks-anywhere/pkg/clustermanager/mocks"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/wait"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const kubeconfigFile = "c.kubeconfig"
+
+type fakeClientFactory struct {
+	client client.Client
+	err    error
+}
+
+func (f *fakeClientFactory) BuildClientFromKubeconfig(kubeconfig string) (client.Client, error) {
+	if kubeconfig != kubeconfigFile {
+		return nil, errors.New("unexpected kubeconfig " + kubeconfig)
+	}
+	return f.client, f.err
+}
+
+type kubernetesClientTest struct {
+	*WithT
+	ctx      context.Context
+	cluster  *types.Cluster
+	kubectl  *mocksmanager.MockClusterClient
+	client   client.Client
+	cmClient *clustermanager.KubernetesClusterClient
+}
+
+func newKubernetesClientTest(t *testing.T, objs ...client.Object) *kubernetesClientTest {
+	scheme, err := kubernetes.NewScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	kubectl := mocksmanager.NewMockClusterClient(gomock.NewController(t))
+
+	return &kubernetesClientTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		cluster:  &types.Cluster{KubeconfigFile: kubeconfigFile},
+		kubectl:  kubectl,
+		client:   c,
+		cmClient: clustermanager.NewKubernetesClusterClient(kubectl, &fakeClientFactory{client: c}),
+	}
+}
+
+func capiClusterWithCondition(conditionType clusterv1.ConditionType, status corev1.ConditionStatus) *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: constants.EksaSystemNamespace},
+		Status: clusterv1.ClusterStatus{
+			Conditions: clusterv1.Conditions{{Type: conditionType, Status: status}},
+		},
+	}
+}
+
+func TestKubernetesClusterClientGetEksaCluster(t *testing.T) {
+	eksaCluster := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "my-ns"}}
+	tt := newKubernetesClientTest(t,
+		eksaCluster,
+		&v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "other-cluster", Namespace: "default"}},
+	)
+
+	got, err := tt.cmClient.GetEksaCluster(tt.ctx, tt.cluster, "my-cluster")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got.Name).To(Equal("my-cluster"))
+	tt.Expect(got.Namespace).To(Equal("my-ns"))
+}
+
+func TestKubernetesClusterClientGetEksaClusterNotFound(t *testing.T) {
+	tt := newKubernetesClientTest(t)
+
+	_, err := tt.cmClient.GetEksaCluster(tt.ctx, tt.cluster, "my-cluster")
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "error should be NotFound, got %v", err)
+}
+
+func TestKubernetesClusterClientGetEksaClusterClientError(t *testing.T) {
+	tt := newKubernetesClientTest(t)
+	tt.cmClient = clustermanager.NewKubernetesClusterClient(tt.kubectl, &fakeClientFactory{err: errors.New("building client")})
+
+	_, err := tt.cmClient.GetEksaCluster(tt.ctx, tt.cluster, "my-cluster")
+	tt.Expect(err).To(MatchError("building client"))
+}
+
+func TestKubernetesClusterClientGetBundles(t *testing.T) {
+	bundles := &releasev1alpha1.Bundles{ObjectMeta: metav1.ObjectMeta{Name: "bundles-1", Namespace: constants.EksaSystemNamespace}}
+	tt := newKubernetesClientTest(t, bundles)
+
+	got, err := tt.cmClient.GetBundles(tt.ctx, kubeconfigFile, "bundles-1", constants.EksaSystemNamespace)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got.Name).To(Equal("bundles-1"))
+}
+
+func TestKubernetesClusterClientGetEksdReleaseNotFound(t *testing.T) {
+	tt := newKubernetesClientTest(t)
+
+	_, err := tt.cmClient.GetEksdRelease(tt.ctx, "kubernetes-1-23-eks-1", constants.EksaSystemNamespace, kubeconfigFile)
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "error should be NotFound, got %v", err)
+}
+
+func TestKubernetesClusterClientGetEksaConfigsDefaultNamespace(t *testing.T) {
+	tt := newKubernetesClientTest(t,
+		&v1alpha1.GitOpsConfig{ObjectMeta: metav1.ObjectMeta{Name: "gitops", Namespace: "default"}},
+		&v1alpha1.FluxConfig{ObjectMeta: metav1.ObjectMeta{Name: "flux", Namespace: "default"}},
+		&v1alpha1.OIDCConfig{ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: "default"}},
+		&v1alpha1.AWSIamConfig{ObjectMeta: metav1.ObjectMeta{Name: "aws-iam", Namespace: "default"}},
+	)
+
+	gitOps, err := tt.cmClient.GetEksaGitOpsConfig(tt.ctx, "gitops", kubeconfigFile, "")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(gitOps.Name).To(Equal("gitops"))
+
+	flux, err := tt.cmClient.GetEksaFluxConfig(tt.ctx, "flux", kubeconfigFile, "")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(flux.Name).To(Equal("flux"))
+
+	oidc, err := tt.cmClient.GetEksaOIDCConfig(tt.ctx, "oidc", kubeconfigFile, "")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(oidc.Name).To(Equal("oidc"))
+
+	awsIam, err := tt.cmClient.GetEksaAWSIamConfig(tt.ctx, "aws-iam", kubeconfigFile, "")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(awsIam.Name).To(Equal("aws-iam"))
+}
+
+func TestKubernetesClusterClientListObjects(t *testing.T) {
+	tt := newKubernetesClientTest(t,
+		&v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "my-ns"}},
+		&v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c2", Namespace: "my-ns"}},
+		&v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c3", Namespace: "other-ns"}},
+	)
+	clusters := &v1alpha1.ClusterList{}
+
+	tt.Expect(tt.cmClient.ListObjects(tt.ctx, "clusters", "my-ns", kubeconfigFile, clusters)).To(Succeed())
+	tt.Expect(clusters.Items).To(HaveLen(2))
+}
+
+// patchRecordingClient records the patches instead of sending them, since the fake client doesn't
+// support server side apply.
+type patchRecordingClient struct {
+	client.Client
+	patch   client.Patch
+	options *client.PatchOptions
+	obj     client.Object
+	err     error
+}
+
+func (c *patchRecordingClient) Patch(_ context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.obj = obj
+	c.patch = patch
+	c.options = &client.PatchOptions{}
+	c.options.ApplyOptions(opts)
+	return c.err
+}
+
+func TestKubernetesClusterClientApplyServerSide(t *testing.T) {
+	tt := newKubernetesClientTest(t)
+	recorder := &patchRecordingClient{Client: tt.client}
+	cmClient := clustermanager.NewKubernetesClusterClient(tt.kubectl, &fakeClientFactory{client: recorder})
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "my-cm",
+			Namespace:       "my-ns",
+			ResourceVersion: "5",
+			ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Data: map[string]string{"key": "value"},
+	}
+
+	tt.Expect(cmClient.Apply(tt.ctx, kubeconfigFile, cm)).To(Succeed())
+	tt.Expect(recorder.patch.Type()).To(Equal(apitypes.ApplyPatchType))
+	tt.Expect(recorder.options.FieldManager).To(Equal("eks-a-cli"))
+	tt.Expect(recorder.options.Force).To(HaveValue(BeTrue()))
+	tt.Expect(recorder.obj.GetObjectKind().GroupVersionKind()).To(Equal(corev1.SchemeGroupVersion.WithKind("ConfigMap")))
+	tt.Expect(recorder.obj.GetManagedFields()).To(BeNil())
+	tt.Expect(recorder.obj.GetResourceVersion()).To(Equal("5"))
+}
+
+func TestKubernetesClusterClientApplyConflict(t *testing.T) {
+	tt := newKubernetesClientTest(t)
+	recorder := &patchRecordingClient{
+		Client: tt.client,
+		err:    apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "my-cm", errors.New("object has been modified")),
+	}
+	cmClient := clustermanager.NewKubernetesClusterClient(tt.kubectl, &fakeClientFactory{client: recorder})
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "my-cm", Namespace: "my-ns", ResourceVersion: "1"}}
+
+	err := cmClient.Apply(tt.ctx, kubeconfigFile, cm)
+	tt.Expect(apierrors.IsConflict(err)).To(BeTrue(), "error should be Conflict, got %v", err)
+}
+
+func TestKubernetesClusterClientWaitForControlPlaneReady(t *testing.T) {
+	tt := newKubernetesClientTest(t, capiClusterWithCondition(clusterv1.ControlPlaneReadyCondition, corev1.ConditionTrue))
+
+	tt.Expect(tt.cmClient.WaitForControlPlaneReady(tt.ctx, tt.cluster, "1m", "my-cluster")).To(Succeed())
+}
+
+func TestKubernetesClusterClientWaitForControlPlaneNotReady(t *testing.T) {
+	tt := newKubernetesClientTest(t, capiClusterWithCondition(clusterv1.ControlPlaneReadyCondition, corev1.ConditionFalse))
+
+	tt.Expect(tt.cmClient.WaitForControlPlaneNotReady(tt.ctx, tt.cluster, "1m", "my-cluster")).To(Succeed())
+}
+
+func TestKubernetesClusterClientWaitForManagedExternalEtcdReady(t *testing.T) {
+	tt := newKubernetesClientTest(t, capiClusterWithCondition(clusterv1.ManagedExternalEtcdClusterReadyCondition, corev1.ConditionTrue))
+
+	tt.Expect(tt.cmClient.WaitForManagedExternalEtcdReady(tt.ctx, tt.cluster, "1m", "my-cluster")).To(Succeed())
+}
+
+func TestKubernetesClusterClientWaitForClusterReadyTimeout(t *testing.T) {
+	tt := newKubernetesClientTest(t, capiClusterWithCondition(clusterv1.ReadyCondition, corev1.ConditionFalse))
+
+	err := tt.cmClient.WaitForClusterReady(tt.ctx, tt.cluster, "10ms", "my-cluster")
+	tt.Expect(wait.IsTimeout(err)).To(BeTrue(), "error should be a timeout, got %v", err)
+	tt.Expect(err).To(MatchError(ContainSubstring("timed out waiting for condition Ready=True on cluster my-cluster")))
+	tt.Expect(err).To(MatchError(ContainSubstring("condition Ready is False")))
+}
+
+func TestKubernetesClusterClientWaitForClusterReadyTimeoutClusterNotFound(t *testing.T) {
+	tt := newKubernetesClientTest(t)
+
+	err := tt.cmClient.WaitForClusterReady(tt.ctx, tt.cluster, "10ms", "my-cluster")
+	tt.Expect(err).To(MatchError(ContainSubstring("timed out waiting for condition Ready=True on cluster my-cluster")))
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "error should wrap NotFound, got %v", err)
+}
+
+func TestKubernetesClusterClientWaitForClusterReadyForbidden(t *testing.T) {
+	tt := newKubernetesClientTest(t)
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Group: clusterv1.GroupVersion.Group, Resource: "clusters"}, "my-cluster", errors.New("no access"))
+	tt.cmClient = clustermanager.NewKubernetesClusterClient(tt.kubectl, &fakeClientFactory{client: errorClient{Client: tt.client, err: forbidden}})
+
+	err := tt.cmClient.WaitForClusterReady(tt.ctx, tt.cluster, "1m", "my-cluster")
+	tt.Expect(wait.IsResourceFailed(err)).To(BeTrue(), "wait should fail without retrying, got %v", err)
+	tt.Expect(apierrors.IsForbidden(err)).To(BeTrue(), "error should be Forbidden, got %v", err)
+}
+
+func TestKubernetesClusterClientWaitForClusterReadyInvalidTimeout(t *testing.T) {
+	tt := newKubernetesClientTest(t)
+
+	err := tt.cmClient.WaitForClusterReady(tt.ctx, tt.cluster, "forever", "my-cluster")
+	tt.Expect(err).To(MatchError(ContainSubstring("unparsable timeout specified")))
+}
+
+func TestKubernetesClusterClientWaitForClusterReadyCanceled(t *testing.T) {
+	tt := newKubernetesClientTest(t)
+	ctx, cancel := context.WithCancel(tt.ctx)
+	cancel()
+
+	err := tt.cmClient.WaitForClusterReady(ctx, tt.cluster, "1m", "my-cluster")
+	tt.Expect(wait.IsCanceled(err)).To(BeTrue(), "error should be a cancellation, got %v", err)
+	tt.Expect(err).To(MatchError(context.Canceled))
+}
+
+func TestKubernetesClusterClientGetObjectNonClientObjectUsesKubectl(t *testing.T) {
+	tt := newKubernetesClientTest(t)
+	obj := &metav1.Status{}
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "status", "name", "ns", kubeconfigFile, obj)
+
+	tt.Expect(tt.cmClient.GetObject(tt.ctx, "status", "name", "ns", kubeconfigFile, obj)).To(Succeed())
+}
+
+func TestKubernetesClusterClientDelegatesOtherOperations(t *testing.T) {
+	tt := newKubernetesClientTest(t)
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.cluster, "my-cluster").Return(nil, nil)
+
+	_, err := tt.cmClient.GetMachines(tt.ctx, tt.cluster, "my-cluster")
+	tt.Expect(err).NotTo(HaveOccurred())
+}
+
+type errorClient struct {
+	client.Client
+	err error
+}
+
+func (c errorClient) Get(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+	return c.err
+}
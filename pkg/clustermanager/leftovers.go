@@ -0,0 +1,99 @@
+package clustermanager
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// ClusterLeftover is an object kept in the management cluster for a cluster that outlived its deletion.
+type ClusterLeftover struct {
+	ResourceType string
+	Name         string
+	Namespace    string
+}
+
+func (l ClusterLeftover) String() string {
+	if l.Namespace == "" {
+		return fmt.Sprintf("%s %s", l.ResourceType, l.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", l.ResourceType, l.Namespace, l.Name)
+}
+
+// DeleteClusterLeftovers removes the secrets and namespaces kept in managementCluster for the cluster named
+// clusterName once the cluster is deleted. It tries to remove all of them before returning an error.
+func (c *ClusterManager) DeleteClusterLeftovers(ctx context.Context, managementCluster *types.Cluster, clusterName string) error {
+	leftovers, err := c.AuditClusterLeftovers(ctx, managementCluster, clusterName)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, l := range leftovers {
+		logger.V(3).Info("Deleting cluster leftover", "object", l.String())
+		if err := c.clusterClient.DeleteIgnoreNotFound(ctx, l.ResourceType, l.Name, l.Namespace, managementCluster.KubeconfigFile); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// AuditClusterLeftovers returns the secrets and namespaces kept in managementCluster for the cluster named
+// clusterName that still exist.
+func (c *ClusterManager) AuditClusterLeftovers(ctx context.Context, managementCluster *types.Cluster, clusterName string) ([]ClusterLeftover, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.clusterClient.ListObjects(ctx, "secrets", constants.EksaSystemNamespace, managementCluster.KubeconfigFile, secrets); err != nil {
+		return nil, fmt.Errorf("listing secrets: %v", err)
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := c.clusterClient.ListObjects(ctx, "namespaces", "", managementCluster.KubeconfigFile, namespaces); err != nil {
+		return nil, fmt.Errorf("listing namespaces: %v", err)
+	}
+
+	leftovers := clusterSecretLeftovers(secrets.Items, clusterName)
+	packagesNamespace := "eksa-packages-" + clusterName
+	for _, ns := range namespaces.Items {
+		if ns.Name == packagesNamespace {
+			leftovers = append(leftovers, ClusterLeftover{ResourceType: "namespace", Name: ns.Name})
+		}
+	}
+
+	return leftovers, nil
+}
+
+// clusterSecretLeftovers returns the secrets that belong to the cluster named clusterName: the kubeconfig,
+// CAs and etcd certificates from CAPI and the IAM authenticator CA. They are selected by the CAPI cluster
+// name label or by an owner reference to the CAPI cluster, never by name, since the name of a cluster can
+// be the prefix of the name of another one.
+func clusterSecretLeftovers(secrets []corev1.Secret, clusterName string) []ClusterLeftover {
+	var leftovers []ClusterLeftover
+	for _, s := range secrets {
+		if s.Labels[clusterv1.ClusterLabelName] == clusterName || ownedByCAPICluster(s.OwnerReferences, clusterName) {
+			leftovers = append(leftovers, ClusterLeftover{ResourceType: "secret", Name: s.Name, Namespace: s.Namespace})
+		}
+	}
+
+	return leftovers
+}
+
+func ownedByCAPICluster(refs []metav1.OwnerReference, clusterName string) bool {
+	for _, ref := range refs {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err == nil && gv.Group == clusterv1.GroupVersion.Group && ref.Kind == "Cluster" && ref.Name == clusterName {
+			return true
+		}
+	}
+
+	return false
+}
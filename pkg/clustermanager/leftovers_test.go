@@ -0,0 +1,149 @@
+package clustermanager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func (tt *testSetup) expectListLeftovers(secrets []corev1.Secret, namespaces []corev1.Namespace) {
+	tt.mocks.client.EXPECT().ListObjects(tt.ctx, "secrets", constants.EksaSystemNamespace, tt.cluster.KubeconfigFile, &corev1.SecretList{}).
+		DoAndReturn(func(_ context.Context, _, _, _ string, obj *corev1.SecretList) error {
+			obj.Items = secrets
+			return nil
+		})
+	tt.mocks.client.EXPECT().ListObjects(tt.ctx, "namespaces", "", tt.cluster.KubeconfigFile, &corev1.NamespaceList{}).
+		DoAndReturn(func(_ context.Context, _, _, _ string, obj *corev1.NamespaceList) error {
+			obj.Items = namespaces
+			return nil
+		})
+}
+
+func clusterSecret(name, clusterName string) corev1.Secret {
+	return corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: constants.EksaSystemNamespace,
+		Labels:    map[string]string{clusterv1.ClusterLabelName: clusterName},
+	}}
+}
+
+func leftoverSecrets() []corev1.Secret {
+	return []corev1.Secret{
+		clusterSecret("workload-kubeconfig", "workload"),
+		clusterSecret("workload-aws-iam-authenticator-ca", "workload"),
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:      "workload-md-0-token",
+			Namespace: constants.EksaSystemNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster", Name: "workload"},
+			},
+		}},
+		clusterSecret("workload-2-kubeconfig", "workload-2"),
+		clusterSecret("cluster-name-kubeconfig", "cluster-name"),
+		{ObjectMeta: metav1.ObjectMeta{Name: "workload-registry-credentials", Namespace: constants.EksaSystemNamespace}},
+	}
+}
+
+func leftoverNamespaces() []corev1.Namespace {
+	return []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "eksa-system"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "eksa-packages-workload"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "eksa-packages-cluster-name"}},
+	}
+}
+
+func TestClusterManagerAuditClusterLeftovers(t *testing.T) {
+	tt := newTest(t)
+	tt.expectListLeftovers(leftoverSecrets(), leftoverNamespaces())
+
+	leftovers, err := tt.clusterManager.AuditClusterLeftovers(tt.ctx, tt.cluster, "workload")
+	tt.Expect(err).To(Succeed())
+	tt.Expect(leftovers).To(Equal([]clustermanager.ClusterLeftover{
+		{ResourceType: "secret", Name: "workload-kubeconfig", Namespace: constants.EksaSystemNamespace},
+		{ResourceType: "secret", Name: "workload-aws-iam-authenticator-ca", Namespace: constants.EksaSystemNamespace},
+		{ResourceType: "secret", Name: "workload-md-0-token", Namespace: constants.EksaSystemNamespace},
+		{ResourceType: "namespace", Name: "eksa-packages-workload"},
+	}))
+}
+
+func TestClusterManagerAuditClusterLeftoversClusterNamePrefix(t *testing.T) {
+	tt := newTest(t)
+	secrets := []corev1.Secret{
+		clusterSecret("prod-kubeconfig", "prod"),
+		clusterSecret("prod-etcd", "prod"),
+		clusterSecret("prod-managed-kubeconfig", "prod-managed"),
+		clusterSecret("prod-managed-etcd", "prod-managed"),
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:      "prod-managed-ca",
+			Namespace: constants.EksaSystemNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster", Name: "prod-managed"},
+			},
+		}},
+	}
+	namespaces := []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "eksa-packages-prod"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "eksa-packages-prod-managed"}},
+	}
+	tt.expectListLeftovers(secrets, namespaces)
+
+	leftovers, err := tt.clusterManager.AuditClusterLeftovers(tt.ctx, tt.cluster, "prod")
+	tt.Expect(err).To(Succeed())
+	tt.Expect(leftovers).To(Equal([]clustermanager.ClusterLeftover{
+		{ResourceType: "secret", Name: "prod-kubeconfig", Namespace: constants.EksaSystemNamespace},
+		{ResourceType: "secret", Name: "prod-etcd", Namespace: constants.EksaSystemNamespace},
+		{ResourceType: "namespace", Name: "eksa-packages-prod"},
+	}))
+}
+
+func TestClusterManagerAuditClusterLeftoversNone(t *testing.T) {
+	tt := newTest(t)
+	tt.expectListLeftovers(nil, nil)
+
+	tt.Expect(tt.clusterManager.AuditClusterLeftovers(tt.ctx, tt.cluster, "workload")).To(BeEmpty())
+}
+
+func TestClusterManagerAuditClusterLeftoversListError(t *testing.T) {
+	tt := newTest(t)
+	tt.mocks.client.EXPECT().ListObjects(tt.ctx, "secrets", constants.EksaSystemNamespace, tt.cluster.KubeconfigFile, gomock.Any()).Return(errors.New("forbidden"))
+
+	_, err := tt.clusterManager.AuditClusterLeftovers(tt.ctx, tt.cluster, "workload")
+	tt.Expect(err).To(MatchError(ContainSubstring("listing secrets: forbidden")))
+}
+
+func TestClusterManagerDeleteClusterLeftovers(t *testing.T) {
+	tt := newTest(t)
+	tt.expectListLeftovers(leftoverSecrets(), leftoverNamespaces())
+	kubeconfig := tt.cluster.KubeconfigFile
+	tt.mocks.client.EXPECT().DeleteIgnoreNotFound(tt.ctx, "secret", "workload-kubeconfig", constants.EksaSystemNamespace, kubeconfig)
+	tt.mocks.client.EXPECT().DeleteIgnoreNotFound(tt.ctx, "secret", "workload-aws-iam-authenticator-ca", constants.EksaSystemNamespace, kubeconfig)
+	tt.mocks.client.EXPECT().DeleteIgnoreNotFound(tt.ctx, "secret", "workload-md-0-token", constants.EksaSystemNamespace, kubeconfig)
+	tt.mocks.client.EXPECT().DeleteIgnoreNotFound(tt.ctx, "namespace", "eksa-packages-workload", "", kubeconfig)
+
+	tt.Expect(tt.clusterManager.DeleteClusterLeftovers(tt.ctx, tt.cluster, "workload")).To(Succeed())
+}
+
+func TestClusterManagerDeleteClusterLeftoversKeepsGoingOnError(t *testing.T) {
+	tt := newTest(t)
+	tt.expectListLeftovers(leftoverSecrets()[:2], nil)
+	kubeconfig := tt.cluster.KubeconfigFile
+	tt.mocks.client.EXPECT().DeleteIgnoreNotFound(tt.ctx, "secret", "workload-kubeconfig", constants.EksaSystemNamespace, kubeconfig).Return(errors.New("forbidden"))
+	tt.mocks.client.EXPECT().DeleteIgnoreNotFound(tt.ctx, "secret", "workload-aws-iam-authenticator-ca", constants.EksaSystemNamespace, kubeconfig)
+
+	tt.Expect(tt.clusterManager.DeleteClusterLeftovers(tt.ctx, tt.cluster, "workload")).To(MatchError(ContainSubstring("forbidden")))
+}
+
+func TestClusterLeftoverString(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clustermanager.ClusterLeftover{ResourceType: "secret", Name: "a", Namespace: "b"}.String()).To(Equal("secret b/a"))
+	g.Expect(clustermanager.ClusterLeftover{ResourceType: "namespace", Name: "a"}.String()).To(Equal("namespace a"))
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/clustermanager (interfaces: ClusterClient,Networking,AwsIamAuth)
+// Source: github.com/aws/eks-anywhere/pkg/clustermanager (interfaces: ClusterClient,Networking,AwsIamAuth,BackupInstaller,OSUpdatesInstaller)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -18,6 +18,7 @@ import (
 	v1alpha10 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 	v1alpha11 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	gomock "github.com/golang/mock/gomock"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -44,6 +45,20 @@ func (m *MockClusterClient) EXPECT() *MockClusterClientMockRecorder {
 	return m.recorder
 }
 
+// Apply mocks base method.
+func (m *MockClusterClient) Apply(arg0 context.Context, arg1 string, arg2 runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockClusterClientMockRecorder) Apply(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockClusterClient)(nil).Apply), arg0, arg1, arg2)
+}
+
 // ApplyKubeSpecFromBytes mocks base method.
 func (m *MockClusterClient) ApplyKubeSpecFromBytes(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
 	m.ctrl.T.Helper()
@@ -172,6 +187,20 @@ func (mr *MockClusterClientMockRecorder) DeleteGitOpsConfig(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGitOpsConfig", reflect.TypeOf((*MockClusterClient)(nil).DeleteGitOpsConfig), arg0, arg1, arg2, arg3)
 }
 
+// DeleteIgnoreNotFound mocks base method.
+func (m *MockClusterClient) DeleteIgnoreNotFound(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIgnoreNotFound", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIgnoreNotFound indicates an expected call of DeleteIgnoreNotFound.
+func (mr *MockClusterClientMockRecorder) DeleteIgnoreNotFound(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIgnoreNotFound", reflect.TypeOf((*MockClusterClient)(nil).DeleteIgnoreNotFound), arg0, arg1, arg2, arg3, arg4)
+}
+
 // DeleteOIDCConfig mocks base method.
 func (m *MockClusterClient) DeleteOIDCConfig(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -444,6 +473,20 @@ func (mr *MockClusterClientMockRecorder) GetMachines(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachines", reflect.TypeOf((*MockClusterClient)(nil).GetMachines), arg0, arg1, arg2)
 }
 
+// GetObject mocks base method.
+func (m *MockClusterClient) GetObject(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockClusterClientMockRecorder) GetObject(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockClusterClient)(nil).GetObject), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GetWorkloadKubeconfig mocks base method.
 func (m *MockClusterClient) GetWorkloadKubeconfig(arg0 context.Context, arg1 string, arg2 *types.Cluster) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -586,6 +629,34 @@ func (mr *MockClusterClientMockRecorder) UpdateEnvironmentVariablesInNamespace(a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvironmentVariablesInNamespace", reflect.TypeOf((*MockClusterClient)(nil).UpdateEnvironmentVariablesInNamespace), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// UpdateReplicasInNamespace mocks base method.
+func (m *MockClusterClient) UpdateReplicasInNamespace(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 *types.Cluster, arg5 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReplicasInNamespace", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReplicasInNamespace indicates an expected call of UpdateReplicasInNamespace.
+func (mr *MockClusterClientMockRecorder) UpdateReplicasInNamespace(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReplicasInNamespace", reflect.TypeOf((*MockClusterClient)(nil).UpdateReplicasInNamespace), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// UpdateResourceRequestsInNamespace mocks base method.
+func (m *MockClusterClient) UpdateResourceRequestsInNamespace(arg0 context.Context, arg1, arg2 string, arg3 map[string]string, arg4 *types.Cluster, arg5 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateResourceRequestsInNamespace", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateResourceRequestsInNamespace indicates an expected call of UpdateResourceRequestsInNamespace.
+func (mr *MockClusterClientMockRecorder) UpdateResourceRequestsInNamespace(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResourceRequestsInNamespace", reflect.TypeOf((*MockClusterClient)(nil).UpdateResourceRequestsInNamespace), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // ValidateControlPlaneNodes mocks base method.
 func (m *MockClusterClient) ValidateControlPlaneNodes(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -829,3 +900,77 @@ func (mr *MockAwsIamAuthMockRecorder) UpgradeAWSIAMAuth(arg0, arg1, arg2 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeAWSIAMAuth", reflect.TypeOf((*MockAwsIamAuth)(nil).UpgradeAWSIAMAuth), arg0, arg1, arg2)
 }
+
+// MockBackupInstaller is a mock of BackupInstaller interface.
+type MockBackupInstaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupInstallerMockRecorder
+}
+
+// MockBackupInstallerMockRecorder is the mock recorder for MockBackupInstaller.
+type MockBackupInstallerMockRecorder struct {
+	mock *MockBackupInstaller
+}
+
+// NewMockBackupInstaller creates a new mock instance.
+func NewMockBackupInstaller(ctrl *gomock.Controller) *MockBackupInstaller {
+	mock := &MockBackupInstaller{ctrl: ctrl}
+	mock.recorder = &MockBackupInstallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupInstaller) EXPECT() *MockBackupInstallerMockRecorder {
+	return m.recorder
+}
+
+// Install mocks base method.
+func (m *MockBackupInstaller) Install(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Install", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Install indicates an expected call of Install.
+func (mr *MockBackupInstallerMockRecorder) Install(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Install", reflect.TypeOf((*MockBackupInstaller)(nil).Install), arg0, arg1, arg2)
+}
+
+// MockOSUpdatesInstaller is a mock of OSUpdatesInstaller interface.
+type MockOSUpdatesInstaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockOSUpdatesInstallerMockRecorder
+}
+
+// MockOSUpdatesInstallerMockRecorder is the mock recorder for MockOSUpdatesInstaller.
+type MockOSUpdatesInstallerMockRecorder struct {
+	mock *MockOSUpdatesInstaller
+}
+
+// NewMockOSUpdatesInstaller creates a new mock instance.
+func NewMockOSUpdatesInstaller(ctrl *gomock.Controller) *MockOSUpdatesInstaller {
+	mock := &MockOSUpdatesInstaller{ctrl: ctrl}
+	mock.recorder = &MockOSUpdatesInstallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOSUpdatesInstaller) EXPECT() *MockOSUpdatesInstallerMockRecorder {
+	return m.recorder
+}
+
+// Install mocks base method.
+func (m *MockOSUpdatesInstaller) Install(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Install", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Install indicates an expected call of Install.
+func (mr *MockOSUpdatesInstallerMockRecorder) Install(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Install", reflect.TypeOf((*MockOSUpdatesInstaller)(nil).Install), arg0, arg1, arg2)
+}
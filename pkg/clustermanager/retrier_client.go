@@ -6,10 +6,18 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager/internal"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+// edgeProfileControllerRequests are the resource requests of the eksa-controller-manager in clusters with the
+// edge profile.
+var edgeProfileControllerRequests = map[string]string{
+	"cpu":    "20m",
+	"memory": "64Mi",
+}
+
 type retrierClient struct {
 	*client
 	*retrier.Retrier
@@ -45,9 +53,51 @@ func (c *retrierClient) installCustomComponents(ctx context.Context, clusterSpec
 			return fmt.Errorf("applying eks-a components spec: %v", err)
 		}
 	}
+
+	if clusterSpec.Cluster.IsEdgeProfile() {
+		err = c.Retrier.Retry(
+			func() error {
+				return c.UpdateResourceRequestsInNamespace(ctx, "deployment", "eksa-controller-manager", edgeProfileControllerRequests, cluster, "eksa-system")
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("applying eks-a controller edge profile resources: %v", err)
+		}
+	}
+
+	// Small clusters can't afford a second controller replica, nor drain a node with the only replica
+	// protected by a PodDisruptionBudget.
+	if clusterSpec.Cluster.IsEdgeProfile() || clusterSpec.Cluster.IsSingleNode() {
+		if err = c.runSingleController(ctx, cluster); err != nil {
+			return err
+		}
+	}
 	return c.waitForDeployments(ctx, internal.EksaDeployments, cluster)
 }
 
+// runSingleController scales the eksa-controller-manager to a single replica and removes its PodDisruptionBudget.
+func (c *retrierClient) runSingleController(ctx context.Context, cluster *types.Cluster) error {
+	err := c.Retrier.Retry(
+		func() error {
+			return c.UpdateReplicasInNamespace(ctx, "deployment", "eksa-controller-manager", 1, cluster, constants.EksaSystemNamespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("scaling eks-a controller to a single replica: %v", err)
+	}
+
+	err = c.Retrier.Retry(
+		func() error {
+			return c.DeleteIgnoreNotFound(ctx, "poddisruptionbudget", "eksa-controller-manager", constants.EksaSystemNamespace, cluster.KubeconfigFile)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("removing eks-a controller pod disruption budget: %v", err)
+	}
+
+	return nil
+}
+
 func (c *retrierClient) ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error {
 	return c.Retry(
 		func() error {
@@ -2,6 +2,7 @@ package clustermarshaller
 
 import (
 	"fmt"
+	"sort"
 
 	"sigs.k8s.io/yaml"
 
@@ -14,7 +15,7 @@ import (
 )
 
 func MarshalClusterSpec(clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) ([]byte, error) {
-	marshallables := make([]v1alpha1.Marshallable, 0, 5+len(machineConfigs)+len(clusterSpec.TinkerbellTemplateConfigs))
+	marshallables := make([]v1alpha1.Marshallable, 0, 5+len(machineConfigs)+len(clusterSpec.TinkerbellTemplateConfigs)+len(clusterSpec.SnowIPPools))
 	marshallables = append(marshallables,
 		clusterSpec.Cluster.ConvertConfigToConfigGenerateStruct(),
 		datacenterConfig.Marshallable(),
@@ -24,6 +25,8 @@ func MarshalClusterSpec(clusterSpec *cluster.Spec, datacenterConfig providers.Da
 		marshallables = append(marshallables, machineConfig.Marshallable())
 	}
 
+	marshallables = append(marshallables, snowIPPoolsMarshallables(clusterSpec.SnowIPPools)...)
+
 	// If a GitOpsConfig is present, marshal the GitOpsConfig to file; otherwise, use the FluxConfig
 	// Allows us to use the FluxConfig internally but preserve the provided spec while GitOpsConfig is being deprecated
 	if clusterSpec.GitOpsConfig != nil {
@@ -64,6 +67,21 @@ func MarshalClusterSpec(clusterSpec *cluster.Spec, datacenterConfig providers.Da
 	return templater.AppendYamlResources(resources...), nil
 }
 
+// snowIPPoolsMarshallables returns the SnowIPPools sorted by name so the generated cluster spec is stable.
+func snowIPPoolsMarshallables(pools map[string]*v1alpha1.SnowIPPool) []v1alpha1.Marshallable {
+	names := make([]string, 0, len(pools))
+	for name := range pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	marshallables := make([]v1alpha1.Marshallable, 0, len(pools))
+	for _, name := range names {
+		marshallables = append(marshallables, pools[name].ConvertConfigToConfigGenerateStruct())
+	}
+	return marshallables
+}
+
 func WriteClusterConfig(clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig, writer filewriter.FileWriter) error {
 	resourcesSpec, err := MarshalClusterSpec(clusterSpec, datacenterConfig, machineConfigs)
 	if err != nil {
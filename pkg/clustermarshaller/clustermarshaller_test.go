@@ -313,3 +313,91 @@ func TestWriteClusterConfigWithFluxConfig(t *testing.T) {
 
 	test.AssertFilesEquals(t, gotFile, "testdata/expected_marshalled_cluster_flux_config.yaml")
 }
+
+func TestWriteClusterConfigSnowIPPools(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.APIVersion = v1alpha1.GroupVersion.String()
+		s.Cluster.TypeMeta.Kind = v1alpha1.ClusterKind
+		s.Cluster.CreationTimestamp = v1.Time{Time: time.Now()}
+		s.Cluster.Name = "mycluster"
+		s.Cluster.SetSelfManaged()
+
+		s.SnowIPPools = map[string]*v1alpha1.SnowIPPool{}
+		for _, name := range []string{"ippool-2", "ippool-1"} {
+			s.SnowIPPools[name] = &v1alpha1.SnowIPPool{
+				TypeMeta: v1.TypeMeta{
+					Kind:       v1alpha1.SnowIPPoolKind,
+					APIVersion: v1alpha1.GroupVersion.String(),
+				},
+				ObjectMeta: v1.ObjectMeta{
+					Name:              name,
+					CreationTimestamp: v1.Time{Time: time.Now()},
+				},
+				Spec: v1alpha1.SnowIPPoolSpec{
+					Pools: []v1alpha1.IPPool{
+						{
+							IPStart: "1.2.3.4",
+							IPEnd:   "1.2.3.10",
+							Subnet:  "1.2.3.0/24",
+							Gateway: "1.2.3.1",
+						},
+					},
+				},
+			}
+		}
+	})
+
+	datacenterConfig := &v1alpha1.SnowDatacenterConfig{
+		TypeMeta: v1.TypeMeta{
+			Kind:       v1alpha1.SnowDatacenterKind,
+			APIVersion: v1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:              "config",
+			CreationTimestamp: v1.Time{Time: time.Now()},
+		},
+	}
+
+	machineConfigs := []providers.MachineConfig{
+		&v1alpha1.SnowMachineConfig{
+			TypeMeta: v1.TypeMeta{
+				Kind:       v1alpha1.SnowMachineConfigKind,
+				APIVersion: v1alpha1.GroupVersion.String(),
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name:              "machineconf-1",
+				CreationTimestamp: v1.Time{Time: time.Now()},
+			},
+			Spec: v1alpha1.SnowMachineConfigSpec{
+				Devices: []string{"1.2.3.4"},
+				Network: v1alpha1.SnowNetwork{
+					DirectNetworkInterfaces: []v1alpha1.SnowDirectNetworkInterface{
+						{
+							Index:   1,
+							Primary: true,
+							IPPoolRef: &v1alpha1.Ref{
+								Kind: v1alpha1.SnowIPPoolKind,
+								Name: "ippool-1",
+							},
+						},
+						{
+							Index: 2,
+							IPPoolRef: &v1alpha1.Ref{
+								Kind: v1alpha1.SnowIPPoolKind,
+								Name: "ippool-2",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	g := NewWithT(t)
+
+	folder, writer := test.NewWriter(t)
+	gotFile := filepath.Join(folder, "mycluster-eks-a-cluster.yaml")
+
+	g.Expect(clustermarshaller.WriteClusterConfig(clusterSpec, datacenterConfig, machineConfigs, writer)).To(Succeed())
+
+	test.AssertFilesEquals(t, gotFile, "testdata/expected_marshalled_snow_ip_pools.yaml")
+}
@@ -0,0 +1,36 @@
+package clusterstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Format is an output format for State.
+type Format string
+
+// FormatTerraformJSON is an indented JSON document with snake_case keys and a stable field and
+// node group order, suitable for Terraform's jsondecode.
+const FormatTerraformJSON Format = "terraform-json"
+
+// ValidateFormat returns an error if format is not supported.
+func ValidateFormat(format Format) error {
+	if format != FormatTerraformJSON {
+		return fmt.Errorf("unsupported format %s, supported formats are %s", format, FormatTerraformJSON)
+	}
+	return nil
+}
+
+// Write writes s to w in format.
+func Write(w io.Writer, s *State, format Format) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(s); err != nil {
+		return fmt.Errorf("encoding cluster state: %v", err)
+	}
+	return nil
+}
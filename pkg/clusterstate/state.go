@@ -0,0 +1,200 @@
+package clusterstate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// FormatVersion is the version of the State schema. It only changes when fields are removed or
+// their meaning changes, new fields can be added without bumping it.
+const FormatVersion = 1
+
+// State is a machine-readable representation of a realized cluster.
+type State struct {
+	FormatVersion int     `json:"format_version"`
+	Cluster       Cluster `json:"cluster"`
+}
+
+// Cluster is the realized state of an EKS-A cluster.
+type Cluster struct {
+	Name              string      `json:"name"`
+	Namespace         string      `json:"namespace"`
+	ManagementCluster string      `json:"management_cluster"`
+	KubernetesVersion string      `json:"kubernetes_version"`
+	EksdRelease       string      `json:"eksd_release,omitempty"`
+	Ready             bool        `json:"ready"`
+	Endpoint          Endpoint    `json:"endpoint"`
+	KubeconfigSecret  ObjectRef   `json:"kubeconfig_secret"`
+	ControlPlane      NodeGroup   `json:"control_plane"`
+	Etcd              *NodeGroup  `json:"etcd,omitempty"`
+	WorkerNodeGroups  []NodeGroup `json:"worker_node_groups"`
+}
+
+// Endpoint is the API server endpoint of a cluster.
+type Endpoint struct {
+	Host string `json:"host"`
+	Port int32  `json:"port"`
+	URL  string `json:"url"`
+}
+
+// ObjectRef references a kubernetes object in the management cluster.
+type ObjectRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// MachineConfigRef references the machine config of a node group.
+type MachineConfigRef struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// NodeGroup is the realized state of a group of nodes.
+type NodeGroup struct {
+	Name              string            `json:"name"`
+	Replicas          int32             `json:"replicas"`
+	ReadyReplicas     int32             `json:"ready_replicas"`
+	MinCount          *int              `json:"min_count,omitempty"`
+	MaxCount          *int              `json:"max_count,omitempty"`
+	KubernetesVersion string            `json:"kubernetes_version,omitempty"`
+	MachineConfig     *MachineConfigRef `json:"machine_config,omitempty"`
+}
+
+// Exporter reads the state of clusters from their management cluster.
+type Exporter struct {
+	client kubernetes.Client
+}
+
+// NewExporter constructs a new Exporter using a client for the management cluster.
+func NewExporter(client kubernetes.Client) *Exporter {
+	return &Exporter{
+		client: client,
+	}
+}
+
+// Export returns the state of the cluster with name and namespace.
+func (e *Exporter) Export(ctx context.Context, name, namespace string) (*State, error) {
+	cluster := &anywherev1.Cluster{}
+	if err := e.client.Get(ctx, name, namespace, cluster); err != nil {
+		return nil, fmt.Errorf("getting cluster %s: %v", name, err)
+	}
+
+	capiCluster := &clusterv1.Cluster{}
+	if err := e.client.Get(ctx, name, constants.EksaSystemNamespace, capiCluster); err != nil {
+		return nil, fmt.Errorf("getting CAPI cluster %s: %v", name, err)
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := e.client.Get(ctx, name, constants.EksaSystemNamespace, kcp); err != nil {
+		return nil, fmt.Errorf("getting control plane for cluster %s: %v", name, err)
+	}
+
+	managementCluster := cluster.ManagedBy()
+	if managementCluster == "" {
+		managementCluster = cluster.Name
+	}
+
+	endpoint := capiCluster.Spec.ControlPlaneEndpoint
+	s := &State{
+		FormatVersion: FormatVersion,
+		Cluster: Cluster{
+			Name:              cluster.Name,
+			Namespace:         cluster.Namespace,
+			ManagementCluster: managementCluster,
+			KubernetesVersion: string(cluster.Spec.KubernetesVersion),
+			Ready:             conditions.IsTrue(capiCluster, clusterv1.ReadyCondition),
+			Endpoint: Endpoint{
+				Host: endpoint.Host,
+				Port: endpoint.Port,
+			},
+			KubeconfigSecret: ObjectRef{
+				Name:      secret.Name(capiCluster.Name, secret.Kubeconfig),
+				Namespace: capiCluster.Namespace,
+			},
+			ControlPlane: NodeGroup{
+				Name:              kcp.Name,
+				Replicas:          kcp.Status.Replicas,
+				ReadyReplicas:     kcp.Status.ReadyReplicas,
+				KubernetesVersion: versionOrEmpty(kcp.Status.Version),
+				MachineConfig:     machineConfigRef(cluster.Spec.ControlPlaneConfiguration.MachineGroupRef),
+			},
+			WorkerNodeGroups: make([]NodeGroup, 0, len(cluster.Spec.WorkerNodeGroupConfigurations)),
+		},
+	}
+	if endpoint.IsValid() {
+		s.Cluster.Endpoint.URL = fmt.Sprintf("https://%s", endpoint.String())
+	}
+	if ref := cluster.Status.EksdReleaseRef; ref != nil {
+		s.Cluster.EksdRelease = ref.Name
+	}
+
+	if etcdRef := capiCluster.Spec.ManagedExternalEtcdRef; etcdRef != nil {
+		etcdCluster := &etcdv1.EtcdadmCluster{}
+		if err := e.client.Get(ctx, etcdRef.Name, etcdRef.Namespace, etcdCluster); err != nil {
+			return nil, fmt.Errorf("getting etcd cluster %s: %v", etcdRef.Name, err)
+		}
+
+		s.Cluster.Etcd = &NodeGroup{
+			Name:          etcdCluster.Name,
+			ReadyReplicas: etcdCluster.Status.ReadyReplicas,
+		}
+		if etcdCluster.Spec.Replicas != nil {
+			s.Cluster.Etcd.Replicas = *etcdCluster.Spec.Replicas
+		}
+		if etcd := cluster.Spec.ExternalEtcdConfiguration; etcd != nil {
+			s.Cluster.Etcd.MachineConfig = machineConfigRef(etcd.MachineGroupRef)
+		}
+	}
+
+	for _, group := range cluster.Spec.WorkerNodeGroupConfigurations {
+		mdName := fmt.Sprintf("%s-%s", cluster.Name, group.Name)
+		md := &clusterv1.MachineDeployment{}
+		if err := e.client.Get(ctx, mdName, constants.EksaSystemNamespace, md); err != nil {
+			return nil, fmt.Errorf("getting machine deployment %s: %v", mdName, err)
+		}
+
+		nodeGroup := NodeGroup{
+			Name:              group.Name,
+			Replicas:          md.Status.Replicas,
+			ReadyReplicas:     md.Status.ReadyReplicas,
+			KubernetesVersion: versionOrEmpty(md.Spec.Template.Spec.Version),
+			MachineConfig:     machineConfigRef(group.MachineGroupRef),
+		}
+		if a := group.AutoScalingConfiguration; a != nil {
+			nodeGroup.MinCount = &a.MinCount
+			nodeGroup.MaxCount = &a.MaxCount
+		}
+		s.Cluster.WorkerNodeGroups = append(s.Cluster.WorkerNodeGroups, nodeGroup)
+	}
+
+	sort.Slice(s.Cluster.WorkerNodeGroups, func(i, j int) bool {
+		return s.Cluster.WorkerNodeGroups[i].Name < s.Cluster.WorkerNodeGroups[j].Name
+	})
+
+	return s, nil
+}
+
+func machineConfigRef(ref *anywherev1.Ref) *MachineConfigRef {
+	if ref == nil {
+		return nil
+	}
+	return &MachineConfigRef{Kind: ref.Kind, Name: ref.Name}
+}
+
+func versionOrEmpty(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
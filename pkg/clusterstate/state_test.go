@@ -0,0 +1,161 @@
+package clusterstate_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes/mocks"
+	"github.com/aws/eks-anywhere/pkg/clusterstate"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+type exporterTest struct {
+	*WithT
+	ctx      context.Context
+	client   *mocks.MockClient
+	exporter *clusterstate.Exporter
+}
+
+func newExporterTest(t *testing.T) *exporterTest {
+	client := mocks.NewMockClient(gomock.NewController(t))
+	return &exporterTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		client:   client,
+		exporter: clusterstate.NewExporter(client),
+	}
+}
+
+func (tt *exporterTest) expectGet(name, namespace string, obj kubernetes.Object) {
+	tt.client.EXPECT().Get(tt.ctx, name, namespace, gomock.AssignableToTypeOf(obj)).DoAndReturn(
+		func(_ context.Context, _, _ string, o kubernetes.Object) error {
+			switch want := obj.(type) {
+			case *anywherev1.Cluster:
+				want.DeepCopyInto(o.(*anywherev1.Cluster))
+			case *clusterv1.Cluster:
+				want.DeepCopyInto(o.(*clusterv1.Cluster))
+			case *controlplanev1.KubeadmControlPlane:
+				want.DeepCopyInto(o.(*controlplanev1.KubeadmControlPlane))
+			case *etcdv1.EtcdadmCluster:
+				want.DeepCopyInto(o.(*etcdv1.EtcdadmCluster))
+			case *clusterv1.MachineDeployment:
+				want.DeepCopyInto(o.(*clusterv1.MachineDeployment))
+			}
+			return nil
+		},
+	)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestExporterExport(t *testing.T) {
+	tt := newExporterTest(t)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube123,
+			ManagementCluster: anywherev1.ManagementCluster{Name: "mgmt"},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				Count:           3,
+				MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "workload-cp"},
+			},
+			ExternalEtcdConfiguration: &anywherev1.ExternalEtcdConfiguration{
+				Count:           3,
+				MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "workload-etcd"},
+			},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{
+					Name:                     "md-1",
+					MachineGroupRef:          &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "workload-md"},
+					AutoScalingConfiguration: &anywherev1.AutoScalingConfiguration{MinCount: 1, MaxCount: 5},
+				},
+				{
+					Name:            "md-0",
+					MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "workload-md"},
+				},
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			EksdReleaseRef: &anywherev1.EksdReleaseRef{Name: "kubernetes-1-23-eks-7"},
+		},
+	}
+	capiCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "10.0.0.10", Port: 6443},
+			ManagedExternalEtcdRef: &corev1.ObjectReference{
+				Name:      "workload-etcd",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+		Status: clusterv1.ClusterStatus{
+			Conditions: clusterv1.Conditions{{Type: clusterv1.ReadyCondition, Status: corev1.ConditionTrue}},
+		},
+	}
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			Replicas:      3,
+			ReadyReplicas: 2,
+			Version:       ptr("v1.23.7-eks-1-23-4"),
+		},
+	}
+	etcdCluster := &etcdv1.EtcdadmCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-etcd", Namespace: constants.EksaSystemNamespace},
+		Spec:       etcdv1.EtcdadmClusterSpec{Replicas: ptr[int32](3)},
+		Status:     etcdv1.EtcdadmClusterStatus{ReadyReplicas: 3},
+	}
+	md := func(replicas int32) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			Spec: clusterv1.MachineDeploymentSpec{
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{Version: ptr("v1.23.7-eks-1-23-4")},
+				},
+			},
+			Status: clusterv1.MachineDeploymentStatus{Replicas: replicas, ReadyReplicas: replicas},
+		}
+	}
+
+	tt.expectGet("workload", "default", cluster)
+	tt.expectGet("workload", constants.EksaSystemNamespace, capiCluster)
+	tt.expectGet("workload", constants.EksaSystemNamespace, kcp)
+	tt.expectGet("workload-etcd", constants.EksaSystemNamespace, etcdCluster)
+	tt.expectGet("workload-md-1", constants.EksaSystemNamespace, md(2))
+	tt.expectGet("workload-md-0", constants.EksaSystemNamespace, md(1))
+
+	state, err := tt.exporter.Export(tt.ctx, "workload", "default")
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	var b bytes.Buffer
+	tt.Expect(clusterstate.Write(&b, state, clusterstate.FormatTerraformJSON)).To(Succeed())
+	test.AssertContentToFile(t, b.String(), "testdata/expected_state.json")
+}
+
+func TestExporterExportClusterNotFound(t *testing.T) {
+	tt := newExporterTest(t)
+	tt.client.EXPECT().Get(tt.ctx, "workload", "default", &anywherev1.Cluster{}).Return(errors.New("not found"))
+
+	_, err := tt.exporter.Export(tt.ctx, "workload", "default")
+	tt.Expect(err).To(MatchError("getting cluster workload: not found"))
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	g := NewWithT(t)
+	var b bytes.Buffer
+	g.Expect(clusterstate.Write(&b, &clusterstate.State{}, "yaml")).To(MatchError("unsupported format yaml, supported formats are terraform-json"))
+}
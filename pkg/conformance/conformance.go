@@ -0,0 +1,115 @@
+// Package conformance runs the Kubernetes conformance tests against EKS-A clusters.
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const statusPrefix = "Status:"
+
+// Sonobuoy runs the conformance tests in a cluster and collects their results.
+type Sonobuoy interface {
+	RunConformance(ctx context.Context, kubeconfig string, opts executables.SonobuoyRunOpts) error
+	Retrieve(ctx context.Context, kubeconfig, dir string) (archivePath string, err error)
+	Results(ctx context.Context, archivePath string) (string, error)
+	Delete(ctx context.Context, kubeconfig string) error
+}
+
+// Runner runs the conformance tests with the images of the cluster bundle.
+type Runner struct {
+	sonobuoy Sonobuoy
+}
+
+// NewRunner constructs a new Runner.
+func NewRunner(sonobuoy Sonobuoy) *Runner {
+	return &Runner{
+		sonobuoy: sonobuoy,
+	}
+}
+
+// Result is the outcome of a conformance run.
+type Result struct {
+	// Archive is the path of the results archive.
+	Archive string
+	// Summary is the per plugin summary of the results.
+	Summary string
+	// Passed is true when every plugin passed.
+	Passed bool
+}
+
+// Run runs the conformance tests in mode, waits for them to complete and retrieves the results
+// archive to outputDir. The sonobuoy resources are removed from the cluster once the results
+// are retrieved.
+func (r *Runner) Run(ctx context.Context, spec *cluster.Spec, kubeconfig, outputDir string, mode executables.SonobuoyMode, timeout time.Duration) (*Result, error) {
+	opts := RunOpts(spec, mode, timeout)
+	if opts.SonobuoyImage == "" {
+		logger.Info("Warning: the bundle doesn't include the conformance images, using the sonobuoy default images")
+	}
+
+	logger.Info("Running conformance tests", "mode", mode)
+	if err := r.sonobuoy.RunConformance(ctx, kubeconfig, opts); err != nil {
+		return nil, r.cleanup(ctx, kubeconfig, fmt.Errorf("running conformance tests: %v", err))
+	}
+
+	archive, err := r.sonobuoy.Retrieve(ctx, kubeconfig, outputDir)
+	if err != nil {
+		return nil, r.cleanup(ctx, kubeconfig, fmt.Errorf("retrieving conformance results: %v", err))
+	}
+
+	summary, err := r.sonobuoy.Results(ctx, archive)
+	if err != nil {
+		return nil, r.cleanup(ctx, kubeconfig, fmt.Errorf("reading conformance results: %v", err))
+	}
+
+	if err := r.sonobuoy.Delete(ctx, kubeconfig); err != nil {
+		return nil, fmt.Errorf("removing conformance tests from cluster: %v", err)
+	}
+
+	return &Result{
+		Archive: archive,
+		Summary: summary,
+		Passed:  passed(summary),
+	}, nil
+}
+
+func (r *Runner) cleanup(ctx context.Context, kubeconfig string, runErr error) error {
+	if err := r.sonobuoy.Delete(ctx, kubeconfig); err != nil {
+		logger.V(4).Info("Failed removing conformance tests from cluster", "error", err)
+	}
+	return runErr
+}
+
+// RunOpts returns the sonobuoy options to run the conformance tests with the images of the
+// cluster bundle. Images not included in the bundle are left empty.
+func RunOpts(spec *cluster.Spec, mode executables.SonobuoyMode, timeout time.Duration) executables.SonobuoyRunOpts {
+	images := spec.VersionsBundle.Conformance
+	return executables.SonobuoyRunOpts{
+		Mode:                 mode,
+		SonobuoyImage:        images.Sonobuoy.VersionedImage(),
+		KubeConformanceImage: images.KubeConformance.VersionedImage(),
+		SystemdLogsImage:     images.SystemdLogs.VersionedImage(),
+		Timeout:              timeout,
+	}
+}
+
+func passed(summary string) bool {
+	statuses := 0
+	for _, line := range strings.Split(summary, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, statusPrefix) {
+			continue
+		}
+		statuses++
+		if strings.TrimSpace(strings.TrimPrefix(line, statusPrefix)) != "passed" {
+			return false
+		}
+	}
+	return statuses > 0
+}
@@ -0,0 +1,141 @@
+package conformance_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/conformance"
+	"github.com/aws/eks-anywhere/pkg/conformance/mocks"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	kubeconfig = "my-cluster/my-cluster-eks-a-cluster.kubeconfig"
+	outputDir  = "my-cluster/conformance"
+	archive    = "my-cluster/conformance/202210181200_sonobuoy_0a1b.tar.gz"
+)
+
+type runnerTest struct {
+	*WithT
+	ctx      context.Context
+	sonobuoy *mocks.MockSonobuoy
+	runner   *conformance.Runner
+	spec     *cluster.Spec
+	opts     executables.SonobuoyRunOpts
+}
+
+func newRunnerTest(t *testing.T) *runnerTest {
+	ctrl := gomock.NewController(t)
+	sonobuoy := mocks.NewMockSonobuoy(ctrl)
+	return &runnerTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		sonobuoy: sonobuoy,
+		runner:   conformance.NewRunner(sonobuoy),
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.VersionsBundle.Conformance = releasev1alpha1.ConformanceBundle{
+				Sonobuoy:        releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/sonobuoy:v0.56.10"},
+				KubeConformance: releasev1alpha1.Image{URI: "public.ecr.aws/eks-distro/kubernetes/conformance:v1.23.7"},
+				SystemdLogs:     releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/systemd-logs:v0.4"},
+			}
+		}),
+		opts: executables.SonobuoyRunOpts{
+			Mode:                 executables.SonobuoyQuick,
+			SonobuoyImage:        "public.ecr.aws/eks-anywhere/sonobuoy:v0.56.10",
+			KubeConformanceImage: "public.ecr.aws/eks-distro/kubernetes/conformance:v1.23.7",
+			SystemdLogsImage:     "public.ecr.aws/eks-anywhere/systemd-logs:v0.4",
+			Timeout:              time.Hour,
+		},
+	}
+}
+
+func (tt *runnerTest) run() (*conformance.Result, error) {
+	return tt.runner.Run(tt.ctx, tt.spec, kubeconfig, outputDir, executables.SonobuoyQuick, time.Hour)
+}
+
+func TestRunnerRunPassed(t *testing.T) {
+	tt := newRunnerTest(t)
+	summary := "Plugin: e2e\nStatus: passed\n\nPlugin: systemd-logs\nStatus: passed\n"
+	tt.sonobuoy.EXPECT().RunConformance(tt.ctx, kubeconfig, tt.opts)
+	tt.sonobuoy.EXPECT().Retrieve(tt.ctx, kubeconfig, outputDir).Return(archive, nil)
+	tt.sonobuoy.EXPECT().Results(tt.ctx, archive).Return(summary, nil)
+	tt.sonobuoy.EXPECT().Delete(tt.ctx, kubeconfig)
+
+	tt.Expect(tt.run()).To(Equal(&conformance.Result{
+		Archive: archive,
+		Summary: summary,
+		Passed:  true,
+	}))
+}
+
+func TestRunnerRunFailed(t *testing.T) {
+	tt := newRunnerTest(t)
+	summary := "Plugin: e2e\nStatus: failed\n\nPlugin: systemd-logs\nStatus: passed\n"
+	tt.sonobuoy.EXPECT().RunConformance(tt.ctx, kubeconfig, tt.opts)
+	tt.sonobuoy.EXPECT().Retrieve(tt.ctx, kubeconfig, outputDir).Return(archive, nil)
+	tt.sonobuoy.EXPECT().Results(tt.ctx, archive).Return(summary, nil)
+	tt.sonobuoy.EXPECT().Delete(tt.ctx, kubeconfig)
+
+	result, err := tt.run()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Passed).To(BeFalse())
+}
+
+func TestRunnerRunNoStatus(t *testing.T) {
+	tt := newRunnerTest(t)
+	tt.sonobuoy.EXPECT().RunConformance(tt.ctx, kubeconfig, tt.opts)
+	tt.sonobuoy.EXPECT().Retrieve(tt.ctx, kubeconfig, outputDir).Return(archive, nil)
+	tt.sonobuoy.EXPECT().Results(tt.ctx, archive).Return("", nil)
+	tt.sonobuoy.EXPECT().Delete(tt.ctx, kubeconfig)
+
+	result, err := tt.run()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Passed).To(BeFalse())
+}
+
+func TestRunnerRunErrorCleansUp(t *testing.T) {
+	tt := newRunnerTest(t)
+	tt.sonobuoy.EXPECT().RunConformance(tt.ctx, kubeconfig, tt.opts).Return(errors.New("timed out"))
+	tt.sonobuoy.EXPECT().Delete(tt.ctx, kubeconfig).Return(errors.New("cluster unreachable"))
+
+	_, err := tt.run()
+	tt.Expect(err).To(MatchError(ContainSubstring("running conformance tests: timed out")))
+}
+
+func TestRunnerRunRetrieveError(t *testing.T) {
+	tt := newRunnerTest(t)
+	tt.sonobuoy.EXPECT().RunConformance(tt.ctx, kubeconfig, tt.opts)
+	tt.sonobuoy.EXPECT().Retrieve(tt.ctx, kubeconfig, outputDir).Return("", errors.New("error"))
+	tt.sonobuoy.EXPECT().Delete(tt.ctx, kubeconfig)
+
+	_, err := tt.run()
+	tt.Expect(err).To(MatchError(ContainSubstring("retrieving conformance results")))
+}
+
+func TestRunnerRunDeleteError(t *testing.T) {
+	tt := newRunnerTest(t)
+	tt.sonobuoy.EXPECT().RunConformance(tt.ctx, kubeconfig, tt.opts)
+	tt.sonobuoy.EXPECT().Retrieve(tt.ctx, kubeconfig, outputDir).Return(archive, nil)
+	tt.sonobuoy.EXPECT().Results(tt.ctx, archive).Return("Status: passed", nil)
+	tt.sonobuoy.EXPECT().Delete(tt.ctx, kubeconfig).Return(errors.New("error"))
+
+	_, err := tt.run()
+	tt.Expect(err).To(MatchError(ContainSubstring("removing conformance tests from cluster")))
+}
+
+func TestRunOptsWithoutBundleImages(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec()
+
+	g.Expect(conformance.RunOpts(spec, executables.SonobuoyCertifiedConformance, 0)).To(Equal(executables.SonobuoyRunOpts{
+		Mode: executables.SonobuoyCertifiedConformance,
+	}))
+}
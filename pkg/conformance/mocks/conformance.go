@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/conformance/conformance.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	executables "github.com/aws/eks-anywhere/pkg/executables"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSonobuoy is a mock of Sonobuoy interface.
+type MockSonobuoy struct {
+	ctrl     *gomock.Controller
+	recorder *MockSonobuoyMockRecorder
+}
+
+// MockSonobuoyMockRecorder is the mock recorder for MockSonobuoy.
+type MockSonobuoyMockRecorder struct {
+	mock *MockSonobuoy
+}
+
+// NewMockSonobuoy creates a new mock instance.
+func NewMockSonobuoy(ctrl *gomock.Controller) *MockSonobuoy {
+	mock := &MockSonobuoy{ctrl: ctrl}
+	mock.recorder = &MockSonobuoyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSonobuoy) EXPECT() *MockSonobuoyMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSonobuoy) Delete(ctx context.Context, kubeconfig string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, kubeconfig)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSonobuoyMockRecorder) Delete(ctx, kubeconfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSonobuoy)(nil).Delete), ctx, kubeconfig)
+}
+
+// Results mocks base method.
+func (m *MockSonobuoy) Results(ctx context.Context, archivePath string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Results", ctx, archivePath)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Results indicates an expected call of Results.
+func (mr *MockSonobuoyMockRecorder) Results(ctx, archivePath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Results", reflect.TypeOf((*MockSonobuoy)(nil).Results), ctx, archivePath)
+}
+
+// Retrieve mocks base method.
+func (m *MockSonobuoy) Retrieve(ctx context.Context, kubeconfig, dir string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retrieve", ctx, kubeconfig, dir)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retrieve indicates an expected call of Retrieve.
+func (mr *MockSonobuoyMockRecorder) Retrieve(ctx, kubeconfig, dir interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retrieve", reflect.TypeOf((*MockSonobuoy)(nil).Retrieve), ctx, kubeconfig, dir)
+}
+
+// RunConformance mocks base method.
+func (m *MockSonobuoy) RunConformance(ctx context.Context, kubeconfig string, opts executables.SonobuoyRunOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunConformance", ctx, kubeconfig, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunConformance indicates an expected call of RunConformance.
+func (mr *MockSonobuoyMockRecorder) RunConformance(ctx, kubeconfig, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunConformance", reflect.TypeOf((*MockSonobuoy)(nil).RunConformance), ctx, kubeconfig, opts)
+}
@@ -27,6 +27,7 @@ const (
 	EtcdadmControllerProviderName           = "bootstrap-etcdadm-controller"
 	DefaultHttpsPort                        = "443"
 	DefaultWorkerNodeGroupName              = "md-0"
+	DefaultClusterDomain                    = "cluster.local"
 
 	VSphereProviderName    = "vsphere"
 	DockerProviderName     = "docker"
@@ -40,6 +41,19 @@ const (
 	EksaLicenseName        = "eksa-license"
 	EksaPackagesName       = "eksa-packages"
 
+	// VSphereSerialConsoleLogFile is the file, in the VM directory of its datastore, where a vSphere VM
+	// with the serial console enabled writes the output of its serial port.
+	VSphereSerialConsoleLogFile = "serial-console.log"
+
+	// ManagedTagsKey is the tag EKS-A sets on the infrastructure resources of a cluster to record, comma
+	// separated, the keys of the cluster tags it set, so it can remove them when they leave the spec.
+	ManagedTagsKey = "anywhere.eks.amazonaws.com/tags"
+
+	// VSphereClusterTagCategory is the vCenter tag category of the tag EKS-A attaches to every VM of a
+	// cluster, named eksaCluster:<management cluster name>:<cluster name>, so the VMs of the cluster can be
+	// found without CAPI.
+	VSphereClusterTagCategory = "eksaCluster"
+
 	DefaultRegistry            = "public.ecr.aws"
 	CloudstackAnnotationSuffix = "cloudstack.anywhere.eks.amazonaws.com/v1alpha1"
 
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type objectChangesKey struct{}
+
+// ObjectChanges collects the objects created, updated and deleted during a reconciliation.
+// It's safe for concurrent use.
+type ObjectChanges struct {
+	mu      sync.Mutex
+	created map[string]struct{}
+	updated map[string]struct{}
+	deleted map[string]struct{}
+}
+
+// WithObjectChanges returns a context that collects the changes made to objects by the
+// helpers that support it, like serverside.ReconcileObject, and the ObjectChanges collecting them.
+func WithObjectChanges(ctx context.Context) (context.Context, *ObjectChanges) {
+	changes := &ObjectChanges{
+		created: map[string]struct{}{},
+		updated: map[string]struct{}{},
+		deleted: map[string]struct{}{},
+	}
+	return context.WithValue(ctx, objectChangesKey{}, changes), changes
+}
+
+// ObjectChangesFromContext returns the ObjectChanges of the context, or nil if it's not collecting changes.
+func ObjectChangesFromContext(ctx context.Context) *ObjectChanges {
+	changes, _ := ctx.Value(objectChangesKey{}).(*ObjectChanges)
+	return changes
+}
+
+// Created records the creation of an object.
+func (c *ObjectChanges) Created(obj client.Object) {
+	c.record(c.created, obj)
+}
+
+// Updated records the update of an object.
+func (c *ObjectChanges) Updated(obj client.Object) {
+	c.record(c.updated, obj)
+}
+
+// Deleted records the deletion of an object.
+func (c *ObjectChanges) Deleted(obj client.Object) {
+	c.record(c.deleted, obj)
+}
+
+// CreatedObjects returns the objects created, sorted, as "<kind> <namespace>/<name>".
+func (c *ObjectChanges) CreatedObjects() []string {
+	return c.list(c.created)
+}
+
+// UpdatedObjects returns the objects updated, sorted, as "<kind> <namespace>/<name>".
+func (c *ObjectChanges) UpdatedObjects() []string {
+	return c.list(c.updated)
+}
+
+// DeletedObjects returns the objects deleted, sorted, as "<kind> <namespace>/<name>".
+func (c *ObjectChanges) DeletedObjects() []string {
+	return c.list(c.deleted)
+}
+
+// Empty returns true if no changes have been recorded.
+func (c *ObjectChanges) Empty() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.created)+len(c.updated)+len(c.deleted) == 0
+}
+
+func (c *ObjectChanges) record(objs map[string]struct{}, obj client.Object) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	objs[objectChangeName(obj)] = struct{}{}
+}
+
+func (c *ObjectChanges) list(objs map[string]struct{}) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(objs) == 0 {
+		return nil
+	}
+	l := make([]string, 0, len(objs))
+	for o := range objs {
+		l = append(l, o)
+	}
+	sort.Strings(l)
+	return l
+}
+
+// objectChangeName identifies an object in the ObjectChanges lists.
+func objectChangeName(obj client.Object) string {
+	name := obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = obj.GetNamespace() + "/" + name
+	}
+	return fmt.Sprintf("%s %s", obj.GetObjectKind().GroupVersionKind().Kind, name)
+}
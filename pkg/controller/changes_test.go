@@ -0,0 +1,49 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/controller"
+)
+
+func TestObjectChangesFromContextNotCollecting(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(controller.ObjectChangesFromContext(context.Background())).To(BeNil())
+}
+
+func TestObjectChanges(t *testing.T) {
+	g := NewWithT(t)
+	ctx, changes := controller.WithObjectChanges(context.Background())
+	g.Expect(controller.ObjectChangesFromContext(ctx)).To(BeIdenticalTo(changes))
+	g.Expect(changes.Empty()).To(BeTrue())
+
+	capiCluster := &clusterv1.Cluster{
+		TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "eksa-system"},
+	}
+	namespace := &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "eksa-system"},
+	}
+	secret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "eksa-system"},
+	}
+
+	changes.Created(namespace)
+	changes.Created(capiCluster)
+	changes.Updated(capiCluster)
+	changes.Updated(capiCluster)
+	changes.Deleted(secret)
+
+	g.Expect(changes.Empty()).To(BeFalse())
+	g.Expect(changes.CreatedObjects()).To(Equal([]string{"Cluster eksa-system/my-cluster", "Namespace eksa-system"}))
+	g.Expect(changes.UpdatedObjects()).To(Equal([]string{"Cluster eksa-system/my-cluster"}))
+	g.Expect(changes.DeletedObjects()).To(Equal([]string{"Secret eksa-system/creds"}))
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RemoteClientsetBuilder builds clientsets for the clusters managed from the management cluster,
+// using the kubeconfig CAPI stores for each of them.
+type RemoteClientsetBuilder struct {
+	client     client.Reader
+	sourceName string
+}
+
+// NewRemoteClientsetBuilder constructs a new RemoteClientsetBuilder. sourceName identifies the
+// caller in the user agent of the requests.
+func NewRemoteClientsetBuilder(client client.Reader, sourceName string) *RemoteClientsetBuilder {
+	return &RemoteClientsetBuilder{
+		client:     client,
+		sourceName: sourceName,
+	}
+}
+
+// Clientset returns a clientset for the CAPI cluster with key cluster.
+func (b *RemoteClientsetBuilder) Clientset(ctx context.Context, cluster client.ObjectKey) (kubernetes.Interface, error) {
+	config, err := remote.RESTConfig(ctx, b.sourceName, b.client, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("building clientset for cluster %s: %v", cluster.Name, err)
+	}
+
+	return clientset, nil
+}
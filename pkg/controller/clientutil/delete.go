@@ -5,6 +5,8 @@ import (
 
 	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/controller"
 )
 
 func DeleteYaml(ctx context.Context, c client.Client, yaml []byte) error {
@@ -31,5 +33,9 @@ func deleteObject(ctx context.Context, c client.Client, obj client.Object) error
 		return errors.Wrapf(err, "deleting object %s, %s/%s", obj.GetObjectKind().GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
 
+	if changes := controller.ObjectChangesFromContext(ctx); changes != nil {
+		changes.Deleted(obj)
+	}
+
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 )
 
@@ -80,6 +81,21 @@ spec:
 	}
 }
 
+func TestDeleteYamlRecordsChanges(t *testing.T) {
+	g := NewWithT(t)
+	c := fake.NewClientBuilder().WithObjects(cluster("cluster-1")).Build()
+	ctx, changes := controller.WithObjectChanges(context.Background())
+	yaml := []byte(`apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: cluster-1
+  namespace: default`)
+
+	g.Expect(clientutil.DeleteYaml(ctx, c, yaml)).To(Succeed())
+	g.Expect(changes.DeletedObjects()).To(ConsistOf("Cluster default/cluster-1"))
+	g.Expect(changes.CreatedObjects()).To(BeEmpty())
+}
+
 func cluster(name string) *clusterapiv1.Cluster {
 	c := &clusterapiv1.Cluster{
 		TypeMeta: metav1.TypeMeta{
@@ -5,6 +5,10 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -37,3 +41,49 @@ func CheckControlPlaneReady(ctx context.Context, client client.Client, log logr.
 	log.Info("CAPI control plane is ready")
 	return controller.Result{}, nil
 }
+
+// CheckControlPlaneRolloutReady is a controller helper to check whether a CAPI cluster CP for an
+// eks-a cluster can safely roll out new machines before applying desired, the KubeadmControlPlane
+// generated from the eks-a cluster. The check only runs when desired references a different machine
+// template than the current KubeadmControlPlane, like when its machines are vertically scaled, since
+// that triggers a rollout. It returns a result requeueing the request until the control plane is
+// ready, see clusterapi.ControlPlaneRolloutReady.
+func CheckControlPlaneRolloutReady(ctx context.Context, client client.Client, log logr.Logger, cluster *anywherev1.Cluster, desired *controlplanev1.KubeadmControlPlane) (controller.Result, error) {
+	capiCluster, err := controller.GetCAPICluster(ctx, client, cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if capiCluster == nil {
+		return controller.Result{}, nil
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	err = client.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, kcp)
+	if apierrors.IsNotFound(err) {
+		return controller.Result{}, nil
+	}
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if kcp.Spec.MachineTemplate.InfrastructureRef.Name == desired.Spec.MachineTemplate.InfrastructureRef.Name {
+		return controller.Result{}, nil
+	}
+
+	var etcdadmCluster *etcdv1.EtcdadmCluster
+	if etcdRef := capiCluster.Spec.ManagedExternalEtcdRef; etcdRef != nil {
+		etcdadmCluster = &etcdv1.EtcdadmCluster{}
+		if err := client.Get(ctx, types.NamespacedName{Namespace: etcdRef.Namespace, Name: etcdRef.Name}, etcdadmCluster); err != nil {
+			return controller.Result{}, err
+		}
+	}
+
+	if err := clusterapi.ControlPlaneRolloutReady(kcp, etcdadmCluster); err != nil {
+		log.Info("CAPI control plane is not ready to roll out new machines, requeuing", "reason", err.Error())
+		return controller.ResultWithRequeue(30 * time.Second), nil
+	}
+
+	log.Info("Rolling out new CAPI control plane machines", "machineTemplate", desired.Spec.MachineTemplate.InfrastructureRef.Name)
+	return controller.Result{}, nil
+}
@@ -10,6 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -82,6 +83,68 @@ func TestCheckControlPlaneReadyErrorReading(t *testing.T) {
 	g.Expect(err).To(MatchError(ContainSubstring("no kind is registered for the type")))
 }
 
+func TestCheckControlPlaneRolloutReadySameMachineTemplate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	eksaCluster := eksaCluster()
+	kcp := kubeadmControlPlane(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Status.ReadyReplicas = 0
+	})
+
+	client := fake.NewClientBuilder().WithObjects(eksaCluster, capiCluster(), kcp).Build()
+
+	result, err := clusters.CheckControlPlaneRolloutReady(ctx, client, test.NewNullLogger(), eksaCluster, kubeadmControlPlane())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestCheckControlPlaneRolloutReadyNewMachineTemplateReady(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	eksaCluster := eksaCluster()
+	desired := kubeadmControlPlane(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Spec.MachineTemplate.InfrastructureRef.Name = "my-cluster-control-plane-2"
+	})
+
+	client := fake.NewClientBuilder().WithObjects(eksaCluster, capiCluster(), kubeadmControlPlane()).Build()
+
+	result, err := clusters.CheckControlPlaneRolloutReady(ctx, client, test.NewNullLogger(), eksaCluster, desired)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestCheckControlPlaneRolloutReadyNewMachineTemplateNotReady(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	eksaCluster := eksaCluster()
+	kcp := kubeadmControlPlane(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Status.ReadyReplicas = 2
+	})
+	desired := kubeadmControlPlane(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Spec.MachineTemplate.InfrastructureRef.Name = "my-cluster-control-plane-2"
+	})
+
+	client := fake.NewClientBuilder().WithObjects(eksaCluster, capiCluster(), kcp).Build()
+
+	result, err := clusters.CheckControlPlaneRolloutReady(ctx, client, test.NewNullLogger(), eksaCluster, desired)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(
+		controller.Result{Result: &controllerruntime.Result{RequeueAfter: 30 * time.Second}}),
+	)
+}
+
+func TestCheckControlPlaneRolloutReadyNoControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	eksaCluster := eksaCluster()
+
+	client := fake.NewClientBuilder().WithObjects(eksaCluster, capiCluster()).Build()
+
+	result, err := clusters.CheckControlPlaneRolloutReady(ctx, client, test.NewNullLogger(), eksaCluster, kubeadmControlPlane())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+}
+
 func eksaCluster() *anywherev1.Cluster {
 	return &anywherev1.Cluster{
 		TypeMeta: metav1.TypeMeta{
@@ -114,3 +177,38 @@ func capiCluster(opts ...capiClusterOpt) *clusterv1.Cluster {
 
 	return c
 }
+
+type kubeadmControlPlaneOpt func(*controlplanev1.KubeadmControlPlane)
+
+func kubeadmControlPlane(opts ...kubeadmControlPlaneOpt) *controlplanev1.KubeadmControlPlane {
+	replicas := int32(3)
+	k := &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "eksa-system",
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Replicas: &replicas,
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: corev1.ObjectReference{
+					Name: "my-cluster-control-plane-1",
+				},
+			},
+		},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			Replicas:        replicas,
+			UpdatedReplicas: replicas,
+			ReadyReplicas:   replicas,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	return k
+}
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// FaultAction is the way an injected fault disrupts a phase.
+type FaultAction string
+
+const (
+	// FaultFail makes the phase return an error without running it.
+	FaultFail FaultAction = "fail"
+	// FaultDelay runs the phase after waiting for the fault's Duration.
+	FaultDelay FaultAction = "delay"
+	// FaultRequeue makes the phase requeue after the fault's Duration without running it.
+	FaultRequeue FaultAction = "requeue"
+)
+
+// Fault disrupts the executions of a named phase. It's only meant to test how reconcilers
+// and their controllers deal with failures, never to be used in production.
+type Fault struct {
+	// Phase is the name of the phase the fault applies to, as returned by PhaseName.
+	Phase    string
+	Action   FaultAction
+	Duration time.Duration
+	// Times is the number of executions of the phase the fault applies to. After those,
+	// the phase runs normally. Zero means all executions.
+	Times int
+}
+
+// PhaseFaults injects Faults in the phases run by a PhaseRunner. It keeps track of how many
+// times each fault has been injected, so its behavior is deterministic for a sequence of runs.
+type PhaseFaults struct {
+	mu       sync.Mutex
+	faults   map[string]Fault
+	injected map[string]int
+}
+
+// NewPhaseFaults builds a PhaseFaults with the given faults. Only the last fault of each phase is kept.
+func NewPhaseFaults(faults ...Fault) *PhaseFaults {
+	f := &PhaseFaults{
+		faults:   make(map[string]Fault, len(faults)),
+		injected: map[string]int{},
+	}
+	for _, fault := range faults {
+		f.faults[fault.Phase] = fault
+	}
+	return f
+}
+
+// ParsePhaseFaults builds a PhaseFaults from a comma separated list of faults with the format
+// <phase>=<action>[:<duration>][*<times>]. For example "ReconcileControlPlane=fail*2,ReconcileWorkers=delay:5s".
+func ParsePhaseFaults(s string) (*PhaseFaults, error) {
+	var faults []Fault
+	for _, f := range strings.Split(s, ",") {
+		if strings.TrimSpace(f) == "" {
+			continue
+		}
+		fault, err := parseFault(strings.TrimSpace(f))
+		if err != nil {
+			return nil, fmt.Errorf("parsing phase fault %s: %v", f, err)
+		}
+		faults = append(faults, fault)
+	}
+
+	return NewPhaseFaults(faults...), nil
+}
+
+func parseFault(s string) (Fault, error) {
+	phase, action, ok := strings.Cut(s, "=")
+	if !ok || phase == "" {
+		return Fault{}, fmt.Errorf("expected <phase>=<action>")
+	}
+	fault := Fault{Phase: phase}
+
+	if a, times, ok := strings.Cut(action, "*"); ok {
+		n, err := strconv.Atoi(times)
+		if err != nil || n < 1 {
+			return Fault{}, fmt.Errorf("invalid times %s", times)
+		}
+		fault.Times = n
+		action = a
+	}
+
+	if a, duration, ok := strings.Cut(action, ":"); ok {
+		d, err := time.ParseDuration(duration)
+		if err != nil {
+			return Fault{}, err
+		}
+		fault.Duration = d
+		action = a
+	}
+
+	fault.Action = FaultAction(action)
+	if err := validateFault(fault); err != nil {
+		return Fault{}, err
+	}
+
+	return fault, nil
+}
+
+func validateFault(fault Fault) error {
+	switch fault.Action {
+	case FaultFail:
+		return nil
+	case FaultDelay, FaultRequeue:
+		if fault.Duration <= 0 {
+			return fmt.Errorf("%s requires a positive duration", fault.Action)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown action %s", fault.Action)
+	}
+}
+
+// Injected returns how many times a fault has been injected in the phase.
+func (f *PhaseFaults) Injected(phase string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.injected[phase]
+}
+
+// next returns the fault to inject in this execution of the phase, if any.
+func (f *PhaseFaults) next(phase string) (Fault, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fault, ok := f.faults[phase]
+	if !ok || (fault.Times > 0 && f.injected[phase] >= fault.Times) {
+		return Fault{}, false
+	}
+	f.injected[phase]++
+	return fault, true
+}
+
+// run executes the phase, disrupted by its fault if there is one left to inject.
+func (f *PhaseFaults) run(ctx context.Context, log logr.Logger, name string, phase Phase, clusterSpec *cluster.Spec) (Result, error) {
+	fault, ok := f.next(name)
+	if !ok {
+		return phase(ctx, log, clusterSpec)
+	}
+
+	log.Info("Injecting fault in phase", "phase", name, "action", fault.Action, "duration", fault.Duration)
+	switch fault.Action {
+	case FaultFail:
+		return Result{}, fmt.Errorf("injected failure in phase %s", name)
+	case FaultRequeue:
+		return ResultWithRequeue(fault.Duration), nil
+	default:
+		select {
+		case <-ctx.Done():
+			return Result{}, ctx.Err()
+		case <-time.After(fault.Duration):
+		}
+		return phase(ctx, log, clusterSpec)
+	}
+}
+
+var (
+	defaultPhaseFaultsMu sync.RWMutex
+	defaultPhaseFaults   *PhaseFaults
+)
+
+// SetDefaultPhaseFaults configures the faults injected by all the PhaseRunners created after
+// with NewPhaseRunner. Passing nil disables fault injection.
+func SetDefaultPhaseFaults(f *PhaseFaults) {
+	defaultPhaseFaultsMu.Lock()
+	defer defaultPhaseFaultsMu.Unlock()
+	defaultPhaseFaults = f
+}
+
+func getDefaultPhaseFaults() *PhaseFaults {
+	defaultPhaseFaultsMu.RLock()
+	defer defaultPhaseFaultsMu.RUnlock()
+	return defaultPhaseFaults
+}
+
+// PhaseName returns the name used to target a phase with a Fault: the name of the function or method
+// implementing it, without package or receiver. For example, "ReconcileControlPlane".
+func PhaseName(p Phase) string {
+	name := runtime.FuncForPC(reflect.ValueOf(p).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,152 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller"
+)
+
+func TestParsePhaseFaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		faults  string
+		want    []controller.Fault
+		wantErr string
+	}{
+		{
+			name:   "empty",
+			faults: "",
+		},
+		{
+			name:   "all actions",
+			faults: "ReconcileControlPlane=fail*2, ReconcileWorkers=delay:5s,ReconcileCNI=requeue:1m*1",
+			want: []controller.Fault{
+				{Phase: "ReconcileControlPlane", Action: controller.FaultFail, Times: 2},
+				{Phase: "ReconcileWorkers", Action: controller.FaultDelay, Duration: 5 * time.Second},
+				{Phase: "ReconcileCNI", Action: controller.FaultRequeue, Duration: time.Minute, Times: 1},
+			},
+		},
+		{
+			name:    "missing action",
+			faults:  "ReconcileControlPlane",
+			wantErr: "parsing phase fault ReconcileControlPlane: expected <phase>=<action>",
+		},
+		{
+			name:    "unknown action",
+			faults:  "ReconcileControlPlane=panic",
+			wantErr: "parsing phase fault ReconcileControlPlane=panic: unknown action panic",
+		},
+		{
+			name:    "delay without duration",
+			faults:  "ReconcileControlPlane=delay",
+			wantErr: "parsing phase fault ReconcileControlPlane=delay: delay requires a positive duration",
+		},
+		{
+			name:    "invalid times",
+			faults:  "ReconcileControlPlane=fail*0",
+			wantErr: "parsing phase fault ReconcileControlPlane=fail*0: invalid times 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := controller.ParsePhaseFaults(tt.faults)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(controller.NewPhaseFaults(tt.want...)))
+		})
+	}
+}
+
+func TestPhaseName(t *testing.T) {
+	g := NewWithT(t)
+	p := newPhase()
+	g.Expect(controller.PhaseName(p.run)).To(Equal("run"))
+	g.Expect(controller.PhaseName(phaseReturnError)).To(Equal("phaseReturnError"))
+}
+
+func TestPhaseRunnerRunWithFaultsFail(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	phase1 := newPhase()
+	faults := controller.NewPhaseFaults(controller.Fault{Phase: "run", Action: controller.FaultFail, Times: 1})
+	r := controller.NewPhaseRunner().Register(phase1.run).WithFaults(faults)
+
+	_, err := r.Run(ctx, test.NewNullLogger(), &cluster.Spec{})
+	g.Expect(err).To(MatchError("injected failure in phase run"))
+	g.Expect(phase1.executed).To(BeFalse())
+
+	_, err = r.Run(ctx, test.NewNullLogger(), &cluster.Spec{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(phase1.executed).To(BeTrue())
+	g.Expect(faults.Injected("run")).To(Equal(1))
+}
+
+func TestPhaseRunnerRunWithFaultsRequeue(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	phase1 := newPhase()
+	faults := controller.NewPhaseFaults(controller.Fault{Phase: "run", Action: controller.FaultRequeue, Duration: time.Minute})
+	r := controller.NewPhaseRunner().Register(phase1.run).WithFaults(faults)
+
+	for i := 0; i < 3; i++ {
+		result, err := r.Run(ctx, test.NewNullLogger(), &cluster.Spec{})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result.ToCtrlResult().RequeueAfter).To(Equal(time.Minute))
+	}
+	g.Expect(phase1.executed).To(BeFalse())
+	g.Expect(faults.Injected("run")).To(Equal(3))
+}
+
+func TestPhaseRunnerRunWithFaultsDelay(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	phase1 := newPhase()
+	faults := controller.NewPhaseFaults(controller.Fault{Phase: "run", Action: controller.FaultDelay, Duration: 10 * time.Millisecond})
+	r := controller.NewPhaseRunner().Register(phase1.run).WithFaults(faults)
+
+	start := time.Now()
+	_, err := r.Run(ctx, test.NewNullLogger(), &cluster.Spec{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(phase1.executed).To(BeTrue())
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 10*time.Millisecond))
+}
+
+func TestPhaseRunnerRunWithFaultsDelayContextCanceled(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	phase1 := newPhase()
+	faults := controller.NewPhaseFaults(controller.Fault{Phase: "run", Action: controller.FaultDelay, Duration: time.Hour})
+	r := controller.NewPhaseRunner().Register(phase1.run).WithFaults(faults)
+
+	_, err := r.Run(ctx, test.NewNullLogger(), &cluster.Spec{})
+	g.Expect(err).To(MatchError(context.Canceled))
+	g.Expect(phase1.executed).To(BeFalse())
+}
+
+func TestPhaseRunnerRunWithDefaultFaults(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	phase1 := newPhase()
+	controller.SetDefaultPhaseFaults(controller.NewPhaseFaults(controller.Fault{Phase: "phaseReturnError", Action: controller.FaultRequeue, Duration: time.Second}))
+	t.Cleanup(func() { controller.SetDefaultPhaseFaults(nil) })
+	r := controller.NewPhaseRunner().Register(
+		phaseReturnError,
+		phase1.run,
+	)
+
+	result, err := r.Run(ctx, test.NewNullLogger(), &cluster.Spec{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.ToCtrlResult().RequeueAfter).To(Equal(time.Second))
+	g.Expect(phase1.executed).To(BeFalse())
+}
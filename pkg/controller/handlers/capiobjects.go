@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -39,3 +41,24 @@ func CAPIObjectToCluster(log logr.Logger) handler.MapFunc {
 		}}
 	}
 }
+
+// CAPIMachineToCluster returns a request handler that enqueues an EKS-A Cluster reconcile request
+// for the CAPI Machines of a CAPI Cluster that contains the cluster name and namespace labels.
+func CAPIMachineToCluster(c client.Client, log logr.Logger) handler.MapFunc {
+	clusterHandler := CAPIObjectToCluster(log)
+	return func(o client.Object) []reconcile.Request {
+		clusterName, ok := o.GetLabels()[clusterv1.ClusterLabelName]
+		if !ok {
+			log.V(6).Info("Machine doesn't belong to a CAPI Cluster, ignoring", "name", o.GetName())
+			return nil
+		}
+
+		cluster := &clusterv1.Cluster{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: clusterName, Namespace: o.GetNamespace()}, cluster); err != nil {
+			log.V(6).Info("Failed getting CAPI Cluster of machine, ignoring", "name", o.GetName(), "cluster", clusterName, "error", err.Error())
+			return nil
+		}
+
+		return clusterHandler(cluster)
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"github.com/go-logr/logr"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -68,3 +70,66 @@ func TestCAPIObjectToCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestCAPIMachineToCluster(t *testing.T) {
+	capiCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "eksa-system",
+			Labels: map[string]string{
+				clusterapi.EKSAClusterLabelName:      "my-cluster",
+				clusterapi.EKSAClusterLabelNamespace: "my-namespace",
+			},
+		},
+	}
+	testCases := []struct {
+		testName     string
+		obj          client.Object
+		wantRequests []reconcile.Request
+	}{
+		{
+			testName:     "no cluster label",
+			obj:          &clusterv1.Machine{},
+			wantRequests: nil,
+		},
+		{
+			testName: "missing capi cluster",
+			obj: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "eksa-system",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: "other-cluster"},
+				},
+			},
+			wantRequests: nil,
+		},
+		{
+			testName: "machine of managed capi cluster",
+			obj: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "eksa-system",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: "my-cluster"},
+				},
+			},
+			wantRequests: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      "my-cluster",
+						Namespace: "my-namespace",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(capiCluster).Build()
+			handle := handlers.CAPIMachineToCluster(c, logr.New(logf.NullLogSink{}))
+			requests := handle(tt.obj)
+			g.Expect(requests).To(Equal(tt.wantRequests))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// VSphereCredentialsToClusters returns a request handler that enqueues a reconcile request for all the vSphere
+// EKS-A Clusters when the eksa-system vSphere credentials secret changes, so rotated credentials are propagated
+// to their workload clusters.
+func VSphereCredentialsToClusters(c client.Client, log logr.Logger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		if o.GetNamespace() != constants.EksaSystemNamespace || o.GetName() != constants.VSphereCredentialsName {
+			return nil
+		}
+
+		clusters := &anywherev1.ClusterList{}
+		if err := c.List(context.Background(), clusters); err != nil {
+			log.Error(err, "Failed listing clusters for vSphere credentials change")
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, cluster := range clusters.Items {
+			if cluster.Spec.DatacenterRef.Kind != anywherev1.VSphereDatacenterKind {
+				continue
+			}
+
+			log.Info("Enqueuing Cluster request coming from vSphere credentials change", "cluster", cluster.Name)
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: cluster.Namespace,
+					Name:      cluster.Name,
+				},
+			})
+		}
+
+		return requests
+	}
+}
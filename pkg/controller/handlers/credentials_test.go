@@ -0,0 +1,80 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller/handlers"
+)
+
+func TestVSphereCredentialsToClusters(t *testing.T) {
+	vsphereCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "my-namespace"},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind, Name: "my-datacenter"},
+		},
+	}
+	dockerCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-docker-cluster", Namespace: "my-namespace"},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{Kind: anywherev1.DockerDatacenterKind, Name: "my-datacenter"},
+		},
+	}
+	testCases := []struct {
+		testName     string
+		obj          client.Object
+		wantRequests []reconcile.Request
+	}{
+		{
+			testName: "other secret",
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "eksa-system"},
+			},
+			wantRequests: nil,
+		},
+		{
+			testName: "vsphere credentials in other namespace",
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vsphere-credentials", Namespace: "default"},
+			},
+			wantRequests: nil,
+		},
+		{
+			testName: "vsphere credentials",
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vsphere-credentials", Namespace: "eksa-system"},
+			},
+			wantRequests: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      "my-cluster",
+						Namespace: "my-namespace",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			g.Expect(anywherev1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vsphereCluster, dockerCluster).Build()
+			handle := handlers.VSphereCredentialsToClusters(c, logr.New(logf.NullLogSink{}))
+			requests := handle(tt.obj)
+			g.Expect(requests).To(Equal(tt.wantRequests))
+		})
+	}
+}
@@ -14,11 +14,13 @@ type Phase func(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec)
 // PhaseRunner allows to execute Phases in order.
 type PhaseRunner struct {
 	phases []Phase
+	faults *PhaseFaults
 }
 
 // NewPhaseRunner creates a new PhaseRunner without any Phases.
+// It injects the faults configured with SetDefaultPhaseFaults, if any.
 func NewPhaseRunner() PhaseRunner {
-	return PhaseRunner{}
+	return PhaseRunner{faults: getDefaultPhaseFaults()}
 }
 
 // Register adds a phase to the runnner.
@@ -27,12 +29,18 @@ func (r PhaseRunner) Register(phases ...Phase) PhaseRunner {
 	return r
 }
 
+// WithFaults injects faults in the phases of the runner. Only meant for tests.
+func (r PhaseRunner) WithFaults(faults *PhaseFaults) PhaseRunner {
+	r.faults = faults
+	return r
+}
+
 // Run will execute phases in the order they were registered until a phase
 // returns an error or a Result that requests to an interruption.
 func (r PhaseRunner) Run(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (Result, error) {
 	for _, p := range r.phases {
-		if r, err := p(ctx, log, clusterSpec); r.Return() {
-			return r, nil
+		if result, err := r.runPhase(ctx, log, p, clusterSpec); result.Return() {
+			return result, nil
 		} else if err != nil {
 			return Result{}, err
 		}
@@ -40,3 +48,11 @@ func (r PhaseRunner) Run(ctx context.Context, log logr.Logger, clusterSpec *clus
 
 	return Result{}, nil
 }
+
+func (r PhaseRunner) runPhase(ctx context.Context, log logr.Logger, p Phase, clusterSpec *cluster.Spec) (Result, error) {
+	if r.faults == nil {
+		return p(ctx, log, clusterSpec)
+	}
+
+	return r.faults.run(ctx, log, PhaseName(p), p, clusterSpec)
+}
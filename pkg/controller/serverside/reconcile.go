@@ -4,8 +4,12 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 )
 
@@ -31,11 +35,63 @@ func ReconcileObjects(ctx context.Context, c client.Client, objs []client.Object
 }
 
 func ReconcileObject(ctx context.Context, c client.Client, obj client.Object) error {
+	changes := controller.ObjectChangesFromContext(ctx)
+	// The patch might reset the type meta of the object, keep a reference to it to record the change.
+	ref := objectReference(obj)
+	var previousVersion string
+	if changes != nil {
+		var err error
+		if previousVersion, err = resourceVersion(ctx, c, obj); err != nil {
+			return errors.Wrapf(err, "failed to read object %s, %s/%s", obj.GetObjectKind().GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+		}
+	}
+
 	// Server side apply
 	err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldManager), client.ForceOwnership)
 	if err != nil {
 		return errors.Wrapf(err, "failed to reconcile object %s, %s/%s", obj.GetObjectKind().GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
 
+	if changes != nil {
+		recordChange(changes, ref, previousVersion, obj.GetResourceVersion())
+	}
+
 	return nil
 }
+
+// resourceVersion returns the current resource version of an object, empty if it doesn't exist.
+// It reads the object as unstructured so it bypasses the client cache, which might be stale or
+// not watching the object type.
+func resourceVersion(ctx context.Context, c client.Client, obj client.Object) (string, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), u); apierrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return u.GetResourceVersion(), nil
+}
+
+func objectReference(obj client.Object) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: obj.GetObjectKind().GroupVersionKind().GroupVersion().String(),
+			Kind:       obj.GetObjectKind().GroupVersionKind().Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+		},
+	}
+}
+
+func recordChange(changes *controller.ObjectChanges, ref client.Object, previousVersion, currentVersion string) {
+	switch {
+	case previousVersion == "":
+		changes.Created(ref)
+	case previousVersion != currentVersion:
+		changes.Updated(ref)
+	}
+}
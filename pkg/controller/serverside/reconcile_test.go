@@ -11,6 +11,7 @@ import (
 	clusterapiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/serverside"
 )
 
@@ -122,6 +123,31 @@ spec:
 	}
 }
 
+func TestReconcileObjectRecordsChanges(t *testing.T) {
+	g := NewWithT(t)
+	c := env.Client()
+	ns := env.CreateNamespaceForTest(context.Background(), t)
+	ctx, changes := controller.WithObjectChanges(context.Background())
+
+	existing := newCluster("cluster-1")
+	existing.SetNamespace(ns)
+	g.Expect(c.Create(ctx, existing)).To(Succeed())
+
+	updated := newCluster("cluster-1", func(c capiCluster) { c.Spec.Paused = true })
+	updated.SetNamespace(ns)
+	created := newCluster("cluster-2")
+	created.SetNamespace(ns)
+
+	g.Expect(serverside.ReconcileObject(ctx, c, created)).To(Succeed())
+	g.Expect(serverside.ReconcileObject(ctx, c, updated)).To(Succeed())
+	g.Expect(changes.CreatedObjects()).To(ConsistOf("Cluster " + ns + "/cluster-2"))
+	g.Expect(changes.UpdatedObjects()).To(ConsistOf("Cluster " + ns + "/cluster-1"))
+
+	ctx, changes = controller.WithObjectChanges(context.Background())
+	g.Expect(serverside.ReconcileObject(ctx, c, updated.DeepCopy())).To(Succeed())
+	g.Expect(changes.Empty()).To(BeTrue())
+}
+
 type capiCluster = *clusterapiv1.Cluster
 
 func newCluster(name string, changes ...func(capiCluster)) *clusterapiv1.Cluster {
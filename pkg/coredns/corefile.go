@@ -0,0 +1,92 @@
+// Package coredns renders the CoreDNS configuration of EKS-A clusters.
+package coredns
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap kubeadm creates with the CoreDNS Corefile.
+	ConfigMapName = "coredns"
+	// CorefileKey is the key of the Corefile in the CoreDNS ConfigMap.
+	CorefileKey = "Corefile"
+
+	defaultUpstream = "/etc/resolv.conf"
+)
+
+// Corefile returns the CoreDNS Corefile for a configuration. It is the Corefile kubeadm
+// generates, forwarding to the upstream servers, with a server block per stub domain
+// followed by the custom server blocks. A nil configuration returns the kubeadm Corefile.
+func Corefile(config *v1alpha1.CoreDNSConfiguration) string {
+	if config == nil {
+		config = &v1alpha1.CoreDNSConfiguration{}
+	}
+
+	upstream := defaultUpstream
+	if len(config.UpstreamServers) > 0 {
+		upstream = strings.Join(config.UpstreamServers, " ")
+	}
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, `.:53 {
+    errors
+    health {
+       lameduck 5s
+    }
+    ready
+    kubernetes %s in-addr.arpa ip6.arpa {
+       pods insecure
+       fallthrough in-addr.arpa ip6.arpa
+       ttl 30
+    }
+    prometheus :9153
+    forward . %s {
+       max_concurrent 1000
+    }
+    cache 30
+    loop
+    reload
+    loadbalance
+}
+`, constants.DefaultClusterDomain, upstream)
+
+	for _, stub := range config.StubDomains {
+		fmt.Fprintf(b, `%s:53 {
+    errors
+    cache 30
+    forward . %s
+}
+`, strings.TrimSuffix(stub.Domain, "."), strings.Join(stub.Servers, " "))
+	}
+
+	if blocks := strings.TrimSpace(config.CustomServerBlocks); blocks != "" {
+		b.WriteString(blocks)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// ConfigMap returns the CoreDNS ConfigMap with the Corefile for the cluster.
+func ConfigMap(cluster *v1alpha1.Cluster) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName,
+			Namespace: constants.KubeSystemNamespace,
+		},
+		Data: map[string]string{
+			CorefileKey: Corefile(cluster.Spec.CoreDNSConfiguration),
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package coredns_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/coredns"
+)
+
+const defaultCorefile = `.:53 {
+    errors
+    health {
+       lameduck 5s
+    }
+    ready
+    kubernetes cluster.local in-addr.arpa ip6.arpa {
+       pods insecure
+       fallthrough in-addr.arpa ip6.arpa
+       ttl 30
+    }
+    prometheus :9153
+    forward . /etc/resolv.conf {
+       max_concurrent 1000
+    }
+    cache 30
+    loop
+    reload
+    loadbalance
+}
+`
+
+func TestCorefileDefault(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(coredns.Corefile(nil)).To(Equal(defaultCorefile))
+	g.Expect(coredns.Corefile(&v1alpha1.CoreDNSConfiguration{})).To(Equal(defaultCorefile))
+}
+
+func TestCorefileCustomized(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.CoreDNSConfiguration{
+		UpstreamServers: []string{"10.0.0.2", "10.0.0.3:5353"},
+		StubDomains: []v1alpha1.CoreDNSStubDomain{
+			{Domain: "corp.example.com.", Servers: []string{"10.1.0.2"}},
+			{Domain: "10.in-addr.arpa", Servers: []string{"10.1.0.2", "10.1.0.3"}},
+		},
+		CustomServerBlocks: "\nlab.example.com:53 {\n    file /etc/coredns/lab.db\n}\n\n",
+	}
+
+	g.Expect(coredns.Corefile(config)).To(Equal(`.:53 {
+    errors
+    health {
+       lameduck 5s
+    }
+    ready
+    kubernetes cluster.local in-addr.arpa ip6.arpa {
+       pods insecure
+       fallthrough in-addr.arpa ip6.arpa
+       ttl 30
+    }
+    prometheus :9153
+    forward . 10.0.0.2 10.0.0.3:5353 {
+       max_concurrent 1000
+    }
+    cache 30
+    loop
+    reload
+    loadbalance
+}
+corp.example.com:53 {
+    errors
+    cache 30
+    forward . 10.1.0.2
+}
+10.in-addr.arpa:53 {
+    errors
+    cache 30
+    forward . 10.1.0.2 10.1.0.3
+}
+lab.example.com:53 {
+    file /etc/coredns/lab.db
+}
+`))
+}
+
+func TestConfigMap(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{}
+
+	cm := coredns.ConfigMap(cluster)
+	g.Expect(cm.Name).To(Equal("coredns"))
+	g.Expect(cm.Namespace).To(Equal(constants.KubeSystemNamespace))
+	g.Expect(cm.Kind).To(Equal("ConfigMap"))
+	g.Expect(cm.Data).To(HaveKeyWithValue(coredns.CorefileKey, defaultCorefile))
+}
@@ -0,0 +1,363 @@
+// Package credentials reads the provider credentials file, a single file holding named profiles
+// with the credentials of each provider. The credentials of a profile are exported to the
+// environment variables the providers read them from.
+package credentials
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/ini.v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/aws"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+// DefaultProfile is the profile used when neither the CLI flags nor the cluster spec set one.
+const DefaultProfile = "default"
+
+const redacted = "<redacted>"
+
+// pathEnvVars hold paths to credentials files instead of credentials, they aren't redacted from the logs.
+var pathEnvVars = map[string]bool{
+	aws.EksaAwsCredentialsFileKey: true,
+	aws.EksaAwsCABundlesFileKey:   true,
+}
+
+// Secret is a credential value that is never printed.
+type Secret string
+
+// String implements fmt.Stringer, hiding the value.
+func (s Secret) String() string {
+	return redacted
+}
+
+// GoString implements fmt.GoStringer, hiding the value.
+func (s Secret) GoString() string {
+	return redacted
+}
+
+// File is the content of a credentials file, the profiles of each provider by name.
+type File struct {
+	VSphere    map[string]VSphereProfile    `json:"vsphere,omitempty"`
+	CloudStack map[string]CloudStackProfile `json:"cloudstack,omitempty"`
+	Nutanix    map[string]NutanixProfile    `json:"nutanix,omitempty"`
+	Snow       map[string]SnowProfile       `json:"snow,omitempty"`
+}
+
+// UserCredentials is a username and password pair.
+type UserCredentials struct {
+	Username string `json:"username"`
+	Password Secret `json:"password"`
+}
+
+// VSphereProfile holds the vSphere credentials. The cloud provider and CSI driver
+// credentials default to the main ones.
+type VSphereProfile struct {
+	UserCredentials `json:",inline"`
+	CloudProvider   *UserCredentials `json:"cloudProvider,omitempty"`
+	CSI             *UserCredentials `json:"csi,omitempty"`
+}
+
+// CloudStackProfile holds the credentials of each CloudStack management endpoint. Their name
+// is the credentialsRef of the availability zones.
+type CloudStackProfile []CloudStackCredentials
+
+// CloudStackCredentials are the credentials of a CloudStack management endpoint.
+type CloudStackCredentials struct {
+	Name      string `json:"name"`
+	APIKey    Secret `json:"apiKey"`
+	SecretKey Secret `json:"secretKey"`
+	APIURL    string `json:"apiUrl"`
+	VerifySSL *bool  `json:"verifySsl,omitempty"`
+}
+
+// NutanixProfile holds the Prism Central credentials.
+type NutanixProfile struct {
+	UserCredentials `json:",inline"`
+}
+
+// SnowProfile holds the paths to the Snow devices credentials and CA bundles files.
+type SnowProfile struct {
+	CredentialsFile string `json:"credentialsFile"`
+	CABundlesFile   string `json:"caBundlesFile"`
+}
+
+// ReadFile reads and validates a credentials file.
+func ReadFile(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading credentials file: %v", err)
+	}
+
+	return Parse(content)
+}
+
+// Parse parses and validates the content of a credentials file. Unknown and duplicated fields
+// are rejected. Errors never include the secret values of the file.
+func Parse(content []byte) (*File, error) {
+	f := &File{}
+	if err := yaml.UnmarshalStrict(content, f); err != nil {
+		return nil, fmt.Errorf("parsing credentials file: %s", redact(err.Error(), content))
+	}
+
+	if err := f.validate(); err != nil {
+		return nil, fmt.Errorf("invalid credentials file: %v", err)
+	}
+
+	return f, nil
+}
+
+// redact replaces the values of the secret fields in msg. The content is parsed leniently
+// since it might not match the File schema.
+func redact(msg string, content []byte) string {
+	var raw interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return msg
+	}
+
+	for _, secret := range secretValues(raw, false) {
+		msg = strings.ReplaceAll(msg, secret, redacted)
+	}
+	return msg
+}
+
+var secretFields = map[string]bool{"password": true, "apiKey": true, "secretKey": true}
+
+func secretValues(node interface{}, secret bool) []string {
+	var values []string
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, v := range n {
+			values = append(values, secretValues(v, secretFields[k])...)
+		}
+	case []interface{}:
+		for _, v := range n {
+			values = append(values, secretValues(v, secret)...)
+		}
+	default:
+		if s := fmt.Sprint(n); secret && s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+func (f *File) validate() error {
+	var errs []string
+	for name, p := range f.VSphere {
+		errs = append(errs, prefix("vsphere."+name, p.validate())...)
+	}
+	for name, p := range f.CloudStack {
+		errs = append(errs, prefix("cloudstack."+name, p.validate())...)
+	}
+	for name, p := range f.Nutanix {
+		errs = append(errs, prefix("nutanix."+name, p.UserCredentials.validate())...)
+	}
+	for name, p := range f.Snow {
+		errs = append(errs, prefix("snow."+name, p.validate())...)
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func prefix(path string, errs []string) []string {
+	for i := range errs {
+		errs[i] = path + errs[i]
+	}
+	return errs
+}
+
+func (c UserCredentials) validate() []string {
+	var errs []string
+	if c.Username == "" {
+		errs = append(errs, ".username is required")
+	}
+	if c.Password == "" {
+		errs = append(errs, ".password is required")
+	}
+	return errs
+}
+
+func (p VSphereProfile) validate() []string {
+	errs := p.UserCredentials.validate()
+	if p.CloudProvider != nil {
+		errs = append(errs, prefix(".cloudProvider", p.CloudProvider.validate())...)
+	}
+	if p.CSI != nil {
+		errs = append(errs, prefix(".csi", p.CSI.validate())...)
+	}
+	return errs
+}
+
+func (p CloudStackProfile) validate() []string {
+	if len(p) == 0 {
+		return []string{" requires at least one endpoint"}
+	}
+
+	var errs []string
+	names := map[string]bool{}
+	for i, c := range p {
+		path := fmt.Sprintf("[%d]", i)
+		name := strings.ToLower(c.Name)
+		switch {
+		case name == "":
+			errs = append(errs, path+".name is required")
+		case names[name]:
+			errs = append(errs, fmt.Sprintf("%s.name %s is duplicated", path, c.Name))
+		}
+		names[name] = true
+		if c.APIKey == "" {
+			errs = append(errs, path+".apiKey is required")
+		}
+		if c.SecretKey == "" {
+			errs = append(errs, path+".secretKey is required")
+		}
+		if c.APIURL == "" {
+			errs = append(errs, path+".apiUrl is required")
+		}
+	}
+	return errs
+}
+
+func (p SnowProfile) validate() []string {
+	var errs []string
+	if p.CredentialsFile == "" {
+		errs = append(errs, ".credentialsFile is required")
+	}
+	if p.CABundlesFile == "" {
+		errs = append(errs, ".caBundlesFile is required")
+	}
+	return errs
+}
+
+// EnvVars returns the environment variables the provider of the datacenter kind reads its
+// credentials from, set to the values of the profile. It returns no variables for the
+// providers without credentials.
+func (f *File) EnvVars(datacenterKind, profile string) (map[string]string, error) {
+	switch datacenterKind {
+	case v1alpha1.VSphereDatacenterKind:
+		p, ok := f.VSphere[profile]
+		if !ok {
+			return nil, profileNotFound("vsphere", profile)
+		}
+		return p.envVars(), nil
+	case v1alpha1.CloudStackDatacenterKind:
+		p, ok := f.CloudStack[profile]
+		if !ok {
+			return nil, profileNotFound("cloudstack", profile)
+		}
+		return p.envVars()
+	case v1alpha1.NutanixDatacenterKind:
+		p, ok := f.Nutanix[profile]
+		if !ok {
+			return nil, profileNotFound("nutanix", profile)
+		}
+		return map[string]string{
+			constants.NutanixUsernameKey: p.Username,
+			constants.NutanixPasswordKey: string(p.Password),
+		}, nil
+	case v1alpha1.SnowDatacenterKind:
+		p, ok := f.Snow[profile]
+		if !ok {
+			return nil, profileNotFound("snow", profile)
+		}
+		return map[string]string{
+			aws.EksaAwsCredentialsFileKey: p.CredentialsFile,
+			aws.EksaAwsCABundlesFileKey:   p.CABundlesFile,
+		}, nil
+	default:
+		return map[string]string{}, nil
+	}
+}
+
+func profileNotFound(provider, profile string) error {
+	return fmt.Errorf("profile %s not found for %s in credentials file", profile, provider)
+}
+
+func (p VSphereProfile) envVars() map[string]string {
+	cloudProvider, csi := p.UserCredentials, p.UserCredentials
+	if p.CloudProvider != nil {
+		cloudProvider = *p.CloudProvider
+	}
+	if p.CSI != nil {
+		csi = *p.CSI
+	}
+
+	return map[string]string{
+		config.EksavSphereUsernameKey:    p.Username,
+		config.EksavSpherePasswordKey:    string(p.Password),
+		config.EksavSphereCPUsernameKey:  cloudProvider.Username,
+		config.EksavSphereCPPasswordKey:  string(cloudProvider.Password),
+		config.EksavSphereCSIUsernameKey: csi.Username,
+		config.EksavSphereCSIPasswordKey: string(csi.Password),
+	}
+}
+
+// envVars encodes the profile in the base64 ini format of the CloudStack provider.
+func (p CloudStackProfile) envVars() (map[string]string, error) {
+	cfg := ini.Empty()
+	for _, c := range p {
+		section, err := cfg.NewSection(c.Name)
+		if err != nil {
+			return nil, fmt.Errorf("encoding cloudstack credentials %s: %v", c.Name, err)
+		}
+		verifySSL := true
+		if c.VerifySSL != nil {
+			verifySSL = *c.VerifySSL
+		}
+		section.Key("api-key").SetValue(string(c.APIKey))
+		section.Key("secret-key").SetValue(string(c.SecretKey))
+		section.Key("api-url").SetValue(c.APIURL)
+		section.Key("verify-ssl").SetValue(strconv.FormatBool(verifySSL))
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := cfg.WriteTo(buf); err != nil {
+		return nil, fmt.Errorf("encoding cloudstack credentials: %v", err)
+	}
+
+	return map[string]string{
+		decoder.EksacloudStackCloudConfigB64SecretKey: base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+// Load reads the credentials file and exports the credentials of the profile for the provider
+// of the datacenter kind to the environment, overriding the variables already set.
+func Load(path, datacenterKind, profile string) error {
+	f, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	envVars, err := f.EnvVars(datacenterKind, profile)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range envVars {
+		if err := os.Setenv(k, v); err != nil {
+			return fmt.Errorf("setting %s from credentials file: %v", k, err)
+		}
+		if !pathEnvVars[k] {
+			logger.RegisterSecrets(v)
+		}
+	}
+
+	return nil
+}
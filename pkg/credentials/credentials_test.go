@@ -0,0 +1,204 @@
+package credentials_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/credentials"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+const credentialsFile = `
+vsphere:
+  default:
+    username: admin
+    password: vsphere-password
+    csi:
+      username: csi
+      password: csi-password
+cloudstack:
+  default:
+  - name: global
+    apiKey: api-key
+    secretKey: secret-key
+    apiUrl: https://cloudstack.example.com/client/api
+    verifySsl: false
+nutanix:
+  prod:
+    username: nutanix
+    password: nutanix-password
+snow:
+  default:
+    credentialsFile: /home/user/snow/credentials
+    caBundlesFile: /home/user/snow/ca-bundles
+`
+
+func TestParseEnvVarsVSphere(t *testing.T) {
+	g := NewWithT(t)
+	f, err := credentials.Parse([]byte(credentialsFile))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(f.EnvVars(v1alpha1.VSphereDatacenterKind, credentials.DefaultProfile)).To(Equal(map[string]string{
+		"EKSA_VSPHERE_USERNAME":     "admin",
+		"EKSA_VSPHERE_PASSWORD":     "vsphere-password",
+		"EKSA_VSPHERE_CP_USERNAME":  "admin",
+		"EKSA_VSPHERE_CP_PASSWORD":  "vsphere-password",
+		"EKSA_VSPHERE_CSI_USERNAME": "csi",
+		"EKSA_VSPHERE_CSI_PASSWORD": "csi-password",
+	}))
+}
+
+func TestParseEnvVarsCloudStack(t *testing.T) {
+	g := NewWithT(t)
+	f, err := credentials.Parse([]byte(credentialsFile))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	envVars, err := f.EnvVars(v1alpha1.CloudStackDatacenterKind, credentials.DefaultProfile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(envVars).To(HaveKey(decoder.EksacloudStackCloudConfigB64SecretKey))
+
+	t.Setenv(decoder.EksacloudStackCloudConfigB64SecretKey, envVars[decoder.EksacloudStackCloudConfigB64SecretKey])
+	execConfig, err := decoder.ParseCloudStackSecret()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(execConfig.Profiles).To(Equal([]decoder.CloudStackProfileConfig{{
+		Name:          "global",
+		ApiKey:        "api-key",
+		SecretKey:     "secret-key",
+		ManagementUrl: "https://cloudstack.example.com/client/api",
+		VerifySsl:     "false",
+	}}))
+}
+
+func TestParseEnvVarsNutanixAndSnow(t *testing.T) {
+	g := NewWithT(t)
+	f, err := credentials.Parse([]byte(credentialsFile))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(f.EnvVars(v1alpha1.NutanixDatacenterKind, "prod")).To(Equal(map[string]string{
+		"NUTANIX_USER":     "nutanix",
+		"NUTANIX_PASSWORD": "nutanix-password",
+	}))
+	g.Expect(f.EnvVars(v1alpha1.SnowDatacenterKind, credentials.DefaultProfile)).To(Equal(map[string]string{
+		"EKSA_AWS_CREDENTIALS_FILE": "/home/user/snow/credentials",
+		"EKSA_AWS_CA_BUNDLES_FILE":  "/home/user/snow/ca-bundles",
+	}))
+	g.Expect(f.EnvVars(v1alpha1.DockerDatacenterKind, credentials.DefaultProfile)).To(BeEmpty())
+}
+
+func TestEnvVarsProfileNotFound(t *testing.T) {
+	g := NewWithT(t)
+	f, err := credentials.Parse([]byte(credentialsFile))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = f.EnvVars(v1alpha1.NutanixDatacenterKind, credentials.DefaultProfile)
+	g.Expect(err).To(MatchError("profile default not found for nutanix in credentials file"))
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			content: "vsphere:\n  default:\n    username: admin\n    passwd: secret\n",
+			wantErr: `parsing credentials file: error unmarshaling JSON: while decoding JSON: json: unknown field "passwd"`,
+		},
+		{
+			name:    "unknown provider",
+			content: "docker: {}\n",
+			wantErr: `parsing credentials file: error unmarshaling JSON: while decoding JSON: json: unknown field "docker"`,
+		},
+		{
+			name:    "missing fields",
+			content: "vsphere:\n  default:\n    username: admin\n    csi:\n      password: secret\n",
+			wantErr: "invalid credentials file: vsphere.default.csi.username is required, vsphere.default.password is required",
+		},
+		{
+			name:    "duplicated cloudstack endpoint",
+			content: "cloudstack:\n  default:\n  - {name: az, apiKey: a, secretKey: s, apiUrl: u}\n  - {name: AZ, apiKey: a, secretKey: s, apiUrl: u}\n",
+			wantErr: "invalid credentials file: cloudstack.default[1].name AZ is duplicated",
+		},
+		{
+			name:    "empty cloudstack profile",
+			content: "cloudstack:\n  default: []\n",
+			wantErr: "invalid credentials file: cloudstack.default requires at least one endpoint",
+		},
+		{
+			name:    "snow",
+			content: "snow:\n  default:\n    credentialsFile: creds\n",
+			wantErr: "invalid credentials file: snow.default.caBundlesFile is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := credentials.Parse([]byte(tt.content))
+			g.Expect(err).To(MatchError(tt.wantErr))
+		})
+	}
+}
+
+func TestParseErrorRedactsSecrets(t *testing.T) {
+	g := NewWithT(t)
+	content := "nutanix:\n  default:\n    username: admin\n    password: [\"s3cr3t\"]\n"
+
+	_, err := credentials.Parse([]byte(content))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).NotTo(ContainSubstring("s3cr3t"))
+}
+
+func TestSecretString(t *testing.T) {
+	g := NewWithT(t)
+	profile := credentials.NutanixProfile{
+		UserCredentials: credentials.UserCredentials{Username: "admin", Password: "s3cr3t"},
+	}
+
+	g.Expect(fmt.Sprintf("%v", profile)).NotTo(ContainSubstring("s3cr3t"))
+	g.Expect(fmt.Sprintf("%+v", profile)).NotTo(ContainSubstring("s3cr3t"))
+	g.Expect(fmt.Sprintf("%#v", profile)).NotTo(ContainSubstring("s3cr3t"))
+}
+
+func TestLoad(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "credentials.yaml")
+	g.Expect(os.WriteFile(path, []byte(credentialsFile), 0o600)).To(Succeed())
+	t.Setenv("NUTANIX_USER", "old")
+	t.Setenv("NUTANIX_PASSWORD", "old")
+
+	g.Expect(credentials.Load(path, v1alpha1.NutanixDatacenterKind, "prod")).To(Succeed())
+	g.Expect(os.Getenv("NUTANIX_USER")).To(Equal("nutanix"))
+	g.Expect(os.Getenv("NUTANIX_PASSWORD")).To(Equal("nutanix-password"))
+}
+
+func TestLoadFileNotFound(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(credentials.Load("missing.yaml", v1alpha1.NutanixDatacenterKind, "prod")).To(
+		MatchError(ContainSubstring("reading credentials file")),
+	)
+}
+
+func TestCloudStackSecretEncoding(t *testing.T) {
+	g := NewWithT(t)
+	f, err := credentials.Parse([]byte("cloudstack:\n  default:\n  - {name: az, apiKey: 'a#b;c', secretKey: s, apiUrl: u}\n"))
+	g.Expect(err).NotTo(HaveOccurred())
+	envVars, err := f.EnvVars(v1alpha1.CloudStackDatacenterKind, credentials.DefaultProfile)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	decoded, err := base64.StdEncoding.DecodeString(envVars[decoder.EksacloudStackCloudConfigB64SecretKey])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(decoded)).To(ContainSubstring("[az]"))
+
+	t.Setenv(decoder.EksacloudStackCloudConfigB64SecretKey, envVars[decoder.EksacloudStackCloudConfigB64SecretKey])
+	execConfig, err := decoder.ParseCloudStackSecret()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(execConfig.Profiles[0].ApiKey).To(Equal("a#b;c"))
+	g.Expect(execConfig.Profiles[0].VerifySsl).To(Equal("true"))
+}
@@ -12,8 +12,8 @@ import (
 	"github.com/aws/eks-anywhere-packages/pkg/artifacts"
 	"github.com/aws/eks-anywhere-packages/pkg/bundle"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
-	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -43,14 +43,16 @@ func parseKubeVersion(kubeVersion string) (string, string, error) {
 	return major, minor, nil
 }
 
+// GetVersionBundle returns the VersionsBundle for the cluster Kubernetes version. Curated packages don't
+// depend on the EKS-D release, so EKS-D release pins aren't applied.
 func GetVersionBundle(reader Reader, eksaVersion string, spec *v1alpha1.Cluster) (*releasev1.VersionsBundle, error) {
 	b, err := reader.ReadBundlesForVersion(eksaVersion)
 	if err != nil {
 		return nil, err
 	}
-	versionsBundle, err := cluster.GetVersionsBundle(spec, b)
-	if err != nil {
-		return nil, err
+	versionsBundle := bundles.VersionsBundleForKubernetesVersion(b, string(spec.Spec.KubernetesVersion))
+	if versionsBundle == nil {
+		return nil, fmt.Errorf("kubernetes version %s is not supported by bundles manifest %d", spec.Spec.KubernetesVersion, b.Spec.Number)
 	}
 	return versionsBundle, nil
 }
@@ -0,0 +1,258 @@
+package oras
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/utils/urls"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// DefaultCopyWorkers is the default number of artifacts copied in parallel.
+const DefaultCopyWorkers = 4
+
+// dockerManifestMediaTypes are cached by the copy along the OCI manifests so they are pushed after their layers.
+var dockerManifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}
+
+// Registry copies artifacts between registries.
+type Registry interface {
+	// Copy copies the src artifact with all its content to dst without modifying it and returns its digest.
+	Copy(ctx context.Context, src, dst string) (string, error)
+	// Digest returns the digest of the artifact in the destination registry.
+	Digest(ctx context.Context, ref string) (string, error)
+}
+
+// OrasRegistry copies artifacts between two registries with oras.
+type OrasRegistry struct {
+	src, dst *content.Registry
+}
+
+// NewOrasRegistry builds an OrasRegistry copying artifacts from src to dst.
+func NewOrasRegistry(src, dst *content.Registry) *OrasRegistry {
+	return &OrasRegistry{
+		src: src,
+		dst: dst,
+	}
+}
+
+// Copy copies the manifests and layers of the src artifact to dst. Layers already present in
+// the destination aren't pushed again and the layers of each manifest are copied in parallel.
+func (r *OrasRegistry) Copy(ctx context.Context, src, dst string) (string, error) {
+	// oras pushes the root manifest by digest, so the destination can't include it twice.
+	dst, _, _ = strings.Cut(dst, "@")
+	desc, err := oras.Copy(ctx, r.src, src, r.dst, dst,
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithAdditionalCachedMediaTypes(dockerManifestMediaTypes...),
+	)
+	if err != nil {
+		return "", err
+	}
+	return desc.Digest.String(), nil
+}
+
+// Digest returns the digest of the artifact ref in the destination registry.
+func (r *OrasRegistry) Digest(ctx context.Context, ref string) (string, error) {
+	_, desc, err := r.dst.Resolve(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	return desc.Digest.String(), nil
+}
+
+// PackagesArtifacts returns the package bundles of the bundles and the helm charts and images of their curated packages.
+func PackagesArtifacts(ctx context.Context, bundles *releasev1.Bundles) ([]string, error) {
+	var artifacts []string
+	for _, vb := range bundles.Spec.VersionsBundles {
+		bundleRef, err := curatedpackages.GetPackageBundleRef(vb)
+		if err != nil {
+			return nil, fmt.Errorf("reading package bundle reference for kubernetes %s: %v", vb.KubeVersion, err)
+		}
+		packages, err := curatedpackages.ReadPackagesArtifacts(ctx, vb, bundleRef)
+		if err != nil {
+			return nil, fmt.Errorf("reading package bundle %s: %v", bundleRef, err)
+		}
+		artifacts = append(artifacts, bundleRef)
+		for _, p := range packages {
+			artifacts = append(artifacts, p.URI)
+		}
+	}
+	return UniqueCharts(artifacts), nil
+}
+
+// copyState are the artifacts already copied to the destination registry, persisted so an interrupted
+// copy can be resumed.
+type copyState struct {
+	// Copied are the digests of the copied artifacts indexed by their destination reference.
+	Copied map[string]string `json:"copied"`
+}
+
+// RegistryCopier copies artifacts to a registry preserving their digests.
+type RegistryCopier struct {
+	registry    Registry
+	dstRegistry string
+	stateFile   string
+	workers     int
+
+	lock  sync.Mutex
+	state copyState
+}
+
+// NewRegistryCopier builds a RegistryCopier that copies artifacts to dstRegistry, keeping their repositories
+// and tags, with workers artifacts in parallel. It records the copied artifacts in stateFile.
+func NewRegistryCopier(registry Registry, dstRegistry, stateFile string, workers int) *RegistryCopier {
+	if workers < 1 {
+		workers = DefaultCopyWorkers
+	}
+	return &RegistryCopier{
+		registry:    registry,
+		dstRegistry: dstRegistry,
+		stateFile:   stateFile,
+		workers:     workers,
+	}
+}
+
+// Copy copies the artifacts to the destination registry and verifies their digests there afterwards.
+// Artifacts recorded in the state file by a previous copy are skipped if the destination registry
+// still has them with the same digest.
+func (c *RegistryCopier) Copy(ctx context.Context, artifacts []string) error {
+	if err := c.readState(); err != nil {
+		return err
+	}
+
+	jobs := make(chan string)
+	errs := make(chan error, len(artifacts))
+	wg := sync.WaitGroup{}
+	for i := 0; i < c.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for src := range jobs {
+				if err := c.copyArtifact(ctx, src); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	for _, a := range artifacts {
+		jobs <- a
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+
+	var copyErrs []error
+	for err := range errs {
+		copyErrs = append(copyErrs, err)
+	}
+	if len(copyErrs) > 0 {
+		return fmt.Errorf("copying %d of %d artifacts failed, run the copy again to resume it: %v",
+			len(copyErrs), len(artifacts), kerrors.NewAggregate(copyErrs))
+	}
+
+	return c.verify(ctx, artifacts)
+}
+
+func (c *RegistryCopier) copyArtifact(ctx context.Context, src string) error {
+	dst := urls.ReplaceHost(src, c.dstRegistry)
+
+	if digest, ok := c.copiedDigest(dst); ok {
+		if current, err := c.registry.Digest(ctx, dst); err == nil && current == digest {
+			logger.V(4).Info("Skipping artifact already copied", "artifact", dst, "digest", digest)
+			return nil
+		}
+	}
+
+	logger.V(2).Info("Copying artifact", "source", src, "destination", dst)
+	digest, err := c.registry.Copy(ctx, src, dst)
+	if err != nil {
+		return fmt.Errorf("copying %s to %s: %v", src, dst, err)
+	}
+	logger.Info("Copied artifact", "artifact", dst, "digest", digest)
+
+	return c.recordCopied(dst, digest)
+}
+
+// verify checks the destination registry has all the artifacts with the digests they were copied with.
+func (c *RegistryCopier) verify(ctx context.Context, artifacts []string) error {
+	logger.Info("Verifying copied artifacts", "registry", c.dstRegistry)
+	var failed []string
+	for _, src := range artifacts {
+		dst := urls.ReplaceHost(src, c.dstRegistry)
+		want, _ := c.copiedDigest(dst)
+		got, err := c.registry.Digest(ctx, dst)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", dst, err))
+			continue
+		}
+		if got != want {
+			failed = append(failed, fmt.Sprintf("%s: digest %s doesn't match copied digest %s", dst, got, want))
+		}
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("verifying copied artifacts: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (c *RegistryCopier) copiedDigest(dst string) (string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	digest, ok := c.state.Copied[dst]
+	return digest, ok
+}
+
+func (c *RegistryCopier) readState() error {
+	c.state = copyState{Copied: map[string]string{}}
+	data, err := os.ReadFile(c.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading copy state file: %v", err)
+	}
+	if err := json.Unmarshal(data, &c.state); err != nil {
+		return fmt.Errorf("parsing copy state file %s: %v", c.stateFile, err)
+	}
+	if c.state.Copied == nil {
+		c.state.Copied = map[string]string{}
+	}
+	return nil
+}
+
+// recordCopied adds the artifact to the state and persists it, so it survives an interruption of the copy.
+func (c *RegistryCopier) recordCopied(dst, digest string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.state.Copied[dst] = digest
+
+	data, err := json.MarshalIndent(c.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first, so an interruption never leaves a truncated state file.
+	tmp := c.stateFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return fmt.Errorf("writing copy state file: %v", err)
+	}
+	if err := os.Rename(tmp, c.stateFile); err != nil {
+		return fmt.Errorf("writing copy state file: %v", err)
+	}
+	return nil
+}
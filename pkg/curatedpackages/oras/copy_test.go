@@ -0,0 +1,135 @@
+package oras_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/oras/mocks"
+)
+
+const (
+	dstRegistry  = "harbor.example.com:8443"
+	chartSrc     = "public.ecr.aws/eks-anywhere/hello-eks-anywhere:0.1.1"
+	chartDst     = "harbor.example.com:8443/eks-anywhere/hello-eks-anywhere:0.1.1"
+	chartDigest  = "sha256:c8c9a0d62c4c2a7f1b7ac1eaa6f4c5d1c6a7b8f2d9f1c2b3a4d5e6f708192a3b"
+	imageDigest  = "sha256:0f0b2b6e9f3b2d4c5a6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4"
+	imageSrc     = "783794618700.dkr.ecr.us-west-2.amazonaws.com/hello-eks-anywhere@" + imageDigest
+	imageDst     = "harbor.example.com:8443/hello-eks-anywhere@" + imageDigest
+	otherDigest  = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	stateContent = `{
+  "copied": {
+    "` + chartDst + `": "` + chartDigest + `"
+  }
+}`
+)
+
+type copierTest struct {
+	*WithT
+	ctx       context.Context
+	registry  *mocks.MockRegistry
+	stateFile string
+	copier    *oras.RegistryCopier
+	artifacts []string
+}
+
+func newCopierTest(t *testing.T) *copierTest {
+	ctrl := gomock.NewController(t)
+	registry := mocks.NewMockRegistry(ctrl)
+	stateFile := filepath.Join(t.TempDir(), "copy-state.json")
+	return &copierTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		registry:  registry,
+		stateFile: stateFile,
+		copier:    oras.NewRegistryCopier(registry, dstRegistry, stateFile, 2),
+		artifacts: []string{chartSrc, imageSrc},
+	}
+}
+
+func (tt *copierTest) writeState(content string) {
+	tt.Expect(os.WriteFile(tt.stateFile, []byte(content), 0o640)).To(Succeed())
+}
+
+func (tt *copierTest) expectState(content string) {
+	data, err := os.ReadFile(tt.stateFile)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(data)).To(Equal(content))
+}
+
+func TestRegistryCopierCopySuccess(t *testing.T) {
+	tt := newCopierTest(t)
+	tt.registry.EXPECT().Copy(tt.ctx, chartSrc, chartDst).Return(chartDigest, nil)
+	tt.registry.EXPECT().Copy(tt.ctx, imageSrc, imageDst).Return(imageDigest, nil)
+	tt.registry.EXPECT().Digest(tt.ctx, chartDst).Return(chartDigest, nil)
+	tt.registry.EXPECT().Digest(tt.ctx, imageDst).Return(imageDigest, nil)
+
+	tt.Expect(tt.copier.Copy(tt.ctx, tt.artifacts)).To(Succeed())
+	tt.expectState(`{
+  "copied": {
+    "` + chartDst + `": "` + chartDigest + `",
+    "` + imageDst + `": "` + imageDigest + `"
+  }
+}`)
+}
+
+func TestRegistryCopierCopyResume(t *testing.T) {
+	tt := newCopierTest(t)
+	tt.writeState(stateContent)
+	tt.registry.EXPECT().Digest(tt.ctx, chartDst).Return(chartDigest, nil).Times(2)
+	tt.registry.EXPECT().Copy(tt.ctx, imageSrc, imageDst).Return(imageDigest, nil)
+	tt.registry.EXPECT().Digest(tt.ctx, imageDst).Return(imageDigest, nil)
+
+	tt.Expect(tt.copier.Copy(tt.ctx, tt.artifacts)).To(Succeed())
+}
+
+func TestRegistryCopierCopyResumeArtifactChanged(t *testing.T) {
+	tt := newCopierTest(t)
+	tt.writeState(stateContent)
+	tt.artifacts = []string{chartSrc}
+	gomock.InOrder(
+		tt.registry.EXPECT().Digest(tt.ctx, chartDst).Return(otherDigest, nil),
+		tt.registry.EXPECT().Copy(tt.ctx, chartSrc, chartDst).Return(chartDigest, nil),
+		tt.registry.EXPECT().Digest(tt.ctx, chartDst).Return(chartDigest, nil),
+	)
+
+	tt.Expect(tt.copier.Copy(tt.ctx, tt.artifacts)).To(Succeed())
+}
+
+func TestRegistryCopierCopyError(t *testing.T) {
+	tt := newCopierTest(t)
+	tt.registry.EXPECT().Copy(tt.ctx, chartSrc, chartDst).Return(chartDigest, nil)
+	tt.registry.EXPECT().Copy(tt.ctx, imageSrc, imageDst).Return("", errors.New("connection reset by peer"))
+
+	tt.Expect(tt.copier.Copy(tt.ctx, tt.artifacts)).To(MatchError(
+		"copying 1 of 2 artifacts failed, run the copy again to resume it: copying " + imageSrc + " to " + imageDst + ": connection reset by peer",
+	))
+	tt.expectState(stateContent)
+}
+
+func TestRegistryCopierCopyVerifyError(t *testing.T) {
+	tt := newCopierTest(t)
+	tt.registry.EXPECT().Copy(tt.ctx, chartSrc, chartDst).Return(chartDigest, nil)
+	tt.registry.EXPECT().Copy(tt.ctx, imageSrc, imageDst).Return(imageDigest, nil)
+	tt.registry.EXPECT().Digest(tt.ctx, chartDst).Return(otherDigest, nil)
+	tt.registry.EXPECT().Digest(tt.ctx, imageDst).Return("", errors.New("not found"))
+
+	tt.Expect(tt.copier.Copy(tt.ctx, tt.artifacts)).To(MatchError(
+		"verifying copied artifacts: " +
+			chartDst + ": digest " + otherDigest + " doesn't match copied digest " + chartDigest + ", " +
+			imageDst + ": not found",
+	))
+}
+
+func TestRegistryCopierCopyInvalidState(t *testing.T) {
+	tt := newCopierTest(t)
+	tt.writeState("{")
+
+	tt.Expect(tt.copier.Copy(tt.ctx, tt.artifacts)).To(MatchError(ContainSubstring("parsing copy state file")))
+}
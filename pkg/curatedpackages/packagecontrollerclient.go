@@ -4,13 +4,17 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/wait"
 )
 
 //go:embed config/awssecret.yaml
@@ -43,6 +47,10 @@ type PackageControllerClient struct {
 	httpProxy             string
 	httpsProxy            string
 	noProxy               []string
+	valuesOverride        *v1alpha1.HelmValuesOverride
+	componentValues       map[string]interface{}
+	// disconnectedRegistry is the registry mirror the package controller resolves all artifacts from, if any.
+	disconnectedRegistry string
 	// activeBundleTimeout is the timeout to activate a bundle on installation.
 	activeBundleTimeout time.Duration
 }
@@ -101,17 +109,32 @@ func (pc *PackageControllerClient) EnableCuratedPackages(ctx context.Context) er
 		values = append(values, httpProxy, httpsProxy, noProxy)
 	}
 
+	if pc.disconnectedRegistry != "" {
+		values = append(values,
+			fmt.Sprintf("defaultRegistry=%s", registry),
+			fmt.Sprintf("defaultImageRegistry=%s", pc.disconnectedRegistry),
+		)
+	}
+
+	// Helm values overrides are set last so they take precedence over the managed component configuration.
+	values = append(values, componentSetValues(pc.componentValues)...)
+
+	if pc.valuesOverride != nil {
+		overrides, err := helmSetValues(*pc.valuesOverride)
+		if err != nil {
+			return err
+		}
+		values = append(values, overrides...)
+	}
+
 	err := pc.chartInstaller.InstallChart(ctx, pc.chartName, ociUri, pc.chartVersion, pc.kubeConfig, "", values)
 	if err != nil {
 		return err
 	}
 
-	if err = pc.ApplySecret(ctx); err != nil {
-		logger.Info("Warning: No AWS key/license provided. Please be aware this might prevent the package controller from installing curated packages.")
-	}
-
-	if err = pc.CreateCronJob(ctx); err != nil {
-		logger.Info("Warning: not able to trigger cron job, please be aware this will prevent the package controller from installing curated packages.")
+	// Without ECR access, there are no ECR credentials to create nor refresh.
+	if pc.disconnectedRegistry == "" {
+		pc.setUpECRCredentials(ctx)
 	}
 
 	if err := pc.waitForActiveBundle(ctx); err != nil {
@@ -121,6 +144,16 @@ func (pc *PackageControllerClient) EnableCuratedPackages(ctx context.Context) er
 	return nil
 }
 
+func (pc *PackageControllerClient) setUpECRCredentials(ctx context.Context) {
+	if err := pc.ApplySecret(ctx); err != nil {
+		logger.Info("Warning: No AWS key/license provided. Please be aware this might prevent the package controller from installing curated packages.")
+	}
+
+	if err := pc.CreateCronJob(ctx); err != nil {
+		logger.Info("Warning: not able to trigger cron job, please be aware this will prevent the package controller from installing curated packages.")
+	}
+}
+
 // InstallPBCResources installs Curated Packages Bundle Controller Custom Resource
 // This method is used only for Workload clusters
 // Please refer to this documentation: https://github.com/aws/eks-anywhere-packages/blob/main/docs/design/remote-management.md
@@ -129,6 +162,10 @@ func (pc *PackageControllerClient) InstallPBCResources(ctx context.Context) erro
 		"clusterName": pc.clusterName,
 		"namespace":   constants.EksaPackagesName,
 	}
+	if pc.disconnectedRegistry != "" {
+		templateValues["defaultRegistry"] = GetRegistry(pc.uri)
+		templateValues["defaultImageRegistry"] = pc.disconnectedRegistry
+	}
 
 	result, err := templater.Execute(packageBundleControllerYaml, templateValues)
 	if err != nil {
@@ -160,45 +197,25 @@ func (pc *PackageControllerClient) waitForActiveBundle(ctx context.Context) erro
 	if pc.activeBundleTimeout > 0 {
 		timeout = pc.activeBundleTimeout
 	}
-	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
-	done := make(chan error)
-	go func() {
-		defer close(done)
-		pbc := &packagesv1.PackageBundleController{}
-		for {
-			err := pc.kubectl.GetObject(timeoutCtx, packageBundleControllerResource, pc.clusterName,
-				packagesv1.PackageNamespace, pc.kubeConfig, pbc)
-			if err != nil {
-				done <- fmt.Errorf("getting package bundle controller: %w", err)
-				return
-			}
-
-			if pbc.Spec.ActiveBundle != "" {
-				logger.V(6).Info("found packages bundle controller active bundle",
-					"name", pbc.Spec.ActiveBundle)
-				return
-			}
-
-			logger.V(6).Info("waiting for package bundle controller to activate a bundle",
-				"clusterName", pc.clusterName)
-			// TODO read a polling interval value from the context, falling
-			// back to this as a default.
-			time.Sleep(time.Second)
-		}
-	}()
 
-	select {
-	case <-timeoutCtx.Done():
-		return timeoutCtx.Err()
-	case err := <-done:
+	pbc := &packagesv1.PackageBundleController{}
+	return wait.New(timeout).For(ctx, "package bundle controller to activate a bundle", func(ctx context.Context) (wait.Status, error) {
+		err := pc.kubectl.GetObject(ctx, packageBundleControllerResource, pc.clusterName,
+			packagesv1.PackageNamespace, pc.kubeConfig, pbc)
 		if err != nil {
-			return err
+			return wait.Status{}, wait.Failed(fmt.Errorf("getting package bundle controller: %w", err))
 		}
 
-		return nil
-	}
+		if pbc.Spec.ActiveBundle != "" {
+			logger.V(6).Info("found packages bundle controller active bundle",
+				"name", pbc.Spec.ActiveBundle)
+			return wait.Status{Done: true}, nil
+		}
+
+		logger.V(6).Info("waiting for package bundle controller to activate a bundle",
+			"clusterName", pc.clusterName)
+		return wait.Status{}, nil
+	})
 }
 
 // IsInstalled checks if a package controller custom resource exists.
@@ -292,3 +309,80 @@ func WithManagementClusterName(managementClusterName string) func(client *Packag
 		config.managementClusterName = managementClusterName
 	}
 }
+
+// WithValuesOverride sets the Helm values overrides from the cluster spec for the package controller chart.
+func WithValuesOverride(override v1alpha1.HelmValuesOverride) func(client *PackageControllerClient) {
+	return func(config *PackageControllerClient) {
+		config.valuesOverride = &override
+	}
+}
+
+// WithManagedComponentValues sets the Helm values derived from the package controller managed component configuration.
+func WithManagedComponentValues(values map[string]interface{}) func(client *PackageControllerClient) {
+	return func(config *PackageControllerClient) {
+		config.componentValues = values
+	}
+}
+
+// WithDisconnectedRegistry makes the package controller resolve package bundles, helm charts and images only from
+// the registry mirror, where they're imported with the same repositories they have in ECR.
+func WithDisconnectedRegistry(registryMirror string) func(client *PackageControllerClient) {
+	return func(config *PackageControllerClient) {
+		config.disconnectedRegistry = registryMirror
+	}
+}
+
+func componentSetValues(componentValues map[string]interface{}) []string {
+	paths := make([]string, 0, len(componentValues))
+	for path := range componentValues {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var values []string
+	for _, path := range paths {
+		values = appendHelmSetValues(values, path, componentValues[path])
+	}
+	return values
+}
+
+// helmSetValues converts Helm values overrides to the path=value format used by helm --set.
+func helmSetValues(override v1alpha1.HelmValuesOverride) ([]string, error) {
+	parsed, err := override.ParsedValues()
+	if err != nil {
+		return nil, fmt.Errorf("parsing package controller helm values overrides: %v", err)
+	}
+
+	var values []string
+	for _, path := range override.SortedPaths() {
+		values = appendHelmSetValues(values, path, parsed[path])
+	}
+
+	return values, nil
+}
+
+func appendHelmSetValues(values []string, path string, value interface{}) []string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			values = appendHelmSetValues(values, path+"."+k, v[k])
+		}
+	case []interface{}:
+		for i, e := range v {
+			values = appendHelmSetValues(values, fmt.Sprintf("%s[%d]", path, i), e)
+		}
+	case nil:
+		values = append(values, path+"=null")
+	case float64:
+		values = append(values, path+"="+strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		// Helm requires commas to be escaped: https://github.com/rancher/rancher/issues/16195
+		values = append(values, path+"="+strings.ReplaceAll(fmt.Sprint(v), ",", "\\,"))
+	}
+	return values
+}
@@ -15,6 +15,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages/mocks"
@@ -125,6 +126,47 @@ func TestEnableCuratedPackagesSucceedInWorkloadCluster(t *testing.T) {
 	tt.Expect(err).To(BeNil())
 }
 
+func TestEnableCuratedPackagesDisconnectedSuccess(t *testing.T) {
+	tt := newPackageControllerTest(t)
+	tt.ociUri = "1.2.3.4:443/eks-anywhere/eks-anywhere-packages"
+	tt.command = curatedpackages.NewPackageControllerClient(
+		tt.chartInstaller, tt.kubectl, tt.clusterName, tt.kubeConfig, tt.ociUri, tt.chartName, tt.chartVersion,
+		curatedpackages.WithManagementClusterName(tt.clusterName),
+		curatedpackages.WithDisconnectedRegistry("1.2.3.4:443"),
+	)
+
+	values := []string{
+		"sourceRegistry=1.2.3.4:443/eks-anywhere",
+		"clusterName=billy",
+		"defaultRegistry=1.2.3.4:443/eks-anywhere",
+		"defaultImageRegistry=1.2.3.4:443",
+	}
+	tt.chartInstaller.EXPECT().InstallChart(tt.ctx, tt.chartName, "oci://"+tt.ociUri, tt.chartVersion, tt.kubeConfig, "", values).Return(nil)
+	any := gomock.Any()
+	tt.kubectl.EXPECT().
+		GetObject(any, any, any, any, any, any).
+		DoAndReturn(getPBCSuccess(t)).
+		AnyTimes()
+
+	tt.Expect(tt.command.EnableCuratedPackages(tt.ctx)).To(Succeed())
+}
+
+func TestEnableCuratedPackagesDisconnectedSucceedInWorkloadCluster(t *testing.T) {
+	tt := newPackageControllerTest(t)
+	tt.command = curatedpackages.NewPackageControllerClient(
+		tt.chartInstaller, tt.kubectl, tt.clusterName, tt.kubeConfig, "1.2.3.4:443/eks-anywhere/eks-anywhere-packages", tt.chartName, tt.chartVersion,
+		curatedpackages.WithManagementClusterName("mgmt"),
+		curatedpackages.WithDisconnectedRegistry("1.2.3.4:443"),
+	)
+	dat, err := os.ReadFile("testdata/packagebundlectrl_disconnected.yaml")
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	params := []string{"create", "-f", "-", "--kubeconfig", tt.kubeConfig}
+	tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, dat, params).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.command.EnableCuratedPackages(tt.ctx)).To(Succeed())
+}
+
 func getPBCSuccess(t *testing.T) func(context.Context, string, string, string, string, *packagesv1.PackageBundleController) error {
 	return func(_ context.Context, _, _, _, _ string, obj *packagesv1.PackageBundleController) error {
 		pbc := &packagesv1.PackageBundleController{
@@ -183,6 +225,94 @@ func TestEnableCuratedPackagesWithProxy(t *testing.T) {
 	}
 }
 
+func TestEnableCuratedPackagesWithValuesOverride(t *testing.T) {
+	tt := newPackageControllerTest(t)
+	tt.command = curatedpackages.NewPackageControllerClient(
+		tt.chartInstaller, tt.kubectl, "billy", tt.kubeConfig, tt.ociUri, tt.chartName, tt.chartVersion,
+		curatedpackages.WithEksaSecretAccessKey(tt.eksaAccessKey),
+		curatedpackages.WithEksaRegion(tt.eksaRegion),
+		curatedpackages.WithEksaAccessKeyId(tt.eksaAccessId),
+		curatedpackages.WithManagementClusterName(tt.clusterName),
+		curatedpackages.WithValuesOverride(v1alpha1.HelmValuesOverride{
+			Chart: v1alpha1.PackagesChart,
+			Values: map[string]string{
+				"resources":   "{limits: {cpu: 500m, memory: 1Gi}}",
+				"replicas":    "2",
+				"tolerations": "[{key: node-role.kubernetes.io/control-plane, effect: NoSchedule}]",
+			},
+		}),
+	)
+
+	registry := curatedpackages.GetRegistry(tt.ociUri)
+	values := []string{
+		fmt.Sprintf("sourceRegistry=%s", registry),
+		"clusterName=billy",
+		"replicas=2",
+		"resources.limits.cpu=500m",
+		"resources.limits.memory=1Gi",
+		"tolerations[0].effect=NoSchedule",
+		"tolerations[0].key=node-role.kubernetes.io/control-plane",
+	}
+	params := []string{"create", "-f", "-", "--kubeconfig", tt.kubeConfig}
+	dat, err := os.ReadFile("testdata/awssecret_test.yaml")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, dat, params).Return(bytes.Buffer{}, nil)
+	params = []string{"create", "job", jobName, "--from=" + cronJobName, "--kubeconfig", tt.kubeConfig, "--namespace", constants.EksaPackagesName}
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, params).Return(bytes.Buffer{}, nil)
+	tt.chartInstaller.EXPECT().InstallChart(tt.ctx, tt.chartName, "oci://"+tt.ociUri, tt.chartVersion, tt.kubeConfig, "", values).Return(nil)
+	any := gomock.Any()
+	tt.kubectl.EXPECT().
+		GetObject(any, any, any, any, any, any).
+		DoAndReturn(getPBCSuccess(t)).
+		AnyTimes()
+
+	tt.Expect(tt.command.EnableCuratedPackages(tt.ctx)).To(Succeed())
+}
+
+func TestEnableCuratedPackagesWithManagedComponentValues(t *testing.T) {
+	tt := newPackageControllerTest(t)
+	tt.command = curatedpackages.NewPackageControllerClient(
+		tt.chartInstaller, tt.kubectl, "billy", tt.kubeConfig, tt.ociUri, tt.chartName, tt.chartVersion,
+		curatedpackages.WithEksaSecretAccessKey(tt.eksaAccessKey),
+		curatedpackages.WithEksaRegion(tt.eksaRegion),
+		curatedpackages.WithEksaAccessKeyId(tt.eksaAccessId),
+		curatedpackages.WithManagementClusterName(tt.clusterName),
+		curatedpackages.WithManagedComponentValues(map[string]interface{}{
+			"nodeSelector": map[string]interface{}{"pool": "system"},
+			"resources":    map[string]interface{}{"limits": map[string]interface{}{"cpu": "200m"}},
+		}),
+		curatedpackages.WithValuesOverride(v1alpha1.HelmValuesOverride{
+			Chart: v1alpha1.PackagesChart,
+			Values: map[string]string{
+				"resources.limits.cpu": "500m",
+			},
+		}),
+	)
+
+	registry := curatedpackages.GetRegistry(tt.ociUri)
+	values := []string{
+		fmt.Sprintf("sourceRegistry=%s", registry),
+		"clusterName=billy",
+		"nodeSelector.pool=system",
+		"resources.limits.cpu=200m",
+		"resources.limits.cpu=500m",
+	}
+	params := []string{"create", "-f", "-", "--kubeconfig", tt.kubeConfig}
+	dat, err := os.ReadFile("testdata/awssecret_test.yaml")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, dat, params).Return(bytes.Buffer{}, nil)
+	params = []string{"create", "job", jobName, "--from=" + cronJobName, "--kubeconfig", tt.kubeConfig, "--namespace", constants.EksaPackagesName}
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, params).Return(bytes.Buffer{}, nil)
+	tt.chartInstaller.EXPECT().InstallChart(tt.ctx, tt.chartName, "oci://"+tt.ociUri, tt.chartVersion, tt.kubeConfig, "", values).Return(nil)
+	any := gomock.Any()
+	tt.kubectl.EXPECT().
+		GetObject(any, any, any, any, any, any).
+		DoAndReturn(getPBCSuccess(t)).
+		AnyTimes()
+
+	tt.Expect(tt.command.EnableCuratedPackages(tt.ctx)).To(Succeed())
+}
+
 func TestEnableCuratedPackagesWithEmptyProxy(t *testing.T) {
 	tt := newPackageControllerTest(t)
 	tt.command = curatedpackages.NewPackageControllerClient(
@@ -38,7 +38,13 @@ func NewInstaller(runner KubectlRunner, pc PackageHandler, pcc PackageController
 }
 
 // InstallCuratedPackages installs curated packages as part of the cluster creation.
+// Clusters with the edge profile skip them to keep their footprint small.
 func (pi *Installer) InstallCuratedPackages(ctx context.Context) {
+	if pi.spec.Cluster.IsEdgeProfile() {
+		logger.Info("Skipping curated packages installation for the edge cluster profile")
+		return
+	}
+
 	PrintLicense()
 	err := pi.installPackagesController(ctx)
 	// There is an ask from customers to avoid considering the failure of installing curated packages
@@ -93,3 +93,10 @@ func TestPackageInstallerFailWhenPackageFails(t *testing.T) {
 
 	tt.command.InstallCuratedPackages(tt.ctx)
 }
+
+func TestPackageInstallerSkipsEdgeProfile(t *testing.T) {
+	tt := newPackageInstallerTest(t)
+	tt.spec.Cluster.Spec.ClusterProfile = anywherev1.EdgeClusterProfile
+
+	tt.command.InstallCuratedPackages(tt.ctx)
+}
@@ -75,16 +75,45 @@ func (r *PackageReader) ReadChartsFromBundles(ctx context.Context, b *releasev1.
 }
 
 func fetchPackagesHelmChart(ctx context.Context, versionsBundle releasev1.VersionsBundle, artifact string) ([]releasev1.Image, error) {
+	bundle, err := pullPackageBundle(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+	return packagesHelmCharts(versionsBundle, bundle), nil
+}
+
+func (r *PackageReader) fetchImagesFromBundle(ctx context.Context, versionsBundle releasev1.VersionsBundle, artifact string) ([]releasev1.Image, error) {
+	bundle, err := pullPackageBundle(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+	return packagesImages(versionsBundle, bundle), nil
+}
+
+// ReadPackagesArtifacts returns the helm charts and images of the curated packages in the package bundle
+// artifact of the versions bundle.
+func ReadPackagesArtifacts(ctx context.Context, versionsBundle releasev1.VersionsBundle, artifact string) ([]releasev1.Image, error) {
+	bundle, err := pullPackageBundle(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+	return append(packagesHelmCharts(versionsBundle, bundle), packagesImages(versionsBundle, bundle)...), nil
+}
+
+func pullPackageBundle(ctx context.Context, artifact string) (*packagesv1.PackageBundle, error) {
 	data, err := PullLatestBundle(ctx, artifact)
 	if err != nil {
 		return nil, err
 	}
-	ctrl := versionsBundle.PackageController.Controller
 	bundle := &packagesv1.PackageBundle{}
-	err = yaml.Unmarshal(data, bundle)
-	if err != nil {
+	if err = yaml.Unmarshal(data, bundle); err != nil {
 		return nil, err
 	}
+	return bundle, nil
+}
+
+func packagesHelmCharts(versionsBundle releasev1.VersionsBundle, bundle *packagesv1.PackageBundle) []releasev1.Image {
+	ctrl := versionsBundle.PackageController.Controller
 	images := make([]releasev1.Image, 0, len(bundle.Spec.Packages))
 	for _, p := range bundle.Spec.Packages {
 		pHC := releasev1.Image{
@@ -96,20 +125,10 @@ func fetchPackagesHelmChart(ctx context.Context, versionsBundle releasev1.Versio
 		}
 		images = append(images, pHC)
 	}
-	return images, nil
+	return images
 }
 
-func (r *PackageReader) fetchImagesFromBundle(ctx context.Context, versionsBundle releasev1.VersionsBundle, artifact string) ([]releasev1.Image, error) {
-	data, err := PullLatestBundle(ctx, artifact)
-	if err != nil {
-		return nil, err
-	}
-	bundle := &packagesv1.PackageBundle{}
-	err = yaml.Unmarshal(data, bundle)
-	if err != nil {
-		return nil, err
-	}
-
+func packagesImages(versionsBundle releasev1.VersionsBundle, bundle *packagesv1.PackageBundle) []releasev1.Image {
 	ctrl := versionsBundle.PackageController.Controller
 	images := make([]releasev1.Image, 0, len(bundle.Spec.Packages))
 
@@ -126,7 +145,7 @@ func (r *PackageReader) fetchImagesFromBundle(ctx context.Context, versionsBundl
 			images = append(images, image)
 		}
 	}
-	return images, nil
+	return images
 }
 
 func getRegistry(uri string) string {
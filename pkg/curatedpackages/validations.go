@@ -1,8 +1,22 @@
 package curatedpackages
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 )
 
 func ValidateKubeVersion(kubeVersion string, clusterName string) error {
@@ -22,3 +36,184 @@ func ValidateKubeVersion(kubeVersion string, clusterName string) error {
 	}
 	return fmt.Errorf("please specify kube-version or cluster name")
 }
+
+// ValidatePackagesNodeSelectors validates the nodeSelectors in the configuration of the packages at packagesLocation,
+// a file or a directory, match the labels of a node group of the cluster that runs workloads. Otherwise the packages
+// would stay pending forever after the cluster is created. The well-known labels the nodes get from Kubernetes,
+// like kubernetes.io/os, are not validated.
+func ValidatePackagesNodeSelectors(spec *cluster.Spec, packagesLocation string) error {
+	if packagesLocation == "" || spec.Cluster.IsEdgeProfile() {
+		return nil
+	}
+
+	packages, err := readPackages(packagesLocation)
+	if err != nil {
+		return fmt.Errorf("reading packages: %v", err)
+	}
+
+	nodeGroupsLabels := workloadNodeGroupsLabels(spec.Cluster)
+	for _, p := range packages {
+		selectors, err := packageNodeSelectors(p)
+		if err != nil {
+			return fmt.Errorf("reading config of package %s: %v", p.Name, err)
+		}
+
+		for _, selector := range selectors {
+			if !anyLabelsMatch(nodeGroupsLabels, selector) {
+				return fmt.Errorf("nodeSelector %s of package %s doesn't match the labels of any node group", selector, p.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// readPackages reads the packages in the yaml file at location, or in the yaml files of the directory at location.
+func readPackages(location string) ([]packagesv1.Package, error) {
+	info, err := os.Stat(location)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{location}
+	if info.IsDir() {
+		files = nil
+		for _, pattern := range []string{"*.yaml", "*.yml"} {
+			matches, err := filepath.Glob(filepath.Join(location, pattern))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, matches...)
+		}
+		sort.Strings(files)
+	}
+
+	var packages []packagesv1.Package
+	for _, file := range files {
+		filePackages, err := readPackagesFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %v", file, err)
+		}
+		packages = append(packages, filePackages...)
+	}
+
+	return packages, nil
+}
+
+func readPackagesFile(file string) ([]packagesv1.Package, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var packages []packagesv1.Package
+	document := yamlutil.NewYAMLReader(bufio.NewReader(f))
+	for {
+		manifest, err := document.Read()
+		if errors.Is(err, io.EOF) {
+			return packages, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		p := packagesv1.Package{}
+		if err := yaml.Unmarshal(manifest, &p); err != nil {
+			return nil, err
+		}
+		if p.Kind == kind {
+			packages = append(packages, p)
+		}
+	}
+}
+
+// packageNodeSelectors returns the nodeSelectors found at any level of the configuration of a package,
+// without the well-known labels.
+func packageNodeSelectors(p packagesv1.Package) ([]labels.Set, error) {
+	if p.Spec.Config == "" {
+		return nil, nil
+	}
+
+	var config interface{}
+	if err := yaml.Unmarshal([]byte(p.Spec.Config), &config); err != nil {
+		return nil, err
+	}
+
+	var selectors []labels.Set
+	collectNodeSelectors(config, &selectors)
+	return selectors, nil
+}
+
+func collectNodeSelectors(value interface{}, selectors *[]labels.Set) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if selector, ok := v[k].(map[string]interface{}); ok && k == "nodeSelector" {
+				if s := nodeSelectorLabels(selector); len(s) > 0 {
+					*selectors = append(*selectors, s)
+				}
+				continue
+			}
+			collectNodeSelectors(v[k], selectors)
+		}
+	case []interface{}:
+		for _, e := range v {
+			collectNodeSelectors(e, selectors)
+		}
+	}
+}
+
+func nodeSelectorLabels(selector map[string]interface{}) labels.Set {
+	s := labels.Set{}
+	for k, v := range selector {
+		if !isWellKnownLabel(k) {
+			s[k] = fmt.Sprint(v)
+		}
+	}
+	return s
+}
+
+// isWellKnownLabel returns true for the labels in the kubernetes.io and k8s.io namespaces, which the nodes get
+// from the kubelet and the cloud providers instead of the node groups.
+func isWellKnownLabel(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	for _, domain := range []string{"kubernetes.io", "k8s.io"} {
+		if prefix == domain || strings.HasSuffix(prefix, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// workloadNodeGroupsLabels returns the labels of the node groups that run workloads: the worker node groups,
+// and the control plane when it's schedulable or the cluster doesn't have worker nodes.
+func workloadNodeGroupsLabels(c *v1alpha1.Cluster) []labels.Set {
+	nodeGroupsLabels := make([]labels.Set, 0, len(c.Spec.WorkerNodeGroupConfigurations)+1)
+	cp := c.Spec.ControlPlaneConfiguration
+	if cp.Schedulable || len(c.Spec.WorkerNodeGroupConfigurations) == 0 {
+		nodeGroupsLabels = append(nodeGroupsLabels, cp.Labels)
+	}
+	for _, w := range c.Spec.WorkerNodeGroupConfigurations {
+		nodeGroupsLabels = append(nodeGroupsLabels, w.Labels)
+	}
+	return nodeGroupsLabels
+}
+
+func anyLabelsMatch(nodeGroupsLabels []labels.Set, selector labels.Set) bool {
+	for _, l := range nodeGroupsLabels {
+		if labels.SelectorFromSet(selector).Matches(l) {
+			return true
+		}
+	}
+	return false
+}
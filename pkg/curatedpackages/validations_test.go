@@ -3,6 +3,11 @@ package curatedpackages_test
 import (
 	"testing"
 
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 )
 
@@ -42,3 +47,85 @@ func TestValidateKubeVersionWhenInvalidVersionFails(t *testing.T) {
 		t.Errorf("Registry with %s should fail", kubeVersion)
 	}
 }
+
+func TestValidatePackagesNodeSelectors(t *testing.T) {
+	tests := []struct {
+		name             string
+		packagesLocation string
+		cluster          func(c *v1alpha1.Cluster)
+		wantErr          string
+	}{
+		{
+			name:             "no packages",
+			packagesLocation: "",
+		},
+		{
+			name:             "worker node group matches",
+			packagesLocation: "testdata/packages_node_selectors.yaml",
+			cluster: func(c *v1alpha1.Cluster) {
+				c.Spec.WorkerNodeGroupConfigurations[0].Labels = map[string]string{"node-type": "storage", "zone": "a"}
+			},
+		},
+		{
+			name:             "directory",
+			packagesLocation: "testdata",
+			cluster: func(c *v1alpha1.Cluster) {
+				c.Spec.WorkerNodeGroupConfigurations[0].Labels = map[string]string{"node-type": "storage"}
+			},
+		},
+		{
+			name:             "schedulable control plane matches",
+			packagesLocation: "testdata/packages_node_selectors.yaml",
+			cluster: func(c *v1alpha1.Cluster) {
+				c.Spec.ControlPlaneConfiguration.Schedulable = true
+				c.Spec.ControlPlaneConfiguration.Labels = map[string]string{"node-type": "storage"}
+			},
+		},
+		{
+			name:             "control plane doesn't run workloads",
+			packagesLocation: "testdata/packages_node_selectors.yaml",
+			cluster: func(c *v1alpha1.Cluster) {
+				c.Spec.ControlPlaneConfiguration.Labels = map[string]string{"node-type": "storage"}
+			},
+			wantErr: "nodeSelector node-type=storage of package my-harbor doesn't match the labels of any node group",
+		},
+		{
+			name:             "no node group matches",
+			packagesLocation: "testdata/packages_node_selectors.yaml",
+			cluster: func(c *v1alpha1.Cluster) {
+				c.Spec.WorkerNodeGroupConfigurations[0].Labels = map[string]string{"node-type": "gpu"}
+			},
+			wantErr: "nodeSelector node-type=storage of package my-harbor doesn't match the labels of any node group",
+		},
+		{
+			name:             "edge profile",
+			packagesLocation: "testdata/packages_node_selectors.yaml",
+			cluster: func(c *v1alpha1.Cluster) {
+				c.Spec.ClusterProfile = v1alpha1.EdgeClusterProfile
+			},
+		},
+		{
+			name:             "missing packages",
+			packagesLocation: "testdata/missing.yaml",
+			wantErr:          "reading packages: stat testdata/missing.yaml: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}}
+				if tt.cluster != nil {
+					tt.cluster(s.Cluster)
+				}
+			})
+
+			err := curatedpackages.ValidatePackagesNodeSelectors(spec, tt.packagesLocation)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
@@ -2,19 +2,17 @@ package dependencies
 
 import (
 	"context"
-	"crypto/x509"
-	"encoding/pem"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/google/uuid"
-	prismgoclient "github.com/nutanix-cloud-native/prism-go-client"
 	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/awsiamauth"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	"github.com/aws/eks-anywhere/pkg/bottlerocketupdates"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
@@ -44,54 +42,67 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
+	"github.com/aws/eks-anywhere/pkg/telemetry"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/utils/urls"
+	"github.com/aws/eks-anywhere/pkg/validations/postcreate"
+	"github.com/aws/eks-anywhere/pkg/velero"
 	"github.com/aws/eks-anywhere/pkg/version"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
 type Dependencies struct {
-	Provider                  providers.Provider
-	ClusterAwsCli             *executables.Clusterawsadm
-	DockerClient              *executables.Docker
-	Kubectl                   *executables.Kubectl
-	Govc                      *executables.Govc
-	Cmk                       *executables.Cmk
-	SnowAwsClientRegistry     *snow.AwsClientRegistry
-	SnowConfigManager         *snow.ConfigManager
-	Writer                    filewriter.FileWriter
-	Kind                      *executables.Kind
-	Clusterctl                *executables.Clusterctl
-	Flux                      *executables.Flux
-	Troubleshoot              *executables.Troubleshoot
-	Helm                      *executables.Helm
-	UnAuthKubeClient          *kubernetes.UnAuthClient
-	Networking                clustermanager.Networking
-	CiliumTemplater           *cilium.Templater
-	AwsIamAuth                *awsiamauth.Installer
-	ClusterManager            *clustermanager.ClusterManager
-	Bootstrapper              *bootstrapper.Bootstrapper
-	GitOpsFlux                *flux.Flux
-	Git                       *gitfactory.GitTools
-	EksdInstaller             *eksd.Installer
-	EksdUpgrader              *eksd.Upgrader
-	AnalyzerFactory           diagnostics.AnalyzerFactory
-	CollectorFactory          diagnostics.CollectorFactory
-	DignosticCollectorFactory diagnostics.DiagnosticBundleFactory
-	CAPIManager               *clusterapi.Manager
-	ResourceSetManager        *clusterapi.ResourceSetManager
-	FileReader                *files.Reader
-	ManifestReader            *manifests.Reader
-	closers                   []types.Closer
-	CliConfig                 *config.CliConfig
-	PackageInstaller          interfaces.PackageInstaller
-	BundleRegistry            curatedpackages.BundleRegistry
-	PackageControllerClient   *curatedpackages.PackageControllerClient
-	PackageClient             curatedpackages.PackageHandler
-	VSphereValidator          *vsphere.Validator
-	VSphereDefaulter          *vsphere.Defaulter
-	NutanixPrismClient        *v3.Client
-	SnowValidator             *snow.AwsClientValidator
+	Provider                    providers.Provider
+	ClusterAwsCli               *executables.Clusterawsadm
+	DockerClient                *executables.Docker
+	Kubectl                     *executables.Kubectl
+	Govc                        *executables.Govc
+	Cmk                         *executables.Cmk
+	SnowAwsClientRegistry       *snow.AwsClientRegistry
+	SnowConfigManager           *snow.ConfigManager
+	Writer                      filewriter.FileWriter
+	Kind                        *executables.Kind
+	Clusterctl                  *executables.Clusterctl
+	Flux                        *executables.Flux
+	Troubleshoot                *executables.Troubleshoot
+	Helm                        *executables.Helm
+	Sonobuoy                    *executables.Sonobuoy
+	UnAuthKubeClient            *kubernetes.UnAuthClient
+	KubeClientFactory           *kubernetes.RuntimeClientFactory
+	Networking                  clustermanager.Networking
+	CiliumTemplater             *cilium.Templater
+	AwsIamAuth                  *awsiamauth.Installer
+	BackupInstaller             *velero.Installer
+	OSUpdatesInstaller          *bottlerocketupdates.Installer
+	ClusterManager              *clustermanager.ClusterManager
+	Bootstrapper                *bootstrapper.Bootstrapper
+	GitOpsFlux                  *flux.Flux
+	Git                         *gitfactory.GitTools
+	EksdInstaller               *eksd.Installer
+	EksdUpgrader                *eksd.Upgrader
+	AnalyzerFactory             diagnostics.AnalyzerFactory
+	CollectorFactory            diagnostics.CollectorFactory
+	DignosticCollectorFactory   diagnostics.DiagnosticBundleFactory
+	CAPIManager                 *clusterapi.Manager
+	ResourceSetManager          *clusterapi.ResourceSetManager
+	FileReader                  *files.Reader
+	ManifestReader              *manifests.Reader
+	closers                     []types.Closer
+	CliConfig                   *config.CliConfig
+	PackageInstaller            interfaces.PackageInstaller
+	BundleRegistry              curatedpackages.BundleRegistry
+	PackageControllerClient     *curatedpackages.PackageControllerClient
+	PackageClient               curatedpackages.PackageHandler
+	VSphereValidator            *vsphere.Validator
+	VSphereDefaulter            *vsphere.Defaulter
+	VSphereClusterTagger        *vsphere.ClusterTagger
+	CloudStackValidatorRegistry cloudstack.ValidatorRegistry
+	CloudStackTagsRegistry      cloudstack.TagsClientRegistry
+	NutanixPrismClient          *v3.Client
+	NutanixValidatorRegistry    nutanix.ValidatorRegistry
+	SnowValidator               *snow.AwsClientValidator
+	PostCreateValidator         *postcreate.Validator
 }
 
 func (d *Dependencies) Close(ctx context.Context) error {
@@ -121,6 +132,8 @@ type Factory struct {
 	proxyConfiguration       map[string]string
 	writerFolder             string
 	diagnosticCollectorImage string
+	diagnosticRedactions     []string
+	diagnosticLogsMaxBytes   int64
 	buildSteps               []buildStep
 	dependencies             Dependencies
 }
@@ -367,6 +380,7 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 			f.dependencies.Provider = snow.NewProvider(
 				f.dependencies.UnAuthKubeClient,
 				f.dependencies.SnowConfigManager,
+				f.dependencies.SnowAwsClientRegistry,
 				skipIpCheck,
 			)
 
@@ -440,7 +454,7 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 				machineConfigs,
 				clusterConfig,
 				f.dependencies.Kubectl,
-				f.dependencies.NutanixPrismClient.V3,
+				nutanix.NewRateLimitedClient(f.dependencies.NutanixPrismClient.V3, ratelimit.For(datacenterConfig.Spec.Endpoint)),
 				crypto.NewTlsValidator(),
 				time.Now,
 			)
@@ -449,6 +463,8 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 			return fmt.Errorf("no provider support for datacenter kind: %s", clusterConfig.Spec.DatacenterRef.Kind)
 		}
 
+		telemetry.SetProvider(ctx, f.dependencies.Provider.Name())
+
 		return nil
 	})
 
@@ -646,6 +662,22 @@ func (f *Factory) WithTroubleshoot() *Factory {
 	return f
 }
 
+// WithSonobuoy builds a Sonobuoy executable.
+func (f *Factory) WithSonobuoy() *Factory {
+	f.WithExecutableBuilder()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.Sonobuoy != nil {
+			return nil
+		}
+
+		f.dependencies.Sonobuoy = f.executablesConfig.builder.BuildSonobuoyExecutable()
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithHelm(opts ...executables.HelmOpt) *Factory {
 	f.WithExecutableBuilder().WithProxyConfiguration()
 
@@ -706,6 +738,37 @@ func (f *Factory) WithCiliumTemplater() *Factory {
 	return f
 }
 
+// WithBackupInstaller builds the installer for the Velero backup component.
+func (f *Factory) WithBackupInstaller() *Factory {
+	// Same Helm options as for Cilium, since both charts are pulled from the bundle registry or its mirror.
+	f.WithKubectl().WithHelm(executables.WithInsecure())
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.BackupInstaller != nil {
+			return nil
+		}
+		f.dependencies.BackupInstaller = velero.NewInstaller(f.dependencies.Helm, f.dependencies.Kubectl)
+		return nil
+	})
+
+	return f
+}
+
+// WithOSUpdatesInstaller builds the installer for the Bottlerocket update operator.
+func (f *Factory) WithOSUpdatesInstaller() *Factory {
+	f.WithKubectl().WithHelm(executables.WithInsecure())
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.OSUpdatesInstaller != nil {
+			return nil
+		}
+		f.dependencies.OSUpdatesInstaller = bottlerocketupdates.NewInstaller(f.dependencies.Helm, f.dependencies.Kubectl)
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithAwsIamAuth() *Factory {
 	f.WithKubectl().WithWriter()
 
@@ -748,7 +811,7 @@ type clusterManagerClient struct {
 }
 
 func (f *Factory) WithClusterManager(clusterConfig *v1alpha1.Cluster, opts ...clustermanager.ClusterManagerOpt) *Factory {
-	f.WithClusterctl().WithKubectl().WithNetworking(clusterConfig).WithWriter().WithDiagnosticBundleFactory().WithAwsIamAuth()
+	f.WithClusterctl().WithKubectl().WithKubeClientFactory().WithNetworking(clusterConfig).WithWriter().WithDiagnosticBundleFactory().WithAwsIamAuth().WithBackupInstaller().WithOSUpdatesInstaller()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.ClusterManager != nil {
@@ -756,15 +819,21 @@ func (f *Factory) WithClusterManager(clusterConfig *v1alpha1.Cluster, opts ...cl
 		}
 
 		f.dependencies.ClusterManager = clustermanager.New(
-			&clusterManagerClient{
-				f.dependencies.Clusterctl,
-				f.dependencies.Kubectl,
-			},
+			clustermanager.NewKubernetesClusterClient(
+				&clusterManagerClient{
+					f.dependencies.Clusterctl,
+					f.dependencies.Kubectl,
+				},
+				f.dependencies.KubeClientFactory,
+			),
 			f.dependencies.Networking,
 			f.dependencies.Writer,
 			f.dependencies.DignosticCollectorFactory,
 			f.dependencies.AwsIamAuth,
-			opts...,
+			append([]clustermanager.ClusterManagerOpt{
+				clustermanager.WithBackupInstaller(f.dependencies.BackupInstaller),
+				clustermanager.WithOSUpdatesInstaller(f.dependencies.OSUpdatesInstaller),
+			}, opts...)...,
 		)
 		return nil
 	})
@@ -895,6 +964,22 @@ func (f *Factory) WithPackageInstaller(spec *cluster.Spec, packagesLocation, kub
 	return f
 }
 
+// WithPostCreateValidator builds a validator that smoke tests newly created clusters.
+func (f *Factory) WithPostCreateValidator(opts ...postcreate.ValidatorOpt) *Factory {
+	f.WithKubectl()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.PostCreateValidator != nil {
+			return nil
+		}
+
+		f.dependencies.PostCreateValidator = postcreate.NewValidator(f.dependencies.Kubectl, opts...)
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithPackageControllerClient(spec *cluster.Spec, kubeConfig string) *Factory {
 	f.WithHelm(executables.WithInsecure()).WithKubectl()
 
@@ -910,6 +995,28 @@ func (f *Factory) WithPackageControllerClient(spec *cluster.Spec, kubeConfig str
 
 		httpProxy, httpsProxy, noProxy := getProxyConfiguration(spec)
 		eksaAccessKeyId, eksaSecretKey, eksaRegion := os.Getenv(config.EksaAccessKeyIdEnv), os.Getenv(config.EksaSecretAccessKeyEnv), os.Getenv(config.EksaRegionEnv)
+		opts := []curatedpackages.PackageControllerClientOpt{
+			curatedpackages.WithEksaAccessKeyId(eksaAccessKeyId),
+			curatedpackages.WithEksaSecretAccessKey(eksaSecretKey),
+			curatedpackages.WithEksaRegion(eksaRegion),
+			curatedpackages.WithHTTPProxy(httpProxy),
+			curatedpackages.WithHTTPSProxy(httpsProxy),
+			curatedpackages.WithNoProxy(noProxy),
+			curatedpackages.WithManagementClusterName(managementClusterName),
+		}
+		componentValues, err := spec.Cluster.ManagedComponentHelmValues(v1alpha1.PackagesChart)
+		if err != nil {
+			return err
+		}
+		if len(componentValues) > 0 {
+			opts = append(opts, curatedpackages.WithManagedComponentValues(componentValues))
+		}
+		if override, ok := spec.Cluster.HelmValuesOverride(v1alpha1.PackagesChart); ok {
+			opts = append(opts, curatedpackages.WithValuesOverride(override))
+		}
+		if mirror := spec.Cluster.Spec.RegistryMirrorConfiguration; mirror != nil && mirror.DisconnectedPackages {
+			opts = append(opts, curatedpackages.WithDisconnectedRegistry(spec.Cluster.RegistryMirror()))
+		}
 		f.dependencies.PackageControllerClient = curatedpackages.NewPackageControllerClient(
 			f.dependencies.Helm,
 			f.dependencies.Kubectl,
@@ -918,13 +1025,7 @@ func (f *Factory) WithPackageControllerClient(spec *cluster.Spec, kubeConfig str
 			imageUrl,
 			chart.Name,
 			chart.Tag(),
-			curatedpackages.WithEksaAccessKeyId(eksaAccessKeyId),
-			curatedpackages.WithEksaSecretAccessKey(eksaSecretKey),
-			curatedpackages.WithEksaRegion(eksaRegion),
-			curatedpackages.WithHTTPProxy(httpProxy),
-			curatedpackages.WithHTTPSProxy(httpsProxy),
-			curatedpackages.WithNoProxy(noProxy),
-			curatedpackages.WithManagementClusterName(managementClusterName),
+			opts...,
 		)
 		return nil
 	})
@@ -989,6 +1090,8 @@ func (f *Factory) WithDiagnosticBundleFactory() *Factory {
 			CollectorFactory: f.dependencies.CollectorFactory,
 			Kubectl:          f.dependencies.Kubectl,
 			Writer:           f.dependencies.Writer,
+			Redactions:       f.diagnosticRedactions,
+			LogsMaxBytes:     f.diagnosticLogsMaxBytes,
 		}
 
 		f.dependencies.DignosticCollectorFactory = diagnostics.NewFactory(opts)
@@ -1011,6 +1114,14 @@ func (f *Factory) WithAnalyzerFactory() *Factory {
 	return f
 }
 
+// WithDiagnosticBundleLimits configures the extra redactions and the per logs collector size cap
+// of the support bundles built by the diagnostic bundle factory.
+func (f *Factory) WithDiagnosticBundleLimits(redactions []string, logsMaxBytes int64) *Factory {
+	f.diagnosticRedactions = redactions
+	f.diagnosticLogsMaxBytes = logsMaxBytes
+	return f
+}
+
 func (f *Factory) WithDiagnosticCollectorImage(diagnosticCollectorImage string) *Factory {
 	f.diagnosticCollectorImage = diagnosticCollectorImage
 	return f
@@ -1113,6 +1224,20 @@ func (f *Factory) WithUnAuthKubeClient() *Factory {
 	return f
 }
 
+// WithKubeClientFactory builds a factory for kubernetes API clients that don't depend on kubectl.
+func (f *Factory) WithKubeClientFactory() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.KubeClientFactory != nil {
+			return nil
+		}
+
+		f.dependencies.KubeClientFactory = kubernetes.NewRuntimeClientFactory()
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithVSphereValidator() *Factory {
 	f.WithGovc()
 
@@ -1134,6 +1259,63 @@ func (f *Factory) WithVSphereValidator() *Factory {
 	return f
 }
 
+// WithCloudStackValidatorRegistry builds a registry of CloudStack validators calling CloudStack with cmk.
+func (f *Factory) WithCloudStackValidatorRegistry(skipIpCheck bool) *Factory {
+	f.WithExecutableBuilder().WithWriter()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.CloudStackValidatorRegistry != nil {
+			return nil
+		}
+
+		f.dependencies.CloudStackValidatorRegistry = cloudstack.NewValidatorRegistry(
+			f.executablesConfig.builder,
+			f.dependencies.Writer,
+			&networkutils.DefaultNetClient{},
+			skipIpCheck,
+		)
+
+		return nil
+	})
+
+	return f
+}
+
+// WithCloudStackTagsRegistry builds a registry of clients managing the tags of CloudStack virtual machines with cmk.
+func (f *Factory) WithCloudStackTagsRegistry() *Factory {
+	f.WithExecutableBuilder().WithWriter()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.CloudStackTagsRegistry != nil {
+			return nil
+		}
+
+		f.dependencies.CloudStackTagsRegistry = cloudstack.NewTagsClientRegistry(
+			f.executablesConfig.builder,
+			f.dependencies.Writer,
+		)
+
+		return nil
+	})
+
+	return f
+}
+
+// WithNutanixValidatorRegistry builds a registry of Nutanix validators calling the Prism Central API.
+func (f *Factory) WithNutanixValidatorRegistry() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.NutanixValidatorRegistry != nil {
+			return nil
+		}
+
+		f.dependencies.NutanixValidatorRegistry = nutanix.NewValidatorRegistry(crypto.NewTlsValidator())
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithVSphereDefaulter() *Factory {
 	f.WithGovc()
 
@@ -1150,6 +1332,23 @@ func (f *Factory) WithVSphereDefaulter() *Factory {
 	return f
 }
 
+// WithVSphereClusterTagger builds a ClusterTagger creating the vCenter tags of clusters with govc.
+func (f *Factory) WithVSphereClusterTagger() *Factory {
+	f.WithGovc()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.VSphereClusterTagger != nil {
+			return nil
+		}
+
+		f.dependencies.VSphereClusterTagger = vsphere.NewClusterTagger(f.dependencies.Govc)
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithPrismClient(clusterConfigFile string) *Factory {
 	if f.dependencies.NutanixPrismClient != nil {
 		return f
@@ -1160,34 +1359,9 @@ func (f *Factory) WithPrismClient(clusterConfigFile string) *Factory {
 			return fmt.Errorf("unable to get datacenter config from file %s: %v", clusterConfigFile, err)
 		}
 
-		clientOpts := make([]v3.ClientOption, 0)
-		if datacenterConfig.Spec.AdditionalTrustBundle != "" {
-			block, _ := pem.Decode([]byte(datacenterConfig.Spec.AdditionalTrustBundle))
-			certs, err := x509.ParseCertificates(block.Bytes)
-			if err != nil {
-				return fmt.Errorf("unable to parse additional trust bundle %s: %v", datacenterConfig.Spec.AdditionalTrustBundle, err)
-			}
-			if len(certs) == 0 {
-				return fmt.Errorf("unable to extract certs from the addtional trust bundle %s", datacenterConfig.Spec.AdditionalTrustBundle)
-			}
-			clientOpts = append(clientOpts, v3.WithCertificate(certs[0]))
-		}
-
-		endpoint := datacenterConfig.Spec.Endpoint
-		port := datacenterConfig.Spec.Port
-		url := fmt.Sprintf("%s:%d", endpoint, port)
-		creds := nutanix.GetCredsFromEnv()
-		nutanixCreds := prismgoclient.Credentials{
-			URL:      url,
-			Username: creds.PrismCentral.Username,
-			Password: creds.PrismCentral.Password,
-			Endpoint: endpoint,
-			Port:     fmt.Sprintf("%d", port),
-		}
-
-		client, err := v3.NewV3Client(nutanixCreds, clientOpts...)
+		client, err := nutanix.NewPrismClient(datacenterConfig, nutanix.GetCredsFromEnv())
 		if err != nil {
-			return fmt.Errorf("error creating nutanix client: %v", err)
+			return err
 		}
 		f.dependencies.NutanixPrismClient = client
 		return nil
@@ -204,18 +204,22 @@ func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 		WithAnalyzerFactory().
 		WithCollectorFactory().
 		WithTroubleshoot().
+		WithSonobuoy().
 		WithCAPIManager().
 		WithManifestReader().
 		WithUnAuthKubeClient().
 		WithCmk().
 		WithVSphereDefaulter().
 		WithVSphereValidator().
+		WithVSphereClusterTagger().
 		WithCiliumTemplater().
 		Build(context.Background())
 
 	tt.Expect(err).To(BeNil())
 	tt.Expect(deps.Bootstrapper).NotTo(BeNil())
 	tt.Expect(deps.ClusterManager).NotTo(BeNil())
+	tt.Expect(deps.BackupInstaller).NotTo(BeNil())
+	tt.Expect(deps.OSUpdatesInstaller).NotTo(BeNil())
 	tt.Expect(deps.Provider).NotTo(BeNil())
 	tt.Expect(deps.GitOpsFlux).NotTo(BeNil())
 	tt.Expect(deps.Writer).NotTo(BeNil())
@@ -224,11 +228,13 @@ func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 	tt.Expect(deps.AnalyzerFactory).NotTo(BeNil())
 	tt.Expect(deps.CollectorFactory).NotTo(BeNil())
 	tt.Expect(deps.Troubleshoot).NotTo(BeNil())
+	tt.Expect(deps.Sonobuoy).NotTo(BeNil())
 	tt.Expect(deps.CAPIManager).NotTo(BeNil())
 	tt.Expect(deps.ManifestReader).NotTo(BeNil())
 	tt.Expect(deps.UnAuthKubeClient).NotTo(BeNil())
 	tt.Expect(deps.VSphereDefaulter).NotTo(BeNil())
 	tt.Expect(deps.VSphereValidator).NotTo(BeNil())
+	tt.Expect(deps.VSphereClusterTagger).NotTo(BeNil())
 	tt.Expect(deps.CiliumTemplater).NotTo(BeNil())
 }
 
@@ -0,0 +1,143 @@
+// Package deprecatedapis detects the usage of deprecated Kubernetes APIs in a cluster from the
+// kube-apiserver metrics.
+package deprecatedapis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/semver"
+)
+
+const metricName = "apiserver_requested_deprecated_apis"
+
+// API is a deprecated API requested since the kube-apiserver started.
+type API struct {
+	Group          string `json:"group,omitempty"`
+	Version        string `json:"version"`
+	Resource       string `json:"resource"`
+	RemovedRelease string `json:"removedRelease,omitempty"`
+}
+
+func (a API) String() string {
+	gv := a.Version
+	if a.Group != "" {
+		gv = a.Group + "/" + a.Version
+	}
+	return fmt.Sprintf("%s %s", gv, a.Resource)
+}
+
+// RemovedIn returns true if the API isn't served anymore in the Kubernetes version. It's false
+// for APIs without a planned removal release.
+func (a API) RemovedIn(version v1alpha1.KubernetesVersion) (bool, error) {
+	if a.RemovedRelease == "" {
+		return false, nil
+	}
+	removed, err := semver.New(a.RemovedRelease + ".0")
+	if err != nil {
+		return false, fmt.Errorf("parsing removed release of %s: %v", a, err)
+	}
+	target, err := semver.KubeVersionToValidSemver(version)
+	if err != nil {
+		return false, fmt.Errorf("parsing kubernetes version %s: %v", version, err)
+	}
+	return !removed.GreaterThan(target), nil
+}
+
+// Parse returns the deprecated APIs requested since the kube-apiserver started from its
+// metrics in the Prometheus text format. Subresources are reported with their resource.
+func Parse(metrics string) []API {
+	var apis []API
+	seen := map[API]struct{}{}
+	for _, line := range strings.Split(metrics, "\n") {
+		if !strings.HasPrefix(line, metricName+"{") {
+			continue
+		}
+
+		end := strings.LastIndex(line, "}")
+		if end < 0 {
+			continue
+		}
+		if value, err := strconv.ParseFloat(strings.TrimSpace(line[end+1:]), 64); err != nil || value == 0 {
+			continue
+		}
+
+		labels := labels(line[len(metricName)+1 : end])
+		api := API{
+			Group:          labels["group"],
+			Version:        labels["version"],
+			Resource:       labels["resource"],
+			RemovedRelease: labels["removed_release"],
+		}
+		if _, ok := seen[api]; ok {
+			continue
+		}
+		seen[api] = struct{}{}
+		apis = append(apis, api)
+	}
+
+	return apis
+}
+
+func labels(labels string) map[string]string {
+	m := map[string]string{}
+	for _, label := range strings.Split(labels, ",") {
+		name, value, found := strings.Cut(label, "=")
+		if !found {
+			continue
+		}
+		m[strings.TrimSpace(name)] = strings.Trim(value, `"`)
+	}
+	return m
+}
+
+// KubectlClient reads the kube-apiserver metrics.
+type KubectlClient interface {
+	GetAPIServerMetrics(ctx context.Context, kubeconfig string) (string, error)
+}
+
+// Scanner detects the usage of deprecated APIs in a cluster.
+type Scanner struct {
+	kubectl KubectlClient
+}
+
+// NewScanner constructs a new Scanner.
+func NewScanner(kubectl KubectlClient) *Scanner {
+	return &Scanner{
+		kubectl: kubectl,
+	}
+}
+
+// Scan returns the deprecated APIs requested in the cluster since its kube-apiserver started.
+func (s *Scanner) Scan(ctx context.Context, kubeconfig string) ([]API, error) {
+	metrics, err := s.kubectl.GetAPIServerMetrics(ctx, kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(metrics), nil
+}
+
+// Removed returns the APIs requested in the cluster that aren't served anymore in the
+// target Kubernetes version.
+func (s *Scanner) Removed(ctx context.Context, kubeconfig string, target v1alpha1.KubernetesVersion) ([]API, error) {
+	apis, err := s.Scan(ctx, kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []API
+	for _, api := range apis {
+		r, err := api.RemovedIn(target)
+		if err != nil {
+			return nil, err
+		}
+		if r {
+			removed = append(removed, api)
+		}
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,94 @@
+package deprecatedapis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/deprecatedapis"
+	"github.com/aws/eks-anywhere/pkg/deprecatedapis/mocks"
+)
+
+const (
+	kubeconfig = "my-cluster/my-cluster-eks-a-cluster.kubeconfig"
+	metrics    = `# HELP apiserver_requested_deprecated_apis [STABLE] Gauge of deprecated APIs that have been requested
+# TYPE apiserver_requested_deprecated_apis gauge
+apiserver_requested_deprecated_apis{group="policy",removed_release="1.25",resource="podsecuritypolicies",subresource="",version="v1beta1"} 1
+apiserver_requested_deprecated_apis{group="autoscaling",removed_release="1.26",resource="horizontalpodautoscalers",subresource="",version="v2beta2"} 1
+apiserver_requested_deprecated_apis{group="autoscaling",removed_release="1.26",resource="horizontalpodautoscalers",subresource="status",version="v2beta2"} 1
+apiserver_requested_deprecated_apis{group="batch",removed_release="1.25",resource="cronjobs",subresource="",version="v1beta1"} 0
+apiserver_requested_deprecated_apis{group="",removed_release="",resource="componentstatuses",subresource="",version="v1"} 1
+apiserver_request_total{code="200",resource="pods",verb="LIST",version="v1"} 12
+`
+)
+
+var (
+	psp = deprecatedapis.API{Group: "policy", Version: "v1beta1", Resource: "podsecuritypolicies", RemovedRelease: "1.25"}
+	hpa = deprecatedapis.API{Group: "autoscaling", Version: "v2beta2", Resource: "horizontalpodautoscalers", RemovedRelease: "1.26"}
+	cs  = deprecatedapis.API{Version: "v1", Resource: "componentstatuses"}
+)
+
+func TestParse(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(deprecatedapis.Parse(metrics)).To(Equal([]deprecatedapis.API{psp, hpa, cs}))
+}
+
+func TestParseEmpty(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(deprecatedapis.Parse("")).To(BeEmpty())
+}
+
+func TestAPIString(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(psp.String()).To(Equal("policy/v1beta1 podsecuritypolicies"))
+	g.Expect(cs.String()).To(Equal("v1 componentstatuses"))
+}
+
+func TestAPIRemovedIn(t *testing.T) {
+	tests := []struct {
+		name    string
+		api     deprecatedapis.API
+		version v1alpha1.KubernetesVersion
+		want    bool
+	}{
+		{name: "removed in version", api: psp, version: "1.25", want: true},
+		{name: "removed before version", api: psp, version: "1.26", want: true},
+		{name: "removed after version", api: psp, version: v1alpha1.Kube124, want: false},
+		{name: "no removal release", api: cs, version: "1.25", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.api.RemovedIn(tt.version)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestAPIRemovedInInvalidVersion(t *testing.T) {
+	g := NewWithT(t)
+	_, err := psp.RemovedIn("latest")
+	g.Expect(err).To(MatchError(ContainSubstring("parsing kubernetes version latest")))
+}
+
+func TestScannerRemoved(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	kubectl := mocks.NewMockKubectlClient(gomock.NewController(t))
+	kubectl.EXPECT().GetAPIServerMetrics(ctx, kubeconfig).Return(metrics, nil)
+
+	g.Expect(deprecatedapis.NewScanner(kubectl).Removed(ctx, kubeconfig, "1.25")).To(Equal([]deprecatedapis.API{psp}))
+}
+
+func TestScannerRemovedError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	kubectl := mocks.NewMockKubectlClient(gomock.NewController(t))
+	kubectl.EXPECT().GetAPIServerMetrics(ctx, kubeconfig).Return("", errors.New("forbidden"))
+
+	_, err := deprecatedapis.NewScanner(kubectl).Removed(ctx, kubeconfig, "1.25")
+	g.Expect(err).To(MatchError("forbidden"))
+}
@@ -34,6 +34,7 @@ type clusterInfo struct {
 type logLimits struct {
 	MaxAge    string `json:"maxAge,omitempty"`
 	MaxLines  int64  `json:"maxLines,omitempty"`
+	MaxBytes  int64  `json:"maxBytes,omitempty"`
 	SinceTime metav1.Time
 }
 
@@ -2,9 +2,14 @@ package diagnostics
 
 import (
 	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -12,6 +17,10 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers"
 )
 
+// serialConsoleLogMaxBytes is the size of the end of each serial console log collected, which is where the boot
+// failures are.
+const serialConsoleLogMaxBytes = 64 * 1024
+
 type collectorFactory struct {
 	DiagnosticCollectorImage string
 }
@@ -69,19 +78,20 @@ func (c *collectorFactory) DataCenterConfigCollectors(datacenter v1alpha1.Ref, s
 	case v1alpha1.DockerDatacenterKind:
 		return c.eksaDockerCollectors()
 	case v1alpha1.CloudStackDatacenterKind:
-		return c.eksaCloudstackCollectors()
+		return c.eksaCloudstackCollectors(spec)
 	case v1alpha1.TinkerbellDatacenterKind:
-		return c.eksaTinkerbellCollectors()
+		return c.eksaTinkerbellCollectors(spec)
 	case v1alpha1.SnowDatacenterKind:
 		return c.eksaSnowCollectors()
 	case v1alpha1.NutanixDatacenterKind:
-		return c.eksaNutanixCollectors()
+		return c.eksaNutanixCollectors(spec)
 	default:
 		return nil
 	}
 }
 
-func (c *collectorFactory) eksaNutanixCollectors() []*Collect {
+func (c *collectorFactory) eksaNutanixCollectors(spec *cluster.Spec) []*Collect {
+	var collectors []*Collect
 	nutanixLogs := []*Collect{
 		{
 			Logs: &logs{
@@ -90,7 +100,14 @@ func (c *collectorFactory) eksaNutanixCollectors() []*Collect {
 			},
 		},
 	}
-	return append(nutanixLogs, c.nutanixCrdCollectors()...)
+	collectors = append(collectors, nutanixLogs...)
+	collectors = append(collectors, c.nutanixCrdCollectors()...)
+	collectors = append(collectors, c.controlPlaneEndpointCollectors(spec)...)
+	if spec.NutanixDatacenter != nil {
+		prismCentral := fmt.Sprintf("https://%s:%d", spec.NutanixDatacenter.Spec.Endpoint, spec.NutanixDatacenter.Spec.Port)
+		collectors = append(collectors, c.endpointAccessCollector("check-prism-central", prismCentral))
+	}
+	return collectors
 }
 
 func (c *collectorFactory) eksaSnowCollectors() []*Collect {
@@ -105,7 +122,7 @@ func (c *collectorFactory) eksaSnowCollectors() []*Collect {
 	return append(snowLogs, c.snowCrdCollectors()...)
 }
 
-func (c *collectorFactory) eksaTinkerbellCollectors() []*Collect {
+func (c *collectorFactory) eksaTinkerbellCollectors(spec *cluster.Spec) []*Collect {
 	tinkerbellLogs := []*Collect{
 		{
 			Logs: &logs{
@@ -114,6 +131,16 @@ func (c *collectorFactory) eksaTinkerbellCollectors() []*Collect {
 			},
 		},
 	}
+	if spec.TinkerbellDatacenter != nil && spec.TinkerbellDatacenter.Spec.SerialConsole.IsEnabled() {
+		// The Hook forwards its console to Boots, so the boot-time logs of the machines end up in the Boots logs.
+		tinkerbellLogs = append(tinkerbellLogs, &Collect{
+			Logs: &logs{
+				Namespace: constants.EksaSystemNamespace,
+				Name:      logpath("boots"),
+				Selector:  []string{"app=boots"},
+			},
+		})
+	}
 	return append(tinkerbellLogs, c.tinkerbellCrdCollectors()...)
 }
 
@@ -131,10 +158,12 @@ func (c *collectorFactory) eksaVsphereCollectors(spec *cluster.Spec) []*Collect
 	collectors = append(collectors, c.vsphereCrdCollectors()...)
 	collectors = append(collectors, c.apiServerCollectors(spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host)...)
 	collectors = append(collectors, c.vmsAccessCollector(spec.Cluster.Spec.ControlPlaneConfiguration))
+	collectors = append(collectors, c.vsphereSerialConsoleCollectors(spec)...)
 	return collectors
 }
 
-func (c *collectorFactory) eksaCloudstackCollectors() []*Collect {
+func (c *collectorFactory) eksaCloudstackCollectors(spec *cluster.Spec) []*Collect {
+	var collectors []*Collect
 	cloudstackLogs := []*Collect{
 		{
 			Logs: &logs{
@@ -143,7 +172,15 @@ func (c *collectorFactory) eksaCloudstackCollectors() []*Collect {
 			},
 		},
 	}
-	return append(cloudstackLogs, c.cloudstackCrdCollectors()...)
+	collectors = append(collectors, cloudstackLogs...)
+	collectors = append(collectors, c.cloudstackCrdCollectors()...)
+	collectors = append(collectors, c.controlPlaneEndpointCollectors(spec)...)
+	if spec.CloudStackDatacenter != nil {
+		for i, az := range spec.CloudStackDatacenter.Spec.AvailabilityZones {
+			collectors = append(collectors, c.endpointAccessCollector(fmt.Sprintf("check-cloudstack-management-api-%d", i), az.ManagementApiEndpoint))
+		}
+	}
+	return collectors
 }
 
 func (c *collectorFactory) eksaDockerCollectors() []*Collect {
@@ -365,6 +402,7 @@ func (c *collectorFactory) cloudstackCrdCollectors() []*Collect {
 		"cloudstackaffinitygroups.infrastructure.cluster.x-k8s.io",
 		"cloudstackclusters.infrastructure.cluster.x-k8s.io",
 		"cloudstackdatacenterconfigs.anywhere.eks.amazonaws.com",
+		"cloudstackfailuredomains.infrastructure.cluster.x-k8s.io",
 		"cloudstackisolatednetworks.infrastructure.cluster.x-k8s.io",
 		"cloudstackmachineconfigs.anywhere.eks.amazonaws.com",
 		"cloudstackmachines.infrastructure.cluster.x-k8s.io",
@@ -444,6 +482,15 @@ func (c *collectorFactory) apiServerCollectors(controlPlaneIP string) []*Collect
 	return collectors
 }
 
+// controlPlaneEndpointCollectors collect connection info to the control plane endpoint of the cluster, if it has one.
+func (c *collectorFactory) controlPlaneEndpointCollectors(spec *cluster.Spec) []*Collect {
+	endpoint := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint
+	if endpoint == nil || endpoint.Host == "" {
+		return nil
+	}
+	return c.apiServerCollectors(endpoint.Host)
+}
+
 func (c *collectorFactory) controlPlaneNetworkPathCollector(controlPlaneIP string) []*Collect {
 	ports := []string{"6443", "22"}
 	var collectors []*Collect
@@ -494,6 +541,27 @@ func (c *collectorFactory) pingHostCollector(hostIP string) *Collect {
 	}
 }
 
+// endpointAccessCollector checks from a pod in the cluster that the provider API at url can be reached, collecting
+// the HTTP status code it returns.
+func (c *collectorFactory) endpointAccessCollector(name, url string) *Collect {
+	request := fmt.Sprintf("curl -k -s -S -o /dev/null -w \"%%{http_code}\n\" --max-time 10 %s; echo exit code: $?", url)
+	return &Collect{
+		RunPod: &runPod{
+			Name:      name,
+			Namespace: constants.EksaDiagnosticsNamespace,
+			PodSpec: &v1.PodSpec{
+				Containers: []v1.Container{{
+					Name:    name,
+					Image:   c.DiagnosticCollectorImage,
+					Command: []string{"/bin/sh", "-c"},
+					Args:    []string{request},
+				}},
+			},
+			Timeout: "30s",
+		},
+	}
+}
+
 // vmsAccessCollector will connect to API server first, then collect vsphere-cloud-controller-manager logs
 // on control plane node.
 func (c *collectorFactory) vmsAccessCollector(controlPlaneConfiguration v1alpha1.ControlPlaneConfiguration) *Collect {
@@ -547,6 +615,62 @@ func (c *collectorFactory) vmsAccessCollector(controlPlaneConfiguration v1alpha1
 	}
 }
 
+// vsphereSerialConsoleCollectors collect the serial console logs of the cluster VMs from the datastores of the machine
+// configs that enable them. The logs are downloaded from vCenter, so they are collected even for the machines that
+// never joined the cluster. The VMs are listed from their VSphereVMs, which only exist in the management cluster.
+func (c *collectorFactory) vsphereSerialConsoleCollectors(spec *cluster.Spec) []*Collect {
+	if spec.VSphereDatacenter == nil {
+		return nil
+	}
+
+	datastoresSeen := map[string]bool{}
+	var datastores []string
+	for _, machineConfig := range spec.VSphereMachineConfigs {
+		datastore := machineConfig.Spec.Datastore
+		if machineConfig.Spec.SerialConsole.IsEnabled() && !datastoresSeen[datastore] {
+			datastores = append(datastores, datastore)
+			datastoresSeen[datastore] = true
+		}
+	}
+	sort.Strings(datastores)
+
+	collectors := make([]*Collect, 0, len(datastores))
+	for i, datastore := range datastores {
+		collectors = append(collectors, c.vsphereSerialConsoleCollector(fmt.Sprintf("vsphere-serial-console-%d", i), spec, datastore))
+	}
+	return collectors
+}
+
+func (c *collectorFactory) vsphereSerialConsoleCollector(name string, spec *cluster.Spec, datastore string) *Collect {
+	query := url.Values{
+		"dcPath": []string{strings.TrimPrefix(spec.VSphereDatacenter.Spec.Datacenter, "/")},
+		"dsName": []string{path.Base(datastore)},
+	}
+	credential := func(key string) string {
+		return fmt.Sprintf("kubectl get secret -n %s %s -o jsonpath='{.data.%s}' | base64 -d", constants.EksaSystemNamespace, constants.VSphereCredentialsName, key)
+	}
+	listVMs := fmt.Sprintf("kubectl get vspherevms -n %s -l %s=%s -o jsonpath='{.items[*].metadata.name}'", constants.EksaSystemNamespace, clusterv1.ClusterLabelName, spec.Cluster.Name)
+	getLog := fmt.Sprintf("curl -k -s -S -f --max-time 30 -u \"$VSPHERE_USERNAME:$VSPHERE_PASSWORD\" \"https://%s/folder/$vm/%s?%s\" | tail -c %d", spec.VSphereDatacenter.Spec.Server, constants.VSphereSerialConsoleLogFile, query.Encode(), serialConsoleLogMaxBytes)
+	script := fmt.Sprintf("VSPHERE_USERNAME=$(%s); VSPHERE_PASSWORD=$(%s); for vm in $(%s); do echo \"==> $vm\"; %s; done", credential("username"), credential("password"), listVMs, getLog)
+
+	return &Collect{
+		RunPod: &runPod{
+			Name:      name,
+			Namespace: constants.EksaDiagnosticsNamespace,
+			PodSpec: &v1.PodSpec{
+				Containers: []v1.Container{{
+					Name:    name,
+					Image:   c.DiagnosticCollectorImage,
+					Command: []string{"/bin/sh", "-c"},
+					Args:    []string{script},
+				}},
+				ServiceAccountName: "default",
+			},
+			Timeout: "2m",
+		},
+	}
+}
+
 func makeTolerations(taints []v1.Taint) []v1.Toleration {
 	tolerations := []v1.Toleration{
 		{
@@ -64,13 +64,57 @@ func TestVsphereDataCenterConfigCollectors(t *testing.T) {
 	g.Expect(collectors[10].RunPod.PodSpec.Containers[0].Name).To(Equal("check-cloud-controller"))
 }
 
+func TestVsphereDataCenterConfigCollectorsSerialConsole(t *testing.T) {
+	g := NewGomegaWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &eksav1alpha1.Endpoint{Host: "1.1.1.1"}
+		s.VSphereDatacenter = &eksav1alpha1.VSphereDatacenterConfig{
+			Spec: eksav1alpha1.VSphereDatacenterConfigSpec{
+				Server:     "vcenter.example.com",
+				Datacenter: "/SDDC-Datacenter",
+			},
+		}
+		s.VSphereMachineConfigs = map[string]*eksav1alpha1.VSphereMachineConfig{
+			"cp": {
+				Spec: eksav1alpha1.VSphereMachineConfigSpec{
+					Datastore:     "/SDDC-Datacenter/datastore/WorkloadDatastore",
+					SerialConsole: &eksav1alpha1.SerialConsoleConfiguration{Enabled: true},
+				},
+			},
+			"md-0": {
+				Spec: eksav1alpha1.VSphereMachineConfigSpec{
+					Datastore:     "/SDDC-Datacenter/datastore/WorkloadDatastore",
+					SerialConsole: &eksav1alpha1.SerialConsoleConfiguration{Enabled: true},
+				},
+			},
+			"md-1": {
+				Spec: eksav1alpha1.VSphereMachineConfigSpec{
+					Datastore: "/SDDC-Datacenter/datastore/OtherDatastore",
+				},
+			},
+		}
+	})
+	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.VSphereDatacenterKind}
+	factory := diagnostics.NewDefaultCollectorFactory()
+	collectors := factory.DataCenterConfigCollectors(datacenter, spec)
+	g.Expect(collectors).To(HaveLen(12), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
+	serialConsole := collectors[11].RunPod
+	g.Expect(serialConsole.Name).To(Equal("vsphere-serial-console-0"))
+	g.Expect(serialConsole.Namespace).To(Equal("eksa-diagnostics"))
+	g.Expect(serialConsole.PodSpec.Containers[0].Args[0]).To(And(
+		ContainSubstring("kubectl get vspherevms -n eksa-system -l cluster.x-k8s.io/cluster-name=test-cluster"),
+		ContainSubstring("https://vcenter.example.com/folder/$vm/serial-console.log?dcPath=SDDC-Datacenter&dsName=WorkloadDatastore"),
+	))
+}
+
 func TestCloudStackDataCenterConfigCollectors(t *testing.T) {
 	g := NewGomegaWithT(t)
 	spec := test.NewClusterSpec(func(s *cluster.Spec) {})
 	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.CloudStackDatacenterKind}
 	factory := diagnostics.NewDefaultCollectorFactory()
 	collectors := factory.DataCenterConfigCollectors(datacenter, spec)
-	g.Expect(collectors).To(HaveLen(10), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors).To(HaveLen(11), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
 	g.Expect(collectors[0].Logs.Namespace).To(Equal(constants.CapcSystemNamespace))
 	g.Expect(collectors[0].Logs.Name).To(Equal(fmt.Sprintf("logs/%s", constants.CapcSystemNamespace)))
 	for _, collector := range collectors[1:] {
@@ -79,6 +123,31 @@ func TestCloudStackDataCenterConfigCollectors(t *testing.T) {
 	}
 }
 
+func TestCloudStackDataCenterConfigCollectorsConnectivity(t *testing.T) {
+	g := NewGomegaWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &eksav1alpha1.Endpoint{Host: "1.1.1.1"}
+		s.CloudStackDatacenter = &eksav1alpha1.CloudStackDatacenterConfig{
+			Spec: eksav1alpha1.CloudStackDatacenterConfigSpec{
+				AvailabilityZones: []eksav1alpha1.CloudStackAvailabilityZone{
+					{Name: "az-1", ManagementApiEndpoint: "http://cloudstack-1:8080/client/api"},
+					{Name: "az-2", ManagementApiEndpoint: "http://cloudstack-2:8080/client/api"},
+				},
+			},
+		}
+	})
+	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.CloudStackDatacenterKind}
+	factory := diagnostics.NewDefaultCollectorFactory()
+	collectors := factory.DataCenterConfigCollectors(datacenter, spec)
+	g.Expect(collectors).To(HaveLen(15), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors[11].RunPod.PodSpec.Containers[0].Name).To(Equal("check-host-port"))
+	g.Expect(collectors[12].RunPod.PodSpec.Containers[0].Name).To(Equal("ping-host-ip"))
+	g.Expect(collectors[13].RunPod.Name).To(Equal("check-cloudstack-management-api-0"))
+	g.Expect(collectors[13].RunPod.PodSpec.Containers[0].Args[0]).To(ContainSubstring("http://cloudstack-1:8080/client/api"))
+	g.Expect(collectors[14].RunPod.Name).To(Equal("check-cloudstack-management-api-1"))
+	g.Expect(collectors[14].RunPod.PodSpec.Containers[0].Args[0]).To(ContainSubstring("http://cloudstack-2:8080/client/api"))
+}
+
 func TestTinkerbellDataCenterConfigCollectors(t *testing.T) {
 	g := NewGomegaWithT(t)
 	spec := test.NewClusterSpec(func(s *cluster.Spec) {})
@@ -94,6 +163,23 @@ func TestTinkerbellDataCenterConfigCollectors(t *testing.T) {
 	}
 }
 
+func TestTinkerbellDataCenterConfigCollectorsSerialConsole(t *testing.T) {
+	g := NewGomegaWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.TinkerbellDatacenter = &eksav1alpha1.TinkerbellDatacenterConfig{
+			Spec: eksav1alpha1.TinkerbellDatacenterConfigSpec{
+				SerialConsole: &eksav1alpha1.SerialConsoleConfiguration{Enabled: true},
+			},
+		}
+	})
+	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellDatacenterKind}
+	factory := diagnostics.NewDefaultCollectorFactory()
+	collectors := factory.DataCenterConfigCollectors(datacenter, spec)
+	g.Expect(collectors).To(HaveLen(14), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors[1].Logs.Namespace).To(Equal(constants.EksaSystemNamespace))
+	g.Expect(collectors[1].Logs.Selector).To(Equal([]string{"app=boots"}))
+}
+
 func TestSnowCollectors(t *testing.T) {
 	g := NewGomegaWithT(t)
 	spec := test.NewClusterSpec(func(s *cluster.Spec) {})
@@ -123,3 +209,24 @@ func TestNutanixCollectors(t *testing.T) {
 		g.Expect("eksa-diagnostics").To(Equal(collector.RunPod.Namespace))
 	}
 }
+
+func TestNutanixCollectorsConnectivity(t *testing.T) {
+	g := NewGomegaWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &eksav1alpha1.Endpoint{Host: "1.1.1.1"}
+		s.NutanixDatacenter = &eksav1alpha1.NutanixDatacenterConfig{
+			Spec: eksav1alpha1.NutanixDatacenterConfigSpec{
+				Endpoint: "prism.nutanix.com",
+				Port:     9440,
+			},
+		}
+	})
+	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.NutanixDatacenterKind}
+	factory := diagnostics.NewDefaultCollectorFactory()
+	collectors := factory.DataCenterConfigCollectors(datacenter, spec)
+	g.Expect(collectors).To(HaveLen(9), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors[6].RunPod.PodSpec.Containers[0].Name).To(Equal("check-host-port"))
+	g.Expect(collectors[7].RunPod.PodSpec.Containers[0].Name).To(Equal("ping-host-ip"))
+	g.Expect(collectors[8].RunPod.Name).To(Equal("check-prism-central"))
+	g.Expect(collectors[8].RunPod.PodSpec.Containers[0].Args[0]).To(ContainSubstring("https://prism.nutanix.com:9440"))
+}
@@ -32,9 +32,18 @@ const (
 	defaultClusterName          = "eksa-cluster"
 )
 
+// bundleLimits are the redaction rules and size caps applied to the generated support bundles.
+type bundleLimits struct {
+	// redactions are regexes masked from all the collected files on top of the default redactors.
+	redactions []string
+	// logsMaxBytes caps the size of the logs collected from each container. 0 means no limit.
+	logsMaxBytes int64
+}
+
 type EksaDiagnosticBundle struct {
 	bundle           *supportBundle
 	bundlePath       string
+	redactorsPath    string
 	client           BundleClient
 	collectorFactory CollectorFactory
 	clusterSpec      *cluster.Spec
@@ -44,10 +53,11 @@ type EksaDiagnosticBundle struct {
 	retrier          *retrier.Retrier
 	writer           filewriter.FileWriter
 	analysis         []*executables.SupportBundleAnalysis
+	limits           bundleLimits
 }
 
 func newDiagnosticBundleManagementCluster(af AnalyzerFactory, cf CollectorFactory, spec *cluster.Spec, client BundleClient,
-	kubectl *executables.Kubectl, kubeconfig string, writer filewriter.FileWriter,
+	kubectl *executables.Kubectl, kubeconfig string, writer filewriter.FileWriter, limits bundleLimits,
 ) (*EksaDiagnosticBundle, error) {
 	b := &EksaDiagnosticBundle{
 		bundle: &supportBundle{
@@ -67,13 +77,15 @@ func newDiagnosticBundleManagementCluster(af AnalyzerFactory, cf CollectorFactor
 		kubeconfig:       kubeconfig,
 		retrier:          retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
 		writer:           writer,
+		limits:           limits,
 	}
 
 	b.WithDefaultCollectors().
 		WithDefaultAnalyzers().
 		WithManagementCluster(true).
 		WithDatacenterConfig(spec.Cluster.Spec.DatacenterRef, spec).
-		WithLogTextAnalyzers()
+		WithLogTextAnalyzers().
+		withLogsMaxBytes()
 
 	err := b.WriteBundleConfig()
 	if err != nil {
@@ -84,7 +96,7 @@ func newDiagnosticBundleManagementCluster(af AnalyzerFactory, cf CollectorFactor
 }
 
 func newDiagnosticBundleFromSpec(af AnalyzerFactory, cf CollectorFactory, spec *cluster.Spec, provider providers.Provider,
-	client BundleClient, kubectl *executables.Kubectl, kubeconfig string, writer filewriter.FileWriter,
+	client BundleClient, kubectl *executables.Kubectl, kubeconfig string, writer filewriter.FileWriter, limits bundleLimits,
 ) (*EksaDiagnosticBundle, error) {
 	b := &EksaDiagnosticBundle{
 		bundle: &supportBundle{
@@ -105,6 +117,7 @@ func newDiagnosticBundleFromSpec(af AnalyzerFactory, cf CollectorFactory, spec *
 		kubectl:          kubectl,
 		retrier:          retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
 		writer:           writer,
+		limits:           limits,
 	}
 
 	b = b.
@@ -117,7 +130,8 @@ func newDiagnosticBundleFromSpec(af AnalyzerFactory, cf CollectorFactory, spec *
 		WithDefaultAnalyzers().
 		WithDefaultCollectors().
 		WithPackagesCollectors().
-		WithLogTextAnalyzers()
+		WithLogTextAnalyzers().
+		withLogsMaxBytes()
 
 	err := b.WriteBundleConfig()
 	if err != nil {
@@ -145,7 +159,7 @@ func newDiagnosticBundleDefault(af AnalyzerFactory, cf CollectorFactory) *EksaDi
 	return b.WithDefaultAnalyzers().WithDefaultCollectors().WithManagementCluster(true)
 }
 
-func newDiagnosticBundleCustom(af AnalyzerFactory, cf CollectorFactory, client BundleClient, kubectl *executables.Kubectl, bundlePath string, kubeconfig string, writer filewriter.FileWriter) *EksaDiagnosticBundle {
+func newDiagnosticBundleCustom(af AnalyzerFactory, cf CollectorFactory, client BundleClient, kubectl *executables.Kubectl, bundlePath string, kubeconfig string, writer filewriter.FileWriter, limits bundleLimits) *EksaDiagnosticBundle {
 	return &EksaDiagnosticBundle{
 		bundlePath:       bundlePath,
 		analyzerFactory:  af,
@@ -155,14 +169,19 @@ func newDiagnosticBundleCustom(af AnalyzerFactory, cf CollectorFactory, client B
 		kubectl:          kubectl,
 		retrier:          retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
 		writer:           writer,
+		limits:           limits,
 	}
 }
 
 func (e *EksaDiagnosticBundle) CollectAndAnalyze(ctx context.Context, sinceTimeValue *time.Time) error {
 	e.createDiagnosticNamespaceAndRoles(ctx)
 
+	if err := e.writeRedactors(); err != nil {
+		return err
+	}
+
 	logger.Info("⏳ Collecting support bundle from cluster, this can take a while", "cluster", e.clusterName(), "bundle", e.bundlePath, "since", sinceTimeValue, "kubeconfig", e.kubeconfig)
-	archivePath, err := e.client.Collect(ctx, e.bundlePath, sinceTimeValue, e.kubeconfig)
+	archivePath, err := e.client.Collect(ctx, e.bundlePath, e.redactorsPath, sinceTimeValue, e.kubeconfig)
 	if err != nil {
 		return fmt.Errorf("failed to Collect support bundle: %v", err)
 	}
@@ -210,6 +229,27 @@ func (e *EksaDiagnosticBundle) WriteBundleConfig() error {
 	return nil
 }
 
+// writeRedactors writes the redactors that mask the EKS-A credentials and the configured redactions
+// from the collected files.
+func (e *EksaDiagnosticBundle) writeRedactors() error {
+	r, err := eksaRedactor(e.limits.redactions)
+	if err != nil {
+		return err
+	}
+	redactorsYaml, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("outputing redactors yaml: %v", err)
+	}
+	timestamp := time.Now().Format(time.RFC3339)
+	filename := fmt.Sprintf(generatedRedactorsNameFormat, e.clusterName(), timestamp)
+	e.redactorsPath, err = e.writer.Write(filename, redactorsYaml)
+	if err != nil {
+		return err
+	}
+	logger.V(3).Info("redactors config written", "path", e.redactorsPath)
+	return nil
+}
+
 func (e *EksaDiagnosticBundle) PrintAnalysis() error {
 	if e.analysis == nil {
 		return nil
@@ -303,6 +343,28 @@ func (e *EksaDiagnosticBundle) WithLogTextAnalyzers() *EksaDiagnosticBundle {
 	return e
 }
 
+// withLogsMaxBytes caps the size of the logs the logs collectors in the bundle gather from each container,
+// unless the collector already sets its own cap. troubleshoot applies the cap to each container log, not to
+// the whole collector.
+func (e *EksaDiagnosticBundle) withLogsMaxBytes() *EksaDiagnosticBundle {
+	if e.limits.logsMaxBytes <= 0 {
+		return e
+	}
+
+	for _, c := range e.bundle.Spec.Collectors {
+		if c.Logs == nil {
+			continue
+		}
+		if c.Logs.Limits == nil {
+			c.Logs.Limits = &logLimits{}
+		}
+		if c.Logs.Limits.MaxBytes == 0 {
+			c.Logs.Limits.MaxBytes = e.limits.logsMaxBytes
+		}
+	}
+	return e
+}
+
 // createDiagnosticNamespace attempts to create the namespace eksa-diagnostics and associated RBAC objects.
 // collector pods, for example host log collectors or run command collectors, will be launched in this namespace with the default service account.
 // this method intentionally does not return an error
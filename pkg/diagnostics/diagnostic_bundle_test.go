@@ -3,10 +3,12 @@ package diagnostics_test
 import (
 	"bytes"
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -17,6 +19,7 @@ import (
 	supportMocks "github.com/aws/eks-anywhere/pkg/diagnostics/interfaces/mocks"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providerMocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
@@ -335,7 +338,7 @@ func TestBundleFromSpecComplete(t *testing.T) {
 		c.EXPECT().PackagesCollectors().Return(nil)
 
 		w := givenWriter(t)
-		w.EXPECT().Write(gomock.Any(), gomock.Any()).Times(2)
+		w.EXPECT().Write(gomock.Any(), gomock.Any()).Times(3)
 
 		k, e := givenKubectl(t)
 		expectedParam := []string{"create", "namespace", constants.EksaDiagnosticsNamespace, "--kubeconfig", kubeconfig}
@@ -363,7 +366,7 @@ func TestBundleFromSpecComplete(t *testing.T) {
 
 		tc := givenTroubleshootClient(t)
 		mockArchivePath := "/tmp/archive/path"
-		tc.EXPECT().Collect(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockArchivePath, nil)
+		tc.EXPECT().Collect(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockArchivePath, nil)
 		tc.EXPECT().Analyze(ctx, gomock.Any(), mockArchivePath).Return(returnAnalysis, nil)
 
 		opts := diagnostics.EksaDiagnosticBundleFactoryOpts{
@@ -540,3 +543,91 @@ func TestGenerateManagementClusterBundleDockerProvider(t *testing.T) {
 		}
 	})
 }
+
+func TestGenerateManagementClusterBundleLogsMaxBytes(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "bootstrap-cluster"
+		s.Cluster.Spec.DatacenterRef = eksav1alpha1.Ref{
+			Kind: eksav1alpha1.DockerDatacenterKind,
+		}
+	})
+
+	a := givenMockAnalyzerFactory(t)
+	a.EXPECT().DefaultAnalyzers().Return(nil)
+	a.EXPECT().ManagementClusterAnalyzers().Return(nil)
+	a.EXPECT().DataCenterConfigAnalyzers(spec.Cluster.Spec.DatacenterRef).Return(nil)
+	a.EXPECT().EksaLogTextAnalyzers(gomock.Any()).Return(nil)
+
+	var bundleYaml []byte
+	w := givenWriter(t)
+	w.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(name string, content []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+		bundleYaml = content
+		return name, nil
+	})
+
+	opts := diagnostics.EksaDiagnosticBundleFactoryOpts{
+		AnalyzerFactory:  a,
+		CollectorFactory: diagnostics.NewDefaultCollectorFactory(),
+		Writer:           w,
+		LogsMaxBytes:     1048576,
+	}
+
+	f := diagnostics.NewFactory(opts)
+	_, err := f.DiagnosticBundleManagementCluster(spec, "testcluster.kubeconfig")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(bundleYaml)).To(ContainSubstring("maxBytes: 1048576"))
+	g.Expect(strings.Count(string(bundleYaml), "maxBytes: 1048576")).To(Equal(strings.Count(string(bundleYaml), "- logs:")))
+}
+
+func TestCollectAndAnalyzeCustomBundleRedactions(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	kubeconfig := "testcluster.kubeconfig"
+
+	k, e := givenKubectl(t)
+	e.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, nil).Times(2)
+	e.EXPECT().ExecuteWithStdin(ctx, gomock.Any(), gomock.Any()).Return(bytes.Buffer{}, nil).Times(2)
+
+	var redactorsYaml []byte
+	w := givenWriter(t)
+	w.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(name string, content []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+		if strings.Contains(name, "redactors") {
+			redactorsYaml = content
+		}
+		return name, nil
+	}).Times(2)
+
+	tc := givenTroubleshootClient(t)
+	tc.EXPECT().Collect(ctx, "bundle.yaml", gomock.Any(), gomock.Any(), kubeconfig).Return("/tmp/archive/path", nil)
+	tc.EXPECT().Analyze(ctx, "bundle.yaml", "/tmp/archive/path").Return([]*executables.SupportBundleAnalysis{{Title: "test"}}, nil)
+
+	opts := getOpts(t)
+	opts.Kubectl = k
+	opts.Writer = w
+	opts.Client = tc
+	opts.Redactions = []string{`api-token=\w+`}
+
+	f := diagnostics.NewFactory(opts)
+	b := f.DiagnosticBundleCustom(kubeconfig, "bundle.yaml")
+	g.Expect(b.CollectAndAnalyze(ctx, nil)).To(Succeed())
+	g.Expect(string(redactorsYaml)).To(ContainSubstring("name: user redactions"))
+	g.Expect(string(redactorsYaml)).To(ContainSubstring(`redactor: api-token=\w+`))
+}
+
+func TestCollectAndAnalyzeCustomBundleInvalidRedaction(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	k, e := givenKubectl(t)
+	e.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, nil)
+	e.EXPECT().ExecuteWithStdin(ctx, gomock.Any(), gomock.Any()).Return(bytes.Buffer{}, nil)
+
+	opts := getOpts(t)
+	opts.Kubectl = k
+	opts.Redactions = []string{"api-token=("}
+
+	f := diagnostics.NewFactory(opts)
+	b := f.DiagnosticBundleCustom("testcluster.kubeconfig", "bundle.yaml")
+	g.Expect(b.CollectAndAnalyze(ctx, nil)).To(MatchError(ContainSubstring("invalid redaction api-token=(")))
+}
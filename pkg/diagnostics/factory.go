@@ -15,6 +15,10 @@ type EksaDiagnosticBundleFactoryOpts struct {
 	CollectorFactory CollectorFactory
 	Kubectl          *executables.Kubectl
 	Writer           filewriter.FileWriter
+	// Redactions are regexes masked from all the collected files on top of the default redactors.
+	Redactions []string
+	// LogsMaxBytes caps the size of the logs collected from each container. 0 means no limit.
+	LogsMaxBytes int64
 }
 
 type eksaDiagnosticBundleFactory struct {
@@ -23,6 +27,7 @@ type eksaDiagnosticBundleFactory struct {
 	collectorFactory CollectorFactory
 	kubectl          *executables.Kubectl
 	writer           filewriter.FileWriter
+	limits           bundleLimits
 }
 
 func NewFactory(opts EksaDiagnosticBundleFactoryOpts) *eksaDiagnosticBundleFactory {
@@ -32,6 +37,10 @@ func NewFactory(opts EksaDiagnosticBundleFactoryOpts) *eksaDiagnosticBundleFacto
 		collectorFactory: opts.CollectorFactory,
 		kubectl:          opts.Kubectl,
 		writer:           opts.Writer,
+		limits: bundleLimits{
+			redactions:   opts.Redactions,
+			logsMaxBytes: opts.LogsMaxBytes,
+		},
 	}
 }
 
@@ -44,11 +53,11 @@ func (f *eksaDiagnosticBundleFactory) DiagnosticBundle(spec *cluster.Spec, provi
 }
 
 func (f *eksaDiagnosticBundleFactory) DiagnosticBundleManagementCluster(spec *cluster.Spec, kubeconfig string) (DiagnosticBundle, error) {
-	return newDiagnosticBundleManagementCluster(f.analyzerFactory, f.collectorFactory, spec, f.client, f.kubectl, kubeconfig, f.writer)
+	return newDiagnosticBundleManagementCluster(f.analyzerFactory, f.collectorFactory, spec, f.client, f.kubectl, kubeconfig, f.writer, f.limits)
 }
 
 func (f *eksaDiagnosticBundleFactory) DiagnosticBundleWorkloadCluster(spec *cluster.Spec, provider providers.Provider, kubeconfig string) (DiagnosticBundle, error) {
-	return newDiagnosticBundleFromSpec(f.analyzerFactory, f.collectorFactory, spec, provider, f.client, f.kubectl, kubeconfig, f.writer)
+	return newDiagnosticBundleFromSpec(f.analyzerFactory, f.collectorFactory, spec, provider, f.client, f.kubectl, kubeconfig, f.writer, f.limits)
 }
 
 func (f *eksaDiagnosticBundleFactory) DiagnosticBundleDefault() DiagnosticBundle {
@@ -56,5 +65,5 @@ func (f *eksaDiagnosticBundleFactory) DiagnosticBundleDefault() DiagnosticBundle
 }
 
 func (f *eksaDiagnosticBundleFactory) DiagnosticBundleCustom(kubeconfig string, bundlePath string) DiagnosticBundle {
-	return newDiagnosticBundleCustom(f.analyzerFactory, f.collectorFactory, f.client, f.kubectl, bundlePath, kubeconfig, f.writer)
+	return newDiagnosticBundleCustom(f.analyzerFactory, f.collectorFactory, f.client, f.kubectl, bundlePath, kubeconfig, f.writer, f.limits)
 }
@@ -11,7 +11,7 @@ import (
 )
 
 type BundleClient interface {
-	Collect(ctx context.Context, bundlePath string, sinceTime *time.Time, kubeconfig string) (archivePath string, err error)
+	Collect(ctx context.Context, bundlePath, redactorsPath string, sinceTime *time.Time, kubeconfig string) (archivePath string, err error)
 	Analyze(ctx context.Context, bundleSpecPath string, archivePath string) ([]*executables.SupportBundleAnalysis, error)
 }
 
@@ -0,0 +1,73 @@
+package diagnostics
+
+import (
+	"fmt"
+	"regexp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	redactorKind                 = "Redactor"
+	generatedRedactorsNameFormat = "%s-%s-redactors.yaml"
+)
+
+type redactor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec redactorSpec `json:"spec,omitempty"`
+}
+
+type redactorSpec struct {
+	Redactors []*redact `json:"redactors,omitempty"`
+}
+
+type redact struct {
+	Name     string   `json:"name,omitempty"`
+	Removals removals `json:"removals"`
+}
+
+type removals struct {
+	Regex []*regexRemoval `json:"regex,omitempty"`
+}
+
+type regexRemoval struct {
+	Redactor string `json:"redactor,omitempty"`
+}
+
+// eksaRedactor masks the credentials the logger redacts and the extra redactions from the files
+// collected in the support bundle, on top of the troubleshoot default redactors.
+func eksaRedactor(redactions []string) (*redactor, error) {
+	removal := removals{}
+	for _, pattern := range logger.RedactPatterns() {
+		removal.Regex = append(removal.Regex, &regexRemoval{Redactor: pattern})
+	}
+	redactors := []*redact{{Name: "eks-anywhere credentials", Removals: removal}}
+
+	if len(redactions) > 0 {
+		extra := removals{}
+		for _, pattern := range redactions {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("invalid redaction %s: %v", pattern, err)
+			}
+			extra.Regex = append(extra.Regex, &regexRemoval{Redactor: pattern})
+		}
+		redactors = append(redactors, &redact{Name: "user redactions", Removals: extra})
+	}
+
+	return &redactor{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       redactorKind,
+			APIVersion: troubleshootApiVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "eksa-redactors",
+		},
+		Spec: redactorSpec{
+			Redactors: redactors,
+		},
+	}, nil
+}
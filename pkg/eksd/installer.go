@@ -76,11 +76,21 @@ func (i *Installer) SetRetrier(retrier *retrier.Retrier) {
 
 func (i *Installer) InstallEksdManifest(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
 	var eksdReleaseManifest []byte
+	releaseURLs := make([]string, 0, len(clusterSpec.Bundles.Spec.VersionsBundles)+1)
 	for _, vb := range clusterSpec.Bundles.Spec.VersionsBundles {
+		releaseURLs = append(releaseURLs, vb.EksD.EksDReleaseUrl)
+	}
+
+	// A pinned EKS-D release isn't part of the Bundles so it needs to be installed explicitly.
+	if _, pinned := clusterSpec.Cluster.EksdReleasePin(); pinned {
+		releaseURLs = append(releaseURLs, clusterSpec.VersionsBundle.EksD.EksDReleaseUrl)
+	}
+
+	for _, releaseURL := range releaseURLs {
 		if err := i.retrier.Retry(
 			func() error {
 				var readerErr error
-				eksdReleaseManifest, readerErr = i.reader.ReadFile(vb.EksD.EksDReleaseUrl)
+				eksdReleaseManifest, readerErr = i.reader.ReadFile(releaseURL)
 				return readerErr
 			},
 		); err != nil {
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	m "github.com/aws/eks-anywhere/internal/test/mocks"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -107,3 +108,18 @@ func bundle() *v1alpha1.Bundles {
 		},
 	}
 }
+
+func TestInstallEksdManifestWithPinnedRelease(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.clusterSpec.Bundles = bundle()
+	tt.clusterSpec.Cluster.Spec.EksdReleasePins = []anywherev1.EksdReleasePin{
+		{KubernetesVersion: tt.clusterSpec.Cluster.Spec.KubernetesVersion, Number: 2},
+	}
+	tt.clusterSpec.VersionsBundle.EksD.EksDReleaseUrl = "pinned.yaml"
+
+	tt.reader.EXPECT().ReadFile(testdataFile).Return([]byte("test data"), nil).Times(2)
+	tt.reader.EXPECT().ReadFile("pinned.yaml").Return([]byte("pinned data"), nil)
+	tt.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, []byte("test data"), constants.EksaSystemNamespace).Return(nil).Times(2)
+	tt.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, []byte("pinned data"), constants.EksaSystemNamespace).Return(nil)
+	tt.Expect(tt.eksdInstaller.InstallEksdManifest(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+}
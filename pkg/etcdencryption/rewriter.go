@@ -0,0 +1,103 @@
+package etcdencryption
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// listPageSize is the number of objects listed at once when rewriting a resource, to bound the memory
+// used for resources with many objects.
+const listPageSize = 500
+
+// Rewriter rewrites the objects of encrypted resources in the clusters managed from the management
+// cluster, using the kubeconfig CAPI stores for each of them. The kube-apiserver writes back to etcd the
+// objects read with a key other than the one of its first encryption provider, so rewriting them encrypts
+// them again with the current key.
+type Rewriter struct {
+	client     client.Reader
+	sourceName string
+}
+
+// NewRewriter constructs a new Rewriter. sourceName identifies the caller in the user agent of the requests.
+func NewRewriter(client client.Reader, sourceName string) *Rewriter {
+	return &Rewriter{
+		client:     client,
+		sourceName: sourceName,
+	}
+}
+
+// Rewrite rewrites all the objects of resource, like secrets or deployments.apps, in the CAPI cluster with
+// key cluster.
+func (r *Rewriter) Rewrite(ctx context.Context, cluster client.ObjectKey, resource string) error {
+	config, err := remote.RESTConfig(ctx, r.sourceName, r.client, cluster)
+	if err != nil {
+		return err
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return fmt.Errorf("building discovery client for cluster %s: %v", cluster.Name, err)
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+	if err != nil {
+		return fmt.Errorf("discovering resources of cluster %s: %v", cluster.Name, err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("building client for cluster %s: %v", cluster.Name, err)
+	}
+
+	return RewriteResource(ctx, dynamicClient, restmapper.NewDiscoveryRESTMapper(groupResources), resource)
+}
+
+// RewriteResource updates every object of resource without changes. Objects modified or deleted since
+// they were listed are skipped, they have already been written with the current key.
+func RewriteResource(ctx context.Context, dynamicClient dynamic.Interface, mapper meta.RESTMapper, resource string) error {
+	gvr, err := mapper.ResourceFor(parseGroupResource(resource).WithVersion(""))
+	if err != nil {
+		return fmt.Errorf("finding resource %s: %v", resource, err)
+	}
+
+	opts := metav1.ListOptions{Limit: listPageSize}
+	for {
+		list, err := dynamicClient.Resource(gvr).List(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("listing %s: %v", resource, err)
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			_, err := dynamicClient.Resource(gvr).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+			if err != nil && !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("rewriting %s %s: %v", resource, client.ObjectKeyFromObject(obj), err)
+			}
+		}
+
+		if list.GetContinue() == "" {
+			return nil
+		}
+		opts.Continue = list.GetContinue()
+	}
+}
+
+// parseGroupResource parses the resources of an EncryptionConfiguration, like secrets or deployments.apps.
+func parseGroupResource(resource string) schema.GroupResource {
+	name, group := resource, ""
+	if i := strings.Index(resource, "."); i >= 0 {
+		name, group = resource[:i], resource[i+1:]
+	}
+
+	return schema.GroupResource{Group: group, Resource: name}
+}
@@ -0,0 +1,139 @@
+package etcdencryption_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/etcdencryption"
+)
+
+func kubeconfigSecret(server string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-kubeconfig", Namespace: "eksa-system"},
+		Data: map[string][]byte{
+			"value": []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: workload
+  cluster:
+    server: %s
+contexts:
+- name: workload
+  context:
+    cluster: workload
+    user: admin
+current-context: workload
+users:
+- name: admin
+  user:
+    token: token
+`, server)),
+		},
+	}
+}
+
+func TestRewriterRewrite(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var rewritten []string
+	mux := http.NewServeMux()
+	writeJSON := func(w http.ResponseWriter, status int, body string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}
+	mux.HandleFunc("/api", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, `{"kind":"APIVersions","versions":["v1"]}`)
+	})
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, `{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`)
+	})
+	mux.HandleFunc("/api/v1", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, `{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"secrets","namespaced":true,"kind":"Secret","verbs":["get","list","update"]}]}`)
+	})
+	mux.HandleFunc("/api/v1/secrets", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.URL.Query().Get("limit")).To(Equal("500"))
+		if r.URL.Query().Get("continue") == "" {
+			writeJSON(w, http.StatusOK, `{"kind":"SecretList","apiVersion":"v1","metadata":{"continue":"page-2"},"items":[{"kind":"Secret","apiVersion":"v1","metadata":{"name":"a","namespace":"default"}}]}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"kind":"SecretList","apiVersion":"v1","metadata":{},"items":[{"kind":"Secret","apiVersion":"v1","metadata":{"name":"b","namespace":"kube-system"}}]}`)
+	})
+	mux.HandleFunc("/api/v1/namespaces/", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPut))
+		mu.Lock()
+		rewritten = append(rewritten, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/api/v1/namespaces/kube-system/secrets/b" {
+			writeJSON(w, http.StatusConflict, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Conflict","code":409}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"kind":"Secret","apiVersion":"v1","metadata":{"name":"a","namespace":"default"}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	rewriter := etcdencryption.NewRewriter(fake.NewClientBuilder().WithObjects(kubeconfigSecret(server.URL)).Build(), "test")
+	g.Expect(rewriter.Rewrite(ctx, client.ObjectKey{Name: "workload", Namespace: "eksa-system"}, "secrets")).To(Succeed())
+	g.Expect(rewritten).To(Equal([]string{
+		"/api/v1/namespaces/default/secrets/a",
+		"/api/v1/namespaces/kube-system/secrets/b",
+	}))
+}
+
+func TestRewriterRewriteMissingKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+	rewriter := etcdencryption.NewRewriter(fake.NewClientBuilder().Build(), "test")
+
+	g.Expect(rewriter.Rewrite(context.Background(), client.ObjectKey{Name: "workload", Namespace: "eksa-system"}, "secrets")).NotTo(Succeed())
+}
+
+func TestRewriteResourceGroupResource(t *testing.T) {
+	g := NewWithT(t)
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(gvr.GroupVersion().WithKind("Deployment"), meta.RESTScopeNamespace)
+	deployment := &unstructured.Unstructured{}
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	deployment.SetName("controller")
+	deployment.SetNamespace("default")
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "DeploymentList"}, deployment)
+
+	g.Expect(etcdencryption.RewriteResource(context.Background(), dynamicClient, mapper, "deployments.apps")).To(Succeed())
+
+	var updated []string
+	for _, action := range dynamicClient.Actions() {
+		if action.GetVerb() == "update" {
+			updated = append(updated, action.GetNamespace()+"/"+action.GetResource().Resource)
+		}
+	}
+	g.Expect(updated).To(Equal([]string{"default/deployments"}))
+}
+
+func TestRewriteResourceUnknownResource(t *testing.T) {
+	g := NewWithT(t)
+	mapper := meta.NewDefaultRESTMapper(nil)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	g.Expect(etcdencryption.RewriteResource(context.Background(), dynamicClient, mapper, "widgets.example.com")).To(
+		MatchError(ContainSubstring("finding resource widgets.example.com")))
+}
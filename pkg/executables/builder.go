@@ -68,6 +68,11 @@ func (b *ExecutablesBuilder) BuildHelmExecutable(opts ...HelmOpt) *Helm {
 	return NewHelm(b.executableBuilder.Build(helmPath), opts...)
 }
 
+// BuildSonobuoyExecutable returns a Sonobuoy that runs the sonobuoy binary of the tools image.
+func (b *ExecutablesBuilder) BuildSonobuoyExecutable() *Sonobuoy {
+	return NewSonobuoy(b.executableBuilder.Build(sonobuoyToolsPath))
+}
+
 // Init initializes the executable builder and returns a Closer
 // that needs to be called once the executables are not in used anymore
 // The closer will cleanup and free all internal resources.
@@ -81,6 +86,20 @@ func BuildSonobuoyExecutable() *Sonobuoy {
 	})
 }
 
+// BuildVirshExecutable returns a Virsh running on the host, where the libvirt daemon lives.
+func BuildVirshExecutable(libvirtURI string) *Virsh {
+	return NewVirsh(&executable{
+		cli: virshPath,
+	}, libvirtURI)
+}
+
+// BuildVbmcExecutable returns a Vbmc running on the host, next to the libvirt daemon.
+func BuildVbmcExecutable(libvirtURI string) *Vbmc {
+	return NewVbmc(&executable{
+		cli: vbmcPath,
+	}, libvirtURI)
+}
+
 func BuildDockerExecutable() *Docker {
 	return NewDocker(&executable{
 		cli: dockerPath,
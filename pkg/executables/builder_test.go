@@ -40,6 +40,8 @@ func TestLocalExecutablesBuilderAllExecutables(t *testing.T) {
 	g.Expect(trouble).NotTo(BeNil())
 	helm := b.BuildHelmExecutable()
 	g.Expect(helm).NotTo(BeNil())
+	sonobuoy := b.BuildSonobuoyExecutable()
+	g.Expect(sonobuoy).NotTo(BeNil())
 
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(closer(ctx)).To(Succeed())
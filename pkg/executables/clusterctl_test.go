@@ -40,6 +40,8 @@ type clusterctlTest struct {
 
 func newClusterctlTest(t *testing.T) *clusterctlTest {
 	ctrl := gomock.NewController(t)
+	// clusterctl writes the overrides layer in the cluster folder, relative to the working directory.
+	test.InTempDir(t)
 	_, writer := test.NewWriter(t)
 	e := mockexecutables.NewMockExecutable(ctrl)
 
@@ -8,13 +8,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
 	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
@@ -35,6 +38,20 @@ type Cmk struct {
 	writer     filewriter.FileWriter
 	executable Executable
 	configMap  map[string]decoder.CloudStackProfileConfig
+
+	configFiles *cmkConfigFiles
+}
+
+// cmkConfigFiles holds the config files written for each profile. Commands can run concurrently, so
+// a config file is only rewritten when its content changes, never while another command reads it.
+type cmkConfigFiles struct {
+	sync.Mutex
+	files map[string]cmkConfigFile
+}
+
+type cmkConfigFile struct {
+	path    string
+	content string
 }
 
 func (c *Cmk) Close(ctx context.Context) error {
@@ -345,9 +362,10 @@ func NewCmk(executable Executable, writer filewriter.FileWriter, configs []decod
 	}
 
 	return &Cmk{
-		writer:     writer,
-		executable: executable,
-		configMap:  configMap,
+		writer:      writer,
+		executable:  executable,
+		configMap:   configMap,
+		configFiles: &cmkConfigFiles{files: map[string]cmkConfigFile{}},
 	}
 }
 
@@ -409,6 +427,78 @@ func (c *Cmk) CleanupVms(ctx context.Context, profile string, clusterName string
 	return nil
 }
 
+// GetVirtualMachineTags returns the tags of the virtual machines with ids visible with profile, keyed by
+// virtual machine id. The virtual machines that don't exist or aren't visible with profile are omitted.
+func (c *Cmk) GetVirtualMachineTags(ctx context.Context, profile string, ids []string) (map[string]map[string]string, error) {
+	command := newCmkCommand("list virtualmachines")
+	applyCmkArgs(&command, appendArgs(fmt.Sprintf("ids=\"%s\"", strings.Join(ids, ",")), "listall=true"))
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return nil, fmt.Errorf("listing virtual machines %v: %s: %v", ids, result.String(), err)
+	}
+
+	vmTags := map[string]map[string]string{}
+	if result.Len() == 0 {
+		return vmTags, nil
+	}
+	response := struct {
+		CmkVirtualMachines []cmkVirtualMachine `json:"virtualmachine"`
+	}{}
+	if err = json.Unmarshal(result.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("parsing response into json: %v", err)
+	}
+	for _, vm := range response.CmkVirtualMachines {
+		tags := make(map[string]string, len(vm.Tags))
+		for _, tag := range vm.Tags {
+			tags[tag.Key] = tag.Value
+		}
+		vmTags[vm.Id] = tags
+	}
+
+	return vmTags, nil
+}
+
+// CreateVirtualMachineTags adds tags to the virtual machine with id. CloudStack doesn't overwrite
+// existing tags, so the tags with new values must be deleted first.
+func (c *Cmk) CreateVirtualMachineTags(ctx context.Context, profile, id string, tags map[string]string) error {
+	command := newCmkCommand("create tags")
+	applyCmkArgs(&command, appendArgs("resourcetype=UserVm", fmt.Sprintf("resourceids=\"%s\"", id)))
+	for i, key := range sortedKeys(tags) {
+		applyCmkArgs(&command, appendArgs(
+			fmt.Sprintf("tags[%d].key=\"%s\"", i, key),
+			fmt.Sprintf("tags[%d].value=\"%s\"", i, tags[key]),
+		))
+	}
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return fmt.Errorf("creating tags for virtual machine %s: %s: %v", id, result.String(), err)
+	}
+	return nil
+}
+
+// DeleteVirtualMachineTags removes the tags with keys from the virtual machine with id.
+func (c *Cmk) DeleteVirtualMachineTags(ctx context.Context, profile, id string, keys []string) error {
+	command := newCmkCommand("delete tags")
+	applyCmkArgs(&command, appendArgs("resourcetype=UserVm", fmt.Sprintf("resourceids=\"%s\"", id)))
+	for i, key := range keys {
+		applyCmkArgs(&command, appendArgs(fmt.Sprintf("tags[%d].key=\"%s\"", i, key)))
+	}
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return fmt.Errorf("deleting tags of virtual machine %s: %s: %v", id, result.String(), err)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (c *Cmk) exec(ctx context.Context, profile string, args ...string) (stdout bytes.Buffer, err error) {
 	if err != nil {
 		return bytes.Buffer{}, fmt.Errorf("failed get environment map: %v", err)
@@ -420,7 +510,12 @@ func (c *Cmk) exec(ctx context.Context, profile string, args ...string) (stdout
 	}
 
 	argsWithConfigFile := append([]string{"-c", configFile}, args...)
-	return c.executable.Execute(ctx, argsWithConfigFile...)
+	// All the profiles of a CloudStack management server share its rate limiter.
+	err = ratelimit.For(c.configMap[profile].ManagementUrl).Do(ctx, func() error {
+		stdout, err = c.executable.Execute(ctx, argsWithConfigFile...)
+		return err
+	})
+	return stdout, err
 }
 
 func (c *Cmk) buildCmkConfigFile(profile string) (configFile string, err error) {
@@ -429,8 +524,6 @@ func (c *Cmk) buildCmkConfigFile(profile string) (configFile string, err error)
 		return "", fmt.Errorf("profile %s does not exist", profile)
 	}
 
-	t := templater.New(c.writer)
-
 	config.Timeout = defaultCloudStackPreflightTimeout
 	if timeout, isSet := os.LookupEnv("CLOUDSTACK_PREFLIGHT_TIMEOUT"); isSet {
 		if _, err := strconv.ParseUint(timeout, 10, 16); err != nil {
@@ -438,15 +531,28 @@ func (c *Cmk) buildCmkConfigFile(profile string) (configFile string, err error)
 		}
 		config.Timeout = timeout
 	}
-	writtenFileName, err := t.WriteToFile(cmkConfigTemplate, config, fmt.Sprintf(cmkConfigFileNameTemplate, profile))
+	content, err := templater.Execute(cmkConfigTemplate, config)
 	if err != nil {
 		return "", fmt.Errorf("creating file for cmk config: %v", err)
 	}
+
+	c.configFiles.Lock()
+	defer c.configFiles.Unlock()
+	if f, ok := c.configFiles.files[profile]; ok && f.content == string(content) {
+		return f.path, nil
+	}
+
+	writtenFileName, err := c.writer.Write(fmt.Sprintf(cmkConfigFileNameTemplate, profile), content)
+	if err != nil {
+		return "", fmt.Errorf("creating file for cmk config: writing template file: %v", err)
+	}
 	configFile, err = filepath.Abs(writtenFileName)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate absolute filepath for generated config file at %s", writtenFileName)
 	}
 
+	c.configFiles.files[profile] = cmkConfigFile{path: configFile, content: string(content)}
+
 	return configFile, nil
 }
 
@@ -469,6 +575,17 @@ type cmkResourceIdentifier struct {
 	Name string `json:"name"`
 }
 
+type cmkVirtualMachine struct {
+	Id   string   `json:"id"`
+	Name string   `json:"name"`
+	Tags []cmkTag `json:"tags"`
+}
+
+type cmkTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 type cmkDiskOffering struct {
 	Id         string `json:"id"`
 	Name       string `json:"name"`
@@ -274,6 +274,70 @@ func TestCmkCleanupVms(t *testing.T) {
 	}
 }
 
+func TestCmkGetVirtualMachineTags(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	ids := []string{"30e8b0b1-f286-4372-9f1f-441e199a3f49", "6a4e5b1c-1f0e-4c4e-a0c6-0a1f5e4f8a11"}
+
+	executable.EXPECT().Execute(ctx, []string{
+		"-c", configFilePath,
+		"list", "virtualmachines", fmt.Sprintf("ids=\"%s,%s\"", ids[0], ids[1]), "listall=true",
+	}).Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_virtualmachine_tags.json")), nil)
+
+	cmk := executables.NewCmk(executable, writer, execConfig.Profiles)
+	g.Expect(cmk.GetVirtualMachineTags(ctx, execConfig.Profiles[0].Name, ids)).To(Equal(map[string]map[string]string{
+		ids[0]: {"environment": "prod"},
+		ids[1]: {},
+	}))
+}
+
+func TestCmkGetVirtualMachineTagsNotFound(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	executable.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, nil)
+
+	cmk := executables.NewCmk(executable, writer, execConfig.Profiles)
+	g.Expect(cmk.GetVirtualMachineTags(ctx, execConfig.Profiles[0].Name, []string{"vm-1"})).To(BeEmpty())
+}
+
+func TestCmkCreateVirtualMachineTags(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	executable.EXPECT().Execute(ctx, []string{
+		"-c", configFilePath,
+		"create", "tags", "resourcetype=UserVm", "resourceids=\"vm-1\"",
+		"tags[0].key=\"cost-center\"", "tags[0].value=\"platform\"",
+		"tags[1].key=\"environment\"", "tags[1].value=\"prod\"",
+	}).Return(bytes.Buffer{}, errors.New("tag already exists"))
+
+	cmk := executables.NewCmk(executable, writer, execConfig.Profiles)
+	err := cmk.CreateVirtualMachineTags(ctx, execConfig.Profiles[0].Name, "vm-1", map[string]string{"environment": "prod", "cost-center": "platform"})
+	g.Expect(err).To(MatchError(ContainSubstring("creating tags for virtual machine vm-1")))
+}
+
+func TestCmkDeleteVirtualMachineTags(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	executable.EXPECT().Execute(ctx, []string{
+		"-c", configFilePath,
+		"delete", "tags", "resourcetype=UserVm", "resourceids=\"vm-1\"", "tags[0].key=\"environment\"",
+	}).Return(bytes.Buffer{}, nil)
+
+	cmk := executables.NewCmk(executable, writer, execConfig.Profiles)
+	g.Expect(cmk.DeleteVirtualMachineTags(ctx, execConfig.Profiles[0].Name, "vm-1", []string{"environment"})).To(Succeed())
+}
+
 func TestCmkListOperations(t *testing.T) {
 	_, writer := test.NewWriter(t)
 	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
@@ -3,6 +3,7 @@ package executables
 import (
 	"bytes"
 	"context"
+	"time"
 )
 
 type commandRunner interface {
@@ -15,6 +16,7 @@ type Command struct {
 	args          []string
 	stdIn         []byte
 	envVars       map[string]string
+	timeout       time.Duration
 }
 
 func NewCommand(ctx context.Context, commandRunner commandRunner, args ...string) *Command {
@@ -35,6 +37,21 @@ func (c *Command) WithStdIn(stdIn []byte) *Command {
 	return c
 }
 
+// WithTimeout sets the maximum time the command can run. Once reached, the command and the processes
+// it started are stopped, the same as when its context is canceled.
+func (c *Command) WithTimeout(timeout time.Duration) *Command {
+	c.timeout = timeout
+	return c
+}
+
 func (c *Command) Run() (out bytes.Buffer, err error) {
-	return c.commandRunner.Run(c)
+	if c.timeout <= 0 {
+		return c.commandRunner.Run(c)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+	cmd := *c
+	cmd.ctx = ctx
+	return c.commandRunner.Run(&cmd)
 }
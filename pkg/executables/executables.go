@@ -83,6 +83,16 @@ func (e *executable) Close(ctx context.Context) error {
 	return nil
 }
 
+// RegisterEnvSecrets registers the credentials set in the environment variables passed to the
+// executables as logger secrets, so they are redacted from all logs and errors.
+func RegisterEnvSecrets() {
+	for _, k := range redactedEnvKeys {
+		if v, ok := os.LookupEnv(k); ok {
+			logger.RegisterSecrets(v)
+		}
+	}
+}
+
 func RedactCreds(cmd string, envMap map[string]string) string {
 	redactedEnvs := []string{}
 	for _, redactedEnvKey := range redactedEnvKeys {
@@ -99,7 +109,7 @@ func RedactCreds(cmd string, envMap map[string]string) string {
 
 func execute(ctx context.Context, cli string, in []byte, envVars map[string]string, args ...string) (stdout bytes.Buffer, err error) {
 	var stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, cli, args...)
+	cmd := exec.Command(cli, args...)
 	logger.V(6).Info("Executing command", "cmd", RedactCreds(cmd.String(), envVars))
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -107,8 +117,12 @@ func execute(ctx context.Context, cli string, in []byte, envVars map[string]stri
 		cmd.Stdin = bytes.NewReader(in)
 	}
 
-	err = cmd.Run()
+	err = runInProcessGroup(ctx, cmd)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			logger.V(6).Info("Command interrupted", "cli", cli, "reason", ctxErr, "stderr", stderr.String())
+			return stdout, fmt.Errorf("%s was interrupted: %w", cli, ctxErr)
+		}
 		if stderr.Len() > 0 {
 			if logger.MaxLogging() {
 				logger.V(logger.MaxLoggingLevel()).Info(cli, "stderr", stderr.String())
@@ -1,7 +1,12 @@
 package executables_test
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
@@ -17,3 +22,27 @@ func TestRedactCreds(t *testing.T) {
 		t.Fatalf("executables.RedactCreds expected = %s, got = %s", expected, redactedStr)
 	}
 }
+
+func TestExecutableTimeoutStopsChildProcesses(t *testing.T) {
+	g := NewWithT(t)
+	sh := executables.NewExecutable("sh")
+
+	// The background sleep holds stdout open, so the command only returns
+	// before it finishes if the whole process group is stopped.
+	start := time.Now()
+	_, err := sh.Command(context.Background(), "-c", "sleep 30 & wait").WithTimeout(100 * time.Millisecond).Run()
+
+	g.Expect(err).To(MatchError(ContainSubstring("sh was interrupted")))
+	g.Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+	g.Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+}
+
+func TestExecutableCanceledContext(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := executables.NewExecutable("sh").Execute(ctx, "-c", "true")
+
+	g.Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+}
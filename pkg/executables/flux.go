@@ -48,6 +48,12 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, flux
 		params = append(params, "--personal")
 	}
 
+	// Flux clones over https with the token in its secret, which the controller replaces with
+	// short lived installation tokens of the GitHub App.
+	if c.Github.App != nil {
+		params = append(params, "--token-auth")
+	}
+
 	token, err := github.GetGithubAccessTokenFromEnv()
 	if err != nil {
 		return fmt.Errorf("setting token env: %v", err)
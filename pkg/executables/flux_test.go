@@ -116,6 +116,27 @@ func TestFluxInstallGithubToolkitsSuccess(t *testing.T) {
 				"bootstrap", githubProvider, "--repository", repo, "--owner", owner, "--path", path, "--ssh-key-algorithm", "ecdsa", "--namespace", "flux-system",
 			},
 		},
+		{
+			testName: "with github app",
+			cluster:  &types.Cluster{},
+			fluxConfig: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					ClusterConfigPath: path,
+					Github: &v1alpha1.GithubProviderConfig{
+						Owner:      owner,
+						Repository: repo,
+						App: &v1alpha1.GithubAppConfig{
+							AppID:               1234,
+							InstallationID:      5678,
+							PrivateKeySecretRef: "github-app",
+						},
+					},
+				},
+			},
+			wantExecArgs: []interface{}{
+				"bootstrap", githubProvider, "--repository", repo, "--owner", owner, "--path", path, "--ssh-key-algorithm", "ecdsa", "--token-auth",
+			},
+		},
 		{
 			testName: "minimum args",
 			cluster:  &types.Cluster{},
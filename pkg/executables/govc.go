@@ -21,6 +21,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -77,7 +78,7 @@ func NewGovc(executable Executable, writer filewriter.FileWriter, opts ...GovcOp
 	g := &Govc{
 		writer:       writer,
 		Executable:   executable,
-		Retrier:      retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+		Retrier:      retrier.NewWithMaxRetries(maxRetries, backOffPeriod, retrier.WithAbortOn(retrier.IsError(ratelimit.ErrCircuitOpen))),
 		requiredEnvs: envVars,
 	}
 
@@ -103,6 +104,15 @@ func (g *Govc) exec(ctx context.Context, args ...string) (stdout bytes.Buffer, e
 	return g.ExecuteWithEnv(ctx, envMap, args...)
 }
 
+// ExecuteWithEnv runs govc through the rate limiter shared by all the clients of the vCenter server in envs.
+func (g *Govc) ExecuteWithEnv(ctx context.Context, envs map[string]string, args ...string) (stdout bytes.Buffer, err error) {
+	err = ratelimit.For(envs[govcURLKey]).Do(ctx, func() error {
+		stdout, err = g.Executable.ExecuteWithEnv(ctx, envs, args...)
+		return err
+	})
+	return stdout, err
+}
+
 func (g *Govc) Close(ctx context.Context) error {
 	if g == nil {
 		return nil
@@ -131,7 +141,7 @@ func (g *Govc) SearchTemplate(ctx context.Context, datacenter string, machineCon
 
 	var templateResponse bytes.Buffer
 	var err error
-	err = g.Retry(func() error {
+	err = g.RetryWithContext(ctx, func(ctx context.Context, _ retrier.Attempt) error {
 		templateResponse, err = g.exec(ctx, params...)
 		return err
 	})
@@ -366,6 +376,15 @@ func (g *Govc) ImportTemplate(ctx context.Context, library, ovaURL, name string)
 	return nil
 }
 
+// ImportLibraryItem imports the local file in path into library as an item called name.
+func (g *Govc) ImportLibraryItem(ctx context.Context, library, path, name string) error {
+	logger.V(4).Info("Importing library item", "file", path, "name", name)
+	if _, err := g.exec(ctx, "library.import", "-k", "-n", name, library, path); err != nil {
+		return fmt.Errorf("importing library item: %v", err)
+	}
+	return nil
+}
+
 func (g *Govc) DeployTemplate(ctx context.Context, library, templateName, vmName, deployFolder, datacenter, datastore, network, resourcePool string, deployOptionsOverride []byte) error {
 	envMap, err := g.validateAndSetupCreds()
 	if err != nil {
@@ -393,7 +412,7 @@ func (g *Govc) DeployTemplate(ctx context.Context, library, templateName, vmName
 
 	bFolderNotFound := false
 	params := []string{"folder.info", deployFolder}
-	err = g.Retry(func() error {
+	err = g.RetryWithContext(ctx, func(ctx context.Context, _ retrier.Attempt) error {
 		errBuffer, err := g.ExecuteWithEnv(ctx, envMap, params...)
 		errString := strings.ToLower(errBuffer.String())
 		if err != nil {
@@ -407,7 +426,7 @@ func (g *Govc) DeployTemplate(ctx context.Context, library, templateName, vmName
 	})
 	if err != nil || bFolderNotFound {
 		params = []string{"folder.create", deployFolder}
-		err = g.Retry(func() error {
+		err = g.RetryWithContext(ctx, func(ctx context.Context, _ retrier.Attempt) error {
 			errBuffer, err := g.ExecuteWithEnv(ctx, envMap, params...)
 			errString := strings.ToLower(errBuffer.String())
 			if err != nil && !strings.Contains(errString, "already exists") {
@@ -591,6 +610,76 @@ func (g *Govc) CleanupVms(ctx context.Context, clusterName string, dryRun bool)
 	return nil
 }
 
+// CleanupClusterVMs powers off and destroys the VMs under folder the cluster tag with id clusterTagID is
+// attached to, returning the paths of the deleted VMs.
+func (g *Govc) CleanupClusterVMs(ctx context.Context, folder, clusterTagID string) ([]string, error) {
+	envMap, err := g.validateAndSetupCreds()
+	if err != nil {
+		return nil, fmt.Errorf("failed govc validations: %v", err)
+	}
+
+	vms, err := g.findClusterVMs(ctx, envMap, folder, clusterTagID)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, vm := range vms {
+		if _, err := g.ExecuteWithEnv(ctx, envMap, "vm.power", "-off", "-force", vm); err != nil {
+			logger.V(3).Info("Failed powering off vm, it may already be off", "vm", vm, "error", err)
+		}
+		if _, err := g.ExecuteWithEnv(ctx, envMap, "object.destroy", vm); err != nil {
+			return deleted, fmt.Errorf("deleting vm %s: %v", vm, err)
+		}
+		deleted = append(deleted, vm)
+	}
+
+	return deleted, nil
+}
+
+// managedObjectReference is a vSphere managed object reference as printed by govc, Type:Value in text output.
+type managedObjectReference struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
+
+// findClusterVMs returns the paths of the VMs under folder the tag with id clusterTagID is attached to.
+func (g *Govc) findClusterVMs(ctx context.Context, envMap map[string]string, folder, clusterTagID string) ([]string, error) {
+	response, err := g.ExecuteWithEnv(ctx, envMap, "tags.attached.ls", "-json", clusterTagID)
+	if err != nil {
+		return nil, fmt.Errorf("getting objects attached to tag %s: %v", clusterTagID, err)
+	}
+	var attached []managedObjectReference
+	if err = json.Unmarshal(response.Bytes(), &attached); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling govc response from list attached objects: %v", err)
+	}
+	attachedLookup := make(map[string]struct{}, len(attached))
+	for _, ref := range attached {
+		attachedLookup[ref.Type+":"+ref.Value] = struct{}{}
+	}
+
+	response, err = g.ExecuteWithEnv(ctx, envMap, "find", "-i", folder, "-type", "m")
+	if err != nil {
+		return nil, fmt.Errorf("getting vms in folder %s: %v", folder, err)
+	}
+
+	var vms []string
+	scanner := bufio.NewScanner(strings.NewReader(response.String()))
+	for scanner.Scan() {
+		ref := strings.TrimSpace(scanner.Text())
+		if _, ok := attachedLookup[ref]; !ok {
+			continue
+		}
+		path, err := g.ExecuteWithEnv(ctx, envMap, "ls", "-L", ref)
+		if err != nil {
+			return nil, fmt.Errorf("getting path of vm %s: %v", ref, err)
+		}
+		vms = append(vms, strings.TrimSpace(path.String()))
+	}
+
+	return vms, nil
+}
+
 func (g *Govc) ValidateVCenterConnection(ctx context.Context, server string) error {
 	skipVerifyTransport := http.DefaultTransport.(*http.Transport).Clone()
 	skipVerifyTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
@@ -604,7 +693,7 @@ func (g *Govc) ValidateVCenterConnection(ctx context.Context, server string) err
 }
 
 func (g *Govc) ValidateVCenterAuthentication(ctx context.Context) error {
-	err := g.Retry(func() error {
+	err := g.RetryWithContext(ctx, func(ctx context.Context, _ retrier.Attempt) error {
 		_, err := g.exec(ctx, "about", "-k")
 		return err
 	})
@@ -651,7 +740,7 @@ func (g *Govc) ConfigureCertThumbprint(ctx context.Context, server, thumbprint s
 
 func (g *Govc) DatacenterExists(ctx context.Context, datacenter string) (bool, error) {
 	exists := false
-	err := g.Retry(func() error {
+	err := g.RetryWithContext(ctx, func(ctx context.Context, _ retrier.Attempt) error {
 		result, err := g.exec(ctx, "datacenter.info", datacenter)
 		if err == nil {
 			exists = true
@@ -675,7 +764,7 @@ func (g *Govc) DatacenterExists(ctx context.Context, datacenter string) (bool, e
 func (g *Govc) NetworkExists(ctx context.Context, network string) (bool, error) {
 	exists := false
 
-	err := g.Retry(func() error {
+	err := g.RetryWithContext(ctx, func(ctx context.Context, _ retrier.Attempt) error {
 		networkResponse, err := g.exec(ctx, "find", "-maxdepth=1", filepath.Dir(network), "-type", "n", "-name", filepath.Base(network))
 		if err != nil {
 			return err
@@ -706,7 +795,7 @@ func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenter
 		return err
 	}
 	params := []string{"datastore.info", machineConfig.Spec.Datastore}
-	err = g.Retry(func() error {
+	err = g.RetryWithContext(ctx, func(ctx context.Context, _ retrier.Attempt) error {
 		_, err = g.ExecuteWithEnv(ctx, envMap, params...)
 		if err != nil {
 			datastorePath := filepath.Dir(machineConfig.Spec.Datastore)
@@ -731,7 +820,7 @@ func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenter
 			return err
 		}
 		params = []string{"folder.info", machineConfig.Spec.Folder}
-		err = g.Retry(func() error {
+		err = g.RetryWithContext(ctx, func(ctx context.Context, _ retrier.Attempt) error {
 			_, err := g.ExecuteWithEnv(ctx, envMap, params...)
 			if err != nil {
 				err = g.createFolder(ctx, envMap, machineConfig)
@@ -757,7 +846,7 @@ func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenter
 
 	var poolInfoResponse bytes.Buffer
 	params = []string{"find", "-json", "/" + datacenterConfig.Spec.Datacenter, "-type", "p", "-name", filepath.Base(machineConfig.Spec.ResourcePool)}
-	err = g.Retry(func() error {
+	err = g.RetryWithContext(ctx, func(ctx context.Context, _ retrier.Attempt) error {
 		poolInfoResponse, err = g.ExecuteWithEnv(ctx, envMap, params...)
 		return err
 	})
@@ -814,7 +903,7 @@ func prependPath(folderType FolderType, folderPath string, datacenter string) (s
 
 func (g *Govc) createFolder(ctx context.Context, envMap map[string]string, machineConfig *v1alpha1.VSphereMachineConfig) error {
 	params := []string{"folder.create", machineConfig.Spec.Folder}
-	err := g.Retry(func() error {
+	err := g.RetryWithContext(ctx, func(ctx context.Context, _ retrier.Attempt) error {
 		_, err := g.ExecuteWithEnv(ctx, envMap, params...)
 		if err != nil {
 			return fmt.Errorf("creating folder: %v", err)
@@ -833,7 +922,7 @@ func (g *Govc) isValidPath(ctx context.Context, envMap map[string]string, path s
 func (g *Govc) GetTags(ctx context.Context, path string) ([]string, error) {
 	var tagsResponse bytes.Buffer
 	var err error
-	err = g.Retry(func() error {
+	err = g.RetryWithContext(ctx, func(ctx context.Context, _ retrier.Attempt) error {
 		tagsResponse, err = g.exec(ctx, "tags.attached.ls", "-json", "-r", path)
 		return err
 	})
@@ -891,6 +980,31 @@ func (g *Govc) AddTag(ctx context.Context, path, tag string) error {
 	return nil
 }
 
+// ListCategoryTagIDs returns the IDs of the tags of a category, by tag name.
+func (g *Govc) ListCategoryTagIDs(ctx context.Context, category string) (map[string]string, error) {
+	tagsResponse, err := g.exec(ctx, "tags.ls", "-c", category, "-json")
+	if err != nil {
+		return nil, fmt.Errorf("govc returned error when listing tags of category %s: %v", category, err)
+	}
+
+	tagsJson := tagsResponse.String()
+	if tagsJson == "null" {
+		return nil, nil
+	}
+
+	tags := make([]tag, 0)
+	if err = json.Unmarshal([]byte(tagsJson), &tags); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling govc response from list tags of category %s: %v", category, err)
+	}
+
+	tagIDs := make(map[string]string, len(tags))
+	for _, t := range tags {
+		tagIDs[t.Name] = t.Id
+	}
+
+	return tagIDs, nil
+}
+
 func (g *Govc) CreateTag(ctx context.Context, tag, category string) error {
 	if _, err := g.exec(ctx, "tags.create", "-c", category, tag); err != nil {
 		return fmt.Errorf("govc returned error when creating tag %s: %v", tag, err)
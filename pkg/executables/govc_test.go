@@ -22,6 +22,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 )
 
@@ -424,6 +425,20 @@ func TestDeployTemplateFromLibraryErrorMarkAsTemplate(t *testing.T) {
 	tt.assertDeployTemplateError(t)
 }
 
+func TestSearchTemplateCircuitOpenNoRetries(t *testing.T) {
+	machineConfig := newMachineConfig(t)
+	ctx := context.Background()
+	datacenter := "SDDC-Datacenter"
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, gomock.Any()).Return(bytes.Buffer{}, fmt.Errorf("%w: too many failures", ratelimit.ErrCircuitOpen)).Times(1)
+
+	_, err := g.SearchTemplate(ctx, datacenter, machineConfig)
+	if err == nil || !strings.Contains(err.Error(), ratelimit.ErrCircuitOpen.Error()) {
+		t.Fatalf("Govc.SearchTemplate() err = %v, want %v", err, ratelimit.ErrCircuitOpen)
+	}
+}
+
 func TestGovcValidateVCenterSetupMachineConfig(t *testing.T) {
 	ctx := context.Background()
 	ts := newHTTPSServer(t)
@@ -515,6 +530,58 @@ func TestGovcCleanupVms(t *testing.T) {
 	}
 }
 
+func TestGovcCleanupClusterVMs(t *testing.T) {
+	ctx := context.Background()
+	folder := "/SDDC-Datacenter/vm/eksa"
+	env := govcEnvironment
+	mockCtrl := gomock.NewController(t)
+	_, writer := test.NewWriter(t)
+	setupContext(t)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+
+	tagID := "urn:vmomi:InventoryServiceTag:1:GLOBAL"
+	attached := `[{"Type":"VirtualMachine","Value":"vm-1"},{"Type":"VirtualMachine","Value":"vm-2"},{"Type":"VirtualMachine","Value":"vm-9"}]`
+	vms := "VirtualMachine:vm-1\nVirtualMachine:vm-2\nVirtualMachine:vm-3\n"
+	gomock.InOrder(
+		executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.attached.ls", "-json", tagID).Return(*bytes.NewBufferString(attached), nil),
+		executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-i", folder, "-type", "m").Return(*bytes.NewBufferString(vms), nil),
+		executable.EXPECT().ExecuteWithEnv(ctx, env, "ls", "-L", "VirtualMachine:vm-1").Return(*bytes.NewBufferString(folder + "/cluster-cp-1\n"), nil),
+		executable.EXPECT().ExecuteWithEnv(ctx, env, "ls", "-L", "VirtualMachine:vm-2").Return(*bytes.NewBufferString(folder + "/cluster-md-0-1\n"), nil),
+		executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.power", "-off", "-force", folder+"/cluster-cp-1").Return(bytes.Buffer{}, errors.New("already off")),
+		executable.EXPECT().ExecuteWithEnv(ctx, env, "object.destroy", folder+"/cluster-cp-1").Return(bytes.Buffer{}, nil),
+		executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.power", "-off", "-force", folder+"/cluster-md-0-1").Return(bytes.Buffer{}, nil),
+		executable.EXPECT().ExecuteWithEnv(ctx, env, "object.destroy", folder+"/cluster-md-0-1").Return(bytes.Buffer{}, errors.New("permission denied")),
+	)
+
+	g := executables.NewGovc(executable, writer)
+	tt := NewWithT(t)
+	deleted, err := g.CleanupClusterVMs(ctx, folder, tagID)
+	tt.Expect(err).To(MatchError(ContainSubstring("deleting vm " + folder + "/cluster-md-0-1: permission denied")))
+	tt.Expect(deleted).To(ConsistOf(folder + "/cluster-cp-1"))
+}
+
+func TestGovcCleanupClusterVMsNoneAttached(t *testing.T) {
+	ctx := context.Background()
+	folder := "/SDDC-Datacenter/vm/eksa"
+	env := govcEnvironment
+	mockCtrl := gomock.NewController(t)
+	_, writer := test.NewWriter(t)
+	setupContext(t)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+
+	tagID := "urn:vmomi:InventoryServiceTag:1:GLOBAL"
+	gomock.InOrder(
+		executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.attached.ls", "-json", tagID).Return(*bytes.NewBufferString("null"), nil),
+		executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-i", folder, "-type", "m").Return(*bytes.NewBufferString("VirtualMachine:vm-1\n"), nil),
+	)
+
+	g := executables.NewGovc(executable, writer)
+	tt := NewWithT(t)
+	deleted, err := g.CleanupClusterVMs(ctx, folder, tagID)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(deleted).To(BeEmpty())
+}
+
 func TestCreateLibrarySuccess(t *testing.T) {
 	datastore := "/SDDC-Datacenter/datastore/WorkloadDatastore"
 	ctx := context.Background()
@@ -756,6 +823,36 @@ func TestCreateTagError(t *testing.T) {
 	}
 }
 
+func TestListCategoryTagIDsSuccess(t *testing.T) {
+	ctx := context.Background()
+	tagsReponse := `[
+		{
+			"id": "urn:vmomi:InventoryServiceTag:5555:GLOBAL",
+			"name": "platform",
+			"category_id": "urn:vmomi:InventoryServiceCategory:1111:GLOBAL"
+		}
+	]`
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.ls", "-c", "cost-center", "-json").Return(*bytes.NewBufferString(tagsReponse), nil)
+
+	tt := NewWithT(t)
+	tags, err := g.ListCategoryTagIDs(ctx, "cost-center")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tags).To(Equal(map[string]string{"platform": "urn:vmomi:InventoryServiceTag:5555:GLOBAL"}))
+}
+
+func TestListCategoryTagIDsError(t *testing.T) {
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.ls", "-c", "cost-center", "-json").Return(bytes.Buffer{}, errors.New("category not found"))
+
+	tt := NewWithT(t)
+	_, err := g.ListCategoryTagIDs(ctx, "cost-center")
+	tt.Expect(err).To(MatchError(ContainSubstring("listing tags of category cost-center: category not found")))
+}
+
 func TestListCategoriesSuccessNoCategories(t *testing.T) {
 	ctx := context.Background()
 
@@ -885,6 +982,32 @@ func TestImportTemplateError(t *testing.T) {
 	}
 }
 
+func TestImportLibraryItemSuccess(t *testing.T) {
+	path := "template.ova"
+	name := "name"
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "library.import", "-k", "-n", name, templateLibrary, path).Return(*bytes.NewBufferString(""), nil)
+
+	if err := g.ImportLibraryItem(ctx, templateLibrary, path, name); err != nil {
+		t.Fatalf("Govc.ImportLibraryItem() err = %v, want err nil", err)
+	}
+}
+
+func TestImportLibraryItemError(t *testing.T) {
+	path := "template.ova"
+	name := "name"
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "library.import", "-k", "-n", name, templateLibrary, path).Return(bytes.Buffer{}, errors.New("error from execute with env"))
+
+	if err := g.ImportLibraryItem(ctx, templateLibrary, path, name); err == nil {
+		t.Fatal("Govc.ImportLibraryItem() err = nil, want err not nil")
+	}
+}
+
 func TestDeleteTemplateSuccess(t *testing.T) {
 	template := "template"
 	resourcePool := "resourcePool"
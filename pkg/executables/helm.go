@@ -63,12 +63,22 @@ func NewHelm(executable Executable, opts ...HelmOpt) *Helm {
 }
 
 func (h *Helm) Template(ctx context.Context, ociURI, version, namespace string, values interface{}, kubeVersion string) ([]byte, error) {
+	return h.template(ctx, ociURI, version, namespace, values, kubeVersion)
+}
+
+// TemplateWithCRDs renders a chart like Template, including the CRDs in the crds directory of the chart.
+func (h *Helm) TemplateWithCRDs(ctx context.Context, ociURI, version, namespace string, values interface{}, kubeVersion string) ([]byte, error) {
+	return h.template(ctx, ociURI, version, namespace, values, kubeVersion, "--include-crds")
+}
+
+func (h *Helm) template(ctx context.Context, ociURI, version, namespace string, values interface{}, kubeVersion string, extraParams ...string) ([]byte, error) {
 	valuesYaml, err := yaml.Marshal(values)
 	if err != nil {
 		return nil, fmt.Errorf("failed marshalling values for helm template: %v", err)
 	}
 
 	params := []string{"template", h.url(ociURI), "--version", version, "--namespace", namespace, "--kube-version", kubeVersion}
+	params = append(params, extraParams...)
 	params = h.addInsecureFlagIfProvided(params)
 	params = append(params, "-f", "-")
 
@@ -70,6 +70,15 @@ func TestHelmTemplateSuccess(t *testing.T) {
 	tt.Expect(tt.h.Template(tt.ctx, tt.ociURI, tt.version, tt.namespace, tt.values, "1.22")).To(Equal(tt.wantTemplateContent), "helm.Template() should succeed return correct template content")
 }
 
+func TestHelmTemplateWithCRDsSuccess(t *testing.T) {
+	tt := newHelmTemplateTest(t)
+	expectCommand(
+		tt.e, tt.ctx, "template", tt.ociURI, "--version", tt.version, "--namespace", tt.namespace, "--kube-version", "1.22", "--include-crds", "-f", "-",
+	).withStdIn(tt.valuesYaml).withEnvVars(tt.envVars).to().Return(*bytes.NewBuffer(tt.wantTemplateContent), nil)
+
+	tt.Expect(tt.h.TemplateWithCRDs(tt.ctx, tt.ociURI, tt.version, tt.namespace, tt.values, "1.22")).To(Equal(tt.wantTemplateContent), "helm.TemplateWithCRDs() should succeed return correct template content")
+}
+
 func TestHelmTemplateSuccessWithInsecure(t *testing.T) {
 	tt := newHelmTemplateTest(t, executables.WithInsecure())
 	expectCommand(
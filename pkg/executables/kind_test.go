@@ -140,6 +140,8 @@ func TestKindCreateBootstrapClusterSuccess(t *testing.T) {
 }
 
 func TestKindCreateBootstrapClusterSuccessWithRegistryMirror(t *testing.T) {
+	// kind writes the registry CA in the cluster folder, relative to the working directory.
+	test.InTempDir(t)
 	_, writer := test.NewWriter(t)
 
 	clusterName := "test_cluster"
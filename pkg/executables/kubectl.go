@@ -20,6 +20,7 @@ import (
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,6 +43,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/wait"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -59,6 +61,7 @@ var (
 	eksaTinkerbellDatacenterResourceType = fmt.Sprintf("tinkerbelldatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaTinkerbellMachineResourceType    = fmt.Sprintf("tinkerbellmachineconfigs.%s", v1alpha1.GroupVersion.Group)
 	TinkerbellHardwareResourceType       = fmt.Sprintf("hardware.%s", tinkv1alpha1.GroupVersion.Group)
+	tinkerbellMachineResourceType        = fmt.Sprintf("tinkerbellmachines.infrastructure.%s", clusterv1.GroupVersion.Group)
 	rufioBaseboardManagementResourceType = fmt.Sprintf("baseboardmanagements.%s", rufiov1alpha1.GroupVersion.Group)
 	eksaCloudStackDatacenterResourceType = fmt.Sprintf("cloudstackdatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaCloudStackMachineResourceType    = fmt.Sprintf("cloudstackmachineconfigs.%s", v1alpha1.GroupVersion.Group)
@@ -322,37 +325,24 @@ func (k *Kubectl) WaitForService(ctx context.Context, kubeconfig string, timeout
 		return fmt.Errorf("parsing duration %q: %w", timeout, err)
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(ctx, timeoutDur)
-	defer cancel()
-	timedOut := timeoutCtx.Done()
-
-	const pollInterval = time.Second
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
-
 	svc := &corev1.Service{}
-	for {
-		select {
-		case <-timedOut:
-			return timeoutCtx.Err()
-		case <-ticker.C:
-			err := k.GetObject(ctx, "service", target, namespace, kubeconfig, svc)
-			if err != nil {
-				logger.V(6).Info("failed to poll service", "target", target, "namespace", namespace, "error", err)
-				continue
-			}
-			for _, ingress := range svc.Status.LoadBalancer.Ingress {
-				if ingress.IP != "" {
-					logger.V(5).Info("found a load balancer:", "IP", svc.Spec.ClusterIP)
-					return nil
-				}
-			}
-			if svc.Spec.ClusterIP != "" {
-				logger.V(5).Info("found a ClusterIP:", "IP", svc.Spec.ClusterIP)
-				return nil
+	return wait.New(timeoutDur).For(ctx, fmt.Sprintf("service %s/%s to get an IP", namespace, target), func(_ context.Context) (wait.Status, error) {
+		if err := k.GetObject(ctx, "service", target, namespace, kubeconfig, svc); err != nil {
+			logger.V(6).Info("failed to poll service", "target", target, "namespace", namespace, "error", err)
+			return wait.Status{}, err
+		}
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				logger.V(5).Info("found a load balancer:", "IP", svc.Spec.ClusterIP)
+				return wait.Status{Done: true}, nil
 			}
 		}
-	}
+		if svc.Spec.ClusterIP != "" {
+			logger.V(5).Info("found a ClusterIP:", "IP", svc.Spec.ClusterIP)
+			return wait.Status{Done: true}, nil
+		}
+		return wait.Status{}, nil
+	})
 }
 
 func (k *Kubectl) WaitForDeployment(ctx context.Context, cluster *types.Cluster, timeout string, condition string, target string, namespace string) error {
@@ -518,35 +508,20 @@ func (k *Kubectl) waitJSONPathLoop(ctx context.Context, kubeconfig string, timeo
 		return fmt.Errorf("parsing duration %q: %w", timeout, err)
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(ctx, timeoutDur)
-	defer cancel()
-	timedOut := timeoutCtx.Done()
-
-	const pollInterval = time.Second
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-timedOut:
-			return fmt.Errorf("waiting for %s %s on %s: timed out", jsonpath, forCondition, property)
-		case <-ticker.C:
-			params := []string{
-				"get", property,
-				"-o", fmt.Sprintf("jsonpath='{.%s}'", jsonpath),
-				"--kubeconfig", kubeconfig,
-				"-n", namespace,
-			}
-			stdout, err := k.Execute(ctx, params...)
-			if err != nil {
-				return fmt.Errorf("waiting for %s %s on %s: %w", jsonpath, forCondition, property, err)
-			}
-			fmt.Printf("%v=%v\n", stdout.String(), fmt.Sprintf("'%s'", forCondition))
-			if stdout.String() == fmt.Sprintf("'%s'", forCondition) {
-				return nil
-			}
-		}
+	params := []string{
+		"get", property,
+		"-o", fmt.Sprintf("jsonpath='{.%s}'", jsonpath),
+		"--kubeconfig", kubeconfig,
+		"-n", namespace,
 	}
+	want := fmt.Sprintf("'%s'", forCondition)
+	return wait.New(timeoutDur).For(ctx, fmt.Sprintf("%s %s on %s", jsonpath, forCondition, property), func(_ context.Context) (wait.Status, error) {
+		stdout, err := k.Execute(ctx, params...)
+		if err != nil {
+			return wait.Status{}, wait.Failed(err)
+		}
+		return wait.Status{Done: stdout.String() == want, Message: fmt.Sprintf("%s=%s", jsonpath, stdout.String())}, nil
+	})
 }
 
 func (k *Kubectl) DeleteEksaDatacenterConfig(ctx context.Context, eksaDatacenterResourceType string, eksaDatacenterConfigName string, kubeconfigFile string, namespace string) error {
@@ -1172,6 +1147,10 @@ func WithAllNamespaces() KubectlOpt {
 	return appendOpt("-A")
 }
 
+func WithSelector(selector string) KubectlOpt {
+	return appendOpt("--selector", selector)
+}
+
 func WithSkipTLSVerify() KubectlOpt {
 	return appendOpt("--insecure-skip-tls-verify=true")
 }
@@ -1340,6 +1319,44 @@ func (k *Kubectl) UpdateEnvironmentVariablesInNamespace(ctx context.Context, res
 	return k.UpdateEnvironmentVariables(ctx, resourceType, resourceName, envMap, WithCluster(cluster), WithNamespace(namespace))
 }
 
+// UpdateResourceRequests sets the compute resource requests of the containers of a resource.
+func (k *Kubectl) UpdateResourceRequests(ctx context.Context, resourceType, resourceName string, requests map[string]string, opts ...KubectlOpt) error {
+	names := make([]string, 0, len(requests))
+	for name := range requests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	r := make([]string, 0, len(names))
+	for _, name := range names {
+		r = append(r, fmt.Sprintf("%s=%s", name, requests[name]))
+	}
+
+	params := []string{"set", "resources", resourceType, resourceName, "--requests", strings.Join(r, ",")}
+	applyOpts(&params, opts...)
+	_, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("setting the resource requests in %s %s: %v", resourceType, resourceName, err)
+	}
+	return nil
+}
+
+// UpdateResourceRequestsInNamespace sets the compute resource requests of the containers of a resource in namespace.
+func (k *Kubectl) UpdateResourceRequestsInNamespace(ctx context.Context, resourceType, resourceName string, requests map[string]string, cluster *types.Cluster, namespace string) error {
+	return k.UpdateResourceRequests(ctx, resourceType, resourceName, requests, WithCluster(cluster), WithNamespace(namespace))
+}
+
+// UpdateReplicasInNamespace scales a resource in namespace to replicas.
+func (k *Kubectl) UpdateReplicasInNamespace(ctx context.Context, resourceType, resourceName string, replicas int, cluster *types.Cluster, namespace string) error {
+	params := []string{"scale", resourceType, resourceName, fmt.Sprintf("--replicas=%d", replicas)}
+	applyOpts(&params, WithCluster(cluster), WithNamespace(namespace))
+	_, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("scaling %s %s: %v", resourceType, resourceName, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) UpdateAnnotation(ctx context.Context, resourceType, objectName string, annotations map[string]string, opts ...KubectlOpt) error {
 	params := []string{"annotate", resourceType, objectName}
 	for k, v := range annotations {
@@ -1665,6 +1682,75 @@ func (k *Kubectl) GetProvisionedTinkerbellHardware(ctx context.Context, kubeconf
 	return list.Items, nil
 }
 
+// GetTinkerbellMachineNames retrieves the names of the TinkerbellMachines of the cluster clusterName in namespace.
+func (k *Kubectl) GetTinkerbellMachineNames(ctx context.Context, kubeconfig, clusterName, namespace string) ([]string, error) {
+	params := []string{
+		"get", tinkerbellMachineResourceType,
+		"-l", fmt.Sprintf("%s=%s", clusterv1.ClusterLabelName, clusterName),
+		"--kubeconfig", kubeconfig,
+		"-o", "json",
+		"--namespace", namespace,
+	}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting tinkerbell machines of cluster %s: %v", clusterName, err)
+	}
+
+	var list metav1.PartialObjectMetadataList
+	if err := json.Unmarshal(stdOut.Bytes(), &list); err != nil {
+		return nil, fmt.Errorf("parsing tinkerbell machines of cluster %s: %v", clusterName, err)
+	}
+
+	names := make([]string, 0, len(list.Items))
+	for _, m := range list.Items {
+		names = append(names, m.Name)
+	}
+
+	return names, nil
+}
+
+// GetPodDisruptionBudgets retrieves the PodDisruptionBudgets of all namespaces.
+func (k *Kubectl) GetPodDisruptionBudgets(ctx context.Context, kubeconfig string) ([]policyv1.PodDisruptionBudget, error) {
+	params := []string{"get", "poddisruptionbudgets.v1.policy", "-A", "-o", "json", "--kubeconfig", kubeconfig}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting pod disruption budgets: %v", err)
+	}
+
+	list := &policyv1.PodDisruptionBudgetList{}
+	if err := json.Unmarshal(stdOut.Bytes(), list); err != nil {
+		return nil, fmt.Errorf("parsing get pod disruption budgets response: %v", err)
+	}
+
+	return list.Items, nil
+}
+
+// GetAPIServerMetrics retrieves the kube-apiserver metrics in the Prometheus text format.
+func (k *Kubectl) GetAPIServerMetrics(ctx context.Context, kubeconfig string) (string, error) {
+	stdOut, err := k.Execute(ctx, "get", "--raw", "/metrics", "--kubeconfig", kubeconfig)
+	if err != nil {
+		return "", fmt.Errorf("getting kube-apiserver metrics: %v", err)
+	}
+	return stdOut.String(), nil
+}
+
+// ReleaseTinkerbellHardware removes the owner reference information from a Tinkerbell Hardware object so
+// it can be provisioned again, and wipes the user data the owning machine left on it.
+func (k *Kubectl) ReleaseTinkerbellHardware(ctx context.Context, kubeconfig, name, namespace string) error {
+	patch := `{"metadata":{"labels":{"v1alpha1.tinkerbell.org/ownerName":null,"v1alpha1.tinkerbell.org/ownerNamespace":null}},"spec":{"userData":null}}`
+	params := []string{
+		"patch", TinkerbellHardwareResourceType, name,
+		"--type=merge", "-p", patch,
+		"--kubeconfig", kubeconfig,
+		"--namespace", namespace,
+	}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("releasing hardware %s: %v", name, err)
+	}
+
+	return nil
+}
+
 func (k *Kubectl) GetEksaVSphereMachineConfig(ctx context.Context, vsphereMachineConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereMachineConfig, error) {
 	params := []string{"get", eksaVSphereMachineResourceType, vsphereMachineConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
 	stdOut, err := k.Execute(ctx, params...)
@@ -2032,6 +2118,19 @@ func (k *Kubectl) Delete(ctx context.Context, resourceType, name, namespace, kub
 	return nil
 }
 
+// DeleteIgnoreNotFound performs a DELETE call like Delete, without failing if the object doesn't exist.
+// The namespace is omitted for cluster scoped objects when empty.
+func (k *Kubectl) DeleteIgnoreNotFound(ctx context.Context, resourceType, name, namespace, kubeconfig string) error {
+	params := []string{"delete", resourceType, name, "--kubeconfig", kubeconfig, "--ignore-not-found=true"}
+	if namespace != "" {
+		params = append(params, "--namespace", namespace)
+	}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("deleting %s %s: %v", resourceType, name, err)
+	}
+	return nil
+}
+
 // DeleteClusterObject performs a DELETE call like above except without namespace required.
 func (k *Kubectl) DeleteClusterObject(ctx context.Context, resourceType, name, kubeconfig string) error {
 	if _, err := k.Execute(ctx, "delete", resourceType, name, "--kubeconfig", kubeconfig); err != nil {
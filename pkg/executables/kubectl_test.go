@@ -16,6 +16,7 @@ import (
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -640,6 +641,9 @@ func TestKubectlGetMachines(t *testing.T) {
 					Metadata: types.MachineMetadata{
 						Name: "eksa-test-capd-control-plane-5nfdg",
 					},
+					Spec: types.MachineSpec{
+						ProviderID: ptr.String("docker:////eksa-test-capd-control-plane-5nfdg"),
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
@@ -685,6 +689,9 @@ func TestKubectlGetMachines(t *testing.T) {
 					Metadata: types.MachineMetadata{
 						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
 					},
+					Spec: types.MachineSpec{
+						ProviderID: ptr.String("docker:////eksa-test-capd-md-0-bb7885f6f-gkb85"),
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
@@ -720,6 +727,9 @@ func TestKubectlGetMachines(t *testing.T) {
 						},
 						Name: "eksa-test-capd-control-plane-5nfdg",
 					},
+					Spec: types.MachineSpec{
+						ProviderID: ptr.String("docker:////eksa-test-capd-control-plane-5nfdg"),
+					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
 							APIVersion: "v1",
@@ -737,6 +747,9 @@ func TestKubectlGetMachines(t *testing.T) {
 						},
 						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
 					},
+					Spec: types.MachineSpec{
+						ProviderID: ptr.String("docker:////eksa-test-capd-md-0-bb7885f6f-gkb85"),
+					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
 							APIVersion: "v1",
@@ -759,6 +772,9 @@ func TestKubectlGetMachines(t *testing.T) {
 						},
 						Name: "eksa-test-capd-control-plane-5nfdg",
 					},
+					Spec: types.MachineSpec{
+						ProviderID: ptr.String("docker:////eksa-test-capd-control-plane-5nfdg"),
+					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
 							APIVersion: "v1",
@@ -814,6 +830,9 @@ func TestKubectlGetMachines(t *testing.T) {
 						},
 						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
 					},
+					Spec: types.MachineSpec{
+						ProviderID: ptr.String("docker:////eksa-test-capd-md-0-bb7885f6f-gkb85"),
+					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
 							APIVersion: "v1",
@@ -854,6 +873,9 @@ func TestKubectlGetMachines(t *testing.T) {
 						},
 						Name: "eksa-test-capd-control-plane-5nfdg",
 					},
+					Spec: types.MachineSpec{
+						ProviderID: ptr.String("docker:////eksa-test-capd-control-plane-5nfdg"),
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
@@ -1910,6 +1932,39 @@ func TestKubectlUpdateEnvironmentVariablesInNamespace(t *testing.T) {
 	}
 }
 
+func TestKubectlUpdateResourceRequestsInNamespace(t *testing.T) {
+	k, ctx, cluster, e := newKubectl(t)
+	requests := map[string]string{
+		"memory": "64Mi",
+		"cpu":    "20m",
+	}
+	e.EXPECT().Execute(ctx, []string{
+		"set", "resources", "deployment",
+		"eksa-controller-manager", "--requests", "cpu=20m,memory=64Mi",
+		"--kubeconfig", cluster.KubeconfigFile,
+		"--namespace", "eksa-system",
+	})
+
+	err := k.UpdateResourceRequestsInNamespace(ctx, "deployment", "eksa-controller-manager", requests, cluster, "eksa-system")
+	if err != nil {
+		t.Fatalf("Kubectl.UpdateResourceRequestsInNamespace() error = %v, want nil", err)
+	}
+}
+
+func TestKubectlUpdateReplicasInNamespace(t *testing.T) {
+	k, ctx, cluster, e := newKubectl(t)
+	e.EXPECT().Execute(ctx, []string{
+		"scale", "deployment", "eksa-controller-manager", "--replicas=1",
+		"--kubeconfig", cluster.KubeconfigFile,
+		"--namespace", "eksa-system",
+	})
+
+	err := k.UpdateReplicasInNamespace(ctx, "deployment", "eksa-controller-manager", 1, cluster, "eksa-system")
+	if err != nil {
+		t.Fatalf("Kubectl.UpdateReplicasInNamespace() error = %v, want nil", err)
+	}
+}
+
 func TestKubectlUpdateAnnotation(t *testing.T) {
 	k, ctx, cluster, e := newKubectl(t)
 	e.EXPECT().Execute(ctx, []string{
@@ -2455,6 +2510,40 @@ func TestGetUnprovisionedTinkerbellHardware(t *testing.T) {
 	tt.Expect(hardware).To(Equal(expect))
 }
 
+func TestReleaseTinkerbellHardware(t *testing.T) {
+	tt := newKubectlTest(t)
+	kubeconfig := "foo/bar"
+
+	params := []string{
+		"patch", executables.TinkerbellHardwareResourceType, "hw1",
+		"--type=merge", "-p", `{"metadata":{"labels":{"v1alpha1.tinkerbell.org/ownerName":null,"v1alpha1.tinkerbell.org/ownerNamespace":null}},"spec":{"userData":null}}`,
+		"--kubeconfig", kubeconfig,
+		"--namespace", tt.namespace,
+	}
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Eq(params)).Return(bytes.Buffer{}, errors.New("forbidden"))
+
+	tt.Expect(tt.k.ReleaseTinkerbellHardware(tt.ctx, kubeconfig, "hw1", tt.namespace)).To(MatchError("releasing hardware hw1: forbidden"))
+}
+
+func TestGetTinkerbellMachineNames(t *testing.T) {
+	tt := newKubectlTest(t)
+	kubeconfig := "foo/bar"
+
+	params := []string{
+		"get", "tinkerbellmachines.infrastructure.cluster.x-k8s.io",
+		"-l", "cluster.x-k8s.io/cluster-name=test",
+		"--kubeconfig", kubeconfig,
+		"-o", "json",
+		"--namespace", tt.namespace,
+	}
+	machinesJSON := `{"items":[{"metadata":{"name":"test-control-plane-abcde"}},{"metadata":{"name":"test-md-0-fghij"}}]}`
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Eq(params)).Return(*bytes.NewBufferString(machinesJSON), nil)
+
+	names, err := tt.k.GetTinkerbellMachineNames(tt.ctx, kubeconfig, "test", tt.namespace)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(names).To(Equal([]string{"test-control-plane-abcde", "test-md-0-fghij"}))
+}
+
 func TestGetUnprovisionedTinkerbellHardware_MarshallingError(t *testing.T) {
 	tt := newKubectlTest(t)
 	kubeconfig := "foo/bar"
@@ -2504,6 +2593,28 @@ func TestKubectlDelete(t *testing.T) {
 	tt.Expect(tt.k.Delete(tt.ctx, resourceType, name, tt.namespace, tt.kubeconfig)).To(Succeed())
 }
 
+func TestKubectlDeleteIgnoreNotFound(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"delete", "secret", "my-cluster-ca", "--kubeconfig", tt.kubeconfig, "--ignore-not-found=true", "--namespace", tt.namespace,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.DeleteIgnoreNotFound(tt.ctx, "secret", "my-cluster-ca", tt.namespace, tt.kubeconfig)).To(Succeed())
+}
+
+func TestKubectlDeleteIgnoreNotFoundClusterScopedError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"delete", "namespace", "eksa-packages-my-cluster", "--kubeconfig", tt.kubeconfig, "--ignore-not-found=true",
+	).Return(bytes.Buffer{}, errors.New("forbidden"))
+
+	tt.Expect(tt.k.DeleteIgnoreNotFound(tt.ctx, "namespace", "eksa-packages-my-cluster", "", tt.kubeconfig)).To(
+		MatchError("deleting namespace eksa-packages-my-cluster: forbidden"),
+	)
+}
+
 func TestKubectlDeleteClusterObject(t *testing.T) {
 	tt := newKubectlTest(t)
 	name := "my-storageclass"
@@ -3109,3 +3220,43 @@ func TestKubectlSearchTinkerbellDatacenterConfigNotFound(t *testing.T) {
 	_, err := tt.k.SearchTinkerbellDatacenterConfig(tt.ctx, "test", kubeconfigfile, tt.namespace)
 	tt.Expect(err).NotTo(BeNil())
 }
+
+func TestKubectlGetPodDisruptionBudgets(t *testing.T) {
+	tt := newKubectlTest(t)
+	list := &policyv1.PodDisruptionBudgetList{
+		Items: []policyv1.PodDisruptionBudget{{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+		}},
+	}
+	b, err := json.Marshal(list)
+	tt.Expect(err).To(Succeed())
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "poddisruptionbudgets.v1.policy", "-A", "-o", "json", "--kubeconfig", tt.kubeconfig,
+	).Return(*bytes.NewBuffer(b), nil)
+
+	tt.Expect(tt.k.GetPodDisruptionBudgets(tt.ctx, tt.kubeconfig)).To(Equal(list.Items))
+}
+
+func TestKubectlGetPodDisruptionBudgetsError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "poddisruptionbudgets.v1.policy", "-A", "-o", "json", "--kubeconfig", tt.kubeconfig,
+	).Return(bytes.Buffer{}, errors.New("forbidden"))
+
+	_, err := tt.k.GetPodDisruptionBudgets(tt.ctx, tt.kubeconfig)
+	tt.Expect(err).To(MatchError("getting pod disruption budgets: forbidden"))
+}
+
+func TestKubectlGetAPIServerMetrics(t *testing.T) {
+	tt := newKubectlTest(t)
+	metrics := "apiserver_requested_deprecated_apis{group=\"policy\",removed_release=\"1.25\",resource=\"podsecuritypolicies\",subresource=\"\",version=\"v1beta1\"} 1\n"
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "--raw", "/metrics", "--kubeconfig", tt.kubeconfig,
+	).Return(*bytes.NewBufferString(metrics), nil)
+
+	tt.Expect(tt.k.GetAPIServerMetrics(tt.ctx, tt.kubeconfig)).To(Equal(metrics))
+}
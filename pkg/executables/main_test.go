@@ -0,0 +1,15 @@
+package executables_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
+)
+
+func TestMain(m *testing.M) {
+	// The mocked executables answer much faster than any real provider API, don't throttle them
+	// or count their failures across tests.
+	ratelimit.SetDefaultConfig(ratelimit.Config{QPS: 1e6, Burst: 1e6})
+	os.Exit(m.Run())
+}
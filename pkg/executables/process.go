@@ -0,0 +1,46 @@
+package executables
+
+import (
+	"context"
+	"os/exec"
+	"time"
+)
+
+// processKillGracePeriod is how long a command has to exit after being asked to terminate
+// before it's killed.
+var processKillGracePeriod = 10 * time.Second
+
+// runInProcessGroup runs cmd in its own process group and waits for it to finish. If ctx is done
+// before, the whole group, including any process started by the command, is asked to terminate
+// and killed if it's still running after processKillGracePeriod.
+func runInProcessGroup(ctx context.Context, cmd *exec.Cmd) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+		}
+
+		terminateProcessGroup(cmd.Process)
+		timer := time.NewTimer(processKillGracePeriod)
+		defer timer.Stop()
+		select {
+		case <-done:
+		case <-timer.C:
+			killProcessGroup(cmd.Process)
+		}
+	}()
+
+	return cmd.Wait()
+}
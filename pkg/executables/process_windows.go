@@ -0,0 +1,19 @@
+//go:build windows
+
+package executables
+
+import (
+	"os"
+	"os/exec"
+)
+
+// Process groups can't be signaled on Windows, so only the command process is stopped.
+func setProcessGroup(_ *exec.Cmd) {}
+
+func terminateProcessGroup(p *os.Process) {
+	_ = p.Kill()
+}
+
+func killProcessGroup(p *os.Process) {
+	_ = p.Kill()
+}
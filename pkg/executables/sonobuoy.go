@@ -4,12 +4,36 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
-const sonobuoyPath = "./sonobuoy"
+const (
+	sonobuoyPath      = "./sonobuoy"
+	sonobuoyToolsPath = "sonobuoy"
+)
+
+// SonobuoyMode is the set of tests sonobuoy runs.
+type SonobuoyMode string
+
+const (
+	// SonobuoyCertifiedConformance runs the whole Kubernetes conformance suite.
+	SonobuoyCertifiedConformance SonobuoyMode = "certified-conformance"
+	// SonobuoyQuick runs a single conformance test, to smoke test the cluster.
+	SonobuoyQuick SonobuoyMode = "quick"
+)
+
+// SonobuoyRunOpts configures a sonobuoy run. Images left empty use the sonobuoy defaults.
+type SonobuoyRunOpts struct {
+	Mode                 SonobuoyMode
+	SonobuoyImage        string
+	KubeConformanceImage string
+	SystemdLogsImage     string
+	Timeout              time.Duration
+}
 
 type Sonobuoy struct {
 	Executable
@@ -65,3 +89,51 @@ func (k *Sonobuoy) GetResults(ctx context.Context, contextName string, args ...s
 	}
 	return command + output.String(), err
 }
+
+// RunConformance runs the sonobuoy tests in the cluster and waits for them to complete.
+func (k *Sonobuoy) RunConformance(ctx context.Context, kubeconfig string, opts SonobuoyRunOpts) error {
+	params := []string{"run", "--kubeconfig", kubeconfig, "--mode", string(opts.Mode), "--wait"}
+	if opts.Timeout > 0 {
+		params = append(params, "--timeout", strconv.Itoa(int(opts.Timeout.Seconds())))
+	}
+	if opts.SonobuoyImage != "" {
+		params = append(params, "--sonobuoy-image", opts.SonobuoyImage)
+	}
+	if opts.KubeConformanceImage != "" {
+		params = append(params, "--kube-conformance-image", opts.KubeConformanceImage)
+	}
+	if opts.SystemdLogsImage != "" {
+		params = append(params, "--systemd-logs-image", opts.SystemdLogsImage)
+	}
+
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("executing sonobuoy run: %v", err)
+	}
+	return nil
+}
+
+// Retrieve downloads the results archive of the last sonobuoy run to dir and returns its path.
+func (k *Sonobuoy) Retrieve(ctx context.Context, kubeconfig, dir string) (archivePath string, err error) {
+	output, err := k.Execute(ctx, "retrieve", dir, "--kubeconfig", kubeconfig)
+	if err != nil {
+		return "", fmt.Errorf("executing sonobuoy retrieve: %v", err)
+	}
+	return strings.TrimSpace(output.String()), nil
+}
+
+// Results returns the summary of the results in a sonobuoy archive.
+func (k *Sonobuoy) Results(ctx context.Context, archivePath string) (string, error) {
+	output, err := k.Execute(ctx, "results", archivePath)
+	if err != nil {
+		return "", fmt.Errorf("executing sonobuoy results: %v", err)
+	}
+	return output.String(), nil
+}
+
+// Delete removes the sonobuoy namespace and resources from the cluster.
+func (k *Sonobuoy) Delete(ctx context.Context, kubeconfig string) error {
+	if _, err := k.Execute(ctx, "delete", "--kubeconfig", kubeconfig, "--wait"); err != nil {
+		return fmt.Errorf("executing sonobuoy delete: %v", err)
+	}
+	return nil
+}
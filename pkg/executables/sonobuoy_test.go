@@ -0,0 +1,89 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+const sonobuoyKubeconfig = "c/c-eks-a-cluster.kubeconfig"
+
+func newSonobuoy(t *testing.T) (*executables.Sonobuoy, context.Context, *mockexecutables.MockExecutable) {
+	ctrl := gomock.NewController(t)
+	e := mockexecutables.NewMockExecutable(ctrl)
+	return executables.NewSonobuoy(e), context.Background(), e
+}
+
+func TestSonobuoyRunConformance(t *testing.T) {
+	g := NewWithT(t)
+	s, ctx, e := newSonobuoy(t)
+	e.EXPECT().Execute(ctx,
+		"run", "--kubeconfig", sonobuoyKubeconfig, "--mode", "certified-conformance", "--wait",
+		"--timeout", "7200",
+		"--sonobuoy-image", "sonobuoy:v0.56.10",
+		"--kube-conformance-image", "conformance:v1.23.7",
+		"--systemd-logs-image", "systemd-logs:v0.4",
+	).Return(bytes.Buffer{}, nil)
+
+	g.Expect(s.RunConformance(ctx, sonobuoyKubeconfig, executables.SonobuoyRunOpts{
+		Mode:                 executables.SonobuoyCertifiedConformance,
+		SonobuoyImage:        "sonobuoy:v0.56.10",
+		KubeConformanceImage: "conformance:v1.23.7",
+		SystemdLogsImage:     "systemd-logs:v0.4",
+		Timeout:              2 * time.Hour,
+	})).To(Succeed())
+}
+
+func TestSonobuoyRunConformanceDefaults(t *testing.T) {
+	g := NewWithT(t)
+	s, ctx, e := newSonobuoy(t)
+	e.EXPECT().Execute(ctx, "run", "--kubeconfig", sonobuoyKubeconfig, "--mode", "quick", "--wait").Return(bytes.Buffer{}, errors.New("error"))
+
+	g.Expect(s.RunConformance(ctx, sonobuoyKubeconfig, executables.SonobuoyRunOpts{
+		Mode: executables.SonobuoyQuick,
+	})).To(MatchError(ContainSubstring("executing sonobuoy run")))
+}
+
+func TestSonobuoyRetrieve(t *testing.T) {
+	g := NewWithT(t)
+	s, ctx, e := newSonobuoy(t)
+	e.EXPECT().Execute(ctx, "retrieve", "c/conformance", "--kubeconfig", sonobuoyKubeconfig).Return(
+		*bytes.NewBufferString("c/conformance/202210181200_sonobuoy_0a1b.tar.gz\n"), nil,
+	)
+
+	g.Expect(s.Retrieve(ctx, sonobuoyKubeconfig, "c/conformance")).To(Equal("c/conformance/202210181200_sonobuoy_0a1b.tar.gz"))
+}
+
+func TestSonobuoyRetrieveError(t *testing.T) {
+	g := NewWithT(t)
+	s, ctx, e := newSonobuoy(t)
+	e.EXPECT().Execute(ctx, "retrieve", "c/conformance", "--kubeconfig", sonobuoyKubeconfig).Return(bytes.Buffer{}, errors.New("error"))
+
+	_, err := s.Retrieve(ctx, sonobuoyKubeconfig, "c/conformance")
+	g.Expect(err).To(MatchError(ContainSubstring("executing sonobuoy retrieve")))
+}
+
+func TestSonobuoyResults(t *testing.T) {
+	g := NewWithT(t)
+	s, ctx, e := newSonobuoy(t)
+	summary := "Plugin: e2e\nStatus: passed\n"
+	e.EXPECT().Execute(ctx, "results", "results.tar.gz").Return(*bytes.NewBufferString(summary), nil)
+
+	g.Expect(s.Results(ctx, "results.tar.gz")).To(Equal(summary))
+}
+
+func TestSonobuoyDelete(t *testing.T) {
+	g := NewWithT(t)
+	s, ctx, e := newSonobuoy(t)
+	e.EXPECT().Execute(ctx, "delete", "--kubeconfig", sonobuoyKubeconfig, "--wait").Return(bytes.Buffer{}, nil)
+
+	g.Expect(s.Delete(ctx, sonobuoyKubeconfig)).To(Succeed())
+}
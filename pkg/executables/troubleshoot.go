@@ -23,12 +23,16 @@ func NewTroubleshoot(executable Executable) *Troubleshoot {
 	}
 }
 
-func (t *Troubleshoot) Collect(ctx context.Context, bundlePath string, sinceTime *time.Time, kubeconfig string) (archivePath string, err error) {
+// Collect collects the support bundle. The redactors spec, if not empty, adds redactors to the default ones.
+func (t *Troubleshoot) Collect(ctx context.Context, bundlePath, redactorsPath string, sinceTime *time.Time, kubeconfig string) (archivePath string, err error) {
 	marshalledTime, err := sinceTime.MarshalText()
 	if err != nil {
 		return "", fmt.Errorf("could not marshal sinceTime for Collect parameters: %v", err)
 	}
 	params := []string{bundlePath, "--kubeconfig", kubeconfig, "--interactive=false", "--since-time", string(marshalledTime)}
+	if redactorsPath != "" {
+		params = append(params, "--redactors", redactorsPath)
+	}
 
 	output, err := t.Execute(ctx, params...)
 	if err != nil {
@@ -30,7 +30,23 @@ func TestTroubleshootCollectSuccess(t *testing.T) {
 	returnBuffer := bytes.Buffer{}
 	returnBuffer.Write([]byte(archivePath))
 	e.EXPECT().Execute(ctx, gomock.Eq(expectedParams)).Return(returnBuffer, nil)
-	if _, err := ts.Collect(ctx, bundlePath, &sinceTime, cluster.KubeconfigFile); err != nil {
+	if _, err := ts.Collect(ctx, bundlePath, "", &sinceTime, cluster.KubeconfigFile); err != nil {
+		t.Errorf("Troubleshoot.Collect() error = %v, want nil", err)
+	}
+}
+
+func TestTroubleshootCollectWithRedactors(t *testing.T) {
+	ts, ctx, cluster, e := newTroubleshoot(t)
+	sinceTime, err := time.Parse(time.RFC3339, sinceTimeString)
+	if err != nil {
+		t.Errorf("Troubleshoot.Collect() error: failed to parse time: %v", err)
+	}
+	redactorsPath := "./redactors.yaml"
+	expectedParams := []string{bundlePath, "--kubeconfig", cluster.KubeconfigFile, "--interactive=false", "--since-time", sinceTimeString, "--redactors", redactorsPath}
+	returnBuffer := bytes.Buffer{}
+	returnBuffer.Write([]byte(archivePath))
+	e.EXPECT().Execute(ctx, gomock.Eq(expectedParams)).Return(returnBuffer, nil)
+	if _, err := ts.Collect(ctx, bundlePath, redactorsPath, &sinceTime, cluster.KubeconfigFile); err != nil {
 		t.Errorf("Troubleshoot.Collect() error = %v, want nil", err)
 	}
 }
@@ -0,0 +1,63 @@
+package executables
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	vbmcPath = "vbmc"
+
+	vbmcDomainNotFound = "No domain with matching name"
+)
+
+// Vbmc manages VirtualBMC instances, IPMI endpoints that control libvirt domains.
+type Vbmc struct {
+	Executable
+	libvirtURI string
+}
+
+// NewVbmc returns a Vbmc that controls the domains of the libvirt daemon at libvirtURI.
+func NewVbmc(executable Executable, libvirtURI string) *Vbmc {
+	if libvirtURI == "" {
+		libvirtURI = DefaultLibvirtURI
+	}
+	return &Vbmc{
+		Executable: executable,
+		libvirtURI: libvirtURI,
+	}
+}
+
+// Add registers a BMC for domain listening on address and port and protected by username and password.
+func (b *Vbmc) Add(ctx context.Context, domain, address string, port int, username, password string) error {
+	params := []string{
+		"add", domain,
+		"--address", address,
+		"--port", strconv.Itoa(port),
+		"--username", username,
+		"--password", password,
+		"--libvirt-uri", b.libvirtURI,
+	}
+	if _, err := b.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("adding bmc for %s: %v", domain, err)
+	}
+	return nil
+}
+
+// Start starts serving the BMC of domain.
+func (b *Vbmc) Start(ctx context.Context, domain string) error {
+	if _, err := b.Execute(ctx, "start", domain); err != nil {
+		return fmt.Errorf("starting bmc for %s: %v", domain, err)
+	}
+	return nil
+}
+
+// Delete stops and removes the BMC of domain. It doesn't fail if the BMC doesn't exist.
+func (b *Vbmc) Delete(ctx context.Context, domain string) error {
+	if _, err := b.Execute(ctx, "delete", domain); err != nil && !strings.Contains(err.Error(), vbmcDomainNotFound) {
+		return fmt.Errorf("deleting bmc for %s: %v", domain, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+func newVbmc(t *testing.T) (*executables.Vbmc, *mockexecutables.MockExecutable, context.Context) {
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	return executables.NewVbmc(executable, ""), executable, context.Background()
+}
+
+func TestVbmcAdd(t *testing.T) {
+	g := NewWithT(t)
+	b, e, ctx := newVbmc(t)
+	e.EXPECT().Execute(ctx,
+		"add", "vm-0", "--address", "192.168.122.1", "--port", "6230",
+		"--username", "admin", "--password", "secret", "--libvirt-uri", "qemu:///system",
+	)
+
+	g.Expect(b.Add(ctx, "vm-0", "192.168.122.1", 6230, "admin", "secret")).To(Succeed())
+}
+
+func TestVbmcAddError(t *testing.T) {
+	g := NewWithT(t)
+	b, e, ctx := newVbmc(t)
+	e.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("port in use"))
+
+	g.Expect(b.Add(ctx, "vm-0", "192.168.122.1", 6230, "admin", "secret")).To(MatchError("adding bmc for vm-0: port in use"))
+}
+
+func TestVbmcStart(t *testing.T) {
+	g := NewWithT(t)
+	b, e, ctx := newVbmc(t)
+	e.EXPECT().Execute(ctx, "start", "vm-0")
+
+	g.Expect(b.Start(ctx, "vm-0")).To(Succeed())
+}
+
+func TestVbmcDeleteNotFound(t *testing.T) {
+	g := NewWithT(t)
+	b, e, ctx := newVbmc(t)
+	e.EXPECT().Execute(ctx, "delete", "vm-0").Return(bytes.Buffer{}, errors.New("No domain with matching name vm-0 was found"))
+
+	g.Expect(b.Delete(ctx, "vm-0")).To(Succeed())
+}
+
+func TestVbmcDeleteError(t *testing.T) {
+	g := NewWithT(t)
+	b, e, ctx := newVbmc(t)
+	e.EXPECT().Execute(ctx, "delete", "vm-0").Return(bytes.Buffer{}, errors.New("connection refused"))
+
+	g.Expect(b.Delete(ctx, "vm-0")).To(MatchError("deleting bmc for vm-0: connection refused"))
+}
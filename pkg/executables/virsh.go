@@ -0,0 +1,79 @@
+package executables
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	virshPath = "virsh"
+	// DefaultLibvirtURI is the libvirt connection used when none is provided.
+	DefaultLibvirtURI = "qemu:///system"
+
+	virshDomainNotFound   = "failed to get domain"
+	virshDomainNotRunning = "domain is not running"
+)
+
+// Virsh manages libvirt domains and volumes on the host.
+type Virsh struct {
+	Executable
+	uri string
+}
+
+// NewVirsh returns a Virsh that connects to the libvirt daemon at uri.
+func NewVirsh(executable Executable, uri string) *Virsh {
+	if uri == "" {
+		uri = DefaultLibvirtURI
+	}
+	return &Virsh{
+		Executable: executable,
+		uri:        uri,
+	}
+}
+
+// CreateVolume creates a qcow2 volume of capacityGiB in pool.
+func (v *Virsh) CreateVolume(ctx context.Context, pool, name string, capacityGiB int) error {
+	if _, err := v.execute(ctx, "vol-create-as", pool, name, fmt.Sprintf("%dG", capacityGiB), "--format", "qcow2"); err != nil {
+		return fmt.Errorf("creating volume %s: %v", name, err)
+	}
+	return nil
+}
+
+// DefineDomain defines, or redefines if it already exists, the domain described by domainXML without starting it.
+func (v *Virsh) DefineDomain(ctx context.Context, domainXML []byte) error {
+	params := []string{"--connect", v.uri, "define", "/dev/stdin"}
+	if _, err := v.ExecuteWithStdin(ctx, domainXML, params...); err != nil {
+		return fmt.Errorf("defining domain: %v", err)
+	}
+	return nil
+}
+
+// DeleteDomain powers off the domain name and removes it along with its storage.
+// It doesn't fail if the domain doesn't exist.
+func (v *Virsh) DeleteDomain(ctx context.Context, name string) error {
+	if _, err := v.execute(ctx, "destroy", name); err != nil && !isVirshError(err, virshDomainNotRunning, virshDomainNotFound) {
+		return fmt.Errorf("powering off domain %s: %v", name, err)
+	}
+
+	if _, err := v.execute(ctx, "undefine", name, "--remove-all-storage", "--nvram"); err != nil && !isVirshError(err, virshDomainNotFound) {
+		return fmt.Errorf("undefining domain %s: %v", name, err)
+	}
+
+	return nil
+}
+
+func (v *Virsh) execute(ctx context.Context, args ...string) (string, error) {
+	params := append([]string{"--connect", v.uri}, args...)
+	out, err := v.Execute(ctx, params...)
+	return out.String(), err
+}
+
+func isVirshError(err error, messages ...string) bool {
+	for _, m := range messages {
+		if strings.Contains(err.Error(), m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+func newVirsh(t *testing.T) (*executables.Virsh, *mockexecutables.MockExecutable, context.Context) {
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	return executables.NewVirsh(executable, ""), executable, context.Background()
+}
+
+func TestVirshCreateVolume(t *testing.T) {
+	g := NewWithT(t)
+	v, e, ctx := newVirsh(t)
+	e.EXPECT().Execute(ctx, "--connect", "qemu:///system", "vol-create-as", "default", "vm-0.qcow2", "40G", "--format", "qcow2")
+
+	g.Expect(v.CreateVolume(ctx, "default", "vm-0.qcow2", 40)).To(Succeed())
+}
+
+func TestVirshCreateVolumeError(t *testing.T) {
+	g := NewWithT(t)
+	v, e, ctx := newVirsh(t)
+	e.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("pool not found"))
+
+	g.Expect(v.CreateVolume(ctx, "default", "vm-0.qcow2", 40)).To(MatchError("creating volume vm-0.qcow2: pool not found"))
+}
+
+func TestVirshDefineDomain(t *testing.T) {
+	g := NewWithT(t)
+	e := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	v := executables.NewVirsh(e, "qemu+ssh://host/system")
+	ctx := context.Background()
+	domain := []byte("<domain/>")
+	e.EXPECT().ExecuteWithStdin(ctx, domain, "--connect", "qemu+ssh://host/system", "define", "/dev/stdin")
+
+	g.Expect(v.DefineDomain(ctx, domain)).To(Succeed())
+}
+
+func TestVirshDeleteDomain(t *testing.T) {
+	g := NewWithT(t)
+	v, e, ctx := newVirsh(t)
+	gomock.InOrder(
+		e.EXPECT().Execute(ctx, "--connect", "qemu:///system", "destroy", "vm-0").Return(bytes.Buffer{}, errors.New("error: Requested operation is not valid: domain is not running")),
+		e.EXPECT().Execute(ctx, "--connect", "qemu:///system", "undefine", "vm-0", "--remove-all-storage", "--nvram"),
+	)
+
+	g.Expect(v.DeleteDomain(ctx, "vm-0")).To(Succeed())
+}
+
+func TestVirshDeleteDomainNotFound(t *testing.T) {
+	g := NewWithT(t)
+	v, e, ctx := newVirsh(t)
+	e.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("error: failed to get domain 'vm-0'")).Times(2)
+
+	g.Expect(v.DeleteDomain(ctx, "vm-0")).To(Succeed())
+}
+
+func TestVirshDeleteDomainError(t *testing.T) {
+	g := NewWithT(t)
+	v, e, ctx := newVirsh(t)
+	e.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("permission denied"))
+
+	g.Expect(v.DeleteDomain(ctx, "vm-0")).To(MatchError("powering off domain vm-0: permission denied"))
+}
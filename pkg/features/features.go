@@ -9,6 +9,8 @@ const (
 	NutanixProviderEnvVar           = "NUTANIX_PROVIDER"
 	UseNewWorkflowsEnvVar           = "USE_NEW_WORKFLOWS"
 	K8s124SupportEnvVar             = "K8S_1_24_SUPPORT"
+	FakeProviderEnvVar              = "FAKE_PROVIDER"
+	PhaseFaultInjectionEnvVar       = "PHASE_FAULT_INJECTION"
 )
 
 func FeedGates(featureGates []string) {
@@ -78,3 +80,21 @@ func UseNewWorkflows() Feature {
 		IsActive: globalFeatures.isActiveForEnvVar(UseNewWorkflowsEnvVar),
 	}
 }
+
+// FakeProvider returns a feature that is active if the FAKE_PROVIDER environment variable is true. It makes the
+// controller reconcile Docker clusters with an in-memory provider meant for integration tests.
+func FakeProvider() Feature {
+	return Feature{
+		Name:     "In-memory fake provider for controller integration tests",
+		IsActive: globalFeatures.isActiveForEnvVar(FakeProviderEnvVar),
+	}
+}
+
+// PhaseFaultInjection returns a feature that is active if the PHASE_FAULT_INJECTION environment variable is true.
+// It allows the controller to inject faults in the reconciliation phases, only meant for tests.
+func PhaseFaultInjection() Feature {
+	return Feature{
+		Name:     "Fault injection in reconciliation phases",
+		IsActive: globalFeatures.isActiveForEnvVar(PhaseFaultInjectionEnvVar),
+	}
+}
@@ -85,3 +85,19 @@ func TestWithK8s124FeatureFlag(t *testing.T) {
 	t.Setenv(K8s124SupportEnvVar, "true")
 	g.Expect(IsActive(K8s124Support())).To(BeTrue())
 }
+
+func TestWithFakeProviderFeatureFlag(t *testing.T) {
+	g := NewWithT(t)
+	setupContext(t)
+
+	t.Setenv(FakeProviderEnvVar, "true")
+	g.Expect(IsActive(FakeProvider())).To(BeTrue())
+}
+
+func TestWithPhaseFaultInjectionFeatureFlag(t *testing.T) {
+	g := NewWithT(t)
+	setupContext(t)
+
+	t.Setenv(PhaseFaultInjectionEnvVar, "true")
+	g.Expect(IsActive(PhaseFaultInjection())).To(BeTrue())
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/eks-anywhere/pkg/files (interfaces: OCIPuller)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOCIPuller is a mock of OCIPuller interface.
+type MockOCIPuller struct {
+	ctrl     *gomock.Controller
+	recorder *MockOCIPullerMockRecorder
+}
+
+// MockOCIPullerMockRecorder is the mock recorder for MockOCIPuller.
+type MockOCIPullerMockRecorder struct {
+	mock *MockOCIPuller
+}
+
+// NewMockOCIPuller creates a new mock instance.
+func NewMockOCIPuller(ctrl *gomock.Controller) *MockOCIPuller {
+	mock := &MockOCIPuller{ctrl: ctrl}
+	mock.recorder = &MockOCIPullerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOCIPuller) EXPECT() *MockOCIPullerMockRecorder {
+	return m.recorder
+}
+
+// Pull mocks base method.
+func (m *MockOCIPuller) Pull(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pull", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockOCIPullerMockRecorder) Pull(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockOCIPuller)(nil).Pull), arg0, arg1)
+}
@@ -0,0 +1,235 @@
+package files
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+	"oras.land/oras-go/pkg/target"
+)
+
+const (
+	ociScheme = "oci"
+	// cosignSignatureAnnotation is the annotation of the cosign signature layers with the signature of their payload.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+// OCIPuller pulls the content of a single file OCI artifact.
+type OCIPuller interface {
+	Pull(ctx context.Context, ref string) ([]byte, error)
+}
+
+// IsOCIReference returns true if uri references an OCI artifact, e.g. oci://registry/org/specs/prod:v3.
+func IsOCIReference(uri string) bool {
+	return strings.HasPrefix(uri, ociScheme+"://")
+}
+
+// IsPinnedOCIReference returns true if uri references an OCI artifact by its digest,
+// e.g. oci://registry/org/specs/prod@sha256:<digest>.
+func IsPinnedOCIReference(uri string) bool {
+	return IsOCIReference(uri) && strings.Contains(uri, "@")
+}
+
+// RegistryPuller pulls single file artifacts from an OCI registry, like the ones pushed with
+// oras push. Registry credentials are read from the docker config.
+// The content of artifacts referenced by digest is verified against it and, when the puller has a
+// public key, the artifacts need a cosign signature made with its private key.
+type RegistryPuller struct {
+	publicKey *ecdsa.PublicKey
+}
+
+type RegistryPullerOpt func(*RegistryPuller)
+
+// WithSignatureVerification makes the puller verify the cosign signature of the artifacts with publicKey.
+func WithSignatureVerification(publicKey *ecdsa.PublicKey) RegistryPullerOpt {
+	return func(p *RegistryPuller) {
+		p.publicKey = publicKey
+	}
+}
+
+func NewRegistryPuller(opts ...RegistryPullerOpt) *RegistryPuller {
+	p := &RegistryPuller{}
+	for _, o := range opts {
+		o(p)
+	}
+
+	return p
+}
+
+// Pull downloads the artifact referenced by ref and returns the content of its only layer.
+func (p *RegistryPuller) Pull(ctx context.Context, ref string) ([]byte, error) {
+	registry, err := content.NewRegistry(content.RegistryOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("creating registry client: %v", err)
+	}
+
+	return p.pull(ctx, registry, ref)
+}
+
+func (p *RegistryPuller) pull(ctx context.Context, registry target.Target, ref string) ([]byte, error) {
+	manifestDesc, manifest, store, err := pullManifest(ctx, registry, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if i := strings.LastIndex(ref, "@"); i != -1 && string(manifestDesc.Digest) != ref[i+1:] {
+		return nil, fmt.Errorf("artifact %s has digest %s", ref, manifestDesc.Digest)
+	}
+
+	if p.publicKey != nil {
+		if err := p.verifySignature(ctx, registry, ref, manifestDesc); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(manifest.Layers) != 1 {
+		return nil, fmt.Errorf("artifact %s must contain exactly one file, found %d", ref, len(manifest.Layers))
+	}
+
+	_, data, ok := store.Get(manifest.Layers[0])
+	if !ok {
+		return nil, fmt.Errorf("content for artifact %s not found", ref)
+	}
+	if err := verifyDigest(manifest.Layers[0], data); err != nil {
+		return nil, fmt.Errorf("content for artifact %s: %v", ref, err)
+	}
+
+	return data, nil
+}
+
+// pullManifest copies the artifact referenced by ref to a memory store and returns its manifest,
+// verified against its digest.
+func pullManifest(ctx context.Context, registry target.Target, ref string) (ocispec.Descriptor, *ocispec.Manifest, *content.Memory, error) {
+	store := content.NewMemory()
+	manifestDesc, err := oras.Copy(ctx, registry, ref, store, "")
+	if err != nil {
+		return ocispec.Descriptor{}, nil, nil, fmt.Errorf("pulling artifact %s: %v", ref, err)
+	}
+
+	_, manifestContent, ok := store.Get(manifestDesc)
+	if !ok {
+		return ocispec.Descriptor{}, nil, nil, fmt.Errorf("manifest for artifact %s not found", ref)
+	}
+	if err := verifyDigest(manifestDesc, manifestContent); err != nil {
+		return ocispec.Descriptor{}, nil, nil, fmt.Errorf("manifest for artifact %s: %v", ref, err)
+	}
+
+	manifest := &ocispec.Manifest{}
+	if err := json.Unmarshal(manifestContent, manifest); err != nil {
+		return ocispec.Descriptor{}, nil, nil, fmt.Errorf("parsing manifest for artifact %s: %v", ref, err)
+	}
+
+	return manifestDesc, manifest, store, nil
+}
+
+func verifyDigest(desc ocispec.Descriptor, data []byte) error {
+	if err := desc.Digest.Validate(); err != nil {
+		return err
+	}
+	if d := desc.Digest.Algorithm().FromBytes(data); d != desc.Digest {
+		return fmt.Errorf("digest %s doesn't match the expected %s", d, desc.Digest)
+	}
+
+	return nil
+}
+
+// simpleSigningPayload is the payload signed by cosign, which references the digest of the signed manifest.
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifySignature looks for a cosign signature of the artifact manifest made with the private key of
+// the puller public key. Cosign stores the signatures in the sha256-<digest>.sig tag of the repository.
+func (p *RegistryPuller) verifySignature(ctx context.Context, registry target.Target, ref string, manifestDesc ocispec.Descriptor) error {
+	signatureRef := ociRepository(ref) + ":" + strings.Replace(string(manifestDesc.Digest), ":", "-", 1) + ".sig"
+	_, signatures, store, err := pullManifest(ctx, registry, signatureRef)
+	if err != nil {
+		return fmt.Errorf("reading signatures of artifact %s: %v", ref, err)
+	}
+
+	for _, layer := range signatures.Layers {
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+		_, payload, ok := store.Get(layer)
+		if !ok {
+			continue
+		}
+
+		hash := sha256.Sum256(payload)
+		if !ecdsa.VerifyASN1(p.publicKey, hash[:], signature) {
+			continue
+		}
+
+		signed := &simpleSigningPayload{}
+		if err := json.Unmarshal(payload, signed); err == nil && signed.Critical.Image.DockerManifestDigest == string(manifestDesc.Digest) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("artifact %s doesn't have a signature valid for the public key", ref)
+}
+
+// ociRepository returns the repository of an artifact reference, without its tag and digest.
+func ociRepository(ref string) string {
+	if i := strings.LastIndex(ref, "@"); i != -1 {
+		ref = ref[:i]
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[:i]
+	}
+
+	return ref
+}
+
+// ReadPublicKey reads a PEM encoded ECDSA public key, like the cosign.pub file generated by cosign generate-key-pair.
+func ReadPublicKey(filename string) (*ecdsa.PublicKey, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading public key: %v", err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("public key %s is not PEM encoded", filename)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key %s: %v", filename, err)
+	}
+
+	publicKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("only ECDSA public keys are supported")
+	}
+
+	return publicKey, nil
+}
+
+// ReadOCIFile pulls the single file OCI artifact referenced by uri, e.g. oci://registry/org/specs/prod:v3.
+func (r *Reader) ReadOCIFile(ctx context.Context, uri string) ([]byte, error) {
+	ref := strings.TrimPrefix(uri, ociScheme+"://")
+	data, err := r.ociPuller.Pull(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading file from oci artifact [%s]: %v", ref, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,146 @@
+package files
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/pkg/content"
+)
+
+const clusterConfig = "kind: Cluster"
+
+type ociTest struct {
+	*WithT
+	ctx      context.Context
+	registry *content.Memory
+	key      *ecdsa.PrivateKey
+}
+
+func newOCITest(t *testing.T) *ociTest {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ociTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		registry: content.NewMemory(),
+		key:      key,
+	}
+}
+
+// pushArtifact stores an artifact with a layer for each content and returns the descriptor of its manifest.
+func (tt *ociTest) pushArtifact(ref string, layers map[string][]byte, annotations map[string]map[string]string) ocispec.Descriptor {
+	config, err := tt.registry.Add("", "application/vnd.oci.image.config.v1+json", []byte("{}"))
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	manifest := ocispec.Manifest{Versioned: specs.Versioned{SchemaVersion: 2}, Config: config}
+	for name, data := range layers {
+		layer, err := tt.registry.Add(name, "", data)
+		tt.Expect(err).NotTo(HaveOccurred())
+		for k, v := range annotations[name] {
+			layer.Annotations[k] = v
+		}
+		manifest.Layers = append(manifest.Layers, layer)
+	}
+
+	manifestContent, err := json.Marshal(manifest)
+	tt.Expect(err).NotTo(HaveOccurred())
+	desc, err := tt.registry.Add("", ocispec.MediaTypeImageManifest, manifestContent)
+	tt.Expect(err).NotTo(HaveOccurred())
+	desc.Annotations = nil
+	tt.Expect(tt.registry.StoreManifest(ref, desc, manifestContent)).To(Succeed())
+
+	return desc
+}
+
+// sign stores a cosign signature of the artifact manifest made with key.
+func (tt *ociTest) sign(repository string, manifestDesc ocispec.Descriptor, key *ecdsa.PrivateKey) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"}}`, repository, manifestDesc.Digest))
+	hash := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	ref := repository + ":" + strings.Replace(string(manifestDesc.Digest), ":", "-", 1) + ".sig"
+	tt.pushArtifact(ref, map[string][]byte{"payload": payload}, map[string]map[string]string{
+		"payload": {cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+	})
+}
+
+func TestRegistryPullerPullPinnedDigest(t *testing.T) {
+	tt := newOCITest(t)
+	desc := tt.pushArtifact("registry.example.com/org/specs/prod:v3", map[string][]byte{"cluster.yaml": []byte(clusterConfig)}, nil)
+	ref := "registry.example.com/org/specs/prod@" + string(desc.Digest)
+	tt.Expect(tt.registry.StoreManifest(ref, desc, nil)).To(Succeed())
+
+	tt.Expect(NewRegistryPuller().pull(tt.ctx, tt.registry, ref)).To(BeEquivalentTo(clusterConfig))
+}
+
+func TestRegistryPullerPullPinnedDigestMismatch(t *testing.T) {
+	tt := newOCITest(t)
+	desc := tt.pushArtifact("registry.example.com/org/specs/prod:v3", map[string][]byte{"cluster.yaml": []byte(clusterConfig)}, nil)
+	ref := "registry.example.com/org/specs/prod@sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+	tt.Expect(tt.registry.StoreManifest(ref, desc, nil)).To(Succeed())
+
+	_, err := NewRegistryPuller().pull(tt.ctx, tt.registry, ref)
+	tt.Expect(err).To(MatchError(ContainSubstring("has digest " + string(desc.Digest))))
+}
+
+func TestRegistryPullerPullSigned(t *testing.T) {
+	tt := newOCITest(t)
+	desc := tt.pushArtifact("registry.example.com/org/specs/prod:v3", map[string][]byte{"cluster.yaml": []byte(clusterConfig)}, nil)
+	tt.sign("registry.example.com/org/specs/prod", desc, tt.key)
+	puller := NewRegistryPuller(WithSignatureVerification(&tt.key.PublicKey))
+
+	tt.Expect(puller.pull(tt.ctx, tt.registry, "registry.example.com/org/specs/prod:v3")).To(BeEquivalentTo(clusterConfig))
+}
+
+func TestRegistryPullerPullSignedWithOtherKey(t *testing.T) {
+	tt := newOCITest(t)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tt.Expect(err).NotTo(HaveOccurred())
+	desc := tt.pushArtifact("registry.example.com/org/specs/prod:v3", map[string][]byte{"cluster.yaml": []byte(clusterConfig)}, nil)
+	tt.sign("registry.example.com/org/specs/prod", desc, otherKey)
+	puller := NewRegistryPuller(WithSignatureVerification(&tt.key.PublicKey))
+
+	_, err = puller.pull(tt.ctx, tt.registry, "registry.example.com/org/specs/prod:v3")
+	tt.Expect(err).To(MatchError(ContainSubstring("doesn't have a signature valid for the public key")))
+}
+
+func TestRegistryPullerPullNotSigned(t *testing.T) {
+	tt := newOCITest(t)
+	tt.pushArtifact("registry.example.com/org/specs/prod:v3", map[string][]byte{"cluster.yaml": []byte(clusterConfig)}, nil)
+	puller := NewRegistryPuller(WithSignatureVerification(&tt.key.PublicKey))
+
+	_, err := puller.pull(tt.ctx, tt.registry, "registry.example.com/org/specs/prod:v3")
+	tt.Expect(err).To(MatchError(ContainSubstring("reading signatures of artifact registry.example.com/org/specs/prod:v3")))
+}
+
+func TestOCIRepository(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{ref: "registry.example.com/org/specs/prod:v3", want: "registry.example.com/org/specs/prod"},
+		{ref: "registry.example.com:5000/org/specs/prod", want: "registry.example.com:5000/org/specs/prod"},
+		{ref: "registry.example.com:5000/org/specs/prod:v3@sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945", want: "registry.example.com:5000/org/specs/prod"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.ref, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ociRepository(tc.ref)).To(Equal(tc.want))
+		})
+	}
+}
@@ -18,6 +18,7 @@ type Reader struct {
 	embedFS    embed.FS
 	httpClient *http.Client
 	userAgent  string
+	ociPuller  OCIPuller
 }
 
 type ReaderOpt func(*Reader)
@@ -34,11 +35,18 @@ func WithUserAgent(userAgent string) ReaderOpt {
 	}
 }
 
+func WithOCIPuller(puller OCIPuller) ReaderOpt {
+	return func(s *Reader) {
+		s.ociPuller = puller
+	}
+}
+
 func NewReader(opts ...ReaderOpt) *Reader {
 	r := &Reader{
 		embedFS:    embed.FS{},
 		httpClient: &http.Client{},
 		userAgent:  "eks-a/unknown",
+		ociPuller:  NewRegistryPuller(),
 	}
 
 	for _, o := range opts {
@@ -1,13 +1,24 @@
 package files_test
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"embed"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/files/mocks"
 )
 
 //go:embed testdata
@@ -71,3 +82,65 @@ func TestReaderReadFileSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestReaderReadOCIFile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	puller := mocks.NewMockOCIPuller(ctrl)
+	puller.EXPECT().Pull(ctx, "registry.example.com/org/specs/prod:v3").Return([]byte("kind: Cluster"), nil)
+
+	r := files.NewReader(files.WithOCIPuller(puller))
+	got, err := r.ReadOCIFile(ctx, "oci://registry.example.com/org/specs/prod:v3")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(got)).To(Equal("kind: Cluster"))
+}
+
+func TestReaderReadOCIFileError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	puller := mocks.NewMockOCIPuller(ctrl)
+	puller.EXPECT().Pull(ctx, "registry.example.com/org/specs/prod:v3").Return(nil, errors.New("unauthorized"))
+
+	r := files.NewReader(files.WithOCIPuller(puller))
+	_, err := r.ReadOCIFile(ctx, "oci://registry.example.com/org/specs/prod:v3")
+	g.Expect(err).To(MatchError(ContainSubstring("unauthorized")))
+}
+
+func TestIsOCIReference(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(files.IsOCIReference("oci://registry.example.com/org/specs/prod:v3")).To(BeTrue())
+	g.Expect(files.IsOCIReference("cluster.yaml")).To(BeFalse())
+	g.Expect(files.IsOCIReference("https://example.com/cluster.yaml")).To(BeFalse())
+}
+
+func TestIsPinnedOCIReference(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(files.IsPinnedOCIReference("oci://registry.example.com/org/specs/prod@sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945")).To(BeTrue())
+	g.Expect(files.IsPinnedOCIReference("oci://registry.example.com/org/specs/prod:v3")).To(BeFalse())
+	g.Expect(files.IsPinnedOCIReference("cluster@prod.yaml")).To(BeFalse())
+}
+
+func TestReadPublicKey(t *testing.T) {
+	g := NewWithT(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).To(BeNil())
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	g.Expect(err).To(BeNil())
+	filename := filepath.Join(t.TempDir(), "cosign.pub")
+	g.Expect(os.WriteFile(filename, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600)).To(Succeed())
+
+	publicKey, err := files.ReadPublicKey(filename)
+	g.Expect(err).To(BeNil())
+	g.Expect(publicKey.Equal(&key.PublicKey)).To(BeTrue())
+}
+
+func TestReadPublicKeyNotPEM(t *testing.T) {
+	g := NewWithT(t)
+	filename := filepath.Join(t.TempDir(), "cosign.pub")
+	g.Expect(os.WriteFile(filename, []byte("not a key"), 0o600)).To(Succeed())
+
+	_, err := files.ReadPublicKey(filename)
+	g.Expect(err).To(MatchError(ContainSubstring("is not PEM encoded")))
+}
@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	goGithub "github.com/google/go-github/v35/github"
+)
+
+const (
+	// appJWTValidity is kept under the 10 minutes maximum accepted by GitHub.
+	appJWTValidity = 9 * time.Minute
+	// appJWTClockSkew backdates the JWTs to allow for clock drift with GitHub.
+	appJWTClockSkew = time.Minute
+)
+
+// InstallationToken is an access token of a GitHub App installation.
+type InstallationToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// AppTokenIssuer issues installation access tokens for GitHub Apps, authenticating as the app with a JWT
+// signed with its private key.
+type AppTokenIssuer struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// AppTokenIssuerOpt customizes an AppTokenIssuer.
+type AppTokenIssuerOpt func(*AppTokenIssuer)
+
+// WithAppAPIURL sets the URL of the GitHub API, for GitHub Enterprise servers.
+func WithAppAPIURL(u *url.URL) AppTokenIssuerOpt {
+	return func(i *AppTokenIssuer) {
+		i.baseURL = u
+	}
+}
+
+// WithAppHTTPClient sets the http client used to call the GitHub API.
+func WithAppHTTPClient(c *http.Client) AppTokenIssuerOpt {
+	return func(i *AppTokenIssuer) {
+		i.httpClient = c
+	}
+}
+
+// NewAppTokenIssuer builds an AppTokenIssuer calling the public GitHub API by default.
+func NewAppTokenIssuer(opts ...AppTokenIssuerOpt) *AppTokenIssuer {
+	i := &AppTokenIssuer{
+		httpClient: http.DefaultClient,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// InstallationToken issues a new access token for an installation of a GitHub App. GitHub tokens expire after an hour.
+func (i *AppTokenIssuer) InstallationToken(ctx context.Context, appID, installationID int64, privateKey []byte) (*InstallationToken, error) {
+	key, err := parseAppPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	jwt, err := appJWT(appID, key, i.now())
+	if err != nil {
+		return nil, err
+	}
+
+	client := goGithub.NewClient(&http.Client{
+		Transport: &bearerTransport{token: jwt, base: i.httpClient.Transport},
+		Timeout:   i.httpClient.Timeout,
+	})
+	if i.baseURL != nil {
+		client.BaseURL = i.baseURL
+	}
+
+	token, _, err := client.Apps.CreateInstallationToken(ctx, installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating access token for installation %d of github app %d: %v", installationID, appID, err)
+	}
+
+	return &InstallationToken{
+		Token:     token.GetToken(),
+		ExpiresAt: token.GetExpiresAt(),
+	}, nil
+}
+
+func parseAppPrivateKey(privateKey []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return nil, errors.New("decoding github app private key: no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing github app private key: %v", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("github app private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// appJWT builds the RS256 JWT used to authenticate as a GitHub App.
+func appJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTValidity).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("signing github app jwt: %v", err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+type bearerTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
@@ -0,0 +1,101 @@
+package github_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+)
+
+func TestAppTokenIssuerInstallationToken(t *testing.T) {
+	g := NewWithT(t)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	g.Expect(err).NotTo(HaveOccurred())
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/app/installations/5678/access_tokens" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(jwt, ".")
+		if len(parts) != 3 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature) != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		c := map[string]interface{}{}
+		_ = json.Unmarshal(claims, &c)
+		if c["iss"] != "1234" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"token":      "ghs_installation-token",
+			"expires_at": expiresAt.Format(time.RFC3339),
+		})
+	}))
+	defer server.Close()
+
+	apiURL, err := url.Parse(server.URL + "/")
+	g.Expect(err).NotTo(HaveOccurred())
+	issuer := github.NewAppTokenIssuer(github.WithAppAPIURL(apiURL), github.WithAppHTTPClient(server.Client()))
+
+	token, err := issuer.InstallationToken(context.Background(), 1234, 5678, privateKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token.Token).To(Equal("ghs_installation-token"))
+	g.Expect(token.ExpiresAt.Equal(expiresAt)).To(BeTrue())
+}
+
+func TestAppTokenIssuerInstallationTokenInvalidKey(t *testing.T) {
+	g := NewWithT(t)
+	issuer := github.NewAppTokenIssuer()
+
+	_, err := issuer.InstallationToken(context.Background(), 1234, 5678, []byte("not a key"))
+	g.Expect(err).To(MatchError("decoding github app private key: no PEM data found"))
+}
+
+func TestAppTokenIssuerInstallationTokenAPIError(t *testing.T) {
+	g := NewWithT(t)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	g.Expect(err).NotTo(HaveOccurred())
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	apiURL, err := url.Parse(server.URL + "/")
+	g.Expect(err).NotTo(HaveOccurred())
+	issuer := github.NewAppTokenIssuer(github.WithAppAPIURL(apiURL), github.WithAppHTTPClient(server.Client()))
+
+	_, err = issuer.InstallationToken(context.Background(), 1234, 5678, privateKey)
+	g.Expect(err).To(MatchError(ContainSubstring("creating access token for installation 5678 of github app 1234")))
+}
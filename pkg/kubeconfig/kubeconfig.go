@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
@@ -125,3 +126,59 @@ func ValidateKubeconfigPath(clusterName string, parentFolders ...string) error {
 	}
 	return nil
 }
+
+// WithContext returns the path to a kubeconfig file that only holds the context contextName of the kubeconfig
+// filename, or its current context when contextName is empty. This way, every tool using the returned file,
+// which don't all honor the current context the same way, targets the same cluster. The returned file is
+// filename itself when it only holds that context. Otherwise, the file is a temporary file with the credentials
+// of the context embedded, which callers must delete with the returned func once done.
+func WithContext(filename, contextName string) (path string, remove func(), err error) {
+	if filename == "" {
+		if contextName != "" {
+			return "", nil, fmt.Errorf("kubeconfig context %s requires a kubeconfig file", contextName)
+		}
+		return "", func() {}, nil
+	}
+
+	config, err := clientcmd.LoadFromFile(filename)
+	if err != nil {
+		return "", nil, fmt.Errorf("loading kubeconfig %s: %v", filename, err)
+	}
+
+	if contextName == "" {
+		contextName = config.CurrentContext
+	}
+	if _, ok := config.Contexts[contextName]; !ok {
+		return "", nil, fmt.Errorf("context %q not found in kubeconfig %s", contextName, filename)
+	}
+	if len(config.Contexts) == 1 && config.CurrentContext == contextName {
+		return filename, func() {}, nil
+	}
+
+	config.CurrentContext = contextName
+	if err := clientcmdapi.MinifyConfig(config); err != nil {
+		return "", nil, fmt.Errorf("selecting context %s of kubeconfig %s: %v", contextName, filename, err)
+	}
+	// Paths in the kubeconfig are relative to its folder, so files are embedded before writing it somewhere else.
+	if err := clientcmdapi.FlattenConfig(config); err != nil {
+		return "", nil, fmt.Errorf("selecting context %s of kubeconfig %s: %v", contextName, filename, err)
+	}
+
+	return writeTempFile(config)
+}
+
+func writeTempFile(config *clientcmdapi.Config) (path string, remove func(), err error) {
+	f, err := os.CreateTemp("", "eksa-kubeconfig-*.kubeconfig")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating kubeconfig file for context %s: %v", config.CurrentContext, err)
+	}
+	f.Close()
+	remove = func() { os.Remove(f.Name()) }
+
+	if err := clientcmd.WriteToFile(*config, f.Name()); err != nil {
+		remove()
+		return "", nil, fmt.Errorf("writing kubeconfig file for context %s: %v", config.CurrentContext, err)
+	}
+
+	return f.Name(), remove, nil
+}
@@ -2,11 +2,13 @@ package kubeconfig_test
 
 import (
 	"bytes"
+	"fmt"
 	"io/fs"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
@@ -185,3 +187,108 @@ func TestResolveAndValidateFilename(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+var multiContextKubeconfig = []byte(`
+apiVersion: v1
+clusters:
+- cluster:
+    insecure-skip-tls-verify: true
+    server: https://127.0.0.1:38471
+  name: test
+- cluster:
+    insecure-skip-tls-verify: true
+    server: https://127.0.0.2:6443
+  name: prod
+contexts:
+- context:
+    cluster: test
+    user: test-admin
+  name: test-admin@test
+- context:
+    cluster: prod
+    user: prod-admin
+  name: prod-admin@prod
+current-context: test-admin@test
+kind: Config
+preferences: {}
+users:
+- name: test-admin
+  user:
+    client-certificate-data: dGVzdA==
+    client-key-data: dGVzdA==
+- name: prod-admin
+  user:
+    client-certificate-data: cHJvZA==
+    client-key-data: cHJvZA==
+`)
+
+func TestWithContext(t *testing.T) {
+	t.Run("returns the file when it only has the current context", func(t *testing.T) {
+		goodFile := test.WithFakeFileContents(t, bytes.NewReader(goodKubeconfig))
+
+		filename, remove, err := kubeconfig.WithContext(goodFile.Name(), "")
+		assert.NoError(t, err)
+		assert.Equal(t, goodFile.Name(), filename)
+
+		remove()
+		assert.FileExists(t, goodFile.Name())
+	})
+
+	t.Run("returns a file with only the requested context", func(t *testing.T) {
+		multiFile := test.WithFakeFileContents(t, bytes.NewReader(multiContextKubeconfig))
+
+		filename, remove, err := kubeconfig.WithContext(multiFile.Name(), "prod-admin@prod")
+		assert.NoError(t, err)
+		t.Cleanup(remove)
+
+		config, err := clientcmd.LoadFromFile(filename)
+		assert.NoError(t, err)
+		assert.Equal(t, "prod-admin@prod", config.CurrentContext)
+		assert.Len(t, config.Contexts, 1)
+		assert.Len(t, config.Clusters, 1)
+		assert.Equal(t, "https://127.0.0.2:6443", config.Clusters["prod"].Server)
+		assert.Equal(t, []byte("prod"), config.AuthInfos["prod-admin"].ClientCertificateData)
+	})
+
+	t.Run("returns a file with only the current context", func(t *testing.T) {
+		multiFile := test.WithFakeFileContents(t, bytes.NewReader(multiContextKubeconfig))
+
+		filename, remove, err := kubeconfig.WithContext(multiFile.Name(), "")
+		assert.NoError(t, err)
+		t.Cleanup(remove)
+
+		config, err := clientcmd.LoadFromFile(filename)
+		assert.NoError(t, err)
+		assert.Equal(t, "test-admin@test", config.CurrentContext)
+		assert.Len(t, config.Clusters, 1)
+	})
+
+	t.Run("removes the file with the requested context", func(t *testing.T) {
+		multiFile := test.WithFakeFileContents(t, bytes.NewReader(multiContextKubeconfig))
+
+		filename, remove, err := kubeconfig.WithContext(multiFile.Name(), "prod-admin@prod")
+		assert.NoError(t, err)
+
+		remove()
+		assert.NoFileExists(t, filename)
+		assert.FileExists(t, multiFile.Name())
+	})
+
+	t.Run("reports errors for contexts not in the file", func(t *testing.T) {
+		multiFile := test.WithFakeFileContents(t, bytes.NewReader(multiContextKubeconfig))
+
+		_, _, err := kubeconfig.WithContext(multiFile.Name(), "staging")
+		assert.EqualError(t, err, fmt.Sprintf("context \"staging\" not found in kubeconfig %s", multiFile.Name()))
+	})
+
+	t.Run("reports errors for a context without a file", func(t *testing.T) {
+		_, _, err := kubeconfig.WithContext("", "prod-admin@prod")
+		assert.EqualError(t, err, "kubeconfig context prod-admin@prod requires a kubeconfig file")
+	})
+
+	t.Run("returns empty without a file", func(t *testing.T) {
+		filename, _, err := kubeconfig.WithContext("", "")
+		assert.NoError(t, err)
+		assert.Empty(t, filename)
+	})
+}
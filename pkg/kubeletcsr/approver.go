@@ -0,0 +1,197 @@
+package kubeletcsr
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	nodeUserPrefix = "system:node:"
+	nodesGroup     = "system:nodes"
+
+	// approvalReason is set in the Approved condition of the CSRs approved by the Approver.
+	approvalReason = "EKSAKubeletServingApprove"
+)
+
+// allowedUsages are the key usages kubelets request for their serving certificates.
+var allowedUsages = map[certificatesv1.KeyUsage]struct{}{
+	certificatesv1.UsageDigitalSignature: {},
+	certificatesv1.UsageKeyEncipherment:  {},
+	certificatesv1.UsageServerAuth:       {},
+}
+
+// Approver approves the kubelet serving certificate signing requests of a cluster. It only approves
+// the requests made by a node for itself, for names and addresses that node reports in its status.
+type Approver struct {
+	client kubernetes.Interface
+}
+
+// NewApprover constructs a new Approver for the cluster client connects to.
+func NewApprover(client kubernetes.Interface) *Approver {
+	return &Approver{
+		client: client,
+	}
+}
+
+// ApprovePending approves the pending kubelet serving CSRs that pass validation and returns the names
+// of the approved ones. CSRs that don't pass validation are left pending and reported in the error.
+func (a *Approver) ApprovePending(ctx context.Context) ([]string, error) {
+	csrs, err := a.client.CertificatesV1().CertificateSigningRequests().List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.signerName=" + certificatesv1.KubeletServingSignerName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing certificate signing requests: %v", err)
+	}
+
+	var approved []string
+	var errs []error
+	for i := range csrs.Items {
+		csr := &csrs.Items[i]
+		if csr.Spec.SignerName != certificatesv1.KubeletServingSignerName || !pending(csr) {
+			continue
+		}
+
+		if err := a.validate(ctx, csr); err != nil {
+			errs = append(errs, fmt.Errorf("not approving certificate signing request %s: %v", csr.Name, err))
+			continue
+		}
+
+		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+			Type:           certificatesv1.CertificateApproved,
+			Status:         corev1.ConditionTrue,
+			Reason:         approvalReason,
+			Message:        "Kubelet serving certificate approved by EKS Anywhere",
+			LastUpdateTime: metav1.Now(),
+		})
+		if _, err := a.client.CertificatesV1().CertificateSigningRequests().UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{}); err != nil {
+			errs = append(errs, fmt.Errorf("approving certificate signing request %s: %v", csr.Name, err))
+			continue
+		}
+		approved = append(approved, csr.Name)
+	}
+
+	return approved, kerrors.NewAggregate(errs)
+}
+
+func (a *Approver) validate(ctx context.Context, csr *certificatesv1.CertificateSigningRequest) error {
+	if err := validateRequester(csr); err != nil {
+		return err
+	}
+	request, err := parseRequest(csr)
+	if err != nil {
+		return err
+	}
+
+	nodeName := strings.TrimPrefix(csr.Spec.Username, nodeUserPrefix)
+	node, err := a.client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("getting node %s: %v", nodeName, err)
+	}
+
+	return validateSubjectAltNames(request, node)
+}
+
+// validateRequester checks csr was made by a node with the usages of a serving certificate.
+func validateRequester(csr *certificatesv1.CertificateSigningRequest) error {
+	if !strings.HasPrefix(csr.Spec.Username, nodeUserPrefix) || !contains(csr.Spec.Groups, nodesGroup) {
+		return fmt.Errorf("requester %s is not a node", csr.Spec.Username)
+	}
+
+	for _, u := range csr.Spec.Usages {
+		if _, ok := allowedUsages[u]; !ok {
+			return fmt.Errorf("usage %s is not allowed", u)
+		}
+	}
+
+	return nil
+}
+
+// parseRequest parses the certificate request of csr and checks its subject is the node making the request.
+func parseRequest(csr *certificatesv1.CertificateSigningRequest) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode(csr.Spec.Request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, fmt.Errorf("request is not a PEM encoded certificate request")
+	}
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate request: %v", err)
+	}
+
+	if request.Subject.CommonName != csr.Spec.Username {
+		return nil, fmt.Errorf("common name %s doesn't match requester %s", request.Subject.CommonName, csr.Spec.Username)
+	}
+	if len(request.Subject.Organization) != 1 || request.Subject.Organization[0] != nodesGroup {
+		return nil, fmt.Errorf("organization must be %s", nodesGroup)
+	}
+	if len(request.EmailAddresses) > 0 || len(request.URIs) > 0 {
+		return nil, fmt.Errorf("email and URI subject alternative names are not allowed")
+	}
+
+	return request, nil
+}
+
+// validateSubjectAltNames checks all the DNS names and IP addresses requested are reported by node.
+func validateSubjectAltNames(request *x509.CertificateRequest, node *corev1.Node) error {
+	if len(request.DNSNames) == 0 && len(request.IPAddresses) == 0 {
+		return fmt.Errorf("no subject alternative names requested")
+	}
+
+	names, ips := nodeAddresses(node)
+	for _, name := range request.DNSNames {
+		if _, ok := names[name]; !ok {
+			return fmt.Errorf("DNS name %s is not an address of node %s", name, node.Name)
+		}
+	}
+	for _, ip := range request.IPAddresses {
+		if _, ok := ips[ip.String()]; !ok {
+			return fmt.Errorf("IP address %s is not an address of node %s", ip, node.Name)
+		}
+	}
+
+	return nil
+}
+
+// nodeAddresses returns the DNS names and the IP addresses reported by node.
+func nodeAddresses(node *corev1.Node) (names, ips map[string]struct{}) {
+	names = map[string]struct{}{}
+	ips = map[string]struct{}{}
+	for _, address := range node.Status.Addresses {
+		switch address.Type {
+		case corev1.NodeHostName, corev1.NodeInternalDNS, corev1.NodeExternalDNS:
+			names[address.Address] = struct{}{}
+		case corev1.NodeInternalIP, corev1.NodeExternalIP:
+			if ip := net.ParseIP(address.Address); ip != nil {
+				ips[ip.String()] = struct{}{}
+			}
+		}
+	}
+	return names, ips
+}
+
+func pending(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, c := range csr.Status.Conditions {
+		if c.Type == certificatesv1.CertificateApproved || c.Type == certificatesv1.CertificateDenied || c.Type == certificatesv1.CertificateFailed {
+			return false
+		}
+	}
+	return len(csr.Status.Certificate) == 0
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package kubeletcsr_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/aws/eks-anywhere/pkg/kubeletcsr"
+)
+
+var node = &corev1.Node{
+	ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+	Status: corev1.NodeStatus{
+		Addresses: []corev1.NodeAddress{
+			{Type: corev1.NodeHostName, Address: "node-1"},
+			{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+		},
+	},
+}
+
+func certificateRequest(t *testing.T, commonName string, dnsNames []string, ips []string) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: commonName, Organization: []string{"system:nodes"}},
+		DNSNames: dnsNames,
+	}
+	for _, ip := range ips {
+		template.IPAddresses = append(template.IPAddresses, net.ParseIP(ip))
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
+func csr(name, username string, request []byte) *certificatesv1.CertificateSigningRequest {
+	return &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			SignerName: certificatesv1.KubeletServingSignerName,
+			Username:   username,
+			Groups:     []string{"system:nodes", "system:authenticated"},
+			Usages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+				certificatesv1.UsageKeyEncipherment,
+				certificatesv1.UsageServerAuth,
+			},
+			Request: request,
+		},
+	}
+}
+
+func approved(g *WithT, client *fake.Clientset, name string) bool {
+	c, err := client.CertificatesV1().CertificateSigningRequests().Get(context.Background(), name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, cond := range c.Status.Conditions {
+		if cond.Type == certificatesv1.CertificateApproved {
+			return true
+		}
+	}
+	return false
+}
+
+func TestApproverApprovePending(t *testing.T) {
+	g := NewWithT(t)
+	valid := csr("csr-valid", "system:node:node-1", certificateRequest(t, "system:node:node-1", []string{"node-1"}, []string{"10.0.0.1"}))
+	client := fake.NewSimpleClientset(node, valid)
+
+	names, err := kubeletcsr.NewApprover(client).ApprovePending(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(names).To(ConsistOf("csr-valid"))
+	g.Expect(approved(g, client, "csr-valid")).To(BeTrue())
+}
+
+func TestApproverApprovePendingSkipsOtherRequests(t *testing.T) {
+	g := NewWithT(t)
+	request := certificateRequest(t, "system:node:node-1", []string{"node-1"}, nil)
+	clientSigner := csr("csr-client", "system:node:node-1", request)
+	clientSigner.Spec.SignerName = certificatesv1.KubeAPIServerClientKubeletSignerName
+	alreadyApproved := csr("csr-approved", "system:node:node-1", request)
+	alreadyApproved.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+		{Type: certificatesv1.CertificateApproved, Status: corev1.ConditionTrue},
+	}
+	client := fake.NewSimpleClientset(node, clientSigner, alreadyApproved)
+
+	names, err := kubeletcsr.NewApprover(client).ApprovePending(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(names).To(BeEmpty())
+	g.Expect(approved(g, client, "csr-client")).To(BeFalse())
+}
+
+func TestApproverApprovePendingInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		csr     func(t *testing.T) *certificatesv1.CertificateSigningRequest
+		wantErr string
+	}{
+		{
+			name: "requester is not a node",
+			csr: func(t *testing.T) *certificatesv1.CertificateSigningRequest {
+				c := csr("csr", "admin", certificateRequest(t, "admin", []string{"node-1"}, nil))
+				c.Spec.Groups = []string{"system:masters"}
+				return c
+			},
+			wantErr: "not approving certificate signing request csr: requester admin is not a node",
+		},
+		{
+			name: "client auth usage",
+			csr: func(t *testing.T) *certificatesv1.CertificateSigningRequest {
+				c := csr("csr", "system:node:node-1", certificateRequest(t, "system:node:node-1", []string{"node-1"}, nil))
+				c.Spec.Usages = append(c.Spec.Usages, certificatesv1.UsageClientAuth)
+				return c
+			},
+			wantErr: "usage client auth is not allowed",
+		},
+		{
+			name: "common name for another node",
+			csr: func(t *testing.T) *certificatesv1.CertificateSigningRequest {
+				return csr("csr", "system:node:node-1", certificateRequest(t, "system:node:node-2", []string{"node-1"}, nil))
+			},
+			wantErr: "common name system:node:node-2 doesn't match requester system:node:node-1",
+		},
+		{
+			name: "DNS name not of the node",
+			csr: func(t *testing.T) *certificatesv1.CertificateSigningRequest {
+				return csr("csr", "system:node:node-1", certificateRequest(t, "system:node:node-1", []string{"kubernetes.default"}, nil))
+			},
+			wantErr: "DNS name kubernetes.default is not an address of node node-1",
+		},
+		{
+			name: "IP not of the node",
+			csr: func(t *testing.T) *certificatesv1.CertificateSigningRequest {
+				return csr("csr", "system:node:node-1", certificateRequest(t, "system:node:node-1", nil, []string{"10.0.0.2"}))
+			},
+			wantErr: "IP address 10.0.0.2 is not an address of node node-1",
+		},
+		{
+			name: "node doesn't exist",
+			csr: func(t *testing.T) *certificatesv1.CertificateSigningRequest {
+				return csr("csr", "system:node:node-3", certificateRequest(t, "system:node:node-3", []string{"node-3"}, nil))
+			},
+			wantErr: "getting node node-3",
+		},
+		{
+			name: "invalid request",
+			csr: func(t *testing.T) *certificatesv1.CertificateSigningRequest {
+				return csr("csr", "system:node:node-1", []byte("invalid"))
+			},
+			wantErr: "request is not a PEM encoded certificate request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			client := fake.NewSimpleClientset([]runtime.Object{node, tt.csr(t)}...)
+
+			names, err := kubeletcsr.NewApprover(client).ApprovePending(context.Background())
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			g.Expect(names).To(BeEmpty())
+			g.Expect(approved(g, client, "csr")).To(BeFalse())
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RedactMask replaces the redacted values.
+const RedactMask = "*****"
+
+// minSecretLength avoids masking short values that would match too much unrelated text.
+const minSecretLength = 4
+
+// redactPatterns match the known credential formats. The value matched by the mask group is
+// masked, the rest of the match is kept to give context.
+var redactPatterns = []string{
+	// key: value and key=value pairs for credential keys, also matching json and env vars.
+	`(?i)(?:password|passwd|secret[-_]?key|secret[-_]?access[-_]?key|api[-_]?key|access[-_]?key[-_]?id|token|activation[-_]?code|b64encoded[-_]?secret)"?\s*[:=]\s*"?(?P<mask>[^\s",'&}]+)`,
+	// HTTP authorization headers.
+	`(?i)authorization"?\s*[:=]\s*"?(?:bearer|basic)\s+(?P<mask>[^\s",']+)`,
+	// Passwords in URLs.
+	`://[^/\s:@]+:(?P<mask>[^/\s@]+)@`,
+	// AWS access key ids.
+	`\b(?P<mask>(?:AKIA|ASIA)[A-Z0-9]{16})\b`,
+}
+
+var compiledRedactPatterns = compileRedactPatterns(redactPatterns)
+
+// secretKeys are the log keys whose values are always masked.
+var secretKeys = regexp.MustCompile(`(?i)(password|passwd|token|api-?key|secret-?key|activation-?code)$`)
+
+var (
+	secretsLock sync.RWMutex
+	secrets     []string
+)
+
+func compileRedactPatterns(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		compiled = append(compiled, regexp.MustCompile(p))
+	}
+	return compiled
+}
+
+// RedactPatterns returns the regular expressions of the known credential formats. The
+// value matched by the named group mask is the one to redact.
+func RedactPatterns() []string {
+	return append([]string{}, redactPatterns...)
+}
+
+// RegisterSecrets adds values to mask wherever they appear, like the credentials read from the
+// environment or generated at runtime. Values shorter than 4 characters are ignored.
+func RegisterSecrets(values ...string) {
+	secretsLock.Lock()
+	defer secretsLock.Unlock()
+	for _, v := range values {
+		if len(v) >= minSecretLength {
+			secrets = append(secrets, v)
+		}
+	}
+	// Longest first, so a secret containing another one is fully masked.
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+}
+
+// Redact masks the registered secrets and the values matching the known credential patterns in s.
+func Redact(s string) string {
+	secretsLock.RLock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, RedactMask)
+	}
+	secretsLock.RUnlock()
+
+	for _, r := range compiledRedactPatterns {
+		s = maskGroup(r, s)
+	}
+	return s
+}
+
+func maskGroup(r *regexp.Regexp, s string) string {
+	group := r.SubexpIndex("mask")
+	matches := r.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+
+	b := strings.Builder{}
+	last := 0
+	for _, m := range matches {
+		start, end := m[2*group], m[2*group+1]
+		if start < 0 || s[start:end] == RedactMask {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(RedactMask)
+		last = end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// RedactError returns an error wrapping err with its message redacted. It returns nil if err is nil.
+func RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &redactedError{err: err}
+}
+
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return Redact(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// Errorf is like fmt.Errorf but redacts the message of the returned error.
+func Errorf(format string, a ...interface{}) error {
+	return RedactError(fmt.Errorf(format, a...))
+}
+
+// NewRedactingWriter returns a writer that redacts everything written to w. Each write
+// is redacted on its own, so values split across writes aren't masked.
+func NewRedactingWriter(w io.Writer) io.Writer {
+	return redactingWriter{w: w}
+}
+
+type redactingWriter struct {
+	w io.Writer
+}
+
+func (r redactingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(r.w, Redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// NewRedactingCore wraps a zap core to redact the messages and field values of all entries.
+// It can be passed to zap.WrapCore.
+func NewRedactingCore(core zapcore.Core) zapcore.Core {
+	return redactingCore{Core: core}
+}
+
+type redactingCore struct {
+	zapcore.Core
+}
+
+func (c redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return redactingCore{Core: c.Core.With(redactFields(fields))}
+}
+
+func (c redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = Redact(entry.Message)
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, 0, len(fields))
+	for _, f := range fields {
+		redacted = append(redacted, redactField(f))
+	}
+	return redacted
+}
+
+func redactField(f zapcore.Field) zapcore.Field {
+	if secretKeys.MatchString(f.Key) && f.Type != zapcore.ErrorType {
+		return zap.String(f.Key, RedactMask)
+	}
+
+	switch f.Type {
+	case zapcore.StringType:
+		f.String = Redact(f.String)
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			return zap.String(f.Key, Redact(err.Error()))
+		}
+	case zapcore.StringerType:
+		if s, ok := stringer(f.Interface); ok {
+			return zap.String(f.Key, Redact(s))
+		}
+	case zapcore.ReflectType:
+		if content, err := json.Marshal(f.Interface); err == nil {
+			if redacted := Redact(string(content)); json.Valid([]byte(redacted)) {
+				return zap.Reflect(f.Key, json.RawMessage(redacted))
+			}
+		}
+	}
+	return f
+}
+
+// stringer calls String, recovering from panics of nil receivers like the zap encoder does.
+func stringer(v interface{}) (s string, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			s, ok = "", false
+		}
+	}()
+	st, ok := v.(fmt.Stringer)
+	if !ok {
+		return "", false
+	}
+	return st.String(), true
+}
@@ -10,7 +10,7 @@ import (
 )
 
 // InitZap creates a zap logger with the provided verbosity level
-// and sets it as the package logger.
+// and sets it as the package logger. All entries are redacted, see Redact.
 // 0 is the least verbose and 10 the most verbose.
 // The package logger can only be init once, so subsequent calls to this method
 // won't have any effect.
@@ -29,7 +29,7 @@ func InitZap(level int, opts ...LoggerOpt) error {
 		cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
 
-	zapLog, err := cfg.Build()
+	zapLog, err := cfg.Build(zap.WrapCore(NewRedactingCore))
 	if err != nil {
 		return fmt.Errorf("creating zap logger: %v", err)
 	}
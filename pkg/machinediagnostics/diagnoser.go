@@ -0,0 +1,257 @@
+package machinediagnostics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// BootstrapFailedReason is the reason of the machines whose bootstrap config couldn't be generated or run.
+	BootstrapFailedReason = "BootstrapFailed"
+	// InfrastructureFailedReason is the reason of the machines whose infrastructure couldn't be provisioned.
+	InfrastructureFailedReason = "InfrastructureFailed"
+	// NodeJoinTimeoutReason is the reason of the provisioned machines whose node didn't join the cluster in time,
+	// usually because cloud-init or kubeadm join failed.
+	NodeJoinTimeoutReason = "NodeJoinTimeout"
+
+	// DefaultNodeJoinTimeout is how long a provisioned machine can take to join the cluster before it's reported.
+	DefaultNodeJoinTimeout = 20 * time.Minute
+
+	// MaxMessageLength is the max number of characters of the messages reported for a failure.
+	MaxMessageLength = 512
+	// MaxLogLength is the max number of characters of the log excerpts reported for a failure.
+	MaxLogLength = 2048
+)
+
+// Failure is the diagnostic of a machine that failed to bootstrap.
+type Failure struct {
+	Machine string
+	Reason  string
+	Message string
+	// Log is an excerpt of the machine bootstrap logs, empty if the provider doesn't report them.
+	Log string
+	// Since is when the failure happened, as reported by CAPI.
+	Since time.Time
+}
+
+// LogReader reads an excerpt of the bootstrap logs of a machine from its provider.
+type LogReader interface {
+	BootstrapLog(ctx context.Context, machine *clusterv1.Machine) (string, error)
+}
+
+// Diagnoser finds the machines of a cluster that failed to bootstrap and collects the reason and
+// the provider logs of each failure.
+type Diagnoser struct {
+	client          client.Client
+	logReaders      map[string]LogReader
+	nodeJoinTimeout time.Duration
+	now             func() time.Time
+}
+
+// Opt allows to customize a Diagnoser.
+type Opt func(*Diagnoser)
+
+// WithLogReader configures the LogReader for the machines with an infrastructure of the given kind.
+func WithLogReader(infrastructureKind string, reader LogReader) Opt {
+	return func(d *Diagnoser) {
+		d.logReaders[infrastructureKind] = reader
+	}
+}
+
+// WithNodeJoinTimeout configures how long a provisioned machine can take to join the cluster before it's reported.
+func WithNodeJoinTimeout(timeout time.Duration) Opt {
+	return func(d *Diagnoser) {
+		d.nodeJoinTimeout = timeout
+	}
+}
+
+// WithNow configures the clock used to check the node join timeout.
+func WithNow(now func() time.Time) Opt {
+	return func(d *Diagnoser) {
+		d.now = now
+	}
+}
+
+// NewDiagnoser constructs a new Diagnoser.
+func NewDiagnoser(client client.Client, opts ...Opt) *Diagnoser {
+	d := &Diagnoser{
+		client:          client,
+		logReaders:      map[string]LogReader{},
+		nodeJoinTimeout: DefaultNodeJoinTimeout,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Diagnose returns the failures of the machines of cluster, sorted by machine. The log excerpts that
+// can't be read are replaced by the error reading them, so a provider failure doesn't hide the others.
+func (d *Diagnoser) Diagnose(ctx context.Context, cluster *anywherev1.Cluster) ([]Failure, error) {
+	machines := &clusterv1.MachineList{}
+	if err := d.client.List(ctx, machines,
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{clusterv1.ClusterLabelName: cluster.Name},
+	); err != nil {
+		return nil, fmt.Errorf("listing machines for cluster %s: %v", cluster.Name, err)
+	}
+
+	var failures []Failure
+	for m := range machines.Items {
+		machine := &machines.Items[m]
+		failure, ok := d.machineFailure(machine)
+		if !ok {
+			continue
+		}
+
+		if reader, ok := d.logReaders[machine.Spec.InfrastructureRef.Kind]; ok {
+			log, err := reader.BootstrapLog(ctx, machine)
+			if err != nil {
+				log = fmt.Sprintf("reading bootstrap log: %v", err)
+			}
+			failure.Log = Tail(log, MaxLogLength)
+		}
+		failures = append(failures, failure)
+	}
+
+	sort.Slice(failures, func(a, b int) bool {
+		return failures[a].Machine < failures[b].Machine
+	})
+
+	return failures, nil
+}
+
+// machineFailure returns the failure of a machine, if any. The terminal failures reported by CAPI
+// take precedence over the conditions, and the node join timeout is only checked for machines
+// whose infrastructure and bootstrap data are ready.
+func (d *Diagnoser) machineFailure(machine *clusterv1.Machine) (Failure, bool) {
+	if !machine.DeletionTimestamp.IsZero() || machine.Status.NodeRef != nil {
+		return Failure{}, false
+	}
+
+	if machine.Status.FailureReason != nil || machine.Status.FailureMessage != nil {
+		reason := InfrastructureFailedReason
+		if machine.Status.FailureReason != nil {
+			reason = string(*machine.Status.FailureReason)
+		}
+		return d.failure(machine, reason, stringValue(machine.Status.FailureMessage), lastUpdated(machine)), true
+	}
+
+	if c := conditions.Get(machine, clusterv1.BootstrapReadyCondition); isError(c) {
+		return d.failure(machine, BootstrapFailedReason, conditionMessage(c), c.LastTransitionTime.Time), true
+	}
+	if c := conditions.Get(machine, clusterv1.InfrastructureReadyCondition); isError(c) {
+		return d.failure(machine, InfrastructureFailedReason, conditionMessage(c), c.LastTransitionTime.Time), true
+	}
+
+	provisioned := conditions.Get(machine, clusterv1.InfrastructureReadyCondition)
+	if machine.Status.BootstrapReady && provisioned != nil && provisioned.Status == corev1.ConditionTrue {
+		if since := provisioned.LastTransitionTime.Time; d.now().Sub(since) > d.nodeJoinTimeout {
+			message := fmt.Sprintf("node didn't join the cluster %s after the machine was provisioned", d.nodeJoinTimeout)
+			return d.failure(machine, NodeJoinTimeoutReason, message, since), true
+		}
+	}
+
+	return Failure{}, false
+}
+
+func (d *Diagnoser) failure(machine *clusterv1.Machine, reason, message string, since time.Time) Failure {
+	return Failure{
+		Machine: machine.Name,
+		Reason:  reason,
+		Message: Truncate(message, MaxMessageLength),
+		Since:   since,
+	}
+}
+
+func isError(c *clusterv1.Condition) bool {
+	return c != nil && c.Status == corev1.ConditionFalse && c.Severity == clusterv1.ConditionSeverityError
+}
+
+func conditionMessage(c *clusterv1.Condition) string {
+	if c.Message == "" {
+		return c.Reason
+	}
+	return fmt.Sprintf("%s: %s", c.Reason, c.Message)
+}
+
+func lastUpdated(machine *clusterv1.Machine) time.Time {
+	if machine.Status.LastUpdated != nil {
+		return machine.Status.LastUpdated.Time
+	}
+	return machine.CreationTimestamp.Time
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// Truncate returns the first max characters of s, ending with "..." if it's truncated.
+func Truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max-3] + "..."
+}
+
+// Tail returns the last lines of log that fit in max characters, starting with "..." if it's truncated.
+// The last line is truncated if it doesn't fit by itself.
+func Tail(log string, max int) string {
+	log = strings.TrimRight(log, "\n")
+	if len(log) <= max {
+		return log
+	}
+
+	tail := log[len(log)-max+4:]
+	if i := strings.Index(tail, "\n"); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	}
+	return "...\n" + tail
+}
+
+// Statuses converts failures to the Cluster status, keeping the time a failure was first observed
+// if it was already reported with the same reason.
+func Statuses(failures []Failure, previous []anywherev1.MachineFailureStatus) []anywherev1.MachineFailureStatus {
+	if len(failures) == 0 {
+		return nil
+	}
+
+	since := make(map[string]anywherev1.MachineFailureStatus, len(previous))
+	for _, p := range previous {
+		since[p.Machine] = p
+	}
+
+	statuses := make([]anywherev1.MachineFailureStatus, 0, len(failures))
+	for _, f := range failures {
+		status := anywherev1.MachineFailureStatus{
+			Machine: f.Machine,
+			Reason:  f.Reason,
+			Message: f.Message,
+			Log:     f.Log,
+			Since:   metav1.NewTime(f.Since.UTC().Truncate(time.Second)),
+		}
+		if p, ok := since[f.Machine]; ok && p.Reason == f.Reason {
+			status.Since = p.Since
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
@@ -0,0 +1,197 @@
+package machinediagnostics_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/machinediagnostics"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+var now = time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+
+type logReader struct {
+	log string
+	err error
+}
+
+func (r logReader) BootstrapLog(_ context.Context, _ *clusterv1.Machine) (string, error) {
+	return r.log, r.err
+}
+
+func machine(name string, opts ...func(*clusterv1.Machine)) *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1.ClusterLabelName: "workload"},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "workload",
+			InfrastructureRef: corev1.ObjectReference{
+				Kind: "VSphereMachine",
+				Name: name,
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+func withCondition(condition clusterv1.ConditionType, status corev1.ConditionStatus, severity clusterv1.ConditionSeverity, reason, message string, since time.Time) func(*clusterv1.Machine) {
+	return func(m *clusterv1.Machine) {
+		m.Status.Conditions = append(m.Status.Conditions, clusterv1.Condition{
+			Type:               condition,
+			Status:             status,
+			Severity:           severity,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: metav1.NewTime(since),
+		})
+	}
+}
+
+func newDiagnoser(g *WithT, objs ...runtime.Object) *machinediagnostics.Diagnoser {
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+
+	return machinediagnostics.NewDiagnoser(c,
+		machinediagnostics.WithNow(func() time.Time { return now }),
+		machinediagnostics.WithNodeJoinTimeout(10*time.Minute),
+		machinediagnostics.WithLogReader("VSphereMachine", logReader{log: "cloud-init started\n[ERROR] kubeadm join failed\n"}),
+	)
+}
+
+func TestDiagnoserDiagnose(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload"}}
+	failureReason := capierrors.CreateMachineError
+	diagnoser := newDiagnoser(g,
+		machine("workload-md-0-healthy", func(m *clusterv1.Machine) {
+			m.Status.NodeRef = &corev1.ObjectReference{Name: "node"}
+		}),
+		machine("workload-md-0-provisioning",
+			withCondition(clusterv1.InfrastructureReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, "WaitingForIP", "", now.Add(-time.Hour)),
+		),
+		machine("workload-md-0-failed", func(m *clusterv1.Machine) {
+			m.Status.FailureReason = &failureReason
+			m.Status.FailureMessage = ptr.String("failed to clone template")
+			m.Status.LastUpdated = &metav1.Time{Time: now.Add(-time.Minute)}
+		}),
+		machine("workload-cp-bootstrap",
+			withCondition(clusterv1.BootstrapReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityError, "DataSecretGenerationFailed", "invalid join config", now.Add(-2*time.Minute)),
+		),
+		machine("workload-cp-not-joined", func(m *clusterv1.Machine) {
+			m.Status.BootstrapReady = true
+		}, withCondition(clusterv1.InfrastructureReadyCondition, corev1.ConditionTrue, "", "", "", now.Add(-11*time.Minute))),
+		machine("workload-cp-joining", func(m *clusterv1.Machine) {
+			m.Status.BootstrapReady = true
+		}, withCondition(clusterv1.InfrastructureReadyCondition, corev1.ConditionTrue, "", "", "", now.Add(-5*time.Minute))),
+		machine("other-cluster", func(m *clusterv1.Machine) {
+			m.Labels[clusterv1.ClusterLabelName] = "other"
+			m.Status.FailureMessage = ptr.String("failed")
+		}),
+	)
+
+	failures, err := diagnoser.Diagnose(context.Background(), cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	log := "cloud-init started\n[ERROR] kubeadm join failed"
+	g.Expect(failures).To(Equal([]machinediagnostics.Failure{
+		{
+			Machine: "workload-cp-bootstrap",
+			Reason:  machinediagnostics.BootstrapFailedReason,
+			Message: "DataSecretGenerationFailed: invalid join config",
+			Log:     log,
+			Since:   now.Add(-2 * time.Minute).Local(),
+		},
+		{
+			Machine: "workload-cp-not-joined",
+			Reason:  machinediagnostics.NodeJoinTimeoutReason,
+			Message: "node didn't join the cluster 10m0s after the machine was provisioned",
+			Log:     log,
+			Since:   now.Add(-11 * time.Minute).Local(),
+		},
+		{
+			Machine: "workload-md-0-failed",
+			Reason:  "CreateError",
+			Message: "failed to clone template",
+			Log:     log,
+			Since:   now.Add(-time.Minute).Local(),
+		},
+	}))
+}
+
+func TestDiagnoserDiagnoseLogReaderError(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(machine("workload-md-0", func(m *clusterv1.Machine) {
+		m.Status.FailureMessage = ptr.String("failed to power on")
+	})).Build()
+	diagnoser := machinediagnostics.NewDiagnoser(c,
+		machinediagnostics.WithLogReader("VSphereMachine", logReader{err: errors.New("connection refused")}),
+	)
+
+	failures, err := diagnoser.Diagnose(context.Background(), &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(failures).To(HaveLen(1))
+	g.Expect(failures[0].Reason).To(Equal(machinediagnostics.InfrastructureFailedReason))
+	g.Expect(failures[0].Log).To(Equal("reading bootstrap log: connection refused"))
+}
+
+func TestDiagnoserDiagnoseListError(t *testing.T) {
+	g := NewWithT(t)
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	diagnoser := machinediagnostics.NewDiagnoser(c)
+
+	_, err := diagnoser.Diagnose(context.Background(), &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload"}})
+	g.Expect(err).To(MatchError(ContainSubstring("listing machines for cluster workload")))
+}
+
+func TestTruncate(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(machinediagnostics.Truncate("short", 10)).To(Equal("short"))
+	g.Expect(machinediagnostics.Truncate("a long message", 10)).To(Equal("a long ..."))
+}
+
+func TestTail(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(machinediagnostics.Tail("line 1\nline 2\n", 20)).To(Equal("line 1\nline 2"))
+	g.Expect(machinediagnostics.Tail("line 1\nline 2\nline 3\n", 16)).To(Equal("...\nline 3"))
+	g.Expect(machinediagnostics.Tail(strings.Repeat("a", 20), 10)).To(Equal("...\naaaaaa"))
+}
+
+func TestStatuses(t *testing.T) {
+	g := NewWithT(t)
+	before := metav1.NewTime(now.Add(-time.Hour))
+	previous := []anywherev1.MachineFailureStatus{
+		{Machine: "md-0", Reason: machinediagnostics.NodeJoinTimeoutReason, Since: before},
+		{Machine: "md-1", Reason: machinediagnostics.BootstrapFailedReason, Since: before},
+	}
+	failures := []machinediagnostics.Failure{
+		{Machine: "md-0", Reason: machinediagnostics.NodeJoinTimeoutReason, Message: "still not joined", Since: now.Add(500 * time.Millisecond)},
+		{Machine: "md-1", Reason: "CreateError", Message: "failed", Since: now},
+	}
+
+	g.Expect(machinediagnostics.Statuses(nil, previous)).To(BeNil())
+	g.Expect(machinediagnostics.Statuses(failures, previous)).To(Equal([]anywherev1.MachineFailureStatus{
+		{Machine: "md-0", Reason: machinediagnostics.NodeJoinTimeoutReason, Message: "still not joined", Since: before},
+		{Machine: "md-1", Reason: "CreateError", Message: "failed", Since: metav1.NewTime(now)},
+	}))
+}
@@ -0,0 +1,57 @@
+package machinediagnostics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TinkerbellMachineKind is the infrastructure kind of the Tinkerbell machines.
+const TinkerbellMachineKind = "TinkerbellMachine"
+
+// TinkerbellWorkflowReader reads the bootstrap logs of Tinkerbell machines from the actions of the
+// workflow that provisions them, which CAPT names after the TinkerbellMachine.
+type TinkerbellWorkflowReader struct {
+	client client.Client
+}
+
+// NewTinkerbellWorkflowReader constructs a new TinkerbellWorkflowReader.
+func NewTinkerbellWorkflowReader(client client.Client) *TinkerbellWorkflowReader {
+	return &TinkerbellWorkflowReader{client: client}
+}
+
+// BootstrapLog returns the state of the workflow actions that didn't succeed, one per line, ending
+// with the one that failed or timed out.
+func (r *TinkerbellWorkflowReader) BootstrapLog(ctx context.Context, machine *clusterv1.Machine) (string, error) {
+	ref := machine.Spec.InfrastructureRef
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = machine.Namespace
+	}
+
+	workflow := &tinkv1alpha1.Workflow{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, workflow); err != nil {
+		return "", fmt.Errorf("getting workflow %s: %v", ref.Name, err)
+	}
+
+	lines := []string{fmt.Sprintf("workflow %s: %s", workflow.Name, workflow.Status.State)}
+	for _, task := range workflow.Status.Tasks {
+		for _, action := range task.Actions {
+			if action.Status == "" || action.Status == tinkv1alpha1.WorkflowStateSuccess {
+				continue
+			}
+			line := fmt.Sprintf("task %s, action %s: %s", task.Name, action.Name, action.Status)
+			if action.Message != "" {
+				line += ": " + action.Message
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
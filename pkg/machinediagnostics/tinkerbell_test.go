@@ -0,0 +1,55 @@
+package machinediagnostics_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/machinediagnostics"
+)
+
+func TestTinkerbellWorkflowReaderBootstrapLog(t *testing.T) {
+	g := NewWithT(t)
+	workflow := &tinkv1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0-abcde", Namespace: constants.EksaSystemNamespace},
+		Status: tinkv1alpha1.WorkflowStatus{
+			State: tinkv1alpha1.WorkflowStateFailed,
+			Tasks: []tinkv1alpha1.Task{
+				{
+					Name: "os-installation",
+					Actions: []tinkv1alpha1.Action{
+						{Name: "stream-image", Status: tinkv1alpha1.WorkflowStateSuccess},
+						{Name: "write-netplan", Status: tinkv1alpha1.WorkflowStateFailed, Message: "mount /dev/sda2: no such device"},
+						{Name: "reboot-image"},
+					},
+				},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	g.Expect(tinkv1alpha1.AddToScheme(scheme)).To(Succeed())
+	reader := machinediagnostics.NewTinkerbellWorkflowReader(fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(workflow).Build())
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0-abcde", Namespace: constants.EksaSystemNamespace},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{Kind: machinediagnostics.TinkerbellMachineKind, Name: "workload-md-0-abcde"},
+		},
+	}
+
+	log, err := reader.BootstrapLog(context.Background(), machine)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(log).To(Equal(`workflow workload-md-0-abcde: STATE_FAILED
+task os-installation, action write-netplan: STATE_FAILED: mount /dev/sda2: no such device`))
+
+	machine.Spec.InfrastructureRef.Name = "missing"
+	_, err = reader.BootstrapLog(context.Background(), machine)
+	g.Expect(err).To(MatchError(ContainSubstring("getting workflow missing")))
+}
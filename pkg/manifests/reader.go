@@ -3,9 +3,11 @@ package manifests
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/manifests/releases"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -40,8 +42,13 @@ func NewReader(filereader FileReader, opts ...ReaderOpt) *Reader {
 	return r
 }
 
+// ReadReleases reads the EKS-A releases manifest.
+func (r *Reader) ReadReleases() (*releasev1.Release, error) {
+	return releases.ReadReleasesFromURL(r, r.releasesManifestURL)
+}
+
 func (r *Reader) ReadBundlesForVersion(version string) (*releasev1.Bundles, error) {
-	rls, err := releases.ReadReleasesFromURL(r, r.releasesManifestURL)
+	rls, err := r.ReadReleases()
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +78,35 @@ func (r *Reader) ReadEKSD(eksaVersion, kubeVersion string) (*eksdv1.Release, err
 	return bundles.ReadEKSD(r, *versionsBundle)
 }
 
+// ReadEksDReleaseBundle returns the EKS-D bundle entry with the given name for kubeVersion, taken from the
+// Bundles of the newest EKS-A release that shipped it. Releases whose Bundles can't be read are skipped.
+func (r *Reader) ReadEksDReleaseBundle(kubeVersion, name string) (*releasev1.EksDRelease, error) {
+	rls, err := r.ReadReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	eksaReleases := append([]releasev1.EksARelease(nil), rls.Spec.Releases...)
+	sort.Slice(eksaReleases, func(i, j int) bool {
+		return eksaReleases[i].Number > eksaReleases[j].Number
+	})
+
+	for i := range eksaReleases {
+		b, err := releases.ReadBundlesForRelease(r, &eksaReleases[i])
+		if err != nil {
+			logger.V(4).Info("Skipping unreadable eks-a release bundles", "release", eksaReleases[i].Version, "error", err.Error())
+			continue
+		}
+
+		versionsBundle := bundles.VersionsBundleForKubernetesVersion(b, kubeVersion)
+		if versionsBundle != nil && versionsBundle.EksD.Name == name {
+			return &versionsBundle.EksD, nil
+		}
+	}
+
+	return nil, fmt.Errorf("eks-d release %s is not shipped by any eks-a release", name)
+}
+
 func (r *Reader) ReadImages(eksaVersion string) ([]releasev1.Image, error) {
 	bundle, err := r.ReadBundlesForVersion(eksaVersion)
 	if err != nil {
@@ -1,6 +1,7 @@
 package manifests_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -146,6 +147,96 @@ spec:
 	g.Expect(err).To(MatchError(ContainSubstring("kubernetes version 1.22 is not supported by bundles manifest 0")))
 }
 
+func TestReaderReadEksDReleaseBundle(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockReader(ctrl)
+
+	releasesManifest := `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Release
+metadata:
+  name: release-1
+spec:
+  releases:
+    - bundleManifestUrl: "https://bundles/bundles-1.yaml"
+      number: 1
+      version: v0.0.1
+    - bundleManifestUrl: "https://bundles/bundles-2.yaml"
+      number: 2
+      version: v0.0.2
+    - bundleManifestUrl: "https://bundles/bundles-3.yaml"
+      number: 3
+      version: v0.0.3`
+	reader.EXPECT().ReadFile(releases.ManifestURL()).Return([]byte(releasesManifest), nil)
+
+	reader.EXPECT().ReadFile("https://bundles/bundles-3.yaml").Return(nil, errors.New("not found"))
+
+	reader.EXPECT().ReadFile("https://bundles/bundles-2.yaml").Return([]byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Bundles
+metadata:
+  name: bundles-2
+spec:
+  versionsBundles:
+  - kubeVersion: "1.23"
+    eksD:
+      name: kubernetes-1-23-eks-6
+      kubeVersion: v1.23.9`), nil)
+	reader.EXPECT().ReadFile("https://bundles/bundles-1.yaml").Return([]byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Bundles
+metadata:
+  name: bundles-1
+spec:
+  versionsBundles:
+  - kubeVersion: "1.23"
+    eksD:
+      name: kubernetes-1-23-eks-4
+      kubeVersion: v1.23.7
+      manifestUrl: https://distro.eks.amazonaws.com/kubernetes-1-23/kubernetes-1-23-eks-4.yaml
+      ova:
+        bottlerocket:
+          uri: https://assets/bottlerocket-v1.23.7-eks-d-1-23-4-amd64.ova`), nil)
+
+	r := manifests.NewReader(reader)
+	g.Expect(r.ReadEksDReleaseBundle("1.23", "kubernetes-1-23-eks-4")).To(Equal(&releasev1.EksDRelease{
+		Name:           "kubernetes-1-23-eks-4",
+		KubeVersion:    "v1.23.7",
+		EksDReleaseUrl: "https://distro.eks.amazonaws.com/kubernetes-1-23/kubernetes-1-23-eks-4.yaml",
+		Ova: releasev1.OSImageBundle{
+			Bottlerocket: releasev1.Archive{URI: "https://assets/bottlerocket-v1.23.7-eks-d-1-23-4-amd64.ova"},
+		},
+	}))
+}
+
+func TestReaderReadEksDReleaseBundleNotShipped(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockReader(ctrl)
+
+	releasesManifest := `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Release
+metadata:
+  name: release-1
+spec:
+  releases:
+    - bundleManifestUrl: "https://bundles/bundles.yaml"
+      number: 1
+      version: v0.0.1`
+	reader.EXPECT().ReadFile(releases.ManifestURL()).Return([]byte(releasesManifest), nil)
+	reader.EXPECT().ReadFile("https://bundles/bundles.yaml").Return([]byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Bundles
+metadata:
+  name: bundles-1
+spec:
+  versionsBundles:
+  - kubeVersion: "1.23"
+    eksD:
+      name: kubernetes-1-23-eks-6`), nil)
+
+	r := manifests.NewReader(reader)
+	_, err := r.ReadEksDReleaseBundle("1.23", "kubernetes-1-23-eks-4")
+	g.Expect(err).To(MatchError("eks-d release kubernetes-1-23-eks-4 is not shipped by any eks-a release"))
+}
+
 func TestReaderReadImages(t *testing.T) {
 	g := NewWithT(t)
 	ctrl := gomock.NewController(t)
@@ -212,3 +303,25 @@ metadata:
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(charts).To(BeEmpty())
 }
+
+func TestReaderReadReleases(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockReader(ctrl)
+
+	releasesManifest := `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Release
+metadata:
+  name: release-1
+spec:
+  releases:
+    - bundleManifestUrl: "https://bundles/bundles.yaml"
+      version: v0.0.1`
+	reader.EXPECT().ReadFile("https://releases/eks-a-release.yaml").Return([]byte(releasesManifest), nil)
+
+	r := manifests.NewReader(reader, manifests.WithReleasesManifest("https://releases/eks-a-release.yaml"))
+	release, err := r.ReadReleases()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(release.Spec.Releases).To(HaveLen(1))
+	g.Expect(release.Spec.Releases[0].Version).To(Equal("v0.0.1"))
+}
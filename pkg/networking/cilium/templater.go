@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -118,6 +119,10 @@ func (t *Templater) GenerateManifest(ctx context.Context, spec *cluster.Spec, op
 		o(c)
 	}
 
+	if err := applyValuesOverrides(c.values, spec); err != nil {
+		return nil, err
+	}
+
 	uri, version := getChartUriAndVersion(spec)
 	var manifest []byte
 
@@ -174,12 +179,16 @@ type values map[string]interface{}
 func (c values) set(value interface{}, path ...string) {
 	element := c
 	for _, p := range path[:len(path)-1] {
-		e, ok := element[p]
-		if !ok {
-			e = values{}
-			element[p] = e
+		switch e := element[p].(type) {
+		case values:
+			element = e
+		case map[string]interface{}:
+			element = values(e)
+		default:
+			v := values{}
+			element[p] = v
+			element = v
 		}
-		element = e.(values)
 	}
 	element[path[len(path)-1]] = value
 }
@@ -219,12 +228,64 @@ func templateValues(spec *cluster.Spec) values {
 		val["operator"].(values)["replicas"] = 1
 	}
 
+	// The edge profile trades the metrics and the operator high availability for a smaller footprint.
+	if spec.Cluster.IsEdgeProfile() {
+		val["prometheus"] = values{"enabled": false}
+		val["operator"].(values)["prometheus"] = values{"enabled": false}
+		val["operator"].(values)["replicas"] = 1
+	}
+
 	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode != "" {
 		val["policyEnforcementMode"] = spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode
 	}
+
+	// Cilium doesn't run on Windows, keep the agent and operator on Linux nodes.
+	if len(spec.WindowsWorkerNodeGroups()) > 0 {
+		linux := values{"kubernetes.io/os": "linux"}
+		val["nodeSelector"] = linux
+		val["operator"].(values)["nodeSelector"] = linux
+	}
 	return val
 }
 
+// applyValuesOverrides sets the Cilium Helm values derived from the managed components
+// configuration and the ones overridden in the cluster spec, replacing the values computed by EKS-A.
+// Explicit Helm values overrides take precedence over the managed components configuration.
+func applyValuesOverrides(val values, spec *cluster.Spec) error {
+	componentValues, err := spec.Cluster.ManagedComponentHelmValues(anywherev1.CiliumChart)
+	if err != nil {
+		return fmt.Errorf("building cilium managed components values: %v", err)
+	}
+	for _, path := range sortedKeys(componentValues) {
+		val.set(componentValues[path], strings.Split(path, ".")...)
+	}
+
+	override, ok := spec.Cluster.HelmValuesOverride(anywherev1.CiliumChart)
+	if !ok {
+		return nil
+	}
+
+	parsed, err := override.ParsedValues()
+	if err != nil {
+		return fmt.Errorf("parsing cilium helm values overrides: %v", err)
+	}
+
+	for _, path := range override.SortedPaths() {
+		val.set(parsed[path], strings.Split(path, ".")...)
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func getChartUriAndVersion(spec *cluster.Spec) (uri, version string) {
 	chart := spec.VersionsBundle.Cilium.HelmChart
 	uri = fmt.Sprintf("oci://%s", chart.Image())
@@ -0,0 +1,182 @@
+package nodessh
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// Node is a cluster node that can be reached through ssh.
+type Node struct {
+	Machine  string
+	Address  string
+	User     string
+	OSFamily anywherev1.OSFamily
+}
+
+// SSHArgs returns the arguments for the ssh client to log into the node with privateKey. It opens an
+// interactive session when command is empty. Bottlerocket nodes are logged into through the admin
+// container, so the session and command are run in the host with sheltie.
+func (n *Node) SSHArgs(privateKey string, command []string) []string {
+	args := []string{
+		"-i", privateKey,
+		// Node addresses are reused when machines are replaced, so their host keys are not checked.
+		"-o", "StrictHostKeyChecking=no",
+		"-o", "UserKnownHostsFile=/dev/null",
+	}
+
+	if len(command) == 0 {
+		args = append(args, "-t", n.User+"@"+n.Address)
+		if n.OSFamily == anywherev1.Bottlerocket {
+			args = append(args, "sudo", "sheltie")
+		}
+		return args
+	}
+
+	args = append(args, n.User+"@"+n.Address)
+	if n.OSFamily == anywherev1.Bottlerocket {
+		// The same sheltie does, entering all the namespaces of the host init process.
+		args = append(args, "sudo", "nsenter", "-t", "1", "-a")
+	}
+	return append(args, command...)
+}
+
+// Run opens an ssh session to the node, running command if not empty, connected to the standard
+// input and outputs of the process.
+func (n *Node) Run(ctx context.Context, privateKey string, command []string) error {
+	cmd := exec.CommandContext(ctx, "ssh", n.SSHArgs(privateKey, command)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running ssh to machine %s: %v", n.Machine, err)
+	}
+
+	return nil
+}
+
+// Resolver finds the nodes of the CAPI machines of a cluster, reading the machines from its
+// management cluster.
+type Resolver struct {
+	client client.Client
+}
+
+// NewResolver constructs a new Resolver.
+func NewResolver(client client.Client) *Resolver {
+	return &Resolver{
+		client: client,
+	}
+}
+
+// Resolve returns the node of the machine machineName of the cluster in config. The login user and
+// OS are read from the machine config of the control plane, etcd or worker node group the machine
+// belongs to.
+func (r *Resolver) Resolve(ctx context.Context, config *cluster.Config, machineName string) (*Node, error) {
+	machine := &clusterv1.Machine{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: machineName, Namespace: constants.EksaSystemNamespace}, machine); err != nil {
+		return nil, fmt.Errorf("getting machine %s: %v", machineName, err)
+	}
+
+	if machine.Labels[clusterv1.ClusterLabelName] != config.Cluster.Name {
+		return nil, fmt.Errorf("machine %s doesn't belong to cluster %s", machineName, config.Cluster.Name)
+	}
+
+	address := machineAddress(machine)
+	if address == "" {
+		return nil, fmt.Errorf("machine %s doesn't have an address yet", machineName)
+	}
+
+	machineRef := machineGroupRef(config, machine)
+	if machineRef == nil {
+		return nil, fmt.Errorf("machine config for machine %s not found in cluster %s", machineName, config.Cluster.Name)
+	}
+
+	user, osFamily, err := machineConfigLogin(config, machineRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Machine:  machineName,
+		Address:  address,
+		User:     user,
+		OSFamily: osFamily,
+	}, nil
+}
+
+// machineGroupRef returns the ref to the machine config used to create machine.
+func machineGroupRef(config *cluster.Config, machine *clusterv1.Machine) *anywherev1.Ref {
+	if _, ok := machine.Labels[clusterv1.MachineControlPlaneLabelName]; ok {
+		return config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef
+	}
+
+	if _, ok := machine.Labels[clusterv1.MachineEtcdClusterLabelName]; ok {
+		if config.Cluster.Spec.ExternalEtcdConfiguration == nil {
+			return nil
+		}
+		return config.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef
+	}
+
+	spec := &cluster.Spec{Config: config}
+	for _, w := range config.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if machine.Labels[clusterv1.MachineDeploymentLabelName] == clusterapi.MachineDeploymentName(spec, w) {
+			return w.MachineGroupRef
+		}
+	}
+
+	return nil
+}
+
+// machineConfigLogin returns the login user and OS family of the machine config machineRef.
+func machineConfigLogin(config *cluster.Config, machineRef *anywherev1.Ref) (string, anywherev1.OSFamily, error) {
+	var users []anywherev1.UserConfiguration
+	var osFamily anywherev1.OSFamily
+	switch machineRef.Kind {
+	case anywherev1.VSphereMachineConfigKind:
+		if m := config.VsphereMachineConfig(machineRef.Name); m != nil {
+			users, osFamily = m.Spec.Users, m.Spec.OSFamily
+		}
+	case anywherev1.CloudStackMachineConfigKind:
+		if m := config.CloudStackMachineConfig(machineRef.Name); m != nil {
+			users = m.Spec.Users
+		}
+	case anywherev1.NutanixMachineConfigKind:
+		if m := config.NutanixMachineConfig(machineRef.Name); m != nil {
+			users, osFamily = m.Spec.Users, m.Spec.OSFamily
+		}
+	case anywherev1.TinkerbellMachineConfigKind:
+		if m := config.TinkerbellMachineConfig(machineRef.Name); m != nil {
+			users, osFamily = m.Spec.Users, m.Spec.OSFamily
+		}
+	default:
+		return "", "", fmt.Errorf("ssh is not supported for %s machines", strings.TrimSuffix(machineRef.Kind, "MachineConfig"))
+	}
+
+	if len(users) == 0 {
+		return "", "", fmt.Errorf("%s %s doesn't have any user", machineRef.Kind, machineRef.Name)
+	}
+
+	return users[0].Name, osFamily, nil
+}
+
+// machineAddress returns the first internal address of m, falling back to its first external address.
+func machineAddress(m *clusterv1.Machine) string {
+	for _, t := range []clusterv1.MachineAddressType{clusterv1.MachineInternalIP, clusterv1.MachineExternalIP} {
+		for _, a := range m.Status.Addresses {
+			if a.Type == t {
+				return a.Address
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,216 @@
+package nodessh_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/nodessh"
+)
+
+func machine(name string, labels map[string]string, address string) *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1.ClusterLabelName: "workload"},
+		},
+	}
+	for k, v := range labels {
+		m.Labels[k] = v
+	}
+	if address != "" {
+		m.Status.Addresses = clusterv1.MachineAddresses{
+			{Type: clusterv1.MachineExternalIP, Address: "192.168.0.1"},
+			{Type: clusterv1.MachineInternalIP, Address: address},
+		}
+	}
+	return m
+}
+
+func newClient(g *WithT, objs ...runtime.Object) client.Client {
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	return fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+}
+
+func vsphereMachineConfig(name string, osFamily anywherev1.OSFamily) *anywherev1.VSphereMachineConfig {
+	return &anywherev1.VSphereMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: anywherev1.VSphereMachineConfigSpec{
+			OSFamily: osFamily,
+			Users:    []anywherev1.UserConfiguration{{Name: string(osFamily) + "-user"}},
+		},
+	}
+}
+
+func clusterConfig() *cluster.Config {
+	return &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload"},
+			Spec: anywherev1.ClusterSpec{
+				ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+					MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "cp"},
+				},
+				ExternalEtcdConfiguration: &anywherev1.ExternalEtcdConfiguration{
+					MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "etcd"},
+				},
+				WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+					{
+						Name:            "md-0",
+						MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "worker"},
+					},
+				},
+			},
+		},
+		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{
+			"cp":     vsphereMachineConfig("cp", anywherev1.Ubuntu),
+			"etcd":   vsphereMachineConfig("etcd", anywherev1.Bottlerocket),
+			"worker": vsphereMachineConfig("worker", anywherev1.Bottlerocket),
+		},
+	}
+}
+
+func TestResolverResolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		machine *clusterv1.Machine
+		want    *nodessh.Node
+	}{
+		{
+			name:    "control plane",
+			machine: machine("workload-cp-1", map[string]string{clusterv1.MachineControlPlaneLabelName: ""}, "10.0.0.1"),
+			want:    &nodessh.Node{Machine: "workload-cp-1", Address: "10.0.0.1", User: "ubuntu-user", OSFamily: anywherev1.Ubuntu},
+		},
+		{
+			name:    "etcd",
+			machine: machine("workload-etcd-1", map[string]string{clusterv1.MachineEtcdClusterLabelName: "workload-etcd"}, "10.0.0.10"),
+			want:    &nodessh.Node{Machine: "workload-etcd-1", Address: "10.0.0.10", User: "bottlerocket-user", OSFamily: anywherev1.Bottlerocket},
+		},
+		{
+			name:    "worker",
+			machine: machine("workload-md-0-1", map[string]string{clusterv1.MachineDeploymentLabelName: "workload-md-0"}, "10.0.0.20"),
+			want:    &nodessh.Node{Machine: "workload-md-0-1", Address: "10.0.0.20", User: "bottlerocket-user", OSFamily: anywherev1.Bottlerocket},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := nodessh.NewResolver(newClient(g, tt.machine))
+
+			g.Expect(r.Resolve(context.Background(), clusterConfig(), tt.machine.Name)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestResolverResolveErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		machine *clusterv1.Machine
+		config  func(*cluster.Config)
+		wantErr string
+	}{
+		{
+			name:    "machine not found",
+			machine: machine("workload-cp-2", nil, "10.0.0.1"),
+			wantErr: "getting machine workload-cp-1",
+		},
+		{
+			name: "machine of another cluster",
+			machine: machine("workload-cp-1", map[string]string{
+				clusterv1.ClusterLabelName:             "other",
+				clusterv1.MachineControlPlaneLabelName: "",
+			}, "10.0.0.1"),
+			wantErr: "machine workload-cp-1 doesn't belong to cluster workload",
+		},
+		{
+			name:    "no address",
+			machine: machine("workload-cp-1", map[string]string{clusterv1.MachineControlPlaneLabelName: ""}, ""),
+			wantErr: "machine workload-cp-1 doesn't have an address yet",
+		},
+		{
+			name:    "unknown node group",
+			machine: machine("workload-cp-1", map[string]string{clusterv1.MachineDeploymentLabelName: "workload-md-1"}, "10.0.0.1"),
+			wantErr: "machine config for machine workload-cp-1 not found in cluster workload",
+		},
+		{
+			name:    "no users",
+			machine: machine("workload-cp-1", map[string]string{clusterv1.MachineControlPlaneLabelName: ""}, "10.0.0.1"),
+			config: func(c *cluster.Config) {
+				c.VSphereMachineConfigs["cp"].Spec.Users = nil
+			},
+			wantErr: "VSphereMachineConfig cp doesn't have any user",
+		},
+		{
+			name:    "unsupported provider",
+			machine: machine("workload-cp-1", map[string]string{clusterv1.MachineControlPlaneLabelName: ""}, "10.0.0.1"),
+			config: func(c *cluster.Config) {
+				c.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Kind = anywherev1.SnowMachineConfigKind
+			},
+			wantErr: "ssh is not supported for Snow machines",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := clusterConfig()
+			if tt.config != nil {
+				tt.config(config)
+			}
+			r := nodessh.NewResolver(newClient(g, tt.machine))
+
+			_, err := r.Resolve(context.Background(), config, "workload-cp-1")
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestNodeSSHArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		osFamily anywherev1.OSFamily
+		command  []string
+		want     []string
+	}{
+		{
+			name:     "ubuntu session",
+			osFamily: anywherev1.Ubuntu,
+			want:     []string{"-t", "capv@10.0.0.1"},
+		},
+		{
+			name:     "ubuntu command",
+			osFamily: anywherev1.Ubuntu,
+			command:  []string{"journalctl", "-u", "kubelet"},
+			want:     []string{"capv@10.0.0.1", "journalctl", "-u", "kubelet"},
+		},
+		{
+			name:     "bottlerocket session",
+			osFamily: anywherev1.Bottlerocket,
+			want:     []string{"-t", "capv@10.0.0.1", "sudo", "sheltie"},
+		},
+		{
+			name:     "bottlerocket command",
+			osFamily: anywherev1.Bottlerocket,
+			command:  []string{"journalctl", "-u", "kubelet"},
+			want:     []string{"capv@10.0.0.1", "sudo", "nsenter", "-t", "1", "-a", "journalctl", "-u", "kubelet"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			n := &nodessh.Node{Machine: "workload-cp-1", Address: "10.0.0.1", User: "capv", OSFamily: tt.osFamily}
+
+			want := append([]string{"-i", "key", "-o", "StrictHostKeyChecking=no", "-o", "UserKnownHostsFile=/dev/null"}, tt.want...)
+			g.Expect(n.SSHArgs("key", tt.command)).To(Equal(want))
+		})
+	}
+}
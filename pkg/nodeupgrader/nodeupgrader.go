@@ -0,0 +1,144 @@
+// Package nodeupgrader builds the pods that upgrade the Kubernetes components of a node in place,
+// without replacing its machine, and decides which nodes of a cluster upgrade in place. The pods
+// copy the components in the upgrader image to the host and run the upgrade script in the host
+// namespaces.
+package nodeupgrader
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	nodeLabel = "anywhere.eks.amazonaws.com/node-upgrader"
+
+	upgradeScript = "/usr/local/eksa-upgrades/scripts/upgrade.sh"
+
+	hostComponentsDir  = "/usr/local"
+	componentsMountDir = "/usr/host"
+	componentsDir      = "/eksa-upgrades"
+
+	// Steps of the upgrade script.
+	kubeadmInFirstControlPlane = "kubeadm_in_first_cp"
+	kubeadmInRestControlPlane  = "kubeadm_in_rest_cp"
+	kubeadmInWorker            = "kubeadm_in_worker"
+	kubeletAndKubectl          = "kubelet_and_kubectl"
+)
+
+// PodName returns the name of the upgrader pod for a node.
+func PodName(nodeName string) string {
+	return fmt.Sprintf("%s-node-upgrader", nodeName)
+}
+
+// UpgradeFirstControlPlanePod returns the pod that upgrades the first control plane node to
+// kubernetesVersion. It runs kubeadm upgrade apply, which upgrades the cluster components,
+// so it needs to complete before upgrading the rest of the nodes.
+func UpgradeFirstControlPlanePod(nodeName, image, kubernetesVersion string) *corev1.Pod {
+	return upgraderPod(nodeName, image, kubeadmInFirstControlPlane, kubernetesVersion)
+}
+
+// UpgradeRestControlPlanePod returns the pod that upgrades a control plane node other than the first one.
+func UpgradeRestControlPlanePod(nodeName, image string) *corev1.Pod {
+	return upgraderPod(nodeName, image, kubeadmInRestControlPlane)
+}
+
+// UpgradeWorkerPod returns the pod that upgrades a worker node.
+func UpgradeWorkerPod(nodeName, image string) *corev1.Pod {
+	return upgraderPod(nodeName, image, kubeadmInWorker)
+}
+
+// IsPatchUpgrade returns true when newSpec changes the Kubernetes patch version of the cluster
+// without changing its minor version, the only upgrades that can be done in place.
+func IsPatchUpgrade(currentSpec, newSpec *cluster.Spec) bool {
+	return currentSpec.Cluster.Spec.KubernetesVersion == newSpec.Cluster.Spec.KubernetesVersion &&
+		currentSpec.VersionsBundle.KubeDistro.Kubernetes.Tag != newSpec.VersionsBundle.KubeDistro.Kubernetes.Tag
+}
+
+// ControlPlaneUpgradesInPlace returns true when the control plane nodes should be upgraded in place
+// from currentSpec to newSpec.
+func ControlPlaneUpgradesInPlace(currentSpec, newSpec *cluster.Spec) bool {
+	strategy := newSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy
+	return strategy != nil && strategy.Type == v1alpha1.InPlaceStrategyType && IsPatchUpgrade(currentSpec, newSpec)
+}
+
+// WorkerNodeGroupUpgradesInPlace returns true when the nodes of the worker node group should be
+// upgraded in place from currentSpec to newSpec. New worker node groups are always provisioned.
+func WorkerNodeGroupUpgradesInPlace(currentSpec, newSpec *cluster.Spec, group v1alpha1.WorkerNodeGroupConfiguration) bool {
+	if group.UpgradeRolloutStrategy == nil || group.UpgradeRolloutStrategy.Type != v1alpha1.InPlaceStrategyType {
+		return false
+	}
+
+	if _, ok := cluster.BuildMapForWorkerNodeGroupsByName(currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations)[group.Name]; !ok {
+		return false
+	}
+
+	return IsPatchUpgrade(currentSpec, newSpec)
+}
+
+func upgraderPod(nodeName, image string, kubeadmStep ...string) *corev1.Pod {
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	components := corev1.VolumeMount{Name: "host-components", MountPath: componentsMountDir}
+
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PodName(nodeName),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				nodeLabel: nodeName,
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:      nodeName,
+			HostPID:       true,
+			RestartPolicy: corev1.RestartPolicyNever,
+			// The upgrader has to run in every node, including the tainted control plane ones.
+			Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			Volumes: []corev1.Volume{{
+				Name: components.Name,
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{Path: hostComponentsDir, Type: &hostPathType},
+				},
+			}},
+			// Init containers run in order, so kubeadm upgrades the node before the kubelet is
+			// replaced and restarted by the main container.
+			InitContainers: []corev1.Container{
+				{
+					Name:         "components-copier",
+					Image:        image,
+					Command:      []string{"cp"},
+					Args:         []string{"-r", componentsDir, componentsMountDir},
+					VolumeMounts: []corev1.VolumeMount{components},
+				},
+				hostContainer("kubeadm-upgrader", image, kubeadmStep...),
+			},
+			Containers: []corev1.Container{
+				hostContainer("kubelet-kubectl-upgrader", image, kubeletAndKubectl),
+			},
+		},
+	}
+}
+
+// hostContainer returns a container that runs a step of the upgrade script in the host namespaces.
+func hostContainer(name, image string, step ...string) corev1.Container {
+	privileged := true
+	return corev1.Container{
+		Name:    name,
+		Image:   image,
+		Command: []string{"nsenter"},
+		Args: append(
+			[]string{"--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "--", upgradeScript},
+			step...,
+		),
+		SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+	}
+}
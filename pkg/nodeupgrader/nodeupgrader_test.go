@@ -0,0 +1,139 @@
+package nodeupgrader_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
+)
+
+const (
+	nodeName = "my-node"
+	image    = "public.ecr.aws/eks-anywhere/upgrader:v1-24-14-eks-a-1"
+)
+
+func TestUpgradeFirstControlPlanePod(t *testing.T) {
+	g := NewWithT(t)
+	pod := nodeupgrader.UpgradeFirstControlPlanePod(nodeName, image, "v1.24.14-eks-1-24-14")
+
+	g.Expect(pod.Name).To(Equal("my-node-node-upgrader"))
+	g.Expect(pod.Namespace).To(Equal(constants.EksaSystemNamespace))
+	g.Expect(pod.Spec.NodeName).To(Equal(nodeName))
+	g.Expect(pod.Spec.HostPID).To(BeTrue())
+	g.Expect(pod.Spec.InitContainers).To(HaveLen(2))
+	g.Expect(pod.Spec.InitContainers[0].Image).To(Equal(image))
+	g.Expect(pod.Spec.InitContainers[0].Args).To(Equal([]string{"-r", "/eksa-upgrades", "/usr/host"}))
+	g.Expect(pod.Spec.InitContainers[1].Args).To(Equal([]string{
+		"--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "--",
+		"/usr/local/eksa-upgrades/scripts/upgrade.sh", "kubeadm_in_first_cp", "v1.24.14-eks-1-24-14",
+	}))
+	g.Expect(*pod.Spec.InitContainers[1].SecurityContext.Privileged).To(BeTrue())
+	g.Expect(pod.Spec.Containers).To(HaveLen(1))
+	g.Expect(pod.Spec.Containers[0].Args).To(Equal([]string{
+		"--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "--",
+		"/usr/local/eksa-upgrades/scripts/upgrade.sh", "kubelet_and_kubectl",
+	}))
+}
+
+func TestUpgradeRestControlPlanePod(t *testing.T) {
+	g := NewWithT(t)
+	pod := nodeupgrader.UpgradeRestControlPlanePod(nodeName, image)
+
+	g.Expect(pod.Spec.InitContainers[1].Args).To(ContainElement("kubeadm_in_rest_cp"))
+	g.Expect(pod.Spec.Containers[0].Args).To(ContainElement("kubelet_and_kubectl"))
+}
+
+func TestUpgradeWorkerPod(t *testing.T) {
+	g := NewWithT(t)
+	pod := nodeupgrader.UpgradeWorkerPod(nodeName, image)
+
+	g.Expect(pod.Spec.InitContainers[1].Args).To(ContainElement("kubeadm_in_worker"))
+	g.Expect(pod.Spec.Containers[0].Args).To(ContainElement("kubelet_and_kubectl"))
+}
+
+func TestIsPatchUpgrade(t *testing.T) {
+	tests := []struct {
+		name           string
+		currentVersion v1alpha1.KubernetesVersion
+		currentTag     string
+		newVersion     v1alpha1.KubernetesVersion
+		newTag         string
+		want           bool
+	}{
+		{
+			name:           "patch upgrade",
+			currentVersion: v1alpha1.Kube124,
+			currentTag:     "v1.24.13-eks-1-24-13",
+			newVersion:     v1alpha1.Kube124,
+			newTag:         "v1.24.14-eks-1-24-14",
+			want:           true,
+		},
+		{
+			name:           "minor upgrade",
+			currentVersion: v1alpha1.Kube123,
+			currentTag:     "v1.23.17-eks-1-23-20",
+			newVersion:     v1alpha1.Kube124,
+			newTag:         "v1.24.14-eks-1-24-14",
+			want:           false,
+		},
+		{
+			name:           "same version",
+			currentVersion: v1alpha1.Kube124,
+			currentTag:     "v1.24.14-eks-1-24-14",
+			newVersion:     v1alpha1.Kube124,
+			newTag:         "v1.24.14-eks-1-24-14",
+			want:           false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			currentSpec := specWithVersion(tt.currentVersion, tt.currentTag)
+			newSpec := specWithVersion(tt.newVersion, tt.newTag)
+			g.Expect(nodeupgrader.IsPatchUpgrade(currentSpec, newSpec)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestControlPlaneUpgradesInPlace(t *testing.T) {
+	g := NewWithT(t)
+	currentSpec := specWithVersion(v1alpha1.Kube123, "v1.23.17-eks-1-23-20")
+	newSpec := specWithVersion(v1alpha1.Kube123, "v1.23.18-eks-1-23-21")
+	g.Expect(nodeupgrader.ControlPlaneUpgradesInPlace(currentSpec, newSpec)).To(BeFalse())
+
+	newSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{Type: v1alpha1.RollingUpdateStrategyType}
+	g.Expect(nodeupgrader.ControlPlaneUpgradesInPlace(currentSpec, newSpec)).To(BeFalse())
+
+	newSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.Type = v1alpha1.InPlaceStrategyType
+	g.Expect(nodeupgrader.ControlPlaneUpgradesInPlace(currentSpec, newSpec)).To(BeTrue())
+
+	newSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube124
+	g.Expect(nodeupgrader.ControlPlaneUpgradesInPlace(currentSpec, newSpec)).To(BeFalse())
+}
+
+func TestWorkerNodeGroupUpgradesInPlace(t *testing.T) {
+	g := NewWithT(t)
+	currentSpec := specWithVersion(v1alpha1.Kube124, "v1.24.13-eks-1-24-13")
+	currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}}
+	newSpec := specWithVersion(v1alpha1.Kube124, "v1.24.14-eks-1-24-14")
+	group := v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"}
+	g.Expect(nodeupgrader.WorkerNodeGroupUpgradesInPlace(currentSpec, newSpec, group)).To(BeFalse())
+
+	group.UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+	g.Expect(nodeupgrader.WorkerNodeGroupUpgradesInPlace(currentSpec, newSpec, group)).To(BeTrue())
+
+	group.Name = "md-1"
+	g.Expect(nodeupgrader.WorkerNodeGroupUpgradesInPlace(currentSpec, newSpec, group)).To(BeFalse())
+}
+
+func specWithVersion(version v1alpha1.KubernetesVersion, tag string) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.KubernetesVersion = version
+		s.VersionsBundle.KubeDistro.Kubernetes.Tag = tag
+	})
+}
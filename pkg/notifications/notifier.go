@@ -0,0 +1,119 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// EventType is the kind of transition a notification is sent for.
+type EventType string
+
+const (
+	UpgradeStarted      EventType = "UpgradeStarted"
+	UpgradeCompleted    EventType = "UpgradeCompleted"
+	UpgradeFailed       EventType = "UpgradeFailed"
+	MachineUnhealthy    EventType = "MachineUnhealthy"
+	CertificateExpiring EventType = "CertificateExpiring"
+)
+
+// Event is a lifecycle or health transition of a cluster.
+type Event struct {
+	Type      EventType         `json:"type"`
+	Cluster   string            `json:"cluster"`
+	Namespace string            `json:"namespace"`
+	Message   string            `json:"message"`
+	Time      time.Time         `json:"time"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+// DefaultTemplate renders an Event as JSON.
+const DefaultTemplate = `{{ toJSON . }}`
+
+// Sink delivers a rendered notification.
+type Sink interface {
+	Send(ctx context.Context, subject string, payload []byte) error
+}
+
+// Notifier renders events with a template and sends them to all its sinks.
+type Notifier struct {
+	sinks    []Sink
+	template *template.Template
+}
+
+// NotifierOpt configures a Notifier.
+type NotifierOpt func(*Notifier) error
+
+// WithTemplate sets the template used to render the payload of the notifications. The template
+// is executed with an Event.
+func WithTemplate(text string) NotifierOpt {
+	return func(n *Notifier) error {
+		t, err := parseTemplate(text)
+		if err != nil {
+			return err
+		}
+		n.template = t
+		return nil
+	}
+}
+
+// NewNotifier constructs a new Notifier sending to sinks.
+func NewNotifier(sinks []Sink, opts ...NotifierOpt) (*Notifier, error) {
+	t, err := parseTemplate(DefaultTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Notifier{
+		sinks:    sinks,
+		template: t,
+	}
+	for _, opt := range opts {
+		if err := opt(n); err != nil {
+			return nil, err
+		}
+	}
+
+	return n, nil
+}
+
+// Notify sends e to all sinks. A failing sink doesn't prevent the delivery to the others.
+func (n *Notifier) Notify(ctx context.Context, e Event) error {
+	var payload bytes.Buffer
+	if err := n.template.Execute(&payload, e); err != nil {
+		return fmt.Errorf("rendering notification for %s: %v", e.Type, err)
+	}
+
+	subject := fmt.Sprintf("EKS Anywhere cluster %s: %s", e.Cluster, e.Type)
+	var errs []error
+	for _, s := range n.sinks {
+		if err := s.Send(ctx, subject, payload.Bytes()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	t, err := template.New("notification").Funcs(template.FuncMap{
+		"toJSON": toJSON,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing notification template: %v", err)
+	}
+	return t, nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,75 @@
+package notifications_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/notifications"
+)
+
+type fakeSink struct {
+	subjects []string
+	payloads []string
+	err      error
+}
+
+func (f *fakeSink) Send(_ context.Context, subject string, payload []byte) error {
+	f.subjects = append(f.subjects, subject)
+	f.payloads = append(f.payloads, string(payload))
+	return f.err
+}
+
+var testEvent = notifications.Event{
+	Type:      notifications.UpgradeStarted,
+	Cluster:   "workload",
+	Namespace: "default",
+	Message:   "Cluster workload started rolling out changes",
+	Time:      time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC),
+}
+
+func TestNotifierNotifyDefaultTemplate(t *testing.T) {
+	g := NewWithT(t)
+	sink := &fakeSink{}
+	n, err := notifications.NewNotifier([]notifications.Sink{sink})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(n.Notify(context.Background(), testEvent)).To(Succeed())
+	g.Expect(sink.subjects).To(ConsistOf("EKS Anywhere cluster workload: UpgradeStarted"))
+	g.Expect(sink.payloads).To(ConsistOf(
+		`{"type":"UpgradeStarted","cluster":"workload","namespace":"default","message":"Cluster workload started rolling out changes","time":"2022-08-01T10:00:00Z"}`,
+	))
+}
+
+func TestNotifierNotifyCustomTemplate(t *testing.T) {
+	g := NewWithT(t)
+	sink := &fakeSink{}
+	n, err := notifications.NewNotifier(
+		[]notifications.Sink{sink},
+		notifications.WithTemplate(`{"text": {{ printf "%s: %s" .Type .Message | toJSON }}}`),
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(n.Notify(context.Background(), testEvent)).To(Succeed())
+	g.Expect(sink.payloads).To(ConsistOf(`{"text": "UpgradeStarted: Cluster workload started rolling out changes"}`))
+}
+
+func TestNotifierInvalidTemplate(t *testing.T) {
+	g := NewWithT(t)
+	_, err := notifications.NewNotifier(nil, notifications.WithTemplate("{{ .Type"))
+	g.Expect(err).To(MatchError(ContainSubstring("parsing notification template")))
+}
+
+func TestNotifierNotifySinkError(t *testing.T) {
+	g := NewWithT(t)
+	failing := &fakeSink{err: errors.New("connection refused")}
+	working := &fakeSink{}
+	n, err := notifications.NewNotifier([]notifications.Sink{failing, working})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(n.Notify(context.Background(), testEvent)).To(MatchError("connection refused"))
+	g.Expect(working.payloads).To(HaveLen(1))
+}
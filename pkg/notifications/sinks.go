@@ -0,0 +1,103 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// WebhookSink posts notifications to an HTTP endpoint.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink constructs a new WebhookSink posting to url.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Send posts payload to the webhook. Any non 2xx response is an error.
+func (w *WebhookSink) Send(ctx context.Context, _ string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("building webhook request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting notification to webhook: %v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("posting notification to webhook: unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// SNSPublisher publishes messages to an SNS topic.
+type SNSPublisher interface {
+	PublishWithContext(ctx aws.Context, input *sns.PublishInput, opts ...request.Option) (*sns.PublishOutput, error)
+}
+
+// SNSSink publishes notifications to an SNS topic.
+type SNSSink struct {
+	topicARN string
+	client   SNSPublisher
+}
+
+// NewSNSSink constructs a new SNSSink for topicARN, using the credentials from the environment.
+// The region is taken from the topic ARN.
+func NewSNSSink(topicARN string) (*SNSSink, error) {
+	parsed, err := arn.Parse(topicARN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SNS topic ARN %s: %v", topicARN, err)
+	}
+
+	sess, err := session.NewSession(aws.NewConfig().WithRegion(parsed.Region))
+	if err != nil {
+		return nil, fmt.Errorf("creating AWS session for SNS: %v", err)
+	}
+
+	return NewSNSSinkWithClient(topicARN, sns.New(sess)), nil
+}
+
+// NewSNSSinkWithClient constructs a new SNSSink for topicARN using client.
+func NewSNSSinkWithClient(topicARN string, client SNSPublisher) *SNSSink {
+	return &SNSSink{
+		topicARN: topicARN,
+		client:   client,
+	}
+}
+
+// Send publishes payload to the SNS topic.
+func (s *SNSSink) Send(ctx context.Context, subject string, payload []byte) error {
+	_, err := s.client.PublishWithContext(ctx, &sns.PublishInput{
+		TopicArn: aws.String(s.topicARN),
+		Subject:  aws.String(subject),
+		Message:  aws.String(string(payload)),
+	})
+	if err != nil {
+		return fmt.Errorf("publishing notification to SNS topic %s: %v", s.topicARN, err)
+	}
+
+	return nil
+}
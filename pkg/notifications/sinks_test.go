@@ -0,0 +1,83 @@
+package notifications_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sns"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/notifications"
+)
+
+func TestWebhookSinkSend(t *testing.T) {
+	g := NewWithT(t)
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+		b, _ := io.ReadAll(r.Body)
+		got = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := notifications.NewWebhookSink(server.URL)
+	g.Expect(sink.Send(context.Background(), "subject", []byte(`{"type":"UpgradeStarted"}`))).To(Succeed())
+	g.Expect(got).To(Equal(`{"type":"UpgradeStarted"}`))
+}
+
+func TestWebhookSinkSendErrorStatus(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := notifications.NewWebhookSink(server.URL)
+	g.Expect(sink.Send(context.Background(), "subject", []byte("{}"))).To(
+		MatchError("posting notification to webhook: unexpected status 500 Internal Server Error"),
+	)
+}
+
+type fakeSNS struct {
+	input *sns.PublishInput
+	err   error
+}
+
+func (f *fakeSNS) PublishWithContext(_ aws.Context, input *sns.PublishInput, _ ...request.Option) (*sns.PublishOutput, error) {
+	f.input = input
+	return &sns.PublishOutput{}, f.err
+}
+
+func TestSNSSinkSend(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeSNS{}
+	sink := notifications.NewSNSSinkWithClient("arn:aws:sns:us-west-2:123456789012:eksa", client)
+
+	g.Expect(sink.Send(context.Background(), "subject", []byte("payload"))).To(Succeed())
+	g.Expect(aws.StringValue(client.input.TopicArn)).To(Equal("arn:aws:sns:us-west-2:123456789012:eksa"))
+	g.Expect(aws.StringValue(client.input.Subject)).To(Equal("subject"))
+	g.Expect(aws.StringValue(client.input.Message)).To(Equal("payload"))
+}
+
+func TestSNSSinkSendError(t *testing.T) {
+	g := NewWithT(t)
+	sink := notifications.NewSNSSinkWithClient("arn:aws:sns:us-west-2:123456789012:eksa", &fakeSNS{err: errors.New("access denied")})
+
+	g.Expect(sink.Send(context.Background(), "subject", []byte("payload"))).To(
+		MatchError("publishing notification to SNS topic arn:aws:sns:us-west-2:123456789012:eksa: access denied"),
+	)
+}
+
+func TestNewSNSSinkInvalidARN(t *testing.T) {
+	g := NewWithT(t)
+	_, err := notifications.NewSNSSink("eksa-topic")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid SNS topic ARN eksa-topic")))
+}
@@ -0,0 +1,187 @@
+package notifications
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// DefaultCertificateExpiryThreshold is how long before expiry a certificate is reported.
+const DefaultCertificateExpiryThreshold = 30 * 24 * time.Hour
+
+// Snapshot is the observed state of a cluster and its CAPI objects at a point in time. The expiry of
+// the certificates of its control plane and etcd machines is read from the cluster status.
+type Snapshot struct {
+	Cluster            *anywherev1.Cluster
+	ControlPlane       *controlplanev1.KubeadmControlPlane
+	MachineDeployments []clusterv1.MachineDeployment
+	Machines           []clusterv1.Machine
+	Time               time.Time
+}
+
+// Watcher turns successive snapshots of clusters into transition events. The state last reported for
+// each cluster is kept in its status, so transitions are neither lost nor reported twice across restarts
+// and leader changes. The first snapshot of a cluster without a reported state is used as baseline for
+// the lifecycle events.
+type Watcher struct {
+	certificateExpiryThreshold time.Duration
+}
+
+// WatcherOpt configures a Watcher.
+type WatcherOpt func(*Watcher)
+
+// WithCertificateExpiryThreshold sets how long before expiry a certificate is reported.
+func WithCertificateExpiryThreshold(d time.Duration) WatcherOpt {
+	return func(w *Watcher) {
+		w.certificateExpiryThreshold = d
+	}
+}
+
+// NewWatcher constructs a new Watcher.
+func NewWatcher(opts ...WatcherOpt) *Watcher {
+	w := &Watcher{
+		certificateExpiryThreshold: DefaultCertificateExpiryThreshold,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Observe returns the events for the transitions since the state last reported in the status of the
+// cluster of s, and records the current state in that status. The caller persists the cluster status.
+func (w *Watcher) Observe(s Snapshot) []Event {
+	current := &anywherev1.NotificationStatus{Upgrading: upgrading(s)}
+	if s.Cluster.Status.FailureMessage != nil {
+		current.FailureMessage = *s.Cluster.Status.FailureMessage
+	}
+
+	previous := s.Cluster.Status.Notifications
+	if previous == nil {
+		previous = &anywherev1.NotificationStatus{
+			Upgrading:      current.Upgrading,
+			FailureMessage: current.FailureMessage,
+		}
+	}
+
+	var events []Event
+	newEvent := func(t EventType, message string, details map[string]string) {
+		events = append(events, Event{
+			Type:      t,
+			Cluster:   s.Cluster.Name,
+			Namespace: s.Cluster.Namespace,
+			Message:   message,
+			Time:      s.Time,
+			Details:   details,
+		})
+	}
+
+	version := string(s.Cluster.Spec.KubernetesVersion)
+	switch {
+	case current.FailureMessage != "" && current.FailureMessage != previous.FailureMessage:
+		newEvent(UpgradeFailed, current.FailureMessage, map[string]string{"kubernetesVersion": version})
+	case current.Upgrading && !previous.Upgrading:
+		newEvent(UpgradeStarted, fmt.Sprintf("Cluster %s started rolling out changes", s.Cluster.Name), map[string]string{"kubernetesVersion": version})
+	case !current.Upgrading && previous.Upgrading && current.FailureMessage == "":
+		newEvent(UpgradeCompleted, fmt.Sprintf("Cluster %s finished rolling out changes", s.Cluster.Name), map[string]string{"kubernetesVersion": version})
+	}
+
+	for i := range s.Machines {
+		m := &s.Machines[i]
+		if conditions.IsFalse(m, clusterv1.MachineHealthCheckSucceededCondition) {
+			current.UnhealthyMachines = append(current.UnhealthyMachines, m.Name)
+			if !contains(previous.UnhealthyMachines, m.Name) {
+				newEvent(MachineUnhealthy,
+					fmt.Sprintf("Machine %s failed its health check: %s", m.Name, conditions.GetMessage(m, clusterv1.MachineHealthCheckSucceededCondition)),
+					machineDetails(m),
+				)
+			}
+		}
+
+		if expiry, ok := certificateExpiry(s.Cluster.Status.Certificates, m.Name); ok && expiry.Sub(s.Time) < w.certificateExpiryThreshold {
+			current.CertificateExpiringMachines = append(current.CertificateExpiringMachines, m.Name)
+			if !contains(previous.CertificateExpiringMachines, m.Name) {
+				details := machineDetails(m)
+				details["expiry"] = expiry.UTC().Format(time.RFC3339)
+				newEvent(CertificateExpiring,
+					fmt.Sprintf("Certificates of machine %s expire on %s, roll the machine to renew them", m.Name, expiry.UTC().Format(time.RFC3339)),
+					details,
+				)
+			}
+		}
+	}
+
+	// Machines are listed in no particular order, sorting them keeps the status stable.
+	sort.Strings(current.UnhealthyMachines)
+	sort.Strings(current.CertificateExpiringMachines)
+	s.Cluster.Status.Notifications = current
+
+	return events
+}
+
+// upgrading returns true while the control plane or any worker node group is rolling out changes.
+func upgrading(s Snapshot) bool {
+	if kcp := s.ControlPlane; kcp != nil {
+		if kcp.Status.ObservedGeneration < kcp.Generation {
+			return true
+		}
+		if kcp.Status.Version == nil || *kcp.Status.Version != kcp.Spec.Version {
+			return true
+		}
+		if kcp.Status.UpdatedReplicas != kcp.Status.Replicas {
+			return true
+		}
+	}
+
+	for _, md := range s.MachineDeployments {
+		if md.Status.ObservedGeneration < md.Generation {
+			return true
+		}
+		if md.Status.UpdatedReplicas != md.Status.Replicas {
+			return true
+		}
+	}
+
+	return false
+}
+
+// certificateExpiry returns when the first of the reported certificates of a machine expires.
+// It returns false if none of the certificates belongs to the machine.
+func certificateExpiry(certs []anywherev1.CertificateStatus, machine string) (time.Time, bool) {
+	var expiry time.Time
+	found := false
+	for _, c := range certs {
+		if c.Machine != machine {
+			continue
+		}
+		if !found || c.ExpiresAt.Time.Before(expiry) {
+			expiry = c.ExpiresAt.Time
+			found = true
+		}
+	}
+
+	return expiry, found
+}
+
+func machineDetails(m *clusterv1.Machine) map[string]string {
+	details := map[string]string{"machine": m.Name}
+	if m.Status.NodeRef != nil {
+		details["node"] = m.Status.NodeRef.Name
+	}
+	return details
+}
+
+func contains(machines []string, machine string) bool {
+	for _, m := range machines {
+		if m == machine {
+			return true
+		}
+	}
+	return false
+}
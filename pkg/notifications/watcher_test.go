@@ -0,0 +1,253 @@
+package notifications_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/notifications"
+)
+
+var now = time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+
+func snapshot(upgrading bool) notifications.Snapshot {
+	version := "v1.23.7-eks-1-23-4"
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Generation: 2},
+		Spec:       controlplanev1.KubeadmControlPlaneSpec{Version: version},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			ObservedGeneration: 2,
+			Version:            &version,
+			Replicas:           3,
+			UpdatedReplicas:    3,
+		},
+	}
+	if upgrading {
+		kcp.Status.UpdatedReplicas = 1
+	}
+
+	return notifications.Snapshot{
+		Cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+			Spec:       anywherev1.ClusterSpec{KubernetesVersion: anywherev1.Kube123},
+		},
+		ControlPlane: kcp,
+		MachineDeployments: []clusterv1.MachineDeployment{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0", Generation: 1},
+				Status:     clusterv1.MachineDeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2},
+			},
+		},
+		Time: now,
+	}
+}
+
+// observer feeds snapshots of a cluster to a Watcher, carrying the reported state over between them like
+// the cluster status does.
+type observer struct {
+	watcher *notifications.Watcher
+	status  *anywherev1.NotificationStatus
+}
+
+func newObserver(opts ...notifications.WatcherOpt) *observer {
+	return &observer{watcher: notifications.NewWatcher(opts...)}
+}
+
+func (o *observer) observe(s notifications.Snapshot) []notifications.Event {
+	s.Cluster.Status.Notifications = o.status
+	events := o.watcher.Observe(s)
+	o.status = s.Cluster.Status.Notifications
+	return events
+}
+
+func eventTypes(events []notifications.Event) []notifications.EventType {
+	types := make([]notifications.EventType, 0, len(events))
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestWatcherObserveFirstSnapshotIsBaseline(t *testing.T) {
+	g := NewWithT(t)
+	o := newObserver()
+
+	g.Expect(o.observe(snapshot(true))).To(BeEmpty())
+}
+
+func TestWatcherObserveUpgradeStartedAndCompleted(t *testing.T) {
+	g := NewWithT(t)
+	o := newObserver()
+
+	g.Expect(o.observe(snapshot(false))).To(BeEmpty())
+	g.Expect(eventTypes(o.observe(snapshot(true)))).To(ConsistOf(notifications.UpgradeStarted))
+	g.Expect(o.observe(snapshot(true))).To(BeEmpty())
+	events := o.observe(snapshot(false))
+	g.Expect(eventTypes(events)).To(ConsistOf(notifications.UpgradeCompleted))
+	g.Expect(events[0].Cluster).To(Equal("workload"))
+	g.Expect(events[0].Namespace).To(Equal("default"))
+	g.Expect(events[0].Time).To(Equal(now))
+	g.Expect(events[0].Details).To(HaveKeyWithValue("kubernetesVersion", "1.23"))
+}
+
+func TestWatcherObserveWorkerNodeGroupUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	o := newObserver()
+	g.Expect(o.observe(snapshot(false))).To(BeEmpty())
+
+	s := snapshot(false)
+	s.MachineDeployments[0].Generation = 2
+	g.Expect(eventTypes(o.observe(s))).To(ConsistOf(notifications.UpgradeStarted))
+}
+
+func TestWatcherObserveUpgradeFailed(t *testing.T) {
+	g := NewWithT(t)
+	o := newObserver()
+	g.Expect(o.observe(snapshot(true))).To(BeEmpty())
+
+	failed := snapshot(true)
+	message := "machine provisioning timed out"
+	failed.Cluster.Status.FailureMessage = &message
+	events := o.observe(failed)
+	g.Expect(eventTypes(events)).To(ConsistOf(notifications.UpgradeFailed))
+	g.Expect(events[0].Message).To(Equal(message))
+
+	g.Expect(o.observe(failed)).To(BeEmpty())
+	g.Expect(eventTypes(o.observe(snapshot(false)))).To(ConsistOf(notifications.UpgradeCompleted))
+}
+
+func TestWatcherObserveMachineUnhealthy(t *testing.T) {
+	g := NewWithT(t)
+	o := newObserver()
+	g.Expect(o.observe(snapshot(false))).To(BeEmpty())
+
+	s := snapshot(false)
+	s.Machines = []clusterv1.Machine{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0-abcde", CreationTimestamp: metav1.NewTime(now)},
+			Status: clusterv1.MachineStatus{
+				NodeRef: &corev1.ObjectReference{Name: "node-1"},
+				Conditions: clusterv1.Conditions{
+					{
+						Type:    clusterv1.MachineHealthCheckSucceededCondition,
+						Status:  corev1.ConditionFalse,
+						Message: "Node failed to report startup",
+					},
+				},
+			},
+		},
+	}
+
+	events := o.observe(s)
+	g.Expect(eventTypes(events)).To(ConsistOf(notifications.MachineUnhealthy))
+	g.Expect(events[0].Message).To(Equal("Machine workload-md-0-abcde failed its health check: Node failed to report startup"))
+	g.Expect(events[0].Details).To(Equal(map[string]string{"machine": "workload-md-0-abcde", "node": "node-1"}))
+
+	g.Expect(o.observe(s)).To(BeEmpty())
+}
+
+func TestWatcherObserveCertificateExpiring(t *testing.T) {
+	g := NewWithT(t)
+	o := newObserver(notifications.WithCertificateExpiryThreshold(7 * 24 * time.Hour))
+
+	s := snapshot(false)
+	s.Machines = []clusterv1.Machine{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "workload-cp-old",
+				Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "workload-cp-new",
+				Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "workload-etcd-old",
+				Labels: map[string]string{clusterv1.MachineEtcdClusterLabelName: ""},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "workload-md-0-old",
+				CreationTimestamp: metav1.NewTime(now.Add(-360 * 24 * time.Hour)),
+			},
+		},
+	}
+	s.Cluster.Status.Certificates = []anywherev1.CertificateStatus{
+		{
+			Component: certificates.ControlPlaneComponent,
+			Name:      certificates.APIServerCertificate,
+			Machine:   "workload-cp-new",
+			ExpiresAt: metav1.NewTime(now.Add(65 * 24 * time.Hour)),
+		},
+		{
+			Component: certificates.ControlPlaneComponent,
+			Name:      certificates.APIServerCertificate,
+			Machine:   "workload-cp-old",
+			ExpiresAt: metav1.NewTime(now.Add(5 * 24 * time.Hour)),
+		},
+		{
+			Component: certificates.EtcdComponent,
+			Name:      certificates.APIServerEtcdClientCertificate,
+			ExpiresAt: metav1.NewTime(now.Add(24 * time.Hour)),
+		},
+		{
+			Component: certificates.EtcdComponent,
+			Name:      certificates.EtcdServerCertificate,
+			Machine:   "workload-etcd-old",
+			ExpiresAt: metav1.NewTime(now.Add(10 * 24 * time.Hour)),
+		},
+	}
+
+	events := o.observe(s)
+	g.Expect(eventTypes(events)).To(ConsistOf(notifications.CertificateExpiring))
+	g.Expect(events[0].Details).To(HaveKeyWithValue("machine", "workload-cp-old"))
+	g.Expect(events[0].Details).To(HaveKeyWithValue("expiry", "2022-08-06T10:00:00Z"))
+
+	g.Expect(o.observe(s)).To(BeEmpty())
+
+	s.Time = now.Add(5 * 24 * time.Hour)
+	events = o.observe(s)
+	g.Expect(eventTypes(events)).To(ConsistOf(notifications.CertificateExpiring))
+	g.Expect(events[0].Details).To(HaveKeyWithValue("machine", "workload-etcd-old"))
+	g.Expect(events[0].Details).To(HaveKeyWithValue("expiry", "2022-08-11T10:00:00Z"))
+}
+
+func TestWatcherObserveCertificateExpiringNotInspected(t *testing.T) {
+	g := NewWithT(t)
+	o := newObserver()
+
+	s := snapshot(false)
+	s.Machines = []clusterv1.Machine{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "workload-cp-old",
+				CreationTimestamp: metav1.NewTime(now.Add(-400 * 24 * time.Hour)),
+				Labels:            map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+			},
+		},
+	}
+
+	g.Expect(o.observe(s)).To(BeEmpty())
+}
+
+func TestWatcherObserveReportedState(t *testing.T) {
+	g := NewWithT(t)
+	w := notifications.NewWatcher()
+
+	s := snapshot(false)
+	s.Cluster.Status.Notifications = &anywherev1.NotificationStatus{Upgrading: true}
+	g.Expect(eventTypes(w.Observe(s))).To(ConsistOf(notifications.UpgradeCompleted))
+	g.Expect(s.Cluster.Status.Notifications).To(Equal(&anywherev1.NotificationStatus{}))
+}
@@ -0,0 +1,78 @@
+package operatorapi
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// TokenAuthenticator authenticates requests with static bearer tokens.
+type TokenAuthenticator struct {
+	tokens [][]byte
+}
+
+// NewTokenAuthenticator constructs a new TokenAuthenticator accepting tokens.
+func NewTokenAuthenticator(tokens ...string) (*TokenAuthenticator, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("at least one token is required")
+	}
+
+	a := &TokenAuthenticator{tokens: make([][]byte, 0, len(tokens))}
+	for _, t := range tokens {
+		a.tokens = append(a.tokens, []byte(t))
+	}
+	return a, nil
+}
+
+// NewTokenAuthenticatorFromFile constructs a new TokenAuthenticator with the tokens in a file,
+// one per line. Empty lines and lines starting with # are ignored.
+func NewTokenAuthenticatorFromFile(path string) (*TokenAuthenticator, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading token file: %v", err)
+	}
+
+	var tokens []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens = append(tokens, line)
+	}
+
+	a, err := NewTokenAuthenticator(tokens...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token file %s: %v", path, err)
+	}
+	return a, nil
+}
+
+// Authenticate returns true if the request carries a valid bearer token.
+func (a *TokenAuthenticator) Authenticate(r *http.Request) bool {
+	token, ok := bearerToken(r)
+	if !ok {
+		return false
+	}
+
+	valid := 0
+	for _, t := range a.tokens {
+		valid |= subtle.ConstantTimeCompare(t, []byte(token))
+	}
+	return valid == 1
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	h := r.Header.Get("Authorization")
+	if len(h) <= len(prefix) || !strings.EqualFold(h[:len(prefix)], prefix) {
+		return "", false
+	}
+	return h[len(prefix):], true
+}
@@ -0,0 +1,43 @@
+package operatorapi_test
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/operatorapi"
+)
+
+func TestTokenAuthenticatorFromFile(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "tokens")
+	g.Expect(os.WriteFile(path, []byte("# portal\ntoken-1\n\n  token-2  \n"), 0o600)).To(Succeed())
+
+	a, err := operatorapi.NewTokenAuthenticatorFromFile(path)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for header, want := range map[string]bool{
+		"Bearer token-1": true,
+		"bearer token-2": true,
+		"Bearer token-3": false,
+		"Bearer ":        false,
+		"token-1":        false,
+		"":               false,
+	} {
+		r := httptest.NewRequest("GET", "/v1/clusters/test", nil)
+		r.Header.Set("Authorization", header)
+		g.Expect(a.Authenticate(r)).To(Equal(want), header)
+	}
+}
+
+func TestTokenAuthenticatorFromFileEmpty(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "tokens")
+	g.Expect(os.WriteFile(path, []byte("# no tokens\n"), 0o600)).To(Succeed())
+
+	_, err := operatorapi.NewTokenAuthenticatorFromFile(path)
+	g.Expect(err).To(MatchError(ContainSubstring("at least one token is required")))
+}
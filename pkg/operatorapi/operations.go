@@ -0,0 +1,136 @@
+package operatorapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// OperationType is the lifecycle operation requested for a cluster.
+type OperationType string
+
+const (
+	OperationCreate  OperationType = "create"
+	OperationUpgrade OperationType = "upgrade"
+	OperationDelete  OperationType = "delete"
+)
+
+// OperationState is the state of an operation in the queue.
+type OperationState string
+
+const (
+	OperationPending OperationState = "pending"
+	OperationRunning OperationState = "running"
+	// OperationSubmitted means the change was handed to the EKS-A controller, the progress of the
+	// cluster itself is reported by its status.
+	OperationSubmitted OperationState = "submitted"
+	OperationFailed    OperationState = "failed"
+)
+
+// Operation is a lifecycle operation enqueued through the API.
+type Operation struct {
+	ID        string         `json:"id"`
+	Type      OperationType  `json:"type"`
+	Cluster   string         `json:"cluster"`
+	Namespace string         `json:"namespace"`
+	State     OperationState `json:"state"`
+	Error     string         `json:"error,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+type queuedOperation struct {
+	id  string
+	run func(ctx context.Context) error
+}
+
+// errQueueFull is returned when no more operations can be enqueued.
+var errQueueFull = errors.New("operation queue is full")
+
+// operationQueue runs operations one at a time in submission order and keeps track of their state.
+type operationQueue struct {
+	mu         sync.Mutex
+	operations map[string]*Operation
+	next       int
+	queue      chan queuedOperation
+	now        func() time.Time
+}
+
+func newOperationQueue(size int) *operationQueue {
+	return &operationQueue{
+		operations: map[string]*Operation{},
+		queue:      make(chan queuedOperation, size),
+		now:        time.Now,
+	}
+}
+
+func (q *operationQueue) enqueue(opType OperationType, cluster, namespace string, run func(ctx context.Context) error) (Operation, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.next++
+	now := q.now()
+	op := &Operation{
+		ID:        fmt.Sprintf("op-%d", q.next),
+		Type:      opType,
+		Cluster:   cluster,
+		Namespace: namespace,
+		State:     OperationPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	select {
+	case q.queue <- queuedOperation{id: op.ID, run: run}:
+	default:
+		return Operation{}, errQueueFull
+	}
+
+	q.operations[op.ID] = op
+	return *op, nil
+}
+
+func (q *operationQueue) get(id string) (Operation, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	op, ok := q.operations[id]
+	if !ok {
+		return Operation{}, false
+	}
+	return *op, true
+}
+
+func (q *operationQueue) setState(id string, state OperationState, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	op := q.operations[id]
+	op.State = state
+	op.UpdatedAt = q.now()
+	if err != nil {
+		op.Error = err.Error()
+	}
+}
+
+// run processes operations until ctx is cancelled.
+func (q *operationQueue) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case op := <-q.queue:
+			q.setState(op.id, OperationRunning, nil)
+			if err := op.run(ctx); err != nil {
+				logger.Error(err, "Operation failed", "id", op.id)
+				q.setState(op.id, OperationFailed, err)
+				continue
+			}
+			q.setState(op.id, OperationSubmitted, nil)
+		}
+	}
+}
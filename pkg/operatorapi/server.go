@@ -0,0 +1,360 @@
+package operatorapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	defaultNamespace   = "default"
+	defaultQueueSize   = 100
+	maxRequestBodySize = 1 << 20
+	shutdownTimeout    = 10 * time.Second
+)
+
+// ClusterClient reads and writes EKS-A objects in the management cluster.
+type ClusterClient interface {
+	Get(ctx context.Context, name, namespace, kubeconfig string, obj runtime.Object) error
+	Apply(ctx context.Context, kubeconfig string, obj runtime.Object) error
+	Delete(ctx context.Context, name, namespace, kubeconfig string, obj runtime.Object) error
+}
+
+// Authenticator decides if a request is allowed to use the API.
+type Authenticator interface {
+	Authenticate(r *http.Request) bool
+}
+
+// Server exposes the lifecycle of the workload clusters of a management cluster over a REST API.
+// Operations don't run the CLI workflows: they apply or delete the EKS-A objects in the management
+// cluster and the EKS-A controller reconciles them.
+type Server struct {
+	client            ClusterClient
+	auth              Authenticator
+	managementCluster string
+	kubeconfig        string
+	operations        *operationQueue
+}
+
+// ServerOpt configures a Server.
+type ServerOpt func(*Server)
+
+// WithQueueSize sets the maximum number of operations waiting to be processed.
+func WithQueueSize(size int) ServerOpt {
+	return func(s *Server) {
+		s.operations = newOperationQueue(size)
+	}
+}
+
+// NewServer constructs a new Server for the management cluster with name managementCluster,
+// accessible with kubeconfig.
+func NewServer(client ClusterClient, auth Authenticator, managementCluster, kubeconfig string, opts ...ServerOpt) *Server {
+	s := &Server{
+		client:            client,
+		auth:              auth,
+		managementCluster: managementCluster,
+		kubeconfig:        kubeconfig,
+		operations:        newOperationQueue(defaultQueueSize),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Start processes the enqueued operations in the background until ctx is cancelled.
+func (s *Server) Start(ctx context.Context) {
+	go s.operations.run(ctx)
+}
+
+// ListenAndServeTLS starts processing operations and serves the API on addr until ctx is cancelled.
+func (s *Server) ListenAndServeTLS(ctx context.Context, addr, certFile, keyFile string) error {
+	s.Start(ctx)
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "Shutting down API server")
+		}
+	}()
+
+	logger.Info("Serving EKS-A API", "address", addr, "managementCluster", s.managementCluster)
+	if err := server.ListenAndServeTLS(certFile, keyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving API: %v", err)
+	}
+
+	return nil
+}
+
+// Handler returns the http.Handler for the API.
+//
+//	GET    /healthz
+//	POST   /v1/clusters                 create a cluster from a cluster config
+//	GET    /v1/clusters/{name}          get the status of a cluster
+//	PUT    /v1/clusters/{name}          upgrade a cluster to a cluster config
+//	DELETE /v1/clusters/{name}          delete a cluster
+//	GET    /v1/operations/{id}          get the state of an operation
+//
+// The cluster endpoints accept a namespace query parameter, default is "default".
+func (s *Server) Handler() http.Handler {
+	api := http.NewServeMux()
+	api.HandleFunc("/v1/clusters", s.handleClusters)
+	api.HandleFunc("/v1/clusters/", s.handleCluster)
+	api.HandleFunc("/v1/operations/", s.handleOperation)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle("/v1/", s.authenticated(api))
+	return mux
+}
+
+func (s *Server) authenticated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.auth.Authenticate(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleClusters(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	config, err := s.readConfig(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx := r.Context()
+	name, namespace := config.Cluster.Name, clusterNamespace(config.Cluster.Namespace)
+	_, err = s.getCluster(ctx, name, namespace)
+	switch {
+	case err == nil:
+		writeError(w, http.StatusConflict, fmt.Errorf("cluster %s already exists", name))
+		return
+	case !apierrors.IsNotFound(err):
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.enqueue(w, OperationCreate, name, namespace, func(ctx context.Context) error {
+		return s.applyConfig(ctx, config)
+	})
+}
+
+func (s *Server) handleCluster(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/v1/clusters/")
+	if name == "" || strings.Contains(name, "/") {
+		writeError(w, http.StatusNotFound, fmt.Errorf("path %s not found", r.URL.Path))
+		return
+	}
+	namespace := clusterNamespace(r.URL.Query().Get("namespace"))
+
+	ctx := r.Context()
+	existing, err := s.getCluster(ctx, name, namespace)
+	if apierrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("cluster %s not found", name))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, newClusterStatus(existing))
+	case http.MethodPut:
+		if existing.IsSelfManaged() {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("cluster %s is a management cluster, upgrade it with the CLI", name))
+			return
+		}
+		config, err := s.readConfig(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if config.Cluster.Name != name || clusterNamespace(config.Cluster.Namespace) != namespace {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("cluster config is for %s/%s, not %s/%s", clusterNamespace(config.Cluster.Namespace), config.Cluster.Name, namespace, name))
+			return
+		}
+		s.enqueue(w, OperationUpgrade, name, namespace, func(ctx context.Context) error {
+			return s.applyConfig(ctx, config)
+		})
+	case http.MethodDelete:
+		if existing.IsSelfManaged() {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("cluster %s is a management cluster, delete it with the CLI", name))
+			return
+		}
+		s.enqueue(w, OperationDelete, name, namespace, func(ctx context.Context) error {
+			if err := s.client.Delete(ctx, name, namespace, s.kubeconfig, &anywherev1.Cluster{}); err != nil {
+				return fmt.Errorf("deleting cluster %s: %v", name, err)
+			}
+			return nil
+		})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	}
+}
+
+func (s *Server) handleOperation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/v1/operations/")
+	op, ok := s.operations.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("operation %s not found", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, op)
+}
+
+func (s *Server) enqueue(w http.ResponseWriter, opType OperationType, name, namespace string, run func(ctx context.Context) error) {
+	op, err := s.operations.enqueue(opType, name, namespace, run)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+
+	w.Header().Set("Location", "/v1/operations/"+op.ID)
+	writeJSON(w, http.StatusAccepted, op)
+}
+
+// readConfig parses and validates the cluster config in the request body. Clusters created through
+// the API are always managed by the served management cluster.
+func (s *Server) readConfig(r *http.Request) (*cluster.Config, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %v", err)
+	}
+
+	config, err := cluster.ParseConfig(body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cluster config: %v", err)
+	}
+
+	if config.Cluster.Spec.ManagementCluster.Name == "" {
+		config.Cluster.SetManagedBy(s.managementCluster)
+	}
+	if config.Cluster.ManagedBy() != s.managementCluster {
+		return nil, fmt.Errorf("cluster %s must be managed by %s", config.Cluster.Name, s.managementCluster)
+	}
+
+	if err := cluster.SetConfigDefaults(config); err != nil {
+		return nil, fmt.Errorf("setting defaults for cluster config: %v", err)
+	}
+
+	if err := cluster.ValidateConfig(config); err != nil {
+		return nil, fmt.Errorf("invalid cluster config: %v", err)
+	}
+
+	return config, nil
+}
+
+func (s *Server) applyConfig(ctx context.Context, config *cluster.Config) error {
+	// The Cluster is applied last so the controller finds all the objects it references.
+	for _, obj := range config.ChildObjects() {
+		if err := s.client.Apply(ctx, s.kubeconfig, obj); err != nil {
+			return fmt.Errorf("applying %s %s: %v", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+	}
+
+	if err := s.client.Apply(ctx, s.kubeconfig, config.Cluster); err != nil {
+		return fmt.Errorf("applying cluster %s: %v", config.Cluster.Name, err)
+	}
+
+	return nil
+}
+
+func (s *Server) getCluster(ctx context.Context, name, namespace string) (*anywherev1.Cluster, error) {
+	c := &anywherev1.Cluster{}
+	if err := s.client.Get(ctx, name, namespace, s.kubeconfig, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ClusterStatus is the status of a cluster returned by the API.
+type ClusterStatus struct {
+	Name              string                       `json:"name"`
+	Namespace         string                       `json:"namespace"`
+	KubernetesVersion anywherev1.KubernetesVersion `json:"kubernetesVersion"`
+	Ready             bool                         `json:"ready"`
+	FailureMessage    string                       `json:"failureMessage,omitempty"`
+	Conditions        []clusterv1.Condition        `json:"conditions,omitempty"`
+}
+
+func newClusterStatus(c *anywherev1.Cluster) ClusterStatus {
+	status := ClusterStatus{
+		Name:              c.Name,
+		Namespace:         clusterNamespace(c.Namespace),
+		KubernetesVersion: c.Spec.KubernetesVersion,
+		Conditions:        c.Status.Conditions,
+	}
+	if c.Status.FailureMessage != nil {
+		status.FailureMessage = *c.Status.FailureMessage
+	}
+	for _, condition := range c.Status.Conditions {
+		if condition.Type == clusterv1.ReadyCondition {
+			status.Ready = condition.Status == corev1.ConditionTrue
+		}
+	}
+	return status
+}
+
+func clusterNamespace(namespace string) string {
+	if namespace == "" {
+		return defaultNamespace
+	}
+	return namespace
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Error(err, "Writing API response")
+	}
+}
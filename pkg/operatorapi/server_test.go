@@ -0,0 +1,230 @@
+package operatorapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/operatorapi"
+	"github.com/aws/eks-anywhere/pkg/operatorapi/mocks"
+)
+
+const (
+	token      = "secret-token"
+	kubeconfig = "mgmt.kubeconfig"
+)
+
+type serverTest struct {
+	*WithT
+	t       *testing.T
+	ctx     context.Context
+	client  *mocks.MockClusterClient
+	server  *operatorapi.Server
+	handler http.Handler
+}
+
+func newServerTest(t *testing.T) *serverTest {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	auth, err := operatorapi.NewTokenAuthenticator(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := &serverTest{
+		WithT:  NewWithT(t),
+		t:      t,
+		ctx:    ctx,
+		client: mocks.NewMockClusterClient(gomock.NewController(t)),
+	}
+	tt.server = operatorapi.NewServer(tt.client, auth, "mgmt", kubeconfig)
+	tt.server.Start(ctx)
+	tt.handler = tt.server.Handler()
+	return tt
+}
+
+func (tt *serverTest) do(method, path string, body []byte) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, bytes.NewReader(body))
+	r.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	tt.handler.ServeHTTP(w, r)
+	return w
+}
+
+func (tt *serverTest) expectCluster(name string, c *anywherev1.Cluster) {
+	tt.client.EXPECT().Get(gomock.Any(), name, "default", kubeconfig, &anywherev1.Cluster{}).DoAndReturn(
+		func(_ context.Context, _, _, _ string, obj runtime.Object) error {
+			if c == nil {
+				return apierrors.NewNotFound(schema.GroupResource{Resource: "clusters"}, name)
+			}
+			c.DeepCopyInto(obj.(*anywherev1.Cluster))
+			return nil
+		},
+	)
+}
+
+func (tt *serverTest) operation(w *httptest.ResponseRecorder) operatorapi.Operation {
+	op := operatorapi.Operation{}
+	tt.Expect(json.Unmarshal(w.Body.Bytes(), &op)).To(Succeed())
+	return op
+}
+
+func (tt *serverTest) eventuallyOperationState(id string) AsyncAssertion {
+	return tt.Eventually(func() operatorapi.OperationState {
+		return tt.operation(tt.do(http.MethodGet, "/v1/operations/"+id, nil)).State
+	})
+}
+
+func workloadCluster() *anywherev1.Cluster {
+	c := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+	}
+	c.SetManagedBy("mgmt")
+	return c
+}
+
+func TestServerHealthz(t *testing.T) {
+	tt := newServerTest(t)
+	w := httptest.NewRecorder()
+	tt.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	tt.Expect(w.Code).To(Equal(http.StatusOK))
+}
+
+func TestServerUnauthorized(t *testing.T) {
+	tt := newServerTest(t)
+	r := httptest.NewRequest(http.MethodGet, "/v1/clusters/workload", nil)
+	r.Header.Set("Authorization", "Bearer wrong")
+	w := httptest.NewRecorder()
+	tt.handler.ServeHTTP(w, r)
+	tt.Expect(w.Code).To(Equal(http.StatusUnauthorized))
+}
+
+func TestServerCreateCluster(t *testing.T) {
+	tt := newServerTest(t)
+	tt.expectCluster("workload", nil)
+	applied := make(chan string, 2)
+	tt.client.EXPECT().Apply(gomock.Any(), kubeconfig, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, obj runtime.Object) error {
+			applied <- obj.GetObjectKind().GroupVersionKind().Kind
+			return nil
+		},
+	).Times(2)
+
+	w := tt.do(http.MethodPost, "/v1/clusters", []byte(test.ReadFile(t, "testdata/workload_cluster.yaml")))
+	tt.Expect(w.Code).To(Equal(http.StatusAccepted), w.Body.String())
+	op := tt.operation(w)
+	tt.Expect(op.Type).To(Equal(operatorapi.OperationCreate))
+	tt.Expect(op.Cluster).To(Equal("workload"))
+	tt.Expect(w.Header().Get("Location")).To(Equal("/v1/operations/" + op.ID))
+
+	tt.eventuallyOperationState(op.ID).Should(Equal(operatorapi.OperationSubmitted))
+	tt.Expect(<-applied).To(Equal(anywherev1.DockerDatacenterKind))
+	tt.Expect(<-applied).To(Equal(anywherev1.ClusterKind))
+}
+
+func TestServerCreateClusterAlreadyExists(t *testing.T) {
+	tt := newServerTest(t)
+	tt.expectCluster("workload", workloadCluster())
+
+	w := tt.do(http.MethodPost, "/v1/clusters", []byte(test.ReadFile(t, "testdata/workload_cluster.yaml")))
+	tt.Expect(w.Code).To(Equal(http.StatusConflict))
+}
+
+func TestServerCreateClusterInvalidConfig(t *testing.T) {
+	tt := newServerTest(t)
+
+	w := tt.do(http.MethodPost, "/v1/clusters", []byte("kind: Cluster\n"))
+	tt.Expect(w.Code).To(Equal(http.StatusBadRequest))
+}
+
+func TestServerCreateClusterApplyFails(t *testing.T) {
+	tt := newServerTest(t)
+	tt.expectCluster("workload", nil)
+	tt.client.EXPECT().Apply(gomock.Any(), kubeconfig, gomock.Any()).Return(errors.New("connection refused"))
+
+	w := tt.do(http.MethodPost, "/v1/clusters", []byte(test.ReadFile(t, "testdata/workload_cluster.yaml")))
+	tt.Expect(w.Code).To(Equal(http.StatusAccepted))
+	op := tt.operation(w)
+
+	tt.eventuallyOperationState(op.ID).Should(Equal(operatorapi.OperationFailed))
+	tt.Expect(tt.operation(tt.do(http.MethodGet, "/v1/operations/"+op.ID, nil)).Error).To(Equal("applying DockerDatacenterConfig workload: connection refused"))
+}
+
+func TestServerUpgradeClusterNameMismatch(t *testing.T) {
+	tt := newServerTest(t)
+	c := workloadCluster()
+	c.Name = "other"
+	tt.expectCluster("other", c)
+
+	w := tt.do(http.MethodPut, "/v1/clusters/other", []byte(test.ReadFile(t, "testdata/workload_cluster.yaml")))
+	tt.Expect(w.Code).To(Equal(http.StatusBadRequest))
+}
+
+func TestServerUpgradeManagementCluster(t *testing.T) {
+	tt := newServerTest(t)
+	c := workloadCluster()
+	c.SetSelfManaged()
+	tt.expectCluster("workload", c)
+
+	w := tt.do(http.MethodPut, "/v1/clusters/workload", []byte(test.ReadFile(t, "testdata/workload_cluster.yaml")))
+	tt.Expect(w.Code).To(Equal(http.StatusBadRequest))
+}
+
+func TestServerDeleteCluster(t *testing.T) {
+	tt := newServerTest(t)
+	tt.expectCluster("workload", workloadCluster())
+	tt.client.EXPECT().Delete(gomock.Any(), "workload", "default", kubeconfig, &anywherev1.Cluster{}).Return(nil)
+
+	w := tt.do(http.MethodDelete, "/v1/clusters/workload", nil)
+	tt.Expect(w.Code).To(Equal(http.StatusAccepted))
+	op := tt.operation(w)
+	tt.Expect(op.Type).To(Equal(operatorapi.OperationDelete))
+
+	tt.eventuallyOperationState(op.ID).Should(Equal(operatorapi.OperationSubmitted))
+}
+
+func TestServerClusterStatus(t *testing.T) {
+	tt := newServerTest(t)
+	c := workloadCluster()
+	c.Spec.KubernetesVersion = anywherev1.Kube123
+	c.Status.Conditions = []clusterv1.Condition{{Type: clusterv1.ReadyCondition, Status: corev1.ConditionTrue}}
+	tt.expectCluster("workload", c)
+
+	w := tt.do(http.MethodGet, "/v1/clusters/workload", nil)
+	tt.Expect(w.Code).To(Equal(http.StatusOK))
+	status := operatorapi.ClusterStatus{}
+	tt.Expect(json.Unmarshal(w.Body.Bytes(), &status)).To(Succeed())
+	tt.Expect(status.Name).To(Equal("workload"))
+	tt.Expect(status.KubernetesVersion).To(Equal(anywherev1.Kube123))
+	tt.Expect(status.Ready).To(BeTrue())
+}
+
+func TestServerClusterNotFound(t *testing.T) {
+	tt := newServerTest(t)
+	tt.expectCluster("workload", nil)
+
+	w := tt.do(http.MethodGet, "/v1/clusters/workload", nil)
+	tt.Expect(w.Code).To(Equal(http.StatusNotFound))
+}
+
+func TestServerOperationNotFound(t *testing.T) {
+	tt := newServerTest(t)
+
+	w := tt.do(http.MethodGet, "/v1/operations/op-42", nil)
+	tt.Expect(w.Code).To(Equal(http.StatusNotFound))
+}
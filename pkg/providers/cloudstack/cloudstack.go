@@ -34,6 +34,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -62,6 +63,7 @@ type cloudstackProvider struct {
 	machineConfigs        map[string]*v1alpha1.CloudStackMachineConfig
 	clusterConfig         *v1alpha1.Cluster
 	providerKubectlClient ProviderKubectlClient
+	cmk                   ProviderCmkClient
 	writer                filewriter.FileWriter
 	selfSigned            bool
 	templateBuilder       *CloudStackTemplateBuilder
@@ -232,6 +234,7 @@ type ProviderKubectlClient interface {
 	DeleteEksaCloudStackDatacenterConfig(ctx context.Context, cloudstackDatacenterConfigName string, kubeconfigFile string, namespace string) error
 	DeleteEksaCloudStackMachineConfig(ctx context.Context, cloudstackMachineConfigName string, kubeconfigFile string, namespace string) error
 	SetEksaControllerEnvVar(ctx context.Context, envVar, envVarVal, kubeconfig string) error
+	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
 }
 
 func NewProvider(datacenterConfig *v1alpha1.CloudStackDatacenterConfig, machineConfigs map[string]*v1alpha1.CloudStackMachineConfig, clusterConfig *v1alpha1.Cluster, providerKubectlClient ProviderKubectlClient, providerCmkClient ProviderCmkClient, writer filewriter.FileWriter, now types.NowFunc, skipIpCheck bool, log logr.Logger) *cloudstackProvider {
@@ -250,6 +253,7 @@ func NewProvider(datacenterConfig *v1alpha1.CloudStackDatacenterConfig, machineC
 		machineConfigs:        machineConfigs,
 		clusterConfig:         clusterConfig,
 		providerKubectlClient: providerKubectlClient,
+		cmk:                   providerCmkClient,
 		writer:                writer,
 		selfSigned:            false,
 		templateBuilder: &CloudStackTemplateBuilder{
@@ -448,36 +452,47 @@ func (p *cloudstackProvider) SetupAndValidateCreateCluster(ctx context.Context,
 		return fmt.Errorf("validating environment variables: %v", err)
 	}
 
+	var specErr, endpointErr error
 	if err := p.validateClusterSpec(ctx, clusterSpec); err != nil {
-		return fmt.Errorf("validating cluster spec: %v", err)
+		specErr = fmt.Errorf("validating cluster spec: %v", err)
 	}
-
 	if err := p.validator.ValidateControlPlaneEndpointUniqueness(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host); err != nil {
-		return fmt.Errorf("validating control plane endpoint uniqueness: %v", err)
+		endpointErr = fmt.Errorf("validating control plane endpoint uniqueness: %v", err)
+	}
+	if err := validations.AggregateErrors(specErr, endpointErr); err != nil {
+		return err
 	}
 
 	if err := p.setupSSHAuthKeysForCreate(); err != nil {
 		return fmt.Errorf("setting up SSH keys: %v", err)
 	}
 
-	if clusterSpec.Cluster.IsManaged() {
-		for _, mc := range p.MachineConfigs(clusterSpec) {
-			em, err := p.providerKubectlClient.SearchCloudStackMachineConfig(ctx, mc.GetName(), clusterSpec.ManagementCluster.KubeconfigFile, mc.GetNamespace())
-			if err != nil {
-				return err
-			}
-			if len(em) > 0 {
-				return fmt.Errorf("CloudStackMachineConfig %s already exists", mc.GetName())
-			}
-		}
-		existingDatacenter, err := p.providerKubectlClient.SearchCloudStackDatacenterConfig(ctx, clusterSpec.CloudStackDatacenter.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace)
+	return p.validateManagedClusterObjectsDontExist(ctx, clusterSpec)
+}
+
+// validateManagedClusterObjectsDontExist checks the provider objects of a new workload cluster
+// don't exist in its management cluster yet.
+func (p *cloudstackProvider) validateManagedClusterObjectsDontExist(ctx context.Context, clusterSpec *cluster.Spec) error {
+	if !clusterSpec.Cluster.IsManaged() {
+		return nil
+	}
+
+	for _, mc := range p.MachineConfigs(clusterSpec) {
+		em, err := p.providerKubectlClient.SearchCloudStackMachineConfig(ctx, mc.GetName(), clusterSpec.ManagementCluster.KubeconfigFile, mc.GetNamespace())
 		if err != nil {
 			return err
 		}
-		if len(existingDatacenter) > 0 {
-			return fmt.Errorf("CloudStackDatacenter %s already exists", clusterSpec.CloudStackDatacenter.Name)
+		if len(em) > 0 {
+			return fmt.Errorf("CloudStackMachineConfig %s already exists", mc.GetName())
 		}
 	}
+	existingDatacenter, err := p.providerKubectlClient.SearchCloudStackDatacenterConfig(ctx, clusterSpec.CloudStackDatacenter.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace)
+	if err != nil {
+		return err
+	}
+	if len(existingDatacenter) > 0 {
+		return fmt.Errorf("CloudStackDatacenter %s already exists", clusterSpec.CloudStackDatacenter.Name)
+	}
 
 	return nil
 }
@@ -495,15 +510,15 @@ func (p *cloudstackProvider) SetupAndValidateUpgradeCluster(ctx context.Context,
 		return fmt.Errorf("setting up SSH keys: %v", err)
 	}
 
+	var uniquenessErr, secretsErr error
 	if err := p.validateMachineConfigsNameUniqueness(ctx, cluster, clusterSpec); err != nil {
-		return fmt.Errorf("failed validate machineconfig uniqueness: %v", err)
+		uniquenessErr = fmt.Errorf("failed validate machineconfig uniqueness: %v", err)
 	}
-
 	if err := p.validateSecretsUnchanged(ctx, cluster); err != nil {
-		return fmt.Errorf("validating secrets unchanged: %v", err)
+		secretsErr = fmt.Errorf("validating secrets unchanged: %v", err)
 	}
 
-	return nil
+	return validations.AggregateErrors(uniquenessErr, secretsErr)
 }
 
 func (p *cloudstackProvider) SetupAndValidateDeleteCluster(ctx context.Context, _ *types.Cluster, _ *cluster.Spec) error {
@@ -751,10 +766,13 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
-		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+		Append(clusterapi.KubeletServingCertificateExtraArgs(clusterSpec.Cluster)).
+		Append(clusterapi.ImageCredentialProviderExtraArgs(clusterSpec.Cluster))
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
+		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster)).
 		Append(sharedExtraArgs)
 	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
@@ -764,6 +782,8 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		"controlPlaneEndpointHost":                   host,
 		"controlPlaneEndpointPort":                   port,
 		"controlPlaneReplicas":                       clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count,
+		"apiServerCertSANs":                          clusterSpec.Cluster.Spec.ControlPlaneConfiguration.CertSANs,
+		"staticPodManifests":                         clusterapi.StaticPodManifestFiles(clusterSpec.Cluster.Spec.ControlPlaneConfiguration),
 		"kubernetesRepository":                       bundle.KubeDistro.Kubernetes.Repository,
 		"kubernetesVersion":                          bundle.KubeDistro.Kubernetes.Tag,
 		"etcdRepository":                             bundle.KubeDistro.Etcd.Repository,
@@ -801,6 +821,9 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		"serviceCidrs":                               clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"apiserverExtraArgs":                         apiServerExtraArgs.ToPartialYaml(),
 		"kubeletExtraArgs":                           kubeletExtraArgs.ToPartialYaml(),
+		"credentialProviderFiles":                    clusterapi.ImageCredentialProviderConfigFiles(clusterSpec.Cluster),
+		"etcdEncryptionFiles":                        clusterapi.EtcdEncryptionConfigFiles(clusterSpec.Cluster),
+		"etcdEncryptionVolumes":                      clusterapi.EtcdEncryptionExtraVolumes(clusterSpec.Cluster, v1alpha1.RedHat),
 		"etcdExtraArgs":                              etcdExtraArgs.ToPartialYaml(),
 		"etcdCipherSuites":                           crypto.SecureCipherSuitesString(),
 		"controllermanagerExtraArgs":                 controllerManagerExtraArgs.ToPartialYaml(),
@@ -810,6 +833,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		"etcdImage":                                  bundle.KubeDistro.EtcdImage.VersionedImage(),
 		"eksaSystemNamespace":                        constants.EksaSystemNamespace,
 	}
+	common.SetKubeVipValues(values, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint)
 
 	auditPolicy, err := common.GetAuditPolicy(clusterSpec.Cluster.Spec.KubernetesVersion)
 	if err != nil {
@@ -827,6 +851,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		values["registryMirrorConfiguration"] = clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint
 		if len(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent) > 0 {
 			values["registryCACert"] = clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent
+			values["registryCACertTrustCommand"] = clusterapi.RegistryMirrorCACertTrustCommand(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint)
 		}
 	}
 
@@ -843,6 +868,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 	if len(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints) > 0 {
 		values["controlPlaneTaints"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints
 	}
+	values["controlPlaneSchedulable"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Schedulable
 
 	if clusterSpec.AWSIamConfig != nil {
 		values["awsIamAuth"] = true
@@ -897,7 +923,9 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 	format := "cloud-config"
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
-		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
+		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
+		Append(clusterapi.KubeletServingCertificateExtraArgs(clusterSpec.Cluster)).
+		Append(clusterapi.ImageCredentialProviderExtraArgs(clusterSpec.Cluster))
 
 	values := map[string]interface{}{
 		"clusterName":                      clusterSpec.Cluster.Name,
@@ -916,6 +944,7 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 		"cloudstackWorkerSshAuthorizedKey": workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys[0],
 		"format":                           format,
 		"kubeletExtraArgs":                 kubeletExtraArgs.ToPartialYaml(),
+		"credentialProviderFiles":          clusterapi.ImageCredentialProviderConfigFiles(clusterSpec.Cluster),
 		"eksaSystemNamespace":              constants.EksaSystemNamespace,
 		"workerNodeGroupName":              fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name),
 		"workerNodeGroupTaints":            workerNodeGroupConfiguration.Taints,
@@ -927,6 +956,7 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 		values["registryMirrorConfiguration"] = clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint
 		if len(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent) > 0 {
 			values["registryCACert"] = clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent
+			values["registryCACertTrustCommand"] = clusterapi.RegistryMirrorCACertTrustCommand(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint)
 		}
 	}
 
@@ -1132,18 +1162,12 @@ func (p *cloudstackProvider) GenerateCAPISpecForCreate(ctx context.Context, _ *t
 }
 
 func (p *cloudstackProvider) machineConfigsSpecChanged(ctx context.Context, cc *v1alpha1.Cluster, cluster *types.Cluster, newClusterSpec *cluster.Spec) (bool, error) {
-	machineConfigMap := make(map[string]*v1alpha1.CloudStackMachineConfig)
-	for _, config := range p.MachineConfigs(nil) {
-		mc := config.(*v1alpha1.CloudStackMachineConfig)
-		machineConfigMap[mc.Name] = mc
-	}
-
 	for _, oldMcRef := range cc.MachineConfigRefs() {
 		existingCsmc, err := p.providerKubectlClient.GetEksaCloudStackMachineConfig(ctx, oldMcRef.Name, cluster.KubeconfigFile, newClusterSpec.Cluster.Namespace)
 		if err != nil {
 			return false, err
 		}
-		csmc, ok := machineConfigMap[oldMcRef.Name]
+		csmc, ok := newClusterSpec.CloudStackMachineConfigs[oldMcRef.Name]
 		if !ok {
 			p.log.V(3).Info(fmt.Sprintf("Old machine config spec %s not found in the existing spec", oldMcRef.Name))
 			return true, nil
@@ -1265,6 +1289,10 @@ func (p *cloudstackProvider) DeleteResources(ctx context.Context, clusterSpec *c
 	return p.providerKubectlClient.DeleteEksaCloudStackDatacenterConfig(ctx, clusterSpec.CloudStackDatacenter.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.CloudStackDatacenter.Namespace)
 }
 
+func (p *cloudstackProvider) CleanupOrphanedResources(_ context.Context, _ *types.Cluster, _ *cluster.Spec) error {
+	return providers.ErrOrphanCleanupNotSupported
+}
+
 func (p *cloudstackProvider) PostClusterDeleteValidate(_ context.Context, _ *types.Cluster) error {
 	// No validations
 	return nil
@@ -0,0 +1,66 @@
+package cloudstack
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/conformance"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestProviderConformance(t *testing.T) {
+	conformance.Run(t, conformance.Subject{
+		New: func(t *testing.T) (providers.Provider, *cluster.Spec) {
+			setupContext(t)
+			ctrl := gomock.NewController(t)
+			spec := givenClusterSpec(t, testClusterConfigMainFilename)
+			kubectl := mocks.NewMockProviderKubectlClient(ctrl)
+			kubectl.EXPECT().GetEksaCloudStackDatacenterConfig(gomock.Any(), spec.CloudStackDatacenter.Name, gomock.Any(), gomock.Any()).
+				Return(spec.CloudStackDatacenter.DeepCopy(), nil).AnyTimes()
+			for name, m := range spec.CloudStackMachineConfigs {
+				kubectl.EXPECT().GetEksaCloudStackMachineConfig(gomock.Any(), name, gomock.Any(), gomock.Any()).
+					Return(m.DeepCopy(), nil).AnyTimes()
+			}
+
+			return newProvider(t, spec.CloudStackDatacenter, spec.CloudStackMachineConfigs, spec.Cluster, kubectl, givenWildcardCmk(ctrl)), spec
+		},
+		Cluster: &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"},
+		UpgradeTriggers: map[string]func(*cluster.Spec){
+			"new availability zone network": func(s *cluster.Spec) {
+				s.CloudStackDatacenter.Spec.AvailabilityZones[0].Zone.Network.Name = "new-network"
+			},
+			"new machine template": func(s *cluster.Spec) {
+				s.CloudStackMachineConfigs[s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec.Template.Name = "new-template"
+			},
+		},
+		VersionBump: func(s *cluster.Spec) {
+			s.VersionsBundle.CloudStack.Version = "v9.9.9"
+		},
+		SnapshotDir: "testdata/snapshots",
+		SnapshotMatrix: conformance.Matrix{
+			"controlplane": {
+				"single": func(s *cluster.Spec) { s.Cluster.Spec.ControlPlaneConfiguration.Count = 1 },
+				"ha":     func(s *cluster.Spec) { s.Cluster.Spec.ControlPlaneConfiguration.Count = 3 },
+			},
+			"workers": {
+				"untainted": func(s *cluster.Spec) { s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Taints = nil },
+				"tainted": func(s *cluster.Spec) {
+					s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Taints = []corev1.Taint{{Key: "dedicated", Value: "cloudstack", Effect: corev1.TaintEffectNoSchedule}}
+				},
+			},
+		},
+		InvalidSpecs: map[string]func(*cluster.Spec){
+			"control plane endpoint without host": func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = ""
+			},
+			"unknown control plane machine config": func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name = "unknown"
+			},
+		},
+	})
+}
@@ -0,0 +1,139 @@
+package cloudstack
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta2"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	yamlcapi "github.com/aws/eks-anywhere/pkg/clusterapi/yaml"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+// ControlPlane represents a CAPI CloudStack control plane.
+type ControlPlane = clusterapi.ControlPlane[*cloudstackv1.CloudStackCluster, *cloudstackv1.CloudStackMachineTemplate]
+
+// ControlPlaneSpec builds a CloudStack ControlPlane definition based on an eks-a cluster spec.
+// It talks to the cluster with a client to detect changes in the immutable machine templates and
+// generates new names for them.
+func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*ControlPlane, error) {
+	if controlPlaneMachineConfig(spec) == nil {
+		return nil, errors.New("control plane CloudStackMachineConfig not found in cluster spec")
+	}
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil && etcdMachineConfig(spec) == nil {
+		return nil, errors.New("etcd CloudStackMachineConfig not found in cluster spec")
+	}
+
+	templateBuilder := templateBuilderForSpec(spec)
+
+	controlPlaneYaml, err := templateBuilder.GenerateCAPISpecControlPlane(
+		spec,
+		func(values map[string]interface{}) {
+			values[cpTemplateNameKey] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+			values[etcdTemplateNameKey] = clusterapi.EtcdMachineTemplateName(spec.Cluster)
+			values["cloudstackControlPlaneSshAuthorizedKey"] = sshAuthorizedKey(controlPlaneMachineConfig(spec))
+			values["cloudstackEtcdSshAuthorizedKey"] = sshAuthorizedKey(etcdMachineConfig(spec))
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating cloudstack control plane yaml spec")
+	}
+
+	parser, builder, err := yamlcapi.NewControlPlaneParserAndBuilder(
+		logger,
+		yamlutil.NewMapping(
+			"CloudStackCluster",
+			func() *cloudstackv1.CloudStackCluster {
+				return &cloudstackv1.CloudStackCluster{}
+			},
+		),
+		machineTemplateMapping(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "building cloudstack control plane parser")
+	}
+
+	if err = parser.Parse(controlPlaneYaml, builder); err != nil {
+		return nil, errors.Wrap(err, "parsing cloudstack control plane yaml")
+	}
+
+	cp := builder.ControlPlane
+	if err = cp.UpdateImmutableObjectNames(ctx, client, getMachineTemplate, machineTemplateEqual); err != nil {
+		return nil, errors.Wrap(err, "updating cloudstack immutable object names")
+	}
+
+	return cp, nil
+}
+
+// templateBuilderForSpec builds a template builder with the machine configs of the spec.
+func templateBuilderForSpec(spec *cluster.Spec) *CloudStackTemplateBuilder {
+	workerMachineSpecs := make(map[string]v1alpha1.CloudStackMachineConfigSpec, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, w := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if m, ok := spec.CloudStackMachineConfigs[w.MachineGroupRef.Name]; ok {
+			workerMachineSpecs[w.MachineGroupRef.Name] = m.Spec
+		}
+	}
+
+	var controlPlaneMachineSpec, etcdMachineSpec *v1alpha1.CloudStackMachineConfigSpec
+	if m := controlPlaneMachineConfig(spec); m != nil {
+		controlPlaneMachineSpec = &m.Spec
+	}
+	if m := etcdMachineConfig(spec); m != nil {
+		etcdMachineSpec = &m.Spec
+	}
+
+	// The template builder reads the datacenter config from the spec, so it doesn't need it.
+	return NewCloudStackTemplateBuilder(nil, controlPlaneMachineSpec, etcdMachineSpec, workerMachineSpecs, time.Now).(*CloudStackTemplateBuilder)
+}
+
+func controlPlaneMachineConfig(spec *cluster.Spec) *v1alpha1.CloudStackMachineConfig {
+	if spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef == nil {
+		return nil
+	}
+	return spec.CloudStackMachineConfigs[spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+}
+
+func etcdMachineConfig(spec *cluster.Spec) *v1alpha1.CloudStackMachineConfig {
+	etcd := spec.Cluster.Spec.ExternalEtcdConfiguration
+	if etcd == nil || etcd.MachineGroupRef == nil {
+		return nil
+	}
+	return spec.CloudStackMachineConfigs[etcd.MachineGroupRef.Name]
+}
+
+// sshAuthorizedKey returns the first ssh key of the first user of the machine config, if any.
+func sshAuthorizedKey(machineConfig *v1alpha1.CloudStackMachineConfig) string {
+	if machineConfig == nil || len(machineConfig.Spec.Users) == 0 || len(machineConfig.Spec.Users[0].SshAuthorizedKeys) == 0 {
+		return ""
+	}
+	return machineConfig.Spec.Users[0].SshAuthorizedKeys[0]
+}
+
+func machineTemplateMapping() yamlutil.Mapping[*cloudstackv1.CloudStackMachineTemplate] {
+	return yamlutil.NewMapping(
+		"CloudStackMachineTemplate",
+		func() *cloudstackv1.CloudStackMachineTemplate {
+			return &cloudstackv1.CloudStackMachineTemplate{}
+		},
+	)
+}
+
+func getMachineTemplate(ctx context.Context, client kubernetes.Client, name, namespace string) (*cloudstackv1.CloudStackMachineTemplate, error) {
+	m := &cloudstackv1.CloudStackMachineTemplate{}
+	if err := client.Get(ctx, name, namespace, m); err != nil {
+		return nil, errors.Wrap(err, "reading cloudstackMachineTemplate")
+	}
+
+	return m, nil
+}
+
+func machineTemplateEqual(new, old *cloudstackv1.CloudStackMachineTemplate) bool {
+	return equality.Semantic.DeepDerivative(new.Spec, old.Spec)
+}
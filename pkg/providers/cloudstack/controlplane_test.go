@@ -0,0 +1,47 @@
+package cloudstack
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+)
+
+func TestControlPlaneSpecNewCluster(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := givenClusterSpec(t, testClusterConfigMainFilename)
+
+	cp, err := ControlPlaneSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp).NotTo(BeNil())
+	g.Expect(cp.Cluster.Name).To(Equal("test"))
+	g.Expect(cp.ProviderCluster.Name).To(Equal("test"))
+	g.Expect(cp.KubeadmControlPlane.Name).To(Equal("test"))
+	g.Expect(cp.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("test-control-plane-1"))
+	g.Expect(cp.ControlPlaneMachineTemplate.Name).To(Equal("test-control-plane-1"))
+	g.Expect(cp.EtcdCluster).NotTo(BeNil())
+	g.Expect(cp.EtcdMachineTemplate.Name).To(Equal("test-etcd-1"))
+}
+
+func TestControlPlaneSpecMissingControlPlaneMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	spec := givenClusterSpec(t, testClusterConfigMainFilename)
+	spec.CloudStackMachineConfigs = nil
+
+	_, err := ControlPlaneSpec(context.Background(), test.NewNullLogger(), test.NewFakeKubeClient(), spec)
+	g.Expect(err).To(MatchError(ContainSubstring("control plane CloudStackMachineConfig not found")))
+}
+
+func TestControlPlaneSpecNoKubeVersion(t *testing.T) {
+	g := NewWithT(t)
+	spec := givenClusterSpec(t, testClusterConfigMainFilename)
+	spec.Cluster.Spec.KubernetesVersion = ""
+
+	_, err := ControlPlaneSpec(context.Background(), test.NewNullLogger(), test.NewFakeKubeClient(), spec)
+	g.Expect(err).To(MatchError(ContainSubstring("generating cloudstack control plane yaml spec")))
+}
@@ -41,6 +41,34 @@ func (m *MockProviderCmkClient) EXPECT() *MockProviderCmkClientMockRecorder {
 	return m.recorder
 }
 
+// CreateVirtualMachineTags mocks base method.
+func (m *MockProviderCmkClient) CreateVirtualMachineTags(arg0 context.Context, arg1, arg2 string, arg3 map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVirtualMachineTags", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVirtualMachineTags indicates an expected call of CreateVirtualMachineTags.
+func (mr *MockProviderCmkClientMockRecorder) CreateVirtualMachineTags(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVirtualMachineTags", reflect.TypeOf((*MockProviderCmkClient)(nil).CreateVirtualMachineTags), arg0, arg1, arg2, arg3)
+}
+
+// DeleteVirtualMachineTags mocks base method.
+func (m *MockProviderCmkClient) DeleteVirtualMachineTags(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVirtualMachineTags", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVirtualMachineTags indicates an expected call of DeleteVirtualMachineTags.
+func (mr *MockProviderCmkClientMockRecorder) DeleteVirtualMachineTags(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVirtualMachineTags", reflect.TypeOf((*MockProviderCmkClient)(nil).DeleteVirtualMachineTags), arg0, arg1, arg2, arg3)
+}
+
 // GetManagementApiEndpoint mocks base method.
 func (m *MockProviderCmkClient) GetManagementApiEndpoint(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +84,21 @@ func (mr *MockProviderCmkClientMockRecorder) GetManagementApiEndpoint(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagementApiEndpoint", reflect.TypeOf((*MockProviderCmkClient)(nil).GetManagementApiEndpoint), arg0)
 }
 
+// GetVirtualMachineTags mocks base method.
+func (m *MockProviderCmkClient) GetVirtualMachineTags(arg0 context.Context, arg1 string, arg2 []string) (map[string]map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVirtualMachineTags", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVirtualMachineTags indicates an expected call of GetVirtualMachineTags.
+func (mr *MockProviderCmkClientMockRecorder) GetVirtualMachineTags(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVirtualMachineTags", reflect.TypeOf((*MockProviderCmkClient)(nil).GetVirtualMachineTags), arg0, arg1, arg2)
+}
+
 // ValidateAccountPresent mocks base method.
 func (m *MockProviderCmkClient) ValidateAccountPresent(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -368,6 +411,21 @@ func (mr *MockProviderKubectlClientMockRecorder) GetMachineDeployment(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineDeployment", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetMachineDeployment), varargs...)
 }
 
+// GetMachines mocks base method.
+func (m *MockProviderKubectlClient) GetMachines(arg0 context.Context, arg1 *types.Cluster, arg2 string) ([]types.Machine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachines", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.Machine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachines indicates an expected call of GetMachines.
+func (mr *MockProviderKubectlClientMockRecorder) GetMachines(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachines", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetMachines), arg0, arg1, arg2)
+}
+
 // GetSecretFromNamespace mocks base method.
 func (m *MockProviderKubectlClient) GetSecretFromNamespace(arg0 context.Context, arg1, arg2, arg3 string) (*v1.Secret, error) {
 	m.ctrl.T.Helper()
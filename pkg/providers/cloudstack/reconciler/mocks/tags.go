@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/providers/cloudstack/tags.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cloudstack "github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	decoder "github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTagsCmkClient is a mock of TagsCmkClient interface.
+type MockTagsCmkClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagsCmkClientMockRecorder
+}
+
+// MockTagsCmkClientMockRecorder is the mock recorder for MockTagsCmkClient.
+type MockTagsCmkClientMockRecorder struct {
+	mock *MockTagsCmkClient
+}
+
+// NewMockTagsCmkClient creates a new mock instance.
+func NewMockTagsCmkClient(ctrl *gomock.Controller) *MockTagsCmkClient {
+	mock := &MockTagsCmkClient{ctrl: ctrl}
+	mock.recorder = &MockTagsCmkClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagsCmkClient) EXPECT() *MockTagsCmkClientMockRecorder {
+	return m.recorder
+}
+
+// CreateVirtualMachineTags mocks base method.
+func (m *MockTagsCmkClient) CreateVirtualMachineTags(ctx context.Context, profile, id string, tags map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVirtualMachineTags", ctx, profile, id, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVirtualMachineTags indicates an expected call of CreateVirtualMachineTags.
+func (mr *MockTagsCmkClientMockRecorder) CreateVirtualMachineTags(ctx, profile, id, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVirtualMachineTags", reflect.TypeOf((*MockTagsCmkClient)(nil).CreateVirtualMachineTags), ctx, profile, id, tags)
+}
+
+// DeleteVirtualMachineTags mocks base method.
+func (m *MockTagsCmkClient) DeleteVirtualMachineTags(ctx context.Context, profile, id string, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVirtualMachineTags", ctx, profile, id, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVirtualMachineTags indicates an expected call of DeleteVirtualMachineTags.
+func (mr *MockTagsCmkClientMockRecorder) DeleteVirtualMachineTags(ctx, profile, id, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVirtualMachineTags", reflect.TypeOf((*MockTagsCmkClient)(nil).DeleteVirtualMachineTags), ctx, profile, id, keys)
+}
+
+// GetVirtualMachineTags mocks base method.
+func (m *MockTagsCmkClient) GetVirtualMachineTags(ctx context.Context, profile string, ids []string) (map[string]map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVirtualMachineTags", ctx, profile, ids)
+	ret0, _ := ret[0].(map[string]map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVirtualMachineTags indicates an expected call of GetVirtualMachineTags.
+func (mr *MockTagsCmkClientMockRecorder) GetVirtualMachineTags(ctx, profile, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVirtualMachineTags", reflect.TypeOf((*MockTagsCmkClient)(nil).GetVirtualMachineTags), ctx, profile, ids)
+}
+
+// MockTagsClientRegistry is a mock of TagsClientRegistry interface.
+type MockTagsClientRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagsClientRegistryMockRecorder
+}
+
+// MockTagsClientRegistryMockRecorder is the mock recorder for MockTagsClientRegistry.
+type MockTagsClientRegistryMockRecorder struct {
+	mock *MockTagsClientRegistry
+}
+
+// NewMockTagsClientRegistry creates a new mock instance.
+func NewMockTagsClientRegistry(ctrl *gomock.Controller) *MockTagsClientRegistry {
+	mock := &MockTagsClientRegistry{ctrl: ctrl}
+	mock.recorder = &MockTagsClientRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagsClientRegistry) EXPECT() *MockTagsClientRegistryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockTagsClientRegistry) Get(execConfig *decoder.CloudStackExecConfig) (cloudstack.TagsCmkClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", execConfig)
+	ret0, _ := ret[0].(cloudstack.TagsCmkClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTagsClientRegistryMockRecorder) Get(execConfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTagsClientRegistry)(nil).Get), execConfig)
+}
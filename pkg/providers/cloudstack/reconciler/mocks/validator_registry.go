@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/providers/cloudstack/validator_registry.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	executables "github.com/aws/eks-anywhere/pkg/executables"
+	filewriter "github.com/aws/eks-anywhere/pkg/filewriter"
+	cloudstack "github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	decoder "github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProviderValidator is a mock of ProviderValidator interface.
+type MockProviderValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderValidatorMockRecorder
+}
+
+// MockProviderValidatorMockRecorder is the mock recorder for MockProviderValidator.
+type MockProviderValidatorMockRecorder struct {
+	mock *MockProviderValidator
+}
+
+// NewMockProviderValidator creates a new mock instance.
+func NewMockProviderValidator(ctrl *gomock.Controller) *MockProviderValidator {
+	mock := &MockProviderValidator{ctrl: ctrl}
+	mock.recorder = &MockProviderValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProviderValidator) EXPECT() *MockProviderValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateCloudStackDatacenterConfig mocks base method.
+func (m *MockProviderValidator) ValidateCloudStackDatacenterConfig(ctx context.Context, datacenterConfig *v1alpha1.CloudStackDatacenterConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCloudStackDatacenterConfig", ctx, datacenterConfig)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateCloudStackDatacenterConfig indicates an expected call of ValidateCloudStackDatacenterConfig.
+func (mr *MockProviderValidatorMockRecorder) ValidateCloudStackDatacenterConfig(ctx, datacenterConfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCloudStackDatacenterConfig", reflect.TypeOf((*MockProviderValidator)(nil).ValidateCloudStackDatacenterConfig), ctx, datacenterConfig)
+}
+
+// ValidateClusterMachineConfigs mocks base method.
+func (m *MockProviderValidator) ValidateClusterMachineConfigs(ctx context.Context, cloudStackClusterSpec *cloudstack.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateClusterMachineConfigs", ctx, cloudStackClusterSpec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateClusterMachineConfigs indicates an expected call of ValidateClusterMachineConfigs.
+func (mr *MockProviderValidatorMockRecorder) ValidateClusterMachineConfigs(ctx, cloudStackClusterSpec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateClusterMachineConfigs", reflect.TypeOf((*MockProviderValidator)(nil).ValidateClusterMachineConfigs), ctx, cloudStackClusterSpec)
+}
+
+// MockValidatorRegistry is a mock of ValidatorRegistry interface.
+type MockValidatorRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidatorRegistryMockRecorder
+}
+
+// MockValidatorRegistryMockRecorder is the mock recorder for MockValidatorRegistry.
+type MockValidatorRegistryMockRecorder struct {
+	mock *MockValidatorRegistry
+}
+
+// NewMockValidatorRegistry creates a new mock instance.
+func NewMockValidatorRegistry(ctrl *gomock.Controller) *MockValidatorRegistry {
+	mock := &MockValidatorRegistry{ctrl: ctrl}
+	mock.recorder = &MockValidatorRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidatorRegistry) EXPECT() *MockValidatorRegistryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockValidatorRegistry) Get(execConfig *decoder.CloudStackExecConfig) (cloudstack.ProviderValidator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", execConfig)
+	ret0, _ := ret[0].(cloudstack.ProviderValidator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockValidatorRegistryMockRecorder) Get(execConfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockValidatorRegistry)(nil).Get), execConfig)
+}
+
+// MockCmkBuilder is a mock of CmkBuilder interface.
+type MockCmkBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockCmkBuilderMockRecorder
+}
+
+// MockCmkBuilderMockRecorder is the mock recorder for MockCmkBuilder.
+type MockCmkBuilderMockRecorder struct {
+	mock *MockCmkBuilder
+}
+
+// NewMockCmkBuilder creates a new mock instance.
+func NewMockCmkBuilder(ctrl *gomock.Controller) *MockCmkBuilder {
+	mock := &MockCmkBuilder{ctrl: ctrl}
+	mock.recorder = &MockCmkBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCmkBuilder) EXPECT() *MockCmkBuilderMockRecorder {
+	return m.recorder
+}
+
+// BuildCmkExecutable mocks base method.
+func (m *MockCmkBuilder) BuildCmkExecutable(writer filewriter.FileWriter, configs []decoder.CloudStackProfileConfig) *executables.Cmk {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildCmkExecutable", writer, configs)
+	ret0, _ := ret[0].(*executables.Cmk)
+	return ret0
+}
+
+// BuildCmkExecutable indicates an expected call of BuildCmkExecutable.
+func (mr *MockCmkBuilderMockRecorder) BuildCmkExecutable(writer, configs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildCmkExecutable", reflect.TypeOf((*MockCmkBuilder)(nil).BuildCmkExecutable), writer, configs)
+}
@@ -0,0 +1,267 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+const defaultVerifySsl = "true"
+
+// CNIReconciler is an interface for reconciling CNI in the CloudStack cluster reconciler.
+type CNIReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
+// BootstrapManifestsReconciler applies the bootstrap manifests of a cluster.
+type BootstrapManifestsReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
+// RemoteClientRegistry is an interface that defines methods for remote clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// Reconciler reconciles CloudStack clusters.
+type Reconciler struct {
+	client               client.Client
+	validatorRegistry    cloudstack.ValidatorRegistry
+	tagsRegistry         cloudstack.TagsClientRegistry
+	cniReconciler        CNIReconciler
+	manifestsReconciler  BootstrapManifestsReconciler
+	remoteClientRegistry RemoteClientRegistry
+	*serverside.ObjectApplier
+}
+
+// New defines a new CloudStack reconciler.
+func New(client client.Client, validatorRegistry cloudstack.ValidatorRegistry, tagsRegistry cloudstack.TagsClientRegistry, cniReconciler CNIReconciler, manifestsReconciler BootstrapManifestsReconciler, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		validatorRegistry:    validatorRegistry,
+		tagsRegistry:         tagsRegistry,
+		cniReconciler:        cniReconciler,
+		manifestsReconciler:  manifestsReconciler,
+		remoteClientRegistry: remoteClientRegistry,
+		ObjectApplier:        serverside.NewObjectApplier(client),
+	}
+}
+
+// ExecConfig builds the CloudStack profiles of the availability zones of the datacenter config from the
+// credentials Secrets in the eksa-system namespace, named after their credentialsRef.
+func ExecConfig(ctx context.Context, cli client.Client, datacenterConfig *anywherev1.CloudStackDatacenterConfig) (*decoder.CloudStackExecConfig, error) {
+	execConfig := &decoder.CloudStackExecConfig{}
+	added := map[string]bool{}
+	for _, az := range datacenterConfig.Spec.AvailabilityZones {
+		if added[az.CredentialsRef] {
+			continue
+		}
+		added[az.CredentialsRef] = true
+
+		secret := &apiv1.Secret{}
+		key := client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: az.CredentialsRef}
+		if err := cli.Get(ctx, key, secret); err != nil {
+			return nil, fmt.Errorf("failed getting cloudstack credentials secret %s: %v", az.CredentialsRef, err)
+		}
+
+		verifySsl := string(secret.Data["verify-ssl"])
+		if verifySsl == "" {
+			verifySsl = defaultVerifySsl
+		}
+		secretKey := string(secret.Data["secret-key"])
+		logger.RegisterSecrets(secretKey)
+
+		execConfig.Profiles = append(execConfig.Profiles, decoder.CloudStackProfileConfig{
+			Name:          az.CredentialsRef,
+			ApiKey:        string(secret.Data["api-key"]),
+			SecretKey:     secretKey,
+			ManagementUrl: string(secret.Data["api-url"]),
+			VerifySsl:     verifySsl,
+		})
+	}
+
+	return execConfig, nil
+}
+
+// Reconcile reconciles the cluster to the desired state defined in its spec.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	log = log.WithValues("provider", "cloudstack")
+	clusterSpec, err := c.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.NewPhaseRunner().Register(
+		r.ValidateDatacenterConfig,
+		r.ValidateMachineConfigs,
+		r.ReconcileControlPlane,
+		r.CheckControlPlaneReady,
+		r.ReconcileCNI,
+		r.ReconcileBootstrapManifests,
+		r.ReconcileWorkers,
+		r.ReconcileTags,
+	).Run(ctx, log, clusterSpec)
+}
+
+// ValidateDatacenterConfig validates the CloudStackDatacenterConfig against the CloudStack API, updating
+// the cluster status if it's invalid.
+func (r *Reconciler) ValidateDatacenterConfig(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateDatacenterConfig")
+	validator, err := r.validator(ctx, clusterSpec)
+	if err != nil {
+		log.Error(err, "Failed to build CloudStack validator")
+		return controller.Result{}, err
+	}
+
+	if err := validator.ValidateCloudStackDatacenterConfig(ctx, clusterSpec.CloudStackDatacenter); err != nil {
+		log.Error(err, "Invalid CloudStackDatacenterConfig")
+		failureMessage := err.Error()
+		clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+		return controller.Result{}, err
+	}
+	return controller.Result{}, nil
+}
+
+// ValidateMachineConfigs performs additional, context-aware validations on the machine configs.
+func (r *Reconciler) ValidateMachineConfigs(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateMachineConfigs")
+	validator, err := r.validator(ctx, clusterSpec)
+	if err != nil {
+		log.Error(err, "Failed to build CloudStack validator")
+		return controller.Result{}, err
+	}
+
+	cloudstackClusterSpec := cloudstack.NewSpec(clusterSpec, clusterSpec.CloudStackMachineConfigs, clusterSpec.CloudStackDatacenter)
+	if err := validator.ValidateClusterMachineConfigs(ctx, cloudstackClusterSpec); err != nil {
+		log.Error(err, "Invalid CloudStackMachineConfig")
+		failureMessage := err.Error()
+		clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+		return controller.Result{}, err
+	}
+	return controller.Result{}, nil
+}
+
+// ReconcileControlPlane applies the control plane CAPI objects to the cluster.
+func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileControlPlane")
+	log.Info("Applying control plane CAPI objects")
+	return r.Apply(ctx, func() ([]kubernetes.Object, error) {
+		cp, err := cloudstack.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+		if err != nil {
+			return nil, err
+		}
+		return cp.Objects(), nil
+	})
+}
+
+// CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
+// Requeues with the appropriate wait times whenever the cluster is not ready yet.
+func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "checkControlPlaneReady")
+	return clusters.CheckControlPlaneReady(ctx, r.client, log, clusterSpec.Cluster)
+}
+
+// ReconcileCNI takes the Cilium CNI in a cluster to the desired state defined in a cluster spec.
+func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileCNI")
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
+// ReconcileBootstrapManifests applies the bootstrap manifests of the cluster spec once its CNI is ready.
+func (r *Reconciler) ReconcileBootstrapManifests(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileBootstrapManifests")
+	if len(clusterSpec.Cluster.Spec.BootstrapManifests) == 0 {
+		return controller.Result{}, nil
+	}
+
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.manifestsReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
+// ReconcileWorkers applies the worker CAPI objects to the cluster.
+func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileWorkers")
+	log.Info("Applying worker CAPI objects")
+	return r.Apply(ctx, func() ([]kubernetes.Object, error) {
+		w, err := cloudstack.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+		if err != nil {
+			return nil, err
+		}
+		return w.WorkerObjects(), nil
+	})
+}
+
+// ReconcileTags propagates the tags of the cluster spec to the virtual machines of the cluster.
+func (r *Reconciler) ReconcileTags(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileTags")
+	execConfig, err := ExecConfig(ctx, r.client, clusterSpec.CloudStackDatacenter)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	cmk, err := r.tagsRegistry.Get(execConfig)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := r.client.List(ctx, machines,
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{clusterv1.ClusterLabelName: clusterSpec.Cluster.Name},
+	); err != nil {
+		return controller.Result{}, fmt.Errorf("listing machines of cluster: %v", err)
+	}
+
+	ids := make([]string, 0, len(machines.Items))
+	for _, machine := range machines.Items {
+		if id, ok := cloudstack.VirtualMachineID(machine.Spec.ProviderID); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	profiles := make([]string, 0, len(execConfig.Profiles))
+	for _, profile := range execConfig.Profiles {
+		profiles = append(profiles, profile.Name)
+	}
+
+	log.Info("Tagging cluster virtual machines")
+	if err := cloudstack.SyncVirtualMachineTags(ctx, cmk, profiles, ids, clusterSpec.Cluster.Spec.Tags); err != nil {
+		return controller.Result{}, fmt.Errorf("tagging cluster virtual machines: %v", err)
+	}
+
+	return controller.Result{}, nil
+}
+
+func (r *Reconciler) validator(ctx context.Context, clusterSpec *c.Spec) (cloudstack.ProviderValidator, error) {
+	execConfig, err := ExecConfig(ctx, r.client, clusterSpec.CloudStackDatacenter)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.validatorRegistry.Get(execConfig)
+}
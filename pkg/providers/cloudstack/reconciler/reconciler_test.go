@@ -0,0 +1,292 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	clusterspec "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/reconciler"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/reconciler/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func TestExecConfigSuccess(t *testing.T) {
+	g := NewWithT(t)
+	datacenter := cloudStackDatacenter("global", "global", "other")
+	cli := fake.NewClientBuilder().WithObjects(
+		credentialsSecret("global", "true"),
+		credentialsSecret("other", ""),
+	).Build()
+
+	execConfig, err := reconciler.ExecConfig(context.Background(), cli, datacenter)
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(execConfig.Profiles).To(ConsistOf(
+		decoder.CloudStackProfileConfig{
+			Name:          "global",
+			ApiKey:        "api-key-global",
+			SecretKey:     "secret-key-global",
+			ManagementUrl: "https://global:8080/client/api",
+			VerifySsl:     "true",
+		},
+		decoder.CloudStackProfileConfig{
+			Name:          "other",
+			ApiKey:        "api-key-other",
+			SecretKey:     "secret-key-other",
+			ManagementUrl: "https://other:8080/client/api",
+			VerifySsl:     "true",
+		},
+	))
+}
+
+func TestExecConfigMissingSecret(t *testing.T) {
+	g := NewWithT(t)
+	datacenter := cloudStackDatacenter("global")
+	cli := fake.NewClientBuilder().Build()
+
+	_, err := reconciler.ExecConfig(context.Background(), cli, datacenter)
+
+	g.Expect(err).To(MatchError(ContainSubstring("failed getting cloudstack credentials secret global")))
+}
+
+func TestReconcilerValidateDatacenterConfigSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.validatorRegistry.EXPECT().Get(gomock.Any()).Return(tt.validator, nil)
+	tt.validator.EXPECT().ValidateCloudStackDatacenterConfig(tt.ctx, tt.spec.CloudStackDatacenter).Return(nil)
+
+	result, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateDatacenterConfigInvalid(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.validatorRegistry.EXPECT().Get(gomock.Any()).Return(tt.validator, nil)
+	tt.validator.EXPECT().ValidateCloudStackDatacenterConfig(tt.ctx, tt.spec.CloudStackDatacenter).Return(errors.New("zone not found"))
+
+	_, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("zone not found")))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(HaveValue(Equal("zone not found")))
+}
+
+func TestReconcilerValidateDatacenterConfigMissingCredentials(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.client = fake.NewClientBuilder().Build()
+
+	_, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("failed getting cloudstack credentials secret global")))
+}
+
+func TestReconcilerValidateMachineConfigsInvalid(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.validatorRegistry.EXPECT().Get(gomock.Any()).Return(tt.validator, nil)
+	tt.validator.EXPECT().ValidateClusterMachineConfigs(tt.ctx, gomock.Any()).Return(errors.New("template not found"))
+
+	_, err := tt.reconciler().ValidateMachineConfigs(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("template not found")))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(HaveValue(Equal("template not found")))
+}
+
+func TestReconcilerCheckControlPlaneReadyNoCAPICluster(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	result, err := tt.reconciler().CheckControlPlaneReady(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(5 * time.Second)))
+}
+
+func TestReconcilerReconcileCNISuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: constants.EksaSystemNamespace},
+	).Return(remoteClient, nil)
+	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, gomock.Any(), remoteClient, tt.spec)
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileCNIErrorClientRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: constants.EksaSystemNamespace},
+	).Return(nil, errors.New("building client"))
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileBootstrapManifestsSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.spec.Cluster.Spec.BootstrapManifests = []anywherev1.BootstrapManifest{{Name: "manifests", Inline: "apiVersion: v1"}}
+	remoteClient := fake.NewClientBuilder().Build()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: constants.EksaSystemNamespace},
+	).Return(remoteClient, nil)
+	tt.manifestsReconciler.EXPECT().Reconcile(tt.ctx, gomock.Any(), remoteClient, tt.spec)
+
+	result, err := tt.reconciler().ReconcileBootstrapManifests(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileBootstrapManifestsNoManifests(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	result, err := tt.reconciler().ReconcileBootstrapManifests(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileTags(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.spec.Cluster.Spec.Tags = map[string]string{"environment": "prod"}
+	tt.client = fake.NewClientBuilder().WithObjects(
+		credentialsSecret("global", "false"),
+		machine("workload-cluster-cp-1", "workload-cluster", "cloudstack:///vm-1"),
+		machine("workload-cluster-md-0-1", "workload-cluster", ""),
+		machine("other-cluster-cp-1", "other-cluster", "cloudstack:///vm-2"),
+	).Build()
+
+	tt.tagsRegistry.EXPECT().Get(gomock.Any()).Return(tt.tagsClient, nil)
+	tt.tagsClient.EXPECT().GetVirtualMachineTags(tt.ctx, "global", []string{"vm-1"}).Return(
+		map[string]map[string]string{"vm-1": {"environment": "dev", constants.ManagedTagsKey: "environment"}}, nil,
+	)
+	gomock.InOrder(
+		tt.tagsClient.EXPECT().DeleteVirtualMachineTags(tt.ctx, "global", "vm-1", []string{"environment"}),
+		tt.tagsClient.EXPECT().CreateVirtualMachineTags(tt.ctx, "global", "vm-1", map[string]string{"environment": "prod"}),
+	)
+
+	result, err := tt.reconciler().ReconcileTags(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileTagsErrorRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.tagsRegistry.EXPECT().Get(gomock.Any()).Return(nil, errors.New("no CloudStack profiles"))
+
+	_, err := tt.reconciler().ReconcileTags(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError("no CloudStack profiles"))
+}
+
+type reconcilerTest struct {
+	t testing.TB
+	*WithT
+	ctx                  context.Context
+	spec                 *clusterspec.Spec
+	client               client.Client
+	validatorRegistry    *mocks.MockValidatorRegistry
+	validator            *mocks.MockProviderValidator
+	tagsRegistry         *mocks.MockTagsClientRegistry
+	tagsClient           *mocks.MockTagsCmkClient
+	cniReconciler        *mocks.MockCNIReconciler
+	manifestsReconciler  *mocks.MockBootstrapManifestsReconciler
+	remoteClientRegistry *mocks.MockRemoteClientRegistry
+}
+
+func newReconcilerTest(t testing.TB) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	spec := test.NewClusterSpec(func(s *clusterspec.Spec) {
+		s.Cluster.Name = "workload-cluster"
+		s.Cluster.Namespace = "default"
+		s.CloudStackDatacenter = cloudStackDatacenter("global")
+	})
+
+	return &reconcilerTest{
+		t:                    t,
+		WithT:                NewWithT(t),
+		ctx:                  context.Background(),
+		spec:                 spec,
+		client:               fake.NewClientBuilder().WithObjects(credentialsSecret("global", "false")).Build(),
+		validatorRegistry:    mocks.NewMockValidatorRegistry(ctrl),
+		validator:            mocks.NewMockProviderValidator(ctrl),
+		tagsRegistry:         mocks.NewMockTagsClientRegistry(ctrl),
+		tagsClient:           mocks.NewMockTagsCmkClient(ctrl),
+		cniReconciler:        mocks.NewMockCNIReconciler(ctrl),
+		manifestsReconciler:  mocks.NewMockBootstrapManifestsReconciler(ctrl),
+		remoteClientRegistry: mocks.NewMockRemoteClientRegistry(ctrl),
+	}
+}
+
+func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
+	return reconciler.New(tt.client, tt.validatorRegistry, tt.tagsRegistry, tt.cniReconciler, tt.manifestsReconciler, tt.remoteClientRegistry)
+}
+
+func cloudStackDatacenter(credentialsRefs ...string) *anywherev1.CloudStackDatacenterConfig {
+	d := &anywherev1.CloudStackDatacenterConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datacenter",
+			Namespace: "default",
+		},
+	}
+	for i, ref := range credentialsRefs {
+		d.Spec.AvailabilityZones = append(d.Spec.AvailabilityZones, anywherev1.CloudStackAvailabilityZone{
+			Name:           "az-" + string(rune('a'+i)),
+			CredentialsRef: ref,
+		})
+	}
+	return d
+}
+
+func credentialsSecret(name, verifySsl string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			"api-key":    []byte("api-key-" + name),
+			"secret-key": []byte("secret-key-" + name),
+			"api-url":    []byte("https://" + name + ":8080/client/api"),
+			"verify-ssl": []byte(verifySsl),
+		},
+	}
+}
+
+func machine(name, clusterName, providerID string) *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1.ClusterLabelName: clusterName},
+		},
+	}
+	if providerID != "" {
+		m.Spec.ProviderID = ptr.String(providerID)
+	}
+	return m
+}
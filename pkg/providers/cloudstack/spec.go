@@ -1,6 +1,8 @@
 package cloudstack
 
 import (
+	"sort"
+
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 )
@@ -38,3 +40,14 @@ func (s *Spec) etcdMachineConfig() *anywherev1.CloudStackMachineConfig {
 	}
 	return s.machineConfigsLookup[s.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name]
 }
+
+// machineConfigs returns the machine configs used by the cluster sorted by name.
+func (s *Spec) machineConfigs() []*anywherev1.CloudStackMachineConfig {
+	machineConfigs := make([]*anywherev1.CloudStackMachineConfig, 0, len(s.machineConfigsLookup))
+	for _, m := range s.machineConfigsLookup {
+		machineConfigs = append(machineConfigs, m)
+	}
+	sort.Slice(machineConfigs, func(i, j int) bool { return machineConfigs[i].Name < machineConfigs[j].Name })
+
+	return machineConfigs
+}
@@ -0,0 +1,150 @@
+package cloudstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// providerIDPrefix is the prefix CAPC adds to the id of the virtual machine of a machine in its provider id.
+const providerIDPrefix = "cloudstack:///"
+
+// TagsCmkClient manages the tags of CloudStack virtual machines.
+type TagsCmkClient interface {
+	GetVirtualMachineTags(ctx context.Context, profile string, ids []string) (map[string]map[string]string, error)
+	CreateVirtualMachineTags(ctx context.Context, profile, id string, tags map[string]string) error
+	DeleteVirtualMachineTags(ctx context.Context, profile, id string, keys []string) error
+}
+
+// TagsClientRegistry builds clients to manage tags authenticated with a set of CloudStack credentials.
+type TagsClientRegistry interface {
+	Get(execConfig *decoder.CloudStackExecConfig) (TagsCmkClient, error)
+}
+
+// CmkTagsClientRegistry builds cmk clients to manage tags.
+type CmkTagsClientRegistry struct {
+	builder CmkBuilder
+	writer  filewriter.FileWriter
+}
+
+// NewTagsClientRegistry builds a CmkTagsClientRegistry.
+func NewTagsClientRegistry(builder CmkBuilder, writer filewriter.FileWriter) *CmkTagsClientRegistry {
+	return &CmkTagsClientRegistry{
+		builder: builder,
+		writer:  writer,
+	}
+}
+
+// Get returns a cmk client authenticated with the profiles of execConfig.
+func (r *CmkTagsClientRegistry) Get(execConfig *decoder.CloudStackExecConfig) (TagsCmkClient, error) {
+	if execConfig == nil || len(execConfig.Profiles) == 0 {
+		return nil, errors.New("no CloudStack profiles to build a tags client with")
+	}
+
+	return r.builder.BuildCmkExecutable(r.writer, execConfig.Profiles), nil
+}
+
+// VirtualMachineID returns the id of the CloudStack virtual machine of a CAPI machine from its provider id.
+func VirtualMachineID(providerID *string) (string, bool) {
+	if providerID == nil || !strings.HasPrefix(*providerID, providerIDPrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(*providerID, providerIDPrefix), true
+}
+
+// SyncVirtualMachineTags sets tags on the virtual machines with ids, and removes the tags EKS-A set
+// before that are not in tags anymore. Each virtual machine is updated with the first profile it is
+// visible with, since the availability zones of a cluster can use different accounts.
+func SyncVirtualMachineTags(ctx context.Context, cmk TagsCmkClient, profiles []string, ids []string, tags map[string]string) error {
+	pending := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		pending[id] = struct{}{}
+	}
+
+	for _, profile := range profiles {
+		if len(pending) == 0 {
+			return nil
+		}
+
+		vmTags, err := cmk.GetVirtualMachineTags(ctx, profile, sortedIDs(pending))
+		if err != nil {
+			return err
+		}
+
+		for id, current := range vmTags {
+			delete(pending, id)
+			if err := syncVirtualMachineTags(ctx, cmk, profile, id, current, tags); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// TagClusterResources propagates the tags of the cluster spec to the virtual machines of the cluster.
+func (p *cloudstackProvider) TagClusterResources(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	machines, err := p.providerKubectlClient.GetMachines(ctx, managementCluster, clusterSpec.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(machines))
+	for _, machine := range machines {
+		if id, ok := VirtualMachineID(machine.Spec.ProviderID); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	profiles := make([]string, 0, len(p.execConfig.Profiles))
+	for _, profile := range p.execConfig.Profiles {
+		profiles = append(profiles, profile.Name)
+	}
+
+	return SyncVirtualMachineTags(ctx, p.cmk, profiles, ids, clusterSpec.Cluster.Spec.Tags)
+}
+
+func syncVirtualMachineTags(ctx context.Context, cmk TagsCmkClient, profile, id string, current, desired map[string]string) error {
+	set, remove := common.TagsChanges(current, desired)
+	// CloudStack doesn't overwrite the value of a tag, so the tags that change are recreated.
+	for key := range set {
+		if _, ok := current[key]; ok {
+			remove = append(remove, key)
+		}
+	}
+	sort.Strings(remove)
+
+	if len(remove) > 0 {
+		logger.V(4).Info("Removing virtual machine tags", "vm", id, "tags", remove)
+		if err := cmk.DeleteVirtualMachineTags(ctx, profile, id, remove); err != nil {
+			return fmt.Errorf("untagging virtual machine %s: %v", id, err)
+		}
+	}
+	if len(set) > 0 {
+		logger.V(4).Info("Setting virtual machine tags", "vm", id, "tags", set)
+		if err := cmk.CreateVirtualMachineTags(ctx, profile, id, set); err != nil {
+			return fmt.Errorf("tagging virtual machine %s: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+func sortedIDs(ids map[string]struct{}) []string {
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,96 @@
+package cloudstack
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func TestVirtualMachineID(t *testing.T) {
+	g := NewWithT(t)
+	id, ok := VirtualMachineID(ptr.String("cloudstack:///vm-1"))
+	g.Expect(ok).To(BeTrue())
+	g.Expect(id).To(Equal("vm-1"))
+
+	_, ok = VirtualMachineID(nil)
+	g.Expect(ok).To(BeFalse())
+	_, ok = VirtualMachineID(ptr.String("vsphere://vm-1"))
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestSyncVirtualMachineTagsMultipleProfiles(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+	tags := map[string]string{"environment": "prod"}
+
+	cmk.EXPECT().GetVirtualMachineTags(ctx, "az-1", []string{"vm-1", "vm-2", "vm-3"}).Return(
+		map[string]map[string]string{"vm-1": {}}, nil,
+	)
+	cmk.EXPECT().CreateVirtualMachineTags(ctx, "az-1", "vm-1", map[string]string{
+		"environment":            "prod",
+		constants.ManagedTagsKey: "environment",
+	})
+	cmk.EXPECT().GetVirtualMachineTags(ctx, "az-2", []string{"vm-2", "vm-3"}).Return(
+		map[string]map[string]string{"vm-2": {
+			"environment":            "prod",
+			"team":                   "eks",
+			constants.ManagedTagsKey: "environment,team",
+		}}, nil,
+	)
+	gomock.InOrder(
+		cmk.EXPECT().DeleteVirtualMachineTags(ctx, "az-2", "vm-2", []string{constants.ManagedTagsKey, "team"}),
+		cmk.EXPECT().CreateVirtualMachineTags(ctx, "az-2", "vm-2", map[string]string{constants.ManagedTagsKey: "environment"}),
+	)
+
+	g.Expect(SyncVirtualMachineTags(ctx, cmk, []string{"az-1", "az-2"}, []string{"vm-3", "vm-1", "vm-2"}, tags)).To(Succeed())
+}
+
+func TestSyncVirtualMachineTagsError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+
+	cmk.EXPECT().GetVirtualMachineTags(ctx, "az-1", []string{"vm-1"}).Return(map[string]map[string]string{"vm-1": {}}, nil)
+	cmk.EXPECT().CreateVirtualMachineTags(ctx, "az-1", "vm-1", gomock.Any()).Return(errors.New("permission denied"))
+
+	err := SyncVirtualMachineTags(ctx, cmk, []string{"az-1"}, []string{"vm-1"}, map[string]string{"environment": "prod"})
+	g.Expect(err).To(MatchError("tagging virtual machine vm-1: permission denied"))
+}
+
+func TestProviderTagClusterResources(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cmk := mocks.NewMockProviderCmkClient(mockCtrl)
+	clusterConfig := givenClusterConfig(t, testClusterConfigMainFilename)
+	clusterConfig.Spec.Tags = map[string]string{"environment": "prod"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster = clusterConfig
+	})
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterConfig, kubectl, cmk)
+	provider.execConfig = &decoder.CloudStackExecConfig{Profiles: []decoder.CloudStackProfileConfig{{Name: "global"}}}
+	managementCluster := &types.Cluster{Name: "management", KubeconfigFile: "management.kubeconfig"}
+
+	kubectl.EXPECT().GetMachines(ctx, managementCluster, clusterSpec.Cluster.Name).Return([]types.Machine{
+		{Spec: types.MachineSpec{ProviderID: ptr.String("cloudstack:///vm-1")}},
+		{},
+	}, nil)
+	cmk.EXPECT().GetVirtualMachineTags(ctx, "global", []string{"vm-1"}).Return(nil, errors.New("unauthorized"))
+
+	g.Expect(provider.TagClusterResources(ctx, managementCluster, clusterSpec)).To(MatchError("unauthorized"))
+}
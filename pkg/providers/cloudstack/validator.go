@@ -11,8 +11,12 @@ import (
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
+// maxParallelMachineConfigValidations bounds the machine config validations calling CloudStack at the same time.
+const maxParallelMachineConfigValidations = 5
+
 type Validator struct {
 	cmk         ProviderCmkClient
 	netClient   networkutils.NetClient
@@ -56,6 +60,7 @@ type ProviderCmkClient interface {
 	ValidateNetworkPresent(ctx context.Context, profile string, domainId string, network anywherev1.CloudStackResourceIdentifier, zoneId string, account string) error
 	ValidateDomainAndGetId(ctx context.Context, profile string, domain string) (string, error)
 	ValidateAccountPresent(ctx context.Context, profile string, account string, domainId string) error
+	TagsCmkClient
 }
 
 func (v *Validator) validateCloudStackAccess(ctx context.Context, datacenterConfig *anywherev1.CloudStackDatacenterConfig) error {
@@ -195,43 +200,61 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, cloudStac
 		return fmt.Errorf("validating controlPlaneConfiguration.Endpoint.Host: %v", err)
 	}
 
-	for _, machineConfig := range cloudStackClusterSpec.machineConfigsLookup {
-		if machineConfig.Namespace != cloudStackClusterSpec.Cluster.Namespace {
-			return fmt.Errorf(
-				"CloudStackMachineConfig %s and Cluster objects must have the same namespace: CloudStackMachineConfig namespace=%s; Cluster namespace=%s",
-				machineConfig.Name,
-				machineConfig.Namespace,
-				cloudStackClusterSpec.Cluster.Namespace,
-			)
-		}
-		if len(machineConfig.Spec.Users) <= 0 {
-			machineConfig.Spec.Users = []anywherev1.UserConfiguration{{}}
-		}
-		if len(machineConfig.Spec.Users[0].SshAuthorizedKeys) <= 0 {
-			machineConfig.Spec.Users[0].SshAuthorizedKeys = []string{""}
-		}
-		if len(machineConfig.Spec.ComputeOffering.Id) == 0 && len(machineConfig.Spec.ComputeOffering.Name) == 0 {
-			return fmt.Errorf("computeOffering is not set for CloudStackMachineConfig %s. Default computeOffering is not supported in CloudStack, please provide a computeOffering name or ID", machineConfig.Name)
-		}
-		if len(machineConfig.Spec.Template.Id) == 0 && len(machineConfig.Spec.Template.Name) == 0 {
-			return fmt.Errorf("template is not set for CloudStackMachineConfig %s. Default template is not supported in CloudStack, please provide a template name or ID", machineConfig.Name)
-		}
-		if err, fieldName, fieldValue := machineConfig.Spec.DiskOffering.Validate(); err != nil {
-			return fmt.Errorf("machine config %s validation failed: %s: %s invalid, %v", machineConfig.Name, fieldName, fieldValue, err)
-		}
-		if err = v.validateMachineConfig(ctx, cloudStackClusterSpec.datacenterConfig, machineConfig); err != nil {
-			return fmt.Errorf("machine config %s validation failed: %v", machineConfig.Name, err)
-		}
-		if err = v.validateAffinityConfig(machineConfig); err != nil {
+	machineConfigs := cloudStackClusterSpec.machineConfigs()
+	for _, machineConfig := range machineConfigs {
+		if err = v.validateMachineConfigFields(cloudStackClusterSpec, machineConfig); err != nil {
 			return err
 		}
 	}
 
+	checks := make([]func() error, 0, len(machineConfigs))
+	for _, machineConfig := range machineConfigs {
+		machineConfig := machineConfig
+		checks = append(checks, func() error {
+			if err := v.validateMachineConfig(ctx, cloudStackClusterSpec.datacenterConfig, machineConfig); err != nil {
+				return fmt.Errorf("machine config %s validation failed: %v", machineConfig.Name, err)
+			}
+			return nil
+		})
+	}
+	if err = validations.RunConcurrently(maxParallelMachineConfigValidations, checks...); err != nil {
+		return err
+	}
+
 	logger.MarkPass("Validated cluster Machine Configs")
 
 	return nil
 }
 
+// validateMachineConfigFields validates the fields of a machine config that don't need CloudStack, defaulting the users.
+func (v *Validator) validateMachineConfigFields(cloudStackClusterSpec *Spec, machineConfig *anywherev1.CloudStackMachineConfig) error {
+	if machineConfig.Namespace != cloudStackClusterSpec.Cluster.Namespace {
+		return fmt.Errorf(
+			"CloudStackMachineConfig %s and Cluster objects must have the same namespace: CloudStackMachineConfig namespace=%s; Cluster namespace=%s",
+			machineConfig.Name,
+			machineConfig.Namespace,
+			cloudStackClusterSpec.Cluster.Namespace,
+		)
+	}
+	if len(machineConfig.Spec.Users) <= 0 {
+		machineConfig.Spec.Users = []anywherev1.UserConfiguration{{}}
+	}
+	if len(machineConfig.Spec.Users[0].SshAuthorizedKeys) <= 0 {
+		machineConfig.Spec.Users[0].SshAuthorizedKeys = []string{""}
+	}
+	if len(machineConfig.Spec.ComputeOffering.Id) == 0 && len(machineConfig.Spec.ComputeOffering.Name) == 0 {
+		return fmt.Errorf("computeOffering is not set for CloudStackMachineConfig %s. Default computeOffering is not supported in CloudStack, please provide a computeOffering name or ID", machineConfig.Name)
+	}
+	if len(machineConfig.Spec.Template.Id) == 0 && len(machineConfig.Spec.Template.Name) == 0 {
+		return fmt.Errorf("template is not set for CloudStackMachineConfig %s. Default template is not supported in CloudStack, please provide a template name or ID", machineConfig.Name)
+	}
+	if err, fieldName, fieldValue := machineConfig.Spec.DiskOffering.Validate(); err != nil {
+		return fmt.Errorf("machine config %s validation failed: %s: %s invalid, %v", machineConfig.Name, fieldName, fieldValue, err)
+	}
+
+	return v.validateAffinityConfig(machineConfig)
+}
+
 func (v *Validator) ValidateControlPlaneEndpointUniqueness(endpoint string) error {
 	if v.skipIpCheck {
 		logger.Info("Skipping control plane endpoint uniqueness check")
@@ -0,0 +1,56 @@
+package cloudstack
+
+import (
+	"context"
+	"errors"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+// ProviderValidator validates the CloudStack configs of a cluster against the CloudStack API.
+type ProviderValidator interface {
+	ValidateCloudStackDatacenterConfig(ctx context.Context, datacenterConfig *anywherev1.CloudStackDatacenterConfig) error
+	ValidateClusterMachineConfigs(ctx context.Context, cloudStackClusterSpec *Spec) error
+}
+
+// ValidatorRegistry builds validators authenticated with a set of CloudStack credentials.
+type ValidatorRegistry interface {
+	Get(execConfig *decoder.CloudStackExecConfig) (ProviderValidator, error)
+}
+
+// CmkBuilder builds cmk clients authenticated with CloudStack profiles.
+type CmkBuilder interface {
+	BuildCmkExecutable(writer filewriter.FileWriter, configs []decoder.CloudStackProfileConfig) *executables.Cmk
+}
+
+// CmkValidatorRegistry builds validators calling CloudStack with cmk.
+type CmkValidatorRegistry struct {
+	builder     CmkBuilder
+	writer      filewriter.FileWriter
+	netClient   networkutils.NetClient
+	skipIpCheck bool
+}
+
+// NewValidatorRegistry builds a CmkValidatorRegistry.
+func NewValidatorRegistry(builder CmkBuilder, writer filewriter.FileWriter, netClient networkutils.NetClient, skipIpCheck bool) *CmkValidatorRegistry {
+	return &CmkValidatorRegistry{
+		builder:     builder,
+		writer:      writer,
+		netClient:   netClient,
+		skipIpCheck: skipIpCheck,
+	}
+}
+
+// Get returns a Validator with a cmk client authenticated with the profiles of execConfig.
+func (r *CmkValidatorRegistry) Get(execConfig *decoder.CloudStackExecConfig) (ProviderValidator, error) {
+	if execConfig == nil || len(execConfig.Profiles) == 0 {
+		return nil, errors.New("no CloudStack profiles to build a validator with")
+	}
+
+	cmk := r.builder.BuildCmkExecutable(r.writer, execConfig.Profiles)
+	return NewValidator(cmk, r.netClient, r.skipIpCheck), nil
+}
@@ -0,0 +1,42 @@
+package cloudstack
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+type fakeCmkBuilder struct {
+	configs []decoder.CloudStackProfileConfig
+}
+
+func (b *fakeCmkBuilder) BuildCmkExecutable(_ filewriter.FileWriter, configs []decoder.CloudStackProfileConfig) *executables.Cmk {
+	b.configs = configs
+	return &executables.Cmk{}
+}
+
+func TestValidatorRegistryGetSuccess(t *testing.T) {
+	g := NewWithT(t)
+	builder := &fakeCmkBuilder{}
+	registry := NewValidatorRegistry(builder, nil, nil, true)
+	execConfig := &decoder.CloudStackExecConfig{
+		Profiles: []decoder.CloudStackProfileConfig{{Name: "global"}},
+	}
+
+	validator, err := registry.Get(execConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(validator).NotTo(BeNil())
+	g.Expect(builder.configs).To(Equal(execConfig.Profiles))
+}
+
+func TestValidatorRegistryGetNoProfiles(t *testing.T) {
+	g := NewWithT(t)
+	registry := NewValidatorRegistry(&fakeCmkBuilder{}, nil, nil, true)
+
+	_, err := registry.Get(&decoder.CloudStackExecConfig{})
+	g.Expect(err).To(MatchError(ContainSubstring("no CloudStack profiles")))
+}
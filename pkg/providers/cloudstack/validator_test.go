@@ -920,6 +920,37 @@ func TestValidateMachineConfigsHappyCase(t *testing.T) {
 	assert.Equal(t, "1.2.3.4:6443", clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host)
 }
 
+func TestValidateMachineConfigsReportsAllErrors(t *testing.T) {
+	ctx := context.Background()
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+	machineConfigs, err := v1alpha1.GetCloudStackMachineConfigs(path.Join(testDataDir, testClusterConfigMainFilename))
+	if err != nil {
+		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
+	}
+	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
+	if err != nil {
+		t.Fatalf("unable to get datacenter config from file")
+	}
+	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
+	cloudStackClusterSpec := &Spec{
+		Spec:                 clusterSpec,
+		datacenterConfig:     datacenterConfig,
+		machineConfigsLookup: machineConfigs,
+	}
+	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	setupMockForAvailabilityZonesValidation(cmk, ctx, datacenterConfig.Spec.AvailabilityZones)
+
+	cmk.EXPECT().ValidateTemplatePresent(ctx, gomock.Any(), gomock.Any(),
+		gomock.Any(), datacenterConfig.Spec.AvailabilityZones[0].Account, testTemplate).Times(3).Return(errors.New("template not found"))
+
+	_ = validator.ValidateCloudStackDatacenterConfig(ctx, datacenterConfig)
+	err = validator.ValidateClusterMachineConfigs(ctx, cloudStackClusterSpec)
+	assert.EqualError(t, err, "validation failed with 3 errors: "+
+		"machine config test validation failed: validating template: template not found,"+
+		"machine config test-cp validation failed: validating template: template not found,"+
+		"machine config test-etcd validation failed: validating template: template not found")
+}
+
 func TestValidateCloudStackMachineConfig(t *testing.T) {
 	ctx := context.Background()
 	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
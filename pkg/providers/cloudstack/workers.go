@@ -0,0 +1,50 @@
+package cloudstack
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta2"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	capiyaml "github.com/aws/eks-anywhere/pkg/clusterapi/yaml"
+)
+
+// Workers represents the CloudStack specific CAPI spec for worker nodes.
+type Workers = clusterapi.Workers[*cloudstackv1.CloudStackMachineTemplate]
+
+// WorkersSpec generates a CloudStack specific CAPI spec for an eks-a cluster worker nodes.
+// It talks to the cluster with a client to detect changes in immutable objects and generates new
+// names for them.
+func WorkersSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*Workers, error) {
+	machineTemplateNames := make(map[string]string, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	kubeadmConfigTemplateNames := make(map[string]string, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, w := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineTemplateNames[w.Name] = clusterapi.WorkerMachineTemplateName(spec, w)
+		kubeadmConfigTemplateNames[w.Name] = clusterapi.DefaultKubeadmConfigTemplateName(spec, w)
+	}
+
+	workersYaml, err := templateBuilderForSpec(spec).GenerateCAPISpecWorkers(spec, machineTemplateNames, kubeadmConfigTemplateNames)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating cloudstack workers yaml spec")
+	}
+
+	parser, builder, err := capiyaml.NewWorkersParserAndBuilder(logger, machineTemplateMapping())
+	if err != nil {
+		return nil, errors.Wrap(err, "building cloudstack workers parser and builder")
+	}
+
+	if err = parser.Parse(workersYaml, builder); err != nil {
+		return nil, errors.Wrap(err, "parsing cloudstack CAPI workers yaml")
+	}
+
+	workers := builder.Workers
+	if err = workers.UpdateImmutableObjectNames(ctx, client, getMachineTemplate, machineTemplateEqual); err != nil {
+		return nil, errors.Wrap(err, "updating cloudstack worker immutable object names")
+	}
+
+	return workers, nil
+}
@@ -0,0 +1,31 @@
+package cloudstack
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+)
+
+func TestWorkersSpecNewCluster(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := givenClusterSpec(t, "cluster_main_multiple_worker_node_groups.yaml")
+	client := test.NewFakeKubeClient()
+
+	workers, err := WorkersSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers).NotTo(BeNil())
+	g.Expect(workers.Groups).To(HaveLen(2))
+	for _, group := range workers.Groups {
+		g.Expect(group.MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name).To(Equal(group.ProviderMachineTemplate.Name))
+		g.Expect(group.MachineDeployment.Spec.Template.Spec.Bootstrap.ConfigRef.Name).To(Equal(group.KubeadmConfigTemplate.Name))
+	}
+	g.Expect([]string{
+		workers.Groups[0].ProviderMachineTemplate.Name,
+		workers.Groups[1].ProviderMachineTemplate.Name,
+	}).To(ConsistOf("test-md-0-1", "test-md-1-1"))
+}
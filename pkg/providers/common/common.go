@@ -3,6 +3,7 @@ package common
 import (
 	_ "embed"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -69,6 +70,11 @@ func GenerateSSHAuthKey(writer filewriter.FileWriter) (string, error) {
 	return key, nil
 }
 
+// GeneratedSSHPrivateKeyPath returns the path where the private key generated for the nodes of clusterName is written.
+func GeneratedSSHPrivateKeyPath(clusterName string) string {
+	return filepath.Join(clusterName, privateKeyFileName)
+}
+
 func CPMachineTemplateBase(clusterName string) string {
 	return fmt.Sprintf("%s-control-plane-template", clusterName)
 }
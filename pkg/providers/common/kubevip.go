@@ -0,0 +1,23 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// SetKubeVipValues adds the kube-vip leader election and metrics settings of the control plane
+// endpoint to the control plane template values.
+func SetKubeVipValues(values map[string]interface{}, endpoint *v1alpha1.Endpoint) {
+	var kubeVip *v1alpha1.KubeVipConfiguration
+	if endpoint != nil {
+		kubeVip = endpoint.KubeVip
+	}
+
+	values["kubeVipLeaseDuration"] = kubeVip.LeaseDuration()
+	values["kubeVipRenewDeadline"] = kubeVip.RenewDeadline()
+	values["kubeVipRetryPeriod"] = kubeVip.RetryPeriod()
+	if kubeVip != nil && kubeVip.PrometheusPort != 0 {
+		values["kubeVipPrometheusServer"] = fmt.Sprintf(":%d", kubeVip.PrometheusPort)
+	}
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// TagsChanges returns the tags to set on a resource with the current tags so it carries the desired
+// tags of a cluster, and the keys of the tags to remove because EKS-A set them before and they are not
+// desired anymore. The keys of the tags EKS-A manages are recorded in the constants.ManagedTagsKey tag,
+// so tags set on the resource by other means are left untouched.
+func TagsChanges(current, desired map[string]string) (set map[string]string, remove []string) {
+	managed := managedTags(desired)
+
+	set = map[string]string{}
+	for key, value := range managed {
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			set[key] = value
+		}
+	}
+
+	previous, ok := current[constants.ManagedTagsKey]
+	if !ok {
+		return set, nil
+	}
+	if _, ok := managed[constants.ManagedTagsKey]; !ok {
+		remove = append(remove, constants.ManagedTagsKey)
+	}
+	for _, key := range strings.Split(previous, ",") {
+		if _, ok := managed[key]; !ok && key != "" {
+			remove = append(remove, key)
+		}
+	}
+	sort.Strings(remove)
+
+	return set, remove
+}
+
+// managedTags returns the desired tags with the constants.ManagedTagsKey tag recording their keys.
+func managedTags(desired map[string]string) map[string]string {
+	managed := make(map[string]string, len(desired)+1)
+	keys := make([]string, 0, len(desired))
+	for key, value := range desired {
+		managed[key] = value
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		managed[constants.ManagedTagsKey] = strings.Join(keys, ",")
+	}
+
+	return managed
+}
@@ -0,0 +1,174 @@
+// Package conformance holds the checks every providers.Provider implementation must pass, so the
+// providers behave the same way where the workflows rely on it. Each provider runs them from its own
+// tests with Run, supplying a Subject that builds the provider with faked dependencies.
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// Subject is a provider under test.
+type Subject struct {
+	// New returns the provider and a valid cluster spec for it. It's called once per check. The provider
+	// dependencies must accept the calls the checks make, any number of times.
+	New func(t *testing.T) (providers.Provider, *cluster.Spec)
+	// Cluster is passed to the provider methods that take a management or workload cluster.
+	Cluster *types.Cluster
+	// UpgradeTriggers are spec changes that require rolling out new machines. UpgradeNeeded must report each of them.
+	UpgradeTriggers map[string]func(*cluster.Spec)
+	// VersionBump changes the provider components version in the spec. ChangeDiff must report it.
+	// It's skipped when nil.
+	VersionBump func(*cluster.Spec)
+	// InvalidSpecs are spec changes the create preflight must reject with an error.
+	InvalidSpecs map[string]func(*cluster.Spec)
+	// SnapshotDir holds the golden files of the create templates rendered for each permutation of SnapshotMatrix.
+	// The snapshots are skipped when it's empty.
+	SnapshotDir    string
+	SnapshotMatrix Matrix
+}
+
+// Run runs all the conformance checks against s.
+func Run(t *testing.T, s Subject) {
+	t.Run("identity", func(t *testing.T) { checkIdentity(t, s) })
+	t.Run("create templates are deterministic", func(t *testing.T) { checkDeterministicTemplates(t, s) })
+	t.Run("no upgrade needed for an unchanged spec", func(t *testing.T) { checkNoUpgradeNeeded(t, s) })
+	for name, change := range s.UpgradeTriggers {
+		change := change
+		t.Run("upgrade needed for "+name, func(t *testing.T) { checkUpgradeNeeded(t, s, change) })
+	}
+	t.Run("change diff", func(t *testing.T) { checkChangeDiff(t, s) })
+	for name, change := range s.InvalidSpecs {
+		change := change
+		t.Run("preflight rejects "+name, func(t *testing.T) { checkPreflightRejects(t, s, change) })
+	}
+	if s.SnapshotDir != "" {
+		t.Run("template snapshots", func(t *testing.T) { RunTemplateSnapshots(t, s) })
+	}
+}
+
+func checkIdentity(t *testing.T, s Subject) {
+	p, spec := s.New(t)
+	if p.Name() == "" {
+		t.Error("Name() must not be empty")
+	}
+	if p.DatacenterResourceType() == "" {
+		t.Error("DatacenterResourceType() must not be empty")
+	}
+	if len(p.MachineConfigs(spec)) > 0 && p.MachineResourceType() == "" {
+		t.Error("MachineResourceType() must not be empty when the provider has machine configs")
+	}
+	if dc := p.DatacenterConfig(spec); dc == nil || dc.Kind() == "" {
+		t.Error("DatacenterConfig() must return the datacenter config of the spec, with its kind")
+	}
+}
+
+func checkDeterministicTemplates(t *testing.T, s Subject) {
+	ctx := context.Background()
+	p, spec := s.New(t)
+
+	cp, md, err := p.GenerateCAPISpecForCreate(ctx, s.Cluster, spec.DeepCopy())
+	if err != nil {
+		t.Fatalf("GenerateCAPISpecForCreate() returned an error for a valid spec: %v", err)
+	}
+	if len(cp) == 0 {
+		t.Fatal("GenerateCAPISpecForCreate() returned an empty control plane spec")
+	}
+
+	cp2, md2, err := p.GenerateCAPISpecForCreate(ctx, s.Cluster, spec.DeepCopy())
+	if err != nil {
+		t.Fatalf("GenerateCAPISpecForCreate() returned an error the second time: %v", err)
+	}
+	if !bytes.Equal(cp, cp2) {
+		t.Errorf("GenerateCAPISpecForCreate() control plane spec changed between calls with the same input:\n%s", diff(cp, cp2))
+	}
+	if !bytes.Equal(md, md2) {
+		t.Errorf("GenerateCAPISpecForCreate() workers spec changed between calls with the same input:\n%s", diff(md, md2))
+	}
+}
+
+func checkNoUpgradeNeeded(t *testing.T, s Subject) {
+	p, spec := s.New(t)
+	needed, err := p.UpgradeNeeded(context.Background(), spec.DeepCopy(), spec, s.Cluster)
+	if err != nil {
+		t.Fatalf("UpgradeNeeded() returned an error: %v", err)
+	}
+	if needed {
+		t.Error("UpgradeNeeded() must be false when the spec didn't change")
+	}
+}
+
+func checkUpgradeNeeded(t *testing.T, s Subject, change func(*cluster.Spec)) {
+	p, spec := s.New(t)
+	newSpec := spec.DeepCopy()
+	change(newSpec)
+	needed, err := p.UpgradeNeeded(context.Background(), newSpec, spec, s.Cluster)
+	if err != nil {
+		t.Fatalf("UpgradeNeeded() returned an error: %v", err)
+	}
+	if !needed {
+		t.Error("UpgradeNeeded() must be true")
+	}
+}
+
+func checkChangeDiff(t *testing.T, s Subject) {
+	p, spec := s.New(t)
+	if d := p.ChangeDiff(spec, spec.DeepCopy()); d != nil {
+		t.Errorf("ChangeDiff() must be nil when the spec didn't change, got %+v", *d)
+	}
+
+	if s.VersionBump == nil {
+		return
+	}
+
+	newSpec := spec.DeepCopy()
+	s.VersionBump(newSpec)
+	d := p.ChangeDiff(spec, newSpec)
+	if d == nil {
+		t.Fatal("ChangeDiff() must report a new provider version")
+	}
+	if d.ComponentName != p.Name() {
+		t.Errorf("ChangeDiff() component name must be the provider name %s, got %s", p.Name(), d.ComponentName)
+	}
+	if d.OldVersion == d.NewVersion {
+		t.Errorf("ChangeDiff() must report different versions, got %s", d.NewVersion)
+	}
+}
+
+func checkPreflightRejects(t *testing.T, s Subject, change func(*cluster.Spec)) {
+	p, spec := s.New(t)
+	change(spec)
+
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("SetupAndValidateCreateCluster() panicked instead of returning an error: %v", r)
+			}
+		}()
+		err = p.SetupAndValidateCreateCluster(context.Background(), spec)
+	}()
+
+	if err == nil {
+		t.Fatal("SetupAndValidateCreateCluster() must return an error")
+	}
+	if err.Error() == "" {
+		t.Error("SetupAndValidateCreateCluster() must return an error with a message")
+	}
+}
+
+func diff(a, b []byte) string {
+	al, bl := bytes.Split(a, []byte("\n")), bytes.Split(b, []byte("\n"))
+	for i := 0; i < len(al) && i < len(bl); i++ {
+		if !bytes.Equal(al[i], bl[i]) {
+			return fmt.Sprintf("line %d: %q != %q", i+1, al[i], bl[i])
+		}
+	}
+	return fmt.Sprintf("lengths differ: %d != %d lines", len(al), len(bl))
+}
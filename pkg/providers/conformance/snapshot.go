@@ -0,0 +1,91 @@
+package conformance
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// Matrix holds the dimensions the templates are snapshot tested across. Each dimension maps the name of
+// its values to the change that applies them to the spec.
+type Matrix map[string]map[string]func(*cluster.Spec)
+
+// Permutation combines one value of every dimension of a Matrix.
+type Permutation struct {
+	// Name identifies the values of the permutation, like "etcd-external_kubernetes-1.24".
+	Name    string
+	changes []func(*cluster.Spec)
+}
+
+// Apply applies the changes of all the values of p to spec.
+func (p Permutation) Apply(spec *cluster.Spec) {
+	for _, change := range p.changes {
+		change(spec)
+	}
+}
+
+// Permutations returns every combination of the values of m, in a stable order. An empty matrix has
+// a single permutation, "default", that doesn't change the spec.
+func (m Matrix) Permutations() []Permutation {
+	permutations := []Permutation{{}}
+	for _, dimension := range sortedKeys(m) {
+		values := m[dimension]
+		next := make([]Permutation, 0, len(permutations)*len(values))
+		for _, p := range permutations {
+			for _, value := range sortedKeys(values) {
+				next = append(next, Permutation{
+					Name:    joinName(p.Name, dimension+"-"+value),
+					changes: append(append([]func(*cluster.Spec){}, p.changes...), values[value]),
+				})
+			}
+		}
+		permutations = next
+	}
+
+	if len(permutations) == 1 && permutations[0].Name == "" {
+		permutations[0].Name = "default"
+	}
+	return permutations
+}
+
+// RunTemplateSnapshots renders the create templates of the provider in s for every permutation of
+// s.SnapshotMatrix and compares them semantically with the golden files <name>_cp.yaml and <name>_md.yaml
+// in s.SnapshotDir. Run the tests with -update to write the golden files after a reviewed template change.
+func RunTemplateSnapshots(t *testing.T, s Subject) {
+	for _, p := range s.SnapshotMatrix.Permutations() {
+		p := p
+		t.Run(p.Name, func(t *testing.T) {
+			provider, spec := s.New(t)
+			p.Apply(spec)
+
+			cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), s.Cluster, spec)
+			if err != nil {
+				t.Fatalf("GenerateCAPISpecForCreate() returned an error: %v", err)
+			}
+
+			test.AssertYAMLContentToFile(t, string(cp), filepath.Join(s.SnapshotDir, p.Name+"_cp.yaml"))
+			test.AssertYAMLContentToFile(t, string(md), filepath.Join(s.SnapshotDir, p.Name+"_md.yaml"))
+		})
+	}
+}
+
+func joinName(prefix, value string) string {
+	if prefix == "" {
+		return value
+	}
+	return strings.Join([]string{prefix, value}, "_")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,43 @@
+package conformance_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/conformance"
+)
+
+func TestMatrixPermutations(t *testing.T) {
+	g := NewWithT(t)
+	m := conformance.Matrix{
+		"kubernetes": {
+			"1.24": func(s *cluster.Spec) { s.Cluster.Spec.KubernetesVersion = "1.24" },
+			"1.23": func(s *cluster.Spec) { s.Cluster.Spec.KubernetesVersion = "1.23" },
+		},
+		"controlplane": {
+			"ha": func(s *cluster.Spec) { s.Cluster.Spec.ControlPlaneConfiguration.Count = 3 },
+		},
+	}
+
+	permutations := m.Permutations()
+	names := make([]string, 0, len(permutations))
+	for _, p := range permutations {
+		names = append(names, p.Name)
+	}
+	g.Expect(names).To(Equal([]string{"controlplane-ha_kubernetes-1.23", "controlplane-ha_kubernetes-1.24"}))
+
+	spec := test.NewClusterSpec()
+	permutations[1].Apply(spec)
+	g.Expect(string(spec.Cluster.Spec.KubernetesVersion)).To(Equal("1.24"))
+	g.Expect(spec.Cluster.Spec.ControlPlaneConfiguration.Count).To(Equal(3))
+}
+
+func TestMatrixPermutationsEmpty(t *testing.T) {
+	g := NewWithT(t)
+	permutations := conformance.Matrix{}.Permutations()
+	g.Expect(permutations).To(HaveLen(1))
+	g.Expect(permutations[0].Name).To(Equal("default"))
+}
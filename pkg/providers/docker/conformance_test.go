@@ -0,0 +1,72 @@
+package docker_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/conformance"
+	"github.com/aws/eks-anywhere/pkg/providers/docker"
+	dockerMocks "github.com/aws/eks-anywhere/pkg/providers/docker/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func TestProviderConformance(t *testing.T) {
+	conformance.Run(t, conformance.Subject{
+		New: func(t *testing.T) (providers.Provider, *cluster.Spec) {
+			ctrl := gomock.NewController(t)
+			client := dockerMocks.NewMockProviderClient(ctrl)
+			kubectl := dockerMocks.NewMockProviderKubectlClient(ctrl)
+			clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = "test-cluster"
+				s.Cluster.Spec.KubernetesVersion = "1.24"
+				s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+				s.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+				s.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(1), MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}, Name: "md-0"}}
+				s.DockerDatacenter = &v1alpha1.DockerDatacenterConfig{
+					TypeMeta: metav1.TypeMeta{Kind: v1alpha1.DockerDatacenterKind},
+				}
+				s.VersionsBundle = versionsBundle
+			}).DeepCopy() // The checks change the spec, it can't share the versions bundle with the other tests.
+			return docker.NewProvider(clusterSpec.DockerDatacenter, client, kubectl, test.FakeNow), clusterSpec
+		},
+		Cluster: &types.Cluster{Name: "test-cluster"},
+		UpgradeTriggers: map[string]func(*cluster.Spec){
+			"new manager image": func(s *cluster.Spec) {
+				s.VersionsBundle.Docker.Manager.ImageDigest = "sha256:new"
+			},
+			"new haproxy image": func(s *cluster.Spec) {
+				s.VersionsBundle.Haproxy.Image.ImageDigest = "sha256:new"
+			},
+		},
+		VersionBump: func(s *cluster.Spec) {
+			s.VersionsBundle.Docker.Version = "v9.9.9"
+		},
+		SnapshotDir: "testdata/snapshots",
+		SnapshotMatrix: conformance.Matrix{
+			"etcd": {
+				"stacked": func(s *cluster.Spec) { s.Cluster.Spec.ExternalEtcdConfiguration = nil },
+				"external": func(s *cluster.Spec) {
+					s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+				},
+			},
+			"kubernetes": {
+				"1.23": func(s *cluster.Spec) { s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube123 },
+				"1.24": func(s *cluster.Spec) { s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube124 },
+			},
+		},
+		InvalidSpecs: map[string]func(*cluster.Spec){
+			"kubernetes version without kind node image": func(s *cluster.Spec) {
+				s.VersionsBundle.EksD.KindNode = releasev1alpha1.Image{}
+			},
+		},
+	})
+}
@@ -113,6 +113,10 @@ func (p *provider) DeleteResources(_ context.Context, _ *cluster.Spec) error {
 	return nil
 }
 
+func (p *provider) CleanupOrphanedResources(_ context.Context, _ *types.Cluster, _ *cluster.Spec) error {
+	return providers.ErrOrphanCleanupNotSupported
+}
+
 func (p *provider) PostClusterDeleteValidate(_ context.Context, _ *types.Cluster) error {
 	// No validations
 	return nil
@@ -125,6 +129,9 @@ func (p *provider) PostMoveManagementToBootstrap(_ context.Context, _ *types.Clu
 
 func (p *provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error {
 	logger.Info("Warning: The docker infrastructure provider is meant for local development and testing only")
+	if clusterSpec.VersionsBundle.EksD.KindNode.URI == "" {
+		return fmt.Errorf("no kind node image available for kubernetes version %s", clusterSpec.Cluster.Spec.KubernetesVersion)
+	}
 	return nil
 }
 
@@ -210,7 +217,9 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
-		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+		Append(clusterapi.KubeletServingCertificateExtraArgs(clusterSpec.Cluster)).
+		Append(clusterapi.ImageCredentialProviderExtraArgs(clusterSpec.Cluster))
 
 	cgroupDriverArgs, err := kubeletCgroupDriverExtraArgs(clusterSpec.Cluster.Spec.KubernetesVersion)
 	if err != nil {
@@ -223,6 +232,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
+		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster)).
 		Append(sharedExtraArgs)
 	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
@@ -230,6 +240,8 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 	values := map[string]interface{}{
 		"clusterName":                clusterSpec.Cluster.Name,
 		"control_plane_replicas":     clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count,
+		"apiServerCertSANs":          clusterSpec.Cluster.Spec.ControlPlaneConfiguration.CertSANs,
+		"staticPodManifests":         clusterapi.StaticPodManifestFiles(clusterSpec.Cluster.Spec.ControlPlaneConfiguration),
 		"kubernetesRepository":       bundle.KubeDistro.Kubernetes.Repository,
 		"kubernetesVersion":          bundle.KubeDistro.Kubernetes.Tag,
 		"etcdRepository":             bundle.KubeDistro.Etcd.Repository,
@@ -243,6 +255,9 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		"controllermanagerExtraArgs": controllerManagerExtraArgs.ToPartialYaml(),
 		"schedulerExtraArgs":         sharedExtraArgs.ToPartialYaml(),
 		"kubeletExtraArgs":           kubeletExtraArgs.ToPartialYaml(),
+		"credentialProviderFiles":    clusterapi.ImageCredentialProviderConfigFiles(clusterSpec.Cluster),
+		"etcdEncryptionFiles":        clusterapi.EtcdEncryptionConfigFiles(clusterSpec.Cluster),
+		"etcdEncryptionVolumes":      clusterapi.EtcdEncryptionExtraVolumes(clusterSpec.Cluster, v1alpha1.Ubuntu),
 		"externalEtcdVersion":        bundle.KubeDistro.EtcdVersion,
 		"eksaSystemNamespace":        constants.EksaSystemNamespace,
 		"podCidrs":                   clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
@@ -260,6 +275,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 	}
 
 	values["controlPlaneTaints"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints
+	values["controlPlaneSchedulable"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Schedulable
 
 	auditPolicy, err := common.GetAuditPolicy(clusterSpec.Cluster.Spec.KubernetesVersion)
 	if err != nil {
@@ -274,7 +290,9 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 	bundle := clusterSpec.VersionsBundle
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
-		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
+		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
+		Append(clusterapi.KubeletServingCertificateExtraArgs(clusterSpec.Cluster)).
+		Append(clusterapi.ImageCredentialProviderExtraArgs(clusterSpec.Cluster))
 
 	cgroupDriverArgs, err := kubeletCgroupDriverExtraArgs(clusterSpec.Cluster.Spec.KubernetesVersion)
 	if err != nil {
@@ -284,15 +302,16 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 		kubeletExtraArgs.Append(cgroupDriverArgs)
 	}
 	values := map[string]interface{}{
-		"clusterName":           clusterSpec.Cluster.Name,
-		"kubernetesVersion":     bundle.KubeDistro.Kubernetes.Tag,
-		"kindNodeImage":         bundle.EksD.KindNode.VersionedImage(),
-		"eksaSystemNamespace":   constants.EksaSystemNamespace,
-		"kubeletExtraArgs":      kubeletExtraArgs.ToPartialYaml(),
-		"workerReplicas":        *workerNodeGroupConfiguration.Count,
-		"workerNodeGroupName":   fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name),
-		"workerNodeGroupTaints": workerNodeGroupConfiguration.Taints,
-		"autoscalingConfig":     workerNodeGroupConfiguration.AutoScalingConfiguration,
+		"clusterName":             clusterSpec.Cluster.Name,
+		"kubernetesVersion":       bundle.KubeDistro.Kubernetes.Tag,
+		"kindNodeImage":           bundle.EksD.KindNode.VersionedImage(),
+		"eksaSystemNamespace":     constants.EksaSystemNamespace,
+		"kubeletExtraArgs":        kubeletExtraArgs.ToPartialYaml(),
+		"credentialProviderFiles": clusterapi.ImageCredentialProviderConfigFiles(clusterSpec.Cluster),
+		"workerReplicas":          *workerNodeGroupConfiguration.Count,
+		"workerNodeGroupName":     fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name),
+		"workerNodeGroupTaints":   workerNodeGroupConfiguration.Taints,
+		"autoscalingConfig":       workerNodeGroupConfiguration.AutoScalingConfiguration,
 	}
 
 	return values, nil
@@ -554,8 +573,12 @@ func (p *provider) RunPostControlPlaneUpgrade(ctx context.Context, oldClusterSpe
 	return nil
 }
 
-func (p *provider) UpgradeNeeded(_ context.Context, _, _ *cluster.Spec, _ *types.Cluster) (bool, error) {
-	return false, nil
+func (p *provider) UpgradeNeeded(_ context.Context, newSpec, currentSpec *cluster.Spec, _ *types.Cluster) (bool, error) {
+	newV, oldV := newSpec.VersionsBundle, currentSpec.VersionsBundle
+
+	return newV.Docker.Manager.ImageDigest != oldV.Docker.Manager.ImageDigest ||
+		newV.Docker.KubeProxy.ImageDigest != oldV.Docker.KubeProxy.ImageDigest ||
+		newV.Haproxy.Image.ImageDigest != oldV.Haproxy.Image.ImageDigest, nil
 }
 
 func (p *provider) RunPostControlPlaneCreation(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
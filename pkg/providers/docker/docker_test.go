@@ -173,6 +173,22 @@ func TestProviderGenerateDeploymentFileSuccessUpdateMachineTemplate(t *testing.T
 			wantCPFile: "testdata/valid_deployment_cp_taints_expected.yaml",
 			wantMDFile: "testdata/valid_deployment_md_expected.yaml",
 		},
+		{
+			testName: "valid config with schedulable cp",
+			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = "test-cluster"
+				s.Cluster.Spec.KubernetesVersion = "1.19"
+				s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+				s.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+				s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+				s.Cluster.Spec.ControlPlaneConfiguration.Schedulable = true
+				s.VersionsBundle = versionsBundle
+				s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}, Name: "md-0"}}
+			}),
+			wantCPFile: "testdata/valid_deployment_cp_schedulable_expected.yaml",
+			wantMDFile: "testdata/valid_deployment_md_expected.yaml",
+		},
 		{
 			testName: "valid config with md taints",
 			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
@@ -722,6 +738,20 @@ func TestDockerTemplateBuilderGenerateCAPISpecControlPlane(t *testing.T) {
 	}
 }
 
+func TestDockerTemplateBuilderGenerateCAPISpecControlPlaneCertSANs(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Spec.KubernetesVersion = "1.22"
+		s.Cluster.Spec.ControlPlaneConfiguration.CertSANs = []string{"api.example.com", "10.0.0.1"}
+	})
+	builder := docker.NewDockerTemplateBuilder(time.Now)
+
+	gotContent, err := builder.GenerateCAPISpecControlPlane(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(gotContent)).To(ContainSubstring("certSANs:\n        - localhost\n        - 127.0.0.1\n        - api.example.com\n        - 10.0.0.1\n"))
+}
+
 func TestDockerTemplateBuilderGenerateCAPISpecWorkers(t *testing.T) {
 	type args struct {
 		clusterSpec *cluster.Spec
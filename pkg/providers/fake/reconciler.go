@@ -0,0 +1,169 @@
+// Package fake implements an in-memory provider cluster reconciler that simulates provisioning the machines of a
+// cluster, with configurable latencies and failures. It allows testing the cluster controller without any
+// infrastructure and it's only registered in the controller when the FakeProvider feature is active.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// MachinesProvisionedCondition reports the progress of the simulated machines of a cluster.
+	MachinesProvisionedCondition clusterv1.ConditionType = "MachinesProvisioned"
+
+	// MachineLatencyEnvVar sets Config.MachineLatency when the config is read from the environment.
+	MachineLatencyEnvVar = "FAKE_PROVIDER_MACHINE_LATENCY"
+	// FailedMachinesEnvVar sets Config.FailedMachines, as a comma separated list, when the config is read from the environment.
+	FailedMachinesEnvVar = "FAKE_PROVIDER_FAILED_MACHINES"
+
+	provisioningReason = "Provisioning"
+	failedReason       = "MachineFailed"
+)
+
+// Config describes how the simulated machines behave.
+type Config struct {
+	// MachineLatency is how long each machine takes to be provisioned.
+	MachineLatency time.Duration
+	// FailedMachines are the names of the machines that fail once provisioned. Machines are named
+	// <cluster>-control-plane-<index> and <cluster>-<worker node group>-<index>, starting at 0.
+	FailedMachines []string
+}
+
+// ConfigFromEnv reads a Config from the FAKE_PROVIDER_* environment variables.
+func ConfigFromEnv() (Config, error) {
+	config := Config{}
+	if latency := os.Getenv(MachineLatencyEnvVar); latency != "" {
+		d, err := time.ParseDuration(latency)
+		if err != nil {
+			return Config{}, fmt.Errorf("parsing %s: %v", MachineLatencyEnvVar, err)
+		}
+		config.MachineLatency = d
+	}
+	if failed := os.Getenv(FailedMachinesEnvVar); failed != "" {
+		config.FailedMachines = strings.Split(failed, ",")
+	}
+
+	return config, nil
+}
+
+// Reconciler simulates the machines of the clusters it reconciles. Machines start provisioning the first time
+// they are seen and are ready once Config.MachineLatency has passed.
+type Reconciler struct {
+	config Config
+	now    types.NowFunc
+	failed sets.String
+
+	mu sync.Mutex
+	// machines holds the provisioning start time of the machines, per cluster.
+	machines map[string]map[string]time.Time
+}
+
+// NewReconciler returns a Reconciler that simulates machines as described by config.
+func NewReconciler(config Config, now types.NowFunc) *Reconciler {
+	return &Reconciler{
+		config:   config,
+		now:      now,
+		failed:   sets.NewString(config.FailedMachines...),
+		machines: map[string]map[string]time.Time{},
+	}
+}
+
+// Reconcile provisions the machines cluster needs and forgets the ones it doesn't need anymore. It requeues until
+// all the machines are ready and sets a failure message in the cluster if any of them fails.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	log = log.WithValues("provider", "fake")
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	machines := r.syncMachines(log, cluster, now)
+
+	ready := 0
+	var wait time.Duration
+	for _, name := range machineNames(cluster) {
+		remaining := r.config.MachineLatency - now.Sub(machines[name])
+		if remaining > 0 {
+			if wait == 0 || remaining < wait {
+				wait = remaining
+			}
+			continue
+		}
+
+		if r.failed.Has(name) {
+			message := fmt.Sprintf("machine %s failed to provision", name)
+			log.Error(nil, message)
+			cluster.Status.FailureMessage = &message
+			conditions.MarkFalse(cluster, MachinesProvisionedCondition, failedReason, clusterv1.ConditionSeverityError, message)
+			return controller.ResultWithReturn(), nil
+		}
+		ready++
+	}
+
+	if ready < len(machines) {
+		conditions.MarkFalse(cluster, MachinesProvisionedCondition, provisioningReason, clusterv1.ConditionSeverityInfo, "%d of %d machines provisioned", ready, len(machines))
+		return controller.ResultWithRequeue(wait), nil
+	}
+
+	conditions.MarkTrue(cluster, MachinesProvisionedCondition)
+	return controller.Result{}, nil
+}
+
+// Forget drops the simulated machines of cluster, as if they had been deleted.
+func (r *Reconciler) Forget(cluster *anywherev1.Cluster) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.machines, clusterKey(cluster))
+}
+
+func (r *Reconciler) syncMachines(log logr.Logger, cluster *anywherev1.Cluster, now time.Time) map[string]time.Time {
+	key := clusterKey(cluster)
+	existing := r.machines[key]
+	machines := make(map[string]time.Time, len(existing))
+	for _, name := range machineNames(cluster) {
+		started, ok := existing[name]
+		if !ok {
+			log.Info("Provisioning fake machine", "machine", name)
+			started = now
+		}
+		machines[name] = started
+	}
+	r.machines[key] = machines
+
+	return machines
+}
+
+func machineNames(cluster *anywherev1.Cluster) []string {
+	var names []string
+	for i := 0; i < cluster.Spec.ControlPlaneConfiguration.Count; i++ {
+		names = append(names, fmt.Sprintf("%s-control-plane-%d", cluster.Name, i))
+	}
+	for _, group := range cluster.Spec.WorkerNodeGroupConfigurations {
+		count := 0
+		if group.Count != nil {
+			count = *group.Count
+		}
+		for i := 0; i < count; i++ {
+			names = append(names, fmt.Sprintf("%s-%s-%d", cluster.Name, group.Name, i))
+		}
+	}
+
+	return names
+}
+
+func clusterKey(cluster *anywherev1.Cluster) string {
+	return cluster.Namespace + "/" + cluster.Name
+}
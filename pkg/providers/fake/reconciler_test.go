@@ -0,0 +1,134 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/providers/fake"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+type reconcilerTest struct {
+	*WithT
+	ctx     context.Context
+	now     time.Time
+	cluster *anywherev1.Cluster
+}
+
+func newReconcilerTest(t *testing.T) *reconcilerTest {
+	return &reconcilerTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		now:   time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+		cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+			Spec: anywherev1.ClusterSpec{
+				ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{Count: 1},
+				WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+					{Name: "md-0", Count: ptr.Int(2)},
+				},
+			},
+		},
+	}
+}
+
+func (tt *reconcilerTest) reconciler(config fake.Config) *fake.Reconciler {
+	return fake.NewReconciler(config, func() time.Time { return tt.now })
+}
+
+func (tt *reconcilerTest) reconcile(r *fake.Reconciler) controller.Result {
+	result, err := r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	return result
+}
+
+func TestReconcilerReconcileNoLatency(t *testing.T) {
+	tt := newReconcilerTest(t)
+	r := tt.reconciler(fake.Config{})
+
+	tt.Expect(tt.reconcile(r)).To(Equal(controller.Result{}))
+	tt.Expect(conditions.IsTrue(tt.cluster, fake.MachinesProvisionedCondition)).To(BeTrue())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerReconcileProvisioning(t *testing.T) {
+	tt := newReconcilerTest(t)
+	r := tt.reconciler(fake.Config{MachineLatency: time.Minute})
+
+	tt.Expect(tt.reconcile(r)).To(Equal(controller.ResultWithRequeue(time.Minute)))
+	tt.Expect(conditions.IsFalse(tt.cluster, fake.MachinesProvisionedCondition)).To(BeTrue())
+	tt.Expect(conditions.GetMessage(tt.cluster, fake.MachinesProvisionedCondition)).To(Equal("0 of 3 machines provisioned"))
+
+	tt.now = tt.now.Add(20 * time.Second)
+	tt.Expect(tt.reconcile(r)).To(Equal(controller.ResultWithRequeue(40 * time.Second)))
+
+	tt.now = tt.now.Add(40 * time.Second)
+	tt.Expect(tt.reconcile(r)).To(Equal(controller.Result{}))
+	tt.Expect(conditions.IsTrue(tt.cluster, fake.MachinesProvisionedCondition)).To(BeTrue())
+}
+
+func TestReconcilerReconcileScaleUp(t *testing.T) {
+	tt := newReconcilerTest(t)
+	r := tt.reconciler(fake.Config{MachineLatency: time.Minute})
+	tt.reconcile(r)
+	tt.now = tt.now.Add(time.Minute)
+	tt.Expect(tt.reconcile(r)).To(Equal(controller.Result{}))
+
+	tt.cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+	tt.Expect(tt.reconcile(r)).To(Equal(controller.ResultWithRequeue(time.Minute)))
+	tt.Expect(conditions.GetMessage(tt.cluster, fake.MachinesProvisionedCondition)).To(Equal("3 of 4 machines provisioned"))
+}
+
+func TestReconcilerReconcileMachineFailure(t *testing.T) {
+	tt := newReconcilerTest(t)
+	r := tt.reconciler(fake.Config{MachineLatency: time.Minute, FailedMachines: []string{"workload-md-0-1"}})
+
+	tt.Expect(tt.reconcile(r)).To(Equal(controller.ResultWithRequeue(time.Minute)))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeNil())
+
+	tt.now = tt.now.Add(time.Minute)
+	tt.Expect(tt.reconcile(r)).To(Equal(controller.ResultWithReturn()))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(Equal(ptr.String("machine workload-md-0-1 failed to provision")))
+	tt.Expect(conditions.GetSeverity(tt.cluster, fake.MachinesProvisionedCondition)).To(HaveValue(Equal(clusterv1.ConditionSeverityError)))
+}
+
+func TestReconcilerForget(t *testing.T) {
+	tt := newReconcilerTest(t)
+	r := tt.reconciler(fake.Config{MachineLatency: time.Minute})
+	tt.reconcile(r)
+	tt.now = tt.now.Add(time.Minute)
+
+	r.Forget(tt.cluster)
+
+	tt.Expect(tt.reconcile(r)).To(Equal(controller.ResultWithRequeue(time.Minute)))
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(fake.MachineLatencyEnvVar, "30s")
+	t.Setenv(fake.FailedMachinesEnvVar, "a-control-plane-0,a-md-0-0")
+
+	config, err := fake.ConfigFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config).To(Equal(fake.Config{
+		MachineLatency: 30 * time.Second,
+		FailedMachines: []string{"a-control-plane-0", "a-md-0-0"},
+	}))
+}
+
+func TestConfigFromEnvInvalidLatency(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(fake.MachineLatencyEnvVar, "soon")
+
+	_, err := fake.ConfigFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring("parsing FAKE_PROVIDER_MACHINE_LATENCY")))
+}
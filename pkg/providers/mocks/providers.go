@@ -68,6 +68,20 @@ func (mr *MockProviderMockRecorder) ChangeDiff(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeDiff", reflect.TypeOf((*MockProvider)(nil).ChangeDiff), arg0, arg1)
 }
 
+// CleanupOrphanedResources mocks base method.
+func (m *MockProvider) CleanupOrphanedResources(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupOrphanedResources", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupOrphanedResources indicates an expected call of CleanupOrphanedResources.
+func (mr *MockProviderMockRecorder) CleanupOrphanedResources(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupOrphanedResources", reflect.TypeOf((*MockProvider)(nil).CleanupOrphanedResources), arg0, arg1, arg2)
+}
+
 // DatacenterConfig mocks base method.
 func (m *MockProvider) DatacenterConfig(arg0 *cluster.Spec) providers.DatacenterConfig {
 	m.ctrl.T.Helper()
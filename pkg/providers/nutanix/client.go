@@ -2,8 +2,16 @@ package nutanix
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
 
+	prismgoclient "github.com/nutanix-cloud-native/prism-go-client"
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
 	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
 )
 
 type Client interface {
@@ -16,3 +24,87 @@ type Client interface {
 	GetCluster(ctx context.Context, uuid string) (*v3.ClusterIntentResponse, error)
 	ListCluster(ctx context.Context, getEntitiesRequest *v3.DSMetadata) (*v3.ClusterListIntentResponse, error)
 }
+
+// NewPrismClient builds a Prism Central v3 client for the endpoint of the datacenter config, trusting its
+// additional trust bundle if set.
+func NewPrismClient(datacenterConfig *anywherev1.NutanixDatacenterConfig, creds credentials.BasicAuthCredential) (*v3.Client, error) {
+	clientOpts := make([]v3.ClientOption, 0)
+	if datacenterConfig.Spec.AdditionalTrustBundle != "" {
+		block, _ := pem.Decode([]byte(datacenterConfig.Spec.AdditionalTrustBundle))
+		if block == nil {
+			return nil, fmt.Errorf("unable to decode additional trust bundle %s", datacenterConfig.Spec.AdditionalTrustBundle)
+		}
+		certs, err := x509.ParseCertificates(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse additional trust bundle %s: %v", datacenterConfig.Spec.AdditionalTrustBundle, err)
+		}
+		if len(certs) == 0 {
+			return nil, fmt.Errorf("unable to extract certs from the addtional trust bundle %s", datacenterConfig.Spec.AdditionalTrustBundle)
+		}
+		clientOpts = append(clientOpts, v3.WithCertificate(certs[0]))
+	}
+
+	endpoint := datacenterConfig.Spec.Endpoint
+	port := datacenterConfig.Spec.Port
+	nutanixCreds := prismgoclient.Credentials{
+		URL:      fmt.Sprintf("%s:%d", endpoint, port),
+		Username: creds.PrismCentral.Username,
+		Password: creds.PrismCentral.Password,
+		Endpoint: endpoint,
+		Port:     fmt.Sprintf("%d", port),
+	}
+
+	client, err := v3.NewV3Client(nutanixCreds, clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating nutanix client: %v", err)
+	}
+	return client, nil
+}
+
+// rateLimitedClient calls Prism Central through the rate limiter of its endpoint.
+type rateLimitedClient struct {
+	client  Client
+	limiter *ratelimit.Limiter
+}
+
+// NewRateLimitedClient wraps client so its calls go through limiter.
+func NewRateLimitedClient(client Client, limiter *ratelimit.Limiter) Client {
+	return &rateLimitedClient{
+		client:  client,
+		limiter: limiter,
+	}
+}
+
+func (c *rateLimitedClient) GetSubnet(ctx context.Context, uuid string) (*v3.SubnetIntentResponse, error) {
+	return limited(ctx, c.limiter, func() (*v3.SubnetIntentResponse, error) { return c.client.GetSubnet(ctx, uuid) })
+}
+
+func (c *rateLimitedClient) ListSubnet(ctx context.Context, getEntitiesRequest *v3.DSMetadata) (*v3.SubnetListIntentResponse, error) {
+	return limited(ctx, c.limiter, func() (*v3.SubnetListIntentResponse, error) { return c.client.ListSubnet(ctx, getEntitiesRequest) })
+}
+
+func (c *rateLimitedClient) GetImage(ctx context.Context, uuid string) (*v3.ImageIntentResponse, error) {
+	return limited(ctx, c.limiter, func() (*v3.ImageIntentResponse, error) { return c.client.GetImage(ctx, uuid) })
+}
+
+func (c *rateLimitedClient) ListImage(ctx context.Context, getEntitiesRequest *v3.DSMetadata) (*v3.ImageListIntentResponse, error) {
+	return limited(ctx, c.limiter, func() (*v3.ImageListIntentResponse, error) { return c.client.ListImage(ctx, getEntitiesRequest) })
+}
+
+func (c *rateLimitedClient) GetCluster(ctx context.Context, uuid string) (*v3.ClusterIntentResponse, error) {
+	return limited(ctx, c.limiter, func() (*v3.ClusterIntentResponse, error) { return c.client.GetCluster(ctx, uuid) })
+}
+
+func (c *rateLimitedClient) ListCluster(ctx context.Context, getEntitiesRequest *v3.DSMetadata) (*v3.ClusterListIntentResponse, error) {
+	return limited(ctx, c.limiter, func() (*v3.ClusterListIntentResponse, error) { return c.client.ListCluster(ctx, getEntitiesRequest) })
+}
+
+func limited[T any](ctx context.Context, limiter *ratelimit.Limiter, call func() (T, error)) (T, error) {
+	var result T
+	err := limiter.Do(ctx, func() error {
+		var err error
+		result, err = call()
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,36 @@
+package nutanix
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
+)
+
+func TestRateLimitedClient(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	mockClient := NewMockClient(ctrl)
+	limiter := ratelimit.NewLimiter("prism", ratelimit.Config{QPS: 1000, Burst: 10, FailureThreshold: 1, Cooldown: time.Minute})
+	client := NewRateLimitedClient(mockClient, limiter)
+
+	subnet := &v3.SubnetIntentResponse{}
+	mockClient.EXPECT().GetSubnet(ctx, "uuid").Return(subnet, nil)
+	got, err := client.GetSubnet(ctx, "uuid")
+	require.NoError(t, err)
+	assert.Same(t, subnet, got)
+
+	mockClient.EXPECT().ListImage(ctx, gomock.Any()).Return(nil, errors.New("too many requests"))
+	_, err = client.ListImage(ctx, &v3.DSMetadata{})
+	assert.EqualError(t, err, "too many requests")
+
+	_, err = client.GetCluster(ctx, "uuid")
+	assert.ErrorIs(t, err, ratelimit.ErrCircuitOpen)
+}
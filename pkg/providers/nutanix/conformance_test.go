@@ -0,0 +1,133 @@
+package nutanix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nutanix-cloud-native/prism-go-client/utils"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	mockCrypto "github.com/aws/eks-anywhere/pkg/crypto/mocks"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/conformance"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func TestProviderConformance(t *testing.T) {
+	conformance.Run(t, conformance.Subject{
+		New: func(t *testing.T) (providers.Provider, *cluster.Spec) {
+			t.Setenv(constants.NutanixUsernameKey, "admin")
+			t.Setenv(constants.NutanixPasswordKey, "password")
+			ctrl := gomock.NewController(t)
+			spec := test.NewFullClusterSpec(t, "testdata/eksa-cluster.yaml")
+
+			// Kubectl reads back the eks-a objects of the spec, as if the cluster had been created with it.
+			executable := mockexecutables.NewMockExecutable(ctrl)
+			executable.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, args ...string) (bytes.Buffer, error) {
+					var obj interface{}
+					name := args[len(args)-1]
+					for _, arg := range args {
+						switch arg {
+						case "nutanixdatacenterconfigs.anywhere.eks.amazonaws.com":
+							obj = spec.NutanixDatacenter
+						case "nutanixmachineconfigs.anywhere.eks.amazonaws.com":
+							obj = spec.NutanixMachineConfigs[name]
+						}
+					}
+					if obj == nil {
+						return bytes.Buffer{}, fmt.Errorf("unexpected kubectl call %v", args)
+					}
+					b, err := json.Marshal(obj)
+					return *bytes.NewBuffer(b), err
+				},
+			).AnyTimes()
+
+			client := NewMockClient(ctrl)
+			client.EXPECT().ListCluster(gomock.Any(), gomock.Any()).Return(&v3.ClusterListIntentResponse{
+				Entities: []*v3.ClusterIntentResponse{
+					{
+						Metadata: &v3.Metadata{UUID: utils.StringPtr("a15f6966-bfc7-4d1e-8575-224096fc1cda")},
+						Spec:     &v3.Cluster{Name: utils.StringPtr("prism-element")},
+						Status: &v3.ClusterDefStatus{
+							Resources: &v3.ClusterObj{
+								Config: &v3.ClusterConfig{ServiceList: []*string{utils.StringPtr("AOS")}},
+							},
+						},
+					},
+				},
+			}, nil).AnyTimes()
+			client.EXPECT().ListSubnet(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, m *v3.DSMetadata) (*v3.SubnetListIntentResponse, error) {
+					res := &v3.SubnetListIntentResponse{}
+					if *m.Filter == "name==prism-subnet" {
+						res.Entities = []*v3.SubnetIntentResponse{
+							{
+								Metadata: &v3.Metadata{UUID: utils.StringPtr("a15f6966-bfc7-4d1e-8575-224096fc1cdb")},
+								Spec:     &v3.Subnet{Name: utils.StringPtr("prism-subnet")},
+							},
+						}
+					}
+					return res, nil
+				},
+			).AnyTimes()
+			client.EXPECT().ListImage(gomock.Any(), gomock.Any()).Return(&v3.ImageListIntentResponse{
+				Entities: []*v3.ImageIntentResponse{
+					{
+						Metadata: &v3.Metadata{UUID: utils.StringPtr("a15f6966-bfc7-4d1e-8575-224096fc1cdc")},
+						Spec:     &v3.Image{Name: utils.StringPtr("prism-image")},
+					},
+				},
+			}, nil).AnyTimes()
+
+			provider := NewProvider(spec.NutanixDatacenter, spec.NutanixMachineConfigs, spec.Cluster,
+				executables.NewKubectl(executable), client, mockCrypto.NewMockTlsValidator(ctrl), test.FakeNow)
+
+			return provider, spec
+		},
+		Cluster: &types.Cluster{Name: "eksa-unit-test", KubeconfigFile: "testdata/kubeconfig.yaml"},
+		UpgradeTriggers: map[string]func(*cluster.Spec){
+			"new endpoint": func(s *cluster.Spec) {
+				s.NutanixDatacenter.Spec.Endpoint = "new.prism.nutanix.com"
+			},
+			"new machine image": func(s *cluster.Spec) {
+				name := "new-image"
+				s.NutanixMachineConfigs[s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec.Image.Name = &name
+			},
+		},
+		VersionBump: func(s *cluster.Spec) {
+			s.VersionsBundle.Nutanix.Version = "v9.9.9"
+		},
+		SnapshotDir: "testdata/snapshots",
+		SnapshotMatrix: conformance.Matrix{
+			"controlplane": {
+				"single": func(s *cluster.Spec) { s.Cluster.Spec.ControlPlaneConfiguration.Count = 1 },
+				"ha":     func(s *cluster.Spec) { s.Cluster.Spec.ControlPlaneConfiguration.Count = 3 },
+			},
+			// The nutanix templates don't render worker taints, the workers vary by count instead.
+			"workers": {
+				"single": func(s *cluster.Spec) { s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(1) },
+				"scaled": func(s *cluster.Spec) { s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3) },
+			},
+		},
+		InvalidSpecs: map[string]func(*cluster.Spec){
+			"unknown subnet": func(s *cluster.Spec) {
+				name := "unknown-subnet"
+				s.NutanixMachineConfigs[s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec.Subnet.Name = &name
+			},
+			"missing prism element cluster name": func(s *cluster.Spec) {
+				s.NutanixMachineConfigs[s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec.Cluster.Name = nil
+			},
+		},
+	})
+}
@@ -0,0 +1,210 @@
+package nutanix
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	yamlcapi "github.com/aws/eks-anywhere/pkg/clusterapi/yaml"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+const (
+	nutanixClusterKind         = "NutanixCluster"
+	nutanixMachineTemplateKind = "NutanixMachineTemplate"
+	nutanixInfrastructureAPI   = "infrastructure.cluster.x-k8s.io/v1beta1"
+)
+
+// BaseControlPlane represents a CAPI Nutanix control plane.
+// The CAPX API types are not vendored, so the provider objects are handled as unstructured.
+type BaseControlPlane = clusterapi.ControlPlane[*unstructured.Unstructured, *unstructured.Unstructured]
+
+// ControlPlane holds the Nutanix specific objects for a CAPI Nutanix control plane.
+type ControlPlane struct {
+	BaseControlPlane
+	Secrets []*corev1.Secret
+}
+
+// Objects returns the control plane objects associated with the Nutanix cluster.
+func (p ControlPlane) Objects() []kubernetes.Object {
+	o := p.BaseControlPlane.Objects()
+	for _, s := range p.Secrets {
+		o = append(o, s)
+	}
+
+	return o
+}
+
+// ControlPlaneBuilder defines the builder for all objects in the CAPI Nutanix control plane.
+type ControlPlaneBuilder struct {
+	BaseBuilder  *yamlcapi.ControlPlaneBuilder[*unstructured.Unstructured, *unstructured.Unstructured]
+	ControlPlane *ControlPlane
+}
+
+// BuildFromParsed implements the base yamlcapi.BuildFromParsed and processes any additional objects for the Nutanix control plane.
+func (b *ControlPlaneBuilder) BuildFromParsed(lookup yamlutil.ObjectLookup) error {
+	if err := b.BaseBuilder.BuildFromParsed(lookup); err != nil {
+		return err
+	}
+
+	b.ControlPlane.BaseControlPlane = *b.BaseBuilder.ControlPlane
+	for _, obj := range lookup {
+		if obj.GetObjectKind().GroupVersionKind().Kind == constants.SecretKind {
+			b.ControlPlane.Secrets = append(b.ControlPlane.Secrets, obj.(*corev1.Secret))
+		}
+	}
+
+	return nil
+}
+
+// ControlPlaneSpec builds a Nutanix ControlPlane definition based on an eks-a cluster spec, including
+// the Secret with the Prism Central credentials used by CAPX.
+// It talks to the cluster with a client to detect changes in the immutable machine templates and
+// generates new names for them.
+func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec, creds credentials.BasicAuthCredential) (*ControlPlane, error) {
+	if spec.NutanixDatacenter == nil {
+		return nil, errors.New("NutanixDatacenterConfig not found in cluster spec")
+	}
+	if controlPlaneMachineConfig(spec) == nil {
+		return nil, errors.New("control plane NutanixMachineConfig not found in cluster spec")
+	}
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil && etcdMachineConfig(spec) == nil {
+		return nil, errors.New("etcd NutanixMachineConfig not found in cluster spec")
+	}
+
+	templateBuilder := templateBuilderForSpec(spec, creds)
+
+	controlPlaneYaml, err := templateBuilder.GenerateCAPISpecControlPlane(
+		spec,
+		func(values map[string]interface{}) {
+			values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating nutanix control plane yaml spec")
+	}
+
+	secretYaml, err := templateBuilder.GenerateCAPISpecSecret(spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating nutanix credentials secret yaml spec")
+	}
+
+	parser, builder, err := newControlPlaneParser(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = parser.Parse(templater.AppendYamlResources(controlPlaneYaml, secretYaml), builder); err != nil {
+		return nil, errors.Wrap(err, "parsing nutanix control plane yaml")
+	}
+
+	cp := builder.ControlPlane
+	if err = cp.UpdateImmutableObjectNames(ctx, client, getMachineTemplate, machineTemplateEqual); err != nil {
+		return nil, errors.Wrap(err, "updating nutanix immutable object names")
+	}
+
+	return cp, nil
+}
+
+func newControlPlaneParser(logger logr.Logger) (*yamlutil.Parser, *ControlPlaneBuilder, error) {
+	parser, baseBuilder, err := yamlcapi.NewControlPlaneParserAndBuilder(
+		logger,
+		unstructuredMapping(nutanixClusterKind),
+		unstructuredMapping(nutanixMachineTemplateKind),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "building nutanix control plane parser")
+	}
+
+	err = parser.RegisterMappings(
+		yamlutil.NewMapping(constants.SecretKind, func() yamlutil.APIObject {
+			return &corev1.Secret{}
+		}),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "registering nutanix control plane mappings in parser")
+	}
+
+	builder := &ControlPlaneBuilder{
+		BaseBuilder:  baseBuilder,
+		ControlPlane: &ControlPlane{},
+	}
+
+	return parser, builder, nil
+}
+
+// templateBuilderForSpec builds a template builder with the datacenter and machine configs of the spec.
+func templateBuilderForSpec(spec *cluster.Spec, creds credentials.BasicAuthCredential) *TemplateBuilder {
+	workerMachineSpecs := make(map[string]v1alpha1.NutanixMachineConfigSpec, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, w := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if m, ok := spec.NutanixMachineConfigs[w.MachineGroupRef.Name]; ok {
+			workerMachineSpecs[w.MachineGroupRef.Name] = m.Spec
+		}
+	}
+
+	var datacenterSpec *v1alpha1.NutanixDatacenterConfigSpec
+	if spec.NutanixDatacenter != nil {
+		datacenterSpec = &spec.NutanixDatacenter.Spec
+	}
+
+	var controlPlaneMachineSpec, etcdMachineSpec *v1alpha1.NutanixMachineConfigSpec
+	if m := controlPlaneMachineConfig(spec); m != nil {
+		controlPlaneMachineSpec = &m.Spec
+	}
+	if m := etcdMachineConfig(spec); m != nil {
+		etcdMachineSpec = &m.Spec
+	}
+
+	return NewNutanixTemplateBuilder(datacenterSpec, controlPlaneMachineSpec, etcdMachineSpec, workerMachineSpecs, creds, time.Now)
+}
+
+func controlPlaneMachineConfig(spec *cluster.Spec) *v1alpha1.NutanixMachineConfig {
+	if spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef == nil {
+		return nil
+	}
+	return spec.NutanixMachineConfigs[spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+}
+
+func etcdMachineConfig(spec *cluster.Spec) *v1alpha1.NutanixMachineConfig {
+	etcd := spec.Cluster.Spec.ExternalEtcdConfiguration
+	if etcd == nil || etcd.MachineGroupRef == nil {
+		return nil
+	}
+	return spec.NutanixMachineConfigs[etcd.MachineGroupRef.Name]
+}
+
+func unstructuredMapping(kind string) yamlutil.Mapping[*unstructured.Unstructured] {
+	return yamlutil.NewMapping(
+		kind,
+		func() *unstructured.Unstructured {
+			return &unstructured.Unstructured{}
+		},
+	)
+}
+
+func getMachineTemplate(ctx context.Context, client kubernetes.Client, name, namespace string) (*unstructured.Unstructured, error) {
+	m := &unstructured.Unstructured{}
+	m.SetAPIVersion(nutanixInfrastructureAPI)
+	m.SetKind(nutanixMachineTemplateKind)
+	if err := client.Get(ctx, name, namespace, m); err != nil {
+		return nil, errors.Wrap(err, "reading nutanixMachineTemplate")
+	}
+
+	return m, nil
+}
+
+func machineTemplateEqual(new, old *unstructured.Unstructured) bool {
+	return equality.Semantic.DeepDerivative(new.Object["spec"], old.Object["spec"])
+}
@@ -0,0 +1,67 @@
+package nutanix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func testCredentials() credentials.BasicAuthCredential {
+	return credentials.BasicAuthCredential{
+		PrismCentral: credentials.PrismCentralBasicAuth{
+			BasicAuth: credentials.BasicAuth{
+				Username: "admin",
+				Password: "password",
+			},
+		},
+	}
+}
+
+func TestControlPlaneSpecNewCluster(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := test.NewFullClusterSpec(t, "testdata/eksa-cluster.yaml")
+
+	cp, err := ControlPlaneSpec(ctx, logger, client, spec, testCredentials())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp).NotTo(BeNil())
+	g.Expect(cp.Cluster.Name).To(Equal("eksa-unit-test"))
+	g.Expect(cp.ProviderCluster.GetName()).To(Equal("eksa-unit-test"))
+	g.Expect(cp.ProviderCluster.GetKind()).To(Equal("NutanixCluster"))
+	g.Expect(cp.KubeadmControlPlane.Name).To(Equal("eksa-unit-test"))
+	g.Expect(cp.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("eksa-unit-test-control-plane-1"))
+	g.Expect(cp.ControlPlaneMachineTemplate.GetName()).To(Equal("eksa-unit-test-control-plane-1"))
+	g.Expect(cp.Secrets).To(HaveLen(1))
+	g.Expect(cp.Secrets[0].Name).To(Equal("eksa-unit-test"))
+	g.Expect(cp.Secrets[0].Namespace).To(Equal(constants.EksaSystemNamespace))
+	g.Expect(cp.Objects()).To(HaveLen(5))
+
+	creds, err := CredentialsFromSecret(cp.Secrets[0])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(creds).To(Equal(testCredentials()))
+}
+
+func TestControlPlaneSpecMissingDatacenterConfig(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/eksa-cluster.yaml")
+	spec.NutanixDatacenter = nil
+
+	_, err := ControlPlaneSpec(context.Background(), test.NewNullLogger(), test.NewFakeKubeClient(), spec, testCredentials())
+	g.Expect(err).To(MatchError(ContainSubstring("NutanixDatacenterConfig not found")))
+}
+
+func TestControlPlaneSpecMissingControlPlaneMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/eksa-cluster.yaml")
+	spec.NutanixMachineConfigs = nil
+
+	_, err := ControlPlaneSpec(context.Background(), test.NewNullLogger(), test.NewFakeKubeClient(), spec, testCredentials())
+	g.Expect(err).To(MatchError(ContainSubstring("control plane NutanixMachineConfig not found")))
+}
@@ -0,0 +1,33 @@
+package nutanix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CredentialsFromSecret reads the Prism Central basic auth credentials from a CAPX credentials Secret,
+// like the one generated for every cluster in the eksa-system namespace.
+func CredentialsFromSecret(secret *corev1.Secret) (credentials.BasicAuthCredential, error) {
+	creds := []credentials.Credential{}
+	if err := json.Unmarshal(secret.Data[credentials.KeyName], &creds); err != nil {
+		return credentials.BasicAuthCredential{}, fmt.Errorf("unmarshalling nutanix credentials from secret %s: %v", secret.Name, err)
+	}
+
+	for _, c := range creds {
+		if c.Type != credentials.BasicAuthCredentialType {
+			continue
+		}
+
+		basicAuth := credentials.BasicAuthCredential{}
+		if err := json.Unmarshal(c.Data, &basicAuth); err != nil {
+			return credentials.BasicAuthCredential{}, fmt.Errorf("unmarshalling nutanix basic auth credentials from secret %s: %v", secret.Name, err)
+		}
+		return basicAuth, nil
+	}
+
+	return credentials.BasicAuthCredential{}, errors.New("no nutanix basic auth credentials found in secret " + secret.Name)
+}
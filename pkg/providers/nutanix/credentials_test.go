@@ -0,0 +1,31 @@
+package nutanix
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCredentialsFromSecretInvalidJSON(t *testing.T) {
+	g := NewWithT(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Data:       map[string][]byte{"credentials": []byte("not-json")},
+	}
+
+	_, err := CredentialsFromSecret(secret)
+	g.Expect(err).To(MatchError(ContainSubstring("unmarshalling nutanix credentials from secret test")))
+}
+
+func TestCredentialsFromSecretNoBasicAuth(t *testing.T) {
+	g := NewWithT(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Data:       map[string][]byte{"credentials": []byte(`[{"type":"other","data":{}}]`)},
+	}
+
+	_, err := CredentialsFromSecret(secret)
+	g.Expect(err).To(MatchError("no nutanix basic auth credentials found in secret test"))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
@@ -19,6 +20,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -141,6 +143,10 @@ func (p *Provider) DeleteResources(ctx context.Context, clusterSpec *cluster.Spe
 	return p.kubectlClient.DeleteEksaNutanixDatacenterConfig(ctx, clusterSpec.NutanixDatacenter.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.NutanixDatacenter.Namespace)
 }
 
+func (p *Provider) CleanupOrphanedResources(_ context.Context, _ *types.Cluster, _ *cluster.Spec) error {
+	return providers.ErrOrphanCleanupNotSupported
+}
+
 func (p *Provider) PostClusterDeleteValidate(ctx context.Context, managementCluster *types.Cluster) error {
 	// TODO(nutanix): figure out if we need something else here
 	return nil
@@ -156,13 +162,20 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 		return fmt.Errorf("failed to validate datacenter config: %v", err)
 	}
 
-	for _, conf := range clusterSpec.NutanixMachineConfigs {
-		if err := p.validator.ValidateMachineConfig(ctx, conf); err != nil {
-			return fmt.Errorf("failed to validate machine config: %v", err)
+	names := make([]string, 0, len(clusterSpec.NutanixMachineConfigs))
+	for name := range clusterSpec.NutanixMachineConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := make([]error, 0, len(names))
+	for _, name := range names {
+		if err := p.validator.ValidateMachineConfig(ctx, clusterSpec.NutanixMachineConfigs[name]); err != nil {
+			errs = append(errs, fmt.Errorf("failed to validate machine config: %v", err))
 		}
 	}
 
-	return nil
+	return validations.AggregateErrors(errs...)
 }
 
 func (p *Provider) SetupAndValidateDeleteCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
@@ -557,7 +570,7 @@ func (p *Provider) UpgradeNeeded(ctx context.Context, newSpec, currentSpec *clus
 	if err != nil {
 		return false, err
 	}
-	if !reflect.DeepEqual(existingVdc.Spec, p.datacenterConfig.Spec) {
+	if !reflect.DeepEqual(existingVdc.Spec, newSpec.NutanixDatacenter.Spec) {
 		logger.V(3).Info("New provider spec is different from the new spec")
 		return true, nil
 	}
@@ -570,18 +583,12 @@ func (p *Provider) UpgradeNeeded(ctx context.Context, newSpec, currentSpec *clus
 }
 
 func (p *Provider) machineConfigsSpecChanged(ctx context.Context, cc *v1alpha1.Cluster, cluster *types.Cluster, newClusterSpec *cluster.Spec) (bool, error) {
-	machineConfigMap := make(map[string]*v1alpha1.NutanixMachineConfig)
-	for _, config := range p.MachineConfigs(nil) {
-		mc := config.(*v1alpha1.NutanixMachineConfig)
-		machineConfigMap[mc.Name] = mc
-	}
-
 	for _, oldMcRef := range cc.MachineConfigRefs() {
 		existingVmc, err := p.kubectlClient.GetEksaNutanixMachineConfig(ctx, oldMcRef.Name, cluster.KubeconfigFile, newClusterSpec.Cluster.Namespace)
 		if err != nil {
 			return false, err
 		}
-		csmc, ok := machineConfigMap[oldMcRef.Name]
+		csmc, ok := newClusterSpec.NutanixMachineConfigs[oldMcRef.Name]
 		if !ok {
 			logger.V(3).Info(fmt.Sprintf("Old machine config spec %s not found in the existing spec", oldMcRef.Name))
 			return true, nil
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/providers/nutanix/validator_registry.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	nutanix "github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	gomock "github.com/golang/mock/gomock"
+	credentials "github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+)
+
+// MockProviderValidator is a mock of ProviderValidator interface.
+type MockProviderValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderValidatorMockRecorder
+}
+
+// MockProviderValidatorMockRecorder is the mock recorder for MockProviderValidator.
+type MockProviderValidatorMockRecorder struct {
+	mock *MockProviderValidator
+}
+
+// NewMockProviderValidator creates a new mock instance.
+func NewMockProviderValidator(ctrl *gomock.Controller) *MockProviderValidator {
+	mock := &MockProviderValidator{ctrl: ctrl}
+	mock.recorder = &MockProviderValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProviderValidator) EXPECT() *MockProviderValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateDatacenterConfig mocks base method.
+func (m *MockProviderValidator) ValidateDatacenterConfig(ctx context.Context, config *v1alpha1.NutanixDatacenterConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateDatacenterConfig", ctx, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateDatacenterConfig indicates an expected call of ValidateDatacenterConfig.
+func (mr *MockProviderValidatorMockRecorder) ValidateDatacenterConfig(ctx, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDatacenterConfig", reflect.TypeOf((*MockProviderValidator)(nil).ValidateDatacenterConfig), ctx, config)
+}
+
+// ValidateMachineConfig mocks base method.
+func (m *MockProviderValidator) ValidateMachineConfig(ctx context.Context, config *v1alpha1.NutanixMachineConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateMachineConfig", ctx, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateMachineConfig indicates an expected call of ValidateMachineConfig.
+func (mr *MockProviderValidatorMockRecorder) ValidateMachineConfig(ctx, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateMachineConfig", reflect.TypeOf((*MockProviderValidator)(nil).ValidateMachineConfig), ctx, config)
+}
+
+// MockValidatorRegistry is a mock of ValidatorRegistry interface.
+type MockValidatorRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidatorRegistryMockRecorder
+}
+
+// MockValidatorRegistryMockRecorder is the mock recorder for MockValidatorRegistry.
+type MockValidatorRegistryMockRecorder struct {
+	mock *MockValidatorRegistry
+}
+
+// NewMockValidatorRegistry creates a new mock instance.
+func NewMockValidatorRegistry(ctrl *gomock.Controller) *MockValidatorRegistry {
+	mock := &MockValidatorRegistry{ctrl: ctrl}
+	mock.recorder = &MockValidatorRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidatorRegistry) EXPECT() *MockValidatorRegistryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockValidatorRegistry) Get(datacenterConfig *v1alpha1.NutanixDatacenterConfig, creds credentials.BasicAuthCredential) (nutanix.ProviderValidator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", datacenterConfig, creds)
+	ret0, _ := ret[0].(nutanix.ProviderValidator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockValidatorRegistryMockRecorder) Get(datacenterConfig, creds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockValidatorRegistry)(nil).Get), datacenterConfig, creds)
+}
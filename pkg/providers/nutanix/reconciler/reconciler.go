@@ -0,0 +1,213 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	apiv1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+)
+
+// CNIReconciler is an interface for reconciling CNI in the Nutanix cluster reconciler.
+type CNIReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
+// BootstrapManifestsReconciler applies the bootstrap manifests of a cluster.
+type BootstrapManifestsReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
+// RemoteClientRegistry is an interface that defines methods for remote clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// Reconciler reconciles Nutanix clusters.
+type Reconciler struct {
+	client               client.Client
+	validatorRegistry    nutanix.ValidatorRegistry
+	cniReconciler        CNIReconciler
+	manifestsReconciler  BootstrapManifestsReconciler
+	remoteClientRegistry RemoteClientRegistry
+	*serverside.ObjectApplier
+}
+
+// New defines a new Nutanix reconciler.
+func New(client client.Client, validatorRegistry nutanix.ValidatorRegistry, cniReconciler CNIReconciler, manifestsReconciler BootstrapManifestsReconciler, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		validatorRegistry:    validatorRegistry,
+		cniReconciler:        cniReconciler,
+		manifestsReconciler:  manifestsReconciler,
+		remoteClientRegistry: remoteClientRegistry,
+		ObjectApplier:        serverside.NewObjectApplier(client),
+	}
+}
+
+// Credentials reads the Prism Central credentials of a cluster from the CAPX credentials Secret of its
+// management cluster, in the eksa-system namespace.
+func Credentials(ctx context.Context, cli client.Client, cluster *anywherev1.Cluster) (credentials.BasicAuthCredential, error) {
+	name := cluster.ManagedBy()
+	if name == "" {
+		name = cluster.Name
+	}
+
+	secret := &apiv1.Secret{}
+	key := client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: name}
+	if err := cli.Get(ctx, key, secret); err != nil {
+		return credentials.BasicAuthCredential{}, fmt.Errorf("failed getting nutanix credentials secret %s: %v", name, err)
+	}
+
+	creds, err := nutanix.CredentialsFromSecret(secret)
+	if err != nil {
+		return credentials.BasicAuthCredential{}, err
+	}
+	logger.RegisterSecrets(creds.PrismCentral.Password)
+
+	return creds, nil
+}
+
+// Reconcile reconciles the cluster to the desired state defined in its spec.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	log = log.WithValues("provider", "nutanix")
+	clusterSpec, err := c.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.NewPhaseRunner().Register(
+		r.ValidateDatacenterConfig,
+		r.ValidateMachineConfigs,
+		r.ReconcileControlPlane,
+		r.CheckControlPlaneReady,
+		r.ReconcileCNI,
+		r.ReconcileBootstrapManifests,
+		r.ReconcileWorkers,
+	).Run(ctx, log, clusterSpec)
+}
+
+// ValidateDatacenterConfig validates the NutanixDatacenterConfig against Prism Central, updating
+// the cluster status if it's invalid.
+func (r *Reconciler) ValidateDatacenterConfig(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateDatacenterConfig")
+	validator, err := r.validator(ctx, clusterSpec)
+	if err != nil {
+		log.Error(err, "Failed to build Nutanix validator")
+		return controller.Result{}, err
+	}
+
+	if err := validator.ValidateDatacenterConfig(ctx, clusterSpec.NutanixDatacenter); err != nil {
+		log.Error(err, "Invalid NutanixDatacenterConfig")
+		failureMessage := err.Error()
+		clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+		return controller.Result{}, err
+	}
+	return controller.Result{}, nil
+}
+
+// ValidateMachineConfigs validates the images, clusters and subnets referenced by the machine configs
+// against Prism Central, updating the cluster status if any of them is invalid.
+func (r *Reconciler) ValidateMachineConfigs(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateMachineConfigs")
+	validator, err := r.validator(ctx, clusterSpec)
+	if err != nil {
+		log.Error(err, "Failed to build Nutanix validator")
+		return controller.Result{}, err
+	}
+
+	for _, machineConfig := range clusterSpec.NutanixMachineConfigs {
+		if err := validator.ValidateMachineConfig(ctx, machineConfig); err != nil {
+			log.Error(err, "Invalid NutanixMachineConfig", "machineConfig", machineConfig.Name)
+			failureMessage := err.Error()
+			clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+			return controller.Result{}, err
+		}
+	}
+	return controller.Result{}, nil
+}
+
+// ReconcileControlPlane applies the control plane CAPI objects to the cluster.
+func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileControlPlane")
+	log.Info("Applying control plane CAPI objects")
+	return r.Apply(ctx, func() ([]kubernetes.Object, error) {
+		creds, err := Credentials(ctx, r.client, clusterSpec.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		cp, err := nutanix.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec, creds)
+		if err != nil {
+			return nil, err
+		}
+		return cp.Objects(), nil
+	})
+}
+
+// CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
+// Requeues with the appropriate wait times whenever the cluster is not ready yet.
+func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "checkControlPlaneReady")
+	return clusters.CheckControlPlaneReady(ctx, r.client, log, clusterSpec.Cluster)
+}
+
+// ReconcileCNI takes the Cilium CNI in a cluster to the desired state defined in a cluster spec.
+func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileCNI")
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
+// ReconcileBootstrapManifests applies the bootstrap manifests of the cluster spec once its CNI is ready.
+func (r *Reconciler) ReconcileBootstrapManifests(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileBootstrapManifests")
+	if len(clusterSpec.Cluster.Spec.BootstrapManifests) == 0 {
+		return controller.Result{}, nil
+	}
+
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.manifestsReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
+// ReconcileWorkers applies the worker CAPI objects to the cluster.
+func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileWorkers")
+	log.Info("Applying worker CAPI objects")
+	return r.Apply(ctx, func() ([]kubernetes.Object, error) {
+		w, err := nutanix.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+		if err != nil {
+			return nil, err
+		}
+		return w.WorkerObjects(), nil
+	})
+}
+
+func (r *Reconciler) validator(ctx context.Context, clusterSpec *c.Spec) (nutanix.ProviderValidator, error) {
+	creds, err := Credentials(ctx, r.client, clusterSpec.Cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.validatorRegistry.Get(clusterSpec.NutanixDatacenter, creds)
+}
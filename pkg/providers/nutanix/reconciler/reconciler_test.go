@@ -0,0 +1,238 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	clusterspec "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix/reconciler"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix/reconciler/mocks"
+)
+
+func TestCredentialsSuccess(t *testing.T) {
+	g := NewWithT(t)
+	cli := fake.NewClientBuilder().WithObjects(credentialsSecret("management-cluster")).Build()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-cluster"},
+		Spec: anywherev1.ClusterSpec{
+			ManagementCluster: anywherev1.ManagementCluster{Name: "management-cluster"},
+		},
+	}
+
+	creds, err := reconciler.Credentials(context.Background(), cli, cluster)
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(creds.PrismCentral.Username).To(Equal("admin"))
+	g.Expect(creds.PrismCentral.Password).To(Equal("password"))
+}
+
+func TestCredentialsMissingSecret(t *testing.T) {
+	g := NewWithT(t)
+	cli := fake.NewClientBuilder().Build()
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload-cluster"}}
+
+	_, err := reconciler.Credentials(context.Background(), cli, cluster)
+
+	g.Expect(err).To(MatchError(ContainSubstring("failed getting nutanix credentials secret workload-cluster")))
+}
+
+func TestReconcilerValidateDatacenterConfigSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.validatorRegistry.EXPECT().Get(tt.spec.NutanixDatacenter, gomock.Any()).Return(tt.validator, nil)
+	tt.validator.EXPECT().ValidateDatacenterConfig(tt.ctx, tt.spec.NutanixDatacenter).Return(nil)
+
+	result, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateDatacenterConfigInvalid(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.validatorRegistry.EXPECT().Get(tt.spec.NutanixDatacenter, gomock.Any()).Return(tt.validator, nil)
+	tt.validator.EXPECT().ValidateDatacenterConfig(tt.ctx, tt.spec.NutanixDatacenter).Return(errors.New("prism central unreachable"))
+
+	_, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("prism central unreachable")))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(HaveValue(Equal("prism central unreachable")))
+}
+
+func TestReconcilerValidateDatacenterConfigMissingCredentials(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.client = fake.NewClientBuilder().Build()
+
+	_, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("failed getting nutanix credentials secret management-cluster")))
+}
+
+func TestReconcilerValidateMachineConfigsSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.validatorRegistry.EXPECT().Get(tt.spec.NutanixDatacenter, gomock.Any()).Return(tt.validator, nil)
+	tt.validator.EXPECT().ValidateMachineConfig(tt.ctx, tt.spec.NutanixMachineConfigs["cp-machine"]).Return(nil)
+	tt.validator.EXPECT().ValidateMachineConfig(tt.ctx, tt.spec.NutanixMachineConfigs["worker-machine"]).Return(nil)
+
+	result, err := tt.reconciler().ValidateMachineConfigs(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateMachineConfigsInvalid(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.validatorRegistry.EXPECT().Get(tt.spec.NutanixDatacenter, gomock.Any()).Return(tt.validator, nil)
+	tt.validator.EXPECT().ValidateMachineConfig(tt.ctx, gomock.Any()).Return(errors.New("image not found"))
+
+	_, err := tt.reconciler().ValidateMachineConfigs(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("image not found")))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(HaveValue(Equal("image not found")))
+}
+
+func TestReconcilerValidateMachineConfigsErrorValidatorRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.validatorRegistry.EXPECT().Get(tt.spec.NutanixDatacenter, gomock.Any()).Return(nil, errors.New("building client"))
+
+	_, err := tt.reconciler().ValidateMachineConfigs(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerCheckControlPlaneReadyNoCAPICluster(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	result, err := tt.reconciler().CheckControlPlaneReady(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(5 * time.Second)))
+}
+
+func TestReconcilerReconcileCNISuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: constants.EksaSystemNamespace},
+	).Return(remoteClient, nil)
+	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, gomock.Any(), remoteClient, tt.spec)
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileCNIErrorClientRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: constants.EksaSystemNamespace},
+	).Return(nil, errors.New("building client"))
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileBootstrapManifestsSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.spec.Cluster.Spec.BootstrapManifests = []anywherev1.BootstrapManifest{{Name: "manifests", Inline: "apiVersion: v1"}}
+	remoteClient := fake.NewClientBuilder().Build()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: constants.EksaSystemNamespace},
+	).Return(remoteClient, nil)
+	tt.manifestsReconciler.EXPECT().Reconcile(tt.ctx, gomock.Any(), remoteClient, tt.spec)
+
+	result, err := tt.reconciler().ReconcileBootstrapManifests(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileBootstrapManifestsNoManifests(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	result, err := tt.reconciler().ReconcileBootstrapManifests(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+type reconcilerTest struct {
+	t testing.TB
+	*WithT
+	ctx                  context.Context
+	spec                 *clusterspec.Spec
+	client               client.Client
+	validatorRegistry    *mocks.MockValidatorRegistry
+	validator            *mocks.MockProviderValidator
+	cniReconciler        *mocks.MockCNIReconciler
+	manifestsReconciler  *mocks.MockBootstrapManifestsReconciler
+	remoteClientRegistry *mocks.MockRemoteClientRegistry
+}
+
+func newReconcilerTest(t testing.TB) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	spec := test.NewClusterSpec(func(s *clusterspec.Spec) {
+		s.Cluster.Name = "workload-cluster"
+		s.Cluster.Namespace = "default"
+		s.Cluster.Spec.ManagementCluster.Name = "management-cluster"
+		s.NutanixDatacenter = &anywherev1.NutanixDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "datacenter", Namespace: "default"},
+			Spec:       anywherev1.NutanixDatacenterConfigSpec{Endpoint: "prism.nutanix.com", Port: 9440},
+		}
+		s.NutanixMachineConfigs = map[string]*anywherev1.NutanixMachineConfig{
+			"cp-machine":     {ObjectMeta: metav1.ObjectMeta{Name: "cp-machine", Namespace: "default"}},
+			"worker-machine": {ObjectMeta: metav1.ObjectMeta{Name: "worker-machine", Namespace: "default"}},
+		}
+	})
+
+	return &reconcilerTest{
+		t:                    t,
+		WithT:                NewWithT(t),
+		ctx:                  context.Background(),
+		spec:                 spec,
+		client:               fake.NewClientBuilder().WithObjects(credentialsSecret("management-cluster")).Build(),
+		validatorRegistry:    mocks.NewMockValidatorRegistry(ctrl),
+		validator:            mocks.NewMockProviderValidator(ctrl),
+		cniReconciler:        mocks.NewMockCNIReconciler(ctrl),
+		manifestsReconciler:  mocks.NewMockBootstrapManifestsReconciler(ctrl),
+		remoteClientRegistry: mocks.NewMockRemoteClientRegistry(ctrl),
+	}
+}
+
+func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
+	return reconciler.New(tt.client, tt.validatorRegistry, tt.cniReconciler, tt.manifestsReconciler, tt.remoteClientRegistry)
+}
+
+func credentialsSecret(name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			credentials.KeyName: []byte(`[{"type":"basic_auth","data":{"prismCentral":{"username":"admin","password":"password"}}}]`),
+		},
+	}
+}
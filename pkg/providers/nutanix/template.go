@@ -9,9 +9,11 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -129,10 +131,18 @@ func buildTemplateMapCP(
 		"clusterName":                  clusterSpec.Cluster.Name,
 		"controlPlaneEndpointIp":       clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host,
 		"controlPlaneReplicas":         clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count,
+		"controlPlaneSchedulable":      clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Schedulable,
+		"apiServerCertSANs":            clusterSpec.Cluster.Spec.ControlPlaneConfiguration.CertSANs,
+		"staticPodManifests":           clusterapi.StaticPodManifestFiles(clusterSpec.Cluster.Spec.ControlPlaneConfiguration),
 		"controlPlaneSshAuthorizedKey": controlPlaneMachineSpec.Users[0].SshAuthorizedKeys[0],
 		"controlPlaneSshUsername":      controlPlaneMachineSpec.Users[0].Name,
 		"eksaSystemNamespace":          constants.EksaSystemNamespace,
 		"format":                       format,
+		"kubeletExtraArgs":             kubeletExtraArgs(clusterSpec.Cluster).ToPartialYaml(),
+		"credentialProviderFiles":      clusterapi.ImageCredentialProviderConfigFiles(clusterSpec.Cluster),
+		"etcdEncryptionFiles":          clusterapi.EtcdEncryptionConfigFiles(clusterSpec.Cluster),
+		"etcdEncryptionVolumes":        clusterapi.EtcdEncryptionExtraVolumes(clusterSpec.Cluster, controlPlaneMachineSpec.OSFamily),
+		"apiserverExtraArgs":           clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster).ToPartialYaml(),
 		"podCidrs":                     clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
 		"serviceCidrs":                 clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"kubernetesVersion":            bundle.KubeDistro.Kubernetes.Tag,
@@ -158,6 +168,7 @@ func buildTemplateMapCP(
 		"subnetName":                   controlPlaneMachineSpec.Subnet.Name,  // TODO(nutanix): pass name or uuid based on type of identifier
 	}
 	values["nutanixInsecure"] = datacenterSpec.AdditionalTrustBundle != ""
+	common.SetKubeVipValues(values, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint)
 
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
 		values["externalEtcd"] = true
@@ -173,26 +184,35 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 	format := "cloud-config"
 
 	values := map[string]interface{}{
-		"clusterName":            clusterSpec.Cluster.Name,
-		"eksaSystemNamespace":    constants.EksaSystemNamespace,
-		"format":                 format,
-		"kubernetesVersion":      bundle.KubeDistro.Kubernetes.Tag,
-		"workerReplicas":         *workerNodeGroupConfiguration.Count,
-		"workerPoolName":         "md-0",
-		"workerSshAuthorizedKey": workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys[0],
-		"workerSshUsername":      workerNodeGroupMachineSpec.Users[0].Name,
-		"vcpusPerSocket":         workerNodeGroupMachineSpec.VCPUsPerSocket,
-		"vcpuSockets":            workerNodeGroupMachineSpec.VCPUSockets,
-		"memorySize":             workerNodeGroupMachineSpec.MemorySize.String(),
-		"systemDiskSize":         workerNodeGroupMachineSpec.SystemDiskSize.String(),
-		"imageName":              workerNodeGroupMachineSpec.Image.Name,   // TODO(nutanix): pass name or uuid based on type of identifier
-		"nutanixPEClusterName":   workerNodeGroupMachineSpec.Cluster.Name, // TODO(nutanix): pass name or uuid based on type of identifier
-		"subnetName":             workerNodeGroupMachineSpec.Subnet.Name,  // TODO(nutanix): pass name or uuid based on type of identifier
-		"workerNodeGroupName":    fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name),
+		"clusterName":             clusterSpec.Cluster.Name,
+		"eksaSystemNamespace":     constants.EksaSystemNamespace,
+		"format":                  format,
+		"kubeletExtraArgs":        kubeletExtraArgs(clusterSpec.Cluster).ToPartialYaml(),
+		"credentialProviderFiles": clusterapi.ImageCredentialProviderConfigFiles(clusterSpec.Cluster),
+		"kubernetesVersion":       bundle.KubeDistro.Kubernetes.Tag,
+		"workerReplicas":          *workerNodeGroupConfiguration.Count,
+		"workerPoolName":          "md-0",
+		"workerSshAuthorizedKey":  workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys[0],
+		"workerSshUsername":       workerNodeGroupMachineSpec.Users[0].Name,
+		"vcpusPerSocket":          workerNodeGroupMachineSpec.VCPUsPerSocket,
+		"vcpuSockets":             workerNodeGroupMachineSpec.VCPUSockets,
+		"memorySize":              workerNodeGroupMachineSpec.MemorySize.String(),
+		"systemDiskSize":          workerNodeGroupMachineSpec.SystemDiskSize.String(),
+		"imageName":               workerNodeGroupMachineSpec.Image.Name,   // TODO(nutanix): pass name or uuid based on type of identifier
+		"nutanixPEClusterName":    workerNodeGroupMachineSpec.Cluster.Name, // TODO(nutanix): pass name or uuid based on type of identifier
+		"subnetName":              workerNodeGroupMachineSpec.Subnet.Name,  // TODO(nutanix): pass name or uuid based on type of identifier
+		"workerNodeGroupName":     fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name),
 	}
 	return values
 }
 
+// kubeletExtraArgs returns the kubelet args shared by the control plane and worker nodes.
+func kubeletExtraArgs(cluster *v1alpha1.Cluster) clusterapi.ExtraArgs {
+	return clusterapi.ExtraArgs{}.
+		Append(clusterapi.KubeletServingCertificateExtraArgs(cluster)).
+		Append(clusterapi.ImageCredentialProviderExtraArgs(cluster))
+}
+
 func buildTemplateMapSecret(clusterSpec *cluster.Spec, creds []byte) map[string]interface{} {
 	values := map[string]interface{}{
 		"clusterName":              clusterSpec.Cluster.Name,
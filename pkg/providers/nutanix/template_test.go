@@ -117,3 +117,100 @@ func TestNewNutanixTemplateBuilderGenerateCAPISpecSecret(t *testing.T) {
 	assert.Nil(t, secretSpec)
 	assert.Error(t, err)
 }
+
+func TestNewNutanixTemplateBuilderControlPlaneSchedulable(t *testing.T) {
+	dcConf := &anywherev1.NutanixDatacenterConfig{}
+	err := yaml.Unmarshal([]byte(nutanixDatacenterConfigSpec), dcConf)
+	require.NoError(t, err)
+
+	machineConf := &anywherev1.NutanixMachineConfig{}
+	err = yaml.Unmarshal([]byte(nutanixMachineConfigSpec), machineConf)
+	require.NoError(t, err)
+
+	workerConfs := map[string]anywherev1.NutanixMachineConfigSpec{
+		"eksa-unit-test": machineConf.Spec,
+	}
+
+	t.Setenv(constants.NutanixUsernameKey, "admin")
+	t.Setenv(constants.NutanixPasswordKey, "password")
+	creds := GetCredsFromEnv()
+	builder := NewNutanixTemplateBuilder(&dcConf.Spec, &machineConf.Spec, &machineConf.Spec, workerConfs, creds, time.Now)
+
+	v := version.Info{GitVersion: "v0.0.1"}
+	buildSpec, err := cluster.NewSpecFromClusterConfig("testdata/eksa-cluster.yaml", v, cluster.WithReleasesManifest("testdata/simple_release.yaml"))
+	require.NoError(t, err)
+
+	cpSpec, err := builder.GenerateCAPISpecControlPlane(buildSpec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(cpSpec), "taints: []")
+	assert.NotContains(t, string(cpSpec), "joinConfiguration:")
+
+	buildSpec.Cluster.Spec.ControlPlaneConfiguration.Schedulable = true
+	cpSpec, err = builder.GenerateCAPISpecControlPlane(buildSpec)
+	require.NoError(t, err)
+	assert.Contains(t, string(cpSpec), `    initConfiguration:
+      nodeRegistration:
+        kubeletExtraArgs:
+          # We have to pin the cgroupDriver to cgroupfs as kubeadm >=1.21 defaults to systemd
+          # kind will implement systemd support in: https://github.com/kubernetes-sigs/kind/issues/1726
+          #cgroup-driver: cgroupfs
+          eviction-hard: nodefs.available<0%,nodefs.inodesFree<0%,imagefs.available<0%
+        taints: []
+    joinConfiguration:
+      nodeRegistration:
+        kubeletExtraArgs:
+          eviction-hard: nodefs.available<0%,nodefs.inodesFree<0%,imagefs.available<0%
+        taints: []
+`)
+}
+
+func TestNewNutanixTemplateBuilderEtcdEncryption(t *testing.T) {
+	dcConf := &anywherev1.NutanixDatacenterConfig{}
+	err := yaml.Unmarshal([]byte(nutanixDatacenterConfigSpec), dcConf)
+	require.NoError(t, err)
+
+	machineConf := &anywherev1.NutanixMachineConfig{}
+	err = yaml.Unmarshal([]byte(nutanixMachineConfigSpec), machineConf)
+	require.NoError(t, err)
+
+	workerConfs := map[string]anywherev1.NutanixMachineConfigSpec{
+		"eksa-unit-test": machineConf.Spec,
+	}
+
+	t.Setenv(constants.NutanixUsernameKey, "admin")
+	t.Setenv(constants.NutanixPasswordKey, "password")
+	creds := GetCredsFromEnv()
+	builder := NewNutanixTemplateBuilder(&dcConf.Spec, &machineConf.Spec, &machineConf.Spec, workerConfs, creds, time.Now)
+
+	v := version.Info{GitVersion: "v0.0.1"}
+	buildSpec, err := cluster.NewSpecFromClusterConfig("testdata/eksa-cluster.yaml", v, cluster.WithReleasesManifest("testdata/simple_release.yaml"))
+	require.NoError(t, err)
+	buildSpec.Cluster.Spec.EtcdEncryption = []anywherev1.EtcdEncryption{
+		{
+			Providers: []anywherev1.EtcdEncryptionProvider{
+				{KMS: &anywherev1.KMS{Name: "aws-encryption-provider", SocketListenAddress: "unix:///var/run/kmsplugin/socket.sock"}},
+			},
+		},
+	}
+
+	cpSpec, err := builder.GenerateCAPISpecControlPlane(buildSpec)
+	require.NoError(t, err)
+	assert.Contains(t, string(cpSpec), `        extraArgs:
+          encryption-provider-config: /etc/kubernetes/enc/encryption-config.yaml
+        extraVolumes:
+        - hostPath: /etc/kubernetes/enc
+          mountPath: /etc/kubernetes/enc
+          name: encryption-config
+          pathType: DirectoryOrCreate
+          readOnly: true
+        - hostPath: /var/run/kmsplugin
+          mountPath: /var/run/kmsplugin
+          name: kms-plugin-0
+          pathType: DirectoryOrCreate
+          readOnly: false
+      controllerManager:
+`)
+	assert.Contains(t, string(cpSpec), `        path: /etc/kubernetes/enc/encryption-config.yaml
+        permissions: "0600"
+`)
+}
@@ -0,0 +1,44 @@
+package nutanix
+
+import (
+	"context"
+
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/crypto"
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
+)
+
+// ProviderValidator validates the Nutanix configs of a cluster against Prism Central.
+type ProviderValidator interface {
+	ValidateDatacenterConfig(ctx context.Context, config *anywherev1.NutanixDatacenterConfig) error
+	ValidateMachineConfig(ctx context.Context, config *anywherev1.NutanixMachineConfig) error
+}
+
+// ValidatorRegistry builds validators authenticated with a set of Prism Central credentials.
+type ValidatorRegistry interface {
+	Get(datacenterConfig *anywherev1.NutanixDatacenterConfig, creds credentials.BasicAuthCredential) (ProviderValidator, error)
+}
+
+// PrismValidatorRegistry builds validators calling the Prism Central v3 API.
+type PrismValidatorRegistry struct {
+	certValidator crypto.TlsValidator
+}
+
+// NewValidatorRegistry builds a PrismValidatorRegistry.
+func NewValidatorRegistry(certValidator crypto.TlsValidator) *PrismValidatorRegistry {
+	return &PrismValidatorRegistry{
+		certValidator: certValidator,
+	}
+}
+
+// Get returns a Validator with a client for the Prism Central of datacenterConfig, authenticated with creds.
+func (r *PrismValidatorRegistry) Get(datacenterConfig *anywherev1.NutanixDatacenterConfig, creds credentials.BasicAuthCredential) (ProviderValidator, error) {
+	client, err := NewPrismClient(datacenterConfig, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewValidator(NewRateLimitedClient(client.V3, ratelimit.For(datacenterConfig.Spec.Endpoint)), r.certValidator), nil
+}
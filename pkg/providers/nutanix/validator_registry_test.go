@@ -0,0 +1,40 @@
+package nutanix
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/crypto"
+)
+
+func TestValidatorRegistryGetSuccess(t *testing.T) {
+	g := NewWithT(t)
+	registry := NewValidatorRegistry(crypto.NewTlsValidator())
+	datacenterConfig := &v1alpha1.NutanixDatacenterConfig{
+		Spec: v1alpha1.NutanixDatacenterConfigSpec{
+			Endpoint: "prism.nutanix.com",
+			Port:     9440,
+		},
+	}
+
+	validator, err := registry.Get(datacenterConfig, testCredentials())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(validator).NotTo(BeNil())
+}
+
+func TestValidatorRegistryGetInvalidTrustBundle(t *testing.T) {
+	g := NewWithT(t)
+	registry := NewValidatorRegistry(crypto.NewTlsValidator())
+	datacenterConfig := &v1alpha1.NutanixDatacenterConfig{
+		Spec: v1alpha1.NutanixDatacenterConfigSpec{
+			Endpoint:              "prism.nutanix.com",
+			Port:                  9440,
+			AdditionalTrustBundle: "invalid",
+		},
+	}
+
+	_, err := registry.Get(datacenterConfig, testCredentials())
+	g.Expect(err).To(HaveOccurred())
+}
@@ -2,6 +2,7 @@ package providers
 
 import (
 	"context"
+	"errors"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
@@ -9,6 +10,10 @@ import (
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+// ErrOrphanCleanupNotSupported is returned by the providers that can't remove the infrastructure of a cluster
+// without its CAPI objects.
+var ErrOrphanCleanupNotSupported = errors.New("cleaning up orphaned resources is not supported by this provider")
+
 type Provider interface {
 	Name() string
 	SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error
@@ -39,6 +44,9 @@ type Provider interface {
 	RunPostControlPlaneUpgrade(ctx context.Context, oldClusterSpec *cluster.Spec, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, managementCluster *types.Cluster) error
 	UpgradeNeeded(ctx context.Context, newSpec, currentSpec *cluster.Spec, cluster *types.Cluster) (bool, error)
 	DeleteResources(ctx context.Context, clusterSpec *cluster.Spec) error
+	// CleanupOrphanedResources removes the infrastructure of a cluster whose CAPI objects are gone or can't be read,
+	// without relying on them. managementCluster is nil when the cluster was not managed by another cluster.
+	CleanupOrphanedResources(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error
 	InstallCustomProviderComponents(ctx context.Context, kubeconfigFile string) error
 	PostClusterDeleteValidate(ctx context.Context, managementCluster *types.Cluster) error
 	// PostMoveManagementToBootstrap is called after the CAPI management is moved back to the bootstrap cluster.
@@ -17,6 +17,7 @@ limitations under the License.
 package snow
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
@@ -152,6 +153,10 @@ type AWSSnowMachineSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	Devices []string `json:"devices,omitempty"`
 
+	// Network is the DNI configuration for the machine.
+	// +optional
+	Network AWSSnowNetwork `json:"network,omitempty"`
+
 	// SpotMarketOptions allows users to configure instances to be run using AWS Spot instances.
 	// TODO: Evaluate the need or remove completely.
 	// +optional
@@ -164,6 +169,42 @@ type AWSSnowMachineSpec struct {
 	// Tenancy string `json:"tenancy,omitempty"`
 }
 
+// AWSSnowNetwork specifies the network configurations for snow.
+type AWSSnowNetwork struct {
+	// DirectNetworkInterfaces is a list of DNI configurations.
+	// A maximum of 8 may be specified.
+	// +optional
+	// +kubebuilder:validation:MaxItems=8
+	DirectNetworkInterfaces []AWSSnowDirectNetworkInterface `json:"directNetworkInterfaces,omitempty"`
+}
+
+// AWSSnowDirectNetworkInterface defines a direct network interface (DNI) configuration.
+type AWSSnowDirectNetworkInterface struct {
+	// Index is the index number of DNI used to clarify the position in the list. Usually starts with 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8
+	// +optional
+	Index int `json:"index,omitempty"`
+
+	// VlanID is the vlan id assigned by the user for the DNI.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4095
+	// +optional
+	VlanID *int32 `json:"vlanID,omitempty"`
+
+	// DHCP defines whether DHCP is used to assign ip for the DNI.
+	// +optional
+	DHCP bool `json:"dhcp,omitempty"`
+
+	// IPPool is the reference to the AWSSnowIPPool that provides the ip address for the DNI.
+	// +optional
+	IPPool *corev1.ObjectReference `json:"ipPool,omitempty"`
+
+	// Primary indicates whether the DNI is primary or not.
+	// +optional
+	Primary bool `json:"primary,omitempty"`
+}
+
 // CloudInit defines options related to the bootstrapping systems where
 // CloudInit is used.
 // TODO: Right now, this is a full copy of awsmachine_types.go in cluster-api-provider-aws.
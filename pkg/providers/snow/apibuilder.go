@@ -2,6 +2,7 @@ package snow
 
 import (
 	"fmt"
+	"sort"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,6 +21,7 @@ import (
 const (
 	SnowClusterKind         = "AWSSnowCluster"
 	SnowMachineTemplateKind = "AWSSnowMachineTemplate"
+	SnowIPPoolKind          = "AWSSnowIPPool"
 )
 
 func CAPICluster(clusterSpec *cluster.Spec, snowCluster *snowv1.AWSSnowCluster, kubeadmControlPlane *controlplanev1.KubeadmControlPlane) *clusterv1.Cluster {
@@ -203,8 +205,79 @@ func SnowMachineTemplate(name string, machineConfig *v1alpha1.SnowMachineConfig)
 					PhysicalNetworkConnectorType: &networkConnector,
 					Devices:                      machineConfig.Spec.Devices,
 					ContainersVolume:             machineConfig.Spec.ContainersVolume,
+					Network:                      snowNetwork(machineConfig.Spec.Network),
 				},
 			},
 		},
 	}
 }
+
+func snowNetwork(network v1alpha1.SnowNetwork) snowv1.AWSSnowNetwork {
+	var dnis []snowv1.AWSSnowDirectNetworkInterface
+	for _, dni := range network.DirectNetworkInterfaces {
+		d := snowv1.AWSSnowDirectNetworkInterface{
+			Index:   dni.Index,
+			VlanID:  dni.VlanID,
+			DHCP:    dni.DHCP,
+			Primary: dni.Primary,
+		}
+
+		if dni.IPPoolRef != nil {
+			d.IPPool = &v1.ObjectReference{
+				APIVersion: clusterapi.InfrastructureAPIVersion(),
+				Kind:       SnowIPPoolKind,
+				Name:       dni.IPPoolRef.Name,
+				Namespace:  constants.EksaSystemNamespace,
+			}
+		}
+
+		dnis = append(dnis, d)
+	}
+
+	return snowv1.AWSSnowNetwork{
+		DirectNetworkInterfaces: dnis,
+	}
+}
+
+// SnowIPPool builds the AWSSnowIPPool in the eksa-system namespace from a SnowIPPool.
+func SnowIPPool(pool *v1alpha1.SnowIPPool) *snowv1.AWSSnowIPPool {
+	ipPools := make([]snowv1.IPPool, 0, len(pool.Spec.Pools))
+	for _, p := range pool.Spec.Pools {
+		p := p
+		ipPools = append(ipPools, snowv1.IPPool{
+			IPStart: &p.IPStart,
+			IPEnd:   &p.IPEnd,
+			Subnet:  &p.Subnet,
+			Gateway: &p.Gateway,
+		})
+	}
+
+	return &snowv1.AWSSnowIPPool{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterapi.InfrastructureAPIVersion(),
+			Kind:       SnowIPPoolKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pool.GetName(),
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: snowv1.AWSSnowIPPoolSpec{
+			IPPools: ipPools,
+		},
+	}
+}
+
+// SnowIPPools builds the AWSSnowIPPools for all the SnowIPPools in the cluster spec, sorted by name.
+func SnowIPPools(clusterSpec *cluster.Spec) []*snowv1.AWSSnowIPPool {
+	names := make([]string, 0, len(clusterSpec.SnowIPPools))
+	for name := range clusterSpec.SnowIPPools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pools := make([]*snowv1.AWSSnowIPPool, 0, len(names))
+	for _, name := range names {
+		pools = append(pools, SnowIPPool(clusterSpec.SnowIPPools[name]))
+	}
+	return pools
+}
@@ -185,6 +185,7 @@ var registryMirrorTests = []struct {
 	name                 string
 	registryMirrorConfig *v1alpha1.RegistryMirrorConfiguration
 	wantFiles            []bootstrapv1.File
+	wantCommands         []string
 }{
 	{
 		name: "with ca cert",
@@ -209,6 +210,9 @@ var registryMirrorTests = []struct {
 				Content: "xyz",
 			},
 		},
+		wantCommands: []string{
+			"if command -v update-ca-certificates >/dev/null; then cp /etc/containerd/certs.d/1.2.3.4:443/ca.crt /usr/local/share/ca-certificates/registry-mirror-ca.crt && update-ca-certificates; else cp /etc/containerd/certs.d/1.2.3.4:443/ca.crt /etc/pki/ca-trust/source/anchors/registry-mirror-ca.crt && update-ca-trust extract; fi",
+		},
 	},
 	{
 		name: "with insecure skip",
@@ -270,6 +274,9 @@ var registryMirrorTests = []struct {
 				Content: "xyz",
 			},
 		},
+		wantCommands: []string{
+			"if command -v update-ca-certificates >/dev/null; then cp /etc/containerd/certs.d/1.2.3.4:443/ca.crt /usr/local/share/ca-certificates/registry-mirror-ca.crt && update-ca-certificates; else cp /etc/containerd/certs.d/1.2.3.4:443/ca.crt /etc/pki/ca-trust/source/anchors/registry-mirror-ca.crt && update-ca-trust extract; fi",
+		},
 	},
 }
 
@@ -283,7 +290,7 @@ func TestKubeadmControlPlaneWithRegistryMirror(t *testing.T) {
 			g.Expect(err).To(Succeed())
 			want := wantKubeadmControlPlane()
 			want.Spec.KubeadmConfigSpec.Files = tt.wantFiles
-			want.Spec.KubeadmConfigSpec.PreKubeadmCommands = append(want.Spec.KubeadmConfigSpec.PreKubeadmCommands, wantRegistryMirrorCommands()...)
+			want.Spec.KubeadmConfigSpec.PreKubeadmCommands = append(want.Spec.KubeadmConfigSpec.PreKubeadmCommands, append(tt.wantCommands, wantRegistryMirrorCommands()...)...)
 			g.Expect(got).To(Equal(want))
 		})
 	}
@@ -582,6 +589,106 @@ func TestSnowMachineTemplate(t *testing.T) {
 	tt.Expect(got).To(Equal(want))
 }
 
+func TestSnowMachineTemplateWithNetwork(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	vlanID := int32(1)
+	machineConfig := tt.machineConfigs["test-cp"]
+	machineConfig.Spec.Network = v1alpha1.SnowNetwork{
+		DirectNetworkInterfaces: []v1alpha1.SnowDirectNetworkInterface{
+			{
+				Index:   1,
+				DHCP:    true,
+				Primary: true,
+			},
+			{
+				Index:  2,
+				VlanID: &vlanID,
+				IPPoolRef: &v1alpha1.Ref{
+					Kind: v1alpha1.SnowIPPoolKind,
+					Name: "ip-pool-1",
+				},
+			},
+		},
+	}
+	got := snow.SnowMachineTemplate("snow-test-control-plane-1", machineConfig)
+	want := wantSnowMachineTemplate()
+	want.SetName("snow-test-control-plane-1")
+	want.Spec.Template.Spec.InstanceType = "sbe-c.large"
+	want.Spec.Template.Spec.Network = snowv1.AWSSnowNetwork{
+		DirectNetworkInterfaces: []snowv1.AWSSnowDirectNetworkInterface{
+			{
+				Index:   1,
+				DHCP:    true,
+				Primary: true,
+			},
+			{
+				Index:  2,
+				VlanID: &vlanID,
+				IPPool: &v1.ObjectReference{
+					APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+					Kind:       "AWSSnowIPPool",
+					Name:       "ip-pool-1",
+					Namespace:  "eksa-system",
+				},
+			},
+		},
+	}
+	tt.Expect(got).To(Equal(want))
+}
+
+func TestSnowIPPools(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.clusterSpec.SnowIPPools = map[string]*v1alpha1.SnowIPPool{
+		"ip-pool-2": givenSnowIPPool("ip-pool-2"),
+		"ip-pool-1": givenSnowIPPool("ip-pool-1"),
+	}
+	got := snow.SnowIPPools(tt.clusterSpec)
+	tt.Expect(got).To(Equal([]*snowv1.AWSSnowIPPool{wantSnowIPPool("ip-pool-1"), wantSnowIPPool("ip-pool-2")}))
+}
+
+func givenSnowIPPool(name string) *v1alpha1.SnowIPPool {
+	return &v1alpha1.SnowIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-namespace",
+		},
+		Spec: v1alpha1.SnowIPPoolSpec{
+			Pools: []v1alpha1.IPPool{
+				{
+					IPStart: "1.2.3.10",
+					IPEnd:   "1.2.3.20",
+					Subnet:  "1.2.3.0/24",
+					Gateway: "1.2.3.1",
+				},
+			},
+		},
+	}
+}
+
+func wantSnowIPPool(name string) *snowv1.AWSSnowIPPool {
+	ipStart, ipEnd, subnet, gateway := "1.2.3.10", "1.2.3.20", "1.2.3.0/24", "1.2.3.1"
+	return &snowv1.AWSSnowIPPool{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+			Kind:       "AWSSnowIPPool",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "eksa-system",
+		},
+		Spec: snowv1.AWSSnowIPPoolSpec{
+			IPPools: []snowv1.IPPool{
+				{
+					IPStart: &ipStart,
+					IPEnd:   &ipEnd,
+					Subnet:  &subnet,
+					Gateway: &gateway,
+				},
+			},
+		},
+	}
+}
+
 func tlsCipherSuitesArgs() map[string]string {
 	return map[string]string{"tls-cipher-suites": "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}
 }
@@ -12,6 +12,10 @@ type AwsClient interface {
 	EC2ImportKeyPair(ctx context.Context, keyName string, keyMaterial []byte) error
 	IsSnowballDeviceUnlocked(ctx context.Context) (bool, error)
 	SnowballDeviceSoftwareVersion(ctx context.Context) (string, error)
+	EC2TerminateInstances(ctx context.Context, ids []string) error
+	EC2InstanceTagsByTag(ctx context.Context, key, value string) (map[string]map[string]string, error)
+	EC2CreateTags(ctx context.Context, ids []string, tags map[string]string) error
+	EC2DeleteTags(ctx context.Context, ids []string, keys []string) error
 }
 
 type AwsClientMap map[string]AwsClient
@@ -0,0 +1,131 @@
+package snow
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/aws"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// CertificateRenewalWindow is how long before their expiration the device certificates are reported as expiring.
+	CertificateRenewalWindow = 30 * 24 * time.Hour
+
+	// BootstrapCredentialsSecretName is the secret holding the credentials the CAPAS and EKS-A controllers use
+	// to reach the devices.
+	BootstrapCredentialsSecretName = "capas-manager-bootstrap-credentials"
+)
+
+// CertificatesExpiry returns the earliest expiration date of the device certificates in an eks-a snow credentials secret.
+func CertificatesExpiry(secret *v1.Secret) (time.Time, error) {
+	certs, ok := secret.Data[v1alpha1.SnowCertificatesKey]
+	if !ok {
+		return time.Time{}, fmt.Errorf("unable to retrieve %s from secret [%s]", v1alpha1.SnowCertificatesKey, secret.GetName())
+	}
+
+	return aws.CABundleExpiry(decodeSecretData(certs))
+}
+
+// CertificatesExpiryMessage describes the state of device certificates expiring at expiry. It returns an empty
+// string when they are valid beyond the CertificateRenewalWindow.
+func CertificatesExpiryMessage(expiry, now time.Time) string {
+	switch {
+	case !now.Before(expiry):
+		return fmt.Sprintf("snow device certificates expired on %s, connections to the devices will fail until they are renewed", expiry.Format(time.RFC3339))
+	case expiry.Sub(now) < CertificateRenewalWindow:
+		return fmt.Sprintf("snow device certificates expire on %s, renew them before then", expiry.Format(time.RFC3339))
+	default:
+		return ""
+	}
+}
+
+func warnOnCertificatesExpiry(secret *v1.Secret) {
+	if secret == nil {
+		return
+	}
+
+	expiry, err := CertificatesExpiry(secret)
+	if err != nil {
+		logger.V(3).Info("Unable to read snow device certificates expiry", "error", err)
+		return
+	}
+
+	if message := CertificatesExpiryMessage(expiry, time.Now()); message != "" {
+		logger.Info("Warning: " + message)
+	}
+}
+
+// decodeSecretData returns the content of the files stored in the eks-a credentials secret, which holds them
+// base64 encoded like the CAPAS env variables. Content that isn't base64 encoded is returned as is.
+func decodeSecretData(data []byte) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return data
+	}
+	return decoded
+}
+
+// CredentialsRotator replaces the device credentials and certificates of a cluster in its management cluster.
+type CredentialsRotator struct {
+	client KubeUnAuthClient
+}
+
+// NewCredentialsRotator returns a CredentialsRotator that updates the secrets through client.
+func NewCredentialsRotator(client KubeUnAuthClient) *CredentialsRotator {
+	return &CredentialsRotator{client: client}
+}
+
+// Rotate applies config.SnowCredentialsSecret in managementCluster, along with the CAPAS secret derived from it and
+// the bootstrap credentials. The controllers pick the new credentials up on their next reconcile, without restarting.
+func (r *CredentialsRotator) Rotate(ctx context.Context, managementCluster *types.Cluster, config *cluster.Config) error {
+	secret := config.SnowCredentialsSecret
+	expiry, err := CertificatesExpiry(secret)
+	if err != nil {
+		return fmt.Errorf("reading new snow device certificates: %v", err)
+	}
+	if !time.Now().Before(expiry) {
+		return fmt.Errorf("new snow device certificates expired on %s", expiry.Format(time.RFC3339))
+	}
+
+	capasSecret, err := capasCredentialsSecret(&cluster.Spec{Config: config})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range []*v1.Secret{secret, capasSecret, bootstrapCredentialsSecret(secret)} {
+		logger.V(3).Info("Updating snow credentials secret", "name", s.Name, "namespace", s.Namespace)
+		if err := r.client.Apply(ctx, managementCluster.KubeconfigFile, s); err != nil {
+			return fmt.Errorf("applying snow credentials secret %s: %v", s.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func bootstrapCredentialsSecret(eksaSecret *v1.Secret) *v1.Secret {
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      BootstrapCredentialsSecretName,
+			Namespace: constants.CapasSystemNamespace,
+		},
+		Data: map[string][]byte{
+			v1alpha1.SnowCredentialsKey:  decodeSecretData(eksaSecret.Data[v1alpha1.SnowCredentialsKey]),
+			v1alpha1.SnowCertificatesKey: decodeSecretData(eksaSecret.Data[v1alpha1.SnowCertificatesKey]),
+		},
+		Type: v1.SecretTypeOpaque,
+	}
+}
@@ -0,0 +1,154 @@
+package snow_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+)
+
+func certificatePEM(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "snow"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &k.PublicKey, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func credentialsSecretWithCertificates(t *testing.T, notAfter time.Time) *v1.Secret {
+	s := wantEksaCredentialsSecret()
+	s.Data["credentials"] = []byte(base64.StdEncoding.EncodeToString([]byte("creds")))
+	s.Data["ca-bundle"] = []byte(base64.StdEncoding.EncodeToString(certificatePEM(t, notAfter)))
+	return s
+}
+
+func TestCertificatesExpiry(t *testing.T) {
+	g := NewWithT(t)
+	notAfter := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	expiry, err := snow.CertificatesExpiry(credentialsSecretWithCertificates(t, notAfter))
+	g.Expect(err).To(Succeed())
+	g.Expect(expiry).To(BeTemporally("==", notAfter))
+}
+
+func TestCertificatesExpiryRawData(t *testing.T) {
+	g := NewWithT(t)
+	notAfter := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	s := wantEksaCredentialsSecret()
+	s.Data["ca-bundle"] = certificatePEM(t, notAfter)
+
+	expiry, err := snow.CertificatesExpiry(s)
+	g.Expect(err).To(Succeed())
+	g.Expect(expiry).To(BeTemporally("==", notAfter))
+}
+
+func TestCertificatesExpiryMissingBundle(t *testing.T) {
+	g := NewWithT(t)
+	s := wantEksaCredentialsSecret()
+	delete(s.Data, "ca-bundle")
+
+	_, err := snow.CertificatesExpiry(s)
+	g.Expect(err).To(MatchError(ContainSubstring("unable to retrieve ca-bundle from secret [test-snow-credentials]")))
+}
+
+func TestCertificatesExpiryMessage(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		expiry time.Time
+		want   string
+	}{
+		"expired": {
+			expiry: now.Add(-time.Hour),
+			want:   "snow device certificates expired on 2025-12-31T23:00:00Z, connections to the devices will fail until they are renewed",
+		},
+		"expiring": {
+			expiry: now.Add(24 * time.Hour),
+			want:   "snow device certificates expire on 2026-01-02T00:00:00Z, renew them before then",
+		},
+		"valid": {
+			expiry: now.Add(snow.CertificateRenewalWindow),
+			want:   "",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(snow.CertificatesExpiryMessage(tc.expiry, now)).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestCredentialsRotatorRotate(t *testing.T) {
+	tt := newSnowTest(t)
+	secret := credentialsSecretWithCertificates(t, time.Now().Add(365*24*time.Hour))
+	tt.clusterSpec.SnowCredentialsSecret = secret
+	rotator := snow.NewCredentialsRotator(tt.kubeUnAuthClient)
+
+	gomock.InOrder(
+		tt.kubeUnAuthClient.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, secret),
+		tt.kubeUnAuthClient.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, o runtime.Object) error {
+				obj := o.(*v1.Secret)
+				tt.Expect(obj.Name).To(Equal("snow-test-snow-credentials"))
+				tt.Expect(obj.Namespace).To(Equal(constants.EksaSystemNamespace))
+				tt.Expect(obj.Data).To(Equal(secret.Data))
+				return nil
+			},
+		),
+		tt.kubeUnAuthClient.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, o runtime.Object) error {
+				obj := o.(*v1.Secret)
+				tt.Expect(obj.Name).To(Equal(snow.BootstrapCredentialsSecretName))
+				tt.Expect(obj.Namespace).To(Equal(constants.CapasSystemNamespace))
+				tt.Expect(obj.Data["credentials"]).To(Equal([]byte("creds")))
+				tt.Expect(string(obj.Data["ca-bundle"])).To(HavePrefix("-----BEGIN CERTIFICATE-----"))
+				return nil
+			},
+		),
+	)
+
+	tt.Expect(rotator.Rotate(tt.ctx, tt.cluster, tt.clusterSpec.Config)).To(Succeed())
+}
+
+func TestCredentialsRotatorRotateExpiredCertificates(t *testing.T) {
+	tt := newSnowTest(t)
+	tt.clusterSpec.SnowCredentialsSecret = credentialsSecretWithCertificates(t, time.Now().Add(-time.Hour))
+
+	err := snow.NewCredentialsRotator(tt.kubeUnAuthClient).Rotate(tt.ctx, tt.cluster, tt.clusterSpec.Config)
+	tt.Expect(err).To(MatchError(ContainSubstring("new snow device certificates expired on")))
+}
+
+func TestCredentialsRotatorRotateApplyError(t *testing.T) {
+	tt := newSnowTest(t)
+	tt.clusterSpec.SnowCredentialsSecret = credentialsSecretWithCertificates(t, time.Now().Add(365*24*time.Hour))
+	tt.kubeUnAuthClient.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).Return(errors.New("connection refused"))
+
+	err := snow.NewCredentialsRotator(tt.kubeUnAuthClient).Rotate(tt.ctx, tt.cluster, tt.clusterSpec.Config)
+	tt.Expect(err).To(MatchError("applying snow credentials secret test-snow-credentials: connection refused"))
+}
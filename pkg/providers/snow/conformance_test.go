@@ -0,0 +1,75 @@
+package snow_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	kubemock "github.com/aws/eks-anywhere/pkg/clients/kubernetes/mocks"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/conformance"
+	"github.com/aws/eks-anywhere/pkg/providers/snow/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const missingAMI = "ami-missing"
+
+func TestProviderConformance(t *testing.T) {
+	conformance.Run(t, conformance.Subject{
+		New: func(t *testing.T) (providers.Provider, *cluster.Spec) {
+			setupContext(t)
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			kubeUnAuthClient := mocks.NewMockKubeUnAuthClient(ctrl)
+			kubeconfigClient := kubemock.NewMockClient(ctrl)
+			aws := mocks.NewMockAwsClient(ctrl)
+
+			kubeUnAuthClient.EXPECT().KubeconfigClient(gomock.Any()).Return(kubeconfigClient).AnyTimes()
+			kubeconfigClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(apierrors.NewNotFound(schema.GroupResource{}, "")).AnyTimes()
+			aws.EXPECT().EC2ImageExists(gomock.Any(), missingAMI).Return(false, nil).AnyTimes()
+			aws.EXPECT().EC2ImageExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+			aws.EXPECT().EC2KeyNameExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+			aws.EXPECT().IsSnowballDeviceUnlocked(gomock.Any()).Return(true, nil).AnyTimes()
+			aws.EXPECT().SnowballDeviceSoftwareVersion(gomock.Any()).Return("102", nil).AnyTimes()
+
+			return newProvider(ctx, t, kubeUnAuthClient, aws, ctrl), givenClusterSpec()
+		},
+		Cluster: &types.Cluster{Name: "cluster"},
+		UpgradeTriggers: map[string]func(*cluster.Spec){
+			"new manager image": func(s *cluster.Spec) {
+				s.VersionsBundle.Snow.Manager.ImageDigest = "sha256:new"
+			},
+			"new machine instance type": func(s *cluster.Spec) {
+				s.SnowMachineConfigs["test-wn"].Spec.InstanceType = v1alpha1.SbeC2XLarge
+			},
+		},
+		VersionBump: func(s *cluster.Spec) {
+			s.VersionsBundle.Snow.Version = "v9.9.9"
+		},
+		SnapshotDir: "testdata/snapshots",
+		SnapshotMatrix: conformance.Matrix{
+			"controlplane": {
+				"single": func(s *cluster.Spec) { s.Cluster.Spec.ControlPlaneConfiguration.Count = 1 },
+				"ha":     func(s *cluster.Spec) { s.Cluster.Spec.ControlPlaneConfiguration.Count = 3 },
+			},
+			"workers": {
+				"untainted": func(s *cluster.Spec) { s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Taints = nil },
+				"tainted": func(s *cluster.Spec) {
+					s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Taints = []corev1.Taint{{Key: "dedicated", Value: "snow", Effect: corev1.TaintEffectNoSchedule}}
+				},
+			},
+		},
+		InvalidSpecs: map[string]func(*cluster.Spec){
+			"ami missing from the devices": func(s *cluster.Spec) {
+				s.SnowMachineConfigs["test-cp"].Spec.AMIID = missingAMI
+			},
+		},
+	})
+}
@@ -46,6 +46,11 @@ func (cm *ConfigManager) snowEntry(ctx context.Context) *cluster.ConfigManagerEn
 			},
 		},
 		Validations: []cluster.Validation{
+			func(c *cluster.Config) error {
+				// Only warn, the validations connecting to the devices fail if the certificates are really unusable.
+				warnOnCertificatesExpiry(c.SnowCredentialsSecret)
+				return nil
+			},
 			func(c *cluster.Config) error {
 				for _, m := range c.SnowMachineConfigs {
 					if err := cm.validator.ValidateEC2ImageExistsOnDevice(ctx, m); err != nil {
@@ -34,6 +34,34 @@ func (m *MockAwsClient) EXPECT() *MockAwsClientMockRecorder {
 	return m.recorder
 }
 
+// EC2CreateTags mocks base method.
+func (m *MockAwsClient) EC2CreateTags(ctx context.Context, ids []string, tags map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2CreateTags", ctx, ids, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EC2CreateTags indicates an expected call of EC2CreateTags.
+func (mr *MockAwsClientMockRecorder) EC2CreateTags(ctx, ids, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2CreateTags", reflect.TypeOf((*MockAwsClient)(nil).EC2CreateTags), ctx, ids, tags)
+}
+
+// EC2DeleteTags mocks base method.
+func (m *MockAwsClient) EC2DeleteTags(ctx context.Context, ids, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2DeleteTags", ctx, ids, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EC2DeleteTags indicates an expected call of EC2DeleteTags.
+func (mr *MockAwsClientMockRecorder) EC2DeleteTags(ctx, ids, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2DeleteTags", reflect.TypeOf((*MockAwsClient)(nil).EC2DeleteTags), ctx, ids, keys)
+}
+
 // EC2ImageExists mocks base method.
 func (m *MockAwsClient) EC2ImageExists(ctx context.Context, imageID string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -63,6 +91,21 @@ func (mr *MockAwsClientMockRecorder) EC2ImportKeyPair(ctx, keyName, keyMaterial
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2ImportKeyPair", reflect.TypeOf((*MockAwsClient)(nil).EC2ImportKeyPair), ctx, keyName, keyMaterial)
 }
 
+// EC2InstanceTagsByTag mocks base method.
+func (m *MockAwsClient) EC2InstanceTagsByTag(ctx context.Context, key, value string) (map[string]map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2InstanceTagsByTag", ctx, key, value)
+	ret0, _ := ret[0].(map[string]map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EC2InstanceTagsByTag indicates an expected call of EC2InstanceTagsByTag.
+func (mr *MockAwsClientMockRecorder) EC2InstanceTagsByTag(ctx, key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2InstanceTagsByTag", reflect.TypeOf((*MockAwsClient)(nil).EC2InstanceTagsByTag), ctx, key, value)
+}
+
 // EC2KeyNameExists mocks base method.
 func (m *MockAwsClient) EC2KeyNameExists(ctx context.Context, keyName string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +121,20 @@ func (mr *MockAwsClientMockRecorder) EC2KeyNameExists(ctx, keyName interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2KeyNameExists", reflect.TypeOf((*MockAwsClient)(nil).EC2KeyNameExists), ctx, keyName)
 }
 
+// EC2TerminateInstances mocks base method.
+func (m *MockAwsClient) EC2TerminateInstances(ctx context.Context, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2TerminateInstances", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EC2TerminateInstances indicates an expected call of EC2TerminateInstances.
+func (mr *MockAwsClientMockRecorder) EC2TerminateInstances(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2TerminateInstances", reflect.TypeOf((*MockAwsClient)(nil).EC2TerminateInstances), ctx, ids)
+}
+
 // IsSnowballDeviceUnlocked mocks base method.
 func (m *MockAwsClient) IsSnowballDeviceUnlocked(ctx context.Context) (bool, error) {
 	m.ctrl.T.Helper()
@@ -40,7 +40,12 @@ func ControlPlaneObjects(ctx context.Context, clusterSpec *cluster.Spec, kubeCli
 	}
 	capiCluster := CAPICluster(clusterSpec, snowCluster, kubeadmControlPlane)
 
-	return []kubernetes.Object{capiCluster, snowCluster, kubeadmControlPlane, new, capasCredentialsSecret}, nil
+	objs := []kubernetes.Object{capiCluster, snowCluster, kubeadmControlPlane, new, capasCredentialsSecret}
+	for _, pool := range SnowIPPools(clusterSpec) {
+		objs = append(objs, pool)
+	}
+
+	return objs, nil
 }
 
 func WorkersObjects(ctx context.Context, clusterSpec *cluster.Spec, kubeClient kubernetes.Client) ([]kubernetes.Object, error) {
@@ -139,10 +144,14 @@ func NewMachineTemplateName(new, old *snowv1.AWSSnowMachineTemplate) string {
 // new subset slice equal to the original slice. i.e. DeepDerivative([]int{1}, []int{1, 2}) returns true.
 // Custom logic is added to justify this usecase since removing a device from the devices list shall trigger machine
 // rollout and recreate or the snow cluster goes into a state where the machines on the removed device can’t be deleted.
+// The same applies to removing a direct network interface.
 func MachineTemplateDeepDerivative(new, old *snowv1.AWSSnowMachineTemplate) bool {
 	if len(new.Spec.Template.Spec.Devices) != len(old.Spec.Template.Spec.Devices) {
 		return false
 	}
+	if len(new.Spec.Template.Spec.Network.DirectNetworkInterfaces) != len(old.Spec.Template.Spec.Network.DirectNetworkInterfaces) {
+		return false
+	}
 	return equality.Semantic.DeepDerivative(new.Spec, old.Spec)
 }
 
@@ -14,6 +14,7 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
@@ -145,6 +146,29 @@ func TestControlPlaneObjectsOldControlPlaneNotExists(t *testing.T) {
 	g.Expect(got).To(Equal([]kubernetes.Object{wantCAPICluster(), wantSnowCluster(), wantKubeadmControlPlane(), mt, wantSnowCredentialsSecret()}))
 }
 
+func TestControlPlaneObjectsWithIPPools(t *testing.T) {
+	g := newSnowTest(t)
+	g.clusterSpec.SnowIPPools = map[string]*v1alpha1.SnowIPPool{
+		"ip-pool-1": givenSnowIPPool("ip-pool-1"),
+	}
+	mt := wantSnowMachineTemplate()
+	g.kubeconfigClient.EXPECT().
+		Get(
+			g.ctx,
+			"snow-test",
+			constants.EksaSystemNamespace,
+			&controlplanev1.KubeadmControlPlane{},
+		).
+		Return(apierrors.NewNotFound(schema.GroupResource{Group: "", Resource: ""}, ""))
+
+	mt.SetName("snow-test-control-plane-1")
+	mt.Spec.Template.Spec.InstanceType = "sbe-c.large"
+
+	got, err := snow.ControlPlaneObjects(g.ctx, g.clusterSpec, g.kubeconfigClient)
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal([]kubernetes.Object{wantCAPICluster(), wantSnowCluster(), wantKubeadmControlPlane(), mt, wantSnowCredentialsSecret(), wantSnowIPPool("ip-pool-1")}))
+}
+
 func TestControlPlaneObjectsOldMachineTemplateNotExists(t *testing.T) {
 	g := newSnowTest(t)
 	mt := wantSnowMachineTemplate()
@@ -646,7 +670,7 @@ func TestKubeadmConfigTemplatesWithRegistryMirror(t *testing.T) {
 				"md-0": wantKubeadmConfigTemplate(),
 			}
 			wantKct["md-0"].Spec.Template.Spec.Files = tt.wantFiles
-			wantKct["md-0"].Spec.Template.Spec.PreKubeadmCommands = append(wantKct["md-0"].Spec.Template.Spec.PreKubeadmCommands, wantRegistryMirrorCommands()...)
+			wantKct["md-0"].Spec.Template.Spec.PreKubeadmCommands = append(wantKct["md-0"].Spec.Template.Spec.PreKubeadmCommands, append(tt.wantCommands, wantRegistryMirrorCommands()...)...)
 			g.Expect(gotMt).To(Equal(wantMt))
 			g.Expect(gotKct).To(Equal(wantKct))
 		})
@@ -753,6 +777,43 @@ func TestNewMachineTemplateName(t *testing.T) {
 			},
 			want: "old-2",
 		},
+		{
+			name: "remove one dni",
+			old: &snowv1.AWSSnowMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "old-1",
+				},
+				Spec: snowv1.AWSSnowMachineTemplateSpec{
+					Template: snowv1.AWSSnowMachineTemplateResource{
+						Spec: snowv1.AWSSnowMachineSpec{
+							Network: snowv1.AWSSnowNetwork{
+								DirectNetworkInterfaces: []snowv1.AWSSnowDirectNetworkInterface{
+									{Index: 1, DHCP: true, Primary: true},
+									{Index: 2, DHCP: true},
+								},
+							},
+						},
+					},
+				},
+			},
+			new: &snowv1.AWSSnowMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-1",
+				},
+				Spec: snowv1.AWSSnowMachineTemplateSpec{
+					Template: snowv1.AWSSnowMachineTemplateResource{
+						Spec: snowv1.AWSSnowMachineSpec{
+							Network: snowv1.AWSSnowNetwork{
+								DirectNetworkInterfaces: []snowv1.AWSSnowDirectNetworkInterface{
+									{Index: 1, DHCP: true, Primary: true},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: "old-2",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
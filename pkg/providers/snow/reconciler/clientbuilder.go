@@ -3,7 +3,9 @@ package reconciler
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"net"
+	"sync"
 
 	awstypes "github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
@@ -16,8 +18,15 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
 )
 
+// AwsClientBuilder builds the device clients from the CAPAS bootstrap credentials secret. The clients are
+// rebuilt when the credentials or certificates in the secret change, so rotated credentials are used
+// without restarting the controller.
 type AwsClientBuilder struct {
 	client client.Client
+
+	mu       sync.Mutex
+	checksum [sha256.Size]byte
+	clients  snow.AwsClientMap
 }
 
 func NewAwsClientBuilder(client client.Client) *AwsClientBuilder {
@@ -33,11 +42,26 @@ func (b *AwsClientBuilder) Get(ctx context.Context) (snow.AwsClientMap, error) {
 		return nil, errors.Wrap(err, "getting snow credentials")
 	}
 
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	checksum := credentialsChecksum(credentials, certificates)
+	if b.clients != nil && checksum == b.checksum {
+		return b.clients, nil
+	}
+
 	clients, err := createAwsClients(ctx, credentials, certificates)
 	if err != nil {
 		return nil, err
 	}
-	return snow.NewAwsClientMap(clients), nil
+
+	b.clients = snow.NewAwsClientMap(clients)
+	b.checksum = checksum
+	return b.clients, nil
+}
+
+func credentialsChecksum(credentials, certificates []byte) [sha256.Size]byte {
+	return sha256.Sum256(bytes.Join([][]byte{credentials, certificates}, []byte{0}))
 }
 
 type credentialConfiguration struct {
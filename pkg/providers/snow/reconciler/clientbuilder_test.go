@@ -2,6 +2,7 @@ package reconciler_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -151,3 +152,36 @@ func testSecret(creds string) *corev1.Secret {
 		},
 	}
 }
+
+func TestBuildSnowAwsClientMapReusesClients(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cl := fake.NewClientBuilder().WithRuntimeObjects(testSecret(validCredentials)).Build()
+	clientBuilder := reconciler.NewAwsClientBuilder(cl)
+
+	first, err := clientBuilder.Get(ctx)
+	g.Expect(err).To(Succeed())
+	second, err := clientBuilder.Get(ctx)
+	g.Expect(err).To(Succeed())
+
+	g.Expect(second["1.2.3.4"]).To(BeIdenticalTo(first["1.2.3.4"]))
+}
+
+func TestBuildSnowAwsClientMapRotatedCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	secret := testSecret(validCredentials)
+	cl := fake.NewClientBuilder().WithRuntimeObjects(secret).Build()
+	clientBuilder := reconciler.NewAwsClientBuilder(cl)
+
+	first, err := clientBuilder.Get(ctx)
+	g.Expect(err).To(Succeed())
+
+	secret.Data["credentials"] = []byte(strings.ReplaceAll(validCredentials, "ABCDEFGHIJKLMNOPQR2T", "ABCDEFGHIJKLMNOPQR3T"))
+	g.Expect(cl.Update(ctx, secret)).To(Succeed())
+
+	second, err := clientBuilder.Get(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(second).To(HaveLen(2))
+	g.Expect(second["1.2.3.4"]).NotTo(BeIdenticalTo(first["1.2.3.4"]))
+}
@@ -7,9 +7,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
 )
 
-const BoostrapSecretName = "capas-manager-bootstrap-credentials"
+const BoostrapSecretName = snow.BootstrapCredentialsSecretName
 
 func getSnowCredentials(ctx context.Context, cli client.Client) (credentials, caBundle []byte, err error) {
 	secret := &apiv1.Secret{}
@@ -3,8 +3,10 @@ package reconciler
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -21,6 +23,11 @@ type CNIReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
 }
 
+// BootstrapManifestsReconciler applies the bootstrap manifests of a cluster.
+type BootstrapManifestsReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
+}
+
 type RemoteClientRegistry interface {
 	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
 }
@@ -28,15 +35,19 @@ type RemoteClientRegistry interface {
 type Reconciler struct {
 	client               client.Client
 	cniReconciler        CNIReconciler
+	manifestsReconciler  BootstrapManifestsReconciler
 	remoteClientRegistry RemoteClientRegistry
+	awsClientRegistry    snow.ClientRegistry
 	*serverside.ObjectApplier
 }
 
-func New(client client.Client, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+func New(client client.Client, cniReconciler CNIReconciler, manifestsReconciler BootstrapManifestsReconciler, remoteClientRegistry RemoteClientRegistry, awsClientRegistry snow.ClientRegistry) *Reconciler {
 	return &Reconciler{
 		client:               client,
 		cniReconciler:        cniReconciler,
+		manifestsReconciler:  manifestsReconciler,
 		remoteClientRegistry: remoteClientRegistry,
+		awsClientRegistry:    awsClientRegistry,
 		ObjectApplier:        serverside.NewObjectApplier(client),
 	}
 }
@@ -49,14 +60,38 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 	}
 
 	return controller.NewPhaseRunner().Register(
+		r.CheckCertificatesExpiry,
 		r.ValidateMachineConfigs,
 		r.ReconcileControlPlane,
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
+		r.ReconcileBootstrapManifests,
 		r.ReconcileWorkers,
+		r.ReconcileTags,
 	).Run(ctx, log, clusterSpec)
 }
 
+// CheckCertificatesExpiry reports device certificates that expired or are about to, so they can be rotated
+// before the controllers lose access to the devices. It never stops the reconciliation.
+func (r *Reconciler) CheckCertificatesExpiry(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "checkCertificatesExpiry")
+	if clusterSpec.SnowCredentialsSecret == nil {
+		return controller.Result{}, nil
+	}
+
+	expiry, err := snow.CertificatesExpiry(clusterSpec.SnowCredentialsSecret)
+	if err != nil {
+		log.Error(err, "Reading snow device certificates expiry")
+		return controller.Result{}, nil
+	}
+
+	if message := snow.CertificatesExpiryMessage(expiry, time.Now()); message != "" {
+		log.Info("Warning: "+message, "secret", clusterSpec.SnowCredentialsSecret.Name)
+	}
+
+	return controller.Result{}, nil
+}
+
 func (r *Reconciler) ValidateMachineConfigs(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "validateMachineConfigs")
 	for _, machineConfig := range clusterSpec.SnowMachineConfigs {
@@ -77,10 +112,24 @@ func (r *Reconciler) ValidateMachineConfigs(ctx context.Context, log logr.Logger
 
 func (s *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileControlPlane")
-	log.Info("Applying control plane CAPI objects")
 
+	objs, err := snow.ControlPlaneObjects(ctx, clusterSpec, clientutil.NewKubeClient(s.client))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	for _, obj := range objs {
+		if kcp, ok := obj.(*controlplanev1.KubeadmControlPlane); ok {
+			result, err := clusters.CheckControlPlaneRolloutReady(ctx, s.client, log, clusterSpec.Cluster, kcp)
+			if err != nil || result.Return() {
+				return result, err
+			}
+		}
+	}
+
+	log.Info("Applying control plane CAPI objects")
 	return s.Apply(ctx, func() ([]kubernetes.Object, error) {
-		return snow.ControlPlaneObjects(ctx, clusterSpec, clientutil.NewKubeClient(s.client))
+		return objs, nil
 	})
 }
 
@@ -100,6 +149,21 @@ func (s *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterS
 	return s.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
 }
 
+// ReconcileBootstrapManifests applies the bootstrap manifests of the cluster spec once its CNI is ready.
+func (s *Reconciler) ReconcileBootstrapManifests(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileBootstrapManifests")
+	if len(clusterSpec.Cluster.Spec.BootstrapManifests) == 0 {
+		return controller.Result{}, nil
+	}
+
+	client, err := s.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return s.manifestsReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
 func (s *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileWorkers")
 	log.Info("Applying worker CAPI objects")
@@ -108,3 +172,19 @@ func (s *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clus
 		return snow.WorkersObjects(ctx, clusterSpec, clientutil.NewKubeClient(s.client))
 	})
 }
+
+// ReconcileTags propagates the tags of the cluster spec to the EC2 instances of the cluster.
+func (s *Reconciler) ReconcileTags(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileTags")
+	clients, err := s.awsClientRegistry.Get(ctx)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	log.Info("Tagging cluster instances")
+	if err := snow.SyncInstanceTags(ctx, clients, clusterSpec); err != nil {
+		return controller.Result{}, fmt.Errorf("tagging cluster instances: %v", err)
+	}
+
+	return controller.Result{}, nil
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	snowmocks "github.com/aws/eks-anywhere/pkg/providers/snow/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/snow/reconciler"
 	"github.com/aws/eks-anywhere/pkg/providers/snow/reconciler/mocks"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
@@ -49,6 +51,8 @@ func TestReconcilerReconcileSuccess(t *testing.T) {
 		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
 	).Return(remoteClient, nil)
 	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, tt.buildSpec())
+	tt.awsClientRegistry.EXPECT().Get(tt.ctx).Return(snow.AwsClientMap{"1.2.3.4": tt.awsClient}, nil)
+	tt.awsClient.EXPECT().EC2InstanceTagsByTag(tt.ctx, gomock.Any(), "owned").Return(nil, nil)
 
 	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
 
@@ -87,6 +91,26 @@ func TestReconcilerValidateMachineConfigsInvalidControlPlaneMachineConfig(t *tes
 	tt.Expect(*tt.cluster.Status.FailureMessage).To(ContainSubstring("Something wrong"))
 }
 
+func TestReconcilerCheckCertificatesExpiryInvalidBundle(t *testing.T) {
+	g := NewWithT(t)
+	spec := &clusterspec.Spec{Config: &clusterspec.Config{SnowCredentialsSecret: credentialsSecret()}}
+
+	result, err := reconciler.New(nil, nil, nil, nil, nil).CheckCertificatesExpiry(context.Background(), test.NewNullLogger(), spec)
+
+	g.Expect(err).To(BeNil(), "certificates errors should not stop the reconciliation")
+	g.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerCheckCertificatesExpiryNoSecret(t *testing.T) {
+	g := NewWithT(t)
+	spec := &clusterspec.Spec{Config: &clusterspec.Config{}}
+
+	result, err := reconciler.New(nil, nil, nil, nil, nil).CheckCertificatesExpiry(context.Background(), test.NewNullLogger(), spec)
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+}
+
 func TestReconcilerReconcileWorkers(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.createAllObjs()
@@ -162,12 +186,79 @@ func TestReconcilerReconcileCNIErrorClientRegistry(t *testing.T) {
 	tt.Expect(result).To(Equal(controller.Result{}))
 }
 
+func TestReconcilerReconcileBootstrapManifestsSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.BootstrapManifests = []anywherev1.BootstrapManifest{
+		{Name: "namespaces", Inline: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: team-a\n"},
+	}
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.manifestsReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
+
+	result, err := tt.reconciler().ReconcileBootstrapManifests(tt.ctx, logger, spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileBootstrapManifestsNoManifests(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ReconcileBootstrapManifests(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileTags(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.Tags = map[string]string{"environment": "prod"}
+	tt.withFakeClient()
+	tagKey := "sigs.k8s.io/cluster-api-provider-aws-snow/cluster/workload-cluster"
+
+	tt.awsClientRegistry.EXPECT().Get(tt.ctx).Return(snow.AwsClientMap{"1.2.3.4": tt.awsClient}, nil)
+	tt.awsClient.EXPECT().EC2InstanceTagsByTag(tt.ctx, tagKey, "owned").Return(map[string]map[string]string{
+		"i-1": {tagKey: "owned"},
+	}, nil)
+	tt.awsClient.EXPECT().EC2CreateTags(tt.ctx, []string{"i-1"}, map[string]string{
+		"environment":            "prod",
+		constants.ManagedTagsKey: "environment",
+	})
+
+	result, err := tt.reconciler().ReconcileTags(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileTagsErrorGettingClients(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	tt.awsClientRegistry.EXPECT().Get(tt.ctx).Return(nil, errors.New("getting snow credentials"))
+
+	_, err := tt.reconciler().ReconcileTags(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(MatchError("getting snow credentials"))
+}
+
 type reconcilerTest struct {
 	t testing.TB
 	*WithT
 	ctx                       context.Context
 	cniReconciler             *mocks.MockCNIReconciler
+	manifestsReconciler       *mocks.MockBootstrapManifestsReconciler
 	remoteClientRegistry      *mocks.MockRemoteClientRegistry
+	awsClientRegistry         *snowmocks.MockClientRegistry
+	awsClient                 *snowmocks.MockAwsClient
 	cluster                   *anywherev1.Cluster
 	client                    client.Client
 	env                       *envtest.Environment
@@ -179,7 +270,10 @@ type reconcilerTest struct {
 func newReconcilerTest(t testing.TB) *reconcilerTest {
 	ctrl := gomock.NewController(t)
 	cniReconciler := mocks.NewMockCNIReconciler(ctrl)
+	manifestsReconciler := mocks.NewMockBootstrapManifestsReconciler(ctrl)
 	remoteClientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+	awsClientRegistry := snowmocks.NewMockClientRegistry(ctrl)
+	awsClient := snowmocks.NewMockAwsClient(ctrl)
 	client := env.Client()
 
 	bundle := createBundle()
@@ -256,7 +350,10 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 		WithT:                NewWithT(t),
 		ctx:                  context.Background(),
 		cniReconciler:        cniReconciler,
+		manifestsReconciler:  manifestsReconciler,
 		remoteClientRegistry: remoteClientRegistry,
+		awsClientRegistry:    awsClientRegistry,
+		awsClient:            awsClient,
 		client:               client,
 		env:                  env,
 		eksaSupportObjs: []envtest.Object{
@@ -307,7 +404,7 @@ func (tt *reconcilerTest) withFakeClient() {
 }
 
 func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
-	return reconciler.New(tt.client, tt.cniReconciler, tt.remoteClientRegistry)
+	return reconciler.New(tt.client, tt.cniReconciler, tt.manifestsReconciler, tt.remoteClientRegistry, tt.awsClientRegistry)
 }
 
 func (tt *reconcilerTest) createAllObjs() {
@@ -3,6 +3,7 @@ package snow
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -16,6 +17,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	providerValidator "github.com/aws/eks-anywhere/pkg/providers/validator"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/templater"
@@ -41,6 +43,7 @@ type SnowProvider struct {
 	kubeUnAuthClient KubeUnAuthClient
 	retrier          *retrier.Retrier
 	configManager    *ConfigManager
+	clientRegistry   ClientRegistry
 	skipIpCheck      bool
 }
 
@@ -50,12 +53,13 @@ type KubeUnAuthClient interface {
 	Apply(ctx context.Context, kubeconfig string, obj runtime.Object) error
 }
 
-func NewProvider(kubeUnAuthClient KubeUnAuthClient, configManager *ConfigManager, skipIpCheck bool) *SnowProvider {
+func NewProvider(kubeUnAuthClient KubeUnAuthClient, configManager *ConfigManager, clientRegistry ClientRegistry, skipIpCheck bool) *SnowProvider {
 	retrier := retrier.NewWithMaxRetries(maxRetries, backOffPeriod)
 	return &SnowProvider{
 		kubeUnAuthClient: kubeUnAuthClient,
 		retrier:          retrier,
 		configManager:    configManager,
+		clientRegistry:   clientRegistry,
 		skipIpCheck:      skipIpCheck,
 	}
 }
@@ -280,6 +284,36 @@ func (p *SnowProvider) DeleteResources(ctx context.Context, clusterSpec *cluster
 	return p.kubeUnAuthClient.Delete(ctx, clusterSpec.SnowDatacenter.GetName(), clusterSpec.SnowDatacenter.GetNamespace(), clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.SnowDatacenter)
 }
 
+// CleanupOrphanedResources terminates the EC2 instances of the cluster on every configured snow device,
+// found by the cluster tag CAPAS sets on the instances it owns.
+func (p *SnowProvider) CleanupOrphanedResources(ctx context.Context, _ *types.Cluster, clusterSpec *cluster.Spec) error {
+	clients, err := p.clientRegistry.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	for device, client := range clients {
+		instances, err := client.EC2InstanceTagsByTag(ctx, snowv1.ClusterTagKey(clusterSpec.Cluster.Name), string(snowv1.ResourceLifecycleOwned))
+		if err != nil {
+			return fmt.Errorf("getting instances on device %s: %v", device, err)
+		}
+		if len(instances) == 0 {
+			continue
+		}
+		ids := make([]string, 0, len(instances))
+		for id := range instances {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		if err := client.EC2TerminateInstances(ctx, ids); err != nil {
+			return fmt.Errorf("terminating instances on device %s: %v", device, err)
+		}
+		logger.Info("Terminated orphaned instances", "device", device, "instances", ids)
+	}
+
+	return nil
+}
+
 func (p *SnowProvider) PostClusterDeleteValidate(_ context.Context, _ *types.Cluster) error {
 	// No validations
 	return nil
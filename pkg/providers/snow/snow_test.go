@@ -251,6 +251,7 @@ func newProvider(ctx context.Context, t *testing.T, kubeUnAuthClient snow.KubeUn
 	return snow.NewProvider(
 		kubeUnAuthClient,
 		configManager,
+		mockClientRegistry,
 		false,
 	)
 }
@@ -791,3 +792,22 @@ func TestUpdateSecretsApplyError(t *testing.T) {
 
 	tt.Expect(tt.provider.UpdateSecrets(tt.ctx, tt.cluster, tt.clusterSpec)).NotTo(Succeed())
 }
+
+func TestCleanupOrphanedResources(t *testing.T) {
+	tt := newSnowTest(t)
+	tt.aws.EXPECT().EC2InstanceTagsByTag(tt.ctx, clusterTagKey, "owned").Return(map[string]map[string]string{"i-2": {}, "i-1": {}}, nil)
+	tt.aws.EXPECT().EC2InstanceTagsByTag(tt.ctx, clusterTagKey, "owned").Return(nil, nil)
+	tt.aws.EXPECT().EC2TerminateInstances(tt.ctx, []string{"i-1", "i-2"}).Return(nil)
+
+	err := tt.provider.CleanupOrphanedResources(tt.ctx, nil, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+}
+
+func TestCleanupOrphanedResourcesError(t *testing.T) {
+	tt := newSnowTest(t)
+	tt.aws.EXPECT().EC2InstanceTagsByTag(tt.ctx, clusterTagKey, "owned").Return(map[string]map[string]string{"i-1": {}}, nil)
+	tt.aws.EXPECT().EC2TerminateInstances(tt.ctx, []string{"i-1"}).Return(errors.New("error"))
+
+	err := tt.provider.CleanupOrphanedResources(tt.ctx, nil, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("terminating instances on device")))
+}
@@ -0,0 +1,52 @@
+package snow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// SyncInstanceTags sets the tags of the cluster spec on the EC2 instances of the cluster in every device,
+// and removes the tags EKS-A set before that are not in the spec anymore. The instances of the cluster
+// are the ones CAPAS tagged as owned by it.
+func SyncInstanceTags(ctx context.Context, clients AwsClientMap, clusterSpec *cluster.Spec) error {
+	for device, client := range clients {
+		instances, err := client.EC2InstanceTagsByTag(ctx, snowv1.ClusterTagKey(clusterSpec.Cluster.Name), string(snowv1.ResourceLifecycleOwned))
+		if err != nil {
+			return fmt.Errorf("getting instances on device %s: %v", device, err)
+		}
+
+		for id, tags := range instances {
+			set, remove := common.TagsChanges(tags, clusterSpec.Cluster.Spec.Tags)
+			if len(set) > 0 {
+				logger.V(4).Info("Setting instance tags", "device", device, "instance", id, "tags", set)
+				if err := client.EC2CreateTags(ctx, []string{id}, set); err != nil {
+					return fmt.Errorf("tagging instance %s on device %s: %v", id, device, err)
+				}
+			}
+			if len(remove) > 0 {
+				logger.V(4).Info("Removing instance tags", "device", device, "instance", id, "tags", remove)
+				if err := client.EC2DeleteTags(ctx, []string{id}, remove); err != nil {
+					return fmt.Errorf("untagging instance %s on device %s: %v", id, device, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// TagClusterResources propagates the tags of the cluster spec to the EC2 instances of the cluster.
+func (p *SnowProvider) TagClusterResources(ctx context.Context, _ *types.Cluster, clusterSpec *cluster.Spec) error {
+	clients, err := p.clientRegistry.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	return SyncInstanceTags(ctx, clients, clusterSpec)
+}
@@ -0,0 +1,83 @@
+package snow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	"github.com/aws/eks-anywhere/pkg/providers/snow/mocks"
+)
+
+const clusterTagKey = "sigs.k8s.io/cluster-api-provider-aws-snow/cluster/snow-test"
+
+func TestSyncInstanceTags(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockAwsClient(gomock.NewController(t))
+	spec := givenClusterSpec()
+	spec.Cluster.Spec.Tags = map[string]string{"cost-center": "platform", "environment": "prod"}
+
+	client.EXPECT().EC2InstanceTagsByTag(ctx, clusterTagKey, "owned").Return(map[string]map[string]string{
+		"i-1": {
+			clusterTagKey:            "owned",
+			"cost-center":            "storage",
+			"team":                   "eks",
+			"owner":                  "someone",
+			constants.ManagedTagsKey: "cost-center,team",
+		},
+	}, nil)
+	client.EXPECT().EC2CreateTags(ctx, []string{"i-1"}, map[string]string{
+		"cost-center":            "platform",
+		"environment":            "prod",
+		constants.ManagedTagsKey: "cost-center,environment",
+	})
+	client.EXPECT().EC2DeleteTags(ctx, []string{"i-1"}, []string{"team"})
+
+	g.Expect(snow.SyncInstanceTags(ctx, snow.AwsClientMap{"1.2.3.4": client}, spec)).To(Succeed())
+}
+
+func TestSyncInstanceTagsRemoveAll(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockAwsClient(gomock.NewController(t))
+	spec := givenClusterSpec()
+
+	client.EXPECT().EC2InstanceTagsByTag(ctx, clusterTagKey, "owned").Return(map[string]map[string]string{
+		"i-1": {clusterTagKey: "owned", "team": "eks", constants.ManagedTagsKey: "team"},
+		"i-2": {clusterTagKey: "owned"},
+	}, nil)
+	client.EXPECT().EC2DeleteTags(ctx, []string{"i-1"}, []string{constants.ManagedTagsKey, "team"})
+
+	g.Expect(snow.SyncInstanceTags(ctx, snow.AwsClientMap{"1.2.3.4": client}, spec)).To(Succeed())
+}
+
+func TestSyncInstanceTagsErrorTagging(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockAwsClient(gomock.NewController(t))
+	spec := givenClusterSpec()
+	spec.Cluster.Spec.Tags = map[string]string{"environment": "prod"}
+
+	client.EXPECT().EC2InstanceTagsByTag(ctx, clusterTagKey, "owned").Return(map[string]map[string]string{
+		"i-1": {clusterTagKey: "owned"},
+	}, nil)
+	client.EXPECT().EC2CreateTags(ctx, []string{"i-1"}, gomock.Any()).Return(errors.New("error"))
+
+	g.Expect(snow.SyncInstanceTags(ctx, snow.AwsClientMap{"1.2.3.4": client}, spec)).To(
+		MatchError("tagging instance i-1 on device 1.2.3.4: error"),
+	)
+}
+
+func TestTagClusterResources(t *testing.T) {
+	tt := newSnowTest(t)
+	tt.clusterSpec.Cluster.Spec.Tags = map[string]string{"environment": "prod"}
+	tt.aws.EXPECT().EC2InstanceTagsByTag(tt.ctx, clusterTagKey, "owned").Return(nil, errors.New("error"))
+
+	err := tt.provider.TagClusterResources(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting instances on device")))
+}
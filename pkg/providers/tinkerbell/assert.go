@@ -1,13 +1,18 @@
 package tinkerbell
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/types"
 )
 
 // TODO(chrisdoherty) Add worker node group assertions
@@ -86,6 +91,138 @@ func AssertOsFamilyValid(spec *ClusterSpec) error {
 	return validateOsFamily(spec)
 }
 
+// AssertImageCredentialProvidersSupported ensures the kubelet image credential providers are only
+// configured for OS families that support them. Bottlerocket nodes don't.
+func AssertImageCredentialProvidersSupported(spec *ClusterSpec) error {
+	if len(spec.Cluster.ImageCredentialProviders()) == 0 {
+		return nil
+	}
+
+	for _, config := range spec.machineConfigsInUse() {
+		if config.OSFamily() == v1alpha1.Bottlerocket {
+			return fmt.Errorf("kubeletConfiguration.imageCredentialProviders is not supported for osFamily %s, TinkerbellMachineConfig %s", v1alpha1.Bottlerocket, config.Name)
+		}
+	}
+
+	return nil
+}
+
+// AssertInPlaceUpgradesSupported ensures the nodes with the InPlace upgrade rollout strategy run an OS family
+// the upgrader supports. Bottlerocket nodes are updated by the Bottlerocket update operator instead.
+func AssertInPlaceUpgradesSupported(spec *ClusterSpec) error {
+	if strategy := spec.ControlPlaneConfiguration().UpgradeRolloutStrategy; strategy != nil && strategy.Type == v1alpha1.InPlaceStrategyType {
+		if err := validateInPlaceUpgradeOSFamily(spec.ControlPlaneMachineConfig()); err != nil {
+			return err
+		}
+	}
+
+	for _, group := range spec.WorkerNodeGroupConfigurations() {
+		if group.UpgradeRolloutStrategy == nil || group.UpgradeRolloutStrategy.Type != v1alpha1.InPlaceStrategyType {
+			continue
+		}
+		if err := validateInPlaceUpgradeOSFamily(spec.WorkerNodeGroupMachineConfig(group)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateInPlaceUpgradeOSFamily(config *v1alpha1.TinkerbellMachineConfig) error {
+	if config.OSFamily() == v1alpha1.Bottlerocket {
+		return fmt.Errorf("'%s' upgrade rollout strategy type is not supported for osFamily %s, TinkerbellMachineConfig %s", v1alpha1.InPlaceStrategyType, v1alpha1.Bottlerocket, config.Name)
+	}
+
+	return nil
+}
+
+// AssertNetworkInterfacesNotSetForEtcd ensures the external etcd machine config doesn't separate
+// the node traffic between network interfaces, since etcd machines don't run the kubelet nor kube-vip.
+func AssertNetworkInterfacesNotSetForEtcd(spec *ClusterSpec) error {
+	if !spec.HasExternalEtcd() {
+		return nil
+	}
+
+	if config := spec.ExternalEtcdMachineConfig(); config.Spec.NetworkInterfaces != nil {
+		return fmt.Errorf("networkInterfaces is not supported for etcd machines, TinkerbellMachineConfig %s", config.Name)
+	}
+
+	return nil
+}
+
+// AssertArchitectureValid ensures all machine configs use the same architecture and, for
+// architectures other than the default, that the node images and OS image are available for it.
+func AssertArchitectureValid(spec *ClusterSpec) error {
+	cpArch := spec.ControlPlaneMachineConfig().Arch()
+	if spec.HasExternalEtcd() {
+		if etcdArch := spec.ExternalEtcdMachineConfig().Arch(); etcdArch != cpArch {
+			return fmt.Errorf("etcd architecture %s must be the same as control plane architecture %s", etcdArch, cpArch)
+		}
+	}
+
+	var archs []v1alpha1.Architecture
+	seen := map[v1alpha1.Architecture]struct{}{}
+	for _, config := range spec.machineConfigsInUse() {
+		arch := config.Arch()
+		// Worker node groups with a different architecture can't share the datacenter OS image
+		// with the control plane.
+		if arch != cpArch && config.Spec.OSImageURL == "" {
+			return fmt.Errorf("TinkerbellMachineConfig %s architecture %s is different from control plane architecture %s, spec.osImageURL must be provided",
+				config.Name, arch, cpArch)
+		}
+
+		if arch != v1alpha1.DefaultArchitecture && osImageURL(&config.Spec, &spec.DatacenterConfig.Spec) == "" &&
+			!containsArch(spec.VersionsBundle.EksD.Raw.Bottlerocket.Arch, arch) {
+			return fmt.Errorf("bottlerocket raw image is not available for architecture %s, please provide a valid osImageURL", arch)
+		}
+
+		if _, ok := seen[arch]; !ok {
+			seen[arch] = struct{}{}
+			archs = append(archs, arch)
+		}
+	}
+
+	// Images running on every node, like the CNI, must be available for all the architectures in use.
+	for _, arch := range archs {
+		if arch == v1alpha1.DefaultArchitecture {
+			continue
+		}
+		if err := spec.ValidateImagesArch(constants.TinkerbellProviderName, arch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HardwareArchMatchesMachineConfigsAssertion ensures the hardware in catalogue selected by each
+// machine config reports the machine config architecture.
+func HardwareArchMatchesMachineConfigsAssertion(catalogue *hardware.Catalogue) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		for _, config := range spec.machineConfigsInUse() {
+			for _, h := range catalogue.AllHardware() {
+				if !hardware.LabelsMatchSelector(config.Spec.HardwareSelector, h.Labels) {
+					continue
+				}
+				if hardwareArch := hardware.HardwareArch(h); hardwareArch != config.Arch() {
+					return fmt.Errorf("hardware %s architecture %s doesn't match TinkerbellMachineConfig %s architecture %s",
+						h.Name, hardwareArch, config.Name, config.Arch())
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func containsArch(archs []string, arch v1alpha1.Architecture) bool {
+	for _, a := range archs {
+		if a == string(arch) {
+			return true
+		}
+	}
+	return false
+}
+
 // AssertcontrolPlaneIPNotInUse ensures the endpoint host for the control plane isn't in use.
 // The check may be unreliable due to its implementation.
 func NewIPNotInUseAssertion(client networkutils.NetClient) ClusterSpecAssertion {
@@ -130,6 +267,34 @@ func AssertPortsNotInUse(client networkutils.NetClient) ClusterSpecAssertion {
 	}
 }
 
+// BMCSecretRefsExistAssertion ensures the pre-existing BMC Secrets referenced by the catalogued
+// hardware exist in the eksa-system namespace of the management cluster. Hardware can't
+// reference them without a management cluster since it's first applied to the bootstrap cluster.
+func BMCSecretRefsExistAssertion(ctx context.Context, catalogue *hardware.Catalogue, kubectl ProviderKubectlClient, managementCluster *types.Cluster) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		refs := hardware.ExternalBMCSecretRefs(catalogue)
+		if len(refs) == 0 {
+			return nil
+		}
+
+		if managementCluster == nil {
+			return fmt.Errorf(
+				"hardware references pre-existing BMC secrets (%s) that require an existing management cluster, use bmc_username and bmc_password instead",
+				strings.Join(refs, ", "),
+			)
+		}
+
+		for _, name := range refs {
+			_, err := kubectl.GetSecret(ctx, name, executables.WithCluster(managementCluster), executables.WithNamespace(constants.EksaSystemNamespace))
+			if err != nil {
+				return fmt.Errorf("reading BMC secret %s referenced by hardware: %v", name, err)
+			}
+		}
+
+		return nil
+	}
+}
+
 // HardwareSatisfiesOnlyOneSelectorAssertion ensures hardware in catalogue only satisfies 1
 // of the MachineConfig's HardwareSelector's from the spec.
 func HardwareSatisfiesOnlyOneSelectorAssertion(catalogue *hardware.Catalogue) ClusterSpecAssertion {
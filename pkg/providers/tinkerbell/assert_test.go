@@ -1,6 +1,7 @@
 package tinkerbell_test
 
 import (
+	"context"
 	"errors"
 	"net"
 	"testing"
@@ -8,14 +9,20 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/onsi/gomega"
+	rufiov1 "github.com/tinkerbell/rufio/api/v1alpha1"
 	"github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/networkutils/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	tinkerbellmocks "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 func TestAssertMachineConfigsValid_ValidSucceds(t *testing.T) {
@@ -39,6 +46,16 @@ func TestAssertMachineConfigsValid_InvalidFails(t *testing.T) {
 				"baz": "qux",
 			}
 		},
+		"InvalidHostOSConfigurationKernelModule": func(clusterSpec *tinkerbell.ClusterSpec) {
+			clusterSpec.ControlPlaneMachineConfig().Spec.HostOSConfiguration = &eksav1alpha1.HostOSConfiguration{
+				KernelModules: []string{"sctp; reboot"},
+			}
+		},
+		"InvalidHostOSConfigurationSysctlValue": func(clusterSpec *tinkerbell.ClusterSpec) {
+			clusterSpec.ControlPlaneMachineConfig().Spec.HostOSConfiguration = &eksav1alpha1.HostOSConfiguration{
+				Sysctls: map[string]string{"net.ipv4.ip_forward": ""},
+			}
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
@@ -49,12 +66,79 @@ func TestAssertMachineConfigsValid_InvalidFails(t *testing.T) {
 	}
 }
 
+func TestAssertImageCredentialProvidersSupported(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Cluster.Spec.KubeletConfiguration = &eksav1alpha1.KubeletConfiguration{
+		ImageCredentialProviders: []eksav1alpha1.ImageCredentialProvider{
+			{Name: "ecr-credential-provider", MatchImages: []string{"*.dkr.ecr.*.amazonaws.com"}},
+		},
+	}
+	g.Expect(tinkerbell.AssertImageCredentialProvidersSupported(clusterSpec)).To(gomega.Succeed())
+
+	clusterSpec.ControlPlaneMachineConfig().Spec.OSFamily = eksav1alpha1.Bottlerocket
+	g.Expect(tinkerbell.AssertImageCredentialProvidersSupported(clusterSpec)).To(gomega.MatchError(
+		gomega.ContainSubstring("kubeletConfiguration.imageCredentialProviders is not supported for osFamily bottlerocket"),
+	))
+}
+
+func TestAssertInPlaceUpgradesSupported(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &eksav1alpha1.ControlPlaneUpgradeRolloutStrategy{
+		Type: eksav1alpha1.InPlaceStrategyType,
+	}
+	clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &eksav1alpha1.WorkerNodesUpgradeRolloutStrategy{
+		Type: eksav1alpha1.InPlaceStrategyType,
+	}
+	g.Expect(tinkerbell.AssertInPlaceUpgradesSupported(clusterSpec)).To(gomega.Succeed())
+
+	clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0]).Spec.OSFamily = eksav1alpha1.Bottlerocket
+	g.Expect(tinkerbell.AssertInPlaceUpgradesSupported(clusterSpec)).To(gomega.MatchError(
+		gomega.ContainSubstring("'InPlace' upgrade rollout strategy type is not supported for osFamily bottlerocket"),
+	))
+
+	clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy.Type = eksav1alpha1.RollingUpdateStrategyType
+	g.Expect(tinkerbell.AssertInPlaceUpgradesSupported(clusterSpec)).To(gomega.Succeed())
+
+	clusterSpec.ControlPlaneMachineConfig().Spec.OSFamily = eksav1alpha1.Bottlerocket
+	g.Expect(tinkerbell.AssertInPlaceUpgradesSupported(clusterSpec)).To(gomega.MatchError(
+		gomega.ContainSubstring("'InPlace' upgrade rollout strategy type is not supported for osFamily bottlerocket"),
+	))
+}
+
+func TestAssertNetworkInterfacesNotSetForEtcd(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.ControlPlaneMachineConfig().Spec.NetworkInterfaces = &eksav1alpha1.NodeNetworkInterfaces{
+		Management: "eth0",
+		Workload:   "eth1",
+	}
+	g.Expect(tinkerbell.AssertNetworkInterfacesNotSetForEtcd(clusterSpec)).To(gomega.Succeed())
+
+	clusterSpec.ExternalEtcdMachineConfig().Spec.NetworkInterfaces = &eksav1alpha1.NodeNetworkInterfaces{
+		Management: "eth0",
+		Workload:   "eth1",
+	}
+	g.Expect(tinkerbell.AssertNetworkInterfacesNotSetForEtcd(clusterSpec)).To(gomega.MatchError(
+		gomega.ContainSubstring("networkInterfaces is not supported for etcd machines"),
+	))
+}
+
 func TestAssertDatacenterConfigValid_ValidSucceeds(t *testing.T) {
 	g := gomega.NewWithT(t)
 	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	g.Expect(tinkerbell.AssertDatacenterConfigValid(clusterSpec)).To(gomega.Succeed())
 }
 
+func TestAssertDatacenterConfigValid_IsoBootSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.IsoBoot = true
+	clusterSpec.DatacenterConfig.Spec.HookIsoURL = "https://hook.iso"
+	g.Expect(tinkerbell.AssertDatacenterConfigValid(clusterSpec)).To(gomega.Succeed())
+}
+
 func TestAssertDatacenterConfigValid_InvalidFails(t *testing.T) {
 	g := gomega.NewWithT(t)
 
@@ -68,6 +152,16 @@ func TestAssertDatacenterConfigValid_InvalidFails(t *testing.T) {
 		"TinkerbellIPInvalid": func(c *tinkerbell.ClusterSpec) {
 			c.DatacenterConfig.Spec.TinkerbellIP = "invalid"
 		},
+		"IsoBootWithoutHookIsoURL": func(c *tinkerbell.ClusterSpec) {
+			c.DatacenterConfig.Spec.IsoBoot = true
+		},
+		"IsoBootInvalidHookIsoURL": func(c *tinkerbell.ClusterSpec) {
+			c.DatacenterConfig.Spec.IsoBoot = true
+			c.DatacenterConfig.Spec.HookIsoURL = "invalid"
+		},
+		"HookIsoURLWithoutIsoBoot": func(c *tinkerbell.ClusterSpec) {
+			c.DatacenterConfig.Spec.HookIsoURL = "https://hook.iso"
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			cluster := NewDefaultValidClusterSpecBuilder().Build()
@@ -490,3 +584,171 @@ func mergeHardwareSelectors(m1, m2 map[string]string) map[string]string {
 	}
 	return m1
 }
+
+func arm64ClusterSpec() *tinkerbell.ClusterSpec {
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	for _, config := range clusterSpec.MachineConfigs {
+		config.Spec.Architecture = eksav1alpha1.ARM64
+	}
+	clusterSpec.VersionsBundle = &cluster.VersionsBundle{
+		VersionsBundle: &releasev1.VersionsBundle{
+			Cilium: releasev1.CiliumBundle{
+				Cilium: releasev1.Image{URI: "public.ecr.aws/cilium:v1", Arch: []string{"amd64", "arm64"}},
+			},
+			Tinkerbell: releasev1.TinkerbellBundle{
+				ClusterAPIController: releasev1.Image{URI: "public.ecr.aws/capt:v1", Arch: []string{"amd64", "arm64"}},
+			},
+		},
+		KubeDistro: &cluster.KubeDistro{},
+	}
+	return clusterSpec
+}
+
+func TestAssertArchitectureValid_DefaultSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	g.Expect(tinkerbell.AssertArchitectureValid(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertArchitectureValid_ARM64Succeeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := arm64ClusterSpec()
+	g.Expect(tinkerbell.AssertArchitectureValid(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertArchitectureValid_MixedWorkersSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := arm64ClusterSpec()
+	clusterSpec.ControlPlaneMachineConfig().Spec.Architecture = eksav1alpha1.AMD64
+	clusterSpec.ExternalEtcdMachineConfig().Spec.Architecture = eksav1alpha1.AMD64
+	for _, group := range clusterSpec.WorkerNodeGroupConfigurations() {
+		clusterSpec.WorkerNodeGroupMachineConfig(group).Spec.OSImageURL = "https://ubuntu.aarch64"
+	}
+	g.Expect(tinkerbell.AssertArchitectureValid(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertArchitectureValid_MixedWorkersWithoutOSImageFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := arm64ClusterSpec()
+	clusterSpec.ControlPlaneMachineConfig().Spec.Architecture = eksav1alpha1.AMD64
+	clusterSpec.ExternalEtcdMachineConfig().Spec.Architecture = eksav1alpha1.AMD64
+	g.Expect(tinkerbell.AssertArchitectureValid(clusterSpec)).To(gomega.MatchError(
+		gomega.ContainSubstring("spec.osImageURL must be provided"),
+	))
+}
+
+func TestAssertArchitectureValid_MixedEtcdFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := arm64ClusterSpec()
+	clusterSpec.ExternalEtcdMachineConfig().Spec.Architecture = eksav1alpha1.AMD64
+	g.Expect(tinkerbell.AssertArchitectureValid(clusterSpec)).To(gomega.MatchError(
+		"etcd architecture amd64 must be the same as control plane architecture arm64",
+	))
+}
+
+func TestAssertArchitectureValid_ImageNotAvailableFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := arm64ClusterSpec()
+	clusterSpec.VersionsBundle.Tinkerbell.ClusterAPIController.Arch = []string{"amd64"}
+	g.Expect(tinkerbell.AssertArchitectureValid(clusterSpec)).To(gomega.MatchError(
+		"images not available for architecture arm64: public.ecr.aws/capt:v1",
+	))
+}
+
+func TestAssertArchitectureValid_OSImageNotAvailableFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := arm64ClusterSpec()
+	clusterSpec.DatacenterConfig.Spec.OSImageURL = ""
+	clusterSpec.VersionsBundle.EksD.Raw.Bottlerocket.Arch = []string{"amd64"}
+	g.Expect(tinkerbell.AssertArchitectureValid(clusterSpec)).To(gomega.MatchError(
+		gomega.ContainSubstring("bottlerocket raw image is not available for architecture arm64"),
+	))
+}
+
+func TestHardwareArchMatchesMachineConfigsAssertion(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := arm64ClusterSpec()
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "arm",
+			Labels: clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+		},
+		Spec: v1alpha1.HardwareSpec{
+			Interfaces: []v1alpha1.Interface{{DHCP: &v1alpha1.DHCP{Arch: hardware.AArch64}}},
+		},
+	})).To(gomega.Succeed())
+
+	assertion := tinkerbell.HardwareArchMatchesMachineConfigsAssertion(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "x86",
+			Labels: clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0]).Spec.HardwareSelector,
+		},
+		Spec: v1alpha1.HardwareSpec{
+			Interfaces: []v1alpha1.Interface{{DHCP: &v1alpha1.DHCP{Arch: hardware.X86_64}}},
+		},
+	})).To(gomega.Succeed())
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(
+		"hardware x86 architecture amd64 doesn't match TinkerbellMachineConfig worker-node-group architecture arm64",
+	))
+}
+
+func newBMCSecretRefCatalogue(g *gomega.WithT) *hardware.Catalogue {
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertBMC(&rufiov1.BaseboardManagement{
+		Spec: rufiov1.BaseboardManagementSpec{
+			Connection: rufiov1.Connection{
+				AuthSecretRef: corev1.SecretReference{Name: "bmc-secret"},
+			},
+		},
+	})).To(gomega.Succeed())
+	return catalogue
+}
+
+func TestBMCSecretRefsExistAssertion_NoRefsSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	kubectl := tinkerbellmocks.NewMockProviderKubectlClient(gomock.NewController(t))
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	assertion := tinkerbell.BMCSecretRefsExistAssertion(context.Background(), hardware.NewCatalogue(), kubectl, nil)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestBMCSecretRefsExistAssertion_ExistingSecretSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+	kubectl := tinkerbellmocks.NewMockProviderKubectlClient(gomock.NewController(t))
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	managementCluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+
+	kubectl.EXPECT().GetSecret(ctx, "bmc-secret", gomock.Any()).Return(&corev1.Secret{}, nil)
+
+	assertion := tinkerbell.BMCSecretRefsExistAssertion(ctx, newBMCSecretRefCatalogue(g), kubectl, managementCluster)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestBMCSecretRefsExistAssertion_MissingSecretFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+	kubectl := tinkerbellmocks.NewMockProviderKubectlClient(gomock.NewController(t))
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	managementCluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+
+	kubectl.EXPECT().GetSecret(ctx, "bmc-secret", gomock.Any()).Return(nil, errors.New("not found"))
+
+	assertion := tinkerbell.BMCSecretRefsExistAssertion(ctx, newBMCSecretRefCatalogue(g), kubectl, managementCluster)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError("reading BMC secret bmc-secret referenced by hardware: not found"))
+}
+
+func TestBMCSecretRefsExistAssertion_NoManagementClusterFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	kubectl := tinkerbellmocks.NewMockProviderKubectlClient(gomock.NewController(t))
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	assertion := tinkerbell.BMCSecretRefsExistAssertion(context.Background(), newBMCSecretRefCatalogue(g), kubectl, nil)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("require an existing management cluster")))
+}
@@ -71,6 +71,19 @@ func (s *ClusterSpec) WorkerNodeGroupMachineConfig(conf v1alpha1.WorkerNodeGroup
 	return s.MachineConfigs[conf.MachineGroupRef.Name]
 }
 
+// machineConfigsInUse returns the machine configs referenced by the control plane, etcd and
+// worker node groups.
+func (s *ClusterSpec) machineConfigsInUse() []*v1alpha1.TinkerbellMachineConfig {
+	configs := []*v1alpha1.TinkerbellMachineConfig{s.ControlPlaneMachineConfig()}
+	if s.HasExternalEtcd() {
+		configs = append(configs, s.ExternalEtcdMachineConfig())
+	}
+	for _, group := range s.WorkerNodeGroupConfigurations() {
+		configs = append(configs, s.WorkerNodeGroupMachineConfig(group))
+	}
+	return configs
+}
+
 // ClusterSpecAssertion makes an assertion on spec.
 type ClusterSpecAssertion func(spec *ClusterSpec) error
 
@@ -108,6 +121,10 @@ func NewClusterSpecValidator(assertions ...ClusterSpecAssertion) *ClusterSpecVal
 		AssertMachineConfigsValid,
 		AssertMachineConfigNamespaceMatchesDatacenterConfig,
 		AssertOsFamilyValid,
+		AssertImageCredentialProvidersSupported,
+		AssertInPlaceUpgradesSupported,
+		AssertNetworkInterfacesNotSetForEtcd,
+		AssertArchitectureValid,
 		AssertTinkerbellIPAndControlPlaneIPNotSame,
 	)
 	v.Register(assertions...)
@@ -0,0 +1,56 @@
+package tinkerbell
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/conformance"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/mocks"
+	stackmocks "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestProviderConformance(t *testing.T) {
+	conformance.Run(t, conformance.Subject{
+		New: func(t *testing.T) (providers.Provider, *cluster.Spec) {
+			ctrl := gomock.NewController(t)
+			_, writer := test.NewWriter(t)
+			spec := givenClusterSpec(t, "cluster_tinkerbell_stacked_etcd.yaml")
+			provider := newProvider(spec.TinkerbellDatacenter, spec.TinkerbellMachineConfigs, spec.Cluster, writer,
+				stackmocks.NewMockDocker(ctrl), stackmocks.NewMockHelm(ctrl), mocks.NewMockProviderKubectlClient(ctrl), false)
+			stackInstaller := stackmocks.NewMockStackInstaller(ctrl)
+			stackInstaller.EXPECT().CleanupLocalBoots(gomock.Any(), false).AnyTimes()
+			provider.stackInstaller = stackInstaller
+
+			return provider, spec
+		},
+		Cluster:     &types.Cluster{Name: "test"},
+		SnapshotDir: "testdata/snapshots",
+		SnapshotMatrix: conformance.Matrix{
+			"controlplane": {
+				"single": func(s *cluster.Spec) { s.Cluster.Spec.ControlPlaneConfiguration.Count = 1 },
+				"ha":     func(s *cluster.Spec) { s.Cluster.Spec.ControlPlaneConfiguration.Count = 3 },
+			},
+			"workers": {
+				"untainted": func(s *cluster.Spec) { s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Taints = nil },
+				"tainted": func(s *cluster.Spec) {
+					s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Taints = []corev1.Taint{{Key: "dedicated", Value: "tinkerbell", Effect: corev1.TaintEffectNoSchedule}}
+				},
+			},
+		},
+		InvalidSpecs: map[string]func(*cluster.Spec){
+			"external etcd": func(s *cluster.Spec) {
+				s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+			},
+			"invalid os image url": func(s *cluster.Spec) {
+				s.TinkerbellDatacenter.Spec.OSImageURL = "not a url"
+			},
+		},
+	})
+}
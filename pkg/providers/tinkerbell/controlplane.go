@@ -0,0 +1,211 @@
+package tinkerbell
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	yamlcapi "github.com/aws/eks-anywhere/pkg/clusterapi/yaml"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+// The CAPT API types are not vendored, so the Tinkerbell provider objects are handled as unstructured.
+var machineTemplateGVK = schema.GroupVersionKind{
+	Group:   "infrastructure.cluster.x-k8s.io",
+	Version: "v1beta1",
+	Kind:    TinkerbellMachineTemplateKind,
+}
+
+// MachineListGVK is the GroupVersionKind of a list of CAPT TinkerbellMachines.
+var MachineListGVK = schema.GroupVersionKind{
+	Group:   "infrastructure.cluster.x-k8s.io",
+	Version: "v1beta1",
+	Kind:    "TinkerbellMachineList",
+}
+
+// ControlPlane represents a CAPI Tinkerbell control plane.
+type ControlPlane = clusterapi.ControlPlane[*unstructured.Unstructured, *unstructured.Unstructured]
+
+// ControlPlaneSpec builds a Tinkerbell ControlPlane definition based on an eks-a cluster spec.
+// The disk of the default templates is read from the hardware matching the machine configs selectors.
+// It talks to the cluster with a client to detect changes in the immutable machine templates and
+// generates new names for them.
+func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec, hw []tinkv1alpha1.Hardware) (*ControlPlane, error) {
+	if controlPlaneMachineConfig(spec) == nil {
+		return nil, errors.New("control plane TinkerbellMachineConfig not found in cluster spec")
+	}
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil && etcdMachineConfig(spec) == nil {
+		return nil, errors.New("etcd TinkerbellMachineConfig not found in cluster spec")
+	}
+
+	templateBuilder, err := templateBuilderForSpec(spec, hw)
+	if err != nil {
+		return nil, err
+	}
+
+	controlPlaneYaml, err := templateBuilder.GenerateCAPISpecControlPlane(
+		spec,
+		func(values map[string]interface{}) {
+			values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+			values["etcdTemplateName"] = clusterapi.EtcdMachineTemplateName(spec.Cluster)
+			values["controlPlaneSshAuthorizedKey"] = sshAuthorizedKey(controlPlaneMachineConfig(spec))
+			values["etcdSshAuthorizedKey"] = sshAuthorizedKey(etcdMachineConfig(spec))
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating tinkerbell control plane yaml spec")
+	}
+
+	parser, builder, err := yamlcapi.NewControlPlaneParserAndBuilder(
+		logger,
+		yamlutil.NewMapping(
+			"TinkerbellCluster",
+			func() *unstructured.Unstructured {
+				return &unstructured.Unstructured{}
+			},
+		),
+		machineTemplateMapping(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "building tinkerbell control plane parser")
+	}
+
+	if err = parser.Parse(controlPlaneYaml, builder); err != nil {
+		return nil, errors.Wrap(err, "parsing tinkerbell control plane yaml")
+	}
+
+	cp := builder.ControlPlane
+	if err = cp.UpdateImmutableObjectNames(ctx, client, getMachineTemplate, machineTemplateEqual); err != nil {
+		return nil, errors.Wrap(err, "updating tinkerbell immutable object names")
+	}
+
+	return cp, nil
+}
+
+// templateBuilderForSpec builds a template builder with the machine configs of the spec and a disk
+// extractor populated with the disks of hw.
+func templateBuilderForSpec(spec *cluster.Spec, hw []tinkv1alpha1.Hardware) (*TemplateBuilder, error) {
+	workerMachineSpecs := make(map[string]v1alpha1.TinkerbellMachineConfigSpec, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, w := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if m, ok := spec.TinkerbellMachineConfigs[w.MachineGroupRef.Name]; ok {
+			workerMachineSpecs[w.MachineGroupRef.Name] = m.Spec
+		}
+	}
+
+	var controlPlaneMachineSpec, etcdMachineSpec *v1alpha1.TinkerbellMachineConfigSpec
+	if m := controlPlaneMachineConfig(spec); m != nil {
+		controlPlaneMachineSpec = &m.Spec
+	}
+	if m := etcdMachineConfig(spec); m != nil {
+		etcdMachineSpec = &m.Spec
+	}
+
+	diskExtractor, err := diskExtractorForHardware(spec, hw)
+	if err != nil {
+		return nil, err
+	}
+
+	datacenterSpec := &spec.TinkerbellDatacenter.Spec
+	// Workload clusters are provisioned by the Tinkerbell stack of the management cluster, so both
+	// template IPs point to it.
+	return NewTemplateBuilder(datacenterSpec, controlPlaneMachineSpec, etcdMachineSpec, diskExtractor, workerMachineSpecs, datacenterSpec.TinkerbellIP, time.Now).(*TemplateBuilder), nil
+}
+
+// diskExtractorForHardware registers the hardware selectors of all the machine configs in the spec
+// and inserts the disks of hw, splitting it between provisioned and available hardware.
+func diskExtractorForHardware(spec *cluster.Spec, hw []tinkv1alpha1.Hardware) (*hardware.DiskExtractor, error) {
+	diskExtractor := hardware.NewDiskExtractor()
+	for _, m := range spec.TinkerbellMachineConfigs {
+		if err := diskExtractor.Register(m.Spec.HardwareSelector); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range hw {
+		if len(hw[i].Spec.Disks) == 0 {
+			continue
+		}
+
+		insert := diskExtractor.InsertDisks
+		if IsHardwareProvisioned(&hw[i]) {
+			insert = diskExtractor.InsertProvisionedHardwareDisks
+		}
+		if err := insert(&hw[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return diskExtractor, nil
+}
+
+// IsHardwareProvisioned returns true if CAPT has acquired hw for a machine.
+func IsHardwareProvisioned(hw *tinkv1alpha1.Hardware) bool {
+	_, ok := hw.Labels[hardwareOwnerNameLabel]
+	return ok
+}
+
+// IsHardwareProvisionedFor returns true if CAPT has acquired hw for one of the TinkerbellMachines in the
+// eksa-system namespace named in machines, the machines of a cluster.
+func IsHardwareProvisionedFor(hw *tinkv1alpha1.Hardware, machines types.Lookup) bool {
+	return hw.Labels[hardwareOwnerNamespaceLabel] == constants.EksaSystemNamespace &&
+		machines.IsPresent(hw.Labels[hardwareOwnerNameLabel])
+}
+
+func controlPlaneMachineConfig(spec *cluster.Spec) *v1alpha1.TinkerbellMachineConfig {
+	if spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef == nil {
+		return nil
+	}
+	return spec.TinkerbellMachineConfigs[spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+}
+
+func etcdMachineConfig(spec *cluster.Spec) *v1alpha1.TinkerbellMachineConfig {
+	etcd := spec.Cluster.Spec.ExternalEtcdConfiguration
+	if etcd == nil || etcd.MachineGroupRef == nil {
+		return nil
+	}
+	return spec.TinkerbellMachineConfigs[etcd.MachineGroupRef.Name]
+}
+
+// sshAuthorizedKey returns the first ssh key of the first user of the machine config, if any.
+func sshAuthorizedKey(machineConfig *v1alpha1.TinkerbellMachineConfig) string {
+	if machineConfig == nil || len(machineConfig.Spec.Users) == 0 || len(machineConfig.Spec.Users[0].SshAuthorizedKeys) == 0 {
+		return ""
+	}
+	return machineConfig.Spec.Users[0].SshAuthorizedKeys[0]
+}
+
+func machineTemplateMapping() yamlutil.Mapping[*unstructured.Unstructured] {
+	return yamlutil.NewMapping(
+		TinkerbellMachineTemplateKind,
+		func() *unstructured.Unstructured {
+			return &unstructured.Unstructured{}
+		},
+	)
+}
+
+func getMachineTemplate(ctx context.Context, client kubernetes.Client, name, namespace string) (*unstructured.Unstructured, error) {
+	m := &unstructured.Unstructured{}
+	m.SetGroupVersionKind(machineTemplateGVK)
+	if err := client.Get(ctx, name, namespace, m); err != nil {
+		return nil, errors.Wrap(err, "reading tinkerbellMachineTemplate")
+	}
+
+	return m, nil
+}
+
+func machineTemplateEqual(new, old *unstructured.Unstructured) bool {
+	return equality.Semantic.DeepDerivative(new.Object["spec"], old.Object["spec"])
+}
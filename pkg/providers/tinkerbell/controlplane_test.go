@@ -0,0 +1,111 @@
+package tinkerbell
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestControlPlaneSpecNewCluster(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := givenClusterSpec(t, "cluster_tinkerbell_stacked_etcd.yaml")
+
+	cp, err := ControlPlaneSpec(ctx, logger, client, spec, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp).NotTo(BeNil())
+	g.Expect(cp.Cluster.Name).To(Equal("test"))
+	g.Expect(cp.ProviderCluster.GetName()).To(Equal("test"))
+	g.Expect(cp.KubeadmControlPlane.Name).To(Equal("test"))
+	g.Expect(cp.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("test-control-plane-1"))
+	g.Expect(cp.ControlPlaneMachineTemplate.GetName()).To(Equal("test-control-plane-1"))
+	g.Expect(cp.EtcdCluster).To(BeNil())
+}
+
+func TestControlPlaneSpecDefaultTemplateUsesHardwareDisk(t *testing.T) {
+	g := NewWithT(t)
+	spec := givenClusterSpec(t, "cluster_tinkerbell_stacked_etcd.yaml")
+	spec.TinkerbellTemplateConfigs = nil
+	hw := []tinkv1alpha1.Hardware{
+		hardwareWithDisk("hw-cp", map[string]string{"type": "cp"}, "/dev/nvme0n1"),
+		hardwareWithDisk("hw-worker", map[string]string{"type": "worker"}, "/dev/sda"),
+	}
+
+	cp, err := ControlPlaneSpec(context.Background(), test.NewNullLogger(), test.NewFakeKubeClient(), spec, hw)
+	g.Expect(err).NotTo(HaveOccurred())
+	override, _, err := unstructured.NestedString(cp.ControlPlaneMachineTemplate.Object, "spec", "template", "spec", "templateOverride")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(override).To(ContainSubstring("/dev/nvme0n1"))
+	g.Expect(override).NotTo(ContainSubstring("/dev/sda"))
+}
+
+func TestControlPlaneSpecDefaultTemplateWithProxy(t *testing.T) {
+	g := NewWithT(t)
+	spec := givenClusterSpec(t, "cluster_tinkerbell_stacked_etcd.yaml")
+	spec.TinkerbellTemplateConfigs = nil
+	spec.Cluster.Spec.ProxyConfiguration = &v1alpha1.ProxyConfiguration{
+		HttpProxy:  "http://proxy.example.com:3128",
+		HttpsProxy: "https://proxy.example.com:3128",
+		NoProxy:    []string{".internal"},
+	}
+	hw := []tinkv1alpha1.Hardware{
+		hardwareWithDisk("hw-cp", map[string]string{"type": "cp"}, "/dev/sda"),
+	}
+
+	cp, err := ControlPlaneSpec(context.Background(), test.NewNullLogger(), test.NewFakeKubeClient(), spec, hw)
+	g.Expect(err).NotTo(HaveOccurred())
+	override, _, err := unstructured.NestedString(cp.ControlPlaneMachineTemplate.Object, "spec", "template", "spec", "templateOverride")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(override).To(ContainSubstring("HTTP_PROXY: http://proxy.example.com:3128"))
+	g.Expect(override).To(ContainSubstring("HTTPS_PROXY: https://proxy.example.com:3128"))
+	g.Expect(override).To(ContainSubstring("NO_PROXY: " + spec.TinkerbellDatacenter.Spec.TinkerbellIP + "," + spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host + ",.internal"))
+}
+
+func TestControlPlaneSpecDefaultTemplateNoHardware(t *testing.T) {
+	g := NewWithT(t)
+	spec := givenClusterSpec(t, "cluster_tinkerbell_stacked_etcd.yaml")
+	spec.TinkerbellTemplateConfigs = nil
+
+	_, err := ControlPlaneSpec(context.Background(), test.NewNullLogger(), test.NewFakeKubeClient(), spec, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("getting control plane disk type of the hardware selector")))
+}
+
+func TestControlPlaneSpecMissingControlPlaneMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	spec := givenClusterSpec(t, "cluster_tinkerbell_stacked_etcd.yaml")
+	spec.TinkerbellMachineConfigs = nil
+
+	_, err := ControlPlaneSpec(context.Background(), test.NewNullLogger(), test.NewFakeKubeClient(), spec, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("control plane TinkerbellMachineConfig not found")))
+}
+
+func TestIsHardwareProvisioned(t *testing.T) {
+	g := NewWithT(t)
+	hw := hardwareWithDisk("hw", nil, "/dev/sda")
+	g.Expect(IsHardwareProvisioned(&hw)).To(BeFalse())
+
+	hw.Labels = map[string]string{hardwareOwnerNameLabel: "test-control-plane-abcde"}
+	g.Expect(IsHardwareProvisioned(&hw)).To(BeTrue())
+}
+
+func hardwareWithDisk(name string, labels map[string]string, disk string) tinkv1alpha1.Hardware {
+	return tinkv1alpha1.Hardware{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "eksa-system",
+			Labels:    labels,
+		},
+		Spec: tinkv1alpha1.HardwareSpec{
+			Disks: []tinkv1alpha1.Disk{{Device: disk}},
+		},
+	}
+}
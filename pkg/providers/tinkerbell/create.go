@@ -53,6 +53,7 @@ func (p *Provider) PreCAPIInstallOnBootstrap(ctx context.Context, cluster *types
 		p.datacenterConfig.Spec.HookImagesURLPath,
 		stack.WithBootsOnDocker(),
 		stack.WithHostPortEnabled(true), // enable host port on bootstrap cluster
+		stack.WithSerialConsole(p.datacenterConfig.Spec.SerialConsole.IsEnabled()),
 	)
 	if err != nil {
 		return fmt.Errorf("install Tinkerbell stack on bootstrap cluster: %v", err)
@@ -103,6 +104,7 @@ func (p *Provider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster,
 		stack.WithLoadBalancerEnabled(
 			len(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations) != 0 && // load balancer is handled by kube-vip in control plane nodes
 				!p.datacenterConfig.Spec.SkipLoadBalancerDeployment), // configure load balancer based on datacenterConfig.Spec.SkipLoadBalancerDeployment
+		stack.WithSerialConsole(p.datacenterConfig.Spec.SerialConsole.IsEnabled()),
 	)
 	if err != nil {
 		return fmt.Errorf("installing stack on workload cluster: %v", err)
@@ -176,6 +178,8 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 	clusterSpecValidator := NewClusterSpecValidator(
 		MinimumHardwareAvailableAssertionForCreate(p.catalogue),
 		HardwareSatisfiesOnlyOneSelectorAssertion(p.catalogue),
+		HardwareArchMatchesMachineConfigsAssertion(p.catalogue),
+		BMCSecretRefsExistAssertion(ctx, p.catalogue, p.providerKubectlClient, clusterSpec.ManagementCluster),
 	)
 
 	clusterSpecValidator.Register(AssertPortsNotInUse(p.netClient))
@@ -244,13 +248,13 @@ func (p *Provider) getHardwareFromManagementCluster(ctx context.Context, cluster
 
 func (p *Provider) readCSVToCatalogue() error {
 	// Create a catalogue writer used to write hardware to the catalogue.
-	catalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue)
+	catalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue, p.hardwareWriterOptions()...)
 
 	// Combine disk extraction with catalogue writing. Disk extraction will be used for rendering
 	// templates.
 	writer := hardware.MultiMachineWriter(catalogueWriter, &p.diskExtractor)
 
-	machineValidator := hardware.NewDefaultMachineValidator()
+	machineValidator := p.newMachineValidator()
 
 	// Build a set of selectors from machine configs.
 	selectors := selectorsFromMachineConfigs(p.machineConfigs)
@@ -267,6 +271,25 @@ func (p *Provider) readCSVToCatalogue() error {
 	return hardware.TranslateAll(machines, writer, machineValidator)
 }
 
+// hardwareWriterOptions returns the options to write the hardware to the catalogue. Machines booted
+// from ISO through virtual media mustn't be netbooted.
+func (p *Provider) hardwareWriterOptions() []hardware.HardwareCatalogueWriterOption {
+	if p.datacenterConfig.Spec.IsoBoot {
+		return []hardware.HardwareCatalogueWriterOption{hardware.WithPXEDisabled()}
+	}
+	return nil
+}
+
+// newMachineValidator creates a machine validator with the default assertions and the assertions
+// required by the datacenter config.
+func (p *Provider) newMachineValidator() *hardware.DefaultMachineValidator {
+	validator := hardware.NewDefaultMachineValidator()
+	if p.datacenterConfig.Spec.IsoBoot {
+		validator.Register(hardware.RedfishBMC())
+	}
+	return validator
+}
+
 // selectorsFromMachineConfigs extracts all selectors from TinkerbellMachineConfigs returning them
 // as a slice. It doesn't need the map, it only accepts that for ease as that's how we manage them
 // in the provider construct.
@@ -2,11 +2,21 @@ package tinkerbell
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+// hardwareOwnerNameLabel and hardwareOwnerNamespaceLabel are set by CAPT on the hardware it acquires
+// for a TinkerbellMachine, to its name and namespace.
+const (
+	hardwareOwnerNameLabel      = "v1alpha1.tinkerbell.org/ownerName"
+	hardwareOwnerNamespaceLabel = "v1alpha1.tinkerbell.org/ownerNamespace"
+)
+
 func (p *Provider) SetupAndValidateDeleteCluster(ctx context.Context, cluster *types.Cluster, _ *cluster.Spec) error {
 	// noop
 	return nil
@@ -28,3 +38,46 @@ func (p *Provider) PostClusterDeleteValidate(ctx context.Context, managementClus
 
 	return nil
 }
+
+// CleanupOrphanedResources releases the hardware held by the machines of the cluster in the management
+// cluster and wipes their user data, then removes the local boots container a failed create may have left.
+func (p *Provider) CleanupOrphanedResources(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if managementCluster != nil {
+		if err := p.releaseHardware(ctx, managementCluster.KubeconfigFile, clusterSpec.Cluster.Name); err != nil {
+			return err
+		}
+	}
+
+	return p.stackInstaller.UninstallLocal(ctx)
+}
+
+// releaseHardware releases the hardware acquired for the TinkerbellMachines of the cluster clusterName.
+func (p *Provider) releaseHardware(ctx context.Context, kubeconfig, clusterName string) error {
+	machines, err := p.providerKubectlClient.GetTinkerbellMachineNames(ctx, kubeconfig, clusterName, constants.EksaSystemNamespace)
+	if err != nil {
+		return err
+	}
+	if len(machines) == 0 {
+		logger.Info("No tinkerbell machines found for the cluster, hardware acquired for machines already deleted must be released manually", "cluster", clusterName)
+		return nil
+	}
+
+	hardware, err := p.providerKubectlClient.GetProvisionedTinkerbellHardware(ctx, kubeconfig, constants.EksaSystemNamespace)
+	if err != nil {
+		return fmt.Errorf("retrieving provisioned hardware: %v", err)
+	}
+
+	machinesLookup := types.SliceToLookup(machines)
+	for i := range hardware {
+		hw := &hardware[i]
+		if !IsHardwareProvisionedFor(hw, machinesLookup) {
+			continue
+		}
+		if err := p.providerKubectlClient.ReleaseTinkerbellHardware(ctx, kubeconfig, hw.Name, hw.Namespace); err != nil {
+			return err
+		}
+		logger.Info("Released hardware", "hardware", hw.Name)
+	}
+
+	return nil
+}
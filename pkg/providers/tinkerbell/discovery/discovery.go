@@ -0,0 +1,217 @@
+// Package discovery scans networks for BMCs to pre-fill the Tinkerbell hardware CSV of the machines they manage.
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// Protocols BMCs are discovered with.
+const (
+	Redfish = hardware.BMCProtocolRedfish
+	IPMI    = hardware.BMCProtocolIPMI
+)
+
+// Defaults of a Scanner.
+const (
+	DefaultTimeout = 5 * time.Second
+	DefaultWorkers = 32
+)
+
+// maxScanAddresses caps the addresses of a range, so a typo in a CIDR doesn't start a scan of millions of hosts.
+const maxScanAddresses = 1 << 16
+
+// ModelLabel is the label of the machines with the model reported by their BMC.
+const ModelLabel = "model"
+
+// BMC is a discovered baseboard management controller.
+type BMC struct {
+	IPAddress string
+	Protocol  string
+
+	Manufacturer string
+	Model        string
+	SerialNumber string
+	// MACAddresses of the network interfaces of the machine, not of the BMC. The first one is used to netboot it.
+	MACAddresses []string
+}
+
+// Prober probes an address for a BMC.
+type Prober interface {
+	// Probe returns nil when there isn't a BMC at the address. It can return a BMC along an error when it
+	// found one but couldn't read all its details.
+	Probe(ctx context.Context, ip string) (*BMC, error)
+}
+
+// Scanner scans IP addresses for BMCs.
+type Scanner struct {
+	probers []Prober
+	timeout time.Duration
+	workers int
+}
+
+// NewScanner builds a Scanner probing each address with the probers, in order, until one finds a BMC.
+// Each prober has timeout to probe an address and workers addresses are probed in parallel.
+func NewScanner(timeout time.Duration, workers int, probers ...Prober) *Scanner {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if workers < 1 {
+		workers = DefaultWorkers
+	}
+	return &Scanner{
+		probers: probers,
+		timeout: timeout,
+		workers: workers,
+	}
+}
+
+// Scan returns the BMCs found in the addresses, in the order of the addresses.
+func (s *Scanner) Scan(ctx context.Context, ips []net.IP) []BMC {
+	found := make([]*BMC, len(ips))
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				found[j] = s.probe(ctx, ips[j].String())
+			}
+		}()
+	}
+	for i := range ips {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	bmcs := make([]BMC, 0, len(ips))
+	for _, b := range found {
+		if b != nil {
+			bmcs = append(bmcs, *b)
+		}
+	}
+	return bmcs
+}
+
+func (s *Scanner) probe(ctx context.Context, ip string) *BMC {
+	for _, p := range s.probers {
+		probeCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		bmc, err := p.Probe(probeCtx, ip)
+		cancel()
+		if bmc == nil {
+			continue
+		}
+		if err != nil {
+			logger.Info("Warning: BMC found but its details couldn't be read", "address", ip, "protocol", bmc.Protocol, "error", err)
+		} else {
+			logger.V(2).Info("BMC found", "address", ip, "protocol", bmc.Protocol, "model", bmc.Model)
+		}
+		return bmc
+	}
+	return nil
+}
+
+// Machines builds the hardware CSV machines of the BMCs, accessed with username and password.
+// The network configuration, hostname and disk of the machines are left for the user to fill.
+func Machines(bmcs []BMC, username, password string) []hardware.Machine {
+	machines := make([]hardware.Machine, 0, len(bmcs))
+	for _, b := range bmcs {
+		m := hardware.Machine{
+			BMCIPAddress: b.IPAddress,
+			BMCUsername:  username,
+			BMCPassword:  password,
+			BMCProtocol:  b.Protocol,
+			Labels:       hardware.Labels{},
+		}
+		if len(b.MACAddresses) > 0 {
+			m.MACAddress = b.MACAddresses[0]
+		}
+		if model := labelValue(b.Model); model != "" {
+			m.Labels[ModelLabel] = model
+		}
+		machines = append(machines, m)
+	}
+	return machines
+}
+
+// labelValue converts s into a valid label value replacing the invalid characters with dashes.
+func labelValue(s string) string {
+	value := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, s)
+	if len(value) > 63 {
+		value = value[:63]
+	}
+	return strings.Trim(value, "-_.")
+}
+
+// ParseIPRange returns the addresses of a CIDR, like 10.0.0.0/24, without its network and broadcast addresses,
+// or of an inclusive range, like 10.0.0.10-10.0.0.50. Only IPv4 is supported.
+func ParseIPRange(ipRange string) ([]net.IP, error) {
+	if strings.Contains(ipRange, "/") {
+		return parseCIDR(ipRange)
+	}
+
+	start, end, _ := strings.Cut(ipRange, "-")
+	if end == "" {
+		end = start
+	}
+	first, last := net.ParseIP(strings.TrimSpace(start)).To4(), net.ParseIP(strings.TrimSpace(end)).To4()
+	if first == nil || last == nil {
+		return nil, fmt.Errorf("invalid IP range %s, it must be a CIDR or a range like 10.0.0.10-10.0.0.50", ipRange)
+	}
+	if bytes.Compare(first, last) > 0 {
+		return nil, fmt.Errorf("invalid IP range %s, its start is after its end", ipRange)
+	}
+
+	return addresses(binary.BigEndian.Uint32(first), binary.BigEndian.Uint32(last), ipRange)
+}
+
+func parseCIDR(cidr string) ([]net.IP, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil || network.IP.To4() == nil {
+		return nil, fmt.Errorf("invalid IPv4 CIDR %s", cidr)
+	}
+
+	ones, bits := network.Mask.Size()
+	first := binary.BigEndian.Uint32(network.IP.To4())
+	last := first | (1<<(bits-ones) - 1)
+	// /31 and /32 networks don't have network and broadcast addresses.
+	if bits-ones > 1 {
+		first++
+		last--
+	}
+
+	return addresses(first, last, cidr)
+}
+
+func addresses(first, last uint32, ipRange string) ([]net.IP, error) {
+	if last-first >= maxScanAddresses {
+		return nil, fmt.Errorf("IP range %s has more than %d addresses", ipRange, maxScanAddresses)
+	}
+
+	ips := make([]net.IP, 0, last-first+1)
+	for a := first; ; a++ {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, a)
+		ips = append(ips, ip)
+		if a == last {
+			break
+		}
+	}
+	return ips, nil
+}
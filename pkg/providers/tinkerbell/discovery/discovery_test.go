@@ -0,0 +1,140 @@
+package discovery_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/discovery"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/discovery/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+func ips(addresses ...string) []net.IP {
+	parsed := make([]net.IP, 0, len(addresses))
+	for _, a := range addresses {
+		parsed = append(parsed, net.ParseIP(a).To4())
+	}
+	return parsed
+}
+
+func TestScannerScan(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	redfish := mocks.NewMockProber(ctrl)
+	ipmi := mocks.NewMockProber(ctrl)
+
+	redfish.EXPECT().Probe(gomock.Any(), "10.0.0.1").Return(&discovery.BMC{IPAddress: "10.0.0.1", Protocol: discovery.Redfish}, nil)
+	redfish.EXPECT().Probe(gomock.Any(), "10.0.0.2").Return(nil, nil)
+	redfish.EXPECT().Probe(gomock.Any(), "10.0.0.3").Return(nil, nil)
+	redfish.EXPECT().Probe(gomock.Any(), "10.0.0.4").Return(&discovery.BMC{IPAddress: "10.0.0.4", Protocol: discovery.Redfish}, errors.New("401 Unauthorized"))
+	ipmi.EXPECT().Probe(gomock.Any(), "10.0.0.2").Return(&discovery.BMC{IPAddress: "10.0.0.2", Protocol: discovery.IPMI}, nil)
+	ipmi.EXPECT().Probe(gomock.Any(), "10.0.0.3").Return(nil, nil)
+
+	scanner := discovery.NewScanner(time.Second, 2, redfish, ipmi)
+
+	g.Expect(scanner.Scan(ctx, ips("10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"))).To(Equal([]discovery.BMC{
+		{IPAddress: "10.0.0.1", Protocol: discovery.Redfish},
+		{IPAddress: "10.0.0.2", Protocol: discovery.IPMI},
+		{IPAddress: "10.0.0.4", Protocol: discovery.Redfish},
+	}))
+}
+
+func TestMachines(t *testing.T) {
+	g := NewWithT(t)
+	bmcs := []discovery.BMC{
+		{
+			IPAddress:    "10.0.0.1",
+			Protocol:     discovery.Redfish,
+			Model:        "PowerEdge R650 (Rev. 2)",
+			MACAddresses: []string{"00:00:00:00:00:01", "00:00:00:00:00:02"},
+		},
+		{IPAddress: "10.0.0.2", Protocol: discovery.IPMI},
+	}
+
+	g.Expect(discovery.Machines(bmcs, "admin", "password")).To(Equal([]hardware.Machine{
+		{
+			MACAddress:   "00:00:00:00:00:01",
+			Labels:       hardware.Labels{"model": "PowerEdge-R650--Rev.-2"},
+			BMCIPAddress: "10.0.0.1",
+			BMCUsername:  "admin",
+			BMCPassword:  "password",
+			BMCProtocol:  hardware.BMCProtocolRedfish,
+		},
+		{
+			Labels:       hardware.Labels{},
+			BMCIPAddress: "10.0.0.2",
+			BMCUsername:  "admin",
+			BMCPassword:  "password",
+			BMCProtocol:  hardware.BMCProtocolIPMI,
+		},
+	}))
+}
+
+func TestParseIPRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		ipRange string
+		want    []net.IP
+		wantErr string
+	}{
+		{
+			name:    "cidr",
+			ipRange: "10.0.0.0/30",
+			want:    ips("10.0.0.1", "10.0.0.2"),
+		},
+		{
+			name:    "cidr single address",
+			ipRange: "10.0.0.5/32",
+			want:    ips("10.0.0.5"),
+		},
+		{
+			name:    "range",
+			ipRange: "10.0.0.254-10.0.1.1",
+			want:    ips("10.0.0.254", "10.0.0.255", "10.0.1.0", "10.0.1.1"),
+		},
+		{
+			name:    "single address",
+			ipRange: "10.0.0.5",
+			want:    ips("10.0.0.5"),
+		},
+		{
+			name:    "invalid range",
+			ipRange: "10.0.0.5-10.0.0",
+			wantErr: "invalid IP range 10.0.0.5-10.0.0",
+		},
+		{
+			name:    "reversed range",
+			ipRange: "10.0.0.5-10.0.0.1",
+			wantErr: "its start is after its end",
+		},
+		{
+			name:    "ipv6 cidr",
+			ipRange: "fd00::/120",
+			wantErr: "invalid IPv4 CIDR fd00::/120",
+		},
+		{
+			name:    "too many addresses",
+			ipRange: "10.0.0.0/8",
+			wantErr: "IP range 10.0.0.0/8 has more than 65536 addresses",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := discovery.ParseIPRange(tt.ipRange)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package discovery
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// IPMIProber discovers IPMI BMCs with an RMCP presence ping. IPMI doesn't expose the network interfaces of the
+// machine, so the BMCs it discovers don't have MAC addresses nor model.
+type IPMIProber struct {
+	port int
+}
+
+// IPMIProberOpt customizes an IPMIProber.
+type IPMIProberOpt func(*IPMIProber)
+
+// WithIPMIPort sets the UDP port of the RMCP service. Defaults to 623.
+func WithIPMIPort(port int) IPMIProberOpt {
+	return func(p *IPMIProber) {
+		p.port = port
+	}
+}
+
+// NewIPMIProber builds an IPMIProber.
+func NewIPMIProber(opts ...IPMIProberOpt) *IPMIProber {
+	p := &IPMIProber{port: hardware.IPMIPort}
+	for _, o := range opts {
+		o(p)
+	}
+	return p
+}
+
+// Probe sends a presence ping to ip and waits for the pong until ctx is done.
+func (p *IPMIProber) Probe(ctx context.Context, ip string) (*BMC, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "udp", net.JoinHostPort(ip, strconv.Itoa(p.port)))
+	if err != nil {
+		return nil, nil
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(DefaultTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, nil
+	}
+
+	if err := hardware.PingRMCP(conn); err != nil {
+		return nil, nil
+	}
+
+	return &BMC{IPAddress: ip, Protocol: IPMI}, nil
+}
@@ -0,0 +1,61 @@
+package discovery_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/discovery"
+)
+
+// newRMCPServer answers RMCP presence pings with a pong when answer is true.
+func newRMCPServer(t *testing.T, answer bool) int {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		ping := make([]byte, 64)
+		for {
+			n, addr, err := conn.ReadFrom(ping)
+			if err != nil {
+				return
+			}
+			if !answer || n < 12 || ping[8] != 0x80 {
+				continue
+			}
+			pong := []byte{
+				0x06, 0x00, 0xff, 0x06,
+				0x00, 0x00, 0x11, 0xbe,
+				0x40, 0x00, 0x00, 0x10,
+				0x00, 0x00, 0x11, 0xbe, 0x00, 0x00, 0x00, 0x00, 0x81, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			}
+			_, _ = conn.WriteTo(pong, addr)
+		}
+	}()
+
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func TestIPMIProberProbe(t *testing.T) {
+	g := NewWithT(t)
+	prober := discovery.NewIPMIProber(discovery.WithIPMIPort(newRMCPServer(t, true)))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	g.Expect(prober.Probe(ctx, "127.0.0.1")).To(Equal(&discovery.BMC{IPAddress: "127.0.0.1", Protocol: discovery.IPMI}))
+}
+
+func TestIPMIProberProbeNoAnswer(t *testing.T) {
+	g := NewWithT(t)
+	prober := discovery.NewIPMIProber(discovery.WithIPMIPort(newRMCPServer(t, false)))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	g.Expect(prober.Probe(ctx, "127.0.0.1")).To(BeNil())
+}
@@ -0,0 +1,162 @@
+package discovery
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultRedfishPort = 443
+	redfishServiceRoot = "/redfish/v1/"
+	redfishSystems     = "/redfish/v1/Systems"
+)
+
+type redfishLink struct {
+	ID string `json:"@odata.id"`
+}
+
+type redfishCollection struct {
+	Members []redfishLink `json:"Members"`
+}
+
+type redfishServiceRootResource struct {
+	RedfishVersion string `json:"RedfishVersion"`
+}
+
+type redfishSystem struct {
+	Manufacturer       string      `json:"Manufacturer"`
+	Model              string      `json:"Model"`
+	SerialNumber       string      `json:"SerialNumber"`
+	EthernetInterfaces redfishLink `json:"EthernetInterfaces"`
+}
+
+type redfishEthernetInterface struct {
+	MACAddress          string `json:"MACAddress"`
+	PermanentMACAddress string `json:"PermanentMACAddress"`
+}
+
+// RedfishProber discovers Redfish BMCs and reads the model and network interfaces of their systems.
+type RedfishProber struct {
+	client   *http.Client
+	username string
+	password string
+	port     int
+}
+
+// RedfishProberOpt customizes a RedfishProber.
+type RedfishProberOpt func(*RedfishProber)
+
+// WithRedfishPort sets the HTTPS port of the Redfish service. Defaults to 443.
+func WithRedfishPort(port int) RedfishProberOpt {
+	return func(p *RedfishProber) {
+		p.port = port
+	}
+}
+
+// NewRedfishProber builds a RedfishProber authenticating with username and password. BMCs usually
+// serve self-signed certificates, so they aren't verified.
+func NewRedfishProber(username, password string, opts ...RedfishProberOpt) *RedfishProber {
+	p := &RedfishProber{
+		client: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // #nosec G402
+			},
+		},
+		username: username,
+		password: password,
+		port:     defaultRedfishPort,
+	}
+	for _, o := range opts {
+		o(p)
+	}
+	return p
+}
+
+// Probe looks for a Redfish service root at ip. The service root doesn't require authentication, so a BMC is
+// returned even if the credentials are wrong, along the error reading its systems.
+func (p *RedfishProber) Probe(ctx context.Context, ip string) (*BMC, error) {
+	root := &redfishServiceRootResource{}
+	if err := p.get(ctx, ip, redfishServiceRoot, false, root); err != nil || root.RedfishVersion == "" {
+		return nil, nil
+	}
+
+	bmc := &BMC{IPAddress: ip, Protocol: Redfish}
+	systems := &redfishCollection{}
+	if err := p.get(ctx, ip, redfishSystems, true, systems); err != nil {
+		return bmc, err
+	}
+
+	for _, member := range systems.Members {
+		if err := p.readSystem(ctx, ip, member.ID, bmc); err != nil {
+			return bmc, err
+		}
+	}
+
+	return bmc, nil
+}
+
+func (p *RedfishProber) readSystem(ctx context.Context, ip, path string, bmc *BMC) error {
+	system := &redfishSystem{}
+	if err := p.get(ctx, ip, path, true, system); err != nil {
+		return err
+	}
+	if bmc.Model == "" {
+		bmc.Manufacturer = system.Manufacturer
+		bmc.Model = system.Model
+		bmc.SerialNumber = system.SerialNumber
+	}
+	if system.EthernetInterfaces.ID == "" {
+		return nil
+	}
+
+	interfaces := &redfishCollection{}
+	if err := p.get(ctx, ip, system.EthernetInterfaces.ID, true, interfaces); err != nil {
+		return err
+	}
+	for _, member := range interfaces.Members {
+		nic := &redfishEthernetInterface{}
+		if err := p.get(ctx, ip, member.ID, true, nic); err != nil {
+			return err
+		}
+		mac := nic.PermanentMACAddress
+		if mac == "" {
+			mac = nic.MACAddress
+		}
+		if hw, err := net.ParseMAC(mac); err == nil {
+			bmc.MACAddresses = append(bmc.MACAddresses, hw.String())
+		}
+	}
+
+	return nil
+}
+
+func (p *RedfishProber) get(ctx context.Context, ip, path string, authenticate bool, resource interface{}) error {
+	url := fmt.Sprintf("https://%s%s", net.JoinHostPort(ip, strconv.Itoa(p.port)), path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if authenticate {
+		req.SetBasicAuth(p.username, p.password)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("reading redfish resource %s: %s", path, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(resource); err != nil {
+		return fmt.Errorf("parsing redfish resource %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package discovery_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/discovery"
+)
+
+var redfishResources = map[string]string{
+	"/redfish/v1/":                               `{"RedfishVersion": "1.11.0"}`,
+	"/redfish/v1/Systems":                        `{"Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`,
+	"/redfish/v1/Systems/1":                      `{"Manufacturer": "Dell Inc.", "Model": "PowerEdge R650", "SerialNumber": "ABC123", "EthernetInterfaces": {"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces"}}`,
+	"/redfish/v1/Systems/1/EthernetInterfaces":   `{"Members": [{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1"}, {"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/2"}]}`,
+	"/redfish/v1/Systems/1/EthernetInterfaces/1": `{"MACAddress": "AA:BB:CC:DD:EE:01", "PermanentMACAddress": ""}`,
+	"/redfish/v1/Systems/1/EthernetInterfaces/2": `{"MACAddress": "", "PermanentMACAddress": "aa-bb-cc-dd-ee-02"}`,
+}
+
+func newRedfishServer(t *testing.T) (ip string, port int) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/redfish/v1/" {
+			if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "password" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		resource, ok := redfishResources[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(resource))
+	}))
+	t.Cleanup(server.Close)
+
+	host, p, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err = strconv.Atoi(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return host, port
+}
+
+func TestRedfishProberProbe(t *testing.T) {
+	g := NewWithT(t)
+	ip, port := newRedfishServer(t)
+	prober := discovery.NewRedfishProber("admin", "password", discovery.WithRedfishPort(port))
+
+	g.Expect(prober.Probe(context.Background(), ip)).To(Equal(&discovery.BMC{
+		IPAddress:    ip,
+		Protocol:     discovery.Redfish,
+		Manufacturer: "Dell Inc.",
+		Model:        "PowerEdge R650",
+		SerialNumber: "ABC123",
+		MACAddresses: []string{"aa:bb:cc:dd:ee:01", "aa:bb:cc:dd:ee:02"},
+	}))
+}
+
+func TestRedfishProberProbeWrongCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ip, port := newRedfishServer(t)
+	prober := discovery.NewRedfishProber("admin", "wrong", discovery.WithRedfishPort(port))
+
+	bmc, err := prober.Probe(context.Background(), ip)
+	g.Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+	g.Expect(bmc).To(Equal(&discovery.BMC{IPAddress: ip, Protocol: discovery.Redfish}))
+}
+
+func TestRedfishProberProbeNotRedfish(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+	host, p, err := net.SplitHostPort(server.Listener.Addr().String())
+	g.Expect(err).NotTo(HaveOccurred())
+	port, err := strconv.Atoi(p)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	prober := discovery.NewRedfishProber("admin", "password", discovery.WithRedfishPort(port))
+
+	g.Expect(prober.Probe(context.Background(), host)).To(BeNil())
+}
@@ -165,11 +165,31 @@ func MarshalCatalogue(c *Catalogue) ([]byte, error) {
 }
 
 // NewMachineCatalogueWriter creates a MachineWriter instance that writes Machine instances to
-// catalogue including its BaseboardManagement and Secret data.
-func NewMachineCatalogueWriter(catalogue *Catalogue) MachineWriter {
+// catalogue including its BaseboardManagement and Secret data. opts configure the writing of the Hardware.
+func NewMachineCatalogueWriter(catalogue *Catalogue, opts ...HardwareCatalogueWriterOption) MachineWriter {
 	return MultiMachineWriter(
-		NewHardwareCatalogueWriter(catalogue),
+		NewHardwareCatalogueWriter(catalogue, opts...),
 		NewBMCCatalogueWriter(catalogue),
 		NewSecretCatalogueWriter(catalogue),
 	)
 }
+
+// ExternalBMCSecretRefs returns the names of the Secrets referenced by the catalogued BMCs that
+// aren't in the catalogue, the pre-existing Secrets the hardware references.
+func ExternalBMCSecretRefs(c *Catalogue) []string {
+	catalogued := map[string]bool{}
+	for _, secret := range c.AllSecrets() {
+		catalogued[secret.Name] = true
+	}
+
+	var refs []string
+	seen := map[string]bool{}
+	for _, bmc := range c.AllBMCs() {
+		name := bmc.Spec.Connection.AuthSecretRef.Name
+		if !catalogued[name] && !seen[name] {
+			refs = append(refs, name)
+			seen[name] = true
+		}
+	}
+	return refs
+}
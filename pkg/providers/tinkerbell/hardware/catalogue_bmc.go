@@ -96,8 +96,9 @@ func baseboardManagementComputerFromMachine(m Machine) *v1alpha1.BaseboardManage
 		Spec: v1alpha1.BaseboardManagementSpec{
 			Connection: v1alpha1.Connection{
 				Host: m.BMCIPAddress,
+				Port: m.BMCConnectionPort(),
 				AuthSecretRef: corev1.SecretReference{
-					Name:      formatBMCSecretRef(m),
+					Name:      m.BMCSecretName(),
 					Namespace: constants.EksaSystemNamespace,
 				},
 				InsecureTLS: true,
@@ -79,3 +79,67 @@ func TestBMCCatalogueWriter_Write(t *testing.T) {
 	g.Expect(bmcs[0].Spec.Connection.Host).To(gomega.Equal(machine.BMCIPAddress))
 	g.Expect(bmcs[0].Spec.Connection.AuthSecretRef.Name).To(gomega.ContainSubstring(machine.Hostname))
 }
+
+func TestBMCCatalogueWriter_WriteWithPort(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewBMCCatalogueWriter(catalogue)
+	machine := NewValidMachine()
+	machine.BMCPort = 6230
+
+	g.Expect(writer.Write(machine)).To(gomega.Succeed())
+
+	bmcs := catalogue.AllBMCs()
+	g.Expect(bmcs).To(gomega.HaveLen(1))
+	g.Expect(bmcs[0].Spec.Connection.Port).To(gomega.Equal(6230))
+}
+
+func TestBMCCatalogueWriter_WriteRedfish(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewBMCCatalogueWriter(catalogue)
+	machine := NewValidMachine()
+	machine.BMCProtocol = hardware.BMCProtocolRedfish
+
+	g.Expect(writer.Write(machine)).To(gomega.Succeed())
+
+	bmcs := catalogue.AllBMCs()
+	g.Expect(bmcs).To(gomega.HaveLen(1))
+	g.Expect(bmcs[0].Spec.Connection.Port).To(gomega.Equal(443))
+	g.Expect(bmcs[0].Spec.Connection.InsecureTLS).To(gomega.BeTrue())
+}
+
+func TestBMCCatalogueWriter_WriteRedfishWithPort(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewBMCCatalogueWriter(catalogue)
+	machine := NewValidMachine()
+	machine.BMCProtocol = hardware.BMCProtocolRedfish
+	machine.BMCPort = 8443
+
+	g.Expect(writer.Write(machine)).To(gomega.Succeed())
+
+	bmcs := catalogue.AllBMCs()
+	g.Expect(bmcs).To(gomega.HaveLen(1))
+	g.Expect(bmcs[0].Spec.Connection.Port).To(gomega.Equal(8443))
+}
+
+func TestBMCCatalogueWriter_WriteWithBMCSecret(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewBMCCatalogueWriter(catalogue)
+	machine := NewValidMachine()
+	machine.BMCUsername = ""
+	machine.BMCPassword = ""
+	machine.BMCSecret = "bmc-secret"
+
+	g.Expect(writer.Write(machine)).To(gomega.Succeed())
+
+	bmcs := catalogue.AllBMCs()
+	g.Expect(bmcs).To(gomega.HaveLen(1))
+	g.Expect(bmcs[0].Spec.Connection.AuthSecretRef.Name).To(gomega.Equal("bmc-secret"))
+}
@@ -232,22 +232,38 @@ func WithHardwareBMCRefIndex() CatalogueOption {
 // HardwareCatalogueWriter converts Machine instances to Tinkerbell Hardware and inserts them
 // in a catalogue.
 type HardwareCatalogueWriter struct {
-	catalogue *Catalogue
+	catalogue  *Catalogue
+	disablePXE bool
 }
 
 var _ MachineWriter = &HardwareCatalogueWriter{}
 
+// HardwareCatalogueWriterOption configures a HardwareCatalogueWriter.
+type HardwareCatalogueWriterOption func(*HardwareCatalogueWriter)
+
+// WithPXEDisabled disables PXE in the Hardware so Tinkerbell doesn't netboot the machines, for
+// machines booted from the Hook ISO through virtual media.
+func WithPXEDisabled() HardwareCatalogueWriterOption {
+	return func(w *HardwareCatalogueWriter) {
+		w.disablePXE = true
+	}
+}
+
 // NewHardwareCatalogueWriter creates a new HardwareCatalogueWriter instance.
-func NewHardwareCatalogueWriter(catalogue *Catalogue) *HardwareCatalogueWriter {
-	return &HardwareCatalogueWriter{catalogue: catalogue}
+func NewHardwareCatalogueWriter(catalogue *Catalogue, opts ...HardwareCatalogueWriterOption) *HardwareCatalogueWriter {
+	w := &HardwareCatalogueWriter{catalogue: catalogue}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
 }
 
 // Write converts m to a Tinkerbell Hardware and inserts it into w's Catalogue.
 func (w *HardwareCatalogueWriter) Write(m Machine) error {
-	return w.catalogue.InsertHardware(hardwareFromMachine(m))
+	return w.catalogue.InsertHardware(hardwareFromMachine(m, !w.disablePXE))
 }
 
-func hardwareFromMachine(m Machine) *tinkv1alpha1.Hardware {
+func hardwareFromMachine(m Machine, allowPXE bool) *tinkv1alpha1.Hardware {
 	// allow is necessary to allocate memory so we can get a bool pointer required by
 	// the hardware.
 	allow := true
@@ -287,19 +303,19 @@ func hardwareFromMachine(m Machine) *tinkv1alpha1.Hardware {
 					//
 					// Upstream needs patching but this will suffice for now.
 					OperatingSystem: &tinkv1alpha1.MetadataInstanceOperatingSystem{},
-					AllowPxe:        true,
-					AlwaysPxe:       true,
+					AllowPxe:        allowPXE,
+					AlwaysPxe:       allowPXE,
 				},
 				State: "provisioning",
 			},
 			Interfaces: []tinkv1alpha1.Interface{
 				{
 					Netboot: &tinkv1alpha1.Netboot{
-						AllowPXE:      &allow,
+						AllowPXE:      &allowPXE,
 						AllowWorkflow: &allow,
 					},
 					DHCP: &tinkv1alpha1.DHCP{
-						Arch: "x86_64",
+						Arch: m.DHCPArch(),
 						MAC:  m.MACAddress,
 						IP: &tinkv1alpha1.IP{
 							Address: m.IPAddress,
@@ -333,3 +349,14 @@ func newBMCRefFromMachine(m Machine) *corev1.TypedLocalObjectReference {
 
 	return nil
 }
+
+// HardwareArch returns the EKS-A architecture of hardware based on the architecture it reports
+// to Tinkerbell. Hardware not reporting an architecture is considered amd64.
+func HardwareArch(hardware *tinkv1alpha1.Hardware) eksav1alpha1.Architecture {
+	for _, i := range hardware.Spec.Interfaces {
+		if i.DHCP != nil && i.DHCP.Arch == AArch64 {
+			return eksav1alpha1.ARM64
+		}
+	}
+	return eksav1alpha1.AMD64
+}
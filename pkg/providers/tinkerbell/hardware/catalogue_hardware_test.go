@@ -181,6 +181,25 @@ func TestHardwareCatalogueWriter_Write(t *testing.T) {
 	hardware := catalogue.AllHardware()
 	g.Expect(hardware).To(gomega.HaveLen(1))
 	g.Expect(hardware[0].Name).To(gomega.Equal(machine.Hostname))
+	g.Expect(*hardware[0].Spec.Interfaces[0].Netboot.AllowPXE).To(gomega.BeTrue())
+	g.Expect(hardware[0].Spec.Metadata.Instance.AllowPxe).To(gomega.BeTrue())
+}
+
+func TestHardwareCatalogueWriter_WriteWithPXEDisabled(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewHardwareCatalogueWriter(catalogue, hardware.WithPXEDisabled())
+	machine := NewValidMachine()
+
+	g.Expect(writer.Write(machine)).To(gomega.Succeed())
+
+	hardware := catalogue.AllHardware()
+	g.Expect(hardware).To(gomega.HaveLen(1))
+	g.Expect(*hardware[0].Spec.Interfaces[0].Netboot.AllowPXE).To(gomega.BeFalse())
+	g.Expect(*hardware[0].Spec.Interfaces[0].Netboot.AllowWorkflow).To(gomega.BeTrue())
+	g.Expect(hardware[0].Spec.Metadata.Instance.AllowPxe).To(gomega.BeFalse())
+	g.Expect(hardware[0].Spec.Metadata.Instance.AlwaysPxe).To(gomega.BeFalse())
 }
 
 func TestDiskExtractorWithValidHardwareSelectors(t *testing.T) {
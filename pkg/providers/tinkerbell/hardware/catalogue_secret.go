@@ -76,8 +76,9 @@ func NewSecretCatalogueWriter(catalogue *Catalogue) *SecretCatalogueWriter {
 }
 
 // Write converts m to a Tinkerbell BaseboardManagement and inserts it into w's Catalogue.
+// Machines referencing a pre-existing Secret don't generate one.
 func (w *SecretCatalogueWriter) Write(m Machine) error {
-	if m.HasBMC() {
+	if m.HasBMC() && m.BMCSecret == "" {
 		return w.catalogue.InsertSecret(baseboardManagementSecretFromMachine(m))
 	}
 	return nil
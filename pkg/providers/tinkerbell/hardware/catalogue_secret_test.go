@@ -79,3 +79,17 @@ func TestSecretCatalogueWriter_Write(t *testing.T) {
 	g.Expect(secrets[0].Data).To(gomega.HaveKeyWithValue("username", []byte(machine.BMCUsername)))
 	g.Expect(secrets[0].Data).To(gomega.HaveKeyWithValue("password", []byte(machine.BMCPassword)))
 }
+
+func TestSecretCatalogueWriter_WriteWithBMCSecret(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewSecretCatalogueWriter(catalogue)
+	machine := NewValidMachine()
+	machine.BMCUsername = ""
+	machine.BMCPassword = ""
+	machine.BMCSecret = "bmc-secret"
+
+	g.Expect(writer.Write(machine)).To(gomega.Succeed())
+	g.Expect(catalogue.AllSecrets()).To(gomega.BeEmpty())
+}
@@ -80,3 +80,27 @@ func TestParseYAMLCatalogueWithoutData(t *testing.T) {
 	g.Expect(catalogue.TotalBMCs()).To(gomega.Equal(0))
 	g.Expect(catalogue.TotalSecrets()).To(gomega.Equal(0))
 }
+
+func TestExternalBMCSecretRefs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	bmcs := hardware.NewBMCCatalogueWriter(catalogue)
+	secrets := hardware.NewSecretCatalogueWriter(catalogue)
+
+	plaintext := NewValidMachine()
+	external := NewValidMachine()
+	external.Hostname = "external"
+	external.BMCUsername = ""
+	external.BMCPassword = ""
+	external.BMCSecret = "shared-bmc-secret"
+	shared := external
+	shared.Hostname = "shared"
+
+	for _, m := range []hardware.Machine{plaintext, external, shared} {
+		g.Expect(bmcs.Write(m)).To(gomega.Succeed())
+		g.Expect(secrets.Write(m)).To(gomega.Succeed())
+	}
+
+	g.Expect(hardware.ExternalBMCSecretRefs(catalogue)).To(gomega.Equal([]string{"shared-bmc-secret"}))
+}
@@ -87,3 +87,8 @@ func ensureRequiredColumnsInCSV(unmatched []string) error {
 
 	return nil
 }
+
+// WriteCSV writes machines to w as CSV data that can be read back with a CSVReader.
+func WriteCSV(w io.Writer, machines []Machine) error {
+	return csv.Marshal(machines, w)
+}
@@ -53,6 +53,24 @@ func TestCSVReaderWithMultipleLabels(t *testing.T) {
 	g.Expect(machine).To(gomega.BeEquivalentTo(expect))
 }
 
+func TestWriteCSV(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	expect := NewValidMachine()
+	expect.BMCPort = 6230
+	expect.BMCProtocol = hardware.BMCProtocolRedfish
+
+	var buf bytes.Buffer
+	g.Expect(hardware.WriteCSV(&buf, []hardware.Machine{expect})).To(gomega.Succeed())
+
+	reader, err := hardware.NewCSVReader(&buf)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	machine, err := reader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine).To(gomega.BeEquivalentTo(expect))
+}
+
 func TestCSVReaderFromFile(t *testing.T) {
 	g := gomega.NewWithT(t)
 
@@ -26,13 +26,75 @@ type Machine struct {
 	BMCIPAddress string `csv:"bmc_ip, omitempty"`
 	BMCUsername  string `csv:"bmc_username, omitempty"`
 	BMCPassword  string `csv:"bmc_password, omitempty"`
-	VLANID       string `csv:"vlan_id, omitempty"`
+
+	// BMCSecret is the name of a pre-existing Secret in the eksa-system namespace holding the BMC
+	// username and password, used instead of BMCUsername and BMCPassword.
+	BMCSecret string `csv:"bmc_secret, omitempty"`
+
+	// BMCPort overrides the port used to reach the BMC. It lets several virtual BMCs share a single IP address.
+	BMCPort int `csv:"bmc_port, omitempty"`
+
+	// BMCProtocol is the protocol used to manage the machine through its BMC, ipmi or redfish.
+	// Defaults to ipmi.
+	BMCProtocol string `csv:"bmc_protocol, omitempty"`
+
+	VLANID string `csv:"vlan_id, omitempty"`
+
+	// Arch is the CPU architecture reported to Tinkerbell when the machine netboots. Supported
+	// values are x86_64 and aarch64. Defaults to x86_64.
+	Arch string `csv:"arch, omitempty"`
+}
+
+// Architectures reported by Tinkerbell in the Hardware DHCP configuration.
+const (
+	X86_64  = "x86_64"
+	AArch64 = "aarch64"
+)
+
+// Protocols used to manage machines through their BMC.
+const (
+	BMCProtocolIPMI    = "ipmi"
+	BMCProtocolRedfish = "redfish"
+)
+
+// redfishPort is the port Redfish BMCs serve their HTTPS API on.
+const redfishPort = 443
+
+// DHCPArch returns the architecture reported to Tinkerbell for m.
+func (m *Machine) DHCPArch() string {
+	if m.Arch == "" {
+		return X86_64
+	}
+	return m.Arch
 }
 
 // HasBMC determines if m has a BMC configuration. A BMC configuration is present if any of the BMC fields
 // contain non-empty strings.
 func (m *Machine) HasBMC() bool {
-	return m.BMCIPAddress != "" || m.BMCUsername != "" || m.BMCPassword != ""
+	return m.BMCIPAddress != "" || m.BMCUsername != "" || m.BMCPassword != "" || m.BMCSecret != ""
+}
+
+// BMCSecretName returns the name of the Secret holding the BMC credentials, either the referenced
+// pre-existing Secret or the one generated from BMCUsername and BMCPassword.
+func (m *Machine) BMCSecretName() string {
+	if m.BMCSecret != "" {
+		return m.BMCSecret
+	}
+	return formatBMCSecretRef(*m)
+}
+
+// IsRedfish determines if m's BMC is managed with Redfish.
+func (m *Machine) IsRedfish() bool {
+	return m.BMCProtocol == BMCProtocolRedfish
+}
+
+// BMCConnectionPort returns the port used to reach m's BMC. It's BMCPort when set, else the
+// default port of the BMC protocol. It returns 0 for IPMI so rufio defaults it.
+func (m *Machine) BMCConnectionPort() int {
+	if m.BMCPort == 0 && m.IsRedfish() {
+		return redfishPort
+	}
+	return m.BMCPort
 }
 
 // NameserversSeparator is used to unmarshal Nameservers.
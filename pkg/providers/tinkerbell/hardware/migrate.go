@@ -0,0 +1,39 @@
+package hardware
+
+// BMCCredentialsMigrator is a MachineWriter that moves the plaintext BMC credentials of machines
+// to Secrets. Each migrated machine references its Secret with BMCSecret instead. Secrets are named
+// like the ones generated for plaintext credentials, so inventories already applied to a cluster
+// reference their existing Secrets.
+type BMCCredentialsMigrator struct {
+	secrets  *SecretCatalogueWriter
+	machines []Machine
+}
+
+var _ MachineWriter = &BMCCredentialsMigrator{}
+
+// NewBMCCredentialsMigrator creates a new BMCCredentialsMigrator writing the Secrets to catalogue.
+func NewBMCCredentialsMigrator(catalogue *Catalogue) *BMCCredentialsMigrator {
+	return &BMCCredentialsMigrator{secrets: NewSecretCatalogueWriter(catalogue)}
+}
+
+// Write migrates the BMC credentials of m, if any, and records the migrated machine.
+func (w *BMCCredentialsMigrator) Write(m Machine) error {
+	if m.HasBMC() && m.BMCSecret == "" {
+		if err := w.secrets.Write(m); err != nil {
+			return err
+		}
+		m.BMCSecret = formatBMCSecretRef(m)
+		m.BMCUsername = ""
+		m.BMCPassword = ""
+	}
+
+	w.machines = append(w.machines, m)
+	return nil
+}
+
+// Machines returns the machines written to w with their BMC credentials migrated.
+func (w *BMCCredentialsMigrator) Machines() []Machine {
+	machines := make([]Machine, len(w.machines))
+	copy(machines, w.machines)
+	return machines
+}
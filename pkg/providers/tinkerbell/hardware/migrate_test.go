@@ -0,0 +1,55 @@
+package hardware_test
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+func TestBMCCredentialsMigrator_Write(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	migrator := hardware.NewBMCCredentialsMigrator(catalogue)
+	machine := NewValidMachine()
+
+	g.Expect(migrator.Write(machine)).To(gomega.Succeed())
+
+	secrets := catalogue.AllSecrets()
+	g.Expect(secrets).To(gomega.HaveLen(1))
+	g.Expect(secrets[0].Data).To(gomega.HaveKeyWithValue("username", []byte(machine.BMCUsername)))
+	g.Expect(secrets[0].Data).To(gomega.HaveKeyWithValue("password", []byte(machine.BMCPassword)))
+
+	migrated := migrator.Machines()
+	g.Expect(migrated).To(gomega.HaveLen(1))
+	g.Expect(migrated[0].BMCSecret).To(gomega.Equal(secrets[0].Name))
+	g.Expect(migrated[0].BMCUsername).To(gomega.BeEmpty())
+	g.Expect(migrated[0].BMCPassword).To(gomega.BeEmpty())
+	g.Expect(hardware.StaticMachineAssertions()(migrated[0])).To(gomega.Succeed())
+}
+
+func TestBMCCredentialsMigrator_WriteSkipsMachinesWithoutCredentials(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	migrator := hardware.NewBMCCredentialsMigrator(catalogue)
+
+	withSecret := NewValidMachine()
+	withSecret.BMCUsername = ""
+	withSecret.BMCPassword = ""
+	withSecret.BMCSecret = "bmc-secret"
+
+	withoutBMC := NewValidMachine()
+	withoutBMC.Hostname = "no-bmc"
+	withoutBMC.BMCIPAddress = ""
+	withoutBMC.BMCUsername = ""
+	withoutBMC.BMCPassword = ""
+
+	g.Expect(migrator.Write(withSecret)).To(gomega.Succeed())
+	g.Expect(migrator.Write(withoutBMC)).To(gomega.Succeed())
+
+	g.Expect(catalogue.AllSecrets()).To(gomega.BeEmpty())
+	g.Expect(migrator.Machines()).To(gomega.Equal([]hardware.Machine{withSecret, withoutBMC}))
+}
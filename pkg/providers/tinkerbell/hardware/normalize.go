@@ -52,10 +52,25 @@ func LowercaseMACAddress(m Machine) Machine {
 	return m
 }
 
+// NormalizeArch ensures m's Arch uses the architecture names reported by Tinkerbell, accepting
+// the Go architecture names used by EKS-A machine configs.
+func NormalizeArch(m Machine) Machine {
+	switch arch := strings.ToLower(m.Arch); arch {
+	case "amd64":
+		m.Arch = X86_64
+	case "arm64":
+		m.Arch = AArch64
+	default:
+		m.Arch = arch
+	}
+	return m
+}
+
 // RegisterDefaultNormalizations registers a set of default normalizations on n.
 func RegisterDefaultNormalizations(n *Normalizer) {
 	for _, fn := range []NormalizerFunc{
 		LowercaseMACAddress,
+		NormalizeArch,
 	} {
 		n.Register(fn)
 	}
@@ -63,3 +63,19 @@ func TestRawNormalizerReadError(t *testing.T) {
 
 	g.Expect(err).To(gomega.HaveOccurred())
 }
+
+func TestNormalizeArch(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	for arch, expect := range map[string]string{
+		"":        "",
+		"amd64":   hardware.X86_64,
+		"ARM64":   hardware.AArch64,
+		"aarch64": hardware.AArch64,
+		"x86_64":  hardware.X86_64,
+	} {
+		machine := NewValidMachine()
+		machine.Arch = arch
+		g.Expect(hardware.NormalizeArch(machine).Arch).To(gomega.Equal(expect))
+	}
+}
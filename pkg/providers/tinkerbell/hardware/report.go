@@ -0,0 +1,207 @@
+package hardware
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+)
+
+// RowReport is the validation outcome for a single Machine read from a MachineReader.
+type RowReport struct {
+	// Row is the 1-indexed position of the machine in the source data, excluding any header.
+	Row     int
+	Machine Machine
+	Errors  []error
+}
+
+// Valid returns true if no assertions failed for the row.
+func (r RowReport) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// SelectorCoverage describes how many machines satisfy a hardware selector relative to the number
+// of machines required by the cluster configuration.
+type SelectorCoverage struct {
+	// Name identifies the machine config the selector belongs to.
+	Name     string
+	Selector v1alpha1.HardwareSelector
+	Required int
+	Matched  int
+}
+
+// Satisfied returns true if enough machines match the selector.
+func (c SelectorCoverage) Satisfied() bool {
+	return c.Matched >= c.Required
+}
+
+// Report is a per-row validation report for a hardware source.
+type Report struct {
+	Rows     []RowReport
+	Coverage []SelectorCoverage
+}
+
+// Valid returns true if all rows are valid and all selector requirements are satisfied.
+func (r *Report) Valid() bool {
+	for _, row := range r.Rows {
+		if !row.Valid() {
+			return false
+		}
+	}
+
+	for _, coverage := range r.Coverage {
+		if !coverage.Satisfied() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AddSelectorCoverage computes the number of valid rows matching selector and records it against
+// name. Invalid rows are excluded as they cannot be used for provisioning.
+func (r *Report) AddSelectorCoverage(name string, selector v1alpha1.HardwareSelector, required int) {
+	coverage := SelectorCoverage{
+		Name:     name,
+		Selector: selector,
+		Required: required,
+	}
+
+	for _, row := range r.Rows {
+		if row.Valid() && LabelsMatchSelector(selector, row.Machine.Labels) {
+			coverage.Matched++
+		}
+	}
+
+	r.Coverage = append(r.Coverage, coverage)
+}
+
+// Write writes a human readable representation of r to w.
+func (r *Report) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+
+	fmt.Fprintln(tw, "ROW\tHOSTNAME\tMAC\tIP\tSTATUS")
+	for _, row := range r.Rows {
+		status := "OK"
+		if !row.Valid() {
+			status = joinErrors(row.Errors)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n",
+			row.Row, row.Machine.Hostname, row.Machine.MACAddress, row.Machine.IPAddress, status)
+	}
+
+	if len(r.Coverage) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "MACHINE CONFIG\tSELECTOR\tREQUIRED\tMATCHED\tSTATUS")
+		for _, coverage := range r.Coverage {
+			status := "OK"
+			if !coverage.Satisfied() {
+				status = "INSUFFICIENT HARDWARE"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\n",
+				coverage.Name, selectorString(coverage.Selector), coverage.Required, coverage.Matched, status)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// ValidateAll reads all machines from reader and applies every assertion to each machine,
+// collecting all failures rather than stopping at the first. Assertions should be freshly
+// constructed as stateful assertions, such as the uniqueness assertions, have a 1 time use.
+func ValidateAll(reader MachineReader, assertions ...MachineAssertion) (*Report, error) {
+	report := &Report{}
+
+	for row := 1; ; row++ {
+		machine, err := reader.Read()
+		if err == io.EOF {
+			return report, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read: invalid hardware at row %v: %v", row, err)
+		}
+
+		rowReport := RowReport{Row: row, Machine: machine}
+		for _, assert := range assertions {
+			if err := assert(machine); err != nil {
+				rowReport.Errors = append(rowReport.Errors, err)
+			}
+		}
+
+		report.Rows = append(report.Rows, rowReport)
+	}
+}
+
+// BMCReachable asserts a Machine's BMC answers on its management port: Redfish BMCs must accept
+// HTTPS connections and IPMI BMCs must answer an RMCP presence ping over UDP. If there is no BMC
+// configuration the check is a noop.
+func BMCReachable(client networkutils.NetClient) MachineAssertion {
+	return func(m Machine) error {
+		if !m.HasBMC() || m.BMCIPAddress == "" {
+			return nil
+		}
+
+		if m.IsRedfish() {
+			address := net.JoinHostPort(m.BMCIPAddress, strconv.Itoa(m.BMCConnectionPort()))
+			conn, err := client.DialTimeout("tcp", address, bmcReachableTimeout)
+			if err != nil {
+				return fmt.Errorf("BMC unreachable: %v: %v", address, err)
+			}
+			conn.Close()
+			return nil
+		}
+
+		port := m.BMCPort
+		if port == 0 {
+			port = IPMIPort
+		}
+		address := net.JoinHostPort(m.BMCIPAddress, strconv.Itoa(port))
+		if err := pingIPMI(client, address); err != nil {
+			return fmt.Errorf("BMC unreachable: %v: %v", address, err)
+		}
+
+		return nil
+	}
+}
+
+// bmcReachableTimeout bounds how long BMCReachable waits for a BMC to answer.
+const bmcReachableTimeout = 500 * time.Millisecond
+
+func pingIPMI(client networkutils.NetClient, address string) error {
+	conn, err := client.DialTimeout("udp", address, bmcReachableTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(bmcReachableTimeout)); err != nil {
+		return err
+	}
+
+	return PingRMCP(conn)
+}
+
+func joinErrors(errs []error) string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func selectorString(selector v1alpha1.HardwareSelector) string {
+	pairs := make([]string, 0, len(selector))
+	for k, v := range selector {
+		pairs = append(pairs, fmt.Sprintf("%v=%v", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
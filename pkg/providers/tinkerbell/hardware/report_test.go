@@ -0,0 +1,209 @@
+package hardware_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	netmocks "github.com/aws/eks-anywhere/pkg/networkutils/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware/mocks"
+)
+
+func TestValidateAllCollectsErrorsPerRow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	valid := NewValidMachine()
+	duplicate := NewValidMachine()
+	duplicate.Hostname = "other"
+
+	reader := mocks.NewMockMachineReader(ctrl)
+	gomock.InOrder(
+		reader.EXPECT().Read().Return(valid, nil),
+		reader.EXPECT().Read().Return(duplicate, nil),
+		reader.EXPECT().Read().Return(hardware.Machine{}, io.EOF),
+	)
+
+	report, err := hardware.ValidateAll(
+		reader,
+		hardware.StaticMachineAssertions(),
+		hardware.UniqueIPAddress(),
+		hardware.UniqueMACAddress(),
+		hardware.UniqueHostnames(),
+	)
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report.Rows).To(gomega.HaveLen(2))
+	g.Expect(report.Rows[0].Valid()).To(gomega.BeTrue())
+	g.Expect(report.Rows[1].Row).To(gomega.Equal(2))
+	g.Expect(report.Rows[1].Errors).To(gomega.HaveLen(2))
+	g.Expect(report.Valid()).To(gomega.BeFalse())
+}
+
+func TestValidateAllWithReadError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	reader := mocks.NewMockMachineReader(ctrl)
+	reader.EXPECT().Read().Return(hardware.Machine{}, errors.New("bad row"))
+
+	_, err := hardware.ValidateAll(reader)
+
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("row 1")))
+}
+
+func TestReportSelectorCoverage(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	cp := NewValidMachine()
+	worker := NewValidMachine()
+	worker.Labels = hardware.Labels{"type": "worker"}
+
+	report := &hardware.Report{
+		Rows: []hardware.RowReport{
+			{Row: 1, Machine: cp},
+			{Row: 2, Machine: worker},
+			{Row: 3, Machine: worker, Errors: []error{errors.New("invalid")}},
+		},
+	}
+
+	report.AddSelectorCoverage("cp", v1alpha1.HardwareSelector{"type": "cp"}, 1)
+	report.AddSelectorCoverage("worker", v1alpha1.HardwareSelector{"type": "worker"}, 2)
+
+	g.Expect(report.Coverage).To(gomega.HaveLen(2))
+	g.Expect(report.Coverage[0].Satisfied()).To(gomega.BeTrue())
+	g.Expect(report.Coverage[1].Matched).To(gomega.Equal(1))
+	g.Expect(report.Coverage[1].Satisfied()).To(gomega.BeFalse())
+
+	var buf bytes.Buffer
+	g.Expect(report.Write(&buf)).To(gomega.Succeed())
+	g.Expect(buf.String()).To(gomega.ContainSubstring("INSUFFICIENT HARDWARE"))
+	g.Expect(buf.String()).To(gomega.ContainSubstring("invalid"))
+}
+
+// newRMCPPeer returns a connection whose peer answers a single RMCP presence ping with a pong
+// when answer is true, and closes the connection otherwise.
+func newRMCPPeer(t *testing.T, answer bool) net.Conn {
+	server, client := net.Pipe()
+	t.Cleanup(func() { server.Close() })
+
+	go func() {
+		ping := make([]byte, 64)
+		if _, err := server.Read(ping); err != nil || !answer {
+			server.Close()
+			return
+		}
+		pong := []byte{
+			0x06, 0x00, 0xff, 0x06,
+			0x00, 0x00, 0x11, 0xbe,
+			0x40, 0x00, 0x00, 0x10,
+			0x00, 0x00, 0x11, 0xbe, 0x00, 0x00, 0x00, 0x00, 0x81, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		}
+		_, _ = server.Write(pong)
+	}()
+
+	return client
+}
+
+func TestBMCReachable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	netClient := netmocks.NewMockNetClient(ctrl)
+	netClient.EXPECT().
+		DialTimeout("udp", "10.10.10.11:623", gomock.Any()).
+		Return(newRMCPPeer(t, true), nil)
+
+	g.Expect(hardware.BMCReachable(netClient)(NewValidMachine())).To(gomega.Succeed())
+}
+
+func TestBMCReachableUnreachable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	netClient := netmocks.NewMockNetClient(ctrl)
+	netClient.EXPECT().
+		DialTimeout("udp", "10.10.10.11:623", gomock.Any()).
+		Return(nil, errors.New("failed to connect"))
+
+	g.Expect(hardware.BMCReachable(netClient)(NewValidMachine())).ToNot(gomega.Succeed())
+}
+
+func TestBMCReachableNoPong(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	netClient := netmocks.NewMockNetClient(ctrl)
+	netClient.EXPECT().
+		DialTimeout("udp", "10.10.10.11:623", gomock.Any()).
+		Return(newRMCPPeer(t, false), nil)
+
+	g.Expect(hardware.BMCReachable(netClient)(NewValidMachine())).ToNot(gomega.Succeed())
+}
+
+func TestBMCReachableRedfish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	machine := NewValidMachine()
+	machine.BMCProtocol = hardware.BMCProtocolRedfish
+
+	server, client := net.Pipe()
+	defer server.Close()
+
+	netClient := netmocks.NewMockNetClient(ctrl)
+	netClient.EXPECT().
+		DialTimeout("tcp", "10.10.10.11:443", gomock.Any()).
+		Return(client, nil)
+
+	g.Expect(hardware.BMCReachable(netClient)(machine)).To(gomega.Succeed())
+}
+
+func TestBMCReachableRedfishUnreachable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	machine := NewValidMachine()
+	machine.BMCProtocol = hardware.BMCProtocolRedfish
+
+	netClient := netmocks.NewMockNetClient(ctrl)
+	netClient.EXPECT().
+		DialTimeout("tcp", "10.10.10.11:443", gomock.Any()).
+		Return(nil, errors.New("failed to connect"))
+
+	g.Expect(hardware.BMCReachable(netClient)(machine)).ToNot(gomega.Succeed())
+}
+
+func TestBMCReachableNoBMC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	machine := NewValidMachine()
+	machine.BMCIPAddress, machine.BMCUsername, machine.BMCPassword = "", "", ""
+
+	netClient := netmocks.NewMockNetClient(ctrl)
+
+	g.Expect(hardware.BMCReachable(netClient)(machine)).To(gomega.Succeed())
+}
+
+func TestBMCReachableCustomPort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	machine := NewValidMachine()
+	machine.BMCPort = 6230
+
+	netClient := netmocks.NewMockNetClient(ctrl)
+	netClient.EXPECT().
+		DialTimeout("udp", "10.10.10.11:6230", gomock.Any()).
+		Return(newRMCPPeer(t, true), nil)
+
+	g.Expect(hardware.BMCReachable(netClient)(machine)).To(gomega.Succeed())
+}
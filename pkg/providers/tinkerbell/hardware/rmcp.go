@@ -0,0 +1,37 @@
+package hardware
+
+import (
+	"errors"
+	"net"
+)
+
+// IPMIPort is the default UDP port of the RMCP service of IPMI BMCs.
+const IPMIPort = 623
+
+// rmcpPresencePing is an ASF presence ping, answered by IPMI BMCs without authentication.
+var rmcpPresencePing = []byte{
+	0x06, 0x00, 0xff, 0x06, // RMCP header: version 1.0, no ack, ASF class
+	0x00, 0x00, 0x11, 0xbe, // ASF IANA enterprise number
+	0x80, 0x00, 0x00, 0x00, // presence ping, tag, reserved, no data
+}
+
+const rmcpPresencePong = 0x40
+
+// PingRMCP sends an RMCP presence ping over conn and waits for the pong. Callers are expected to
+// set a deadline on conn.
+func PingRMCP(conn net.Conn) error {
+	if _, err := conn.Write(rmcpPresencePing); err != nil {
+		return err
+	}
+
+	pong := make([]byte, 64)
+	n, err := conn.Read(pong)
+	if err != nil {
+		return err
+	}
+	if n < len(rmcpPresencePing) || pong[8] != rmcpPresencePong {
+		return errors.New("invalid RMCP presence pong")
+	}
+
+	return nil
+}
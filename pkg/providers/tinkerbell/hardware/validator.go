@@ -119,20 +119,8 @@ func StaticMachineAssertions() MachineAssertion {
 		}
 
 		if m.HasBMC() {
-			if m.BMCIPAddress == "" {
-				return newEmptyFieldError("BMCIPAddress")
-			}
-
-			if err := networkutils.ValidateIP(m.BMCIPAddress); err != nil {
-				return fmt.Errorf("BMCIPAddress: %v", err)
-			}
-
-			if m.BMCUsername == "" {
-				return newEmptyFieldError("BMCUsername")
-			}
-
-			if m.BMCPassword == "" {
-				return newEmptyFieldError("BMCPassword")
+			if err := validateBMC(m); err != nil {
+				return err
 			}
 		}
 
@@ -152,10 +140,56 @@ func StaticMachineAssertions() MachineAssertion {
 			}
 		}
 
+		if m.BMCPort < 0 || m.BMCPort > 65535 {
+			return errors.New("BMCPort: must be between 1 and 65535")
+		}
+
+		if m.BMCProtocol != "" && m.BMCProtocol != BMCProtocolIPMI && m.BMCProtocol != BMCProtocolRedfish {
+			return fmt.Errorf("BMCProtocol: must be one of %s, %s", BMCProtocolIPMI, BMCProtocolRedfish)
+		}
+
+		if m.Arch != "" && m.Arch != X86_64 && m.Arch != AArch64 {
+			return fmt.Errorf("Arch: must be one of %s, %s", X86_64, AArch64)
+		}
+
 		return nil
 	}
 }
 
+// validateBMC validates the BMC configuration of m. The BMC credentials are either set in
+// BMCUsername and BMCPassword or referenced with BMCSecret.
+func validateBMC(m Machine) error {
+	if m.BMCIPAddress == "" {
+		return newEmptyFieldError("BMCIPAddress")
+	}
+
+	if err := networkutils.ValidateIP(m.BMCIPAddress); err != nil {
+		return fmt.Errorf("BMCIPAddress: %v", err)
+	}
+
+	if m.BMCSecret != "" {
+		if m.BMCUsername != "" || m.BMCPassword != "" {
+			return newMachineError("BMCSecret is mutually exclusive with BMCUsername and BMCPassword")
+		}
+
+		if errs := apimachineryvalidation.IsDNS1123Subdomain(m.BMCSecret); len(errs) > 0 {
+			return fmt.Errorf("BMCSecret: invalid secret name %v: %v", m.BMCSecret, errs)
+		}
+
+		return nil
+	}
+
+	if m.BMCUsername == "" {
+		return newEmptyFieldError("BMCUsername")
+	}
+
+	if m.BMCPassword == "" {
+		return newEmptyFieldError("BMCPassword")
+	}
+
+	return nil
+}
+
 // UniqueIPAddress asserts a given Machine instance has a unique IPAddress field relative to previously seen Machine
 // instances. It is not thread safe. It has a 1 time use.
 func UniqueIPAddress() MachineAssertion {
@@ -202,8 +236,8 @@ func UniqueHostnames() MachineAssertion {
 }
 
 // UniqueBMCIPAddress asserts a given Machine instance has a unique BMCIPAddress field relative to previously seen
-// Machine instances. If there is no BMC configuration as defined by machine.HasBMC() the check is a noop. It is
-// not thread safe. It has a 1 time use.
+// Machine instances. Machines with a BMCPort only need a unique address and port pair. If there is no BMC
+// configuration as defined by machine.HasBMC() the check is a noop. It is not thread safe. It has a 1 time use.
 func UniqueBMCIPAddress() MachineAssertion {
 	ips := make(map[string]struct{})
 	return func(m Machine) error {
@@ -215,16 +249,32 @@ func UniqueBMCIPAddress() MachineAssertion {
 			return fmt.Errorf("missing BMCIPAddress (mac=\"%v\")", m.MACAddress)
 		}
 
-		if _, seen := ips[m.BMCIPAddress]; seen {
-			return fmt.Errorf("duplicate IPAddress: %v", m.BMCIPAddress)
+		address := m.BMCIPAddress
+		if m.BMCPort != 0 {
+			address = net.JoinHostPort(m.BMCIPAddress, strconv.Itoa(m.BMCPort))
+		}
+
+		if _, seen := ips[address]; seen {
+			return fmt.Errorf("duplicate IPAddress: %v", address)
 		}
 
-		ips[m.BMCIPAddress] = struct{}{}
+		ips[address] = struct{}{}
 
 		return nil
 	}
 }
 
+// RedfishBMC asserts a Machine has a BMC managed with Redfish, required to boot it from the Hook ISO
+// through virtual media.
+func RedfishBMC() MachineAssertion {
+	return func(m Machine) error {
+		if !m.HasBMC() || !m.IsRedfish() {
+			return fmt.Errorf("machine %v: booting from ISO requires a BMC using the %v protocol", m.Hostname, BMCProtocolRedfish)
+		}
+		return nil
+	}
+}
+
 // RegisterDefaultAssertions applies a set of default assertions to validator. The default assertions
 // include UniqueHostnames and UniqueIDs.
 func RegisterDefaultAssertions(validator *DefaultMachineValidator) {
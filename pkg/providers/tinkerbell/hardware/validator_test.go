@@ -81,6 +81,13 @@ func TestUniquenessAssertions(t *testing.T) {
 				{BMCIPAddress: "bar"},
 			},
 		},
+		"BMCIPAddressesWithPorts": {
+			Assertion: hardware.UniqueBMCIPAddress(),
+			Machines: []hardware.Machine{
+				{BMCIPAddress: "foo", BMCPort: 6230},
+				{BMCIPAddress: "foo", BMCPort: 6231},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -125,6 +132,13 @@ func TestUniquenessAssertionsWithDupes(t *testing.T) {
 				{BMCIPAddress: "foo"},
 			},
 		},
+		"BMCIPAddressesWithPorts": {
+			Assertion: hardware.UniqueBMCIPAddress(),
+			Machines: []hardware.Machine{
+				{BMCIPAddress: "foo", BMCPort: 6230},
+				{BMCIPAddress: "foo", BMCPort: 6230},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -145,6 +159,18 @@ func TestStaticMachineAssertions_ValidMachine(t *testing.T) {
 	g.Expect(validate(machine)).ToNot(gomega.HaveOccurred())
 }
 
+func TestStaticMachineAssertions_ValidMachineWithBMCSecret(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	machine := NewValidMachine()
+	machine.BMCUsername = ""
+	machine.BMCPassword = ""
+	machine.BMCSecret = "bmc-secret"
+
+	validate := hardware.StaticMachineAssertions()
+	g.Expect(validate(machine)).ToNot(gomega.HaveOccurred())
+}
+
 func TestStaticMachineAssertions_InvalidMachines(t *testing.T) {
 	g := gomega.NewWithT(t)
 
@@ -215,6 +241,28 @@ func TestStaticMachineAssertions_InvalidMachines(t *testing.T) {
 		"NonIntVLAN": func(h *hardware.Machine) {
 			h.VLANID = "im not an int"
 		},
+		"InvalidArch": func(h *hardware.Machine) {
+			h.Arch = "ppc64le"
+		},
+		"InvalidBMCPort": func(h *hardware.Machine) {
+			h.BMCPort = 70000
+		},
+		"InvalidBMCProtocol": func(h *hardware.Machine) {
+			h.BMCProtocol = "amt"
+		},
+		"BMCSecretWithBMCUsername": func(h *hardware.Machine) {
+			h.BMCSecret = "bmc-secret"
+			h.BMCPassword = ""
+		},
+		"BMCSecretWithBMCPassword": func(h *hardware.Machine) {
+			h.BMCSecret = "bmc-secret"
+			h.BMCUsername = ""
+		},
+		"InvalidBMCSecret": func(h *hardware.Machine) {
+			h.BMCSecret = "Invalid_Secret"
+			h.BMCUsername = ""
+			h.BMCPassword = ""
+		},
 	}
 
 	validate := hardware.StaticMachineAssertions()
@@ -335,6 +383,21 @@ func TestMatchingDisksForSelectors_MultipleMachines_DifferentDisk(t *testing.T)
 	g.Expect(err).ToNot(gomega.Succeed())
 }
 
+func TestRedfishBMC(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	machine := NewValidMachine()
+	machine.BMCProtocol = hardware.BMCProtocolRedfish
+	g.Expect(hardware.RedfishBMC()(machine)).To(gomega.Succeed())
+
+	machine.BMCProtocol = hardware.BMCProtocolIPMI
+	g.Expect(hardware.RedfishBMC()(machine)).To(gomega.MatchError(gomega.ContainSubstring("requires a BMC using the redfish protocol")))
+
+	machine = NewValidMachine()
+	machine.BMCIPAddress, machine.BMCUsername, machine.BMCPassword = "", "", ""
+	g.Expect(hardware.RedfishBMC()(machine)).ToNot(gomega.Succeed())
+}
+
 func NewValidMachine() hardware.Machine {
 	return hardware.Machine{
 		IPAddress:    "10.10.10.10",
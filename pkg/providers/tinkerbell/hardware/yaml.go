@@ -70,7 +70,7 @@ func (yw *TinkerbellManifestYAML) write(data []byte) error {
 // and BaseboardManagement types.
 
 func marshalTinkerbellHardwareYAML(m Machine) ([]byte, error) {
-	return yaml.Marshal(hardwareFromMachine(m))
+	return yaml.Marshal(hardwareFromMachine(m, true))
 }
 
 func marshalTinkerbellBMCYAML(m Machine) ([]byte, error) {
@@ -239,6 +239,21 @@ func (mr *MockProviderKubectlClientMockRecorder) GetSecret(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetSecret), varargs...)
 }
 
+// GetTinkerbellMachineNames mocks base method.
+func (m *MockProviderKubectlClient) GetTinkerbellMachineNames(arg0 context.Context, arg1, arg2, arg3 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTinkerbellMachineNames", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTinkerbellMachineNames indicates an expected call of GetTinkerbellMachineNames.
+func (mr *MockProviderKubectlClientMockRecorder) GetTinkerbellMachineNames(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTinkerbellMachineNames", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetTinkerbellMachineNames), arg0, arg1, arg2, arg3)
+}
+
 // GetUnprovisionedTinkerbellHardware mocks base method.
 func (m *MockProviderKubectlClient) GetUnprovisionedTinkerbellHardware(arg0 context.Context, arg1, arg2 string) ([]v1alpha10.Hardware, error) {
 	m.ctrl.T.Helper()
@@ -254,6 +269,20 @@ func (mr *MockProviderKubectlClientMockRecorder) GetUnprovisionedTinkerbellHardw
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnprovisionedTinkerbellHardware", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetUnprovisionedTinkerbellHardware), arg0, arg1, arg2)
 }
 
+// ReleaseTinkerbellHardware mocks base method.
+func (m *MockProviderKubectlClient) ReleaseTinkerbellHardware(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseTinkerbellHardware", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseTinkerbellHardware indicates an expected call of ReleaseTinkerbellHardware.
+func (mr *MockProviderKubectlClientMockRecorder) ReleaseTinkerbellHardware(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseTinkerbellHardware", reflect.TypeOf((*MockProviderKubectlClient)(nil).ReleaseTinkerbellHardware), arg0, arg1, arg2, arg3)
+}
+
 // SearchTinkerbellDatacenterConfig mocks base method.
 func (m *MockProviderKubectlClient) SearchTinkerbellDatacenterConfig(arg0 context.Context, arg1, arg2, arg3 string) ([]*v1alpha1.TinkerbellDatacenterConfig, error) {
 	m.ctrl.T.Helper()
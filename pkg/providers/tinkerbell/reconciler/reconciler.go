@@ -0,0 +1,335 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// bmcNotReadyRequeue is the wait time before checking again the BaseboardManagements rufio hasn't contacted yet.
+const bmcNotReadyRequeue = 10 * time.Second
+
+// CNIReconciler is an interface for reconciling CNI in the Tinkerbell cluster reconciler.
+type CNIReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
+// BootstrapManifestsReconciler applies the bootstrap manifests of a cluster.
+type BootstrapManifestsReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
+// RemoteClientRegistry is an interface that defines methods for remote clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// Reconciler reconciles Tinkerbell clusters.
+type Reconciler struct {
+	client               client.Client
+	cniReconciler        CNIReconciler
+	manifestsReconciler  BootstrapManifestsReconciler
+	remoteClientRegistry RemoteClientRegistry
+	*serverside.ObjectApplier
+}
+
+// New defines a new Tinkerbell reconciler.
+func New(client client.Client, cniReconciler CNIReconciler, manifestsReconciler BootstrapManifestsReconciler, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		cniReconciler:        cniReconciler,
+		manifestsReconciler:  manifestsReconciler,
+		remoteClientRegistry: remoteClientRegistry,
+		ObjectApplier:        serverside.NewObjectApplier(client),
+	}
+}
+
+// Reconcile reconciles the cluster to the desired state defined in its spec.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	log = log.WithValues("provider", "tinkerbell")
+	clusterSpec, err := c.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if err = r.getTemplateConfigs(ctx, clusterSpec); err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.NewPhaseRunner().Register(
+		r.ValidateClusterSpec,
+		r.ReconcileBMCs,
+		r.ReconcileControlPlane,
+		r.CheckControlPlaneReady,
+		r.ReconcileCNI,
+		r.ReconcileBootstrapManifests,
+		r.ReconcileWorkers,
+	).Run(ctx, log, clusterSpec)
+}
+
+// ValidateClusterSpec runs the Tinkerbell cluster spec validations, including checking there is enough
+// hardware matching the hardware selectors of the machine configs without counting the hardware already
+// provisioned for other clusters. It updates the cluster status if the spec is invalid.
+func (r *Reconciler) ValidateClusterSpec(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateClusterSpec")
+	catalogue, err := r.hardwareCatalogue(ctx, clusterSpec.Cluster.Name)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	validator := tinkerbell.NewClusterSpecValidator(
+		tinkerbell.HardwareSatisfiesOnlyOneSelectorAssertion(catalogue),
+		tinkerbell.MinimumHardwareAvailableAssertionForCreate(catalogue),
+	)
+	if err := validator.Validate(tinkerbellSpec(clusterSpec)); err != nil {
+		log.Error(err, "Invalid Tinkerbell cluster spec")
+		failureMessage := err.Error()
+		clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+		return controller.Result{}, err
+	}
+	return controller.Result{}, nil
+}
+
+// ReconcileBMCs checks the rufio BaseboardManagement of all the hardware the cluster can use exist and
+// can be contacted, since CAPT needs them to power cycle the machines. It requeues while rufio hasn't
+// contacted them yet and updates the cluster status if any of them can't be reached.
+func (r *Reconciler) ReconcileBMCs(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileBMCs")
+	catalogue, err := r.hardwareCatalogue(ctx, clusterSpec.Cluster.Name)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	selectors := hardwareSelectors(clusterSpec)
+	for _, hw := range catalogue.AllHardware() {
+		if hw.Spec.BMCRef == nil || !matchesAnySelector(hw, selectors) {
+			continue
+		}
+
+		bmc := &rufiov1alpha1.BaseboardManagement{}
+		err := r.client.Get(ctx, client.ObjectKey{Name: hw.Spec.BMCRef.Name, Namespace: hw.Namespace}, bmc)
+		if apierrors.IsNotFound(err) {
+			failureMessage := fmt.Sprintf("BaseboardManagement %s referenced by hardware %s not found", hw.Spec.BMCRef.Name, hw.Name)
+			log.Error(err, failureMessage)
+			clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+			return controller.Result{}, err
+		}
+		if err != nil {
+			return controller.Result{}, err
+		}
+
+		contactable := bmcContactableCondition(bmc)
+		if contactable == nil {
+			log.Info("Waiting for BaseboardManagement to be contacted", "baseboardManagement", bmc.Name)
+			return controller.ResultWithRequeue(bmcNotReadyRequeue), nil
+		}
+
+		if contactable.Status == rufiov1alpha1.ConditionFalse {
+			failureMessage := fmt.Sprintf("BaseboardManagement %s for hardware %s is not contactable: %s", bmc.Name, hw.Name, contactable.Message)
+			log.Error(nil, failureMessage)
+			clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+			return controller.ResultWithReturn(), nil
+		}
+	}
+
+	return controller.Result{}, nil
+}
+
+// ReconcileControlPlane applies the control plane CAPI objects to the cluster.
+func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileControlPlane")
+	log.Info("Applying control plane CAPI objects")
+	return r.Apply(ctx, func() ([]kubernetes.Object, error) {
+		hw, err := r.hardware(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		cp, err := tinkerbell.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec, hw)
+		if err != nil {
+			return nil, err
+		}
+		return cp.Objects(), nil
+	})
+}
+
+// CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
+// Requeues with the appropriate wait times whenever the cluster is not ready yet.
+func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "checkControlPlaneReady")
+	return clusters.CheckControlPlaneReady(ctx, r.client, log, clusterSpec.Cluster)
+}
+
+// ReconcileCNI takes the Cilium CNI in a cluster to the desired state defined in a cluster spec.
+func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileCNI")
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
+// ReconcileBootstrapManifests applies the bootstrap manifests of the cluster spec once its CNI is ready.
+func (r *Reconciler) ReconcileBootstrapManifests(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileBootstrapManifests")
+	if len(clusterSpec.Cluster.Spec.BootstrapManifests) == 0 {
+		return controller.Result{}, nil
+	}
+
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.manifestsReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
+// ReconcileWorkers applies the worker CAPI objects to the cluster.
+func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileWorkers")
+	log.Info("Applying worker CAPI objects")
+	return r.Apply(ctx, func() ([]kubernetes.Object, error) {
+		hw, err := r.hardware(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		w, err := tinkerbell.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec, hw)
+		if err != nil {
+			return nil, err
+		}
+		return w.WorkerObjects(), nil
+	})
+}
+
+// getTemplateConfigs retrieves the TinkerbellTemplateConfigs referenced by the machine configs of the spec.
+func (r *Reconciler) getTemplateConfigs(ctx context.Context, clusterSpec *c.Spec) error {
+	for _, m := range clusterSpec.TinkerbellMachineConfigs {
+		name := m.Spec.TemplateRef.Name
+		if name == "" {
+			continue
+		}
+		if _, ok := clusterSpec.TinkerbellTemplateConfigs[name]; ok {
+			continue
+		}
+
+		templateConfig := &anywherev1.TinkerbellTemplateConfig{}
+		if err := r.client.Get(ctx, client.ObjectKey{Name: name, Namespace: clusterSpec.Cluster.Namespace}, templateConfig); err != nil {
+			return fmt.Errorf("getting TinkerbellTemplateConfig %s: %v", name, err)
+		}
+
+		if clusterSpec.TinkerbellTemplateConfigs == nil {
+			clusterSpec.TinkerbellTemplateConfigs = map[string]*anywherev1.TinkerbellTemplateConfig{}
+		}
+		clusterSpec.TinkerbellTemplateConfigs[name] = templateConfig
+	}
+
+	return nil
+}
+
+// hardware lists all the Tinkerbell hardware in the eksa-system namespace.
+func (r *Reconciler) hardware(ctx context.Context) ([]tinkv1alpha1.Hardware, error) {
+	hw := &tinkv1alpha1.HardwareList{}
+	if err := r.client.List(ctx, hw, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return nil, fmt.Errorf("listing tinkerbell hardware: %v", err)
+	}
+
+	return hw.Items, nil
+}
+
+// machineNames returns the names of the TinkerbellMachines of the cluster clusterName.
+func (r *Reconciler) machineNames(ctx context.Context, clusterName string) (types.Lookup, error) {
+	machines := &unstructured.UnstructuredList{}
+	machines.SetGroupVersionKind(tinkerbell.MachineListGVK)
+	if err := r.client.List(ctx, machines,
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{clusterv1.ClusterLabelName: clusterName},
+	); err != nil {
+		return nil, fmt.Errorf("listing tinkerbell machines: %v", err)
+	}
+
+	names := make(types.Lookup, len(machines.Items))
+	for _, m := range machines.Items {
+		names[m.GetName()] = struct{}{}
+	}
+
+	return names, nil
+}
+
+// hardwareCatalogue builds a catalogue with the hardware the cluster can use: the hardware not provisioned yet
+// plus the hardware already provisioned for the cluster machines.
+func (r *Reconciler) hardwareCatalogue(ctx context.Context, clusterName string) (*hardware.Catalogue, error) {
+	hw, err := r.hardware(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	machines, err := r.machineNames(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogue := hardware.NewCatalogue()
+	for i := range hw {
+		if tinkerbell.IsHardwareProvisioned(&hw[i]) && !tinkerbell.IsHardwareProvisionedFor(&hw[i], machines) {
+			continue
+		}
+		if err := catalogue.InsertHardware(&hw[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return catalogue, nil
+}
+
+func tinkerbellSpec(clusterSpec *c.Spec) *tinkerbell.ClusterSpec {
+	return tinkerbell.NewClusterSpec(clusterSpec, clusterSpec.TinkerbellMachineConfigs, clusterSpec.TinkerbellDatacenter)
+}
+
+func hardwareSelectors(clusterSpec *c.Spec) []anywherev1.HardwareSelector {
+	selectors := make([]anywherev1.HardwareSelector, 0, len(clusterSpec.TinkerbellMachineConfigs))
+	for _, m := range clusterSpec.TinkerbellMachineConfigs {
+		selectors = append(selectors, m.Spec.HardwareSelector)
+	}
+	return selectors
+}
+
+func matchesAnySelector(hw *tinkv1alpha1.Hardware, selectors []anywherev1.HardwareSelector) bool {
+	for _, s := range selectors {
+		if hardware.LabelsMatchSelector(s, hw.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func bmcContactableCondition(bmc *rufiov1alpha1.BaseboardManagement) *rufiov1alpha1.BaseboardManagementCondition {
+	for i := range bmc.Status.Conditions {
+		if bmc.Status.Conditions[i].Type == rufiov1alpha1.Contactable {
+			return &bmc.Status.Conditions[i]
+		}
+	}
+	return nil
+}
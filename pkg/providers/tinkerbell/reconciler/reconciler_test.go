@@ -0,0 +1,340 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	clusterspec "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func TestReconcilerValidateClusterSpecSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withObjects(
+		hardware("cp-1", map[string]string{"type": "cp"}, nil),
+		hardware("worker-1", map[string]string{"type": "worker"}, nil),
+	)
+
+	result, err := tt.reconciler().ValidateClusterSpec(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateClusterSpecHardwareOwnedByCluster(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withObjects(
+		hardware("cp-1", map[string]string{
+			"type":                                   "cp",
+			"v1alpha1.tinkerbell.org/ownerName":      "workload-cluster-control-plane-abcde",
+			"v1alpha1.tinkerbell.org/ownerNamespace": constants.EksaSystemNamespace,
+		}, nil),
+		hardware("worker-1", map[string]string{"type": "worker"}, nil),
+		tinkerbellMachine("workload-cluster-control-plane-abcde", "workload-cluster"),
+	)
+
+	_, err := tt.reconciler().ValidateClusterSpec(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateClusterSpecHardwareOwnedByOtherCluster(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withObjects(
+		hardware("cp-1", map[string]string{"type": "cp", "v1alpha1.tinkerbell.org/ownerName": "other-cluster-control-plane-abcde"}, nil),
+		hardware("worker-1", map[string]string{"type": "worker"}, nil),
+	)
+
+	_, err := tt.reconciler().ValidateClusterSpec(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("minimum hardware count not met")))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("minimum hardware count not met")))
+}
+
+func TestReconcilerValidateClusterSpecInvalidMachineConfig(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.spec.TinkerbellMachineConfigs["cp"].Spec.HardwareSelector = nil
+
+	_, err := tt.reconciler().ValidateClusterSpec(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("missing spec.hardwareSelector")))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("missing spec.hardwareSelector")))
+}
+
+func TestReconcilerReconcileBMCsContactable(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withObjects(
+		hardware("cp-1", map[string]string{"type": "cp"}, &corev1.TypedLocalObjectReference{Name: "bmc-cp-1"}),
+		hardware("worker-1", map[string]string{"type": "worker"}, nil),
+		baseboardManagement("bmc-cp-1", rufiov1alpha1.ConditionTrue),
+	)
+
+	result, err := tt.reconciler().ReconcileBMCs(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerReconcileBMCsNotContactedYet(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withObjects(
+		hardware("cp-1", map[string]string{"type": "cp"}, &corev1.TypedLocalObjectReference{Name: "bmc-cp-1"}),
+		baseboardManagement("bmc-cp-1", ""),
+	)
+
+	result, err := tt.reconciler().ReconcileBMCs(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+}
+
+func TestReconcilerReconcileBMCsNotContactable(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withObjects(
+		hardware("cp-1", map[string]string{"type": "cp"}, &corev1.TypedLocalObjectReference{Name: "bmc-cp-1"}),
+		baseboardManagement("bmc-cp-1", rufiov1alpha1.ConditionFalse),
+	)
+
+	result, err := tt.reconciler().ReconcileBMCs(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithReturn()))
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(HaveValue(Equal(
+		"BaseboardManagement bmc-cp-1 for hardware cp-1 is not contactable: connection refused",
+	)))
+}
+
+func TestReconcilerReconcileBMCsMissing(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withObjects(
+		hardware("cp-1", map[string]string{"type": "cp"}, &corev1.TypedLocalObjectReference{Name: "bmc-cp-1"}),
+	)
+
+	_, err := tt.reconciler().ReconcileBMCs(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(HaveOccurred())
+	tt.Expect(tt.spec.Cluster.Status.FailureMessage).To(HaveValue(Equal(
+		"BaseboardManagement bmc-cp-1 referenced by hardware cp-1 not found",
+	)))
+}
+
+func TestReconcilerReconcileBMCsIgnoresHardwareOfOtherClusters(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withObjects(
+		hardware("other-1", map[string]string{"type": "other"}, &corev1.TypedLocalObjectReference{Name: "bmc-other-1"}),
+		hardware("owned-1", map[string]string{"type": "cp", "v1alpha1.tinkerbell.org/ownerName": "other-cluster-control-plane-abcde"}, &corev1.TypedLocalObjectReference{Name: "bmc-owned-1"}),
+	)
+
+	result, err := tt.reconciler().ReconcileBMCs(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerCheckControlPlaneReadyNoCAPICluster(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	result, err := tt.reconciler().CheckControlPlaneReady(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(5 * time.Second)))
+}
+
+func TestReconcilerReconcileCNISuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	remoteClient := fake.NewClientBuilder().Build()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: constants.EksaSystemNamespace},
+	).Return(remoteClient, nil)
+	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, gomock.Any(), remoteClient, tt.spec)
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileCNIErrorClientRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: constants.EksaSystemNamespace},
+	).Return(nil, errors.New("building client"))
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileBootstrapManifestsSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.spec.Cluster.Spec.BootstrapManifests = []anywherev1.BootstrapManifest{{Name: "manifests", Inline: "apiVersion: v1"}}
+	remoteClient := fake.NewClientBuilder().Build()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: constants.EksaSystemNamespace},
+	).Return(remoteClient, nil)
+	tt.manifestsReconciler.EXPECT().Reconcile(tt.ctx, gomock.Any(), remoteClient, tt.spec)
+
+	result, err := tt.reconciler().ReconcileBootstrapManifests(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileBootstrapManifestsNoManifests(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	result, err := tt.reconciler().ReconcileBootstrapManifests(tt.ctx, test.NewNullLogger(), tt.spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+type reconcilerTest struct {
+	t testing.TB
+	*WithT
+	ctx                  context.Context
+	spec                 *clusterspec.Spec
+	client               client.Client
+	cniReconciler        *mocks.MockCNIReconciler
+	manifestsReconciler  *mocks.MockBootstrapManifestsReconciler
+	remoteClientRegistry *mocks.MockRemoteClientRegistry
+}
+
+func newReconcilerTest(t testing.TB) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	spec := test.NewClusterSpec(func(s *clusterspec.Spec) {
+		s.Cluster.Name = "workload-cluster"
+		s.Cluster.Namespace = "default"
+		s.Cluster.Spec.KubernetesVersion = anywherev1.Kube123
+		s.Cluster.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.TinkerbellDatacenterKind, Name: "datacenter"}
+		s.Cluster.Spec.ControlPlaneConfiguration = anywherev1.ControlPlaneConfiguration{
+			Count:           1,
+			Endpoint:        &anywherev1.Endpoint{Host: "1.1.1.1"},
+			MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.TinkerbellMachineConfigKind, Name: "cp"},
+		}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+			{
+				Name:            "md-0",
+				Count:           ptr.Int(1),
+				MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.TinkerbellMachineConfigKind, Name: "worker"},
+			},
+		}
+		s.TinkerbellDatacenter = &anywherev1.TinkerbellDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "datacenter", Namespace: "default"},
+			Spec: anywherev1.TinkerbellDatacenterConfigSpec{
+				TinkerbellIP: "1.1.1.2",
+				OSImageURL:   "https://ubuntu.gz",
+			},
+		}
+		s.TinkerbellMachineConfigs = map[string]*anywherev1.TinkerbellMachineConfig{
+			"cp":     machineConfig("cp"),
+			"worker": machineConfig("worker"),
+		}
+	})
+
+	tt := &reconcilerTest{
+		t:                    t,
+		WithT:                NewWithT(t),
+		ctx:                  context.Background(),
+		spec:                 spec,
+		cniReconciler:        mocks.NewMockCNIReconciler(ctrl),
+		manifestsReconciler:  mocks.NewMockBootstrapManifestsReconciler(ctrl),
+		remoteClientRegistry: mocks.NewMockRemoteClientRegistry(ctrl),
+	}
+	tt.withObjects()
+
+	return tt
+}
+
+func (tt *reconcilerTest) withObjects(objs ...client.Object) {
+	scheme := runtime.NewScheme()
+	tt.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	tt.Expect(anywherev1.AddToScheme(scheme)).To(Succeed())
+	tt.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	tt.Expect(tinkv1alpha1.AddToScheme(scheme)).To(Succeed())
+	tt.Expect(rufiov1alpha1.AddToScheme(scheme)).To(Succeed())
+	tt.client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
+	return reconciler.New(tt.client, tt.cniReconciler, tt.manifestsReconciler, tt.remoteClientRegistry)
+}
+
+func machineConfig(selectorType string) *anywherev1.TinkerbellMachineConfig {
+	return &anywherev1.TinkerbellMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: selectorType, Namespace: "default"},
+		Spec: anywherev1.TinkerbellMachineConfigSpec{
+			HardwareSelector: anywherev1.HardwareSelector{"type": selectorType},
+			OSFamily:         anywherev1.Ubuntu,
+		},
+	}
+}
+
+func hardware(name string, labels map[string]string, bmcRef *corev1.TypedLocalObjectReference) *tinkv1alpha1.Hardware {
+	return &tinkv1alpha1.Hardware{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    labels,
+		},
+		Spec: tinkv1alpha1.HardwareSpec{
+			BMCRef: bmcRef,
+		},
+	}
+}
+
+func tinkerbellMachine(name, clusterName string) *unstructured.Unstructured {
+	m := &unstructured.Unstructured{}
+	m.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta1")
+	m.SetKind("TinkerbellMachine")
+	m.SetName(name)
+	m.SetNamespace(constants.EksaSystemNamespace)
+	m.SetLabels(map[string]string{clusterv1.ClusterLabelName: clusterName})
+	return m
+}
+
+func baseboardManagement(name string, contactable rufiov1alpha1.ConditionStatus) *rufiov1alpha1.BaseboardManagement {
+	bmc := &rufiov1alpha1.BaseboardManagement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+	if contactable != "" {
+		bmc.Status.Conditions = []rufiov1alpha1.BaseboardManagementCondition{
+			{
+				Type:    rufiov1alpha1.Contactable,
+				Status:  contactable,
+				Message: "connection refused",
+			},
+		}
+	}
+	return bmc
+}
@@ -36,6 +36,9 @@ const (
 	grpcPort       = "42113"
 	kubevip        = "kubevip"
 	envoy          = "envoy"
+
+	// serialConsoleKernelArgs keeps the console on screen and mirrors it to the first serial port.
+	serialConsoleKernelArgs = "console=tty0 console=ttyS0,115200"
 )
 
 type Docker interface {
@@ -60,6 +63,7 @@ type Installer struct {
 	hostPort        bool
 	loadBalancer    bool
 	envoy           bool
+	serialConsole   bool
 }
 
 type InstallOption func(s *Installer)
@@ -104,6 +108,14 @@ func WithEnvoyEnabled(enabled bool) InstallOption {
 	}
 }
 
+// WithSerialConsole is an InstallOption that sends the Hook kernel console to the first serial port
+// so boot-time failures can be seen through the BMC.
+func WithSerialConsole(enabled bool) InstallOption {
+	return func(s *Installer) {
+		s.serialConsole = enabled
+	}
+}
+
 // WithLoadBalancer is an InstallOption that allows you to setup a LoadBalancer to expose hegel and tink-server.
 func WithLoadBalancerEnabled(enabled bool) InstallOption {
 	return func(s *Installer) {
@@ -274,6 +286,9 @@ func (s *Installer) getBootsEnv(bundle releasev1alpha1.TinkerbellStackBundle, ti
 		localRegistry := net.JoinHostPort(s.registryMirror.Endpoint, s.registryMirror.Port)
 		extraKernelArgs = fmt.Sprintf("%s insecure_registries=%s", extraKernelArgs, localRegistry)
 	}
+	if s.serialConsole {
+		extraKernelArgs = fmt.Sprintf("%s %s", extraKernelArgs, serialConsoleKernelArgs)
+	}
 
 	return map[string]string{
 		"DATA_MODEL_VERSION":        "kubernetes",
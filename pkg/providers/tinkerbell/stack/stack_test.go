@@ -158,6 +158,11 @@ func TestTinkerbellStackInstallWithDifferentOptions(t *testing.T) {
 			expectedFile: "testdata/expected_with_load_balancer_enabled_false.yaml",
 			opts:         []stack.InstallOption{stack.WithLoadBalancerEnabled(false)},
 		},
+		{
+			name:         "with_serial_console_enabled_true",
+			expectedFile: "testdata/expected_with_serial_console_enabled_true.yaml",
+			opts:         []stack.InstallOption{stack.WithSerialConsole(true)},
+		},
 		{
 			name:         "with_kubernetes_options",
 			expectedFile: "testdata/expected_with_kubernetes_options.yaml",
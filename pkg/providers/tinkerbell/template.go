@@ -17,6 +17,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
@@ -58,6 +59,34 @@ func NewTemplateBuilder(datacenterSpec *v1alpha1.TinkerbellDatacenterConfigSpec,
 	}
 }
 
+// osImageURL returns the OS image URL for the machines using machineSpec, falling back to the
+// datacenter one, so node groups with a different architecture can provision their own image.
+func osImageURL(machineSpec *v1alpha1.TinkerbellMachineConfigSpec, datacenterSpec *v1alpha1.TinkerbellDatacenterConfigSpec) string {
+	if machineSpec.OSImageURL != "" {
+		return machineSpec.OSImageURL
+	}
+	return datacenterSpec.OSImageURL
+}
+
+// setTemplateProxy configures the cluster proxy in all the actions of a default template, so they can
+// stream the OS image through it. The Tinkerbell stack and the control plane endpoint are never proxied.
+func (tb *TemplateBuilder) setTemplateProxy(clusterSpec *cluster.Spec, templateConfig *v1alpha1.TinkerbellTemplateConfig) {
+	proxy := clusterSpec.Cluster.Spec.ProxyConfiguration
+	if proxy == nil {
+		return
+	}
+
+	// +3 for the tinkerbell IPs and the control plane endpoint.
+	noProxy := make([]string, 0, len(proxy.NoProxy)+3)
+	if tb.tinkerbellIp != tb.datacenterSpec.TinkerbellIP {
+		noProxy = append(noProxy, tb.tinkerbellIp)
+	}
+	noProxy = append(noProxy, tb.datacenterSpec.TinkerbellIP, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host)
+	noProxy = append(noProxy, proxy.NoProxy...)
+
+	templateConfig.SetActionsProxy(proxy.HttpProxy, proxy.HttpsProxy, noProxy)
+}
+
 func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spec, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
 	cpTemplateConfig := clusterSpec.TinkerbellTemplateConfigs[tb.controlPlaneMachineSpec.TemplateRef.Name]
 	if cpTemplateConfig == nil {
@@ -69,7 +98,8 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 			}
 		}
 		versionBundle := clusterSpec.VersionsBundle.VersionsBundle
-		cpTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, tb.datacenterSpec.OSImageURL, tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, tb.controlPlaneMachineSpec.OSFamily)
+		cpTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, osImageURL(tb.controlPlaneMachineSpec, tb.datacenterSpec), tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, tb.controlPlaneMachineSpec.OSFamily)
+		tb.setTemplateProxy(clusterSpec, cpTemplateConfig)
 	}
 
 	cpTemplateString, err := cpTemplateConfig.ToTemplateString()
@@ -91,7 +121,8 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 				}
 			}
 			versionBundle := clusterSpec.VersionsBundle.VersionsBundle
-			etcdTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, tb.datacenterSpec.OSImageURL, tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, tb.etcdMachineSpec.OSFamily)
+			etcdTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, osImageURL(tb.etcdMachineSpec, tb.datacenterSpec), tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, tb.etcdMachineSpec.OSFamily)
+			tb.setTemplateProxy(clusterSpec, etcdTemplateConfig)
 		}
 		etcdTemplateString, err = etcdTemplateConfig.ToTemplateString()
 		if err != nil {
@@ -124,7 +155,8 @@ func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 				}
 			}
 			versionBundle := clusterSpec.VersionsBundle.VersionsBundle
-			wTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, tb.datacenterSpec.OSImageURL, tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, workerNodeMachineSpec.OSFamily)
+			wTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, osImageURL(&workerNodeMachineSpec, tb.datacenterSpec), tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, workerNodeMachineSpec.OSFamily)
+			tb.setTemplateProxy(clusterSpec, wTemplateConfig)
 		}
 
 		wTemplateString, err := wTemplateConfig.ToTemplateString()
@@ -147,12 +179,9 @@ func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 		values["workerNodeGroupName"] = workerNodeGroupConfiguration.Name
 		values["workloadkubeadmconfigTemplateName"] = kubeadmconfigTemplateNames[workerNodeGroupConfiguration.Name]
 		values["autoscalingConfig"] = workerNodeGroupConfiguration.AutoScalingConfiguration
+		setIsoBootValues(values, tb.datacenterSpec)
 
-		if workerNodeGroupConfiguration.UpgradeRolloutStrategy != nil {
-			values["upgradeRolloutStrategy"] = true
-			values["maxSurge"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
-			values["maxUnavailable"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxUnavailable
-		}
+		setWorkerUpgradeRolloutStrategyValues(values, workerNodeGroupConfiguration.UpgradeRolloutStrategy)
 
 		bytes, err := templater.Execute(defaultClusterConfigMD, values)
 		if err != nil {
@@ -163,6 +192,18 @@ func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 	return templater.AppendYamlResources(workerSpecs...), nil
 }
 
+// setWorkerUpgradeRolloutStrategyValues sets the rolling update parameters of a worker node group. They are
+// optional for in-place upgrades, so they're left for CAPI to default when both are 0.
+func setWorkerUpgradeRolloutStrategyValues(values map[string]interface{}, strategy *v1alpha1.WorkerNodesUpgradeRolloutStrategy) {
+	if strategy == nil || (strategy.RollingUpdate.MaxSurge == 0 && strategy.RollingUpdate.MaxUnavailable == 0) {
+		return
+	}
+
+	values["upgradeRolloutStrategy"] = true
+	values["maxSurge"] = strategy.RollingUpdate.MaxSurge
+	values["maxUnavailable"] = strategy.RollingUpdate.MaxUnavailable
+}
+
 func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
 	clusterName := newClusterSpec.Cluster.Name
 	var controlPlaneTemplateName, workloadTemplateName, kubeadmconfigTemplateName, etcdTemplateName string
@@ -181,6 +222,8 @@ func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 	if err != nil {
 		return nil, nil, err
 	}
+	// Names of the templates kept by the machines upgraded in place.
+	inPlaceTemplateNames := map[string]struct{}{}
 	needsNewControlPlaneTemplate := NeedsNewControlPlaneTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, controlPlaneTmc, controlPlaneMachineConfig)
 	if !needsNewControlPlaneTemplate {
 		cp, err := p.providerKubectlClient.GetKubeadmControlPlane(ctx, workloadCluster, c.Name, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
@@ -188,6 +231,9 @@ func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 			return nil, nil, err
 		}
 		controlPlaneTemplateName = cp.Spec.MachineTemplate.InfrastructureRef.Name
+		if nodeupgrader.ControlPlaneUpgradesInPlace(currentSpec, newClusterSpec) {
+			inPlaceTemplateNames[controlPlaneTemplateName] = struct{}{}
+		}
 	} else {
 		controlPlaneTemplateName = common.CPMachineTemplateName(clusterName, p.templateBuilder.now)
 	}
@@ -227,6 +273,9 @@ func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 			}
 			workloadTemplateName = md.Spec.Template.Spec.InfrastructureRef.Name
 			workloadTemplateNames[workerNodeGroupConfiguration.Name] = workloadTemplateName
+			if nodeupgrader.WorkerNodeGroupUpgradesInPlace(currentSpec, newClusterSpec, workerNodeGroupConfiguration) {
+				inPlaceTemplateNames[workloadTemplateName] = struct{}{}
+			}
 		} else {
 			workloadTemplateName = common.WorkerMachineTemplateName(clusterName, workerNodeGroupConfiguration.Name, p.templateBuilder.now)
 			workloadTemplateNames[workerNodeGroupConfiguration.Name] = workloadTemplateName
@@ -285,6 +334,10 @@ func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 		return nil, nil, err
 	}
 
+	if controlPlaneSpec, workersSpec, err = omitInPlaceTemplates(controlPlaneSpec, workersSpec, inPlaceTemplateNames); err != nil {
+		return nil, nil, err
+	}
+
 	if p.isScaleUpDown(currentSpec.Cluster, newClusterSpec.Cluster) {
 		cpSpec, err := omitTinkerbellMachineTemplate(controlPlaneSpec)
 		if err == nil {
@@ -350,6 +403,10 @@ func (p *Provider) needsNewMachineTemplate(ctx context.Context, workloadCluster
 		if err != nil {
 			return false, err
 		}
+		if nodeupgrader.WorkerNodeGroupUpgradesInPlace(currentSpec, newClusterSpec, workerNodeGroupConfiguration) {
+			// The bundle only changes the images used to provision the machines, which are upgraded in place.
+			return AnyImmutableFieldChanged(vdc, p.datacenterConfig, workerTmc, workerMachineConfig), nil
+		}
 		needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, workerTmc, workerMachineConfig)
 		return needsNewWorkloadTemplate, nil
 	}
@@ -373,7 +430,8 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 	format := "cloud-config"
 
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
-		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig))
+		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
+		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster))
 
 	// LoadBalancerClass is feature gated in K8S v1.21 and needs to be enabled manually
 	if clusterSpec.Cluster.Spec.KubernetesVersion == v1alpha1.Kube121 {
@@ -382,12 +440,18 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
-		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+		Append(clusterapi.KubeletServingCertificateExtraArgs(clusterSpec.Cluster)).
+		Append(clusterapi.ImageCredentialProviderExtraArgs(clusterSpec.Cluster))
 
 	values := map[string]interface{}{
 		"clusterName":                   clusterSpec.Cluster.Name,
 		"controlPlaneEndpointIp":        clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host,
 		"controlPlaneReplicas":          clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count,
+		"apiServerCertSANs":             clusterSpec.Cluster.Spec.ControlPlaneConfiguration.CertSANs,
+		"staticPodManifests":            clusterapi.StaticPodManifestFiles(clusterSpec.Cluster.Spec.ControlPlaneConfiguration),
+		"nodeIPCommands":                clusterapi.NodeIPCommands(controlPlaneMachineSpec.NetworkInterfaces),
+		"vipInterface":                  clusterapi.VIPInterface(controlPlaneMachineSpec.NetworkInterfaces),
 		"controlPlaneSshAuthorizedKey":  controlPlaneMachineSpec.Users[0].SshAuthorizedKeys,
 		"controlPlaneSshUsername":       controlPlaneMachineSpec.Users[0].Name,
 		"eksaSystemNamespace":           constants.EksaSystemNamespace,
@@ -408,11 +472,17 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		"externalEtcdVersion":           bundle.KubeDistro.EtcdVersion,
 		"etcdCipherSuites":              crypto.SecureCipherSuitesString(),
 		"kubeletExtraArgs":              kubeletExtraArgs.ToPartialYaml(),
+		"credentialProviderFiles":       clusterapi.ImageCredentialProviderConfigFiles(clusterSpec.Cluster),
+		"etcdEncryptionFiles":           clusterapi.EtcdEncryptionConfigFiles(clusterSpec.Cluster),
+		"etcdEncryptionVolumes":         clusterapi.EtcdEncryptionExtraVolumes(clusterSpec.Cluster, controlPlaneMachineSpec.OSFamily),
 		"hardwareSelector":              controlPlaneMachineSpec.HardwareSelector,
 		"controlPlaneTaints":            clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
+		"controlPlaneSchedulable":       clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Schedulable,
 		"workerNodeGroupConfigurations": clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations,
 		"skipLoadBalancerDeployment":    datacenterSpec.SkipLoadBalancerDeployment,
 	}
+	common.SetKubeVipValues(values, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint)
+	setIsoBootValues(values, &datacenterSpec)
 
 	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
 		values["upgradeRolloutStrategy"] = true
@@ -437,6 +507,8 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		values["etcdHardwareSelector"] = etcdMachineSpec.HardwareSelector
 	}
 
+	setHostOSConfigValues(values, controlPlaneMachineSpec)
+
 	if controlPlaneMachineSpec.OSFamily == v1alpha1.Bottlerocket {
 		values["format"] = string(v1alpha1.Bottlerocket)
 		values["pauseRepository"] = bundle.KubeDistro.Pause.Image()
@@ -452,26 +524,55 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 	return values
 }
 
+// setHostOSConfigValues adds the kernel modules and sysctls of a machine config to the template values.
+// Bottlerocket nodes get them as kernel settings instead of files and commands.
+func setHostOSConfigValues(values map[string]interface{}, machineSpec v1alpha1.TinkerbellMachineConfigSpec) {
+	if machineSpec.OSFamily == v1alpha1.Bottlerocket {
+		values["bottlerocketKernelModules"] = clusterapi.BottlerocketKernelModules(machineSpec.HostOSConfiguration)
+		values["bottlerocketKernelSysctls"] = clusterapi.BottlerocketKernelSysctls(machineSpec.HostOSConfiguration)
+		return
+	}
+
+	values["hostOSConfigFiles"] = clusterapi.HostOSConfigFiles(machineSpec.HostOSConfiguration)
+	values["hostOSConfigCommands"] = clusterapi.HostOSConfigCommands(machineSpec.HostOSConfiguration)
+}
+
+// setIsoBootValues sets the values to render the machine templates booting the machines from the
+// Hook ISO through virtual media.
+func setIsoBootValues(values map[string]interface{}, datacenterSpec *v1alpha1.TinkerbellDatacenterConfigSpec) {
+	if datacenterSpec == nil || !datacenterSpec.IsoBoot {
+		return
+	}
+	values["isoBoot"] = true
+	values["hookIsoURL"] = datacenterSpec.HookIsoURL
+}
+
 func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1alpha1.TinkerbellMachineConfigSpec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, workerTemplateOverride string) map[string]interface{} {
 	bundle := clusterSpec.VersionsBundle
 	format := "cloud-config"
 
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
-		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
-		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
+		Append(clusterapi.WorkerNodeLabelsWithOSUpdatesExtraArgs(clusterSpec.Cluster, workerNodeGroupConfiguration, workerNodeGroupMachineSpec.OSFamily)).
+		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
+		Append(clusterapi.KubeletServingCertificateExtraArgs(clusterSpec.Cluster)).
+		Append(clusterapi.ImageCredentialProviderExtraArgs(clusterSpec.Cluster))
 
 	values := map[string]interface{}{
-		"clusterName":            clusterSpec.Cluster.Name,
-		"eksaSystemNamespace":    constants.EksaSystemNamespace,
-		"kubeletExtraArgs":       kubeletExtraArgs.ToPartialYaml(),
-		"format":                 format,
-		"kubernetesVersion":      bundle.KubeDistro.Kubernetes.Tag,
-		"workerNodeGroupName":    workerNodeGroupConfiguration.Name,
-		"workerSshAuthorizedKey": workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys,
-		"workerSshUsername":      workerNodeGroupMachineSpec.Users[0].Name,
-		"hardwareSelector":       workerNodeGroupMachineSpec.HardwareSelector,
-		"workerNodeGroupTaints":  workerNodeGroupConfiguration.Taints,
-	}
+		"clusterName":             clusterSpec.Cluster.Name,
+		"eksaSystemNamespace":     constants.EksaSystemNamespace,
+		"kubeletExtraArgs":        kubeletExtraArgs.ToPartialYaml(),
+		"credentialProviderFiles": clusterapi.ImageCredentialProviderConfigFiles(clusterSpec.Cluster),
+		"format":                  format,
+		"kubernetesVersion":       bundle.KubeDistro.Kubernetes.Tag,
+		"workerNodeGroupName":     workerNodeGroupConfiguration.Name,
+		"workerSshAuthorizedKey":  workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys,
+		"workerSshUsername":       workerNodeGroupMachineSpec.Users[0].Name,
+		"hardwareSelector":        workerNodeGroupMachineSpec.HardwareSelector,
+		"workerNodeGroupTaints":   workerNodeGroupConfiguration.Taints,
+		"nodeIPCommands":          clusterapi.NodeIPCommands(workerNodeGroupMachineSpec.NetworkInterfaces),
+	}
+
+	setHostOSConfigValues(values, workerNodeGroupMachineSpec)
 
 	if workerNodeGroupMachineSpec.OSFamily == v1alpha1.Bottlerocket {
 		values["format"] = string(v1alpha1.Bottlerocket)
@@ -494,6 +595,40 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 }
 
 func omitTinkerbellMachineTemplate(inputSpec []byte) ([]byte, error) {
+	return omitResources(inputSpec, func(u unstructured.Unstructured) bool {
+		return u.GetKind() == TinkerbellMachineTemplateKind
+	})
+}
+
+// omitTinkerbellMachineTemplates removes the TinkerbellMachineTemplates with the given names from the spec.
+func omitTinkerbellMachineTemplates(inputSpec []byte, names map[string]struct{}) ([]byte, error) {
+	return omitResources(inputSpec, func(u unstructured.Unstructured) bool {
+		_, ok := names[u.GetName()]
+		return ok && u.GetKind() == TinkerbellMachineTemplateKind
+	})
+}
+
+// omitInPlaceTemplates removes the templates of the machines upgraded in place from the specs, so the
+// existing templates aren't updated with the images of the new bundle.
+func omitInPlaceTemplates(controlPlaneSpec, workersSpec []byte, names map[string]struct{}) ([]byte, []byte, error) {
+	if len(names) == 0 {
+		return controlPlaneSpec, workersSpec, nil
+	}
+
+	controlPlaneSpec, err := omitTinkerbellMachineTemplates(controlPlaneSpec, names)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workersSpec, err = omitTinkerbellMachineTemplates(workersSpec, names)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return controlPlaneSpec, workersSpec, nil
+}
+
+func omitResources(inputSpec []byte, omit func(unstructured.Unstructured) bool) ([]byte, error) {
 	var outSpec []unstructured.Unstructured
 	resources := strings.Split(string(inputSpec), "---")
 	for _, resource := range resources {
@@ -509,8 +644,7 @@ func omitTinkerbellMachineTemplate(inputSpec []byte) ([]byte, error) {
 		var u unstructured.Unstructured
 		u.SetUnstructuredContent(m)
 
-		// Omit TinkerbellMachineTemplate kind from deployment yaml
-		if u.GetKind() != "" && u.GetKind() != TinkerbellMachineTemplateKind {
+		if u.GetKind() != "" && !omit(u) {
 			outSpec = append(outSpec, u)
 		}
 	}
@@ -518,9 +652,11 @@ func omitTinkerbellMachineTemplate(inputSpec []byte) ([]byte, error) {
 }
 
 func populateRegistryMirrorValues(clusterSpec *cluster.Spec, values map[string]interface{}) map[string]interface{} {
-	values["registryMirrorConfiguration"] = net.JoinHostPort(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint, clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Port)
+	registryMirror := net.JoinHostPort(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint, clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Port)
+	values["registryMirrorConfiguration"] = registryMirror
 	if len(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent) > 0 {
 		values["registryCACert"] = clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent
+		values["registryCACertTrustCommand"] = clusterapi.RegistryMirrorCACertTrustCommand(registryMirror)
 	}
 
 	return values
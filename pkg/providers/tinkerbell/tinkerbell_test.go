@@ -8,8 +8,10 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -217,6 +219,111 @@ func TestTinkerbellProviderGenerateDeploymentFileWithStackedEtcd(t *testing.T) {
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
 }
 
+func TestTinkerbellProviderGenerateDeploymentFileWithEtcdEncryption(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Cluster.Spec.EtcdEncryption = []v1alpha1.EtcdEncryption{
+		{
+			Providers: []v1alpha1.EtcdEncryptionProvider{
+				{KMS: &v1alpha1.KMS{Name: "aws-encryption-provider", SocketListenAddress: "unix:///var/run/kmsplugin/socket.sock"}},
+			},
+		},
+	}
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_etcd_encryption.yaml")
+}
+
+func TestTinkerbellProviderGenerateDeploymentFileWithIsoBoot(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_iso_boot.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+	provider.hardwareCSVFile = "./testdata/hardware_redfish.csv"
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	for _, hw := range provider.catalogue.AllHardware() {
+		if *hw.Spec.Interfaces[0].Netboot.AllowPXE {
+			t.Fatalf("hardware %s allows PXE", hw.Name)
+		}
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_iso_boot.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md_iso_boot.yaml")
+}
+
+func TestTinkerbellProviderIsoBootWithoutRedfishBMCFails(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_iso_boot.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().CleanupLocalBoots(ctx, forceCleanup)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	assertError(t, "machine worker1: booting from ISO requires a BMC using the redfish protocol", err)
+}
+
 func TestTinkerbellProviderGenerateDeploymentFileWithAutoscalerConfiguration(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
@@ -386,6 +493,7 @@ func TestPreCAPIInstallOnBootstrapSuccess(t *testing.T) {
 		"",
 		gomock.Any(),
 		gomock.Any(),
+		gomock.Any(),
 	)
 
 	err := provider.PreCAPIInstallOnBootstrap(ctx, cluster, clusterSpec)
@@ -423,6 +531,7 @@ func TestPostWorkloadInitSuccess(t *testing.T) {
 		gomock.Any(),
 		gomock.Any(),
 		gomock.Any(),
+		gomock.Any(),
 	)
 	stackInstaller.EXPECT().UninstallLocal(ctx)
 
@@ -892,6 +1001,70 @@ func TestTinkerbellTemplate_isScaleUpDownSuccess(t *testing.T) {
 	assert.True(t, provider.isScaleUpDown(clusterSpec.Cluster, newClusterSpec.Cluster), "expected scale up down true")
 }
 
+func TestOmitInPlaceTemplates(t *testing.T) {
+	g := NewWithT(t)
+	controlPlaneSpec := []byte(`apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: TinkerbellMachineTemplate
+metadata:
+  name: test-control-plane-template-1
+---
+apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: test
+`)
+	workersSpec := []byte(`apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: TinkerbellMachineTemplate
+metadata:
+  name: test-md-0-1
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: TinkerbellMachineTemplate
+metadata:
+  name: test-md-1-2
+`)
+
+	cp, md, err := omitInPlaceTemplates(controlPlaneSpec, workersSpec, map[string]struct{}{
+		"test-control-plane-template-1": {},
+		"test-md-0-1":                   {},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(cp)).NotTo(ContainSubstring("TinkerbellMachineTemplate"))
+	g.Expect(string(cp)).To(ContainSubstring("KubeadmControlPlane"))
+	g.Expect(string(md)).NotTo(ContainSubstring("test-md-0-1"))
+	g.Expect(string(md)).To(ContainSubstring("test-md-1-2"))
+
+	cp, md, err = omitInPlaceTemplates(controlPlaneSpec, workersSpec, map[string]struct{}{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp).To(Equal(controlPlaneSpec))
+	g.Expect(md).To(Equal(workersSpec))
+}
+
+func TestSingleNodeRollingUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	currentSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = nil
+		s.Bundles.Spec.Number = 1
+		s.VersionsBundle.KubeDistro.Kubernetes.Tag = "v1.23.7-eks-1-23-4"
+	})
+	newSpec := currentSpec.DeepCopy()
+	newSpec.Bundles.Spec.Number = 2
+	newSpec.VersionsBundle.KubeDistro.Kubernetes.Tag = "v1.23.9-eks-1-23-5"
+	g.Expect(singleNodeRollingUpgrade(currentSpec, newSpec)).To(BeTrue())
+
+	newSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+		Type:          v1alpha1.InPlaceStrategyType,
+		RollingUpdate: v1alpha1.ControlPlaneRollingUpdateParams{MaxSurge: 1},
+	}
+	g.Expect(singleNodeRollingUpgrade(currentSpec, newSpec)).To(BeFalse())
+
+	newSpec = currentSpec.DeepCopy()
+	newSpec.Bundles.Spec.Number = 2
+	newSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+	g.Expect(singleNodeRollingUpgrade(currentSpec, newSpec)).To(BeFalse())
+}
+
 func TestSetupAndValidateCreateWorkloadClusterSuccess(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
 	mockCtrl := gomock.NewController(t)
@@ -1139,3 +1312,101 @@ func TestSetupAndValidateCreateWorkloadClusterErrorProvisionedHardware(t *testin
 	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
 	assertError(t, "retrieving provisioned hardware: error", err)
 }
+
+func TestProviderCleanupOrphanedResources(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpecManifest := "cluster_tinkerbell_single_node_skip_lb.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	managementCluster := &types.Cluster{Name: "management", KubeconfigFile: "management.kubeconfig"}
+
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, clusterSpecManifest))
+	g.Expect(err).NotTo(HaveOccurred())
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster = clusterConfig })
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, false)
+	provider.stackInstaller = stackInstaller
+
+	hardware := func(name, owner, ownerNamespace string) tinkv1alpha1.Hardware {
+		return tinkv1alpha1.Hardware{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				"v1alpha1.tinkerbell.org/ownerName":      owner,
+				"v1alpha1.tinkerbell.org/ownerNamespace": ownerNamespace,
+			},
+		}}
+	}
+	kubectl.EXPECT().GetTinkerbellMachineNames(ctx, managementCluster.KubeconfigFile, clusterSpec.Cluster.Name, constants.EksaSystemNamespace).
+		Return([]string{"single-node-control-plane-abc"}, nil)
+	kubectl.EXPECT().GetProvisionedTinkerbellHardware(ctx, managementCluster.KubeconfigFile, constants.EksaSystemNamespace).
+		Return([]tinkv1alpha1.Hardware{
+			hardware("hw1", "single-node-control-plane-abc", constants.EksaSystemNamespace),
+			hardware("hw2", "single-node-md-0-abc", constants.EksaSystemNamespace),
+			hardware("hw3", "single-node-control-plane-abc", "default"),
+		}, nil)
+	kubectl.EXPECT().ReleaseTinkerbellHardware(ctx, managementCluster.KubeconfigFile, "hw1", constants.EksaSystemNamespace)
+	stackInstaller.EXPECT().UninstallLocal(ctx)
+
+	g.Expect(provider.CleanupOrphanedResources(ctx, managementCluster, clusterSpec)).To(Succeed())
+}
+
+func TestProviderCleanupOrphanedResourcesNoMachines(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpecManifest := "cluster_tinkerbell_single_node_skip_lb.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	managementCluster := &types.Cluster{Name: "management", KubeconfigFile: "management.kubeconfig"}
+
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, clusterSpecManifest))
+	g.Expect(err).NotTo(HaveOccurred())
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster = clusterConfig })
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, false)
+	provider.stackInstaller = stackInstaller
+
+	kubectl.EXPECT().GetTinkerbellMachineNames(ctx, managementCluster.KubeconfigFile, clusterSpec.Cluster.Name, constants.EksaSystemNamespace).
+		Return(nil, nil)
+	stackInstaller.EXPECT().UninstallLocal(ctx)
+
+	g.Expect(provider.CleanupOrphanedResources(ctx, managementCluster, clusterSpec)).To(Succeed())
+}
+
+func TestProviderCleanupOrphanedResourcesSelfManaged(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpecManifest := "cluster_tinkerbell_single_node_skip_lb.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, clusterSpecManifest))
+	g.Expect(err).NotTo(HaveOccurred())
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster = clusterConfig })
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, false)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().UninstallLocal(ctx).Return(errors.New("docker not running"))
+
+	g.Expect(provider.CleanupOrphanedResources(ctx, nil, clusterSpec)).To(MatchError("docker not running"))
+}
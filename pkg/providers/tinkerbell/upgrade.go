@@ -8,6 +8,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -22,7 +23,8 @@ func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc
 	if oldSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host != newSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host {
 		return true
 	}
-	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
+	// The bundle only changes the images used to provision the machines, which are upgraded in place.
+	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number && !nodeupgrader.ControlPlaneUpgradesInPlace(oldSpec, newSpec) {
 		return true
 	}
 
@@ -73,7 +75,7 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 	// If we've been given a CSV with additional hardware for the cluster, validate it and
 	// write it to the catalogue so it can be used for further processing.
 	if p.hardwareCSVIsProvided() {
-		machineCatalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue)
+		machineCatalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue, p.hardwareWriterOptions()...)
 
 		writer := hardware.MultiMachineWriter(machineCatalogueWriter, &p.diskExtractor)
 
@@ -86,7 +88,7 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 		// Enabled Management Cluster that we're upgrading.
 		var selectors []v1alpha1.HardwareSelector
 
-		machineValidator := hardware.NewDefaultMachineValidator()
+		machineValidator := p.newMachineValidator()
 		machineValidator.Register(hardware.MatchingDisksForSelectors(selectors))
 
 		if err := hardware.TranslateAll(machines, writer, machineValidator); err != nil {
@@ -134,16 +136,18 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 		return err
 	}
 
-	return p.validateAvailableHardwareForUpgrade(ctx, currentClusterSpec, clusterSpec)
+	return p.validateAvailableHardwareForUpgrade(ctx, cluster, currentClusterSpec, clusterSpec)
 }
 
-func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, currentSpec, newClusterSpec *cluster.Spec) (err error) {
+func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, managementCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (err error) {
 	clusterSpecValidator := NewClusterSpecValidator(
 		HardwareSatisfiesOnlyOneSelectorAssertion(p.catalogue),
+		HardwareArchMatchesMachineConfigsAssertion(p.catalogue),
+		BMCSecretRefsExistAssertion(ctx, p.catalogue, p.providerKubectlClient, managementCluster),
 	)
 
 	rollingUpgrade := false
-	if currentSpec.Cluster.Spec.KubernetesVersion != newClusterSpec.Cluster.Spec.KubernetesVersion {
+	if currentSpec.Cluster.Spec.KubernetesVersion != newClusterSpec.Cluster.Spec.KubernetesVersion || singleNodeRollingUpgrade(currentSpec, newClusterSpec) {
 		clusterSpecValidator.Register(ExtraHardwareAvailableAssertionForRollingUpgrade(p.catalogue, maxSurgeForRollingUpgrade))
 		rollingUpgrade = true
 	}
@@ -159,6 +163,15 @@ func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, curr
 	return nil
 }
 
+// singleNodeRollingUpgrade returns true when the bundle upgrade of a single node cluster replaces its
+// machine. With no other node to surge into, even a patch upgrade needs spare hardware unless it's
+// done in place.
+func singleNodeRollingUpgrade(currentSpec, newSpec *cluster.Spec) bool {
+	return newSpec.Cluster.IsSingleNode() &&
+		currentSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number &&
+		!nodeupgrader.ControlPlaneUpgradesInPlace(currentSpec, newSpec)
+}
+
 func (p *Provider) PostBootstrapDeleteForUpgrade(ctx context.Context) error {
 	if err := p.stackInstaller.UninstallLocal(ctx); err != nil {
 		return err
@@ -253,6 +266,10 @@ func (p *Provider) ValidateNewSpec(ctx context.Context, cluster *types.Cluster,
 		return fmt.Errorf("spec.TinkerbellIP is immutable. Previous value %s,   New value %s", oSpec.TinkerbellIP, nSpec.TinkerbellIP)
 	}
 
+	if nSpec.IsoBoot != oSpec.IsoBoot {
+		return fmt.Errorf("spec.IsoBoot is immutable. Previous value %t,   New value %t", oSpec.IsoBoot, nSpec.IsoBoot)
+	}
+
 	// for any operation other than k8s version change, osImageURL and hookImageURL are immutable
 	if prevSpec.Spec.KubernetesVersion == clusterSpec.Cluster.Spec.KubernetesVersion {
 		if nSpec.OSImageURL != oSpec.OSImageURL {
@@ -339,6 +356,10 @@ func (p *Provider) validateMachineConfigImmutability(ctx context.Context, cluste
 		return fmt.Errorf("spec.HardwareSelector is immutable. Previous value %v,   New value %v", prevMachineConfig.Spec.HardwareSelector, newConfig.Spec.HardwareSelector)
 	}
 
+	if !newConfig.Spec.NetworkInterfaces.Equal(prevMachineConfig.Spec.NetworkInterfaces) {
+		return fmt.Errorf("spec.networkInterfaces is immutable. Previous value %v,   New value %v", prevMachineConfig.Spec.NetworkInterfaces, newConfig.Spec.NetworkInterfaces)
+	}
+
 	return nil
 }
 
@@ -3,6 +3,7 @@ package tinkerbell
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
@@ -26,6 +27,25 @@ func validateDatacenterConfig(config *v1alpha1.TinkerbellDatacenterConfig) error
 		return fmt.Errorf("TinkerbellDatacenterConfig: invalid tinkerbell ip: %v", err)
 	}
 
+	return validateDatacenterConfigIsoBoot(config)
+}
+
+func validateDatacenterConfigIsoBoot(config *v1alpha1.TinkerbellDatacenterConfig) error {
+	if !config.Spec.IsoBoot {
+		if config.Spec.HookIsoURL != "" {
+			return errors.New("TinkerbellDatacenterConfig: spec.hookIsoURL requires spec.isoBoot")
+		}
+		return nil
+	}
+
+	if config.Spec.HookIsoURL == "" {
+		return errors.New("TinkerbellDatacenterConfig: spec.isoBoot requires spec.hookIsoURL")
+	}
+
+	if _, err := url.ParseRequestURI(config.Spec.HookIsoURL); err != nil {
+		return fmt.Errorf("TinkerbellDatacenterConfig: parsing spec.hookIsoURL: %v", err)
+	}
+
 	return nil
 }
 
@@ -45,6 +65,41 @@ func validateMachineConfig(config *v1alpha1.TinkerbellMachineConfig) error {
 		)
 	}
 
+	if err := validateMachineConfigOSFamily(config); err != nil {
+		return err
+	}
+
+	if arch := config.Arch(); arch != v1alpha1.AMD64 && arch != v1alpha1.ARM64 {
+		return fmt.Errorf(
+			"TinkerbellMachineConfig: unsupported spec.architecture (%v); Please use one of the following: %s, %s",
+			arch,
+			v1alpha1.AMD64,
+			v1alpha1.ARM64,
+		)
+	}
+
+	if config.Spec.OSImageURL != "" {
+		if _, err := url.ParseRequestURI(config.Spec.OSImageURL); err != nil {
+			return fmt.Errorf("TinkerbellMachineConfig: parsing spec.osImageURL: %v", err)
+		}
+	}
+
+	return validateMachineConfigHostOS(config)
+}
+
+func validateMachineConfigHostOS(config *v1alpha1.TinkerbellMachineConfig) error {
+	if err := v1alpha1.ValidateHostOSConfiguration(config.Spec.HostOSConfiguration, config.Spec.OSFamily); err != nil {
+		return fmt.Errorf("TinkerbellMachineConfig: %v: %v", err, config.Name)
+	}
+
+	if err := v1alpha1.ValidateNodeNetworkInterfaces(config.Spec.NetworkInterfaces, config.Spec.OSFamily); err != nil {
+		return fmt.Errorf("TinkerbellMachineConfig: %v: %v", err, config.Name)
+	}
+
+	return nil
+}
+
+func validateMachineConfigOSFamily(config *v1alpha1.TinkerbellMachineConfig) error {
 	if config.Spec.OSFamily == "" {
 		return fmt.Errorf("TinkerbellMachineConfig: missing spec.osFamily: %v", config.Name)
 	}
@@ -80,8 +135,13 @@ func validateOsFamily(spec *ClusterSpec) error {
 		}
 	}
 
-	if controlPlaneOsFamily != v1alpha1.Bottlerocket && spec.DatacenterConfig.Spec.OSImageURL == "" {
-		return fmt.Errorf("please use bottlerocket as osFamily for auto-importing or provide a valid osImageURL")
+	if controlPlaneOsFamily == v1alpha1.Bottlerocket {
+		return nil
+	}
+	for _, config := range spec.machineConfigsInUse() {
+		if osImageURL(&config.Spec, &spec.DatacenterConfig.Spec) == "" {
+			return fmt.Errorf("please use bottlerocket as osFamily for auto-importing or provide a valid osImageURL")
+		}
 	}
 	return nil
 }
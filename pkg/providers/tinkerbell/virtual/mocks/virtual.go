@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/providers/tinkerbell/virtual/virtual.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVirsh is a mock of Virsh interface.
+type MockVirsh struct {
+	ctrl     *gomock.Controller
+	recorder *MockVirshMockRecorder
+}
+
+// MockVirshMockRecorder is the mock recorder for MockVirsh.
+type MockVirshMockRecorder struct {
+	mock *MockVirsh
+}
+
+// NewMockVirsh creates a new mock instance.
+func NewMockVirsh(ctrl *gomock.Controller) *MockVirsh {
+	mock := &MockVirsh{ctrl: ctrl}
+	mock.recorder = &MockVirshMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVirsh) EXPECT() *MockVirshMockRecorder {
+	return m.recorder
+}
+
+// CreateVolume mocks base method.
+func (m *MockVirsh) CreateVolume(ctx context.Context, pool, name string, capacityGiB int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVolume", ctx, pool, name, capacityGiB)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVolume indicates an expected call of CreateVolume.
+func (mr *MockVirshMockRecorder) CreateVolume(ctx, pool, name, capacityGiB interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVolume", reflect.TypeOf((*MockVirsh)(nil).CreateVolume), ctx, pool, name, capacityGiB)
+}
+
+// DefineDomain mocks base method.
+func (m *MockVirsh) DefineDomain(ctx context.Context, domainXML []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DefineDomain", ctx, domainXML)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DefineDomain indicates an expected call of DefineDomain.
+func (mr *MockVirshMockRecorder) DefineDomain(ctx, domainXML interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefineDomain", reflect.TypeOf((*MockVirsh)(nil).DefineDomain), ctx, domainXML)
+}
+
+// DeleteDomain mocks base method.
+func (m *MockVirsh) DeleteDomain(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDomain", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDomain indicates an expected call of DeleteDomain.
+func (mr *MockVirshMockRecorder) DeleteDomain(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDomain", reflect.TypeOf((*MockVirsh)(nil).DeleteDomain), ctx, name)
+}
+
+// MockVirtualBMC is a mock of VirtualBMC interface.
+type MockVirtualBMC struct {
+	ctrl     *gomock.Controller
+	recorder *MockVirtualBMCMockRecorder
+}
+
+// MockVirtualBMCMockRecorder is the mock recorder for MockVirtualBMC.
+type MockVirtualBMCMockRecorder struct {
+	mock *MockVirtualBMC
+}
+
+// NewMockVirtualBMC creates a new mock instance.
+func NewMockVirtualBMC(ctrl *gomock.Controller) *MockVirtualBMC {
+	mock := &MockVirtualBMC{ctrl: ctrl}
+	mock.recorder = &MockVirtualBMCMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVirtualBMC) EXPECT() *MockVirtualBMCMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockVirtualBMC) Add(ctx context.Context, domain, address string, port int, username, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, domain, address, port, username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockVirtualBMCMockRecorder) Add(ctx, domain, address, port, username, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockVirtualBMC)(nil).Add), ctx, domain, address, port, username, password)
+}
+
+// Delete mocks base method.
+func (m *MockVirtualBMC) Delete(ctx context.Context, domain string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVirtualBMCMockRecorder) Delete(ctx, domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVirtualBMC)(nil).Delete), ctx, domain)
+}
+
+// Start mocks base method.
+func (m *MockVirtualBMC) Start(ctx context.Context, domain string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockVirtualBMCMockRecorder) Start(ctx, domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockVirtualBMC)(nil).Start), ctx, domain)
+}
@@ -0,0 +1,254 @@
+// Package virtual simulates bare metal hardware with libvirt domains controlled through virtual BMCs, so the
+// Tinkerbell provider can be exercised without physical machines.
+package virtual
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"hash/fnv"
+	"net"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+//go:embed config/domain.xml
+var domainTemplate string
+
+// Disk is the disk the simulated machines expose to the Tinkerbell workflows.
+const Disk = "/dev/vda"
+
+// Virsh manages the libvirt domains backing the simulated machines.
+type Virsh interface {
+	CreateVolume(ctx context.Context, pool, name string, capacityGiB int) error
+	DefineDomain(ctx context.Context, domainXML []byte) error
+	DeleteDomain(ctx context.Context, name string) error
+}
+
+// VirtualBMC manages the IPMI endpoints Rufio uses to control the simulated machines.
+type VirtualBMC interface {
+	Add(ctx context.Context, domain, address string, port int, username, password string) error
+	Start(ctx context.Context, domain string) error
+	Delete(ctx context.Context, domain string) error
+}
+
+// Config describes a set of simulated machines.
+type Config struct {
+	// NamePrefix is used to name the domains and hostnames, <NamePrefix>-<index>.
+	NamePrefix string
+	// ControlPlaneCount and WorkerCount are the number of machines labeled type=cp and type=worker.
+	ControlPlaneCount int
+	WorkerCount       int
+
+	CPUs        int
+	MemoryMiB   int
+	DiskGiB     int
+	Arch        string
+	Network     string
+	StoragePool string
+
+	// StartIPAddress is the address of the first machine, the rest get the following addresses.
+	// The libvirt network must not serve DHCP for them, Tinkerbell does.
+	StartIPAddress string
+	Netmask        string
+	Gateway        string
+	Nameservers    []string
+
+	// BMCAddress is the host address the virtual BMCs listen on. It must be reachable from the Rufio controller.
+	BMCAddress string
+	// BMCStartPort is the IPMI port of the first machine's BMC, the rest get the following ports.
+	BMCStartPort int
+	BMCUsername  string
+	BMCPassword  string
+}
+
+// Machine type labels matching the hardware selectors used in the cluster spec examples.
+const (
+	ControlPlaneLabel = "cp"
+	WorkerLabel       = "worker"
+)
+
+// Validate checks c describes a set of machines that can be simulated.
+func (c Config) Validate() error {
+	if c.NamePrefix == "" {
+		return fmt.Errorf("name prefix is required")
+	}
+	if c.ControlPlaneCount+c.WorkerCount < 1 {
+		return fmt.Errorf("at least one machine is required")
+	}
+	if c.Arch != hardware.X86_64 && c.Arch != hardware.AArch64 {
+		return fmt.Errorf("arch must be one of %s, %s", hardware.X86_64, hardware.AArch64)
+	}
+	if net.ParseIP(c.StartIPAddress).To4() == nil {
+		return fmt.Errorf("start ip address must be a valid IPv4 address: %s", c.StartIPAddress)
+	}
+	for _, ip := range []struct{ name, value string }{{"gateway", c.Gateway}, {"bmc address", c.BMCAddress}} {
+		if err := networkutils.ValidateIP(ip.value); err != nil {
+			return fmt.Errorf("%s: %v", ip.name, err)
+		}
+	}
+	if c.BMCUsername == "" || c.BMCPassword == "" {
+		return fmt.Errorf("bmc username and password are required")
+	}
+	return nil
+}
+
+// Simulator creates and deletes simulated machines.
+type Simulator struct {
+	virsh  Virsh
+	bmc    VirtualBMC
+	config Config
+}
+
+// NewSimulator returns a Simulator for the machines described by config.
+func NewSimulator(virsh Virsh, bmc VirtualBMC, config Config) *Simulator {
+	return &Simulator{virsh: virsh, bmc: bmc, config: config}
+}
+
+// Machines returns the hardware entries for the simulated machines, ready to be written to a hardware CSV.
+func (s *Simulator) Machines() ([]hardware.Machine, error) {
+	if err := s.config.Validate(); err != nil {
+		return nil, err
+	}
+
+	total := s.config.ControlPlaneCount + s.config.WorkerCount
+	machines := make([]hardware.Machine, 0, total)
+	ip := net.ParseIP(s.config.StartIPAddress).To4()
+	for i := 0; i < total; i++ {
+		machineType := WorkerLabel
+		if i < s.config.ControlPlaneCount {
+			machineType = ControlPlaneLabel
+		}
+
+		name := s.name(i)
+		machines = append(machines, hardware.Machine{
+			Hostname:     name,
+			IPAddress:    ip.String(),
+			Netmask:      s.config.Netmask,
+			Gateway:      s.config.Gateway,
+			Nameservers:  s.config.Nameservers,
+			MACAddress:   macAddress(name),
+			Disk:         Disk,
+			Labels:       hardware.Labels{"type": machineType},
+			BMCIPAddress: s.config.BMCAddress,
+			BMCPort:      s.config.BMCStartPort + i,
+			BMCUsername:  s.config.BMCUsername,
+			BMCPassword:  s.config.BMCPassword,
+			Arch:         s.config.Arch,
+		})
+		ip = nextIP(ip)
+	}
+
+	return machines, nil
+}
+
+// Create defines a powered off libvirt domain and starts a virtual BMC for each simulated machine.
+// Rufio powers the domains on when Tinkerbell provisions them.
+func (s *Simulator) Create(ctx context.Context) ([]hardware.Machine, error) {
+	machines, err := s.Machines()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range machines {
+		logger.V(3).Info("Creating virtual machine", "name", m.Hostname, "bmcPort", m.BMCPort)
+		if err := s.createMachine(ctx, m); err != nil {
+			return nil, fmt.Errorf("creating virtual machine %s: %v", m.Hostname, err)
+		}
+	}
+
+	return machines, nil
+}
+
+// Delete removes the virtual BMCs and libvirt domains of the simulated machines, including their disks.
+// Machines that don't exist are skipped.
+func (s *Simulator) Delete(ctx context.Context) error {
+	machines, err := s.Machines()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range machines {
+		logger.V(3).Info("Deleting virtual machine", "name", m.Hostname)
+		if err := s.bmc.Delete(ctx, m.Hostname); err != nil {
+			return err
+		}
+		if err := s.virsh.DeleteDomain(ctx, m.Hostname); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Simulator) createMachine(ctx context.Context, m hardware.Machine) error {
+	volume := m.Hostname + ".qcow2"
+	if err := s.virsh.CreateVolume(ctx, s.config.StoragePool, volume, s.config.DiskGiB); err != nil {
+		return err
+	}
+
+	domain, err := s.domainXML(m, volume)
+	if err != nil {
+		return err
+	}
+
+	if err := s.virsh.DefineDomain(ctx, domain); err != nil {
+		return err
+	}
+
+	if err := s.bmc.Add(ctx, m.Hostname, m.BMCIPAddress, m.BMCPort, m.BMCUsername, m.BMCPassword); err != nil {
+		return err
+	}
+
+	return s.bmc.Start(ctx, m.Hostname)
+}
+
+func (s *Simulator) domainXML(m hardware.Machine, volume string) ([]byte, error) {
+	values := map[string]interface{}{
+		"Name":        m.Hostname,
+		"CPUs":        s.config.CPUs,
+		"MemoryMiB":   s.config.MemoryMiB,
+		"Arch":        s.config.Arch,
+		"Machine":     "q35",
+		"UEFI":        false,
+		"StoragePool": s.config.StoragePool,
+		"Volume":      volume,
+		"Network":     s.config.Network,
+		"MACAddress":  m.MACAddress,
+	}
+	// ARM guests have no BIOS, they boot through UEFI on the generic virt machine.
+	if s.config.Arch == hardware.AArch64 {
+		values["Machine"] = "virt"
+		values["UEFI"] = true
+	}
+
+	return templater.Execute(domainTemplate, values)
+}
+
+func (s *Simulator) name(index int) string {
+	return fmt.Sprintf("%s-%d", s.config.NamePrefix, index)
+}
+
+// macAddress derives a stable, locally administered MAC address in the QEMU range from name so recreating a
+// machine keeps its hardware identity.
+func macAddress(name string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	sum := h.Sum32()
+	return fmt.Sprintf("52:54:00:%02x:%02x:%02x", byte(sum>>16), byte(sum>>8), byte(sum))
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
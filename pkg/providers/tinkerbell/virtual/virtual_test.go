@@ -0,0 +1,198 @@
+package virtual_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/virtual"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/virtual/mocks"
+)
+
+type simulatorTest struct {
+	*WithT
+	ctx   context.Context
+	virsh *mocks.MockVirsh
+	bmc   *mocks.MockVirtualBMC
+}
+
+func newSimulatorTest(t *testing.T) *simulatorTest {
+	ctrl := gomock.NewController(t)
+	return &simulatorTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		virsh: mocks.NewMockVirsh(ctrl),
+		bmc:   mocks.NewMockVirtualBMC(ctrl),
+	}
+}
+
+func validConfig() virtual.Config {
+	return virtual.Config{
+		NamePrefix:        "eksa-virtual",
+		ControlPlaneCount: 1,
+		WorkerCount:       1,
+		CPUs:              2,
+		MemoryMiB:         4096,
+		DiskGiB:           40,
+		Arch:              hardware.X86_64,
+		Network:           "tinkerbell",
+		StoragePool:       "default",
+		StartIPAddress:    "10.80.0.255",
+		Netmask:           "255.255.0.0",
+		Gateway:           "10.80.0.1",
+		Nameservers:       []string{"1.1.1.1"},
+		BMCAddress:        "10.80.0.1",
+		BMCStartPort:      6230,
+		BMCUsername:       "admin",
+		BMCPassword:       "password",
+	}
+}
+
+func TestSimulatorMachines(t *testing.T) {
+	tt := newSimulatorTest(t)
+	s := virtual.NewSimulator(tt.virsh, tt.bmc, validConfig())
+
+	machines, err := s.Machines()
+	tt.Expect(err).To(Succeed())
+	tt.Expect(machines).To(HaveLen(2))
+
+	tt.Expect(machines[0].Hostname).To(Equal("eksa-virtual-0"))
+	tt.Expect(machines[0].IPAddress).To(Equal("10.80.0.255"))
+	tt.Expect(machines[0].Labels).To(Equal(hardware.Labels{"type": "cp"}))
+	tt.Expect(machines[0].BMCPort).To(Equal(6230))
+	tt.Expect(machines[0].Disk).To(Equal("/dev/vda"))
+	tt.Expect(machines[0].MACAddress).To(HavePrefix("52:54:00:"))
+
+	tt.Expect(machines[1].Hostname).To(Equal("eksa-virtual-1"))
+	tt.Expect(machines[1].IPAddress).To(Equal("10.80.1.0"))
+	tt.Expect(machines[1].Labels).To(Equal(hardware.Labels{"type": "worker"}))
+	tt.Expect(machines[1].BMCPort).To(Equal(6231))
+	tt.Expect(machines[1].MACAddress).ToNot(Equal(machines[0].MACAddress))
+
+	validator := hardware.NewDefaultMachineValidator()
+	for _, m := range machines {
+		tt.Expect(validator.Validate(m)).To(Succeed())
+	}
+}
+
+func TestSimulatorMachinesStableMACAddresses(t *testing.T) {
+	tt := newSimulatorTest(t)
+	first, err := virtual.NewSimulator(tt.virsh, tt.bmc, validConfig()).Machines()
+	tt.Expect(err).To(Succeed())
+	second, err := virtual.NewSimulator(tt.virsh, tt.bmc, validConfig()).Machines()
+	tt.Expect(err).To(Succeed())
+
+	tt.Expect(second[0].MACAddress).To(Equal(first[0].MACAddress))
+}
+
+func TestConfigValidateErrors(t *testing.T) {
+	tests := map[string]struct {
+		mutate  func(*virtual.Config)
+		wantErr string
+	}{
+		"no prefix":    {mutate: func(c *virtual.Config) { c.NamePrefix = "" }, wantErr: "name prefix is required"},
+		"no machines":  {mutate: func(c *virtual.Config) { c.ControlPlaneCount, c.WorkerCount = 0, 0 }, wantErr: "at least one machine"},
+		"bad arch":     {mutate: func(c *virtual.Config) { c.Arch = "ppc64le" }, wantErr: "arch must be one of"},
+		"bad start ip": {mutate: func(c *virtual.Config) { c.StartIPAddress = "fd00::1" }, wantErr: "start ip address"},
+		"no gateway":   {mutate: func(c *virtual.Config) { c.Gateway = "" }, wantErr: "gateway: is required"},
+		"no bmc ip":    {mutate: func(c *virtual.Config) { c.BMCAddress = "" }, wantErr: "bmc address: is required"},
+		"no bmc creds": {mutate: func(c *virtual.Config) { c.BMCPassword = "" }, wantErr: "bmc username and password"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := validConfig()
+			tc.mutate(&c)
+			g.Expect(c.Validate()).To(MatchError(ContainSubstring(tc.wantErr)))
+		})
+	}
+}
+
+func TestSimulatorCreate(t *testing.T) {
+	tt := newSimulatorTest(t)
+	config := validConfig()
+	config.WorkerCount = 0
+	s := virtual.NewSimulator(tt.virsh, tt.bmc, config)
+
+	gomock.InOrder(
+		tt.virsh.EXPECT().CreateVolume(tt.ctx, "default", "eksa-virtual-0.qcow2", 40),
+		tt.virsh.EXPECT().DefineDomain(tt.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, domain []byte) error {
+			tt.Expect(string(domain)).To(ContainSubstring("<name>eksa-virtual-0</name>"))
+			tt.Expect(string(domain)).To(ContainSubstring(`<source network="tinkerbell"/>`))
+			tt.Expect(string(domain)).To(ContainSubstring(`<source pool="default" volume="eksa-virtual-0.qcow2"/>`))
+			tt.Expect(string(domain)).To(ContainSubstring(`machine="q35"`))
+			tt.Expect(string(domain)).ToNot(ContainSubstring(`firmware="efi"`))
+			return nil
+		}),
+		tt.bmc.EXPECT().Add(tt.ctx, "eksa-virtual-0", "10.80.0.1", 6230, "admin", "password"),
+		tt.bmc.EXPECT().Start(tt.ctx, "eksa-virtual-0"),
+	)
+
+	machines, err := s.Create(tt.ctx)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(machines).To(HaveLen(1))
+}
+
+func TestSimulatorCreateAArch64(t *testing.T) {
+	tt := newSimulatorTest(t)
+	config := validConfig()
+	config.WorkerCount = 0
+	config.Arch = hardware.AArch64
+	s := virtual.NewSimulator(tt.virsh, tt.bmc, config)
+
+	tt.virsh.EXPECT().CreateVolume(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any())
+	tt.virsh.EXPECT().DefineDomain(tt.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, domain []byte) error {
+		tt.Expect(string(domain)).To(ContainSubstring(`<type arch="aarch64" machine="virt">hvm</type>`))
+		tt.Expect(string(domain)).To(ContainSubstring(`firmware="efi"`))
+		return nil
+	})
+	tt.bmc.EXPECT().Add(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	tt.bmc.EXPECT().Start(tt.ctx, gomock.Any())
+
+	_, err := s.Create(tt.ctx)
+	tt.Expect(err).To(Succeed())
+}
+
+func TestSimulatorCreateError(t *testing.T) {
+	tt := newSimulatorTest(t)
+	s := virtual.NewSimulator(tt.virsh, tt.bmc, validConfig())
+	tt.virsh.EXPECT().CreateVolume(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("pool is full"))
+
+	_, err := s.Create(tt.ctx)
+	tt.Expect(err).To(MatchError("creating virtual machine eksa-virtual-0: pool is full"))
+}
+
+func TestSimulatorCreateInvalidConfig(t *testing.T) {
+	tt := newSimulatorTest(t)
+	config := validConfig()
+	config.NamePrefix = ""
+
+	_, err := virtual.NewSimulator(tt.virsh, tt.bmc, config).Create(tt.ctx)
+	tt.Expect(err).To(HaveOccurred())
+}
+
+func TestSimulatorDelete(t *testing.T) {
+	tt := newSimulatorTest(t)
+	s := virtual.NewSimulator(tt.virsh, tt.bmc, validConfig())
+	gomock.InOrder(
+		tt.bmc.EXPECT().Delete(tt.ctx, "eksa-virtual-0"),
+		tt.virsh.EXPECT().DeleteDomain(tt.ctx, "eksa-virtual-0"),
+		tt.bmc.EXPECT().Delete(tt.ctx, "eksa-virtual-1"),
+		tt.virsh.EXPECT().DeleteDomain(tt.ctx, "eksa-virtual-1"),
+	)
+
+	tt.Expect(s.Delete(tt.ctx)).To(Succeed())
+}
+
+func TestSimulatorDeleteError(t *testing.T) {
+	tt := newSimulatorTest(t)
+	s := virtual.NewSimulator(tt.virsh, tt.bmc, validConfig())
+	tt.bmc.EXPECT().Delete(tt.ctx, "eksa-virtual-0")
+	tt.virsh.EXPECT().DeleteDomain(tt.ctx, "eksa-virtual-0").Return(errors.New("permission denied"))
+
+	tt.Expect(s.Delete(tt.ctx)).To(MatchError("permission denied"))
+}
@@ -0,0 +1,39 @@
+package tinkerbell
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+)
+
+func TestWorkersSpecNewCluster(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := givenClusterSpec(t, "cluster_tinkerbell_multiple_node_groups.yaml")
+	client := test.NewFakeKubeClient()
+
+	workers, err := WorkersSpec(ctx, logger, client, spec, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers).NotTo(BeNil())
+	g.Expect(workers.Groups).To(HaveLen(2))
+	names := make([]string, 0, len(workers.Groups))
+	for _, group := range workers.Groups {
+		g.Expect(group.MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name).To(Equal(group.ProviderMachineTemplate.GetName()))
+		g.Expect(group.MachineDeployment.Spec.Template.Spec.Bootstrap.ConfigRef.Name).To(Equal(group.KubeadmConfigTemplate.Name))
+		names = append(names, group.ProviderMachineTemplate.GetName())
+	}
+	g.Expect(names).To(ConsistOf("test-md-0-1", "test-md-1-1"))
+}
+
+func TestWorkersSpecDefaultTemplateNoHardware(t *testing.T) {
+	g := NewWithT(t)
+	spec := givenClusterSpec(t, "cluster_tinkerbell_multiple_node_groups.yaml")
+	spec.TinkerbellTemplateConfigs = nil
+
+	_, err := WorkersSpec(context.Background(), test.NewNullLogger(), test.NewFakeKubeClient(), spec, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("getting worker node disk type of the hardware selector")))
+}
@@ -0,0 +1,139 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// ClusterTagsGovcClient manages the vCenter tags of a cluster.
+type ClusterTagsGovcClient interface {
+	ListCategories(ctx context.Context) ([]string, error)
+	CreateCategoryForVM(ctx context.Context, name string) error
+	ListCategoryTagIDs(ctx context.Context, category string) (map[string]string, error)
+	CreateTag(ctx context.Context, tag, category string) error
+}
+
+// ClusterTagger creates the vCenter tags of a cluster so CAPV can attach them to the VMs of the cluster,
+// including the tag that identifies the VMs of the cluster.
+type ClusterTagger struct {
+	govc ClusterTagsGovcClient
+}
+
+// NewClusterTagger builds a ClusterTagger.
+func NewClusterTagger(govc ClusterTagsGovcClient) *ClusterTagger {
+	return &ClusterTagger{govc: govc}
+}
+
+// TagIDs returns the IDs of the tags of the cluster spec followed by the ID of the cluster tag,
+// creating the tag categories and tags that don't exist yet. The IDs follow the order of their
+// categories, so the machine templates rendered with them only change when the tags do.
+func (t *ClusterTagger) TagIDs(ctx context.Context, clusterSpec *cluster.Spec) ([]string, error) {
+	tags := clusterSpec.Cluster.Spec.Tags
+
+	categories, err := t.govc.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing tag categories: %v", err)
+	}
+
+	categoriesLookup := types.SliceToLookup(categories)
+	tagIDs := make([]string, 0, len(tags)+1)
+	for _, category := range sortedKeys(tags) {
+		id, err := t.ensureCategoryTag(ctx, categoriesLookup, category, tags[category])
+		if err != nil {
+			return nil, err
+		}
+		tagIDs = append(tagIDs, id)
+	}
+
+	id, err := t.ensureCategoryTag(ctx, categoriesLookup, constants.VSphereClusterTagCategory, clusterTagName(clusterSpec.Cluster))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(tagIDs, id), nil
+}
+
+// ClusterTagID returns the ID of the tag attached to every VM of cluster, or false if the tag doesn't exist.
+func (t *ClusterTagger) ClusterTagID(ctx context.Context, cluster *v1alpha1.Cluster) (string, bool, error) {
+	categories, err := t.govc.ListCategories(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("listing tag categories: %v", err)
+	}
+	if !types.SliceToLookup(categories).IsPresent(constants.VSphereClusterTagCategory) {
+		return "", false, nil
+	}
+
+	tagIDs, err := t.govc.ListCategoryTagIDs(ctx, constants.VSphereClusterTagCategory)
+	if err != nil {
+		return "", false, err
+	}
+	id, ok := tagIDs[clusterTagName(cluster)]
+
+	return id, ok, nil
+}
+
+func (t *ClusterTagger) ensureCategoryTag(ctx context.Context, categoriesLookup types.Lookup, category, tag string) (string, error) {
+	if !categoriesLookup.IsPresent(category) {
+		logger.V(3).Info("Creating tag category", "category", category)
+		if err := t.govc.CreateCategoryForVM(ctx, category); err != nil {
+			return "", err
+		}
+		categoriesLookup[category] = struct{}{}
+	}
+
+	return t.ensureTag(ctx, category, tag)
+}
+
+func (t *ClusterTagger) ensureTag(ctx context.Context, category, tag string) (string, error) {
+	tagIDs, err := t.govc.ListCategoryTagIDs(ctx, category)
+	if err != nil {
+		return "", err
+	}
+	if id, ok := tagIDs[tag]; ok {
+		return id, nil
+	}
+
+	logger.V(3).Info("Creating tag", "category", category, "tag", tag)
+	if err := t.govc.CreateTag(ctx, tag, category); err != nil {
+		return "", err
+	}
+
+	tagIDs, err = t.govc.ListCategoryTagIDs(ctx, category)
+	if err != nil {
+		return "", err
+	}
+	id, ok := tagIDs[tag]
+	if !ok {
+		return "", fmt.Errorf("tag %s of category %s not found after creating it", tag, category)
+	}
+
+	return id, nil
+}
+
+// clusterTagName returns the name of the tag that identifies the VMs of cluster. It includes the name of
+// the management cluster, so clusters with the same name managed from different management clusters in
+// the same vCenter don't share it.
+func clusterTagName(cluster *v1alpha1.Cluster) string {
+	managementClusterName := cluster.ManagedBy()
+	if cluster.IsSelfManaged() {
+		managementClusterName = cluster.Name
+	}
+
+	return fmt.Sprintf("%s:%s:%s", constants.VSphereClusterTagCategory, managementClusterName, cluster.Name)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
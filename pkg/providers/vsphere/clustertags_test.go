@@ -0,0 +1,130 @@
+package vsphere_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+)
+
+func TestClusterTaggerTagIDs(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+	spec := givenClusterSpec(t, testClusterConfigMainFilename)
+	spec.Cluster.Spec.Tags = map[string]string{"environment": "prod", "cost-center": "platform"}
+
+	govc.EXPECT().ListCategories(ctx).Return([]string{"cost-center", "eksaCluster"}, nil)
+	govc.EXPECT().ListCategoryTagIDs(ctx, "cost-center").Return(map[string]string{
+		"platform": "urn:vmomi:InventoryServiceTag:1:GLOBAL",
+		"storage":  "urn:vmomi:InventoryServiceTag:2:GLOBAL",
+	}, nil)
+	govc.EXPECT().CreateCategoryForVM(ctx, "environment")
+	gomock.InOrder(
+		govc.EXPECT().ListCategoryTagIDs(ctx, "environment").Return(nil, nil),
+		govc.EXPECT().CreateTag(ctx, "prod", "environment"),
+		govc.EXPECT().ListCategoryTagIDs(ctx, "environment").Return(map[string]string{
+			"prod": "urn:vmomi:InventoryServiceTag:3:GLOBAL",
+		}, nil),
+	)
+	govc.EXPECT().ListCategoryTagIDs(ctx, "eksaCluster").Return(map[string]string{
+		"eksaCluster:test:test": "urn:vmomi:InventoryServiceTag:4:GLOBAL",
+	}, nil)
+
+	tagIDs, err := vsphere.NewClusterTagger(govc).TagIDs(ctx, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tagIDs).To(Equal([]string{
+		"urn:vmomi:InventoryServiceTag:1:GLOBAL",
+		"urn:vmomi:InventoryServiceTag:3:GLOBAL",
+		"urn:vmomi:InventoryServiceTag:4:GLOBAL",
+	}))
+}
+
+func TestClusterTaggerTagIDsNoTags(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+	spec := givenClusterSpec(t, testClusterConfigMainFilename)
+
+	govc.EXPECT().ListCategories(ctx).Return(nil, nil)
+	govc.EXPECT().CreateCategoryForVM(ctx, "eksaCluster")
+	gomock.InOrder(
+		govc.EXPECT().ListCategoryTagIDs(ctx, "eksaCluster").Return(nil, nil),
+		govc.EXPECT().CreateTag(ctx, "eksaCluster:test:test", "eksaCluster"),
+		govc.EXPECT().ListCategoryTagIDs(ctx, "eksaCluster").Return(map[string]string{
+			"eksaCluster:test:test": "urn:vmomi:InventoryServiceTag:1:GLOBAL",
+		}, nil),
+	)
+
+	g.Expect(vsphere.NewClusterTagger(govc).TagIDs(ctx, spec)).To(Equal([]string{"urn:vmomi:InventoryServiceTag:1:GLOBAL"}))
+}
+
+func TestClusterTaggerClusterTagID(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+
+	cluster := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	cluster.SetManagedBy("management")
+
+	govc.EXPECT().ListCategories(ctx).Return([]string{"eksaCluster"}, nil)
+	govc.EXPECT().ListCategoryTagIDs(ctx, "eksaCluster").Return(map[string]string{
+		"eksaCluster:management-2:test": "urn:vmomi:InventoryServiceTag:1:GLOBAL",
+		"eksaCluster:management:test":   "urn:vmomi:InventoryServiceTag:2:GLOBAL",
+		"eksaCluster:test:test":         "urn:vmomi:InventoryServiceTag:3:GLOBAL",
+	}, nil)
+
+	id, ok, err := vsphere.NewClusterTagger(govc).ClusterTagID(ctx, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(id).To(Equal("urn:vmomi:InventoryServiceTag:2:GLOBAL"))
+}
+
+func TestClusterTaggerClusterTagIDNoCategory(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+
+	govc.EXPECT().ListCategories(ctx).Return([]string{"os"}, nil)
+
+	_, ok, err := vsphere.NewClusterTagger(govc).ClusterTagID(ctx, &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestClusterTaggerTagIDsErrorCreatingTag(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+	spec := givenClusterSpec(t, testClusterConfigMainFilename)
+	spec.Cluster.Spec.Tags = map[string]string{"environment": "prod"}
+
+	govc.EXPECT().ListCategories(ctx).Return([]string{"environment"}, nil)
+	govc.EXPECT().ListCategoryTagIDs(ctx, "environment").Return(nil, nil)
+	govc.EXPECT().CreateTag(ctx, "prod", "environment").Return(errors.New("permission denied"))
+
+	_, err := vsphere.NewClusterTagger(govc).TagIDs(ctx, spec)
+	g.Expect(err).To(MatchError("permission denied"))
+}
+
+func TestClusterTaggerTagIDsTagMissingAfterCreate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+	spec := givenClusterSpec(t, testClusterConfigMainFilename)
+	spec.Cluster.Spec.Tags = map[string]string{"environment": "prod"}
+
+	govc.EXPECT().ListCategories(ctx).Return([]string{"environment"}, nil)
+	govc.EXPECT().ListCategoryTagIDs(ctx, "environment").Return(nil, nil).Times(2)
+	govc.EXPECT().CreateTag(ctx, "prod", "environment")
+
+	_, err := vsphere.NewClusterTagger(govc).TagIDs(ctx, spec)
+	g.Expect(err).To(MatchError("tag prod of category environment not found after creating it"))
+}
@@ -0,0 +1,68 @@
+package vsphere
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/conformance"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestProviderConformance(t *testing.T) {
+	conformance.Run(t, conformance.Subject{
+		New: func(t *testing.T) (providers.Provider, *cluster.Spec) {
+			setupContext(t)
+			spec := givenClusterSpec(t, testClusterConfigMainFilename)
+			kubectl := mocks.NewMockProviderKubectlClient(gomock.NewController(t))
+			kubectl.EXPECT().GetEksaVSphereDatacenterConfig(gomock.Any(), spec.VSphereDatacenter.Name, gomock.Any(), gomock.Any()).
+				Return(spec.VSphereDatacenter.DeepCopy(), nil).AnyTimes()
+			for name, m := range spec.VSphereMachineConfigs {
+				kubectl.EXPECT().GetEksaVSphereMachineConfig(gomock.Any(), name, gomock.Any(), gomock.Any()).
+					Return(m.DeepCopy(), nil).AnyTimes()
+			}
+
+			return newProviderWithKubectl(t, spec.VSphereDatacenter, spec.Cluster, kubectl), spec
+		},
+		Cluster: &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"},
+		UpgradeTriggers: map[string]func(*cluster.Spec){
+			"new manager image": func(s *cluster.Spec) {
+				s.VersionsBundle.VSphere.Manager.ImageDigest = "sha256:new"
+			},
+			"new datacenter network": func(s *cluster.Spec) {
+				s.VSphereDatacenter.Spec.Network = "/SDDC-Datacenter/network/new-network"
+			},
+			"new machine template": func(s *cluster.Spec) {
+				s.VSphereMachineConfigs[s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec.Template = "/SDDC-Datacenter/vm/Templates/new-template"
+			},
+		},
+		VersionBump: func(s *cluster.Spec) {
+			s.VersionsBundle.VSphere.Version = "v9.9.9"
+		},
+		SnapshotDir: "testdata/snapshots",
+		SnapshotMatrix: conformance.Matrix{
+			"controlplane": {
+				"single": func(s *cluster.Spec) { s.Cluster.Spec.ControlPlaneConfiguration.Count = 1 },
+				"ha":     func(s *cluster.Spec) { s.Cluster.Spec.ControlPlaneConfiguration.Count = 3 },
+			},
+			"workers": {
+				"untainted": func(s *cluster.Spec) { s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Taints = nil },
+				"tainted": func(s *cluster.Spec) {
+					s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Taints = []corev1.Taint{{Key: "dedicated", Value: "vsphere", Effect: corev1.TaintEffectNoSchedule}}
+				},
+			},
+		},
+		InvalidSpecs: map[string]func(*cluster.Spec){
+			"workload network same as the network": func(s *cluster.Spec) {
+				s.VSphereDatacenter.Spec.WorkloadNetwork = s.VSphereDatacenter.Spec.Network
+			},
+			"empty server": func(s *cluster.Spec) {
+				s.VSphereDatacenter.Spec.Server = ""
+			},
+		},
+	})
+}
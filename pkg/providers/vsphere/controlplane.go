@@ -2,6 +2,7 @@ package vsphere
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -15,9 +16,13 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	yamlcapi "github.com/aws/eks-anywhere/pkg/clusterapi/yaml"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/yamlutil"
 )
 
+// clusterResourceSetDataKey is the key of the manifests in the ClusterResourceSet Secrets and ConfigMaps.
+const clusterResourceSetDataKey = "data"
+
 // BaseControlPlane represents a CAPI VSphere control plane.
 type BaseControlPlane = clusterapi.ControlPlane[*vspherev1.VSphereCluster, *vspherev1.VSphereMachineTemplate]
 
@@ -39,6 +44,47 @@ func (p ControlPlane) Objects() []kubernetes.Object {
 	return o
 }
 
+// ClusterResourceSetManifests returns the manifests the ClusterResourceSet of the control plane applies to the
+// workload cluster, the vSphere CSI driver and cloud provider, in the order of its resources.
+func (p ControlPlane) ClusterResourceSetManifests() ([]byte, error) {
+	if p.ClusterResourceSet == nil {
+		return nil, nil
+	}
+
+	secrets := make(map[string]*corev1.Secret, len(p.Secrets))
+	for _, s := range p.Secrets {
+		secrets[s.Name] = s
+	}
+	configMaps := make(map[string]*corev1.ConfigMap, len(p.ConfigMaps))
+	for _, m := range p.ConfigMaps {
+		configMaps[m.Name] = m
+	}
+
+	manifests := make([][]byte, 0, len(p.ClusterResourceSet.Spec.Resources))
+	for _, r := range p.ClusterResourceSet.Spec.Resources {
+		switch r.Kind {
+		case constants.SecretKind:
+			s, ok := secrets[r.Name]
+			if !ok {
+				return nil, fmt.Errorf("secret %s of ClusterResourceSet %s not found", r.Name, p.ClusterResourceSet.Name)
+			}
+			manifest := s.Data[clusterResourceSetDataKey]
+			if data, ok := s.StringData[clusterResourceSetDataKey]; ok {
+				manifest = []byte(data)
+			}
+			manifests = append(manifests, manifest)
+		case constants.ConfigMapKind:
+			m, ok := configMaps[r.Name]
+			if !ok {
+				return nil, fmt.Errorf("configMap %s of ClusterResourceSet %s not found", r.Name, p.ClusterResourceSet.Name)
+			}
+			manifests = append(manifests, []byte(m.Data[clusterResourceSetDataKey]))
+		}
+	}
+
+	return templater.AppendYamlResources(manifests...), nil
+}
+
 // ControlPlaneBuilder defines the builder for all objects in the CAPI VSphere control plane.
 type ControlPlaneBuilder struct {
 	BaseBuilder  *yamlcapi.ControlPlaneBuilder[*vspherev1.VSphereCluster, *vspherev1.VSphereMachineTemplate]
@@ -58,8 +104,8 @@ func (b *ControlPlaneBuilder) BuildFromParsed(lookup yamlutil.ObjectLookup) erro
 }
 
 // ControlPlaneSpec builds a vsphere ControlPlane definition based on an eks-a cluster spec.
-func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*ControlPlane, error) {
-	templateBuilder := NewVsphereTemplateBuilder(time.Now, true)
+func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec, opts ...TemplateBuilderOpt) (*ControlPlane, error) {
+	templateBuilder := NewVsphereTemplateBuilder(time.Now, true, opts...)
 
 	controlPlaneYaml, err := templateBuilder.GenerateCAPISpecControlPlane(
 		spec,
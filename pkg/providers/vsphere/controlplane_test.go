@@ -125,6 +125,75 @@ func TestControlPlaneSpecNoKubeVersion(t *testing.T) {
 	g.Expect(err).To(MatchError(ContainSubstring("generating vsphere control plane yaml spec")))
 }
 
+func TestControlPlaneClusterResourceSetManifests(t *testing.T) {
+	g := NewWithT(t)
+	cp := &vsphere.ControlPlane{
+		Secrets: []*corev1.Secret{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "credentials"},
+				StringData: map[string]string{"data": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: credentials\n"},
+			},
+		},
+		ConfigMaps: []*corev1.ConfigMap{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "manifests"},
+				Data:       map[string]string{"data": "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: csi\n"},
+			},
+		},
+		ClusterResourceSet: &addons.ClusterResourceSet{
+			Spec: addons.ClusterResourceSetSpec{
+				Resources: []addons.ResourceRef{
+					{Kind: "ConfigMap", Name: "manifests"},
+					{Kind: "Secret", Name: "credentials"},
+				},
+			},
+		},
+	}
+
+	manifests, err := cp.ClusterResourceSetManifests()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(manifests)).To(Equal(`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: csi
+
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+
+---
+`))
+}
+
+func TestControlPlaneClusterResourceSetManifestsMissingResource(t *testing.T) {
+	g := NewWithT(t)
+	cp := &vsphere.ControlPlane{
+		ClusterResourceSet: &addons.ClusterResourceSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-crs"},
+			Spec: addons.ClusterResourceSetSpec{
+				Resources: []addons.ResourceRef{{Kind: "Secret", Name: "credentials"}},
+			},
+		},
+	}
+
+	_, err := cp.ClusterResourceSetManifests()
+	g.Expect(err).To(MatchError("secret credentials of ClusterResourceSet my-crs not found"))
+}
+
+func TestControlPlaneSpecClusterResourceSetManifests(t *testing.T) {
+	g := NewWithT(t)
+	spec := givenClusterSpec(t, testClusterConfigMainFilename)
+
+	cp, err := vsphere.ControlPlaneSpec(context.Background(), test.NewNullLogger(), test.NewFakeKubeClient(), spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	manifests, err := cp.ClusterResourceSetManifests()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(manifests)).To(ContainSubstring("name: csi-vsphere-config\n  namespace: kube-system"))
+	g.Expect(string(manifests)).To(ContainSubstring("name: cloud-provider-vsphere-credentials\n  namespace: kube-system"))
+}
+
 func givenClusterSpec(t *testing.T, fileName string) *cluster.Spec {
 	return test.NewFullClusterSpec(t, path.Join(testDataDir, fileName))
 }
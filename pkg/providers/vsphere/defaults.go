@@ -83,6 +83,12 @@ func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machin
 		return fmt.Errorf("can not import ova for osFamily: %s, please use %s as osFamily for auto-importing or provide a valid template", osFamily, anywherev1.Bottlerocket)
 	}
 
+	arch := machineConfig.Arch()
+	if !ovaSupportsArch(ova, arch) {
+		return fmt.Errorf("can not import ova for architecture %s, the %s ova is only available for %s, please provide a valid template",
+			arch, osFamily, strings.Join(ova.Arch, ", "))
+	}
+
 	templateName := fmt.Sprintf("%s-%s-%s-%s-%s", osFamily, eksd.KubeVersion, eksd.Name, strings.Join(ova.Arch, "-"), ova.SHA256[:7])
 	machineConfig.Spec.Template = filepath.Join("/", spec.VSphereDatacenter.Spec.Datacenter, defaultTemplatesFolder, templateName)
 
@@ -135,3 +141,17 @@ func (d *Defaulter) setTemplateFullPath(ctx context.Context,
 	machine.Spec.Template = templateFullPath
 	return nil
 }
+
+// ovaSupportsArch returns true if ova is available for arch. OVAs without architectures are
+// assumed to be amd64.
+func ovaSupportsArch(ova releasev1.Archive, arch anywherev1.Architecture) bool {
+	if len(ova.Arch) == 0 {
+		return arch == anywherev1.AMD64
+	}
+	for _, a := range ova.Arch {
+		if a == string(arch) {
+			return true
+		}
+	}
+	return false
+}
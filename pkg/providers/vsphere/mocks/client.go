@@ -14,6 +14,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
 	v1 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1beta10 "sigs.k8s.io/cluster-api/api/v1beta1"
 	v1beta11 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 )
@@ -69,6 +70,21 @@ func (mr *MockProviderGovcClientMockRecorder) AddUserToGroup(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToGroup", reflect.TypeOf((*MockProviderGovcClient)(nil).AddUserToGroup), arg0, arg1, arg2)
 }
 
+// CleanupClusterVMs mocks base method.
+func (m *MockProviderGovcClient) CleanupClusterVMs(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupClusterVMs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupClusterVMs indicates an expected call of CleanupClusterVMs.
+func (mr *MockProviderGovcClientMockRecorder) CleanupClusterVMs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupClusterVMs", reflect.TypeOf((*MockProviderGovcClient)(nil).CleanupClusterVMs), arg0, arg1, arg2)
+}
+
 // ConfigureCertThumbprint mocks base method.
 func (m *MockProviderGovcClient) ConfigureCertThumbprint(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -343,6 +359,21 @@ func (mr *MockProviderGovcClientMockRecorder) ListCategories(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockProviderGovcClient)(nil).ListCategories), arg0)
 }
 
+// ListCategoryTagIDs mocks base method.
+func (m *MockProviderGovcClient) ListCategoryTagIDs(arg0 context.Context, arg1 string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryTagIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoryTagIDs indicates an expected call of ListCategoryTagIDs.
+func (mr *MockProviderGovcClientMockRecorder) ListCategoryTagIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryTagIDs", reflect.TypeOf((*MockProviderGovcClient)(nil).ListCategoryTagIDs), arg0, arg1)
+}
+
 // ListTags mocks base method.
 func (m *MockProviderGovcClient) ListTags(arg0 context.Context) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -687,6 +718,20 @@ func (mr *MockProviderKubectlClientMockRecorder) GetMachineDeployment(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineDeployment", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetMachineDeployment), varargs...)
 }
 
+// GetObject mocks base method.
+func (m *MockProviderKubectlClient) GetObject(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockProviderKubectlClientMockRecorder) GetObject(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetObject), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GetSecretFromNamespace mocks base method.
 func (m *MockProviderKubectlClient) GetSecretFromNamespace(arg0 context.Context, arg1, arg2, arg3 string) (*v1.Secret, error) {
 	m.ctrl.T.Helper()
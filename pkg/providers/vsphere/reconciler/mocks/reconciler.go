@@ -53,6 +53,44 @@ func (mr *MockCNIReconcilerMockRecorder) Reconcile(ctx, logger, client, spec int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockCNIReconciler)(nil).Reconcile), ctx, logger, client, spec)
 }
 
+// MockBootstrapManifestsReconciler is a mock of BootstrapManifestsReconciler interface.
+type MockBootstrapManifestsReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockBootstrapManifestsReconcilerMockRecorder
+}
+
+// MockBootstrapManifestsReconcilerMockRecorder is the mock recorder for MockBootstrapManifestsReconciler.
+type MockBootstrapManifestsReconcilerMockRecorder struct {
+	mock *MockBootstrapManifestsReconciler
+}
+
+// NewMockBootstrapManifestsReconciler creates a new mock instance.
+func NewMockBootstrapManifestsReconciler(ctrl *gomock.Controller) *MockBootstrapManifestsReconciler {
+	mock := &MockBootstrapManifestsReconciler{ctrl: ctrl}
+	mock.recorder = &MockBootstrapManifestsReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBootstrapManifestsReconciler) EXPECT() *MockBootstrapManifestsReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockBootstrapManifestsReconciler) Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, client, spec)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockBootstrapManifestsReconcilerMockRecorder) Reconcile(ctx, logger, client, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockBootstrapManifestsReconciler)(nil).Reconcile), ctx, logger, client, spec)
+}
+
 // MockRemoteClientRegistry is a mock of RemoteClientRegistry interface.
 type MockRemoteClientRegistry struct {
 	ctrl     *gomock.Controller
@@ -18,6 +18,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 )
 
@@ -26,6 +27,11 @@ type CNIReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
 }
 
+// BootstrapManifestsReconciler is an interface for reconciling the bootstrap manifests in the VSphere cluster reconciler.
+type BootstrapManifestsReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
 // RemoteClientRegistry is an interface that defines methods for remote clients.
 type RemoteClientRegistry interface {
 	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
@@ -35,18 +41,22 @@ type Reconciler struct {
 	client               client.Client
 	validator            *vsphere.Validator
 	defaulter            *vsphere.Defaulter
+	tagger               *vsphere.ClusterTagger
 	cniReconciler        CNIReconciler
+	manifestsReconciler  BootstrapManifestsReconciler
 	remoteClientRegistry RemoteClientRegistry
 	*serverside.ObjectApplier
 }
 
 // New defines a new VSphere reconciler.
-func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.Defaulter, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.Defaulter, tagger *vsphere.ClusterTagger, cniReconciler CNIReconciler, manifestsReconciler BootstrapManifestsReconciler, remoteClientRegistry RemoteClientRegistry) *Reconciler {
 	return &Reconciler{
 		client:               client,
 		validator:            validator,
 		defaulter:            defaulter,
+		tagger:               tagger,
 		cniReconciler:        cniReconciler,
+		manifestsReconciler:  manifestsReconciler,
 		remoteClientRegistry: remoteClientRegistry,
 		ObjectApplier:        serverside.NewObjectApplier(client),
 	}
@@ -70,15 +80,25 @@ func SetupEnvVars(ctx context.Context, vsphereDatacenter *anywherev1.VSphereData
 		return fmt.Errorf("failed getting vsphere credentials secret: %v", err)
 	}
 
-	vsphereUsername := secret.Data["username"]
-	vspherePassword := secret.Data["password"]
-
-	if err := os.Setenv(config.EksavSphereUsernameKey, string(vsphereUsername)); err != nil {
-		return fmt.Errorf("failed setting env %s: %v", config.EksavSphereUsernameKey, err)
+	for _, password := range []string{"password", "passwordCP", "passwordCSI"} {
+		if len(secret.Data[password]) > 0 {
+			logger.RegisterSecrets(string(secret.Data[password]))
+		}
 	}
 
-	if err := os.Setenv(config.EksavSpherePasswordKey, string(vspherePassword)); err != nil {
-		return fmt.Errorf("failed setting env %s: %v", config.EksavSpherePasswordKey, err)
+	// The cloud provider and CSI credentials are optional and default to the vSphere ones when empty.
+	envVars := []struct{ name, key string }{
+		{config.EksavSphereUsernameKey, "username"},
+		{config.EksavSpherePasswordKey, "password"},
+		{config.EksavSphereCPUsernameKey, "usernameCP"},
+		{config.EksavSphereCPPasswordKey, "passwordCP"},
+		{config.EksavSphereCSIUsernameKey, "usernameCSI"},
+		{config.EksavSphereCSIPasswordKey, "passwordCSI"},
+	}
+	for _, e := range envVars {
+		if err := os.Setenv(e.name, string(secret.Data[e.key])); err != nil {
+			return fmt.Errorf("failed setting env %s: %v", e.name, err)
+		}
 	}
 
 	if err := vsphere.SetupEnvVars(vsphereDatacenter); err != nil {
@@ -100,6 +120,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		r.ValidateMachineConfigs,
 		r.ReconcileControlPlane,
 		r.ReconcileCNI,
+		r.ReconcileCSI,
+		r.ReconcileBootstrapManifests,
 		r.ReconcileWorkers,
 	).Run(ctx, log, clusterSpec)
 }
@@ -163,12 +185,67 @@ func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterS
 	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
 }
 
-// ReconcileWorkers applies the worker CAPI objects to the cluster.
+// ReconcileCSI applies the vSphere CSI driver and cloud provider manifests, with their credentials, to the workload
+// cluster. The ClusterResourceSet only applies them once, so this keeps them updated and propagates the rotations of
+// the credentials in the eksa-system vsphere-credentials secret.
+func (r *Reconciler) ReconcileCSI(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileCSI")
+
+	// Read the credentials again, they might have been rotated.
+	if err := SetupEnvVars(ctx, clusterSpec.VSphereDatacenter, r.client); err != nil {
+		return controller.Result{}, err
+	}
+
+	cp, err := vsphere.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	manifests, err := cp.ClusterResourceSetManifests()
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	log.Info("Applying CSI driver and cloud provider manifests")
+	if err := serverside.ReconcileYaml(ctx, client, manifests); err != nil {
+		return controller.Result{}, fmt.Errorf("applying CSI driver and cloud provider manifests: %v", err)
+	}
+
+	return controller.Result{}, nil
+}
+
+// ReconcileBootstrapManifests applies the bootstrap manifests of the cluster spec once its CNI is ready.
+func (r *Reconciler) ReconcileBootstrapManifests(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileBootstrapManifests")
+	if len(clusterSpec.Cluster.Spec.BootstrapManifests) == 0 {
+		return controller.Result{}, nil
+	}
+
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.manifestsReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
+// ReconcileWorkers applies the worker CAPI objects to the cluster. The machine templates attach the
+// tags of the cluster to the VMs, so the tags are created in vCenter first.
 func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileWorkers")
+	tagIDs, err := r.tagger.TagIDs(ctx, clusterSpec)
+	if err != nil {
+		return controller.Result{}, fmt.Errorf("creating cluster tags: %v", err)
+	}
+
 	log.Info("Applying worker CAPI objects")
 	return r.Apply(ctx, func() ([]kubernetes.Object, error) {
-		w, err := vsphere.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+		w, err := vsphere.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec, vsphere.WithTagIDs(tagIDs))
 		if err != nil {
 			return nil, err
 		}
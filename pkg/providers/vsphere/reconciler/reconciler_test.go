@@ -48,17 +48,19 @@ func TestReconcilerReconcileSuccess(t *testing.T) {
 	tt.createAllObjs()
 
 	logger := test.NewNullLogger()
-	remoteClient := fake.NewClientBuilder().Build()
+	// The CSI manifests are applied with server side apply, which the fake client doesn't support.
+	remoteClient := env.Client()
 
 	tt.govcClient.EXPECT().ValidateVCenterSetupMachineConfig(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	tt.govcClient.EXPECT().ValidateVCenterSetupMachineConfig(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	tt.govcClient.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, gomock.Any()).Return("test", nil)
 	tt.govcClient.EXPECT().GetTags(tt.ctx, tt.machineConfigControlPlane.Spec.Template).Return([]string{"os:ubuntu", fmt.Sprintf("eksdRelease:%s", tt.bundle.Spec.VersionsBundles[0].EksD.Name)}, nil)
 	tt.govcClient.EXPECT().GetWorkloadAvailableSpace(tt.ctx, tt.machineConfigControlPlane.Spec.Datastore).Return(100.0, nil).Times(2)
+	tt.expectClusterTag()
 
 	tt.remoteClientRegistry.EXPECT().GetClient(
 		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
-	).Return(remoteClient, nil)
+	).Return(remoteClient, nil).Times(2)
 	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, tt.buildSpec())
 
 	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
@@ -89,6 +91,7 @@ func TestReconcilerFailToSetUpMachineConfigCP(t *testing.T) {
 func TestReconcilerReconcileWorkersSuccess(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.createAllObjs()
+	tt.expectClusterTag()
 
 	result, err := tt.reconciler().ReconcileWorkers(tt.ctx, test.NewNullLogger(), tt.buildSpec())
 
@@ -97,6 +100,24 @@ func TestReconcilerReconcileWorkersSuccess(t *testing.T) {
 	tt.Expect(result).To(Equal(controller.Result{}))
 }
 
+func TestReconcilerReconcileWorkersErrorCreatingTags(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.Tags = map[string]string{"environment": "prod"}
+	tt.withFakeClient()
+	tt.govcClient.EXPECT().ListCategories(tt.ctx).Return(nil, errors.New("permission denied"))
+
+	_, err := tt.reconciler().ReconcileWorkers(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(MatchError("creating cluster tags: listing tag categories: permission denied"))
+}
+
+func (tt *reconcilerTest) expectClusterTag() {
+	tt.govcClient.EXPECT().ListCategories(tt.ctx).Return([]string{"eksaCluster"}, nil)
+	tt.govcClient.EXPECT().ListCategoryTagIDs(tt.ctx, "eksaCluster").Return(map[string]string{
+		"eksaCluster:" + tt.cluster.ManagedBy() + ":" + tt.cluster.Name: "urn:vmomi:InventoryServiceTag:1:GLOBAL",
+	}, nil)
+}
+
 func TestReconcilerInvalidDatacenterConfig(t *testing.T) {
 	tt := newReconcilerTest(t)
 	logger := test.NewNullLogger()
@@ -149,14 +170,110 @@ func TestReconcileCNIErrorClientRegistry(t *testing.T) {
 	tt.Expect(result).To(Equal(controller.Result{}))
 }
 
+func TestReconcileCSISuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.eksaSupportObjs = removeObject(tt.eksaSupportObjs, createSecret())
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, createSecret(func(s *corev1.Secret) {
+		s.Data["usernameCSI"] = []byte("csi-user")
+		s.Data["passwordCSI"] = []byte("csi-password")
+	}))
+	tt.createAllObjs()
+
+	logger := test.NewNullLogger()
+	remoteClient := env.Client()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+
+	result, err := tt.reconciler().ReconcileCSI(tt.ctx, logger, spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	csiConfig := &corev1.Secret{}
+	tt.Expect(remoteClient.Get(tt.ctx, client.ObjectKey{Name: "csi-vsphere-config", Namespace: "kube-system"}, csiConfig)).To(Succeed())
+	tt.Expect(string(csiConfig.Data["csi-vsphere.conf"])).To(ContainSubstring(`user = "csi-user"`))
+
+	cloudProviderCredentials := &corev1.Secret{}
+	tt.Expect(remoteClient.Get(tt.ctx, client.ObjectKey{Name: "cloud-provider-vsphere-credentials", Namespace: "kube-system"}, cloudProviderCredentials)).To(Succeed())
+	tt.Expect(cloudProviderCredentials.Data).To(HaveKeyWithValue(tt.datacenterConfig.Spec.Server+".username", []byte("test")))
+}
+
+func TestReconcileCSIErrorClientRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(nil, errors.New("building client"))
+
+	result, err := tt.reconciler().ReconcileCSI(tt.ctx, logger, spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileCSIErrorMissingCredentials(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.eksaSupportObjs = removeObject(tt.eksaSupportObjs, createSecret())
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	spec := tt.buildSpec()
+
+	result, err := tt.reconciler().ReconcileCSI(tt.ctx, logger, spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("failed getting vsphere credentials secret")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileBootstrapManifestsSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.BootstrapManifests = []anywherev1.BootstrapManifest{
+		{Name: "namespaces", Inline: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: team-a\n"},
+	}
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.manifestsReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
+
+	result, err := tt.reconciler().ReconcileBootstrapManifests(tt.ctx, logger, spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileBootstrapManifestsNoManifests(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ReconcileBootstrapManifests(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
 type reconcilerTest struct {
 	t testing.TB
 	*WithT
 	ctx                       context.Context
 	cniReconciler             *vspherereconcilermocks.MockCNIReconciler
+	manifestsReconciler       *vspherereconcilermocks.MockBootstrapManifestsReconciler
 	govcClient                *mocks.MockProviderGovcClient
 	validator                 *vsphere.Validator
 	defaulter                 *vsphere.Defaulter
+	tagger                    *vsphere.ClusterTagger
 	remoteClientRegistry      *vspherereconcilermocks.MockRemoteClientRegistry
 	cluster                   *anywherev1.Cluster
 	client                    client.Client
@@ -171,6 +288,7 @@ type reconcilerTest struct {
 func newReconcilerTest(t testing.TB) *reconcilerTest {
 	ctrl := gomock.NewController(t)
 	cniReconciler := vspherereconcilermocks.NewMockCNIReconciler(ctrl)
+	manifestsReconciler := vspherereconcilermocks.NewMockBootstrapManifestsReconciler(ctrl)
 	remoteClientRegistry := vspherereconcilermocks.NewMockRemoteClientRegistry(ctrl)
 	client := env.Client()
 
@@ -178,6 +296,7 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 	vcb := govmomi.NewVMOMIClientBuilder()
 	validator := vsphere.NewValidator(govcClient, &networkutils.DefaultNetClient{}, vcb)
 	defaulter := vsphere.NewDefaulter(govcClient)
+	tagger := vsphere.NewClusterTagger(govcClient)
 
 	bundle := createBundle()
 
@@ -248,9 +367,11 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 		WithT:                NewWithT(t),
 		ctx:                  context.Background(),
 		cniReconciler:        cniReconciler,
+		manifestsReconciler:  manifestsReconciler,
 		govcClient:           govcClient,
 		validator:            validator,
 		defaulter:            defaulter,
+		tagger:               tagger,
 		remoteClientRegistry: remoteClientRegistry,
 		client:               client,
 		env:                  env,
@@ -304,7 +425,7 @@ func (tt *reconcilerTest) withFakeClient() {
 }
 
 func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
-	return reconciler.New(tt.client, tt.validator, tt.defaulter, tt.cniReconciler, tt.remoteClientRegistry)
+	return reconciler.New(tt.client, tt.validator, tt.defaulter, tt.tagger, tt.cniReconciler, tt.manifestsReconciler, tt.remoteClientRegistry)
 }
 
 func (tt *reconcilerTest) createAllObjs() {
@@ -553,8 +674,8 @@ func capiCluster(opts ...capiClusterOpt) *clusterv1.Cluster {
 	return c
 }
 
-func createSecret() *corev1.Secret {
-	return &corev1.Secret{
+func createSecret(opts ...func(*corev1.Secret)) *corev1.Secret {
+	s := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: "eksa-system",
 			Name:      vsphere.CredentialsObjectName,
@@ -568,4 +689,20 @@ func createSecret() *corev1.Secret {
 			"password": []byte("test"),
 		},
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func removeObject(objs []envtest.Object, obj envtest.Object) []envtest.Object {
+	filtered := make([]envtest.Object, 0, len(objs))
+	for _, o := range objs {
+		if o.GetObjectKind().GroupVersionKind().Kind != obj.GetObjectKind().GroupVersionKind().Kind || o.GetName() != obj.GetName() {
+			filtered = append(filtered, o)
+		}
+	}
+	return filtered
 }
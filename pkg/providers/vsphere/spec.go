@@ -1,6 +1,8 @@
 package vsphere
 
 import (
+	"sort"
+
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 )
@@ -28,11 +30,13 @@ func (s *Spec) etcdMachineConfig() *anywherev1.VSphereMachineConfig {
 	return etcdMachineConfig(s.Spec)
 }
 
+// machineConfigs returns the machine configs sorted by name.
 func (s *Spec) machineConfigs() []*anywherev1.VSphereMachineConfig {
 	machineConfigs := make([]*anywherev1.VSphereMachineConfig, 0, len(s.VSphereMachineConfigs))
 	for _, m := range s.VSphereMachineConfigs {
 		machineConfigs = append(machineConfigs, m)
 	}
+	sort.Slice(machineConfigs, func(i, j int) bool { return machineConfigs[i].Name < machineConfigs[j].Name })
 
 	return machineConfigs
 }
@@ -20,8 +20,16 @@ func requiredTemplateTags(clusterSpec *cluster.Spec, machineConfig *v1alpha1.VSp
 
 func requiredTemplateTagsByCategory(clusterSpec *cluster.Spec, machineConfig *v1alpha1.VSphereMachineConfig) map[string][]string {
 	osFamily := machineConfig.Spec.OSFamily
-	return map[string][]string{
+	tags := map[string][]string{
 		"eksdRelease": {fmt.Sprintf("eksdRelease:%s", clusterSpec.VersionsBundle.EksD.Name)},
 		"os":          {fmt.Sprintf("os:%s", strings.ToLower(string(osFamily)))},
 	}
+
+	// Templates built before architecture tags were introduced are all amd64, so the tag is only
+	// required for other architectures.
+	if arch := machineConfig.Arch(); arch != v1alpha1.DefaultArchitecture {
+		tags["arch"] = []string{fmt.Sprintf("arch:%s", arch)}
+	}
+
+	return tags
 }
@@ -3,6 +3,7 @@ package vsphere
 import (
 	"fmt"
 	"net"
+	"path"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -20,16 +21,33 @@ import (
 func NewVsphereTemplateBuilder(
 	now types.NowFunc,
 	fromController bool,
+	opts ...TemplateBuilderOpt,
 ) *VsphereTemplateBuilder {
-	return &VsphereTemplateBuilder{
+	vs := &VsphereTemplateBuilder{
 		now:            now,
 		fromController: fromController,
 	}
+	for _, opt := range opts {
+		opt(vs)
+	}
+
+	return vs
 }
 
 type VsphereTemplateBuilder struct {
 	now            types.NowFunc
 	fromController bool
+	tagIDs         []string
+}
+
+// TemplateBuilderOpt customizes a VsphereTemplateBuilder.
+type TemplateBuilderOpt func(*VsphereTemplateBuilder)
+
+// WithTagIDs makes the machine templates attach the vCenter tags with tagIDs to the VMs they create.
+func WithTagIDs(tagIDs []string) TemplateBuilderOpt {
+	return func(vs *VsphereTemplateBuilder) {
+		vs.tagIDs = tagIDs
+	}
 }
 
 func (vs *VsphereTemplateBuilder) GenerateCAPISpecControlPlane(
@@ -49,6 +67,7 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecControlPlane(
 	if err != nil {
 		return nil, err
 	}
+	values["tagIDs"] = vs.tagIDs
 
 	for _, buildOption := range buildOptions {
 		buildOption(values)
@@ -97,10 +116,11 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecWorkers(
 
 	workerSpecs := make([][]byte, 0, len(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
 	for _, workerNodeGroupConfiguration := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineConfig := workerMachineConfig(clusterSpec, workerNodeGroupConfiguration)
 		values, err := buildTemplateMapMD(
 			clusterSpec,
 			clusterSpec.VSphereDatacenter.Spec,
-			workerMachineConfig(clusterSpec, workerNodeGroupConfiguration).Spec,
+			machineConfig.Spec,
 			workerNodeGroupConfiguration,
 		)
 		if err != nil {
@@ -111,8 +131,14 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecWorkers(
 		values["workloadkubeadmconfigTemplateName"] = kubeadmconfigTemplateNames[workerNodeGroupConfiguration.Name]
 
 		values["cgroupDriverSystemd"] = cgroupDriverSystemd
+		values["tagIDs"] = vs.tagIDs
+
+		template := defaultClusterConfigMD
+		if machineConfig.OSFamily() == anywherev1.Windows {
+			template = defaultClusterConfigMDWindows
+		}
 
-		bytes, err := templater.Execute(defaultClusterConfigMD, values)
+		bytes, err := templater.Execute(template, values)
 		if err != nil {
 			return nil, err
 		}
@@ -133,10 +159,13 @@ func buildTemplateMapCP(
 	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
-		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+		Append(clusterapi.KubeletServingCertificateExtraArgs(clusterSpec.Cluster)).
+		Append(clusterapi.ImageCredentialProviderExtraArgs(clusterSpec.Cluster))
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
+		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster)).
 		Append(sharedExtraArgs)
 	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
@@ -152,6 +181,9 @@ func buildTemplateMapCP(
 	values := map[string]interface{}{
 		"clusterName":                          clusterSpec.Cluster.Name,
 		"controlPlaneEndpointIp":               clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host,
+		"vsphereKubeVip":                       !clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.UsesExternalLoadBalancer(),
+		"apiServerCertSANs":                    apiServerCertSANs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration),
+		"staticPodManifests":                   clusterapi.StaticPodManifestFiles(clusterSpec.Cluster.Spec.ControlPlaneConfiguration),
 		"controlPlaneReplicas":                 clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count,
 		"kubernetesRepository":                 bundle.KubeDistro.Kubernetes.Repository,
 		"kubernetesVersion":                    bundle.KubeDistro.Kubernetes.Tag,
@@ -180,6 +212,8 @@ func buildTemplateMapCP(
 		"controlPlaneVMsMemoryMiB":             controlPlaneMachineSpec.MemoryMiB,
 		"controlPlaneVMsNumCPUs":               controlPlaneMachineSpec.NumCPUs,
 		"controlPlaneDiskGiB":                  controlPlaneMachineSpec.DiskGiB,
+		"controlPlaneSerialConsole":            controlPlaneMachineSpec.SerialConsole.IsEnabled(),
+		"serialConsoleLogFile":                 constants.VSphereSerialConsoleLogFile,
 		"controlPlaneSshUsername":              firstControlPlaneMachinesUser.Name,
 		"vsphereControlPlaneSshAuthorizedKey":  controlPlaneSSHKey,
 		"podCidrs":                             clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
@@ -190,6 +224,9 @@ func buildTemplateMapCP(
 		"controllerManagerExtraArgs":           controllerManagerExtraArgs.ToPartialYaml(),
 		"schedulerExtraArgs":                   sharedExtraArgs.ToPartialYaml(),
 		"kubeletExtraArgs":                     kubeletExtraArgs.ToPartialYaml(),
+		"credentialProviderFiles":              clusterapi.ImageCredentialProviderConfigFiles(clusterSpec.Cluster),
+		"etcdEncryptionFiles":                  clusterapi.EtcdEncryptionConfigFiles(clusterSpec.Cluster),
+		"etcdEncryptionVolumes":                clusterapi.EtcdEncryptionExtraVolumes(clusterSpec.Cluster, controlPlaneMachineSpec.OSFamily),
 		"format":                               format,
 		"externalEtcdVersion":                  bundle.KubeDistro.EtcdVersion,
 		"etcdImage":                            bundle.KubeDistro.EtcdImage.VersionedImage(),
@@ -203,6 +240,7 @@ func buildTemplateMapCP(
 		"eksaCSIPassword":                      vuc.EksaVsphereCSIPassword,
 		"disableCSI":                           datacenterSpec.DisableCSI,
 	}
+	common.SetKubeVipValues(values, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint)
 
 	auditPolicy, err := common.GetAuditPolicy(clusterSpec.Cluster.Spec.KubernetesVersion)
 	if err != nil {
@@ -211,9 +249,11 @@ func buildTemplateMapCP(
 	values["auditPolicy"] = auditPolicy
 
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
-		values["registryMirrorConfiguration"] = net.JoinHostPort(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint, clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Port)
+		registryMirror := net.JoinHostPort(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint, clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Port)
+		values["registryMirrorConfiguration"] = registryMirror
 		if len(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent) > 0 {
 			values["registryCACert"] = clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent
+			values["registryCACertTrustCommand"] = clusterapi.RegistryMirrorCACertTrustCommand(registryMirror)
 		}
 
 		if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Authenticate {
@@ -262,8 +302,10 @@ func buildTemplateMapCP(
 		values["etcdVMsNumCPUs"] = etcdMachineSpec.NumCPUs
 		values["etcdVsphereResourcePool"] = etcdMachineSpec.ResourcePool
 		values["etcdVsphereStoragePolicyName"] = etcdMachineSpec.StoragePolicyName
+		values["etcdSerialConsole"] = etcdMachineSpec.SerialConsole.IsEnabled()
 		values["etcdSshUsername"] = firstEtcdMachinesUser.Name
 		values["vsphereEtcdSshAuthorizedKey"] = etcdSSHKey
+		setEtcdDiskValues(values, etcdMachineSpec)
 	}
 
 	if controlPlaneMachineSpec.OSFamily == anywherev1.Bottlerocket {
@@ -274,17 +316,35 @@ func buildTemplateMapCP(
 		values["bottlerocketBootstrapVersion"] = bundle.BottleRocketBootstrap.Bootstrap.Tag()
 	}
 
+	setContainerdConfigValues(values, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.ContainerdConfiguration, controlPlaneMachineSpec.OSFamily)
+	setHostOSConfigValues(values, controlPlaneMachineSpec)
+	setWorkloadNetworkValues(values, datacenterSpec, controlPlaneMachineSpec.OSFamily)
+
 	if len(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints) > 0 {
 		values["controlPlaneTaints"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints
 	}
+	values["controlPlaneSchedulable"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Schedulable
 
 	if clusterSpec.AWSIamConfig != nil {
 		values["awsIamAuth"] = true
 	}
 
+	setWindowsWorkersValues(values, clusterSpec)
+
 	return values, nil
 }
 
+// setWindowsWorkersValues sets the values to install the CNI and kube-proxy on Windows nodes
+// with the control plane ClusterResourceSet when the cluster has Windows worker node groups.
+func setWindowsWorkersValues(values map[string]interface{}, clusterSpec *cluster.Spec) {
+	if len(clusterSpec.WindowsWorkerNodeGroups()) == 0 {
+		return
+	}
+
+	values["windowsWorkers"] = true
+	values["windowsHostProcessImage"] = windowsHostProcessImage
+}
+
 func buildTemplateMapMD(
 	clusterSpec *cluster.Spec,
 	datacenterSpec anywherev1.VSphereDatacenterConfigSpec,
@@ -294,8 +354,10 @@ func buildTemplateMapMD(
 	bundle := clusterSpec.VersionsBundle
 	format := "cloud-config"
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
-		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
-		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
+		Append(clusterapi.WorkerNodeLabelsWithOSUpdatesExtraArgs(clusterSpec.Cluster, workerNodeGroupConfiguration, workerNodeGroupMachineSpec.OSFamily)).
+		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
+		Append(clusterapi.KubeletServingCertificateExtraArgs(clusterSpec.Cluster)).
+		Append(clusterapi.ImageCredentialProviderExtraArgs(clusterSpec.Cluster))
 
 	firstUser := workerNodeGroupMachineSpec.Users[0]
 	sshKey, err := common.StripSshAuthorizedKeyComment(firstUser.SshAuthorizedKeys[0])
@@ -318,11 +380,14 @@ func buildTemplateMapMD(
 		"workloadVMsMemoryMiB":           workerNodeGroupMachineSpec.MemoryMiB,
 		"workloadVMsNumCPUs":             workerNodeGroupMachineSpec.NumCPUs,
 		"workloadDiskGiB":                workerNodeGroupMachineSpec.DiskGiB,
+		"workerSerialConsole":            workerNodeGroupMachineSpec.SerialConsole.IsEnabled(),
+		"serialConsoleLogFile":           constants.VSphereSerialConsoleLogFile,
 		"workerSshUsername":              firstUser.Name,
 		"vsphereWorkerSshAuthorizedKey":  sshKey,
 		"format":                         format,
 		"eksaSystemNamespace":            constants.EksaSystemNamespace,
 		"kubeletExtraArgs":               kubeletExtraArgs.ToPartialYaml(),
+		"credentialProviderFiles":        clusterapi.ImageCredentialProviderConfigFiles(clusterSpec.Cluster),
 		"workerReplicas":                 *workerNodeGroupConfiguration.Count,
 		"workerNodeGroupName":            fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name),
 		"workerNodeGroupTaints":          workerNodeGroupConfiguration.Taints,
@@ -330,9 +395,11 @@ func buildTemplateMapMD(
 	}
 
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
-		values["registryMirrorConfiguration"] = net.JoinHostPort(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint, clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Port)
+		registryMirror := net.JoinHostPort(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint, clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Port)
+		values["registryMirrorConfiguration"] = registryMirror
 		if len(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent) > 0 {
 			values["registryCACert"] = clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent
+			values["registryCACertTrustCommand"] = clusterapi.RegistryMirrorCACertTrustCommand(registryMirror)
 		}
 
 		if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Authenticate {
@@ -373,6 +440,10 @@ func buildTemplateMapMD(
 		values["bottlerocketBootstrapVersion"] = bundle.BottleRocketBootstrap.Bootstrap.Tag()
 	}
 
+	setContainerdConfigValues(values, workerNodeGroupConfiguration.ContainerdConfiguration, workerNodeGroupMachineSpec.OSFamily)
+	setHostOSConfigValues(values, workerNodeGroupMachineSpec)
+	setWorkloadNetworkValues(values, datacenterSpec, workerNodeGroupMachineSpec.OSFamily)
+
 	return values, nil
 }
 
@@ -387,3 +458,77 @@ func initialNamesForWorkers(spec *cluster.Spec) (machineTemplateNames, kubeadmCo
 
 	return machineTemplateNames, kubeadmConfigTemplateNames
 }
+
+// setContainerdConfigValues adds the containerd configuration overrides of a group of nodes to the template values.
+// Bottlerocket nodes only support overriding the sandbox image, through the pause image settings.
+func setContainerdConfigValues(values map[string]interface{}, config *anywherev1.ContainerdConfiguration, osFamily anywherev1.OSFamily) {
+	if osFamily != anywherev1.Bottlerocket {
+		values["containerdConfigFiles"] = clusterapi.ContainerdConfigFiles(config)
+		values["containerdConfigCommands"] = clusterapi.ContainerdConfigCommands(config)
+		return
+	}
+
+	if repository, tag := clusterapi.SandboxImageRepositoryAndTag(config); repository != "" {
+		values["pauseRepository"] = repository
+		values["pauseVersion"] = tag
+	}
+}
+
+// setHostOSConfigValues adds the kernel modules and sysctls of a machine config to the template values.
+// Bottlerocket nodes get them as kernel settings instead of files and commands.
+func setHostOSConfigValues(values map[string]interface{}, machineSpec anywherev1.VSphereMachineConfigSpec) {
+	if machineSpec.OSFamily == anywherev1.Bottlerocket {
+		values["bottlerocketKernelModules"] = clusterapi.BottlerocketKernelModules(machineSpec.HostOSConfiguration)
+		values["bottlerocketKernelSysctls"] = clusterapi.BottlerocketKernelSysctls(machineSpec.HostOSConfiguration)
+		return
+	}
+
+	values["hostOSConfigFiles"] = clusterapi.HostOSConfigFiles(machineSpec.HostOSConfiguration)
+	values["hostOSConfigCommands"] = clusterapi.HostOSConfigCommands(machineSpec.HostOSConfiguration)
+}
+
+// etcdDataDiskDevice is the block device of the etcd data disk, the second disk of the etcd VMs.
+const etcdDataDiskDevice = "sdb"
+
+// setEtcdDiskValues adds the etcd data disk and IO tuning of the etcd machine config to the template values.
+// Bottlerocket machine configs are rejected during validation, so they are never rendered.
+func setEtcdDiskValues(values map[string]interface{}, etcdMachineSpec anywherev1.VSphereMachineConfigSpec) {
+	if etcdMachineSpec.EtcdDisk == nil || etcdMachineSpec.OSFamily == anywherev1.Bottlerocket {
+		return
+	}
+
+	values["etcdDataDiskGiB"] = etcdMachineSpec.EtcdDisk.SizeGiB
+	values["etcdDataDiskFiles"] = clusterapi.EtcdDiskFiles(etcdMachineSpec.EtcdDisk, etcdDataDiskDevice)
+	values["etcdDataDiskCommands"] = clusterapi.EtcdDiskCommands(etcdMachineSpec.EtcdDisk, etcdDataDiskDevice)
+}
+
+// vsphereNodeNetworkInterfaces are the VM interfaces when the datacenter has a workload network.
+// CAPV names the interfaces after the order of the VM network devices.
+var vsphereNodeNetworkInterfaces = &anywherev1.NodeNetworkInterfaces{Management: "eth0", Workload: "eth1"}
+
+// setWorkloadNetworkValues adds the workload network device of the VMs to the template values, with the
+// commands to use it for the kubelet node IP. Bottlerocket and Windows machine configs are rejected during
+// validation, so they are never rendered.
+func setWorkloadNetworkValues(values map[string]interface{}, datacenterSpec anywherev1.VSphereDatacenterConfigSpec, osFamily anywherev1.OSFamily) {
+	if datacenterSpec.WorkloadNetwork == "" || osFamily == anywherev1.Bottlerocket {
+		return
+	}
+
+	values["workloadVsphereNetwork"] = datacenterSpec.WorkloadNetwork
+	values["nodeIPCommands"] = clusterapi.NodeIPCommands(vsphereNodeNetworkInterfaces)
+	values["vipInterface"] = clusterapi.VIPInterface(vsphereNodeNetworkInterfaces)
+	// The cloud provider matches the VM networks by name to report the node addresses.
+	values["internalVMNetworkName"] = path.Base(datacenterSpec.WorkloadNetwork)
+	values["externalVMNetworkName"] = path.Base(datacenterSpec.Network)
+}
+
+// apiServerCertSANs returns the additional names to include in the API server certificate.
+// The API server needs to accept the address of an external load balancer and the DNS name of
+// the endpoint, kubeadm only adds the addresses of the nodes and the kube-vip IP.
+func apiServerCertSANs(controlPlane anywherev1.ControlPlaneConfiguration) []string {
+	sans := make([]string, 0, len(controlPlane.CertSANs)+1)
+	if controlPlane.Endpoint.UsesExternalLoadBalancer() || controlPlane.Endpoint.IsHostname() {
+		sans = append(sans, controlPlane.Endpoint.Hostname())
+	}
+	return append(sans, controlPlane.CertSANs...)
+}
@@ -96,6 +96,56 @@ func TestVsphereTemplateBuilderGenerateCAPISpecWorkersInvalidSSHKey(t *testing.T
 	)
 }
 
+func TestVsphereTemplateBuilderGenerateCAPISpecWorkersWindows(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	workerNodeGroup := spec.Cluster.Spec.WorkerNodeGroupConfigurations[0]
+	machineConfig := spec.VSphereMachineConfigs[workerNodeGroup.MachineGroupRef.Name].DeepCopy()
+	machineConfig.Name = "test-windows"
+	machineConfig.Spec.OSFamily = v1alpha1.Windows
+	machineConfig.Spec.Template = "/SDDC-Datacenter/vm/Templates/windows-2019-kube-v1.23"
+	spec.VSphereMachineConfigs[machineConfig.Name] = machineConfig
+	workerNodeGroup.MachineGroupRef = &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: machineConfig.Name}
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0] = workerNodeGroup
+
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecWorkers(spec,
+		map[string]string{workerNodeGroup.Name: "test-md-0-1"},
+		map[string]string{workerNodeGroup.Name: "test-md-0-template-1"},
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(data), "testdata/expected_results_main_windows_md.yaml")
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneWindowsWorkers(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	workerNodeGroup := spec.Cluster.Spec.WorkerNodeGroupConfigurations[0]
+	machineConfig := spec.VSphereMachineConfigs[workerNodeGroup.MachineGroupRef.Name].DeepCopy()
+	machineConfig.Name = "test-windows"
+	machineConfig.Spec.OSFamily = v1alpha1.Windows
+	spec.VSphereMachineConfigs[machineConfig.Name] = machineConfig
+	workerNodeGroup.MachineGroupRef = &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: machineConfig.Name}
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0] = workerNodeGroup
+
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring("  - kind: ConfigMap\n    name: windows-networking\n"))
+	g.Expect(string(data)).To(ContainSubstring("name: kube-proxy-windows"))
+	g.Expect(string(data)).To(ContainSubstring("image: mcr.microsoft.com/oss/kubernetes/windows-host-process-containers-base-image:v1.0.0"))
+	g.Expect(string(data)).To(ContainSubstring("- name: CLUSTER_CIDR\n              value: 192.168.0.0/16"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneNoWindowsWorkers(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).NotTo(ContainSubstring("windows-networking"))
+}
+
 func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneInvalidControlPlaneSSHKey(t *testing.T) {
 	g := NewWithT(t)
 	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
@@ -122,6 +172,421 @@ func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneInvalidEtcdSSHKey(t *
 	)
 }
 
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneEndpointHostname(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "api.cluster.example.com"
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring("certSANs:\n        - api.cluster.example.com"))
+	g.Expect(string(data)).To(ContainSubstring("host: api.cluster.example.com"))
+	g.Expect(string(data)).To(ContainSubstring("- name: address\n              value: api.cluster.example.com"))
+	g.Expect(string(data)).To(ContainSubstring("path: /etc/kubernetes/manifests/kube-vip.yaml"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneExternalLoadBalancer(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.ExternalLoadBalancer = true
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring("certSANs:\n        - 1.2.3.4"))
+	g.Expect(string(data)).NotTo(ContainSubstring("kube-vip"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneCertSANs(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.ExternalLoadBalancer = true
+	spec.Cluster.Spec.ControlPlaneConfiguration.CertSANs = []string{"api.example.com", "10.0.0.1"}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring("certSANs:\n        - 1.2.3.4\n        - api.example.com\n        - 10.0.0.1\n"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneStaticPodManifests(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ControlPlaneConfiguration.StaticPodManifests = []v1alpha1.StaticPodManifest{
+		{Name: "log-shipper", Content: "apiVersion: v1\nkind: Pod\nmetadata:\n  name: log-shipper\n"},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`    - content: |
+        apiVersion: v1
+        kind: Pod
+        metadata:
+          name: log-shipper
+      owner: root:root
+      path: /etc/kubernetes/manifests/log-shipper.yaml
+`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneContainerdConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ControlPlaneConfiguration.ContainerdConfiguration = &v1alpha1.ContainerdConfiguration{
+		SandboxImage: "registry.local/eks/pause:3.9",
+		EnableNRI:    true,
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`    - content: |
+        [plugins."io.containerd.nri.v1.nri"]
+          disable = false
+      owner: root:root
+      path: /etc/containerd/config_overrides.toml
+`))
+	g.Expect(string(data)).To(ContainSubstring(`    - cat /etc/containerd/config_overrides.toml >> /etc/containerd/config.toml
+    - sed -i 's|^\(\s*\)sandbox_image = .*|\1sandbox_image = "registry.local/eks/pause:3.9"|' /etc/containerd/config.toml
+    - sudo systemctl daemon-reload
+    - sudo systemctl restart containerd
+`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecWorkersContainerdConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].ContainerdConfiguration = &v1alpha1.ContainerdConfiguration{
+		RegistryHeaders: map[string]string{"Authorization": "Basic abc="},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`      files:
+      - content: |
+          [plugins."io.containerd.grpc.v1.cri".registry.headers]
+            "Authorization" = ["Basic abc="]
+        owner: root:root
+        path: /etc/containerd/config_overrides.toml
+      preKubeadmCommands:
+      - cat /etc/containerd/config_overrides.toml >> /etc/containerd/config.toml
+      - sudo systemctl daemon-reload
+      - sudo systemctl restart containerd
+`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecWorkersBottlerocketSandboxImage(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_bottlerocket_external_etcd.yaml")
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].ContainerdConfiguration = &v1alpha1.ContainerdConfiguration{
+		SandboxImage: "registry.local/eks/pause:3.9",
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`        pause:
+          imageRepository: registry.local/eks/pause
+          imageTag: "3.9"
+`))
+	g.Expect(string(data)).NotTo(ContainSubstring("config_overrides.toml"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecWorkersBottlerocketUpdates(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_bottlerocket_external_etcd.yaml")
+	spec.Cluster.Spec.BottlerocketUpdates = &v1alpha1.BottlerocketUpdatesConfiguration{}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring("node-labels: bottlerocket.aws/updater-interface-version=2.0.0"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneHostOSConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.VSphereMachineConfigs["test-cp"].Spec.HostOSConfiguration = &v1alpha1.HostOSConfiguration{
+		KernelModules: []string{"sctp"},
+		Sysctls:       map[string]string{"net.ipv4.ip_forward": "1"},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`    - content: |
+        sctp
+      owner: root:root
+      path: /etc/modules-load.d/eks-anywhere.conf
+    - content: |
+        net.ipv4.ip_forward = 1
+      owner: root:root
+      path: /etc/sysctl.d/99-eks-anywhere.conf
+`))
+	g.Expect(string(data)).To(ContainSubstring(`    - modprobe sctp
+    - sysctl --system
+    - hostname "{{ ds.meta_data.hostname }}"
+`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneKubeVip(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.KubeVip = &v1alpha1.KubeVipConfiguration{
+		LeaseDurationSeconds: 30,
+		RenewDeadlineSeconds: 20,
+		PrometheusPort:       2112,
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`            - name: vip_leaseduration
+              value: "30"
+            - name: vip_renewdeadline
+              value: "20"
+            - name: vip_retryperiod
+              value: "2"
+            - name: prometheus_server
+              value: ":2112"
+`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneEtcdDisk(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.VSphereMachineConfigs["test-etcd"].Spec.EtcdDisk = &v1alpha1.EtcdDiskConfiguration{
+		SizeGiB:        20,
+		IOScheduler:    "mq-deadline",
+		HighIOPriority: true,
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`      - echo "{{ ds.meta_data.hostname }}" >/etc/hostname
+      - mkfs.ext4 -L etcd-data /dev/sdb
+      - mkdir -p /var/lib/etcd
+      - echo "LABEL=etcd-data /var/lib/etcd ext4 defaults,noatime 0 2" >> /etc/fstab
+      - mount /var/lib/etcd
+      - echo mq-deadline > /sys/block/sdb/queue/scheduler
+    files:
+    - content: |
+        ACTION=="add|change", KERNEL=="sdb", ATTR{queue/scheduler}="mq-deadline"
+      owner: root:root
+      path: /etc/udev/rules.d/60-etcd-data-disk.rules
+    - content: |
+        [Service]
+        IOSchedulingClass=best-effort
+        IOSchedulingPriority=0
+      owner: root:root
+      path: /etc/systemd/system/etcd.service.d/10-io-priority.conf
+`))
+	g.Expect(string(data)).To(ContainSubstring(`      additionalDisksGiB:
+      - 20
+      datastore: /SDDC-Datacenter/datastore/WorkloadDatastore
+`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecWorkersHostOSConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.VSphereMachineConfigs["test-wn"].Spec.HostOSConfiguration = &v1alpha1.HostOSConfiguration{
+		KernelModules: []string{"br_netfilter"},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`      files:
+      - content: |
+          br_netfilter
+        owner: root:root
+        path: /etc/modules-load.d/eks-anywhere.conf
+      preKubeadmCommands:
+      - modprobe br_netfilter
+      - hostname "{{ ds.meta_data.hostname }}"
+`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecWorkersBottlerocketHostOSConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_bottlerocket_external_etcd.yaml")
+	spec.VSphereMachineConfigs["test-wn"].Spec.HostOSConfiguration = &v1alpha1.HostOSConfiguration{
+		KernelModules: []string{"br_netfilter"},
+		Sysctls:       map[string]string{"net/ipv4/ip_forward": "1"},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`        kernel:
+          modules:
+          - br_netfilter
+          sysctl:
+            net.ipv4.ip_forward: "1"
+`))
+	g.Expect(string(data)).NotTo(ContainSubstring("modprobe"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecWorkersImageCredentialProviders(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.KubeletConfiguration = &v1alpha1.KubeletConfiguration{
+		ImageCredentialProviders: []v1alpha1.ImageCredentialProvider{
+			{Name: "ecr-credential-provider", MatchImages: []string{"*.dkr.ecr.*.amazonaws.com"}},
+		},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`            feature-gates: KubeletCredentialProviders=true
+            image-credential-provider-bin-dir: /etc/kubernetes/image-credential-provider
+            image-credential-provider-config: /etc/kubernetes/image-credential-provider-config.yaml
+`))
+	g.Expect(string(data)).To(ContainSubstring(`      files:
+      - content: |
+          apiVersion: kubelet.config.k8s.io/v1alpha1
+          kind: CredentialProviderConfig
+          providers:
+          - name: "ecr-credential-provider"
+            apiVersion: credentialprovider.kubelet.k8s.io/v1alpha1
+            defaultCacheDuration: "12h"
+            matchImages:
+            - "*.dkr.ecr.*.amazonaws.com"
+        owner: root:root
+        path: /etc/kubernetes/image-credential-provider-config.yaml
+      preKubeadmCommands:
+`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneWorkloadNetwork(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.VSphereDatacenter.Spec.WorkloadNetwork = "/SDDC-Datacenter/network/sddc-cgw-network-2"
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`      network:
+        devices:
+        - dhcp4: true
+          networkName: /SDDC-Datacenter/network/sddc-cgw-network-1
+        - dhcp4: true
+          networkName: /SDDC-Datacenter/network/sddc-cgw-network-2
+`))
+	g.Expect(string(data)).To(ContainSubstring(`            - name: vip_interface
+              value: eth0
+`))
+	g.Expect(string(data)).To(ContainSubstring(`    - mkdir -p /etc/sysconfig
+    - echo "KUBELET_EXTRA_ARGS=--node-ip=$(ip -4 -o addr show dev eth1 | awk '{print $4}' | cut -d/ -f1 | head -n1)" | tee /etc/default/kubelet /etc/sysconfig/kubelet
+    - hostname "{{ ds.meta_data.hostname }}"
+`))
+	g.Expect(string(data)).To(ContainSubstring(`        nodes:
+          internalVmNetworkName: 'sddc-cgw-network-2'
+          externalVmNetworkName: 'sddc-cgw-network-1'
+`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecWorkersWorkloadNetwork(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.VSphereDatacenter.Spec.WorkloadNetwork = "/SDDC-Datacenter/network/sddc-cgw-network-2"
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`        - dhcp4: true
+          networkName: /SDDC-Datacenter/network/sddc-cgw-network-2
+`))
+	g.Expect(string(data)).To(ContainSubstring(`      preKubeadmCommands:
+      - for i in $(seq 1 60); do ip -4 -o addr show dev eth1 | grep -q inet && break; sleep 1; done
+`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneEndpointIP(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).NotTo(ContainSubstring("certSANs"))
+	g.Expect(string(data)).To(ContainSubstring("path: /etc/kubernetes/manifests/kube-vip.yaml"))
+}
+
 func invalidSSHKey() string {
 	return "ssh-rsa AAAA    B3NzaC1K73CeQ== testemail@test.com"
 }
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneEtcdEncryption(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.EtcdEncryption = []v1alpha1.EtcdEncryption{
+		{
+			Providers: []v1alpha1.EtcdEncryptionProvider{
+				{KMS: &v1alpha1.KMS{Name: "aws-encryption-provider", SocketListenAddress: "unix:///var/run/kmsplugin/socket.sock"}},
+			},
+		},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`          encryption-provider-config: /etc/kubernetes/enc/encryption-config.yaml
+`))
+	g.Expect(string(data)).To(ContainSubstring(`        - hostPath: /etc/kubernetes/enc
+          mountPath: /etc/kubernetes/enc
+          name: encryption-config
+          pathType: DirectoryOrCreate
+          readOnly: true
+        - hostPath: /var/run/kmsplugin
+          mountPath: /var/run/kmsplugin
+          name: kms-plugin-0
+          pathType: DirectoryOrCreate
+          readOnly: false
+      controllerManager:
+`))
+	g.Expect(string(data)).To(ContainSubstring(`    - content: |
+        apiVersion: apiserver.config.k8s.io/v1
+        kind: EncryptionConfiguration
+        resources:
+        - resources:
+          - "secrets"
+          providers:
+          - kms:
+              name: "aws-encryption-provider"
+              endpoint: "unix:///var/run/kmsplugin/socket.sock"
+          - identity: {}
+      owner: root:root
+      path: /etc/kubernetes/enc/encryption-config.yaml
+      permissions: "0600"
+`))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecSerialConsole(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	workerMachineConfigName := spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name
+	spec.VSphereMachineConfigs[workerMachineConfigName].Spec.SerialConsole = &v1alpha1.SerialConsoleConfiguration{Enabled: true}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	serialConsole := `      customVMXKeys:
+        serial0.fileName: serial-console.log
+        serial0.fileType: file
+        serial0.present: "TRUE"
+`
+
+	data, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(serialConsole))
+
+	data, err = builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).NotTo(ContainSubstring("customVMXKeys"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneBottlerocketEtcdEncryption(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_bottlerocket_external_etcd.yaml")
+	spec.Cluster.Spec.EtcdEncryption = []v1alpha1.EtcdEncryption{
+		{
+			Providers: []v1alpha1.EtcdEncryptionProvider{
+				{KMS: &v1alpha1.KMS{Name: "aws-encryption-provider", SocketListenAddress: "unix:///var/run/kmsplugin/socket.sock"}},
+			},
+		},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now, false)
+	data, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`        - hostPath: /var/lib/kubeadm/enc
+          mountPath: /etc/kubernetes/enc
+          name: encryption-config
+`))
+	g.Expect(string(data)).To(ContainSubstring(`      path: /etc/kubernetes/enc/encryption-config.yaml
+`))
+}
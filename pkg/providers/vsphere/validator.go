@@ -8,19 +8,30 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/semver"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
 const (
 	vsphereRootPath = "/"
+	// apiServerPort is the port the external load balancer is expected to serve the API server on.
+	apiServerPort = "6443"
+	// windowsMinKubeVersion is the first Kubernetes version with HostProcess containers enabled by default.
+	windowsMinKubeVersion = anywherev1.Kube123
+	// maxParallelMachineConfigValidations bounds the machine config validations calling vCenter at the same time.
+	maxParallelMachineConfigValidations = 5
 )
 
 type PrivAssociation struct {
@@ -40,10 +51,16 @@ type VSphereClientBuilder interface {
 	Build(ctx context.Context, host string, username string, password string, insecure bool, datacenter string) (govmomi.VSphereClient, error)
 }
 
+// hostResolver resolves DNS names to addresses.
+type hostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
 type Validator struct {
 	govc                 ProviderGovcClient
 	netClient            networkutils.NetClient
 	vSphereClientBuilder VSphereClientBuilder
+	resolver             hostResolver
 }
 
 func NewValidator(govc ProviderGovcClient, netClient networkutils.NetClient, vscb VSphereClientBuilder) *Validator {
@@ -51,6 +68,7 @@ func NewValidator(govc ProviderGovcClient, netClient networkutils.NetClient, vsc
 		govc:                 govc,
 		netClient:            netClient,
 		vSphereClientBuilder: vscb,
+		resolver:             net.DefaultResolver,
 	}
 }
 
@@ -87,13 +105,52 @@ func (v *Validator) ValidateVCenterConfig(ctx context.Context, datacenterConfig
 	}
 	logger.MarkPass("Network validated")
 
+	if datacenterConfig.Spec.WorkloadNetwork != "" {
+		if err := v.validateNetwork(ctx, datacenterConfig.Spec.WorkloadNetwork); err != nil {
+			return err
+		}
+		logger.MarkPass("Workload network validated")
+	}
+
 	return nil
 }
 
 // TODO: dry out machine configs validations.
 func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereClusterSpec *Spec) error {
-	var etcdMachineConfig *anywherev1.VSphereMachineConfig
+	if err := v.validateMachineConfigReferences(vsphereClusterSpec); err != nil {
+		return err
+	}
 
+	if err := v.validateControlPlaneEndpoint(ctx, vsphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint); err != nil {
+		return err
+	}
+
+	if err := validateContainerdConfigurations(vsphereClusterSpec); err != nil {
+		return err
+	}
+
+	if err := validateEtcdHostOSConfiguration(vsphereClusterSpec); err != nil {
+		return err
+	}
+
+	if err := validateEtcdDisk(vsphereClusterSpec); err != nil {
+		return err
+	}
+
+	if err := validateImageCredentialProviders(vsphereClusterSpec); err != nil {
+		return err
+	}
+
+	if err := validateWorkloadNetwork(vsphereClusterSpec); err != nil {
+		return err
+	}
+
+	return v.validateMachineConfigsInVCenter(ctx, vsphereClusterSpec, vsphereClusterSpec.controlPlaneMachineConfig(), vsphereClusterSpec.etcdMachineConfig())
+}
+
+// validateMachineConfigReferences checks the machine configs referenced by the cluster exist and are consistent.
+// The rest of the validations rely on it, so it should run before them.
+func (v *Validator) validateMachineConfigReferences(vsphereClusterSpec *Spec) error {
 	controlPlaneMachineConfig := vsphereClusterSpec.controlPlaneMachineConfig()
 	if controlPlaneMachineConfig == nil {
 		return fmt.Errorf("cannot find VSphereMachineConfig %v for control plane", vsphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name)
@@ -106,46 +163,327 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		}
 	}
 	if vsphereClusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
-		etcdMachineConfig = vsphereClusterSpec.etcdMachineConfig()
+		etcdMachineConfig := vsphereClusterSpec.etcdMachineConfig()
 		if etcdMachineConfig == nil {
 			return fmt.Errorf("cannot find VSphereMachineConfig %v for etcd machines", vsphereClusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name)
 		}
-		if !v.sameOSFamily(vsphereClusterSpec.VSphereMachineConfigs) {
+		linuxMachineConfigs := linuxMachineConfigs(vsphereClusterSpec.VSphereMachineConfigs)
+		if !v.sameOSFamily(linuxMachineConfigs) {
 			return errors.New("all VSphereMachineConfigs must have the same osFamily specified")
 		}
-		if !v.sameTemplate(vsphereClusterSpec.VSphereMachineConfigs) {
+		if !v.sameTemplate(machineConfigsWithArch(linuxMachineConfigs, controlPlaneMachineConfig.Arch())) {
 			return errors.New("all VSphereMachineConfigs must have the same template specified")
 		}
 	}
 
-	// TODO: move this to api Cluster validations
-	if err := v.validateControlPlaneIp(vsphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host); err != nil {
+	return nil
+}
+
+// validateMachineConfigsInVCenter validates the machine configs against vCenter and reports all the failures
+// at once. The datastore usage is only validated once the vCenter setup, which includes the datastores, is valid.
+func (v *Validator) validateMachineConfigsInVCenter(ctx context.Context, vsphereClusterSpec *Spec, controlPlaneMachineConfig, etcdMachineConfig *anywherev1.VSphereMachineConfig) error {
+	setupErr := v.validateVCenterSetup(ctx, vsphereClusterSpec)
+	templatesErr := v.validateTemplates(ctx, vsphereClusterSpec, controlPlaneMachineConfig)
+	if setupErr != nil {
+		return validations.AggregateErrors(setupErr, templatesErr)
+	}
+
+	return validations.AggregateErrors(templatesErr, v.validateDatastoreUsage(ctx, vsphereClusterSpec, controlPlaneMachineConfig, etcdMachineConfig))
+}
+
+// validateTemplates validates the templates of the control plane and the worker node groups.
+func (v *Validator) validateTemplates(ctx context.Context, vsphereClusterSpec *Spec, controlPlaneMachineConfig *anywherev1.VSphereMachineConfig) error {
+	controlPlaneErr := v.validateTemplate(ctx, vsphereClusterSpec, controlPlaneMachineConfig)
+	if controlPlaneErr != nil {
+		logger.V(1).Info("Control plane template validation failed.")
+	}
+
+	err := validations.AggregateErrors(
+		controlPlaneErr,
+		v.validateWindowsWorkers(ctx, vsphereClusterSpec),
+		v.validateArchitecture(ctx, vsphereClusterSpec),
+	)
+	if err != nil {
 		return err
 	}
+	logger.MarkPass("Control plane and Workload templates validated")
 
-	for _, config := range vsphereClusterSpec.VSphereMachineConfigs {
-		var b bool                                                                                             // Temporary until we remove the need to pass a bool pointer
-		err := v.govc.ValidateVCenterSetupMachineConfig(ctx, vsphereClusterSpec.VSphereDatacenter, config, &b) // TODO: remove side effects from this implementation or directly move it to set defaults (pointer to bool is not needed)
-		if err != nil {
-			return fmt.Errorf("validating vCenter setup for VSphereMachineConfig %v: %v", config.Name, err)
+	return nil
+}
+
+// validateVCenterSetup validates the vCenter resources of all the machine configs. Validating a machine
+// config creates its folder when missing, so the machine configs sharing a folder are validated serially
+// and only the different folders are validated concurrently.
+func (v *Validator) validateVCenterSetup(ctx context.Context, vsphereClusterSpec *Spec) error {
+	groups := machineConfigsByFolder(vsphereClusterSpec.machineConfigs())
+	checks := make([]func() error, 0, len(groups))
+	for _, group := range groups {
+		group := group
+		checks = append(checks, func() error {
+			errs := make([]error, 0, len(group))
+			for _, config := range group {
+				var b bool                                                                                             // Temporary until we remove the need to pass a bool pointer
+				err := v.govc.ValidateVCenterSetupMachineConfig(ctx, vsphereClusterSpec.VSphereDatacenter, config, &b) // TODO: remove side effects from this implementation or directly move it to set defaults (pointer to bool is not needed)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("validating vCenter setup for VSphereMachineConfig %v: %v", config.Name, err))
+				}
+			}
+			return validations.AggregateErrors(errs...)
+		})
+	}
+
+	return validations.RunConcurrently(maxParallelMachineConfigValidations, checks...)
+}
+
+// machineConfigsByFolder groups the machine configs by folder, keeping their order. Machine configs without
+// a folder don't create one and are each in their own group.
+func machineConfigsByFolder(machineConfigs []*anywherev1.VSphereMachineConfig) [][]*anywherev1.VSphereMachineConfig {
+	groups := make([][]*anywherev1.VSphereMachineConfig, 0, len(machineConfigs))
+	folderGroups := map[string]int{}
+	for _, config := range machineConfigs {
+		folder := config.Spec.Folder
+		if folder == "" {
+			groups = append(groups, []*anywherev1.VSphereMachineConfig{config})
+			continue
+		}
+		if i, ok := folderGroups[folder]; ok {
+			groups[i] = append(groups[i], config)
+			continue
 		}
+		folderGroups[folder] = len(groups)
+		groups = append(groups, []*anywherev1.VSphereMachineConfig{config})
 	}
 
-	if err := v.validateTemplate(ctx, vsphereClusterSpec, controlPlaneMachineConfig); err != nil {
-		logger.V(1).Info("Control plane template validation failed.")
+	return groups
+}
+
+// validateWorkerTemplates validates the templates of worker node groups concurrently.
+func (v *Validator) validateWorkerTemplates(ctx context.Context, vsphereClusterSpec *Spec, groups []anywherev1.WorkerNodeGroupConfiguration, errFormat string) error {
+	checks := make([]func() error, 0, len(groups))
+	for _, group := range groups {
+		group := group
+		checks = append(checks, func() error {
+			machineConfig := vsphereClusterSpec.workerMachineConfig(group)
+			if err := v.validateTemplate(ctx, vsphereClusterSpec, machineConfig); err != nil {
+				return fmt.Errorf(errFormat, group.Name, err)
+			}
+			return nil
+		})
+	}
+
+	return validations.RunConcurrently(maxParallelMachineConfigValidations, checks...)
+}
+
+// validateWindowsWorkers validates the worker node groups using Windows machine configs. Windows
+// nodes rely on HostProcess containers for kube-proxy and the CNI, and their bootstrap doesn't
+// configure a proxy or a registry mirror.
+func (v *Validator) validateWindowsWorkers(ctx context.Context, vsphereClusterSpec *Spec) error {
+	groups := vsphereClusterSpec.WindowsWorkerNodeGroups()
+	if len(groups) == 0 {
+		return nil
+	}
+
+	kubeVersion, err := semver.KubeVersionToValidSemver(vsphereClusterSpec.Cluster.Spec.KubernetesVersion)
+	if err != nil {
+		return fmt.Errorf("validating windows worker node groups: %v", err)
+	}
+	minKubeVersion, _ := semver.KubeVersionToValidSemver(windowsMinKubeVersion)
+	if kubeVersion.LessThan(minKubeVersion) {
+		return fmt.Errorf("windows worker node groups require kubernetes version %s or later", windowsMinKubeVersion)
+	}
+
+	if vsphereClusterSpec.Cluster.Spec.ProxyConfiguration != nil {
+		return errors.New("proxyConfiguration is not supported with windows worker node groups")
+	}
+
+	if vsphereClusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
+		return errors.New("registryMirrorConfiguration is not supported with windows worker node groups")
+	}
+
+	if err := v.validateWorkerTemplates(ctx, vsphereClusterSpec, groups, "validating template for windows worker node group %s: %v"); err != nil {
 		return err
 	}
-	logger.MarkPass("Control plane and Workload templates validated")
+	logger.MarkPass("Windows worker node groups validated")
+
+	return nil
+}
+
+// validateArchitecture validates the architectures of the machine configs. Worker node groups can
+// use a different architecture than the control plane, in which case they need their own template.
+func (v *Validator) validateArchitecture(ctx context.Context, vsphereClusterSpec *Spec) error {
+	cpArch := vsphereClusterSpec.controlPlaneMachineConfig().Arch()
+	if etcdMachineConfig := vsphereClusterSpec.etcdMachineConfig(); etcdMachineConfig != nil && etcdMachineConfig.Arch() != cpArch {
+		return fmt.Errorf("etcd architecture %s must be the same as control plane architecture %s", etcdMachineConfig.Arch(), cpArch)
+	}
+
+	var otherArchGroups []anywherev1.WorkerNodeGroupConfiguration
+	for _, group := range vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineConfig := vsphereClusterSpec.workerMachineConfig(group)
+		if machineConfig.Arch() != cpArch && machineConfig.OSFamily() != anywherev1.Windows {
+			otherArchGroups = append(otherArchGroups, group)
+		}
+	}
+	if err := v.validateWorkerTemplates(ctx, vsphereClusterSpec, otherArchGroups, "validating template for worker node group %s: %v"); err != nil {
+		return err
+	}
+
+	// Images running on every node, like the CNI, must be available for all the architectures in use.
+	checked := map[anywherev1.Architecture]struct{}{anywherev1.DefaultArchitecture: {}}
+	for _, machineConfig := range vsphereClusterSpec.machineConfigs() {
+		arch := machineConfig.Arch()
+		if _, ok := checked[arch]; ok {
+			continue
+		}
+		checked[arch] = struct{}{}
 
-	return v.validateDatastoreUsage(ctx, vsphereClusterSpec, controlPlaneMachineConfig, etcdMachineConfig)
+		if err := vsphereClusterSpec.ValidateImagesArch(constants.VSphereProviderName, arch); err != nil {
+			return err
+		}
+		logger.MarkPass("Images validated for architecture", "arch", arch)
+	}
+
+	return nil
+}
+
+func linuxMachineConfigs(configs map[string]*anywherev1.VSphereMachineConfig) map[string]*anywherev1.VSphereMachineConfig {
+	linux := make(map[string]*anywherev1.VSphereMachineConfig, len(configs))
+	for name, config := range configs {
+		if config.OSFamily() != anywherev1.Windows {
+			linux[name] = config
+		}
+	}
+	return linux
+}
+
+func machineConfigsWithArch(configs map[string]*anywherev1.VSphereMachineConfig, arch anywherev1.Architecture) map[string]*anywherev1.VSphereMachineConfig {
+	filtered := make(map[string]*anywherev1.VSphereMachineConfig, len(configs))
+	for name, config := range configs {
+		if config.Arch() == arch {
+			filtered[name] = config
+		}
+	}
+	return filtered
+}
+
+// validateEtcdHostOSConfiguration checks the external etcd machines don't set a host OS configuration,
+// since etcdadm doesn't support running the commands needed to apply it.
+func validateEtcdHostOSConfiguration(vsphereClusterSpec *Spec) error {
+	etcdMachineConfig := vsphereClusterSpec.etcdMachineConfig()
+	if etcdMachineConfig == nil || etcdMachineConfig.Spec.HostOSConfiguration == nil {
+		return nil
+	}
+
+	return fmt.Errorf("hostOSConfiguration is not supported for etcd machines, VSphereMachineConfig %s", etcdMachineConfig.Name)
+}
+
+// validateEtcdDisk checks only the machine config of the external etcd machines sets an etcd disk
+// configuration, it's ignored for the control plane and worker machines.
+func validateEtcdDisk(vsphereClusterSpec *Spec) error {
+	etcdMachineConfig := vsphereClusterSpec.etcdMachineConfig()
+	for _, machineConfig := range vsphereClusterSpec.machineConfigs() {
+		if !machineConfig.Spec.EtcdDisk.Equal(nil) && machineConfig != etcdMachineConfig {
+			return fmt.Errorf("etcdDisk is only supported for etcd machines, VSphereMachineConfig %s", machineConfig.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateImageCredentialProviders checks the nodes support the kubelet image credential providers.
+// Bottlerocket and Windows nodes don't get the kubelet image credential provider config.
+func validateImageCredentialProviders(vsphereClusterSpec *Spec) error {
+	if len(vsphereClusterSpec.Cluster.ImageCredentialProviders()) == 0 {
+		return nil
+	}
+
+	for _, machineConfig := range vsphereClusterSpec.machineConfigs() {
+		if osFamily := machineConfig.OSFamily(); osFamily == anywherev1.Bottlerocket || osFamily == anywherev1.Windows {
+			return fmt.Errorf("kubeletConfiguration.imageCredentialProviders is not supported for osFamily %s, VSphereMachineConfig %s", osFamily, machineConfig.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateWorkloadNetwork checks the control plane and worker nodes support a workload network.
+// Bottlerocket and Windows nodes can't select the kubelet node IP from the workload network interface.
+func validateWorkloadNetwork(vsphereClusterSpec *Spec) error {
+	if vsphereClusterSpec.VSphereDatacenter.Spec.WorkloadNetwork == "" {
+		return nil
+	}
+
+	etcdMachineConfig := vsphereClusterSpec.etcdMachineConfig()
+	for _, machineConfig := range vsphereClusterSpec.machineConfigs() {
+		if etcdMachineConfig != nil && machineConfig.Name == etcdMachineConfig.Name {
+			continue
+		}
+		if osFamily := machineConfig.OSFamily(); osFamily == anywherev1.Bottlerocket || osFamily == anywherev1.Windows {
+			return fmt.Errorf("workloadNetwork is not supported for osFamily %s, VSphereMachineConfig %s", osFamily, machineConfig.Name)
+		}
+	}
+
+	return nil
 }
 
-func (v *Validator) validateControlPlaneIp(ip string) error {
-	// check if controlPlaneEndpointIp is valid
-	parsedIp := net.ParseIP(ip)
-	if parsedIp == nil {
-		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host is invalid: %s", ip)
+// validateContainerdConfigurations checks the containerd overrides of each node group are supported by its OS.
+func validateContainerdConfigurations(vsphereClusterSpec *Spec) error {
+	controlPlane := vsphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration
+	if err := validateContainerdConfiguration(controlPlane.ContainerdConfiguration, vsphereClusterSpec.controlPlaneMachineConfig(), "control plane"); err != nil {
+		return err
+	}
+
+	for _, workerNodeGroup := range vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineConfig := vsphereClusterSpec.workerMachineConfig(workerNodeGroup)
+		if err := validateContainerdConfiguration(workerNodeGroup.ContainerdConfiguration, machineConfig, workerNodeGroup.Name); err != nil {
+			return err
+		}
 	}
+
+	return nil
+}
+
+func validateContainerdConfiguration(config *anywherev1.ContainerdConfiguration, machineConfig *anywherev1.VSphereMachineConfig, nodeGroup string) error {
+	if config == nil || machineConfig.Spec.OSFamily != anywherev1.Bottlerocket {
+		return nil
+	}
+
+	if len(config.RegistryHeaders) > 0 || config.EnableNRI {
+		return fmt.Errorf("containerd registryHeaders and enableNRI are not supported for Bottlerocket, node group %s only supports overriding the sandboxImage", nodeGroup)
+	}
+
+	return nil
+}
+
+// validateControlPlaneEndpoint checks the control plane endpoint is either an IP or a DNS name that
+// resolves. kube-vip serves a single address, so without an external load balancer the name must resolve
+// to exactly one IP, the one kube-vip assigns to the control plane nodes.
+func (v *Validator) validateControlPlaneEndpoint(ctx context.Context, endpoint *anywherev1.Endpoint) error {
+	if !endpoint.IsHostname() {
+		return nil
+	}
+
+	if errs := validation.IsDNS1123Subdomain(strings.ToLower(endpoint.Hostname())); len(errs) > 0 {
+		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host is invalid, it must be an IP or a DNS name: %s", endpoint.Host)
+	}
+
+	addresses, err := v.resolver.LookupHost(ctx, endpoint.Hostname())
+	if err != nil {
+		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host %s can't be resolved: %v", endpoint.Host, err)
+	}
+	if len(addresses) == 0 {
+		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host %s doesn't resolve to any address", endpoint.Host)
+	}
+	if endpoint.UsesExternalLoadBalancer() {
+		return nil
+	}
+
+	if len(addresses) > 1 {
+		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host %s resolves to %d addresses, kube-vip needs a name that resolves to a single IP", endpoint.Host, len(addresses))
+	}
+	if err := networkutils.ValidateIP(addresses[0]); err != nil {
+		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host %s doesn't resolve to a valid kube-vip IP: %v", endpoint.Host, err)
+	}
+
 	return nil
 }
 
@@ -305,10 +643,31 @@ func (v *Validator) validateNetwork(ctx context.Context, network string) error {
 	return nil
 }
 
-func (v *Validator) validateControlPlaneIpUniqueness(spec *Spec) error {
-	ip := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
+// validateControlPlaneIpUniqueness checks the kube-vip IP is not in use. For a DNS name, it checks the IP the
+// name resolves to. Names that don't resolve to a single IP are reported by validateControlPlaneEndpoint.
+func (v *Validator) validateControlPlaneIpUniqueness(ctx context.Context, spec *Spec) error {
+	endpoint := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint
+	ip := endpoint.Host
+	if endpoint.IsHostname() {
+		addresses, err := v.resolver.LookupHost(ctx, endpoint.Hostname())
+		if err != nil || len(addresses) != 1 {
+			return nil
+		}
+		ip = addresses[0]
+	}
+
 	if networkutils.IsIPInUse(v.netClient, ip) {
-		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host <%s> is already in use, please provide a unique IP", ip)
+		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host <%s> is already in use, please provide a unique IP", endpoint.Host)
+	}
+	return nil
+}
+
+// validateExternalLoadBalancer checks the external load balancer serving the control plane endpoint
+// accepts connections in the API server port.
+func (v *Validator) validateExternalLoadBalancer(spec *Spec) error {
+	endpoint := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint
+	if !networkutils.IsPortInUse(v.netClient, endpoint.Host, apiServerPort) {
+		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host <%s> is not reachable on port %s, the external load balancer must be configured before creating the cluster", endpoint.Host, apiServerPort)
 	}
 	return nil
 }
@@ -349,6 +708,13 @@ func (v *Validator) validateUserPrivs(ctx context.Context, spec *Spec, vuc *conf
 			path:         spec.VSphereDatacenter.Spec.Network,
 		},
 	}
+	if spec.VSphereDatacenter.Spec.WorkloadNetwork != "" {
+		requiredPrivAssociations = append(requiredPrivAssociations, PrivAssociation{
+			objectType:   govmomi.VSphereTypeNetwork,
+			privsContent: config.VSphereUserPrivsFile,
+			path:         spec.VSphereDatacenter.Spec.WorkloadNetwork,
+		})
+	}
 
 	seen := map[string]interface{}{}
 	for _, mc := range machineConfigs {
@@ -3,15 +3,23 @@ package vsphere
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/govmomi/mocks"
+	vspheremocks "github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 func TestValidatorValidatePrivs(t *testing.T) {
@@ -131,3 +139,200 @@ func TestValidatorValidatePrivsBadJson(t *testing.T) {
 	_, err := v.validatePrivs(ctx, objects, vsc)
 	g.Expect(err).To(MatchError(ContainSubstring(errMsg)))
 }
+
+func TestValidatorValidateArchitectureEtcdDifferentFromControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	spec := NewSpec(test.NewFullClusterSpec(t, "testdata/cluster_main.yaml"))
+	spec.VSphereMachineConfigs["test-cp"].Spec.Architecture = anywherev1.ARM64
+
+	v := Validator{}
+	g.Expect(v.validateArchitecture(context.Background(), spec)).To(MatchError(
+		"etcd architecture amd64 must be the same as control plane architecture arm64",
+	))
+}
+
+func TestValidatorValidateArchitectureImagesNotAvailable(t *testing.T) {
+	g := NewWithT(t)
+	spec := NewSpec(test.NewFullClusterSpec(t, "testdata/cluster_main.yaml"))
+	for _, m := range spec.VSphereMachineConfigs {
+		m.Spec.Architecture = anywherev1.ARM64
+	}
+	spec.VersionsBundle.Cilium.Cilium = releasev1.Image{URI: "public.ecr.aws/cilium:v1", Arch: []string{"amd64"}}
+
+	v := Validator{}
+	g.Expect(v.validateArchitecture(context.Background(), spec)).To(MatchError(
+		ContainSubstring("images not available for architecture arm64: public.ecr.aws/cilium:v1"),
+	))
+}
+
+func TestValidatorValidateVCenterSetupReportsAllErrors(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := NewSpec(test.NewFullClusterSpec(t, "testdata/cluster_main.yaml"))
+	govc := vspheremocks.NewMockProviderGovcClient(gomock.NewController(t))
+	v := NewValidator(govc, nil, nil)
+
+	for name, m := range spec.VSphereMachineConfigs {
+		var err error
+		if name != "test-cp" {
+			err = fmt.Errorf("datastore %s not found", m.Spec.Datastore)
+		}
+		govc.EXPECT().ValidateVCenterSetupMachineConfig(ctx, spec.VSphereDatacenter, m, gomock.Any()).Return(err)
+	}
+
+	g.Expect(v.validateVCenterSetup(ctx, spec)).To(MatchError(
+		"validation failed with 2 errors: " +
+			"validating vCenter setup for VSphereMachineConfig test-etcd: datastore /SDDC-Datacenter/datastore/WorkloadDatastore not found," +
+			"validating vCenter setup for VSphereMachineConfig test-wn: datastore /SDDC-Datacenter/datastore/WorkloadDatastore not found",
+	))
+}
+
+func TestValidatorValidateVCenterSetupSharedFolderSerially(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := NewSpec(test.NewFullClusterSpec(t, "testdata/cluster_main.yaml"))
+	for _, m := range spec.VSphereMachineConfigs {
+		m.Spec.Folder = "/SDDC-Datacenter/vm/shared"
+	}
+	govc := vspheremocks.NewMockProviderGovcClient(gomock.NewController(t))
+	v := NewValidator(govc, nil, nil)
+
+	var mu sync.Mutex
+	running := 0
+	for _, m := range spec.VSphereMachineConfigs {
+		govc.EXPECT().ValidateVCenterSetupMachineConfig(ctx, spec.VSphereDatacenter, m, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *anywherev1.VSphereDatacenterConfig, _ *anywherev1.VSphereMachineConfig, _ *bool) error {
+				mu.Lock()
+				running++
+				concurrent := running
+				mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				running--
+				mu.Unlock()
+				if concurrent > 1 {
+					return errors.New("validated concurrently with a machine config sharing its folder")
+				}
+				return nil
+			},
+		)
+	}
+
+	g.Expect(v.validateVCenterSetup(ctx, spec)).To(Succeed())
+}
+
+func TestMachineConfigsByFolder(t *testing.T) {
+	g := NewWithT(t)
+	configs := []*anywherev1.VSphereMachineConfig{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cp"}, Spec: anywherev1.VSphereMachineConfigSpec{Folder: "/dc/vm/a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "etcd"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "md0"}, Spec: anywherev1.VSphereMachineConfigSpec{Folder: "/dc/vm/b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "md1"}, Spec: anywherev1.VSphereMachineConfigSpec{Folder: "/dc/vm/a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "md2"}},
+	}
+
+	g.Expect(machineConfigsByFolder(configs)).To(Equal([][]*anywherev1.VSphereMachineConfig{
+		{configs[0], configs[3]},
+		{configs[1]},
+		{configs[2]},
+		{configs[4]},
+	}))
+}
+
+func TestMachineConfigsWithArch(t *testing.T) {
+	g := NewWithT(t)
+	configs := map[string]*anywherev1.VSphereMachineConfig{
+		"cp":  {Spec: anywherev1.VSphereMachineConfigSpec{}},
+		"md0": {Spec: anywherev1.VSphereMachineConfigSpec{Architecture: anywherev1.ARM64}},
+		"md1": {Spec: anywherev1.VSphereMachineConfigSpec{Architecture: anywherev1.AMD64}},
+	}
+
+	g.Expect(machineConfigsWithArch(configs, anywherev1.AMD64)).To(HaveLen(2))
+	g.Expect(machineConfigsWithArch(configs, anywherev1.ARM64)).To(HaveKey("md0"))
+}
+
+func TestValidateEtcdHostOSConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	spec := NewSpec(test.NewFullClusterSpec(t, "testdata/cluster_main.yaml"))
+	g.Expect(validateEtcdHostOSConfiguration(spec)).To(Succeed())
+
+	spec.VSphereMachineConfigs["test-etcd"].Spec.HostOSConfiguration = &anywherev1.HostOSConfiguration{
+		KernelModules: []string{"sctp"},
+	}
+	g.Expect(validateEtcdHostOSConfiguration(spec)).To(MatchError(
+		"hostOSConfiguration is not supported for etcd machines, VSphereMachineConfig test-etcd",
+	))
+}
+
+func TestValidateEtcdDisk(t *testing.T) {
+	g := NewWithT(t)
+	spec := NewSpec(test.NewFullClusterSpec(t, "testdata/cluster_main.yaml"))
+	spec.VSphereMachineConfigs["test-etcd"].Spec.EtcdDisk = &anywherev1.EtcdDiskConfiguration{SizeGiB: 20}
+	g.Expect(validateEtcdDisk(spec)).To(Succeed())
+
+	spec.VSphereMachineConfigs["test-cp"].Spec.EtcdDisk = &anywherev1.EtcdDiskConfiguration{HighIOPriority: true}
+	g.Expect(validateEtcdDisk(spec)).To(MatchError(
+		"etcdDisk is only supported for etcd machines, VSphereMachineConfig test-cp",
+	))
+}
+
+func TestValidateImageCredentialProviders(t *testing.T) {
+	g := NewWithT(t)
+	spec := NewSpec(test.NewFullClusterSpec(t, "testdata/cluster_main.yaml"))
+	spec.Cluster.Spec.KubeletConfiguration = &anywherev1.KubeletConfiguration{
+		ImageCredentialProviders: []anywherev1.ImageCredentialProvider{
+			{Name: "ecr-credential-provider", MatchImages: []string{"*.dkr.ecr.*.amazonaws.com"}},
+		},
+	}
+	g.Expect(validateImageCredentialProviders(spec)).To(Succeed())
+
+	spec.VSphereMachineConfigs["test-wn"].Spec.OSFamily = anywherev1.Bottlerocket
+	g.Expect(validateImageCredentialProviders(spec)).To(MatchError(
+		"kubeletConfiguration.imageCredentialProviders is not supported for osFamily bottlerocket, VSphereMachineConfig test-wn",
+	))
+}
+
+func TestValidateWorkloadNetwork(t *testing.T) {
+	g := NewWithT(t)
+	spec := NewSpec(test.NewFullClusterSpec(t, "testdata/cluster_bottlerocket_external_etcd.yaml"))
+	g.Expect(validateWorkloadNetwork(spec)).To(Succeed())
+
+	spec.VSphereDatacenter.Spec.WorkloadNetwork = "/SDDC-Datacenter/network/sddc-cgw-network-2"
+	g.Expect(validateWorkloadNetwork(spec)).To(MatchError(
+		"workloadNetwork is not supported for osFamily bottlerocket, VSphereMachineConfig test-cp",
+	))
+}
+
+func TestValidateWorkloadNetworkIgnoresEtcd(t *testing.T) {
+	g := NewWithT(t)
+	spec := NewSpec(test.NewFullClusterSpec(t, "testdata/cluster_main.yaml"))
+	spec.VSphereDatacenter.Spec.WorkloadNetwork = "/SDDC-Datacenter/network/sddc-cgw-network-2"
+	spec.VSphereMachineConfigs["test-etcd"].Spec.OSFamily = anywherev1.Bottlerocket
+	g.Expect(validateWorkloadNetwork(spec)).To(Succeed())
+}
+
+func TestValidateContainerdConfigurationsBottlerocket(t *testing.T) {
+	g := NewWithT(t)
+	spec := NewSpec(test.NewFullClusterSpec(t, "testdata/cluster_bottlerocket_external_etcd.yaml"))
+	spec.Cluster.Spec.ControlPlaneConfiguration.ContainerdConfiguration = &anywherev1.ContainerdConfiguration{
+		SandboxImage: "registry.local/eks/pause:3.9",
+	}
+	g.Expect(validateContainerdConfigurations(spec)).To(Succeed())
+
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].ContainerdConfiguration = &anywherev1.ContainerdConfiguration{
+		EnableNRI: true,
+	}
+	g.Expect(validateContainerdConfigurations(spec)).To(MatchError(
+		"containerd registryHeaders and enableNRI are not supported for Bottlerocket, node group md-0 only supports overriding the sandboxImage",
+	))
+}
+
+func TestValidateContainerdConfigurationsUbuntu(t *testing.T) {
+	g := NewWithT(t)
+	spec := NewSpec(test.NewFullClusterSpec(t, "testdata/cluster_main.yaml"))
+	spec.Cluster.Spec.ControlPlaneConfiguration.ContainerdConfiguration = &anywherev1.ContainerdConfiguration{
+		RegistryHeaders: map[string]string{"Authorization": "Basic abc="},
+		EnableNRI:       true,
+	}
+	g.Expect(validateContainerdConfigurations(spec)).To(Succeed())
+}
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"text/template"
 	"time"
 
 	"github.com/Masterminds/sprig"
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
@@ -30,6 +33,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -46,6 +50,10 @@ const (
 	defaultTemplatesFolder   = "vm/Templates"
 	maxRetries               = 30
 	backOffPeriod            = 5 * time.Second
+
+	// windowsHostProcessImage is the base image of the HostProcess containers running the CNI
+	// and kube-proxy setup on Windows nodes.
+	windowsHostProcessImage = "mcr.microsoft.com/oss/kubernetes/windows-host-process-containers-base-image:v1.0.0"
 )
 
 //go:embed config/template-cp.yaml
@@ -54,6 +62,9 @@ var defaultCAPIConfigCP string
 //go:embed config/template-md.yaml
 var defaultClusterConfigMD string
 
+//go:embed config/template-md-windows.yaml
+var defaultClusterConfigMDWindows string
+
 //go:embed config/secret.yaml
 var defaultSecretObject string
 
@@ -61,8 +72,9 @@ var defaultSecretObject string
 var defaultStorageClass []byte
 
 var (
-	eksaVSphereDatacenterResourceType = fmt.Sprintf("vspheredatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
-	eksaVSphereMachineResourceType    = fmt.Sprintf("vspheremachineconfigs.%s", v1alpha1.GroupVersion.Group)
+	eksaVSphereDatacenterResourceType  = fmt.Sprintf("vspheredatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
+	eksaVSphereMachineResourceType     = fmt.Sprintf("vspheremachineconfigs.%s", v1alpha1.GroupVersion.Group)
+	vsphereMachineTemplateResourceType = fmt.Sprintf("vspheremachinetemplates.%s", vspherev1.GroupVersion.Group)
 )
 
 var requiredEnvs = []string{vSphereUsernameKey, vSpherePasswordKey, expClusterResourceSetKey}
@@ -79,6 +91,7 @@ type vsphereProvider struct {
 	Retrier               *retrier.Retrier
 	validator             *Validator
 	defaulter             *Defaulter
+	tagger                *ClusterTagger
 }
 
 type ProviderGovcClient interface {
@@ -99,10 +112,12 @@ type ProviderGovcClient interface {
 	CreateLibrary(ctx context.Context, datastore, library string) error
 	DeployTemplateFromLibrary(ctx context.Context, templateDir, templateName, library, datacenter, datastore, network, resourcePool string, resizeDisk2 bool) error
 	ImportTemplate(ctx context.Context, library, ovaURL, name string) error
+	CleanupClusterVMs(ctx context.Context, folder, clusterTagID string) ([]string, error)
 	GetTags(ctx context.Context, path string) (tags []string, err error)
 	ListTags(ctx context.Context) ([]string, error)
 	CreateTag(ctx context.Context, tag, category string) error
 	AddTag(ctx context.Context, path, tag string) error
+	ListCategoryTagIDs(ctx context.Context, category string) (map[string]string, error)
 	ListCategories(ctx context.Context) ([]string, error)
 	CreateCategoryForVM(ctx context.Context, name string) error
 	CreateUser(ctx context.Context, username string, password string) error
@@ -133,6 +148,7 @@ type ProviderKubectlClient interface {
 	SetDaemonSetImage(ctx context.Context, kubeconfigFile, name, namespace, container, image string) error
 	DeleteEksaDatacenterConfig(ctx context.Context, vsphereDatacenterResourceType string, vsphereDatacenterConfigName string, kubeconfigFile string, namespace string) error
 	DeleteEksaMachineConfig(ctx context.Context, vsphereMachineResourceType string, vsphereMachineConfigName string, kubeconfigFile string, namespace string) error
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
 	ApplyTolerationsFromTaintsToDaemonSet(ctx context.Context, oldTaints []corev1.Taint, newTaints []corev1.Taint, dsName string, kubeconfigFile string) error
 }
 
@@ -184,6 +200,7 @@ func NewProviderCustomNet(datacenterConfig *v1alpha1.VSphereDatacenterConfig, cl
 		Retrier:            retrier,
 		validator:          v,
 		defaulter:          NewDefaulter(providerGovcClient),
+		tagger:             NewClusterTagger(providerGovcClient),
 	}
 }
 
@@ -264,6 +281,56 @@ func (p *vsphereProvider) DeleteResources(ctx context.Context, clusterSpec *clus
 	)
 }
 
+// CleanupOrphanedResources deletes the VMs with the cluster tag attached from the folders the machines of the
+// cluster are placed in.
+func (p *vsphereProvider) CleanupOrphanedResources(ctx context.Context, _ *types.Cluster, clusterSpec *cluster.Spec) error {
+	if err := SetupEnvVars(clusterSpec.VSphereDatacenter); err != nil {
+		return fmt.Errorf("failed setup and validations: %v", err)
+	}
+
+	clusterTagID, ok, err := p.tagger.ClusterTagID(ctx, clusterSpec.Cluster)
+	if err != nil {
+		return fmt.Errorf("getting cluster tag: %v", err)
+	}
+	if !ok {
+		logger.Info("Cluster tag not found, no VMs to clean up", "cluster", clusterSpec.Cluster.Name)
+		return nil
+	}
+
+	for _, folder := range machineFolders(clusterSpec) {
+		deleted, err := p.providerGovcClient.CleanupClusterVMs(ctx, folder, clusterTagID)
+		for _, vm := range deleted {
+			logger.Info("Deleted orphaned VM", "vm", vm)
+		}
+		if err != nil {
+			return fmt.Errorf("cleaning up VMs in folder %s: %v", folder, err)
+		}
+	}
+
+	return nil
+}
+
+// machineFolders returns the folders the machines of the cluster are placed in, falling back
+// to the root VM folder of the datacenter for machine configs without one.
+func machineFolders(clusterSpec *cluster.Spec) []string {
+	seen := map[string]struct{}{}
+	folders := make([]string, 0, len(clusterSpec.VSphereMachineConfigs))
+	for _, mc := range clusterSpec.VSphereMachineConfigs {
+		folder := mc.Spec.Folder
+		if folder == "" {
+			folder = fmt.Sprintf("/%s/vm", clusterSpec.VSphereDatacenter.Spec.Datacenter)
+		}
+		if _, ok := seen[folder]; ok {
+			continue
+		}
+		seen[folder] = struct{}{}
+		folders = append(folders, folder)
+	}
+	sort.Strings(folders)
+
+	return folders
+}
+
 func (p *vsphereProvider) PostClusterDeleteValidate(_ context.Context, _ *types.Cluster) error {
 	// No validations
 	return nil
@@ -297,7 +364,7 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 		return fmt.Errorf("failed setting default values for vsphere machine configs: %v", err)
 	}
 
-	if err := p.validator.ValidateClusterMachineConfigs(ctx, vSphereClusterSpec); err != nil {
+	if err := p.validator.validateMachineConfigReferences(vSphereClusterSpec); err != nil {
 		return err
 	}
 
@@ -305,24 +372,7 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 		return fmt.Errorf("failed setup and validations: %v", err)
 	}
 
-	// TODO: move this to validator
 	if clusterSpec.Cluster.IsManaged() {
-		for _, mc := range clusterSpec.VSphereMachineConfigs {
-			em, err := p.providerKubectlClient.SearchVsphereMachineConfig(ctx, mc.GetName(), clusterSpec.ManagementCluster.KubeconfigFile, mc.GetNamespace())
-			if err != nil {
-				return err
-			}
-			if len(em) > 0 {
-				return fmt.Errorf("VSphereMachineConfig %s already exists", mc.GetName())
-			}
-		}
-		existingDatacenter, err := p.providerKubectlClient.SearchVsphereDatacenterConfig(ctx, clusterSpec.VSphereDatacenter.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace)
-		if err != nil {
-			return err
-		}
-		if len(existingDatacenter) > 0 {
-			return fmt.Errorf("VSphereDatacenter %s already exists", clusterSpec.VSphereDatacenter.Name)
-		}
 		for _, identityProviderRef := range clusterSpec.Cluster.Spec.IdentityProviderRefs {
 			if identityProviderRef.Kind == v1alpha1.OIDCConfigKind {
 				clusterSpec.OIDCConfig.SetManagedBy(p.clusterConfig.ManagedBy())
@@ -330,43 +380,101 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 		}
 	}
 
-	if !p.skipIPCheck {
-		if err := p.validator.validateControlPlaneIpUniqueness(vSphereClusterSpec); err != nil {
+	// These validations don't depend on each other, so all of them run and all their failures are reported at once.
+	// The user privileges are validated last since they rely on the paths of the machine configs resources,
+	// which are completed while validating the vCenter setup.
+	if err := validations.AggregateErrors(
+		p.validator.ValidateClusterMachineConfigs(ctx, vSphereClusterSpec),
+		p.validateManagedClusterObjectsDontExist(ctx, clusterSpec),
+		p.validateControlPlaneIPUniqueness(ctx, vSphereClusterSpec),
+		p.validateUserPrivs(ctx, vSphereClusterSpec),
+	); err != nil {
+		return err
+	}
+
+	return p.setTemplateTagIDs(ctx, clusterSpec)
+}
+
+// setTemplateTagIDs creates the vCenter tags of the cluster and makes the machine templates attach them to the VMs.
+func (p *vsphereProvider) setTemplateTagIDs(ctx context.Context, clusterSpec *cluster.Spec) error {
+	tagIDs, err := p.tagger.TagIDs(ctx, clusterSpec)
+	if err != nil {
+		return fmt.Errorf("creating cluster tags: %v", err)
+	}
+	WithTagIDs(tagIDs)(p.templateBuilder)
+
+	return nil
+}
+
+// validateManagedClusterObjectsDontExist checks the provider objects of a new workload cluster
+// don't exist in its management cluster yet.
+// TODO: move this to validator.
+func (p *vsphereProvider) validateManagedClusterObjectsDontExist(ctx context.Context, clusterSpec *cluster.Spec) error {
+	if !clusterSpec.Cluster.IsManaged() {
+		return nil
+	}
+
+	for _, mc := range clusterSpec.VSphereMachineConfigs {
+		em, err := p.providerKubectlClient.SearchVsphereMachineConfig(ctx, mc.GetName(), clusterSpec.ManagementCluster.KubeconfigFile, mc.GetNamespace())
+		if err != nil {
 			return err
 		}
-	} else {
-		logger.Info("Skipping check for whether control plane ip is in use")
+		if len(em) > 0 {
+			return fmt.Errorf("VSphereMachineConfig %s already exists", mc.GetName())
+		}
+	}
+	existingDatacenter, err := p.providerKubectlClient.SearchVsphereDatacenterConfig(ctx, clusterSpec.VSphereDatacenter.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace)
+	if err != nil {
+		return err
+	}
+	if len(existingDatacenter) > 0 {
+		return fmt.Errorf("VSphereDatacenter %s already exists", clusterSpec.VSphereDatacenter.Name)
 	}
 
-	var passed bool
-	var err error
-	vuc := config.NewVsphereUserConfig()
+	return nil
+}
 
-	if passed, err = p.validator.validateUserPrivs(ctx, vSphereClusterSpec, vuc); err != nil {
-		return err
-	} else if passed {
-		s := fmt.Sprintf("%s user vSphere privileges validated", vuc.EksaVsphereUsername)
-		logger.MarkPass(s)
+func (p *vsphereProvider) validateControlPlaneIPUniqueness(ctx context.Context, vSphereClusterSpec *Spec) error {
+	if p.skipIPCheck {
+		logger.Info("Skipping check for whether control plane ip is in use")
+		return nil
+	}
+
+	// External load balancers are expected to be serving the endpoint already.
+	if vSphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.UsesExternalLoadBalancer() {
+		return p.validator.validateExternalLoadBalancer(vSphereClusterSpec)
 	}
 
+	return p.validator.validateControlPlaneIpUniqueness(ctx, vSphereClusterSpec)
+}
+
+// validateUserPrivs validates the privileges of all the vSphere users in the environment
+// and reports the failures for all of them.
+func (p *vsphereProvider) validateUserPrivs(ctx context.Context, vSphereClusterSpec *Spec) error {
+	vuc := config.NewVsphereUserConfig()
+	passed, err := p.validator.validateUserPrivs(ctx, vSphereClusterSpec, vuc)
+	errs := []error{userPrivsResult(vuc.EksaVsphereUsername, passed, err)}
+
 	if len(vuc.EksaVsphereCPUsername) > 0 && vuc.EksaVsphereCPUsername != vuc.EksaVsphereUsername {
-		if passed, err = p.validator.validateCPUserPrivs(ctx, vSphereClusterSpec, vuc); err != nil {
-			return err
-		} else if passed {
-			s := fmt.Sprintf("%s user vSphere privileges validated", vuc.EksaVsphereCPUsername)
-			logger.MarkPass(s)
-		}
+		passed, err = p.validator.validateCPUserPrivs(ctx, vSphereClusterSpec, vuc)
+		errs = append(errs, userPrivsResult(vuc.EksaVsphereCPUsername, passed, err))
 	}
 
 	if len(vuc.EksaVsphereCSIUsername) > 0 && vuc.EksaVsphereCSIUsername != vuc.EksaVsphereUsername {
-		if passed, err = p.validator.validateCSIUserPrivs(ctx, vSphereClusterSpec, vuc); err != nil {
-			return err
-		} else if passed {
-			s := fmt.Sprintf("%s user vSphere privileges validated", vuc.EksaVsphereCSIUsername)
-			logger.MarkPass(s)
-		}
+		passed, err = p.validator.validateCSIUserPrivs(ctx, vSphereClusterSpec, vuc)
+		errs = append(errs, userPrivsResult(vuc.EksaVsphereCSIUsername, passed, err))
 	}
 
+	return validations.AggregateErrors(errs...)
+}
+
+func userPrivsResult(username string, passed bool, err error) error {
+	if err != nil {
+		return err
+	}
+	if passed {
+		logger.MarkPass(fmt.Sprintf("%s user vSphere privileges validated", username))
+	}
 	return nil
 }
 
@@ -393,15 +501,23 @@ func (p *vsphereProvider) SetupAndValidateUpgradeCluster(ctx context.Context, cl
 		return fmt.Errorf("failed setting default values for vsphere machine configs: %v", err)
 	}
 
-	if err := p.validator.ValidateClusterMachineConfigs(ctx, vSphereClusterSpec); err != nil {
+	if err := p.validator.validateMachineConfigReferences(vSphereClusterSpec); err != nil {
 		return err
 	}
 
-	err := p.validateMachineConfigsNameUniqueness(ctx, cluster, clusterSpec)
-	if err != nil {
-		return fmt.Errorf("failed validate machineconfig uniqueness: %v", err)
+	var uniquenessErr error
+	if err := p.validateMachineConfigsNameUniqueness(ctx, cluster, clusterSpec); err != nil {
+		uniquenessErr = fmt.Errorf("failed validate machineconfig uniqueness: %v", err)
 	}
-	return nil
+
+	if err := validations.AggregateErrors(
+		p.validator.ValidateClusterMachineConfigs(ctx, vSphereClusterSpec),
+		uniquenessErr,
+	); err != nil {
+		return err
+	}
+
+	return p.setTemplateTagIDs(ctx, clusterSpec)
 }
 
 func (p *vsphereProvider) validateMachineConfigsNameUniqueness(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
@@ -471,6 +587,9 @@ func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
+	if !v1alpha1.LabelsMapEqual(oldSpec.Cluster.Spec.Tags, newSpec.Cluster.Spec.Tags) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
 }
 
@@ -481,6 +600,9 @@ func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
+	if !v1alpha1.LabelsMapEqual(oldSpec.Cluster.Spec.Tags, newSpec.Cluster.Spec.Tags) {
+		return true
+	}
 	if !v1alpha1.WorkerNodeGroupConfigurationSliceTaintsEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) ||
 		!v1alpha1.WorkerNodeGroupConfigurationsLabelsMapEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) {
 		return true
@@ -490,7 +612,9 @@ func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1
 
 func NeedsNewKubeadmConfigTemplate(newWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration, oldWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration, oldWorkerNodeVmc *v1alpha1.VSphereMachineConfig, newWorkerNodeVmc *v1alpha1.VSphereMachineConfig) bool {
 	return !v1alpha1.TaintsSliceEqual(newWorkerNodeGroup.Taints, oldWorkerNodeGroup.Taints) || !v1alpha1.LabelsMapEqual(newWorkerNodeGroup.Labels, oldWorkerNodeGroup.Labels) ||
-		!v1alpha1.UsersSliceEqual(oldWorkerNodeVmc.Spec.Users, newWorkerNodeVmc.Spec.Users)
+		!v1alpha1.UsersSliceEqual(oldWorkerNodeVmc.Spec.Users, newWorkerNodeVmc.Spec.Users) ||
+		!newWorkerNodeGroup.ContainerdConfiguration.Equal(oldWorkerNodeGroup.ContainerdConfiguration) ||
+		!newWorkerNodeVmc.Spec.HostOSConfiguration.Equal(oldWorkerNodeVmc.Spec.HostOSConfiguration)
 }
 
 func NeedsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig, oldVmc, newVmc *v1alpha1.VSphereMachineConfig) bool {
@@ -500,7 +624,16 @@ func NeedsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1alph
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
-	return AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
+	if !v1alpha1.LabelsMapEqual(oldSpec.Cluster.Spec.Tags, newSpec.Cluster.Spec.Tags) {
+		return true
+	}
+	return AnyEtcdImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
+}
+
+// AnyEtcdImmutableFieldChanged returns true if the etcd machines need to be replaced to apply
+// the changes of the machine config, including the etcd disk configuration.
+func AnyEtcdImmutableFieldChanged(oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig, oldVmc, newVmc *v1alpha1.VSphereMachineConfig) bool {
+	return !oldVmc.Spec.EtcdDisk.Equal(newVmc.Spec.EtcdDisk) || AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
 }
 
 func AnyImmutableFieldChanged(oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig, oldVmc, newVmc *v1alpha1.VSphereMachineConfig) bool {
@@ -531,13 +664,53 @@ func AnyImmutableFieldChanged(oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig,
 	if oldVmc.Spec.Template != newVmc.Spec.Template {
 		return true
 	}
-	return false
+	return !oldVmc.Spec.SerialConsole.Equal(newVmc.Spec.SerialConsole)
+}
+
+// etcdTemplateNameForUpgrade returns the name of the etcd machine template to use for the upgrade,
+// reusing the existing one when nothing that requires a new template changed.
+func (p *vsphereProvider) etcdTemplateNameForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, c *v1alpha1.Cluster, vdc *v1alpha1.VSphereDatacenterConfig, currentSpec, newClusterSpec *cluster.Spec) (string, error) {
+	clusterName := newClusterSpec.Cluster.Name
+	newTemplateName := common.EtcdMachineTemplateName(clusterName, p.templateBuilder.now)
+
+	etcdMachineConfig := newClusterSpec.VSphereMachineConfigs[newClusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name]
+	etcdMachineVmc, err := p.providerKubectlClient.GetEksaVSphereMachineConfig(ctx, c.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name, workloadCluster.KubeconfigFile, newClusterSpec.Cluster.Namespace)
+	if err != nil {
+		return "", err
+	}
+	if !NeedsNewEtcdTemplate(currentSpec, newClusterSpec, vdc, newClusterSpec.VSphereDatacenter, etcdMachineVmc, etcdMachineConfig) {
+		etcdadmCluster, err := p.providerKubectlClient.GetEtcdadmCluster(ctx, workloadCluster, clusterName, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
+		if err != nil {
+			return "", err
+		}
+		existingTemplateName := etcdadmCluster.Spec.InfrastructureTemplate.Name
+		templateName, err := p.reusableMachineTemplateName(ctx, bootstrapCluster, existingTemplateName, newTemplateName)
+		if err != nil {
+			return "", err
+		}
+		if templateName == existingTemplateName {
+			return templateName, nil
+		}
+	}
+
+	/* During a cluster upgrade, etcd machines need to be upgraded first, so that the etcd machines with new spec get created and can be used by controlplane machines
+	as etcd endpoints. KCP rollout should not start until then. As a temporary solution in the absence of static etcd endpoints, we annotate the etcd cluster as "upgrading",
+	so that KCP checks this annotation and does not proceed if etcd cluster is upgrading. The etcdadm controller removes this annotation once the etcd upgrade is complete.
+	*/
+	err = p.providerKubectlClient.UpdateAnnotation(ctx, "etcdadmcluster", fmt.Sprintf("%s-etcd", clusterName),
+		map[string]string{etcdv1.UpgradeInProgressAnnotation: "true"},
+		executables.WithCluster(bootstrapCluster),
+		executables.WithNamespace(constants.EksaSystemNamespace))
+	if err != nil {
+		return "", err
+	}
+
+	return newTemplateName, nil
 }
 
 func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
 	clusterName := newClusterSpec.Cluster.Name
 	var controlPlaneTemplateName, workloadTemplateName, kubeadmconfigTemplateName, etcdTemplateName string
-	var needsNewEtcdTemplate bool
 
 	c, err := p.providerKubectlClient.GetEksaCluster(ctx, workloadCluster, newClusterSpec.Cluster.Name)
 	if err != nil {
@@ -558,7 +731,10 @@ func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootst
 		if err != nil {
 			return nil, nil, err
 		}
-		controlPlaneTemplateName = cp.Spec.MachineTemplate.InfrastructureRef.Name
+		controlPlaneTemplateName, err = p.reusableMachineTemplateName(ctx, bootstrapCluster, cp.Spec.MachineTemplate.InfrastructureRef.Name, common.CPMachineTemplateName(clusterName, p.templateBuilder.now))
+		if err != nil {
+			return nil, nil, err
+		}
 	} else {
 		controlPlaneTemplateName = common.CPMachineTemplateName(clusterName, p.templateBuilder.now)
 	}
@@ -600,7 +776,10 @@ func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootst
 			if err != nil {
 				return nil, nil, err
 			}
-			workloadTemplateName = md.Spec.Template.Spec.InfrastructureRef.Name
+			workloadTemplateName, err = p.reusableMachineTemplateName(ctx, bootstrapCluster, md.Spec.Template.Spec.InfrastructureRef.Name, common.WorkerMachineTemplateName(clusterName, workerNodeGroupConfiguration.Name, p.templateBuilder.now))
+			if err != nil {
+				return nil, nil, err
+			}
 			workloadTemplateNames[workerNodeGroupConfiguration.Name] = workloadTemplateName
 		} else {
 			workloadTemplateName = common.WorkerMachineTemplateName(clusterName, workerNodeGroupConfiguration.Name, p.templateBuilder.now)
@@ -609,32 +788,10 @@ func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootst
 	}
 
 	if newClusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
-		etcdMachineConfig := newClusterSpec.VSphereMachineConfigs[newClusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name]
-		etcdMachineVmc, err := p.providerKubectlClient.GetEksaVSphereMachineConfig(ctx, c.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name, workloadCluster.KubeconfigFile, newClusterSpec.Cluster.Namespace)
+		etcdTemplateName, err = p.etcdTemplateNameForUpgrade(ctx, bootstrapCluster, workloadCluster, c, vdc, currentSpec, newClusterSpec)
 		if err != nil {
 			return nil, nil, err
 		}
-		needsNewEtcdTemplate = NeedsNewEtcdTemplate(currentSpec, newClusterSpec, vdc, newClusterSpec.VSphereDatacenter, etcdMachineVmc, etcdMachineConfig)
-		if !needsNewEtcdTemplate {
-			etcdadmCluster, err := p.providerKubectlClient.GetEtcdadmCluster(ctx, workloadCluster, clusterName, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
-			if err != nil {
-				return nil, nil, err
-			}
-			etcdTemplateName = etcdadmCluster.Spec.InfrastructureTemplate.Name
-		} else {
-			/* During a cluster upgrade, etcd machines need to be upgraded first, so that the etcd machines with new spec get created and can be used by controlplane machines
-			as etcd endpoints. KCP rollout should not start until then. As a temporary solution in the absence of static etcd endpoints, we annotate the etcd cluster as "upgrading",
-			so that KCP checks this annotation and does not proceed if etcd cluster is upgrading. The etcdadm controller removes this annotation once the etcd upgrade is complete.
-			*/
-			err = p.providerKubectlClient.UpdateAnnotation(ctx, "etcdadmcluster", fmt.Sprintf("%s-etcd", clusterName),
-				map[string]string{etcdv1.UpgradeInProgressAnnotation: "true"},
-				executables.WithCluster(bootstrapCluster),
-				executables.WithNamespace(constants.EksaSystemNamespace))
-			if err != nil {
-				return nil, nil, err
-			}
-			etcdTemplateName = common.EtcdMachineTemplateName(clusterName, p.templateBuilder.now)
-		}
 	}
 
 	cpOpt := func(values map[string]interface{}) {
@@ -653,6 +810,22 @@ func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootst
 	return controlPlaneSpec, workersSpec, nil
 }
 
+// reusableMachineTemplateName returns existingName if the existing machine template with that name attaches the
+// same vCenter tags as the new templates, or newName otherwise, since machine templates are immutable. This also
+// rolls out the machines of clusters created before the cluster tag was attached to their VMs.
+func (p *vsphereProvider) reusableMachineTemplateName(ctx context.Context, managementCluster *types.Cluster, existingName, newName string) (string, error) {
+	template := &vspherev1.VSphereMachineTemplate{}
+	if err := p.providerKubectlClient.GetObject(ctx, vsphereMachineTemplateResourceType, existingName, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, template); err != nil {
+		return "", fmt.Errorf("getting machine template %s: %v", existingName, err)
+	}
+
+	if !v1alpha1.SliceEqual(template.Spec.Template.Spec.TagIDs, p.templateBuilder.tagIDs) {
+		return newName, nil
+	}
+
+	return existingName, nil
+}
+
 func (p *vsphereProvider) generateCAPISpecForCreate(ctx context.Context, clusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
 	clusterName := clusterSpec.Cluster.Name
 
@@ -870,6 +1043,22 @@ func (p *vsphereProvider) ValidateNewSpec(ctx context.Context, cluster *types.Cl
 		}
 	}
 
+	if err = validateDatacenterImmutability(nSpec, oSpec); err != nil {
+		return err
+	}
+
+	secretChanged, err := p.secretContentsChanged(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	if secretChanged {
+		return fmt.Errorf("the VSphere credentials derived from %s and %s are immutable; please use the same credentials for the upgraded cluster", vSpherePasswordKey, vSphereUsernameKey)
+	}
+	return nil
+}
+
+func validateDatacenterImmutability(nSpec, oSpec v1alpha1.VSphereDatacenterConfigSpec) error {
 	if nSpec.Server != oSpec.Server {
 		return fmt.Errorf("spec.server is immutable. Previous value %s, new value %s", oSpec.Server, nSpec.Server)
 	}
@@ -881,14 +1070,10 @@ func (p *vsphereProvider) ValidateNewSpec(ctx context.Context, cluster *types.Cl
 		return fmt.Errorf("spec.network is immutable. Previous value %s, new value %s", oSpec.Network, nSpec.Network)
 	}
 
-	secretChanged, err := p.secretContentsChanged(ctx, cluster)
-	if err != nil {
-		return err
+	if nSpec.WorkloadNetwork != oSpec.WorkloadNetwork {
+		return fmt.Errorf("spec.workloadNetwork is immutable. Previous value %s, new value %s", oSpec.WorkloadNetwork, nSpec.WorkloadNetwork)
 	}
 
-	if secretChanged {
-		return fmt.Errorf("the VSphere credentials derived from %s and %s are immutable; please use the same credentials for the upgraded cluster", vSpherePasswordKey, vSphereUsernameKey)
-	}
 	return nil
 }
 
@@ -974,8 +1159,8 @@ func (p *vsphereProvider) RunPostControlPlaneUpgrade(ctx context.Context, oldClu
 	// Even if we create a new ClusterResourceSet, if such resources already exist in the cluster, they won't be reapplied
 	// The long term solution is to add this capability to the cluster-api controller,
 	// with a new mode like "ReApplyOnChanges" or "ReApplyOnCreate" vs the current "ReApplyOnce"
-	err := p.Retrier.Retry(
-		func() error {
+	err := p.Retrier.RetryWithContext(ctx,
+		func(ctx context.Context, _ retrier.Attempt) error {
 			return p.resourceSetManager.ForceUpdate(ctx, resourceSetName(clusterSpec), constants.EksaSystemNamespace, managementCluster, workloadCluster)
 		},
 	)
@@ -23,6 +23,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
@@ -59,7 +61,8 @@ const (
 )
 
 type DummyProviderGovcClient struct {
-	osTag string
+	osTag  string
+	tagIDs map[string]string
 }
 
 func NewDummyProviderGovcClient() *DummyProviderGovcClient {
@@ -142,6 +145,10 @@ func (pc *DummyProviderGovcClient) ImportTemplate(ctx context.Context, library,
 	return nil
 }
 
+func (pc *DummyProviderGovcClient) CleanupClusterVMs(ctx context.Context, folder, clusterTagID string) ([]string, error) {
+	return nil, nil
+}
+
 func (pc *DummyProviderGovcClient) GetTags(ctx context.Context, path string) (tags []string, err error) {
 	return []string{eksd119ReleaseTag, eksd121ReleaseTag, pc.osTag}, nil
 }
@@ -151,6 +158,10 @@ func (pc *DummyProviderGovcClient) ListTags(ctx context.Context) ([]string, erro
 }
 
 func (pc *DummyProviderGovcClient) CreateTag(ctx context.Context, tag, category string) error {
+	if pc.tagIDs == nil {
+		pc.tagIDs = map[string]string{}
+	}
+	pc.tagIDs[tag] = fmt.Sprintf("urn:vmomi:InventoryServiceTag:%s:GLOBAL", tag)
 	return nil
 }
 
@@ -158,6 +169,10 @@ func (pc *DummyProviderGovcClient) AddTag(ctx context.Context, path, tag string)
 	return nil
 }
 
+func (pc *DummyProviderGovcClient) ListCategoryTagIDs(ctx context.Context, category string) (map[string]string, error) {
+	return pc.tagIDs, nil
+}
+
 func (pc *DummyProviderGovcClient) ListCategories(ctx context.Context) ([]string, error) {
 	return nil, nil
 }
@@ -202,7 +217,7 @@ type DummyNetClient struct{}
 
 func (n *DummyNetClient) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
 	// add dummy case for coverage
-	if address == "255.255.255.255:22" {
+	if address == "255.255.255.255:22" || address == "api.cluster.example.com:6443" {
 		return &net.IPConn{}, nil
 	}
 	return nil, errors.New("")
@@ -367,6 +382,10 @@ func (tt *providerTest) setExpectationsForMachineConfigsVCenterValidation() {
 	}
 }
 
+func (tt *providerTest) setExpectationsForDatastoreUsage(availableSpace float64) {
+	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, gomock.Any()).Return(availableSpace, nil).AnyTimes()
+}
+
 func (tt *providerTest) buildNewProvider() {
 	tt.provider = newProvider(
 		tt.t,
@@ -560,6 +579,7 @@ func TestProviderGenerateCAPISpecForUpgradeUpdateMachineTemplate(t *testing.T) {
 				t.Fatalf("failed to setup and validate: %v", err)
 			}
 
+			expectMachineTemplates(kubectl, ctx, provider.templateBuilder.tagIDs)
 			cp, md, err := provider.GenerateCAPISpecForUpgrade(context.Background(), bootstrapCluster, cluster, clusterSpec, clusterSpec.DeepCopy())
 			if err != nil {
 				t.Fatalf("failed to generate cluster api spec contents: %v", err)
@@ -581,6 +601,90 @@ func firstMachineConfig(spec *cluster.Spec) *v1alpha1.VSphereMachineConfig {
 	return mc
 }
 
+func TestProviderGenerateCAPISpecForUpgradeMachineTemplateTagsChanged(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	ctx := context.Background()
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{
+		Name: "test",
+	}
+	bootstrapCluster := &types.Cluster{
+		Name: "bootstrap-test",
+	}
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	oldCP := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: v1.ObjectReference{
+					Name: "test-control-plane-template-original",
+				},
+			},
+		},
+	}
+	oldMD := &clusterv1.MachineDeployment{
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					InfrastructureRef: v1.ObjectReference{
+						Name: "test-md-0-original",
+					},
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &v1.ObjectReference{
+							Name: "test-md-0-template-original",
+						},
+					},
+				},
+			},
+		},
+	}
+	etcdadmCluster := &etcdv1.EtcdadmCluster{
+		Spec: etcdv1.EtcdadmClusterSpec{
+			InfrastructureTemplate: v1.ObjectReference{
+				Name: "test-etcd-template-original",
+			},
+		},
+	}
+
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	provider := newProviderWithKubectl(t, datacenterConfig, clusterSpec.Cluster, kubectl)
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	controlPlaneMachineConfigName := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	workerNodeMachineConfigName := clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name
+	machineDeploymentName := fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name)
+	etcdMachineConfigName := clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name
+	kubectl.EXPECT().GetEksaCluster(ctx, cluster, clusterSpec.Cluster.Name).Return(clusterSpec.Cluster, nil)
+	kubectl.EXPECT().GetEksaVSphereDatacenterConfig(ctx, cluster.Name, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(datacenterConfig, nil)
+	kubectl.EXPECT().GetEksaVSphereMachineConfig(ctx, controlPlaneMachineConfigName, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(clusterSpec.VSphereMachineConfigs[controlPlaneMachineConfigName], nil)
+	kubectl.EXPECT().GetEksaVSphereMachineConfig(ctx, workerNodeMachineConfigName, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(clusterSpec.VSphereMachineConfigs[workerNodeMachineConfigName], nil)
+	kubectl.EXPECT().GetEksaVSphereMachineConfig(ctx, etcdMachineConfigName, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(clusterSpec.VSphereMachineConfigs[etcdMachineConfigName], nil)
+	kubectl.EXPECT().GetKubeadmControlPlane(ctx, cluster, clusterSpec.Cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(oldCP, nil)
+	kubectl.EXPECT().GetMachineDeployment(ctx, machineDeploymentName, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(oldMD, nil).Times(2)
+	kubectl.EXPECT().GetEtcdadmCluster(ctx, cluster, clusterSpec.Cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(etcdadmCluster, nil)
+	// Templates created before the cluster tag was attached to the VMs.
+	expectMachineTemplates(kubectl, ctx, nil)
+	kubectl.EXPECT().UpdateAnnotation(ctx, "etcdadmcluster", fmt.Sprintf("%s-etcd", cluster.Name), map[string]string{etcdv1.UpgradeInProgressAnnotation: "true"}, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster)))
+
+	cp, md, err := provider.GenerateCAPISpecForUpgrade(ctx, bootstrapCluster, cluster, clusterSpec, clusterSpec.DeepCopy())
+	g := NewWithT(t)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(cp)).NotTo(ContainSubstring("test-control-plane-template-original"))
+	g.Expect(string(cp)).NotTo(ContainSubstring("test-etcd-template-original"))
+	g.Expect(string(md)).NotTo(ContainSubstring("test-md-0-original"))
+	g.Expect(string(md)).To(ContainSubstring("test-md-0-template-original"))
+}
+
+func expectMachineTemplates(kubectl *mocks.MockProviderKubectlClient, ctx context.Context, tagIDs []string) {
+	kubectl.EXPECT().GetObject(ctx, "vspheremachinetemplates.infrastructure.cluster.x-k8s.io", gomock.Any(), constants.EksaSystemNamespace, gomock.Any(), gomock.AssignableToTypeOf(&vspherev1.VSphereMachineTemplate{})).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			obj.(*vspherev1.VSphereMachineTemplate).Spec.Template.Spec.TagIDs = tagIDs
+			return nil
+		}).AnyTimes()
+}
+
 func TestProviderGenerateCAPISpecForUpgradeOIDC(t *testing.T) {
 	tests := []struct {
 		testName          string
@@ -638,6 +742,7 @@ func TestProviderGenerateCAPISpecForUpgradeOIDC(t *testing.T) {
 				t.Fatalf("failed to setup and validate: %v", err)
 			}
 
+			expectMachineTemplates(kubectl, ctx, provider.templateBuilder.tagIDs)
 			cp, _, err := provider.GenerateCAPISpecForUpgrade(context.Background(), bootstrapCluster, cluster, clusterSpec, clusterSpec.DeepCopy())
 			if err != nil {
 				t.Fatalf("failed to generate cluster api spec contents: %v", err)
@@ -702,6 +807,7 @@ func TestProviderGenerateCAPISpecForUpgradeMultipleWorkerNodeGroups(t *testing.T
 				t.Fatalf("failed to setup and validate: %v", err)
 			}
 
+			expectMachineTemplates(kubectl, ctx, provider.templateBuilder.tagIDs)
 			_, md, err := provider.GenerateCAPISpecForUpgrade(context.Background(), bootstrapCluster, cluster, clusterSpec, newClusterSpec)
 			if err != nil {
 				t.Fatalf("failed to generate cluster api spec contents: %v", err)
@@ -780,6 +886,7 @@ func TestProviderGenerateCAPISpecForUpgradeUpdateMachineTemplateExternalEtcd(t *
 				t.Fatalf("failed to setup and validate: %v", err)
 			}
 
+			expectMachineTemplates(kubectl, ctx, provider.templateBuilder.tagIDs)
 			cp, md, err := provider.GenerateCAPISpecForUpgrade(context.Background(), bootstrapCluster, cluster, clusterSpec, clusterSpec)
 			if err != nil {
 				t.Fatalf("failed to generate cluster api spec contents: %v", err)
@@ -860,6 +967,7 @@ func TestProviderGenerateCAPISpecForUpgradeNotUpdateMachineTemplate(t *testing.T
 	kubectl.EXPECT().GetKubeadmControlPlane(ctx, cluster, clusterSpec.Cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(oldCP, nil)
 	kubectl.EXPECT().GetMachineDeployment(ctx, machineDeploymentName, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(oldMD, nil).Times(2)
 	kubectl.EXPECT().GetEtcdadmCluster(ctx, cluster, clusterSpec.Cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(etcdadmCluster, nil)
+	expectMachineTemplates(kubectl, ctx, provider.templateBuilder.tagIDs)
 	cp, md, err := provider.GenerateCAPISpecForUpgrade(context.Background(), bootstrapCluster, cluster, clusterSpec, clusterSpec.DeepCopy())
 	if err != nil {
 		t.Fatalf("failed to generate cluster api spec contents: %v", err)
@@ -1791,12 +1899,112 @@ func TestSetupAndValidateCreateClusterBogusIp(t *testing.T) {
 	ctx := context.Background()
 	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
 	provider := givenProvider(t)
-	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "bogus"
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "bogus_host!"
+	setupContext(t)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+
+	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host is invalid, it must be an IP or a DNS name: bogus_host!", err)
+}
+
+func TestSetupAndValidateCreateClusterExternalLoadBalancerNotReachable(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	provider := givenProvider(t)
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.ExternalLoadBalancer = true
+	setupContext(t)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+
+	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host <1.2.3.4> is not reachable on port 6443, the external load balancer must be configured before creating the cluster", err)
+}
+
+type fakeResolver struct {
+	addresses []string
+	err       error
+}
+
+func (r fakeResolver) LookupHost(_ context.Context, _ string) ([]string, error) {
+	return r.addresses, r.err
+}
+
+func TestSetupAndValidateCreateClusterUnresolvableHostname(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	provider := givenProvider(t)
+	provider.validator.resolver = fakeResolver{err: errors.New("no such host")}
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "api.cluster.example.com"
+	setupContext(t)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+
+	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host api.cluster.example.com can't be resolved: no such host", err)
+}
+
+func TestSetupAndValidateCreateClusterHostnameWithoutAddresses(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	provider := givenProvider(t)
+	provider.validator.resolver = fakeResolver{}
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "api.cluster.example.com"
+	setupContext(t)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+
+	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host api.cluster.example.com doesn't resolve to any address", err)
+}
+
+func TestSetupAndValidateCreateClusterHostnameMultipleAddresses(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	provider := givenProvider(t)
+	provider.validator.resolver = fakeResolver{addresses: []string{"1.2.3.4", "1.2.3.5"}}
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "api.cluster.example.com"
+	setupContext(t)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+
+	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host api.cluster.example.com resolves to 2 addresses, kube-vip needs a name that resolves to a single IP", err)
+}
+
+func TestSetupAndValidateCreateClusterHostnameKubeVip(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	provider := givenProvider(t)
+	provider.validator.resolver = fakeResolver{addresses: []string{"1.2.3.4"}}
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "api.cluster.example.com"
+	setupContext(t)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("provider.SetupAndValidateCreateCluster() err = %v, want err = nil", err)
+	}
+}
+
+func TestSetupAndValidateCreateClusterHostnameExternalLoadBalancer(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	provider := givenProvider(t)
+	provider.validator.resolver = fakeResolver{addresses: []string{"1.2.3.4", "1.2.3.5"}}
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "api.cluster.example.com"
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.ExternalLoadBalancer = true
+	setupContext(t)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("provider.SetupAndValidateCreateCluster() err = %v, want err = nil", err)
+	}
+}
+
+func TestSetupAndValidateCreateClusterHostnameUsedIp(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	provider := givenProvider(t)
+	provider.validator.resolver = fakeResolver{addresses: []string{"255.255.255.255"}}
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "api.cluster.example.com"
 	setupContext(t)
 
 	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
 
-	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host is invalid: bogus", err)
+	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host <api.cluster.example.com> is already in use, please provide a unique IP", err)
 }
 
 func TestSetupAndValidateCreateClusterUsedIp(t *testing.T) {
@@ -2137,12 +2345,36 @@ func TestSetupAndValidateCreateClusterTemplateMissingTags(t *testing.T) {
 
 	tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, controlPlaneMachineConfig).Return(controlPlaneMachineConfig.Spec.Template, nil)
 	tt.govc.EXPECT().GetTags(tt.ctx, controlPlaneMachineConfig.Spec.Template).Return(nil, nil)
+	tt.setExpectationsForDatastoreUsage(1000)
 
 	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
 
 	thenErrorPrefixExpected(t, "template "+testTemplate+" is missing tag ", err)
 }
 
+func TestSetupAndValidateCreateClusterReportsAllFailures(t *testing.T) {
+	tt := newProviderTest(t)
+
+	tt.setExpectationForSetup()
+	tt.setExpectationsForDefaultDiskGovcCalls()
+	tt.setExpectationForVCenterValidation()
+	tt.setExpectationsForMachineConfigsVCenterValidation()
+	for _, mc := range tt.machineConfigs {
+		tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, mc).Return(mc.Spec.Template, nil)
+	}
+	controlPlaneMachineConfigName := tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	controlPlaneMachineConfig := tt.machineConfigs[controlPlaneMachineConfigName]
+	tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, controlPlaneMachineConfig).Return(controlPlaneMachineConfig.Spec.Template, nil)
+	tt.govc.EXPECT().GetTags(tt.ctx, controlPlaneMachineConfig.Spec.Template).Return(nil, nil)
+	tt.setExpectationsForDatastoreUsage(1)
+
+	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
+
+	tt.Expect(err).To(HaveOccurred())
+	tt.Expect(err.Error()).To(HavePrefix("validation failed with 2 errors: template " + testTemplate + " is missing tag "))
+	tt.Expect(err.Error()).To(ContainSubstring("not enough space in datastore"))
+}
+
 func TestSetupAndValidateCreateClusterErrorGettingTags(t *testing.T) {
 	tt := newProviderTest(t)
 	errorMessage := "failed getting tags"
@@ -2159,6 +2391,7 @@ func TestSetupAndValidateCreateClusterErrorGettingTags(t *testing.T) {
 	}
 	tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, controlPlaneMachineConfig).Return(controlPlaneMachineConfig.Spec.Template, nil)
 	tt.govc.EXPECT().GetTags(tt.ctx, controlPlaneMachineConfig.Spec.Template).Return(nil, errors.New(errorMessage))
+	tt.setExpectationsForDatastoreUsage(1000)
 
 	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
 
@@ -2878,3 +3111,77 @@ func TestVsphereProviderMachineConfigsManagedCluster(t *testing.T) {
 		}
 	}
 }
+
+func TestProviderCleanupOrphanedResources(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.VSphereMachineConfigs["test-wn"].Spec.Folder = ""
+	tt.clusterSpec.VSphereMachineConfigs["test-etcd"].Spec.Folder = "/SDDC-Datacenter/vm/etcd"
+	tagID := "urn:vmomi:InventoryServiceTag:1:GLOBAL"
+
+	tt.expectClusterTag(tagID)
+	tt.govc.EXPECT().CleanupClusterVMs(tt.ctx, "/SDDC-Datacenter/vm", tagID).Return([]string{"/SDDC-Datacenter/vm/test-cp-1"}, nil)
+	tt.govc.EXPECT().CleanupClusterVMs(tt.ctx, "/SDDC-Datacenter/vm/etcd", tagID).Return(nil, nil)
+
+	tt.Expect(tt.provider.CleanupOrphanedResources(tt.ctx, nil, tt.clusterSpec)).To(Succeed())
+}
+
+func TestProviderCleanupOrphanedResourcesNoClusterTag(t *testing.T) {
+	tt := newProviderTest(t)
+
+	tt.govc.EXPECT().ListCategories(tt.ctx).Return([]string{"os"}, nil)
+
+	tt.Expect(tt.provider.CleanupOrphanedResources(tt.ctx, nil, tt.clusterSpec)).To(Succeed())
+}
+
+func TestProviderCleanupOrphanedResourcesError(t *testing.T) {
+	tt := newProviderTest(t)
+	tagID := "urn:vmomi:InventoryServiceTag:1:GLOBAL"
+
+	tt.expectClusterTag(tagID)
+	tt.govc.EXPECT().CleanupClusterVMs(tt.ctx, "/SDDC-Datacenter/vm", tagID).Return(nil, errors.New("permission denied"))
+
+	tt.Expect(tt.provider.CleanupOrphanedResources(tt.ctx, nil, tt.clusterSpec)).To(
+		MatchError(ContainSubstring("cleaning up VMs in folder /SDDC-Datacenter/vm: permission denied")),
+	)
+}
+
+func (tt *providerTest) expectClusterTag(tagID string) {
+	tt.govc.EXPECT().ListCategories(tt.ctx).Return([]string{"eksaCluster"}, nil)
+	tt.govc.EXPECT().ListCategoryTagIDs(tt.ctx, "eksaCluster").Return(map[string]string{"eksaCluster:test:test": tagID}, nil)
+}
+
+func TestNeedsNewKubeadmConfigTemplateContainerdConfigurationChanged(t *testing.T) {
+	g := NewWithT(t)
+	vmc := givenClusterSpec(t, testClusterConfigMainFilename).VSphereMachineConfigs["test-wn"]
+	oldWorkerNodeGroup := &v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"}
+	newWorkerNodeGroup := oldWorkerNodeGroup.DeepCopy()
+	g.Expect(NeedsNewKubeadmConfigTemplate(newWorkerNodeGroup, oldWorkerNodeGroup, vmc, vmc)).To(BeFalse())
+
+	newWorkerNodeGroup.ContainerdConfiguration = &v1alpha1.ContainerdConfiguration{EnableNRI: true}
+	g.Expect(NeedsNewKubeadmConfigTemplate(newWorkerNodeGroup, oldWorkerNodeGroup, vmc, vmc)).To(BeTrue())
+}
+
+func TestNeedsNewKubeadmConfigTemplateHostOSConfigurationChanged(t *testing.T) {
+	g := NewWithT(t)
+	oldVmc := givenClusterSpec(t, testClusterConfigMainFilename).VSphereMachineConfigs["test-wn"]
+	newVmc := oldVmc.DeepCopy()
+	workerNodeGroup := &v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"}
+	newVmc.Spec.HostOSConfiguration = &v1alpha1.HostOSConfiguration{}
+	g.Expect(NeedsNewKubeadmConfigTemplate(workerNodeGroup, workerNodeGroup, oldVmc, newVmc)).To(BeFalse())
+
+	newVmc.Spec.HostOSConfiguration.Sysctls = map[string]string{"net.ipv4.ip_forward": "1"}
+	g.Expect(NeedsNewKubeadmConfigTemplate(workerNodeGroup, workerNodeGroup, oldVmc, newVmc)).To(BeTrue())
+}
+
+func TestAnyEtcdImmutableFieldChangedEtcdDiskChanged(t *testing.T) {
+	g := NewWithT(t)
+	spec := givenClusterSpec(t, testClusterConfigMainFilename)
+	vdc := spec.VSphereDatacenter
+	oldVmc := spec.VSphereMachineConfigs["test-etcd"]
+	newVmc := oldVmc.DeepCopy()
+	newVmc.Spec.EtcdDisk = &v1alpha1.EtcdDiskConfiguration{}
+	g.Expect(AnyEtcdImmutableFieldChanged(vdc, vdc, oldVmc, newVmc)).To(BeFalse())
+
+	newVmc.Spec.EtcdDisk.SizeGiB = 20
+	g.Expect(AnyEtcdImmutableFieldChanged(vdc, vdc, oldVmc, newVmc)).To(BeTrue())
+}
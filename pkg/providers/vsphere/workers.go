@@ -25,10 +25,10 @@ type (
 // WorkersSpec generates a vSphere specific CAPI spec for an eks-a cluster worker nodes.
 // It talks to the cluster with a client to detect changes in immutable objects and generates new
 // names for them.
-func WorkersSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*Workers, error) {
+func WorkersSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec, opts ...TemplateBuilderOpt) (*Workers, error) {
 	// TODO(g-gaston): refactor template builder so it doesn't behave differently for controller and CLI
 	// TODO(g-gaston): do we need time.Now if the names are not dependent on a timestamp anymore?
-	templateBuilder := NewVsphereTemplateBuilder(time.Now, false)
+	templateBuilder := NewVsphereTemplateBuilder(time.Now, false, opts...)
 	workersYaml, err := templateBuilder.CAPIWorkersSpecWithInitialNames(spec)
 	if err != nil {
 		return nil, err
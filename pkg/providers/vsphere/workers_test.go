@@ -57,6 +57,20 @@ func TestWorkersSpecNewCluster(t *testing.T) {
 	))
 }
 
+func TestWorkersSpecWithTagIDs(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	client := test.NewFakeKubeClient()
+	tagIDs := []string{"urn:vmomi:InventoryServiceTag:1:GLOBAL", "urn:vmomi:InventoryServiceTag:2:GLOBAL"}
+
+	workers, err := vsphere.WorkersSpec(ctx, logger, client, spec, vsphere.WithTagIDs(tagIDs))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers.Groups).To(HaveLen(1))
+	g.Expect(workers.Groups[0].ProviderMachineTemplate.Spec.Template.Spec.TagIDs).To(Equal(tagIDs))
+}
+
 func TestWorkersSpecUpgradeCluster(t *testing.T) {
 	g := NewWithT(t)
 	logger := test.NewNullLogger()
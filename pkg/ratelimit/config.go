@@ -0,0 +1,134 @@
+package ratelimit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	// QPSEnvVar overrides the maximum number of calls per second to each provider API endpoint.
+	QPSEnvVar = "EKSA_PROVIDER_API_QPS"
+	// BurstEnvVar overrides the number of calls to each provider API endpoint allowed above the QPS in a burst.
+	BurstEnvVar = "EKSA_PROVIDER_API_BURST"
+	// FailureThresholdEnvVar overrides the number of consecutive failures that suspend the calls to
+	// a provider API endpoint. 0 disables the circuit breaker.
+	FailureThresholdEnvVar = "EKSA_PROVIDER_API_FAILURE_THRESHOLD"
+	// CooldownEnvVar overrides how long calls to a provider API endpoint are suspended after too many failures.
+	CooldownEnvVar = "EKSA_PROVIDER_API_COOLDOWN"
+)
+
+// Config configures the rate limit and circuit breaker of provider API endpoints.
+type Config struct {
+	// QPS is the maximum sustained number of calls per second.
+	QPS float64
+	// Burst is the maximum number of calls allowed at once.
+	Burst int
+	// FailureThreshold is the number of consecutive failures that open the circuit breaker. 0 disables it.
+	FailureThreshold int
+	// Cooldown is how long the circuit breaker stays open before probing the endpoint again.
+	Cooldown time.Duration
+}
+
+// NewDefaultConfig returns the configuration used when nothing is overridden.
+func NewDefaultConfig() Config {
+	return Config{
+		QPS:              10,
+		Burst:            20,
+		FailureThreshold: 10,
+		Cooldown:         30 * time.Second,
+	}
+}
+
+// Validate checks the configuration values are within range.
+func (c Config) Validate() error {
+	if c.QPS <= 0 {
+		return fmt.Errorf("provider API QPS must be positive, got %v", c.QPS)
+	}
+	if c.Burst < 1 {
+		return fmt.Errorf("provider API burst must be at least 1, got %d", c.Burst)
+	}
+	if c.FailureThreshold < 0 {
+		return fmt.Errorf("provider API failure threshold can't be negative, got %d", c.FailureThreshold)
+	}
+	if c.Cooldown < 0 {
+		return fmt.Errorf("provider API cooldown can't be negative, got %s", c.Cooldown)
+	}
+	return nil
+}
+
+// ConfigFromEnv returns the default configuration with the overrides from the environment.
+func ConfigFromEnv() (Config, error) {
+	c := NewDefaultConfig()
+	var err error
+	if v, ok := os.LookupEnv(QPSEnvVar); ok {
+		if c.QPS, err = strconv.ParseFloat(v, 64); err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %v", QPSEnvVar, err)
+		}
+	}
+	if v, ok := os.LookupEnv(BurstEnvVar); ok {
+		if c.Burst, err = strconv.Atoi(v); err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %v", BurstEnvVar, err)
+		}
+	}
+	if v, ok := os.LookupEnv(FailureThresholdEnvVar); ok {
+		if c.FailureThreshold, err = strconv.Atoi(v); err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %v", FailureThresholdEnvVar, err)
+		}
+	}
+	if v, ok := os.LookupEnv(CooldownEnvVar); ok {
+		if c.Cooldown, err = time.ParseDuration(v); err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %v", CooldownEnvVar, err)
+		}
+	}
+
+	return c, c.Validate()
+}
+
+var (
+	registryLock  sync.Mutex
+	defaultConfig *Config
+	limiters      = map[string]*Limiter{}
+)
+
+// SetDefaultConfig sets the configuration of the limiters returned by For from now on.
+func SetDefaultConfig(c Config) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	defaultConfig = &c
+}
+
+// For returns the Limiter shared by all the clients of a provider API endpoint in the process.
+// Limiters are configured with the config set with SetDefaultConfig or, if not set, with ConfigFromEnv.
+func For(endpoint string) *Limiter {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if l, ok := limiters[endpoint]; ok {
+		return l
+	}
+
+	if defaultConfig == nil {
+		c, err := ConfigFromEnv()
+		if err != nil {
+			logger.Info("Warning: ignoring provider API rate limit overrides", "error", err)
+			c = NewDefaultConfig()
+		}
+		defaultConfig = &c
+	}
+
+	l := NewLimiter(endpoint, *defaultConfig)
+	limiters[endpoint] = l
+	return l
+}
+
+// Reset forgets the shared limiters and the default config. It's meant for tests.
+func Reset() {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	defaultConfig = nil
+	limiters = map[string]*Limiter{}
+}
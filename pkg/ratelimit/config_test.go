@@ -0,0 +1,104 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
+)
+
+func TestConfigFromEnvDefaults(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(ratelimit.ConfigFromEnv()).To(Equal(ratelimit.NewDefaultConfig()))
+}
+
+func TestConfigFromEnvOverrides(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(ratelimit.QPSEnvVar, "2.5")
+	t.Setenv(ratelimit.BurstEnvVar, "5")
+	t.Setenv(ratelimit.FailureThresholdEnvVar, "0")
+	t.Setenv(ratelimit.CooldownEnvVar, "1m")
+
+	g.Expect(ratelimit.ConfigFromEnv()).To(Equal(ratelimit.Config{
+		QPS:              2.5,
+		Burst:            5,
+		FailureThreshold: 0,
+		Cooldown:         time.Minute,
+	}))
+}
+
+func TestConfigFromEnvErrors(t *testing.T) {
+	tests := map[string]struct {
+		env, value, wantErr string
+	}{
+		"invalid qps": {
+			env:     ratelimit.QPSEnvVar,
+			value:   "fast",
+			wantErr: "invalid EKSA_PROVIDER_API_QPS",
+		},
+		"invalid burst": {
+			env:     ratelimit.BurstEnvVar,
+			value:   "1.5",
+			wantErr: "invalid EKSA_PROVIDER_API_BURST",
+		},
+		"invalid failure threshold": {
+			env:     ratelimit.FailureThresholdEnvVar,
+			value:   "many",
+			wantErr: "invalid EKSA_PROVIDER_API_FAILURE_THRESHOLD",
+		},
+		"invalid cooldown": {
+			env:     ratelimit.CooldownEnvVar,
+			value:   "30",
+			wantErr: "invalid EKSA_PROVIDER_API_COOLDOWN",
+		},
+		"zero qps": {
+			env:     ratelimit.QPSEnvVar,
+			value:   "0",
+			wantErr: "provider API QPS must be positive, got 0",
+		},
+		"zero burst": {
+			env:     ratelimit.BurstEnvVar,
+			value:   "0",
+			wantErr: "provider API burst must be at least 1, got 0",
+		},
+		"negative failure threshold": {
+			env:     ratelimit.FailureThresholdEnvVar,
+			value:   "-1",
+			wantErr: "provider API failure threshold can't be negative, got -1",
+		},
+		"negative cooldown": {
+			env:     ratelimit.CooldownEnvVar,
+			value:   "-1s",
+			wantErr: "provider API cooldown can't be negative, got -1s",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Setenv(tc.env, tc.value)
+			_, err := ratelimit.ConfigFromEnv()
+			g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+		})
+	}
+}
+
+func TestFor(t *testing.T) {
+	g := NewWithT(t)
+	t.Cleanup(ratelimit.Reset)
+	ratelimit.Reset()
+
+	l := ratelimit.For("vcenter-1")
+	g.Expect(ratelimit.For("vcenter-1")).To(BeIdenticalTo(l))
+	g.Expect(ratelimit.For("vcenter-2")).NotTo(BeIdenticalTo(l))
+}
+
+func TestForInvalidEnvUsesDefaults(t *testing.T) {
+	g := NewWithT(t)
+	t.Cleanup(ratelimit.Reset)
+	ratelimit.Reset()
+	t.Setenv(ratelimit.QPSEnvVar, "fast")
+
+	g.Expect(ratelimit.For("vcenter")).NotTo(BeNil())
+}
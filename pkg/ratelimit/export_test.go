@@ -0,0 +1,7 @@
+package ratelimit
+
+import "time"
+
+func SetNow(l *Limiter, now func() time.Time) {
+	l.now = now
+}
@@ -0,0 +1,127 @@
+// Package ratelimit throttles the calls EKS Anywhere makes to infrastructure provider APIs, like vCenter,
+// Prism Central or CloudStack, so large fleets don't saturate their task queues.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrCircuitOpen is returned, wrapped, when calls to an endpoint are suspended after too many consecutive failures.
+var ErrCircuitOpen = errors.New("provider API circuit breaker open")
+
+// Limiter throttles the calls to a provider API endpoint to a maximum rate and, after a number of consecutive
+// failures, stops calling it for a cooldown period. Only the errors that mean the endpoint is unavailable or
+// overloaded count as failures, see IsUnavailable. Once the cooldown expires, a single call probes the endpoint:
+// calls resume if it succeeds and are suspended for another cooldown period if it fails.
+// It's safe for concurrent use.
+type Limiter struct {
+	endpoint         string
+	limiter          *rate.Limiter
+	failureThreshold int
+	cooldown         time.Duration
+	now              func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// NewLimiter builds a Limiter for an endpoint with the given configuration.
+func NewLimiter(endpoint string, config Config) *Limiter {
+	return &Limiter{
+		endpoint:         endpoint,
+		limiter:          rate.NewLimiter(rate.Limit(config.QPS), config.Burst),
+		failureThreshold: config.FailureThreshold,
+		cooldown:         config.Cooldown,
+		now:              time.Now,
+	}
+}
+
+// Do waits until the rate limit allows another call and runs call, unless the circuit breaker is open,
+// in which case it fails right away with ErrCircuitOpen. An error from call that IsUnavailable counts
+// towards the consecutive failures that open the circuit breaker, any other result resets them.
+func (l *Limiter) Do(ctx context.Context, call func() error) error {
+	if err := l.allow(); err != nil {
+		return err
+	}
+
+	if err := l.limiter.Wait(ctx); err != nil {
+		l.cancelProbe()
+		return fmt.Errorf("waiting for rate limiter of %s: %v", l.endpoint, err)
+	}
+
+	err := call()
+	l.record(err)
+	return err
+}
+
+func (l *Limiter) allow() error {
+	if l.failureThreshold <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.failures < l.failureThreshold {
+		return nil
+	}
+	if l.probing || l.now().Before(l.openUntil) {
+		return fmt.Errorf("%w: %d consecutive failures calling %s, retrying after %s", ErrCircuitOpen, l.failures, l.endpoint, l.openUntil.Format(time.RFC3339))
+	}
+
+	l.probing = true
+	return nil
+}
+
+func (l *Limiter) record(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.probing = false
+	if !IsUnavailable(err) {
+		l.failures = 0
+		return
+	}
+
+	l.failures++
+	if l.failureThreshold > 0 && l.failures >= l.failureThreshold {
+		l.openUntil = l.now().Add(l.cooldown)
+	}
+}
+
+func (l *Limiter) cancelProbe() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.probing = false
+}
+
+var (
+	serverErrorPattern = regexp.MustCompile(`(?i)\b(status( code)?:? *|http[ /0-9.]* )5[0-9]{2}\b|\b5[0-9]{2} (internal server error|not implemented|bad gateway|service unavailable|gateway timeout)`)
+	throttlingPattern  = regexp.MustCompile(`(?i)\b429\b|too many requests|throttl|rate exceeded|request limit exceeded`)
+	transportPattern   = regexp.MustCompile(`(?i)connection refused|connection reset|i/o timeout|no such host|network is unreachable|tls handshake timeout|server closed|unexpected eof|context deadline exceeded`)
+)
+
+// IsUnavailable reports whether err means a provider API endpoint couldn't serve a call: a transport error,
+// a server error (5xx) or throttling. Errors for a call the endpoint answered, like a resource not found or
+// an invalid request, don't. The provider clients are mostly CLIs, so the error message is matched as well.
+func IsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	msg := err.Error()
+	return transportPattern.MatchString(msg) || serverErrorPattern.MatchString(msg) || throttlingPattern.MatchString(msg)
+}
@@ -0,0 +1,140 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
+)
+
+var errCall = errors.New("govc: 503 Service Unavailable")
+
+func succeed() error { return nil }
+
+func fail() error { return errCall }
+
+func TestLimiterDoThrottles(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	l := ratelimit.NewLimiter("vcenter", ratelimit.Config{QPS: 20, Burst: 1})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		g.Expect(l.Do(ctx, succeed)).To(Succeed())
+	}
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 90*time.Millisecond))
+}
+
+func TestLimiterDoContextCancelled(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	l := ratelimit.NewLimiter("vcenter", ratelimit.Config{QPS: 0.001, Burst: 1})
+	g.Expect(l.Do(ctx, succeed)).To(Succeed())
+
+	cancel()
+	called := false
+	err := l.Do(ctx, func() error {
+		called = true
+		return nil
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("waiting for rate limiter of vcenter")))
+	g.Expect(called).To(BeFalse())
+}
+
+func TestLimiterDoCircuitBreaker(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	now := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+	l := ratelimit.NewLimiter("vcenter", ratelimit.Config{QPS: 1000, Burst: 10, FailureThreshold: 2, Cooldown: time.Minute})
+	ratelimit.SetNow(l, func() time.Time { return now })
+
+	g.Expect(l.Do(ctx, fail)).To(MatchError(errCall))
+	g.Expect(l.Do(ctx, succeed)).To(Succeed(), "a success resets the consecutive failures")
+	g.Expect(l.Do(ctx, fail)).To(MatchError(errCall))
+	g.Expect(l.Do(ctx, fail)).To(MatchError(errCall))
+
+	err := l.Do(ctx, succeed)
+	g.Expect(errors.Is(err, ratelimit.ErrCircuitOpen)).To(BeTrue())
+	g.Expect(err).To(MatchError(ContainSubstring("2 consecutive failures calling vcenter, retrying after 2022-08-01T10:01:00Z")))
+
+	now = now.Add(time.Minute)
+	g.Expect(l.Do(ctx, fail)).To(MatchError(errCall), "the probe after the cooldown calls the endpoint")
+	g.Expect(errors.Is(l.Do(ctx, succeed), ratelimit.ErrCircuitOpen)).To(BeTrue(), "a failed probe opens the circuit again")
+
+	now = now.Add(time.Minute)
+	g.Expect(l.Do(ctx, succeed)).To(Succeed())
+	g.Expect(l.Do(ctx, fail)).To(MatchError(errCall))
+	g.Expect(l.Do(ctx, succeed)).To(Succeed(), "a successful probe closes the circuit")
+}
+
+func TestLimiterDoConcurrentProbe(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	now := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+	l := ratelimit.NewLimiter("vcenter", ratelimit.Config{QPS: 1000, Burst: 10, FailureThreshold: 1, Cooldown: time.Minute})
+	ratelimit.SetNow(l, func() time.Time { return now })
+	g.Expect(l.Do(ctx, fail)).To(MatchError(errCall))
+	now = now.Add(time.Minute)
+
+	err := l.Do(ctx, func() error {
+		g.Expect(errors.Is(l.Do(ctx, succeed), ratelimit.ErrCircuitOpen)).To(BeTrue(), "only one probe runs at a time")
+		return nil
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestLimiterDoCircuitBreakerDisabled(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	l := ratelimit.NewLimiter("vcenter", ratelimit.Config{QPS: 1000, Burst: 10})
+
+	for i := 0; i < 20; i++ {
+		g.Expect(l.Do(ctx, fail)).To(MatchError(errCall))
+	}
+}
+
+func TestLimiterDoCircuitBreakerIgnoresNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	l := ratelimit.NewLimiter("vcenter", ratelimit.Config{QPS: 1000, Burst: 10, FailureThreshold: 2, Cooldown: time.Minute})
+	notFound := errors.New("govc: vm 'cluster-cp-1' not found")
+
+	g.Expect(l.Do(ctx, fail)).To(MatchError(errCall))
+	g.Expect(l.Do(ctx, func() error { return notFound })).To(MatchError(notFound), "an answered call resets the consecutive failures")
+	g.Expect(l.Do(ctx, fail)).To(MatchError(errCall))
+	for i := 0; i < 5; i++ {
+		g.Expect(l.Do(ctx, func() error { return notFound })).To(MatchError(notFound))
+	}
+}
+
+func TestIsUnavailable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "deadline exceeded", err: fmt.Errorf("calling prism: %w", context.DeadlineExceeded), want: true},
+		{name: "net error", err: &net.OpError{Op: "dial", Err: errors.New("refused")}, want: true},
+		{name: "connection refused", err: errors.New("Post \"https://vcenter/sdk\": dial tcp 10.0.0.1:443: connect: connection refused"), want: true},
+		{name: "server error", err: errors.New("govc: ServerFaultCode: 503 Service Unavailable"), want: true},
+		{name: "http status", err: errors.New("Error: HTTP 502 Bad Gateway"), want: true},
+		{name: "status code", err: errors.New("status: 500, message: internal error"), want: true},
+		{name: "throttling", err: errors.New("429 Too Many Requests"), want: true},
+		{name: "not found", err: errors.New("govc: folder '/SDDC-Datacenter/vm/test' not found"), want: false},
+		{name: "unauthorized", err: errors.New("status: 401, message: Authentication required"), want: false},
+		{name: "invalid request", err: errors.New("cmk: (HTTP 431, error code 4350) Unable to find template"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ratelimit.IsUnavailable(tt.err)).To(Equal(tt.want))
+		})
+	}
+}
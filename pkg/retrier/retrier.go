@@ -1,6 +1,9 @@
 package retrier
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
 	"time"
 
@@ -11,6 +14,8 @@ type Retrier struct {
 	retryPolicy   RetryPolicy
 	timeout       time.Duration
 	backoffFactor *float32
+	retryOn       []ErrorClassifier
+	abortOn       []ErrorClassifier
 }
 
 type (
@@ -18,8 +23,21 @@ type (
 	// should be performed and the wait duration indicates the wait time before the next retry.
 	RetryPolicy func(totalRetries int, err error) (retry bool, wait time.Duration)
 	RetrierOpt  func(*Retrier)
+	// ErrorClassifier reports whether an error belongs to a class of errors, like transient
+	// connection errors or authentication failures.
+	ErrorClassifier func(err error) bool
 )
 
+// Attempt describes an execution of the function being retried.
+type Attempt struct {
+	// Number is the number of the attempt, starting at 1.
+	Number int
+	// Elapsed is the time since the first attempt started.
+	Elapsed time.Duration
+	// LastErr is the error returned by the previous attempt. It's nil for the first one.
+	LastErr error
+}
+
 // New creates a new retrier with a global timeout (max time allowed for the whole execution)
 // The default retry policy is to always retry with no wait time in between retries.
 func New(timeout time.Duration, opts ...RetrierOpt) *Retrier {
@@ -35,9 +53,10 @@ func New(timeout time.Duration, opts ...RetrierOpt) *Retrier {
 }
 
 // NewWithMaxRetries creates a new retrier with no global timeout and a max retries policy.
-func NewWithMaxRetries(maxRetries int, backOffPeriod time.Duration) *Retrier {
+// Additional options, like error classifiers, can be passed in opts.
+func NewWithMaxRetries(maxRetries int, backOffPeriod time.Duration, opts ...RetrierOpt) *Retrier {
 	// this value is roughly 292 years, so in practice there is no timeout
-	return New(time.Duration(math.MaxInt64), WithMaxRetries(maxRetries, backOffPeriod))
+	return New(time.Duration(math.MaxInt64), append([]RetrierOpt{WithMaxRetries(maxRetries, backOffPeriod)}, opts...)...)
 }
 
 // WithMaxRetries sets a retry policy that will retry up to maxRetries times
@@ -60,38 +79,72 @@ func WithRetryPolicy(policy RetryPolicy) RetrierOpt {
 	}
 }
 
+// WithRetryOn only retries the errors matched by any of the classifiers. Any other error aborts
+// the execution right away.
+func WithRetryOn(classifiers ...ErrorClassifier) RetrierOpt {
+	return func(r *Retrier) {
+		r.retryOn = append(r.retryOn, classifiers...)
+	}
+}
+
+// WithAbortOn aborts the execution right away when an error is matched by any of the classifiers,
+// regardless of the retry policy. It takes precedence over WithRetryOn.
+func WithAbortOn(classifiers ...ErrorClassifier) RetrierOpt {
+	return func(r *Retrier) {
+		r.abortOn = append(r.abortOn, classifiers...)
+	}
+}
+
+// IsError returns a classifier that matches target and the errors wrapping it.
+func IsError(target error) ErrorClassifier {
+	return func(err error) bool {
+		return errors.Is(err, target)
+	}
+}
+
 // Retry runs the fn function until it either successful completes (not error),
 // the set timeout reached or the retry policy aborts the execution.
 func (r *Retrier) Retry(fn func() error) error {
+	return r.RetryWithContext(context.Background(), func(context.Context, Attempt) error {
+		return fn()
+	})
+}
+
+// RetryWithContext runs the fn function until it either successful completes (not error),
+// the set timeout reached, the retry policy or the error classifiers abort the execution or ctx is done.
+// fn receives the metadata of the current attempt. If ctx is done after a failed attempt, the returned
+// error wraps the error of that attempt.
+func (r *Retrier) RetryWithContext(ctx context.Context, fn func(ctx context.Context, attempt Attempt) error) error {
 	// While it seems aberrant to call a method with a nil receiver, several unit tests actually do.  With a previous
 	// version of this module (which didn't attempt to dereference the receiver until after the wrapped function failed)
 	// these passed.  Changes below, to log the receiver struct's key params changed that breaking the unit tests.
 	// The below conditional block restores the original behavior, enabling these tests to again pass.
 	if r == nil {
-		return fn()
+		return fn(ctx, Attempt{Number: 1})
 	}
 
 	start := time.Now()
-	retries := 0
-	var err error
+	attempt := Attempt{}
 	logger.V(5).Info("Retrier:", "timeout", r.timeout, "backoffFactor", r.backoffFactor)
 	for retry := true; retry; retry = time.Since(start) < r.timeout {
-		err = fn()
-		retries += 1
+		if ctx.Err() != nil {
+			return interrupted(ctx, attempt)
+		}
+
+		attempt.Number++
+		attempt.Elapsed = time.Since(start)
+		err := fn(ctx, attempt)
 		if err == nil {
-			logger.V(5).Info("Retry execution successful", "retries", retries, "duration", time.Since(start))
+			logger.V(5).Info("Retry execution successful", "retries", attempt.Number, "duration", time.Since(start))
 			return nil
 		}
-		logger.V(5).Info("Error happened during retry", "error", err, "retries", retries)
+		attempt.LastErr = err
+		logger.V(5).Info("Error happened during retry", "error", err, "retries", attempt.Number)
 
-		retry, wait := r.retryPolicy(retries, err)
+		retry, wait := r.next(attempt.Number, err)
 		if !retry {
-			logger.V(5).Info("Execution aborted by retry policy")
 			return err
 		}
-		if r.backoffFactor != nil {
-			wait = time.Duration(float32(wait) * (*r.backoffFactor * float32(retries)))
-		}
 
 		// If there's not enough time left for the policy-proposed wait, there's no value in waiting that duration
 		// before quitting at the bottom of the loop.  Just do it now.
@@ -102,12 +155,64 @@ func (r *Retrier) Retry(fn func() error) error {
 		}
 
 		logger.V(5).Info("Sleeping before next retry", "time", wait)
-		time.Sleep(wait)
+		if !sleep(ctx, wait) {
+			return interrupted(ctx, attempt)
+		}
+	}
+
+	logger.V(5).Info("Timeout reached. Returning error", "retries", attempt.Number, "duration", time.Since(start), "error", attempt.LastErr)
+
+	return attempt.LastErr
+}
+
+// next decides, based on the error classifiers and the retry policy, if a failed execution
+// should be retried and how long to wait before doing so.
+func (r *Retrier) next(retries int, err error) (retry bool, wait time.Duration) {
+	if matchesAny(r.abortOn, err) || (len(r.retryOn) > 0 && !matchesAny(r.retryOn, err)) {
+		logger.V(5).Info("Execution aborted by error classification")
+		return false, 0
 	}
 
-	logger.V(5).Info("Timeout reached. Returning error", "retries", retries, "duration", time.Since(start), "error", err)
+	retry, wait = r.retryPolicy(retries, err)
+	if !retry {
+		logger.V(5).Info("Execution aborted by retry policy")
+		return false, 0
+	}
+	if r.backoffFactor != nil {
+		wait = time.Duration(float32(wait) * (*r.backoffFactor * float32(retries)))
+	}
+
+	return true, wait
+}
+
+func matchesAny(classifiers []ErrorClassifier, err error) bool {
+	for _, matches := range classifiers {
+		if matches(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// sleep waits for the given duration, returning false if ctx is done before.
+func sleep(ctx context.Context, wait time.Duration) bool {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func interrupted(ctx context.Context, attempt Attempt) error {
+	logger.V(5).Info("Context done, stopping retries", "retries", attempt.Number, "error", ctx.Err())
+	if attempt.LastErr == nil {
+		return ctx.Err()
+	}
 
-	return err
+	return fmt.Errorf("retries interrupted after %d attempts (%v): %w", attempt.Number, ctx.Err(), attempt.LastErr)
 }
 
 // Retry runs fn with a MaxRetriesPolicy.
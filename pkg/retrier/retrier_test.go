@@ -1,7 +1,9 @@
 package retrier_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -185,3 +187,113 @@ func TestRetrierWithNilReceiver(t *testing.T) {
 		t.Errorf("Retrier didn't correctly handle nil receiver")
 	}
 }
+
+func TestRetryWithContextAttemptMetadata(t *testing.T) {
+	r := retrier.NewWithMaxRetries(3, 0)
+	failures := []error{errors.New("first"), errors.New("second")}
+	var gotAttempts []retrier.Attempt
+	fn := func(_ context.Context, attempt retrier.Attempt) error {
+		gotAttempts = append(gotAttempts, attempt)
+		if attempt.Number <= len(failures) {
+			return failures[attempt.Number-1]
+		}
+		return nil
+	}
+
+	if err := r.RetryWithContext(context.Background(), fn); err != nil {
+		t.Fatalf("Retrier.RetryWithContext() error = %v, want nil", err)
+	}
+
+	if len(gotAttempts) != 3 {
+		t.Fatalf("Wrong number of attempts, got %d, want 3", len(gotAttempts))
+	}
+	for i, attempt := range gotAttempts {
+		if attempt.Number != i+1 {
+			t.Errorf("Attempt %d has number %d", i+1, attempt.Number)
+		}
+		var wantLastErr error
+		if i > 0 {
+			wantLastErr = failures[i-1]
+		}
+		if attempt.LastErr != wantLastErr {
+			t.Errorf("Attempt %d has last error %v, want %v", i+1, attempt.LastErr, wantLastErr)
+		}
+	}
+}
+
+func TestRetryWithContextCanceledWhileWaiting(t *testing.T) {
+	r := retrier.NewWithMaxRetries(10, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	realErr := errors.New("connection refused")
+	gotRetries := 0
+	fn := func(context.Context, retrier.Attempt) error {
+		gotRetries += 1
+		cancel()
+		return realErr
+	}
+
+	err := r.RetryWithContext(ctx, fn)
+	if !errors.Is(err, realErr) {
+		t.Fatalf("Retrier.RetryWithContext() error = %v, want it to wrap %v", err, realErr)
+	}
+
+	if gotRetries != 1 {
+		t.Fatalf("Wrong number of retries, got %d, want 1", gotRetries)
+	}
+}
+
+func TestRetryWithContextAlreadyCanceled(t *testing.T) {
+	r := retrier.NewWithMaxRetries(10, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fn := func(context.Context, retrier.Attempt) error {
+		t.Fatal("fn shouldn't be called with a canceled context")
+		return nil
+	}
+
+	if err := r.RetryWithContext(ctx, fn); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Retrier.RetryWithContext() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestWithAbortOn(t *testing.T) {
+	permanentErr := errors.New("permission denied")
+	r := retrier.NewWithMaxRetries(10, 0, retrier.WithAbortOn(retrier.IsError(permanentErr)))
+	gotRetries := 0
+	fn := func() error {
+		gotRetries += 1
+		if gotRetries == 2 {
+			return fmt.Errorf("listing datastores: %w", permanentErr)
+		}
+		return errors.New("timeout")
+	}
+
+	if err := r.Retry(fn); !errors.Is(err, permanentErr) {
+		t.Fatalf("Retrier.Retry() error = %v, want it to wrap %v", err, permanentErr)
+	}
+
+	if gotRetries != 2 {
+		t.Fatalf("Wrong number of retries, got %d, want 2", gotRetries)
+	}
+}
+
+func TestWithRetryOn(t *testing.T) {
+	transientErr := errors.New("timeout")
+	r := retrier.NewWithMaxRetries(10, 0, retrier.WithRetryOn(retrier.IsError(transientErr)))
+	gotRetries := 0
+	fn := func() error {
+		gotRetries += 1
+		if gotRetries == 3 {
+			return errors.New("invalid credentials")
+		}
+		return transientErr
+	}
+
+	if err := r.Retry(fn); err == nil || err.Error() != "invalid credentials" {
+		t.Fatalf("Retrier.Retry() error = %v, want invalid credentials", err)
+	}
+
+	if gotRetries != 3 {
+		t.Fatalf("Wrong number of retries, got %d, want 3", gotRetries)
+	}
+}
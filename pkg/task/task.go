@@ -27,24 +27,25 @@ type Task interface {
 
 // Command context maintains the mutable and shared entities.
 type CommandContext struct {
-	Bootstrapper       interfaces.Bootstrapper
-	Provider           providers.Provider
-	ClusterManager     interfaces.ClusterManager
-	GitOpsManager      interfaces.GitOpsManager
-	Validations        interfaces.Validator
-	Writer             filewriter.FileWriter
-	EksdInstaller      interfaces.EksdInstaller
-	PackageInstaller   interfaces.PackageInstaller
-	EksdUpgrader       interfaces.EksdUpgrader
-	CAPIManager        interfaces.CAPIManager
-	ClusterSpec        *cluster.Spec
-	CurrentClusterSpec *cluster.Spec
-	UpgradeChangeDiff  *types.ChangeDiff
-	BootstrapCluster   *types.Cluster
-	ManagementCluster  *types.Cluster
-	WorkloadCluster    *types.Cluster
-	Profiler           *Profiler
-	OriginalError      error
+	Bootstrapper        interfaces.Bootstrapper
+	Provider            providers.Provider
+	ClusterManager      interfaces.ClusterManager
+	GitOpsManager       interfaces.GitOpsManager
+	Validations         interfaces.Validator
+	Writer              filewriter.FileWriter
+	EksdInstaller       interfaces.EksdInstaller
+	PackageInstaller    interfaces.PackageInstaller
+	PostCreateValidator interfaces.PostCreateValidator
+	EksdUpgrader        interfaces.EksdUpgrader
+	CAPIManager         interfaces.CAPIManager
+	ClusterSpec         *cluster.Spec
+	CurrentClusterSpec  *cluster.Spec
+	UpgradeChangeDiff   *types.ChangeDiff
+	BootstrapCluster    *types.Cluster
+	ManagementCluster   *types.Cluster
+	WorkloadCluster     *types.Cluster
+	Profiler            *Profiler
+	OriginalError       error
 }
 
 func (c *CommandContext) SetError(err error) {
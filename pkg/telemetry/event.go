@@ -0,0 +1,62 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/wait"
+)
+
+// FailureClass is a coarse, anonymous classification of why a command failed.
+type FailureClass string
+
+const (
+	// FailureValidation means the command failed validating its inputs or the environment.
+	FailureValidation FailureClass = "Validation"
+	// FailureTimeout means the command timed out waiting for a resource.
+	FailureTimeout FailureClass = "Timeout"
+	// FailureCanceled means the command was interrupted by the user.
+	FailureCanceled FailureClass = "Canceled"
+	// FailureProviderAPI means the infrastructure provider API kept failing.
+	FailureProviderAPI FailureClass = "ProviderAPI"
+	// FailureUnknown is used for all other failures.
+	FailureUnknown FailureClass = "Unknown"
+)
+
+// Event is the usage information recorded for each command execution.
+// It doesn't contain any names, addresses, error messages or other information
+// that could identify a user or their infrastructure.
+type Event struct {
+	Time            time.Time    `json:"time"`
+	Command         string       `json:"command"`
+	Provider        string       `json:"provider,omitempty"`
+	DurationSeconds float64      `json:"durationSeconds"`
+	Succeeded       bool         `json:"succeeded"`
+	FailureClass    FailureClass `json:"failureClass,omitempty"`
+	Version         string       `json:"version"`
+	OS              string       `json:"os"`
+	Arch            string       `json:"arch"`
+}
+
+// ClassifyFailure returns the FailureClass for an error returned by a command.
+// It returns an empty class for nil errors.
+func ClassifyFailure(err error) FailureClass {
+	validationErr := &validations.ValidationError{}
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &validationErr):
+		return FailureValidation
+	case wait.IsCanceled(err), errors.Is(err, context.Canceled):
+		return FailureCanceled
+	case wait.IsTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return FailureTimeout
+	case errors.Is(err, ratelimit.ErrCircuitOpen):
+		return FailureProviderAPI
+	default:
+		return FailureUnknown
+	}
+}
@@ -0,0 +1,68 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/ratelimit"
+	"github.com/aws/eks-anywhere/pkg/telemetry"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/wait"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want telemetry.FailureClass
+	}{
+		{
+			name: "no error",
+			err:  nil,
+			want: "",
+		},
+		{
+			name: "validation",
+			err:  fmt.Errorf("validations failed: %w", &validations.ValidationError{Errs: []string{"invalid"}}),
+			want: telemetry.FailureValidation,
+		},
+		{
+			name: "wait timeout",
+			err: wait.New(time.Nanosecond).For(context.Background(), "nodes", func(ctx context.Context) (wait.Status, error) {
+				return wait.Status{}, nil
+			}),
+			want: telemetry.FailureTimeout,
+		},
+		{
+			name: "context deadline",
+			err:  fmt.Errorf("waiting: %w", context.DeadlineExceeded),
+			want: telemetry.FailureTimeout,
+		},
+		{
+			name: "canceled",
+			err:  fmt.Errorf("kubectl was interrupted: %w", context.Canceled),
+			want: telemetry.FailureCanceled,
+		},
+		{
+			name: "provider api",
+			err:  fmt.Errorf("searching template: %w", ratelimit.ErrCircuitOpen),
+			want: telemetry.FailureProviderAPI,
+		},
+		{
+			name: "unknown",
+			err:  errors.New("something went wrong"),
+			want: telemetry.FailureUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(telemetry.ClassifyFailure(tt.err)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	queueFileName   = "events.jsonl"
+	defaultMaxQueue = 500
+)
+
+// Queue stores events locally until they can be sent.
+// Events are stored as JSON lines in a file, so they can be inspected by users.
+// When the queue is full, the oldest events are dropped.
+type Queue struct {
+	path      string
+	maxEvents int
+	mu        sync.Mutex
+}
+
+// NewQueue returns a Queue that stores events in dir.
+func NewQueue(dir string) *Queue {
+	return &Queue{
+		path:      filepath.Join(dir, queueFileName),
+		maxEvents: defaultMaxQueue,
+	}
+}
+
+// Enqueue adds an event to the queue.
+func (q *Queue) Enqueue(e Event) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	events, err := q.read()
+	if err != nil {
+		return err
+	}
+
+	events = append(events, e)
+	if len(events) > q.maxEvents {
+		events = events[len(events)-q.maxEvents:]
+	}
+
+	return q.write(events)
+}
+
+// Events returns all the events in the queue, oldest first.
+func (q *Queue) Events() ([]Event, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.read()
+}
+
+// Remove deletes the n oldest events from the queue.
+func (q *Queue) Remove(n int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	events, err := q.read()
+	if err != nil {
+		return err
+	}
+	if n > len(events) {
+		n = len(events)
+	}
+
+	return q.write(events[n:])
+}
+
+func (q *Queue) read() ([]Event, error) {
+	content, err := os.ReadFile(q.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading telemetry queue: %v", err)
+	}
+
+	var events []Event
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		e := Event{}
+		// Skip corrupted lines instead of losing the whole queue.
+		if err := json.Unmarshal(scanner.Bytes(), &e); err == nil {
+			events = append(events, e)
+		}
+	}
+
+	return events, scanner.Err()
+}
+
+func (q *Queue) write(events []Event) error {
+	if err := os.MkdirAll(filepath.Dir(q.path), 0o700); err != nil {
+		return fmt.Errorf("creating telemetry queue folder: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for _, e := range events {
+		if err := encoder.Encode(e); err != nil {
+			return fmt.Errorf("encoding telemetry event: %v", err)
+		}
+	}
+
+	if err := os.WriteFile(q.path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("writing telemetry queue: %v", err)
+	}
+
+	return nil
+}
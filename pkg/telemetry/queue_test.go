@@ -0,0 +1,48 @@
+package telemetry_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/telemetry"
+)
+
+func TestQueueEnqueueAndRemove(t *testing.T) {
+	g := NewWithT(t)
+	q := telemetry.NewQueue(filepath.Join(t.TempDir(), "telemetry"))
+
+	events, err := q.Events()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(events).To(BeEmpty())
+
+	g.Expect(q.Enqueue(telemetry.Event{Command: "anywhere create cluster"})).To(Succeed())
+	g.Expect(q.Enqueue(telemetry.Event{Command: "anywhere upgrade cluster"})).To(Succeed())
+
+	events, err = q.Events()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(events).To(HaveLen(2))
+	g.Expect(events[0].Command).To(Equal("anywhere create cluster"))
+
+	g.Expect(q.Remove(1)).To(Succeed())
+	events, err = q.Events()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(events).To(ConsistOf(telemetry.Event{Command: "anywhere upgrade cluster"}))
+
+	g.Expect(q.Remove(5)).To(Succeed())
+	g.Expect(q.Events()).To(BeEmpty())
+}
+
+func TestQueueEventsSkipsCorruptedLines(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	content := "{\"command\":\"anywhere create cluster\"}\nnot json\n"
+	g.Expect(os.WriteFile(filepath.Join(dir, "events.jsonl"), []byte(content), 0o600)).To(Succeed())
+	q := telemetry.NewQueue(dir)
+
+	events, err := q.Events()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(events).To(ConsistOf(telemetry.Event{Command: "anywhere create cluster"}))
+}
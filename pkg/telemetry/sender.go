@@ -0,0 +1,54 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Sender sends events to a telemetry backend.
+type Sender interface {
+	Send(ctx context.Context, events []Event) error
+}
+
+// HTTPSender posts events as a JSON array to an HTTP endpoint.
+type HTTPSender struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPSender returns a Sender that posts events to endpoint.
+func NewHTTPSender(endpoint string, client *http.Client) *HTTPSender {
+	return &HTTPSender{
+		endpoint: endpoint,
+		client:   client,
+	}
+}
+
+// Send posts the events to the endpoint.
+func (s *HTTPSender) Send(ctx context.Context, events []Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("marshalling telemetry events: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building telemetry request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending telemetry events: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sending telemetry events: unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// Package telemetry records anonymous usage and failure statistics for CLI commands.
+// Telemetry is disabled by default and users need to opt in explicitly.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+const (
+	// EnabledEnvVar opts in to telemetry when set to "true".
+	EnabledEnvVar = "EKSA_TELEMETRY_ENABLED"
+	// EndpointEnvVar is the HTTP endpoint queued events are sent to.
+	// If not set, events are only stored in the local queue.
+	EndpointEnvVar = "EKSA_TELEMETRY_ENDPOINT"
+	// DirEnvVar overrides the folder where the local queue is stored.
+	DirEnvVar = "EKSA_TELEMETRY_DIR"
+
+	sendTimeout = 5 * time.Second
+)
+
+// Recorder records usage events for CLI commands.
+// All its methods are no-ops on a nil Recorder, which represents disabled telemetry.
+type Recorder struct {
+	queue  *Queue
+	sender Sender
+
+	mu       sync.Mutex
+	provider string
+}
+
+// NewRecorder returns a Recorder that stores events in queue and sends them with sender.
+// If sender is nil, events are only queued.
+func NewRecorder(queue *Queue, sender Sender) *Recorder {
+	return &Recorder{
+		queue:  queue,
+		sender: sender,
+	}
+}
+
+// NewRecorderFromEnv returns a Recorder configured with the telemetry env vars.
+// It returns nil if the user hasn't opted in to telemetry.
+func NewRecorderFromEnv() (*Recorder, error) {
+	if os.Getenv(EnabledEnvVar) != "true" {
+		return nil, nil
+	}
+
+	dir := os.Getenv(DirEnvVar)
+	if dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("finding folder for telemetry queue: %v", err)
+		}
+		dir = filepath.Join(cacheDir, "eks-anywhere", "telemetry")
+	}
+
+	var sender Sender
+	if endpoint := os.Getenv(EndpointEnvVar); endpoint != "" {
+		sender = NewHTTPSender(endpoint, &http.Client{Timeout: sendTimeout})
+	}
+
+	return NewRecorder(NewQueue(dir), sender), nil
+}
+
+// SetProvider sets the infrastructure provider used by the command being recorded.
+func (r *Recorder) SetProvider(provider string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.provider = provider
+}
+
+// Record queues an event for a command execution that took duration and returned err.
+func (r *Recorder) Record(command string, duration time.Duration, err error) error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	provider := r.provider
+	r.mu.Unlock()
+
+	return r.queue.Enqueue(Event{
+		Time:            time.Now().UTC().Truncate(time.Second),
+		Command:         command,
+		Provider:        provider,
+		DurationSeconds: duration.Round(time.Second).Seconds(),
+		Succeeded:       err == nil,
+		FailureClass:    ClassifyFailure(err),
+		Version:         version.Get().GitVersion,
+		OS:              runtime.GOOS,
+		Arch:            runtime.GOARCH,
+	})
+}
+
+// Flush sends all queued events, removing them from the queue once they are accepted.
+// Events are kept in the queue if they can't be sent, so they can be retried later.
+func (r *Recorder) Flush(ctx context.Context) error {
+	if r == nil || r.sender == nil {
+		return nil
+	}
+
+	events, err := r.queue.Events()
+	if err != nil || len(events) == 0 {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	if err := r.sender.Send(ctx, events); err != nil {
+		return err
+	}
+
+	return r.queue.Remove(len(events))
+}
+
+type recorderContextKey struct{}
+
+// WithRecorder returns a copy of ctx that carries r.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderContextKey{}, r)
+}
+
+// SetProvider sets the provider in the Recorder carried by ctx, if any.
+func SetProvider(ctx context.Context, provider string) {
+	r, _ := ctx.Value(recorderContextKey{}).(*Recorder)
+	r.SetProvider(provider)
+}
@@ -0,0 +1,129 @@
+package telemetry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/telemetry"
+)
+
+type fakeSender struct {
+	sent [][]telemetry.Event
+	err  error
+}
+
+func (s *fakeSender) Send(_ context.Context, events []telemetry.Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, events)
+	return nil
+}
+
+func TestRecorderRecordAndFlush(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	queue := telemetry.NewQueue(t.TempDir())
+	sender := &fakeSender{}
+	r := telemetry.NewRecorder(queue, sender)
+	telemetry.SetProvider(telemetry.WithRecorder(ctx, r), "vsphere")
+
+	g.Expect(r.Record("anywhere create cluster", 90*time.Second, nil)).To(Succeed())
+	g.Expect(r.Record("anywhere upgrade cluster", time.Second, context.DeadlineExceeded)).To(Succeed())
+	g.Expect(r.Flush(ctx)).To(Succeed())
+
+	g.Expect(sender.sent).To(HaveLen(1))
+	events := sender.sent[0]
+	g.Expect(events).To(HaveLen(2))
+	g.Expect(events[0].Command).To(Equal("anywhere create cluster"))
+	g.Expect(events[0].Provider).To(Equal("vsphere"))
+	g.Expect(events[0].DurationSeconds).To(Equal(90.0))
+	g.Expect(events[0].Succeeded).To(BeTrue())
+	g.Expect(events[0].FailureClass).To(BeEmpty())
+	g.Expect(events[1].Succeeded).To(BeFalse())
+	g.Expect(events[1].FailureClass).To(Equal(telemetry.FailureTimeout))
+
+	g.Expect(queue.Events()).To(BeEmpty())
+}
+
+func TestRecorderFlushErrorKeepsEvents(t *testing.T) {
+	g := NewWithT(t)
+	queue := telemetry.NewQueue(t.TempDir())
+	r := telemetry.NewRecorder(queue, &fakeSender{err: errors.New("offline")})
+
+	g.Expect(r.Record("anywhere create cluster", time.Second, nil)).To(Succeed())
+	g.Expect(r.Flush(context.Background())).To(MatchError("offline"))
+	g.Expect(queue.Events()).To(HaveLen(1))
+}
+
+func TestRecorderWithoutSenderOnlyQueues(t *testing.T) {
+	g := NewWithT(t)
+	queue := telemetry.NewQueue(t.TempDir())
+	r := telemetry.NewRecorder(queue, nil)
+
+	g.Expect(r.Record("anywhere create cluster", time.Second, nil)).To(Succeed())
+	g.Expect(r.Flush(context.Background())).To(Succeed())
+	g.Expect(queue.Events()).To(HaveLen(1))
+}
+
+func TestNilRecorderIsNoop(t *testing.T) {
+	g := NewWithT(t)
+	var r *telemetry.Recorder
+
+	r.SetProvider("vsphere")
+	telemetry.SetProvider(context.Background(), "vsphere")
+	g.Expect(r.Record("anywhere create cluster", time.Second, nil)).To(Succeed())
+	g.Expect(r.Flush(context.Background())).To(Succeed())
+}
+
+func TestNewRecorderFromEnvDisabledByDefault(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(telemetry.EnabledEnvVar, "")
+
+	r, err := telemetry.NewRecorderFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(r).To(BeNil())
+}
+
+func TestNewRecorderFromEnvEnabled(t *testing.T) {
+	g := NewWithT(t)
+	received := []telemetry.Event{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		g.Expect(req.Method).To(Equal(http.MethodPost))
+		g.Expect(json.NewDecoder(req.Body).Decode(&received)).To(Succeed())
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	t.Setenv(telemetry.EnabledEnvVar, "true")
+	t.Setenv(telemetry.DirEnvVar, dir)
+	t.Setenv(telemetry.EndpointEnvVar, server.URL)
+
+	r, err := telemetry.NewRecorderFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(r.Record("anywhere create cluster", time.Second, nil)).To(Succeed())
+	g.Expect(r.Flush(context.Background())).To(Succeed())
+
+	g.Expect(received).To(HaveLen(1))
+	g.Expect(received[0].Command).To(Equal("anywhere create cluster"))
+	g.Expect(telemetry.NewQueue(dir).Events()).To(BeEmpty())
+}
+
+func TestHTTPSenderSendErrorStatus(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	s := telemetry.NewHTTPSender(server.URL, server.Client())
+	err := s.Send(context.Background(), []telemetry.Event{{Command: "anywhere create cluster"}})
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status 500")))
+}
@@ -0,0 +1,61 @@
+package timesync
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterChecker checks the node clocks of the clusters managed from the management cluster, using
+// the kubeconfig CAPI stores for each of them.
+type ClusterChecker struct {
+	client     client.Reader
+	sourceName string
+	reference  ReferenceClock
+	opts       []CheckerOpt
+}
+
+// NewClusterChecker constructs a new ClusterChecker. sourceName identifies the caller in the user
+// agent of the requests.
+func NewClusterChecker(client client.Reader, sourceName string, reference ReferenceClock, opts ...CheckerOpt) *ClusterChecker {
+	return &ClusterChecker{
+		client:     client,
+		sourceName: sourceName,
+		reference:  reference,
+		opts:       opts,
+	}
+}
+
+// Check measures the clock skew of every node of the CAPI cluster with key cluster.
+func (c *ClusterChecker) Check(ctx context.Context, cluster client.ObjectKey) (*Report, error) {
+	config, err := remote.RESTConfig(ctx, c.sourceName, c.client, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("building clientset for cluster %s: %v", cluster.Name, err)
+	}
+
+	checker := NewChecker(clientsetNodes{clientset}, NewKubeletClock(WithRESTConfig(config)), c.reference, c.opts...)
+	return checker.Check(ctx, cluster.Name)
+}
+
+// clientsetNodes is a NodeLister for the cluster of a clientset, regardless of the kubeconfig.
+type clientsetNodes struct {
+	clientset kubernetes.Interface
+}
+
+func (c clientsetNodes) GetNodes(ctx context.Context, _ string) ([]corev1.Node, error) {
+	nodes, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return nodes.Items, nil
+}
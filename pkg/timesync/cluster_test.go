@@ -0,0 +1,81 @@
+package timesync_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/timesync"
+	"github.com/aws/eks-anywhere/pkg/timesync/mocks"
+)
+
+func TestClusterCheckerCheck(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	now := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/nodes", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"NodeList","apiVersion":"v1","items":[{"metadata":{"name":"node-1"}},{"metadata":{"name":"node-2"}}]}`)
+	})
+	mux.HandleFunc("/api/v1/nodes/node-1/proxy/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Date", now.Format(http.TimeFormat))
+	})
+	mux.HandleFunc("/api/v1/nodes/node-2/proxy/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Date", now.Add(-time.Minute).Format(http.TimeFormat))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	kubeconfig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-kubeconfig", Namespace: "eksa-system"},
+		Data: map[string][]byte{
+			"value": []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: workload
+  cluster:
+    server: %s
+contexts:
+- name: workload
+  context:
+    cluster: workload
+    user: admin
+current-context: workload
+users:
+- name: admin
+  user:
+    token: token
+`, server.URL)),
+		},
+	}
+	reference := mocks.NewMockReferenceClock(gomock.NewController(t))
+	reference.EXPECT().Now(ctx).Return(now, nil).AnyTimes()
+	checker := timesync.NewClusterChecker(fake.NewClientBuilder().WithObjects(kubeconfig).Build(), "test", reference)
+
+	report, err := checker.Check(ctx, client.ObjectKey{Name: "workload", Namespace: "eksa-system"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Nodes).To(Equal([]timesync.NodeSkew{
+		{Node: "node-1", Skew: 500 * time.Millisecond},
+		{Node: "node-2", Skew: -time.Minute + 500*time.Millisecond},
+	}))
+}
+
+func TestClusterCheckerCheckMissingKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+	checker := timesync.NewClusterChecker(fake.NewClientBuilder().Build(), "test", nil)
+
+	_, err := checker.Check(context.Background(), client.ObjectKey{Name: "workload", Namespace: "eksa-system"})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,113 @@
+package timesync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// KubeletClock is a NodeClock that reads the time of a node from the Date header of the kubelet
+// responses, proxied through the API server. The header has a resolution of one second.
+type KubeletClock struct {
+	restConfig *rest.Config
+
+	mu      sync.Mutex
+	clients map[string]*kubeletClient
+}
+
+type kubeletClient struct {
+	host string
+	http *http.Client
+}
+
+// KubeletClockOpt configures a KubeletClock.
+type KubeletClockOpt func(*KubeletClock)
+
+// WithRESTConfig makes the clock reach the API server with config instead of loading the kubeconfig
+// file, which is then only used to identify the cluster.
+func WithRESTConfig(config *rest.Config) KubeletClockOpt {
+	return func(k *KubeletClock) {
+		k.restConfig = config
+	}
+}
+
+// NewKubeletClock constructs a new KubeletClock.
+func NewKubeletClock(opts ...KubeletClockOpt) *KubeletClock {
+	k := &KubeletClock{
+		clients: map[string]*kubeletClient{},
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+	return k
+}
+
+// NodeTime returns the current time of node.
+func (k *KubeletClock) NodeTime(ctx context.Context, kubeconfig, node string) (time.Time, error) {
+	client, err := k.client(kubeconfig)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v1/nodes/%s/proxy/healthz", client.host, url.PathEscape(node))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("building kubelet request: %v", err)
+	}
+
+	resp, err := client.http.Do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reaching kubelet: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Errors can be generated by the API server itself, in which case the Date is not the node's.
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("reaching kubelet: unexpected status %s", resp.Status)
+	}
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing kubelet Date header: %v", err)
+	}
+
+	// The Date is truncated to the second, use the middle of that second.
+	return date.Add(500 * time.Millisecond), nil
+}
+
+func (k *KubeletClock) client(kubeconfig string) (*kubeletClient, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if c, ok := k.clients[kubeconfig]; ok {
+		return c, nil
+	}
+
+	config := k.restConfig
+	if config == nil {
+		var err error
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("loading kubeconfig %s: %v", kubeconfig, err)
+		}
+	}
+
+	httpClient, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("building client for kubeconfig %s: %v", kubeconfig, err)
+	}
+
+	c := &kubeletClient{
+		host: strings.TrimSuffix(config.Host, "/"),
+		http: httpClient,
+	}
+	k.clients[kubeconfig] = c
+	return c, nil
+}
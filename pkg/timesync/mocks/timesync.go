@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/timesync/timesync.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+)
+
+// MockNodeLister is a mock of NodeLister interface.
+type MockNodeLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeListerMockRecorder
+}
+
+// MockNodeListerMockRecorder is the mock recorder for MockNodeLister.
+type MockNodeListerMockRecorder struct {
+	mock *MockNodeLister
+}
+
+// NewMockNodeLister creates a new mock instance.
+func NewMockNodeLister(ctrl *gomock.Controller) *MockNodeLister {
+	mock := &MockNodeLister{ctrl: ctrl}
+	mock.recorder = &MockNodeListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeLister) EXPECT() *MockNodeListerMockRecorder {
+	return m.recorder
+}
+
+// GetNodes mocks base method.
+func (m *MockNodeLister) GetNodes(ctx context.Context, kubeconfig string) ([]v1.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodes", ctx, kubeconfig)
+	ret0, _ := ret[0].([]v1.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodes indicates an expected call of GetNodes.
+func (mr *MockNodeListerMockRecorder) GetNodes(ctx, kubeconfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodes", reflect.TypeOf((*MockNodeLister)(nil).GetNodes), ctx, kubeconfig)
+}
+
+// MockNodeClock is a mock of NodeClock interface.
+type MockNodeClock struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeClockMockRecorder
+}
+
+// MockNodeClockMockRecorder is the mock recorder for MockNodeClock.
+type MockNodeClockMockRecorder struct {
+	mock *MockNodeClock
+}
+
+// NewMockNodeClock creates a new mock instance.
+func NewMockNodeClock(ctrl *gomock.Controller) *MockNodeClock {
+	mock := &MockNodeClock{ctrl: ctrl}
+	mock.recorder = &MockNodeClockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeClock) EXPECT() *MockNodeClockMockRecorder {
+	return m.recorder
+}
+
+// NodeTime mocks base method.
+func (m *MockNodeClock) NodeTime(ctx context.Context, kubeconfig, node string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NodeTime", ctx, kubeconfig, node)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NodeTime indicates an expected call of NodeTime.
+func (mr *MockNodeClockMockRecorder) NodeTime(ctx, kubeconfig, node interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeTime", reflect.TypeOf((*MockNodeClock)(nil).NodeTime), ctx, kubeconfig, node)
+}
+
+// MockReferenceClock is a mock of ReferenceClock interface.
+type MockReferenceClock struct {
+	ctrl     *gomock.Controller
+	recorder *MockReferenceClockMockRecorder
+}
+
+// MockReferenceClockMockRecorder is the mock recorder for MockReferenceClock.
+type MockReferenceClockMockRecorder struct {
+	mock *MockReferenceClock
+}
+
+// NewMockReferenceClock creates a new mock instance.
+func NewMockReferenceClock(ctrl *gomock.Controller) *MockReferenceClock {
+	mock := &MockReferenceClock{ctrl: ctrl}
+	mock.recorder = &MockReferenceClockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReferenceClock) EXPECT() *MockReferenceClockMockRecorder {
+	return m.recorder
+}
+
+// Now mocks base method.
+func (m *MockReferenceClock) Now(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Now", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Now indicates an expected call of Now.
+func (mr *MockReferenceClockMockRecorder) Now(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Now", reflect.TypeOf((*MockReferenceClock)(nil).Now), ctx)
+}
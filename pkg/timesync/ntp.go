@@ -0,0 +1,168 @@
+package timesync
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	ntpPort          = "123"
+	ntpPacketSize    = 48
+	ntpQueryTimeout  = 5 * time.Second
+	ntpModeServer    = 4
+	ntpVersionClient = 4
+	ntpModeClient    = 3
+)
+
+// ntpEpoch is the start of the NTP era 0, 1900-01-01.
+var ntpEpoch = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// NTPClock is a ReferenceClock that corrects the local clock with the offset reported by an NTP
+// server. The offset is queried once and reused for subsequent calls, until the refresh interval
+// passes if one is set.
+type NTPClock struct {
+	server          string
+	localFallback   bool
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mu        sync.Mutex
+	queriedAt time.Time
+	offset    time.Duration
+	offsetErr error
+}
+
+// NTPClockOpt configures an NTPClock.
+type NTPClockOpt func(*NTPClock)
+
+// WithLocalFallback makes the clock fall back to the local clock when the NTP server can't be
+// queried, e.g. in air-gapped environments, instead of returning an error.
+func WithLocalFallback() NTPClockOpt {
+	return func(c *NTPClock) {
+		c.localFallback = true
+	}
+}
+
+// WithRefreshInterval makes the clock query the NTP server again once the offset is older than
+// interval, for long running processes where the local clock drifts.
+func WithRefreshInterval(interval time.Duration) NTPClockOpt {
+	return func(c *NTPClock) {
+		c.refreshInterval = interval
+	}
+}
+
+// NewNTPClock constructs a new NTPClock using server.
+func NewNTPClock(server string, opts ...NTPClockOpt) *NTPClock {
+	c := &NTPClock{
+		server: server,
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Now returns the current time according to the NTP server.
+func (c *NTPClock) Now(ctx context.Context) (time.Time, error) {
+	offset, err := c.currentOffset(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return c.now().Add(offset), nil
+}
+
+func (c *NTPClock) currentOffset(ctx context.Context) (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.queriedAt.IsZero() && (c.refreshInterval == 0 || c.now().Sub(c.queriedAt) < c.refreshInterval) {
+		return c.offset, c.offsetErr
+	}
+
+	c.offset, c.offsetErr = queryOffset(ctx, c.server, c.now)
+	if c.offsetErr != nil && c.localFallback {
+		logger.Info("Warning: unable to query NTP server, using local clock as time reference", "server", c.server, "error", c.offsetErr)
+		c.offset, c.offsetErr = 0, nil
+	}
+	c.queriedAt = c.now()
+
+	return c.offset, c.offsetErr
+}
+
+// queryOffset returns the offset of the local clock relative to the NTP server using a single
+// SNTP request (RFC 4330).
+func queryOffset(ctx context.Context, server string, now func() time.Time) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, ntpQueryTimeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", net.JoinHostPort(server, ntpPort))
+	if err != nil {
+		return 0, fmt.Errorf("connecting to NTP server %s: %v", server, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return 0, fmt.Errorf("setting deadline for NTP server %s: %v", server, err)
+		}
+	}
+
+	req := make([]byte, ntpPacketSize)
+	req[0] = ntpVersionClient<<3 | ntpModeClient
+	sent := now()
+	putNTPTime(req[40:], sent)
+	if _, err := conn.Write(req); err != nil {
+		return 0, fmt.Errorf("querying NTP server %s: %v", server, err)
+	}
+
+	resp := make([]byte, ntpPacketSize)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return 0, fmt.Errorf("reading response from NTP server %s: %v", server, err)
+	}
+	received := now()
+
+	return parseOffset(resp[:n], sent, received)
+}
+
+func parseOffset(resp []byte, sent, received time.Time) (time.Duration, error) {
+	if len(resp) < ntpPacketSize {
+		return 0, errors.New("invalid NTP response: packet too short")
+	}
+	if mode := resp[0] & 0x7; mode != ntpModeServer {
+		return 0, fmt.Errorf("invalid NTP response: unexpected mode %d", mode)
+	}
+	if stratum := resp[1]; stratum == 0 {
+		return 0, fmt.Errorf("NTP server sent kiss-of-death %q", string(resp[12:16]))
+	}
+
+	serverReceived := ntpTime(resp[32:40])
+	serverSent := ntpTime(resp[40:48])
+
+	return (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2, nil
+}
+
+func ntpTime(b []byte) time.Time {
+	seconds := binary.BigEndian.Uint32(b[:4])
+	fraction := binary.BigEndian.Uint32(b[4:8])
+	nanos := (int64(fraction) * int64(time.Second)) >> 32
+	return ntpEpoch.Add(time.Duration(seconds) * time.Second).Add(time.Duration(nanos))
+}
+
+func putNTPTime(b []byte, t time.Time) {
+	d := t.Sub(ntpEpoch)
+	seconds := d / time.Second
+	fraction := (int64(d%time.Second) << 32) / int64(time.Second)
+	binary.BigEndian.PutUint32(b[:4], uint32(seconds))
+	binary.BigEndian.PutUint32(b[4:8], uint32(fraction))
+}
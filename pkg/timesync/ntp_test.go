@@ -0,0 +1,92 @@
+package timesync
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNTPTimeRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+	want := time.Date(2022, 8, 1, 10, 0, 0, 250000000, time.UTC)
+	b := make([]byte, 8)
+	putNTPTime(b, want)
+	g.Expect(ntpTime(b)).To(BeTemporally("~", want, time.Microsecond))
+}
+
+func TestParseOffset(t *testing.T) {
+	g := NewWithT(t)
+	sent := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+	received := sent.Add(100 * time.Millisecond)
+
+	// Server clock 3s ahead, 50ms of network delay each way.
+	resp := make([]byte, ntpPacketSize)
+	resp[0] = ntpVersionClient<<3 | ntpModeServer
+	resp[1] = 2
+	putNTPTime(resp[32:40], sent.Add(3*time.Second+50*time.Millisecond))
+	putNTPTime(resp[40:48], sent.Add(3*time.Second+50*time.Millisecond))
+
+	offset, err := parseOffset(resp, sent, received)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(offset).To(BeNumerically("~", 3*time.Second, time.Microsecond))
+}
+
+func TestParseOffsetKissOfDeath(t *testing.T) {
+	g := NewWithT(t)
+	resp := make([]byte, ntpPacketSize)
+	resp[0] = ntpVersionClient<<3 | ntpModeServer
+	copy(resp[12:16], "RATE")
+
+	_, err := parseOffset(resp, time.Now(), time.Now())
+	g.Expect(err).To(MatchError(`NTP server sent kiss-of-death "RATE"`))
+}
+
+func TestParseOffsetInvalidMode(t *testing.T) {
+	g := NewWithT(t)
+	resp := make([]byte, ntpPacketSize)
+	resp[0] = ntpVersionClient<<3 | ntpModeClient
+
+	_, err := parseOffset(resp, time.Now(), time.Now())
+	g.Expect(err).To(MatchError("invalid NTP response: unexpected mode 3"))
+}
+
+func TestNTPClockLocalFallback(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	// Nothing listens on this port, so the query fails.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	g.Expect(err).NotTo(HaveOccurred())
+	server := conn.LocalAddr().(*net.UDPAddr).IP.String()
+	conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	c := NewNTPClock(server, WithLocalFallback())
+	c.now = func() time.Time { return now }
+	g.Expect(c.Now(ctx)).To(Equal(now))
+}
+
+func TestNTPClockRefreshInterval(t *testing.T) {
+	g := NewWithT(t)
+	queried := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// Nothing listens on the loopback NTP port, so a new query falls back to the local clock.
+	c := NewNTPClock("127.0.0.1", WithLocalFallback(), WithRefreshInterval(time.Hour))
+	c.queriedAt = queried
+	c.offset = 3 * time.Second
+
+	now := queried.Add(30 * time.Minute)
+	c.now = func() time.Time { return now }
+	g.Expect(c.Now(ctx)).To(Equal(now.Add(3 * time.Second)))
+
+	now = queried.Add(2 * time.Hour)
+	g.Expect(c.Now(ctx)).To(Equal(now))
+}
@@ -0,0 +1,178 @@
+package timesync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultNTPServer is the NTP server used as time reference when none is provided.
+	DefaultNTPServer = "pool.ntp.org"
+	// DefaultMaxSkew is the maximum clock skew tolerated by default between a node and the
+	// reference. etcd starts reporting clock drift between members at 1s.
+	DefaultMaxSkew = 2 * time.Second
+)
+
+// NodeLister lists the nodes of a cluster.
+type NodeLister interface {
+	GetNodes(ctx context.Context, kubeconfig string) ([]corev1.Node, error)
+}
+
+// NodeClock reads the current time of a cluster node.
+type NodeClock interface {
+	NodeTime(ctx context.Context, kubeconfig, node string) (time.Time, error)
+}
+
+// ReferenceClock provides the time the node clocks are compared against.
+type ReferenceClock interface {
+	Now(ctx context.Context) (time.Time, error)
+}
+
+// Checker compares the clocks of the nodes of a cluster against a reference clock.
+type Checker struct {
+	nodes     NodeLister
+	nodeClock NodeClock
+	reference ReferenceClock
+	maxSkew   time.Duration
+}
+
+// CheckerOpt configures a Checker.
+type CheckerOpt func(*Checker)
+
+// WithMaxSkew sets the maximum tolerated clock skew.
+func WithMaxSkew(maxSkew time.Duration) CheckerOpt {
+	return func(c *Checker) {
+		c.maxSkew = maxSkew
+	}
+}
+
+// NewChecker constructs a new Checker.
+func NewChecker(nodes NodeLister, nodeClock NodeClock, reference ReferenceClock, opts ...CheckerOpt) *Checker {
+	c := &Checker{
+		nodes:     nodes,
+		nodeClock: nodeClock,
+		reference: reference,
+		maxSkew:   DefaultMaxSkew,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Check measures the clock skew of every node in the cluster. Errors reading the time of a node
+// are recorded in the report instead of failing the whole check.
+func (c *Checker) Check(ctx context.Context, kubeconfig string) (*Report, error) {
+	nodes, err := c.nodes.GetNodes(ctx, kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes: %v", err)
+	}
+
+	report := &Report{MaxSkew: c.maxSkew}
+	for _, node := range nodes {
+		before, err := c.reference.Now(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("reading reference time: %v", err)
+		}
+
+		nodeTime, err := c.nodeClock.NodeTime(ctx, kubeconfig, node.Name)
+		if err != nil {
+			report.Nodes = append(report.Nodes, NodeSkew{Node: node.Name, Err: err})
+			continue
+		}
+
+		after, err := c.reference.Now(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("reading reference time: %v", err)
+		}
+
+		// The node time is read at some point during the request, compare with its midpoint.
+		reference := before.Add(after.Sub(before) / 2)
+		report.Nodes = append(report.Nodes, NodeSkew{Node: node.Name, Skew: nodeTime.Sub(reference)})
+	}
+
+	sort.Slice(report.Nodes, func(i, j int) bool {
+		return report.Nodes[i].Node < report.Nodes[j].Node
+	})
+
+	return report, nil
+}
+
+// Validate returns an error if the clock of any node in the cluster is skewed beyond the
+// maximum tolerated skew or can't be read.
+func (c *Checker) Validate(ctx context.Context, kubeconfig string) error {
+	report, err := c.Check(ctx, kubeconfig)
+	if err != nil {
+		return err
+	}
+	return report.Err()
+}
+
+// NodeSkew is the clock skew of a node. A positive skew means the node clock is ahead.
+type NodeSkew struct {
+	Node string
+	Skew time.Duration
+	Err  error
+}
+
+// Report contains the clock skew of the nodes of a cluster.
+type Report struct {
+	MaxSkew time.Duration
+	Nodes   []NodeSkew
+}
+
+// Synchronized returns true if the skew of n is within maxSkew.
+func (n NodeSkew) Synchronized(maxSkew time.Duration) bool {
+	return n.Err == nil && n.Skew <= maxSkew && n.Skew >= -maxSkew
+}
+
+// Valid returns true if the clocks of all the nodes are synchronized.
+func (r *Report) Valid() bool {
+	return r.Err() == nil
+}
+
+// Err returns an error listing the nodes with a clock skew beyond the maximum, or nil if all
+// of them are synchronized.
+func (r *Report) Err() error {
+	var issues []string
+	for _, n := range r.Nodes {
+		switch {
+		case n.Err != nil:
+			issues = append(issues, fmt.Sprintf("%s: %v", n.Node, n.Err))
+		case !n.Synchronized(r.MaxSkew):
+			issues = append(issues, fmt.Sprintf("%s: clock skew %s", n.Node, n.Skew.Round(time.Millisecond)))
+		}
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("node clocks not synchronized within %s: %s", r.MaxSkew, strings.Join(issues, ", "))
+}
+
+// Write writes a human readable representation of r to w.
+func (r *Report) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+
+	fmt.Fprintln(tw, "NODE\tSKEW\tSTATUS")
+	for _, n := range r.Nodes {
+		switch {
+		case n.Err != nil:
+			fmt.Fprintf(tw, "%s\t-\t%v\n", n.Node, n.Err)
+		case !n.Synchronized(r.MaxSkew):
+			fmt.Fprintf(tw, "%s\t%s\tskew exceeds %s\n", n.Node, n.Skew.Round(time.Millisecond), r.MaxSkew)
+		default:
+			fmt.Fprintf(tw, "%s\t%s\tOK\n", n.Node, n.Skew.Round(time.Millisecond))
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,112 @@
+package timesync_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/timesync"
+	"github.com/aws/eks-anywhere/pkg/timesync/mocks"
+)
+
+const kubeconfig = "test.kubeconfig"
+
+type checkerTest struct {
+	*WithT
+	ctx       context.Context
+	nodes     *mocks.MockNodeLister
+	nodeClock *mocks.MockNodeClock
+	reference *mocks.MockReferenceClock
+	checker   *timesync.Checker
+	now       time.Time
+}
+
+func newCheckerTest(t *testing.T) *checkerTest {
+	ctrl := gomock.NewController(t)
+	tt := &checkerTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		nodes:     mocks.NewMockNodeLister(ctrl),
+		nodeClock: mocks.NewMockNodeClock(ctrl),
+		reference: mocks.NewMockReferenceClock(ctrl),
+		now:       time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC),
+	}
+	tt.checker = timesync.NewChecker(tt.nodes, tt.nodeClock, tt.reference)
+	tt.reference.EXPECT().Now(tt.ctx).Return(tt.now, nil).AnyTimes()
+	return tt
+}
+
+func (tt *checkerTest) expectNodes(names ...string) {
+	nodes := make([]corev1.Node, 0, len(names))
+	for _, n := range names {
+		nodes = append(nodes, corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: n}})
+	}
+	tt.nodes.EXPECT().GetNodes(tt.ctx, kubeconfig).Return(nodes, nil)
+}
+
+func TestCheckerValidateSynchronized(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.expectNodes("node-1", "node-2")
+	tt.nodeClock.EXPECT().NodeTime(tt.ctx, kubeconfig, "node-1").Return(tt.now.Add(500*time.Millisecond), nil)
+	tt.nodeClock.EXPECT().NodeTime(tt.ctx, kubeconfig, "node-2").Return(tt.now.Add(-time.Second), nil)
+
+	tt.Expect(tt.checker.Validate(tt.ctx, kubeconfig)).To(Succeed())
+}
+
+func TestCheckerValidateSkewed(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.expectNodes("node-2", "node-1")
+	tt.nodeClock.EXPECT().NodeTime(tt.ctx, kubeconfig, "node-1").Return(tt.now.Add(-30*time.Second), nil)
+	tt.nodeClock.EXPECT().NodeTime(tt.ctx, kubeconfig, "node-2").Return(time.Time{}, errors.New("reaching kubelet: unexpected status 503"))
+
+	tt.Expect(tt.checker.Validate(tt.ctx, kubeconfig)).To(MatchError(
+		"node clocks not synchronized within 2s: node-1: clock skew -30s, node-2: reaching kubelet: unexpected status 503",
+	))
+}
+
+func TestCheckerCheckMaxSkew(t *testing.T) {
+	tt := newCheckerTest(t)
+	checker := timesync.NewChecker(tt.nodes, tt.nodeClock, tt.reference, timesync.WithMaxSkew(500*time.Millisecond))
+	tt.expectNodes("node-1")
+	tt.nodeClock.EXPECT().NodeTime(tt.ctx, kubeconfig, "node-1").Return(tt.now.Add(time.Second), nil)
+
+	report, err := checker.Check(tt.ctx, kubeconfig)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Valid()).To(BeFalse())
+	tt.Expect(report.Nodes).To(Equal([]timesync.NodeSkew{{Node: "node-1", Skew: time.Second}}))
+}
+
+func TestCheckerCheckListNodesError(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.nodes.EXPECT().GetNodes(tt.ctx, kubeconfig).Return(nil, errors.New("connection refused"))
+
+	_, err := tt.checker.Check(tt.ctx, kubeconfig)
+	tt.Expect(err).To(MatchError("listing nodes: connection refused"))
+}
+
+func TestReportWrite(t *testing.T) {
+	g := NewWithT(t)
+	report := &timesync.Report{
+		MaxSkew: 2 * time.Second,
+		Nodes: []timesync.NodeSkew{
+			{Node: "node-1", Skew: 150 * time.Millisecond},
+			{Node: "node-2", Skew: 5 * time.Second},
+			{Node: "node-3", Err: errors.New("reaching kubelet: timeout")},
+		},
+	}
+
+	var b bytes.Buffer
+	g.Expect(report.Write(&b)).To(Succeed())
+	g.Expect(b.String()).To(Equal(`NODE      SKEW      STATUS
+node-1    150ms     OK
+node-2    5s        skew exceeds 2s
+node-3    -         reaching kubelet: timeout
+`))
+}
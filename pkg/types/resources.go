@@ -10,6 +10,7 @@ type Deployment struct {
 
 type Machine struct {
 	Metadata MachineMetadata `json:"metadata"`
+	Spec     MachineSpec     `json:"spec"`
 	Status   MachineStatus   `json:"status"`
 }
 
@@ -22,6 +23,10 @@ func (m *Machine) HasAnyLabel(labels []string) bool {
 	return false
 }
 
+type MachineSpec struct {
+	ProviderID *string `json:"providerID,omitempty"`
+}
+
 type MachineStatus struct {
 	NodeRef    *ResourceRef `json:"nodeRef,omitempty"`
 	Conditions Conditions
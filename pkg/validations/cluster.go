@@ -1,12 +1,14 @@
 package validations
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
 )
 
 func ValidateCertForRegistryMirror(clusterSpec *cluster.Spec, tlsValidator TlsValidator) error {
@@ -51,3 +53,20 @@ func ValidateK8s124Support(clusterSpec *cluster.Spec) error {
 	}
 	return nil
 }
+
+// ValidateManagementClusterManages checks the kubeconfig of the management cluster points at the cluster
+// managing the cluster in the spec, the self-managed EKS-A cluster with the spec management cluster name,
+// so a kubeconfig or context for another fleet isn't operated on by mistake.
+func ValidateManagementClusterManages(ctx context.Context, k KubectlClient, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	name := clusterSpec.Cluster.ManagedBy()
+	mgmt, err := k.GetEksaCluster(ctx, managementCluster, name)
+	if err != nil {
+		return fmt.Errorf("kubeconfig %s doesn't point at management cluster %s: %v", managementCluster.KubeconfigFile, name, err)
+	}
+	if !mgmt.IsSelfManaged() {
+		return fmt.Errorf("kubeconfig %s doesn't point at management cluster %s, it points at a cluster managed by %s",
+			managementCluster.KubeconfigFile, name, mgmt.ManagedBy())
+	}
+
+	return nil
+}
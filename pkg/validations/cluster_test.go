@@ -1,6 +1,7 @@
 package validations_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/features"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/mocks"
 )
@@ -109,3 +111,49 @@ func TestValidateK8s124SupportActive(t *testing.T) {
 	t.Setenv(features.K8s124SupportEnvVar, "true")
 	tt.Expect(validations.ValidateK8s124Support(tt.clusterSpec)).To(Succeed())
 }
+
+func TestValidateManagementClusterManages(t *testing.T) {
+	managementCluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	tests := []struct {
+		name            string
+		eksaCluster     *anywherev1.Cluster
+		getClusterError error
+		wantErr         string
+	}{
+		{
+			name:        "self managed cluster",
+			eksaCluster: &anywherev1.Cluster{Spec: anywherev1.ClusterSpec{ManagementCluster: anywherev1.ManagementCluster{Name: "mgmt"}}},
+		},
+		{
+			name:            "cluster not found",
+			getClusterError: errors.New("clusters.anywhere.eks.amazonaws.com \"mgmt\" not found"),
+			wantErr:         "kubeconfig mgmt.kubeconfig doesn't point at management cluster mgmt: clusters.anywhere.eks.amazonaws.com \"mgmt\" not found",
+		},
+		{
+			name:        "managed cluster",
+			eksaCluster: &anywherev1.Cluster{Spec: anywherev1.ClusterSpec{ManagementCluster: anywherev1.ManagementCluster{Name: "other-mgmt"}}},
+			wantErr:     "kubeconfig mgmt.kubeconfig doesn't point at management cluster mgmt, it points at a cluster managed by other-mgmt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			k := mocks.NewMockKubectlClient(gomock.NewController(t))
+			clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.SetManagedBy("mgmt")
+			})
+			if tt.eksaCluster != nil {
+				tt.eksaCluster.Name = "mgmt"
+			}
+			k.EXPECT().GetEksaCluster(ctx, managementCluster, "mgmt").Return(tt.eksaCluster, tt.getClusterError)
+
+			err := validations.ValidateManagementClusterManages(ctx, k, managementCluster, clusterSpec)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
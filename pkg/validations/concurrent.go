@@ -0,0 +1,30 @@
+package validations
+
+import "sync"
+
+// RunConcurrently runs independent validations, at most maxParallel at a time, and waits for all of them.
+// A single failure is returned as is. Multiple failures are reported together in a ValidationError,
+// in the order of the validations, so the report doesn't depend on which finished first.
+func RunConcurrently(maxParallel int, validations ...func() error) error {
+	if maxParallel < 1 {
+		maxParallel = 1
+	}
+
+	errs := make([]error, len(validations))
+	slots := make(chan struct{}, maxParallel)
+	wg := &sync.WaitGroup{}
+	for i, validate := range validations {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, validate func() error) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			errs[i] = validate()
+		}(i, validate)
+	}
+	wg.Wait()
+
+	return AggregateErrors(errs...)
+}
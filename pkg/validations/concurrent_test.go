@@ -0,0 +1,83 @@
+package validations_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func TestRunConcurrentlySuccess(t *testing.T) {
+	g := NewWithT(t)
+	var ran int32
+	validation := func() error {
+		atomic.AddInt32(&ran, 1)
+		return nil
+	}
+
+	g.Expect(validations.RunConcurrently(2, validation, validation, validation)).To(Succeed())
+	g.Expect(ran).To(Equal(int32(3)))
+}
+
+func TestRunConcurrentlySingleError(t *testing.T) {
+	g := NewWithT(t)
+	err := errors.New("template not found")
+
+	g.Expect(validations.RunConcurrently(2, func() error { return nil }, func() error { return err })).To(MatchError(err))
+}
+
+func TestRunConcurrentlyAggregatesErrorsInOrder(t *testing.T) {
+	g := NewWithT(t)
+	slow := func() error {
+		time.Sleep(50 * time.Millisecond)
+		return errors.New("datastore not found")
+	}
+	fast := func() error { return errors.New("folder not found") }
+
+	err := validations.RunConcurrently(2, slow, func() error { return nil }, fast)
+	g.Expect(err).To(MatchError("validation failed with 2 errors: datastore not found,folder not found"))
+}
+
+func TestRunConcurrentlyBoundsParallelism(t *testing.T) {
+	g := NewWithT(t)
+	var running, maxRunning int32
+	validation := func() error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+
+	g.Expect(validations.RunConcurrently(2, validation, validation, validation, validation, validation)).To(Succeed())
+	g.Expect(maxRunning).To(Equal(int32(2)))
+}
+
+func TestAggregateErrorsNoErrors(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validations.AggregateErrors(nil, nil)).To(Succeed())
+}
+
+func TestAggregateErrorsSingleError(t *testing.T) {
+	g := NewWithT(t)
+	err := errors.New("template not found")
+
+	g.Expect(validations.AggregateErrors(nil, err)).To(BeIdenticalTo(err))
+}
+
+func TestAggregateErrorsFlattensValidationErrors(t *testing.T) {
+	g := NewWithT(t)
+	nested := &validations.ValidationError{Errs: []string{"datastore not found", "template not found"}}
+
+	err := validations.AggregateErrors(nested, nil, errors.New("ip already in use"))
+	g.Expect(err).To(MatchError("validation failed with 3 errors: datastore not found,template not found,ip already in use"))
+}
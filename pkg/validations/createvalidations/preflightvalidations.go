@@ -6,6 +6,7 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
@@ -54,6 +55,19 @@ func (v *CreateValidations) BuildValidations(ctx context.Context) []validations.
 		},
 	}
 
+	if v.Opts.PackagesLocation != "" {
+		createValidations = append(
+			createValidations,
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate curated packages node selectors",
+					Remediation: "add the labels of the packages nodeSelectors to a node group or update the packages configuration",
+					Err:         curatedpackages.ValidatePackagesNodeSelectors(v.Opts.Spec, v.Opts.PackagesLocation),
+				}
+			},
+		)
+	}
+
 	if v.Opts.Spec.Cluster.IsManaged() {
 		createValidations = append(
 			createValidations,
@@ -85,6 +99,13 @@ func (v *CreateValidations) BuildValidations(ctx context.Context) []validations.
 					Err:         ValidateManagementCluster(ctx, k, targetCluster),
 				}
 			},
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate management cluster kubeconfig",
+					Remediation: fmt.Sprintf("use the kubeconfig and --context of management cluster %s", v.Opts.Spec.Cluster.ManagedBy()),
+					Err:         validations.ValidateManagementClusterManages(ctx, k, v.Opts.ManagementCluster, v.Opts.Spec),
+				}
+			},
 		)
 	}
 
@@ -60,6 +60,35 @@ func TestPreFlightValidationsWorkloadCluster(t *testing.T) {
 	tt.k.EXPECT().GetClusters(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil, nil)
 	tt.k.EXPECT().ValidateClustersCRD(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil)
 	tt.k.EXPECT().ValidateEKSAClustersCRD(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil)
+	tt.k.EXPECT().GetEksaCluster(tt.ctx, tt.c.Opts.ManagementCluster, "mgmt-cluster").Return(managementCluster("mgmt-cluster"), nil)
 
 	tt.Expect(tt.c.PreflightValidations(tt.ctx)).To(Succeed())
 }
+
+func TestPreFlightValidationsWorkloadClusterWrongManagementCluster(t *testing.T) {
+	tt := newPreflightValidationsTest(t)
+	tt.c.Opts.Spec.Cluster.SetManagedBy("mgmt-cluster")
+	otherManagementCluster := managementCluster("mgmt-cluster")
+	otherManagementCluster.SetManagedBy("other-mgmt-cluster")
+
+	tt.k.EXPECT().GetClusters(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil, nil)
+	tt.k.EXPECT().ValidateClustersCRD(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil)
+	tt.k.EXPECT().ValidateEKSAClustersCRD(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil)
+	tt.k.EXPECT().GetEksaCluster(tt.ctx, tt.c.Opts.ManagementCluster, "mgmt-cluster").Return(otherManagementCluster, nil)
+
+	tt.Expect(tt.c.PreflightValidations(tt.ctx)).To(MatchError(ContainSubstring("it points at a cluster managed by other-mgmt-cluster")))
+}
+
+func TestPreFlightValidationsPackagesNodeSelectors(t *testing.T) {
+	tt := newPreflightValidationsTest(t)
+	tt.c.Opts.PackagesLocation = "testdata/packages.yaml"
+
+	tt.Expect(tt.c.PreflightValidations(tt.ctx)).To(MatchError(ContainSubstring("nodeSelector node-type=storage of package my-harbor doesn't match the labels of any node group")))
+}
+
+func managementCluster(name string) *v1alpha1.Cluster {
+	c := &v1alpha1.Cluster{}
+	c.Name = name
+	c.SetSelfManaged()
+	return c
+}
@@ -1,6 +1,7 @@
 package validations
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -16,3 +17,33 @@ func (v *ValidationError) Error() string {
 func (v *ValidationError) String() string {
 	return v.Error()
 }
+
+// AggregateErrors combines the errors of independent validations so they can all be reported at once.
+// Nil errors are ignored and a single failure is returned as is. Multiple failures are returned in a
+// ValidationError, in order, with the errors of nested ValidationErrors flattened into it.
+func AggregateErrors(errs ...error) error {
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+
+	switch len(failed) {
+	case 0:
+		return nil
+	case 1:
+		return failed[0]
+	}
+
+	validationErr := &ValidationError{}
+	for _, err := range failed {
+		var nested *ValidationError
+		if errors.As(err, &nested) {
+			validationErr.Errs = append(validationErr.Errs, nested.Errs...)
+			continue
+		}
+		validationErr.Errs = append(validationErr.Errs, err.Error())
+	}
+	return validationErr
+}
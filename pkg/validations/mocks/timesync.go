@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/validations/validation_options.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTimeSyncValidator is a mock of TimeSyncValidator interface.
+type MockTimeSyncValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeSyncValidatorMockRecorder
+}
+
+// MockTimeSyncValidatorMockRecorder is the mock recorder for MockTimeSyncValidator.
+type MockTimeSyncValidatorMockRecorder struct {
+	mock *MockTimeSyncValidator
+}
+
+// NewMockTimeSyncValidator creates a new mock instance.
+func NewMockTimeSyncValidator(ctrl *gomock.Controller) *MockTimeSyncValidator {
+	mock := &MockTimeSyncValidator{ctrl: ctrl}
+	mock.recorder = &MockTimeSyncValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeSyncValidator) EXPECT() *MockTimeSyncValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockTimeSyncValidator) Validate(ctx context.Context, kubeconfig string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", ctx, kubeconfig)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockTimeSyncValidatorMockRecorder) Validate(ctx, kubeconfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockTimeSyncValidator)(nil).Validate), ctx, kubeconfig)
+}
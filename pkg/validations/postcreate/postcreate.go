@@ -0,0 +1,360 @@
+// Package postcreate runs smoke tests against a newly created cluster to catch broken clusters
+// before handing them off.
+package postcreate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/wait"
+)
+
+const (
+	// DefaultTimeout is the maximum time each validation waits for its workload.
+	DefaultTimeout = 5 * time.Minute
+	// Namespace is the namespace the validation workloads run in. It's deleted once they complete.
+	Namespace = "eksa-post-create-validations"
+
+	pollInterval             = 2 * time.Second
+	defaultStorageClassLabel = "storageclass.kubernetes.io/is-default-class"
+	hostnameLabel            = "kubernetes.io/hostname"
+)
+
+// KubectlClient runs the validation workloads in the cluster.
+type KubectlClient interface {
+	Apply(ctx context.Context, kubeconfig string, obj runtime.Object) error
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+	ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list kubernetes.ObjectList) error
+	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
+	CreateNamespaceIfNotPresent(ctx context.Context, kubeconfig string, namespace string) error
+	DeleteNamespace(ctx context.Context, kubeconfig string, namespace string) error
+}
+
+// Validator runs smoke tests against a newly created cluster: DNS resolution, pod scheduling
+// on every worker node group, image pulls through the registry mirror, volume provisioning
+// with the default storage class and, optionally, load balancer services.
+type Validator struct {
+	kubectl      KubectlClient
+	timeout      time.Duration
+	loadBalancer bool
+}
+
+// ValidatorOpt configures a Validator.
+type ValidatorOpt func(*Validator)
+
+// WithTimeout sets the maximum time each validation waits for its workload.
+func WithTimeout(timeout time.Duration) ValidatorOpt {
+	return func(v *Validator) {
+		v.timeout = timeout
+	}
+}
+
+// WithLoadBalancerValidation enables the validation of LoadBalancer services, for clusters
+// with a load balancer controller.
+func WithLoadBalancerValidation() ValidatorOpt {
+	return func(v *Validator) {
+		v.loadBalancer = true
+	}
+}
+
+// NewValidator constructs a new Validator.
+func NewValidator(kubectl KubectlClient, opts ...ValidatorOpt) *Validator {
+	v := &Validator{
+		kubectl: kubectl,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Validate runs the validations against the workload cluster, reports their results and
+// returns an error if any of them fails. The cluster machines are read from the management cluster.
+func (v *Validator) Validate(ctx context.Context, spec *cluster.Spec, workloadCluster, managementCluster *types.Cluster) error {
+	logger.Info("Running post-create validations")
+	kubeconfig := workloadCluster.KubeconfigFile
+	if err := v.kubectl.CreateNamespaceIfNotPresent(ctx, kubeconfig, Namespace); err != nil {
+		return fmt.Errorf("creating namespace for post-create validations: %v", err)
+	}
+	defer v.cleanup(ctx, kubeconfig)
+
+	results := v.run(ctx, spec, workloadCluster, managementCluster)
+	failed := 0
+	for _, result := range results {
+		result.Report()
+		if result.Err != nil {
+			failed++
+		}
+	}
+	logger.Info("Post-create validations completed", "passed", len(results)-failed, "failed", failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d post-create validations failed", failed)
+	}
+	return nil
+}
+
+func (v *Validator) run(ctx context.Context, spec *cluster.Spec, workloadCluster, managementCluster *types.Cluster) []validations.ValidationResult {
+	results := []validations.ValidationResult{v.validateDNS(ctx, spec, workloadCluster)}
+	results = append(results, v.validateNodeGroups(ctx, spec, workloadCluster, managementCluster)...)
+
+	if spec.Cluster.RegistryMirror() != "" {
+		results = append(results, v.validateRegistryMirror(ctx, spec, workloadCluster))
+	}
+
+	if result, ok := v.validateStorage(ctx, spec, workloadCluster); ok {
+		results = append(results, result)
+	}
+
+	if v.loadBalancer {
+		results = append(results, v.validateLoadBalancer(ctx, workloadCluster))
+	}
+
+	return results
+}
+
+func (v *Validator) cleanup(ctx context.Context, kubeconfig string) {
+	if err := v.kubectl.DeleteNamespace(ctx, kubeconfig, Namespace); err != nil {
+		logger.Info("Warning: failed deleting post-create validations namespace, delete it manually", "namespace", Namespace, "error", err)
+	}
+}
+
+func (v *Validator) validateDNS(ctx context.Context, spec *cluster.Spec, workloadCluster *types.Cluster) validations.ValidationResult {
+	host := "kubernetes.default.svc." + constants.DefaultClusterDomain
+	pod := v.pod(spec, "dns", fmt.Sprintf("for i in $(seq 1 30); do getent hosts %s && exit 0; sleep 2; done; exit 1", host))
+
+	return validations.ValidationResult{
+		Name:        "cluster DNS resolution",
+		Remediation: "check the CoreDNS pods in the kube-system namespace are running and their logs",
+		Err:         v.runPod(ctx, workloadCluster.KubeconfigFile, pod),
+	}
+}
+
+func (v *Validator) validateNodeGroups(ctx context.Context, spec *cluster.Spec, workloadCluster, managementCluster *types.Cluster) []validations.ValidationResult {
+	windows := map[string]struct{}{}
+	for _, group := range spec.WindowsWorkerNodeGroups() {
+		windows[group.Name] = struct{}{}
+	}
+
+	machines, err := v.kubectl.GetMachines(ctx, managementCluster, spec.Cluster.Name)
+	results := make([]validations.ValidationResult, 0, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, group := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if _, ok := windows[group.Name]; ok {
+			logger.V(3).Info("Skipping pod scheduling validation for Windows worker node group", "group", group.Name)
+			continue
+		}
+		if group.Count != nil && *group.Count == 0 {
+			continue
+		}
+
+		result := validations.ValidationResult{
+			Name:        fmt.Sprintf("pod scheduling on worker node group %s", group.Name),
+			Remediation: fmt.Sprintf("check the nodes of worker node group %s are Ready and their kubelet logs", group.Name),
+		}
+		if err != nil {
+			result.Err = fmt.Errorf("getting cluster machines: %v", err)
+		} else {
+			result.Err = v.validateNodeGroup(ctx, spec, workloadCluster, machines, group)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (v *Validator) validateNodeGroup(ctx context.Context, spec *cluster.Spec, workloadCluster *types.Cluster, machines []types.Machine, group v1alpha1.WorkerNodeGroupConfiguration) error {
+	node := nodeForMachineDeployment(machines, clusterapi.MachineDeploymentName(spec, group))
+	if node == "" {
+		return fmt.Errorf("no node found for worker node group %s", group.Name)
+	}
+
+	pod := v.pod(spec, "schedule-"+group.Name, "true")
+	pod.Spec.NodeSelector = map[string]string{hostnameLabel: node}
+	return v.runPod(ctx, workloadCluster.KubeconfigFile, pod)
+}
+
+func nodeForMachineDeployment(machines []types.Machine, machineDeployment string) string {
+	for _, m := range machines {
+		if m.Metadata.Labels[clusterv1.MachineDeploymentLabelName] == machineDeployment && m.Status.NodeRef != nil {
+			return m.Status.NodeRef.Name
+		}
+	}
+	return ""
+}
+
+func (v *Validator) validateRegistryMirror(ctx context.Context, spec *cluster.Spec, workloadCluster *types.Cluster) validations.ValidationResult {
+	pod := v.pod(spec, "registry-mirror", "true")
+	pod.Spec.Containers[0].ImagePullPolicy = corev1.PullAlways
+
+	return validations.ValidationResult{
+		Name:        fmt.Sprintf("image pull through registry mirror %s", spec.Cluster.RegistryMirror()),
+		Remediation: "check the registry mirror is reachable from the nodes and contains the EKS Anywhere images",
+		Err:         v.runPod(ctx, workloadCluster.KubeconfigFile, pod),
+	}
+}
+
+func (v *Validator) validateStorage(ctx context.Context, spec *cluster.Spec, workloadCluster *types.Cluster) (validations.ValidationResult, bool) {
+	result := validations.ValidationResult{
+		Name:        "persistent volume provisioning",
+		Remediation: "check the CSI driver pods and the storage class configuration",
+	}
+
+	storageClasses := &storagev1.StorageClassList{}
+	if err := v.kubectl.ListObjects(ctx, "storageclass", "", workloadCluster.KubeconfigFile, storageClasses); err != nil {
+		result.Err = fmt.Errorf("listing storage classes: %v", err)
+		return result, true
+	}
+
+	storageClass := defaultStorageClass(storageClasses.Items)
+	if storageClass == "" {
+		logger.V(3).Info("Skipping persistent volume provisioning validation, the cluster has no default storage class")
+		return result, false
+	}
+
+	claim := &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "storage",
+			Namespace: Namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &storageClass,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+	if err := v.kubectl.Apply(ctx, workloadCluster.KubeconfigFile, claim); err != nil {
+		result.Err = fmt.Errorf("creating persistent volume claim: %v", err)
+		return result, true
+	}
+
+	// The pod only starts once the claim is bound and the volume is mounted.
+	pod := v.pod(spec, "storage", "true")
+	pod.Spec.Volumes = []corev1.Volume{{
+		Name: "data",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim.Name},
+		},
+	}}
+	pod.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}
+	result.Err = v.runPod(ctx, workloadCluster.KubeconfigFile, pod)
+
+	return result, true
+}
+
+func defaultStorageClass(storageClasses []storagev1.StorageClass) string {
+	for _, s := range storageClasses {
+		if s.Annotations[defaultStorageClassLabel] == "true" {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+func (v *Validator) validateLoadBalancer(ctx context.Context, workloadCluster *types.Cluster) validations.ValidationResult {
+	result := validations.ValidationResult{
+		Name:        "load balancer service",
+		Remediation: "check the load balancer controller is running and has addresses available",
+	}
+
+	service := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "load-balancer",
+			Namespace: Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{{Port: 80}},
+		},
+	}
+	if err := v.kubectl.Apply(ctx, workloadCluster.KubeconfigFile, service); err != nil {
+		result.Err = fmt.Errorf("creating load balancer service: %v", err)
+		return result
+	}
+
+	result.Err = v.waiter().For(ctx, "load balancer service to get an address", func(ctx context.Context) (wait.Status, error) {
+		if err := v.kubectl.GetObject(ctx, "service", service.Name, Namespace, workloadCluster.KubeconfigFile, service); err != nil {
+			return wait.Status{}, err
+		}
+		return wait.Status{Done: len(service.Status.LoadBalancer.Ingress) > 0}, nil
+	})
+
+	return result
+}
+
+// pod returns a pod that runs command in the EKS-A tools image, tolerating all taints.
+func (v *Validator) pod(spec *cluster.Spec, name, command string) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: Namespace,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Tolerations:   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			Containers: []corev1.Container{{
+				Name:    "validation",
+				Image:   spec.VersionsBundle.Eksa.CliTools.VersionedImage(),
+				Command: []string{"/bin/sh", "-c", command},
+			}},
+		},
+	}
+}
+
+// runPod creates the pod and waits for it to complete successfully.
+func (v *Validator) runPod(ctx context.Context, kubeconfig string, pod *corev1.Pod) error {
+	if err := v.kubectl.Apply(ctx, kubeconfig, pod); err != nil {
+		return fmt.Errorf("creating pod %s: %v", pod.Name, err)
+	}
+
+	return v.waiter().For(ctx, fmt.Sprintf("pod %s to complete", pod.Name), func(ctx context.Context) (wait.Status, error) {
+		if err := v.kubectl.GetObject(ctx, "pod", pod.Name, Namespace, kubeconfig, pod); err != nil {
+			return wait.Status{}, err
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			return wait.Status{Done: true}, nil
+		case corev1.PodFailed:
+			return wait.Status{}, wait.Failed(fmt.Errorf("pod %s failed", pod.Name))
+		default:
+			return wait.Status{Message: string(pod.Status.Phase)}, nil
+		}
+	})
+}
+
+func (v *Validator) waiter() *wait.Waiter {
+	return wait.New(v.timeout, wait.WithInterval(pollInterval))
+}
@@ -0,0 +1,228 @@
+package postcreate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations/postcreate"
+	"github.com/aws/eks-anywhere/pkg/validations/postcreate/mocks"
+)
+
+const kubeconfig = "my-cluster/my-cluster-eks-a-cluster.kubeconfig"
+
+type validatorTest struct {
+	*WithT
+	ctx        context.Context
+	kubectl    *mocks.MockKubectlClient
+	spec       *cluster.Spec
+	cluster    *types.Cluster
+	machines   []types.Machine
+	validator  *postcreate.Validator
+	podPhases  map[string]corev1.PodPhase
+	appliedPod map[string]*corev1.Pod
+}
+
+func newValidatorTest(t *testing.T, opts ...postcreate.ValidatorOpt) *validatorTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	opts = append(opts, postcreate.WithTimeout(time.Second))
+	tt := &validatorTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		kubectl: kubectl,
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "my-cluster"
+			s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}}
+		}),
+		cluster: &types.Cluster{Name: "my-cluster", KubeconfigFile: kubeconfig},
+		machines: []types.Machine{{
+			Metadata: types.MachineMetadata{Labels: map[string]string{clusterv1.MachineDeploymentLabelName: "my-cluster-md-0"}},
+			Status:   types.MachineStatus{NodeRef: &types.ResourceRef{Name: "my-cluster-md-0-abcde"}},
+		}},
+		validator:  postcreate.NewValidator(kubectl, opts...),
+		podPhases:  map[string]corev1.PodPhase{},
+		appliedPod: map[string]*corev1.Pod{},
+	}
+
+	return tt
+}
+
+// expectPods makes the pods applied by the validator complete with their phase in podPhases,
+// PodSucceeded by default.
+func (tt *validatorTest) expectPods() {
+	tt.kubectl.EXPECT().Apply(tt.ctx, kubeconfig, gomock.AssignableToTypeOf(&corev1.Pod{})).DoAndReturn(
+		func(_ context.Context, _ string, obj runtime.Object) error {
+			pod := obj.(*corev1.Pod)
+			tt.appliedPod[pod.Name] = pod.DeepCopy()
+			return nil
+		},
+	).AnyTimes()
+	tt.kubectl.EXPECT().GetObject(gomock.Any(), "pod", gomock.Any(), postcreate.Namespace, kubeconfig, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, name, _, _ string, obj runtime.Object) error {
+			phase, ok := tt.podPhases[name]
+			if !ok {
+				phase = corev1.PodSucceeded
+			}
+			obj.(*corev1.Pod).Status.Phase = phase
+			return nil
+		},
+	).AnyTimes()
+}
+
+func (tt *validatorTest) expectNamespace() {
+	tt.kubectl.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, kubeconfig, postcreate.Namespace)
+	tt.kubectl.EXPECT().DeleteNamespace(tt.ctx, kubeconfig, postcreate.Namespace)
+}
+
+func (tt *validatorTest) expectMachines() {
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.cluster, "my-cluster").Return(tt.machines, nil)
+}
+
+func (tt *validatorTest) expectStorageClasses(storageClasses ...storagev1.StorageClass) {
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "storageclass", "", kubeconfig, &storagev1.StorageClassList{}).DoAndReturn(
+		func(_ context.Context, _, _, _ string, list kubernetes.ObjectList) error {
+			list.(*storagev1.StorageClassList).Items = storageClasses
+			return nil
+		},
+	)
+}
+
+func (tt *validatorTest) validate() error {
+	return tt.validator.Validate(tt.ctx, tt.spec, tt.cluster, tt.cluster)
+}
+
+func TestValidatorValidateSuccess(t *testing.T) {
+	tt := newValidatorTest(t)
+	tt.expectNamespace()
+	tt.expectMachines()
+	tt.expectStorageClasses()
+	tt.expectPods()
+
+	tt.Expect(tt.validate()).To(Succeed())
+	tt.Expect(tt.appliedPod).To(HaveLen(2))
+	tt.Expect(tt.appliedPod).To(HaveKey("dns"))
+	tt.Expect(tt.appliedPod["schedule-md-0"].Spec.NodeSelector).To(Equal(map[string]string{"kubernetes.io/hostname": "my-cluster-md-0-abcde"}))
+}
+
+func TestValidatorValidateSkipsEmptyNodeGroups(t *testing.T) {
+	tt := newValidatorTest(t)
+	count := 0
+	tt.spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = &count
+	tt.expectNamespace()
+	tt.expectMachines()
+	tt.expectStorageClasses()
+	tt.expectPods()
+
+	tt.Expect(tt.validate()).To(Succeed())
+	tt.Expect(tt.appliedPod).To(HaveLen(1))
+}
+
+func TestValidatorValidatePodFailed(t *testing.T) {
+	tt := newValidatorTest(t)
+	tt.podPhases["dns"] = corev1.PodFailed
+	tt.expectNamespace()
+	tt.expectMachines()
+	tt.expectStorageClasses()
+	tt.expectPods()
+
+	tt.Expect(tt.validate()).To(MatchError("1 post-create validations failed"))
+}
+
+func TestValidatorValidateNodeGroupWithoutNodes(t *testing.T) {
+	tt := newValidatorTest(t)
+	tt.machines = nil
+	tt.expectNamespace()
+	tt.expectMachines()
+	tt.expectStorageClasses()
+	tt.expectPods()
+
+	tt.Expect(tt.validate()).To(MatchError("1 post-create validations failed"))
+	tt.Expect(tt.appliedPod).NotTo(HaveKey("schedule-md-0"))
+}
+
+func TestValidatorValidateRegistryMirror(t *testing.T) {
+	tt := newValidatorTest(t)
+	tt.spec.Cluster.Spec.RegistryMirrorConfiguration = &v1alpha1.RegistryMirrorConfiguration{
+		Endpoint: "harbor.example.com",
+		Port:     "443",
+	}
+	tt.expectNamespace()
+	tt.expectMachines()
+	tt.expectStorageClasses()
+	tt.expectPods()
+
+	tt.Expect(tt.validate()).To(Succeed())
+	tt.Expect(tt.appliedPod["registry-mirror"].Spec.Containers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
+}
+
+func TestValidatorValidateStorage(t *testing.T) {
+	tt := newValidatorTest(t)
+	tt.expectNamespace()
+	tt.expectMachines()
+	tt.expectStorageClasses(
+		storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{
+			Name:        "standard",
+			Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"},
+		}},
+	)
+	tt.kubectl.EXPECT().Apply(tt.ctx, kubeconfig, gomock.AssignableToTypeOf(&corev1.PersistentVolumeClaim{})).DoAndReturn(
+		func(_ context.Context, _ string, obj runtime.Object) error {
+			tt.Expect(*obj.(*corev1.PersistentVolumeClaim).Spec.StorageClassName).To(Equal("standard"))
+			return nil
+		},
+	)
+	tt.expectPods()
+
+	tt.Expect(tt.validate()).To(Succeed())
+	tt.Expect(tt.appliedPod["storage"].Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("storage"))
+}
+
+func TestValidatorValidateStorageListError(t *testing.T) {
+	tt := newValidatorTest(t)
+	tt.expectNamespace()
+	tt.expectMachines()
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "storageclass", "", kubeconfig, gomock.Any()).Return(errors.New("error"))
+	tt.expectPods()
+
+	tt.Expect(tt.validate()).To(MatchError("1 post-create validations failed"))
+}
+
+func TestValidatorValidateLoadBalancer(t *testing.T) {
+	tt := newValidatorTest(t, postcreate.WithLoadBalancerValidation())
+	tt.expectNamespace()
+	tt.expectMachines()
+	tt.expectStorageClasses()
+	tt.expectPods()
+	tt.kubectl.EXPECT().Apply(tt.ctx, kubeconfig, gomock.AssignableToTypeOf(&corev1.Service{}))
+	tt.kubectl.EXPECT().GetObject(gomock.Any(), "service", "load-balancer", postcreate.Namespace, kubeconfig, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			obj.(*corev1.Service).Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.10"}}
+			return nil
+		},
+	)
+
+	tt.Expect(tt.validate()).To(Succeed())
+}
+
+func TestValidatorValidateNamespaceError(t *testing.T) {
+	tt := newValidatorTest(t)
+	tt.kubectl.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, kubeconfig, postcreate.Namespace).Return(errors.New("error"))
+
+	tt.Expect(tt.validate()).To(MatchError(ContainSubstring("creating namespace for post-create validations")))
+}
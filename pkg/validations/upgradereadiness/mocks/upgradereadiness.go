@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/validations/upgradereadiness/upgradereadiness.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+	v1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	v1 "k8s.io/api/policy/v1"
+)
+
+// MockKubectlClient is a mock of KubectlClient interface.
+type MockKubectlClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubectlClientMockRecorder
+}
+
+// MockKubectlClientMockRecorder is the mock recorder for MockKubectlClient.
+type MockKubectlClientMockRecorder struct {
+	mock *MockKubectlClient
+}
+
+// NewMockKubectlClient creates a new mock instance.
+func NewMockKubectlClient(ctrl *gomock.Controller) *MockKubectlClient {
+	mock := &MockKubectlClient{ctrl: ctrl}
+	mock.recorder = &MockKubectlClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubectlClient) EXPECT() *MockKubectlClientMockRecorder {
+	return m.recorder
+}
+
+// GetAPIServerMetrics mocks base method.
+func (m *MockKubectlClient) GetAPIServerMetrics(ctx context.Context, kubeconfig string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIServerMetrics", ctx, kubeconfig)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIServerMetrics indicates an expected call of GetAPIServerMetrics.
+func (mr *MockKubectlClientMockRecorder) GetAPIServerMetrics(ctx, kubeconfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIServerMetrics", reflect.TypeOf((*MockKubectlClient)(nil).GetAPIServerMetrics), ctx, kubeconfig)
+}
+
+// GetMachines mocks base method.
+func (m *MockKubectlClient) GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachines", ctx, cluster, clusterName)
+	ret0, _ := ret[0].([]types.Machine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachines indicates an expected call of GetMachines.
+func (mr *MockKubectlClientMockRecorder) GetMachines(ctx, cluster, clusterName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachines", reflect.TypeOf((*MockKubectlClient)(nil).GetMachines), ctx, cluster, clusterName)
+}
+
+// GetPodDisruptionBudgets mocks base method.
+func (m *MockKubectlClient) GetPodDisruptionBudgets(ctx context.Context, kubeconfig string) ([]v1.PodDisruptionBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodDisruptionBudgets", ctx, kubeconfig)
+	ret0, _ := ret[0].([]v1.PodDisruptionBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPodDisruptionBudgets indicates an expected call of GetPodDisruptionBudgets.
+func (mr *MockKubectlClientMockRecorder) GetPodDisruptionBudgets(ctx, kubeconfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodDisruptionBudgets", reflect.TypeOf((*MockKubectlClient)(nil).GetPodDisruptionBudgets), ctx, kubeconfig)
+}
+
+// GetUnprovisionedTinkerbellHardware mocks base method.
+func (m *MockKubectlClient) GetUnprovisionedTinkerbellHardware(ctx context.Context, kubeconfig, namespace string) ([]v1alpha1.Hardware, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnprovisionedTinkerbellHardware", ctx, kubeconfig, namespace)
+	ret0, _ := ret[0].([]v1alpha1.Hardware)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnprovisionedTinkerbellHardware indicates an expected call of GetUnprovisionedTinkerbellHardware.
+func (mr *MockKubectlClientMockRecorder) GetUnprovisionedTinkerbellHardware(ctx, kubeconfig, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnprovisionedTinkerbellHardware", reflect.TypeOf((*MockKubectlClient)(nil).GetUnprovisionedTinkerbellHardware), ctx, kubeconfig, namespace)
+}
@@ -0,0 +1,285 @@
+// Package upgradereadiness evaluates a running cluster for the issues that block or break an
+// upgrade, before the upgrade is attempted.
+package upgradereadiness
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	policyv1 "k8s.io/api/policy/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/deprecatedapis"
+	"github.com/aws/eks-anywhere/pkg/semver"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// Status is the outcome of a readiness check.
+type Status string
+
+const (
+	// StatusPass means the check found nothing that affects the upgrade.
+	StatusPass Status = "Pass"
+	// StatusWarning means the check found something to review that doesn't block the upgrade.
+	StatusWarning Status = "Warning"
+	// StatusFail means the check found something that blocks the upgrade.
+	StatusFail Status = "Fail"
+)
+
+// Check is the result of a readiness check.
+type Check struct {
+	Name    string   `json:"name"`
+	Status  Status   `json:"status"`
+	Details []string `json:"details,omitempty"`
+}
+
+// Report is the result of all the readiness checks run against a cluster.
+type Report struct {
+	Cluster string  `json:"cluster"`
+	Ready   bool    `json:"ready"`
+	Checks  []Check `json:"checks"`
+}
+
+// KubectlClient reads the state of the cluster.
+type KubectlClient interface {
+	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
+	GetPodDisruptionBudgets(ctx context.Context, kubeconfig string) ([]policyv1.PodDisruptionBudget, error)
+	GetAPIServerMetrics(ctx context.Context, kubeconfig string) (string, error)
+	GetUnprovisionedTinkerbellHardware(ctx context.Context, kubeconfig, namespace string) ([]tinkv1alpha1.Hardware, error)
+}
+
+// HardwareRequirement is the number of spare Tinkerbell hardware matching a selector
+// a rolling upgrade needs.
+type HardwareRequirement struct {
+	Selector v1alpha1.HardwareSelector
+	Count    int
+}
+
+// Checker runs the readiness checks.
+type Checker struct {
+	kubectl  KubectlClient
+	hardware []HardwareRequirement
+}
+
+// CheckerOpt configures a Checker.
+type CheckerOpt func(*Checker)
+
+// WithSpareHardware enables the check of the spare Tinkerbell hardware for the rolling upgrade.
+func WithSpareHardware(requirements []HardwareRequirement) CheckerOpt {
+	return func(c *Checker) {
+		c.hardware = requirements
+	}
+}
+
+// NewChecker constructs a new Checker.
+func NewChecker(kubectl KubectlClient, opts ...CheckerOpt) *Checker {
+	c := &Checker{
+		kubectl: kubectl,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Check runs the readiness checks against the workload cluster for an upgrade to the spec
+// Kubernetes version. The cluster machines and the Tinkerbell hardware are read from the
+// management cluster. It only returns an error for invalid input, failures to read the cluster
+// state are reported as failed checks.
+func (c *Checker) Check(ctx context.Context, spec *cluster.Spec, workloadCluster, managementCluster *types.Cluster) (*Report, error) {
+	if _, err := semver.KubeVersionToValidSemver(spec.Cluster.Spec.KubernetesVersion); err != nil {
+		return nil, fmt.Errorf("parsing kubernetes version %s: %v", spec.Cluster.Spec.KubernetesVersion, err)
+	}
+
+	report := &Report{
+		Cluster: spec.Cluster.Name,
+		Checks: []Check{
+			c.checkDeprecatedAPIs(ctx, spec, workloadCluster),
+			c.checkPodDisruptionBudgets(ctx, workloadCluster),
+			c.checkMachines(ctx, spec, managementCluster),
+		},
+	}
+	if c.hardware != nil {
+		report.Checks = append(report.Checks, c.checkSpareHardware(ctx, managementCluster))
+	}
+
+	report.Ready = true
+	for _, check := range report.Checks {
+		if check.Status == StatusFail {
+			report.Ready = false
+		}
+	}
+
+	return report, nil
+}
+
+func (c *Checker) checkDeprecatedAPIs(ctx context.Context, spec *cluster.Spec, workloadCluster *types.Cluster) Check {
+	check := Check{Name: "deprecated API usage", Status: StatusPass}
+	apis, err := deprecatedapis.NewScanner(c.kubectl).Scan(ctx, workloadCluster.KubeconfigFile)
+	if err != nil {
+		return failed(check, err)
+	}
+
+	for _, api := range apis {
+		removed, err := api.RemovedIn(spec.Cluster.Spec.KubernetesVersion)
+		if err != nil || !removed {
+			check.Details = append(check.Details, fmt.Sprintf("%s is deprecated and removed in %s", api, releaseOrUnknown(api.RemovedRelease)))
+			if check.Status == StatusPass {
+				check.Status = StatusWarning
+			}
+			continue
+		}
+		check.Details = append(check.Details, fmt.Sprintf("%s is removed in %s and still in use", api, api.RemovedRelease))
+		check.Status = StatusFail
+	}
+
+	return check
+}
+
+func releaseOrUnknown(release string) string {
+	if release == "" {
+		return "an unknown release"
+	}
+	return release
+}
+
+func (c *Checker) checkPodDisruptionBudgets(ctx context.Context, workloadCluster *types.Cluster) Check {
+	check := Check{Name: "pod disruption budgets", Status: StatusPass}
+	pdbs, err := c.kubectl.GetPodDisruptionBudgets(ctx, workloadCluster.KubeconfigFile)
+	if err != nil {
+		return failed(check, err)
+	}
+
+	for _, pdb := range pdbs {
+		if pdb.Status.ExpectedPods > 0 && pdb.Status.DisruptionsAllowed == 0 {
+			check.Status = StatusFail
+			check.Details = append(check.Details, fmt.Sprintf(
+				"%s/%s allows no disruptions (%d/%d pods healthy), node drains will block",
+				pdb.Namespace, pdb.Name, pdb.Status.CurrentHealthy, pdb.Status.ExpectedPods,
+			))
+		}
+	}
+
+	return check
+}
+
+func (c *Checker) checkMachines(ctx context.Context, spec *cluster.Spec, managementCluster *types.Cluster) Check {
+	check := Check{Name: "machine health", Status: StatusPass}
+	machines, err := c.kubectl.GetMachines(ctx, managementCluster, spec.Cluster.Name)
+	if err != nil {
+		return failed(check, err)
+	}
+
+	nodeRef := types.WithNodeRef()
+	nodeHealthy := types.WithNodeHealthy()
+	for _, m := range machines {
+		switch {
+		case !nodeRef(m.Status):
+			check.Details = append(check.Details, fmt.Sprintf("machine %s has no node", m.Metadata.Name))
+		case !nodeHealthy(m.Status):
+			check.Details = append(check.Details, fmt.Sprintf("machine %s node %s is not healthy", m.Metadata.Name, m.Status.NodeRef.Name))
+		default:
+			continue
+		}
+		check.Status = StatusFail
+	}
+
+	return check
+}
+
+func (c *Checker) checkSpareHardware(ctx context.Context, managementCluster *types.Cluster) Check {
+	check := Check{Name: "spare Tinkerbell hardware", Status: StatusPass}
+	hardware, err := c.kubectl.GetUnprovisionedTinkerbellHardware(ctx, managementCluster.KubeconfigFile, constants.EksaSystemNamespace)
+	if err != nil {
+		return failed(check, err)
+	}
+
+	for _, requirement := range c.hardware {
+		available := 0
+		for _, h := range hardware {
+			if matches(requirement.Selector, h.Labels) {
+				available++
+			}
+		}
+		if available < requirement.Count {
+			check.Status = StatusFail
+			check.Details = append(check.Details, fmt.Sprintf(
+				"%d spare hardware matching selector %s, the rolling upgrade needs %d",
+				available, selectorString(requirement.Selector), requirement.Count,
+			))
+		}
+	}
+
+	return check
+}
+
+func matches(selector v1alpha1.HardwareSelector, labels map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func selectorString(selector v1alpha1.HardwareSelector) string {
+	pairs := make([]string, 0, len(selector))
+	for k, v := range selector {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func failed(check Check, err error) Check {
+	check.Status = StatusFail
+	check.Details = append(check.Details, err.Error())
+	return check
+}
+
+// TinkerbellHardwareRequirements returns the spare hardware the rolling upgrade of the cluster
+// needs: the max surge of the control plane and each worker node group, 1 when they don't set
+// a rollout strategy. Groups sharing a hardware selector add up.
+func TinkerbellHardwareRequirements(spec *cluster.Spec, machineConfigs map[string]*v1alpha1.TinkerbellMachineConfig) ([]HardwareRequirement, error) {
+	var requirements []HardwareRequirement
+	add := func(ref *v1alpha1.Ref, count int) error {
+		if ref == nil || machineConfigs[ref.Name] == nil {
+			return fmt.Errorf("machine config not found for machine group ref %v", ref)
+		}
+		selector := machineConfigs[ref.Name].Spec.HardwareSelector
+		for i := range requirements {
+			if selectorString(requirements[i].Selector) == selectorString(selector) {
+				requirements[i].Count += count
+				return nil
+			}
+		}
+		requirements = append(requirements, HardwareRequirement{Selector: selector, Count: count})
+		return nil
+	}
+
+	controlPlane := spec.Cluster.Spec.ControlPlaneConfiguration
+	maxSurge := 1
+	if controlPlane.UpgradeRolloutStrategy != nil {
+		maxSurge = controlPlane.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+	}
+	if err := add(controlPlane.MachineGroupRef, maxSurge); err != nil {
+		return nil, err
+	}
+
+	for _, group := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		maxSurge := 1
+		if group.UpgradeRolloutStrategy != nil {
+			maxSurge = group.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+		}
+		if err := add(group.MachineGroupRef, maxSurge); err != nil {
+			return nil, err
+		}
+	}
+
+	return requirements, nil
+}
@@ -0,0 +1,248 @@
+package upgradereadiness_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradereadiness"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradereadiness/mocks"
+)
+
+const (
+	workloadKubeconfig   = "my-cluster/my-cluster-eks-a-cluster.kubeconfig"
+	managementKubeconfig = "mgmt/mgmt-eks-a-cluster.kubeconfig"
+)
+
+type checkerTest struct {
+	*WithT
+	ctx               context.Context
+	kubectl           *mocks.MockKubectlClient
+	spec              *cluster.Spec
+	workloadCluster   *types.Cluster
+	managementCluster *types.Cluster
+	healthyMachine    types.Machine
+}
+
+func newCheckerTest(t *testing.T) *checkerTest {
+	ctrl := gomock.NewController(t)
+	return &checkerTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		kubectl: mocks.NewMockKubectlClient(ctrl),
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "my-cluster"
+			s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube124
+		}),
+		workloadCluster:   &types.Cluster{Name: "my-cluster", KubeconfigFile: workloadKubeconfig},
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: managementKubeconfig},
+		healthyMachine: types.Machine{
+			Metadata: types.MachineMetadata{Name: "my-cluster-md-0-1"},
+			Status: types.MachineStatus{
+				NodeRef:    &types.ResourceRef{Name: "my-cluster-md-0-1"},
+				Conditions: types.Conditions{{Type: "NodeHealthy", Status: "True"}},
+			},
+		},
+	}
+}
+
+func (tt *checkerTest) expect(metrics string, pdbs []policyv1.PodDisruptionBudget, machines []types.Machine) {
+	tt.kubectl.EXPECT().GetAPIServerMetrics(tt.ctx, workloadKubeconfig).Return(metrics, nil)
+	tt.kubectl.EXPECT().GetPodDisruptionBudgets(tt.ctx, workloadKubeconfig).Return(pdbs, nil)
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "my-cluster").Return(machines, nil)
+}
+
+func (tt *checkerTest) check(opts ...upgradereadiness.CheckerOpt) *upgradereadiness.Report {
+	report, err := upgradereadiness.NewChecker(tt.kubectl, opts...).Check(tt.ctx, tt.spec, tt.workloadCluster, tt.managementCluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	return report
+}
+
+func TestCheckerCheckReady(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.expect("# HELP apiserver_requested_deprecated_apis\n", nil, []types.Machine{tt.healthyMachine})
+
+	tt.Expect(tt.check()).To(Equal(&upgradereadiness.Report{
+		Cluster: "my-cluster",
+		Ready:   true,
+		Checks: []upgradereadiness.Check{
+			{Name: "deprecated API usage", Status: upgradereadiness.StatusPass},
+			{Name: "pod disruption budgets", Status: upgradereadiness.StatusPass},
+			{Name: "machine health", Status: upgradereadiness.StatusPass},
+		},
+	}))
+}
+
+func TestCheckerCheckDeprecatedAPIs(t *testing.T) {
+	tt := newCheckerTest(t)
+	metrics := `# TYPE apiserver_requested_deprecated_apis gauge
+apiserver_requested_deprecated_apis{group="policy",removed_release="1.25",resource="podsecuritypolicies",subresource="",version="v1beta1"} 1
+apiserver_requested_deprecated_apis{group="autoscaling",removed_release="1.24",resource="horizontalpodautoscalers",subresource="",version="v2beta1"} 1
+apiserver_requested_deprecated_apis{group="autoscaling",removed_release="1.24",resource="horizontalpodautoscalers",subresource="status",version="v2beta1"} 1
+apiserver_requested_deprecated_apis{group="batch",removed_release="1.23",resource="cronjobs",subresource="",version="v1beta1"} 0
+`
+	tt.expect(metrics, nil, nil)
+
+	report := tt.check()
+	tt.Expect(report.Ready).To(BeFalse())
+	tt.Expect(report.Checks[0]).To(Equal(upgradereadiness.Check{
+		Name:   "deprecated API usage",
+		Status: upgradereadiness.StatusFail,
+		Details: []string{
+			"policy/v1beta1 podsecuritypolicies is deprecated and removed in 1.25",
+			"autoscaling/v2beta1 horizontalpodautoscalers is removed in 1.24 and still in use",
+		},
+	}))
+}
+
+func TestCheckerCheckDeprecatedAPIsWarning(t *testing.T) {
+	tt := newCheckerTest(t)
+	metrics := `apiserver_requested_deprecated_apis{group="",removed_release="",resource="componentstatuses",subresource="",version="v1"} 1`
+	tt.expect(metrics, nil, nil)
+
+	report := tt.check()
+	tt.Expect(report.Ready).To(BeTrue())
+	tt.Expect(report.Checks[0].Status).To(Equal(upgradereadiness.StatusWarning))
+	tt.Expect(report.Checks[0].Details).To(ConsistOf("v1 componentstatuses is deprecated and removed in an unknown release"))
+}
+
+func TestCheckerCheckPodDisruptionBudgets(t *testing.T) {
+	tt := newCheckerTest(t)
+	pdbs := []policyv1.PodDisruptionBudget{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+			Status:     policyv1.PodDisruptionBudgetStatus{ExpectedPods: 2, CurrentHealthy: 2, DisruptionsAllowed: 1},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "apps"},
+			Status:     policyv1.PodDisruptionBudgetStatus{ExpectedPods: 1, CurrentHealthy: 1},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "apps"},
+		},
+	}
+	tt.expect("", pdbs, nil)
+
+	report := tt.check()
+	tt.Expect(report.Ready).To(BeFalse())
+	tt.Expect(report.Checks[1]).To(Equal(upgradereadiness.Check{
+		Name:    "pod disruption budgets",
+		Status:  upgradereadiness.StatusFail,
+		Details: []string{"apps/db allows no disruptions (1/1 pods healthy), node drains will block"},
+	}))
+}
+
+func TestCheckerCheckMachines(t *testing.T) {
+	tt := newCheckerTest(t)
+	machines := []types.Machine{
+		tt.healthyMachine,
+		{Metadata: types.MachineMetadata{Name: "my-cluster-md-0-2"}},
+		{
+			Metadata: types.MachineMetadata{Name: "my-cluster-md-0-3"},
+			Status: types.MachineStatus{
+				NodeRef:    &types.ResourceRef{Name: "my-cluster-md-0-3"},
+				Conditions: types.Conditions{{Type: "NodeHealthy", Status: "False"}},
+			},
+		},
+	}
+	tt.expect("", nil, machines)
+
+	report := tt.check()
+	tt.Expect(report.Ready).To(BeFalse())
+	tt.Expect(report.Checks[2].Details).To(Equal([]string{
+		"machine my-cluster-md-0-2 has no node",
+		"machine my-cluster-md-0-3 node my-cluster-md-0-3 is not healthy",
+	}))
+}
+
+func TestCheckerCheckReadErrors(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.kubectl.EXPECT().GetAPIServerMetrics(tt.ctx, workloadKubeconfig).Return("", errors.New("forbidden"))
+	tt.kubectl.EXPECT().GetPodDisruptionBudgets(tt.ctx, workloadKubeconfig).Return(nil, nil)
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "my-cluster").Return(nil, nil)
+
+	report := tt.check()
+	tt.Expect(report.Ready).To(BeFalse())
+	tt.Expect(report.Checks[0]).To(Equal(upgradereadiness.Check{
+		Name:    "deprecated API usage",
+		Status:  upgradereadiness.StatusFail,
+		Details: []string{"forbidden"},
+	}))
+}
+
+func TestCheckerCheckSpareHardware(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.expect("", nil, nil)
+	tt.kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(tt.ctx, managementKubeconfig, constants.EksaSystemNamespace).Return(
+		[]tinkv1alpha1.Hardware{
+			{ObjectMeta: metav1.ObjectMeta{Name: "hw1", Labels: map[string]string{"type": "cp"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "hw2", Labels: map[string]string{"type": "worker"}}},
+		}, nil,
+	)
+
+	report := tt.check(upgradereadiness.WithSpareHardware([]upgradereadiness.HardwareRequirement{
+		{Selector: v1alpha1.HardwareSelector{"type": "cp"}, Count: 1},
+		{Selector: v1alpha1.HardwareSelector{"type": "worker"}, Count: 2},
+	}))
+	tt.Expect(report.Ready).To(BeFalse())
+	tt.Expect(report.Checks[3]).To(Equal(upgradereadiness.Check{
+		Name:    "spare Tinkerbell hardware",
+		Status:  upgradereadiness.StatusFail,
+		Details: []string{"1 spare hardware matching selector type=worker, the rolling upgrade needs 2"},
+	}))
+}
+
+func TestCheckerCheckInvalidKubernetesVersion(t *testing.T) {
+	tt := newCheckerTest(t)
+	tt.spec.Cluster.Spec.KubernetesVersion = "latest"
+
+	_, err := upgradereadiness.NewChecker(tt.kubectl).Check(tt.ctx, tt.spec, tt.workloadCluster, tt.managementCluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("parsing kubernetes version latest")))
+}
+
+func TestTinkerbellHardwareRequirements(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &v1alpha1.Ref{Name: "cp"}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+			{
+				Name:            "md-0",
+				MachineGroupRef: &v1alpha1.Ref{Name: "worker"},
+				UpgradeRolloutStrategy: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+					RollingUpdate: v1alpha1.WorkerNodesRollingUpdateParams{MaxSurge: 2},
+				},
+			},
+			{Name: "md-1", MachineGroupRef: &v1alpha1.Ref{Name: "worker"}},
+		}
+	})
+	machineConfigs := map[string]*v1alpha1.TinkerbellMachineConfig{
+		"cp":     {Spec: v1alpha1.TinkerbellMachineConfigSpec{HardwareSelector: v1alpha1.HardwareSelector{"type": "cp"}}},
+		"worker": {Spec: v1alpha1.TinkerbellMachineConfigSpec{HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"}}},
+	}
+
+	g.Expect(upgradereadiness.TinkerbellHardwareRequirements(spec, machineConfigs)).To(Equal([]upgradereadiness.HardwareRequirement{
+		{Selector: v1alpha1.HardwareSelector{"type": "cp"}, Count: 1},
+		{Selector: v1alpha1.HardwareSelector{"type": "worker"}, Count: 3},
+	}))
+}
+
+func TestTinkerbellHardwareRequirementsMissingMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &v1alpha1.Ref{Name: "cp"}
+	})
+
+	_, err := upgradereadiness.TinkerbellHardwareRequirements(spec, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("machine config not found")))
+}
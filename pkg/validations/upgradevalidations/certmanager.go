@@ -0,0 +1,102 @@
+package upgradevalidations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/semver"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const (
+	certManagerControllerSelector = "app.kubernetes.io/name=cert-manager,app.kubernetes.io/component=controller"
+	certManagerVersionLabel       = "app.kubernetes.io/version"
+	clusterctlCoreLabel           = "clusterctl.cluster.x-k8s.io/core"
+	clusterctlCertManagerProvider = "cert-manager"
+)
+
+// ValidateCertManager checks that the cert-manager installations in a management cluster are compatible
+// with the cert-manager mode in the spec. In managed mode, the only cert-manager has to be the one
+// installed by EKS-A. In external mode, a user installed cert-manager must be present with at least the
+// version shipped in the bundle.
+func ValidateCertManager(ctx context.Context, k validations.KubectlClient, cluster *types.Cluster, spec *cluster.Spec) error {
+	if !spec.Cluster.IsSelfManaged() {
+		return nil
+	}
+
+	deployments, err := k.GetDeployments(ctx,
+		executables.WithCluster(cluster),
+		executables.WithAllNamespaces(),
+		executables.WithSelector(certManagerControllerSelector),
+	)
+	if err != nil {
+		return fmt.Errorf("getting cert-manager deployments: %v", err)
+	}
+
+	if spec.Cluster.CertManagerMode() == anywherev1.CertManagerExternal {
+		return validateExternalCertManager(deployments, spec.VersionsBundle.CertManager.Version)
+	}
+
+	return validateManagedCertManager(deployments)
+}
+
+func validateManagedCertManager(deployments []appsv1.Deployment) error {
+	for _, d := range deployments {
+		if d.Labels[clusterctlCoreLabel] != clusterctlCertManagerProvider {
+			return fmt.Errorf("found cert-manager deployment %s/%s not managed by EKS-A, set certManager.mode to %s to use it", d.Namespace, d.Name, anywherev1.CertManagerExternal)
+		}
+	}
+
+	if len(deployments) > 1 {
+		return fmt.Errorf("found %d cert-manager deployments, only one cert-manager installation is supported", len(deployments))
+	}
+
+	return nil
+}
+
+func validateExternalCertManager(deployments []appsv1.Deployment, minVersion string) error {
+	if len(deployments) == 0 {
+		return fmt.Errorf("certManager.mode is %s but no cert-manager deployment was found", anywherev1.CertManagerExternal)
+	}
+
+	min, err := semver.New(minVersion)
+	if err != nil {
+		return fmt.Errorf("parsing cert-manager bundle version: %v", err)
+	}
+
+	for _, d := range deployments {
+		v, err := semver.New(certManagerVersion(d))
+		if err != nil {
+			return fmt.Errorf("reading version of cert-manager deployment %s/%s: %v", d.Namespace, d.Name, err)
+		}
+		if v.LessThan(min) {
+			return fmt.Errorf("cert-manager deployment %s/%s has version %s, minimum supported version is %s", d.Namespace, d.Name, v, min)
+		}
+	}
+
+	return nil
+}
+
+// certManagerVersion returns the version of a cert-manager deployment from its labels, falling back to the
+// controller image tag.
+func certManagerVersion(d appsv1.Deployment) string {
+	if v, ok := d.Labels[certManagerVersionLabel]; ok {
+		return v
+	}
+
+	for _, c := range d.Spec.Template.Spec.Containers {
+		image := strings.SplitN(c.Image, "@", 2)[0]
+		if i := strings.LastIndex(image, ":"); i >= 0 && !strings.Contains(image[i:], "/") {
+			return image[i+1:]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,122 @@
+package upgradevalidations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+)
+
+func certManagerDeployment(namespace string, labels map[string]string, image string) appsv1.Deployment {
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cert-manager",
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "cert-manager", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateCertManager(t *testing.T) {
+	managed := certManagerDeployment("cert-manager", map[string]string{"clusterctl.cluster.x-k8s.io/core": "cert-manager"}, "public.ecr.aws/cert-manager-controller:v1.8.2-eks-a-19")
+	userNew := certManagerDeployment("security", map[string]string{"app.kubernetes.io/version": "v1.9.1"}, "")
+	userOld := certManagerDeployment("security", nil, "quay.io/jetstack/cert-manager-controller:v1.7.0@sha256:abcdef")
+
+	tests := []struct {
+		name        string
+		mode        v1alpha1.CertManagerMode
+		workload    bool
+		deployments []appsv1.Deployment
+		wantErr     string
+	}{
+		{
+			name:        "managed only",
+			deployments: []appsv1.Deployment{managed},
+		},
+		{
+			name:        "managed with user installation",
+			deployments: []appsv1.Deployment{managed, userNew},
+			wantErr:     "found cert-manager deployment security/cert-manager not managed by EKS-A, set certManager.mode to external to use it",
+		},
+		{
+			name:        "external supported version",
+			mode:        v1alpha1.CertManagerExternal,
+			deployments: []appsv1.Deployment{userNew},
+		},
+		{
+			name:        "external old version",
+			mode:        v1alpha1.CertManagerExternal,
+			deployments: []appsv1.Deployment{userOld},
+			wantErr:     "cert-manager deployment security/cert-manager has version v1.7.0, minimum supported version is v1.8.2",
+		},
+		{
+			name:    "external missing",
+			mode:    v1alpha1.CertManagerExternal,
+			wantErr: "certManager.mode is external but no cert-manager deployment was found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			k := mocks.NewMockKubectlClient(gomock.NewController(t))
+			c := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = "test"
+				s.Cluster.SetSelfManaged()
+				s.Cluster.Spec.CertManager = &v1alpha1.CertManagerConfiguration{Mode: tt.mode}
+				s.VersionsBundle.CertManager.Version = "v1.8.2+abcdef"
+			})
+			k.EXPECT().GetDeployments(ctx, gomock.Any()).Return(tt.deployments, nil)
+
+			err := upgradevalidations.ValidateCertManager(ctx, k, c, spec)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateCertManagerWorkloadCluster(t *testing.T) {
+	g := NewWithT(t)
+	k := mocks.NewMockKubectlClient(gomock.NewController(t))
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test"
+		s.Cluster.Spec.ManagementCluster.Name = "mgmt"
+	})
+
+	g.Expect(upgradevalidations.ValidateCertManager(context.Background(), k, &types.Cluster{}, spec)).To(Succeed())
+}
+
+func TestValidateCertManagerGetDeploymentsError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	k := mocks.NewMockKubectlClient(gomock.NewController(t))
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.SetSelfManaged()
+	})
+	k.EXPECT().GetDeployments(ctx, gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	g.Expect(upgradevalidations.ValidateCertManager(ctx, k, &types.Cluster{}, spec)).To(MatchError("getting cert-manager deployments: connection refused"))
+}
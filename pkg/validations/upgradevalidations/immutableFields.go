@@ -39,7 +39,7 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 		return err
 	}
 
-	if !nSpec.ControlPlaneConfiguration.Endpoint.Equal(oSpec.ControlPlaneConfiguration.Endpoint) {
+	if !nSpec.ControlPlaneConfiguration.Endpoint.AddressEqual(oSpec.ControlPlaneConfiguration.Endpoint) {
 		return fmt.Errorf("spec.controlPlaneConfiguration.endpoint is immutable")
 	}
 
@@ -61,6 +61,14 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 		return fmt.Errorf("spec.proxyConfiguration is immutable")
 	}
 
+	if err := validateImmutableKubeletConfiguration(spec.Cluster, prevSpec); err != nil {
+		return err
+	}
+
+	if nSpec.ClusterProfile != oSpec.ClusterProfile {
+		return fmt.Errorf("spec.clusterProfile is immutable")
+	}
+
 	oldETCD := oSpec.ExternalEtcdConfiguration
 	newETCD := nSpec.ExternalEtcdConfiguration
 	if oldETCD != nil && newETCD != nil {
@@ -101,6 +109,18 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 	return provider.ValidateNewSpec(ctx, cluster, spec)
 }
 
+func validateImmutableKubeletConfiguration(new, old *v1alpha1.Cluster) error {
+	if new.KubeletServingCertificateRotationEnabled() != old.KubeletServingCertificateRotationEnabled() {
+		return fmt.Errorf("spec.kubeletConfiguration.servingCertificateRotation is immutable")
+	}
+
+	if !v1alpha1.ImageCredentialProvidersEqual(new.ImageCredentialProviders(), old.ImageCredentialProviders()) {
+		return fmt.Errorf("spec.kubeletConfiguration.imageCredentialProviders is immutable")
+	}
+
+	return nil
+}
+
 func ValidateGitOpsImmutableFields(ctx context.Context, k validations.KubectlClient, cluster *types.Cluster, clusterSpec *cluster.Spec, oldCluster *v1alpha1.Cluster) error {
 	if oldCluster.Spec.GitOpsRef == nil {
 		return nil
@@ -72,6 +72,27 @@ func (u *UpgradeValidations) PreflightValidations(ctx context.Context) (err erro
 			Err:         validations.ValidateK8s124Support(u.Opts.Spec),
 			Silent:      true,
 		},
+		{
+			Name:        "validate cert-manager installation",
+			Remediation: "ensure only one cert-manager is installed and that certManager.mode matches who manages it",
+			Err:         ValidateCertManager(ctx, k, targetCluster, u.Opts.Spec),
+		},
+	}
+
+	if u.Opts.Spec.Cluster.IsManaged() {
+		upgradeValidations = append(upgradeValidations, validations.ValidationResult{
+			Name:        "validate management cluster kubeconfig",
+			Remediation: fmt.Sprintf("use the kubeconfig and --context of management cluster %s", u.Opts.Spec.Cluster.ManagedBy()),
+			Err:         validations.ValidateManagementClusterManages(ctx, k, u.Opts.ManagementCluster, u.Opts.Spec),
+		})
+	}
+
+	if u.Opts.TimeSyncValidator != nil {
+		upgradeValidations = append(upgradeValidations, validations.ValidationResult{
+			Name:        "node clocks synchronized",
+			Remediation: fmt.Sprintf("ensure NTP is configured and running on the nodes of cluster %s, clock skew breaks etcd and TLS", u.Opts.WorkloadCluster.Name),
+			Err:         u.Opts.TimeSyncValidator.Validate(ctx, u.Opts.WorkloadCluster.KubeconfigFile),
+		})
 	}
 
 	return validations.ProcessValidationResults(upgradeValidations)
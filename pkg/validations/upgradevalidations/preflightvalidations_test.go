@@ -414,6 +414,7 @@ func TestPreflightValidationsTinkerbell(t *testing.T) {
 			k.EXPECT().GetClusters(ctx, workloadCluster).Return(tc.getClusterResponse, nil)
 			k.EXPECT().GetEksaCluster(ctx, workloadCluster, clusterSpec.Cluster.Name).Return(existingClusterSpec.Cluster, nil)
 			k.EXPECT().Version(ctx, workloadCluster).Return(versionResponse, nil)
+			k.EXPECT().GetDeployments(ctx, gomock.Any()).Return(nil, nil).MaxTimes(1)
 			upgradeValidations := upgradevalidations.New(opts)
 			err := upgradeValidations.PreflightValidations(ctx)
 			if !reflect.DeepEqual(err, tc.wantErr) {
@@ -464,6 +465,7 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 		workerResponse     error
 		nodeResponse       error
 		crdResponse        error
+		timeSyncResponse   error
 		wantErr            error
 		modifyFunc         func(s *cluster.Spec)
 	}{
@@ -478,6 +480,14 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 			crdResponse:        nil,
 			wantErr:            nil,
 		},
+		{
+			name:               "ValidationFailsNodeClocksNotSynchronized",
+			clusterVersion:     "v1.19.16-eks-1-19-4",
+			upgradeVersion:     "1.19",
+			getClusterResponse: goodClusterResponse,
+			timeSyncResponse:   errors.New("node clocks not synchronized within 2s: node-1: clock skew 30s"),
+			wantErr:            composeError("node clocks not synchronized within 2s: node-1: clock skew 30s"),
+		},
 		{
 			name:               "ValidationFailsMajorVersionPlus2",
 			clusterVersion:     "v1.18.16-eks-1-18-4",
@@ -946,6 +956,52 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:               "ValidationKubeletServingCertificateRotationImmutable",
+			clusterVersion:     "v1.19.16-eks-1-19-4",
+			upgradeVersion:     "1.19",
+			getClusterResponse: goodClusterResponse,
+			cpResponse:         nil,
+			workerResponse:     nil,
+			nodeResponse:       nil,
+			crdResponse:        nil,
+			wantErr:            composeError("spec.kubeletConfiguration.servingCertificateRotation is immutable"),
+			modifyFunc: func(s *cluster.Spec) {
+				s.Cluster.Spec.KubeletConfiguration = &v1alpha1.KubeletConfiguration{ServingCertificateRotation: true}
+			},
+		},
+		{
+			name:               "ValidationImageCredentialProvidersImmutable",
+			clusterVersion:     "v1.19.16-eks-1-19-4",
+			upgradeVersion:     "1.19",
+			getClusterResponse: goodClusterResponse,
+			cpResponse:         nil,
+			workerResponse:     nil,
+			nodeResponse:       nil,
+			crdResponse:        nil,
+			wantErr:            composeError("spec.kubeletConfiguration.imageCredentialProviders is immutable"),
+			modifyFunc: func(s *cluster.Spec) {
+				s.Cluster.Spec.KubeletConfiguration = &v1alpha1.KubeletConfiguration{
+					ImageCredentialProviders: []v1alpha1.ImageCredentialProvider{
+						{Name: "ecr-credential-provider", MatchImages: []string{"*.dkr.ecr.*.amazonaws.com"}},
+					},
+				}
+			},
+		},
+		{
+			name:               "ValidationClusterProfileImmutable",
+			clusterVersion:     "v1.19.16-eks-1-19-4",
+			upgradeVersion:     "1.19",
+			getClusterResponse: goodClusterResponse,
+			cpResponse:         nil,
+			workerResponse:     nil,
+			nodeResponse:       nil,
+			crdResponse:        nil,
+			wantErr:            composeError("spec.clusterProfile is immutable"),
+			modifyFunc: func(s *cluster.Spec) {
+				s.Cluster.Spec.ClusterProfile = v1alpha1.EdgeClusterProfile
+			},
+		},
 		{
 			name:               "ValidationEtcdConfigReplicasImmutable",
 			clusterVersion:     "v1.19.16-eks-1-19-4",
@@ -1131,6 +1187,7 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 			mockCtrl := gomock.NewController(t)
 			k := mocks.NewMockKubectlClient(mockCtrl)
 			tlsValidator := mocks.NewMockTlsValidator(mockCtrl)
+			timeSyncValidator := mocks.NewMockTimeSyncValidator(mockCtrl)
 
 			provider := mockproviders.NewMockProvider(mockCtrl)
 			opts := &validations.Opts{
@@ -1140,6 +1197,7 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 				ManagementCluster: workloadCluster,
 				Provider:          provider,
 				TlsValidator:      tlsValidator,
+				TimeSyncValidator: timeSyncValidator,
 			}
 
 			clusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.KubernetesVersion(tc.upgradeVersion)
@@ -1167,6 +1225,8 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 			k.EXPECT().GetEksaOIDCConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[1].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.OIDCConfig, nil).MaxTimes(1)
 			k.EXPECT().GetEksaAWSIamConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.AWSIamConfig, nil).MaxTimes(1)
 			k.EXPECT().Version(ctx, workloadCluster).Return(versionResponse, nil)
+			k.EXPECT().GetDeployments(ctx, gomock.Any()).Return(nil, nil).MaxTimes(1)
+			timeSyncValidator.EXPECT().Validate(ctx, kubeconfigFilePath).Return(tc.timeSyncResponse)
 			upgradeValidations := upgradevalidations.New(opts)
 			err := upgradeValidations.PreflightValidations(ctx)
 			if !reflect.DeepEqual(err, tc.wantErr) {
@@ -1401,6 +1461,7 @@ func TestPreFlightValidationsGit(t *testing.T) {
 			k.EXPECT().GetEksaOIDCConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.OIDCConfig, nil).MaxTimes(1)
 			k.EXPECT().GetEksaAWSIamConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[1].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.AWSIamConfig, nil).MaxTimes(1)
 			k.EXPECT().Version(ctx, workloadCluster).Return(versionResponse, nil)
+			k.EXPECT().GetDeployments(ctx, gomock.Any()).Return(nil, nil).MaxTimes(1)
 			upgradeValidations := upgradevalidations.New(opts)
 			err := upgradeValidations.PreflightValidations(ctx)
 			if !reflect.DeepEqual(err, tc.wantErr) {
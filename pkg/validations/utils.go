@@ -1,6 +1,7 @@
 package validations
 
 import (
+	"errors"
 	"unicode"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -15,7 +16,7 @@ type ValidationResult struct {
 
 func (v *ValidationResult) Report() {
 	if v.Err != nil {
-		logger.MarkFail("Validation failed", "validation", v.Name, "error", v.Err, "remediation", v.Remediation)
+		v.logFail()
 		return
 	}
 	if !v.Silent {
@@ -23,6 +24,20 @@ func (v *ValidationResult) Report() {
 	}
 }
 
+// logFail logs the error of the validation. Each of the errors of an aggregated ValidationError
+// is logged on its own so all the problems found are easy to tell apart.
+func (v *ValidationResult) logFail() {
+	var validationErr *ValidationError
+	if !errors.As(v.Err, &validationErr) {
+		logger.MarkFail("Validation failed", "validation", v.Name, "error", v.Err, "remediation", v.Remediation)
+		return
+	}
+
+	for _, err := range validationErr.Errs {
+		logger.MarkFail("Validation failed", "validation", v.Name, "error", err, "remediation", v.Remediation)
+	}
+}
+
 func (v *ValidationResult) LogPass() {
 	logger.MarkPass(capitalize(v.Name))
 }
@@ -1,6 +1,8 @@
 package validations
 
 import (
+	"context"
+
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/crypto"
@@ -8,6 +10,11 @@ import (
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+// TimeSyncValidator validates the clocks of the nodes of a cluster are synchronized.
+type TimeSyncValidator interface {
+	Validate(ctx context.Context, kubeconfig string) error
+}
+
 type Opts struct {
 	Kubectl           KubectlClient
 	Spec              *cluster.Spec
@@ -16,6 +23,10 @@ type Opts struct {
 	Provider          providers.Provider
 	TlsValidator      TlsValidator
 	CliConfig         *config.CliConfig
+	// TimeSyncValidator is optional, node clocks are not validated if nil.
+	TimeSyncValidator TimeSyncValidator
+	// PackagesLocation is the file or directory of the curated packages installed with the cluster, optional.
+	PackagesLocation string
 }
 
 func (o *Opts) SetDefaults() {
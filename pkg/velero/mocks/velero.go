@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/velero/velero.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHelm is a mock of Helm interface.
+type MockHelm struct {
+	ctrl     *gomock.Controller
+	recorder *MockHelmMockRecorder
+}
+
+// MockHelmMockRecorder is the mock recorder for MockHelm.
+type MockHelmMockRecorder struct {
+	mock *MockHelm
+}
+
+// NewMockHelm creates a new mock instance.
+func NewMockHelm(ctrl *gomock.Controller) *MockHelm {
+	mock := &MockHelm{ctrl: ctrl}
+	mock.recorder = &MockHelmMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHelm) EXPECT() *MockHelmMockRecorder {
+	return m.recorder
+}
+
+// RegistryLogin mocks base method.
+func (m *MockHelm) RegistryLogin(ctx context.Context, registry, username, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegistryLogin", ctx, registry, username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegistryLogin indicates an expected call of RegistryLogin.
+func (mr *MockHelmMockRecorder) RegistryLogin(ctx, registry, username, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegistryLogin", reflect.TypeOf((*MockHelm)(nil).RegistryLogin), ctx, registry, username, password)
+}
+
+// TemplateWithCRDs mocks base method.
+func (m *MockHelm) TemplateWithCRDs(ctx context.Context, ociURI, version, namespace string, values interface{}, kubeVersion string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplateWithCRDs", ctx, ociURI, version, namespace, values, kubeVersion)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplateWithCRDs indicates an expected call of TemplateWithCRDs.
+func (mr *MockHelmMockRecorder) TemplateWithCRDs(ctx, ociURI, version, namespace, values, kubeVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateWithCRDs", reflect.TypeOf((*MockHelm)(nil).TemplateWithCRDs), ctx, ociURI, version, namespace, values, kubeVersion)
+}
+
+// MockKubernetesClient is a mock of KubernetesClient interface.
+type MockKubernetesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubernetesClientMockRecorder
+}
+
+// MockKubernetesClientMockRecorder is the mock recorder for MockKubernetesClient.
+type MockKubernetesClientMockRecorder struct {
+	mock *MockKubernetesClient
+}
+
+// NewMockKubernetesClient creates a new mock instance.
+func NewMockKubernetesClient(ctrl *gomock.Controller) *MockKubernetesClient {
+	mock := &MockKubernetesClient{ctrl: ctrl}
+	mock.recorder = &MockKubernetesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubernetesClient) EXPECT() *MockKubernetesClientMockRecorder {
+	return m.recorder
+}
+
+// ApplyKubeSpecFromBytes mocks base method.
+func (m *MockKubernetesClient) ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecFromBytes", ctx, cluster, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecFromBytes indicates an expected call of ApplyKubeSpecFromBytes.
+func (mr *MockKubernetesClientMockRecorder) ApplyKubeSpecFromBytes(ctx, cluster, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytes", reflect.TypeOf((*MockKubernetesClient)(nil).ApplyKubeSpecFromBytes), ctx, cluster, data)
+}
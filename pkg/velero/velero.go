@@ -0,0 +1,208 @@
+// Package velero deploys the Velero backup component to the clusters that configure backup.
+package velero
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/semver"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// Namespace is the namespace Velero is deployed to.
+const Namespace = "velero"
+
+const (
+	storageLocationName = "default"
+	pluginsVolumeName   = "plugins"
+	osLabel             = "kubernetes.io/os"
+)
+
+// Helm renders Helm charts.
+type Helm interface {
+	TemplateWithCRDs(ctx context.Context, ociURI, version, namespace string, values interface{}, kubeVersion string) ([]byte, error)
+	RegistryLogin(ctx context.Context, registry, username, password string) error
+}
+
+// KubernetesClient applies manifests to a cluster.
+type KubernetesClient interface {
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+}
+
+// Installer deploys and upgrades Velero with the chart and images of the cluster bundle.
+type Installer struct {
+	helm Helm
+	k8s  KubernetesClient
+}
+
+// NewInstaller builds an Installer.
+func NewInstaller(helm Helm, k8s KubernetesClient) *Installer {
+	return &Installer{
+		helm: helm,
+		k8s:  k8s,
+	}
+}
+
+// Install deploys Velero to the cluster, or upgrades it to the version in the spec bundle if it's already
+// deployed. It does nothing if the spec doesn't configure backup.
+func (i *Installer) Install(ctx context.Context, cluster *types.Cluster, spec *cluster.Spec) error {
+	if spec.Cluster.Spec.Backup == nil {
+		return nil
+	}
+
+	manifest, err := i.GenerateManifest(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	if err := i.k8s.ApplyKubeSpecFromBytes(ctx, cluster, manifest); err != nil {
+		return fmt.Errorf("applying velero manifest: %v", err)
+	}
+
+	return nil
+}
+
+// GenerateManifest renders the Velero chart of the spec bundle with the backup configuration of the spec,
+// including the velero namespace and the Velero CRDs.
+func (i *Installer) GenerateManifest(ctx context.Context, spec *cluster.Spec) ([]byte, error) {
+	chart := spec.VersionsBundle.Velero.HelmChart
+	if chart.URI == "" {
+		return nil, fmt.Errorf("bundle for Kubernetes %s doesn't include velero, backup requires a newer EKS-A version", spec.Cluster.Spec.KubernetesVersion)
+	}
+
+	kubeVersion, err := semver.New(spec.VersionsBundle.KubeDistro.Kubernetes.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubernetes version %s: %v", spec.VersionsBundle.KubeDistro.Kubernetes.Tag, err)
+	}
+
+	if mirror := spec.Cluster.Spec.RegistryMirrorConfiguration; mirror != nil && mirror.Authenticate {
+		username, password, err := config.ReadCredentials()
+		if err != nil {
+			return nil, err
+		}
+		if err := i.helm.RegistryLogin(ctx, spec.Cluster.RegistryMirror(), username, password); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest, err := i.helm.TemplateWithCRDs(
+		ctx,
+		fmt.Sprintf("oci://%s", chart.Image()),
+		chart.Tag(),
+		Namespace,
+		templateValues(spec),
+		fmt.Sprintf("%d.%d", kubeVersion.Major, kubeVersion.Minor),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("generating velero manifest: %v", err)
+	}
+
+	namespace, err := templater.ObjectsToYaml(&corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+		ObjectMeta: metav1.ObjectMeta{Name: Namespace},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(namespace, manifest...), nil
+}
+
+type values map[string]interface{}
+
+func templateValues(spec *cluster.Spec) values {
+	backup := spec.Cluster.Spec.Backup
+	bundle := spec.VersionsBundle.Velero
+	nodeSelector := values{osLabel: "linux"}
+
+	return values{
+		"image": values{
+			"repository": bundle.Velero.Image(),
+			"tag":        bundle.Velero.Tag(),
+		},
+		"initContainers": []values{
+			{
+				"name":  "velero-plugin-for-aws",
+				"image": bundle.PluginForAWS.VersionedImage(),
+				"volumeMounts": []values{
+					{"mountPath": "/target", "name": pluginsVolumeName},
+				},
+			},
+		},
+		"kubectl": values{
+			"image": values{
+				"repository": bundle.Kubectl.Image(),
+				"tag":        bundle.Kubectl.Tag(),
+			},
+		},
+		// CRDs are rendered with the chart and applied with the rest of the manifest.
+		"upgradeCRDs": false,
+		"credentials": values{
+			"useSecret":      true,
+			"existingSecret": backup.StorageLocation.CredentialsSecretName,
+		},
+		"configuration": values{
+			"backupStorageLocation":  []values{storageLocationValues(backup.StorageLocation)},
+			"volumeSnapshotLocation": []values{},
+		},
+		"snapshotsEnabled": false,
+		"deployNodeAgent":  backup.FileSystemBackup,
+		"nodeSelector":     nodeSelector,
+		"nodeAgent": values{
+			"nodeSelector": nodeSelector,
+		},
+		"schedules": scheduleValues(backup.Schedules),
+	}
+}
+
+func storageLocationValues(location anywherev1.BackupStorageLocation) values {
+	locationConfig := values{"region": location.Region}
+	if location.S3URL != "" {
+		locationConfig["s3Url"] = location.S3URL
+		// S3 compatible storage services, like MinIO, usually don't support virtual hosted-style URLs.
+		locationConfig["s3ForcePathStyle"] = "true"
+	}
+
+	v := values{
+		"name":     storageLocationName,
+		"provider": "aws",
+		"bucket":   location.Bucket,
+		"default":  true,
+		"config":   locationConfig,
+	}
+	if location.Prefix != "" {
+		v["prefix"] = location.Prefix
+	}
+
+	return v
+}
+
+func scheduleValues(schedules []anywherev1.BackupSchedule) values {
+	v := make(values, len(schedules))
+	for _, s := range schedules {
+		template := values{"storageLocation": storageLocationName}
+		if s.TTL != "" {
+			template["ttl"] = s.TTL
+		}
+		if len(s.IncludedNamespaces) > 0 {
+			template["includedNamespaces"] = s.IncludedNamespaces
+		}
+		if len(s.ExcludedNamespaces) > 0 {
+			template["excludedNamespaces"] = s.ExcludedNamespaces
+		}
+		v[s.Name] = values{
+			"disabled": false,
+			"schedule": s.Schedule,
+			"template": template,
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,177 @@
+package velero_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/velero"
+	"github.com/aws/eks-anywhere/pkg/velero/mocks"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	chartURI     = "oci://public.ecr.aws/eks-anywhere/velero"
+	chartVersion = "4.0.2"
+	manifest     = "kind: Deployment"
+	namespace    = `apiVersion: v1
+kind: Namespace
+metadata:
+  creationTimestamp: null
+  name: velero
+spec: {}
+status: {}
+`
+)
+
+const wantValues = `configuration:
+  backupStorageLocation:
+  - bucket: backups
+    config:
+      region: us-west-2
+      s3ForcePathStyle: "true"
+      s3Url: https://minio.example.com:9000
+    default: true
+    name: default
+    prefix: prod
+    provider: aws
+  volumeSnapshotLocation: []
+credentials:
+  existingSecret: backup-credentials
+  useSecret: true
+deployNodeAgent: true
+image:
+  repository: public.ecr.aws/eks-anywhere/velero
+  tag: v1.11.0
+initContainers:
+- image: public.ecr.aws/eks-anywhere/velero-plugin-for-aws:v1.7.0
+  name: velero-plugin-for-aws
+  volumeMounts:
+  - mountPath: /target
+    name: plugins
+kubectl:
+  image:
+    repository: public.ecr.aws/eks-anywhere/kubectl
+    tag: v1.24.13
+nodeAgent:
+  nodeSelector:
+    kubernetes.io/os: linux
+nodeSelector:
+  kubernetes.io/os: linux
+schedules:
+  daily:
+    disabled: false
+    schedule: 0 2 * * *
+    template:
+      excludedNamespaces:
+      - kube-system
+      storageLocation: default
+      ttl: 720h
+snapshotsEnabled: false
+upgradeCRDs: false
+`
+
+type installerTest struct {
+	*WithT
+	ctx       context.Context
+	helm      *mocks.MockHelm
+	k8s       *mocks.MockKubernetesClient
+	installer *velero.Installer
+	cluster   *types.Cluster
+	spec      *cluster.Spec
+}
+
+func newInstallerTest(t *testing.T) *installerTest {
+	ctrl := gomock.NewController(t)
+	helm := mocks.NewMockHelm(ctrl)
+	k8s := mocks.NewMockKubernetesClient(ctrl)
+	return &installerTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		helm:      helm,
+		k8s:       k8s,
+		installer: velero.NewInstaller(helm, k8s),
+		cluster:   &types.Cluster{Name: "my-cluster", KubeconfigFile: "my-cluster.kubeconfig"},
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Spec.Backup = &anywherev1.BackupConfiguration{
+				StorageLocation: anywherev1.BackupStorageLocation{
+					Bucket:                "backups",
+					Prefix:                "prod",
+					Region:                "us-west-2",
+					S3URL:                 "https://minio.example.com:9000",
+					CredentialsSecretName: "backup-credentials",
+				},
+				Schedules: []anywherev1.BackupSchedule{
+					{Name: "daily", Schedule: "0 2 * * *", TTL: "720h", ExcludedNamespaces: []string{"kube-system"}},
+				},
+				FileSystemBackup: true,
+			}
+			s.VersionsBundle.KubeDistro.Kubernetes.Tag = "v1.24.13-eks-1-24-14"
+			s.VersionsBundle.Velero = releasev1alpha1.VeleroBundle{
+				Velero:       releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/velero:v1.11.0"},
+				PluginForAWS: releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/velero-plugin-for-aws:v1.7.0"},
+				Kubectl:      releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/kubectl:v1.24.13"},
+				HelmChart:    releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/velero:4.0.2"},
+			}
+		}),
+	}
+}
+
+func (tt *installerTest) expectTemplate(err error) {
+	tt.helm.EXPECT().TemplateWithCRDs(tt.ctx, chartURI, chartVersion, velero.Namespace, gomock.Any(), "1.24").DoAndReturn(
+		func(_ context.Context, _, _, _ string, values interface{}, _ string) ([]byte, error) {
+			gotValues, marshalErr := yaml.Marshal(values)
+			tt.Expect(marshalErr).NotTo(HaveOccurred())
+			tt.Expect(string(gotValues)).To(Equal(wantValues))
+			return []byte(manifest), err
+		},
+	)
+}
+
+func TestInstallerInstallSuccess(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.expectTemplate(nil)
+	tt.k8s.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, []byte(namespace+"\n---\n"+manifest))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallNoBackup(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.Spec.Backup = nil
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestInstallerInstallBundleWithoutVelero(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.spec.Cluster.Spec.KubernetesVersion = anywherev1.Kube124
+	tt.spec.VersionsBundle.Velero = releasev1alpha1.VeleroBundle{}
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(MatchError(
+		"bundle for Kubernetes 1.24 doesn't include velero, backup requires a newer EKS-A version",
+	))
+}
+
+func TestInstallerInstallTemplateError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.expectTemplate(errors.New("chart not found"))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(MatchError("generating velero manifest: chart not found"))
+}
+
+func TestInstallerInstallApplyError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.expectTemplate(nil)
+	tt.k8s.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("connection refused"))
+
+	tt.Expect(tt.installer.Install(tt.ctx, tt.cluster, tt.spec)).To(MatchError("applying velero manifest: connection refused"))
+}
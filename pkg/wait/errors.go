@@ -0,0 +1,113 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Reason explains why a wait didn't succeed.
+type Reason string
+
+const (
+	// ReasonTimeout means the condition wasn't met before the timeout.
+	ReasonTimeout Reason = "Timeout"
+	// ReasonResourceFailed means the resource being waited for reported a terminal failure.
+	ReasonResourceFailed Reason = "ResourceFailed"
+	// ReasonCanceled means the context was canceled before the condition was met.
+	ReasonCanceled Reason = "Canceled"
+)
+
+// Error is returned when a wait doesn't succeed.
+type Error struct {
+	Reason Reason
+	// For describes what was being waited for.
+	For      string
+	Attempts int
+	Elapsed  time.Duration
+	// Err is the cause of the failure: the last error returned by the condition for
+	// timeouts, the failure for failed resources and the context error for cancellations.
+	Err error
+}
+
+func newError(reason Reason, waitingFor string, attempts int, elapsed time.Duration, err error) *Error {
+	return &Error{
+		Reason:   reason,
+		For:      waitingFor,
+		Attempts: attempts,
+		Elapsed:  elapsed,
+		Err:      err,
+	}
+}
+
+func (e *Error) Error() string {
+	var msg string
+	switch e.Reason {
+	case ReasonTimeout:
+		msg = fmt.Sprintf("timed out waiting for %s after %d attempts", e.For, e.Attempts)
+	case ReasonResourceFailed:
+		msg = fmt.Sprintf("%s failed", e.For)
+	default:
+		msg = fmt.Sprintf("waiting for %s canceled", e.For)
+	}
+
+	if e.Err == nil {
+		return msg
+	}
+
+	return fmt.Sprintf("%s: %v", msg, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is makes timeouts match context.DeadlineExceeded, like the errors returned
+// when waiting with a context deadline.
+func (e *Error) Is(target error) bool {
+	return e.Reason == ReasonTimeout && target == context.DeadlineExceeded
+}
+
+// IsTimeout returns true if err is a wait that timed out.
+func IsTimeout(err error) bool {
+	return hasReason(err, ReasonTimeout)
+}
+
+// IsResourceFailed returns true if err is a wait that stopped because the resource failed.
+func IsResourceFailed(err error) bool {
+	return hasReason(err, ReasonResourceFailed)
+}
+
+// IsCanceled returns true if err is a wait that stopped because its context was canceled.
+func IsCanceled(err error) bool {
+	return hasReason(err, ReasonCanceled)
+}
+
+func hasReason(err error, reason Reason) bool {
+	e := &Error{}
+	return errors.As(err, &e) && e.Reason == reason
+}
+
+// Failed marks an error returned by a ConditionFunc as terminal, stopping the wait.
+// It should be used when the resource reaches a state it won't recover from or
+// when the error won't go away by retrying.
+func Failed(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &failedError{err: err}
+}
+
+type failedError struct {
+	err error
+}
+
+func (e *failedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *failedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,5 @@
+package wait
+
+func SetRandom(w *Waiter, random func() float64) {
+	w.random = random
+}
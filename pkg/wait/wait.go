@@ -0,0 +1,276 @@
+// Package wait polls conditions until they are met, the timeout expires or
+// the resource being waited for reports a terminal failure.
+package wait
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const defaultInterval = time.Second
+
+// Status is the result of checking a condition.
+type Status struct {
+	// Done indicates the condition has been met.
+	Done bool
+	// Message describes the current state of the resource, e.g. "2/3 nodes ready".
+	// When it changes between checks the resource is progressing, so the polling
+	// interval is reset to its initial value.
+	Message string
+	// RetryAfter, if set, overrides the computed interval for the next check.
+	// It allows conditions to adapt the polling rate to the state of the resource.
+	RetryAfter time.Duration
+}
+
+// ConditionFunc checks if a condition has been met.
+// Errors are considered transient and the condition is checked again until the timeout,
+// unless they are marked with Failed.
+type ConditionFunc func(ctx context.Context) (Status, error)
+
+// Progress describes the state of a wait after a check that didn't succeed.
+type Progress struct {
+	// For describes what is being waited for.
+	For string
+	// Attempt is the number of checks performed so far, starting at 1.
+	Attempt int
+	// Elapsed is the time since the first check started.
+	Elapsed time.Duration
+	// Remaining is the time left until the timeout, 0 when the wait has no timeout.
+	Remaining time.Duration
+	// NextCheck is the time until the next check.
+	NextCheck time.Duration
+	// Message is the message in the Status returned by the last check.
+	Message string
+	// Err is the error returned by the last check, if any.
+	Err error
+}
+
+// ProgressFunc is called after every check that doesn't meet the condition.
+// It allows to report the progress of a wait, for example to update a CLI spinner.
+type ProgressFunc func(Progress)
+
+// Waiter polls conditions with a global timeout.
+type Waiter struct {
+	timeout       time.Duration
+	interval      time.Duration
+	maxInterval   time.Duration
+	backoffFactor float64
+	jitter        float64
+	progress      []ProgressFunc
+	random        func() float64
+}
+
+// Opt allows to customize a Waiter.
+type Opt func(*Waiter)
+
+// New returns a Waiter that waits at most timeout for a condition. A timeout of 0 means no timeout,
+// the wait only ends when the condition is met, fails or the context is canceled.
+// By default, conditions are checked every second.
+func New(timeout time.Duration, opts ...Opt) *Waiter {
+	w := &Waiter{
+		timeout:       timeout,
+		interval:      defaultInterval,
+		backoffFactor: 1,
+		random:        rand.Float64,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	if w.maxInterval < w.interval {
+		w.maxInterval = w.interval
+	}
+
+	return w
+}
+
+// WithInterval sets the initial time between checks.
+func WithInterval(interval time.Duration) Opt {
+	return func(w *Waiter) {
+		w.interval = interval
+	}
+}
+
+// WithBackoff multiplies the interval by factor after every check where the
+// resource doesn't make progress, up to maxInterval.
+func WithBackoff(factor float64, maxInterval time.Duration) Opt {
+	return func(w *Waiter) {
+		w.backoffFactor = factor
+		w.maxInterval = maxInterval
+	}
+}
+
+// WithJitter adds a random extra wait of up to fraction times the interval
+// before each check, to avoid many waiters hitting an API at the same time.
+func WithJitter(fraction float64) Opt {
+	return func(w *Waiter) {
+		w.jitter = fraction
+	}
+}
+
+// WithProgress registers a function to be called after every check that doesn't meet the condition.
+func WithProgress(f ProgressFunc) Opt {
+	return func(w *Waiter) {
+		w.progress = append(w.progress, f)
+	}
+}
+
+// For checks condition until it's met, returning an *Error when the timeout expires,
+// the context is canceled or the condition reports a failure.
+// The first check is performed immediately. The context passed to condition
+// expires with the timeout, so slow checks don't extend the wait.
+func (w *Waiter) For(ctx context.Context, waitingFor string, condition ConditionFunc) error {
+	start := time.Now()
+	checkCtx := ctx
+	if w.timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, w.timeout)
+		defer cancel()
+	}
+
+	interval := w.interval
+	previousMessage := ""
+	for attempt := 1; ; attempt++ {
+		if ctx.Err() != nil {
+			return newError(ReasonCanceled, waitingFor, attempt-1, time.Since(start), ctx.Err())
+		}
+
+		status, err := condition(checkCtx)
+		if err == nil && status.Done {
+			return nil
+		}
+
+		if err := w.checkError(ctx, waitingFor, attempt, start, err); err != nil {
+			return err
+		}
+
+		interval = w.nextInterval(interval, attempt, status, previousMessage)
+		previousMessage = status.Message
+		elapsed := time.Since(start)
+		next := w.withJitter(interval)
+		var remaining time.Duration
+		if w.timeout > 0 {
+			remaining = w.timeout - elapsed
+		}
+		w.report(Progress{
+			For:       waitingFor,
+			Attempt:   attempt,
+			Elapsed:   elapsed,
+			Remaining: remaining,
+			NextCheck: next,
+			Message:   status.Message,
+			Err:       err,
+		})
+
+		if w.timeout > 0 {
+			if remaining <= 0 {
+				return newError(ReasonTimeout, waitingFor, attempt, elapsed, lastError(err, status))
+			}
+			if next > remaining {
+				next = remaining
+			}
+		}
+		if err := sleep(ctx, next); err != nil {
+			return newError(ReasonCanceled, waitingFor, attempt, time.Since(start), err)
+		}
+	}
+}
+
+// checkError returns an *Error if the error returned by a check should stop the wait.
+// Errors after the timeout expired are most likely caused by it, so they are reported as timeouts.
+func (w *Waiter) checkError(ctx context.Context, waitingFor string, attempt int, start time.Time, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	elapsed := time.Since(start)
+	if ctx.Err() != nil {
+		return newError(ReasonCanceled, waitingFor, attempt, elapsed, ctx.Err())
+	}
+	if w.timeout > 0 && elapsed >= w.timeout {
+		return newError(ReasonTimeout, waitingFor, attempt, elapsed, err)
+	}
+
+	var failed *failedError
+	if errors.As(err, &failed) {
+		return newError(ReasonResourceFailed, waitingFor, attempt, elapsed, failed.err)
+	}
+
+	return nil
+}
+
+func (w *Waiter) nextInterval(current time.Duration, attempt int, status Status, previousMessage string) time.Duration {
+	switch {
+	case status.RetryAfter > 0:
+		return status.RetryAfter
+	case attempt == 1:
+		return w.interval
+	case status.Message != previousMessage:
+		return w.interval
+	}
+
+	next := time.Duration(float64(current) * w.backoffFactor)
+	if next > w.maxInterval {
+		return w.maxInterval
+	}
+	if next < w.interval {
+		return w.interval
+	}
+
+	return next
+}
+
+func (w *Waiter) withJitter(interval time.Duration) time.Duration {
+	if w.jitter <= 0 {
+		return interval
+	}
+
+	return interval + time.Duration(w.random()*w.jitter*float64(interval))
+}
+
+func (w *Waiter) report(p Progress) {
+	for _, f := range w.progress {
+		f(p)
+	}
+}
+
+// LogProgress returns a ProgressFunc that logs the progress of a wait with the given verbosity.
+func LogProgress(level int) ProgressFunc {
+	return func(p Progress) {
+		keysAndValues := []interface{}{"for", p.For, "attempt", p.Attempt, "elapsed", p.Elapsed.Round(time.Second).String()}
+		if p.Message != "" {
+			keysAndValues = append(keysAndValues, "status", p.Message)
+		}
+		if p.Err != nil {
+			keysAndValues = append(keysAndValues, "error", p.Err.Error())
+		}
+		logger.V(level).Info("Waiting", keysAndValues...)
+	}
+}
+
+func lastError(err error, status Status) error {
+	if err != nil {
+		return err
+	}
+	if status.Message != "" {
+		return errors.New(status.Message)
+	}
+
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,235 @@
+package wait_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/wait"
+)
+
+func TestWaiterForSucceedsImmediately(t *testing.T) {
+	g := NewWithT(t)
+	calls := 0
+	w := wait.New(time.Minute)
+
+	err := w.For(context.Background(), "resource", func(ctx context.Context) (wait.Status, error) {
+		calls++
+		return wait.Status{Done: true}, nil
+	})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(calls).To(Equal(1))
+}
+
+func TestWaiterForRetriesErrorsAndPendingStatus(t *testing.T) {
+	g := NewWithT(t)
+	calls := 0
+	w := wait.New(time.Minute, wait.WithInterval(time.Millisecond))
+
+	err := w.For(context.Background(), "resource", func(ctx context.Context) (wait.Status, error) {
+		calls++
+		switch calls {
+		case 1:
+			return wait.Status{}, errors.New("transient error")
+		case 2:
+			return wait.Status{Message: "not ready"}, nil
+		default:
+			return wait.Status{Done: true}, nil
+		}
+	})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(calls).To(Equal(3))
+}
+
+func TestWaiterForTimeout(t *testing.T) {
+	g := NewWithT(t)
+	w := wait.New(5*time.Millisecond, wait.WithInterval(time.Millisecond))
+
+	err := w.For(context.Background(), "nodes", func(ctx context.Context) (wait.Status, error) {
+		return wait.Status{}, errors.New("nodes not ready")
+	})
+
+	g.Expect(wait.IsTimeout(err)).To(BeTrue(), "error should be a timeout, got %v", err)
+	g.Expect(err).To(MatchError(ContainSubstring("timed out waiting for nodes after")))
+	g.Expect(err).To(MatchError(ContainSubstring("nodes not ready")))
+}
+
+func TestWaiterForNoTimeout(t *testing.T) {
+	g := NewWithT(t)
+	calls := 0
+	w := wait.New(0, wait.WithInterval(time.Millisecond))
+
+	err := w.For(context.Background(), "resource", func(ctx context.Context) (wait.Status, error) {
+		calls++
+		if calls < 5 {
+			return wait.Status{Message: "not ready"}, nil
+		}
+		return wait.Status{Done: true}, nil
+	})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(calls).To(Equal(5))
+}
+
+func TestWaiterForTimeoutUsesLastStatusMessage(t *testing.T) {
+	g := NewWithT(t)
+	w := wait.New(5*time.Millisecond, wait.WithInterval(time.Millisecond))
+
+	err := w.For(context.Background(), "nodes", func(ctx context.Context) (wait.Status, error) {
+		return wait.Status{Message: "1/3 nodes ready"}, nil
+	})
+
+	g.Expect(wait.IsTimeout(err)).To(BeTrue(), "error should be a timeout, got %v", err)
+	g.Expect(err).To(MatchError(HaveSuffix("attempts: 1/3 nodes ready")))
+}
+
+func TestWaiterForResourceFailed(t *testing.T) {
+	g := NewWithT(t)
+	calls := 0
+	failure := errors.New("machine provisioning failed")
+	w := wait.New(time.Minute, wait.WithInterval(time.Millisecond))
+
+	err := w.For(context.Background(), "machine", func(ctx context.Context) (wait.Status, error) {
+		calls++
+		return wait.Status{}, wait.Failed(failure)
+	})
+
+	g.Expect(calls).To(Equal(1))
+	g.Expect(wait.IsResourceFailed(err)).To(BeTrue(), "error should be a resource failure, got %v", err)
+	g.Expect(wait.IsTimeout(err)).To(BeFalse())
+	g.Expect(errors.Is(err, failure)).To(BeTrue())
+	g.Expect(err).To(MatchError("machine failed: machine provisioning failed"))
+}
+
+func TestWaiterForCanceled(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	w := wait.New(time.Minute, wait.WithInterval(time.Hour))
+
+	err := w.For(ctx, "resource", func(ctx context.Context) (wait.Status, error) {
+		cancel()
+		return wait.Status{}, nil
+	})
+
+	g.Expect(wait.IsCanceled(err)).To(BeTrue(), "error should be a cancellation, got %v", err)
+	g.Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+}
+
+func TestWaiterForProgress(t *testing.T) {
+	g := NewWithT(t)
+	calls := 0
+	progress := []wait.Progress{}
+	w := wait.New(time.Minute, wait.WithInterval(time.Millisecond), wait.WithProgress(func(p wait.Progress) {
+		progress = append(progress, p)
+	}))
+
+	err := w.For(context.Background(), "nodes", func(ctx context.Context) (wait.Status, error) {
+		calls++
+		if calls == 3 {
+			return wait.Status{Done: true}, nil
+		}
+		return wait.Status{Message: fmt.Sprintf("%d/3 nodes ready", calls)}, nil
+	})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(progress).To(HaveLen(2))
+	g.Expect(progress[0].For).To(Equal("nodes"))
+	g.Expect(progress[0].Attempt).To(Equal(1))
+	g.Expect(progress[0].Message).To(Equal("1/3 nodes ready"))
+	g.Expect(progress[1].Attempt).To(Equal(2))
+	g.Expect(progress[1].Message).To(Equal("2/3 nodes ready"))
+	g.Expect(progress[1].Remaining).To(BeNumerically("<", time.Minute))
+}
+
+func TestWaiterForBackoffResetsOnProgress(t *testing.T) {
+	g := NewWithT(t)
+	calls := 0
+	messages := []string{"0/2 ready", "0/2 ready", "0/2 ready", "1/2 ready", "1/2 ready"}
+	nextChecks := []time.Duration{}
+	w := wait.New(time.Minute,
+		wait.WithInterval(time.Millisecond),
+		wait.WithBackoff(2, 3*time.Millisecond),
+		wait.WithProgress(func(p wait.Progress) {
+			nextChecks = append(nextChecks, p.NextCheck)
+		}),
+	)
+
+	err := w.For(context.Background(), "machines", func(ctx context.Context) (wait.Status, error) {
+		if calls == len(messages) {
+			return wait.Status{Done: true}, nil
+		}
+		calls++
+		return wait.Status{Message: messages[calls-1]}, nil
+	})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(nextChecks).To(Equal([]time.Duration{
+		time.Millisecond,
+		2 * time.Millisecond,
+		3 * time.Millisecond,
+		time.Millisecond,
+		2 * time.Millisecond,
+	}))
+}
+
+func TestWaiterForRetryAfter(t *testing.T) {
+	g := NewWithT(t)
+	calls := 0
+	nextChecks := []time.Duration{}
+	w := wait.New(time.Minute, wait.WithInterval(time.Hour), wait.WithProgress(func(p wait.Progress) {
+		nextChecks = append(nextChecks, p.NextCheck)
+	}))
+
+	err := w.For(context.Background(), "resource", func(ctx context.Context) (wait.Status, error) {
+		calls++
+		if calls == 2 {
+			return wait.Status{Done: true}, nil
+		}
+		return wait.Status{RetryAfter: 2 * time.Millisecond}, nil
+	})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(nextChecks).To(Equal([]time.Duration{2 * time.Millisecond}))
+}
+
+func TestWaiterForJitter(t *testing.T) {
+	g := NewWithT(t)
+	calls := 0
+	nextChecks := []time.Duration{}
+	w := wait.New(time.Minute, wait.WithInterval(10*time.Millisecond), wait.WithJitter(0.5), wait.WithProgress(func(p wait.Progress) {
+		nextChecks = append(nextChecks, p.NextCheck)
+	}))
+	wait.SetRandom(w, func() float64 { return 0.5 })
+
+	err := w.For(context.Background(), "resource", func(ctx context.Context) (wait.Status, error) {
+		calls++
+		return wait.Status{Done: calls == 2}, nil
+	})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(nextChecks).To(Equal([]time.Duration{12500 * time.Microsecond}))
+}
+
+func TestFailedNil(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(wait.Failed(nil)).To(BeNil())
+}
+
+func TestWaiterForSlowCheckTimeout(t *testing.T) {
+	g := NewWithT(t)
+	w := wait.New(5 * time.Millisecond)
+
+	err := w.For(context.Background(), "resource", func(ctx context.Context) (wait.Status, error) {
+		<-ctx.Done()
+		return wait.Status{}, wait.Failed(ctx.Err())
+	})
+
+	g.Expect(wait.IsTimeout(err)).To(BeTrue(), "error should be a timeout, got %v", err)
+	g.Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+}
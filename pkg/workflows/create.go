@@ -24,12 +24,15 @@ type Create struct {
 	writer           filewriter.FileWriter
 	eksdInstaller    interfaces.EksdInstaller
 	packageInstaller interfaces.PackageInstaller
+	postCreate       interfaces.PostCreateValidator
 }
 
+// NewCreate constructs a new Create workflow. postCreate is optional, the post-create
+// validations are skipped when it's nil.
 func NewCreate(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
 	clusterManager interfaces.ClusterManager, gitOpsManager interfaces.GitOpsManager,
 	writer filewriter.FileWriter, eksdInstaller interfaces.EksdInstaller,
-	packageInstaller interfaces.PackageInstaller,
+	packageInstaller interfaces.PackageInstaller, postCreate interfaces.PostCreateValidator,
 ) *Create {
 	return &Create{
 		bootstrapper:     bootstrapper,
@@ -39,6 +42,7 @@ func NewCreate(bootstrapper interfaces.Bootstrapper, provider providers.Provider
 		writer:           writer,
 		eksdInstaller:    eksdInstaller,
 		packageInstaller: packageInstaller,
+		postCreate:       postCreate,
 	}
 }
 
@@ -51,15 +55,16 @@ func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator i
 		}
 	}
 	commandContext := &task.CommandContext{
-		Bootstrapper:     c.bootstrapper,
-		Provider:         c.provider,
-		ClusterManager:   c.clusterManager,
-		GitOpsManager:    c.gitOpsManager,
-		ClusterSpec:      clusterSpec,
-		Writer:           c.writer,
-		Validations:      validator,
-		EksdInstaller:    c.eksdInstaller,
-		PackageInstaller: c.packageInstaller,
+		Bootstrapper:        c.bootstrapper,
+		Provider:            c.provider,
+		ClusterManager:      c.clusterManager,
+		GitOpsManager:       c.gitOpsManager,
+		ClusterSpec:         clusterSpec,
+		Writer:              c.writer,
+		Validations:         validator,
+		EksdInstaller:       c.eksdInstaller,
+		PackageInstaller:    c.packageInstaller,
+		PostCreateValidator: c.postCreate,
 	}
 
 	if clusterSpec.ManagementCluster != nil {
@@ -95,6 +100,8 @@ type DeleteBootstrapClusterTask struct {
 
 type InstallCuratedPackagesTask struct{}
 
+type PostCreateValidationsTask struct{}
+
 // CreateBootStrapClusterTask implementation
 
 func (s *CreateBootStrapClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -254,6 +261,24 @@ func (s *CreateWorkloadClusterTask) Run(ctx context.Context, commandContext *tas
 		return &CollectDiagnosticsTask{}
 	}
 
+	err = commandContext.ClusterManager.TagClusterResources(ctx, commandContext.BootstrapCluster, commandContext.ClusterSpec, commandContext.Provider)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
+	err = commandContext.ClusterManager.InstallBackup(ctx, workloadCluster, commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
+	err = commandContext.ClusterManager.InstallOSUpdates(ctx, workloadCluster, commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
 	if !commandContext.BootstrapCluster.ExistingManagement {
 		logger.Info("Creating EKS-A namespace")
 		err = commandContext.ClusterManager.CreateEKSANamespace(ctx, workloadCluster)
@@ -478,7 +503,7 @@ func (s *DeleteBootstrapClusterTask) Name() string {
 
 func (cp *InstallCuratedPackagesTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	commandContext.PackageInstaller.InstallCuratedPackages(ctx)
-	return nil
+	return &PostCreateValidationsTask{}
 }
 
 func (cp *InstallCuratedPackagesTask) Name() string {
@@ -492,3 +517,33 @@ func (s *InstallCuratedPackagesTask) Restore(ctx context.Context, commandContext
 func (s *InstallCuratedPackagesTask) Checkpoint() *task.CompletedTask {
 	return nil
 }
+
+// PostCreateValidationsTask implementation
+
+func (s *PostCreateValidationsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if commandContext.PostCreateValidator == nil || commandContext.OriginalError != nil {
+		return nil
+	}
+
+	managementCluster := commandContext.WorkloadCluster
+	if commandContext.BootstrapCluster.ExistingManagement {
+		managementCluster = commandContext.BootstrapCluster
+	}
+	err := commandContext.PostCreateValidator.Validate(ctx, commandContext.ClusterSpec, commandContext.WorkloadCluster, managementCluster)
+	if err != nil {
+		commandContext.SetError(err)
+	}
+	return nil
+}
+
+func (s *PostCreateValidationsTask) Name() string {
+	return "post-create-validations"
+}
+
+func (s *PostCreateValidationsTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return nil, nil
+}
+
+func (s *PostCreateValidationsTask) Checkpoint() *task.CompletedTask {
+	return nil
+}
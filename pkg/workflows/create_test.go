@@ -24,6 +24,7 @@ import (
 type createTestSetup struct {
 	t                *testing.T
 	packageInstaller *mocks.MockPackageInstaller
+	postCreate       *mocks.MockPostCreateValidator
 	bootstrapper     *mocks.MockBootstrapper
 	clusterManager   *mocks.MockClusterManager
 	gitOpsManager    *mocks.MockGitOpsManager
@@ -50,10 +51,11 @@ func newCreateTest(t *testing.T) *createTestSetup {
 	writer := writermocks.NewMockFileWriter(mockCtrl)
 	eksd := mocks.NewMockEksdInstaller(mockCtrl)
 	packageInstaller := mocks.NewMockPackageInstaller(mockCtrl)
+	postCreate := mocks.NewMockPostCreateValidator(mockCtrl)
 
 	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
 	machineConfigs := []providers.MachineConfig{&v1alpha1.VSphereMachineConfig{}}
-	workflow := workflows.NewCreate(bootstrapper, provider, clusterManager, gitOpsManager, writer, eksd, packageInstaller)
+	workflow := workflows.NewCreate(bootstrapper, provider, clusterManager, gitOpsManager, writer, eksd, packageInstaller, postCreate)
 	validator := mocks.NewMockValidator(mockCtrl)
 
 	return &createTestSetup{
@@ -66,6 +68,7 @@ func newCreateTest(t *testing.T) *createTestSetup {
 		validator:        validator,
 		eksd:             eksd,
 		packageInstaller: packageInstaller,
+		postCreate:       postCreate,
 		datacenterConfig: datacenterConfig,
 		machineConfigs:   machineConfigs,
 		workflow:         workflow,
@@ -114,6 +117,15 @@ func (c *createTestSetup) expectCreateWorkload() {
 		c.clusterManager.EXPECT().InstallStorageClass(
 			c.ctx, c.workloadCluster, c.provider,
 		),
+		c.clusterManager.EXPECT().TagClusterResources(
+			c.ctx, c.bootstrapCluster, c.clusterSpec, c.provider,
+		),
+		c.clusterManager.EXPECT().InstallBackup(
+			c.ctx, c.workloadCluster, c.clusterSpec,
+		),
+		c.clusterManager.EXPECT().InstallOSUpdates(
+			c.ctx, c.workloadCluster, c.clusterSpec,
+		),
 		c.clusterManager.EXPECT().CreateEKSANamespace(
 			c.ctx, c.workloadCluster,
 		),
@@ -144,6 +156,15 @@ func (c *createTestSetup) expectCreateWorkloadSkipCAPI() {
 		c.clusterManager.EXPECT().InstallStorageClass(
 			c.ctx, c.workloadCluster, c.provider,
 		),
+		c.clusterManager.EXPECT().TagClusterResources(
+			c.ctx, c.bootstrapCluster, c.clusterSpec, c.provider,
+		),
+		c.clusterManager.EXPECT().InstallBackup(
+			c.ctx, c.workloadCluster, c.clusterSpec,
+		),
+		c.clusterManager.EXPECT().InstallOSUpdates(
+			c.ctx, c.workloadCluster, c.clusterSpec,
+		),
 	)
 	c.clusterManager.EXPECT().InstallCAPI(
 		c.ctx, c.clusterSpec, c.workloadCluster, c.provider,
@@ -211,6 +232,14 @@ func (c *createTestSetup) expectCuratedPackagesInstallation() {
 	c.packageInstaller.EXPECT().InstallCuratedPackages(c.ctx).Times(1)
 }
 
+func (c *createTestSetup) expectPostCreateValidations() *gomock.Call {
+	managementCluster := c.workloadCluster
+	if c.bootstrapCluster.ExistingManagement {
+		managementCluster = c.bootstrapCluster
+	}
+	return c.postCreate.EXPECT().Validate(c.ctx, c.clusterSpec, c.workloadCluster, managementCluster)
+}
+
 func (c *createTestSetup) expectInstallGitOpsManager() {
 	gomock.InOrder(
 		c.provider.EXPECT().DatacenterConfig(c.clusterSpec).Return(c.datacenterConfig),
@@ -268,6 +297,7 @@ func TestCreateRunSuccess(t *testing.T) {
 	test.expectInstallMHC()
 	test.expectPreflightValidationsToPass()
 	test.expectCuratedPackagesInstallation()
+	test.expectPostCreateValidations()
 
 	err := test.run()
 	if err != nil {
@@ -275,6 +305,52 @@ func TestCreateRunSuccess(t *testing.T) {
 	}
 }
 
+func TestCreateRunPostCreateValidationsFail(t *testing.T) {
+	wantError := errors.New("1 post-create validations failed")
+	test := newCreateTest(t)
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectInstallResourcesOnManagementTask()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallGitOpsManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+	test.expectCuratedPackagesInstallation()
+	test.expectPostCreateValidations().Return(wantError)
+	test.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", test.clusterSpec.Cluster.Name), gomock.Any())
+
+	if err := test.run(); err != wantError {
+		t.Fatalf("Create.Run() err = %v, want err = %v", err, wantError)
+	}
+}
+
+func TestCreateRunSkipPostCreateValidations(t *testing.T) {
+	test := newCreateTest(t)
+	test.workflow = workflows.NewCreate(test.bootstrapper, test.provider, test.clusterManager, test.gitOpsManager, test.writer, test.eksd, test.packageInstaller, nil)
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectInstallResourcesOnManagementTask()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallGitOpsManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+	test.expectCuratedPackagesInstallation()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
 func TestCreateRunAWSIamConfigFail(t *testing.T) {
 	wantError := errors.New("test error")
 	test := newCreateTest(t)
@@ -315,6 +391,7 @@ func TestCreateRunAWSIamConfigSuccess(t *testing.T) {
 	test.expectInstallMHC()
 	test.expectPreflightValidationsToPass()
 	test.expectCuratedPackagesInstallation()
+	test.expectPostCreateValidations()
 
 	err := test.run()
 	if err != nil {
@@ -338,6 +415,7 @@ func TestCreateRunSuccessForceCleanup(t *testing.T) {
 	test.expectInstallMHC()
 	test.expectPreflightValidationsToPass()
 	test.expectCuratedPackagesInstallation()
+	test.expectPostCreateValidations()
 
 	err := test.run()
 	if err != nil {
@@ -369,6 +447,7 @@ func TestCreateWorkloadClusterRunSuccess(t *testing.T) {
 	test.expectInstallMHC()
 	test.expectPreflightValidationsToPass()
 	test.expectCuratedPackagesInstallation()
+	test.expectPostCreateValidations()
 
 	if err := test.run(); err != nil {
 		t.Fatalf("Create.Run() err = %v, want err = nil", err)
@@ -403,6 +482,7 @@ func TestCreateWorkloadClusterRunAWSIamConfigSuccess(t *testing.T) {
 	test.expectInstallMHC()
 	test.expectPreflightValidationsToPass()
 	test.expectCuratedPackagesInstallation()
+	test.expectPostCreateValidations()
 
 	if err := test.run(); err != nil {
 		t.Fatalf("Create.Run() err = %v, want err = nil", err)
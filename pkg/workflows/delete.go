@@ -2,6 +2,8 @@ package workflows
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -34,7 +36,10 @@ func NewDelete(bootstrapper interfaces.Bootstrapper, provider providers.Provider
 	}
 }
 
-func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, forceCleanup bool, kubeconfig string) error {
+// Run deletes the cluster. When force is set and the CAPI cluster is not found in the management cluster,
+// the infrastructure of the cluster is removed through the provider instead, which requires
+// confirmOrphanCleanup since it destroys the machines of the cluster.
+func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, forceCleanup, force, confirmOrphanCleanup bool, kubeconfig string) error {
 	if forceCleanup {
 		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, &types.Cluster{
 			Name: workloadCluster.Name,
@@ -56,10 +61,20 @@ func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, cluste
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&setupAndValidate{}, c.writer).RunTask(ctx, commandContext)
+	return task.NewTaskRunner(&setupAndValidate{force: force, confirmOrphanCleanup: confirmOrphanCleanup}, c.writer).RunTask(ctx, commandContext)
 }
 
-type setupAndValidate struct{}
+type setupAndValidate struct {
+	force                bool
+	confirmOrphanCleanup bool
+}
+
+type detectOrphanedCluster struct {
+	force                bool
+	confirmOrphanCleanup bool
+}
+
+type cleanupOrphanedResources struct{}
 
 type createManagementCluster struct{}
 
@@ -73,6 +88,10 @@ type cleanupGitRepo struct{}
 
 type deletePackageResources struct{}
 
+type cleanupClusterLeftovers struct{}
+
+type auditClusterLeftovers struct{}
+
 type deleteManagementCluster struct{}
 
 func (s *setupAndValidate) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -82,7 +101,7 @@ func (s *setupAndValidate) Run(ctx context.Context, commandContext *task.Command
 		commandContext.SetError(err)
 		return nil
 	}
-	return &createManagementCluster{}
+	return &detectOrphanedCluster{force: s.force, confirmOrphanCleanup: s.confirmOrphanCleanup}
 }
 
 func (s *setupAndValidate) Name() string {
@@ -97,6 +116,87 @@ func (s *setupAndValidate) Checkpoint() *task.CompletedTask {
 	return nil
 }
 
+func (s *detectOrphanedCluster) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	clusterName := commandContext.WorkloadCluster.Name
+	exists, err := commandContext.ClusterManager.CAPIClusterExists(ctx, capiManagementCluster(commandContext), clusterName)
+	switch {
+	case err == nil && exists:
+		return &createManagementCluster{}
+	case err != nil && s.force:
+		// Only a cluster that is not found is orphaned, an unreachable management cluster is not.
+		commandContext.SetError(fmt.Errorf("checking for the CAPI cluster %s, its infrastructure is only removed when it's not found: %v", clusterName, err))
+		return nil
+	case err != nil:
+		// Keep going, the regular deletion surfaces its own errors if the cluster is really unreachable.
+		logger.V(3).Info("Failed checking for the CAPI cluster", "error", err)
+		return &createManagementCluster{}
+	case !s.force:
+		commandContext.SetError(fmt.Errorf("CAPI cluster %s not found, its infrastructure can only be removed with --force", clusterName))
+		return nil
+	case !s.confirmOrphanCleanup:
+		commandContext.SetError(fmt.Errorf("CAPI cluster %s not found, removing its infrastructure destroys its machines, re-run with --confirm-orphan-cleanup to proceed", clusterName))
+		return nil
+	}
+
+	logger.Info("CAPI cluster not found, treating it as orphaned", "cluster", clusterName)
+
+	return &cleanupOrphanedResources{}
+}
+
+func (s *detectOrphanedCluster) Name() string {
+	return "detect-orphaned-cluster"
+}
+
+func (s *detectOrphanedCluster) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return nil, nil
+}
+
+func (s *detectOrphanedCluster) Checkpoint() *task.CompletedTask {
+	return nil
+}
+
+func (s *cleanupOrphanedResources) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Cleaning up orphaned cluster infrastructure")
+	var managementCluster *types.Cluster
+	if commandContext.BootstrapCluster != nil && commandContext.BootstrapCluster.ExistingManagement {
+		managementCluster = commandContext.BootstrapCluster
+	}
+
+	err := commandContext.Provider.CleanupOrphanedResources(ctx, managementCluster, commandContext.ClusterSpec)
+	if errors.Is(err, providers.ErrOrphanCleanupNotSupported) {
+		err = fmt.Errorf("%s provider: %v", commandContext.Provider.Name(), err)
+	}
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	if managementCluster != nil {
+		return &cleanupClusterLeftovers{}
+	}
+	return &deleteManagementCluster{}
+}
+
+func (s *cleanupOrphanedResources) Name() string {
+	return "cleanup-orphaned-resources"
+}
+
+func (s *cleanupOrphanedResources) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return nil, nil
+}
+
+func (s *cleanupOrphanedResources) Checkpoint() *task.CompletedTask {
+	return nil
+}
+
+// capiManagementCluster returns the cluster holding the CAPI objects of the cluster being deleted.
+func capiManagementCluster(commandContext *task.CommandContext) *types.Cluster {
+	if commandContext.BootstrapCluster != nil && commandContext.BootstrapCluster.ExistingManagement {
+		return commandContext.BootstrapCluster
+	}
+	return commandContext.WorkloadCluster
+}
+
 func (s *createManagementCluster) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if commandContext.BootstrapCluster != nil && commandContext.BootstrapCluster.ExistingManagement {
 		return &deleteWorkloadCluster{}
@@ -242,8 +342,7 @@ func (s *deletePackageResources) Run(ctx context.Context, commandContext *task.C
 		logger.Info("Problem delete package resources: %v", err)
 	}
 
-	// A bit odd to traverse to this state here, but it is the terminal state
-	return &deleteManagementCluster{}
+	return &cleanupClusterLeftovers{}
 }
 
 func (s *deletePackageResources) Name() string {
@@ -258,6 +357,61 @@ func (s *deletePackageResources) Checkpoint() *task.CompletedTask {
 	return nil
 }
 
+func (s *cleanupClusterLeftovers) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Cleaning up cluster secrets and namespaces", "clusterName", commandContext.WorkloadCluster.Name)
+	err := commandContext.ClusterManager.DeleteClusterLeftovers(ctx, leftoversManagementCluster(commandContext), commandContext.WorkloadCluster.Name)
+	if err != nil {
+		logger.Info("Problem cleaning up cluster secrets and namespaces", "error", err)
+	}
+
+	return &auditClusterLeftovers{}
+}
+
+func (s *cleanupClusterLeftovers) Name() string {
+	return "cleanup-cluster-leftovers"
+}
+
+func (s *cleanupClusterLeftovers) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return nil, nil
+}
+
+func (s *cleanupClusterLeftovers) Checkpoint() *task.CompletedTask {
+	return nil
+}
+
+func (s *auditClusterLeftovers) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	leftovers, err := commandContext.ClusterManager.AuditClusterLeftovers(ctx, leftoversManagementCluster(commandContext), commandContext.WorkloadCluster.Name)
+	if err != nil {
+		logger.Info("Warning: could not verify the cluster secrets and namespaces were removed", "error", err)
+	}
+	for _, l := range leftovers {
+		logger.Info("Warning: cluster leftover could not be removed, delete it manually", "object", l.String())
+	}
+
+	// A bit odd to traverse to this state here, but it is the terminal state
+	return &deleteManagementCluster{}
+}
+
+func (s *auditClusterLeftovers) Name() string {
+	return "audit-cluster-leftovers"
+}
+
+func (s *auditClusterLeftovers) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return nil, nil
+}
+
+func (s *auditClusterLeftovers) Checkpoint() *task.CompletedTask {
+	return nil
+}
+
+// leftoversManagementCluster returns the long lived management cluster holding the secrets of the cluster being deleted.
+func leftoversManagementCluster(commandContext *task.CommandContext) *types.Cluster {
+	if commandContext.ManagementCluster != nil {
+		return commandContext.ManagementCluster
+	}
+	return commandContext.BootstrapCluster
+}
+
 func (s *deleteManagementCluster) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if commandContext.OriginalError != nil {
 		collector := &CollectMgmtClusterDiagnosticsTask{}
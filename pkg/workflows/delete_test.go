@@ -3,6 +3,7 @@ package workflows_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -10,7 +11,9 @@ import (
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/providers"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/workflows"
@@ -28,6 +31,8 @@ type deleteTestSetup struct {
 	ctx              context.Context
 	clusterSpec      *cluster.Spec
 	forceCleanup     bool
+	force            bool
+	confirmOrphan    bool
 	bootstrapCluster *types.Cluster
 	workloadCluster  *types.Cluster
 }
@@ -60,6 +65,10 @@ func (c *deleteTestSetup) expectSetup() {
 	c.provider.EXPECT().SetupAndValidateDeleteCluster(c.ctx, c.workloadCluster, c.clusterSpec)
 }
 
+func (c *deleteTestSetup) expectCAPIClusterExists(exists bool, err error) {
+	c.clusterManager.EXPECT().CAPIClusterExists(c.ctx, gomock.Any(), c.workloadCluster.Name).Return(exists, err)
+}
+
 func (c *deleteTestSetup) expectCreateBootstrap() {
 	opts := []bootstrapper.BootstrapClusterOption{
 		bootstrapper.WithExtraDockerMounts(),
@@ -97,6 +106,13 @@ func (c *deleteTestSetup) expectNotToDeletePackageResources() {
 	c.clusterManager.EXPECT().DeletePackageResources(c.ctx, c.clusterSpec.ManagementCluster, gomock.Any()).Return(nil).Times(0)
 }
 
+func (c *deleteTestSetup) expectCleanupClusterLeftovers() {
+	gomock.InOrder(
+		c.clusterManager.EXPECT().DeleteClusterLeftovers(c.ctx, c.clusterSpec.ManagementCluster, c.workloadCluster.Name),
+		c.clusterManager.EXPECT().AuditClusterLeftovers(c.ctx, c.clusterSpec.ManagementCluster, c.workloadCluster.Name),
+	)
+}
+
 func (c *deleteTestSetup) expectDeleteBootstrap() {
 	gomock.InOrder(
 		c.bootstrapper.EXPECT().DeleteBootstrapCluster(
@@ -144,12 +160,13 @@ func (c *deleteTestSetup) expectNotToMoveManagement() {
 
 func (c *deleteTestSetup) run() error {
 	// ctx context.Context, workloadCluster *types.Cluster, forceCleanup bool
-	return c.workflow.Run(c.ctx, c.workloadCluster, c.clusterSpec, c.forceCleanup, "")
+	return c.workflow.Run(c.ctx, c.workloadCluster, c.clusterSpec, c.forceCleanup, c.force, c.confirmOrphan, "")
 }
 
 func TestDeleteRunSuccess(t *testing.T) {
 	test := newDeleteTest(t)
 	test.expectSetup()
+	test.expectCAPIClusterExists(true, nil)
 	test.expectCreateBootstrap()
 	test.expectDeleteWorkload(test.bootstrapCluster)
 	test.expectCleanupGitRepo()
@@ -166,6 +183,7 @@ func TestDeleteRunSuccess(t *testing.T) {
 func TestDeleteWorkloadRunSuccess(t *testing.T) {
 	test := newDeleteTest(t)
 	test.expectSetup()
+	test.expectCAPIClusterExists(true, nil)
 	test.expectNotToCreateBootstrap()
 	test.clusterSpec.ManagementCluster = &types.Cluster{
 		Name:               "management-cluster",
@@ -177,6 +195,7 @@ func TestDeleteWorkloadRunSuccess(t *testing.T) {
 	test.expectCleanupGitRepo()
 	test.expectNotToMoveManagement()
 	test.expectDeletePackageResources()
+	test.expectCleanupClusterLeftovers()
 	test.expectNotToDeleteBootstrap()
 
 	err := test.run()
@@ -188,6 +207,7 @@ func TestDeleteWorkloadRunSuccess(t *testing.T) {
 func TestDeleteWorkloadDeletePackageResourceError(t *testing.T) {
 	test := newDeleteTest(t)
 	test.expectSetup()
+	test.expectCAPIClusterExists(true, nil)
 	test.expectNotToCreateBootstrap()
 	test.clusterSpec.ManagementCluster = &types.Cluster{
 		Name:               "management-cluster",
@@ -199,6 +219,36 @@ func TestDeleteWorkloadDeletePackageResourceError(t *testing.T) {
 	test.expectCleanupGitRepo()
 	test.expectNotToMoveManagement()
 	test.clusterManager.EXPECT().DeletePackageResources(test.ctx, test.clusterSpec.ManagementCluster, gomock.Any()).Return(fmt.Errorf("boom"))
+	test.expectCleanupClusterLeftovers()
+	test.expectNotToDeleteBootstrap()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestDeleteWorkloadRunClusterLeftoversRemain(t *testing.T) {
+	test := newDeleteTest(t)
+	test.expectSetup()
+	test.expectCAPIClusterExists(true, nil)
+	test.expectNotToCreateBootstrap()
+	test.clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
+	}
+	test.clusterSpec.Cluster.SetManagedBy(test.clusterSpec.ManagementCluster.Name)
+	test.expectDeleteWorkload(test.clusterSpec.ManagementCluster)
+	test.expectCleanupGitRepo()
+	test.expectNotToMoveManagement()
+	test.expectDeletePackageResources()
+	gomock.InOrder(
+		test.clusterManager.EXPECT().DeleteClusterLeftovers(test.ctx, test.clusterSpec.ManagementCluster, test.workloadCluster.Name).Return(fmt.Errorf("forbidden")),
+		test.clusterManager.EXPECT().AuditClusterLeftovers(test.ctx, test.clusterSpec.ManagementCluster, test.workloadCluster.Name).Return(
+			[]clustermanager.ClusterLeftover{{ResourceType: "secret", Name: "workload-kubeconfig", Namespace: "eksa-system"}}, nil,
+		),
+	)
 	test.expectNotToDeleteBootstrap()
 
 	err := test.run()
@@ -206,3 +256,131 @@ func TestDeleteWorkloadDeletePackageResourceError(t *testing.T) {
 		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
 	}
 }
+
+func TestDeleteRunCAPIClusterNotFound(t *testing.T) {
+	test := newDeleteTest(t)
+	test.expectSetup()
+	test.expectCAPIClusterExists(false, nil)
+	test.expectNotToCreateBootstrap()
+	test.bootstrapper.EXPECT().DeleteBootstrapCluster(test.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := test.run()
+	if err == nil || !strings.Contains(err.Error(), "CAPI cluster workload not found") {
+		t.Fatalf("Delete.Run() err = %v, want CAPI cluster not found error", err)
+	}
+}
+
+func TestDeleteRunCAPIClusterCheckErrorContinues(t *testing.T) {
+	test := newDeleteTest(t)
+	test.expectSetup()
+	test.expectCAPIClusterExists(false, fmt.Errorf("connection refused"))
+	test.expectCreateBootstrap()
+	test.expectDeleteWorkload(test.bootstrapCluster)
+	test.expectCleanupGitRepo()
+	test.expectMoveManagement()
+	test.expectNotToDeletePackageResources()
+	test.expectDeleteBootstrap()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestDeleteRunForceOrphanedCluster(t *testing.T) {
+	test := newDeleteTest(t)
+	test.force = true
+	test.confirmOrphan = true
+	test.expectSetup()
+	test.expectCAPIClusterExists(false, nil)
+	test.expectNotToCreateBootstrap()
+	test.provider.EXPECT().CleanupOrphanedResources(test.ctx, nil, test.clusterSpec)
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestDeleteRunForceOrphanedClusterNotConfirmed(t *testing.T) {
+	test := newDeleteTest(t)
+	test.force = true
+	test.expectSetup()
+	test.expectCAPIClusterExists(false, nil)
+	test.expectNotToCreateBootstrap()
+	test.provider.EXPECT().CleanupOrphanedResources(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := test.run()
+	if err == nil || !strings.Contains(err.Error(), "re-run with --confirm-orphan-cleanup") {
+		t.Fatalf("Delete.Run() err = %v, want confirmation error", err)
+	}
+}
+
+func TestDeleteRunForceCAPIClusterCheckError(t *testing.T) {
+	test := newDeleteTest(t)
+	test.force = true
+	test.expectSetup()
+	test.expectCAPIClusterExists(false, fmt.Errorf("connection refused"))
+	test.expectNotToCreateBootstrap()
+	test.provider.EXPECT().CleanupOrphanedResources(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := test.run()
+	if err == nil || err.Error() != "checking for the CAPI cluster workload, its infrastructure is only removed when it's not found: connection refused" {
+		t.Fatalf("Delete.Run() err = %v, want check error", err)
+	}
+}
+
+func TestDeleteWorkloadRunForceOrphanedCluster(t *testing.T) {
+	test := newDeleteTest(t)
+	test.force = true
+	test.confirmOrphan = true
+	test.expectSetup()
+	test.expectNotToCreateBootstrap()
+	test.clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               "management-cluster",
+		KubeconfigFile:     "kc.kubeconfig",
+		ExistingManagement: true,
+	}
+	test.expectCAPIClusterExists(false, nil)
+	test.provider.EXPECT().CleanupOrphanedResources(test.ctx, test.clusterSpec.ManagementCluster, test.clusterSpec)
+	test.expectCleanupClusterLeftovers()
+	test.expectNotToDeleteBootstrap()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestDeleteRunForceOrphanedClusterNotSupported(t *testing.T) {
+	test := newDeleteTest(t)
+	test.force = true
+	test.confirmOrphan = true
+	test.expectSetup()
+	test.expectCAPIClusterExists(false, nil)
+	test.provider.EXPECT().CleanupOrphanedResources(test.ctx, nil, test.clusterSpec).Return(providers.ErrOrphanCleanupNotSupported)
+	test.provider.EXPECT().Name().Return("docker")
+
+	err := test.run()
+	if err == nil || err.Error() != "docker provider: cleaning up orphaned resources is not supported by this provider" {
+		t.Fatalf("Delete.Run() err = %v, want not supported error", err)
+	}
+}
+
+func TestDeleteRunForceExistingCluster(t *testing.T) {
+	test := newDeleteTest(t)
+	test.force = true
+	test.expectSetup()
+	test.expectCAPIClusterExists(true, nil)
+	test.expectCreateBootstrap()
+	test.expectDeleteWorkload(test.bootstrapCluster)
+	test.expectCleanupGitRepo()
+	test.expectMoveManagement()
+	test.expectNotToDeletePackageResources()
+	test.expectDeleteBootstrap()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+}
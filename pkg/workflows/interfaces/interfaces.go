@@ -5,6 +5,7 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -26,6 +27,9 @@ type ClusterManager interface {
 	InstallNetworking(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
 	UpgradeNetworking(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec, provider providers.Provider) (*types.ChangeDiff, error)
 	InstallStorageClass(ctx context.Context, cluster *types.Cluster, provider providers.Provider) error
+	TagClusterResources(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
+	InstallBackup(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error
+	InstallOSUpdates(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error
 	SaveLogsManagementCluster(ctx context.Context, spec *cluster.Spec, cluster *types.Cluster) error
 	SaveLogsWorkloadCluster(ctx context.Context, provider providers.Provider, spec *cluster.Spec, cluster *types.Cluster) error
 	InstallCustomComponents(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster, provider providers.Provider) error
@@ -41,6 +45,9 @@ type ClusterManager interface {
 	InstallAwsIamAuth(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error
 	CreateAwsIamAuthCaSecret(ctx context.Context, bootstrapCluster *types.Cluster, workloadClusterName string) error
 	DeletePackageResources(ctx context.Context, managementCluster *types.Cluster, clusterName string) error
+	CAPIClusterExists(ctx context.Context, managementCluster *types.Cluster, clusterName string) (bool, error)
+	DeleteClusterLeftovers(ctx context.Context, managementCluster *types.Cluster, clusterName string) error
+	AuditClusterLeftovers(ctx context.Context, managementCluster *types.Cluster, clusterName string) ([]clustermanager.ClusterLeftover, error)
 }
 
 type GitOpsManager interface {
@@ -76,3 +83,7 @@ type EksdUpgrader interface {
 type PackageInstaller interface {
 	InstallCuratedPackages(ctx context.Context)
 }
+
+type PostCreateValidator interface {
+	Validate(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster, managementCluster *types.Cluster) error
+}
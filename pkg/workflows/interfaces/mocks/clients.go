@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/workflows/interfaces (interfaces: Bootstrapper,ClusterManager,GitOpsManager,Validator,CAPIManager,EksdInstaller,EksdUpgrader,PackageInstaller)
+// Source: github.com/aws/eks-anywhere/pkg/workflows/interfaces (interfaces: Bootstrapper,ClusterManager,GitOpsManager,Validator,CAPIManager,EksdInstaller,EksdUpgrader,PackageInstaller,PostCreateValidator)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -10,6 +10,7 @@ import (
 
 	bootstrapper "github.com/aws/eks-anywhere/pkg/bootstrapper"
 	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	clustermanager "github.com/aws/eks-anywhere/pkg/clustermanager"
 	constants "github.com/aws/eks-anywhere/pkg/constants"
 	providers "github.com/aws/eks-anywhere/pkg/providers"
 	types "github.com/aws/eks-anywhere/pkg/types"
@@ -111,6 +112,36 @@ func (mr *MockClusterManagerMockRecorder) ApplyBundles(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyBundles", reflect.TypeOf((*MockClusterManager)(nil).ApplyBundles), arg0, arg1, arg2)
 }
 
+// AuditClusterLeftovers mocks base method.
+func (m *MockClusterManager) AuditClusterLeftovers(arg0 context.Context, arg1 *types.Cluster, arg2 string) ([]clustermanager.ClusterLeftover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditClusterLeftovers", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]clustermanager.ClusterLeftover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuditClusterLeftovers indicates an expected call of AuditClusterLeftovers.
+func (mr *MockClusterManagerMockRecorder) AuditClusterLeftovers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditClusterLeftovers", reflect.TypeOf((*MockClusterManager)(nil).AuditClusterLeftovers), arg0, arg1, arg2)
+}
+
+// CAPIClusterExists mocks base method.
+func (m *MockClusterManager) CAPIClusterExists(arg0 context.Context, arg1 *types.Cluster, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CAPIClusterExists", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CAPIClusterExists indicates an expected call of CAPIClusterExists.
+func (mr *MockClusterManagerMockRecorder) CAPIClusterExists(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CAPIClusterExists", reflect.TypeOf((*MockClusterManager)(nil).CAPIClusterExists), arg0, arg1, arg2)
+}
+
 // CreateAwsIamAuthCaSecret mocks base method.
 func (m *MockClusterManager) CreateAwsIamAuthCaSecret(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -182,6 +213,20 @@ func (mr *MockClusterManagerMockRecorder) DeleteCluster(arg0, arg1, arg2, arg3,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCluster", reflect.TypeOf((*MockClusterManager)(nil).DeleteCluster), arg0, arg1, arg2, arg3, arg4)
 }
 
+// DeleteClusterLeftovers mocks base method.
+func (m *MockClusterManager) DeleteClusterLeftovers(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClusterLeftovers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClusterLeftovers indicates an expected call of DeleteClusterLeftovers.
+func (mr *MockClusterManagerMockRecorder) DeleteClusterLeftovers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClusterLeftovers", reflect.TypeOf((*MockClusterManager)(nil).DeleteClusterLeftovers), arg0, arg1, arg2)
+}
+
 // DeletePackageResources mocks base method.
 func (m *MockClusterManager) DeletePackageResources(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -240,6 +285,20 @@ func (mr *MockClusterManagerMockRecorder) InstallAwsIamAuth(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallAwsIamAuth", reflect.TypeOf((*MockClusterManager)(nil).InstallAwsIamAuth), arg0, arg1, arg2, arg3)
 }
 
+// InstallBackup mocks base method.
+func (m *MockClusterManager) InstallBackup(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallBackup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallBackup indicates an expected call of InstallBackup.
+func (mr *MockClusterManagerMockRecorder) InstallBackup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallBackup", reflect.TypeOf((*MockClusterManager)(nil).InstallBackup), arg0, arg1, arg2)
+}
+
 // InstallCAPI mocks base method.
 func (m *MockClusterManager) InstallCAPI(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster, arg3 providers.Provider) error {
 	m.ctrl.T.Helper()
@@ -296,6 +355,20 @@ func (mr *MockClusterManagerMockRecorder) InstallNetworking(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallNetworking", reflect.TypeOf((*MockClusterManager)(nil).InstallNetworking), arg0, arg1, arg2, arg3)
 }
 
+// InstallOSUpdates mocks base method.
+func (m *MockClusterManager) InstallOSUpdates(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallOSUpdates", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallOSUpdates indicates an expected call of InstallOSUpdates.
+func (mr *MockClusterManagerMockRecorder) InstallOSUpdates(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallOSUpdates", reflect.TypeOf((*MockClusterManager)(nil).InstallOSUpdates), arg0, arg1, arg2)
+}
+
 // InstallStorageClass mocks base method.
 func (m *MockClusterManager) InstallStorageClass(arg0 context.Context, arg1 *types.Cluster, arg2 providers.Provider) error {
 	m.ctrl.T.Helper()
@@ -399,6 +472,20 @@ func (mr *MockClusterManagerMockRecorder) SaveLogsWorkloadCluster(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLogsWorkloadCluster", reflect.TypeOf((*MockClusterManager)(nil).SaveLogsWorkloadCluster), arg0, arg1, arg2, arg3)
 }
 
+// TagClusterResources mocks base method.
+func (m *MockClusterManager) TagClusterResources(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagClusterResources", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagClusterResources indicates an expected call of TagClusterResources.
+func (mr *MockClusterManagerMockRecorder) TagClusterResources(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagClusterResources", reflect.TypeOf((*MockClusterManager)(nil).TagClusterResources), arg0, arg1, arg2, arg3)
+}
+
 // Upgrade mocks base method.
 func (m *MockClusterManager) Upgrade(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 *cluster.Spec) (*types.ChangeDiff, error) {
 	m.ctrl.T.Helper()
@@ -805,3 +892,40 @@ func (mr *MockPackageInstallerMockRecorder) InstallCuratedPackages(arg0 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCuratedPackages", reflect.TypeOf((*MockPackageInstaller)(nil).InstallCuratedPackages), arg0)
 }
+
+// MockPostCreateValidator is a mock of PostCreateValidator interface.
+type MockPostCreateValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostCreateValidatorMockRecorder
+}
+
+// MockPostCreateValidatorMockRecorder is the mock recorder for MockPostCreateValidator.
+type MockPostCreateValidatorMockRecorder struct {
+	mock *MockPostCreateValidator
+}
+
+// NewMockPostCreateValidator creates a new mock instance.
+func NewMockPostCreateValidator(ctrl *gomock.Controller) *MockPostCreateValidator {
+	mock := &MockPostCreateValidator{ctrl: ctrl}
+	mock.recorder = &MockPostCreateValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostCreateValidator) EXPECT() *MockPostCreateValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockPostCreateValidator) Validate(arg0 context.Context, arg1 *cluster.Spec, arg2, arg3 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockPostCreateValidatorMockRecorder) Validate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockPostCreateValidator)(nil).Validate), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,209 @@
+package yamlutil
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// MergeLayout returns the updated yaml document laid out like the original one: the fields present
+// in both keep the original order, comments and scalar style, the fields only in updated are
+// appended after them and the fields only in original are dropped. This allows to decode a user
+// provided document, modify the object and encode it back without losing the user layout.
+//
+// Since encoding an object doesn't output the fields with empty values, fields that are only
+// in original with an empty value are kept and fields that are only in updated with an empty
+// value are not added. This way decoding and encoding a document without changes returns the
+// original document, as long as its sequences are indented under their keys, the only style the
+// yaml encoder supports. Dropping the empty values can change the meaning of the document for
+// types where an empty value isn't the same as a missing one, so callers that know the type of
+// the document should check the result decodes to the updated object.
+func MergeLayout(original, updated []byte) ([]byte, error) {
+	originalNode := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(original, originalNode); err != nil {
+		return nil, fmt.Errorf("parsing original yaml: %v", err)
+	}
+	updatedNode := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(updated, updatedNode); err != nil {
+		return nil, fmt.Errorf("parsing updated yaml: %v", err)
+	}
+	if len(originalNode.Content) == 0 {
+		return updated, nil
+	}
+	if len(updatedNode.Content) == 0 {
+		return nil, nil
+	}
+
+	originalNode.Content[0] = mergeNode(originalNode.Content[0], updatedNode.Content[0])
+
+	b := &bytes.Buffer{}
+	encoder := yamlv3.NewEncoder(b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(originalNode); err != nil {
+		return nil, fmt.Errorf("encoding merged yaml: %v", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("encoding merged yaml: %v", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+func mergeNode(original, updated *yamlv3.Node) *yamlv3.Node {
+	switch {
+	case original.Kind == yamlv3.MappingNode && updated.Kind == yamlv3.MappingNode:
+		mergeMapping(original, updated)
+		return original
+	case original.Kind == yamlv3.SequenceNode && updated.Kind == yamlv3.SequenceNode:
+		mergeSequence(original, updated)
+		return original
+	case sameValue(original, updated):
+		return original
+	}
+
+	updated.HeadComment = original.HeadComment
+	updated.LineComment = original.LineComment
+	updated.FootComment = original.FootComment
+	return updated
+}
+
+func mergeMapping(original, updated *yamlv3.Node) {
+	updatedValues := make(map[string]*yamlv3.Node, len(updated.Content)/2)
+	for i := 0; i+1 < len(updated.Content); i += 2 {
+		updatedValues[updated.Content[i].Value] = updated.Content[i+1]
+	}
+
+	merged := make([]*yamlv3.Node, 0, len(updated.Content))
+	seen := make(map[string]struct{}, len(original.Content)/2)
+	for i := 0; i+1 < len(original.Content); i += 2 {
+		key, value := original.Content[i], original.Content[i+1]
+		seen[key.Value] = struct{}{}
+		u, ok := updatedValues[key.Value]
+		switch {
+		case ok:
+			merged = append(merged, key, mergeNode(value, u))
+		case isEmpty(value):
+			merged = append(merged, key, value)
+		}
+	}
+
+	for i := 0; i+1 < len(updated.Content); i += 2 {
+		key, value := updated.Content[i], updated.Content[i+1]
+		if _, ok := seen[key.Value]; !ok {
+			if value = pruneEmpty(value); !isEmpty(value) {
+				merged = append(merged, key, value)
+			}
+		}
+	}
+
+	original.Content = merged
+}
+
+// mergeSequence merges the items of both sequences by name if all of them are mappings
+// with a name, like the worker node groups, or by position otherwise.
+func mergeSequence(original, updated *yamlv3.Node) {
+	originalByName, ok := itemsByName(original)
+	if _, updatedNamed := itemsByName(updated); !ok || !updatedNamed {
+		for i, item := range updated.Content {
+			if i < len(original.Content) {
+				updated.Content[i] = mergeNode(original.Content[i], item)
+			} else {
+				updated.Content[i] = pruneEmpty(item)
+			}
+		}
+		original.Content = updated.Content
+		return
+	}
+
+	for i, item := range updated.Content {
+		if o, ok := originalByName[itemName(item)]; ok {
+			updated.Content[i] = mergeNode(o, item)
+		} else {
+			updated.Content[i] = pruneEmpty(item)
+		}
+	}
+	original.Content = updated.Content
+}
+
+func itemsByName(sequence *yamlv3.Node) (map[string]*yamlv3.Node, bool) {
+	items := make(map[string]*yamlv3.Node, len(sequence.Content))
+	for _, item := range sequence.Content {
+		name := itemName(item)
+		if name == "" {
+			return nil, false
+		}
+		if _, ok := items[name]; ok {
+			return nil, false
+		}
+		items[name] = item
+	}
+
+	return items, true
+}
+
+func itemName(item *yamlv3.Node) string {
+	if item.Kind != yamlv3.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		if item.Content[i].Value == "name" && item.Content[i+1].Kind == yamlv3.ScalarNode {
+			return item.Content[i+1].Value
+		}
+	}
+
+	return ""
+}
+
+// pruneEmpty removes the fields with empty values from the mappings in a node only present in
+// updated, which the encoder outputs for the struct fields without omitempty.
+func pruneEmpty(node *yamlv3.Node) *yamlv3.Node {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		content := make([]*yamlv3.Node, 0, len(node.Content))
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if value := pruneEmpty(node.Content[i+1]); !isEmpty(value) {
+				content = append(content, node.Content[i], value)
+			}
+		}
+		node.Content = content
+	case yamlv3.SequenceNode:
+		for i, item := range node.Content {
+			node.Content[i] = pruneEmpty(item)
+		}
+	}
+
+	return node
+}
+
+// sameValue returns true if both nodes decode to the same value, even if they are written
+// with a different style, like a quoted and an unquoted string.
+func sameValue(a, b *yamlv3.Node) bool {
+	var av, bv interface{}
+	if err := a.Decode(&av); err != nil {
+		return false
+	}
+	if err := b.Decode(&bv); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(av, bv)
+}
+
+// isEmpty returns true if the node has the zero value of its type, which encoding an
+// object with omitempty fields doesn't output.
+func isEmpty(node *yamlv3.Node) bool {
+	switch node.Kind {
+	case yamlv3.MappingNode, yamlv3.SequenceNode:
+		return len(node.Content) == 0
+	case yamlv3.ScalarNode:
+		var v interface{}
+		if err := node.Decode(&v); err != nil {
+			return false
+		}
+		return v == nil || v == "" || v == false || v == 0 || v == 0.0
+	}
+
+	return false
+}
@@ -0,0 +1,113 @@
+package yamlutil_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+const userCluster = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  # Control plane nodes
+  controlPlaneConfiguration:
+    count: 3
+    endpoint:
+      host: "10.0.0.1"
+    machineGroupRef:
+      name: my-cp-machines
+      kind: VSphereMachineConfig
+  kubernetesVersion: "1.23"
+  workerNodeGroupConfigurations:
+    - name: md-1
+      count: 2 # scaled by the autoscaler
+    - name: md-0
+      count: 1
+  datacenterRef:
+    kind: VSphereDatacenterConfig
+    name: my-datacenter
+`
+
+func roundTrip(t *testing.T, original string, modify func(*anywherev1.Cluster)) string {
+	t.Helper()
+	g := NewWithT(t)
+	cluster := &anywherev1.Cluster{}
+	g.Expect(yamlutil.UnmarshalStrict([]byte(original), cluster)).To(Succeed())
+	modify(cluster)
+	updated, err := yaml.Marshal(cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	merged, err := yamlutil.MergeLayout([]byte(original), updated)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return string(merged)
+}
+
+func TestMergeLayoutRoundTripWithoutChanges(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(roundTrip(t, userCluster, func(*anywherev1.Cluster) {})).To(Equal(userCluster))
+}
+
+func TestMergeLayoutRoundTripWithChanges(t *testing.T) {
+	g := NewWithT(t)
+	merged := roundTrip(t, userCluster, func(c *anywherev1.Cluster) {
+		c.Spec.KubernetesVersion = anywherev1.Kube124
+		c.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+			c.Spec.WorkerNodeGroupConfigurations[1],
+			{Name: "md-2", Count: c.Spec.WorkerNodeGroupConfigurations[1].Count},
+			c.Spec.WorkerNodeGroupConfigurations[0],
+		}
+		c.Spec.ControlPlaneConfiguration.Endpoint = nil
+		c.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{
+			Cilium: &anywherev1.CiliumConfig{PolicyEnforcementMode: anywherev1.CiliumPolicyModeAlways},
+		}
+	})
+
+	g.Expect(merged).To(Equal(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  # Control plane nodes
+  controlPlaneConfiguration:
+    count: 3
+    machineGroupRef:
+      name: my-cp-machines
+      kind: VSphereMachineConfig
+  kubernetesVersion: "1.24"
+  workerNodeGroupConfigurations:
+    - name: md-0
+      count: 1
+    - count: 1
+      name: md-2
+    - name: md-1
+      count: 2 # scaled by the autoscaler
+  datacenterRef:
+    kind: VSphereDatacenterConfig
+    name: my-datacenter
+  clusterNetwork:
+    cniConfig:
+      cilium:
+        policyEnforcementMode: always
+`))
+}
+
+func TestMergeLayoutEmptyOriginal(t *testing.T) {
+	g := NewWithT(t)
+	updated := []byte("kind: Cluster\n")
+
+	g.Expect(yamlutil.MergeLayout(nil, updated)).To(Equal(updated))
+}
+
+func TestMergeLayoutInvalidOriginal(t *testing.T) {
+	g := NewWithT(t)
+	_, err := yamlutil.MergeLayout([]byte("kind: [Cluster"), []byte("kind: Cluster\n"))
+
+	g.Expect(err).To(MatchError(ContainSubstring("parsing original yaml")))
+}
@@ -0,0 +1,261 @@
+package yamlutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+)
+
+var documentSeparatorRegex = regexp.MustCompile(`(?m)^---[ \t\r]*$`)
+
+// Document is a yaml document from a multi-document manifest.
+type Document struct {
+	Content []byte
+	// Line is the line of the manifest where the document starts, the first one being 1.
+	// It's used to report the position of the errors in the manifest.
+	Line int
+}
+
+// SplitDocuments splits a multi-document yaml manifest by its "---" separator lines. The line break
+// before a separator belongs to it, so a block scalar at the end of a document doesn't end with one.
+func SplitDocuments(manifest []byte) []Document {
+	matches := documentSeparatorRegex.FindAllIndex(manifest, -1)
+	docs := make([]Document, 0, len(matches)+1)
+	start, line := 0, 1
+	for _, m := range matches {
+		docs = append(docs, Document{Content: bytes.TrimSuffix(manifest[start:m[0]], []byte("\n")), Line: line})
+		line += strings.Count(string(manifest[start:m[0]]), "\n")
+		start = m[1]
+	}
+
+	return append(docs, Document{Content: manifest[start:], Line: line})
+}
+
+// UnmarshalStrict decodes a single yaml document into obj, failing on unknown and duplicated fields.
+func UnmarshalStrict(doc []byte, obj interface{}) error {
+	return Document{Content: doc, Line: 1}.UnmarshalStrict(obj)
+}
+
+// UnmarshalStrict decodes the document into obj, failing on unknown and duplicated fields.
+// Unknown fields are returned as an UnknownFieldError with their position in the manifest.
+func (d Document) UnmarshalStrict(obj interface{}) error {
+	err := yaml.UnmarshalStrict(d.Content, obj)
+	if err == nil {
+		return nil
+	}
+
+	if unknown := d.findUnknownField(reflect.TypeOf(obj)); unknown != nil {
+		return unknown
+	}
+	if d.Line > 1 {
+		return fmt.Errorf("document starting at line %d: %v", d.Line, err)
+	}
+
+	return err
+}
+
+// UnknownFieldError is a field in a yaml document that doesn't exist in the type it's decoded into.
+type UnknownFieldError struct {
+	// Field is the path of the field in the document, like spec.workerNodeGroupConfigurations[0].name.
+	Field  string
+	Line   int
+	Column int
+	// Suggestion is the closest known field, if any is similar enough to be a typo.
+	Suggestion string
+}
+
+func (e *UnknownFieldError) Error() string {
+	msg := fmt.Sprintf("line %d, column %d: unknown field %q", e.Line, e.Column, e.Field)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", e.Suggestion)
+	}
+	return msg
+}
+
+func (d Document) findUnknownField(t reflect.Type) *UnknownFieldError {
+	node := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(d.Content, node); err != nil {
+		return nil
+	}
+
+	unknown := findUnknownField(node, t, "")
+	if unknown != nil {
+		unknown.Line += d.Line - 1
+	}
+
+	return unknown
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// findUnknownField walks the yaml node and the type it's decoded into together, following the
+// encoding/json field matching rules, and returns the first field in the node missing in the type.
+func findUnknownField(node *yamlv3.Node, t reflect.Type, path string) *UnknownFieldError {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for node.Kind == yamlv3.DocumentNode || node.Kind == yamlv3.AliasNode {
+		if node.Kind == yamlv3.AliasNode {
+			node = node.Alias
+		} else if len(node.Content) > 0 {
+			node = node.Content[0]
+		} else {
+			return nil
+		}
+	}
+	// Types with custom decoding, like metav1.Time or resource.Quantity, can't be walked.
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return nil
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yamlv3.MappingNode:
+		return findUnknownStructField(node, t, path)
+	case t.Kind() == reflect.Map && node.Kind == yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if unknown := findUnknownField(node.Content[i+1], t.Elem(), fieldPath(path, node.Content[i].Value)); unknown != nil {
+				return unknown
+			}
+		}
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && node.Kind == yamlv3.SequenceNode:
+		for i, item := range node.Content {
+			if unknown := findUnknownField(item, t.Elem(), path+"["+strconv.Itoa(i)+"]"); unknown != nil {
+				return unknown
+			}
+		}
+	}
+
+	return nil
+}
+
+func findUnknownStructField(node *yamlv3.Node, t reflect.Type, path string) *UnknownFieldError {
+	fields := jsonFields(t)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		fieldType, ok := lookupJSONField(fields, key.Value)
+		if !ok {
+			return &UnknownFieldError{
+				Field:      fieldPath(path, key.Value),
+				Line:       key.Line,
+				Column:     key.Column,
+				Suggestion: closestField(fields, key.Value),
+			}
+		}
+
+		if unknown := findUnknownField(node.Content[i+1], fieldType, fieldPath(path, key.Value)); unknown != nil {
+			return unknown
+		}
+	}
+
+	return nil
+}
+
+// jsonFields returns the types of the fields of a struct by their json name, including the
+// fields of the embedded structs without a name, like the inlined metav1.TypeMeta.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		embedded := f.Type
+		if embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+		if f.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			for n, ft := range jsonFields(embedded) {
+				if _, ok := fields[n]; !ok {
+					fields[n] = ft
+				}
+			}
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+
+	return fields
+}
+
+// lookupJSONField finds a field by its exact name first and then case insensitively,
+// the same as encoding/json does.
+func lookupJSONField(fields map[string]reflect.Type, name string) (reflect.Type, bool) {
+	if t, ok := fields[name]; ok {
+		return t, true
+	}
+	for n, t := range fields {
+		if strings.EqualFold(n, name) {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
+
+// maxSuggestionDistance is the max number of edits between an unknown field and a known one
+// for the later to be suggested.
+const maxSuggestionDistance = 2
+
+func closestField(fields map[string]reflect.Type, name string) string {
+	closest, closestDistance := "", maxSuggestionDistance+1
+	for n := range fields {
+		if d := editDistance(strings.ToLower(n), strings.ToLower(name)); d < closestDistance || (d == closestDistance && n < closest) {
+			closest, closestDistance = n, d
+		}
+	}
+
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(prev[j]+1, current[j-1]+1, prev[j-1]+cost)
+		}
+		prev = current
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func fieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,170 @@
+package yamlutil_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+func TestSplitDocuments(t *testing.T) {
+	g := NewWithT(t)
+	manifest := []byte(`kind: Cluster
+metadata:
+  name: a
+---
+kind: VSphereDatacenterConfig
+---
+kind: VSphereMachineConfig
+`)
+
+	g.Expect(yamlutil.SplitDocuments(manifest)).To(Equal([]yamlutil.Document{
+		{Content: []byte("kind: Cluster\nmetadata:\n  name: a"), Line: 1},
+		{Content: []byte("\nkind: VSphereDatacenterConfig"), Line: 4},
+		{Content: []byte("\nkind: VSphereMachineConfig\n"), Line: 6},
+	}))
+}
+
+func TestSplitDocumentsSeparatorTrailingWhitespace(t *testing.T) {
+	g := NewWithT(t)
+	manifest := []byte("kind: Cluster\n--- \t\nkind: VSphereDatacenterConfig\r\n---\r\nkind: VSphereMachineConfig\n")
+
+	g.Expect(yamlutil.SplitDocuments(manifest)).To(Equal([]yamlutil.Document{
+		{Content: []byte("kind: Cluster"), Line: 1},
+		{Content: []byte("\nkind: VSphereDatacenterConfig\r"), Line: 2},
+		{Content: []byte("\nkind: VSphereMachineConfig\n"), Line: 4},
+	}))
+}
+
+func TestUnmarshalStrictSuccess(t *testing.T) {
+	g := NewWithT(t)
+	doc := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+  creationTimestamp: null
+spec:
+  kubernetesVersion: "1.23"
+  workerNodeGroupConfigurations:
+  - name: md-0
+    count: 1
+    labels:
+      my-label: value
+`)
+	cluster := &anywherev1.Cluster{}
+
+	g.Expect(yamlutil.UnmarshalStrict(doc, cluster)).To(Succeed())
+	g.Expect(cluster.Name).To(Equal("my-cluster"))
+	g.Expect(cluster.Spec.WorkerNodeGroupConfigurations).To(HaveLen(1))
+	g.Expect(cluster.Spec.WorkerNodeGroupConfigurations[0].Labels).To(HaveKeyWithValue("my-label", "value"))
+}
+
+func TestUnmarshalStrictCaseInsensitiveField(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &anywherev1.Cluster{}
+
+	g.Expect(yamlutil.UnmarshalStrict([]byte("spec:\n  KubernetesVersion: \"1.23\"\n"), cluster)).To(Succeed())
+	g.Expect(cluster.Spec.KubernetesVersion).To(Equal(anywherev1.KubernetesVersion("1.23")))
+}
+
+func TestUnmarshalStrictUnknownField(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr *yamlutil.UnknownFieldError
+	}{
+		{
+			name: "typo",
+			doc: `kind: Cluster
+spec:
+  kubernetesVersion: "1.23"
+  workerNodeGroupConfiguration:
+  - name: md-0
+`,
+			wantErr: &yamlutil.UnknownFieldError{
+				Field:      "spec.workerNodeGroupConfiguration",
+				Line:       4,
+				Column:     3,
+				Suggestion: "workerNodeGroupConfigurations",
+			},
+		},
+		{
+			name: "nested in list",
+			doc: `spec:
+  workerNodeGroupConfigurations:
+  - name: md-0
+  - name: md-1
+    machineGroupRef:
+      kind: VSphereMachineConfig
+      nmae: my-machines
+`,
+			wantErr: &yamlutil.UnknownFieldError{
+				Field:      "spec.workerNodeGroupConfigurations[1].machineGroupRef.nmae",
+				Line:       7,
+				Column:     7,
+				Suggestion: "name",
+			},
+		},
+		{
+			name: "without suggestion",
+			doc: `metadata:
+  name: my-cluster
+spec:
+  somethingElse: true
+`,
+			wantErr: &yamlutil.UnknownFieldError{
+				Field:  "spec.somethingElse",
+				Line:   4,
+				Column: 3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := yamlutil.UnmarshalStrict([]byte(tt.doc), &anywherev1.Cluster{})
+			g.Expect(err).To(HaveOccurred())
+			unknown := &yamlutil.UnknownFieldError{}
+			g.Expect(errors.As(err, &unknown)).To(BeTrue())
+			g.Expect(unknown).To(Equal(tt.wantErr))
+		})
+	}
+}
+
+func TestDocumentUnmarshalStrictUnknownFieldLineInManifest(t *testing.T) {
+	g := NewWithT(t)
+	manifest := []byte(`kind: VSphereDatacenterConfig
+metadata:
+  name: my-datacenter
+---
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  controlPlaneConfiguraton:
+    count: 3
+`)
+	docs := yamlutil.SplitDocuments(manifest)
+	g.Expect(docs).To(HaveLen(2))
+
+	err := docs[1].UnmarshalStrict(&anywherev1.Cluster{})
+	g.Expect(err).To(MatchError(`line 9, column 3: unknown field "spec.controlPlaneConfiguraton", did you mean "controlPlaneConfiguration"?`))
+}
+
+func TestDocumentUnmarshalStrictOtherErrorLineInManifest(t *testing.T) {
+	g := NewWithT(t)
+	manifest := []byte(`kind: VSphereDatacenterConfig
+---
+kind: Cluster
+spec:
+  controlPlaneConfiguration:
+    count: three
+`)
+	docs := yamlutil.SplitDocuments(manifest)
+
+	err := docs[1].UnmarshalStrict(&anywherev1.Cluster{})
+	g.Expect(err).To(MatchError(ContainSubstring("document starting at line 2: ")))
+}
@@ -159,6 +159,68 @@ func (vb *VersionsBundle) NutanixImages() []Image {
 	return i
 }
 
+// ConformanceImages returns the images used to run the conformance tests, omitting the ones
+// not included in the bundle.
+func (vb *VersionsBundle) ConformanceImages() []Image {
+	i := make([]Image, 0, 3)
+	for _, image := range []Image{vb.Conformance.Sonobuoy, vb.Conformance.KubeConformance, vb.Conformance.SystemdLogs} {
+		if image.URI != "" {
+			i = append(i, image)
+		}
+	}
+
+	return i
+}
+
+// VeleroImages returns the images of the Velero backup component, omitting the ones not included
+// in the bundle.
+func (vb *VersionsBundle) VeleroImages() []Image {
+	i := make([]Image, 0, 3)
+	for _, image := range []Image{vb.Velero.Velero, vb.Velero.PluginForAWS, vb.Velero.Kubectl} {
+		if image.URI != "" {
+			i = append(i, image)
+		}
+	}
+
+	return i
+}
+
+// BottlerocketUpdatesImages returns the image of the Bottlerocket update operator, if included in the bundle.
+func (vb *VersionsBundle) BottlerocketUpdatesImages() []Image {
+	if vb.BottlerocketUpdates.Operator.URI == "" {
+		return nil
+	}
+
+	return []Image{vb.BottlerocketUpdates.Operator}
+}
+
+// UpgraderImages returns the image of the in-place node upgrader, if included in the bundle.
+func (vb *VersionsBundle) UpgraderImages() []Image {
+	if vb.Upgrader.Upgrader.URI == "" {
+		return nil
+	}
+
+	return []Image{vb.Upgrader.Upgrader}
+}
+
+// FluxImages returns the images for the Flux GitOps controllers.
+func (vb *VersionsBundle) FluxImages() []Image {
+	return []Image{
+		vb.Flux.HelmController,
+		vb.Flux.KustomizeController,
+		vb.Flux.NotificationController,
+		vb.Flux.SourceController,
+	}
+}
+
+// PackageControllerImages returns the images for the curated packages controller.
+func (vb *VersionsBundle) PackageControllerImages() []Image {
+	return []Image{
+		vb.PackageController.Controller,
+		vb.PackageController.TokenRefresher,
+	}
+}
+
 func (vb *VersionsBundle) SharedImages() []Image {
 	return []Image{
 		vb.Bootstrap.Controller,
@@ -203,6 +265,10 @@ func (vb *VersionsBundle) Images() []Image {
 		vb.SnowImages(),
 		vb.TinkerbellImages(),
 		vb.NutanixImages(),
+		vb.ConformanceImages(),
+		vb.VeleroImages(),
+		vb.BottlerocketUpdatesImages(),
+		vb.UpgraderImages(),
 	}
 
 	size := 0
@@ -219,9 +285,55 @@ func (vb *VersionsBundle) Images() []Image {
 }
 
 func (vb *VersionsBundle) Charts() map[string]*Image {
-	return map[string]*Image{
+	charts := map[string]*Image{
 		"cilium":                &vb.Cilium.HelmChart,
 		"eks-anywhere-packages": &vb.PackageController.HelmChart,
 		"tinkerbell-chart":      &vb.Tinkerbell.TinkerbellStack.TinkebellChart,
 	}
+
+	// Bundles released before Velero was added don't include its chart.
+	if vb.Velero.HelmChart.URI != "" {
+		charts["velero"] = &vb.Velero.HelmChart
+	}
+
+	if vb.BottlerocketUpdates.HelmChart.URI != "" {
+		charts["bottlerocket-update-operator"] = &vb.BottlerocketUpdates.HelmChart
+	}
+
+	return charts
+}
+
+// ComponentVersions returns the version of each component in the bundle keyed by component name.
+// Components without a version, such as providers not included in the bundle, are omitted.
+func (vb *VersionsBundle) ComponentVersions() map[string]string {
+	versions := map[string]string{
+		"eks-distro":                      vb.EksD.Name,
+		"core-cluster-api":                vb.ClusterAPI.Version,
+		"capi-kubeadm-bootstrap":          vb.Bootstrap.Version,
+		"capi-kubeadm-control-plane":      vb.ControlPlane.Version,
+		"cert-manager":                    vb.CertManager.Version,
+		"cluster-api-provider-docker":     vb.Docker.Version,
+		"cluster-api-provider-vsphere":    vb.VSphere.Version,
+		"cluster-api-provider-cloudstack": vb.CloudStack.Version,
+		"cluster-api-provider-tinkerbell": vb.Tinkerbell.Version,
+		"cluster-api-provider-snow":       vb.Snow.Version,
+		"cluster-api-provider-nutanix":    vb.Nutanix.Version,
+		"cilium":                          vb.Cilium.Version,
+		"kindnetd":                        vb.Kindnetd.Version,
+		"flux":                            vb.Flux.Version,
+		"eks-anywhere-cluster-controller": vb.Eksa.Version,
+		"eks-anywhere-packages":           vb.PackageController.Version,
+		"etcdadm-bootstrap-provider":      vb.ExternalEtcdBootstrap.Version,
+		"etcdadm-controller":              vb.ExternalEtcdController.Version,
+		"velero":                          vb.Velero.Version,
+		"bottlerocket-update-operator":    vb.BottlerocketUpdates.Version,
+	}
+
+	for component, version := range versions {
+		if version == "" {
+			delete(versions, component)
+		}
+	}
+
+	return versions
 }
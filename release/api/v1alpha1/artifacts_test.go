@@ -100,3 +100,120 @@ func TestVersionsBundleSnowImages(t *testing.T) {
 		})
 	}
 }
+
+func TestVersionsBundleComponentVersions(t *testing.T) {
+	g := NewWithT(t)
+	versionsBundle := &v1alpha1.VersionsBundle{
+		EksD: v1alpha1.EksDRelease{
+			Name: "kubernetes-1-23-eks-4",
+		},
+		Cilium: v1alpha1.CiliumBundle{
+			Version: "v1.10.14-eksa.1",
+		},
+	}
+
+	g.Expect(versionsBundle.ComponentVersions()).To(Equal(map[string]string{
+		"eks-distro": "kubernetes-1-23-eks-4",
+		"cilium":     "v1.10.14-eksa.1",
+	}))
+}
+
+func TestVersionsBundleFluxImages(t *testing.T) {
+	g := NewWithT(t)
+	versionsBundle := &v1alpha1.VersionsBundle{
+		Flux: v1alpha1.FluxBundle{
+			HelmController:         v1alpha1.Image{Name: "helm-controller"},
+			KustomizeController:    v1alpha1.Image{Name: "kustomize-controller"},
+			NotificationController: v1alpha1.Image{Name: "notification-controller"},
+			SourceController:       v1alpha1.Image{Name: "source-controller"},
+		},
+	}
+
+	g.Expect(versionsBundle.FluxImages()).To(ConsistOf(
+		v1alpha1.Image{Name: "helm-controller"},
+		v1alpha1.Image{Name: "kustomize-controller"},
+		v1alpha1.Image{Name: "notification-controller"},
+		v1alpha1.Image{Name: "source-controller"},
+	))
+}
+
+func TestVersionsBundlePackageControllerImages(t *testing.T) {
+	g := NewWithT(t)
+	versionsBundle := &v1alpha1.VersionsBundle{
+		PackageController: v1alpha1.PackageBundle{
+			Controller:     v1alpha1.Image{Name: "controller"},
+			TokenRefresher: v1alpha1.Image{Name: "token-refresher"},
+		},
+	}
+
+	g.Expect(versionsBundle.PackageControllerImages()).To(ConsistOf(
+		v1alpha1.Image{Name: "controller"},
+		v1alpha1.Image{Name: "token-refresher"},
+	))
+}
+
+func TestVersionsBundleConformanceImages(t *testing.T) {
+	g := NewWithT(t)
+	versionsBundle := &v1alpha1.VersionsBundle{}
+	g.Expect(versionsBundle.ConformanceImages()).To(BeEmpty())
+
+	versionsBundle.Conformance = v1alpha1.ConformanceBundle{
+		Sonobuoy:        v1alpha1.Image{Name: "sonobuoy", URI: "public.ecr.aws/eks-anywhere/sonobuoy:v0.56.10"},
+		KubeConformance: v1alpha1.Image{Name: "conformance", URI: "public.ecr.aws/eks-distro/kubernetes/conformance:v1.23.7"},
+		SystemdLogs:     v1alpha1.Image{Name: "systemd-logs", URI: "public.ecr.aws/eks-anywhere/systemd-logs:v0.4"},
+	}
+	g.Expect(versionsBundle.ConformanceImages()).To(ConsistOf(
+		versionsBundle.Conformance.Sonobuoy,
+		versionsBundle.Conformance.KubeConformance,
+		versionsBundle.Conformance.SystemdLogs,
+	))
+	g.Expect(versionsBundle.Images()).To(ContainElement(versionsBundle.Conformance.Sonobuoy))
+}
+
+func TestVersionsBundleVeleroImages(t *testing.T) {
+	g := NewWithT(t)
+	versionsBundle := &v1alpha1.VersionsBundle{}
+	g.Expect(versionsBundle.VeleroImages()).To(BeEmpty())
+	g.Expect(versionsBundle.Charts()).NotTo(HaveKey("velero"))
+
+	versionsBundle.Velero = v1alpha1.VeleroBundle{
+		Velero:       v1alpha1.Image{Name: "velero", URI: "public.ecr.aws/eks-anywhere/velero:v1.11.0"},
+		PluginForAWS: v1alpha1.Image{Name: "velero-plugin-for-aws", URI: "public.ecr.aws/eks-anywhere/velero-plugin-for-aws:v1.7.0"},
+		Kubectl:      v1alpha1.Image{Name: "kubectl", URI: "public.ecr.aws/eks-anywhere/kubectl:v1.26.4"},
+		HelmChart:    v1alpha1.Image{Name: "velero-chart", URI: "public.ecr.aws/eks-anywhere/velero:4.0.2"},
+	}
+	g.Expect(versionsBundle.VeleroImages()).To(ConsistOf(
+		versionsBundle.Velero.Velero,
+		versionsBundle.Velero.PluginForAWS,
+		versionsBundle.Velero.Kubectl,
+	))
+	g.Expect(versionsBundle.Images()).To(ContainElement(versionsBundle.Velero.Velero))
+	g.Expect(versionsBundle.Charts()).To(HaveKeyWithValue("velero", &versionsBundle.Velero.HelmChart))
+}
+
+func TestVersionsBundleBottlerocketUpdatesImages(t *testing.T) {
+	g := NewWithT(t)
+	versionsBundle := &v1alpha1.VersionsBundle{}
+	g.Expect(versionsBundle.BottlerocketUpdatesImages()).To(BeEmpty())
+	g.Expect(versionsBundle.Charts()).NotTo(HaveKey("bottlerocket-update-operator"))
+
+	versionsBundle.BottlerocketUpdates = v1alpha1.BottlerocketUpdatesBundle{
+		Operator:  v1alpha1.Image{Name: "bottlerocket-update-operator", URI: "public.ecr.aws/eks-anywhere/bottlerocket-update-operator:v1.1.0"},
+		HelmChart: v1alpha1.Image{Name: "bottlerocket-update-operator-chart", URI: "public.ecr.aws/eks-anywhere/bottlerocket-update-operator:1.1.0"},
+	}
+	g.Expect(versionsBundle.BottlerocketUpdatesImages()).To(ConsistOf(versionsBundle.BottlerocketUpdates.Operator))
+	g.Expect(versionsBundle.Images()).To(ContainElement(versionsBundle.BottlerocketUpdates.Operator))
+	g.Expect(versionsBundle.Charts()).To(HaveKeyWithValue("bottlerocket-update-operator", &versionsBundle.BottlerocketUpdates.HelmChart))
+}
+
+func TestVersionsBundleUpgraderImages(t *testing.T) {
+	g := NewWithT(t)
+	versionsBundle := &v1alpha1.VersionsBundle{}
+	g.Expect(versionsBundle.UpgraderImages()).To(BeEmpty())
+
+	versionsBundle.Upgrader = v1alpha1.UpgraderBundle{
+		Upgrader: v1alpha1.Image{Name: "upgrader", URI: "public.ecr.aws/eks-anywhere/aws/upgrader:v1-24-14-eks-a-1"},
+	}
+	g.Expect(versionsBundle.UpgraderImages()).To(ConsistOf(versionsBundle.Upgrader.Upgrader))
+	g.Expect(versionsBundle.Images()).To(ContainElement(versionsBundle.Upgrader.Upgrader))
+}
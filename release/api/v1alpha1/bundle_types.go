@@ -82,6 +82,10 @@ type VersionsBundle struct {
 	Haproxy                HaproxyBundle               `json:"haproxy,omitempty"`
 	Snow                   SnowBundle                  `json:"snow,omitempty"`
 	Nutanix                NutanixBundle               `json:"nutanix,omitempty"`
+	Conformance            ConformanceBundle           `json:"conformance,omitempty"`
+	Velero                 VeleroBundle                `json:"velero,omitempty"`
+	BottlerocketUpdates    BottlerocketUpdatesBundle   `json:"bottlerocketUpdates,omitempty"`
+	Upgrader               UpgraderBundle              `json:"upgrader,omitempty"`
 	// This field has been deprecated
 	Aws *AwsBundle `json:"aws,omitempty"`
 }
@@ -322,6 +326,36 @@ type HaproxyBundle struct {
 	Image Image `json:"image"`
 }
 
+// ConformanceBundle contains the images used to run the Kubernetes conformance tests
+// against a cluster with sonobuoy.
+type ConformanceBundle struct {
+	Sonobuoy        Image `json:"sonobuoy"`
+	KubeConformance Image `json:"kubeConformance"`
+	SystemdLogs     Image `json:"systemdLogs"`
+}
+
+// VeleroBundle contains the Helm chart and images of the Velero backup component.
+type VeleroBundle struct {
+	Version      string `json:"version,omitempty"`
+	Velero       Image  `json:"velero"`
+	PluginForAWS Image  `json:"pluginForAWS"`
+	Kubectl      Image  `json:"kubectl"`
+	HelmChart    Image  `json:"helmChart,omitempty"`
+}
+
+// BottlerocketUpdatesBundle contains the Helm chart and image of the Bottlerocket update operator.
+type BottlerocketUpdatesBundle struct {
+	Version   string `json:"version,omitempty"`
+	Operator  Image  `json:"operator"`
+	HelmChart Image  `json:"helmChart,omitempty"`
+}
+
+// UpgraderBundle contains the image with the Kubernetes components and scripts used to upgrade
+// the nodes of a cluster in place.
+type UpgraderBundle struct {
+	Upgrader Image `json:"upgrader"`
+}
+
 type SnowBundle struct {
 	Version    string   `json:"version"`
 	Manager    Image    `json:"manager"`
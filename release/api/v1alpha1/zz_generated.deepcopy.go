@@ -133,6 +133,23 @@ func (in *BottlerocketBootstrapBundle) DeepCopy() *BottlerocketBootstrapBundle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BottlerocketUpdatesBundle) DeepCopyInto(out *BottlerocketUpdatesBundle) {
+	*out = *in
+	in.Operator.DeepCopyInto(&out.Operator)
+	in.HelmChart.DeepCopyInto(&out.HelmChart)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketUpdatesBundle.
+func (in *BottlerocketUpdatesBundle) DeepCopy() *BottlerocketUpdatesBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(BottlerocketUpdatesBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bundles) DeepCopyInto(out *Bundles) {
 	*out = *in
@@ -289,6 +306,24 @@ func (in *CloudStackBundle) DeepCopy() *CloudStackBundle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConformanceBundle) DeepCopyInto(out *ConformanceBundle) {
+	*out = *in
+	in.Sonobuoy.DeepCopyInto(&out.Sonobuoy)
+	in.KubeConformance.DeepCopyInto(&out.KubeConformance)
+	in.SystemdLogs.DeepCopyInto(&out.SystemdLogs)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConformanceBundle.
+func (in *ConformanceBundle) DeepCopy() *ConformanceBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(ConformanceBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoreClusterAPI) DeepCopyInto(out *CoreClusterAPI) {
 	*out = *in
@@ -587,6 +622,7 @@ func (in *Manifest) DeepCopy() *Manifest {
 func (in *NutanixBundle) DeepCopyInto(out *NutanixBundle) {
 	*out = *in
 	in.ClusterAPIController.DeepCopyInto(&out.ClusterAPIController)
+	in.KubeVip.DeepCopyInto(&out.KubeVip)
 	out.Components = in.Components
 	out.Metadata = in.Metadata
 	out.ClusterTemplate = in.ClusterTemplate
@@ -813,6 +849,22 @@ func (in *TinkerbellStackBundle) DeepCopy() *TinkerbellStackBundle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgraderBundle) DeepCopyInto(out *UpgraderBundle) {
+	*out = *in
+	in.Upgrader.DeepCopyInto(&out.Upgrader)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgraderBundle.
+func (in *UpgraderBundle) DeepCopy() *UpgraderBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgraderBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereBundle) DeepCopyInto(out *VSphereBundle) {
 	*out = *in
@@ -837,6 +889,25 @@ func (in *VSphereBundle) DeepCopy() *VSphereBundle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VeleroBundle) DeepCopyInto(out *VeleroBundle) {
+	*out = *in
+	in.Velero.DeepCopyInto(&out.Velero)
+	in.PluginForAWS.DeepCopyInto(&out.PluginForAWS)
+	in.Kubectl.DeepCopyInto(&out.Kubectl)
+	in.HelmChart.DeepCopyInto(&out.HelmChart)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VeleroBundle.
+func (in *VeleroBundle) DeepCopy() *VeleroBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(VeleroBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VersionsBundle) DeepCopyInto(out *VersionsBundle) {
 	*out = *in
@@ -861,6 +932,10 @@ func (in *VersionsBundle) DeepCopyInto(out *VersionsBundle) {
 	in.Haproxy.DeepCopyInto(&out.Haproxy)
 	in.Snow.DeepCopyInto(&out.Snow)
 	in.Nutanix.DeepCopyInto(&out.Nutanix)
+	in.Conformance.DeepCopyInto(&out.Conformance)
+	in.Velero.DeepCopyInto(&out.Velero)
+	in.BottlerocketUpdates.DeepCopyInto(&out.BottlerocketUpdates)
+	in.Upgrader.DeepCopyInto(&out.Upgrader)
 	if in.Aws != nil {
 		in, out := &in.Aws, &out.Aws
 		*out = new(AwsBundle)
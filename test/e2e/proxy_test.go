@@ -18,6 +18,16 @@ func runProxyConfigFlow(test *framework.ClusterE2ETest) {
 	test.DeleteCluster()
 }
 
+func runTinkerbellProxyConfigFlow(test *framework.ClusterE2ETest) {
+	test.GenerateClusterConfig()
+	test.GenerateHardwareConfig()
+	test.PowerOffHardware()
+	test.CreateCluster(framework.WithForce(), framework.WithControlPlaneWaitTimeout("20m"))
+	test.StopIfFailed()
+	test.DeleteCluster()
+	test.ValidateHardwareDecommissioned()
+}
+
 func TestVSphereKubernetes124UbuntuProxyConfig(t *testing.T) {
 	test := framework.NewClusterE2ETest(
 		t,
@@ -75,3 +85,15 @@ func TestSnowKubernetes121UbuntuProxyConfig(t *testing.T) {
 	)
 	runProxyConfigFlow(test)
 }
+
+func TestTinkerbellKubernetes123UbuntuProxyConfig(t *testing.T) {
+	test := framework.NewClusterE2ETest(
+		t,
+		framework.NewTinkerbell(t, framework.WithUbuntu123Tinkerbell()),
+		framework.WithClusterFiller(api.WithKubernetesVersion(v1alpha1.Kube123)),
+		framework.WithControlPlaneHardware(1),
+		framework.WithWorkerHardware(1),
+		framework.WithProxy(framework.TinkerbellProxyRequiredEnvVars),
+	)
+	runTinkerbellProxyConfigFlow(test)
+}
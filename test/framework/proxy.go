@@ -14,6 +14,9 @@ const (
 	cloudstackHttpProxyVar  = "T_HTTP_PROXY_CLOUDSTACK"
 	cloudstackHttpsProxyVar = "T_HTTPS_PROXY_CLOUDSTACK"
 	cloudstackNoProxyVar    = "T_NO_PROXY_CLOUDSTACK"
+	tinkerbellHttpProxyVar  = "T_HTTP_PROXY_TINKERBELL"
+	tinkerbellHttpsProxyVar = "T_HTTPS_PROXY_TINKERBELL"
+	tinkerbellNoProxyVar    = "T_NO_PROXY_TINKERBELL"
 )
 
 var VsphereProxyRequiredEnvVars = ProxyRequiredEnvVars{
@@ -28,6 +31,12 @@ var CloudstackProxyRequiredEnvVars = ProxyRequiredEnvVars{
 	NoProxy:    cloudstackNoProxyVar,
 }
 
+var TinkerbellProxyRequiredEnvVars = ProxyRequiredEnvVars{
+	HttpProxy:  tinkerbellHttpProxyVar,
+	HttpsProxy: tinkerbellHttpsProxyVar,
+	NoProxy:    tinkerbellNoProxyVar,
+}
+
 type ProxyRequiredEnvVars struct {
 	HttpProxy  string
 	HttpsProxy string
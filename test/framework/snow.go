@@ -2,6 +2,7 @@ package framework
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/aws/eks-anywhere/internal/pkg/api"
@@ -18,6 +19,12 @@ const (
 	snowPodCidr          = "T_SNOW_POD_CIDR"
 	snowCredentialsFile  = "EKSA_AWS_CREDENTIALS_FILE"
 	snowCertificatesFile = "EKSA_AWS_CA_BUNDLES_FILE"
+	snowIPPoolIPStart    = "T_SNOW_IPPOOL_IPSTART"
+	snowIPPoolIPEnd      = "T_SNOW_IPPOOL_IPEND"
+	snowIPPoolGateway    = "T_SNOW_IPPOOL_GATEWAY"
+	snowIPPoolSubnet     = "T_SNOW_IPPOOL_SUBNET"
+
+	snowIPPoolName = "ip-pool-1"
 )
 
 var requiredSnowEnvVars = []string{
@@ -128,6 +135,34 @@ func WithSnowUbuntu123() SnowOpt {
 	}
 }
 
+// WithSnowDHCP configures all the machine configs with a single DNI using DHCP.
+func WithSnowDHCP() SnowOpt {
+	return func(s *Snow) {
+		s.fillers = append(s.fillers, api.WithSnowDHCPForAllMachines())
+	}
+}
+
+// WithSnowStaticIP configures all the machine configs with a single DNI using static ips from a SnowIPPool.
+// The pool has an ip range per device, built from the comma separated values in the T_SNOW_IPPOOL_* env vars.
+func WithSnowStaticIP() SnowOpt {
+	return func(s *Snow) {
+		checkRequiredEnvVars(s.t, []string{snowIPPoolIPStart, snowIPPoolIPEnd, snowIPPoolGateway, snowIPPoolSubnet})
+
+		ipStarts := strings.Split(os.Getenv(snowIPPoolIPStart), ",")
+		ipEnds := strings.Split(os.Getenv(snowIPPoolIPEnd), ",")
+		gateways := strings.Split(os.Getenv(snowIPPoolGateway), ",")
+		subnets := strings.Split(os.Getenv(snowIPPoolSubnet), ",")
+		if len(ipEnds) != len(ipStarts) || len(gateways) != len(ipStarts) || len(subnets) != len(ipStarts) {
+			s.t.Fatalf("snow ip pool env vars must have the same number of comma separated values")
+		}
+
+		for i := range ipStarts {
+			s.fillers = append(s.fillers, api.WithSnowIPPool(snowIPPoolName, ipStarts[i], ipEnds[i], gateways[i], subnets[i]))
+		}
+		s.fillers = append(s.fillers, api.WithSnowStaticIPForAllMachines(snowIPPoolName))
+	}
+}
+
 func WithSnowWorkerNodeGroup(name string, workerNodeGroup *WorkerNodeGroup, fillers ...api.SnowMachineConfigFiller) SnowOpt {
 	return func(s *Snow) {
 		s.fillers = append(s.fillers, snowMachineConfig(name, fillers...))
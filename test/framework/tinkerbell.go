@@ -208,3 +208,10 @@ func WithCustomTinkerbellMachineConfig(selector string) TinkerbellOpt {
 		t.fillers = append([]api.TinkerbellFiller{api.WithCustomTinkerbellMachineConfig(selector)}, t.fillers...)
 	}
 }
+
+// WithTinkerbellActionImages pins the images of the template config actions, for example to registry mirror images.
+func WithTinkerbellActionImages(images map[api.TinkerbellAction]string) TinkerbellOpt {
+	return func(t *Tinkerbell) {
+		t.fillers = append(t.fillers, api.WithTinkerbellActionImageOverrides(images))
+	}
+}